@@ -15,6 +15,7 @@ var (
 	parseExpression bool
 	parseDumpAST    bool
 	parseFormat     string
+	parseMaxDepth   int
 )
 
 var parseCmd = &cobra.Command{
@@ -28,7 +29,8 @@ Use -e to parse a single expression from the command line.
 Output formats:
   dwscript  Valid DWScript source code (default)
   tree      Hierarchical AST structure visualization
-  json      JSON representation of the AST`,
+  json      JSON representation of the AST
+  dot       GraphViz DOT graph of the AST (render with "dot -Tsvg")`,
 	Args: cobra.MaximumNArgs(1),
 	RunE: runParse,
 }
@@ -38,7 +40,8 @@ func init() {
 
 	parseCmd.Flags().BoolVarP(&parseExpression, "expression", "e", false, "parse an expression from the command line")
 	parseCmd.Flags().BoolVar(&parseDumpAST, "dump-ast", false, "dump the full AST structure (deprecated: use --format=tree)")
-	parseCmd.Flags().StringVar(&parseFormat, "format", "dwscript", "output format: dwscript, tree, or json")
+	parseCmd.Flags().StringVar(&parseFormat, "format", "dwscript", "output format: dwscript, tree, json, or dot")
+	parseCmd.Flags().IntVar(&parseMaxDepth, "max-depth", 0, "maximum AST depth for dot output (0 = unlimited)")
 }
 
 func runParse(_ *cobra.Command, args []string) error {
@@ -100,13 +103,19 @@ func runParse(_ *cobra.Command, args []string) error {
 			Format: printer.FormatJSON,
 			Style:  printer.StyleDetailed,
 		}
+	case "dot":
+		printerOpts = printer.Options{
+			Format:   printer.FormatDOT,
+			Style:    printer.StyleDetailed,
+			MaxDepth: parseMaxDepth,
+		}
 	case "dwscript":
 		printerOpts = printer.Options{
 			Format: printer.FormatDWScript,
 			Style:  printer.StyleDetailed,
 		}
 	default:
-		return fmt.Errorf("unknown format: %s (use dwscript, tree, or json)", format)
+		return fmt.Errorf("unknown format: %s (use dwscript, tree, json, or dot)", format)
 	}
 
 	// Print the AST
@@ -0,0 +1,53 @@
+package cmd
+
+import (
+	"os"
+
+	"github.com/cwbudde/go-dws/pkg/dwscript"
+	"github.com/cwbudde/go-dws/pkg/repl"
+	"github.com/spf13/cobra"
+)
+
+var replCmd = &cobra.Command{
+	Use:   "repl",
+	Short: "Start an interactive DWScript session",
+	Long: `Start a read-eval-print loop that evaluates DWScript statements as they
+are typed.
+
+Declarations stay visible to later input. Input that leaves a block, string
+or expression unfinished continues on the next line, and the value of a bare
+expression is printed. Press Ctrl+D to exit.
+
+Examples:
+  # Start the REPL
+  dwscript repl
+
+  # Start the REPL with a conditional symbol defined
+  dwscript repl -D DEBUG`,
+	Args: cobra.NoArgs,
+	RunE: runREPL,
+}
+
+func init() {
+	rootCmd.AddCommand(replCmd)
+
+	replCmd.Flags().BoolVar(&typeCheck, "type-check", true, "perform semantic type checking before execution (default: true)")
+	replCmd.Flags().StringSliceVarP(&defines, "define", "D", []string{}, "define a conditional symbol for {$IFDEF} (can be specified multiple times)")
+}
+
+func runREPL(_ *cobra.Command, _ []string) error {
+	engine, err := dwscript.New(
+		dwscript.WithOutput(os.Stdout),
+		dwscript.WithTypeCheck(typeCheck),
+		dwscript.WithDefines(defines...),
+		dwscript.WithIncludePaths(unitSearchPaths...),
+	)
+	if err != nil {
+		return err
+	}
+	r, err := repl.New(engine, os.Stdout)
+	if err != nil {
+		return err
+	}
+	return r.Run(os.Stdin)
+}
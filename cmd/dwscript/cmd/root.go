@@ -3,12 +3,13 @@ package cmd
 import (
 	"fmt"
 
+	"github.com/cwbudde/go-dws/pkg/dwscript"
 	"github.com/spf13/cobra"
 )
 
 var (
 	// Version information (set by build flags)
-	Version   = "0.1.0-dev"
+	Version   = dwscript.Version
 	GitCommit = "unknown"
 	BuildDate = "unknown"
 )
@@ -8,7 +8,9 @@ import (
 	"sort"
 	"strconv"
 	"strings"
+	"time"
 
+	"github.com/cwbudde/go-dws/internal/builtins"
 	"github.com/cwbudde/go-dws/internal/bytecode"
 	"github.com/cwbudde/go-dws/internal/encoding"
 	"github.com/cwbudde/go-dws/internal/errors"
@@ -25,29 +27,80 @@ import (
 )
 
 var (
-	evalExpr     string
-	dumpAST      bool
-	trace        bool
-	typeCheck    bool
-	showUnits    bool
-	maxRecursion int
-	bytecodeMode bool
-	hintsLevel   string
+	evalExpr      string
+	dumpAST       bool
+	trace         bool
+	typeCheck     bool
+	showUnits     bool
+	maxRecursion  int
+	heapRecursion bool
+	bytecodeMode  bool
+	hintsLevel    string
+	defines       []string
 )
 
 // simpleOptions implements interp.Options for the CLI.
 type simpleOptions struct {
 	MaxRecursionDepth int
+	RecursionStrategy interp.RecursionStrategy
 }
 
 func (o *simpleOptions) GetExternalFunctions() *interp.ExternalFunctionRegistry {
 	return nil // CLI doesn't use external functions
 }
 
+func (o *simpleOptions) GetBuiltins() *builtins.Registry {
+	return nil // CLI uses the default builtins
+}
+
 func (o *simpleOptions) GetMaxRecursionDepth() int {
 	return o.MaxRecursionDepth
 }
 
+func (o *simpleOptions) GetVariantOverflow() interp.VariantOverflowMode {
+	return interp.VariantOverflowWrap
+}
+
+func (o *simpleOptions) GetIntegerOverflowCheck() bool {
+	return false
+}
+
+func (o *simpleOptions) GetRangeChecks() bool {
+	return false
+}
+
+func (o *simpleOptions) GetAssertions() bool {
+	return true
+}
+
+func (o *simpleOptions) GetRecursionStrategy() interp.RecursionStrategy {
+	return o.RecursionStrategy
+}
+
+func (o *simpleOptions) GetValuePool() *interp.ValuePool {
+	return nil // a new enabled pool
+}
+
+func (o *simpleOptions) GetContracts() interp.ContractMode {
+	return interp.ContractsFull
+}
+
+func (o *simpleOptions) GetRandomSeed() (int64, bool) {
+	return 0, false
+}
+
+func (o *simpleOptions) GetDestructors() bool {
+	return true
+}
+
+func (o *simpleOptions) GetFormatSettings() interp.FormatSettings {
+	return interp.InvariantFormatSettings()
+}
+
+func (o *simpleOptions) GetClock() func() time.Time {
+	return nil
+}
+
 var runCmd = &cobra.Command{
 	Use:   "run [file]",
 	Short: "Run a DWScript file or expression",
@@ -67,7 +120,10 @@ Examples:
   dwscript run --trace script.dws
 
   # Run with custom recursion limit
-  dwscript run --max-recursion 2048 script.dws`,
+  dwscript run --max-recursion 2048 script.dws
+
+  # Run with conditional symbols defined for {$IFDEF}
+  dwscript run -D DEBUG -D FEATURE_X script.dws`,
 	Args: cobra.MaximumNArgs(1),
 	RunE: runScript,
 }
@@ -81,7 +137,9 @@ func init() {
 	runCmd.Flags().BoolVar(&typeCheck, "type-check", true, "perform semantic type checking before execution (default: true)")
 	runCmd.Flags().BoolVar(&showUnits, "show-units", false, "display unit dependency tree")
 	runCmd.Flags().IntVar(&maxRecursion, "max-recursion", 1024, "maximum recursion depth (default: 1024)")
+	runCmd.Flags().BoolVar(&heapRecursion, "heap-recursion", false, "run nested calls on heap-allocated stacks, so a large --max-recursion cannot overflow the Go stack")
 	runCmd.Flags().BoolVar(&bytecodeMode, "bytecode", false, "execute via bytecode VM instead of AST interpreter (experimental)")
+	runCmd.Flags().StringSliceVarP(&defines, "define", "D", []string{}, "define a conditional symbol for {$IFDEF} (can be specified multiple times)")
 	runCmd.Flags().StringVar(&hintsLevel, "hints", "off", "print compiler hints/warnings to stderr, non-fatal: off|normal|strict|pedantic (pedantic includes case-mismatch hints)")
 }
 
@@ -191,11 +249,12 @@ func runScript(_ *cobra.Command, args []string) error {
 	}
 
 	// Lexer: tokenize the input, resolving {$INCLUDE} directives relative to the
-	// directory of the script being run (disabled for inline -e expressions).
-	var lexerOpts []lexer.LexerOption
+	// directory of the script being run and then the unit search paths (disabled
+	// for inline -e expressions).
+	lexerOpts := []lexer.LexerOption{lexer.WithDefines(defines...)}
 	if evalExpr == "" {
-		lexerOpts = append(lexerOpts, lexer.WithIncludeResolver(
-			lexer.NewFileIncludeResolver(filepath.Dir(filename))))
+		lexerOpts = append(lexerOpts, lexer.WithSourceName(filename), lexer.WithIncludeResolver(
+			lexer.NewFileIncludeResolver(filepath.Dir(filename), unitSearchPaths...)))
 	}
 	l := lexer.New(input, lexerOpts...)
 
@@ -203,10 +262,10 @@ func runScript(_ *cobra.Command, args []string) error {
 	p := parser.New(l)
 	program := p.ParseProgram()
 
-	// Check for parser and include-resolution errors. A failed {$INCLUDE} must be
-	// surfaced too; otherwise a script with a missing include would run with its
-	// include content silently dropped. Other lexer errors remain advisory.
-	lexErrs := p.LexerIncludeErrors()
+	// Check for parser and directive errors. A failed {$INCLUDE} or unbalanced
+	// {$IFDEF} must be surfaced too; otherwise a script would run with code
+	// silently dropped. Other lexer errors remain advisory.
+	lexErrs := p.LexerDirectiveErrors()
 	if len(p.Errors()) > 0 || len(lexErrs) > 0 {
 		compilerErrors := make([]*errors.CompilerError, 0, len(p.Errors())+len(lexErrs))
 		for i := range lexErrs {
@@ -329,6 +388,9 @@ func runScript(_ *cobra.Command, args []string) error {
 	opts := &simpleOptions{
 		MaxRecursionDepth: maxRecursion,
 	}
+	if heapRecursion {
+		opts.RecursionStrategy = interp.RecursionHeap
+	}
 	interpreter := runner.NewWithOptions(os.Stdout, opts)
 
 	// Set source code for enhanced runtime error messages
@@ -347,6 +409,8 @@ func runScript(_ *cobra.Command, args []string) error {
 	// Set up unit registry if search paths are provided or if we're running from a file
 	if len(searchPaths) > 0 {
 		registry := units.NewUnitRegistry(searchPaths)
+		registry.SetDefines(defines)
+		registry.SetIncludePaths(searchPaths)
 		interpreter.SetUnitRegistry(registry)
 
 		// Check if the program uses any units and load them
@@ -644,6 +708,8 @@ func buildBytecodeProgram(program *ast.Program, usedUnits []string, searchPaths
 	}
 
 	registry := units.NewUnitRegistry(searchPaths)
+	registry.SetDefines(defines)
+	registry.SetIncludePaths(searchPaths)
 	for _, unitName := range usedUnits {
 		if _, err := registry.LoadUnit(unitName, searchPaths); err != nil {
 			return nil, nil, fmt.Errorf("failed to load unit '%s': %w", unitName, err)
@@ -358,13 +358,9 @@ func TestExpressionProperty(t *testing.T) {
 	}
 }
 
-// TestDefaultProperty tests default indexed properties via CLI
-//
-// NOTE: Default properties (obj[index] syntax) are not yet implemented.
-// This test currently skips but the test file exists for future implementation.
+// TestDefaultProperty tests reading and writing default indexed properties
+// through obj[index] syntax via CLI
 func TestDefaultProperty(t *testing.T) {
-	t.Skip("Default property implementation (obj[index] syntax for objects with default properties) is pending")
-
 	buildCmd := exec.Command("go", "build", "-o", "../../bin/dwscript", ".")
 	if err := buildCmd.Run(); err != nil {
 		t.Skipf("Skipping CLI tests: failed to build CLI: %v", err)
@@ -7,7 +7,8 @@
 //	go run cmd/gen-visitor/main.go
 //
 // The tool parses all AST node definitions in pkg/ast/*.go and generates
-// pkg/ast/visitor_generated.go with type-safe walk functions.
+// pkg/ast/visitor_generated.go with type-safe walk functions and
+// pkg/ast/rewrite_generated.go with the matching rewrite functions.
 package main
 
 import (
@@ -39,6 +40,7 @@ type FieldInfo struct {
 	Skip            bool   // True if field has `ast:"skip"` tag
 	IsSliceOfValues bool   // True if slice contains values, not pointers
 	Order           int    // Custom traversal order from `ast:"order:N"` tag (0 = default/unset)
+	Optional        bool   // True if field has `ast:"optional"` tag (may be nil)
 }
 
 // knownNodeTypes are types that implement the Node interface
@@ -59,6 +61,7 @@ var knownNodeTypes = map[string]bool{
 	"SetTypeNode":             true,
 	"ClassOfTypeNode":         true,
 	"FunctionPointerTypeNode": true,
+	"RecordTypeNode":          true,
 
 	// Annotation types
 	"TypeAnnotation": true,
@@ -112,27 +115,35 @@ func run() error {
 		return fmt.Errorf("parsing AST files: %w", err)
 	}
 
-	// Generate visitor code
-	code, err := generateVisitorCode(nodes)
-	if err != nil {
-		return fmt.Errorf("generating code: %w", err)
+	outputs := []struct {
+		generate func([]*NodeInfo) ([]byte, error)
+		file     string
+	}{
+		{generateVisitorCode, "visitor_generated.go"},
+		{generateRewriteCode, "rewrite_generated.go"},
 	}
+	for _, output := range outputs {
+		code, err := output.generate(nodes)
+		if err != nil {
+			return fmt.Errorf("generating %s: %w", output.file, err)
+		}
 
-	// Format the generated code
-	formatted, err := format.Source(code)
-	if err != nil {
-		// Print the unformatted code to help debug formatting errors
-		fmt.Println(string(code))
-		return fmt.Errorf("formatting code: %w", err)
-	}
+		// Format the generated code
+		formatted, err := format.Source(code)
+		if err != nil {
+			// Print the unformatted code to help debug formatting errors
+			fmt.Println(string(code))
+			return fmt.Errorf("formatting %s: %w", output.file, err)
+		}
 
-	// Write to output file
-	outputFile := filepath.Join(astDir, "visitor_generated.go")
-	if err := os.WriteFile(outputFile, formatted, 0644); err != nil {
-		return fmt.Errorf("writing output file: %w", err)
-	}
+		// Write to output file
+		outputFile := filepath.Join(astDir, output.file)
+		if err := os.WriteFile(outputFile, formatted, 0644); err != nil {
+			return fmt.Errorf("writing output file: %w", err)
+		}
 
-	fmt.Printf("Generated %s (%d bytes)\n", outputFile, len(formatted))
+		fmt.Printf("Generated %s (%d bytes)\n", outputFile, len(formatted))
+	}
 	fmt.Printf("Processed %d node types\n", len(nodes))
 	return nil
 }
@@ -251,8 +262,9 @@ func extractFields(structType *ast.StructType) []*FieldInfo {
 			continue
 		}
 
-		// Check for ast tags (skip, order)
+		// Check for ast tags (skip, optional, order)
 		skip := false
+		optional := false
 		order := 0
 		if field.Tag != nil {
 			tag := field.Tag.Value
@@ -260,6 +272,10 @@ func extractFields(structType *ast.StructType) []*FieldInfo {
 			if strings.Contains(tag, `ast:"skip"`) {
 				skip = true
 			}
+			// Check for optional tag: ast:"optional" or ast:"optional,order:N"
+			if strings.Contains(tag, `ast:"optional`) {
+				optional = true
+			}
 			// Check for order tag: ast:"order:N"
 			if strings.Contains(tag, "order:") {
 				// Extract order value
@@ -333,6 +349,7 @@ func extractFields(structType *ast.StructType) []*FieldInfo {
 					IsNode:          isNode,
 					IsHelper:        isHelper,
 					Skip:            skip,
+					Optional:        optional,
 					Order:           order,
 					IsSliceOfValues: isSliceOfValues,
 				})
@@ -643,3 +660,124 @@ func generateWalkFunction(buf *bytes.Buffer, node *NodeInfo) {
 
 	buf.WriteString("}\n\n")
 }
+
+// generateRewriteCode generates the Rewrite function and a rewrite function
+// for each node type
+//
+//nolint:unparam // error return kept for consistency with common generator pattern
+func generateRewriteCode(nodes []*NodeInfo) ([]byte, error) {
+	var buf bytes.Buffer
+
+	buf.WriteString(`// Code generated by cmd/gen-visitor/main.go. DO NOT EDIT.
+
+package ast
+
+// Rewrite traverses an AST in depth-first order, starting at the given node,
+// and replaces each node with the result of calling fn on it. Children are
+// rewritten before their parent, so fn sees a node whose children have
+// already been replaced, and the result of fn is not traversed again.
+// Rewrite returns fn's result for node itself.
+//
+// Nodes are updated in place. When fn returns nil for an element of a slice
+// field, the element is deleted, and when it returns nil for an optional
+// single-node field (one tagged ast:"optional", such as IfStatement.Alternative),
+// the field is cleared. Any other replacement must be assignable to the static
+// type of the field it is stored in; otherwise, or when fn returns nil for a
+// required single-node field, Rewrite panics with a *RewriteError.
+//
+// This function is automatically generated from AST node definitions.
+// To regenerate, run: go generate ./pkg/ast
+func Rewrite(node Node, fn func(Node) Node) Node {
+	if node == nil {
+		return nil
+	}
+
+	// Rewrite children based on node type
+	switch n := node.(type) {
+`)
+
+	for _, node := range nodes {
+		fmt.Fprintf(&buf, "\tcase *%s:\n", node.Name)
+		fmt.Fprintf(&buf, "\t\trewrite%s(n, fn)\n", node.Name)
+	}
+
+	buf.WriteString(`	}
+	return fn(node)
+}
+
+`)
+
+	for _, node := range nodes {
+		generateRewriteFunction(&buf, node)
+	}
+
+	return buf.Bytes(), nil
+}
+
+// generateRewriteFunction generates a rewrite function for a specific node
+// type. Each replacement is converted to the field's static type with
+// rewriteField or rewriteElement, which report mismatches as a *RewriteError.
+// Optional fields accept a nil replacement and are cleared by it.
+func generateRewriteFunction(buf *bytes.Buffer, node *NodeInfo) {
+	fmt.Fprintf(buf, "// rewrite%s rewrites the children of a %s node\n", node.Name, node.Name)
+
+	var fields []*FieldInfo
+	for _, field := range sortFieldsByOrder(node.Fields) {
+		if !field.Skip {
+			fields = append(fields, field)
+		}
+	}
+	if len(fields) == 0 {
+		fmt.Fprintf(buf, "func rewrite%s(_ *%s, _ func(Node) Node) {\n", node.Name, node.Name)
+		buf.WriteString("\t// No children to rewrite\n}\n\n")
+		return
+	}
+
+	fmt.Fprintf(buf, "func rewrite%s(n *%s, fn func(Node) Node) {\n", node.Name, node.Name)
+	for _, field := range fields {
+		path := node.Name + "." + field.Name
+		elemType := strings.TrimPrefix(field.Type, "[]")
+
+		switch {
+		case !field.IsSlice && field.Optional:
+			// Optional field - a nil replacement clears it
+			fmt.Fprintf(buf, "\tif n.%s != nil {\n", field.Name)
+			fmt.Fprintf(buf, "\t\tif r := Rewrite(n.%s, fn); r != nil {\n", field.Name)
+			fmt.Fprintf(buf, "\t\t\tn.%s = rewriteElement[%s](r, %q)\n", field.Name, field.Type, path)
+			buf.WriteString("\t\t} else {\n")
+			fmt.Fprintf(buf, "\t\t\tn.%s = nil\n", field.Name)
+			buf.WriteString("\t\t}\n\t}\n")
+
+		case !field.IsSlice:
+			fmt.Fprintf(buf, "\tif n.%s != nil {\n", field.Name)
+			fmt.Fprintf(buf, "\t\tn.%s = rewriteField[%s](Rewrite(n.%s, fn), %q)\n", field.Name, field.Type, field.Name, path)
+			buf.WriteString("\t}\n")
+
+		case field.IsSliceOfValues && !isInterfaceType(elemType):
+			// Slice of concrete struct values - rewrite addressable elements
+			// and copy the replacement back
+			fmt.Fprintf(buf, "\tif n.%s != nil {\n", field.Name)
+			fmt.Fprintf(buf, "\t\tkept := n.%s[:0]\n", field.Name)
+			fmt.Fprintf(buf, "\t\tfor i := range n.%s {\n", field.Name)
+			fmt.Fprintf(buf, "\t\t\tif r := Rewrite(&n.%s[i], fn); r != nil {\n", field.Name)
+			fmt.Fprintf(buf, "\t\t\t\tkept = append(kept, *rewriteElement[*%s](r, %q))\n", elemType, path)
+			buf.WriteString("\t\t\t}\n\t\t}\n")
+			fmt.Fprintf(buf, "\t\tn.%s = kept\n", field.Name)
+			buf.WriteString("\t}\n")
+
+		default:
+			// Slice of pointers or interfaces - nil elements are kept as is
+			fmt.Fprintf(buf, "\tif n.%s != nil {\n", field.Name)
+			fmt.Fprintf(buf, "\t\tkept := n.%s[:0]\n", field.Name)
+			fmt.Fprintf(buf, "\t\tfor _, item := range n.%s {\n", field.Name)
+			buf.WriteString("\t\t\tif item == nil {\n")
+			buf.WriteString("\t\t\t\tkept = append(kept, item)\n")
+			buf.WriteString("\t\t\t} else if r := Rewrite(item, fn); r != nil {\n")
+			fmt.Fprintf(buf, "\t\t\t\tkept = append(kept, rewriteElement[%s](r, %q))\n", elemType, path)
+			buf.WriteString("\t\t\t}\n\t\t}\n")
+			fmt.Fprintf(buf, "\t\tn.%s = kept\n", field.Name)
+			buf.WriteString("\t}\n")
+		}
+	}
+	buf.WriteString("}\n\n")
+}
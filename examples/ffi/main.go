@@ -198,7 +198,7 @@ func registerUtilityFunctions(engine *dwscript.Engine) {
 	}))
 
 	// Format string
-	mustRegister(engine.RegisterFunction("Format", func(template string, args []string) string {
+	mustRegister(engine.RegisterFunction("Format", func(template string, args ...string) string {
 		result := template
 		for i, arg := range args {
 			placeholder := fmt.Sprintf("{%d}", i)
@@ -47,12 +47,8 @@ func Length(ctx Context, args []Value) Value {
 
 	// Handle strings
 	if strVal, ok := arg.(*runtime.StringValue); ok {
-		// Count runes (characters), not bytes
-		length := int64(0)
-		for range strVal.Value {
-			length++
-		}
-		return &runtime.IntegerValue{Value: length}
+		// Count code points (characters), not bytes
+		return &runtime.IntegerValue{Value: strVal.Length()}
 	}
 
 	return ctx.NewError("Length() expects array or string, got %T", arg)
@@ -14,6 +14,7 @@ package builtins
 
 import (
 	"math/rand"
+	"time"
 
 	"github.com/cwbudde/go-dws/internal/interp/runtime"
 	"github.com/cwbudde/go-dws/internal/types"
@@ -62,6 +63,18 @@ type Context interface {
 	// Used by the SetRandSeed() and Randomize() built-in functions.
 	SetRandSeed(seed int64)
 
+	// RandomSeedFixed reports whether the host fixed the random seed, in which
+	// case Randomize() leaves the generator untouched.
+	RandomSeedFixed() bool
+
+	// FormatSettings returns the decimal, thousand and date separators used by
+	// FloatToStr, StrToFloat, Format and DateToStr.
+	FormatSettings() runtime.FormatSettings
+
+	// CurrentTime returns the current time as reported by the host clock.
+	// Used by Now(), Date(), Time() and the other clock-reading builtins.
+	CurrentTime() time.Time
+
 	// UnwrapVariant returns the underlying value if input is a Variant, otherwise returns input as-is.
 	// This allows built-in functions to work with both direct values and Variant-wrapped values.
 	UnwrapVariant(value Value) Value
@@ -187,8 +200,8 @@ type Context interface {
 	// Returns (value, true) on success, or (0.0, false) on error.
 	ParseFloat(s string) (float64, bool)
 
-	// FormatString formats a string using Go fmt.Sprintf semantics with DWScript values.
-	// Supports %s, %d, %f, %v, %x, %X, %o format verbs.
+	// FormatString formats a string using Delphi Format semantics with DWScript values.
+	// Supports the d, u, e, f, g, n, m, p, s and x types (see FormatValues).
 	// Returns (formatted string, nil) on success, or ("", error) on formatting error.
 	FormatString(format string, args []Value) (string, error)
 
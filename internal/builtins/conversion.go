@@ -23,6 +23,7 @@ import (
 //   - StrToInt: Convert string to integer
 //   - StrToFloat: Convert string to float
 //   - FloatToStr: Convert float to string
+//   - FloatToStrF: Convert float to string in a Delphi float format
 //   - BoolToStr: Convert boolean to string
 //
 // These functions use the Context helper methods (ToInt64, ToFloat64, etc.)
@@ -185,7 +186,7 @@ func StrToInt(ctx Context, args []Value) Value {
 					if raiser, ok := ctx.(interface {
 						RaiseException(className, message string, pos any)
 					}); ok {
-						raiser.RaiseException("Exception", msg, pos)
+						raiser.RaiseException("EConvertError", msg, pos)
 						return ctx.NewError(msg)
 					}
 				}
@@ -194,7 +195,7 @@ func StrToInt(ctx Context, args []Value) Value {
 			if raiser, ok := ctx.(interface {
 				RaiseException(className, message string, pos any)
 			}); ok {
-				raiser.RaiseException("Exception", msg, nil)
+				raiser.RaiseException("EConvertError", msg, nil)
 			}
 
 			return ctx.NewError(msg)
@@ -232,7 +233,7 @@ func StrToInt(ctx Context, args []Value) Value {
 				if raiser, ok := ctx.(interface {
 					RaiseException(className, message string, pos any)
 				}); ok {
-					raiser.RaiseException("Exception", msg, pos)
+					raiser.RaiseException("EConvertError", msg, pos)
 					return ctx.NewError(msg)
 				}
 			}
@@ -242,7 +243,7 @@ func StrToInt(ctx Context, args []Value) Value {
 		if raiser, ok := ctx.(interface {
 			RaiseException(className, message string, pos any)
 		}); ok {
-			raiser.RaiseException("Exception", msg, nil)
+			raiser.RaiseException("EConvertError", msg, nil)
 		}
 
 		return ctx.NewError(msg)
@@ -252,6 +253,7 @@ func StrToInt(ctx Context, args []Value) Value {
 }
 
 // StrToFloat converts a string to a float, raising an error if the string is invalid.
+// The string must use the decimal separator of the engine's FormatSettings.
 // StrToFloat(s: String): Float
 func StrToFloat(ctx Context, args []Value) Value {
 	if len(args) != 1 {
@@ -264,12 +266,9 @@ func StrToFloat(ctx Context, args []Value) Value {
 		return ctx.NewError("StrToFloat() expects string argument, got %s", args[0].Type())
 	}
 
-	// Try to parse the string as a float
-	s := strings.TrimSpace(strVal.Value)
-
-	// Use strconv.ParseFloat for strict parsing (doesn't accept partial matches)
-	floatValue, err := strconv.ParseFloat(s, 64)
-	if err != nil {
+	// Strict parsing with the configured decimal separator (no partial matches)
+	floatValue, ok := ctx.FormatSettings().ParseFloat(strVal.Value)
+	if !ok {
 		msg := fmt.Sprintf("%q is not a valid floating point value", strVal.Value)
 
 		// Attach source position if available
@@ -280,7 +279,7 @@ func StrToFloat(ctx Context, args []Value) Value {
 				if raiser, ok := ctx.(interface {
 					RaiseException(className, message string, pos any)
 				}); ok {
-					raiser.RaiseException("Exception", msg, pos)
+					raiser.RaiseException("EConvertError", msg, pos)
 					return ctx.NewError(msg)
 				}
 			}
@@ -290,7 +289,7 @@ func StrToFloat(ctx Context, args []Value) Value {
 		if raiser, ok := ctx.(interface {
 			RaiseException(className, message string, pos any)
 		}); ok {
-			raiser.RaiseException("Exception", msg, nil)
+			raiser.RaiseException("EConvertError", msg, nil)
 		}
 
 		return ctx.NewError(msg)
@@ -299,7 +298,8 @@ func StrToFloat(ctx Context, args []Value) Value {
 	return &runtime.FloatValue{Value: floatValue}
 }
 
-// FloatToStr converts a float to its string representation.
+// FloatToStr converts a float to its string representation, using the decimal
+// separator of the engine's FormatSettings.
 // FloatToStr(f: Float [, precision: Integer]): String
 // Precision >= 0 formats with fixed decimals, <0 rounds to powers of 10.
 func FloatToStr(ctx Context, args []Value) Value {
@@ -333,7 +333,7 @@ func FloatToStr(ctx Context, args []Value) Value {
 
 		// Extremely large precision falls back to default formatting
 		if prec > 15 {
-			return &runtime.StringValue{Value: strconv.FormatFloat(floatValue, 'g', -1, 64)}
+			return &runtime.StringValue{Value: ctx.FormatSettings().LocalizeNumber(strconv.FormatFloat(floatValue, 'g', -1, 64))}
 		}
 
 		// Use fixed-point formatting, trimming trailing zeros when precision is zero
@@ -341,14 +341,117 @@ func FloatToStr(ctx Context, args []Value) Value {
 		if prec == 0 {
 			result = strings.TrimSuffix(result, ".")
 		}
-		return &runtime.StringValue{Value: result}
+		return &runtime.StringValue{Value: ctx.FormatSettings().LocalizeNumber(result)}
 	}
 
 	// Default formatting keeps significant digits without losing precision
 	result := strconv.FormatFloat(floatValue, 'g', -1, 64)
+	return &runtime.StringValue{Value: ctx.FormatSettings().LocalizeNumber(result)}
+}
+
+// Float formats accepted by FloatToStrF, with the ordinals of Delphi's
+// TFloatFormat. Scripts use them through the ffGeneral..ffCurrency constants.
+const (
+	FloatFormatGeneral = iota
+	FloatFormatExponent
+	FloatFormatFixed
+	FloatFormatNumber
+	FloatFormatCurrency
+)
+
+// FloatToStrF converts a float to a string using a Delphi float format.
+// FloatToStrF(value: Float, format: Integer [, precision, digits: Integer]): String
+//
+// Precision is the number of significant digits (1..18, default 15). Digits
+// is the number of decimals for ffFixed, ffNumber and ffCurrency, and the
+// minimum number of exponent digits (0..4) for ffGeneral and ffExponent.
+// ffNumber and ffCurrency group thousands; no currency symbol is added.
+func FloatToStrF(ctx Context, args []Value) Value {
+	if len(args) != 2 && len(args) != 4 {
+		return ctx.NewError("FloatToStrF() expects 2 or 4 arguments, got %d", len(args))
+	}
+
+	value, ok := ctx.ToFloat64(args[0])
+	if !ok {
+		return ctx.NewError("FloatToStrF() expects float argument, got %s", args[0].Type())
+	}
+	format, ok := ctx.ToInt64(args[1])
+	if !ok || format < FloatFormatGeneral || format > FloatFormatCurrency {
+		return ctx.NewError("FloatToStrF() expects a float format (ffGeneral..ffCurrency), got %s", args[1].String())
+	}
+
+	precision, digits := int64(15), int64(0)
+	if len(args) == 4 {
+		if precision, ok = ctx.ToInt64(args[2]); !ok {
+			return ctx.NewError("FloatToStrF() expects integer precision, got %s", args[2].Type())
+		}
+		if digits, ok = ctx.ToInt64(args[3]); !ok {
+			return ctx.NewError("FloatToStrF() expects integer digits, got %s", args[3].Type())
+		}
+	}
+	precision = min(max(precision, 1), 18)
+	digits = min(max(digits, 0), 18)
+
+	result := formatFloatF(value, int(format), int(precision), int(digits), ctx.FormatSettings())
 	return &runtime.StringValue{Value: result}
 }
 
+// formatFloatF implements the float formats of FloatToStrF.
+func formatFloatF(f float64, format, precision, digits int, settings runtime.FormatSettings) string {
+	switch {
+	case math.IsNaN(f):
+		return "NAN"
+	case math.IsInf(f, 1):
+		return "INF"
+	case math.IsInf(f, -1):
+		return "-INF"
+	}
+
+	mantissa, exp := splitExponent(strconv.FormatFloat(f, 'E', precision-1, 64))
+	switch format {
+	case FloatFormatExponent:
+		return settings.LocalizeNumber(mantissa) + formatExponentDigits(exp, min(digits, 4), true)
+
+	case FloatFormatFixed, FloatFormatNumber, FloatFormatCurrency:
+		// Values with more integer digits than precision use ffGeneral
+		if f == 0 || exp < precision {
+			// Round to precision significant digits first, as Delphi does
+			rounded, _ := strconv.ParseFloat(mantissa+"E"+strconv.Itoa(exp), 64)
+			s := strconv.FormatFloat(rounded, 'f', digits, 64)
+			if strings.Trim(s, "-0.") == "" {
+				s = strings.TrimPrefix(s, "-")
+			}
+			if format == FloatFormatFixed {
+				return settings.LocalizeNumber(s)
+			}
+			return groupThousands(s, settings)
+		}
+		digits = 0
+	}
+
+	if f == 0 {
+		return "0"
+	}
+	if exp >= precision || math.Abs(f) < 0.00001 {
+		return settings.LocalizeNumber(trimFraction(mantissa)) + formatExponentDigits(exp, min(digits, 4), false)
+	}
+	decimals := max(precision-1-exp, 0)
+	return settings.LocalizeNumber(trimFraction(strconv.FormatFloat(f, 'f', decimals, 64)))
+}
+
+// formatExponentDigits renders exp as "E" followed by its sign and at least
+// minDigits digits. A "+" sign is only written when plus is set.
+func formatExponentDigits(exp, minDigits int, plus bool) string {
+	sign := ""
+	if exp < 0 {
+		sign = "-"
+		exp = -exp
+	} else if plus {
+		sign = "+"
+	}
+	return "E" + sign + zeroPad(strconv.Itoa(exp), minDigits)
+}
+
 // BoolToStr converts a boolean to its string representation.
 // BoolToStr(b: Boolean): String
 func BoolToStr(ctx Context, args []Value) Value {
@@ -1,6 +1,7 @@
 package builtins
 
 import (
+	"math"
 	"testing"
 
 	"github.com/cwbudde/go-dws/internal/interp/runtime"
@@ -371,6 +372,59 @@ func TestFloatToStr(t *testing.T) {
 	}
 }
 
+func TestFloatToStrF(t *testing.T) {
+	ctx := newMockContext()
+	f := func(v float64) Value { return &runtime.FloatValue{Value: v} }
+	i := func(v int64) Value { return &runtime.IntegerValue{Value: v} }
+
+	tests := []struct {
+		name     string
+		expected string
+		args     []Value
+		isError  bool
+	}{
+		{name: "general", args: []Value{f(1234.5), i(FloatFormatGeneral), i(15), i(0)}, expected: "1234.5"},
+		{name: "general rounds to precision", args: []Value{f(3.14159), i(FloatFormatGeneral), i(3), i(0)}, expected: "3.14"},
+		{name: "general large", args: []Value{f(1.5e20), i(FloatFormatGeneral), i(15), i(0)}, expected: "1.5E20"},
+		{name: "general small", args: []Value{f(0.000001234), i(FloatFormatGeneral), i(15), i(2)}, expected: "1.234E-06"},
+		{name: "general two arguments", args: []Value{f(2.5), i(FloatFormatGeneral)}, expected: "2.5"},
+		{name: "exponent", args: []Value{f(1234.56), i(FloatFormatExponent), i(4), i(2)}, expected: "1.235E+03"},
+		{name: "exponent negative", args: []Value{f(-0.00125), i(FloatFormatExponent), i(3), i(0)}, expected: "-1.25E-3"},
+		{name: "fixed", args: []Value{f(3.14159), i(FloatFormatFixed), i(15), i(2)}, expected: "3.14"},
+		{name: "fixed pads decimals", args: []Value{f(3.14159), i(FloatFormatFixed), i(3), i(4)}, expected: "3.1400"},
+		{name: "fixed negative zero", args: []Value{f(-0.001), i(FloatFormatFixed), i(15), i(2)}, expected: "0.00"},
+		{name: "fixed too many digits", args: []Value{f(123456), i(FloatFormatFixed), i(4), i(2)}, expected: "1.235E5"},
+		{name: "number", args: []Value{f(1234567.891), i(FloatFormatNumber), i(15), i(2)}, expected: "1,234,567.89"},
+		{name: "currency", args: []Value{f(-1234.5), i(FloatFormatCurrency), i(15), i(2)}, expected: "-1,234.50"},
+		{name: "integer value", args: []Value{i(42), i(FloatFormatFixed), i(15), i(1)}, expected: "42.0"},
+		{name: "nan", args: []Value{f(math.NaN()), i(FloatFormatFixed), i(15), i(2)}, expected: "NAN"},
+		{name: "infinity", args: []Value{f(math.Inf(-1)), i(FloatFormatGeneral), i(15), i(0)}, expected: "-INF"},
+		{name: "unknown format", args: []Value{f(1), i(7), i(15), i(2)}, isError: true},
+		{name: "wrong argument count", args: []Value{f(1), i(FloatFormatFixed), i(15)}, isError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := FloatToStrF(ctx, tt.args)
+
+			if tt.isError {
+				if result.Type() != "ERROR" {
+					t.Errorf("expected error, got %v", result)
+				}
+				return
+			}
+
+			strVal, ok := result.(*runtime.StringValue)
+			if !ok {
+				t.Fatalf("expected StringValue, got %T: %v", result, result)
+			}
+			if strVal.Value != tt.expected {
+				t.Errorf("expected %q, got %q", tt.expected, strVal.Value)
+			}
+		})
+	}
+}
+
 func TestBoolToStr(t *testing.T) {
 	ctx := newMockContext()
 
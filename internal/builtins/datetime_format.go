@@ -1,9 +1,10 @@
 package builtins
 
 import (
-	"time"
+	"fmt"
 
 	"github.com/cwbudde/go-dws/internal/interp/runtime"
+	"github.com/cwbudde/go-dws/internal/lexer"
 )
 
 // =============================================================================
@@ -30,7 +31,7 @@ func FormatDateTime(ctx Context, args []Value) Value {
 		return ctx.NewError("FormatDateTime() expects Float/TDateTime as second argument, got %s", args[1].Type())
 	}
 
-	result := formatDateTime(formatVal.Value, dtVal.Value)
+	result := formatDateTime(formatVal.Value, defaultDateFormat(ctx), dtVal.Value)
 	return &runtime.StringValue{Value: result}
 }
 
@@ -46,8 +47,8 @@ func DateTimeToStr(ctx Context, args []Value) Value {
 		return ctx.NewError("DateTimeToStr() expects Float/TDateTime, got %s", args[0].Type())
 	}
 
-	// Use default format: YYYY-MM-DD HH:MM:SS
-	result := formatDateTime("yyyy-mm-dd hh:nn:ss", dtVal.Value)
+	// Use default format: YYYY-MM-DD HH:MM:SS with the configured date separator
+	result := formatDateTime("ddddd hh:nn:ss", defaultDateFormat(ctx), dtVal.Value)
 	return &runtime.StringValue{Value: result}
 }
 
@@ -63,8 +64,8 @@ func DateToStr(ctx Context, args []Value) Value {
 		return ctx.NewError("DateToStr() expects Float/TDateTime, got %s", args[0].Type())
 	}
 
-	// Use default date format: YYYY-MM-DD
-	result := formatDateTime("yyyy-mm-dd", dtVal.Value)
+	// Use default date format: YYYY-MM-DD with the configured date separator
+	result := formatDateTime("ddddd", defaultDateFormat(ctx), dtVal.Value)
 	return &runtime.StringValue{Value: result}
 }
 
@@ -81,10 +82,17 @@ func TimeToStr(ctx Context, args []Value) Value {
 	}
 
 	// Use default time format: HH:MM:SS
-	result := formatDateTime("hh:nn:ss", dtVal.Value)
+	result := formatDateTime("hh:nn:ss", defaultDateFormat(ctx), dtVal.Value)
 	return &runtime.StringValue{Value: result}
 }
 
+// defaultDateFormat returns the year-month-day format used by DateToStr and
+// DateTimeToStr, joined by the engine's date separator.
+func defaultDateFormat(ctx Context) string {
+	sep := ctx.FormatSettings().DateSeparator
+	return "yyyy" + sep + "mm" + sep + "dd"
+}
+
 // DateToISO8601 implements the DateToISO8601() built-in function.
 // Formats date as ISO 8601 string (YYYY-MM-DD).
 func DateToISO8601(ctx Context, args []Value) Value {
@@ -138,7 +146,7 @@ func DateTimeToRFC822(ctx Context, args []Value) Value {
 // =============================================================================
 
 // StrToDate implements the StrToDate() built-in function.
-// Parses a date string to TDateTime.
+// Parses a date string to TDateTime. Invalid dates raise EConvertError.
 func StrToDate(ctx Context, args []Value) Value {
 	if len(args) != 1 {
 		return ctx.NewError("StrToDate() expects 1 argument, got %d", len(args))
@@ -151,14 +159,14 @@ func StrToDate(ctx Context, args []Value) Value {
 
 	dt, err := parseDate(strVal.Value)
 	if err != nil {
-		return ctx.NewError("StrToDate() %s", err)
+		return raiseDateTimeParseError(ctx, strVal.Value)
 	}
 
 	return &runtime.FloatValue{Value: dt}
 }
 
 // StrToDateTime implements the StrToDateTime() built-in function.
-// Parses a datetime string to TDateTime.
+// Parses a datetime string to TDateTime. Invalid input raises EConvertError.
 func StrToDateTime(ctx Context, args []Value) Value {
 	if len(args) != 1 {
 		return ctx.NewError("StrToDateTime() expects 1 argument, got %d", len(args))
@@ -171,14 +179,14 @@ func StrToDateTime(ctx Context, args []Value) Value {
 
 	dt, err := parseDateTime(strVal.Value)
 	if err != nil {
-		return ctx.NewError("StrToDateTime() %s", err)
+		return raiseDateTimeParseError(ctx, strVal.Value)
 	}
 
 	return &runtime.FloatValue{Value: dt}
 }
 
 // StrToTime implements the StrToTime() built-in function.
-// Parses a time string to TDateTime.
+// Parses a time string to TDateTime. Invalid times raise EConvertError.
 func StrToTime(ctx Context, args []Value) Value {
 	if len(args) != 1 {
 		return ctx.NewError("StrToTime() expects 1 argument, got %d", len(args))
@@ -191,12 +199,41 @@ func StrToTime(ctx Context, args []Value) Value {
 
 	dt, err := parseTime(strVal.Value)
 	if err != nil {
-		return ctx.NewError("StrToTime() %s", err)
+		return raiseDateTimeParseError(ctx, strVal.Value)
 	}
 
 	return &runtime.FloatValue{Value: dt}
 }
 
+// raiseDateTimeParseError raises EConvertError for a string StrToDate,
+// StrToDateTime or StrToTime cannot parse.
+func raiseDateTimeParseError(ctx Context, s string) Value {
+	msg := fmt.Sprintf("Date/time parsing error for %q", s)
+
+	// Attach source position if available
+	if node := ctx.CurrentNode(); node != nil {
+		if posNode, ok := node.(interface{ Pos() lexer.Position }); ok {
+			pos := posNode.Pos()
+			msg = fmt.Sprintf("%s [line: %d, column: %d]", msg, pos.Line, pos.Column)
+			if raiser, ok := ctx.(interface {
+				RaiseException(className, message string, pos any)
+			}); ok {
+				raiser.RaiseException("EConvertError", msg, pos)
+				return ctx.NewError(msg)
+			}
+		}
+	}
+
+	// Fallback: raise without position if no node available
+	if raiser, ok := ctx.(interface {
+		RaiseException(className, message string, pos any)
+	}); ok {
+		raiser.RaiseException("EConvertError", msg, nil)
+	}
+
+	return ctx.NewError(msg)
+}
+
 // ISO8601ToDateTime implements the ISO8601ToDateTime() built-in function.
 // Parses an ISO 8601 string to TDateTime.
 func ISO8601ToDateTime(ctx Context, args []Value) Value {
@@ -248,7 +285,7 @@ func UnixTime(ctx Context, args []Value) Value {
 		return ctx.NewError("UnixTime() expects 0 arguments, got %d", len(args))
 	}
 
-	now := time.Now().UTC()
+	now := ctx.CurrentTime()
 	return &runtime.IntegerValue{Value: now.Unix()}
 }
 
@@ -259,7 +296,7 @@ func UnixTimeMSec(ctx Context, args []Value) Value {
 		return ctx.NewError("UnixTimeMSec() expects 0 arguments, got %d", len(args))
 	}
 
-	now := time.Now().UTC()
+	now := ctx.CurrentTime()
 	return &runtime.IntegerValue{Value: now.UnixMilli()}
 }
 
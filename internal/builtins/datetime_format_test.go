@@ -52,6 +52,41 @@ func TestFormatDateTime(t *testing.T) {
 			format:   "d/m/yyyy h:n:s",
 			expected: "15/3/2023 12:30:45",
 		},
+		{
+			name:     "day and month names",
+			format:   "dddd, mmmm d (ddd mmm)",
+			expected: "Wednesday, March 15 (Wed Mar)",
+		},
+		{
+			name:     "case-insensitive specifiers",
+			format:   "YYYY-MM-DD HH:NN",
+			expected: "2023-03-15 12:30",
+		},
+		{
+			name:     "minutes after hour",
+			format:   "h:mm",
+			expected: "12:30",
+		},
+		{
+			name:     "quoted literals",
+			format:   `"day" d 'of' mmmm`,
+			expected: "day 15 of March",
+		},
+		{
+			name:     "12-hour clock",
+			format:   "h:nn am/pm|hh A/P|ampm",
+			expected: "12:30 pm|12 P|PM",
+		},
+		{
+			name:     "short and long date",
+			format:   "ddddd|dddddd",
+			expected: "2023-03-15|Wednesday, March 15, 2023",
+		},
+		{
+			name:     "date and time",
+			format:   "c",
+			expected: "2023-03-15 12:30:45",
+		},
 	}
 
 	for _, tt := range tests {
@@ -14,13 +14,13 @@ import (
 // =============================================================================
 
 // Now implements the Now() built-in function.
-// Returns the current date and time as TDateTime.
+// Returns the current local date and time as TDateTime.
 func Now(ctx Context, args []Value) Value {
 	if len(args) != 0 {
 		return ctx.NewError("Now() expects 0 arguments, got %d", len(args))
 	}
 
-	now := time.Now().UTC()
+	now := ctx.CurrentTime()
 	dtValue := goTimeToDelphiDateTime(now)
 
 	return &runtime.FloatValue{Value: dtValue}
@@ -33,7 +33,7 @@ func Date(ctx Context, args []Value) Value {
 		return ctx.NewError("Date() expects 0 arguments, got %d", len(args))
 	}
 
-	now := time.Now().UTC()
+	now := ctx.CurrentTime()
 	// Zero out the time component
 	dateOnly := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
 	dtValue := goTimeToDelphiDateTime(dateOnly)
@@ -48,7 +48,7 @@ func Time(ctx Context, args []Value) Value {
 		return ctx.NewError("Time() expects 0 arguments, got %d", len(args))
 	}
 
-	now := time.Now().UTC()
+	now := ctx.CurrentTime()
 	// Use epoch date, only keep time
 	timeOnly := time.Date(1899, 12, 30, now.Hour(), now.Minute(), now.Second(), now.Nanosecond(), time.UTC)
 	dtValue := goTimeToDelphiDateTime(timeOnly)
@@ -63,7 +63,7 @@ func UTCDateTime(ctx Context, args []Value) Value {
 		return ctx.NewError("UTCDateTime() expects 0 arguments, got %d", len(args))
 	}
 
-	now := time.Now().UTC()
+	now := ctx.CurrentTime().UTC()
 	dtValue := goTimeToDelphiDateTime(now)
 
 	return &runtime.FloatValue{Value: dtValue}
@@ -370,23 +370,42 @@ const (
 //   - Integer part = number of days since December 30, 1899
 //   - Fractional part = time of day (0.5 = noon, 0.25 = 6am)
 //
-// Core conversion function for DateTime support
+// The wall clock of t is used as-is, whatever its location. Core conversion
+// function for DateTime support.
 func goTimeToDelphiDateTime(t time.Time) float64 {
-	// Calculate days since Delphi epoch
-	duration := t.Sub(delphiEpoch)
-	days := duration.Seconds() / secondsPerDay
-	return days
+	// Work from Unix seconds: a time.Duration cannot span the ~8100 years
+	// between the epoch and 9999-12-31.
+	wall := time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), time.UTC)
+	seconds := wall.Unix() - delphiEpoch.Unix()
+	days := math.Floor(float64(seconds) / secondsPerDay)
+	timeOfDay := (float64(seconds) - days*secondsPerDay + float64(wall.Nanosecond())/1e9) / secondsPerDay
+	// Before the epoch the day count is negative but the time of day still
+	// counts forward, so 1899-12-29 06:00 is -1.25.
+	if days < 0 {
+		return days - timeOfDay
+	}
+	return days + timeOfDay
 }
 
 // delphiDateTimeToGoTime converts a Delphi TDateTime float64 to Go time.Time.
-// The result is in UTC timezone.
+// The result is in UTC timezone and rounded to the nearest millisecond, as
+// Delphi's DecodeTime does. The fractional part is the time of day also for
+// negative values, so -1.25 is 1899-12-29 06:00.
 //
 // Core conversion function for DateTime support
 func delphiDateTimeToGoTime(dt float64) time.Time {
-	// Calculate duration from epoch
-	seconds := dt * secondsPerDay
-	duration := time.Duration(seconds * float64(time.Second))
-	return delphiEpoch.Add(duration)
+	if dt < 0 {
+		days := math.Trunc(dt)
+		dt = days + (days - dt)
+	}
+	msecs := int64(math.Round(dt * millisecondsPerDay))
+	seconds := msecs / 1000
+	remainder := msecs % 1000
+	if remainder < 0 {
+		seconds--
+		remainder += 1000
+	}
+	return time.Unix(delphiEpoch.Unix()+seconds, remainder*int64(time.Millisecond)).UTC()
 }
 
 // isValidDate checks if the given year, month, day constitutes a valid date.
@@ -460,71 +479,217 @@ func extractTimeComponents(dt float64) (hour, minute, second, millisecond int) {
 }
 
 // formatDateTime formats a TDateTime value according to a format string.
-// This implements DWScript's FormatDateTime function with Delphi-style format specifiers.
+// This implements DWScript's FormatDateTime function with Delphi-style format
+// specifiers. Specifiers are case-insensitive; shortDate is the format 'c' and
+// 'ddddd' expand to.
 //
 // Supported format specifiers:
 //
-//	yyyy - 4-digit year (e.g., 2023)
-//	yy   - 2-digit year (e.g., 23)
-//	mm   - 2-digit month (01-12)
-//	m    - month without leading zero (1-12)
-//	dd   - 2-digit day (01-31)
-//	d    - day without leading zero (1-31)
-//	hh   - 2-digit hour, 24h format (00-23)
-//	h    - hour without leading zero, 24h format (0-23)
-//	nn   - 2-digit minute (00-59)
-//	n    - minute without leading zero (0-59)
-//	ss   - 2-digit second (00-59)
-//	s    - second without leading zero (0-59)
-//	zzz  - 3-digit millisecond (000-999)
-//	z    - millisecond without leading zeros (0-999)
+//	c      - short date followed by hh:nn:ss (the time is omitted at midnight)
+//	yyyy   - 4-digit year (e.g., 2023)
+//	yy     - 2-digit year (e.g., 23)
+//	mmmm   - month name (January-December)
+//	mmm    - short month name (Jan-Dec)
+//	mm     - 2-digit month (01-12), or minute right after an hour specifier
+//	m      - month without leading zero (1-12), or minute after an hour
+//	dddddd - long date (dddd, mmmm d, yyyy)
+//	ddddd  - short date
+//	dddd   - day name (Sunday-Saturday)
+//	ddd    - short day name (Sun-Sat)
+//	dd     - 2-digit day (01-31)
+//	d      - day without leading zero (1-31)
+//	hh     - 2-digit hour (00-23)
+//	h      - hour without leading zero (0-23)
+//	nn     - 2-digit minute (00-59)
+//	n      - minute without leading zero (0-59)
+//	ss     - 2-digit second (00-59)
+//	s      - second without leading zero (0-59)
+//	zzz    - 3-digit millisecond (000-999)
+//	z      - millisecond without leading zeros (0-999)
+//	tt     - long time (hh:nn:ss)
+//	t      - short time (hh:nn)
+//	am/pm  - am or pm, keeping the case of the specifier; hours use a 12-hour clock
+//	a/p    - a or p, keeping the case of the specifier; hours use a 12-hour clock
+//	ampm   - AM or PM; hours use a 12-hour clock
+//	"..."  - literal text, also '...'
 //
-// Note: Format specifiers are case-sensitive.
-func formatDateTime(format string, dt float64) string {
+// Any other character is copied as-is.
+func formatDateTime(format, shortDate string, dt float64) string {
 	t := delphiDateTimeToGoTime(dt)
-
-	year := t.Year()
-	month := int(t.Month())
-	day := t.Day()
-	hour := t.Hour()
-	minute := t.Minute()
-	second := t.Second()
-	millisecond := t.Nanosecond() / 1000000
-
-	result := format
-
-	// Process format specifiers in order of longest to shortest to avoid conflicts
-	// e.g., 'yyyy' should be processed before 'yy'
-
-	// Year formats
-	result = strings.ReplaceAll(result, "yyyy", fmt.Sprintf("%04d", year))
-	result = strings.ReplaceAll(result, "yy", fmt.Sprintf("%02d", year%100))
-
-	// Month formats
-	result = strings.ReplaceAll(result, "mm", fmt.Sprintf("%02d", month))
-	result = strings.ReplaceAll(result, "m", fmt.Sprintf("%d", month))
-
-	// Day formats
-	result = strings.ReplaceAll(result, "dd", fmt.Sprintf("%02d", day))
-	result = strings.ReplaceAll(result, "d", fmt.Sprintf("%d", day))
-
-	// Hour formats (24h)
-	result = strings.ReplaceAll(result, "hh", fmt.Sprintf("%02d", hour))
-	result = strings.ReplaceAll(result, "h", fmt.Sprintf("%d", hour))
-
-	// Minute formats (nn for minute to avoid conflict with month m)
-	result = strings.ReplaceAll(result, "nn", fmt.Sprintf("%02d", minute))
-	result = strings.ReplaceAll(result, "n", fmt.Sprintf("%d", minute))
-
-	// Second formats
-	result = strings.ReplaceAll(result, "ss", fmt.Sprintf("%02d", second))
-	result = strings.ReplaceAll(result, "s", fmt.Sprintf("%d", second))
-
-	// Millisecond formats
-	result = strings.ReplaceAll(result, "zzz", fmt.Sprintf("%03d", millisecond))
-	result = strings.ReplaceAll(result, "z", fmt.Sprintf("%d", millisecond))
-
-	return result
+	var sb strings.Builder
+	writeDateTimeFormat(&sb, format, shortDate, t, hasAMPMSpecifier(format))
+	return sb.String()
+}
+
+// writeDateTimeFormat appends t formatted with format to sb. twelveHour
+// selects the 12-hour clock for the h and hh specifiers.
+func writeDateTimeFormat(sb *strings.Builder, format, shortDate string, t time.Time, twelveHour bool) {
+	lower := strings.ToLower(format)
+	afterHour := false
+	for i := 0; i < len(format); {
+		c := lower[i]
+		run := 1
+		for i+run < len(lower) && lower[i+run] == c {
+			run++
+		}
+		wasHour := afterHour
+		afterHour = false
+
+		switch c {
+		case '"', '\'':
+			end := strings.IndexByte(format[i+1:], format[i])
+			if end < 0 {
+				sb.WriteString(format[i+1:])
+				return
+			}
+			sb.WriteString(format[i+1 : i+1+end])
+			i += end + 2
+			continue
+		case 'c':
+			writeDateTimeFormat(sb, shortDate, shortDate, t, twelveHour)
+			if t.Hour() != 0 || t.Minute() != 0 || t.Second() != 0 || t.Nanosecond() != 0 {
+				sb.WriteByte(' ')
+				writeDateTimeFormat(sb, "hh:nn:ss", shortDate, t, twelveHour)
+			}
+			i++
+			continue
+		case 'y':
+			switch {
+			case run >= 4:
+				fmt.Fprintf(sb, "%04d", t.Year())
+				run = 4
+			case run >= 2:
+				fmt.Fprintf(sb, "%02d", t.Year()%100)
+				run = 2
+			default:
+				sb.WriteByte(format[i])
+			}
+		case 'm':
+			run = min(run, 4)
+			switch {
+			case wasHour && run <= 2:
+				writeDateTimeNumber(sb, t.Minute(), run)
+			case run == 4:
+				sb.WriteString(t.Month().String())
+			case run == 3:
+				sb.WriteString(t.Month().String()[:3])
+			default:
+				writeDateTimeNumber(sb, int(t.Month()), run)
+			}
+		case 'd':
+			run = min(run, 6)
+			switch run {
+			case 6:
+				writeDateTimeFormat(sb, "dddd, mmmm d, yyyy", shortDate, t, twelveHour)
+			case 5:
+				writeDateTimeFormat(sb, shortDate, shortDate, t, twelveHour)
+			case 4:
+				sb.WriteString(t.Weekday().String())
+			case 3:
+				sb.WriteString(t.Weekday().String()[:3])
+			default:
+				writeDateTimeNumber(sb, t.Day(), run)
+			}
+		case 'h':
+			run = min(run, 2)
+			hour := t.Hour()
+			if twelveHour {
+				hour %= 12
+				if hour == 0 {
+					hour = 12
+				}
+			}
+			writeDateTimeNumber(sb, hour, run)
+			afterHour = true
+		case 'n':
+			run = min(run, 2)
+			writeDateTimeNumber(sb, t.Minute(), run)
+		case 's':
+			run = min(run, 2)
+			writeDateTimeNumber(sb, t.Second(), run)
+		case 'z':
+			millisecond := t.Nanosecond() / int(time.Millisecond)
+			if run >= 3 {
+				fmt.Fprintf(sb, "%03d", millisecond)
+				run = 3
+			} else {
+				fmt.Fprintf(sb, "%d", millisecond)
+				run = 1
+			}
+		case 't':
+			run = min(run, 2)
+			if run == 2 {
+				writeDateTimeFormat(sb, "hh:nn:ss", shortDate, t, twelveHour)
+			} else {
+				writeDateTimeFormat(sb, "hh:nn", shortDate, t, twelveHour)
+			}
+		case 'a':
+			pm := t.Hour() >= 12
+			switch {
+			case strings.HasPrefix(lower[i:], "am/pm"):
+				if pm {
+					sb.WriteString(format[i+3 : i+5])
+				} else {
+					sb.WriteString(format[i : i+2])
+				}
+				run = 5
+			case strings.HasPrefix(lower[i:], "a/p"):
+				if pm {
+					sb.WriteByte(format[i+2])
+				} else {
+					sb.WriteByte(format[i])
+				}
+				run = 3
+			case strings.HasPrefix(lower[i:], "ampm"):
+				if pm {
+					sb.WriteString("PM")
+				} else {
+					sb.WriteString("AM")
+				}
+				run = 4
+			default:
+				sb.WriteByte(format[i])
+				run = 1
+			}
+		default:
+			sb.WriteByte(format[i])
+			run = 1
+			// Separators such as ':' keep a following m as minutes (h:mm)
+			afterHour = wasHour && (c < 'a' || c > 'z')
+		}
+		i += run
+	}
+}
+
+// writeDateTimeNumber writes n, zero-padded to two digits when width is 2.
+func writeDateTimeNumber(sb *strings.Builder, n, width int) {
+	if width >= 2 {
+		fmt.Fprintf(sb, "%02d", n)
+	} else {
+		fmt.Fprintf(sb, "%d", n)
+	}
+}
+
+// hasAMPMSpecifier reports whether format contains an am/pm, a/p or ampm
+// specifier outside quoted text, which switches hours to a 12-hour clock.
+func hasAMPMSpecifier(format string) bool {
+	lower := strings.ToLower(format)
+	for i := 0; i < len(lower); i++ {
+		switch lower[i] {
+		case '"', '\'':
+			end := strings.IndexByte(lower[i+1:], lower[i])
+			if end < 0 {
+				return false
+			}
+			i += end + 1
+		case 'a':
+			rest := lower[i:]
+			if strings.HasPrefix(rest, "am/pm") || strings.HasPrefix(rest, "a/p") || strings.HasPrefix(rest, "ampm") {
+				return true
+			}
+		}
+	}
+	return false
 }
 
 // parseDateTime attempts to parse a date/time string in various common formats.
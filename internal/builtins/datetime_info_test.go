@@ -1,6 +1,7 @@
 package builtins
 
 import (
+	"math"
 	"testing"
 	"time"
 
@@ -1013,3 +1014,29 @@ func TestFirstDayOfWeek(t *testing.T) {
 		})
 	}
 }
+
+func TestDelphiDateTimeConversion(t *testing.T) {
+	tests := []struct {
+		time time.Time
+		name string
+		dt   float64
+	}{
+		{name: "epoch", dt: 0, time: time.Date(1899, 12, 30, 0, 0, 0, 0, time.UTC)},
+		{name: "epoch noon", dt: 0.5, time: time.Date(1899, 12, 30, 12, 0, 0, 0, time.UTC)},
+		{name: "with milliseconds", dt: 45356.588298611111, time: time.Date(2024, 3, 5, 14, 7, 9, 0, time.UTC)},
+		{name: "day before epoch", dt: -1, time: time.Date(1899, 12, 29, 0, 0, 0, 0, time.UTC)},
+		{name: "negative with time of day", dt: -1.25, time: time.Date(1899, 12, 29, 6, 0, 0, 0, time.UTC)},
+		{name: "far before epoch", dt: -36522.75, time: time.Date(1800, 1, 1, 18, 0, 0, 0, time.UTC)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := delphiDateTimeToGoTime(tt.dt); !got.Equal(tt.time) {
+				t.Errorf("delphiDateTimeToGoTime(%v) = %v, want %v", tt.dt, got, tt.time)
+			}
+			if got := goTimeToDelphiDateTime(tt.time); math.Abs(got-tt.dt) > 1e-9 {
+				t.Errorf("goTimeToDelphiDateTime(%v) = %v, want %v", tt.time, got, tt.dt)
+			}
+		})
+	}
+}
@@ -5,6 +5,8 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/cwbudde/go-dws/internal/types"
+
 	"github.com/cwbudde/go-dws/internal/interp/runtime"
 	"github.com/cwbudde/go-dws/internal/lexer"
 	pkgast "github.com/cwbudde/go-dws/pkg/ast"
@@ -24,6 +26,8 @@ import (
 //   - StrToJSON: Encode string for JSON
 //   - StrToCSSText: Encode string for CSS text
 //   - StrToXML: Encode string for XML (with optional mode)
+//   - UTF8Encode/UTF8Decode: Convert between text and a byte string
+//   - BytesOf/StringOf: Convert between text and an array of UTF-8 bytes
 //
 // These functions provide safe encoding for various output formats to prevent
 // injection attacks and ensure proper rendering.
@@ -285,3 +289,117 @@ func xmlEncode(s string, mode int) (string, error) {
 
 	return b.String(), nil
 }
+
+// UTF8Encode returns the UTF-8 encoding of a string as a byte string: each
+// character of the result is one byte (code point 0..255).
+// UTF8Encode(str: String): String
+//
+// Example:
+//
+//	Length(UTF8Encode('é')) // Returns: 2
+func UTF8Encode(ctx Context, args []Value) Value {
+	if len(args) != 1 {
+		return ctx.NewError("UTF8Encode() expects exactly 1 argument, got %d", len(args))
+	}
+	strVal, ok := args[0].(*runtime.StringValue)
+	if !ok {
+		return ctx.NewError("UTF8Encode() expects string argument, got %s", args[0].Type())
+	}
+	return &runtime.StringValue{Value: bytesToByteString([]byte(strVal.Value))}
+}
+
+// UTF8Decode decodes a byte string produced by UTF8Encode back to text.
+// Characters above 255 are not bytes and raise EConvertError.
+// UTF8Decode(str: String): String
+func UTF8Decode(ctx Context, args []Value) Value {
+	if len(args) != 1 {
+		return ctx.NewError("UTF8Decode() expects exactly 1 argument, got %d", len(args))
+	}
+	strVal, ok := args[0].(*runtime.StringValue)
+	if !ok {
+		return ctx.NewError("UTF8Decode() expects string argument, got %s", args[0].Type())
+	}
+	buf := make([]byte, 0, len(strVal.Value))
+	for _, r := range strVal.Value {
+		if r > 0xFF {
+			return raiseEncodingError(ctx, fmt.Sprintf("UTF8Decode() invalid byte character #%d", r))
+		}
+		buf = append(buf, byte(r))
+	}
+	return &runtime.StringValue{Value: strings.ToValidUTF8(string(buf), "\uFFFD")}
+}
+
+// BytesOf returns the UTF-8 bytes of a string as an array of Byte.
+// BytesOf(str: String): array of Byte
+//
+// Example:
+//
+//	BytesOf('Aé') // Returns: [65, 195, 169]
+func BytesOf(ctx Context, args []Value) Value {
+	if len(args) != 1 {
+		return ctx.NewError("BytesOf() expects exactly 1 argument, got %d", len(args))
+	}
+	strVal, ok := args[0].(*runtime.StringValue)
+	if !ok {
+		return ctx.NewError("BytesOf() expects string argument, got %s", args[0].Type())
+	}
+	elements := make([]Value, len(strVal.Value))
+	for i := 0; i < len(strVal.Value); i++ {
+		elements[i] = &runtime.IntegerValue{Value: int64(strVal.Value[i])}
+	}
+	return &runtime.ArrayValue{
+		ArrayType: types.NewDynamicArrayType(types.BYTE),
+		Elements:  elements,
+	}
+}
+
+// StringOf decodes an array of UTF-8 bytes to a string. Values outside
+// 0..255 raise EConvertError; invalid UTF-8 sequences decode to U+FFFD.
+// StringOf(bytes: array of Byte): String
+func StringOf(ctx Context, args []Value) Value {
+	if len(args) != 1 {
+		return ctx.NewError("StringOf() expects exactly 1 argument, got %d", len(args))
+	}
+	arrVal, ok := args[0].(*runtime.ArrayValue)
+	if !ok {
+		return ctx.NewError("StringOf() expects array of Byte argument, got %s", args[0].Type())
+	}
+	buf := make([]byte, len(arrVal.Elements))
+	for i, elem := range arrVal.Elements {
+		b, ok := ctx.ToInt64(elem)
+		if !ok {
+			return ctx.NewError("StringOf() expects array of Byte argument, got element %s", elem.Type())
+		}
+		if b < 0 || b > 0xFF {
+			return raiseEncodingError(ctx, fmt.Sprintf("StringOf() byte value %d at index %d is out of range", b, i))
+		}
+		buf[i] = byte(b)
+	}
+	return &runtime.StringValue{Value: strings.ToValidUTF8(string(buf), "\uFFFD")}
+}
+
+// bytesToByteString maps each byte to the character with the same code point.
+func bytesToByteString(data []byte) string {
+	var b strings.Builder
+	b.Grow(len(data))
+	for _, c := range data {
+		b.WriteRune(rune(c))
+	}
+	return b.String()
+}
+
+// raiseEncodingError raises a catchable EConvertError at the current node,
+// falling back to a runtime error when the context cannot raise exceptions.
+func raiseEncodingError(ctx Context, msg string) Value {
+	if raiser, ok := ctx.(interface {
+		RaiseException(className, message string, pos any)
+	}); ok {
+		var pos any
+		if node := ctx.CurrentNode(); node != nil {
+			pos = node.Pos()
+		}
+		raiser.RaiseException("EConvertError", msg, pos)
+		return &runtime.StringValue{}
+	}
+	return ctx.NewError("%s", msg)
+}
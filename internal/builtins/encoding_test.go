@@ -320,3 +320,55 @@ func TestStrToXML(t *testing.T) {
 		})
 	}
 }
+
+func TestUTF8EncodeDecode(t *testing.T) {
+	ctx := newMockContext()
+
+	encoded := UTF8Encode(ctx, []Value{&runtime.StringValue{Value: "Aé€"}})
+	strVal, ok := encoded.(*runtime.StringValue)
+	if !ok {
+		t.Fatalf("UTF8Encode() returned %T, want *runtime.StringValue", encoded)
+	}
+	want := []rune{'A', 0xC3, 0xA9, 0xE2, 0x82, 0xAC}
+	if got := []rune(strVal.Value); string(got) != string(want) {
+		t.Errorf("UTF8Encode() = %v, want %v", got, want)
+	}
+
+	decoded := UTF8Decode(ctx, []Value{strVal})
+	if got, ok := decoded.(*runtime.StringValue); !ok || got.Value != "Aé€" {
+		t.Errorf("UTF8Decode() = %v, want %q", decoded, "Aé€")
+	}
+
+	if _, ok := UTF8Decode(ctx, []Value{&runtime.StringValue{Value: "€"}}).(*mockErrorValue); !ok {
+		t.Error("UTF8Decode() should fail for characters above 255")
+	}
+}
+
+func TestBytesOfStringOf(t *testing.T) {
+	ctx := newMockContext()
+
+	result := BytesOf(ctx, []Value{&runtime.StringValue{Value: "Aé"}})
+	arr, ok := result.(*runtime.ArrayValue)
+	if !ok {
+		t.Fatalf("BytesOf() returned %T, want *runtime.ArrayValue", result)
+	}
+	want := []int64{65, 195, 169}
+	if len(arr.Elements) != len(want) {
+		t.Fatalf("BytesOf() returned %d bytes, want %d", len(arr.Elements), len(want))
+	}
+	for i, w := range want {
+		if got := arr.Elements[i].(*runtime.IntegerValue).Value; got != w {
+			t.Errorf("BytesOf()[%d] = %d, want %d", i, got, w)
+		}
+	}
+
+	str := StringOf(ctx, []Value{arr})
+	if got, ok := str.(*runtime.StringValue); !ok || got.Value != "Aé" {
+		t.Errorf("StringOf() = %v, want %q", str, "Aé")
+	}
+
+	outOfRange := &runtime.ArrayValue{Elements: []Value{&runtime.IntegerValue{Value: 256}}}
+	if _, ok := StringOf(ctx, []Value{outOfRange}).(*mockErrorValue); !ok {
+		t.Error("StringOf() should fail for values above 255")
+	}
+}
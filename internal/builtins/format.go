@@ -0,0 +1,449 @@
+package builtins
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/cwbudde/go-dws/internal/interp/runtime"
+)
+
+// Delphi-compatible Format implementation.
+//
+// A format specifier has the form
+//
+//	"%" [index ":"] ["-"] [width] ["." precision] type
+//
+// where index, width and precision are either decimal numbers or "*" (taken
+// from the next argument, which must be an Integer), and type is one of
+// d, u, e, f, g, n, m, p, s, x (case-insensitive). "%%" is a literal percent.
+//
+// Specifier errors (an unknown type, a truncated specifier or a missing
+// argument) are reported as *FormatSpecError so Format can raise
+// EConvertError. Like Delphi, unused trailing arguments are ignored. An argument whose type does not fit its specifier is
+// reported as a plain error with DWScript's
+// "Format '%d' invalid or incompatible with argument" message.
+
+// FormatSpecError reports a malformed format string or a specifier without
+// an argument.
+type FormatSpecError struct {
+	Message string
+}
+
+func (e *FormatSpecError) Error() string {
+	return e.Message
+}
+
+// formatSpec is one parsed "%..." specifier. Numeric fields are -1 when
+// absent; the *Star flags mark values to be taken from the argument list.
+type formatSpec struct {
+	raw           string // the specifier as written, e.g. "%-8.2f"
+	verb          byte   // lower-cased type character
+	left          bool   // '-' flag: left-justify within width
+	index         int
+	width         int
+	precision     int
+	indexStar     bool
+	widthStar     bool
+	precisionStar bool
+}
+
+// Default precisions and symbols used by Delphi when none is given.
+const (
+	formatDefaultFixedDigits   = 2  // %f, %n, %m
+	formatDefaultSignificant   = 15 // %e, %g
+	formatMaxSignificantDigits = 18
+	formatCurrencySymbol       = "$"
+)
+
+// FormatValues formats args according to the Delphi format string format.
+// Variant arguments are unwrapped before formatting. Floating-point output
+// uses the decimal and thousand separators of settings.
+func FormatValues(format string, args []Value, settings runtime.FormatSettings) (string, error) {
+	var b strings.Builder
+	argIndex := 0
+
+	nextArg := func(raw string) (Value, error) {
+		if argIndex >= len(args) {
+			return nil, &FormatSpecError{Message: fmt.Sprintf("No argument for format '%s'", raw)}
+		}
+		arg := unwrapFormatArg(args[argIndex])
+		argIndex++
+		return arg, nil
+	}
+
+	// starArg reads a '*' index, width or precision from the argument list.
+	starArg := func(raw string) (int, error) {
+		arg, err := nextArg(raw)
+		if err != nil {
+			return 0, err
+		}
+		intVal, ok := arg.(*runtime.IntegerValue)
+		if !ok {
+			return 0, fmt.Errorf("Format '%s' invalid or incompatible with argument", raw)
+		}
+		return int(intVal.Value), nil
+	}
+
+	i := 0
+	for i < len(format) {
+		ch := format[i]
+		if ch != '%' {
+			b.WriteByte(ch)
+			i++
+			continue
+		}
+		if i+1 < len(format) && format[i+1] == '%' {
+			b.WriteByte('%')
+			i += 2
+			continue
+		}
+
+		spec, next, err := parseFormatSpec(format, i)
+		if err != nil {
+			return "", err
+		}
+		i = next
+
+		// '*' placeholders are consumed in the order they appear.
+		if spec.indexStar {
+			if spec.index, err = starArg(spec.raw); err != nil {
+				return "", err
+			}
+			if spec.index < 0 {
+				return "", &FormatSpecError{Message: fmt.Sprintf("Invalid format specifier '%s'", spec.raw)}
+			}
+		}
+		if spec.index >= 0 {
+			argIndex = spec.index
+		}
+		if spec.widthStar {
+			if spec.width, err = starArg(spec.raw); err != nil {
+				return "", err
+			}
+			if spec.width < 0 {
+				spec.left = true
+				spec.width = -spec.width
+			}
+		}
+		if spec.precisionStar {
+			if spec.precision, err = starArg(spec.raw); err != nil {
+				return "", err
+			}
+		}
+
+		arg, err := nextArg(spec.raw)
+		if err != nil {
+			return "", err
+		}
+		text, err := formatArgument(spec, arg, settings)
+		if err != nil {
+			return "", err
+		}
+		b.WriteString(padFormatted(text, spec.width, spec.left))
+	}
+
+	return b.String(), nil
+}
+
+// parseFormatSpec parses the specifier starting at format[start] == '%' and
+// returns it along with the index just past it.
+func parseFormatSpec(format string, start int) (formatSpec, int, error) {
+	spec := formatSpec{index: -1, width: -1, precision: -1}
+	i := start + 1
+
+	// readNumber reads a decimal number (star == false) or '*' (star == true).
+	readNumber := func() (n int, present, star bool) {
+		if i < len(format) && format[i] == '*' {
+			i++
+			return 0, true, true
+		}
+		begin := i
+		for i < len(format) && format[i] >= '0' && format[i] <= '9' {
+			i++
+		}
+		if i == begin {
+			return 0, false, false
+		}
+		n, _ = strconv.Atoi(format[begin:i])
+		return n, true, false
+	}
+
+	// An optional "index:" prefix; without the colon the number is the width.
+	n, present, star := readNumber()
+	if present && i < len(format) && format[i] == ':' {
+		i++
+		spec.index, spec.indexStar = n, star
+		if star {
+			spec.index = -1
+		}
+		present = false
+	}
+	if !present {
+		if i < len(format) && format[i] == '-' {
+			spec.left = true
+			i++
+		}
+		n, present, star = readNumber()
+	}
+	if present {
+		spec.width, spec.widthStar = n, star
+		if star {
+			spec.width = -1
+		}
+	}
+	if i < len(format) && format[i] == '.' {
+		i++
+		n, _, star = readNumber()
+		spec.precision, spec.precisionStar = n, star
+	}
+
+	end := i
+	if i < len(format) {
+		_, size := utf8.DecodeRuneInString(format[i:])
+		end = i + size
+	}
+	if i < len(format) {
+		switch verb := format[i] | 0x20; verb {
+		case 'd', 'u', 'e', 'f', 'g', 'n', 'm', 'p', 's', 'x':
+			spec.verb = verb
+			spec.raw = format[start:end]
+			return spec, end, nil
+		}
+	}
+	return spec, end, &FormatSpecError{Message: fmt.Sprintf("Invalid format specifier '%s'", format[start:end])}
+}
+
+// unwrapFormatArg unwraps Variant arguments to their underlying value.
+func unwrapFormatArg(value Value) Value {
+	if variant, ok := value.(*runtime.VariantValue); ok {
+		if variant.Value == nil {
+			return &runtime.UnassignedValue{}
+		}
+		return variant.Value
+	}
+	return value
+}
+
+// formatArgument renders one argument for spec, without width padding.
+func formatArgument(spec formatSpec, arg Value, settings runtime.FormatSettings) (string, error) {
+	incompatible := fmt.Errorf("Format '%s' invalid or incompatible with argument", spec.raw)
+
+	switch v := arg.(type) {
+	case *runtime.IntegerValue:
+		switch spec.verb {
+		case 'd':
+			return formatDecimal(v.Value < 0, strconv.FormatUint(absInt64(v.Value), 10), spec.precision), nil
+		case 'u':
+			return formatDecimal(false, strconv.FormatUint(uint64(v.Value), 10), spec.precision), nil
+		case 'x':
+			return zeroPad(strings.ToUpper(strconv.FormatUint(uint64(v.Value), 16)), spec.precision), nil
+		case 'p':
+			return zeroPad(strings.ToUpper(strconv.FormatUint(uint64(v.Value), 16)), 16), nil
+		case 'e', 'f', 'g', 'n', 'm':
+			// Integers are promoted to Float for the floating-point verbs.
+			return formatFloat(spec, float64(v.Value), settings), nil
+		case 's':
+			return truncateRunes(strconv.FormatInt(v.Value, 10), spec.precision), nil
+		}
+	case *runtime.FloatValue:
+		switch spec.verb {
+		case 'e', 'f', 'g', 'n', 'm':
+			return formatFloat(spec, v.Value, settings), nil
+		case 's':
+			return truncateRunes(fmt.Sprintf("%f", v.Value), spec.precision), nil
+		}
+	case *runtime.StringValue:
+		if spec.verb == 's' {
+			return truncateRunes(v.Value, spec.precision), nil
+		}
+	case *runtime.BooleanValue:
+		if spec.verb == 's' {
+			text := "False"
+			if v.Value {
+				text = "True"
+			}
+			return truncateRunes(text, spec.precision), nil
+		}
+	case *runtime.NilValue:
+		if spec.verb == 'p' {
+			return strings.Repeat("0", 16), nil
+		}
+	case *runtime.ObjectInstance:
+		if spec.verb == 'p' {
+			return zeroPad(strings.ToUpper(strings.TrimPrefix(fmt.Sprintf("%p", v), "0x")), 16), nil
+		}
+	}
+	return "", incompatible
+}
+
+// formatFloat renders f for the e, f, g, n and m verbs.
+func formatFloat(spec formatSpec, f float64, settings runtime.FormatSettings) string {
+	switch {
+	case math.IsNaN(f):
+		return "NAN"
+	case math.IsInf(f, 1):
+		return "INF"
+	case math.IsInf(f, -1):
+		return "-INF"
+	}
+	// Clamp tiny magnitudes to zero to avoid "-0.00" artifacts when formatting.
+	if math.Abs(f) < 1e-12 {
+		f = 0
+	}
+
+	switch spec.verb {
+	case 'e':
+		return settings.LocalizeNumber(formatFloatExponent(f, significantDigits(spec.precision)))
+	case 'g':
+		return settings.LocalizeNumber(formatFloatGeneral(f, significantDigits(spec.precision)))
+	case 'n':
+		text := strconv.FormatFloat(f, 'f', fixedDigits(spec.precision), 64)
+		return groupThousands(text, settings)
+	case 'm':
+		text := groupThousands(strconv.FormatFloat(math.Abs(f), 'f', fixedDigits(spec.precision), 64), settings)
+		if f < 0 {
+			return "-" + formatCurrencySymbol + text
+		}
+		return formatCurrencySymbol + text
+	default:
+		return settings.LocalizeNumber(strconv.FormatFloat(f, 'f', fixedDigits(spec.precision), 64))
+	}
+}
+
+// formatFloatExponent renders f as "-d.ddd...E+ddd" with digits significant
+// digits and an exponent of at least three digits.
+func formatFloatExponent(f float64, digits int) string {
+	mantissa, exp := splitExponent(strconv.FormatFloat(f, 'E', digits-1, 64))
+	return mantissa + formatExponent(exp)
+}
+
+// formatFloatGeneral renders f in the shortest of fixed or scientific notation
+// using at most digits significant digits, without trailing zeros.
+func formatFloatGeneral(f float64, digits int) string {
+	if f == 0 {
+		return "0"
+	}
+	mantissa, exp := splitExponent(strconv.FormatFloat(f, 'E', digits-1, 64))
+	if exp >= digits || math.Abs(f) < 0.00001 {
+		return trimFraction(mantissa) + formatExponent(exp)
+	}
+	decimals := digits - 1 - exp
+	if decimals < 0 {
+		decimals = 0
+	}
+	return trimFraction(strconv.FormatFloat(f, 'f', decimals, 64))
+}
+
+// splitExponent splits Go's "E" notation into mantissa and exponent.
+func splitExponent(s string) (string, int) {
+	pos := strings.IndexByte(s, 'E')
+	exp, _ := strconv.Atoi(s[pos+1:])
+	return s[:pos], exp
+}
+
+// formatExponent renders exp as "E+ddd" / "E-ddd".
+func formatExponent(exp int) string {
+	sign := "+"
+	if exp < 0 {
+		sign = "-"
+		exp = -exp
+	}
+	return "E" + sign + zeroPad(strconv.Itoa(exp), 3)
+}
+
+// trimFraction removes trailing zeros (and a trailing point) from a decimal.
+func trimFraction(s string) string {
+	if !strings.Contains(s, ".") {
+		return s
+	}
+	s = strings.TrimRight(s, "0")
+	return strings.TrimSuffix(s, ".")
+}
+
+// groupThousands inserts thousand separators into the integer part of a
+// decimal number and localizes its decimal separator.
+func groupThousands(s string, settings runtime.FormatSettings) string {
+	sign := ""
+	if strings.HasPrefix(s, "-") {
+		sign, s = "-", s[1:]
+	}
+	intPart, frac := s, ""
+	if dot := strings.IndexByte(s, '.'); dot >= 0 {
+		intPart, frac = s[:dot], s[dot:]
+	}
+	var b strings.Builder
+	for i, digit := range intPart {
+		if i > 0 && (len(intPart)-i)%3 == 0 {
+			b.WriteString(settings.ThousandSeparator)
+		}
+		b.WriteRune(digit)
+	}
+	return sign + b.String() + settings.LocalizeNumber(frac)
+}
+
+// formatDecimal renders a sign and digits, zero-padding the digits to precision.
+func formatDecimal(negative bool, digits string, precision int) string {
+	digits = zeroPad(digits, precision)
+	if negative {
+		return "-" + digits
+	}
+	return digits
+}
+
+// zeroPad left-pads s with zeros to at least n characters.
+func zeroPad(s string, n int) string {
+	if len(s) >= n {
+		return s
+	}
+	return strings.Repeat("0", n-len(s)) + s
+}
+
+// padFormatted pads s with spaces to width, on the right when left is set.
+func padFormatted(s string, width int, left bool) string {
+	n := utf8.RuneCountInString(s)
+	if n >= width {
+		return s
+	}
+	if left {
+		return s + strings.Repeat(" ", width-n)
+	}
+	return strings.Repeat(" ", width-n) + s
+}
+
+// truncateRunes limits s to precision characters when precision is set.
+func truncateRunes(s string, precision int) string {
+	if precision < 0 || utf8.RuneCountInString(s) <= precision {
+		return s
+	}
+	return string([]rune(s)[:precision])
+}
+
+func absInt64(v int64) uint64 {
+	if v < 0 {
+		return uint64(-v)
+	}
+	return uint64(v)
+}
+
+func fixedDigits(precision int) int {
+	if precision < 0 {
+		return formatDefaultFixedDigits
+	}
+	return precision
+}
+
+func significantDigits(precision int) int {
+	if precision < 0 {
+		return formatDefaultSignificant
+	}
+	if precision < 1 {
+		return 1
+	}
+	if precision > formatMaxSignificantDigits {
+		return formatMaxSignificantDigits
+	}
+	return precision
+}
@@ -0,0 +1,126 @@
+package builtins
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/cwbudde/go-dws/internal/interp/runtime"
+)
+
+// TestFormatValues mirrors the examples of Delphi's SysUtils.Format documentation.
+func TestFormatValues(t *testing.T) {
+	i := func(v int64) Value { return &runtime.IntegerValue{Value: v} }
+	f := func(v float64) Value { return &runtime.FloatValue{Value: v} }
+	s := func(v string) Value { return &runtime.StringValue{Value: v} }
+
+	tests := []struct {
+		name     string
+		format   string
+		args     []Value
+		expected string
+	}{
+		// Type specifiers
+		{"decimal", "%d", []Value{i(-123)}, "-123"},
+		{"too many arguments", "%d", []Value{i(1), i(2)}, "1"},
+		{"arguments without specifiers", "x", []Value{i(1)}, "x"},
+		{"decimal precision", "%.5d", []Value{i(-123)}, "-00123"},
+		{"unsigned", "%u", []Value{i(-1)}, "18446744073709551615"},
+		{"hex", "%x", []Value{i(255)}, "FF"},
+		{"hex precision", "%.4x", []Value{i(255)}, "00FF"},
+		{"exponent", "%e", []Value{f(1234.5)}, "1.23450000000000E+003"},
+		{"exponent precision", "%.3e", []Value{f(-0.00012345)}, "-1.23E-004"},
+		{"fixed default", "%f", []Value{f(3.14159)}, "3.14"},
+		{"fixed precision", "%.4f", []Value{f(3.14159)}, "3.1416"},
+		{"fixed integer", "%.1f", []Value{i(5)}, "5.0"},
+		{"general", "%g", []Value{f(1234.5)}, "1234.5"},
+		{"general large", "%g", []Value{f(1e20)}, "1E+020"},
+		{"general small", "%g", []Value{f(0.000001)}, "1E-006"},
+		{"general precision", "%.3g", []Value{f(3.14159)}, "3.14"},
+		{"number", "%n", []Value{f(1234567.891)}, "1,234,567.89"},
+		{"number negative", "%.0n", []Value{f(-1234)}, "-1,234"},
+		{"money", "%m", []Value{f(1234.5)}, "$1,234.50"},
+		{"money negative", "%m", []Value{f(-2)}, "-$2.00"},
+		{"pointer", "%p", []Value{&runtime.NilValue{}}, "0000000000000000"},
+		{"string", "%s", []Value{s("abc")}, "abc"},
+		{"string precision", "%.2s", []Value{s("abcdef")}, "ab"},
+		{"boolean", "%s", []Value{&runtime.BooleanValue{Value: true}}, "True"},
+		{"upper-case type", "%D %S", []Value{i(1), s("a")}, "1 a"},
+		{"infinity", "%f", []Value{f(math.Inf(-1))}, "-INF"},
+
+		// Width and the '-' flag
+		{"width", "[%5d]", []Value{i(42)}, "[   42]"},
+		{"left justify", "[%-5d]", []Value{i(42)}, "[42   ]"},
+		{"string width", "[%10s]", []Value{s("abc")}, "[       abc]"},
+		{"string left", "[%-10s]", []Value{s("abc")}, "[abc       ]"},
+		{"width and precision", "[%8.2f]", []Value{f(3.14159)}, "[    3.14]"},
+		{"width shorter than value", "[%2d]", []Value{i(12345)}, "[12345]"},
+
+		// '*' arguments
+		{"star width", "[%*d]", []Value{i(5), i(42)}, "[   42]"},
+		{"star precision", "%.*f", []Value{i(3), f(3.14159)}, "3.142"},
+		{"star width and precision", "[%*.*f]", []Value{i(8), i(2), f(3.14159)}, "[    3.14]"},
+		{"negative star width", "[%*d]", []Value{i(-5), i(42)}, "[42   ]"},
+
+		// Positional index
+		{"index", "%1:s %0:s", []Value{s("World"), s("Hello")}, "Hello World"},
+		{"index then sequential", "%d %d %0:d %d", []Value{i(1), i(2)}, "1 2 1 2"},
+		{"index with width", "[%0:-6s]", []Value{s("ab")}, "[ab    ]"},
+		{"repeated index", "%0:s%0:s", []Value{s("ab")}, "abab"},
+
+		// Literals
+		{"percent", "100%%", nil, "100%"},
+		{"percent after specifier", "%.1f%%", []Value{f(12.34)}, "12.3%"},
+		{"no specifiers", "hello", nil, "hello"},
+		{"variant argument", "%d", []Value{&runtime.VariantValue{Value: i(7)}}, "7"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := FormatValues(tt.format, tt.args, runtime.InvariantFormatSettings())
+			if err != nil {
+				t.Fatalf("FormatValues(%q) error: %v", tt.format, err)
+			}
+			if result != tt.expected {
+				t.Errorf("FormatValues(%q) = %q, want %q", tt.format, result, tt.expected)
+			}
+		})
+	}
+}
+
+// TestFormatValuesErrors verifies that malformed specifiers and argument count
+// mismatches yield *FormatSpecError, while incompatible arguments do not.
+func TestFormatValuesErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		format   string
+		args     []Value
+		specErr  bool
+		expected string
+	}{
+		{"unknown type", "%z", []Value{&runtime.IntegerValue{Value: 1}}, true, "Invalid format specifier '%z'"},
+		{"truncated specifier", "abc %5", nil, true, "Invalid format specifier '%5'"},
+		{"lone percent", "%", nil, true, "Invalid format specifier '%'"},
+		{"missing argument", "%d %d", []Value{&runtime.IntegerValue{Value: 1}}, true, "No argument for format '%d'"},
+		{"index out of range", "%2:d", []Value{&runtime.IntegerValue{Value: 1}}, true, "No argument for format '%2:d'"},
+		{"string for decimal", "%d", []Value{&runtime.StringValue{Value: "x"}}, false, "Format '%d' invalid or incompatible with argument"},
+		{"float for decimal", "%5d", []Value{&runtime.FloatValue{Value: 1.5}}, false, "Format '%5d' invalid or incompatible with argument"},
+		{"non-integer star", "%*d", []Value{&runtime.StringValue{Value: "x"}, &runtime.IntegerValue{Value: 1}}, false, "Format '%*d' invalid or incompatible with argument"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := FormatValues(tt.format, tt.args, runtime.InvariantFormatSettings())
+			if err == nil {
+				t.Fatalf("FormatValues(%q) expected error", tt.format)
+			}
+			var specErr *FormatSpecError
+			if errors.As(err, &specErr) != tt.specErr {
+				t.Errorf("FormatValues(%q) error %T, spec error = %v", tt.format, err, tt.specErr)
+			}
+			if err.Error() != tt.expected {
+				t.Errorf("FormatValues(%q) error = %q, want %q", tt.format, err.Error(), tt.expected)
+			}
+		})
+	}
+}
@@ -2,6 +2,7 @@ package builtins
 
 import (
 	"github.com/cwbudde/go-dws/internal/interp/runtime"
+	"github.com/cwbudde/go-dws/internal/types"
 )
 
 // ============================================================================
@@ -17,6 +18,7 @@ import (
 //   - ToJSONFormatted: Convert value to JSON string (formatted)
 //   - JSONHasField: Check if JSON object has field
 //   - JSONKeys: Get keys of JSON object
+//   - Keys: Get keys of JSON object or associative array
 //   - JSONValues: Get values of JSON object/array
 //   - JSONLength: Get length of JSON array or object
 //
@@ -150,6 +152,28 @@ func JSONKeys(ctx Context, args []Value) Value {
 	return ctx.CreateStringArray(keys)
 }
 
+// Keys returns an array containing the keys of a JSON object or an
+// associative array.
+// Keys(value: Variant): array of String
+// Keys(value: array [K] of E): array of K
+//
+// JSON object keys are returned in insertion order; associative array keys
+// in the same order as its .Keys method. Other values yield an empty array.
+func Keys(ctx Context, args []Value) Value {
+	if len(args) != 1 {
+		return ctx.NewError("Keys() expects exactly 1 argument, got %d", len(args))
+	}
+
+	if assoc, ok := args[0].(*runtime.AssociativeArrayValue); ok {
+		return &runtime.ArrayValue{
+			ArrayType: types.NewDynamicArrayType(assoc.KeyType()),
+			Elements:  assoc.Keys(),
+		}
+	}
+
+	return ctx.CreateStringArray(ctx.JSONGetKeys(args[0]))
+}
+
 // JSONValues returns an array containing all the values of a JSON object or array.
 // JSONValues(obj: Variant): array of Variant
 //
@@ -2,6 +2,7 @@ package builtins
 
 import (
 	"math"
+	"math/rand"
 	"time"
 
 	"github.com/cwbudde/go-dws/internal/interp/runtime"
@@ -24,7 +25,21 @@ func Abs(ctx Context, args []Value) Value {
 	switch v := arg.(type) {
 	case *runtime.IntegerValue:
 		if v.Value < 0 {
-			return &runtime.IntegerValue{Value: -v.Value}
+			result, overflow := runtime.CheckedIntegerNegate(v.Value)
+			if overflow {
+				if checker, ok := ctx.(interface{ IntegerOverflowCheck() bool }); ok && checker.IntegerOverflowCheck() {
+					if raiser, ok := ctx.(interface {
+						RaiseException(className, message string, pos any)
+					}); ok {
+						var pos any
+						if node := ctx.CurrentNode(); node != nil {
+							pos = node.Pos()
+						}
+						raiser.RaiseException("EIntOverflow", "Integer overflow", pos)
+					}
+				}
+			}
+			return &runtime.IntegerValue{Value: result}
 		}
 		return v
 	case *runtime.FloatValue:
@@ -37,6 +52,7 @@ func Abs(ctx Context, args []Value) Value {
 // Min implements the Min() built-in function.
 // It returns the minimum of two numbers.
 // Min(a, b) - supports Integer and Float (mixed allowed)
+// Integer-Integer returns Integer, otherwise Float; a NaN operand yields NaN.
 func Min(ctx Context, args []Value) Value {
 	if len(args) != 2 {
 		return ctx.NewError("Min() expects exactly 2 arguments, got %d", len(args))
@@ -64,27 +80,16 @@ func Min(ctx Context, args []Value) Value {
 		}
 		// Integer-Float (promote to float)
 		if r, ok := right.(*runtime.FloatValue); ok {
-			leftFloat := float64(l.Value)
-			if leftFloat < r.Value {
-				return &runtime.FloatValue{Value: leftFloat}
-			}
-			return r
+			return &runtime.FloatValue{Value: minFloat(float64(l.Value), r.Value)}
 		}
 	case *runtime.FloatValue:
 		// Float-Float
 		if r, ok := right.(*runtime.FloatValue); ok {
-			if l.Value < r.Value {
-				return l
-			}
-			return r
+			return &runtime.FloatValue{Value: minFloat(l.Value, r.Value)}
 		}
 		// Float-Integer (promote integer)
 		if r, ok := right.(*runtime.IntegerValue); ok {
-			rightFloat := float64(r.Value)
-			if l.Value < rightFloat {
-				return l
-			}
-			return &runtime.FloatValue{Value: rightFloat}
+			return &runtime.FloatValue{Value: minFloat(l.Value, float64(r.Value))}
 		}
 	}
 
@@ -94,6 +99,7 @@ func Min(ctx Context, args []Value) Value {
 // Max implements the Max() built-in function.
 // It returns the maximum of two numbers.
 // Max(a, b) - supports Integer and Float (mixed allowed)
+// Integer-Integer returns Integer, otherwise Float; a NaN operand yields NaN.
 func Max(ctx Context, args []Value) Value {
 	if len(args) != 2 {
 		return ctx.NewError("Max() expects exactly 2 arguments, got %d", len(args))
@@ -121,33 +127,48 @@ func Max(ctx Context, args []Value) Value {
 		}
 		// Integer-Float
 		if r, ok := right.(*runtime.FloatValue); ok {
-			leftFloat := float64(l.Value)
-			if leftFloat > r.Value {
-				return &runtime.FloatValue{Value: leftFloat}
-			}
-			return r
+			return &runtime.FloatValue{Value: maxFloat(float64(l.Value), r.Value)}
 		}
 	case *runtime.FloatValue:
 		// Float-Float
 		if r, ok := right.(*runtime.FloatValue); ok {
-			if l.Value > r.Value {
-				return l
-			}
-			return r
+			return &runtime.FloatValue{Value: maxFloat(l.Value, r.Value)}
 		}
 		// Float-Integer
 		if r, ok := right.(*runtime.IntegerValue); ok {
-			rightFloat := float64(r.Value)
-			if l.Value > rightFloat {
-				return l
-			}
-			return &runtime.FloatValue{Value: rightFloat}
+			return &runtime.FloatValue{Value: maxFloat(l.Value, float64(r.Value))}
 		}
 	}
 
 	return ctx.NewError("Max() expects Integer or Float arguments, got %s and %s", left.Type(), right.Type())
 }
 
+// minFloat returns the smaller of l and r, or NaN if either is NaN. Every
+// comparison with NaN is False, as in the < and > operators and Variant
+// comparisons, so choosing an operand by comparing them would make the
+// result depend on the argument order.
+func minFloat(l, r float64) float64 {
+	if math.IsNaN(l) || math.IsNaN(r) {
+		return math.NaN()
+	}
+	if l < r {
+		return l
+	}
+	return r
+}
+
+// maxFloat returns the larger of l and r, or NaN if either is NaN (see
+// minFloat).
+func maxFloat(l, r float64) float64 {
+	if math.IsNaN(l) || math.IsNaN(r) {
+		return math.NaN()
+	}
+	if l > r {
+		return l
+	}
+	return r
+}
+
 // Sqr implements the Sqr() built-in function.
 // It returns the square of a number.
 // Sqr(x) - returns x * x (Integer → Integer, Float → Float)
@@ -594,31 +615,46 @@ func IsInfinite(ctx Context, args []Value) Value {
 
 // =============================================================================
 // Random Number Functions
-// NOTE: These functions require access to the random number generator and seed.
-// The Context interface needs to be extended with:
-//   - RandSource() *rand.Rand (or similar) to access the RNG
-//   - GetRandSeed() int64 to get the current seed
-//   - SetRandSeed(seed int64) to set the seed
 // =============================================================================
+//
+// The random number generator belongs to the running program (see
+// Context.RandSource), so programs running concurrently do not share state.
+// SetRandSeed, or a seed fixed by the host, makes every run draw the same
+// sequence. The sequence comes from Go's math/rand and is specific to go-dws:
+// it does not reproduce the numbers Delphi or DWScript draw for that seed.
 
 // Random implements the Random() built-in function.
-// It returns a random Float between 0.0 (inclusive) and 1.0 (exclusive).
-// Random() - returns random Float in [0, 1)
+// Random() returns a random Float in [0, 1).
+// Random(n) returns a random Integer in [0, n), or 0 when n <= 0.
 func Random(ctx Context, args []Value) Value {
-	if len(args) != 0 {
-		return ctx.NewError("Random() expects no arguments, got %d", len(args))
+	switch len(args) {
+	case 0:
+		return &runtime.FloatValue{Value: ctx.RandSource().Float64()}
+	case 1:
+		intVal, ok := args[0].(*runtime.IntegerValue)
+		if !ok {
+			return ctx.NewError("Random() expects Integer as argument, got %s", args[0].Type())
+		}
+		if intVal.Value <= 0 {
+			return &runtime.IntegerValue{Value: 0}
+		}
+		return &runtime.IntegerValue{Value: randomInRange(ctx.RandSource(), 0, intVal.Value)}
+	default:
+		return ctx.NewError("Random() expects 0 or 1 arguments, got %d", len(args))
 	}
-
-	return &runtime.FloatValue{Value: ctx.RandSource().Float64()}
 }
 
 // Randomize implements the Randomize() built-in procedure.
-// It seeds the random number generator with the current time.
+// It seeds the random number generator with the current time, unless the host
+// fixed the seed, in which case it does nothing so runs stay reproducible.
 // Randomize() - seeds RNG with current time (no return value)
 func Randomize(ctx Context, args []Value) Value {
 	if len(args) != 0 {
 		return ctx.NewError("Randomize() expects no arguments, got %d", len(args))
 	}
+	if ctx.RandomSeedFixed() {
+		return &runtime.NilValue{}
+	}
 
 	seed := time.Now().UnixNano()
 	ctx.SetRandSeed(seed)
@@ -626,30 +662,51 @@ func Randomize(ctx Context, args []Value) Value {
 }
 
 // RandomInt implements the RandomInt() built-in function.
-// It returns a random integer between 0 (inclusive) and max (exclusive).
-// RandomInt(max) - max must be positive
+// RandomInt(max) returns a random Integer in [0, max); max must be positive.
+// RandomInt(min, max) returns a random Integer in [min, max); max must be
+// greater than min.
 func RandomInt(ctx Context, args []Value) Value {
-	if len(args) != 1 {
-		return ctx.NewError("RandomInt() expects exactly 1 argument, got %d", len(args))
+	if len(args) != 1 && len(args) != 2 {
+		return ctx.NewError("RandomInt() expects 1 or 2 arguments, got %d", len(args))
 	}
 
-	arg := args[0]
-
-	// Only accept Integer argument
-	intVal, ok := arg.(*runtime.IntegerValue)
-	if !ok {
-		return ctx.NewError("RandomInt() expects Integer as argument, got %s", arg.Type())
+	bounds := make([]int64, len(args))
+	for i, arg := range args {
+		intVal, ok := arg.(*runtime.IntegerValue)
+		if !ok {
+			return ctx.NewError("RandomInt() expects Integer as argument, got %s", arg.Type())
+		}
+		bounds[i] = intVal.Value
 	}
 
-	max := intVal.Value
+	if len(bounds) == 1 {
+		if bounds[0] <= 0 {
+			return ctx.NewError("RandomInt() expects max > 0, got %d", bounds[0])
+		}
+		return &runtime.IntegerValue{Value: randomInRange(ctx.RandSource(), 0, bounds[0])}
+	}
 
-	// Validate max > 0
-	if max <= 0 {
-		return ctx.NewError("RandomInt() expects max > 0, got %d", max)
+	if bounds[1] <= bounds[0] {
+		return ctx.NewError("RandomInt() expects max > min, got %d and %d", bounds[0], bounds[1])
 	}
+	return &runtime.IntegerValue{Value: randomInRange(ctx.RandSource(), bounds[0], bounds[1])}
+}
 
-	randomValue := ctx.RandSource().Intn(int(max))
-	return &runtime.IntegerValue{Value: int64(randomValue)}
+// randomInRange returns a random integer in [lo, hi), which must not be
+// empty. Ranges that fit an int draw with rng.Intn, so RandomInt(n),
+// RandomInt(0, n) and Random(n) produce the same sequence.
+func randomInRange(rng *rand.Rand, lo, hi int64) int64 {
+	// The wrapping subtraction yields the exact span even when hi - lo
+	// overflows int64.
+	span := uint64(hi) - uint64(lo)
+	if span <= math.MaxInt {
+		return lo + int64(rng.Intn(int(span)))
+	}
+	for {
+		if v := rng.Uint64(); v < span {
+			return int64(uint64(lo) + v)
+		}
+	}
 }
 
 // SetRandSeed implements the SetRandSeed() built-in function.
@@ -682,12 +739,29 @@ func RandSeed(ctx Context, args []Value) Value {
 }
 
 // RandG implements the RandG() built-in function.
-// It returns a Gaussian (normal) distributed random number with mean=0 and stddev=1.
-// Uses the Box-Muller transform.
-// RandG(): Float
+// It returns a Gaussian (normal) distributed random number, using the
+// Box-Muller transform.
+// RandG(): Float - mean 0, standard deviation 1
+// RandG(mean, stdDev: Float): Float
 func RandG(ctx Context, args []Value) Value {
-	if len(args) != 0 {
-		return ctx.NewError("RandG() expects no arguments, got %d", len(args))
+	mean, stdDev := 0.0, 1.0
+	switch len(args) {
+	case 0:
+	case 2:
+		params := make([]float64, 2)
+		for i, arg := range args {
+			switch v := ctx.UnwrapVariant(arg).(type) {
+			case *runtime.FloatValue:
+				params[i] = v.Value
+			case *runtime.IntegerValue:
+				params[i] = float64(v.Value)
+			default:
+				return ctx.NewError("RandG() expects Float as argument, got %s", arg.Type())
+			}
+		}
+		mean, stdDev = params[0], params[1]
+	default:
+		return ctx.NewError("RandG() expects 0 or 2 arguments, got %d", len(args))
 	}
 
 	// Box-Muller transform to generate Gaussian distributed random numbers
@@ -704,5 +778,5 @@ func RandG(ctx Context, args []Value) Value {
 	// Box-Muller transform
 	z0 := math.Sqrt(-2.0*math.Log(u1)) * math.Cos(2.0*math.Pi*u2)
 
-	return &runtime.FloatValue{Value: z0}
+	return &runtime.FloatValue{Value: mean + z0*stdDev}
 }
@@ -2,6 +2,7 @@ package builtins
 
 import (
 	"math"
+	"math/rand"
 	"testing"
 
 	"github.com/cwbudde/go-dws/internal/interp/runtime"
@@ -283,6 +284,22 @@ func TestMax(t *testing.T) {
 	}
 }
 
+func TestMinMaxNaN(t *testing.T) {
+	ctx := newMockContext()
+	nan := &runtime.FloatValue{Value: math.NaN()}
+	one := &runtime.IntegerValue{Value: 1}
+	half := &runtime.FloatValue{Value: 0.5}
+
+	for _, args := range [][]Value{{nan, one}, {one, nan}, {nan, half}, {half, nan}, {nan, nan}} {
+		for name, fn := range map[string]BuiltinFunc{"Min": Min, "Max": Max} {
+			result, ok := fn(ctx, args).(*runtime.FloatValue)
+			if !ok || !math.IsNaN(result.Value) {
+				t.Errorf("%s(%v, %v) = %v, want NaN", name, args[0], args[1], result)
+			}
+		}
+	}
+}
+
 func TestSqr(t *testing.T) {
 	ctx := newMockContext()
 
@@ -1211,3 +1228,45 @@ func TestIsInfinite(t *testing.T) {
 		})
 	}
 }
+
+func TestRandomInRange(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	tests := []struct {
+		name   string
+		lo, hi int64
+	}{
+		{"single value", 7, 8},
+		{"negative range", -10, -5},
+		{"around zero", -3, 3},
+		{"full Integer range", math.MinInt64, math.MaxInt64},
+		{"wider than MaxInt64", -1, math.MaxInt64},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for i := 0; i < 100; i++ {
+				if v := randomInRange(rng, tt.lo, tt.hi); v < tt.lo || v >= tt.hi {
+					t.Fatalf("randomInRange(%d, %d) = %d, out of range", tt.lo, tt.hi, v)
+				}
+			}
+		})
+	}
+
+	// RandomInt(n), RandomInt(0, n) and Random(n) draw the same sequence
+	ctx := newMockContext()
+	ctx.SetRandSeed(99)
+	first := []Value{
+		RandomInt(ctx, []Value{&runtime.IntegerValue{Value: 1000}}),
+		RandomInt(ctx, []Value{&runtime.IntegerValue{Value: 1000}}),
+	}
+	ctx.SetRandSeed(99)
+	second := []Value{
+		RandomInt(ctx, []Value{&runtime.IntegerValue{Value: 0}, &runtime.IntegerValue{Value: 1000}}),
+		Random(ctx, []Value{&runtime.IntegerValue{Value: 1000}}),
+	}
+	for i := range first {
+		if first[i].String() != second[i].String() {
+			t.Errorf("draw %d: %s and %s differ", i, first[i], second[i])
+		}
+	}
+}
@@ -1,6 +1,7 @@
 package builtins
 
 import (
+	"fmt"
 	"math"
 
 	"github.com/cwbudde/go-dws/internal/interp/runtime"
@@ -84,8 +85,7 @@ func Round(ctx Context, args []Value) Value {
 
 	// Round to nearest integer using banker's rounding (round-half-to-even)
 	// DWScript uses banker's rounding: 16.5 → 16, 17.5 → 18
-	rounded := math.RoundToEven(value)
-	return &runtime.IntegerValue{Value: int64(rounded)}
+	return floatToInteger(ctx, "Round", math.RoundToEven(value))
 }
 
 // Trunc implements the Trunc() built-in function.
@@ -109,9 +109,7 @@ func Trunc(ctx Context, args []Value) Value {
 		return ctx.NewError("Trunc() expects Integer or Float as argument, got %s", arg.Type())
 	}
 
-	// Truncate toward zero
-	truncated := math.Trunc(value)
-	return &runtime.IntegerValue{Value: int64(truncated)}
+	return floatToInteger(ctx, "Trunc", math.Trunc(value))
 }
 
 // Ceil implements the Ceil() built-in function.
@@ -135,9 +133,7 @@ func Ceil(ctx Context, args []Value) Value {
 		return ctx.NewError("Ceil() expects Integer or Float as argument, got %s", arg.Type())
 	}
 
-	// Ceil returns float64 - convert to integer
-	ceiling := math.Ceil(value)
-	return &runtime.IntegerValue{Value: int64(ceiling)}
+	return floatToInteger(ctx, "Ceil", math.Ceil(value))
 }
 
 // Floor implements the Floor() built-in function.
@@ -161,9 +157,28 @@ func Floor(ctx Context, args []Value) Value {
 		return ctx.NewError("Floor() expects Integer or Float as argument, got %s", arg.Type())
 	}
 
-	// Floor returns float64 - convert to integer
-	floor := math.Floor(value)
-	return &runtime.IntegerValue{Value: int64(floor)}
+	return floatToInteger(ctx, "Floor", math.Floor(value))
+}
+
+// floatToInteger converts the integral Float result of Round, Trunc, Ceil or
+// Floor to an Integer. NaN, infinities and values outside the Integer range
+// raise EInvalidOp instead of wrapping to an arbitrary Integer.
+func floatToInteger(ctx Context, name string, value float64) Value {
+	if !math.IsNaN(value) && value >= math.MinInt64 && value < math.MaxInt64 {
+		return &runtime.IntegerValue{Value: int64(value)}
+	}
+	msg := fmt.Sprintf("%s() cannot convert %s to Integer", name, (&runtime.FloatValue{Value: value}).String())
+	if raiser, ok := ctx.(interface {
+		RaiseException(className, message string, pos any)
+	}); ok {
+		var pos any
+		if node := ctx.CurrentNode(); node != nil {
+			pos = node.Pos()
+		}
+		raiser.RaiseException("EInvalidOp", msg, pos)
+		return &runtime.IntegerValue{}
+	}
+	return ctx.NewError("%s", msg)
 }
 
 // ClampInt implements the ClampInt() built-in function.
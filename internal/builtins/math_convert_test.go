@@ -263,6 +263,25 @@ func TestFloor(t *testing.T) {
 	}
 }
 
+func TestFloatToIntegerOutOfRange(t *testing.T) {
+	ctx := newMockContext()
+	funcs := map[string]BuiltinFunc{"Round": Round, "Trunc": Trunc, "Ceil": Ceil, "Floor": Floor}
+
+	for name, fn := range funcs {
+		for _, value := range []float64{math.NaN(), math.Inf(1), math.Inf(-1), 1e19, -1e19} {
+			result := fn(ctx, []Value{&runtime.FloatValue{Value: value}})
+			if result.Type() != "ERROR" {
+				t.Errorf("%s(%v) = %v, want error", name, value, result)
+			}
+		}
+
+		result := fn(ctx, []Value{&runtime.FloatValue{Value: -9.2e18}})
+		if !valuesEqual(result, &runtime.IntegerValue{Value: -9200000000000000000}) {
+			t.Errorf("%s(-9.2e18) = %v, want -9200000000000000000", name, result)
+		}
+	}
+}
+
 func TestClampInt(t *testing.T) {
 	ctx := newMockContext()
 
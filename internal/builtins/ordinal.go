@@ -27,6 +27,7 @@ import (
 //   - Enum: returns the ordinal value (position) of the enum member
 //   - Boolean: False → 0, True → 1
 //   - Integer: returns the value unchanged
+//   - Subrange: returns the underlying Integer value
 //   - String (character): returns Unicode code point of first character
 //   - Empty string: returns 0
 //
@@ -59,6 +60,11 @@ func Ord(ctx Context, args []Value) Value {
 		return intVal
 	}
 
+	// Handle subrange values (their Integer value)
+	if subrange, ok := arg.(*runtime.SubrangeValue); ok {
+		return &runtime.IntegerValue{Value: int64(subrange.Value)}
+	}
+
 	// Handle string values (characters)
 	// In DWScript, character literals are single-character strings
 	if strVal, ok := arg.(*runtime.StringValue); ok {
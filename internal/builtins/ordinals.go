@@ -1,6 +1,9 @@
 package builtins
 
 import (
+	"fmt"
+	"unicode"
+
 	"github.com/cwbudde/go-dws/internal/interp/runtime"
 	"github.com/cwbudde/go-dws/internal/types"
 )
@@ -35,74 +38,61 @@ func findEnumPositionForContext(ctx Context, enumType *types.EnumType, val *runt
 }
 
 // Succ implements the Succ() built-in function.
-// It returns the successor of an ordinal value (Integer or Enum).
+// It returns the successor of an ordinal value: Integer (and subrange) values
+// are incremented, enums and Booleans move to the next member, and a
+// single-character String moves to the next code point.
 func Succ(ctx Context, args []Value) Value {
 	if len(args) != 1 {
 		return ctx.NewError("Succ() expects exactly 1 argument, got %d", len(args))
 	}
-
-	arg := args[0]
-
-	switch val := arg.(type) {
-	case *runtime.IntegerValue:
-		return &runtime.IntegerValue{Value: val.Value + 1}
-
-	case *runtime.EnumValue:
-		return succEnumValue(ctx, val)
-
-	default:
-		return ctx.NewError("Succ() expects Integer or Enum, got %s", arg.Type())
-	}
-}
-
-// succEnumValue computes the successor enum value for Succ().
-func succEnumValue(ctx Context, val *runtime.EnumValue) Value {
-	enumType, errVal := getEnumTypeForContext(ctx, val)
-	if errVal != nil {
-		return errVal
-	}
-
-	currentPos, errVal := findEnumPositionForContext(ctx, enumType, val)
-	if errVal != nil {
-		return errVal
-	}
-
-	if currentPos >= len(enumType.OrderedNames)-1 {
-		return ctx.NewError("Succ() cannot get successor of maximum enum value")
-	}
-
-	nextValueName := enumType.OrderedNames[currentPos+1]
-	nextOrdinal := enumType.Values[nextValueName]
-	return &runtime.EnumValue{
-		TypeName:     val.TypeName,
-		ValueName:    nextValueName,
-		OrdinalValue: nextOrdinal,
-	}
+	return stepOrdinal(ctx, "Succ", args[0], 1)
 }
 
 // Pred implements the Pred() built-in function.
-// It returns the predecessor of an ordinal value (Integer or Enum).
+// It returns the predecessor of an ordinal value: Integer (and subrange)
+// values are decremented, enums and Booleans move to the previous member, and
+// a single-character String moves to the previous code point.
 func Pred(ctx Context, args []Value) Value {
 	if len(args) != 1 {
 		return ctx.NewError("Pred() expects exactly 1 argument, got %d", len(args))
 	}
+	return stepOrdinal(ctx, "Pred", args[0], -1)
+}
 
-	arg := args[0]
-
+// stepOrdinal moves an ordinal value by delta (+1 for Succ, -1 for Pred).
+func stepOrdinal(ctx Context, name string, arg Value, delta int) Value {
 	switch val := arg.(type) {
 	case *runtime.IntegerValue:
-		return &runtime.IntegerValue{Value: val.Value - 1}
+		return &runtime.IntegerValue{Value: val.Value + int64(delta)}
+
+	case *runtime.SubrangeValue:
+		return &runtime.IntegerValue{Value: int64(val.Value + delta)}
 
 	case *runtime.EnumValue:
-		return predEnumValue(ctx, val)
+		return stepEnumValue(ctx, name, val, delta)
 
-	default:
-		return ctx.NewError("Pred() expects Integer or Enum, got %s", arg.Type())
+	case *runtime.BooleanValue:
+		if val.Value == (delta > 0) {
+			return ordinalBoundError(ctx, name, "Boolean")
+		}
+		return &runtime.BooleanValue{Value: delta > 0}
+
+	case *runtime.StringValue:
+		runes := []rune(val.Value)
+		if len(runes) != 1 {
+			break
+		}
+		code := runes[0] + rune(delta)
+		if code < 0 || code > unicode.MaxRune {
+			return ordinalBoundError(ctx, name, "Char")
+		}
+		return &runtime.StringValue{Value: string(code)}
 	}
+	return ctx.NewError("%s() expects Integer or Enum, got %s", name, arg.Type())
 }
 
-// predEnumValue computes the predecessor enum value for Pred().
-func predEnumValue(ctx Context, val *runtime.EnumValue) Value {
+// stepEnumValue computes the adjacent enum value for Succ()/Pred().
+func stepEnumValue(ctx Context, name string, val *runtime.EnumValue, delta int) Value {
 	enumType, errVal := getEnumTypeForContext(ctx, val)
 	if errVal != nil {
 		return errVal
@@ -113,15 +103,38 @@ func predEnumValue(ctx Context, val *runtime.EnumValue) Value {
 		return errVal
 	}
 
-	if currentPos <= 0 {
-		return ctx.NewError("Pred() cannot get predecessor of minimum enum value")
+	nextPos := currentPos + delta
+	if nextPos < 0 || nextPos >= len(enumType.OrderedNames) {
+		return ordinalBoundError(ctx, name, "enum")
 	}
 
-	prevValueName := enumType.OrderedNames[currentPos-1]
-	prevOrdinal := enumType.Values[prevValueName]
+	nextValueName := enumType.OrderedNames[nextPos]
 	return &runtime.EnumValue{
 		TypeName:     val.TypeName,
-		ValueName:    prevValueName,
-		OrdinalValue: prevOrdinal,
+		ValueName:    nextValueName,
+		OrdinalValue: enumType.Values[nextValueName],
+	}
+}
+
+// ordinalBoundError reports Succ() past the last or Pred() before the first
+// value of an ordinal type. With range checking enabled this raises a
+// catchable ERangeError; otherwise it is a runtime error.
+func ordinalBoundError(ctx Context, name, kind string) Value {
+	msg := fmt.Sprintf("%s() cannot get successor of maximum %s value", name, kind)
+	if name == "Pred" {
+		msg = fmt.Sprintf("%s() cannot get predecessor of minimum %s value", name, kind)
+	}
+	if checker, ok := ctx.(interface{ RangeChecksEnabled() bool }); ok && checker.RangeChecksEnabled() {
+		if raiser, ok := ctx.(interface {
+			RaiseException(className, message string, pos any)
+		}); ok {
+			var pos any
+			if node := ctx.CurrentNode(); node != nil {
+				pos = node.Pos()
+			}
+			raiser.RaiseException("ERangeError", msg, pos)
+			return &runtime.IntegerValue{}
+		}
 	}
+	return ctx.NewError("%s", msg)
 }
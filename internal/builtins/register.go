@@ -168,10 +168,10 @@ func RegisterMathFunctions(r *Registry) {
 		Sig([]types.Type{F}, F))
 
 	// Random number functions
-	r.RegisterWithSignature("Random", Random, CategoryMath, "Returns a random float between 0 and 1",
-		Sig(nil, F))
+	r.RegisterWithSignature("Random", Random, CategoryMath, "Returns a random float between 0 and 1, or a random integer below n",
+		SigOptional([]types.Type{I}, F, 0)) // Random(n) returns Integer, see the semantic analyzer
 	r.RegisterWithSignature("RandomInt", RandomInt, CategoryMath, "Returns a random integer in range",
-		Sig([]types.Type{I}, I))
+		SigOptional([]types.Type{I, I}, I, 1))
 	r.RegisterWithSignature("Randomize", Randomize, CategoryMath, "Seeds the random number generator",
 		Sig(nil, nil)) // Procedure
 	r.RegisterWithSignature("SetRandSeed", SetRandSeed, CategoryMath, "Sets the random number seed",
@@ -179,7 +179,7 @@ func RegisterMathFunctions(r *Registry) {
 	r.RegisterWithSignature("RandSeed", RandSeed, CategoryMath, "Returns the current random seed",
 		Sig(nil, I))
 	r.RegisterWithSignature("RandG", RandG, CategoryMath, "Returns a random Gaussian value",
-		Sig([]types.Type{F, F}, F))
+		SigOptional([]types.Type{F, F}, F, 0))
 }
 
 // RegisterStringFunctions registers all string manipulation built-in functions.
@@ -465,6 +465,8 @@ func RegisterConversionFunctions(r *Registry) {
 		Sig([]types.Type{S}, F))
 	r.RegisterWithSignature("FloatToStr", FloatToStr, CategoryConversion, "Converts float to string",
 		SigOptional([]types.Type{F, I}, S, 1)) // Optional precision
+	r.RegisterWithSignature("FloatToStrF", FloatToStrF, CategoryConversion, "Converts float to string using a float format",
+		SigOptional([]types.Type{F, I, I, I}, S, 2)) // Optional precision and digits
 	r.RegisterWithSignature("BoolToStr", BoolToStr, CategoryConversion, "Converts boolean to string",
 		Sig([]types.Type{B}, S))
 
@@ -500,6 +502,14 @@ func RegisterEncodingFunctions(r *Registry) {
 		Sig([]types.Type{S}, S))
 	r.RegisterWithSignature("StrToXML", StrToXML, CategoryEncoding, "Encodes string for XML",
 		Sig([]types.Type{S}, S))
+	r.RegisterWithSignature("UTF8Encode", UTF8Encode, CategoryEncoding, "Encodes string as UTF-8 bytes, one character per byte",
+		Sig([]types.Type{S}, S))
+	r.RegisterWithSignature("UTF8Decode", UTF8Decode, CategoryEncoding, "Decodes a string of UTF-8 bytes",
+		Sig([]types.Type{S}, S))
+	r.RegisterWithSignature("BytesOf", BytesOf, CategoryEncoding, "Returns the UTF-8 bytes of a string",
+		Sig([]types.Type{S}, types.NewDynamicArrayType(types.BYTE)))
+	r.RegisterWithSignature("StringOf", StringOf, CategoryEncoding, "Decodes an array of UTF-8 bytes to a string",
+		Sig([]types.Type{types.NewDynamicArrayType(types.BYTE)}, S))
 }
 
 // RegisterJSONFunctions registers all JSON manipulation built-in functions.
@@ -519,6 +529,8 @@ func RegisterJSONFunctions(r *Registry) {
 		Sig([]types.Type{V, S}, B))
 	r.RegisterWithSignature("JSONKeys", JSONKeys, CategoryJSON, "Returns keys of JSON object",
 		Sig([]types.Type{V}, V)) // Returns array of string
+	r.RegisterWithSignature("Keys", Keys, CategoryJSON, "Returns keys of JSON object or associative array",
+		Sig([]types.Type{V}, V)) // Returns array of key type
 	r.RegisterWithSignature("JSONValues", JSONValues, CategoryJSON, "Returns values of JSON object/array",
 		Sig([]types.Type{V}, V)) // Returns array
 	r.RegisterWithSignature("JSONLength", JSONLength, CategoryJSON, "Returns length of JSON array/object",
@@ -529,10 +541,14 @@ func RegisterJSONFunctions(r *Registry) {
 func RegisterTypeFunctions(r *Registry) {
 	S := types.STRING
 	V := types.VARIANT
-	r.RegisterWithSignature("TypeOf", TypeOf, CategoryType, "Returns the type name of a value",
+	r.RegisterWithSignature("TypeOf", TypeOf, CategoryType, "Returns the runtime type information of a value",
+		Sig([]types.Type{V}, types.RTTI_TYPEINFO))
+	r.RegisterWithSignature("TypeName", TypeName, CategoryType, "Returns the type name of a value",
 		Sig([]types.Type{V}, S))
 	r.RegisterWithSignature("TypeOfClass", TypeOfClass, CategoryType, "Returns the class name of an object",
 		Sig([]types.Type{V}, S))
+	r.RegisterWithSignature("GetClass", GetClass, CategoryType, "Returns the runtime class of an object",
+		Sig([]types.Type{V}, types.NewClassOfType(types.NewClassType("TObject", nil))))
 }
 
 // RegisterIOFunctions registers all I/O built-in functions.
@@ -176,6 +176,45 @@ func (r *Registry) RegisterWithSignature(name string, fn BuiltinFunc, category C
 	r.categories[category] = append(r.categories[category], name)
 }
 
+// Unregister removes a built-in function from the registry (case-insensitive).
+// Returns true if the function was registered.
+func (r *Registry) Unregister(name string) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	info, ok := r.functions.Get(name)
+	if !ok {
+		return false
+	}
+	r.functions.Delete(name)
+
+	names := r.categories[info.Category]
+	for i, registered := range names {
+		if ident.Equal(registered, name) {
+			r.categories[info.Category] = append(names[:i:i], names[i+1:]...)
+			break
+		}
+	}
+	return true
+}
+
+// Clone returns an independent copy of the registry. Registering or
+// unregistering functions in the copy does not affect r, so an engine can
+// customize its builtins without touching DefaultRegistry.
+func (r *Registry) Clone() *Registry {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	categories := make(map[Category][]string, len(r.categories))
+	for category, names := range r.categories {
+		categories[category] = append([]string(nil), names...)
+	}
+	return &Registry{
+		functions:  r.functions.Clone(),
+		categories: categories,
+	}
+}
+
 // RegisterBatch registers multiple functions at once.
 // Each entry in the batch is a tuple of (name, function, category, description).
 func (r *Registry) RegisterBatch(entries []struct {
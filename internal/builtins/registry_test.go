@@ -5,6 +5,7 @@ import (
 	"math/rand"
 	"strconv"
 	"testing"
+	"time"
 
 	"github.com/cwbudde/go-dws/internal/interp/runtime"
 	"github.com/cwbudde/go-dws/pkg/ast"
@@ -20,15 +21,17 @@ func (e *mockErrorValue) String() string { return "ERROR: " + e.Message }
 
 // mockContext implements the Context interface for testing
 type mockContext struct {
-	rng       *rand.Rand
-	lastError string
-	randSeed  int64
+	rng            *rand.Rand
+	lastError      string
+	randSeed       int64
+	formatSettings runtime.FormatSettings
 }
 
 func newMockContext() *mockContext {
 	return &mockContext{
-		randSeed: 0,
-		rng:      rand.New(rand.NewSource(0)),
+		randSeed:       0,
+		rng:            rand.New(rand.NewSource(0)),
+		formatSettings: runtime.InvariantFormatSettings(),
 	}
 }
 
@@ -54,6 +57,18 @@ func (m *mockContext) SetRandSeed(seed int64) {
 	m.rng = rand.New(rand.NewSource(seed))
 }
 
+func (m *mockContext) RandomSeedFixed() bool {
+	return false
+}
+
+func (m *mockContext) FormatSettings() runtime.FormatSettings {
+	return m.formatSettings
+}
+
+func (m *mockContext) CurrentTime() time.Time {
+	return time.Now()
+}
+
 func (m *mockContext) UnwrapVariant(value Value) Value {
 	return value
 }
@@ -256,72 +271,7 @@ func (m *mockContext) ParseFloat(s string) (float64, bool) {
 }
 
 func (m *mockContext) FormatString(format string, args []Value) (string, error) {
-	// Minimal parser to mirror interpreter FormatString behavior for tests.
-	type formatSpec struct {
-		verb rune
-	}
-	var specs []formatSpec
-	i := 0
-	for i < len(format) {
-		ch := format[i]
-		if ch == '%' {
-			if i+1 < len(format) && format[i+1] == '%' {
-				i += 2
-				continue
-			}
-			i++
-			for i < len(format) {
-				b := format[i]
-				if (b >= '0' && b <= '9') || b == '.' || b == '+' || b == '-' || b == ' ' || b == '#' {
-					i++
-					continue
-				}
-				break
-			}
-			if i < len(format) {
-				verb := rune(format[i])
-				if verb == 's' || verb == 'd' || verb == 'f' || verb == 'v' || verb == 'x' || verb == 'X' || verb == 'o' {
-					specs = append(specs, formatSpec{verb: verb})
-				}
-				i++
-			}
-		} else {
-			i++
-		}
-	}
-
-	if len(specs) != len(args) {
-		return "", fmt.Errorf("expects %d arguments for format string, got %d", len(specs), len(args))
-	}
-
-	goArgs := make([]interface{}, len(args))
-	for idx, arg := range args {
-		if idx >= len(specs) {
-			break
-		}
-		spec := specs[idx]
-		switch v := arg.(type) {
-		case *runtime.IntegerValue:
-			switch spec.verb {
-			case 'f':
-				goArgs[idx] = float64(v.Value)
-			case 's':
-				goArgs[idx] = fmt.Sprintf("%d", v.Value)
-			default:
-				goArgs[idx] = v.Value
-			}
-		case *runtime.FloatValue:
-			goArgs[idx] = v.Value
-		case *runtime.StringValue:
-			goArgs[idx] = v.Value
-		case *runtime.BooleanValue:
-			goArgs[idx] = v.Value
-		default:
-			goArgs[idx] = arg.String()
-		}
-	}
-
-	return fmt.Sprintf(format, goArgs...), nil
+	return FormatValues(format, args, m.formatSettings)
 }
 
 // GetLowBound returns the low bound of a value.
@@ -548,6 +498,55 @@ func TestClear(t *testing.T) {
 	}
 }
 
+func TestUnregister(t *testing.T) {
+	r := NewRegistry()
+
+	mockFunc := func(ctx Context, args []Value) Value {
+		return &runtime.IntegerValue{Value: 0}
+	}
+
+	r.Register("Test1", mockFunc, CategoryMath, "Test")
+	r.Register("Test2", mockFunc, CategoryMath, "Test")
+
+	if !r.Unregister("TEST1") {
+		t.Error("Unregister should report a registered function")
+	}
+	if r.Has("Test1") {
+		t.Error("Function should not exist after unregister")
+	}
+	if r.CategoryCount(CategoryMath) != 1 {
+		t.Errorf("Expected 1 math function after unregister, got %d", r.CategoryCount(CategoryMath))
+	}
+	if r.Unregister("Test1") {
+		t.Error("Unregister should report an unknown function")
+	}
+}
+
+func TestClone(t *testing.T) {
+	r := NewRegistry()
+
+	mockFunc := func(ctx Context, args []Value) Value {
+		return &runtime.IntegerValue{Value: 0}
+	}
+
+	r.Register("Test1", mockFunc, CategoryMath, "Test")
+	r.Register("Test2", mockFunc, CategoryString, "Test")
+
+	clone := r.Clone()
+	clone.Unregister("Test1")
+	clone.Register("Test3", mockFunc, CategoryString, "Test")
+
+	if !r.Has("Test1") || r.Has("Test3") {
+		t.Error("Changes to the clone should not affect the original")
+	}
+	if r.CategoryCount(CategoryString) != 1 {
+		t.Errorf("Expected 1 string function in the original, got %d", r.CategoryCount(CategoryString))
+	}
+	if clone.Has("Test1") || !clone.Has("Test2") || !clone.Has("Test3") {
+		t.Error("Clone should reflect its own changes")
+	}
+}
+
 func TestDefaultRegistry(t *testing.T) {
 	if DefaultRegistry == nil {
 		t.Fatal("DefaultRegistry is nil")
@@ -1,6 +1,7 @@
 package builtins
 
 import (
+	"errors"
 	"fmt"
 	"strings"
 
@@ -265,8 +266,8 @@ func StrToFloatDef(ctx Context, args []Value) Value {
 		return ctx.NewError("StrToFloatDef() expects float as second argument, got %T", args[1])
 	}
 
-	// Try to parse the string using Context helper
-	floatValue, ok := ctx.ParseFloat(strVal.Value)
+	// Parse with the configured decimal separator
+	floatValue, ok := ctx.FormatSettings().ParseFloat(strVal.Value)
 	if !ok {
 		// Return the default value on error
 		return &runtime.FloatValue{Value: defaultVal.Value}
@@ -281,14 +282,20 @@ func StrToFloatDef(ctx Context, args []Value) Value {
 //
 // Signature: Format(formatStr: String, args: array of const) -> String
 //
-// Supports: %s (string), %d (integer), %f (float), %% (literal %)
-// Optional: width and precision (%5d, %.2f, %8.2f)
+// Follows Delphi's SysUtils.Format: specifiers have the form
+// %[index:][-][width][.precision]type with type one of d, u, e, f, g, n, m,
+// p, s, x; width, precision and index may be '*' to take them from the
+// argument list. %% is a literal percent. See FormatValues for details.
+//
+// A malformed specifier or a missing/extra argument raises EConvertError;
+// an argument incompatible with its specifier raises EDelphi.
 //
 // Example:
 //
 //	var name := 'World';
 //	var count := 42;
 //	PrintLn(Format('Hello %s! Count: %d', [name, count]));
+//	PrintLn(Format('%1:s %0:s', ['World', 'Hello']));
 func Format(ctx Context, args []Value) Value {
 	// Expect exactly 2 arguments: format string and array of values
 	if len(args) != 2 {
@@ -315,8 +322,14 @@ func Format(ctx Context, args []Value) Value {
 		// verb/argument incompatibilities ("Format '%d' invalid or incompatible
 		// with argument"); pass it through. Other errors fall back to the generic
 		// wording.
+		// Malformed specifiers and argument count mismatches raise EConvertError.
+		className := "EDelphi"
 		baseMsg := "Format invalid or incompatible with argument"
-		if strings.HasPrefix(err.Error(), "Format ") {
+		var specErr *FormatSpecError
+		if errors.As(err, &specErr) {
+			className = "EConvertError"
+			baseMsg = specErr.Message
+		} else if strings.HasPrefix(err.Error(), "Format ") {
 			baseMsg = err.Error()
 		}
 		msg := baseMsg
@@ -334,9 +347,9 @@ func Format(ctx Context, args []Value) Value {
 		if raiser, ok := ctx.(interface {
 			RaiseException(className, message string, pos any)
 		}); ok {
-			raiser.RaiseException("EDelphi", msg, pos)
+			raiser.RaiseException(className, msg, pos)
 		}
-		return ctx.NewError("EDelphi: " + msg)
+		return ctx.NewError(className + ": " + msg)
 	}
 
 	return &runtime.StringValue{Value: result}
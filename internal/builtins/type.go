@@ -12,14 +12,20 @@ import (
 // from internal/interp to use the Context interface pattern.
 //
 // Functions in this file:
-//   - TypeOf: Get the type name of a value
+//   - TypeOf: Get the runtime type information of a value
+//   - TypeName: Get the type name of a value
 //   - TypeOfClass: Get the class name of an object
+//   - GetClass: Get the runtime class (metaclass) of an object
 //
 // These functions use Context helper methods to access type information
 // without creating circular dependencies with internal/interp types.
 
-// TypeOf returns the type name of a value.
-// TypeOf(value: Variant): String
+// TypeOf returns the runtime type information of a value.
+// TypeOf(value: Variant): TRTTITypeInfo
+//
+// The result is queried through its Name, IsClass, IsArray, IsRecord, Fields
+// and Methods members, and prints as the type name. Contexts that cannot build
+// type information return the type name as a String instead.
 //
 // Returns type names like:
 //   - "INTEGER" for integers
@@ -38,12 +44,31 @@ func TypeOf(ctx Context, args []Value) Value {
 		return ctx.NewError("TypeOf() expects exactly 1 argument, got %d", len(args))
 	}
 
+	if resolver, ok := ctx.(interface{ GetTypeInfoOf(value Value) Value }); ok {
+		return resolver.GetTypeInfoOf(args[0])
+	}
+
 	// Get type name using Context helper
 	typeName := ctx.GetTypeOf(args[0])
 
 	return &runtime.StringValue{Value: typeName}
 }
 
+// TypeName returns the name of a value's runtime type.
+// TypeName(value: Variant): String
+//
+// This is the same as TypeOf(value).Name; objects report their class name.
+func TypeName(ctx Context, args []Value) Value {
+	if len(args) != 1 {
+		return ctx.NewError("TypeName() expects exactly 1 argument, got %d", len(args))
+	}
+
+	if resolver, ok := ctx.(interface{ GetTypeInfoOf(value Value) Value }); ok {
+		return &runtime.StringValue{Value: resolver.GetTypeInfoOf(args[0]).String()}
+	}
+	return &runtime.StringValue{Value: ctx.GetTypeOf(args[0])}
+}
+
 // TypeOfClass returns the class name of an object value.
 // TypeOfClass(obj: TObject): String
 //
@@ -62,3 +87,33 @@ func TypeOfClass(ctx Context, args []Value) Value {
 
 	return &runtime.StringValue{Value: className}
 }
+
+// GetClass returns the runtime class of an object as a metaclass value.
+// GetClass(obj: TObject): class of TObject
+//
+// The result is the object's dynamic class, not its declared type, so
+// GetClass(obj).Create constructs a new instance of the same runtime class
+// (virtual construction). A class reference argument is returned as-is.
+// Passing nil raises an exception.
+func GetClass(ctx Context, args []Value) Value {
+	if len(args) != 1 {
+		return ctx.NewError("GetClass() expects exactly 1 argument, got %d", len(args))
+	}
+
+	resolver, ok := ctx.(interface{ GetClassValueOf(value Value) Value })
+	if !ok {
+		return ctx.NewError("GetClass() is not supported in this context")
+	}
+
+	classVal := resolver.GetClassValueOf(ctx.UnwrapVariant(args[0]))
+	if classVal == nil {
+		msg := "Object not instantiated"
+		if raiser, ok := ctx.(interface {
+			RaiseException(className, message string, pos any)
+		}); ok {
+			raiser.RaiseException("Exception", msg, nil)
+		}
+		return ctx.NewError(msg)
+	}
+	return classVal
+}
@@ -59,7 +59,7 @@ func (c *Compiler) compileExpression(expr ast.Expression) error {
 	case *ast.RecordLiteralExpression:
 		return c.compileRecordLiteralExpression(node)
 	default:
-		return c.errorf(expr, "unsupported expression type %T", expr)
+		return c.unsupportedf(expr, "unsupported expression type %T", expr)
 	}
 }
 
@@ -248,7 +248,7 @@ func (c *Compiler) compileSetLiteral(expr *ast.SetLiteral) error {
 				// Non-constant range - compile start and end, VM will expand
 				// For now, we'll compile the range expression itself and let
 				// the VM handle expansion (this requires VM support)
-				return c.errorf(rangeExpr, "non-constant ranges in set literals not yet supported")
+				return c.unsupportedf(rangeExpr, "non-constant ranges in set literals not yet supported")
 			}
 		} else {
 			// Regular element - compile it normally
@@ -336,7 +336,7 @@ func (c *Compiler) expandConstantRange(rangeExpr *ast.RangeExpression) ([]Value,
 		return result, nil
 	}
 
-	return nil, c.errorf(rangeExpr, "unsupported range type: %s", startVal.Type)
+	return nil, c.unsupportedf(rangeExpr, "unsupported range type: %s", startVal.Type)
 }
 
 // extractConstantValue extracts a constant value from an expression if possible.
@@ -441,7 +441,7 @@ func (c *Compiler) compileNewExpression(expr *ast.NewExpression) error {
 		return c.errorf(expr, "invalid new expression")
 	}
 	if len(expr.Arguments) > 0 {
-		return c.errorf(expr, "constructors with arguments are not supported in bytecode yet")
+		return c.unsupportedf(expr, "constructors with arguments are not supported in bytecode yet")
 	}
 	constIdx := c.chunk.AddConstant(StringValue(expr.ClassName.Value))
 	if constIdx > 0xFFFF {
@@ -603,7 +603,7 @@ func (c *Compiler) compileBinaryExpression(expr *ast.BinaryExpression) error {
 	case ">=":
 		c.chunk.WriteSimple(OpGreaterEqual, line)
 	default:
-		return c.errorf(expr, "unsupported binary operator %q", expr.Operator)
+		return c.unsupportedf(expr, "unsupported binary operator %q", expr.Operator)
 	}
 
 	return nil
@@ -771,7 +771,7 @@ func (c *Compiler) compileUnaryExpression(expr *ast.UnaryExpression) error {
 	case "not":
 		c.chunk.WriteSimple(OpNot, line)
 	default:
-		return c.errorf(expr, "unsupported unary operator %q", expr.Operator)
+		return c.unsupportedf(expr, "unsupported unary operator %q", expr.Operator)
 	}
 
 	return nil
@@ -936,7 +936,7 @@ func (c *Compiler) compileIsExpression(expr *ast.IsExpression) error {
 
 	// Type checking mode - not yet fully implemented in bytecode
 	// For now, we'll return an error and let the interpreter handle it
-	return c.errorf(expr, "type checking with 'is' operator not yet supported in bytecode mode")
+	return c.unsupportedf(expr, "type checking with 'is' operator not yet supported in bytecode mode")
 }
 
 // compileRecordLiteralExpression compiles a record literal.
@@ -955,7 +955,7 @@ func (c *Compiler) compileRecordLiteralExpression(expr *ast.RecordLiteralExpress
 		typeName = expr.TypeName.Value
 	} else {
 		// Anonymous records not yet supported
-		return c.errorf(expr, "anonymous record literals not yet supported in bytecode")
+		return c.unsupportedf(expr, "anonymous record literals not yet supported in bytecode")
 	}
 
 	// Emit OpNewRecord instruction to create the record instance
@@ -985,7 +985,7 @@ func (c *Compiler) compileRecordLiteralExpression(expr *ast.RecordLiteralExpress
 			fieldName = fieldInit.Name.Value
 		} else {
 			// Positional field initialization not yet supported
-			return c.errorf(fieldInit, "positional field initialization not yet supported in bytecode")
+			return c.unsupportedf(fieldInit, "positional field initialization not yet supported in bytecode")
 		}
 
 		// Emit OpSetField to set the field value
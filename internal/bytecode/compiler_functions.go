@@ -80,6 +80,9 @@ func (c *Compiler) compileCallExpression(expr *ast.CallExpression) error {
 				if !c.hasEnclosingLocal(ident.Value) {
 					if _, ok := c.resolveGlobal(ident.Value); !ok {
 						// Not shadowed - compile as builtin call
+						if !hasVMBuiltin(ident.Value) {
+							return c.unsupportedf(expr, "built-in function %s is not supported by the bytecode VM", ident.Value)
+						}
 						for _, arg := range expr.Arguments {
 							if err := c.compileExpression(arg); err != nil {
 								return err
@@ -167,7 +170,7 @@ func (c *Compiler) isBuiltinFunction(name string) bool {
 		"startofmonth", "endofmonth", "startofyear", "endofyear", "istoday",
 		"isyesterday", "istomorrow", "issameday", "comparedate", "comparetime",
 		"comparedatetime", "parsejson", "tojson", "tojsonformatted",
-		"jsonhasfield", "jsonkeys", "jsonvalues", "jsonlength",
+		"jsonhasfield", "jsonkeys", "keys", "jsonvalues", "jsonlength",
 		"getstacktrace", "getcallstack":
 		return true
 	default:
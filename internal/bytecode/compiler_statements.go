@@ -29,6 +29,8 @@ func (c *Compiler) compileStatement(stmt ast.Statement) error {
 		return nil
 	case *ast.VarDeclStatement:
 		return c.compileVarDecl(node)
+	case *ast.ConstDecl:
+		return c.compileConstDecl(node)
 	case *ast.AssignmentStatement:
 		return c.compileAssignment(node)
 	case *ast.ExpressionStatement:
@@ -62,7 +64,7 @@ func (c *Compiler) compileStatement(stmt ast.Statement) error {
 	case *ast.EnumDecl:
 		return nil // No bytecode needed for type declarations
 	default:
-		return c.errorf(stmt, "unsupported statement type %T", stmt)
+		return c.unsupportedf(stmt, "unsupported statement type %T", stmt)
 	}
 }
 
@@ -126,6 +128,41 @@ func (c *Compiler) compileVarDecl(stmt *ast.VarDeclStatement) error {
 	return nil
 }
 
+// compileConstDecl stores a constant like an initialized variable. Constants
+// declared inside a function or block live in a local slot, so they go out
+// of scope with the block that declared them.
+func (c *Compiler) compileConstDecl(stmt *ast.ConstDecl) error {
+	if stmt.Name == nil {
+		return c.errorf(stmt, "constant declaration without a name")
+	}
+
+	constType := typeFromAnnotation(stmt.Type)
+	if constType == nil && stmt.Value != nil {
+		constType = c.inferExpressionType(stmt.Value)
+	}
+	if c.isGlobalScope() {
+		index, err := c.declareGlobal(stmt.Name, constType)
+		if err != nil {
+			return err
+		}
+		if err := c.emitInitializer(stmt.Value, stmt); err != nil {
+			return err
+		}
+		c.chunk.Write(OpStoreGlobal, 0, index, lineOf(stmt.Name))
+		return nil
+	}
+
+	slot, err := c.declareLocal(stmt.Name, constType)
+	if err != nil {
+		return err
+	}
+	if err := c.emitInitializer(stmt.Value, stmt); err != nil {
+		return err
+	}
+	c.chunk.Write(OpStoreLocal, 0, slot, lineOf(stmt.Name))
+	return nil
+}
+
 func (c *Compiler) emitInitializer(value ast.Expression, stmt ast.Statement) error {
 	if value != nil {
 		return c.compileExpression(value)
@@ -136,7 +173,7 @@ func (c *Compiler) emitInitializer(value ast.Expression, stmt ast.Statement) err
 
 func (c *Compiler) compileAssignment(stmt *ast.AssignmentStatement) error {
 	if stmt.Operator != lexer.ASSIGN {
-		return c.errorf(stmt, "unsupported assignment operator %s", stmt.Operator)
+		return c.unsupportedf(stmt, "unsupported assignment operator %s", stmt.Operator)
 	}
 
 	switch target := stmt.Target.(type) {
@@ -147,7 +184,7 @@ func (c *Compiler) compileAssignment(stmt *ast.AssignmentStatement) error {
 	case *ast.IndexExpression:
 		return c.compileIndexAssignment(target, stmt.Value)
 	default:
-		return c.errorf(stmt.Target, "unsupported assignment target %T", stmt.Target)
+		return c.unsupportedf(stmt.Target, "unsupported assignment target %T", stmt.Target)
 	}
 }
 
@@ -165,6 +202,10 @@ func (c *Compiler) compileExpressionStatement(stmt *ast.ExpressionStatement) err
 }
 
 func (c *Compiler) compileIf(stmt *ast.IfStatement) error {
+	if stmt.InlineVar != nil {
+		return c.unsupportedf(stmt, "inline variables in if conditions are not supported in bytecode yet")
+	}
+
 	if err := c.compileExpression(stmt.Condition); err != nil {
 		return err
 	}
@@ -192,6 +233,10 @@ func (c *Compiler) compileIf(stmt *ast.IfStatement) error {
 }
 
 func (c *Compiler) compileWhile(stmt *ast.WhileStatement) error {
+	if stmt.InlineVar != nil {
+		return c.unsupportedf(stmt, "inline variables in while conditions are not supported in bytecode yet")
+	}
+
 	loopStart := len(c.chunk.Code)
 	ctx := c.pushLoop(loopKindWhile, loopStart)
 	defer c.popLoop()
@@ -436,7 +481,7 @@ func (c *Compiler) compileFunctionDecl(fn *ast.FunctionDecl) error {
 		return c.errorf(fn, "function declaration missing name")
 	}
 	if !c.isGlobalScope() {
-		return c.errorf(fn, "local function declarations are not supported yet")
+		return c.unsupportedf(fn, "local function declarations are not supported yet")
 	}
 
 	globalSlot, err := c.declareGlobal(fn.Name, typeFromAnnotation(fn.ReturnType))
@@ -0,0 +1,119 @@
+package bytecode
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+
+	"github.com/cwbudde/go-dws/pkg/ast"
+	"github.com/cwbudde/go-dws/pkg/token"
+)
+
+// UnsupportedError reports a construct the bytecode compiler cannot compile
+// yet. Programs using it have to run on the AST interpreter instead.
+type UnsupportedError struct {
+	// Node is the AST node of the construct.
+	Node ast.Node
+	// NodeType is the name of the node's AST type, e.g. "ForStatement".
+	NodeType string
+	// Message describes the construct.
+	Message string
+}
+
+// Error implements the error interface using the compiler's error format.
+func (e *UnsupportedError) Error() string {
+	message := e.Message
+	if pos := e.Pos(); pos.Line > 0 {
+		message = fmt.Sprintf("%s at %d:%d", message, pos.Line, pos.Column)
+	}
+	return "bytecode compile error: " + message
+}
+
+// Pos returns the source position of the construct.
+func (e *UnsupportedError) Pos() token.Position {
+	if e.Node == nil {
+		return token.Position{}
+	}
+	return e.Node.Pos()
+}
+
+// unsupportedf returns an UnsupportedError for node.
+func (c *Compiler) unsupportedf(node ast.Node, format string, args ...interface{}) error {
+	return &UnsupportedError{
+		Node:     node,
+		NodeType: NodeTypeName(node),
+		Message:  fmt.Sprintf(format, args...),
+	}
+}
+
+// NodeTypeName returns the name of an AST node's type without the package
+// qualifier, e.g. "ForStatement" for *ast.ForStatement.
+func NodeTypeName(node ast.Node) string {
+	if node == nil {
+		return ""
+	}
+	t := reflect.TypeOf(node)
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	return t.Name()
+}
+
+// supportedNodeTypes lists the statement and expression nodes handled by
+// compileStatement and compileExpression. Keep it in sync with both.
+var supportedNodeTypes = []ast.Node{
+	// Statements and declarations
+	(*ast.AssignmentStatement)(nil),
+	(*ast.BlockStatement)(nil),
+	(*ast.BreakStatement)(nil),
+	(*ast.ClassDecl)(nil),
+	(*ast.ConstDecl)(nil),
+	(*ast.ContinueStatement)(nil),
+	(*ast.EmptyStatement)(nil),
+	(*ast.EnumDecl)(nil),
+	(*ast.ExpressionStatement)(nil),
+	(*ast.FunctionDecl)(nil),
+	(*ast.HelperDecl)(nil),
+	(*ast.IfStatement)(nil),
+	(*ast.RaiseStatement)(nil),
+	(*ast.RecordDecl)(nil),
+	(*ast.RepeatStatement)(nil),
+	(*ast.ReturnStatement)(nil),
+	(*ast.TryStatement)(nil),
+	(*ast.VarDeclStatement)(nil),
+	(*ast.WhileStatement)(nil),
+
+	// Expressions
+	(*ast.ArrayLiteralExpression)(nil),
+	(*ast.BinaryExpression)(nil),
+	(*ast.BooleanLiteral)(nil),
+	(*ast.CallExpression)(nil),
+	(*ast.FloatLiteral)(nil),
+	(*ast.Identifier)(nil),
+	(*ast.IfExpression)(nil),
+	(*ast.IndexExpression)(nil),
+	(*ast.IntegerLiteral)(nil),
+	(*ast.IsExpression)(nil),
+	(*ast.LambdaExpression)(nil),
+	(*ast.MemberAccessExpression)(nil),
+	(*ast.MethodCallExpression)(nil),
+	(*ast.NewArrayExpression)(nil),
+	(*ast.NewExpression)(nil),
+	(*ast.NilLiteral)(nil),
+	(*ast.RecordLiteralExpression)(nil),
+	(*ast.SetLiteral)(nil),
+	(*ast.StringLiteral)(nil),
+	(*ast.UnaryExpression)(nil),
+}
+
+// SupportedNodeTypes returns the sorted names of the AST node types the
+// bytecode compiler can compile. Some of them are only supported in part, in
+// which case compiling an unsupported use returns an UnsupportedError.
+func SupportedNodeTypes() []string {
+	names := make([]string, len(supportedNodeTypes))
+	for i, node := range supportedNodeTypes {
+		names[i] = NodeTypeName(node)
+	}
+	sort.Strings(names)
+	return names
+}
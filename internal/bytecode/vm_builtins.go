@@ -1,5 +1,11 @@
 package bytecode
 
+import (
+	"sync"
+
+	pkgident "github.com/cwbudde/go-dws/pkg/ident"
+)
+
 // registerBuiltins registers all built-in functions with the VM.
 // The actual implementations are split across multiple files for better organization:
 // - vm_builtins_misc.go: Print, PrintLn, Length
@@ -18,4 +24,32 @@ func (vm *VM) registerBuiltins() {
 
 	// Register math functions
 	vm.registerMathBuiltins()
+
+	// The compiler emits normalized builtin names, so register every builtin
+	// under its normalized name as well.
+	names := make([]string, 0, len(vm.builtins))
+	for name := range vm.builtins {
+		names = append(names, name)
+	}
+	for _, name := range names {
+		vm.builtins[pkgident.Normalize(name)] = vm.builtins[name]
+	}
+}
+
+var (
+	vmBuiltinNamesOnce sync.Once
+	vmBuiltinNames     map[string]bool
+)
+
+// hasVMBuiltin reports whether the VM implements the built-in function name.
+// Names are case-insensitive.
+func hasVMBuiltin(name string) bool {
+	vmBuiltinNamesOnce.Do(func() {
+		vm := NewVMWithOutput(nil)
+		vmBuiltinNames = make(map[string]bool, len(vm.builtins))
+		for builtin := range vm.builtins {
+			vmBuiltinNames[pkgident.Normalize(builtin)] = true
+		}
+	})
+	return vmBuiltinNames[pkgident.Normalize(name)]
 }
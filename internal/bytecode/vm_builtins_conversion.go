@@ -42,7 +42,11 @@ func builtinFloatToStr(vm *VM, args []Value) (Value, error) {
 		return NilValue(), vm.runtimeError("FloatToStr expects a numeric argument")
 	}
 	// AsFloat() handles conversion for both types
-	return StringValue(fmt.Sprintf("%g", args[0].AsFloat())), nil
+	result := fmt.Sprintf("%g", args[0].AsFloat())
+	if sep := vm.decimalSeparator; sep != "" && sep != "." {
+		result = strings.Replace(result, ".", sep, 1)
+	}
+	return StringValue(result), nil
 }
 
 func builtinStrToInt(vm *VM, args []Value) (Value, error) {
@@ -67,8 +71,15 @@ func builtinStrToFloat(vm *VM, args []Value) (Value, error) {
 	if !args[0].IsString() {
 		return NilValue(), vm.runtimeError("StrToFloat expects a string argument")
 	}
+	s := args[0].AsString()
+	if sep := vm.decimalSeparator; sep != "" && sep != "." {
+		if strings.Contains(s, ".") {
+			return NilValue(), vm.runtimeError("StrToFloat: invalid float string")
+		}
+		s = strings.Replace(s, sep, ".", 1)
+	}
 	var val float64
-	_, err := fmt.Sscanf(args[0].AsString(), "%f", &val)
+	_, err := fmt.Sscanf(s, "%f", &val)
 	if err != nil {
 		return NilValue(), vm.runtimeError("StrToFloat: invalid float string")
 	}
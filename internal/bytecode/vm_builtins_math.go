@@ -271,27 +271,60 @@ func builtinIntPower(vm *VM, args []Value) (Value, error) {
 }
 
 func builtinRandom(vm *VM, args []Value) (Value, error) {
-	if len(args) != 0 {
-		return NilValue(), vm.runtimeError("Random expects no arguments, got %d", len(args))
+	switch len(args) {
+	case 0:
+		return FloatValue(vm.rand.Float64()), nil
+	case 1:
+		if !args[0].IsInt() {
+			return NilValue(), vm.runtimeError("Random expects Integer argument, got %s", args[0].Type.String())
+		}
+		if args[0].AsInt() <= 0 {
+			return IntValue(0), nil
+		}
+		return IntValue(randomInRange(vm.rand, 0, args[0].AsInt())), nil
+	default:
+		return NilValue(), vm.runtimeError("Random expects 0 or 1 arguments, got %d", len(args))
 	}
-	return FloatValue(vm.rand.Float64()), nil
 }
 
 func builtinRandomInt(vm *VM, args []Value) (Value, error) {
-	if len(args) != 1 {
-		return NilValue(), vm.runtimeError("RandomInt expects 1 argument, got %d", len(args))
+	if len(args) != 1 && len(args) != 2 {
+		return NilValue(), vm.runtimeError("RandomInt expects 1 or 2 arguments, got %d", len(args))
 	}
 
-	if !args[0].IsInt() {
-		return NilValue(), vm.runtimeError("RandomInt expects Integer argument, got %s", args[0].Type.String())
+	for _, arg := range args {
+		if !arg.IsInt() {
+			return NilValue(), vm.runtimeError("RandomInt expects Integer argument, got %s", arg.Type.String())
+		}
+	}
+
+	if len(args) == 1 {
+		max := args[0].AsInt()
+		if max <= 0 {
+			return NilValue(), vm.runtimeError("RandomInt expects max > 0, got %d", max)
+		}
+		return IntValue(randomInRange(vm.rand, 0, max)), nil
 	}
 
-	max := args[0].AsInt()
-	if max <= 0 {
-		return NilValue(), vm.runtimeError("RandomInt expects max > 0, got %d", max)
+	min, max := args[0].AsInt(), args[1].AsInt()
+	if max <= min {
+		return NilValue(), vm.runtimeError("RandomInt expects max > min, got %d and %d", min, max)
 	}
+	return IntValue(randomInRange(vm.rand, min, max)), nil
+}
 
-	return IntValue(int64(vm.rand.Intn(int(max)))), nil
+// randomInRange returns a random integer in [lo, hi), drawing the same
+// sequence as the AST interpreter's Random and RandomInt.
+func randomInRange(rng *rand.Rand, lo, hi int64) int64 {
+	span := uint64(hi) - uint64(lo)
+	if span <= math.MaxInt {
+		return lo + int64(rng.Intn(int(span)))
+	}
+	for {
+		if v := rng.Uint64(); v < span {
+			return int64(uint64(lo) + v)
+		}
+	}
 }
 
 func builtinRandSeed(vm *VM, args []Value) (Value, error) {
@@ -302,8 +335,24 @@ func builtinRandSeed(vm *VM, args []Value) (Value, error) {
 }
 
 func builtinRandG(vm *VM, args []Value) (Value, error) {
-	if len(args) != 0 {
-		return NilValue(), vm.runtimeError("RandG expects no arguments, got %d", len(args))
+	mean, stdDev := 0.0, 1.0
+	switch len(args) {
+	case 0:
+	case 2:
+		params := make([]float64, 2)
+		for i, arg := range args {
+			switch {
+			case arg.IsFloat():
+				params[i] = arg.AsFloat()
+			case arg.IsInt():
+				params[i] = float64(arg.AsInt())
+			default:
+				return NilValue(), vm.runtimeError("RandG expects Float argument, got %s", arg.Type.String())
+			}
+		}
+		mean, stdDev = params[0], params[1]
+	default:
+		return NilValue(), vm.runtimeError("RandG expects 0 or 2 arguments, got %d", len(args))
 	}
 
 	// Generate Gaussian random number using Box-Muller transform
@@ -318,7 +367,7 @@ func builtinRandG(vm *VM, args []Value) (Value, error) {
 	// Box-Muller transform
 	z0 := math.Sqrt(-2.0*math.Log(u1)) * math.Cos(2.0*math.Pi*u2)
 
-	return FloatValue(z0), nil
+	return FloatValue(mean + z0*stdDev), nil
 }
 
 func builtinSetRandSeed(vm *VM, args []Value) (Value, error) {
@@ -341,6 +390,9 @@ func builtinRandomize(vm *VM, args []Value) (Value, error) {
 	if len(args) != 0 {
 		return NilValue(), vm.runtimeError("Randomize expects no arguments, got %d", len(args))
 	}
+	if vm.fixedRandSeed {
+		return NilValue(), nil
+	}
 
 	seed := time.Now().UnixNano()
 	vm.randSeed = seed
@@ -447,6 +447,10 @@ func (vm *VM) callValueWithSelf(callee Value, args []Value, self Value) error {
 			return vm.runtimeError("invalid built-in function")
 		}
 		builtinFunc, ok := vm.builtins[name]
+		if !ok {
+			// Builtin globals keep their declared casing (e.g. "SetRandSeed")
+			builtinFunc, ok = vm.builtins[ident.Normalize(name)]
+		}
 		if !ok {
 			return vm.runtimeError("built-in function %q not found", name)
 		}
@@ -473,6 +477,9 @@ func (vm *VM) callClosure(closure *Closure, args []Value, self Value) error {
 	if fn.Arity >= 0 && len(args) != fn.Arity {
 		return vm.runtimeError("function %s expected %d arguments but got %d", fn.Name, fn.Arity, len(args))
 	}
+	if err := vm.checkInterrupt(); err != nil {
+		return err
+	}
 
 	localCount := fn.Chunk.LocalCount
 	if localCount < len(args) {
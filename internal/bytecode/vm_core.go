@@ -27,7 +27,10 @@ type VM struct {
 	openUpvalues      []*Upvalue
 	exceptionHandlers []exceptionHandler
 	finallyStack      []finallyContext
+	decimalSeparator  string
 	randSeed          int64
+	fixedRandSeed     bool
+	interrupt         func() bool
 }
 
 // NewVM creates a new VM with default configuration.
@@ -52,11 +55,45 @@ func NewVMWithOutput(output io.Writer) *VM {
 		helpers:           make(map[string]*HelperInfo),
 		rand:              rand.New(rand.NewSource(defaultSeed)),
 		randSeed:          defaultSeed,
+		decimalSeparator:  ".",
 	}
 	vm.registerBuiltins()
 	return vm
 }
 
+// FixRandomSeed seeds the random number generator with seed and makes
+// Randomize a no-op, so every run draws the same sequence.
+func (vm *VM) FixRandomSeed(seed int64) {
+	vm.randSeed = seed
+	vm.rand = rand.New(rand.NewSource(seed))
+	vm.fixedRandSeed = true
+}
+
+// SetInterrupt makes Run stop with a runtime error once interrupted returns
+// true. The VM polls it on every backward jump and function call. A nil
+// function never interrupts.
+func (vm *VM) SetInterrupt(interrupted func() bool) {
+	vm.interrupt = interrupted
+}
+
+// checkInterrupt returns a runtime error when the function set with
+// SetInterrupt reports an interruption.
+func (vm *VM) checkInterrupt() error {
+	if vm.interrupt != nil && vm.interrupt() {
+		return vm.runtimeError("script interrupted")
+	}
+	return nil
+}
+
+// SetDecimalSeparator sets the decimal separator used by FloatToStr and
+// StrToFloat. The default (and the value used for an empty sep) is ".".
+func (vm *VM) SetDecimalSeparator(sep string) {
+	if sep == "" {
+		sep = "."
+	}
+	vm.decimalSeparator = sep
+}
+
 func (vm *VM) reset() {
 	vm.stack = vm.stack[:0]
 	vm.frames = vm.frames[:0]
@@ -1085,6 +1085,9 @@ func (vm *VM) Run(chunk *Chunk) (Value, error) {
 			}
 		case OpLoop:
 			frame.ip += int(inst.SignedB())
+			if err := vm.checkInterrupt(); err != nil {
+				return NilValue(), err
+			}
 		case OpReturn:
 			var ret = NilValue()
 			if inst.A() != 0 {
@@ -68,6 +68,23 @@ func TestVMParityBasic(t *testing.T) {
 				end;
 			`,
 		},
+		{
+			name: "Global constant",
+			source: `
+				const Limit = 3;
+				PrintLn(IntToStr(Limit * 2));
+			`,
+		},
+		{
+			name: "Block-local constant",
+			source: `
+				const Outer = 'outer';
+				begin
+					const Inner: String = 'inner';
+					PrintLn(Inner + ' ' + Outer);
+				end;
+			`,
+		},
 		// TODO: For loop not yet supported in bytecode compiler
 		// {
 		// 	name: "For loop",
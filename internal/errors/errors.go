@@ -19,7 +19,13 @@ type CompilerError struct {
 }
 
 // NewCompilerError creates a new compiler error.
+// When pos lies in another file than file (e.g. inside an {$INCLUDE}d file),
+// the error is attributed to pos.Source and no source context is shown, since
+// source holds the text of file.
 func NewCompilerError(pos lexer.Position, message, source, file string) *CompilerError {
+	if pos.Source != "" && pos.Source != file {
+		file, source = pos.Source, ""
+	}
 	return &CompilerError{
 		Pos:     pos,
 		Message: message,
@@ -9,11 +9,14 @@ import (
 	"strconv"
 	"strings"
 
+	"github.com/cwbudde/go-dws/internal/builtins"
 	dwserrors "github.com/cwbudde/go-dws/internal/errors"
 	"github.com/cwbudde/go-dws/internal/generics"
 	"github.com/cwbudde/go-dws/internal/lexer"
 	"github.com/cwbudde/go-dws/internal/parser"
 	"github.com/cwbudde/go-dws/internal/semantic"
+	"github.com/cwbudde/go-dws/internal/types"
+	"github.com/cwbudde/go-dws/internal/units"
 	"github.com/cwbudde/go-dws/pkg/ast"
 )
 
@@ -40,6 +43,9 @@ type Diagnostic struct {
 	Message  string
 	Rendered string
 	Code     string
+	// Source is the file the diagnostic's position belongs to (see
+	// token.Position.Source); empty for the unnamed top-level source.
+	Source   string
 	Phase    Phase
 	Line     int
 	Column   int
@@ -78,6 +84,10 @@ type Result struct {
 	Diagnostics        []Diagnostic
 	SemanticAttempted  bool
 	SemanticSuccessful bool
+	// Incomplete is set when the source ended inside an unfinished construct,
+	// such as an open begin/end block or string literal; see
+	// parser.Parser.Incomplete.
+	Incomplete bool
 }
 
 // HasFatalDiagnostics reports whether compilation produced fatal front-end diagnostics.
@@ -150,31 +160,47 @@ func Parse(source string) *Result {
 // resolve {$INCLUDE} directives relative to the directory of filename. An empty
 // filename disables include resolution.
 func ParseWithFilename(source, filename string) *Result {
-	opts := includeOptions(filename)
+	return ParseWithOptions(source, filename)
+}
+
+// ParseWithOptions parses source like ParseWithFilename, applying the extra lexer
+// options (e.g. lexer.WithDefines or a custom include resolver) after the
+// filename-based include configuration.
+func ParseWithOptions(source, filename string, lexerOpts ...lexer.LexerOption) *Result {
+	return ParseWithConfig(source, filename, parser.DefaultConfig(), lexerOpts...)
+}
+
+// ParseWithConfig parses source like ParseWithOptions, building the parser from
+// config (e.g. to change ParserConfig.MaxErrors).
+func ParseWithConfig(source, filename string, config parser.ParserConfig, lexerOpts ...lexer.LexerOption) *Result {
+	opts := append(includeOptions(filename), lexerOpts...)
 	l := lexer.New(source, opts...)
-	p := parser.New(l)
+	p := parser.NewParserBuilder(l).WithConfig(config).Build()
 	program := p.ParseProgram()
 
-	// Include-resolution failures (e.g. an unresolvable {$INCLUDE}) are otherwise
-	// invisible to the parser-error path, which would let a script with a missing
-	// include compile and run with its include content silently dropped. Other lexer
-	// errors remain advisory and are not surfaced here.
-	diags := lexerDiagnostics(p.LexerIncludeErrors())
+	// Directive failures (e.g. an unresolvable {$INCLUDE} or an unterminated
+	// {$IFDEF}) are otherwise invisible to the parser-error path, which would let a
+	// script compile and run with code silently dropped. Other lexer errors remain
+	// advisory and are not surfaced here.
+	diags := lexerDiagnostics(p.LexerDirectiveErrors())
 	diags = append(diags, parserDiagnostics(p.Errors())...)
 
 	return &Result{
 		Program:     program,
 		Diagnostics: filterDiagnostics(diags),
+		Incomplete:  p.Incomplete(),
 	}
 }
 
-// includeOptions builds the lexer options that enable {$INCLUDE} resolution rooted
-// at the directory containing filename. It returns no options when filename is empty.
+// includeOptions builds the lexer options that name the source after filename and
+// enable {$INCLUDE} resolution rooted at its directory. It returns no options when
+// filename is empty.
 func includeOptions(filename string) []lexer.LexerOption {
 	if filename == "" {
 		return nil
 	}
 	return []lexer.LexerOption{
+		lexer.WithSourceName(filename),
 		lexer.WithIncludeResolver(lexer.NewFileIncludeResolver(filepath.Dir(filename))),
 	}
 }
@@ -182,11 +208,131 @@ func includeOptions(filename string) []lexer.LexerOption {
 // Compile parses source and, if parsing succeeds, runs semantic analysis.
 // This is the shared compile-front-end boundary for diagnostics collection.
 func Compile(source, filename string, hintsLevel semantic.HintsLevel) *Result {
-	result := ParseWithFilename(source, filename)
-	return compileParsedResult(result, source, filename, hintsLevel)
+	return CompileWithOptions(source, filename, hintsLevel)
+}
+
+// CompileWithOptions compiles source like Compile, passing lexerOpts to the lexer
+// (see ParseWithOptions).
+func CompileWithOptions(source, filename string, hintsLevel semantic.HintsLevel, lexerOpts ...lexer.LexerOption) *Result {
+	return CompileWithConfig(source, filename, hintsLevel, parser.DefaultConfig(), lexerOpts...)
+}
+
+// CompileWithConfig compiles source like CompileWithOptions, building the
+// parser from config (see ParseWithConfig).
+func CompileWithConfig(source, filename string, hintsLevel semantic.HintsLevel, config parser.ParserConfig, lexerOpts ...lexer.LexerOption) *Result {
+	return CompileWithGlobals(source, filename, hintsLevel, config, nil, lexerOpts...)
+}
+
+// Global is a variable supplied by the host application rather than declared
+// in the source.
+type Global struct {
+	Type types.Type
+	Name string
+}
+
+// Function is a function supplied by the host application rather than
+// declared in the source.
+type Function struct {
+	Type *types.FunctionType
+	Name string
+}
+
+// Unit is a unit implemented by the host application rather than loaded from
+// source. Programs reach its functions and constants with qualified names.
+type Unit struct {
+	Name      string
+	Functions []Function
+	Constants []Global
+}
+
+// CompileWithGlobals compiles source like CompileWithConfig, predeclaring
+// globals before semantic analysis (see semantic.Analyzer.DeclareGlobal).
+func CompileWithGlobals(source, filename string, hintsLevel semantic.HintsLevel, config parser.ParserConfig, globals []Global, lexerOpts ...lexer.LexerOption) *Result {
+	return CompileWithAnalysis(source, filename, hintsLevel, config, AnalysisOptions{Globals: globals}, lexerOpts...)
+}
+
+// AnalysisOptions configures semantic analysis beyond the hints level.
+type AnalysisOptions struct {
+	// Globals are predeclared before analysis.
+	Globals []Global
+	// Functions are predeclared before analysis.
+	Functions []Function
+	// Builtins, when set, replaces builtins.DefaultRegistry as the set of
+	// built-in functions available to the program.
+	Builtins *builtins.Registry
+	// ConstantFolding records the values of constant expressions in the
+	// SemanticInfo (see semantic.Analyzer.EnableConstantFolding).
+	ConstantFolding bool
+	// IntegerOverflowCheck must match the runtime setting when folding, so
+	// overflowing expressions are left to raise EIntOverflow.
+	IntegerOverflowCheck bool
+	// StrictTypes reports types the analyzer cannot determine as errors
+	// instead of defaulting to Variant (see semantic.Analyzer.SetStrictTypes).
+	StrictTypes bool
+	// UnitResolver, when set, supplies the sources of units named in uses
+	// clauses (see LinkUnits).
+	UnitResolver units.SourceResolver
+	// UnitCache, when set, holds the units parsed by earlier compilations so
+	// LinkUnits can reuse them.
+	UnitCache *units.UnitCache
+	// HostUnits are the units provided by the host. Their members are
+	// predeclared before analysis, and LinkUnits does not resolve them.
+	HostUnits []Unit
+	// Defines are the conditional symbols predefined in the units LinkUnits
+	// loads. The program itself is configured by the lexer options.
+	Defines []string
+	// IncludePaths are the directories searched for files included by the
+	// units LinkUnits loads.
+	IncludePaths []string
+}
+
+// CompileWithAnalysis compiles source like CompileWithConfig, configuring the
+// semantic analyzer with opts.
+func CompileWithAnalysis(source, filename string, hintsLevel semantic.HintsLevel, config parser.ParserConfig, opts AnalysisOptions, lexerOpts ...lexer.LexerOption) *Result {
+	result := ParseWithConfig(source, filename, config, lexerOpts...)
+	LinkUnits(result, opts)
+	return compileParsedResult(result, source, filename, hintsLevel, opts)
+}
+
+func compileParsedResult(result *Result, source, filename string, hintsLevel semantic.HintsLevel, opts AnalysisOptions) *Result {
+	return AnalyzeWith(result, NewAnalyzer(hintsLevel, opts), source, filename)
+}
+
+// NewAnalyzer returns a semantic analyzer configured with opts the way
+// CompileWithAnalysis configures it, with the globals, functions and host
+// units of opts already predeclared.
+func NewAnalyzer(hintsLevel semantic.HintsLevel, opts AnalysisOptions) *semantic.Analyzer {
+	analyzer := semantic.NewAnalyzer()
+	analyzer.SetHintsLevel(hintsLevel)
+	if opts.Builtins != nil {
+		analyzer.SetBuiltinRegistry(opts.Builtins)
+	}
+	for _, global := range opts.Globals {
+		analyzer.DeclareGlobal(global.Name, global.Type)
+	}
+	for _, fn := range opts.Functions {
+		analyzer.DeclareFunction(fn.Name, fn.Type)
+	}
+	for _, unit := range opts.HostUnits {
+		for _, fn := range unit.Functions {
+			analyzer.DeclareUnitFunction(unit.Name, fn.Name, fn.Type)
+		}
+		for _, constant := range unit.Constants {
+			analyzer.DeclareUnitConstant(unit.Name, constant.Name, constant.Type)
+		}
+	}
+	if opts.ConstantFolding {
+		analyzer.EnableConstantFolding(opts.IntegerOverflowCheck)
+	}
+	analyzer.SetStrictTypes(opts.StrictTypes)
+	return analyzer
 }
 
-func compileParsedResult(result *Result, source, filename string, hintsLevel semantic.HintsLevel) *Result {
+// AnalyzeWith runs semantic analysis of a parsed result with analyzer and
+// adds its diagnostics to the result. The analyzer may already hold the
+// declarations of programs it analyzed before; their diagnostics are not
+// reported again.
+func AnalyzeWith(result *Result, analyzer *semantic.Analyzer, source, filename string) *Result {
 	if result.Program == nil || result.HasSemanticBlockingDiagnosticsInPhase(PhaseParsing) {
 		return result
 	}
@@ -195,8 +341,7 @@ func compileParsedResult(result *Result, source, filename string, hintsLevel sem
 	// analysis, so the analyzer and evaluator only ever see ordinary types.
 	generics.Monomorphize(result.Program)
 
-	analyzer := semantic.NewAnalyzer()
-	analyzer.SetHintsLevel(hintsLevel)
+	analyzer.ResetErrors()
 	analyzer.SetSource(source, filename)
 	analyzer.SetParseHadErrors(result.HasDiagnosticsInPhase(PhaseParsing))
 	result.Analyzer = analyzer
@@ -372,6 +517,7 @@ func lexerDiagnostics(errs []lexer.LexerError) []Diagnostic {
 	for i := range errs {
 		diags = append(diags, Diagnostic{
 			Message:        errs[i].Message,
+			Source:         errs[i].Pos.Source,
 			Phase:          PhaseParsing,
 			Line:           errs[i].Pos.Line,
 			Column:         errs[i].Pos.Column,
@@ -393,6 +539,7 @@ func parserDiagnostics(errors []*parser.ParserError) []Diagnostic {
 		diags = append(diags, Diagnostic{
 			Message:        message,
 			Code:           err.Code,
+			Source:         err.Pos.Source,
 			Phase:          PhaseParsing,
 			Line:           err.Pos.Line,
 			Column:         err.Pos.Column,
@@ -443,7 +590,7 @@ func parserDiagnosticBlocksSemantic(err *parser.ParserError) bool {
 var parserBlockContextSuffix = regexp.MustCompile(` \(in .* block starting at line \d+\)$`)
 var semanticVisibilityError = regexp.MustCompile(`^cannot (?:access|call) (?:private|protected) (?:field|method|property|class variable) '([^']+)' of class '([^']+)'$`)
 var semanticImplicitVisibilityError = regexp.MustCompile(`^cannot access (?:private|protected) field '([^']+)'$`)
-var diagnosticVisibleMember = regexp.MustCompile(`^Member symbol "([^"]+)" is not visible from this scope$`)
+var diagnosticVisibleMember = regexp.MustCompile(`^Member symbol "([^"]+)" is (?:strict (?:private|protected) and )?not visible from this scope$`)
 var diagnosticAccessibleMember = regexp.MustCompile(`^There is no accessible member with name "([^"]+)" for type `)
 
 func normalizeParserDiagnosticMessage(message string) string {
@@ -510,6 +657,7 @@ func semanticDiagnostics(analyzer *semantic.Analyzer) []Diagnostic {
 				Message:  message,
 				Rendered: rendered,
 				Code:     string(err.Type),
+				Source:   err.Pos.Source,
 				Phase:    PhaseSemantic,
 				Line:     line,
 				Column:   column,
@@ -0,0 +1,127 @@
+package frontend
+
+import (
+	"github.com/cwbudde/go-dws/internal/units"
+	"github.com/cwbudde/go-dws/pkg/ast"
+)
+
+// LinkUnits loads the units named in the program's uses clauses through
+// opts.UnitResolver, recursively following their own uses clauses, and splices the
+// unit sections into result.Program so analysis and execution see a single
+// program: interface and implementation declarations and initialization code
+// in dependency order, then the main statements, then finalization code in
+// reverse order.
+//
+// Units in opts.HostUnits are provided by the host at run time (see
+// Engine.RegisterUnit) and are not passed to the resolver. Units are parsed
+// with opts.Defines predefined and include files searched in
+// opts.IncludePaths.
+//
+// When opts.UnitCache is not nil, units parsed by earlier calls sharing it
+// are reused as long as their source has the same structural hash (see
+// units.StructuralHash), and newly parsed units are added to it. The program
+// receives a deep copy of each cached unit (see ast.Clone), so programs
+// sharing a cache can be analyzed and run concurrently.
+//
+// Load failures (an unknown unit, a parse error in a unit or circular uses)
+// are recorded as fatal parsing diagnostics and leave the program unchanged.
+func LinkUnits(result *Result, opts AnalysisOptions) {
+	resolver, cache := opts.UnitResolver, opts.UnitCache
+	if result.Program == nil || resolver == nil {
+		return
+	}
+
+	used := usedUnitNames(result.Program.Statements)
+	if len(used) == 0 {
+		return
+	}
+
+	registry := units.NewUnitRegistry(nil)
+	registry.SetSourceResolver(resolver)
+	if cache != nil {
+		registry.SetCache(cache)
+	}
+	registry.SetDefines(opts.Defines)
+	registry.SetIncludePaths(opts.IncludePaths)
+	for _, unit := range opts.HostUnits {
+		_ = registry.RegisterUnit(unit.Name, units.NewUnit(unit.Name, ""))
+	}
+	for _, name := range used {
+		if _, err := registry.LoadUnit(name, nil); err != nil {
+			result.addUnitDiagnostic(err)
+			return
+		}
+	}
+
+	order, err := registry.ComputeInitializationOrder()
+	if err != nil {
+		result.addUnitDiagnostic(err)
+		return
+	}
+
+	var decls, inits, finals []ast.Statement
+	for _, name := range order {
+		unit, _ := registry.GetUnit(name)
+		decls = append(decls, unitStatements(unit.InterfaceSection)...)
+		decls = append(decls, unitStatements(unit.ImplementationSection)...)
+		inits = append(inits, unitStatements(unit.InitializationSection)...)
+	}
+	for i := len(order) - 1; i >= 0; i-- {
+		unit, _ := registry.GetUnit(order[i])
+		finals = append(finals, unitStatements(unit.FinalizationSection)...)
+	}
+
+	// Cached units are shared by every program the cache serves, and
+	// analysis annotates the nodes it visits, so link private copies.
+	unitStmts := append(append(decls, inits...), finals...)
+	if cache != nil {
+		unitStmts = ast.Clone(&ast.BlockStatement{Statements: unitStmts}).(*ast.BlockStatement).Statements
+	}
+	mainStart := len(decls) + len(inits)
+
+	stmts := make([]ast.Statement, 0, len(unitStmts)+len(result.Program.Statements))
+	stmts = append(stmts, unitStmts[:mainStart]...)
+	stmts = append(stmts, unitStatements(&ast.BlockStatement{Statements: result.Program.Statements})...)
+	stmts = append(stmts, unitStmts[mainStart:]...)
+	result.Program.Statements = stmts
+}
+
+func (r *Result) addUnitDiagnostic(err error) {
+	r.Diagnostics = append(r.Diagnostics, Diagnostic{
+		Message:        err.Error(),
+		Code:           "E_UNIT_LOAD",
+		Phase:          PhaseParsing,
+		Severity:       SeverityError,
+		Fatal:          true,
+		BlocksSemantic: true,
+	})
+}
+
+// usedUnitNames returns the unit names of the top-level uses clauses.
+func usedUnitNames(stmts []ast.Statement) []string {
+	var names []string
+	for _, stmt := range stmts {
+		if uses, ok := stmt.(*ast.UsesClause); ok {
+			for _, unit := range uses.Units {
+				names = append(names, unit.Value)
+			}
+		}
+	}
+	return names
+}
+
+// unitStatements returns the statements of a unit section without its uses
+// clauses, which LinkUnits has already resolved.
+func unitStatements(block *ast.BlockStatement) []ast.Statement {
+	if block == nil {
+		return nil
+	}
+	stmts := make([]ast.Statement, 0, len(block.Statements))
+	for _, stmt := range block.Statements {
+		if _, ok := stmt.(*ast.UsesClause); ok {
+			continue
+		}
+		stmts = append(stmts, stmt)
+	}
+	return stmts
+}
@@ -28,6 +28,15 @@ func isTemplateDecl(stmt ast.Statement) bool {
 	return len(typeParamsOf(stmt)) > 0
 }
 
+// genericMethodImpl returns stmt as an out-of-line method implementation of a
+// generic type (`function TBox<T>.Get: T`), or nil if it is not one.
+func genericMethodImpl(stmt ast.Statement) *ast.FunctionDecl {
+	if fd, ok := stmt.(*ast.FunctionDecl); ok && fd.ClassName != nil && len(fd.ClassTypeParams) > 0 {
+		return fd
+	}
+	return nil
+}
+
 // declName returns the declared type name for a type declaration statement.
 func declName(stmt ast.Statement) string {
 	switch d := stmt.(type) {
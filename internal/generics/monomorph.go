@@ -20,6 +20,10 @@
 //   - Inserts each specialized declaration immediately before its first use, so
 //     that its type arguments (which DWScript requires to be declared earlier)
 //     are already registered.
+//   - Specializes out-of-line method implementations (`function TList<T>.Add`)
+//     for every instantiation of their type, emitting each one after both the
+//     specialized declaration and the template implementation, so the method
+//     body sees the same declarations as the template's.
 package generics
 
 import (
@@ -36,8 +40,10 @@ func Monomorphize(prog *ast.Program) {
 		return
 	}
 	m := &monomorphizer{
-		templates: make(map[string]templateInfo),
-		emitted:   make(map[string]bool),
+		templates:   make(map[string]templateInfo),
+		emitted:     make(map[string]bool),
+		instances:   make(map[string][]instance),
+		seenMethods: make(map[*ast.FunctionDecl]bool),
 	}
 	m.collectTemplates(prog.Statements)
 	if len(m.templates) == 0 {
@@ -47,25 +53,55 @@ func Monomorphize(prog *ast.Program) {
 }
 
 type templateInfo struct {
-	decl   ast.Statement
-	params []string
+	decl    ast.Statement
+	params  []string
+	methods []*ast.FunctionDecl // out-of-line method implementations
+}
+
+// instance is a specialization of a template: its mangled name and the type
+// arguments it was generated for.
+type instance struct {
+	mangled string
+	args    []ast.TypeExpression
 }
 
 type monomorphizer struct {
 	templates map[string]templateInfo
 	emitted   map[string]bool
+	// instances lists the specializations generated so far for each template.
+	instances map[string][]instance
+	// seenMethods holds the template method implementations already passed
+	// while rewriting; later specializations emit them immediately.
+	seenMethods map[*ast.FunctionDecl]bool
 	// out is the statement list being built for the current pass; specialized
 	// declarations are appended here just before the statement that uses them.
 	out []ast.Statement
 }
 
-// collectTemplates records every generic template declaration by its base name.
+// collectTemplates records every generic template declaration by its base name,
+// together with the out-of-line implementations of its methods.
 // Multi-declaration `type` sections are parsed into a BlockStatement, so this
 // recurses into blocks to find templates declared there too.
 func (m *monomorphizer) collectTemplates(stmts []ast.Statement) {
+	var methods []*ast.FunctionDecl
+	m.collectDecls(stmts, &methods)
+	for _, fd := range methods {
+		key := ident.Normalize(fd.ClassName.Value)
+		if tpl, ok := m.templates[key]; ok {
+			tpl.methods = append(tpl.methods, fd)
+			m.templates[key] = tpl
+		}
+	}
+}
+
+func (m *monomorphizer) collectDecls(stmts []ast.Statement, methods *[]*ast.FunctionDecl) {
 	for _, stmt := range stmts {
 		if block, ok := stmt.(*ast.BlockStatement); ok {
-			m.collectTemplates(block.Statements)
+			m.collectDecls(block.Statements, methods)
+			continue
+		}
+		if fd := genericMethodImpl(stmt); fd != nil {
+			*methods = append(*methods, fd)
 			continue
 		}
 		if params := typeParamsOf(stmt); len(params) > 0 {
@@ -93,6 +129,15 @@ func (m *monomorphizer) rewriteStatements(stmts []ast.Statement) []ast.Statement
 		if isTemplateDecl(stmt) {
 			continue // templates are replaced by their specializations
 		}
+		if fd := genericMethodImpl(stmt); fd != nil && m.isTemplate(fd.ClassName.Value) {
+			// Specialize the implementation for the instantiations generated
+			// so far; later ones pick it up in ensureSpecialized.
+			m.seenMethods[fd] = true
+			for _, inst := range m.instances[ident.Normalize(fd.ClassName.Value)] {
+				m.specializeMethod(fd, inst)
+			}
+			continue
+		}
 		if block, ok := stmt.(*ast.BlockStatement); ok {
 			block.Statements = m.rewriteStatements(block.Statements)
 			if len(block.Statements) == 0 {
@@ -161,9 +206,40 @@ func (m *monomorphizer) ensureSpecialized(base string, args []ast.TypeExpression
 	// emitting their dependencies before it.
 	m.rewrite(reflect.ValueOf(clone))
 	m.out = append(m.out, clone)
+
+	inst := instance{mangled: mangled, args: args}
+	m.instances[ident.Normalize(base)] = append(m.instances[ident.Normalize(base)], inst)
+	for _, fd := range tpl.methods {
+		if m.seenMethods[fd] {
+			m.specializeMethod(fd, inst)
+		}
+	}
 	return mangled
 }
 
+// specializeMethod emits the implementation of a template method for one
+// instantiation: the type parameters named in the implementation header are
+// replaced by the instance's type arguments and the method is qualified by
+// the specialized type name.
+func (m *monomorphizer) specializeMethod(fd *ast.FunctionDecl, inst instance) {
+	subst := make(map[string]ast.TypeExpression, len(fd.ClassTypeParams))
+	for i, p := range fd.ClassTypeParams {
+		if i < len(inst.args) {
+			subst[ident.Normalize(p)] = inst.args[i]
+		}
+	}
+
+	clone, ok := cloneNode(reflect.ValueOf(fd), subst).Interface().(*ast.FunctionDecl)
+	if !ok {
+		return
+	}
+	clone.ClassName.Value = inst.mangled
+	clone.ClassTypeParams = nil
+
+	m.rewrite(reflect.ValueOf(clone))
+	m.out = append(m.out, clone)
+}
+
 // rewrite walks the tree at v, rewriting generic type references in place and
 // replacing GenericTypeRef expression nodes with plain identifiers carrying the
 // mangled name.
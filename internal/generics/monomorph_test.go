@@ -1,6 +1,7 @@
 package generics
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/cwbudde/go-dws/internal/lexer"
@@ -200,3 +201,59 @@ var p : TPair<Integer, String>;`)
 		t.Errorf("Second field type = %q, want String", got)
 	}
 }
+
+// findMethodImpls returns the out-of-line method implementations for className.
+func findMethodImpls(prog *ast.Program, className string) []*ast.FunctionDecl {
+	var methods []*ast.FunctionDecl
+	for _, stmt := range prog.Statements {
+		if fd, ok := stmt.(*ast.FunctionDecl); ok && fd.ClassName != nil && fd.ClassName.Value == className {
+			methods = append(methods, fd)
+		}
+	}
+	return methods
+}
+
+func TestMonomorphize_MethodImplementation_SpecializedPerInstance(t *testing.T) {
+	prog := parseProgram(t, `type TBox<T> = record Value : T; function Get : T; end;
+var a : TBox<Integer>;
+function TBox<T>.Get : T; begin Result := Value; end;
+var b : TBox<String>;`)
+	Monomorphize(prog)
+
+	if methods := findMethodImpls(prog, "TBox"); len(methods) != 0 {
+		t.Fatalf("template method implementation should have been removed, got %d", len(methods))
+	}
+	for _, name := range []string{"TBox<Integer>", "TBox<String>"} {
+		methods := findMethodImpls(prog, name)
+		if len(methods) != 1 {
+			t.Fatalf("expected 1 implementation of %s.Get, got %d", name, len(methods))
+		}
+		if len(methods[0].ClassTypeParams) != 0 {
+			t.Errorf("%s.Get should have no class type params, got %v", name, methods[0].ClassTypeParams)
+		}
+		want := name[len("TBox<") : len(name)-1]
+		if got := methods[0].ReturnType.String(); got != want {
+			t.Errorf("%s.Get return type = %q, want %q", name, got, want)
+		}
+	}
+
+	// TBox<Integer> is used before the template implementation, so its method
+	// follows the implementation's position rather than the specialization.
+	var order []string
+	for _, stmt := range prog.Statements {
+		switch s := stmt.(type) {
+		case *ast.FunctionDecl:
+			order = append(order, s.ClassName.Value+"."+s.Name.Value)
+		case *ast.VarDeclStatement:
+			order = append(order, "var")
+		default:
+			if n := declName(stmt); n != "" {
+				order = append(order, n)
+			}
+		}
+	}
+	want := "TBox<Integer> var TBox<Integer>.Get TBox<String> TBox<String>.Get var"
+	if got := strings.Join(order, " "); got != want {
+		t.Errorf("statement order = %q, want %q", got, want)
+	}
+}
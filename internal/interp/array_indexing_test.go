@@ -1454,3 +1454,51 @@ func TestBooleanIndexedArray(t *testing.T) {
 		t.Fatalf("expected output %q, got %q (val=%T %v)", expected, output, val, val)
 	}
 }
+
+// TestStaticArrayDeclaredBounds tests that static arrays index, report and
+// iterate over their declared bounds rather than zero-based positions.
+func TestStaticArrayDeclaredBounds(t *testing.T) {
+	input := `
+type TArr = array[-2..2] of Integer;
+var a: array[3..7] of Integer;
+var m: array[1..2, 5..6] of Integer;
+var i: Integer;
+for i := 3 to 7 do a[i] := i * 10;
+PrintLn(a[3]);
+PrintLn(IntToStr(Low(a)) + '..' + IntToStr(High(a)) + ' ' + IntToStr(Length(a)));
+PrintLn(IntToStr(Low(TArr)) + '..' + IntToStr(High(TArr)));
+for var v in a do Print(v, ' ');
+PrintLn('');
+m[2, 6] := 4;
+PrintLn(IntToStr(Low(m[1])) + '..' + IntToStr(High(m[1])));
+for var row in m do for var v in row do Print(v, ' ');
+PrintLn('');
+try
+  i := 8; PrintLn(a[i]);
+except on E: ERangeError do PrintLn(E.Message); end;
+try
+  i := 4; m[1, i] := 1;
+except on E: ERangeError do PrintLn(E.Message); end;
+`
+	_, output := testEvalWithOutput(input)
+
+	expected := []string{
+		"30",
+		"3..7 5",
+		"-2..2",
+		"30 40 50 60 70 ",
+		"5..6",
+		"0 0 0 4 ",
+		"Upper bound exceeded! Index 8 [",
+		"Lower bound exceeded! Index 4 [",
+	}
+	lines := strings.Split(output, "\n")
+	if len(lines) < len(expected) {
+		t.Fatalf("expected %d lines of output, got:\n%s", len(expected), output)
+	}
+	for i, want := range expected {
+		if !strings.HasPrefix(lines[i], want) {
+			t.Errorf("line %d = %q, want prefix %q", i+1, lines[i], want)
+		}
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"math/rand"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/cwbudde/go-dws/internal/builtins"
 	"github.com/cwbudde/go-dws/internal/errors"
@@ -48,6 +49,29 @@ func (i *Interpreter) SetRandSeed(seed int64) {
 	i.setRandomSeed(seed)
 }
 
+// RandomSeedFixed reports whether the host fixed the random seed.
+// This implements the builtins.Context interface.
+func (i *Interpreter) RandomSeedFixed() bool {
+	return i.engineState.FixedRandomSeed
+}
+
+// FormatSettings returns the separators used by the conversion builtins.
+// This implements the builtins.Context interface.
+func (i *Interpreter) FormatSettings() runtime.FormatSettings {
+	return i.engineState.FormatSettings
+}
+
+// CurrentTime returns the current time from the host clock.
+// This implements the builtins.Context interface.
+func (i *Interpreter) CurrentTime() time.Time {
+	return i.engineState.Clock()
+}
+
+// IntegerOverflowCheck reports whether checked Integer arithmetic is enabled.
+func (i *Interpreter) IntegerOverflowCheck() bool {
+	return i.engineState.IntegerOverflowCheck
+}
+
 // UnwrapVariant returns the underlying value if input is a Variant.
 func (i *Interpreter) UnwrapVariant(value builtins.Value) builtins.Value {
 	if value != nil {
@@ -230,6 +254,19 @@ func (i *Interpreter) GetClassOf(value builtins.Value) string {
 	return ""
 }
 
+// GetClassValueOf returns the runtime class of an object as a class value.
+func (i *Interpreter) GetClassValueOf(value builtins.Value) builtins.Value {
+	switch v := value.(type) {
+	case *ClassValue, *ClassInfoValue:
+		return value
+	case *ObjectInstance:
+		if ci, ok := v.Class.(*ClassInfo); ok {
+			return &ClassValue{ClassInfo: ci}
+		}
+	}
+	return nil
+}
+
 // JSONHasField checks if a JSON object value has a given field.
 func (i *Interpreter) JSONHasField(value builtins.Value, fieldName string) bool {
 	val := unwrapVariant(value)
@@ -701,106 +738,9 @@ func (i *Interpreter) ParseFloat(s string) (float64, bool) {
 	return floatValue, true
 }
 
-// FormatString formats a string using Go fmt.Sprintf semantics with DWScript values.
+// FormatString formats a string using Delphi Format semantics with DWScript values.
 func (i *Interpreter) FormatString(format string, args []builtins.Value) (string, error) {
-	type formatSpec struct {
-		verb  rune
-		index int
-	}
-	normalizeFloat := func(f float64) float64 {
-		// Clamp tiny magnitudes to zero to avoid "-0.00" artifacts when formatting.
-		if math.Abs(f) < 1e-12 || (f == 0 && math.Signbit(f)) {
-			return 0
-		}
-		return f
-	}
-
-	// Parse format specifiers
-	var specs []formatSpec
-	argIndex := 0
-	iStr := 0
-	for iStr < len(format) {
-		ch := format[iStr]
-		if ch == '%' {
-			if iStr+1 < len(format) && format[iStr+1] == '%' {
-				iStr += 2
-				continue
-			}
-			iStr++
-			// Skip width/precision/flags
-			for iStr < len(format) {
-				b := format[iStr]
-				if (b >= '0' && b <= '9') || b == '.' || b == '+' || b == '-' || b == ' ' || b == '#' {
-					iStr++
-					continue
-				}
-				break
-			}
-			if iStr < len(format) {
-				verb := rune(format[iStr])
-				if verb == 's' || verb == 'd' || verb == 'f' || verb == 'v' || verb == 'x' || verb == 'X' || verb == 'o' {
-					specs = append(specs, formatSpec{verb: verb, index: argIndex})
-					argIndex++
-				}
-				iStr++
-			}
-		} else {
-			iStr++
-		}
-	}
-
-	// Validate that we have the right number of arguments
-	if len(specs) != len(args) {
-		return "", fmt.Errorf("expects %d arguments for format string, got %d", len(specs), len(args))
-	}
-
-	// Convert DWScript values to Go interface{} values
-	goArgs := make([]interface{}, len(args))
-	for idx, elem := range args {
-		if idx >= len(specs) {
-			break
-		}
-		spec := specs[idx]
-		unwrapped := unwrapVariant(elem)
-
-		switch v := unwrapped.(type) {
-		case *IntegerValue:
-			switch spec.verb {
-			case 'd', 'x', 'X', 'o', 'v':
-				goArgs[idx] = v.Value
-			case 'f':
-				goArgs[idx] = normalizeFloat(float64(v.Value))
-			case 's':
-				goArgs[idx] = fmt.Sprintf("%d", v.Value)
-			default:
-				return "", fmt.Errorf("cannot use %%%c with Integer value at index %d", spec.verb, idx)
-			}
-		case *FloatValue:
-			switch spec.verb {
-			case 'f', 'v':
-				goArgs[idx] = normalizeFloat(v.Value)
-			case 's':
-				goArgs[idx] = fmt.Sprintf("%f", v.Value)
-			default:
-				return "", fmt.Errorf("cannot use %%%c with Float value at index %d", spec.verb, idx)
-			}
-		case *StringValue:
-			switch spec.verb {
-			case 's', 'v':
-				goArgs[idx] = v.Value
-			case 'd', 'x', 'X', 'o', 'f':
-				return "", fmt.Errorf("cannot use %%%c with String value at index %d", spec.verb, idx)
-			default:
-				goArgs[idx] = v.Value
-			}
-		case *BooleanValue:
-			goArgs[idx] = v.Value
-		default:
-			return "", fmt.Errorf("cannot format value of type %s at index %d", unwrapped.Type(), idx)
-		}
-	}
-
-	return fmt.Sprintf(format, goArgs...), nil
+	return builtins.FormatValues(format, args, i.engineState.FormatSettings)
 }
 
 // GetLowBound returns the lower bound for arrays, enums, or type meta-values.
@@ -417,3 +417,70 @@ PrintLn(Ord(High(TColor)));
 		})
 	}
 }
+
+// TestDefaultValues tests Default(T) for primitive and structured types.
+func TestDefaultValues(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{
+			name:     "Default(Integer)",
+			input:    "PrintLn(Default(Integer));",
+			expected: "0\n",
+		},
+		{
+			name:     "Default(String)",
+			input:    "PrintLn('[' + Default(String) + ']');",
+			expected: "[]\n",
+		},
+		{
+			name: "Default of a record",
+			input: `
+type TPoint = record X, Y: Integer; Name: String; end;
+var p: TPoint;
+p.X := 5; p.Name := 'a';
+p := Default(TPoint);
+PrintLn(p.X);
+PrintLn(p.Name = '');
+`,
+			expected: "0\nTrue\n",
+		},
+		{
+			name: "Default of a class type",
+			input: `
+type TObj = class end;
+var o := TObj.Create;
+o := Default(TObj);
+PrintLn(o = nil);
+`,
+			expected: "True\n",
+		},
+		{
+			name: "Default of array, set and enum types",
+			input: `
+type TColor = (Red, Green);
+type TColors = set of TColor;
+type TInts = array of Integer;
+var a: TInts := [1, 2];
+a := Default(TInts);
+var s: TColors := [Green];
+s := Default(TColors);
+PrintLn(a.Length);
+PrintLn(Green in s);
+PrintLn(Ord(Default(TColor)));
+`,
+			expected: "0\nFalse\n0\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, output := testEvalWithOutput(tt.input)
+			if output != tt.expected {
+				t.Errorf("output mismatch:\ngot:  %q\nwant: %q", output, tt.expected)
+			}
+		})
+	}
+}
@@ -209,6 +209,60 @@ func (c *ClassInfo) GetOwnProperties() []*runtime.PropertyInfo {
 	return props
 }
 
+// GetOwnMethods returns the instance and class methods declared directly on
+// this class (not inherited), one declaration per overload. The order is
+// unspecified. Used by RTTI to enumerate a class level's methods.
+func (c *ClassInfo) GetOwnMethods() []*ast.FunctionDecl {
+	if c == nil {
+		return nil
+	}
+	// Inherited methods are copied into the method maps, so skip the
+	// declarations the parent already has.
+	seen := make(map[*ast.FunctionDecl]bool)
+	c.Parent.eachMethodDecl(func(decl *ast.FunctionDecl) { seen[decl] = true })
+
+	var methods []*ast.FunctionDecl
+	c.eachMethodDecl(func(decl *ast.FunctionDecl) {
+		if !seen[decl] {
+			seen[decl] = true
+			methods = append(methods, decl)
+		}
+	})
+	return methods
+}
+
+// eachMethodDecl calls fn for every instance and class method declaration in
+// the class's method maps, including inherited ones.
+func (c *ClassInfo) eachMethodDecl(fn func(*ast.FunctionDecl)) {
+	if c == nil {
+		return
+	}
+	for _, decl := range c.Methods {
+		if decl != nil {
+			fn(decl)
+		}
+	}
+	for _, overloads := range c.MethodOverloads {
+		for _, decl := range overloads {
+			if decl != nil {
+				fn(decl)
+			}
+		}
+	}
+	for _, decl := range c.ClassMethods {
+		if decl != nil {
+			fn(decl)
+		}
+	}
+	for _, overloads := range c.ClassMethodOverloads {
+		for _, decl := range overloads {
+			if decl != nil {
+				fn(decl)
+			}
+		}
+	}
+}
+
 // FieldExists checks if a field exists
 func (c *ClassInfo) FieldExists(normalizedName string) bool {
 	if c == nil {
@@ -407,7 +407,8 @@ func (c *ClassInfo) RegisterOperatorBinding(operatorSymbol, bindingName string,
 		}
 		normalizedOperands = append(normalizedOperands, key)
 	}
-	if !includesClass {
+	// A single operand type is always combined with the class itself.
+	if len(normalizedOperands) == 1 || !includesClass {
 		if ident.Equal(operatorSymbol, "in") {
 			normalizedOperands = append(normalizedOperands, classKey)
 		} else {
@@ -578,7 +579,7 @@ func (c *ClassInfo) propagateConstructorImplementation(allClasses map[string]int
 func replaceMethodInOverloadListNoReceiver(list []*ast.FunctionDecl, impl *ast.FunctionDecl) []*ast.FunctionDecl {
 	for idx, decl := range list {
 		if parameterTypesEqualFold(decl.Parameters, impl.Parameters) {
-			mergeParameterDefaults(impl, decl)
+			runtime.MergeParameterDefaults(impl, decl)
 			list[idx] = impl
 			return list
 		}
@@ -604,17 +605,3 @@ func parameterTypesEqualFold(params1, params2 []*ast.Parameter) bool {
 	}
 	return true
 }
-
-// mergeParameterDefaults copies parameter default values from the class
-// declaration into an out-of-line implementation that did not respecify them
-// (DWScript's "default not respecified" rule).
-func mergeParameterDefaults(impl, decl *ast.FunctionDecl) {
-	if impl == nil || decl == nil || len(impl.Parameters) != len(decl.Parameters) {
-		return
-	}
-	for i, declParam := range decl.Parameters {
-		if impl.Parameters[i].DefaultValue == nil && declParam.DefaultValue != nil {
-			impl.Parameters[i].DefaultValue = declParam.DefaultValue
-		}
-	}
-}
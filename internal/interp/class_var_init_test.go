@@ -228,3 +228,137 @@ PrintLn(IntToStr(TBase.Value));
 		})
 	}
 }
+
+func TestClassVarInitializedOnceAndShared(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{
+			name: "initializer runs once regardless of instance count",
+			input: `
+var calls: Integer;
+function NextID: Integer;
+begin
+	Inc(calls);
+	Result := calls * 100;
+end;
+
+type TFoo = class
+	class var Seed: Integer := NextID;
+end;
+
+PrintLn('start');
+var a := TFoo.Create;
+var b := TFoo.Create;
+var c := TFoo.Create;
+PrintLn(IntToStr(calls));
+PrintLn(IntToStr(a.Seed) + ' ' + IntToStr(b.Seed) + ' ' + IntToStr(c.Seed));
+`,
+			expected: "start\n1\n100 100 100\n",
+		},
+		{
+			name: "write through one instance is visible to others",
+			input: `
+type TFoo = class
+	class var Shared: Integer := 100;
+end;
+
+var a := TFoo.Create;
+var b := TFoo.Create;
+a.Shared := 5;
+PrintLn(IntToStr(b.Shared));
+PrintLn(IntToStr(TFoo.Shared));
+b.Shared += 2;
+PrintLn(IntToStr(a.Shared));
+`,
+			expected: "5\n5\n7\n",
+		},
+		{
+			name: "instance method updates shared slot",
+			input: `
+type TFoo = class
+	class var Count: Integer;
+	procedure Bump; begin Count := Count + 1; end;
+end;
+
+var a := TFoo.Create;
+var b := TFoo.Create;
+a.Bump;
+b.Bump;
+a.Bump;
+PrintLn(IntToStr(TFoo.Count));
+PrintLn(IntToStr(b.Count));
+`,
+			expected: "3\n3\n",
+		},
+		{
+			name: "descendants share the ancestor's class var",
+			input: `
+type TFoo = class
+	class var Shared: Integer := 1;
+end;
+type TBar = class(TFoo) end;
+
+var c := TBar.Create;
+TBar.Shared := 10;
+PrintLn(IntToStr(TFoo.Shared));
+c.Shared := 20;
+PrintLn(IntToStr(TFoo.Shared));
+PrintLn(IntToStr(TFoo.Create.Shared));
+`,
+			expected: "10\n20\n20\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, output := testEvalClassVarInit(tt.input)
+			if isError(result) {
+				t.Fatalf("interpreter error: %s", result.String())
+			}
+			if output != tt.expected {
+				t.Errorf("wrong output. expected=%q, got=%q", tt.expected, output)
+			}
+		})
+	}
+}
+
+// TestClassVarInitializedBeforeFirstStatement verifies that class var
+// initializers run at program start, in declaration order, and can read
+// class constants and earlier class vars by their bare names.
+func TestClassVarInitializedBeforeFirstStatement(t *testing.T) {
+	input := `
+var order: String;
+function Trace(name: String; value: Integer): Integer;
+begin
+	order := order + name + ';';
+	Result := value;
+end;
+
+PrintLn('start ' + order);
+
+type TFirst = class
+	const Base = 10;
+	const Double = Base * 2;
+	class var A: Integer := Trace('A', Double + 1);
+	class var B: Integer := Trace('B', A * 2);
+end;
+
+type TSecond = class
+	class var C: Integer := Trace('C', TFirst.B + 1);
+end;
+
+PrintLn(IntToStr(TFirst.A) + ' ' + IntToStr(TFirst.B) + ' ' + IntToStr(TSecond.C));
+PrintLn(order);
+`
+	result, output := testEvalClassVarInit(input)
+	if isError(result) {
+		t.Fatalf("interpreter error: %s", result.String())
+	}
+	expected := "start A;B;C;\n21 42 43\nA;B;C;\n"
+	if output != expected {
+		t.Errorf("wrong output. expected=%q, got=%q", expected, output)
+	}
+}
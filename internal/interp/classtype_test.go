@@ -303,3 +303,107 @@ end.`
 		t.Errorf("Expected output:\n%s\n\nGot:\n%s", expected, output)
 	}
 }
+
+// TestGetClassBuiltin tests GetClass() returning an object's runtime metaclass
+func TestGetClassBuiltin(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{
+			name: "runtime class of object held in base variable",
+			input: `
+type TBase = class
+end;
+type TDerived = class(TBase)
+end;
+
+var obj: TBase := TDerived.Create;
+PrintLn(GetClass(obj).ClassName);
+PrintLn(GetClass(obj) = TDerived);`,
+			expected: "TDerived\nTrue\n",
+		},
+		{
+			name: "virtual construction through GetClass",
+			input: `
+type TAnimal = class
+	constructor Create; virtual;
+	function Speak: String; virtual;
+end;
+type TDog = class(TAnimal)
+	constructor Create; override;
+	function Speak: String; override;
+end;
+
+constructor TAnimal.Create;
+begin
+	PrintLn('TAnimal.Create');
+end;
+
+function TAnimal.Speak: String;
+begin
+	Result := '...';
+end;
+
+constructor TDog.Create;
+begin
+	inherited;
+	PrintLn('TDog.Create');
+end;
+
+function TDog.Speak: String;
+begin
+	Result := 'Woof';
+end;
+
+function Clone(a: TAnimal): TAnimal;
+begin
+	Result := GetClass(a).Create;
+end;
+
+var original: TAnimal := TDog.Create;
+var copy := Clone(original);
+PrintLn(copy.ClassName);
+PrintLn(copy.Speak);
+PrintLn(copy <> original);`,
+			expected: "TAnimal.Create\nTDog.Create\nTAnimal.Create\nTDog.Create\nTDog\nWoof\nTrue\n",
+		},
+		{
+			name: "class reference argument is returned as-is",
+			input: `
+type TBase = class
+end;
+
+var meta: class of TBase := TBase;
+PrintLn(GetClass(meta).ClassName);`,
+			expected: "TBase\n",
+		},
+		{
+			name: "nil object raises",
+			input: `
+type TBase = class
+end;
+
+var obj: TBase;
+try
+	GetClass(obj);
+except
+	on E: Exception do PrintLn(E.Message);
+end;`,
+			expected: "Object not instantiated\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, output := testEvalWithOutput(tt.input)
+			if isError(result) {
+				t.Fatalf("interpreter error: %s", result.String())
+			}
+			if output != tt.expected {
+				t.Errorf("Expected output:\n%s\n\nGot:\n%s", tt.expected, output)
+			}
+		})
+	}
+}
@@ -2,6 +2,7 @@ package contracts
 
 import (
 	"math/rand"
+	"time"
 
 	"github.com/cwbudde/go-dws/internal/interp/runtime"
 	"github.com/cwbudde/go-dws/internal/units"
@@ -62,6 +63,18 @@ type EngineState struct {
 	LoadedUnits            []string
 	RandomSeed             int64
 	MaxRecursionDepth      int
+	VariantNumericRule     runtime.VariantNumericRule
+	IntegerOverflowCheck   bool
+	RangeChecks            bool
+	DisableAssertions      bool
+	FixedRandomSeed        bool
+	DisableDestructors     bool
+	FormatSettings         runtime.FormatSettings
+	Clock                  func() time.Time
+	ContractMode           runtime.ContractMode
+	RecursionStrategy      runtime.RecursionStrategy
+	// Values is the pool arithmetic results are drawn from.
+	Values *runtime.ValuePool
 }
 
 // The old callback-style focused interfaces were removed during Phase 4.
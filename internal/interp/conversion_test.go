@@ -1438,3 +1438,43 @@ end
 		})
 	}
 }
+
+// TestBuiltinFloatToStrFAndDateTimeParsing tests FloatToStrF with the float
+// format constants, FormatDateTime pictures and the EConvertError raised by
+// the StrToDate family.
+func TestBuiltinFloatToStrFAndDateTimeParsing(t *testing.T) {
+	input := `
+PrintLn(FloatToStrF(3.14159, ffFixed, 15, 2));
+PrintLn(FloatToStrF(1234567.891, ffNumber, 15, 2));
+PrintLn(FloatToStrF(1234.56, ffExponent, 4, 2));
+PrintLn(FloatToStrF(0.5, ffGeneral, 15, 0));
+PrintLn(IntToHex(255, 4));
+var dt := StrToDateTime('2024-03-05 14:07:09');
+PrintLn(FormatDateTime('yyyy-mm-dd hh:nn:ss', dt));
+PrintLn(FormatDateTime('d/m/yy h:nn am/pm', dt));
+PrintLn(FormatDateTime('hh:nn:ss.zzz', StrToTime('08:30:15')));
+PrintLn(FormatDateTime('yyyy-mm-dd hh:nn', -1.25));
+try
+  StrToDate('not a date');
+except
+  on E: EConvertError do PrintLn(E.Message);
+end;
+`
+	result, output := testEvalWithOutputAndSemantic(t, input)
+	if isError(result) {
+		t.Fatalf("unexpected error: %s", result.String())
+	}
+	want := "3.14\n" +
+		"1,234,567.89\n" +
+		"1.235E+03\n" +
+		"0.5\n" +
+		"00FF\n" +
+		"2024-03-05 14:07:09\n" +
+		"5/3/24 2:07 pm\n" +
+		"08:30:15.000\n" +
+		"1899-12-29 06:00\n" +
+		"Date/time parsing error for \"not a date\" [line: 13, column: 3]\n"
+	if output != want {
+		t.Errorf("output = %q, want %q", output, want)
+	}
+}
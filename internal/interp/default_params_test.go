@@ -0,0 +1,82 @@
+package interp
+
+import "testing"
+
+// TestDefaultParameterInvocation tests that omitted trailing arguments take
+// their declared default values for functions, methods, record methods,
+// helpers and constructors.
+func TestDefaultParameterInvocation(t *testing.T) {
+	input := `
+function Scale(x: Integer; factor: Integer = 2; suffix: String = ''): String;
+begin
+  Result := IntToStr(x * factor) + suffix;
+end;
+
+type TC = class
+  FValue: Integer;
+  constructor Make(a: Integer = 2);
+  function Add(b: Integer = 10): Integer;
+end;
+
+constructor TC.Make(a: Integer);
+begin
+  FValue := a;
+end;
+
+function TC.Add(b: Integer): Integer;
+begin
+  Result := FValue + b;
+end;
+
+type TR = record
+  X: Integer;
+  function Twice(a: Integer = 7): Integer;
+  class function Neg(a: Integer = 3): Integer;
+end;
+
+function TR.Twice(a: Integer): Integer;
+begin
+  Result := a * 2;
+end;
+
+class function TR.Neg(a: Integer): Integer;
+begin
+  Result := -a;
+end;
+
+type TIntHelper = helper for Integer
+  function Plus(n: Integer = 1): Integer;
+end;
+
+function TIntHelper.Plus(n: Integer): Integer;
+begin
+  Result := Self + n;
+end;
+
+PrintLn(Scale(1));
+PrintLn(Scale(1, 3));
+PrintLn(Scale(1, 3, '!'));
+
+var c := TC.Make;
+PrintLn(c.Add);
+PrintLn(c.Add(1));
+c := TC.Make(5);
+PrintLn(c.Add());
+
+var r: TR;
+PrintLn(r.Twice);
+PrintLn(r.Twice(1));
+PrintLn(TR.Neg);
+PrintLn(TR.Neg(4));
+
+var i := 5;
+PrintLn(i.Plus);
+PrintLn(i.Plus(3));
+`
+
+	_, output := testEvalWithOutputAndSemantic(t, input)
+	expected := "2\n3\n3!\n12\n3\n15\n14\n2\n-3\n-4\n6\n8\n"
+	if output != expected {
+		t.Errorf("expected output %q, got %q", expected, output)
+	}
+}
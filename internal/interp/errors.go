@@ -15,6 +15,14 @@ import (
 type ErrorValue struct {
 	Err     *interpErrors.InterpreterError
 	Message string
+	// ExceptionClass is the DWScript exception class of an uncaught script
+	// exception (see runtime.ErrorValue.ExceptionClass).
+	ExceptionClass string
+	// Pos is where the error was raised, when known (see runtime.ErrorValue.Pos).
+	Pos *lexer.Position
+	// Detail is the uncaught exception's extra information (see
+	// runtime.ErrorValue.Detail).
+	Detail string
 }
 
 func (e *ErrorValue) Type() string   { return "ERROR" }
@@ -69,6 +69,24 @@ func (e *Evaluator) extractIntegerValue(val Value) (int, error) {
 
 // evalArrayLiteralDirect evaluates an array literal without adapter delegation.
 // Gets type from annotation or context, evaluates elements, coerces to target type, validates bounds.
+// isSetAnnotatedArrayLiteral reports whether semantic analysis typed the
+// bracket literal as a set.
+func (e *Evaluator) isSetAnnotatedArrayLiteral(node *ast.ArrayLiteralExpression, ctx *ExecutionContext) bool {
+	if e.SemanticInfo() == nil {
+		return false
+	}
+	typeAnnot := e.SemanticInfo().GetType(node)
+	if typeAnnot == nil || typeAnnot.Name == "" {
+		return false
+	}
+	if resolvedType, err := e.ResolveTypeWithContext(typeAnnot.Name, ctx); err == nil {
+		_, isSet := types.GetUnderlyingType(resolvedType).(*types.SetType)
+		return isSet
+	}
+	// Inline "set of X" annotations may not resolve as named types.
+	return e.parseInlineSetType(typeAnnot.Name) != nil
+}
+
 func (e *Evaluator) evalArrayLiteralDirect(node *ast.ArrayLiteralExpression, ctx *ExecutionContext) Value {
 	if node == nil {
 		return e.newError(node, "nil array literal")
@@ -76,28 +94,18 @@ func (e *Evaluator) evalArrayLiteralDirect(node *ast.ArrayLiteralExpression, ctx
 
 	// Disambiguation: `[...]` can represent a set literal when semantic analysis expects a SET.
 	// Some contexts (notably empty literals `[]`) otherwise look like an empty array literal.
-	if e.SemanticInfo() != nil {
-		if typeAnnot := e.SemanticInfo().GetType(node); typeAnnot != nil && typeAnnot.Name != "" {
-			isSetAnnotation := false
-			if resolvedType, err := e.ResolveTypeWithContext(typeAnnot.Name, ctx); err == nil {
-				_, isSetAnnotation = types.GetUnderlyingType(resolvedType).(*types.SetType)
-			} else if e.parseInlineSetType(typeAnnot.Name) != nil {
-				// Inline "set of X" annotations may not resolve as named types.
-				isSetAnnotation = true
-			}
-			if isSetAnnotation {
-				setLit := &ast.SetLiteral{
-					Elements:            node.Elements,
-					TypedExpressionBase: node.TypedExpressionBase,
-				}
+	if e.isSetAnnotatedArrayLiteral(node, ctx) {
+		typeAnnot := e.SemanticInfo().GetType(node)
+		setLit := &ast.SetLiteral{
+			Elements:            node.Elements,
+			TypedExpressionBase: node.TypedExpressionBase,
+		}
 
-				// Preserve the type annotation for set inference (esp. for empty `[]`).
-				e.SemanticInfo().SetType(setLit, typeAnnot)
-				defer e.SemanticInfo().ClearType(setLit)
+		// Preserve the type annotation for set inference (esp. for empty `[]`).
+		e.SemanticInfo().SetType(setLit, typeAnnot)
+		defer e.SemanticInfo().ClearType(setLit)
 
-				return e.evalSetLiteralDirect(setLit, ctx)
-			}
-		}
+		return e.evalSetLiteralDirect(setLit, ctx)
 	}
 
 	// Use context type if available
@@ -667,7 +675,7 @@ func (e *Evaluator) raiseArrayBoundExceeded(node ast.Node, index int, upper bool
 	}
 	message = fmt.Sprintf("%s [line: %d, column: %d]", message, pos.Line, pos.Column)
 	if ctx != nil {
-		exc := e.createException("Exception", message, &pos, ctx)
+		exc := e.createException("ERangeError", message, &pos, ctx)
 		ctx.SetException(exc)
 	}
 	return e.nilValue()
@@ -696,6 +704,22 @@ func (e *Evaluator) raiseIndexBoundExceeded(node ast.Node, index int, upper bool
 
 // raiseIndexBoundExceededAt is raiseIndexBoundExceeded with an explicit position.
 func (e *Evaluator) raiseIndexBoundExceededAt(pos token.Position, index int, upper bool) Value {
+	return e.raiseBoundExceededAt(pos, index, upper, "")
+}
+
+// raiseStaticIndexBoundExceededAt raises the "bound exceeded" exception for an
+// out-of-range index into a static array. The message matches DWScript; the
+// declared bounds go in the exception's Detail.
+func (e *Evaluator) raiseStaticIndexBoundExceededAt(pos token.Position, index int, arrayType *types.ArrayType) Value {
+	low, high := *arrayType.LowBound, *arrayType.HighBound
+	detail := fmt.Sprintf("valid range %d..%d", low, high)
+	return e.raiseBoundExceededAt(pos, index, index >= low, detail)
+}
+
+// raiseBoundExceededAt sets a catchable ERangeError reading
+// "<Lower|Upper> bound exceeded! Index <index>" at pos, with detail as the
+// exception's Detail.
+func (e *Evaluator) raiseBoundExceededAt(pos token.Position, index int, upper bool, detail string) Value {
 	ctx := e.currentContext
 	boundWord := "Lower"
 	if upper {
@@ -709,7 +733,10 @@ func (e *Evaluator) raiseIndexBoundExceededAt(pos token.Position, index int, upp
 	}
 	message = fmt.Sprintf("%s [line: %d, column: %d]", message, pos.Line, pos.Column)
 	if ctx != nil {
-		exc := e.createException("Exception", message, &pos, ctx)
+		exc := e.createException("ERangeError", message, &pos, ctx)
+		if excVal, ok := exc.(*runtime.ExceptionValue); ok {
+			excVal.Detail = detail
+		}
 		ctx.SetException(exc)
 	}
 	return e.nilValue()
@@ -807,6 +834,9 @@ func (e *Evaluator) evalArraySortMethod(selfValue Value, args []Value, node ast.
 	}
 
 	if len(args) == 0 {
+		if sortErr := e.sortByLessOperator(arrVal, node); sortErr != nil {
+			return sortErr
+		}
 		runtime.ArrayHelperSort(arrVal)
 		return arrVal
 	}
@@ -840,6 +870,42 @@ func (e *Evaluator) evalArraySortMethod(selfValue Value, args []Value, node ast.
 	return arrVal
 }
 
+// sortByLessOperator sorts record and object elements using their overloaded
+// '<' operator. Arrays of other element types are left to ArrayHelperSort.
+func (e *Evaluator) sortByLessOperator(arrVal *runtime.ArrayValue, node ast.Node) Value {
+	if len(arrVal.Elements) < 2 {
+		return nil
+	}
+	switch arrVal.Elements[0].(type) {
+	case *runtime.RecordValue, *runtime.ObjectInstance:
+	default:
+		return nil
+	}
+
+	var sortErr Value
+	sort.SliceStable(arrVal.Elements, func(i, j int) bool {
+		if sortErr != nil {
+			return false
+		}
+		result, found := e.tryBinaryOperator("<", arrVal.Elements[i], arrVal.Elements[j], node)
+		if !found {
+			sortErr = e.newError(node, "Array does not have a natural sort order")
+			return false
+		}
+		if isError(result) {
+			sortErr = result
+			return false
+		}
+		less, ok := result.(*runtime.BooleanValue)
+		if !ok {
+			sortErr = e.newError(node, "operator '<' must return Boolean, got %s", result.Type())
+			return false
+		}
+		return less.Value
+	})
+	return sortErr
+}
+
 // evalArrayInsert inserts a value at the given index, shifting later elements.
 // The index may range over 0..Length (appending when equal to Length).
 func (e *Evaluator) evalArrayInsert(selfValue Value, args []Value, node ast.Node) Value {
@@ -295,9 +295,10 @@ func (e *Evaluator) assignToSelfMember(
 	return nil
 }
 
-// assignToClassVarViaSelf assigns to a class variable accessed through Self.
+// assignToClassVarViaSelf assigns to a class variable accessed through Self
+// or another instance of the class.
 func (e *Evaluator) assignToClassVarViaSelf(
-	target *ast.Identifier,
+	target ast.Node,
 	targetName string,
 	value Value,
 	selfVal Value,
@@ -438,6 +439,13 @@ func (e *Evaluator) prepareValueForAssignment(
 	if targetType != sourceType {
 		if converted, ok := e.TryImplicitConversion(value, targetType, ctx); ok {
 			value = converted
+		} else if array, isArray := existingVal.(*runtime.ArrayValue); isArray {
+			// Arrays report "ARRAY" as their type; convert by the array type.
+			if variant, isVariant := value.(*runtime.VariantValue); isVariant {
+				if converted, ok := e.convertJSONVariant(variant, array.ArrayType); ok {
+					value = converted
+				}
+			}
 		}
 	}
 
@@ -600,7 +608,7 @@ func (e *Evaluator) evalSubrangeAssignment(
 
 	// Validate the value is in range
 	if err := subrangeVal.ValidateAndSet(intValue); err != nil {
-		return e.newError(target, "%s", err.Error())
+		return e.newErrorOfClass(target, "ERangeError", "%s", err.Error())
 	}
 
 	// Return the subrange value (modified in place)
@@ -1,6 +1,7 @@
 package evaluator
 
 import (
+	"fmt"
 	"math"
 	"strings"
 
@@ -333,26 +334,22 @@ func (e *Evaluator) evalIntegerBinaryOp(op string, left, right Value, node ast.N
 	rightVal := rightInt.Value
 
 	switch op {
-	case "+":
-		return &runtime.IntegerValue{Value: leftVal + rightVal}
-	case "-":
-		return &runtime.IntegerValue{Value: leftVal - rightVal}
-	case "*":
-		return &runtime.IntegerValue{Value: leftVal * rightVal}
+	case "+", "-", "*":
+		return e.integerArithmetic(op, leftVal, rightVal, node)
 	case "/":
 		if rightVal == 0 {
-			return e.newError(node, "division by zero: %d / %d", leftVal, rightVal)
+			return e.newErrorOfClass(node, "EDivByZero", "division by zero: %d / %d", leftVal, rightVal)
 		}
 		// Integer division in DWScript uses / for float division
 		return &runtime.FloatValue{Value: float64(leftVal) / float64(rightVal)}
 	case "div":
 		if rightVal == 0 {
-			return e.newError(node, "division by zero: %d div %d", leftVal, rightVal)
+			return e.newErrorOfClass(node, "EDivByZero", "division by zero: %d div %d", leftVal, rightVal)
 		}
 		return &runtime.IntegerValue{Value: leftVal / rightVal}
 	case "mod":
 		if rightVal == 0 {
-			return e.newError(node, "modulo by zero: %d mod %d", leftVal, rightVal)
+			return e.newErrorOfClass(node, "EDivByZero", "modulo by zero: %d mod %d", leftVal, rightVal)
 		}
 		return &runtime.IntegerValue{Value: leftVal % rightVal}
 	case "shl":
@@ -380,18 +377,8 @@ func (e *Evaluator) evalIntegerBinaryOp(op string, left, right Value, node ast.N
 	case "xor":
 		// Bitwise XOR for integers
 		return &runtime.IntegerValue{Value: leftVal ^ rightVal}
-	case "=":
-		return &runtime.BooleanValue{Value: leftVal == rightVal}
-	case "<>":
-		return &runtime.BooleanValue{Value: leftVal != rightVal}
-	case "<":
-		return &runtime.BooleanValue{Value: leftVal < rightVal}
-	case ">":
-		return &runtime.BooleanValue{Value: leftVal > rightVal}
-	case "<=":
-		return &runtime.BooleanValue{Value: leftVal <= rightVal}
-	case ">=":
-		return &runtime.BooleanValue{Value: leftVal >= rightVal}
+	case "=", "<>", "<", ">", "<=", ">=":
+		return e.evalComparison(op, leftInt, rightInt, node)
 	default:
 		return e.newError(node, "unknown operator: %s %s %s", left.Type(), op, right.Type())
 	}
@@ -400,43 +387,31 @@ func (e *Evaluator) evalIntegerBinaryOp(op string, left, right Value, node ast.N
 // evalFloatBinaryOp evaluates binary operations on floats.
 // Handles mixed integer/float operations by converting to float.
 func (e *Evaluator) evalFloatBinaryOp(op string, left, right Value, node ast.Node) Value {
-	var leftVal, rightVal float64
-
 	// Unwrap Variant values before processing
 	left = unwrapVariant(left)
 	right = unwrapVariant(right)
 
-	// Convert left operand to float
-	switch v := left.(type) {
-	case *runtime.FloatValue:
-		leftVal = v.Value
-	case *runtime.IntegerValue:
-		leftVal = float64(v.Value)
-	default:
+	// Convert both operands to float
+	leftVal, ok := asFloatOperand(left)
+	if !ok {
 		return e.newError(node, "type error in float operation: expected FLOAT or INTEGER, got %s", left.Type())
 	}
-
-	// Convert right operand to float
-	switch v := right.(type) {
-	case *runtime.FloatValue:
-		rightVal = v.Value
-	case *runtime.IntegerValue:
-		rightVal = float64(v.Value)
-	default:
+	rightVal, ok := asFloatOperand(right)
+	if !ok {
 		return e.newError(node, "type error in float operation: expected FLOAT or INTEGER, got %s", right.Type())
 	}
 
 	switch op {
 	case "+":
-		return &runtime.FloatValue{Value: leftVal + rightVal}
+		return e.newFloat(leftVal + rightVal)
 	case "-":
-		return &runtime.FloatValue{Value: leftVal - rightVal}
+		return e.newFloat(leftVal - rightVal)
 	case "*":
-		return &runtime.FloatValue{Value: leftVal * rightVal}
+		return e.newFloat(leftVal * rightVal)
 	case "/":
 		// DWScript float division by zero yields ±Inf / NaN (not an error),
 		// matching IEEE-754 semantics. Go's float64 division does the same.
-		return &runtime.FloatValue{Value: leftVal / rightVal}
+		return e.newFloat(leftVal / rightVal)
 	case "mod":
 		// DWScript permits mod on floats (Delphi fmod semantics).
 		m := math.Mod(leftVal, rightVal)
@@ -444,18 +419,10 @@ func (e *Evaluator) evalFloatBinaryOp(op string, left, right Value, node ast.Nod
 			m = 0 // normalize negative zero to +0 (DWScript prints "0")
 		}
 		return &runtime.FloatValue{Value: m}
-	case "=":
-		return &runtime.BooleanValue{Value: leftVal == rightVal}
-	case "<>":
-		return &runtime.BooleanValue{Value: leftVal != rightVal}
-	case "<":
-		return &runtime.BooleanValue{Value: leftVal < rightVal}
-	case ">":
-		return &runtime.BooleanValue{Value: leftVal > rightVal}
-	case "<=":
-		return &runtime.BooleanValue{Value: leftVal <= rightVal}
-	case ">=":
-		return &runtime.BooleanValue{Value: leftVal >= rightVal}
+	case "=", "<>", "<", ">", "<=", ">=":
+		// Mixed Integer/Float operands compare as floats (see
+		// runtime.IntegerValue.CompareTo).
+		return e.evalComparison(op, left, right, node)
 	default:
 		return e.newError(node, "unknown operator: %s %s %s", left.Type(), op, right.Type())
 	}
@@ -477,24 +444,11 @@ func (e *Evaluator) evalStringBinaryOp(op string, left, right Value, node ast.No
 		return e.newError(node, "expected string, got %s", right.Type())
 	}
 
-	leftVal := leftStr.Value
-	rightVal := rightStr.Value
-
 	switch op {
 	case "+":
-		return &runtime.StringValue{Value: leftVal + rightVal}
-	case "=":
-		return &runtime.BooleanValue{Value: leftVal == rightVal}
-	case "<>":
-		return &runtime.BooleanValue{Value: leftVal != rightVal}
-	case "<":
-		return &runtime.BooleanValue{Value: leftVal < rightVal}
-	case ">":
-		return &runtime.BooleanValue{Value: leftVal > rightVal}
-	case "<=":
-		return &runtime.BooleanValue{Value: leftVal <= rightVal}
-	case ">=":
-		return &runtime.BooleanValue{Value: leftVal >= rightVal}
+		return &runtime.StringValue{Value: leftStr.Value + rightStr.Value}
+	case "=", "<>", "<", ">", "<=", ">=":
+		return e.evalComparison(op, leftStr, rightStr, node)
 	default:
 		return e.newError(node, "unknown operator: %s %s %s", left.Type(), op, right.Type())
 	}
@@ -526,10 +480,8 @@ func (e *Evaluator) evalBooleanBinaryOp(op string, left, right Value, node ast.N
 		return &runtime.BooleanValue{Value: leftVal || rightVal}
 	case "xor":
 		return &runtime.BooleanValue{Value: leftVal != rightVal}
-	case "=":
-		return &runtime.BooleanValue{Value: leftVal == rightVal}
-	case "<>":
-		return &runtime.BooleanValue{Value: leftVal != rightVal}
+	case "=", "<>":
+		return e.evalComparison(op, leftBool, rightBool, node)
 	default:
 		return e.newError(node, "unknown operator: %s %s %s", left.Type(), op, right.Type())
 	}
@@ -593,6 +545,27 @@ func (e *Evaluator) evalEnumBinaryOp(op string, left, right Value, node ast.Node
 	}
 }
 
+// evalComparison evaluates a comparison operator through the value interfaces
+// (runtime.ComparableValue and runtime.OrderableValue), so new value types
+// only need to implement Equals and CompareTo to support comparisons.
+func (e *Evaluator) evalComparison(op string, left, right Value, node ast.Node) Value {
+	result, err := runtime.CompareValues(op, left, right)
+	if err != nil {
+		return e.newError(node, "type mismatch: %s %s %s", left.Type(), op, right.Type())
+	}
+	return &runtime.BooleanValue{Value: result}
+}
+
+// asFloatOperand converts a numeric operand to float through the
+// runtime.NumericValue interface.
+func asFloatOperand(v Value) (float64, bool) {
+	num, ok := v.(runtime.NumericValue)
+	if !ok {
+		return 0, false
+	}
+	return num.AsFloat()
+}
+
 // ============================================================================
 // Complex Type Comparisons
 // ============================================================================
@@ -604,14 +577,22 @@ func (e *Evaluator) evalEqualityComparison(op string, left, right Value, node as
 	leftType := left.Type()
 	rightType := right.Type()
 
+	// Handle function and method pointer comparisons, including against nil
+	leftPtr, leftIsPtr := left.(*runtime.FunctionPointerValue)
+	rightPtr, rightIsPtr := right.(*runtime.FunctionPointerValue)
+	if leftIsPtr || rightIsPtr {
+		result := functionPointersEqual(leftPtr, rightPtr)
+		if op == "=" {
+			return &runtime.BooleanValue{Value: result}
+		}
+		return &runtime.BooleanValue{Value: !result}
+	}
+
 	// Handle nil comparisons
 	if leftType == "NIL" || rightType == "NIL" {
 		// Both nil
 		if leftType == "NIL" && rightType == "NIL" {
-			if op == "=" {
-				return &runtime.BooleanValue{Value: true}
-			}
-			return &runtime.BooleanValue{Value: false}
+			return e.evalComparison(op, left, right, node)
 		}
 
 		// One is nil, one is not - handle interface special case
@@ -648,9 +629,9 @@ func (e *Evaluator) evalEqualityComparison(op string, left, right Value, node as
 	}
 
 	// Handle RTTITypeInfoValue comparisons (TypeOf results)
-	if leftType == "RTTI_TYPE_INFO" && rightType == "RTTI_TYPE_INFO" {
-		// Compare using string representation (contains TypeID)
-		result := left.String() == right.String()
+	if leftInfo, ok := left.(*runtime.RTTITypeInfoValue); ok {
+		rightInfo, ok := right.(*runtime.RTTITypeInfoValue)
+		result := ok && leftInfo.SameType(rightInfo)
 		if op == "=" {
 			return &runtime.BooleanValue{Value: result}
 		}
@@ -678,15 +659,15 @@ func (e *Evaluator) evalEqualityComparison(op string, left, right Value, node as
 		return &runtime.BooleanValue{Value: true}
 	}
 
-	// Handle InterfaceInstance comparisons
-	if leftType == "INTERFACE" && rightType == "INTERFACE" {
-		// Compare underlying objects by identity (pointer equality)
+	// Handle InterfaceInstance comparisons: each assignment of an object to an
+	// interface creates a new wrapper, so interfaces compare by the identity
+	// of the underlying object, also against a plain object reference.
+	if leftType == "INTERFACE" || rightType == "INTERFACE" {
 		var result bool
-		leftIntf, leftOK := left.(InterfaceInstanceValue)
-		rightIntf, rightOK := right.(InterfaceInstanceValue)
+		leftObj, leftOK := referencedObject(left)
+		rightObj, rightOK := referencedObject(right)
 		if leftOK && rightOK {
-			// Both are interface instances - compare underlying object pointers
-			result = leftIntf.GetUnderlyingObjectValue() == rightIntf.GetUnderlyingObjectValue()
+			result = leftObj == rightObj
 		} else {
 			// Fallback to string comparison if type assertion fails
 			result = left.String() == right.String()
@@ -724,6 +705,12 @@ func (e *Evaluator) evalEqualityComparison(op string, left, right Value, node as
 		return &runtime.BooleanValue{Value: !result}
 	}
 
+	// Values that define their own equality (Null, Unassigned and any other
+	// runtime.ComparableValue) compare through it.
+	if _, ok := left.(runtime.ComparableValue); ok {
+		return e.evalComparison(op, left, right, node)
+	}
+
 	// Handle Record comparisons
 	// RecordValue Type() returns record type name or "RECORD"
 	// We check both for named records and anonymous "RECORD" type
@@ -744,6 +731,36 @@ func (e *Evaluator) evalEqualityComparison(op string, left, right Value, node as
 	return e.newError(node, "type mismatch: %s %s %s", left.Type(), op, right.Type())
 }
 
+// referencedObject returns the object an interface or object reference
+// refers to, which is nil for a nil interface.
+func referencedObject(v Value) (Value, bool) {
+	switch ref := v.(type) {
+	case InterfaceInstanceValue:
+		return ref.GetUnderlyingObjectValue(), true
+	case *runtime.ObjectInstance:
+		return ref, true
+	}
+	return nil, false
+}
+
+// functionPointersEqual reports whether two function pointers refer to the
+// same routine, lambda closure or bound method. A nil argument stands for the
+// nil literal and equals only unassigned pointers.
+func functionPointersEqual(left, right *runtime.FunctionPointerValue) bool {
+	leftNil := left == nil || left.IsNil()
+	rightNil := right == nil || right.IsNil()
+	if leftNil || rightNil {
+		return leftNil == rightNil
+	}
+	if left.Lambda != nil || right.Lambda != nil {
+		return left.Lambda == right.Lambda && left.Closure == right.Closure
+	}
+	return left.Function == right.Function &&
+		left.BuiltinName == right.BuiltinName &&
+		left.MethodID == right.MethodID &&
+		left.SelfObject == right.SelfObject
+}
+
 // areEqualityCompatible checks if two values can be compared with = or <>
 // Valid comparisons:
 // - Same types (INTEGER=INTEGER, STRING=STRING, etc.)
@@ -752,7 +769,8 @@ func (e *Evaluator) evalEqualityComparison(op string, left, right Value, node as
 // - Interfaces with interfaces
 // - Records with records (same or different types)
 // - Classes with classes
-// - RTTI_TYPE_INFO with RTTI_TYPE_INFO
+// - RTTI_TYPEINFO with RTTI_TYPEINFO
+// - Function/method pointers with function/method pointers or nil
 func areEqualityCompatible(left, right Value) bool {
 	leftType := left.Type()
 	rightType := right.Type()
@@ -762,6 +780,12 @@ func areEqualityCompatible(left, right Value) bool {
 		return true
 	}
 
+	_, leftIsPtr := left.(*runtime.FunctionPointerValue)
+	_, rightIsPtr := right.(*runtime.FunctionPointerValue)
+	if leftIsPtr || rightIsPtr {
+		return (leftIsPtr || leftType == "NIL") && (rightIsPtr || rightType == "NIL")
+	}
+
 	// Nil can compare with objects, interfaces, classes
 	if leftType == "NIL" || rightType == "NIL" {
 		// Check if other side is object/interface/class
@@ -780,7 +804,7 @@ func areEqualityCompatible(left, right Value) bool {
 
 // isObjectLike checks if a type is object/interface/class-like
 func isObjectLike(typeStr string) bool {
-	if typeStr == "NIL" || typeStr == "INTERFACE" || typeStr == "RTTI_TYPE_INFO" {
+	if typeStr == "NIL" || typeStr == "INTERFACE" || typeStr == "OBJECT" || typeStr == "RTTI_TYPEINFO" {
 		return true
 	}
 	// Check for CLASS[...] pattern
@@ -1117,7 +1141,9 @@ func (e *Evaluator) evalSetComparison(op string, left, right *runtime.SetValue)
 //
 // Variant operations follow these rules:
 //   - Unwrap operands to get actual runtime values
-//   - Apply numeric promotion (Integer + Float → Float)
+//   - Apply numeric promotion (Integer + Float → Float) per the engine's
+//     runtime.VariantNumericRule, which also decides how Integer overflow
+//     in +, - and * is handled (wraps by default, as in DWScript)
 //   - Support string concatenation with + operator
 //   - Raise runtime error if types are incompatible
 //   - Special handling for uninitialized vs explicitly nullish variants
@@ -1192,7 +1218,7 @@ func (e *Evaluator) evalVariantBinaryOp(op string, left, right Value, node ast.N
 	switch {
 	// Both integers
 	case leftType == "INTEGER" && rightType == "INTEGER":
-		return e.evalIntegerBinaryOp(op, leftVal, rightVal, node)
+		return e.evalVariantIntegerBinaryOp(op, leftVal, rightVal, node)
 
 	// Either is float → promote to float
 	case leftType == "FLOAT" || rightType == "FLOAT":
@@ -1243,6 +1269,54 @@ func (e *Evaluator) evalVariantBinaryOp(op string, left, right Value, node ast.N
 	}
 }
 
+// integerArithmetic computes +, - or * on two Integers. Results wrap around
+// on overflow unless checked Integer arithmetic is enabled, in which case an
+// EIntOverflow exception is raised at node.
+func (e *Evaluator) integerArithmetic(op string, left, right int64, node ast.Node) Value {
+	result, overflow := runtime.CheckedIntegerArithmetic(op, left, right)
+	if overflow && e.engineState.IntegerOverflowCheck {
+		e.raiseIntegerOverflow(node)
+	}
+	return e.newInteger(result)
+}
+
+// raiseIntegerOverflow raises a catchable EIntOverflow exception positioned
+// at node. The caller still returns the wrapped result so evaluation of the
+// enclosing expression can unwind normally.
+func (e *Evaluator) raiseIntegerOverflow(node ast.Node) {
+	e.raiseIntegerOverflowMessage(node, "Integer overflow")
+}
+
+// raiseIntegerOverflowMessage raises an EIntOverflow exception with message
+// positioned at node.
+func (e *Evaluator) raiseIntegerOverflowMessage(node ast.Node, message string) {
+	var pos any
+	if node != nil {
+		pos = node.Pos()
+	}
+	e.RaiseException("EIntOverflow", message, pos)
+}
+
+// evalVariantIntegerBinaryOp applies an Integer operation to unwrapped Variant
+// operands, handling +, - and * overflow according to the engine's Variant
+// numeric rule. In VariantOverflowError mode an overflow raises EIntOverflow,
+// like checked Integer arithmetic. Other operators follow the plain Integer
+// semantics.
+func (e *Evaluator) evalVariantIntegerBinaryOp(op string, left, right Value, node ast.Node) Value {
+	leftInt, leftOk := left.(*runtime.IntegerValue)
+	rightInt, rightOk := right.(*runtime.IntegerValue)
+	if !leftOk || !rightOk || (op != "+" && op != "-" && op != "*") {
+		return e.evalIntegerBinaryOp(op, left, right, node)
+	}
+
+	if result, ok := e.engineState.VariantNumericRule.IntegerArithmetic(op, leftInt.Value, rightInt.Value); ok {
+		return result
+	}
+	e.raiseIntegerOverflowMessage(node, fmt.Sprintf("Integer overflow: %d %s %d", leftInt.Value, op, rightInt.Value))
+	result, _ := runtime.CheckedIntegerArithmetic(op, leftInt.Value, rightInt.Value)
+	return &runtime.IntegerValue{Value: result}
+}
+
 // isNullish checks if a value represents a null/unassigned/nil state.
 func isNullish(val Value) bool {
 	if val == nil {
@@ -1287,7 +1361,11 @@ func (e *Evaluator) evalMinusUnaryOp(operand Value, node ast.Node) Value {
 
 	switch v := operand.(type) {
 	case *runtime.IntegerValue:
-		return &runtime.IntegerValue{Value: -v.Value}
+		result, overflow := runtime.CheckedIntegerNegate(v.Value)
+		if overflow && e.engineState.IntegerOverflowCheck {
+			e.raiseIntegerOverflow(node)
+		}
+		return &runtime.IntegerValue{Value: result}
 	case *runtime.FloatValue:
 		return &runtime.FloatValue{Value: -v.Value}
 	default:
@@ -20,15 +20,32 @@ import (
 // boundary. Failed casts raise a catchable exception on ctx and return a nil
 // placeholder value; the caller must check ctx.Exception().
 func (e *Evaluator) coerceBuiltinArgsToSignature(funcName *ast.Identifier, argExprs []ast.Expression, args []Value, ctx *ExecutionContext) Value {
-	sig, ok := builtins.DefaultRegistry.GetSignature(funcName.Value)
-	if !ok || sig == nil {
+	info, ok := e.FunctionRegistry().GetBuiltinInfo(funcName.Value)
+	if !ok || info.Signature == nil {
 		return nil
 	}
+	sig := info.Signature
 
 	for i := range args {
 		if i >= len(sig.ParamTypes) || sig.ParamTypes[i] == nil {
 			break
 		}
+		// Math builtins accept Integer subrange values as plain Integers,
+		// matching the semantic analyzer's overload resolution.
+		if info.Category == builtins.CategoryMath {
+			if subrange, ok := args[i].(*runtime.SubrangeValue); ok {
+				args[i] = &runtime.IntegerValue{Value: int64(subrange.Value)}
+				continue
+			}
+		}
+		// TDateTime parameters are Floats; an Integer day count widens to
+		// Float as it does when assigned to a TDateTime variable.
+		if info.Category == builtins.CategoryDateTime && sig.ParamTypes[i].TypeKind() == "FLOAT" {
+			if intVal, ok := args[i].(*runtime.IntegerValue); ok {
+				args[i] = &runtime.FloatValue{Value: float64(intVal.Value)}
+				continue
+			}
+		}
 		// Only apply variant casts to arguments whose static (declared) type
 		// is Variant; other mismatches keep their strict runtime errors.
 		if i >= len(argExprs) || !e.exprIsStaticVariant(argExprs[i]) {
@@ -0,0 +1,62 @@
+package evaluator
+
+import (
+	"github.com/cwbudde/go-dws/internal/interp/runtime"
+	"github.com/cwbudde/go-dws/pkg/ast"
+)
+
+// ============================================================================
+// Assert Built-in Function
+// ============================================================================
+
+// builtinAssert implements Assert(condition[, message]).
+//
+// Unlike registry builtins, Assert receives its arguments unevaluated: the
+// message expression is only evaluated when the condition is False, and when
+// assertions are disabled (WithAssertions(false)) neither argument is
+// evaluated at all. A failed assertion raises EAssertionFailed carrying the
+// source position of the Assert call.
+func (e *Evaluator) builtinAssert(node *ast.CallExpression, ctx *ExecutionContext) Value {
+	if e.engineState.DisableAssertions {
+		return &runtime.NilValue{}
+	}
+
+	args := node.Arguments
+	if len(args) < 1 || len(args) > 2 {
+		return e.newError(node, "Assert() expects 1-2 arguments, got %d", len(args))
+	}
+
+	condVal := e.Eval(args[0], ctx)
+	if isError(condVal) {
+		return condVal
+	}
+	if ctx.Exception() != nil {
+		return &runtime.NilValue{}
+	}
+	cond, ok := e.UnwrapVariant(condVal).(*runtime.BooleanValue)
+	if !ok {
+		return e.newError(node, "Assert() first argument must be Boolean, got %s", condVal.Type())
+	}
+	if cond.Value {
+		return &runtime.NilValue{}
+	}
+
+	var message string
+	if len(args) == 2 {
+		msgVal := e.Eval(args[1], ctx)
+		if isError(msgVal) {
+			return msgVal
+		}
+		if ctx.Exception() != nil {
+			return &runtime.NilValue{}
+		}
+		msg, ok := e.UnwrapVariant(msgVal).(*runtime.StringValue)
+		if !ok {
+			return e.newError(node, "Assert() second argument must be String, got %s", msgVal.Type())
+		}
+		message = msg.Value
+	}
+
+	e.raiseAssertionFailed(node, message, ctx)
+	return &runtime.NilValue{}
+}
@@ -1,7 +1,6 @@
 package evaluator
 
 import (
-	"github.com/cwbudde/go-dws/internal/builtins"
 	"github.com/cwbudde/go-dws/internal/interp/runtime"
 	"github.com/cwbudde/go-dws/internal/types"
 	"github.com/cwbudde/go-dws/pkg/ast"
@@ -29,7 +28,7 @@ func (e *Evaluator) executeFunctionPointerDirect(funcPtr Value, args []Value, no
 	}
 
 	if builtinName := callable.GetBuiltinName(); builtinName != "" {
-		fn, ok := builtins.DefaultRegistry.Lookup(builtinName)
+		fn, ok := e.FunctionRegistry().LookupBuiltin(builtinName)
 		if !ok {
 			return e.newError(node, "unknown built-in function '%s'", builtinName)
 		}
@@ -118,7 +117,8 @@ func (e *Evaluator) executeLambdaDirect(
 		scope.defineOwned(e, lambdaCtx, param.Name.Value, arg)
 	}
 
-	if lambda.ReturnType != nil || lambda.IsShorthand {
+	yieldsValue := lambda.ReturnType != nil || lambda.IsShorthand || e.lambdaYieldsValue(lambda)
+	if yieldsValue {
 		var resultValue = e.nilValue()
 		if lambda.ReturnType != nil {
 			returnType, err := e.ResolveTypeFromAnnotation(lambda.ReturnType)
@@ -134,7 +134,7 @@ func (e *Evaluator) executeLambdaDirect(
 		scope.defineOwned(e, lambdaCtx, "Result", resultValue)
 	}
 
-	bodyResult := e.Eval(lambda.Body, lambdaCtx)
+	bodyResult := e.callOnStack(lambdaCtx, func() Value { return e.Eval(lambda.Body, lambdaCtx) })
 	if isError(bodyResult) {
 		return bodyResult
 	}
@@ -146,7 +146,7 @@ func (e *Evaluator) executeLambdaDirect(
 		lambdaCtx.ControlFlow().Clear()
 	}
 
-	if lambda.ReturnType != nil || lambda.IsShorthand {
+	if yieldsValue {
 		if resultVal, ok := lambdaEnv.Get("Result"); ok {
 			if value, ok := resultVal.(Value); ok {
 				return e.retainValueForBinding(value, lambdaCtx)
@@ -157,14 +157,50 @@ func (e *Evaluator) executeLambdaDirect(
 	return e.nilValue()
 }
 
+// lambdaYieldsValue reports whether a lambda without a declared return type
+// produces a value, i.e. its body uses Result or calls Exit with a value. Such
+// lambdas get their own Result variable so Exit(value) does not write to the
+// Result of an enclosing function. The answer is cached per lambda.
+func (e *Evaluator) lambdaYieldsValue(lambda *ast.LambdaExpression) bool {
+	if yields, ok := e.lambdaYields[lambda]; ok {
+		return yields
+	}
+
+	yields := false
+	ast.Inspect(lambda.Body, func(node ast.Node) bool {
+		switch n := node.(type) {
+		case *ast.LambdaExpression:
+			// Nested lambdas have their own Result
+			return false
+		case *ast.ExitStatement:
+			yields = yields || n.ReturnValue != nil
+		case *ast.Identifier:
+			yields = yields || ident.Equal(n.Value, "Result")
+		}
+		return !yields
+	})
+
+	if e.lambdaYields == nil {
+		e.lambdaYields = make(map[*ast.LambdaExpression]bool)
+	}
+	e.lambdaYields[lambda] = yields
+	return yields
+}
+
 func (e *Evaluator) executeQualifiedFunctionCall(unitName string, member *ast.Identifier, argsExpr []ast.Expression, node ast.Node, ctx *ExecutionContext) Value {
 	if e.UnitRegistry() == nil {
 		return e.newError(node, "unit registry not initialized")
 	}
-	if _, exists := e.UnitRegistry().GetUnit(unitName); !exists {
+	unit, exists := e.UnitRegistry().GetUnit(unitName)
+	if !exists {
 		return e.newError(node, "unit '%s' not loaded", unitName)
 	}
 
+	// Functions of a host unit are implemented in Go
+	if externalName, ok := unit.LookupHostFunction(member.Value); ok {
+		return e.callExternalFunction(externalName, argsExpr, node, ctx)
+	}
+
 	overloads := e.typeSystem.LookupQualifiedFunction(unitName, member.Value)
 	if len(overloads) == 0 {
 		return e.newError(node, "function '%s' not found in unit '%s'", member.Value, unitName)
@@ -0,0 +1,107 @@
+package evaluator
+
+import (
+	"github.com/cwbudde/go-dws/internal/interp/runtime"
+	"github.com/cwbudde/go-dws/pkg/ast"
+)
+
+// This file contains dispatch helpers for case statements.
+
+// minCaseJumpTableSize is the number of string literal branch values from which
+// a case statement is dispatched through a hash map instead of a linear scan.
+const minCaseJumpTableSize = 8
+
+// caseJumpTable maps string literal branch values to the index of the first
+// branch that lists them. String comparison is case-sensitive.
+type caseJumpTable map[string]int
+
+// caseJumpTableFor returns the jump table for a case statement, building it on
+// first use. Returns nil if the statement is not eligible: it has too few
+// values, or some value is a range or a non-literal expression.
+func (e *Evaluator) caseJumpTableFor(node *ast.CaseStatement) caseJumpTable {
+	if table, ok := e.caseTables[node]; ok {
+		return table
+	}
+
+	table := buildCaseJumpTable(node)
+	if e.caseTables == nil {
+		e.caseTables = make(map[*ast.CaseStatement]caseJumpTable)
+	}
+	e.caseTables[node] = table
+	return table
+}
+
+// buildCaseJumpTable builds a jump table from the branch values of node, or
+// returns nil if the statement must be dispatched linearly.
+func buildCaseJumpTable(node *ast.CaseStatement) caseJumpTable {
+	count := 0
+	for _, branch := range node.Cases {
+		count += len(branch.Values)
+	}
+	if count < minCaseJumpTableSize {
+		return nil
+	}
+
+	table := make(caseJumpTable, count)
+	for i, branch := range node.Cases {
+		for _, value := range branch.Values {
+			var key string
+			switch lit := value.(type) {
+			case *ast.StringLiteral:
+				key = lit.Value
+			case *ast.CharLiteral:
+				key = string(lit.Value)
+			default:
+				return nil
+			}
+			// Earlier branches win, as with a linear scan
+			if _, exists := table[key]; !exists {
+				table[key] = i
+			}
+		}
+	}
+	return table
+}
+
+// matchCaseBranch returns the index of the first branch whose values match
+// caseValue, or -1 if none does. Branch values are evaluated in order and the
+// scan stops at the first match; an error from evaluating a value is returned
+// as the second result.
+func (e *Evaluator) matchCaseBranch(node *ast.CaseStatement, caseValue Value, ctx *ExecutionContext) (int, Value) {
+	if table := e.caseJumpTableFor(node); table != nil {
+		if str, ok := unwrapVariant(caseValue).(*runtime.StringValue); ok {
+			if index, found := table[str.Value]; found {
+				return index, nil
+			}
+			return -1, nil
+		}
+	}
+
+	for i, branch := range node.Cases {
+		for _, branchVal := range branch.Values {
+			if rangeExpr, isRange := branchVal.(*ast.RangeExpression); isRange {
+				startValue := e.Eval(rangeExpr.Start, ctx)
+				if isError(startValue) {
+					return -1, startValue
+				}
+				endValue := e.Eval(rangeExpr.RangeEnd, ctx)
+				if isError(endValue) {
+					return -1, endValue
+				}
+				if IsInRange(caseValue, startValue, endValue) {
+					return i, nil
+				}
+				continue
+			}
+
+			branchValue := e.Eval(branchVal, ctx)
+			if isError(branchValue) {
+				return -1, branchValue
+			}
+			if ValuesEqual(caseValue, branchValue) {
+				return i, nil
+			}
+		}
+	}
+	return -1, nil
+}
@@ -0,0 +1,184 @@
+package evaluator
+
+import (
+	"bytes"
+	"math"
+	"testing"
+
+	"github.com/cwbudde/go-dws/internal/interp/runtime"
+	interptypes "github.com/cwbudde/go-dws/internal/interp/types"
+	"github.com/cwbudde/go-dws/internal/units"
+	"github.com/cwbudde/go-dws/pkg/ast"
+)
+
+var comparisonOps = []string{"=", "<>", "<", ">", "<=", ">="}
+
+func newComparisonTestEvaluator() *Evaluator {
+	var output bytes.Buffer
+	return NewEvaluator(interptypes.NewTypeSystem(), &output, DefaultConfig(),
+		units.NewUnitRegistry(nil), nil, runtime.NewRefCountManager())
+}
+
+func comparisonNode(op string) *ast.BinaryExpression {
+	return &ast.BinaryExpression{
+		Left:     &ast.Identifier{Value: "left"},
+		Operator: op,
+		Right:    &ast.Identifier{Value: "right"},
+	}
+}
+
+// expectComparison checks that a comparison result is a Boolean with the
+// value of the equivalent Go comparison.
+func expectComparison(t *testing.T, result Value, op string, want bool) {
+	t.Helper()
+	b, ok := result.(*runtime.BooleanValue)
+	if !ok {
+		t.Fatalf("%s: expected BOOLEAN, got %T: %v", op, result, result)
+	}
+	if b.Value != want {
+		t.Errorf("%s: got %v, want %v", op, b.Value, want)
+	}
+}
+
+func goCompareInt(op string, l, r int64) bool {
+	switch op {
+	case "=":
+		return l == r
+	case "<>":
+		return l != r
+	case "<":
+		return l < r
+	case ">":
+		return l > r
+	case "<=":
+		return l <= r
+	}
+	return l >= r
+}
+
+func goCompareFloat(op string, l, r float64) bool {
+	switch op {
+	case "=":
+		return l == r
+	case "<>":
+		return l != r
+	case "<":
+		return l < r
+	case ">":
+		return l > r
+	case "<=":
+		return l <= r
+	}
+	return l >= r
+}
+
+func goCompareString(op string, l, r string) bool {
+	switch op {
+	case "=":
+		return l == r
+	case "<>":
+		return l != r
+	case "<":
+		return l < r
+	case ">":
+		return l > r
+	case "<=":
+		return l <= r
+	}
+	return l >= r
+}
+
+// TestEvalIntegerComparisons checks that integer comparisons match Go's.
+func TestEvalIntegerComparisons(t *testing.T) {
+	eval := newComparisonTestEvaluator()
+	pairs := [][2]int64{{1, 2}, {2, 1}, {5, 5}, {-3, 3}, {math.MinInt64, math.MaxInt64}}
+
+	for _, pair := range pairs {
+		for _, op := range comparisonOps {
+			left, right := &runtime.IntegerValue{Value: pair[0]}, &runtime.IntegerValue{Value: pair[1]}
+			result := eval.evalIntegerBinaryOp(op, left, right, comparisonNode(op))
+			expectComparison(t, result, op, goCompareInt(op, pair[0], pair[1]))
+
+			// Variant operands are unwrapped first
+			result = eval.evalIntegerBinaryOp(op, runtime.BoxVariant(left), right, comparisonNode(op))
+			expectComparison(t, result, op, goCompareInt(op, pair[0], pair[1]))
+		}
+	}
+}
+
+// TestEvalFloatComparisons checks that float and mixed integer/float
+// comparisons match Go's, including NaN and infinities.
+func TestEvalFloatComparisons(t *testing.T) {
+	eval := newComparisonTestEvaluator()
+	nan := math.NaN()
+	pairs := [][2]float64{{1.5, 2.5}, {2.5, 1.5}, {0.1, 0.1}, {nan, 1}, {nan, nan}, {math.Inf(-1), math.Inf(1)}, {0, math.Copysign(0, -1)}}
+
+	for _, pair := range pairs {
+		for _, op := range comparisonOps {
+			result := eval.evalFloatBinaryOp(op, &runtime.FloatValue{Value: pair[0]}, &runtime.FloatValue{Value: pair[1]}, comparisonNode(op))
+			expectComparison(t, result, op, goCompareFloat(op, pair[0], pair[1]))
+		}
+	}
+
+	for _, op := range comparisonOps {
+		result := eval.evalFloatBinaryOp(op, &runtime.IntegerValue{Value: 2}, &runtime.FloatValue{Value: 2.5}, comparisonNode(op))
+		expectComparison(t, result, op, goCompareFloat(op, 2, 2.5))
+
+		result = eval.evalFloatBinaryOp(op, &runtime.FloatValue{Value: 3}, &runtime.IntegerValue{Value: 3}, comparisonNode(op))
+		expectComparison(t, result, op, goCompareFloat(op, 3, 3))
+	}
+}
+
+// TestEvalFloatArithmetic checks that operands are converted through
+// runtime.NumericValue and non-numeric operands are still rejected.
+func TestEvalFloatArithmetic(t *testing.T) {
+	eval := newComparisonTestEvaluator()
+
+	result := eval.evalFloatBinaryOp("*", &runtime.IntegerValue{Value: 3}, &runtime.FloatValue{Value: 0.5}, comparisonNode("*"))
+	if f, ok := result.(*runtime.FloatValue); !ok || f.Value != 1.5 {
+		t.Errorf("3 * 0.5 = %v, want 1.5", result)
+	}
+
+	result = eval.evalFloatBinaryOp("+", &runtime.FloatValue{Value: 1}, &runtime.StringValue{Value: "1"}, comparisonNode("+"))
+	if _, ok := result.(*runtime.ErrorValue); !ok {
+		t.Errorf("1.0 + '1': expected error, got %T: %v", result, result)
+	}
+}
+
+// TestEvalStringComparisons checks that string comparisons are ordinal and
+// case-sensitive, like Go's.
+func TestEvalStringComparisons(t *testing.T) {
+	eval := newComparisonTestEvaluator()
+	pairs := [][2]string{{"a", "b"}, {"b", "a"}, {"abc", "abc"}, {"B", "a"}, {"", "x"}, {"ab", "abc"}, {"é", "z"}}
+
+	for _, pair := range pairs {
+		for _, op := range comparisonOps {
+			result := eval.evalStringBinaryOp(op, &runtime.StringValue{Value: pair[0]}, &runtime.StringValue{Value: pair[1]}, comparisonNode(op))
+			expectComparison(t, result, op, goCompareString(op, pair[0], pair[1]))
+		}
+	}
+}
+
+// TestEvalEqualityComparisonComparableValues checks nil, Null and Unassigned
+// equality.
+func TestEvalEqualityComparisonComparableValues(t *testing.T) {
+	eval := newComparisonTestEvaluator()
+	tests := []struct {
+		left  Value
+		right Value
+		name  string
+		equal bool
+	}{
+		{name: "nil = nil", left: &runtime.NilValue{}, right: &runtime.NilValue{}, equal: true},
+		{name: "nil = object", left: &runtime.NilValue{}, right: &runtime.ObjectInstance{}, equal: false},
+		{name: "Null = Null", left: &runtime.NullValue{}, right: &runtime.NullValue{}, equal: true},
+		{name: "Unassigned = Unassigned", left: &runtime.UnassignedValue{}, right: &runtime.UnassignedValue{}, equal: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			expectComparison(t, eval.evalEqualityComparison("=", tt.left, tt.right, comparisonNode("=")), "=", tt.equal)
+			expectComparison(t, eval.evalEqualityComparison("<>", tt.left, tt.right, comparisonNode("<>")), "<>", !tt.equal)
+		})
+	}
+}
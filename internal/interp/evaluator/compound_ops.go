@@ -85,17 +85,13 @@ func (e *Evaluator) applyCompoundOperation(op token.TokenType, left, right Value
 func (e *Evaluator) evalPlusAssign(left, right Value, node ast.Node) Value {
 	// Handle Variant values first - delegate to evalVariantBinaryOp
 	if _, ok := left.(runtime.VariantWrapper); ok {
-		result := e.evalVariantBinaryOp("+", left, right, node)
-		if isError(result) {
-			return result
-		}
-		return result
+		return e.evalVariantCompound("+", left, right, node)
 	}
 
 	switch l := left.(type) {
 	case *runtime.IntegerValue:
 		if r, ok := right.(*runtime.IntegerValue); ok {
-			return &runtime.IntegerValue{Value: l.Value + r.Value}
+			return e.integerArithmetic("+", l.Value, r.Value, node)
 		}
 		// Float to Integer conversion would lose precision, not allowed
 		return e.newError(node, "type mismatch: cannot add %s to Integer", right.Type())
@@ -145,17 +141,13 @@ func (e *Evaluator) evalPlusAssign(left, right Value, node ast.Node) Value {
 func (e *Evaluator) evalMinusAssign(left, right Value, node ast.Node) Value {
 	// Handle Variant values first
 	if _, ok := left.(runtime.VariantWrapper); ok {
-		result := e.evalVariantBinaryOp("-", left, right, node)
-		if isError(result) {
-			return result
-		}
-		return result
+		return e.evalVariantCompound("-", left, right, node)
 	}
 
 	switch l := left.(type) {
 	case *runtime.IntegerValue:
 		if r, ok := right.(*runtime.IntegerValue); ok {
-			return &runtime.IntegerValue{Value: l.Value - r.Value}
+			return e.integerArithmetic("-", l.Value, r.Value, node)
 		}
 		return e.newError(node, "type mismatch: cannot subtract %s from Integer", right.Type())
 
@@ -180,17 +172,13 @@ func (e *Evaluator) evalMinusAssign(left, right Value, node ast.Node) Value {
 func (e *Evaluator) evalTimesAssign(left, right Value, node ast.Node) Value {
 	// Handle Variant values first
 	if _, ok := left.(runtime.VariantWrapper); ok {
-		result := e.evalVariantBinaryOp("*", left, right, node)
-		if isError(result) {
-			return result
-		}
-		return result
+		return e.evalVariantCompound("*", left, right, node)
 	}
 
 	switch l := left.(type) {
 	case *runtime.IntegerValue:
 		if r, ok := right.(*runtime.IntegerValue); ok {
-			return &runtime.IntegerValue{Value: l.Value * r.Value}
+			return e.integerArithmetic("*", l.Value, r.Value, node)
 		}
 		return e.newError(node, "type mismatch: cannot multiply Integer by %s", right.Type())
 
@@ -216,11 +204,7 @@ func (e *Evaluator) evalTimesAssign(left, right Value, node ast.Node) Value {
 func (e *Evaluator) evalDivideAssign(left, right Value, node ast.Node) Value {
 	// Handle Variant values first
 	if _, ok := left.(runtime.VariantWrapper); ok {
-		result := e.evalVariantBinaryOp("/", left, right, node)
-		if isError(result) {
-			return result
-		}
-		return result
+		return e.evalVariantCompound("/", left, right, node)
 	}
 
 	switch l := left.(type) {
@@ -261,15 +245,26 @@ func (e *Evaluator) evalDivideAssign(left, right Value, node ast.Node) Value {
 
 // newDivisionByZeroError creates an enhanced division by zero error for integers.
 func (e *Evaluator) newDivisionByZeroError(node ast.Node, left, right int64) Value {
-	return e.newError(node, "Division by zero")
+	return e.newErrorOfClass(node, "EDivByZero", "Division by zero")
 }
 
 // newFloatDivisionByZeroError creates an enhanced division by zero error for floats.
 func (e *Evaluator) newFloatDivisionByZeroError(node ast.Node, left, right float64) Value {
-	return e.newError(node, "Division by zero")
+	return e.newErrorOfClass(node, "EDivByZero", "Division by zero")
 }
 
 // newFloatIntDivisionByZeroError creates an enhanced division by zero error for float/int.
 func (e *Evaluator) newFloatIntDivisionByZeroError(node ast.Node, left float64, right int64) Value {
-	return e.newError(node, "Division by zero")
+	return e.newErrorOfClass(node, "EDivByZero", "Division by zero")
+}
+
+// evalVariantCompound applies op to a Variant target. The result is boxed
+// again, so the variable keeps holding a Variant and later operations on it
+// still follow the Variant rules.
+func (e *Evaluator) evalVariantCompound(op string, left, right Value, node ast.Node) Value {
+	result := e.evalVariantBinaryOp(op, left, right, node)
+	if isError(result) || result == nil || result.Type() == "VARIANT" {
+		return result
+	}
+	return runtime.BoxVariant(result)
 }
@@ -0,0 +1,35 @@
+package evaluator
+
+import (
+	"github.com/cwbudde/go-dws/internal/interp/runtime"
+	"github.com/cwbudde/go-dws/pkg/ast"
+)
+
+// foldedValue returns the value the semantic analyzer folded for expr, so
+// constant expressions are not re-evaluated. It reports false when constant
+// folding was not enabled or expr was not folded.
+func (e *Evaluator) foldedValue(expr ast.Expression) (Value, bool) {
+	if e.engineState == nil {
+		return nil, false
+	}
+	semanticInfo := e.engineState.SemanticInfo
+	if semanticInfo == nil || semanticInfo.ConstantCount() == 0 {
+		return nil, false
+	}
+	value, ok := semanticInfo.GetConstant(expr)
+	if !ok {
+		return nil, false
+	}
+
+	switch v := value.(type) {
+	case int64:
+		return &runtime.IntegerValue{Value: v}, true
+	case float64:
+		return &runtime.FloatValue{Value: v}, true
+	case string:
+		return &runtime.StringValue{Value: v}, true
+	case bool:
+		return &runtime.BooleanValue{Value: v}, true
+	}
+	return nil, false
+}
@@ -0,0 +1,42 @@
+package evaluator
+
+import (
+	"testing"
+
+	"github.com/cwbudde/go-dws/internal/interp/runtime"
+	interptypes "github.com/cwbudde/go-dws/internal/interp/types"
+	"github.com/cwbudde/go-dws/pkg/ast"
+)
+
+// TestFoldedValuesSkipEvaluation tests that expressions with a folded value
+// in the SemanticInfo return it without evaluating their operands.
+func TestFoldedValuesSkipEvaluation(t *testing.T) {
+	info := ast.NewSemanticInfo()
+	e := NewEvaluator(interptypes.NewTypeSystem(), nil, nil, nil, info, runtime.NewRefCountManager())
+	ctx := NewExecutionContext(runtime.NewEnvironment())
+
+	// The operands are undefined, so evaluating them would fail.
+	binary := &ast.BinaryExpression{Left: &ast.Identifier{Value: "a"}, Operator: "*", Right: &ast.Identifier{Value: "b"}}
+	unary := &ast.UnaryExpression{Operator: "-", Right: &ast.Identifier{Value: "c"}}
+	grouped := &ast.GroupedExpression{Expression: &ast.Identifier{Value: "d"}}
+
+	info.SetConstant(binary, int64(86400))
+	info.SetConstant(unary, 2.5)
+	info.SetConstant(grouped, "text")
+
+	if got := e.Eval(binary, ctx); got.String() != "86400" || got.Type() != "INTEGER" {
+		t.Errorf("binary = %s %s, want INTEGER 86400", got.Type(), got.String())
+	}
+	if got := e.Eval(unary, ctx); got.Type() != "FLOAT" {
+		t.Errorf("unary = %s %s, want FLOAT 2.5", got.Type(), got.String())
+	}
+	if got := e.Eval(grouped, ctx); got.String() != "text" || got.Type() != "STRING" {
+		t.Errorf("grouped = %s %s, want STRING text", got.Type(), got.String())
+	}
+
+	// Expressions without a folded value are still evaluated.
+	other := &ast.BinaryExpression{Left: &ast.Identifier{Value: "a"}, Operator: "+", Right: &ast.Identifier{Value: "b"}}
+	if got := e.Eval(other, ctx); !isError(got) {
+		t.Errorf("unfolded expression = %s, want an error for undefined operands", got.String())
+	}
+}
@@ -20,7 +20,8 @@ import (
 // Polymorphic behavior:
 // - Arrays: Return array bounds from ArrayType or dynamic bounds
 // - Enums: Return first/last enum value as bound
-// - Type meta-values: Return bounds for built-in types or enum bounds
+// - Subranges: Return the declared bounds as Integers
+// - Type meta-values: Return bounds for built-in types, enums or static arrays
 // - Strings: 1-indexed (Low=1, High=Length)
 //
 // These implementations are self-contained and do not require callbacks
@@ -47,6 +48,12 @@ func (e *Evaluator) GetLowBound(value Value) (Value, error) {
 			}
 			return runtime.EnumValueAtIndex(typeMetaVal.TypeName, enumType, 0)
 		}
+		if arrayType, ok := types.GetUnderlyingType(typeMetaVal.TypeInfo).(*types.ArrayType); ok && arrayType.IsStatic() {
+			return &runtime.IntegerValue{Value: int64(*arrayType.LowBound)}, nil
+		}
+		if subrange, ok := types.GetUnderlyingType(typeMetaVal.TypeInfo).(*types.SubrangeType); ok {
+			return &runtime.IntegerValue{Value: int64(subrange.LowBound)}, nil
+		}
 		return nil, fmt.Errorf("Low() not supported for type %s", typeMetaVal.TypeName)
 	}
 
@@ -58,6 +65,11 @@ func (e *Evaluator) GetLowBound(value Value) (Value, error) {
 		return &runtime.IntegerValue{Value: 0}, nil
 	}
 
+	// Subrange values
+	if subrange, ok := value.(*runtime.SubrangeValue); ok {
+		return &runtime.IntegerValue{Value: int64(subrange.SubrangeType.LowBound)}, nil
+	}
+
 	// Enum values
 	if enumVal, ok := value.(*runtime.EnumValue); ok {
 		enumMetadata := e.typeSystem.LookupEnumMetadata(enumVal.TypeName)
@@ -102,6 +114,12 @@ func (e *Evaluator) GetHighBound(value Value) (Value, error) {
 			}
 			return runtime.EnumValueAtIndex(typeMetaVal.TypeName, enumType, len(enumType.OrderedNames)-1)
 		}
+		if arrayType, ok := types.GetUnderlyingType(typeMetaVal.TypeInfo).(*types.ArrayType); ok && arrayType.IsStatic() {
+			return &runtime.IntegerValue{Value: int64(*arrayType.HighBound)}, nil
+		}
+		if subrange, ok := types.GetUnderlyingType(typeMetaVal.TypeInfo).(*types.SubrangeType); ok {
+			return &runtime.IntegerValue{Value: int64(subrange.HighBound)}, nil
+		}
 		return nil, fmt.Errorf("High() not supported for type %s", typeMetaVal.TypeName)
 	}
 
@@ -113,6 +131,11 @@ func (e *Evaluator) GetHighBound(value Value) (Value, error) {
 		return &runtime.IntegerValue{Value: int64(len(arrayVal.Elements) - 1)}, nil
 	}
 
+	// Subrange values
+	if subrange, ok := value.(*runtime.SubrangeValue); ok {
+		return &runtime.IntegerValue{Value: int64(subrange.SubrangeType.HighBound)}, nil
+	}
+
 	// Enum values
 	if enumVal, ok := value.(*runtime.EnumValue); ok {
 		enumMetadata := e.typeSystem.LookupEnumMetadata(enumVal.TypeName)
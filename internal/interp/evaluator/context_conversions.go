@@ -181,6 +181,29 @@ func (e *Evaluator) GetClassOf(value Value) string {
 	return ""
 }
 
+// GetClassValueOf returns the runtime class of an object as a class value,
+// or the value itself if it is already a class reference. Returns nil for
+// nil and non-object values.
+//
+// This backs the GetClass() builtin.
+func (e *Evaluator) GetClassValueOf(value Value) Value {
+	if _, ok := value.(ClassMetaValue); ok {
+		return value
+	}
+	className := e.GetClassOf(value)
+	if className == "" {
+		return nil
+	}
+	classVal, err := e.typeSystem.CreateClassValue(className)
+	if err != nil {
+		return nil
+	}
+	if cv, ok := classVal.(Value); ok {
+		return cv
+	}
+	return nil
+}
+
 // ============================================================================
 // String Parsing Methods
 // ============================================================================
@@ -4,10 +4,12 @@ import (
 	"fmt"
 	"io"
 	"math/rand"
+	"time"
 
 	"github.com/cwbudde/go-dws/internal/interp/runtime"
 	"github.com/cwbudde/go-dws/internal/lexer"
 	"github.com/cwbudde/go-dws/internal/types"
+	"github.com/cwbudde/go-dws/pkg/ast"
 )
 
 // ============================================================================
@@ -37,6 +39,27 @@ func (e *Evaluator) SetRandSeed(seed int64) {
 	e.engineState.Random.Seed(seed)
 }
 
+// RandomSeedFixed reports whether the host fixed the random seed.
+func (e *Evaluator) RandomSeedFixed() bool {
+	return e.engineState.FixedRandomSeed
+}
+
+// FormatSettings returns the separators used by the conversion builtins.
+func (e *Evaluator) FormatSettings() runtime.FormatSettings {
+	return e.engineState.FormatSettings
+}
+
+// CurrentTime returns the current time from the host clock.
+func (e *Evaluator) CurrentTime() time.Time {
+	return e.engineState.Clock()
+}
+
+// IntegerOverflowCheck reports whether checked Integer arithmetic is enabled,
+// so builtins such as Abs() can raise EIntOverflow instead of wrapping.
+func (e *Evaluator) IntegerOverflowCheck() bool {
+	return e.engineState.IntegerOverflowCheck
+}
+
 // Write outputs a string to the configured output writer without a newline.
 func (e *Evaluator) Write(s string) {
 	if e.output != nil {
@@ -129,11 +152,15 @@ func (e *Evaluator) RaiseAssertionFailed(customMessage string) {
 	if ctx == nil {
 		return // No context available, cannot raise exception
 	}
+	e.raiseAssertionFailed(e.CurrentNode(), customMessage, ctx)
+}
 
+// raiseAssertionFailed raises EAssertionFailed for the assertion at node.
+func (e *Evaluator) raiseAssertionFailed(node ast.Node, customMessage string, ctx *ExecutionContext) {
 	// Build message with position info if available
 	var message string
-	if currentNode := e.CurrentNode(); currentNode != nil {
-		pos := currentNode.Pos()
+	if node != nil {
+		pos := node.Pos()
 		message = fmt.Sprintf("Assertion failed [line: %d, column: %d]", pos.Line, pos.Column)
 	} else {
 		message = "Assertion failed"
@@ -188,6 +215,12 @@ func (e *Evaluator) RaiseException(className, message string, pos any) {
 	ctx.SetException(e.createException(className, message, lexerPos, ctx))
 }
 
+// RangeChecksEnabled reports whether range checking ({$R+}) is on, so
+// builtins such as Succ/Pred can raise ERangeError at the ends of a type.
+func (e *Evaluator) RangeChecksEnabled() bool {
+	return e.engineState.RangeChecks
+}
+
 // EvalFunctionPointer executes a function pointer with given arguments.
 func (e *Evaluator) EvalFunctionPointer(funcPtr Value, args []Value) Value {
 	return e.executeFunctionPointerDirect(funcPtr, args, e.CurrentNode(), e.currentContext)
@@ -6,6 +6,7 @@ import (
 	"github.com/cwbudde/go-dws/internal/interp/runtime"
 	"github.com/cwbudde/go-dws/internal/lexer"
 	"github.com/cwbudde/go-dws/pkg/ast"
+	"github.com/cwbudde/go-dws/pkg/printer"
 )
 
 // raiseContractException creates an exception and sets it in the context.
@@ -60,7 +61,7 @@ func contractFuncName(fn *ast.FunctionDecl) string {
 //
 // Returns nil on success, error value if evaluation fails.
 func (e *Evaluator) checkPreconditions(funcName string, preConditions *ast.PreConditions, ctx *ExecutionContext) Value {
-	if preConditions == nil {
+	if preConditions == nil || e.engineState.ContractMode == runtime.ContractsOff {
 		return nil
 	}
 
@@ -81,8 +82,8 @@ func (e *Evaluator) checkPreconditions(funcName string, preConditions *ast.PreCo
 
 		// If the condition failed, raise an exception
 		if !boolVal.Value {
-			// Build error message - clean up parentheses from expression
-			message := cleanContractMessage(condition.Test.String())
+			// Default message is the condition's source text
+			message := printer.Print(condition.Test)
 
 			// Evaluate custom message if provided
 			if condition.Message != nil {
@@ -102,7 +103,7 @@ func (e *Evaluator) checkPreconditions(funcName string, preConditions *ast.PreCo
 				funcName, condPos.Line, condPos.Column, message)
 
 			// Raise exception directly (no adapter!)
-			e.raiseContractException("Exception", fullMessage, condition.Test, ctx)
+			e.raiseContractException("EAssertionFailed", fullMessage, condition.Test, ctx)
 			return nil
 		}
 	}
@@ -116,8 +117,8 @@ func (e *Evaluator) checkPreconditions(funcName string, preConditions *ast.PreCo
 func (e *Evaluator) captureOldValues(funcDecl *ast.FunctionDecl, ctx *ExecutionContext) map[string]Value {
 	oldValues := make(map[string]Value)
 
-	// If there are no postconditions, no need to capture anything
-	if funcDecl.PostConditions == nil {
+	// If postconditions are absent or not checked, no need to capture anything
+	if funcDecl.PostConditions == nil || e.engineState.ContractMode != runtime.ContractsFull {
 		return oldValues
 	}
 
@@ -133,87 +134,55 @@ func (e *Evaluator) captureOldValues(funcDecl *ast.FunctionDecl, ctx *ExecutionC
 	return oldValues
 }
 
-// findOldExpressions recursively searches an expression tree for OldExpression nodes
-// and captures their values.
+// findOldExpressions searches an expression tree for OldExpression nodes
+// and captures the current value of each referenced identifier.
 func (e *Evaluator) findOldExpressions(expr ast.Expression, ctx *ExecutionContext, oldValues map[string]Value) {
 	if expr == nil {
 		return
 	}
 
-	switch node := expr.(type) {
-	case *ast.OldExpression:
-		// Capture the value of this identifier
-		identName := node.Identifier.Value
-		if _, exists := oldValues[identName]; !exists {
-			// Only capture once per identifier
-			val, ok := ctx.Env().Get(identName)
-			if ok {
-				// If the value is a reference (var parameter), dereference it
-				// to capture the actual value, not the reference itself
-				if refVal, ok := val.(Value); ok {
-					if refAccessor, isRef := refVal.(ReferenceAccessor); isRef {
-						derefVal, err := refAccessor.Dereference()
-						if err != nil {
-							// Store nil if dereference fails - error will be reported at evaluation time
-							oldValues[identName] = &runtime.NilValue{}
-						} else {
-							oldValues[identName] = derefVal
-						}
-					} else {
-						oldValues[identName] = refVal
-					}
-				}
-			}
-			// If not found, we'll let the runtime evaluation handle the error
-		}
-
-	case *ast.BinaryExpression:
-		e.findOldExpressions(node.Left, ctx, oldValues)
-		e.findOldExpressions(node.Right, ctx, oldValues)
-
-	case *ast.UnaryExpression:
-		e.findOldExpressions(node.Right, ctx, oldValues)
-
-	case *ast.CallExpression:
-		e.findOldExpressions(node.Function, ctx, oldValues)
-		for _, arg := range node.Arguments {
-			e.findOldExpressions(arg, ctx, oldValues)
-		}
-
-	case *ast.IndexExpression:
-		e.findOldExpressions(node.Left, ctx, oldValues)
-		e.findOldExpressions(node.Index, ctx, oldValues)
-
-	case *ast.MemberAccessExpression:
-		e.findOldExpressions(node.Object, ctx, oldValues)
-
-	case *ast.ArrayLiteralExpression:
-		for _, elem := range node.Elements {
-			e.findOldExpressions(elem, ctx, oldValues)
-		}
-
-	case *ast.SetLiteral:
-		for _, elem := range node.Elements {
-			e.findOldExpressions(elem, ctx, oldValues)
-		}
-
-	case *ast.RecordLiteralExpression:
-		for _, field := range node.Fields {
-			e.findOldExpressions(field.Value, ctx, oldValues)
+	ast.Inspect(expr, func(node ast.Node) bool {
+		switch n := node.(type) {
+		case *ast.LambdaExpression:
+			// Lambda bodies can't reference old from outer postconditions
+			// (they have their own scope), so we don't traverse them
+			return false
+		case *ast.OldExpression:
+			e.captureOldValue(n.Identifier.Value, ctx, oldValues)
+			return false
 		}
+		return true
+	})
+}
 
-	case *ast.LambdaExpression:
-		// Lambda bodies can't reference old from outer postconditions
-		// (they have their own scope), so we don't traverse them
+// captureOldValue stores the current value of identName in oldValues, once
+// per identifier. References (var parameters) are dereferenced so that the
+// value at entry is captured rather than the reference itself.
+func (e *Evaluator) captureOldValue(identName string, ctx *ExecutionContext, oldValues map[string]Value) {
+	if _, exists := oldValues[identName]; exists {
 		return
+	}
 
-	// Literals and identifiers don't contain old expressions
-	case *ast.IntegerLiteral, *ast.FloatLiteral, *ast.StringLiteral,
-		*ast.BooleanLiteral, *ast.Identifier, *ast.NilLiteral:
+	val, ok := ctx.Env().Get(identName)
+	if !ok {
+		// If not found, we'll let the runtime evaluation handle the error
+		return
+	}
+	refVal, ok := val.(Value)
+	if !ok {
+		return
+	}
+	if refAccessor, isRef := refVal.(ReferenceAccessor); isRef {
+		derefVal, err := refAccessor.Dereference()
+		if err != nil {
+			// Store nil if dereference fails - error will be reported at evaluation time
+			oldValues[identName] = &runtime.NilValue{}
+		} else {
+			oldValues[identName] = derefVal
+		}
 		return
-
-		// Add more cases as needed for other expression types
 	}
+	oldValues[identName] = refVal
 }
 
 // checkPostconditions evaluates all postconditions of a function.
@@ -222,7 +191,7 @@ func (e *Evaluator) findOldExpressions(expr ast.Expression, ctx *ExecutionContex
 //
 // Returns nil on success, error value if evaluation fails.
 func (e *Evaluator) checkPostconditions(funcName string, postConditions *ast.PostConditions, ctx *ExecutionContext) Value {
-	if postConditions == nil {
+	if postConditions == nil || e.engineState.ContractMode != runtime.ContractsFull {
 		return nil
 	}
 
@@ -243,8 +212,8 @@ func (e *Evaluator) checkPostconditions(funcName string, postConditions *ast.Pos
 
 		// If the condition failed, raise an exception
 		if !boolVal.Value {
-			// Build error message - clean up parentheses from expression
-			message := cleanContractMessage(condition.Test.String())
+			// Default message is the condition's source text
+			message := printer.Print(condition.Test)
 
 			// Evaluate custom message if provided
 			if condition.Message != nil {
@@ -264,7 +233,7 @@ func (e *Evaluator) checkPostconditions(funcName string, postConditions *ast.Pos
 				funcName, condPos.Line, condPos.Column, message)
 
 			// Raise exception directly (no adapter!)
-			e.raiseContractException("Exception", fullMessage, condition.Test, ctx)
+			e.raiseContractException("EAssertionFailed", fullMessage, condition.Test, ctx)
 			return nil
 		}
 	}
@@ -1,6 +1,7 @@
 package evaluator
 
 import (
+	"math"
 	"time"
 )
 
@@ -16,16 +17,30 @@ import (
 var delphiEpoch = time.Date(1899, time.December, 30, 0, 0, 0, 0, time.UTC)
 
 // Constants for time calculations
-const secondsPerDay = 86400.0
+const millisecondsPerDay = 86400000.0
 
 // delphiDateTimeToGoTime converts a Delphi TDateTime float64 to Go time.Time.
 // TDateTime is a float64 where:
 //   - Integer part = number of days since December 30, 1899
-//   - Fractional part = time of day (0.5 = noon, 0.25 = 6am)
+//   - Fractional part = time of day (0.5 = noon, 0.25 = 6am), also for
+//     negative values (-1.25 = 1899-12-29 06:00)
+//
+// The result is rounded to the nearest millisecond, as Delphi's DecodeTime
+// does, and works from Unix seconds since a time.Duration cannot span the
+// years 1..9999.
 func delphiDateTimeToGoTime(dt float64) time.Time {
-	seconds := dt * secondsPerDay
-	duration := time.Duration(seconds * float64(time.Second))
-	return delphiEpoch.Add(duration)
+	if dt < 0 {
+		days := math.Trunc(dt)
+		dt = days + (days - dt)
+	}
+	msecs := int64(math.Round(dt * millisecondsPerDay))
+	seconds := msecs / 1000
+	remainder := msecs % 1000
+	if remainder < 0 {
+		seconds--
+		remainder += 1000
+	}
+	return time.Unix(delphiEpoch.Unix()+seconds, remainder*int64(time.Millisecond)).UTC()
 }
 
 // extractDateComponents extracts year, month, day from a TDateTime value.
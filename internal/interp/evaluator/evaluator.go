@@ -3,6 +3,7 @@ package evaluator
 import (
 	"io"
 	"math/rand"
+	"time"
 
 	"github.com/cwbudde/go-dws/internal/builtins"
 	"github.com/cwbudde/go-dws/internal/interp/contracts"
@@ -168,13 +169,39 @@ type FunctionPointerCallable interface {
 
 // Config holds evaluator configuration options.
 type Config struct {
-	MaxRecursionDepth int
+	MaxRecursionDepth    int
+	VariantOverflow      runtime.VariantOverflowMode
+	IntegerOverflowCheck bool
+	RangeChecks          bool
+	DisableAssertions    bool
+	ContractMode         runtime.ContractMode
+	// RandomSeed seeds the random number generator when FixedRandomSeed is
+	// set; Randomize() then keeps the sequence reproducible.
+	RandomSeed      int64
+	FixedRandomSeed bool
+	// DisableDestructors turns Free, FreeAndNil and destructor calls into
+	// no-ops (pure GC mode).
+	DisableDestructors bool
+	// FormatSettings holds the separators of the number and date conversion
+	// builtins. A zero value selects the invariant settings.
+	FormatSettings runtime.FormatSettings
+	// Clock reports the current time to Now(), Date() and the other
+	// clock-reading builtins. Nil selects time.Now.
+	Clock func() time.Time
+	// RecursionStrategy selects where the Go stack frames of nested script
+	// calls live (see runtime.RecursionStrategy).
+	RecursionStrategy runtime.RecursionStrategy
+	// ValuePool supplies the Integer and Float results of arithmetic. Nil
+	// selects a new enabled pool.
+	ValuePool *runtime.ValuePool
 }
 
 // DefaultConfig returns default configuration (matches DWScript defaults).
 func DefaultConfig() *Config {
 	return &Config{
 		MaxRecursionDepth: 1024,
+		VariantOverflow:   runtime.DefaultVariantNumericRule().Overflow,
+		FormatSettings:    runtime.InvariantFormatSettings(),
 	}
 }
 
@@ -198,6 +225,14 @@ type Evaluator struct {
 	typeSystem        *interptypes.TypeSystem
 	engineState       *contracts.EngineState
 	selfContainedMode bool
+	// caseTables caches string jump tables per case statement (see caseJumpTableFor).
+	caseTables map[*ast.CaseStatement]caseJumpTable
+	// lambdaYields caches whether untyped lambdas produce a value (see lambdaYieldsValue).
+	lambdaYields map[*ast.LambdaExpression]bool
+	// loopCaptures caches whether loop bodies create lambdas (see evalLoopBody).
+	loopCaptures map[ast.Statement]bool
+	// interrupt stops evaluation once it returns true (see SetInterrupt).
+	interrupt func() bool
 }
 
 // Ensure Evaluator implements builtins.Context interface.
@@ -216,8 +251,11 @@ func NewEvaluator(
 		config = DefaultConfig()
 	}
 
-	const defaultSeed = int64(1)
-	source := rand.NewSource(defaultSeed)
+	seed := int64(1)
+	if config.FixedRandomSeed {
+		seed = config.RandomSeed
+	}
+	source := rand.NewSource(seed)
 	state := &contracts.EngineState{
 		SourceCode:        "",
 		SourceFile:        "",
@@ -229,8 +267,21 @@ func NewEvaluator(
 		MethodRegistry:    runtime.NewMethodRegistry(),
 		Random:            rand.New(source),
 		LoadedUnits:       make([]string, 0),
-		RandomSeed:        defaultSeed,
+		RandomSeed:        seed,
 		MaxRecursionDepth: config.MaxRecursionDepth,
+		VariantNumericRule: runtime.VariantNumericRule{
+			Overflow: config.VariantOverflow,
+		},
+		IntegerOverflowCheck: config.IntegerOverflowCheck,
+		RangeChecks:          config.RangeChecks,
+		DisableAssertions:    config.DisableAssertions,
+		FixedRandomSeed:      config.FixedRandomSeed,
+		DisableDestructors:   config.DisableDestructors,
+		FormatSettings:       formatSettingsOrInvariant(config.FormatSettings),
+		Clock:                clockOrDefault(config.Clock),
+		ContractMode:         config.ContractMode,
+		RecursionStrategy:    config.RecursionStrategy,
+		Values:               valuePoolOrDefault(config.ValuePool),
 	}
 
 	return &Evaluator{
@@ -291,7 +342,19 @@ func (e *Evaluator) SetExternalFunctions(reg ExternalFunctionRegistry) {
 // Config returns the configuration.
 func (e *Evaluator) Config() *Config {
 	return &Config{
-		MaxRecursionDepth: e.engineState.MaxRecursionDepth,
+		MaxRecursionDepth:    e.engineState.MaxRecursionDepth,
+		VariantOverflow:      e.engineState.VariantNumericRule.Overflow,
+		IntegerOverflowCheck: e.engineState.IntegerOverflowCheck,
+		RangeChecks:          e.engineState.RangeChecks,
+		DisableAssertions:    e.engineState.DisableAssertions,
+		ContractMode:         e.engineState.ContractMode,
+		RandomSeed:           e.engineState.RandomSeed,
+		FixedRandomSeed:      e.engineState.FixedRandomSeed,
+		DisableDestructors:   e.engineState.DisableDestructors,
+		FormatSettings:       e.engineState.FormatSettings,
+		Clock:                e.engineState.Clock,
+		RecursionStrategy:    e.engineState.RecursionStrategy,
+		ValuePool:            e.engineState.Values,
 	}
 }
 
@@ -302,6 +365,60 @@ func (e *Evaluator) SetConfig(cfg *Config) {
 	}
 	e.config = cfg
 	e.engineState.MaxRecursionDepth = cfg.MaxRecursionDepth
+	e.engineState.VariantNumericRule.Overflow = cfg.VariantOverflow
+	e.engineState.IntegerOverflowCheck = cfg.IntegerOverflowCheck
+	e.engineState.RangeChecks = cfg.RangeChecks
+	e.engineState.DisableAssertions = cfg.DisableAssertions
+	e.engineState.ContractMode = cfg.ContractMode
+	e.engineState.FixedRandomSeed = cfg.FixedRandomSeed
+	e.engineState.DisableDestructors = cfg.DisableDestructors
+	e.engineState.FormatSettings = formatSettingsOrInvariant(cfg.FormatSettings)
+	e.engineState.Clock = clockOrDefault(cfg.Clock)
+	e.engineState.RecursionStrategy = cfg.RecursionStrategy
+	e.engineState.Values = valuePoolOrDefault(cfg.ValuePool)
+	if cfg.FixedRandomSeed {
+		e.SetRandomSeed(cfg.RandomSeed)
+	}
+}
+
+// valuePoolOrDefault returns pool, or a new enabled pool if pool is nil.
+func valuePoolOrDefault(pool *runtime.ValuePool) *runtime.ValuePool {
+	if pool == nil {
+		return runtime.NewValuePool(true)
+	}
+	return pool
+}
+
+// newInteger returns an Integer value from the engine's value pool.
+func (e *Evaluator) newInteger(value int64) *runtime.IntegerValue {
+	if e.engineState == nil || e.engineState.Values == nil {
+		return runtime.NewInteger(value)
+	}
+	return e.engineState.Values.NewInteger(value)
+}
+
+// newFloat returns a Float value from the engine's value pool.
+func (e *Evaluator) newFloat(value float64) *runtime.FloatValue {
+	if e.engineState == nil || e.engineState.Values == nil {
+		return runtime.NewFloat(value)
+	}
+	return e.engineState.Values.NewFloat(value)
+}
+
+// formatSettingsOrInvariant returns fs, or the invariant settings if fs is unset.
+func formatSettingsOrInvariant(fs runtime.FormatSettings) runtime.FormatSettings {
+	if fs.DecimalSeparator == "" {
+		return runtime.InvariantFormatSettings()
+	}
+	return fs
+}
+
+// clockOrDefault returns clock, or time.Now if clock is unset.
+func clockOrDefault(clock func() time.Time) func() time.Time {
+	if clock == nil {
+		return time.Now
+	}
+	return clock
 }
 
 // MaxRecursionDepth returns the maximum recursion depth.
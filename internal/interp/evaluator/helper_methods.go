@@ -59,31 +59,35 @@ func zeroArgHelperOverload(result *HelperMethodResult) *ast.FunctionDecl {
 		return nil
 	}
 	if len(result.Overloads) == 0 {
-		if result.Method != nil && helperASTMethodEffectiveParamCount(result.Method) == 0 {
+		if result.Method != nil && helperASTMethodRequiredParamCount(result.Method) == 0 {
 			return result.Method
 		}
 		return nil
 	}
 	for _, m := range result.Overloads {
-		if m != nil && helperASTMethodEffectiveParamCount(m) == 0 {
+		if m != nil && helperASTMethodRequiredParamCount(m) == 0 {
 			return m
 		}
 	}
 	return nil
 }
 
-func helperASTMethodEffectiveParamCount(method *ast.FunctionDecl) int {
+// helperASTMethodParams returns the parameters a call passes arguments for,
+// excluding the implicit Self parameter of helper functions.
+func helperASTMethodParams(method *ast.FunctionDecl) []*ast.Parameter {
 	if method == nil {
-		return 0
-	}
-	count := len(method.Parameters)
-	if method.IsHelper {
-		count--
+		return nil
 	}
-	if count < 0 {
-		return 0
+	if method.IsHelper && len(method.Parameters) > 0 {
+		return method.Parameters[1:]
 	}
-	return count
+	return method.Parameters
+}
+
+// helperASTMethodRequiredParamCount returns the number of arguments a call to
+// the helper method must pass; parameters with default values are optional.
+func helperASTMethodRequiredParamCount(method *ast.FunctionDecl) int {
+	return requiredParameterCount(helperASTMethodParams(method))
 }
 
 func helperResultAliasWouldShadowTarget(selfValue Value, name string) bool {
@@ -439,11 +443,15 @@ func (e *Evaluator) CallHelperMethod(
 	if result.Method != nil {
 		if len(result.Overloads) > 1 {
 			for _, candidate := range result.Overloads {
-				expected := len(candidate.Parameters)
-				if candidate.IsHelper {
-					expected--
+				if len(helperASTMethodParams(candidate)) == len(args) {
+					return e.CallASTHelperMethod(result.OwnerHelper, candidate, selfValue, args, node, ctx)
 				}
-				if expected == len(args) {
+			}
+			// No exact match: accept an overload whose omitted trailing
+			// parameters all have defaults.
+			for _, candidate := range result.Overloads {
+				if len(args) >= helperASTMethodRequiredParamCount(candidate) &&
+					len(args) <= len(helperASTMethodParams(candidate)) {
 					return e.CallASTHelperMethod(result.OwnerHelper, candidate, selfValue, args, node, ctx)
 				}
 			}
@@ -510,16 +518,9 @@ func (e *Evaluator) CallASTHelperMethod(
 		return e.newError(node, "helper method not found (nil owner)")
 	}
 
-	expectedArgCount := len(method.Parameters)
-	if method.IsHelper {
-		expectedArgCount--
-	}
-	if expectedArgCount < 0 {
-		expectedArgCount = 0
-	}
-	if len(args) != expectedArgCount {
-		return e.newError(node, "wrong number of arguments for helper method '%s': expected %d, got %d",
-			method.Name.Value, expectedArgCount, len(args))
+	args, err := e.fillDefaultArguments(helperASTMethodParams(method), args, ctx)
+	if err != nil {
+		return e.newError(node, "%v for helper method '%s'", err, method.Name.Value)
 	}
 
 	// Create method environment (enclosed scope)
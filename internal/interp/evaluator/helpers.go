@@ -4,6 +4,7 @@ import (
 	"unicode/utf8"
 
 	"github.com/cwbudde/go-dws/internal/interp/runtime"
+	"github.com/cwbudde/go-dws/pkg/ident"
 )
 
 // This file contains shared helper functions for the evaluator.
@@ -126,10 +127,13 @@ func IsInRange(value, start, end Value) bool {
 			return v.Value >= startStr.Value && v.Value <= endStr.Value
 		}
 
-	default:
-		// For EnumValue and other types, check by type name
-		// TODO: Implement enum range checking
-		return false
+	case *runtime.EnumValue:
+		// Enum ranges (Red..Blue) compare by ordinal within the same enum type
+		startEnum, startOk := start.(*runtime.EnumValue)
+		endEnum, endOk := end.(*runtime.EnumValue)
+		if startOk && endOk && ident.Equal(v.TypeName, startEnum.TypeName) && ident.Equal(v.TypeName, endEnum.TypeName) {
+			return v.OrdinalValue >= startEnum.OrdinalValue && v.OrdinalValue <= endEnum.OrdinalValue
+		}
 	}
 
 	return false
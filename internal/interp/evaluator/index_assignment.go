@@ -4,6 +4,7 @@ import (
 	"strings"
 
 	"github.com/cwbudde/go-dws/internal/interp/runtime"
+	"github.com/cwbudde/go-dws/internal/types"
 	"github.com/cwbudde/go-dws/pkg/ast"
 )
 
@@ -130,6 +131,11 @@ func (e *Evaluator) evalIndexAssignmentDirect(
 		return e.evalDefaultPropertyAssignment(arrayVal, indexVal, value, stmt, ctx)
 	}
 
+	// Records may expose a default indexed property as well: rec[i] := value
+	if recVal, ok := arrayVal.(RecordInstanceValue); ok {
+		return e.evalRecordDefaultPropertyAssignment(recVal, indexVal, value, stmt, ctx)
+	}
+
 	// Associative array write: a[key] := value. Inserts a new key or updates an
 	// existing one; there is no bounds check. Element value semantics are
 	// preserved by snapshotting record/static-array values.
@@ -188,11 +194,8 @@ func (e *Evaluator) evalArrayElementAssignment(
 		lowBound := *arrayType.LowBound
 		highBound := *arrayType.HighBound
 
-		if index < lowBound {
-			return e.raiseIndexBoundExceeded(diagNode, index, false)
-		}
-		if index > highBound {
-			return e.raiseIndexBoundExceeded(diagNode, index, true)
+		if index < lowBound || index > highBound {
+			return e.raiseStaticIndexBoundExceededAt(indexBracketPos(diagNode), index, arrayType)
 		}
 
 		physicalIndex = index - lowBound
@@ -213,6 +216,10 @@ func (e *Evaluator) evalArrayElementAssignment(
 		return e.newError(stmt, "array index out of bounds: physical index %d, length %d", physicalIndex, len(arrayValue.Elements))
 	}
 
+	if rangeErr := e.checkSubrangeWrite(arrayType.ElementType, value, diagNode); rangeErr != nil {
+		return rangeErr
+	}
+
 	// Update the array element. Record/static-array elements have value
 	// semantics, so store a snapshot instead of aliasing the source value.
 	arrayValue.Elements[physicalIndex] = cloneIfCopyable(value)
@@ -231,7 +238,7 @@ func (e *Evaluator) evalStringCharAssignment(
 	// Bounds check using rune length (DWScript strings are 1-based)
 	strLen := RuneLength(strVal.Value)
 	if index < 1 || index > strLen {
-		return e.newError(stmt, "string index out of bounds: %d (string length is %d)", index, strLen)
+		return e.newErrorOfClass(stmt, "ERangeError", "string index out of bounds: %d (string length is %d)", index, strLen)
 	}
 
 	// Value to assign must be a string (character); use first rune
@@ -253,7 +260,7 @@ func (e *Evaluator) evalStringCharAssignment(
 		return value
 	}
 
-	return e.newError(stmt, "string index out of bounds: %d (string length is %d)", index, strLen)
+	return e.newErrorOfClass(stmt, "ERangeError", "string index out of bounds: %d (string length is %d)", index, strLen)
 }
 
 // evalIndexedPropertyAssignment handles indexed property assignment: obj.Prop[i] := value
@@ -450,3 +457,41 @@ func (e *Evaluator) evalDefaultPropertyAssignment(
 
 	return value
 }
+
+// evalRecordDefaultPropertyAssignment handles rec[index] := value through the
+// default indexed property of a record. The setter runs with Self bound to the
+// record itself, so field updates are visible through the assigned variable.
+func (e *Evaluator) evalRecordDefaultPropertyAssignment(
+	record RecordInstanceValue,
+	indexVal Value,
+	value Value,
+	stmt *ast.AssignmentStatement,
+	ctx *ExecutionContext,
+) Value {
+	accessor, ok := record.(runtime.PropertyAccessor)
+	if !ok {
+		return e.newError(stmt, "cannot index type %s", record.Type())
+	}
+	propDesc := accessor.GetDefaultProperty()
+	if propDesc == nil {
+		return e.newError(stmt, "cannot index type %s", record.Type())
+	}
+	propInfo, ok := propDesc.Impl.(*types.RecordPropertyInfo)
+	if !ok {
+		return e.newError(stmt, "internal error: expected *types.RecordPropertyInfo for indexed property write")
+	}
+	if propInfo.WriteField == "" {
+		return e.newError(stmt, readOnlyPropertyWriteMessage)
+	}
+
+	methodDecl, found := record.GetRecordMethod(propInfo.WriteField)
+	if !found {
+		return e.newError(stmt, "default property write accessor '%s' is not a method", propInfo.WriteField)
+	}
+
+	result := e.callRecordMethod(record, methodDecl, []Value{indexVal, value}, stmt, ctx)
+	if isError(result) {
+		return result
+	}
+	return value
+}
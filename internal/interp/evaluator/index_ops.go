@@ -30,11 +30,8 @@ func (e *Evaluator) IndexArray(arr *runtime.ArrayValue, index int, node ast.Node
 		lowBound := *arr.ArrayType.LowBound
 		highBound := *arr.ArrayType.HighBound
 
-		if index < lowBound {
-			return e.raiseIndexBoundExceededAt(node.End(), index, false)
-		}
-		if index > highBound {
-			return e.raiseIndexBoundExceededAt(node.End(), index, true)
+		if index < lowBound || index > highBound {
+			return e.raiseStaticIndexBoundExceededAt(node.End(), index, arr.ArrayType)
 		}
 
 		physicalIndex = index - lowBound
@@ -58,8 +55,11 @@ func (e *Evaluator) IndexArray(arr *runtime.ArrayValue, index int, node ast.Node
 	// Return the element
 	elem := arr.Elements[physicalIndex]
 	if elem == nil {
-		// Return properly typed zero value for uninitialized elements
-		return e.getZeroValueForType(arr.ArrayType.ElementType)
+		// Store a properly typed zero value for uninitialized elements, so
+		// in-place updates through the returned value (a record method
+		// mutating Self, grid[i][j] on a freshly resized row) land in the array.
+		elem = e.getZeroValueForType(arr.ArrayType.ElementType)
+		arr.Elements[physicalIndex] = elem
 	}
 
 	return elem
@@ -68,17 +68,17 @@ func (e *Evaluator) IndexArray(arr *runtime.ArrayValue, index int, node ast.Node
 // IndexString performs string indexing (returns a single-character string).
 // DWScript strings are 1-indexed.
 func (e *Evaluator) IndexString(str *runtime.StringValue, index int, node ast.Node) Value {
-	// DWScript strings are 1-indexed
-	// Use rune-based indexing to handle UTF-8 correctly
-	strLen := RuneLength(str.Value)
+	// DWScript strings are 1-indexed over code points; the StringValue caches
+	// its decoded runes so indexing in a loop stays linear overall.
+	strLen := str.RuneLength()
 	if index < 1 || index > strLen {
-		return e.newError(node, "string index out of bounds: %d (string length is %d)", index, strLen)
+		return e.newErrorOfClass(node, "ERangeError", "string index out of bounds: %d (string length is %d)", index, strLen)
 	}
 
 	// Get the character at the given position
-	char, ok := RuneAt(str.Value, index)
+	char, ok := str.RuneAt(index)
 	if !ok {
-		return e.newError(node, "string index out of bounds: %d", index)
+		return e.newErrorOfClass(node, "ERangeError", "string index out of bounds: %d", index)
 	}
 	return &runtime.StringValue{Value: string(char)}
 }
@@ -204,6 +204,17 @@ func (e *Evaluator) getZeroValueForType(t types.Type) runtime.Value {
 			return &runtime.NilValue{ClassType: classType.Name}
 		}
 		return &runtime.NilValue{}
+	case "ENUM":
+		// Enums default to ordinal 0, matching zeroed storage in DWScript.
+		if enumType, ok := t.(*types.EnumType); ok {
+			return runtime.NewEnumValue(enumType.Name, enumType, 0)
+		}
+		return &runtime.NilValue{}
+	case "SET":
+		if setType, ok := t.(*types.SetType); ok {
+			return runtime.NewSetValue(setType)
+		}
+		return &runtime.NilValue{}
 	case "VARIANT":
 		// Variant fields initialize as nil (VariantValue is in interp package)
 		// For now, return nil - the adapter will handle variant initialization if needed
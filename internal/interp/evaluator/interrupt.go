@@ -0,0 +1,38 @@
+package evaluator
+
+import (
+	"github.com/cwbudde/go-dws/pkg/ast"
+)
+
+// ============================================================================
+// Interruption
+// ============================================================================
+//
+// A host can stop a running script through the function passed to
+// SetInterrupt. The evaluator polls it before every statement and every loop
+// iteration, and once it reports true returns an error value that unwinds
+// the whole program.
+// Unlike other runtime errors it is never turned into a script exception, so
+// try/except cannot catch it, and finally blocks stop at their first
+// statement like everything else.
+// ============================================================================
+
+// SetInterrupt makes evaluation stop once interrupted returns true. A nil
+// function (the default) never interrupts.
+func (e *Evaluator) SetInterrupt(interrupted func() bool) {
+	e.interrupt = interrupted
+}
+
+// interrupted polls the function set with SetInterrupt.
+func (e *Evaluator) interrupted() bool {
+	return e.interrupt != nil && e.interrupt()
+}
+
+// checkInterrupt returns an error positioned at node when evaluation has
+// been interrupted, and nil otherwise.
+func (e *Evaluator) checkInterrupt(node ast.Node) Value {
+	if e.interrupted() {
+		return e.newError(node, "script interrupted")
+	}
+	return nil
+}
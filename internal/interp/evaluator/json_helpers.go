@@ -4,7 +4,6 @@ import (
 	"encoding/json"
 	"fmt"
 	"reflect"
-	"strings"
 
 	"github.com/cwbudde/go-dws/internal/interp/runtime"
 	"github.com/cwbudde/go-dws/internal/jsonvalue"
@@ -220,9 +219,9 @@ func ValueToJSONValue(val Value) *jsonvalue.Value {
 	case *runtime.RecordValue:
 		// Convert DWScript record to JSON object
 		obj := jsonvalue.NewObject()
-		for fieldName, fieldValue := range v.Fields {
+		for _, fieldName := range v.OrderedFieldNames() {
 			// Recursively convert each field
-			jsonField := ValueToJSONValue(fieldValue)
+			jsonField := ValueToJSONValue(v.Fields[fieldName])
 			obj.ObjectSet(fieldName, jsonField)
 		}
 		return obj
@@ -270,19 +269,13 @@ func JSONKindToVarType(kind jsonvalue.Kind) int64 {
 // ParseJSONString parses a JSON string and returns a jsonvalue.Value.
 // This is the core JSON parsing function used by ParseJSON and related functions.
 func ParseJSONString(jsonStr string) (*jsonvalue.Value, error) {
-	// Decode JSON into Go interface{}
-	var data interface{}
-	decoder := json.NewDecoder(strings.NewReader(jsonStr))
-	decoder.UseNumber() // Preserve number precision
-
-	err := decoder.Decode(&data)
+	// Decode token by token so object keys keep their document order
+	jsonVal, err := jsonvalue.Parse(jsonStr)
 	if err != nil {
 		// Return detailed error with position
 		return nil, FormatJSONError(err, jsonStr)
 	}
-
-	// Convert to jsonvalue.Value
-	return GoValueToJSONValue(data), nil
+	return jsonVal, nil
 }
 
 // GoValueToJSONValue converts a Go interface{} value from encoding/json
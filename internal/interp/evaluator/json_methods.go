@@ -327,7 +327,7 @@ func (e *Evaluator) jsonSwap(jv *jsonvalue.Value, args []Value, node ast.Node) V
 	j, jok := ExtractIntegerIndex(argValue(args, 1))
 	n := jv.ArrayLen()
 	if !iok || !jok || i < 0 || i >= n || j < 0 || j >= n {
-		return e.newError(node, "Upper bound exceeded! Index %d", i)
+		return e.newErrorOfClass(node, "ERangeError", "Upper bound exceeded! Index %d", i)
 	}
 	ei, ej := jv.ArrayGet(i), jv.ArrayGet(j)
 	jv.ArraySet(i, ej)
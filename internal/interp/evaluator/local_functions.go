@@ -9,7 +9,6 @@ package evaluator
 import (
 	"strings"
 
-	"github.com/cwbudde/go-dws/internal/builtins"
 	"github.com/cwbudde/go-dws/internal/interp/runtime"
 	"github.com/cwbudde/go-dws/internal/semantic"
 	"github.com/cwbudde/go-dws/internal/types"
@@ -108,7 +107,7 @@ func parametersMatchLocal(a, b []*ast.Parameter) bool {
 // or user overload executed), or (nil, false) to fall through to the regular
 // user-function path.
 func (e *Evaluator) maybeCallBuiltinOverload(funcName string, overloads []*ast.FunctionDecl, node *ast.CallExpression, ctx *ExecutionContext) (Value, bool) {
-	info, ok := builtins.DefaultRegistry.Get(funcName)
+	info, ok := e.FunctionRegistry().GetBuiltinInfo(funcName)
 	if !ok || info == nil || info.Signature == nil {
 		return nil, false
 	}
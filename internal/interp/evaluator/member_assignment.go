@@ -143,6 +143,11 @@ func (e *Evaluator) evalMemberAssignmentDirect(
 			return e.newError(stmt, "property '%s' not found in record", fieldName)
 		}
 		// Simple field assignment - use RecordFieldSetter if available
+		if recVal, ok := objVal.(*runtime.RecordValue); ok && recVal.RecordType != nil {
+			if rangeErr := e.checkSubrangeWrite(recVal.RecordType.GetFieldType(fieldName), value, stmt); rangeErr != nil {
+				return rangeErr
+			}
+		}
 		if setter, ok := objVal.(RecordFieldSetter); ok {
 			setter.SetRecordField(fieldName, value)
 			return value
@@ -188,6 +193,11 @@ func (e *Evaluator) evalMemberAssignmentDirect(
 
 	// NATIVE: Object field/property assignment
 	if objValIface, ok := objVal.(ObjectValue); ok {
+		// Writing a member of an explicitly freed object fails like reading one.
+		if objInst, ok := objVal.(*runtime.ObjectInstance); ok && objInst.ExplicitlyFreed {
+			return e.newError(target.Member, "Object already destroyed")
+		}
+
 		// Check if this is a property (has priority over fields)
 		if objValIface.HasProperty(fieldName) {
 			// Property assignment via callback pattern
@@ -196,9 +206,20 @@ func (e *Evaluator) evalMemberAssignmentDirect(
 			})
 		}
 
+		// Class variable accessed through an instance (obj.ClassVar := ...):
+		// write the shared slot on the declaring class, never a per-instance copy.
+		if getFieldWithStaticClass(objValIface, fieldName, staticClassName) == nil {
+			if _, found := objValIface.GetClassVar(fieldName); found {
+				return e.assignToClassVarViaSelf(stmt, fieldName, value, objVal)
+			}
+		}
+
 		// Direct field assignment (resolved against the static class of the
 		// object expression, which matters for shadowed fields)
 		if objInst, ok := objVal.(*runtime.ObjectInstance); ok {
+			if rangeErr := e.checkSubrangeWrite(objectFieldType(objInst, fieldName), value, stmt); rangeErr != nil {
+				return rangeErr
+			}
 			objInst.SetFieldFromClass(fieldName, value, staticClassName)
 			return value
 		}
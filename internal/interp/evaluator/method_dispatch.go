@@ -252,6 +252,9 @@ func (e *Evaluator) DispatchMethodCall(obj Value, methodName string, args []Valu
 		if helperResult := e.FindHelperMethod(obj, methodName); helperResult != nil {
 			return e.CallHelperMethod(helperResult, obj, args, node, ctx)
 		}
+		if result, handled := e.evalInheritsFrom(classMeta.GetClassInfo(), methodName, args, node); handled {
+			return result
+		}
 		// Handle overloaded class methods via evaluator-owned dispatch
 		if classInfo := classMeta.GetClassInfo(); classInfo != nil {
 			classOverloads := classInfo.GetClassMethodOverloads(methodName)
@@ -579,6 +582,9 @@ func (e *Evaluator) dispatchObjectMethod(obj Value, methodName string, args []Va
 		}
 		return e.runObjectDestructor(objInst, classInfo.LookupMethod("Destroy"), node, ctx)
 	}
+	if result, handled := e.evalInheritsFrom(classInfo, methodName, args, node); handled {
+		return result
+	}
 
 	// Dispatch to evaluator-owned overload resolver when the method has
 	// overloads. Instance and class (static) methods sharing a name form one
@@ -612,11 +618,12 @@ func (e *Evaluator) dispatchObjectMethod(obj Value, methodName string, args []Va
 }
 
 // runObjectDestructor executes an object's destructor and marks the object as destroyed.
+// With destructors disabled (pure GC mode) it does nothing and the object stays usable.
 func (e *Evaluator) runObjectDestructor(obj *runtime.ObjectInstance, destructor *ast.FunctionDecl, node ast.Node, ctx *ExecutionContext) Value {
 	if obj == nil {
 		return e.nilValue()
 	}
-	if obj.Destroyed {
+	if obj.Destroyed || e.engineState.DisableDestructors {
 		return e.nilValue()
 	}
 
@@ -32,6 +32,12 @@ func (e *Evaluator) getValueType(val Value) types.Type {
 		return types.NIL
 	case *runtime.VariantValue:
 		return types.VARIANT
+	case *runtime.EnumValue:
+		if enumType, err := e.lookupEnumType(v.TypeName); err == nil {
+			return enumType
+		}
+		// Unregistered enum: keep the kind so ordinal conversions still apply
+		return types.NewEnumType(v.TypeName, nil, nil)
 	case *runtime.ArrayValue:
 		if v.ArrayType != nil {
 			return v.ArrayType
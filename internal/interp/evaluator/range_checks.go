@@ -0,0 +1,59 @@
+package evaluator
+
+import (
+	"github.com/cwbudde/go-dws/internal/interp/runtime"
+	"github.com/cwbudde/go-dws/internal/types"
+	"github.com/cwbudde/go-dws/pkg/ast"
+	"github.com/cwbudde/go-dws/pkg/ident"
+)
+
+// ============================================================================
+// Range Checks
+// ============================================================================
+//
+// With range checking enabled ({$R+}), writes to array elements and record or
+// object fields declared with a subrange type are validated against the
+// subrange bounds. Variables of a subrange type hold a SubrangeValue and are
+// always validated (see evalSubrangeAssignment).
+// ============================================================================
+
+// checkSubrangeWrite validates value against target when range checking is
+// enabled and target is a subrange type. It returns an ERangeError positioned
+// at node when the value is out of range, and nil otherwise.
+func (e *Evaluator) checkSubrangeWrite(target types.Type, value Value, node ast.Node) Value {
+	if !e.engineState.RangeChecks || target == nil {
+		return nil
+	}
+	subrange, ok := types.GetUnderlyingType(target).(*types.SubrangeType)
+	if !ok {
+		return nil
+	}
+
+	var intValue int
+	switch v := value.(type) {
+	case *runtime.IntegerValue:
+		intValue = int(v.Value)
+	case SubrangeValueAccessor:
+		intValue = v.GetValue()
+	default:
+		return nil
+	}
+
+	if err := types.ValidateRange(intValue, subrange); err != nil {
+		return e.newErrorOfClass(node, "ERangeError", "%s", err.Error())
+	}
+	return nil
+}
+
+// objectFieldType returns the declared type of an object field, or nil when
+// the field has no type metadata.
+func objectFieldType(obj *runtime.ObjectInstance, fieldName string) types.Type {
+	if obj.Class == nil {
+		return nil
+	}
+	field := runtime.LookupFieldInHierarchy(obj.Class.GetMetadata(), ident.Normalize(fieldName))
+	if field == nil {
+		return nil
+	}
+	return field.Type
+}
@@ -27,11 +27,10 @@ func (e *Evaluator) callRecordMethod(
 	node ast.Node,
 	ctx *ExecutionContext,
 ) Value {
-	// 1. Validate parameter count
-	if len(args) != len(method.Parameters) {
-		return e.newError(node,
-			"wrong number of arguments for method '%s': expected %d, got %d",
-			method.Name.Value, len(method.Parameters), len(args))
+	// 1. Validate parameter count, filling omitted optional arguments
+	args, err := e.fillDefaultArguments(method.Parameters, args, ctx)
+	if err != nil {
+		return e.newError(node, "%v for method '%s'", err, method.Name.Value)
 	}
 
 	// 2. Create method environment (child of current context)
@@ -103,6 +102,10 @@ func (e *Evaluator) callRecordStaticMethod(
 	if errVal != nil {
 		return errVal
 	}
+	args, err := e.fillDefaultArguments(method.Parameters, args, ctx)
+	if err != nil {
+		return e.newError(node, "%v for static method '%s'", err, method.Name.Value)
+	}
 
 	ctx.PushEnv()
 	defer ctx.PopEnv()
@@ -176,7 +179,7 @@ func (e *Evaluator) resolveRecordStaticMethod(
 
 	if len(overloads) == 1 {
 		candidate := overloads[0]
-		if len(candidate.Parameters) == len(args) {
+		if len(args) >= requiredParameterCount(candidate.Parameters) && len(args) <= len(candidate.Parameters) {
 			return candidate, nil
 		}
 	} else if candidate := e.resolveRecordMethodOverload(methodName, overloads, args); candidate != nil {
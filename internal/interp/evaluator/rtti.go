@@ -0,0 +1,209 @@
+package evaluator
+
+import (
+	"sort"
+
+	"github.com/cwbudde/go-dws/internal/interp/runtime"
+	"github.com/cwbudde/go-dws/internal/types"
+	"github.com/cwbudde/go-dws/pkg/ast"
+	"github.com/cwbudde/go-dws/pkg/ident"
+)
+
+// GetTypeInfoOf returns the runtime type information of a value as a
+// TRTTITypeInfo value. Objects and interfaces describe their dynamic class;
+// class references describe the referenced class.
+//
+// This backs the TypeOf() builtin.
+func (e *Evaluator) GetTypeInfoOf(value Value) Value {
+	value = unwrapVariant(value)
+	if intf, ok := value.(*runtime.InterfaceInstance); ok && intf.Object != nil {
+		value = intf.Object
+	}
+
+	switch v := value.(type) {
+	case *runtime.ObjectInstance:
+		if v.Class != nil {
+			return &runtime.RTTITypeInfoValue{TypeName: v.Class.GetName(), Class: v.Class, Instance: v}
+		}
+	case ClassMetaValue:
+		return &runtime.RTTITypeInfoValue{TypeName: v.GetClassName(), Class: v.GetClassInfo()}
+	case *runtime.ArrayValue:
+		info := &runtime.RTTITypeInfoValue{TypeName: e.GetTypeOf(v), IsArray: true}
+		if v.ArrayType != nil {
+			info.TypeInfo = v.ArrayType
+			info.TypeName = v.ArrayType.String()
+		}
+		return info
+	case *runtime.RecordValue:
+		info := &runtime.RTTITypeInfoValue{TypeName: e.GetTypeOf(v), IsRecord: true}
+		if v.RecordType != nil {
+			info.TypeInfo = v.RecordType
+		}
+		return info
+	}
+	return &runtime.RTTITypeInfoValue{TypeName: e.GetTypeOf(value)}
+}
+
+// evalRTTIMember reads a member of a TRTTITypeInfo value (see
+// types.RTTITypeInfoType).
+func (e *Evaluator) evalRTTIMember(info *runtime.RTTITypeInfoValue, memberName string, node ast.Node, ctx *ExecutionContext) Value {
+	switch ident.Normalize(memberName) {
+	case "name":
+		return &runtime.StringValue{Value: info.TypeName}
+	case "isclass":
+		return &runtime.BooleanValue{Value: info.IsClass()}
+	case "isarray":
+		return &runtime.BooleanValue{Value: info.IsArray}
+	case "isrecord":
+		return &runtime.BooleanValue{Value: info.IsRecord}
+	case "fields":
+		return e.rttiFields(info, node, ctx)
+	case "methods":
+		return rttiMethods(info)
+	}
+	return e.newError(node, "member '%s' not found on value of type 'TRTTITypeInfo'", memberName)
+}
+
+// rttiFields returns the public fields and readable non-indexed properties of
+// a class, except those of TObject, as TRTTIMember records. The order matches
+// JSON serialization: most derived class first, then ordinally within each
+// class level. Values are only read when the type info was obtained from an
+// object.
+func (e *Evaluator) rttiFields(info *runtime.RTTITypeInfoValue, node ast.Node, ctx *ExecutionContext) Value {
+	result := &runtime.ArrayValue{ArrayType: types.NewDynamicArrayType(types.RTTI_MEMBER)}
+	seen := make(map[string]bool)
+
+	for cur := info.Class; cur != nil && !isRootClass(cur); cur = cur.GetParent() {
+		var names []string
+		values := make(map[string]Value)
+		add := func(name string, value Value) {
+			norm := ident.Normalize(name)
+			if seen[norm] {
+				return
+			}
+			seen[norm] = true
+			names = append(names, name)
+			values[name] = value
+		}
+
+		if meta := cur.GetMetadata(); meta != nil {
+			for _, fm := range meta.Fields {
+				if fm.Visibility != runtime.FieldVisibilityPublic {
+					continue
+				}
+				var value Value
+				if info.Instance != nil {
+					value = info.Instance.GetFieldFromClass(fm.Name, cur.GetName())
+				}
+				add(fm.Name, value)
+			}
+		}
+
+		for _, prop := range ownProperties(cur) {
+			if prop.IsIndexed {
+				continue
+			}
+			pInfo, ok := unwrapPropertyInfo(prop)
+			if !ok || pInfo.ReadKind == types.PropAccessNone || pInfo.IsClassProperty {
+				continue
+			}
+			var value Value
+			if info.Instance != nil {
+				value = e.executePropertyRead(info.Instance, prop, node, ctx)
+				if isError(value) {
+					return value
+				}
+			}
+			add(prop.Name, value)
+		}
+
+		sort.Strings(names)
+		for _, name := range names {
+			result.Elements = append(result.Elements, &runtime.RecordValue{
+				RecordType: types.RTTI_MEMBER,
+				Fields: map[string]Value{
+					"name":  &runtime.StringValue{Value: name},
+					"value": runtime.BoxVariant(values[name]),
+				},
+			})
+		}
+	}
+	return result
+}
+
+// isRootClass reports whether classInfo is the built-in TObject, whose members
+// are not listed by RTTI.
+func isRootClass(classInfo runtime.IClassInfo) bool {
+	return classInfo.GetParent() == nil && ident.Equal(classInfo.GetName(), "TObject")
+}
+
+// ownMethodLister is implemented by the concrete *interp.ClassInfo to expose
+// the methods declared directly on a class level. Asserted structurally on
+// runtime.IClassInfo to avoid an import cycle.
+type ownMethodLister interface {
+	GetOwnMethods() []*ast.FunctionDecl
+}
+
+// rttiMethods returns the names of the public methods of a class, except those
+// of TObject, ordered like rttiFields. Overloads are listed once; constructors
+// and destructors are omitted.
+func rttiMethods(info *runtime.RTTITypeInfoValue) Value {
+	result := &runtime.ArrayValue{ArrayType: types.NewDynamicArrayType(types.STRING)}
+	seen := make(map[string]bool)
+
+	for cur := info.Class; cur != nil && !isRootClass(cur); cur = cur.GetParent() {
+		lister, ok := cur.(ownMethodLister)
+		if !ok {
+			continue
+		}
+		var names []string
+		for _, decl := range lister.GetOwnMethods() {
+			if decl.IsConstructor || decl.IsDestructor || decl.Visibility != ast.VisibilityPublic {
+				continue
+			}
+			norm := ident.Normalize(decl.Name.Value)
+			if seen[norm] {
+				continue
+			}
+			seen[norm] = true
+			names = append(names, decl.Name.Value)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			result.Elements = append(result.Elements, &runtime.StringValue{Value: name})
+		}
+	}
+	return result
+}
+
+// evalInheritsFrom implements the built-in TObject.InheritsFrom for objects
+// and class references. The argument is a class name or a class reference;
+// a class inherits from itself. It reports false as second result when the
+// class declares its own InheritsFrom method, which then takes precedence.
+func (e *Evaluator) evalInheritsFrom(classInfo runtime.IClassInfo, methodName string, args []Value, node ast.Node) (Value, bool) {
+	if classInfo == nil || !ident.Equal(methodName, "InheritsFrom") ||
+		classInfo.LookupMethod(methodName) != nil || classInfo.LookupClassMethod(methodName) != nil {
+		return nil, false
+	}
+	if len(args) != 1 {
+		return e.newError(node, "InheritsFrom expects 1 argument, got %d", len(args)), true
+	}
+
+	var name string
+	switch arg := unwrapVariant(args[0]).(type) {
+	case *runtime.StringValue:
+		name = arg.Value
+	case ClassMetaValue:
+		name = arg.GetClassName()
+	case nil, *runtime.NilValue:
+		return &runtime.BooleanValue{Value: false}, true
+	default:
+		return e.newError(node, "InheritsFrom expects a class name or class reference, got %s", arg.Type()), true
+	}
+	for cur := classInfo; cur != nil; cur = cur.GetParent() {
+		if ident.Equal(cur.GetName(), name) {
+			return &runtime.BooleanValue{Value: true}, true
+		}
+	}
+	return &runtime.BooleanValue{Value: false}, true
+}
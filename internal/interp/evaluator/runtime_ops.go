@@ -38,6 +38,16 @@ func (e *Evaluator) wrapInSubrange(value Value, typeName string, node ast.Node)
 	return subrangeVal, nil
 }
 
+// unwrapSubrange returns the Integer value held by a SubrangeValue, or value
+// unchanged otherwise. Subrange values are plain Integers once read, e.g. as
+// operands or when assigned to a variable of the base type.
+func unwrapSubrange(value Value) Value {
+	if subrange, ok := value.(*runtime.SubrangeValue); ok {
+		return &runtime.IntegerValue{Value: int64(subrange.Value)}
+	}
+	return value
+}
+
 // wrapInInterface wraps an object value in an interface instance.
 // Self-contained: replaces e.oopEngine.WrapInInterface.
 func (e *Evaluator) wrapInInterface(value Value, ifaceName string, node ast.Node) (Value, error) {
@@ -134,6 +144,7 @@ func (e *Evaluator) executeConstructorForObject(obj *runtime.ObjectInstance, con
 	if len(overloads) == 1 {
 		constructor = overloads[0]
 	} else if len(overloads) > 1 {
+		overloads = nearestApplicableConstructors(classInfo, constructorName, overloads, len(args))
 		// Select the best match by argument types (falls back internally to
 		// arg-count and default-parameter matching).
 		if selected, err := e.selectOverload(classInfo.GetName(), constructorName, overloads, args); err == nil {
@@ -162,6 +173,36 @@ func (e *Evaluator) executeConstructorForObject(obj *runtime.ObjectInstance, con
 	return nil
 }
 
+// nearestApplicableConstructors narrows overloads, which are ordered from the
+// class to its ancestors, to the constructors of the most derived class that
+// accept argCount arguments. A redeclared constructor whose parameters all
+// have defaults thus wins over the inherited parameterless TObject.Create.
+func nearestApplicableConstructors(classInfo runtime.IClassInfo, name string, overloads []*ast.FunctionDecl, argCount int) []*ast.FunctionDecl {
+	for current := classInfo; current != nil; current = current.GetParent() {
+		// Classes carry copies of their ancestors' constructors; only those
+		// not found on the parent are declared by current itself.
+		inherited := make(map[*ast.FunctionDecl]bool)
+		if parent := current.GetParent(); parent != nil {
+			for _, ctor := range parent.GetConstructorOverloads(name) {
+				inherited[ctor] = true
+			}
+		}
+		var applicable []*ast.FunctionDecl
+		for _, ctor := range current.GetConstructorOverloads(name) {
+			if inherited[ctor] {
+				continue
+			}
+			if argCount >= requiredParameterCount(ctor.Parameters) && argCount <= len(ctor.Parameters) {
+				applicable = append(applicable, ctor)
+			}
+		}
+		if len(applicable) > 0 {
+			return applicable
+		}
+	}
+	return overloads
+}
+
 // ============================================================================
 // Method Overload Dispatch
 // ============================================================================
@@ -180,6 +221,9 @@ func (e *Evaluator) dispatchObjectMethodOverloaded(obj *runtime.ObjectInstance,
 		if err != nil {
 			return e.newError(node, "%s", err.Error())
 		}
+		if method.IsDestructor {
+			return e.runObjectDestructor(obj, method, node, ctx)
+		}
 		if !method.IsClassMethod {
 			return e.executeObjectMethodDirect(obj, method, args, node, ctx)
 		}
@@ -508,6 +552,12 @@ func (e *Evaluator) evalTryBinaryOperator(operator string, left, right Value, no
 			return result, true
 		}
 	}
+	// Check class operators declared in left/right record types
+	for _, operand := range operands {
+		if result, found := e.lookupRecordOperator(operator, operand, operands, node, ctx); found {
+			return result, true
+		}
+	}
 	// Check global operator registry (with inheritance-compatible type keys)
 	if result, found := e.lookupGlobalOperator(operator, operands, node, ctx); found {
 		return result, true
@@ -515,6 +565,64 @@ func (e *Evaluator) evalTryBinaryOperator(operator string, left, right Value, no
 	return nil, false
 }
 
+// lookupRecordOperator looks up a class operator declared in the record type of
+// candidate, matching the normalized type keys of all operands.
+func (e *Evaluator) lookupRecordOperator(operator string, candidate Value, operands []Value, node ast.Node, ctx *ExecutionContext) (Value, bool) {
+	recordType := e.recordTypeValueOf(candidate)
+	if recordType == nil || len(recordType.Operators) == 0 {
+		return nil, false
+	}
+	operandTypes := make([]string, len(operands))
+	for i, operand := range operands {
+		operandTypes[i] = operatorTypeKey(operand)
+	}
+	entry, found := recordType.LookupOperator(operator, operandTypes)
+	if !found {
+		return nil, false
+	}
+	return e.invokeRecordOperatorEntry(recordType, entry, operands, node, ctx), true
+}
+
+// recordTypeValueOf returns the registered record type of a record value, or nil.
+func (e *Evaluator) recordTypeValueOf(val Value) *RecordTypeValue {
+	rec, ok := val.(*runtime.RecordValue)
+	if !ok || rec.RecordType == nil || rec.RecordType.Name == "" {
+		return nil
+	}
+	recordType, _ := e.typeSystem.LookupRecord(ident.Normalize(rec.RecordType.Name)).(*RecordTypeValue)
+	return recordType
+}
+
+// invokeRecordOperatorEntry invokes the record method bound to a class operator.
+// Class methods receive every operand; instance methods run on the operand at
+// SelfIndex and receive the remaining operands.
+func (e *Evaluator) invokeRecordOperatorEntry(recordType *RecordTypeValue, entry *runtime.OperatorEntry, operands []Value, node ast.Node, ctx *ExecutionContext) Value {
+	if entry.IsClassMethod {
+		return e.callRecordStaticMethod(recordType, entry.BindingName, operands, node, ctx)
+	}
+
+	// Resolve the binding now rather than using entry.Method: an out-of-line
+	// implementation replaces the bodiless declaration after registration.
+	method := recordType.Methods[entry.BindingName]
+	if method == nil {
+		method = entry.Method
+	}
+	if entry.SelfIndex < 0 || entry.SelfIndex >= len(operands) || method == nil {
+		return e.newError(node, "invalid operator configuration for '%s'", entry.Operator)
+	}
+	self, ok := operands[entry.SelfIndex].(RecordInstanceValue)
+	if !ok {
+		return e.newError(node, "operator '%s' requires record operand", entry.Operator)
+	}
+	args := make([]Value, 0, len(operands)-1)
+	for i, v := range operands {
+		if i != entry.SelfIndex {
+			args = append(args, v)
+		}
+	}
+	return e.callRecordMethod(self, method, args, node, ctx)
+}
+
 // lookupClassOperator looks up an operator in the class hierarchy, trying parent type keys.
 func (e *Evaluator) lookupClassOperator(operator string, classInfo runtime.IClassInfo, operands []Value, node ast.Node, ctx *ExecutionContext) (Value, bool) {
 	if classInfo == nil {
@@ -593,9 +701,13 @@ func (e *Evaluator) evalTryUnaryOperator(operator string, operand Value, node as
 		}
 	}
 
+	operands := []Value{operand}
+	if result, found := e.lookupRecordOperator(operator, operand, operands, node, ctx); found {
+		return result, true
+	}
+
 	// Check global operator registry
 	operandTypes := []string{operatorTypeKey(operand)}
-	operands := []Value{operand}
 	if ops := e.typeSystem.Operators(); ops != nil {
 		if entry, found := ops.Lookup(operator, operandTypes); found {
 			return e.invokeGlobalOperatorEntry(entry, operands, node, ctx), true
@@ -2,6 +2,7 @@ package evaluator
 
 import (
 	"fmt"
+	"strconv"
 	"strings"
 
 	"github.com/cwbudde/go-dws/internal/interp/runtime"
@@ -99,7 +100,7 @@ func (e *Evaluator) evalSetLiteralDirect(node *ast.SetLiteral, ctx *ExecutionCon
 		return e.newError(node, "cannot infer type for empty set literal")
 	}
 
-	if annotatedSetType != nil && elementType != nil && !annotatedSetType.ElementType.Equals(elementType) {
+	if annotatedSetType != nil && elementType != nil && !setElementBaseType(annotatedSetType.ElementType).Equals(elementType) {
 		return e.newError(node, "type mismatch in set literal: expected set of %s, got set of %s",
 			annotatedSetType.ElementType.String(), elementType.String())
 	}
@@ -310,3 +311,30 @@ func (e *Evaluator) parseInlineSetType(signature string) *types.SetType {
 	// Create and return the set type
 	return types.NewSetType(enumType)
 }
+
+// setElementBaseType returns the ordinal type that literal elements of a set
+// over t evaluate to: the base type for subranges, t itself otherwise.
+func setElementBaseType(t types.Type) types.Type {
+	if subrange, ok := types.GetUnderlyingType(t).(*types.SubrangeType); ok && subrange.BaseType != nil {
+		return subrange.BaseType
+	}
+	return t
+}
+
+// parseIntegerSubrange parses an anonymous integer subrange rendered as
+// "low..high" (e.g., "-5..5"). Returns nil if the string doesn't match.
+func parseIntegerSubrange(signature string) *types.SubrangeType {
+	lowStr, highStr, ok := strings.Cut(signature, "..")
+	if !ok {
+		return nil
+	}
+	low, err := strconv.Atoi(strings.TrimSpace(lowStr))
+	if err != nil {
+		return nil
+	}
+	high, err := strconv.Atoi(strings.TrimSpace(highStr))
+	if err != nil || high < low {
+		return nil
+	}
+	return &types.SubrangeType{BaseType: types.INTEGER, LowBound: low, HighBound: high}
+}
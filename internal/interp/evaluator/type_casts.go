@@ -355,7 +355,7 @@ func (e *Evaluator) castToEnum(val Value, targetEnum *types.EnumType, typeName s
 // builtinDefault handles the Default() built-in function which expects an unevaluated type identifier.
 // Default(Integer) should pass "Integer" as a string, not evaluate it as a variable.
 // Returns the default/zero value for the specified type, or nil if not a valid type.
-func (e *Evaluator) builtinDefault(args []ast.Expression) Value {
+func (e *Evaluator) builtinDefault(args []ast.Expression, ctx *ExecutionContext) Value {
 	// Check argument count
 	if len(args) != 1 {
 		return &runtime.ErrorValue{Message: "Default() expects exactly one argument"}
@@ -383,10 +383,10 @@ func (e *Evaluator) builtinDefault(args []ast.Expression) Value {
 	case "variant":
 		return &runtime.NilValue{}
 	default:
-		// For class types, records, enums, and other reference/complex types, return nil
-		// Check if it's a valid type by looking it up
-		// For now, return nil (which represents the default value for reference types)
-		return &runtime.NilValue{}
+		// Records get all fields defaulted, arrays and sets start empty and
+		// class types yield a typed nil - the same values an uninitialized
+		// variable of that type receives.
+		return e.createZeroValue(&ast.TypeAnnotation{Token: ident.Token, Name: typeName}, ident, ctx)
 	}
 }
 
@@ -484,8 +484,7 @@ func (e *Evaluator) castToClassType(val Value, className string, node ast.Node)
 	return &interp_TypeCastValue{Object: val, StaticType: classInfoIface}
 }
 
-// raiseTypeCastException raises an exception for invalid type casts.
-// Self-contained: no longer delegates to ExceptionManager.
+// raiseTypeCastException raises an EInvalidCast exception for invalid type casts.
 func (e *Evaluator) raiseTypeCastException(message string, node ast.Node) {
 	ctx := e.currentContext
 	if ctx == nil {
@@ -499,21 +498,5 @@ func (e *Evaluator) raiseTypeCastException(message string, node ast.Node) {
 	}
 	fullMessage := fmt.Sprintf("%s [line: %d, column: %d]", formatDWScriptExceptionMessage(message), pos.Line, pos.Column)
 
-	// Look up Exception class
-	excClass := e.typeSystem.LookupClass("Exception")
-
-	// Get metadata and create instance
-	var metadata *runtime.ClassMetadata
-	var instance *runtime.ObjectInstance
-	if excClass != nil {
-		if classInfo, ok := excClass.(runtime.IClassInfo); ok {
-			metadata = classInfo.GetMetadata()
-			instance = runtime.NewObjectInstance(classInfo)
-			instance.SetField("Message", &runtime.StringValue{Value: fullMessage})
-		}
-	}
-
-	// Create and set exception
-	exc := runtime.NewException(metadata, instance, fullMessage, &pos, ctx.CallStack())
-	ctx.SetException(exc)
+	ctx.SetException(e.createException("EInvalidCast", fullMessage, &pos, ctx))
 }
@@ -6,6 +6,7 @@ import (
 
 	"github.com/cwbudde/go-dws/internal/interp/runtime"
 	interptypes "github.com/cwbudde/go-dws/internal/interp/types"
+	"github.com/cwbudde/go-dws/internal/jsonvalue"
 	"github.com/cwbudde/go-dws/internal/types"
 	"github.com/cwbudde/go-dws/pkg/ast"
 	"github.com/cwbudde/go-dws/pkg/ident"
@@ -152,6 +153,10 @@ func (e *Evaluator) TryImplicitConversion(value Value, targetTypeName string, ct
 	// Normalize type names for conversion lookup (to match how they're registered)
 	normalizedSource := interptypes.NormalizeTypeAnnotation(sourceTypeName)
 	normalizedTarget := interptypes.NormalizeTypeAnnotation(targetTypeName)
+	if _, isObject := value.(*runtime.ObjectInstance); isObject {
+		// Objects report "OBJECT" as their type; conversions are keyed by class.
+		normalizedSource = operatorTypeKey(value)
+	}
 
 	// Try direct conversion first (using TypeSystem's ConversionRegistry)
 	entry, found := e.typeSystem.Conversions().FindImplicit(normalizedSource, normalizedTarget)
@@ -174,19 +179,55 @@ func (e *Evaluator) TryImplicitConversion(value Value, targetTypeName string, ct
 	}
 
 	// Built-in conversions (no registry entry needed)
+	return e.applyBuiltinConversion(value, targetTypeName, ctx)
+}
 
-	// Integer → Float is always allowed in Pascal/Delphi (automatic widening)
-	if normalizedSource == "integer" && normalizedTarget == "float" {
-		if intVal, ok := value.(*runtime.IntegerValue); ok {
-			return &runtime.FloatValue{Value: float64(intVal.Value)}, true
-		}
+// applyBuiltinConversion applies the value-changing conversion that
+// types.IsAssignable selects for assigning value to targetTypeName, so the
+// runtime follows the same rules as semantic analysis.
+//
+// Returns:
+//   - (convertedValue, true) if a conversion was applied
+//   - (original value, false) otherwise
+func (e *Evaluator) applyBuiltinConversion(value Value, targetTypeName string, ctx *ExecutionContext) (Value, bool) {
+	// Only Integer widening, subrange reads, enum ordinals and JSON values
+	// assigned to records or arrays change the runtime value; skip type
+	// resolution for everything else.
+	switch val := value.(type) {
+	case *runtime.IntegerValue, *runtime.EnumValue, *runtime.SubrangeValue:
+	case *runtime.VariantValue:
+		return e.convertJSONToTyped(val, targetTypeName, ctx)
+	default:
+		return value, false
 	}
 
-	// Enum → Integer implicit conversion
-	if enumVal, ok := value.(*runtime.EnumValue); ok && normalizedTarget == "integer" {
-		return &runtime.IntegerValue{Value: int64(enumVal.OrdinalValue)}, true
+	targetType, err := e.ResolveTypeWithContext(targetTypeName, ctx)
+	if err != nil {
+		return value, false
+	}
+	if subrange, ok := value.(*runtime.SubrangeValue); ok {
+		// A subrange value assigned to its base type (or a wider numeric
+		// type) becomes a plain Integer first.
+		if _, toSubrange := types.GetUnderlyingType(targetType).(*types.SubrangeType); toSubrange {
+			return value, false
+		}
+		value = unwrapSubrange(subrange)
+		if converted, ok := e.applyBuiltinConversion(value, targetTypeName, ctx); ok {
+			return converted, true
+		}
+		return value, true
+	}
+	assignable, kind := types.IsAssignable(targetType, e.getValueType(value))
+	if !assignable {
+		return value, false
 	}
 
+	switch kind {
+	case types.ConversionIntToFloat:
+		return &runtime.FloatValue{Value: float64(value.(*runtime.IntegerValue).Value)}, true
+	case types.ConversionEnumToInt:
+		return &runtime.IntegerValue{Value: int64(value.(*runtime.EnumValue).OrdinalValue)}, true
+	}
 	return value, false
 }
 
@@ -202,6 +243,10 @@ func (e *Evaluator) executeConversionEntry(entry *interptypes.ConversionEntry, v
 		return nil, false
 	}
 
+	if entry.Owner != nil {
+		return e.executeOwnedConversion(entry, value, ctx)
+	}
+
 	// Look up the conversion function using TypeSystem's FunctionRegistry
 	overloads := e.typeSystem.LookupFunctions(entry.BindingName)
 	if len(overloads) == 0 {
@@ -225,6 +270,56 @@ func (e *Evaluator) executeConversionEntry(entry *interptypes.ConversionEntry, v
 	return result, true
 }
 
+// executeOwnedConversion executes a class operator conversion declared in a
+// record or class. Class methods receive the value as their only argument;
+// instance methods run on the value itself.
+func (e *Evaluator) executeOwnedConversion(entry *interptypes.ConversionEntry, value Value, ctx *ExecutionContext) (Value, bool) {
+	var result Value
+	switch owner := entry.Owner.(type) {
+	case *RecordTypeValue:
+		if entry.IsClassMethod {
+			result = e.callRecordStaticMethod(owner, entry.BindingName, []Value{value}, nil, ctx)
+			break
+		}
+		record, ok := value.(RecordInstanceValue)
+		if !ok {
+			return nil, false
+		}
+		method, ok := owner.Methods[entry.BindingName]
+		if !ok {
+			return nil, false
+		}
+		result = e.callRecordMethod(record, method, nil, nil, ctx)
+	case runtime.IClassInfo:
+		if entry.IsClassMethod {
+			classValAny, err := e.typeSystem.CreateClassValue(owner.GetName())
+			if err != nil {
+				return nil, false
+			}
+			classMeta, ok := classValAny.(ClassMetaValue)
+			method := owner.LookupClassMethod(entry.BindingName)
+			if !ok || method == nil {
+				return nil, false
+			}
+			result = e.executeClassMethodDirect(classMeta, method, []Value{value}, nil, ctx)
+			break
+		}
+		obj, ok := value.(*runtime.ObjectInstance)
+		method := owner.LookupMethod(entry.BindingName)
+		if !ok || method == nil {
+			return nil, false
+		}
+		result = e.executeObjectMethodDirect(obj, method, nil, nil, ctx)
+	default:
+		return nil, false
+	}
+
+	if result == nil || isErrorValue(result) {
+		return nil, false
+	}
+	return result, true
+}
+
 // executeConversionChain applies a sequence of conversions along a path.
 //
 // This helper iterates through the conversion path, applying each step sequentially.
@@ -330,3 +425,93 @@ func (e *Evaluator) getDefaultValueForTypeName(typeName string) Value {
 		return &runtime.NilValue{}
 	}
 }
+
+// convertJSONToTyped converts a Variant holding a JSON object or array, such
+// as a Go struct returned by a host function, to targetTypeName when that is
+// a record or dynamic array type.
+func (e *Evaluator) convertJSONToTyped(variant *runtime.VariantValue, targetTypeName string, ctx *ExecutionContext) (Value, bool) {
+	if _, ok := variant.Value.(*runtime.JSONValue); !ok {
+		return variant, false
+	}
+	targetType, err := e.ResolveTypeWithContext(targetTypeName, ctx)
+	if err != nil {
+		return variant, false
+	}
+	return e.convertJSONVariant(variant, targetType)
+}
+
+// convertJSONVariant converts a Variant holding a JSON object or array to
+// targetType when that is a record or dynamic array type. Object fields are
+// matched to record fields case-insensitively; record fields missing from the
+// object or null keep their zero value, and object fields the record does
+// not declare are ignored.
+func (e *Evaluator) convertJSONVariant(variant *runtime.VariantValue, targetType types.Type) (Value, bool) {
+	jsonVal, ok := variant.Value.(*runtime.JSONValue)
+	if !ok || jsonVal.Value == nil || targetType == nil {
+		return variant, false
+	}
+	switch types.GetUnderlyingType(targetType).(type) {
+	case *types.RecordType, *types.ArrayType:
+	default:
+		return variant, false
+	}
+	if converted, ok := e.jsonToTypedValue(jsonVal.Value, targetType); ok {
+		return converted, true
+	}
+	return variant, false
+}
+
+// jsonToTypedValue converts a JSON value to a runtime value of typ. It
+// reports false when the JSON value does not fit typ.
+func (e *Evaluator) jsonToTypedValue(jv *jsonvalue.Value, typ types.Type) (Value, bool) {
+	switch t := types.GetUnderlyingType(typ).(type) {
+	case *types.RecordType:
+		if jv.Kind() != jsonvalue.KindObject {
+			return nil, false
+		}
+		record, ok := e.createRecordZeroValue(t).(*runtime.RecordValue)
+		if !ok {
+			return nil, false
+		}
+		for _, key := range jv.ObjectKeys() {
+			fieldKey := ident.Normalize(key)
+			fieldType, exists := t.Fields[fieldKey]
+			if !exists || jv.ObjectGet(key).Kind() == jsonvalue.KindNull {
+				continue
+			}
+			field, ok := e.jsonToTypedValue(jv.ObjectGet(key), fieldType)
+			if !ok {
+				return nil, false
+			}
+			record.Fields[fieldKey] = field
+		}
+		return record, true
+	case *types.ArrayType:
+		if !t.IsDynamic() || jv.Kind() != jsonvalue.KindArray {
+			return nil, false
+		}
+		array := runtime.NewArrayValue(t, nil)
+		for _, elem := range jv.ArrayElements() {
+			value, ok := e.jsonToTypedValue(elem, t.ElementType)
+			if !ok {
+				return nil, false
+			}
+			array.Elements = append(array.Elements, value)
+		}
+		return array, true
+	}
+
+	value := runtime.JSONValueToValue(jv)
+	switch typ.TypeKind() {
+	case "VARIANT":
+		return runtime.BoxVariant(value), true
+	case "FLOAT":
+		if i, ok := value.(*runtime.IntegerValue); ok {
+			return &runtime.FloatValue{Value: float64(i.Value)}, true
+		}
+	}
+	if value.Type() != typ.TypeKind() {
+		return nil, false
+	}
+	return value, true
+}
@@ -305,6 +305,10 @@ func (e *Evaluator) ResolveTypeWithContext(typeName string, ctx *ExecutionContex
 	lowerTypeName := ident.Normalize(typeName)
 	if strings.HasPrefix(lowerTypeName, "set of ") {
 		elementTypeName := strings.TrimSpace(lowerTypeName[len("set of "):])
+		// Anonymous subrange elements are rendered as "low..high".
+		if subrange := parseIntegerSubrange(elementTypeName); subrange != nil {
+			return types.NewSetType(subrange), nil
+		}
 		elementType, err := e.ResolveTypeWithContext(elementTypeName, ctx)
 		if err != nil {
 			return nil, fmt.Errorf("invalid set element type: %w", err)
@@ -326,6 +330,10 @@ func (e *Evaluator) ResolveTypeWithContext(typeName string, ctx *ExecutionContex
 		return types.VARIANT, nil
 	case "jsonvariant":
 		return types.JSON_VARIANT, nil
+	case "tdatetime":
+		return types.TDATETIME, nil
+	case "byte":
+		return types.BYTE, nil
 	case "const":
 		// "Const" redirects to VARIANT for dynamic typing
 		return types.VARIANT, nil
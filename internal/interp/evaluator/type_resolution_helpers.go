@@ -47,7 +47,10 @@ func (e *Evaluator) resolveTypeName(typeName string, ctx *ExecutionContext) (typ
 		return types.VARIANT, nil
 
 	case "tdatetime":
-		return types.DATETIME, nil
+		return types.TDATETIME, nil
+
+	case "byte":
+		return types.BYTE, nil
 
 	case "nil":
 		return types.NIL, nil
@@ -48,9 +48,9 @@ func TestResolveTypeName_Primitives(t *testing.T) {
 		{name: "Const mixed case", typeName: "Const", expectedType: types.VARIANT, expectError: false},
 
 		// TDateTime type
-		{name: "TDateTime lowercase", typeName: "tdatetime", expectedType: types.DATETIME, expectError: false},
-		{name: "TDateTime uppercase", typeName: "TDATETIME", expectedType: types.DATETIME, expectError: false},
-		{name: "TDateTime mixed case", typeName: "TDateTime", expectedType: types.DATETIME, expectError: false},
+		{name: "TDateTime lowercase", typeName: "tdatetime", expectedType: types.TDATETIME, expectError: false},
+		{name: "TDateTime uppercase", typeName: "TDATETIME", expectedType: types.TDATETIME, expectError: false},
+		{name: "TDateTime mixed case", typeName: "TDateTime", expectedType: types.TDATETIME, expectError: false},
 
 		// Nil type
 		{name: "Nil lowercase", typeName: "nil", expectedType: types.NIL, expectError: false},
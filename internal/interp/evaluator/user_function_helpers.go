@@ -5,7 +5,10 @@ import (
 
 	"github.com/cwbudde/go-dws/internal/interp/runtime"
 	"github.com/cwbudde/go-dws/internal/lexer"
+	"github.com/cwbudde/go-dws/internal/semantic"
+	"github.com/cwbudde/go-dws/internal/types"
 	"github.com/cwbudde/go-dws/pkg/ast"
+	"github.com/cwbudde/go-dws/pkg/ident"
 )
 
 // EvaluateDefaultParameters fills in missing optional arguments with default values.
@@ -16,36 +19,34 @@ func (e *Evaluator) EvaluateDefaultParameters(
 	args []Value,
 	ctx *ExecutionContext,
 ) ([]Value, error) {
-	// Calculate required parameter count (parameters without defaults)
-	requiredParams := 0
-	for _, param := range fn.Parameters {
-		if param.DefaultValue == nil {
-			requiredParams++
-		}
-	}
+	return e.fillDefaultArguments(fn.Parameters, args, ctx)
+}
 
+// fillDefaultArguments appends the default values of the trailing params
+// that args omits. Defaults are evaluated in ctx, the caller's environment.
+func (e *Evaluator) fillDefaultArguments(params []*ast.Parameter, args []Value, ctx *ExecutionContext) ([]Value, error) {
 	// Check argument count is within valid range
+	requiredParams := requiredParameterCount(params)
 	if len(args) < requiredParams {
 		return nil, fmt.Errorf("wrong number of arguments: expected at least %d, got %d",
 			requiredParams, len(args))
 	}
-	if len(args) > len(fn.Parameters) {
+	if len(args) > len(params) {
 		return nil, fmt.Errorf("wrong number of arguments: expected at most %d, got %d",
-			len(fn.Parameters), len(args))
+			len(params), len(args))
 	}
 
 	// If all arguments provided, return as-is
-	if len(args) == len(fn.Parameters) {
+	if len(args) == len(params) {
 		return args, nil
 	}
 
 	// Fill in missing optional arguments with default values
-	// Evaluate default expressions in the caller's environment (ctx)
-	result := make([]Value, len(fn.Parameters))
+	result := make([]Value, len(params))
 	copy(result, args)
 
-	for idx := len(args); idx < len(fn.Parameters); idx++ {
-		param := fn.Parameters[idx]
+	for idx := len(args); idx < len(params); idx++ {
+		param := params[idx]
 		if param.DefaultValue == nil {
 			// This should never happen due to requiredParams check above
 			return nil, fmt.Errorf("internal error: missing required parameter at index %d", idx)
@@ -63,6 +64,18 @@ func (e *Evaluator) EvaluateDefaultParameters(
 	return result, nil
 }
 
+// requiredParameterCount returns the number of parameters without a default
+// value, i.e. the fewest arguments a call must pass.
+func requiredParameterCount(params []*ast.Parameter) int {
+	required := 0
+	for _, param := range params {
+		if param.DefaultValue == nil {
+			required++
+		}
+	}
+	return required
+}
+
 // ImplicitConversionFunc is a callback type for implicit type conversion.
 type ImplicitConversionFunc func(value Value, targetTypeName string) (Value, bool)
 
@@ -92,6 +105,7 @@ func (e *Evaluator) BindFunctionParameters(
 			if !param.IsConst {
 				arg = runtime.CopyValue(arg)
 			}
+			arg = openArrayView(param, arg)
 		}
 
 		// Store the argument in the function's environment
@@ -104,6 +118,26 @@ func (e *Evaluator) BindFunctionParameters(
 	return nil
 }
 
+// openArrayView adapts a static array passed to an open array parameter
+// (`a: array of T`), which always indexes from 0: the callee sees the same
+// elements through a zero-based dynamic array, so Low(a) is 0 and High(a) is
+// Length(a)-1 regardless of the caller's declared bounds. Value parameters
+// have already copied the static array, so writes stay local to the callee.
+func openArrayView(param *ast.Parameter, arg Value) Value {
+	typeNode, ok := param.Type.(*ast.ArrayTypeNode)
+	if !ok || !typeNode.IsDynamic() {
+		return arg
+	}
+	arr, ok := arg.(*runtime.ArrayValue)
+	if !ok || arr.ArrayType == nil || !arr.ArrayType.IsStatic() {
+		return arg
+	}
+	return &runtime.ArrayValue{
+		ArrayType: types.NewDynamicArrayType(arr.ArrayType.ElementType),
+		Elements:  arr.Elements,
+	}
+}
+
 // DefaultValueFunc is a callback type for getting the default value for a return type.
 type DefaultValueFunc func(returnTypeName string) Value
 
@@ -229,6 +263,36 @@ func (e *Evaluator) defaultUserFunctionCallbacks(ctx *ExecutionContext) *UserFun
 	}
 }
 
+// heapStackSegment is the number of nested script calls that share one
+// goroutine stack under runtime.RecursionHeap.
+const heapStackSegment = 256
+
+// callOnStack runs the body of a script call whose frame was just pushed.
+// Under runtime.RecursionHeap every heapStackSegment-th nested call continues
+// on a fresh goroutine while the caller waits for it, so no single goroutine
+// stack grows past a segment's worth of frames. A panic is re-raised in the
+// caller's goroutine.
+func (e *Evaluator) callOnStack(ctx *ExecutionContext, call func() Value) Value {
+	if e.engineState.RecursionStrategy != runtime.RecursionHeap ||
+		ctx.GetCallStack().Depth()%heapStackSegment != 0 {
+		return call()
+	}
+
+	var result Value
+	var panicked any
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		defer func() { panicked = recover() }()
+		result = call()
+	}()
+	<-done
+	if panicked != nil {
+		panic(panicked)
+	}
+	return result
+}
+
 func (e *Evaluator) raiseRecursionExceeded(ctx *ExecutionContext) Value {
 	message := fmt.Sprintf("Maximal recursion exceeded (%d)", e.MaxRecursionDepth())
 	exc := e.createException("EScriptStackOverflow", message, nil, ctx)
@@ -236,6 +300,24 @@ func (e *Evaluator) raiseRecursionExceeded(ctx *ExecutionContext) Value {
 	return &runtime.NilValue{}
 }
 
+// functionOuterEnv returns the environment a call to fn encloses. A direct
+// recursive call to a global function encloses the scope the running
+// activation was called from rather than the activation itself: the function
+// cannot see its own earlier locals, and chaining every activation to the
+// previous one would make each name lookup walk the whole recursion.
+func (e *Evaluator) functionOuterEnv(fn *ast.FunctionDecl, ctx *ExecutionContext) *runtime.Environment {
+	current, currentEnv := ctx.CurrentFunction()
+	if current != fn || currentEnv == nil || currentEnv.Outer() == nil {
+		return ctx.Env()
+	}
+	for _, decl := range e.FunctionRegistry().Lookup(fn.Name.Value) {
+		if decl == fn {
+			return currentEnv.Outer()
+		}
+	}
+	return ctx.Env()
+}
+
 func (e *Evaluator) ExecuteUserFunctionDirect(fn *ast.FunctionDecl, args []Value, ctx *ExecutionContext) Value {
 	result, err := e.ExecuteUserFunction(fn, args, ctx, e.defaultUserFunctionCallbacks(ctx))
 	if err != nil {
@@ -291,13 +373,14 @@ func (e *Evaluator) ExecuteUserFunction(
 	}
 
 	// Create new environment for function scope
-	funcEnv := runtime.NewEnclosedEnvironment(ctx.Env())
+	funcEnv := runtime.NewEnclosedEnvironment(e.functionOuterEnv(fn, ctx))
 
 	// Create new context with function environment.
 	// Clone preserves shared execution state while allowing the environment
 	// to be swapped for the function scope.
 	funcCtx := ctx.Clone()
 	funcCtx.SetEnv(funcEnv)
+	funcCtx.SetCurrentFunction(fn, funcEnv)
 
 	// Set return type context for return/exit statements
 	if fn.ReturnType != nil {
@@ -370,7 +453,7 @@ func (e *Evaluator) ExecuteUserFunction(
 		// Execute function body through the evaluator.
 		// This now stays on evaluator-owned execution paths instead of unconditionally
 		// round-tripping through interpreter EvalNode dispatch.
-		bodyResult := e.Eval(fn.Body, funcCtx)
+		bodyResult := e.callOnStack(funcCtx, func() Value { return e.Eval(fn.Body, funcCtx) })
 
 		// A runtime error raised in the body becomes a catchable script exception,
 		// with the routine name spliced into the message ("<msg> in <routine> [line: ...]").
@@ -467,3 +550,131 @@ func (e *Evaluator) ExecuteUserFunction(
 
 	return returnValue, nil
 }
+
+// bindNamedCallArguments returns a copy of node whose arguments are listed
+// positionally in the callee's parameter order. Named arguments are only
+// supported for declared, non-overloaded functions.
+func (e *Evaluator) bindNamedCallArguments(node *ast.CallExpression, ctx *ExecutionContext) (*ast.CallExpression, error) {
+	funcIdent, ok := node.Function.(*ast.Identifier)
+	if !ok {
+		return nil, fmt.Errorf("named arguments are only supported in calls to declared functions and methods")
+	}
+
+	var decls []*ast.FunctionDecl
+	if set := e.lookupLocalFunctions(funcIdent.Value, ctx); set != nil {
+		decls = set.Decls
+	} else {
+		decls = e.FunctionRegistry().Lookup(ident.Normalize(funcIdent.Value))
+	}
+	if len(decls) == 0 {
+		return nil, fmt.Errorf("named arguments are only supported in calls to declared functions and methods")
+	}
+	if len(decls) > 1 {
+		return nil, fmt.Errorf("named arguments are not supported for overloaded function '%s'", funcIdent.Value)
+	}
+
+	args, err := bindArgumentsToDecl(node.Arguments, node.ArgumentNames, decls[0])
+	if err != nil {
+		return nil, fmt.Errorf("%v in call to '%s'", err, funcIdent.Value)
+	}
+
+	positional := *node
+	positional.Arguments = args
+	positional.ArgumentNames = nil
+	return &positional, nil
+}
+
+// bindNamedMethodArguments returns a copy of node whose arguments are listed
+// positionally in the parameter order of the method called on obj. Named
+// arguments are only supported for non-overloaded methods of objects,
+// classes and records.
+func (e *Evaluator) bindNamedMethodArguments(node *ast.MethodCallExpression, obj Value) (*ast.MethodCallExpression, error) {
+	methodName := node.Method.Value
+
+	var decls []*ast.FunctionDecl
+	switch o := obj.(type) {
+	case RecordInstanceValue:
+		if rec, ok := obj.(*runtime.RecordValue); ok {
+			decls = rec.GetRecordMethodOverloads(methodName)
+		}
+		if len(decls) == 0 {
+			if decl, found := o.GetRecordMethod(methodName); found {
+				decls = []*ast.FunctionDecl{decl}
+			}
+		}
+	case *RecordTypeValue:
+		normalized := ident.Normalize(methodName)
+		decls = o.ClassMethodOverloads[normalized]
+		if decl, ok := o.ClassMethods[normalized]; ok && len(decls) == 0 {
+			decls = []*ast.FunctionDecl{decl}
+		}
+	case ObjectValue:
+		if decl, ok := o.GetMethodDecl(methodName).(*ast.FunctionDecl); ok && decl != nil {
+			decls = []*ast.FunctionDecl{decl}
+		} else if decl, ok := o.GetClassMethodDecl(methodName).(*ast.FunctionDecl); ok && decl != nil {
+			decls = []*ast.FunctionDecl{decl}
+		}
+	case ClassMetaValue:
+		if classInfo := o.GetClassInfo(); classInfo != nil {
+			decl := classInfo.GetConstructor(methodName)
+			if decl == nil {
+				decl = classInfo.LookupMethod(methodName)
+			}
+			if decl == nil {
+				decl = classInfo.LookupClassMethod(methodName)
+			}
+			if decl != nil {
+				decls = []*ast.FunctionDecl{decl}
+			}
+		}
+	}
+
+	if len(decls) == 0 {
+		return nil, fmt.Errorf("named arguments are only supported in calls to declared functions and methods")
+	}
+	if len(decls) > 1 || decls[0].IsOverload {
+		return nil, fmt.Errorf("named arguments are not supported for overloaded method '%s'", methodName)
+	}
+
+	args, err := bindArgumentsToDecl(node.Arguments, node.ArgumentNames, decls[0])
+	if err != nil {
+		return nil, fmt.Errorf("%v in call to '%s'", err, methodName)
+	}
+
+	positional := *node
+	positional.Arguments = args
+	positional.ArgumentNames = nil
+	return &positional, nil
+}
+
+// bindNamedConstructorArguments returns a copy of node whose arguments are
+// listed positionally in the parameter order of ctor.
+func bindNamedConstructorArguments(node *ast.NewExpression, ctor *ast.FunctionDecl) (*ast.NewExpression, error) {
+	if ctor == nil {
+		return nil, fmt.Errorf("named arguments are only supported in calls to declared functions and methods")
+	}
+	if ctor.IsOverload {
+		return nil, fmt.Errorf("named arguments are not supported for overloaded method '%s'", ctor.Name.Value)
+	}
+
+	args, err := bindArgumentsToDecl(node.Arguments, node.ArgumentNames, ctor)
+	if err != nil {
+		return nil, fmt.Errorf("%v in call to '%s'", err, ctor.Name.Value)
+	}
+
+	positional := *node
+	positional.Arguments = args
+	positional.ArgumentNames = nil
+	return &positional, nil
+}
+
+// bindArgumentsToDecl binds args (named per names) to the parameters of decl.
+func bindArgumentsToDecl(args []ast.Expression, names []*ast.Identifier, decl *ast.FunctionDecl) ([]ast.Expression, error) {
+	paramNames := make([]string, len(decl.Parameters))
+	defaults := make([]ast.Expression, len(decl.Parameters))
+	for i, param := range decl.Parameters {
+		paramNames[i] = param.Name.Value
+		defaults[i] = param.DefaultValue
+	}
+	return semantic.BindNamedArguments(args, names, paramNames, defaults)
+}
@@ -182,8 +182,14 @@ func (e *Evaluator) evaluateLValueIndex(target *ast.IndexExpression, ctx *Execut
 		return nil, nil, fmt.Errorf("array index out of bounds: physical index %d, length %d", physicalIndex, len(arr.Elements))
 	}
 
-	// Get current value
+	// Get current value. Unset elements of a freshly resized array are
+	// materialized so in-place updates (e.g. SetLength(m[i], n) or a field
+	// write through a record element) land in the array.
 	currentVal := arr.Elements[physicalIndex]
+	if currentVal == nil {
+		currentVal = e.getZeroValueForType(arr.ArrayType.ElementType)
+		arr.Elements[physicalIndex] = currentVal
+	}
 
 	// Create assignment function (captures arr and physicalIndex)
 	assignFunc := func(value Value) error {
@@ -366,8 +372,14 @@ func (e *Evaluator) builtinInc(args []ast.Expression, ctx *ExecutionContext) Val
 
 	switch val := currentVal.(type) {
 	case *runtime.IntegerValue:
-		// Increment integer by delta
-		newValue = &runtime.IntegerValue{Value: val.Value + delta}
+		// Increment integer by delta. The variable is left unchanged when
+		// checked Integer arithmetic raises EIntOverflow.
+		result, overflow := runtime.CheckedIntegerArithmetic("+", val.Value, delta)
+		if overflow && e.engineState.IntegerOverflowCheck {
+			e.raiseIntegerOverflow(lvalue)
+			return &runtime.NilValue{}
+		}
+		newValue = &runtime.IntegerValue{Value: result}
 
 	case *runtime.EnumValue:
 		// For enums, delta must be 1 (get successor)
@@ -458,8 +470,14 @@ func (e *Evaluator) builtinDec(args []ast.Expression, ctx *ExecutionContext) Val
 
 	switch val := currentVal.(type) {
 	case *runtime.IntegerValue:
-		// Decrement integer by delta
-		newValue = &runtime.IntegerValue{Value: val.Value - delta}
+		// Decrement integer by delta. The variable is left unchanged when
+		// checked Integer arithmetic raises EIntOverflow.
+		result, overflow := runtime.CheckedIntegerArithmetic("-", val.Value, delta)
+		if overflow && e.engineState.IntegerOverflowCheck {
+			e.raiseIntegerOverflow(lvalue)
+			return &runtime.NilValue{}
+		}
+		newValue = &runtime.IntegerValue{Value: result}
 
 	case *runtime.EnumValue:
 		// For enums, delta must be 1 (get predecessor)
@@ -799,7 +817,7 @@ func runeSetLength(s string, newLength int) string {
 }
 
 // ============================================================================
-// Swap/DivMod Built-in Functions
+// Swap/DivMod/FreeAndNil Built-in Functions
 // ============================================================================
 
 // builtinSwap implements the Swap() built-in function.
@@ -853,6 +871,89 @@ func (e *Evaluator) builtinSwap(args []ast.Expression, ctx *ExecutionContext) Va
 	return &runtime.NilValue{}
 }
 
+// builtinFreeAndNil implements the FreeAndNil() built-in procedure.
+// FreeAndNil(obj) sets the variable to nil and then frees the object it
+// referred to, running its destructor chain. A nil variable is left as is.
+func (e *Evaluator) builtinFreeAndNil(node *ast.CallExpression, ctx *ExecutionContext) Value {
+	if len(node.Arguments) != 1 {
+		return e.newError(node, "FreeAndNil() expects exactly 1 argument, got %d", len(node.Arguments))
+	}
+
+	val, assignFunc, err := e.EvaluateLValue(node.Arguments[0], ctx)
+	if err != nil {
+		return e.newError(node, "FreeAndNil() argument must be a variable: %s", err.Error())
+	}
+	if ref, isRef := val.(ReferenceAccessor); isRef {
+		deref, derr := ref.Dereference()
+		if derr != nil {
+			return e.newError(node, "%s", derr.Error())
+		}
+		val = deref
+	}
+
+	obj, ok := val.(*runtime.ObjectInstance)
+	if !ok {
+		if _, isNil := val.(*runtime.NilValue); isNil {
+			return &runtime.NilValue{}
+		}
+		return e.newError(node, "FreeAndNil() expects an object, got %s", val.Type())
+	}
+	if obj.ExplicitlyFreed {
+		return e.newError(node, "Object already destroyed")
+	}
+
+	// Like Delphi, the variable is cleared before the destructor runs.
+	if err := assignFunc(&runtime.NilValue{}); err != nil {
+		return e.newError(node, "FreeAndNil() failed to update variable: %s", err.Error())
+	}
+
+	return e.runObjectDestructor(obj, obj.Class.LookupMethod("Destroy"), node, ctx)
+}
+
+// builtinSupports implements Supports(obj, IIntf) and Supports(obj, IIntf, intf).
+// It reports whether the object, or the object behind an interface, implements
+// IIntf; the 3-argument form stores the interface in intf, or a nil interface
+// when the object does not support it.
+func (e *Evaluator) builtinSupports(node *ast.CallExpression, ctx *ExecutionContext) Value {
+	if len(node.Arguments) != 2 && len(node.Arguments) != 3 {
+		return e.newError(node, "Supports() expects 2 or 3 arguments, got %d", len(node.Arguments))
+	}
+
+	obj := e.Eval(node.Arguments[0], ctx)
+	if isError(obj) {
+		return obj
+	}
+	typeName, ok := node.Arguments[1].(*ast.Identifier)
+	if !ok || !e.typeSystem.HasInterface(typeName.Value) {
+		return e.newError(node, "Supports() expects an interface type as second argument")
+	}
+
+	var intf Value
+	if e.IsAssigned(obj) {
+		if cast, err := e.castType(obj, typeName.Value, node); err == nil && e.IsAssigned(cast) {
+			intf = cast
+		}
+	}
+
+	if len(node.Arguments) == 3 {
+		_, assignFunc, err := e.EvaluateLValue(node.Arguments[2], ctx)
+		if err != nil {
+			return e.newError(node, "Supports() out argument must be a variable: %s", err.Error())
+		}
+		out := intf
+		if out == nil {
+			if out, err = e.createInterfaceWrapper(typeName.Value, nil); err != nil {
+				return e.newError(node, "%s", err.Error())
+			}
+		}
+		if err := assignFunc(out); err != nil {
+			return e.newError(node, "Supports() failed to update variable: %s", err.Error())
+		}
+	}
+
+	return &runtime.BooleanValue{Value: intf != nil}
+}
+
 // builtinIncludeExclude implements the procedure forms of the set builtins
 // Include(setVar, element) and Exclude(setVar, element). Both mutate the set
 // variable in place: Include adds an element, Exclude removes it.
@@ -1123,13 +1224,13 @@ func (e *Evaluator) builtinDecodeDate(args []ast.Expression, ctx *ExecutionConte
 		return dtVal
 	}
 
-	floatVal, ok := dtVal.(*runtime.FloatValue)
+	dt, ok := dateTimeArgument(dtVal)
 	if !ok {
 		return e.newError(nil, "DecodeDate() expects Float/TDateTime as first argument, got %s", dtVal.Type())
 	}
 
 	// Extract date components using the datetime utility function
-	year, month, day := extractDateComponents(floatVal.Value)
+	year, month, day := extractDateComponents(dt)
 
 	// Set the var parameters (args 1, 2, 3) using EvaluateLValue
 	components := []int{year, month, day}
@@ -1165,13 +1266,13 @@ func (e *Evaluator) builtinDecodeTime(args []ast.Expression, ctx *ExecutionConte
 		return dtVal
 	}
 
-	floatVal, ok := dtVal.(*runtime.FloatValue)
+	dt, ok := dateTimeArgument(dtVal)
 	if !ok {
 		return e.newError(nil, "DecodeTime() expects Float/TDateTime as first argument, got %s", dtVal.Type())
 	}
 
 	// Extract time components using the datetime utility function
-	hour, minute, second, msec := extractTimeComponents(floatVal.Value)
+	hour, minute, second, msec := extractTimeComponents(dt)
 
 	// Set the var parameters (args 1, 2, 3, 4) using EvaluateLValue
 	components := []int{hour, minute, second, msec}
@@ -1192,6 +1293,18 @@ func (e *Evaluator) builtinDecodeTime(args []ast.Expression, ctx *ExecutionConte
 	return &runtime.NilValue{}
 }
 
-// Note: extractDateComponents and extractTimeComponents are defined in internal/interp/datetime_utils.go
+// dateTimeArgument returns the TDateTime held by val. An Integer counts whole
+// days, as it does when assigned to a TDateTime variable.
+func dateTimeArgument(val Value) (float64, bool) {
+	switch v := val.(type) {
+	case *runtime.FloatValue:
+		return v.Value, true
+	case *runtime.IntegerValue:
+		return float64(v.Value), true
+	}
+	return 0, false
+}
+
+// Note: extractDateComponents and extractTimeComponents are defined in datetime_helpers.go
 // Note: lookupEnumType is defined in set_helpers.go and reused here.
 // Note: isError is defined in visitor_expressions.go and reused here.
@@ -140,6 +140,7 @@ func (e *Evaluator) registerHelperMethodImplementation(helperInfo *runtime.Mutab
 	overloads := helperInfo.MethodOverloads[methodName]
 	for idx, candidate := range overloads {
 		if helperDeclSignaturesEqual(candidate, node) {
+			runtime.MergeParameterDefaults(node, candidate)
 			overloads[idx] = node
 			helperInfo.MethodOverloads[methodName] = overloads
 			helperInfo.Methods[methodName] = node
@@ -463,6 +464,9 @@ func (e *Evaluator) VisitClassDecl(node *ast.ClassDecl, ctx *ExecutionContext) V
 		}
 
 		if field.IsClassVar {
+			// Class vars are initialized here, exactly once per run, and the
+			// slot is owned by the declaring class: instances and descendants
+			// read and write it through the hierarchy, never via copies.
 			var varValue Value
 			if cachedInit != nil {
 				varValue = cachedInit
@@ -476,6 +480,8 @@ func (e *Evaluator) VisitClassDecl(node *ast.ClassDecl, ctx *ExecutionContext) V
 			}
 
 			classInfo.AddClassVarValue(fieldName, varValue)
+			// Later class var initializers may read this one by its bare name.
+			classConstValues[fieldName] = varValue
 			continue
 		}
 
@@ -539,6 +545,14 @@ func (e *Evaluator) VisitClassDecl(node *ast.ClassDecl, ctx *ExecutionContext) V
 			return e.newError(opDecl, "class operator '%s' missing binding", opDecl.OperatorSymbol)
 		}
 
+		if isConversionOperator(opDecl) {
+			_, isClassMethod := classInfo.LookupDeclaredMethod(opDecl.Binding.Value, true)
+			if errVal := e.registerOwnedConversion(classInfo, className, opDecl, isClassMethod, ctx); errVal != nil {
+				return errVal
+			}
+			continue
+		}
+
 		operandTypes := make([]string, 0, len(opDecl.OperandTypes))
 		for _, operand := range opDecl.OperandTypes {
 			typeName := operand.String()
@@ -777,6 +791,122 @@ func (e *Evaluator) VisitOperatorDecl(node *ast.OperatorDecl, ctx *ExecutionCont
 	return &runtime.NilValue{}
 }
 
+// isConversionOperator reports whether a class operator declares an Implicit or
+// Explicit conversion.
+func isConversionOperator(opDecl *ast.OperatorDecl) bool {
+	return ident.Equal(opDecl.OperatorSymbol, "implicit") || ident.Equal(opDecl.OperatorSymbol, "explicit")
+}
+
+// operatorOperandTypeKey resolves an operator operand type to the normalized key
+// used by the operator and conversion registries.
+func (e *Evaluator) operatorOperandTypeKey(typeName string, ctx *ExecutionContext) string {
+	resolvedType, err := e.resolveTypeName(typeName, ctx)
+	if err != nil || resolvedType == nil {
+		return interptypes.NormalizeTypeAnnotation(typeName)
+	}
+	if classType, ok := resolvedType.(*types.ClassType); ok {
+		return interptypes.NormalizeTypeAnnotation(classType.Name)
+	}
+	return interptypes.NormalizeTypeAnnotation(resolvedType.String())
+}
+
+// registerOwnedConversion registers a class operator Implicit or Explicit declared
+// in a class or record. The operand is the source type; the target is the
+// declared return type, or the owner itself when omitted.
+func (e *Evaluator) registerOwnedConversion(owner any, ownerName string, opDecl *ast.OperatorDecl, isClassMethod bool, ctx *ExecutionContext) Value {
+	if len(opDecl.OperandTypes) != 1 {
+		return e.newError(opDecl, "conversion operator '%s' requires exactly one operand", opDecl.OperatorSymbol)
+	}
+
+	from := e.operatorOperandTypeKey(opDecl.OperandTypes[0].String(), ctx)
+	to := interptypes.NormalizeTypeAnnotation(ownerName)
+	if opDecl.ReturnType != nil {
+		to = e.operatorOperandTypeKey(opDecl.ReturnType.String(), ctx)
+	}
+
+	entry := &interptypes.ConversionEntry{
+		Owner:         owner,
+		From:          from,
+		To:            to,
+		BindingName:   ident.Normalize(opDecl.Binding.Value),
+		Implicit:      ident.Equal(opDecl.OperatorSymbol, "implicit"),
+		IsClassMethod: isClassMethod,
+	}
+	if err := e.typeSystem.Conversions().Register(entry); err != nil {
+		return e.newError(opDecl, "conversion from %s to %s already defined", from, to)
+	}
+	return nil
+}
+
+// registerRecordOperator registers a class operator declared in a record.
+// A single operand type is combined with the record itself; the 'in' operator
+// takes the record as its right operand.
+func (e *Evaluator) registerRecordOperator(recordType *RecordTypeValue, opDecl *ast.OperatorDecl, ctx *ExecutionContext) Value {
+	if opDecl == nil {
+		return nil
+	}
+	recordName := recordType.GetRecordTypeName()
+	if opDecl.Binding == nil {
+		return e.newError(opDecl, "class operator '%s' missing binding", opDecl.OperatorSymbol)
+	}
+
+	bindingKey := ident.Normalize(opDecl.Binding.Value)
+	method, isClassMethod := recordType.ClassMethods[bindingKey]
+	if !isClassMethod {
+		var ok bool
+		if method, ok = recordType.Methods[bindingKey]; !ok {
+			return e.newError(opDecl, "binding '%s' for class operator '%s' not found in record '%s'",
+				opDecl.Binding.Value, opDecl.OperatorSymbol, recordName)
+		}
+	}
+
+	if isConversionOperator(opDecl) {
+		return e.registerOwnedConversion(recordType, recordName, opDecl, isClassMethod, ctx)
+	}
+
+	recordKey := interptypes.NormalizeTypeAnnotation(recordName)
+	operandTypes := make([]string, 0, len(opDecl.OperandTypes)+1)
+	includesRecord := false
+	for _, operand := range opDecl.OperandTypes {
+		key := e.operatorOperandTypeKey(operand.String(), ctx)
+		if key == recordKey {
+			includesRecord = true
+		}
+		operandTypes = append(operandTypes, key)
+	}
+	if len(operandTypes) == 1 || !includesRecord {
+		if ident.Equal(opDecl.OperatorSymbol, "in") {
+			operandTypes = append(operandTypes, recordKey)
+		} else {
+			operandTypes = append([]string{recordKey}, operandTypes...)
+		}
+	}
+
+	selfIndex := -1
+	if !isClassMethod {
+		for idx, key := range operandTypes {
+			if key == recordKey {
+				selfIndex = idx
+				break
+			}
+		}
+	}
+
+	entry := &runtime.OperatorEntry{
+		Method:        method,
+		BindingName:   bindingKey,
+		Operator:      opDecl.OperatorSymbol,
+		OperandTypes:  operandTypes,
+		SelfIndex:     selfIndex,
+		IsClassMethod: isClassMethod,
+	}
+	if !recordType.RegisterOperator(entry) {
+		return e.newError(opDecl, "class operator '%s' already defined for operand types (%s)",
+			opDecl.OperatorSymbol, strings.Join(operandTypes, ", "))
+	}
+	return nil
+}
+
 // VisitEnumDecl evaluates an enum declaration.
 // Calculates ordinal values, validates flags (powers of 2), registers in TypeSystem.
 func (e *Evaluator) VisitEnumDecl(node *ast.EnumDecl, ctx *ExecutionContext) Value {
@@ -1110,6 +1240,13 @@ func (e *Evaluator) VisitRecordDecl(node *ast.RecordDecl, ctx *ExecutionContext)
 		recordTypeValue.ClassMethods[k] = v
 	}
 
+	// Register class operators
+	for _, opDecl := range node.Operators {
+		if errVal := e.registerRecordOperator(recordTypeValue, opDecl, ctx); errVal != nil {
+			return errVal
+		}
+	}
+
 	// Register in environment and TypeSystem
 	// Use savedEnv because ctx.Env() is currently tempEnv which will be discarded
 	// Register record type under two keys:
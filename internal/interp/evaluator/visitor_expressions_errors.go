@@ -18,14 +18,25 @@ func (e *Evaluator) newError(node ast.Node, format string, args ...any) Value {
 
 	// Add location information if node is available
 	// Format matches RuntimeError.String() format: "[line: N, column: M]"
+	errVal := &runtime.ErrorValue{}
 	if node != nil {
 		pos := node.Pos()
 		if pos.Line > 0 {
 			message = fmt.Sprintf("%s [line: %d, column: %d]", message, pos.Line, pos.Column)
+			errVal.Pos = &pos
 		}
 	}
+	errVal.Message = message
 
-	return &runtime.ErrorValue{Message: message}
+	return errVal
+}
+
+// newErrorOfClass is newError for runtime failures that map to a specific
+// exception class, so `on E: EDivByZero do` and similar handlers match them.
+func (e *Evaluator) newErrorOfClass(node ast.Node, className, format string, args ...any) Value {
+	errVal := e.newError(node, format, args...).(*runtime.ErrorValue)
+	errVal.ExceptionClass = className
+	return errVal
 }
 
 // isError checks if a value is an error.
@@ -55,15 +66,25 @@ func spliceRoutineNameIntoError(message, routine string) string {
 // If routine is non-empty it is spliced into the message before the location
 // suffix ("<msg> in <routine> [line: ...]"), matching DWScript semantics where
 // the message is formed at the raise point inside the routine.
+//
+// Errors of an interrupted evaluation are not raised: they must unwind past
+// every handler (see SetInterrupt).
 func (e *Evaluator) raiseErrorValueAsException(errVal Value, routine string, ctx *ExecutionContext) {
+	if e.interrupted() {
+		return
+	}
 	message := ""
+	className := "Exception"
 	if ev, ok := errVal.(*runtime.ErrorValue); ok {
 		message = ev.Message
+		if ev.ExceptionClass != "" {
+			className = ev.ExceptionClass
+		}
 	} else if errVal != nil {
 		message = errVal.String()
 	}
 	message = spliceRoutineNameIntoError(message, routine)
-	ctx.SetException(e.createException("Exception", message, nil, ctx))
+	ctx.SetException(e.createException(className, message, nil, ctx))
 }
 
 // currentRoutineName returns the qualified name of the routine currently on
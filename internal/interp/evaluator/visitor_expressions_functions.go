@@ -3,7 +3,6 @@ package evaluator
 import (
 	"fmt"
 
-	"github.com/cwbudde/go-dws/internal/builtins"
 	"github.com/cwbudde/go-dws/internal/interp/runtime"
 	"github.com/cwbudde/go-dws/internal/types"
 	"github.com/cwbudde/go-dws/pkg/ast"
@@ -21,6 +20,16 @@ func (e *Evaluator) VisitCallExpression(node *ast.CallExpression, ctx *Execution
 		return e.newError(node, "call expression missing function")
 	}
 
+	// Named arguments are bound to parameter order, then the call proceeds
+	// as an ordinary positional one.
+	if node.HasNamedArguments() {
+		positional, err := e.bindNamedCallArguments(node, ctx)
+		if err != nil {
+			return e.newError(node, "%s", err.Error())
+		}
+		return e.VisitCallExpression(positional, ctx)
+	}
+
 	// Nested (scoped) function declarations hide all same-named outer
 	// functions and methods for the duration of the enclosing call.
 	if funcIdent, ok := node.Function.(*ast.Identifier); ok {
@@ -326,6 +335,12 @@ func (e *Evaluator) VisitCallExpression(node *ast.CallExpression, ctx *Execution
 		return e.builtinInsert(node.Arguments, ctx)
 	case "swap":
 		return e.builtinSwap(node.Arguments, ctx)
+	case "freeandnil":
+		return e.builtinFreeAndNil(node, ctx)
+	case "supports":
+		return e.builtinSupports(node, ctx)
+	case "assert":
+		return e.builtinAssert(node, ctx)
 	case "include", "exclude":
 		return e.builtinIncludeExclude(funcNameLower, node.Arguments, ctx)
 	case "divmod":
@@ -352,7 +367,7 @@ func (e *Evaluator) VisitCallExpression(node *ast.CallExpression, ctx *Execution
 
 	// Default(TypeName) - expects unevaluated type identifier
 	if funcNameLower == "default" && len(node.Arguments) == 1 {
-		return e.builtinDefault(node.Arguments)
+		return e.builtinDefault(node.Arguments, ctx)
 	}
 
 	// Type casts: TypeName(expression) for single-argument calls
@@ -389,7 +404,7 @@ func (e *Evaluator) VisitCallExpression(node *ast.CallExpression, ctx *Execution
 	}
 
 	// Call built-in function from registry
-	if fn, ok := builtins.DefaultRegistry.Lookup(funcName.Value); ok {
+	if fn, ok := e.FunctionRegistry().LookupBuiltin(funcName.Value); ok {
 		// Variant-typed values reach builtins as their dynamic type; coerce
 		// them to the declared parameter types (DWScript variant casts).
 		if errVal := e.coerceBuiltinArgsToSignature(funcName, node.Arguments, args, ctx); errVal != nil {
@@ -587,7 +602,7 @@ func (e *Evaluator) raiseBoundExceededError(err error, ctx *ExecutionContext) (V
 		ctx = e.currentContext
 	}
 	if ctx != nil {
-		ctx.SetException(e.createException("Exception", be.msg, nil, ctx))
+		ctx.SetException(e.createException("ERangeError", be.msg, nil, ctx))
 	}
 	return e.nilValue(), true
 }
@@ -654,8 +669,11 @@ func (e *Evaluator) prepareArrayElementReference(idxExpr *ast.IndexExpression, c
 
 	// Bind-time bounds check raises a catchable exception at the call site.
 	if _, err := arrayElementPhysicalIndex(arr, index); err != nil {
-		low, _ := arrayElementBounds(arr)
-		e.raiseIndexBoundExceededAt(idxExpr.End(), index, index >= low)
+		if arr.ArrayType.IsStatic() {
+			e.raiseStaticIndexBoundExceededAt(idxExpr.End(), index, arr.ArrayType)
+		} else {
+			e.raiseIndexBoundExceededAt(idxExpr.End(), index, index >= 0)
+		}
 		return nil, true, err
 	}
 
@@ -666,7 +684,10 @@ func (e *Evaluator) prepareArrayElementReference(idxExpr *ast.IndexExpression, c
 		}
 		el := arr.Elements[phys]
 		if el == nil {
-			return e.getZeroValueForType(arr.ArrayType.ElementType), nil
+			// Materialize unset elements so field writes through a record
+			// element reference are stored in the array.
+			el = e.getZeroValueForType(arr.ArrayType.ElementType)
+			arr.Elements[phys] = el
 		}
 		return el, nil
 	}
@@ -682,23 +703,20 @@ func (e *Evaluator) prepareArrayElementReference(idxExpr *ast.IndexExpression, c
 	return runtime.NewReferenceValue(idxExpr.String(), getter, setter), true, nil
 }
 
-// prepareMemberFieldReference builds a live reference for obj.field byref
-// arguments whose base is itself a reference (var parameter). Each access
-// re-dereferences the base, so bound violations of a stale base propagate.
-// Returns handled=false when the base is not a reference or the member is not
-// a plain record/object field.
+// prepareMemberFieldReference builds a live reference for rec.field and
+// obj.field byref arguments. The base is bound through a live reference too
+// (see prepareBaseReference), so nested paths such as recs[i].A or o.R.A write
+// into the storage they name, and each access re-dereferences the base so
+// bound violations of a stale array element propagate. Returns handled=false
+// when the base cannot be bound or the member is not a plain record/object
+// field (e.g. a property), letting the generic lvalue path take over.
 func (e *Evaluator) prepareMemberFieldReference(memberExpr *ast.MemberAccessExpression, ctx *ExecutionContext) (Value, bool, error) {
-	baseIdent, ok := memberExpr.Object.(*ast.Identifier)
-	if !ok || memberExpr.Member == nil {
+	if memberExpr.Member == nil {
 		return nil, false, nil
 	}
-	baseRaw, exists := ctx.Env().Get(baseIdent.Value)
-	if !exists {
-		return nil, false, nil
-	}
-	baseRef, ok := baseRaw.(ReferenceAccessor)
-	if !ok {
-		return nil, false, nil
+	baseRef, handled, err := e.prepareBaseReference(memberExpr.Object, ctx)
+	if !handled || err != nil {
+		return nil, handled, err
 	}
 
 	// Only handle plain record/object field access; anything else (properties,
@@ -713,6 +731,52 @@ func (e *Evaluator) prepareMemberFieldReference(memberExpr *ast.MemberAccessExpr
 	return runtime.NewReferenceValue(memberExpr.String(), getter, setter), true, nil
 }
 
+// prepareBaseReference binds the base of a byref field argument: a variable
+// (reusing its reference when it is itself a var parameter), an array element
+// or a nested field. Other bases, such as function results, are not handled.
+func (e *Evaluator) prepareBaseReference(base ast.Expression, ctx *ExecutionContext) (ReferenceAccessor, bool, error) {
+	switch node := base.(type) {
+	case *ast.Identifier:
+		env := ctx.Env()
+		raw, exists := env.Get(node.Value)
+		if !exists {
+			return nil, false, nil
+		}
+		if ref, ok := raw.(ReferenceAccessor); ok {
+			return ref, true, nil
+		}
+		name := node.Value
+		getter := func() (runtime.Value, error) {
+			val, found := env.Get(name)
+			if !found {
+				return nil, fmt.Errorf("variable %s not found", name)
+			}
+			if runtimeVal, ok := val.(runtime.Value); ok {
+				return runtimeVal, nil
+			}
+			return nil, fmt.Errorf("environment value is not a runtime.Value")
+		}
+		setter := func(val runtime.Value) error {
+			return env.Set(name, val)
+		}
+		return runtime.NewReferenceValue(name, getter, setter), true, nil
+	case *ast.IndexExpression:
+		ref, handled, err := e.prepareArrayElementReference(node, ctx)
+		if !handled || err != nil {
+			return nil, handled, err
+		}
+		return ref.(ReferenceAccessor), true, nil
+	case *ast.MemberAccessExpression:
+		ref, handled, err := e.prepareMemberFieldReference(node, ctx)
+		if !handled || err != nil {
+			return nil, handled, err
+		}
+		return ref.(ReferenceAccessor), true, nil
+	default:
+		return nil, false, nil
+	}
+}
+
 // memberFieldExists reports whether cur is a record/object with a plain field
 // of the given name.
 func memberFieldExists(cur Value, fieldName string) bool {
@@ -771,7 +835,9 @@ func (e *Evaluator) prepareArgsForParameters(
 	argExprs []ast.Expression,
 	ctx *ExecutionContext,
 ) ([]Value, error) {
-	if len(argExprs) != len(parameters) {
+	// Trailing parameters with default values may be omitted; the callee
+	// fills them in.
+	if len(argExprs) > len(parameters) || len(argExprs) < requiredParameterCount(parameters) {
 		return nil, fmt.Errorf("wrong number of arguments: expected %d, got %d", len(parameters), len(argExprs))
 	}
 
@@ -1041,6 +1107,9 @@ func (e *Evaluator) VisitNewExpression(node *ast.NewExpression, ctx *ExecutionCo
 	if classInfoAny == nil {
 		if recordTypeRaw := e.typeSystem.LookupRecord(className); recordTypeRaw != nil {
 			if recordType, ok := recordTypeRaw.(*RecordTypeValue); ok && recordType.HasStaticMethod("Create") {
+				if node.HasNamedArguments() {
+					return e.newError(node, "named arguments are only supported in calls to declared functions and methods")
+				}
 				if errVal := evalArgsByValue(); errVal != nil {
 					return errVal
 				}
@@ -1081,6 +1150,39 @@ func (e *Evaluator) VisitNewExpression(node *ast.NewExpression, ctx *ExecutionCo
 		return e.newError(node, "class '%s' has invalid type", className)
 	}
 
+	// Resolve the constructor `new` should invoke. DWScript's `new TClass(...)`
+	// calls the class's *default* constructor — the one declared with the
+	// `default` directive (which may be named other than "Create"), searched up
+	// the hierarchy — falling back to "Create" when none is marked. A class that
+	// declares only non-default constructors (e.g. `constructor World;` without
+	// `default`) therefore instantiates via plain allocation, matching DWScript.
+	ctorName := "Create"
+	for current := runtime.IClassInfo(classInfo); current != nil; current = current.GetParent() {
+		if dc := current.GetDefaultConstructor(); dc != "" {
+			ctorName = dc
+			break
+		}
+	}
+
+	// Named arguments are bound to the parameter order of the constructor, or
+	// of the class method the "TClass.Create(args)" sugar resolves to.
+	if node.HasNamedArguments() {
+		target := classInfo.GetConstructor(ctorName)
+		if !ident.Equal(node.Token.Literal, "new") {
+			if classOverloads := classInfo.GetClassMethodOverloads("Create"); len(classOverloads) > 0 {
+				if target != nil || len(classOverloads) > 1 {
+					return e.newError(node, "named arguments are not supported for overloaded method 'Create'")
+				}
+				target = classOverloads[0]
+			}
+		}
+		positional, err := bindNamedConstructorArguments(node, target)
+		if err != nil {
+			return e.newError(node, "%s", err.Error())
+		}
+		node = positional
+	}
+
 	// The "TClass.Create(args)" sugar (node token is the class name, not "new")
 	// can resolve to a class method named Create rather than a constructor.
 	if !ident.Equal(node.Token.Literal, "new") {
@@ -1110,20 +1212,6 @@ func (e *Evaluator) VisitNewExpression(node *ast.NewExpression, ctx *ExecutionCo
 		return e.newError(node, "cannot instantiate external class '%s' - external classes are not supported", className)
 	}
 
-	// Resolve the constructor `new` should invoke. DWScript's `new TClass(...)`
-	// calls the class's *default* constructor — the one declared with the
-	// `default` directive (which may be named other than "Create"), searched up
-	// the hierarchy — falling back to "Create" when none is marked. A class that
-	// declares only non-default constructors (e.g. `constructor World;` without
-	// `default`) therefore instantiates via plain allocation, matching DWScript.
-	ctorName := "Create"
-	for current := runtime.IClassInfo(classInfo); current != nil; current = current.GetParent() {
-		if dc := current.GetDefaultConstructor(); dc != "" {
-			ctorName = dc
-			break
-		}
-	}
-
 	// Execute constructor: when the declaration is unambiguous and declares
 	// var/lazy parameters, wrap the arguments (by-ref references / lazy
 	// thunks) so writes inside the constructor reach the caller's variable
@@ -3,7 +3,6 @@ package evaluator
 import (
 	"fmt"
 
-	"github.com/cwbudde/go-dws/internal/builtins"
 	"github.com/cwbudde/go-dws/internal/interp/runtime"
 	"github.com/cwbudde/go-dws/internal/types"
 	"github.com/cwbudde/go-dws/pkg/ast"
@@ -265,7 +264,7 @@ func (e *Evaluator) VisitIdentifier(node *ast.Identifier, ctx *ExecutionContext)
 	if selfRaw, selfOk := ctx.Env().Get("Self"); selfOk && !isCurrentHelperMethod(ctx, node.Value) {
 		if selfVal, ok := selfRaw.(Value); ok {
 			if helperResult := e.FindHelperMethod(selfVal, node.Value); helperResult != nil {
-				if helperResult.Method != nil && helperASTMethodEffectiveParamCount(helperResult.Method) == 0 {
+				if helperResult.Method != nil && helperASTMethodRequiredParamCount(helperResult.Method) == 0 {
 					callExpr := &ast.CallExpression{
 						TypedExpressionBase: ast.TypedExpressionBase{
 							BaseNode: ast.BaseNode{Token: node.Token},
@@ -402,6 +401,12 @@ func (e *Evaluator) VisitIdentifier(node *ast.Identifier, ctx *ExecutionContext)
 		}
 	}
 
+	// External (Go) functions without parameters are auto-invoked like
+	// parameterless builtins, which they may override.
+	if e.ExternalFunctions() != nil && e.ExternalFunctions().Has(node.Value) {
+		return e.callExternalFunction(node.Value, nil, node, ctx)
+	}
+
 	// Final check: check for built-in functions or return undefined error
 	if e.FunctionRegistry().IsBuiltin(node.Value) {
 		// If the semantic type expects a function/method pointer, return a builtin function pointer
@@ -413,7 +418,7 @@ func (e *Evaluator) VisitIdentifier(node *ast.Identifier, ctx *ExecutionContext)
 		}
 
 		// Parameterless built-in functions are auto-invoked
-		if fn, ok := builtins.DefaultRegistry.Lookup(node.Value); ok {
+		if fn, ok := e.FunctionRegistry().LookupBuiltin(node.Value); ok {
 			return fn(e, []Value{}) // Call with empty args (parameterless auto-invoke)
 		}
 		// Builtin registered but not found in registry - should not happen
@@ -525,7 +530,7 @@ func (e *Evaluator) invokeParameterlessUserFunction(fn *ast.FunctionDecl, node a
 		return e.newError(node, "function '%s' has no body", funcName)
 	}
 
-	e.Eval(fn.Body, ctx)
+	e.callOnStack(ctx, func() Value { return e.Eval(fn.Body, ctx) })
 
 	// 6. Handle exceptions during execution
 	if ctx.Exception() != nil {
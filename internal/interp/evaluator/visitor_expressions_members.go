@@ -54,7 +54,10 @@ func (e *Evaluator) VisitMemberAccessExpression(node *ast.MemberAccessExpression
 	// Unit-qualified access (UnitName.Symbol) should not evaluate the unit identifier.
 	if identObj, ok := node.Object.(*ast.Identifier); ok {
 		if _, exists := ctx.Env().Get(identObj.Value); !exists && e.UnitRegistry() != nil {
-			if _, exists := e.UnitRegistry().GetUnit(identObj.Value); exists {
+			if unit, exists := e.UnitRegistry().GetUnit(identObj.Value); exists {
+				if val, ok := unit.LookupConstant(node.Member.Value); ok {
+					return val
+				}
 				if valRaw, ok := ctx.Env().Get(node.Member.Value); ok {
 					if val, ok := valRaw.(Value); ok {
 						return val
@@ -90,6 +93,11 @@ func (e *Evaluator) VisitMemberAccessExpression(node *ast.MemberAccessExpression
 		return e.evalJSONValueMember(jsonValueOf(obj), memberName)
 	}
 
+	// Member access on runtime type information (TypeOf(x).Name, ...).
+	if info, ok := obj.(*runtime.RTTITypeInfoValue); ok {
+		return e.evalRTTIMember(info, memberName, node, ctx)
+	}
+
 	// Associative array parameterless members (a.Keys, a.Length, a.Count, a.Clear).
 	if assoc, ok := obj.(*runtime.AssociativeArrayValue); ok {
 		if result, handled := e.evalAssociativeArrayMethod(assoc, memberName, nil, node); handled {
@@ -130,10 +138,10 @@ func (e *Evaluator) VisitMemberAccessExpression(node *ast.MemberAccessExpression
 			if !found {
 				return e.newError(node, "internal error: method '%s' not retrievable", memberName)
 			}
-			if len(methodDecl.Parameters) > 0 {
+			if required := requiredParameterCount(methodDecl.Parameters); required > 0 {
 				return e.newError(node,
 					"method '%s' of record '%s' requires %d parameter(s); use parentheses to call",
-					memberName, recVal.GetRecordTypeName(), len(methodDecl.Parameters))
+					memberName, recVal.GetRecordTypeName(), required)
 			}
 			return e.callRecordMethod(recVal, methodDecl, []Value{}, node, ctx)
 		}
@@ -183,7 +191,7 @@ func (e *Evaluator) VisitMemberAccessExpression(node *ast.MemberAccessExpression
 		if !isCurrentHelperMethod(ctx, memberName) {
 			helperResult := e.FindHelperMethod(obj, memberName)
 			if helperResult != nil {
-				if helperResult.Method != nil && helperASTMethodEffectiveParamCount(helperResult.Method) == 0 {
+				if helperResult.Method != nil && helperASTMethodRequiredParamCount(helperResult.Method) == 0 {
 					return e.CallHelperMethod(helperResult, obj, []Value{}, node, ctx)
 				}
 				if helperResult.BuiltinSpec != "" {
@@ -239,7 +247,7 @@ func (e *Evaluator) VisitMemberAccessExpression(node *ast.MemberAccessExpression
 			if !isCurrentHelperMethod(ctx, memberName) {
 				helperResult := e.findHelperMethodInHelper(helper, memberName)
 				if helperResult != nil {
-					if helperResult.Method != nil && helperASTMethodEffectiveParamCount(helperResult.Method) == 0 {
+					if helperResult.Method != nil && helperASTMethodRequiredParamCount(helperResult.Method) == 0 {
 						return e.CallHelperMethod(helperResult, obj, []Value{}, node, ctx)
 					}
 					if helperResult.BuiltinSpec != "" {
@@ -373,7 +381,7 @@ func (e *Evaluator) VisitMemberAccessExpression(node *ast.MemberAccessExpression
 		if !isCurrentHelperMethod(ctx, memberName) {
 			helperResult := e.FindHelperMethod(obj, memberName)
 			if helperResult != nil {
-				if helperResult.Method != nil && helperASTMethodEffectiveParamCount(helperResult.Method) == 0 {
+				if helperResult.Method != nil && helperASTMethodRequiredParamCount(helperResult.Method) == 0 {
 					return e.CallHelperMethod(helperResult, obj, []Value{}, node, ctx)
 				}
 				if helperResult.BuiltinSpec != "" {
@@ -604,7 +612,7 @@ func (e *Evaluator) VisitMemberAccessExpression(node *ast.MemberAccessExpression
 		if wrappedValue != nil {
 			helperResult := e.FindHelperMethod(wrappedValue, memberName)
 			if helperResult != nil {
-				if helperResult.Method != nil && helperASTMethodEffectiveParamCount(helperResult.Method) == 0 {
+				if helperResult.Method != nil && helperASTMethodRequiredParamCount(helperResult.Method) == 0 {
 					return e.CallHelperMethod(helperResult, wrappedValue, []Value{}, node, ctx)
 				}
 				if helperResult.BuiltinSpec != "" {
@@ -651,7 +659,7 @@ func (e *Evaluator) VisitMemberAccessExpression(node *ast.MemberAccessExpression
 				if !isCurrentHelperMethod(ctx, memberName) {
 					helperResult := e.findHelperMethodInHelper(helper, memberName)
 					if helperResult != nil {
-						if helperResult.Method != nil && helperASTMethodEffectiveParamCount(helperResult.Method) == 0 {
+						if helperResult.Method != nil && helperASTMethodRequiredParamCount(helperResult.Method) == 0 {
 							return e.CallHelperMethod(helperResult, obj, []Value{}, node, ctx)
 						}
 						if helperResult.Method == nil && helperResult.BuiltinSpec != "" {
@@ -673,7 +681,7 @@ func (e *Evaluator) VisitMemberAccessExpression(node *ast.MemberAccessExpression
 			if helperName != "" {
 				if h := e.lookupMutableHelper(helperName); h != nil {
 					if helperResult := e.findHelperMethodInHelper(h, memberName); helperResult != nil {
-						if (helperResult.Method != nil && helperASTMethodEffectiveParamCount(helperResult.Method) == 0) ||
+						if (helperResult.Method != nil && helperASTMethodRequiredParamCount(helperResult.Method) == 0) ||
 							(helperResult.Method == nil && helperResult.BuiltinSpec != "") {
 							return e.CallHelperMethod(helperResult, obj, []Value{}, node, ctx)
 						}
@@ -758,7 +766,7 @@ func (e *Evaluator) VisitMemberAccessExpression(node *ast.MemberAccessExpression
 		if !isCurrentHelperMethod(ctx, memberName) {
 			helperResult := e.FindHelperMethod(obj, memberName)
 			if helperResult != nil {
-				if helperResult.Method != nil && helperASTMethodEffectiveParamCount(helperResult.Method) == 0 {
+				if helperResult.Method != nil && helperASTMethodRequiredParamCount(helperResult.Method) == 0 {
 					return e.CallHelperMethod(helperResult, obj, []Value{}, node, ctx)
 				}
 				if helperResult.BuiltinSpec != "" {
@@ -968,7 +976,7 @@ func (e *Evaluator) resolveClassMetaMember(obj Value, classMetaVal ClassMetaValu
 
 	if !isCurrentHelperMethod(ctx, memberName) {
 		if helperResult := e.FindHelperMethod(obj, memberName); helperResult != nil {
-			if helperResult.Method != nil && helperASTMethodEffectiveParamCount(helperResult.Method) == 0 {
+			if helperResult.Method != nil && helperASTMethodRequiredParamCount(helperResult.Method) == 0 {
 				return e.CallHelperMethod(helperResult, obj, []Value{}, node, ctx)
 			}
 			if helperResult.BuiltinSpec != "" {
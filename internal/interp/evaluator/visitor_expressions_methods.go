@@ -72,6 +72,9 @@ func (e *Evaluator) VisitMethodCallExpression(node *ast.MethodCallExpression, ct
 				_, unitExists = e.UnitRegistry().GetUnit(identObj.Value)
 			}
 			if unitExists {
+				if node.HasNamedArguments() {
+					return e.newError(node, "named arguments are only supported in calls to declared functions and methods")
+				}
 				return e.executeQualifiedFunctionCall(identObj.Value, node.Method, node.Arguments, node, ctx)
 			}
 		}
@@ -83,6 +86,16 @@ func (e *Evaluator) VisitMethodCallExpression(node *ast.MethodCallExpression, ct
 		return obj
 	}
 
+	// Named arguments are bound to the parameter order of the called method;
+	// the call then proceeds as an ordinary positional one.
+	if node.HasNamedArguments() {
+		positional, err := e.bindNamedMethodArguments(node, obj)
+		if err != nil {
+			return e.newError(node, "%s", err.Error())
+		}
+		node = positional
+	}
+
 	methodName := node.Method.Value
 
 	// Method call on a JSON value receiver: v.TypeName(), v.Add(x), ...
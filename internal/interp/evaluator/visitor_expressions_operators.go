@@ -1,7 +1,6 @@
 package evaluator
 
 import (
-	"github.com/cwbudde/go-dws/internal/builtins"
 	"github.com/cwbudde/go-dws/internal/interp/runtime"
 	"github.com/cwbudde/go-dws/internal/types"
 	"github.com/cwbudde/go-dws/pkg/ast"
@@ -13,6 +12,10 @@ import (
 
 // VisitBinaryExpression evaluates a binary expression (e.g., a + b, x == y).
 func (e *Evaluator) VisitBinaryExpression(node *ast.BinaryExpression, ctx *ExecutionContext) Value {
+	if value, ok := e.foldedValue(node); ok {
+		return value
+	}
+
 	// Handle short-circuit operators first (special evaluation order)
 	switch node.Operator {
 	case "??":
@@ -51,6 +54,7 @@ func (e *Evaluator) VisitBinaryExpression(node *ast.BinaryExpression, ctx *Execu
 	if right == nil {
 		return e.newError(node.Right, "right operand evaluated to nil")
 	}
+	left, right = unwrapSubrange(left), unwrapSubrange(right)
 
 	// Try operator overloading first (custom operators for objects)
 	if result, ok := e.tryBinaryOperator(node.Operator, left, right, node); ok {
@@ -86,11 +90,17 @@ func (e *Evaluator) VisitBinaryExpression(node *ast.BinaryExpression, ctx *Execu
 	case left.Type() == "ARRAY" && right.Type() == "ARRAY":
 		return e.evalArrayBinaryOp(node.Operator, left, right, node)
 
-	// Allow string concatenation with RTTI_TYPEINFO
+	// Allow string concatenation with RTTI_TYPEINFO, and comparing it with a
+	// type name
 	case (left.Type() == "STRING" && right.Type() == "RTTI_TYPEINFO") ||
 		(left.Type() == "RTTI_TYPEINFO" && right.Type() == "STRING"):
-		if node.Operator == "+" {
+		switch node.Operator {
+		case "+":
 			return &runtime.StringValue{Value: left.String() + right.String()}
+		case "=":
+			return &runtime.BooleanValue{Value: ident.Equal(left.String(), right.String())}
+		case "<>":
+			return &runtime.BooleanValue{Value: !ident.Equal(left.String(), right.String())}
 		}
 		return e.newError(node, "type mismatch: %s %s %s", left.Type(), node.Operator, right.Type())
 
@@ -117,11 +127,16 @@ func (e *Evaluator) VisitBinaryExpression(node *ast.BinaryExpression, ctx *Execu
 
 // VisitUnaryExpression evaluates a unary expression (e.g., -x, not b).
 func (e *Evaluator) VisitUnaryExpression(node *ast.UnaryExpression, ctx *ExecutionContext) Value {
+	if value, ok := e.foldedValue(node); ok {
+		return value
+	}
+
 	// Evaluate the operand
 	operand := e.Eval(node.Right, ctx)
 	if isError(operand) {
 		return operand
 	}
+	operand = unwrapSubrange(operand)
 
 	// Try operator overloading first (custom operators for objects)
 	if result, ok := e.tryUnaryOperator(node.Operator, operand, node); ok {
@@ -151,7 +166,8 @@ func (e *Evaluator) VisitUnaryExpression(node *ast.UnaryExpression, ctx *Executi
 // **FUNCTION POINTERS**:
 //   - Regular function/procedure references
 //   - Resolved via function registry (case-insensitive lookup)
-//   - For overloaded functions, the first overload is used
+//   - For overloaded functions, the overload selected by the semantic analyzer
+//     (or the first one) is used
 //   - The function pointer captures the closure environment
 //
 // **METHOD POINTERS** (procedure/function of object):
@@ -173,9 +189,9 @@ func (e *Evaluator) VisitAddressOfExpression(node *ast.AddressOfExpression, ctx
 		overloads := e.FunctionRegistry().Lookup(funcNameLower)
 		if len(overloads) == 0 {
 			// Check for builtin function
-			if _, ok := builtins.DefaultRegistry.Lookup(operand.Value); ok {
+			if _, ok := e.FunctionRegistry().LookupBuiltin(operand.Value); ok {
 				var pointerType *types.FunctionPointerType
-				if sig, found := builtins.DefaultRegistry.GetSignature(operand.Value); found {
+				if sig, found := e.FunctionRegistry().GetBuiltinRegistry().GetSignature(operand.Value); found {
 					// Create function pointer type from builtin signature
 					var returnType types.Type
 					if sig.ReturnType != nil && sig.ReturnType != types.VOID {
@@ -191,9 +207,20 @@ func (e *Evaluator) VisitAddressOfExpression(node *ast.AddressOfExpression, ctx
 			return e.newError(node, "undefined function or procedure: %s", operand.Value)
 		}
 
-		// For overloaded functions, use the first overload
+		// For overloaded functions, use the overload the semantic analyzer
+		// selected from the expected function pointer type, or the first one
 		// Note: Function pointers cannot represent overload sets, only single functions
 		function := overloads[0]
+		if len(overloads) > 1 && e.SemanticInfo() != nil {
+			if decl, ok := e.SemanticInfo().GetSymbol(operand).(*ast.FunctionDecl); ok {
+				for _, overload := range overloads {
+					if overload == decl {
+						function = overload
+						break
+					}
+				}
+			}
+		}
 
 		// Build the function pointer type and create the value
 		pointerType := buildFunctionPointerType(function)
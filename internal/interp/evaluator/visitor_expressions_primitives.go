@@ -28,6 +28,10 @@ func (e *Evaluator) VisitSelfExpression(node *ast.SelfExpression, ctx *Execution
 
 // VisitGroupedExpression evaluates a grouped expression (parenthesized).
 func (e *Evaluator) VisitGroupedExpression(node *ast.GroupedExpression, ctx *ExecutionContext) Value {
+	if value, ok := e.foldedValue(node); ok {
+		return value
+	}
+
 	// Grouped expressions just evaluate their inner expression
 	// Parentheses are only for precedence, they don't change the value
 	return e.Eval(node.Expression, ctx)
@@ -5,6 +5,7 @@ import (
 	"strings"
 
 	"github.com/cwbudde/go-dws/internal/interp/runtime"
+	"github.com/cwbudde/go-dws/internal/jsonvalue"
 	"github.com/cwbudde/go-dws/internal/lexer"
 	"github.com/cwbudde/go-dws/internal/types"
 	"github.com/cwbudde/go-dws/pkg/ast"
@@ -23,11 +24,32 @@ func (e *Evaluator) VisitProgram(node *ast.Program, ctx *ExecutionContext) Value
 		return result
 	}
 
+	// Type, constant and routine declarations are compile-time in DWScript:
+	// evaluate them first, in source order, so class var initializers have
+	// run exactly once before the first statement executes.
 	for _, stmt := range node.Statements {
-		result = e.Eval(stmt, ctx)
+		if !isProgramDeclaration(stmt) {
+			continue
+		}
+		if result = e.Eval(stmt, ctx); isError(result) {
+			return result
+		}
+	}
 
-		// If we hit an error, stop execution
+	for _, stmt := range node.Statements {
+		if isProgramDeclaration(stmt) {
+			continue
+		}
+		if result = e.checkInterrupt(stmt); result == nil {
+			result = e.Eval(stmt, ctx)
+		}
+
+		// If we hit an error, stop execution. Builtins that raise a script
+		// exception also return an error value; keep the exception's class.
 		if isError(result) {
+			if errVal, ok := result.(*runtime.ErrorValue); ok && errVal.ExceptionClass == "" {
+				errVal.ExceptionClass = exceptionClassName(ctx.Exception())
+			}
 			return result
 		}
 
@@ -64,7 +86,7 @@ func (e *Evaluator) VisitProgram(node *ast.Program, ctx *ExecutionContext) Value
 				message += "\n" + trace
 			}
 			message = formatDWScriptExceptionMessage(message)
-			return &runtime.ErrorValue{Message: message}
+			return &runtime.ErrorValue{Message: message, ExceptionClass: exceptionClassName(exc), Pos: exc.Position, Detail: exc.Detail}
 		}
 		type ExceptionInspector interface {
 			Inspect() string
@@ -78,6 +100,46 @@ func (e *Evaluator) VisitProgram(node *ast.Program, ctx *ExecutionContext) Value
 	return result
 }
 
+// isProgramDeclaration reports whether stmt is a top-level declaration that
+// VisitProgram evaluates ahead of the program's statements.
+func isProgramDeclaration(stmt ast.Statement) bool {
+	switch n := stmt.(type) {
+	case *ast.ClassDecl, *ast.InterfaceDecl, *ast.RecordDecl, *ast.EnumDecl,
+		*ast.SetDecl, *ast.ArrayDecl, *ast.HelperDecl, *ast.TypeDeclaration,
+		*ast.ConstDecl, *ast.FunctionDecl, *ast.OperatorDecl:
+		return true
+	case *ast.VarDeclStatement:
+		// Variables without an initializer only reserve storage, so class var
+		// initializers may already use them.
+		return n.Value == nil && !n.IsExternal
+	case *ast.BlockStatement:
+		// A multi-declaration 'type' or 'var' section.
+		if len(n.Statements) == 0 {
+			return false
+		}
+		for _, inner := range n.Statements {
+			if !isProgramDeclaration(inner) {
+				return false
+			}
+		}
+		return true
+	}
+	return false
+}
+
+// exceptionClassName returns the class name of a pending script exception,
+// or "" when exc is not an exception value.
+func exceptionClassName(exc any) string {
+	excVal, ok := exc.(*runtime.ExceptionValue)
+	if !ok || excVal == nil {
+		return ""
+	}
+	if excVal.Metadata != nil {
+		return excVal.Metadata.Name
+	}
+	return "Exception"
+}
+
 func formatDWScriptExceptionMessage(message string) string {
 	message = strings.TrimSpace(message)
 	switch {
@@ -257,7 +319,7 @@ func (e *Evaluator) VisitVarDeclStatement(node *ast.VarDeclStatement, ctx *Execu
 
 		if value == nil {
 			if arrayLit, ok := node.Value.(*ast.ArrayLiteralExpression); ok {
-				if node.Type != nil {
+				if node.Type != nil && !e.isSetAnnotatedArrayLiteral(arrayLit, ctx) {
 					typeName := node.Type.String()
 					resolvedType, err := e.resolveTypeName(typeName, ctx)
 					if err != nil {
@@ -544,6 +606,9 @@ func (e *Evaluator) VisitBlockStatement(node *ast.BlockStatement, ctx *Execution
 	var result Value
 
 	for _, stmt := range node.Statements {
+		if errVal := e.checkInterrupt(stmt); errVal != nil {
+			return errVal
+		}
 		result = e.Eval(stmt, ctx)
 
 		if isError(result) {
@@ -567,6 +632,18 @@ func (e *Evaluator) VisitBlockStatement(node *ast.BlockStatement, ctx *Execution
 
 // VisitIfStatement evaluates an if statement (if-then-else).
 func (e *Evaluator) VisitIfStatement(node *ast.IfStatement, ctx *ExecutionContext) Value {
+	// An inline variable lives in its own scope around both branches
+	if node.InlineVar != nil {
+		ctx.PushEnv()
+		defer ctx.PopEnv()
+		if result := e.Eval(node.InlineVar, ctx); isError(result) {
+			return result
+		}
+		if ctx.Exception() != nil {
+			return &runtime.NilValue{}
+		}
+	}
+
 	// Evaluate the condition
 	condition := e.Eval(node.Condition, ctx)
 	if isError(condition) {
@@ -574,7 +651,7 @@ func (e *Evaluator) VisitIfStatement(node *ast.IfStatement, ctx *ExecutionContex
 	}
 
 	// Convert condition to boolean
-	if IsTruthy(condition) {
+	if e.conditionHolds(condition, node.InlineVar != nil) {
 		return e.Eval(node.Consequence, ctx)
 	} else if node.Alternative != nil {
 		return e.Eval(node.Alternative, ctx)
@@ -588,7 +665,26 @@ func (e *Evaluator) VisitIfStatement(node *ast.IfStatement, ctx *ExecutionContex
 func (e *Evaluator) VisitWhileStatement(node *ast.WhileStatement, ctx *ExecutionContext) Value {
 	var result Value = &runtime.NilValue{}
 
+	// An inline variable lives in its own scope around the loop and is
+	// initialized anew before every iteration
+	if node.InlineVar != nil {
+		ctx.PushEnv()
+		defer ctx.PopEnv()
+	}
+
 	for {
+		if errVal := e.checkInterrupt(node); errVal != nil {
+			return errVal
+		}
+		if node.InlineVar != nil {
+			if initResult := e.Eval(node.InlineVar, ctx); isError(initResult) {
+				return initResult
+			}
+			if ctx.Exception() != nil {
+				break
+			}
+		}
+
 		// Evaluate the condition
 		condition := e.Eval(node.Condition, ctx)
 		if isError(condition) {
@@ -596,7 +692,7 @@ func (e *Evaluator) VisitWhileStatement(node *ast.WhileStatement, ctx *Execution
 		}
 
 		// Check if condition is true
-		if !IsTruthy(condition) {
+		if !e.conditionHolds(condition, node.InlineVar != nil) {
 			break
 		}
 
@@ -631,11 +727,28 @@ func (e *Evaluator) VisitWhileStatement(node *ast.WhileStatement, ctx *Execution
 	return result
 }
 
+// conditionHolds reports whether the condition of an if or while statement
+// is true. The inline variable that an "if var" or "while var" condition
+// names may also be an object reference, which holds when it is assigned.
+func (e *Evaluator) conditionHolds(condition Value, inlineVar bool) bool {
+	if !inlineVar {
+		return IsTruthy(condition)
+	}
+	switch condition.(type) {
+	case *runtime.BooleanValue, *runtime.VariantValue:
+		return IsTruthy(condition)
+	}
+	return e.IsAssigned(condition)
+}
+
 // VisitRepeatStatement evaluates a repeat-until loop statement.
 func (e *Evaluator) VisitRepeatStatement(node *ast.RepeatStatement, ctx *ExecutionContext) Value {
 	var result Value
 
 	for {
+		if errVal := e.checkInterrupt(node); errVal != nil {
+			return errVal
+		}
 		// Execute the body first (repeat-until always executes at least once)
 		result = e.Eval(node.Body, ctx)
 		if isError(result) {
@@ -738,7 +851,7 @@ func (e *Evaluator) VisitForStatement(node *ast.ForStatement, ctx *ExecutionCont
 			}
 			ctx.Env().Define(loopVarName, currentVal)
 
-			result = e.Eval(node.Body, ctx)
+			result = e.evalLoopBody(node.Body, loopVarName, currentVal, ctx)
 			if isError(result) {
 				return result
 			}
@@ -767,7 +880,7 @@ func (e *Evaluator) VisitForStatement(node *ast.ForStatement, ctx *ExecutionCont
 			}
 			ctx.Env().Define(loopVarName, currentVal)
 
-			result = e.Eval(node.Body, ctx)
+			result = e.evalLoopBody(node.Body, loopVarName, currentVal, ctx)
 			if isError(result) {
 				return result
 			}
@@ -793,6 +906,47 @@ func (e *Evaluator) VisitForStatement(node *ast.ForStatement, ctx *ExecutionCont
 	return result
 }
 
+// evalLoopBody evaluates one iteration of a for or for-in loop body. When the
+// body creates lambdas, the iteration runs in its own environment holding a
+// snapshot of the loop variable, so each closure captures the value of its
+// iteration rather than the variable, which only holds the last value once
+// the loop is done. Other bodies run directly in the loop's environment.
+func (e *Evaluator) evalLoopBody(body ast.Statement, loopVarName string, loopValue Value, ctx *ExecutionContext) Value {
+	if errVal := e.checkInterrupt(body); errVal != nil {
+		return errVal
+	}
+	if !e.loopBodyCaptures(body) {
+		return e.Eval(body, ctx)
+	}
+
+	ctx.PushEnv()
+	defer ctx.PopEnv()
+	ctx.Env().Define(loopVarName, loopValue)
+	return e.Eval(body, ctx)
+}
+
+// loopBodyCaptures reports whether a loop body contains a lambda expression.
+// The answer is cached per body.
+func (e *Evaluator) loopBodyCaptures(body ast.Statement) bool {
+	if captures, ok := e.loopCaptures[body]; ok {
+		return captures
+	}
+
+	captures := false
+	ast.Inspect(body, func(node ast.Node) bool {
+		if _, ok := node.(*ast.LambdaExpression); ok {
+			captures = true
+		}
+		return !captures
+	})
+
+	if e.loopCaptures == nil {
+		e.loopCaptures = make(map[ast.Statement]bool)
+	}
+	e.loopCaptures[body] = captures
+	return captures
+}
+
 // VisitForInStatement evaluates a for-in loop statement.
 // Iterates over arrays, sets, strings, and enum types.
 func (e *Evaluator) VisitForInStatement(node *ast.ForInStatement, ctx *ExecutionContext) Value {
@@ -845,7 +999,7 @@ func (e *Evaluator) VisitForInStatement(node *ast.ForInStatement, ctx *Execution
 			ctx.Env().Define(loopVarName, loopValue)
 		}
 
-		result = e.Eval(node.Body, ctx)
+		result = e.evalLoopBody(node.Body, loopVarName, loopValue, ctx)
 		if isError(result) {
 			return true, result
 		}
@@ -868,11 +1022,41 @@ func (e *Evaluator) VisitForInStatement(node *ast.ForInStatement, ctx *Execution
 		return false, result
 	}
 
-	switch col := collectionVal.(type) {
+	switch col := unwrapVariant(collectionVal).(type) {
+	case *runtime.JSONValue:
+		// JSON objects enumerate their keys in insertion order, JSON arrays
+		// their elements; scalars have nothing to enumerate.
+		var items []Value
+		switch col.Value.Kind() {
+		case jsonvalue.KindObject:
+			for _, key := range col.Value.ObjectKeys() {
+				items = append(items, &runtime.StringValue{Value: key})
+			}
+		case jsonvalue.KindArray:
+			for idx := 0; idx < col.Value.ArrayLen(); idx++ {
+				items = append(items, runtime.BoxVariantWithJSON(col.Value.ArrayGet(idx)))
+			}
+		}
+		for idx := 0; idx < len(items); idx += stepOrdinal {
+			stop, val := runBody(items[idx])
+			if isError(val) {
+				return val
+			}
+			if stop {
+				break
+			}
+		}
+
 	case *runtime.ArrayValue:
-		// Iterate over array elements
+		// Iterate over array elements. Elements are stored from the low bound
+		// up, so static arrays are visited in declared index order; unassigned
+		// static elements yield the element type's zero value, as a[i] does.
 		for idx := 0; idx < len(col.Elements); idx += stepOrdinal {
-			stop, val := runBody(runtime.CopyValue(col.Elements[idx]))
+			elem := col.Elements[idx]
+			if elem == nil && col.ArrayType != nil {
+				elem = e.getZeroValueForType(col.ArrayType.ElementType)
+			}
+			stop, val := runBody(runtime.CopyValue(elem))
 			if isError(val) {
 				return val
 			}
@@ -889,36 +1073,48 @@ func (e *Evaluator) VisitForInStatement(node *ast.ForInStatement, ctx *Execution
 		elementType := col.SetType.ElementType
 
 		if enumType, ok := elementType.(*types.EnumType); ok {
-			stepIndex := 0
-			for _, name := range enumType.OrderedNames {
-				ordinal := enumType.Values[name]
-				// Check if this enum value is in the set
-				if col.HasElement(ordinal) {
-					if stepIndex%stepOrdinal != 0 {
-						stepIndex++
-						continue
-					}
-					// Create an enum value for this element
-					enumVal := &runtime.EnumValue{
-						TypeName:     enumType.Name,
-						ValueName:    name,
-						OrdinalValue: ordinal,
-					}
+			// Members are visited in ordinal order; this also covers ordinals
+			// between declared values (e.g. Include(s, TRange(5))).
+			ordinals := col.Ordinals()
+			for idx := 0; idx < len(ordinals); idx += stepOrdinal {
+				enumVal := &runtime.EnumValue{
+					TypeName:     enumType.Name,
+					ValueName:    enumType.GetEnumName(ordinals[idx]),
+					OrdinalValue: ordinals[idx],
+				}
 
-					stop, val := runBody(enumVal)
-					if isError(val) {
-						return val
-					}
-					stepIndex++
-					if stop {
-						break
-					}
+				stop, val := runBody(enumVal)
+				if isError(val) {
+					return val
+				}
+				if stop {
+					break
 				}
 			}
 		} else {
-			// For non-enum sets (Integer, String, Boolean), iterate over ordinal values
-			// This is less common but supported for completeness
-			return e.newError(node, "iteration over non-enum sets not yet implemented")
+			// For non-enum sets (Integer, subrange, Char, Boolean), iterate over
+			// the member ordinals in ascending order.
+			baseType := types.GetUnderlyingType(setElementBaseType(elementType))
+			ordinals := col.Ordinals()
+			for idx := 0; idx < len(ordinals); idx += stepOrdinal {
+				var loopValue Value
+				switch baseType.(type) {
+				case *types.StringType:
+					loopValue = &runtime.StringValue{Value: string(rune(ordinals[idx]))}
+				case *types.BooleanType:
+					loopValue = &runtime.BooleanValue{Value: ordinals[idx] != 0}
+				default:
+					loopValue = &runtime.IntegerValue{Value: int64(ordinals[idx])}
+				}
+
+				stop, val := runBody(loopValue)
+				if isError(val) {
+					return val
+				}
+				if stop {
+					break
+				}
+			}
 		}
 
 	case *runtime.StringValue:
@@ -970,6 +1166,8 @@ func (e *Evaluator) VisitForInStatement(node *ast.ForInStatement, ctx *Execution
 }
 
 // VisitCaseStatement evaluates a case statement (switch).
+// The selector is evaluated once; large string cases dispatch through a
+// jump table (see caseJumpTableFor).
 func (e *Evaluator) VisitCaseStatement(node *ast.CaseStatement, ctx *ExecutionContext) Value {
 	// Evaluate the case expression
 	caseValue := e.Eval(node.Expression, ctx)
@@ -977,42 +1175,12 @@ func (e *Evaluator) VisitCaseStatement(node *ast.CaseStatement, ctx *ExecutionCo
 		return caseValue
 	}
 
-	// Check each case branch in order
-	for _, branch := range node.Cases {
-		// Check each value in this branch
-		for _, branchVal := range branch.Values {
-			// Check if this is a range expression
-			if rangeExpr, isRange := branchVal.(*ast.RangeExpression); isRange {
-				// Evaluate start and end of range
-				startValue := e.Eval(rangeExpr.Start, ctx)
-				if isError(startValue) {
-					return startValue
-				}
-
-				endValue := e.Eval(rangeExpr.RangeEnd, ctx)
-				if isError(endValue) {
-					return endValue
-				}
-
-				// Check if caseValue is within range [start, end]
-				if IsInRange(caseValue, startValue, endValue) {
-					// Execute this branch's statement
-					return e.Eval(branch.Statement, ctx)
-				}
-			} else {
-				// Regular value comparison
-				branchValue := e.Eval(branchVal, ctx)
-				if isError(branchValue) {
-					return branchValue
-				}
-
-				// Check if values match
-				if ValuesEqual(caseValue, branchValue) {
-					// Execute this branch's statement
-					return e.Eval(branch.Statement, ctx)
-				}
-			}
-		}
+	index, errVal := e.matchCaseBranch(node, caseValue, ctx)
+	if errVal != nil {
+		return errVal
+	}
+	if index >= 0 {
+		return e.Eval(node.Cases[index].Statement, ctx)
 	}
 
 	// No branch matched - execute else clause if present
@@ -1055,18 +1223,19 @@ func (e *Evaluator) VisitTryStatement(node *ast.TryStatement, ctx *ExecutionCont
 
 			e.Eval(node.FinallyClause.Block, ctx)
 
-			// If finally raised a new exception, keep it (replaces original)
-			// If finally completed normally, restore the original exception
+			// If finally completed normally, restore the original exception and
+			// re-arm the suspended control-flow signal, unless the finally
+			// block itself raised a new one (which takes precedence).
+			//
+			// If finally raised a new exception, it replaces the in-flight
+			// exception (as in Delphi) and also cancels any suspended
+			// Exit/Break/Continue: the new exception unwinds instead, so a
+			// handler further out resumes normally rather than exiting.
 			if ctx.Exception() == nil {
-				// Finally completed normally, restore original exception
 				ctx.SetException(savedExc)
-			}
-			// else: finally raised an exception, keep it (it replaces the original)
-
-			// Re-arm the suspended control-flow signal unless the finally
-			// block itself raised a new one (which takes precedence).
-			if !ctx.ControlFlow().IsActive() {
-				ctx.ControlFlow().Restore(savedFlow)
+				if !ctx.ControlFlow().IsActive() {
+					ctx.ControlFlow().Restore(savedFlow)
+				}
 			}
 
 			// Restore ExceptObject
@@ -1080,6 +1249,9 @@ func (e *Evaluator) VisitTryStatement(node *ast.TryStatement, ctx *ExecutionCont
 	// Runtime errors (ErrorValue) raised inside the try block are catchable in
 	// DWScript: convert them into a script exception so except handlers see them.
 	if isError(tryResult) && ctx.Exception() == nil {
+		if e.interrupted() {
+			return tryResult
+		}
 		e.raiseErrorValueAsException(tryResult, currentRoutineName(ctx), ctx)
 	}
 
@@ -143,8 +143,10 @@ func (i *Interpreter) registerBuiltinExceptions() {
 		"EConvertError",
 		"ERangeError",
 		"EDivByZero",
+		"EIntOverflow",
 		"EAssertionFailed",
 		"EInvalidOp",
+		"EInvalidCast",
 		"EScriptStackOverflow",
 		"EDelphi", // For Format() and other Delphi-compatible runtime errors
 	}
@@ -193,6 +195,17 @@ func (i *Interpreter) registerBuiltinExceptions() {
 
 	// Use lowercase key for O(1) case-insensitive lookup
 	i.typeSystem.RegisterClassWithParent("EHost", eHostClass, "Exception")
+
+	// Register EExternal, raised for errors returned by external Go functions.
+	eExternalClass := NewClassInfo("EExternal")
+	eExternalClass.Parent = eHostClass
+	eExternalClass.IsAbstractFlag = false
+	eExternalClass.IsExternalFlag = false
+	eExternalClass.Metadata.Parent = eHostClass.Metadata
+	eExternalClass.Metadata.ParentName = "EHost"
+	eExternalClass.Constructors["Create"] = nil
+
+	i.typeSystem.RegisterClassWithParent("EExternal", eExternalClass, "EHost")
 }
 
 // raiseMaxRecursionExceeded raises an EScriptStackOverflow exception when the
@@ -814,6 +814,164 @@ func TestTryFinallyWithReturn(t *testing.T) {
 	}
 }
 
+// TestTryFinallyControlFlow tests that finally blocks run when break, continue
+// or exit leave a try block, and that an exception raised in a finally block
+// replaces both the in-flight exception and any pending control-flow signal.
+func TestTryFinallyControlFlow(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{
+			name: "break runs finally",
+			input: `
+				var i: Integer;
+				for i := 1 to 3 do begin
+					try
+						if i = 2 then break;
+						PrintLn('body ' + IntToStr(i));
+					finally
+						PrintLn('finally ' + IntToStr(i));
+					end;
+				end;
+				PrintLn('after');
+			`,
+			expected: "body 1\nfinally 1\nfinally 2\nafter\n",
+		},
+		{
+			name: "continue runs nested finally blocks",
+			input: `
+				var i: Integer;
+				for i := 1 to 2 do begin
+					try
+						try
+							if i = 1 then continue;
+							PrintLn('body ' + IntToStr(i));
+						finally
+							PrintLn('inner ' + IntToStr(i));
+						end;
+					finally
+						PrintLn('outer ' + IntToStr(i));
+					end;
+				end;
+			`,
+			expected: "inner 1\nouter 1\nbody 2\ninner 2\nouter 2\n",
+		},
+		{
+			name: "exit inside except inside finally",
+			input: `
+				procedure P;
+				begin
+					try
+						try
+							raise Exception.Create('boom');
+						except
+							on E: Exception do begin
+								PrintLn('except ' + E.Message);
+								exit;
+							end;
+						end;
+						PrintLn('not reached');
+					finally
+						PrintLn('finally');
+					end;
+					PrintLn('not reached');
+				end;
+				P;
+				PrintLn('done');
+			`,
+			expected: "except boom\nfinally\ndone\n",
+		},
+		{
+			name: "exception in finally replaces in-flight exception",
+			input: `
+				try
+					try
+						raise Exception.Create('first');
+					finally
+						raise Exception.Create('second');
+					end;
+				except
+					on E: Exception do PrintLn(E.Message);
+				end;
+			`,
+			expected: "second\n",
+		},
+		{
+			name: "exception handled inside finally keeps in-flight exception",
+			input: `
+				try
+					try
+						raise Exception.Create('first');
+					finally
+						try
+							raise Exception.Create('inner');
+						except
+							on E: Exception do PrintLn('handled ' + E.Message);
+						end;
+					end;
+				except
+					on E: Exception do PrintLn('outer ' + E.Message);
+				end;
+			`,
+			expected: "handled inner\nouter first\n",
+		},
+		{
+			name: "exception in finally cancels pending exit",
+			input: `
+				function F: Integer;
+				begin
+					Result := 0;
+					try
+						try
+							exit;
+						finally
+							raise Exception.Create('fin');
+						end;
+					except
+						on E: Exception do PrintLn('caught ' + E.Message);
+					end;
+					PrintLn('continues');
+					Result := 5;
+				end;
+				PrintLn(F);
+			`,
+			expected: "caught fin\ncontinues\n5\n",
+		},
+		{
+			name: "exception in finally cancels pending break",
+			input: `
+				var i, j: Integer;
+				for j := 1 to 2 do begin
+					try
+						for i := 1 to 3 do begin
+							try
+								break;
+							finally
+								raise Exception.Create('fin');
+							end;
+						end;
+						PrintLn('not reached');
+					except
+						on E: Exception do PrintLn('caught ' + IntToStr(j));
+					end;
+				end;
+			`,
+			expected: "caught 1\ncaught 2\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, output := testEvalWithOutput(tt.input)
+			if output != tt.expected {
+				t.Errorf("expected output %q, got %q", tt.expected, output)
+			}
+		})
+	}
+}
+
 // TestRaiseCustomException tests raising a custom exception class
 // Note: This test verifies that custom exception classes properly inherit from Exception
 func TestRaiseCustomException(t *testing.T) {
@@ -5,48 +5,64 @@ import (
 	"sync"
 
 	"github.com/cwbudde/go-dws/internal/interp/contracts"
+	"github.com/cwbudde/go-dws/pkg/ident"
 )
 
 // ExternalFunctionRegistry stores external Go functions registered for DWScript.
-// It provides thread-safe registration and lookup of external functions.
+// It provides thread-safe, case-insensitive registration and lookup of external functions.
 type ExternalFunctionRegistry struct {
-	functions map[string]*ExternalFunctionValue
+	functions *ident.Map[*ExternalFunctionValue]
 	mu        sync.RWMutex
 }
 
 // NewExternalFunctionRegistry creates a new empty registry.
 func NewExternalFunctionRegistry() *ExternalFunctionRegistry {
 	return &ExternalFunctionRegistry{
-		functions: make(map[string]*ExternalFunctionValue),
+		functions: ident.NewMap[*ExternalFunctionValue](),
 	}
 }
 
 // Register adds an external function to the registry.
 // Returns an error if a function with the same name is already registered.
 func (r *ExternalFunctionRegistry) Register(name string, wrapper ExternalFunctionWrapper) error {
+	return r.RegisterWithExceptionClass(name, wrapper, "")
+}
+
+// RegisterWithExceptionClass adds an external function whose returned errors are
+// raised as the named exception class. An empty class name selects EExternal.
+func (r *ExternalFunctionRegistry) RegisterWithExceptionClass(name string, wrapper ExternalFunctionWrapper, exceptionClass string) error {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
-	if _, exists := r.functions[name]; exists {
+	if r.functions.Has(name) {
 		return fmt.Errorf("function %s is already registered", name)
 	}
 
-	r.functions[name] = &ExternalFunctionValue{
-		Name:    name,
-		Wrapper: wrapper,
-	}
+	r.functions.Set(name, &ExternalFunctionValue{
+		Name:           name,
+		Wrapper:        wrapper,
+		ExceptionClass: exceptionClass,
+	})
 
 	return nil
 }
 
+// Unregister removes an external function from the registry.
+// Returns true if the function was registered.
+func (r *ExternalFunctionRegistry) Unregister(name string) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.functions.Delete(name)
+}
+
 // Get retrieves an external function by name.
 // Returns the function and true if found, nil and false otherwise.
 func (r *ExternalFunctionRegistry) Get(name string) (*ExternalFunctionValue, bool) {
 	r.mu.RLock()
 	defer r.mu.RUnlock()
 
-	fn, exists := r.functions[name]
-	return fn, exists
+	return r.functions.Get(name)
 }
 
 // Has checks if an external function with the given name is registered.
@@ -54,8 +70,7 @@ func (r *ExternalFunctionRegistry) Has(name string) bool {
 	r.mu.RLock()
 	defer r.mu.RUnlock()
 
-	_, exists := r.functions[name]
-	return exists
+	return r.functions.Has(name)
 }
 
 // Signature returns metadata needed to prepare arguments for an external function.
@@ -63,7 +78,7 @@ func (r *ExternalFunctionRegistry) Signature(name string) (contracts.ExternalFun
 	r.mu.RLock()
 	defer r.mu.RUnlock()
 
-	fn, exists := r.functions[name]
+	fn, exists := r.functions.Get(name)
 	if !exists || fn.Wrapper == nil {
 		return contracts.ExternalFunctionSignature{}, false
 	}
@@ -79,11 +94,7 @@ func (r *ExternalFunctionRegistry) List() []string {
 	r.mu.RLock()
 	defer r.mu.RUnlock()
 
-	names := make([]string, 0, len(r.functions))
-	for name := range r.functions {
-		names = append(names, name)
-	}
-	return names
+	return r.functions.Keys()
 }
 
 // ExternalFunctionWrapper is an interface for wrapping Go functions.
@@ -108,6 +119,9 @@ type ExternalFunctionWrapper interface {
 type ExternalFunctionValue struct {
 	Wrapper ExternalFunctionWrapper
 	Name    string
+	// ExceptionClass names the class raised for errors returned by the
+	// function; empty means EExternal.
+	ExceptionClass string
 }
 
 // Type implements Value.Type
@@ -1,8 +1,11 @@
 package interp
 
 import (
+	"errors"
 	"fmt"
 	"reflect"
+
+	"github.com/cwbudde/go-dws/internal/interp/runtime"
 )
 
 // callDWScriptFunction invokes a DWScript function from Go context.
@@ -28,7 +31,7 @@ import (
 func (i *Interpreter) callDWScriptFunction(
 	funcPtr *FunctionPointerValue,
 	goArgs []any,
-) (any, error) {
+) (Value, error) {
 	// 1. Marshal Go arguments to DWScript values
 	dwsArgs := make([]Value, len(goArgs))
 	for idx, arg := range goArgs {
@@ -69,26 +72,31 @@ func (i *Interpreter) callDWScriptFunction(
 	}
 
 	// 4. Check for exceptions
-	// If the DWScript callback raised an exception, convert it to a Go error
+	// If the DWScript callback raised an exception, hand it to Go as an error.
+	// The exception is cleared so Go code can decide how to handle it.
 	if exc := i.exceptionValue(); exc != nil {
-		exceptionMsg := exc.Message
-		// Use Metadata instead of ClassInfo (which is now any)
-		exceptionClass := "Exception"
-		if exc.Metadata != nil {
-			exceptionClass = exc.Metadata.Name
-		}
-
-		// Clear exception for Go context
-		// The exception has been converted to an error and will be returned
 		i.clearException()
-
-		// Convert to Go error
-		return nil, fmt.Errorf("DWScript callback exception [%s]: %s", exceptionClass, exceptionMsg)
+		return nil, &callbackException{exc: exc}
 	}
 
-	// 5. Marshal return value to Go
-	// The result is a DWScript Value; we need to convert it to a Go value
-	return marshalValueToGo(result)
+	return result, nil
+}
+
+// callbackException is the error a Go callback wrapper reports when the
+// DWScript function it calls raises an exception. It carries the original
+// exception so it can be re-raised when a wrapper without an error result
+// panics with it out of the host function.
+type callbackException struct {
+	exc *runtime.ExceptionValue
+}
+
+// Error implements the error interface.
+func (e *callbackException) Error() string {
+	exceptionClass := "Exception"
+	if e.exc.Metadata != nil {
+		exceptionClass = e.exc.Metadata.Name
+	}
+	return fmt.Sprintf("DWScript callback exception [%s]: %s", exceptionClass, e.exc.Message)
 }
 
 // callDWScriptFunctionSafe is a wrapper around callDWScriptFunction with panic recovery.
@@ -98,7 +106,7 @@ func (i *Interpreter) callDWScriptFunction(
 func (i *Interpreter) callDWScriptFunctionSafe(
 	funcPtr *FunctionPointerValue,
 	goArgs []any,
-) (result any, err error) {
+) (result Value, err error) {
 	defer func() {
 		if r := recover(); r != nil {
 			err = fmt.Errorf("panic in DWScript callback: %v", r)
@@ -108,62 +116,6 @@ func (i *Interpreter) callDWScriptFunctionSafe(
 	return i.callDWScriptFunction(funcPtr, goArgs)
 }
 
-// marshalValueToGo converts a DWScript Value to a Go any value.
-// This is used for callback return values.
-//
-// Unlike MarshalToGo which requires a target type, this function
-// infers the Go type from the DWScript value type.
-func marshalValueToGo(val Value) (any, error) {
-	switch val.Type() {
-	case "INTEGER":
-		return GoInt(val)
-	case "FLOAT":
-		return GoFloat(val)
-	case "STRING":
-		return GoString(val)
-	case "BOOLEAN":
-		return GoBool(val)
-	case "NIL":
-		return nil, nil
-	case "ARRAY":
-		// Convert array to []any
-		arrayVal, ok := val.(*ArrayValue)
-		if !ok {
-			return nil, fmt.Errorf("expected ArrayValue, got %T", val)
-		}
-
-		result := make([]any, len(arrayVal.Elements))
-		for i, elem := range arrayVal.Elements {
-			goElem, err := marshalValueToGo(elem)
-			if err != nil {
-				return nil, fmt.Errorf("array element %d: %w", i, err)
-			}
-			result[i] = goElem
-		}
-		return result, nil
-
-	case "RECORD":
-		// Convert record to map[string]any
-		recordVal, ok := val.(*RecordValue)
-		if !ok {
-			return nil, fmt.Errorf("expected RecordValue, got %T", val)
-		}
-
-		result := make(map[string]any)
-		for key, fieldVal := range recordVal.Fields {
-			goField, err := marshalValueToGo(fieldVal)
-			if err != nil {
-				return nil, fmt.Errorf("record field %s: %w", key, err)
-			}
-			result[key] = goField
-		}
-		return result, nil
-
-	default:
-		return nil, fmt.Errorf("unsupported DWScript type for callback return: %s", val.Type())
-	}
-}
-
 // createGoFunctionWrapper creates a Go function that calls back into DWScript.
 //
 // This function uses reflection to create a Go function value with the correct
@@ -194,6 +146,10 @@ func createGoFunctionWrapper(
 		panic(fmt.Sprintf("createGoFunctionWrapper: targetType must be a function, got %s", targetType.Kind()))
 	}
 
+	numOut := targetType.NumOut()
+	errorType := reflect.TypeOf((*error)(nil)).Elem()
+	hasErrorReturn := numOut > 0 && targetType.Out(numOut-1) == errorType
+
 	// Use reflection to create a function with the correct signature
 	fn := reflect.MakeFunc(targetType, func(args []reflect.Value) []reflect.Value {
 		// Convert reflect.Value arguments to []any for marshaling
@@ -202,62 +158,79 @@ func createGoFunctionWrapper(
 			goArgs[i] = arg.Interface()
 		}
 
-		// Call DWScript function with panic recovery
+		// Call DWScript function with panic recovery, then convert the result
+		// to the declared Go return type.
+		var out reflect.Value
 		result, err := interp.callDWScriptFunctionSafe(funcPtr, goArgs)
+		if err == nil && (numOut == 2 || (numOut == 1 && !hasErrorReturn)) {
+			out, err = callbackResult(result, targetType.Out(0), interp)
+		}
 
 		// Handle error according to Go function signature
 		if err != nil {
-			// Check if function returns error as last return value
-			numOut := targetType.NumOut()
-			if numOut >= 2 {
-				lastOut := targetType.Out(numOut - 1)
-				errorType := reflect.TypeOf((*error)(nil)).Elem()
-				if lastOut.Implements(errorType) {
-					// Function signature includes error return: (T, error) or just (error)
-					if numOut == 2 {
-						// (T, error) - return zero value and error
-						return []reflect.Value{
-							reflect.Zero(targetType.Out(0)),
-							reflect.ValueOf(err),
-						}
-					} else {
-						// Just (error)
-						return []reflect.Value{reflect.ValueOf(err)}
-					}
+			if !hasErrorReturn {
+				// No error return in signature - panic (Go convention). The
+				// FFI call boundary turns the panic back into an exception.
+				var cbErr *callbackException
+				if errors.As(err, &cbErr) {
+					panic(cbErr)
 				}
+				panic(fmt.Sprintf("callback error: %v", err))
 			}
-
-			// No error return in signature - panic (Go convention)
-			panic(fmt.Sprintf("callback error: %v", err))
+			if numOut == 2 {
+				// (T, error) - return zero value and error
+				return []reflect.Value{reflect.Zero(targetType.Out(0)), reflect.ValueOf(err)}
+			}
+			// Just (error)
+			return []reflect.Value{reflect.ValueOf(err)}
 		}
 
-		// Marshal result(s) based on function signature
-		numOut := targetType.NumOut()
-
-		if numOut == 0 {
+		switch {
+		case numOut == 0:
 			// Procedure (no return value)
 			return []reflect.Value{}
-		}
-
-		// Check if last return is error type
-		hasErrorReturn := false
-		if numOut >= 2 {
-			lastOut := targetType.Out(numOut - 1)
-			errorType := reflect.TypeOf((*error)(nil)).Elem()
-			hasErrorReturn = lastOut.Implements(errorType)
-		}
-
-		if hasErrorReturn {
+		case numOut == 1 && hasErrorReturn:
+			return []reflect.Value{reflect.Zero(errorType)}
+		case numOut == 2:
 			// Function returns (T, error)
-			return []reflect.Value{
-				reflect.ValueOf(result),
-				reflect.Zero(targetType.Out(1)), // nil error
-			}
+			return []reflect.Value{out, reflect.Zero(errorType)}
+		default:
+			// Function returns single value
+			return []reflect.Value{out}
 		}
-
-		// Function returns single value
-		return []reflect.Value{reflect.ValueOf(result)}
 	})
 
 	return fn.Interface()
 }
+
+// callbackResult converts the value returned by a DWScript callback to the
+// Go return type of the wrapper. Interface types receive the natural Go
+// representation of the value (see runtime.ToGo).
+func callbackResult(result Value, outType reflect.Type, interp *Interpreter) (reflect.Value, error) {
+	if result == nil {
+		return reflect.Zero(outType), nil
+	}
+
+	var goVal any
+	var err error
+	if outType.Kind() == reflect.Interface {
+		goVal, err = runtime.ToGo(result)
+	} else {
+		goVal, err = MarshalToGo(result, outType, interp)
+	}
+	if err != nil {
+		return reflect.Value{}, fmt.Errorf("callback result: %w", err)
+	}
+	if goVal == nil {
+		return reflect.Zero(outType), nil
+	}
+
+	rv := reflect.ValueOf(goVal)
+	if rv.Type() != outType {
+		if !rv.Type().ConvertibleTo(outType) {
+			return reflect.Value{}, fmt.Errorf("callback result: cannot use %s as %s", rv.Type(), outType)
+		}
+		rv = rv.Convert(outType)
+	}
+	return rv, nil
+}
@@ -2,14 +2,31 @@ package interp
 
 import (
 	"fmt"
-	goruntime "runtime"
 
 	"github.com/cwbudde/go-dws/internal/interp/runtime"
+	"github.com/cwbudde/go-dws/internal/lexer"
 )
 
+// DefaultExternalExceptionClass is the exception class raised for errors returned
+// by external functions registered without an explicit exception class.
+const DefaultExternalExceptionClass = "EExternal"
+
 // raiseGoErrorAsException converts a Go error returned from host code into a DWScript exception.
 // It builds an EHost instance that captures the original error message and type information.
 func (i *Interpreter) raiseGoErrorAsException(err error) {
+	i.raiseGoError(err, "EHost", "Exception")
+}
+
+// raiseGoErrorAsExceptionClass converts a Go error into an exception of the named class.
+// When the class is not registered or does not descend from Exception, it falls back to
+// EExternal, EHost, and finally Exception.
+func (i *Interpreter) raiseGoErrorAsExceptionClass(err error, className string) {
+	i.raiseGoError(err, className, DefaultExternalExceptionClass, "EHost", "Exception")
+}
+
+// raiseGoError raises err as the first of the candidate classes that is a registered
+// exception class.
+func (i *Interpreter) raiseGoError(err error, candidates ...string) {
 	if err == nil {
 		return
 	}
@@ -20,10 +37,7 @@ func (i *Interpreter) raiseGoErrorAsException(err error) {
 	// Capture current DWScript call stack for diagnostics.
 	callStack := i.callStackTrace()
 
-	hostClass := i.lookupRegisteredClassInfo("EHost")
-	if hostClass == nil {
-		hostClass = i.lookupRegisteredClassInfo("Exception")
-	}
+	hostClass := i.lookupExceptionClass(candidates...)
 	if hostClass == nil {
 		// As a last resort, leave exception unset.
 		return
@@ -34,7 +48,7 @@ func (i *Interpreter) raiseGoErrorAsException(err error) {
 	// Ensure Message field is populated.
 	instance.SetField("Message", &StringValue{Value: message})
 
-	// Populate ExceptionClass when supported (only defined for EHost).
+	// Populate ExceptionClass when supported (only defined for EHost and its descendants).
 	if hostClass.InheritsFrom("EHost") {
 		instance.SetField("ExceptionClass", &StringValue{Value: goType})
 	}
@@ -50,19 +64,36 @@ func (i *Interpreter) raiseGoErrorAsException(err error) {
 	})
 }
 
+// lookupExceptionClass returns the first of the named classes that is registered
+// and descends from Exception, or nil if there is none.
+func (i *Interpreter) lookupExceptionClass(names ...string) *ClassInfo {
+	for _, name := range names {
+		if name == "" {
+			continue
+		}
+		if class := i.lookupRegisteredClassInfo(name); class != nil && class.InheritsFrom("Exception") {
+			return class
+		}
+	}
+	return nil
+}
+
 // handleExternalCallResult provides a shared path for external call wrappers to marshal Go errors.
-// It returns the result when no error occurred; otherwise it raises an EHost exception and returns nil.
-func (i *Interpreter) handleExternalCallResult(result Value, err error) Value {
+// It returns the result when no error occurred; otherwise it raises an exception of the named
+// class (EExternal when empty) and returns nil.
+func (i *Interpreter) handleExternalCallResult(result Value, err error, className string) Value {
 	if err == nil {
 		return result
 	}
 
-	i.raiseGoErrorAsException(err)
+	i.raiseGoErrorAsExceptionClass(err, className)
 	return &NilValue{}
 }
 
-// raiseGoPanicAsException converts a recovered panic value into an EHost exception.
-// It captures the panic message, original type, DWScript call stack, and optional Go stack trace.
+// raiseGoPanicAsException converts a recovered panic value into an EExternal exception.
+// It captures the panic message, original type, DWScript call stack and call-site position.
+// When EExternal is not registered it falls back to EHost
+// and finally Exception.
 func (i *Interpreter) raiseGoPanicAsException(panicValue interface{}) {
 	typeName := "nil"
 	var message string
@@ -89,19 +120,10 @@ func (i *Interpreter) raiseGoPanicAsException(panicValue interface{}) {
 	}
 	message = "panic: " + message
 
-	// Optionally append Go stack trace for debugging.
-	stackBuf := make([]byte, 2048)
-	if n := goruntime.Stack(stackBuf, false); n > 0 {
-		message = message + "\n" + string(stackBuf[:n])
-	}
-
 	// Capture current DWScript call stack.
 	callStack := i.callStackTrace()
 
-	hostClass := i.lookupRegisteredClassInfo("EHost")
-	if hostClass == nil {
-		hostClass = i.lookupRegisteredClassInfo("Exception")
-	}
+	hostClass := i.lookupExceptionClass(DefaultExternalExceptionClass, "EHost", "Exception")
 	if hostClass == nil {
 		return
 	}
@@ -113,28 +135,54 @@ func (i *Interpreter) raiseGoPanicAsException(panicValue interface{}) {
 		instance.SetField("ExceptionClass", &StringValue{Value: typeName})
 	}
 
-	// Position is nil for FFI errors (they don't originate from DWScript source)
 	i.setExceptionValue(&runtime.ExceptionValue{
 		Metadata:  hostClass.Metadata,
 		ClassInfo: hostClass,
 		Instance:  instance,
 		Message:   message,
-		Position:  nil,
+		Position:  i.externalCallPosition(),
 		CallStack: callStack,
 	})
 }
 
+// externalCallPosition returns the source position of the call expression that
+// invoked the external function, or nil when it is not known.
+func (i *Interpreter) externalCallPosition() *lexer.Position {
+	if i.evaluatorInstance == nil {
+		return nil
+	}
+	node := i.evaluatorInstance.CurrentNode()
+	if node == nil {
+		return nil
+	}
+	pos := node.Pos()
+	return &pos
+}
+
 // callExternalFunctionSafe executes a host function capturing panics and converting them into exceptions.
 // The supplied callback should perform marshaling, invoke the Go function, and return the DWScript value plus error.
-func (i *Interpreter) callExternalFunctionSafe(call func() (Value, error)) (result Value) {
+// A returned error is raised as EExternal.
+func (i *Interpreter) callExternalFunctionSafe(call func() (Value, error)) Value {
+	return i.callExternalFunctionSafeAs("", call)
+}
+
+// callExternalFunctionSafeAs is callExternalFunctionSafe raising returned errors as the named
+// exception class instead of EExternal.
+func (i *Interpreter) callExternalFunctionSafeAs(className string, call func() (Value, error)) (result Value) {
 	defer func() {
 		if r := recover(); r != nil {
-			i.raiseGoPanicAsException(r)
+			if cbErr, ok := r.(*callbackException); ok {
+				// A DWScript callback raised an exception through Go code
+				// that cannot return errors.
+				i.setExceptionValue(cbErr.exc)
+			} else {
+				i.raiseGoPanicAsException(r)
+			}
 			result = &NilValue{}
 		}
 	}()
 
 	res, err := call()
-	result = i.handleExternalCallResult(res, err)
+	result = i.handleExternalCallResult(res, err, className)
 	return result
 }
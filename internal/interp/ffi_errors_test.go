@@ -22,11 +22,11 @@ func TestCallExternalFunctionSafeRecoversPanicError(t *testing.T) {
 		t.Fatalf("expected exception to be raised")
 	}
 
-	if interp.GetException().Metadata.Name != "EHost" {
-		t.Fatalf("expected exception class EHost, got %s", interp.GetException().Metadata.Name)
+	if interp.GetException().Metadata.Name != "EExternal" {
+		t.Fatalf("expected exception class EExternal, got %s", interp.GetException().Metadata.Name)
 	}
 
-	if !strings.Contains(interp.GetException().Message, "panic: boom") {
+	if interp.GetException().Message != "panic: boom" {
 		t.Fatalf("expected panic message, got %q", interp.GetException().Message)
 	}
 
@@ -60,6 +60,36 @@ func TestCallExternalFunctionSafePropagatesError(t *testing.T) {
 	}
 }
 
+func TestCallExternalFunctionSafeAsRaisesExceptionClass(t *testing.T) {
+	tests := []struct {
+		className string
+		want      string
+	}{
+		{"", "EExternal"},
+		{"EConvertError", "EConvertError"},
+		{"TObject", "EExternal"},
+		{"ENoSuchClass", "EExternal"},
+	}
+
+	for _, tt := range tests {
+		interp := newTestInterpreter()
+		interp.callExternalFunctionSafeAs(tt.className, func() (Value, error) {
+			return &NilValue{}, errors.New("failed")
+		})
+
+		exc := interp.GetException()
+		if exc == nil {
+			t.Fatalf("class %q: expected exception to be raised", tt.className)
+		}
+		if exc.Metadata.Name != tt.want {
+			t.Errorf("class %q: raised %s, want %s", tt.className, exc.Metadata.Name, tt.want)
+		}
+		if exc.Message != "failed" {
+			t.Errorf("class %q: message = %q, want %q", tt.className, exc.Message, "failed")
+		}
+	}
+}
+
 // TestGoErrorToExceptionConversion tests that Go errors are properly converted to DWScript exceptions.
 func TestGoErrorToExceptionConversion(t *testing.T) {
 	t.Run("BasicErrorConversion", func(t *testing.T) {
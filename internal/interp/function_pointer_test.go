@@ -0,0 +1,90 @@
+package interp
+
+import (
+	"testing"
+)
+
+// TestAddressOfOverloadedFunction tests that @ on an overloaded function takes
+// the address of the overload matching the expected function pointer type.
+func TestAddressOfOverloadedFunction(t *testing.T) {
+	input := `
+function Twice(x: Integer): Integer; overload;
+begin
+	Result := x * 2;
+end;
+function Twice(s: String): String; overload;
+begin
+	Result := s + s;
+end;
+type TIntFunc = function(x: Integer): Integer;
+type TStrFunc = function(s: String): String;
+procedure Run(f: TStrFunc);
+begin
+	PrintLn(f('x'));
+end;
+var fi: TIntFunc := @Twice;
+var fs: TStrFunc;
+fs := @Twice;
+PrintLn(fi(21));
+PrintLn(fs('ab'));
+Run(@Twice);
+`
+	result, output := testEvalWithOutputAndSemantic(t, input)
+	if isError(result) {
+		t.Fatalf("unexpected error: %s", result.String())
+	}
+	if want := "42\nabab\nxx\n"; output != want {
+		t.Errorf("output = %q, want %q", output, want)
+	}
+}
+
+// TestFunctionPointerNil tests nil assignment and nil comparisons of function
+// and method pointers, and Assigned() on every reference kind.
+func TestFunctionPointerNil(t *testing.T) {
+	input := `
+function One(x: Integer): Integer; begin Result := x; end;
+function Two(x: Integer): Integer; begin Result := x; end;
+type TIntFunc = function(x: Integer): Integer;
+type TNotify = procedure of object;
+type IFoo = interface procedure Foo; end;
+type TFoo = class(TObject, IFoo) procedure Foo; begin end; end;
+
+var cb: TIntFunc;
+PrintLn(Assigned(cb), ' ', cb = nil, ' ', nil <> cb);
+cb := @One;
+PrintLn(Assigned(cb), ' ', cb = nil, ' ', cb <> nil);
+var other: TIntFunc := @One;
+PrintLn(cb = other, ' ', cb = @Two);
+cb := nil;
+PrintLn(Assigned(cb), ' ', cb = nil);
+
+var m: TNotify;
+PrintLn(Assigned(m), ' ', m = nil);
+var o: TFoo;
+PrintLn(Assigned(o));
+o := TFoo.Create;
+m := o.Foo;
+PrintLn(Assigned(m), ' ', m <> nil, ' ', Assigned(o));
+
+var i: IFoo;
+PrintLn(Assigned(i));
+i := o;
+PrintLn(Assigned(i));
+`
+	result, output := testEvalWithOutputAndSemantic(t, input)
+	if isError(result) {
+		t.Fatalf("unexpected error: %s", result.String())
+	}
+	want := "False True False\n" +
+		"True False True\n" +
+		"True False\n" +
+		"False True\n" +
+		"False True\n" +
+		"False\n" +
+		"True True True\n" +
+		"False\n" +
+		"True\n"
+	if output != want {
+		t.Errorf("output = %q, want %q", output, want)
+	}
+}
@@ -1,7 +1,6 @@
 package interp
 
 import (
-	"github.com/cwbudde/go-dws/internal/builtins"
 	"github.com/cwbudde/go-dws/pkg/ident"
 )
 
@@ -17,7 +16,7 @@ func (i *Interpreter) callBuiltin(name string, args []Value) Value {
 	}
 
 	// Check the built-in function registry (case-insensitive lookup)
-	if fn, ok := builtins.DefaultRegistry.Lookup(name); ok {
+	if fn, ok := i.typeSystem.Functions().LookupBuiltin(name); ok {
 		return fn(i, args)
 	}
 
@@ -71,8 +70,9 @@ func (i *Interpreter) callExternalFunction(extFunc *ExternalFunctionValue, args
 	extFunc.Wrapper.SetInterpreter(i)
 
 	// Use the existing callExternalFunctionSafe wrapper which handles panics
-	// and converts them to EHost exceptions (from ffi_errors.go)
-	return i.callExternalFunctionSafe(func() (Value, error) {
+	// and converts them to EExternal exceptions (from ffi_errors.go). Returned
+	// errors are raised as the function's exception class.
+	return i.callExternalFunctionSafeAs(extFunc.ExceptionClass, func() (Value, error) {
 		// Call the wrapped Go function
 		return extFunc.Wrapper.Call(args)
 	})
@@ -112,7 +112,7 @@ func normalizeBuiltinName(name string) string {
 		"round": "Round", "trunc": "Trunc", "ceil": "Ceil", "floor": "Floor",
 		"low": "Low", "high": "High", "setlength": "SetLength", "add": "Add",
 		"delete": "Delete", "inttostr": "IntToStr", "inttobin": "IntToBin",
-		"strtoint": "StrToInt", "floattostr": "FloatToStr", "booltostr": "BoolToStr",
+		"strtoint": "StrToInt", "floattostr": "FloatToStr", "floattostrf": "FloatToStrF", "booltostr": "BoolToStr",
 		"strtofloat": "StrToFloat", "strtobool": "StrToBool",
 		"strtointdef": "StrToIntDef", "strtofloatdef": "StrToFloatDef",
 		"chr": "Chr", "charat": "CharAt", "bytesizetostr": "ByteSizeToStr",
@@ -148,7 +148,7 @@ func normalizeBuiltinName(name string) string {
 		"varisnumeric": "VarIsNumeric", "vartostr": "VarToStr", "vartoint": "VarToInt",
 		"vartofloat": "VarToFloat", "varastype": "VarAsType", "varclear": "VarClear",
 		"parsejson": "ParseJSON", "tojson": "ToJSON", "tojsonformatted": "ToJSONFormatted",
-		"jsonhasfield": "JSONHasField", "jsonkeys": "JSONKeys", "jsonvalues": "JSONValues",
+		"jsonhasfield": "JSONHasField", "jsonkeys": "JSONKeys", "keys": "Keys", "jsonvalues": "JSONValues",
 		"jsonlength":    "JSONLength",
 		"getstacktrace": "GetStackTrace", "getcallstack": "GetCallStack",
 		"sametext": "SameText", "comparetext": "CompareText", "comparestr": "CompareStr",
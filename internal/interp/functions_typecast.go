@@ -383,7 +383,7 @@ func (i *Interpreter) castToClass(val Value, targetClass *ClassInfo, node ast.No
 		pos := node.Pos()
 		message := fmt.Sprintf("Cannot cast instance of type \"%s\" to class \"%s\" [line: %d, column: %d]",
 			obj.Class.GetName(), targetClass.Name, pos.Line, pos.Column)
-		i.raiseException("Exception", message, &pos)
+		i.raiseException("EInvalidCast", message, &pos)
 		return nil
 	}
 
@@ -0,0 +1,149 @@
+package interp
+
+import (
+	"github.com/cwbudde/go-dws/internal/interp/runtime"
+	interptypes "github.com/cwbudde/go-dws/internal/interp/types"
+	"github.com/cwbudde/go-dws/pkg/ident"
+)
+
+// GlobalState is a copy of the interpreter's global variables and
+// declarations, taken by SaveGlobalState.
+//
+// Mutable values (strings, arrays, records, sets, associative arrays and
+// variants) are deep-copied, so later changes to the variables do not reach
+// the saved state. Objects, interfaces and function pointers are shared:
+// restoring a state restores which object a variable refers to, not the
+// object's fields. Class variables are not saved, and of the declared types
+// only the set of classes is: classes declared after the state was saved
+// are removed when it is restored.
+type GlobalState struct {
+	values    *ident.Map[Value]
+	functions *interptypes.FunctionRegistry
+	classes   map[string]bool
+}
+
+// SaveGlobalState returns a copy of the current global variables,
+// user-defined functions and classes; see GlobalState.
+func (i *Interpreter) SaveGlobalState() *GlobalState {
+	globals := i.globalEnv()
+	copier := newValueCopier()
+	values := ident.NewMapWithCapacity[Value](globals.Size())
+	globals.Range(func(name string, value Value) bool {
+		values.Set(name, copier.copy(value))
+		return true
+	})
+	classes := make(map[string]bool)
+	for name := range i.typeSystem.AllClasses() {
+		classes[name] = true
+	}
+	return &GlobalState{
+		values:    values,
+		functions: i.typeSystem.Functions().Clone(),
+		classes:   classes,
+	}
+}
+
+// RestoreGlobalState makes the global variables, user-defined functions and
+// classes those of a state saved earlier. Variables defined after the state
+// was saved are removed. The state stays unchanged, so it can be restored again.
+func (i *Interpreter) RestoreGlobalState(state *GlobalState) {
+	globals := i.globalEnv()
+	var added []string
+	globals.Range(func(name string, _ Value) bool {
+		if !state.values.Has(name) {
+			added = append(added, name)
+		}
+		return true
+	})
+	for _, name := range added {
+		globals.Delete(name)
+	}
+
+	copier := newValueCopier()
+	state.values.Range(func(name string, value Value) bool {
+		globals.Define(name, copier.copy(value))
+		return true
+	})
+	i.typeSystem.Functions().RestoreFrom(state.functions)
+	for name := range i.typeSystem.AllClasses() {
+		if !state.classes[name] {
+			i.typeSystem.UnregisterClass(name)
+		}
+	}
+	i.SetEnvironment(globals)
+}
+
+// RemoveFunction removes all overloads of the user-defined function name, so
+// that a later declaration of the same name replaces it instead of adding an
+// overload. Returns true if the function was found and removed.
+func (i *Interpreter) RemoveFunction(name string) bool {
+	return i.typeSystem.Functions().RemoveFunction(name)
+}
+
+// globalEnv returns the outermost environment of the current scope chain.
+func (i *Interpreter) globalEnv() *Environment {
+	env := i.Env()
+	for env.Outer() != nil {
+		env = env.Outer()
+	}
+	return env
+}
+
+// valueCopier deep-copies runtime values for GlobalState. Values reachable
+// through several variables are copied once, so the copies share them the
+// same way the originals do.
+type valueCopier struct {
+	copies map[any]Value
+}
+
+func newValueCopier() *valueCopier {
+	return &valueCopier{copies: make(map[any]Value)}
+}
+
+func (c *valueCopier) copy(v Value) Value {
+	switch val := v.(type) {
+	case *runtime.StringValue:
+		return &runtime.StringValue{Value: val.Value}
+	case *runtime.SetValue:
+		return val.Copy()
+	case *runtime.VariantValue:
+		return &runtime.VariantValue{Value: c.copy(val.Value), ActualType: val.ActualType}
+	case *runtime.ArrayValue:
+		if copied, ok := c.copies[val]; ok {
+			return copied
+		}
+		copied := &runtime.ArrayValue{ArrayType: val.ArrayType, Elements: make([]Value, len(val.Elements))}
+		c.copies[val] = copied
+		for idx, elem := range val.Elements {
+			copied.Elements[idx] = c.copy(elem)
+		}
+		return copied
+	case *runtime.RecordValue:
+		if copied, ok := c.copies[val]; ok {
+			return copied
+		}
+		copied := &runtime.RecordValue{
+			RecordType: val.RecordType,
+			Fields:     make(map[string]Value, len(val.Fields)),
+			Metadata:   val.Metadata,
+		}
+		c.copies[val] = copied
+		for name, field := range val.Fields {
+			copied.Fields[name] = c.copy(field)
+		}
+		return copied
+	case *runtime.AssociativeArrayValue:
+		if copied, ok := c.copies[val]; ok {
+			return copied
+		}
+		copied := runtime.NewAssociativeArrayValue(val.AssocType)
+		c.copies[val] = copied
+		for _, key := range val.Keys() {
+			elem, _ := val.Get(key)
+			copied.Set(key, c.copy(elem))
+		}
+		return copied
+	default:
+		return v
+	}
+}
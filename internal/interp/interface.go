@@ -185,6 +185,11 @@ func (i *Interpreter) runDestructorForRefCount(obj *ObjectInstance) error {
 		return nil
 	}
 
+	// In pure GC mode the object is simply left to the garbage collector
+	if i.engineState.DisableDestructors {
+		return nil
+	}
+
 	// If we're already inside this object's destructor, skip to avoid infinite recursion
 	if obj.DestroyCallDepth > 0 {
 		return nil
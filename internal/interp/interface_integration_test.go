@@ -541,3 +541,54 @@ func TestIntegration_InterfaceLifetimeManagement(t *testing.T) {
 		}
 	})
 }
+
+// TestIntegration_InterfaceProperties tests property reads and writes routed
+// through interface variables to the implementing object's accessors,
+// including inherited and default indexed properties.
+func TestIntegration_InterfaceProperties(t *testing.T) {
+	source := `
+		type
+			INamed = interface
+				function GetName: String;
+				property Name: String read GetName;
+			end;
+
+			IList = interface(INamed)
+				function GetCount: Integer;
+				function GetItem(i: Integer): String;
+				procedure SetItem(i: Integer; v: String);
+				property Count: Integer read GetCount;
+				property Items[i: Integer]: String read GetItem write SetItem; default;
+			end;
+
+			TList = class(TObject, IList)
+				FData: array of String;
+				function GetName: String; begin Result := 'list'; end;
+				function GetCount: Integer; begin Result := FData.Length; end;
+				function GetItem(i: Integer): String; begin Result := FData[i]; end;
+				procedure SetItem(i: Integer; v: String);
+				begin
+					if i >= FData.Length then FData.SetLength(i + 1);
+					FData[i] := v;
+				end;
+			end;
+
+		var l: IList := TList.Create;
+		l.Items[0] := 'a';
+		l[1] := 'b';
+		PrintLn(l.Items[0] + l[1]);
+		PrintLn(l.Count);
+		var n: INamed := l;
+		PrintLn(n.Name);
+	`
+
+	result, output := testEvalWithOutput(source)
+	if result != nil && result.Type() == "ERROR" {
+		t.Fatalf("Runtime error: %v", result.String())
+	}
+
+	expected := "ab\n2\nlist\n"
+	if output != expected {
+		t.Errorf("Expected output %q, got %q", expected, output)
+	}
+}
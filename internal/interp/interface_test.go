@@ -798,3 +798,53 @@ func TestInterfacePolymorphism(t *testing.T) {
 func testInterpreter() *Interpreter {
 	return New(nil)
 }
+
+// TestInterfaceEqualitySupportsAndCasts tests that interfaces compare by the
+// object they reference, Supports() queries an object or interface, and as
+// casts between unrelated interfaces are checked at run time.
+func TestInterfaceEqualitySupportsAndCasts(t *testing.T) {
+	input := `
+type IA = interface procedure A; end;
+type IB = interface function B: Integer; end;
+type TBoth = class(TObject, IA, IB)
+	procedure A; begin end;
+	function B: Integer; begin Result := 7; end;
+end;
+type TOnlyA = class(TObject, IA) procedure A; begin end; end;
+function Wrap(o: TBoth): IA; begin Result := o; end;
+
+var o := TBoth.Create;
+var a1: IA := o;
+var a2: IA := o;
+var b: IB := o;
+PrintLn(a1 = a2, ' ', a1 <> a2, ' ', a1 = Wrap(o), ' ', a1 = Wrap(TBoth.Create));
+PrintLn(a1 = o, ' ', (a1 as IB) = b);
+PrintLn((a1 as IB).B);
+
+var x: IA := TOnlyA.Create;
+try
+	b := x as IB;
+except
+	on E: EInvalidCast do PrintLn(E.Message);
+end;
+
+var bb: IB;
+PrintLn(Supports(o, IB), ' ', Supports(TOnlyA.Create, IB), ' ', Supports(a1, IB));
+if Supports(a1, IB, bb) then PrintLn(bb.B);
+PrintLn(Supports(x, IB, bb), ' ', Assigned(bb));
+`
+	result, output := testEvalWithOutputAndSemantic(t, input)
+	if isError(result) {
+		t.Fatalf("unexpected error: %s", result.String())
+	}
+	want := "True False True False\n" +
+		"True True\n" +
+		"7\n" +
+		"Cannot cast interface of \"TOnlyA\" to interface \"IB\" [line: 21, column: 9]\n" +
+		"True False True\n" +
+		"7\n" +
+		"False False\n"
+	if output != want {
+		t.Errorf("output = %q, want %q", output, want)
+	}
+}
@@ -28,6 +28,7 @@ type evaluatorShim interface {
 	CurrentNode() ast.Node
 	EngineState() *contracts.EngineState
 	SetCurrentNode(node ast.Node)
+	SetInterrupt(interrupted func() bool)
 }
 
 // Interpreter executes DWScript AST nodes and manages the runtime environment.
@@ -66,6 +67,7 @@ func NewWithDeps(
 		if depth := opts.GetMaxRecursionDepth(); depth > 0 {
 			interp.engineState.MaxRecursionDepth = depth
 		}
+		interp.engineState.RecursionStrategy = opts.GetRecursionStrategy()
 	}
 	if interp.engineState.MaxRecursionDepth <= 0 {
 		interp.engineState.MaxRecursionDepth = DefaultMaxRecursionDepth
@@ -103,6 +105,9 @@ func NewWithDeps(
 	env.Define("PI", &FloatValue{Value: math.Pi})
 	env.Define("NaN", &FloatValue{Value: math.NaN()})
 	env.Define("Infinity", &FloatValue{Value: math.Inf(1)})
+	for ordinal, name := range []string{"ffGeneral", "ffExponent", "ffFixed", "ffNumber", "ffCurrency"} {
+		env.Define(name, &IntegerValue{Value: int64(ordinal)})
+	}
 	env.Define("Null", NewNullValue())
 	env.Define("Unassigned", NewUnassignedValue())
 
@@ -116,12 +121,26 @@ func (i *Interpreter) GetException() *runtime.ExceptionValue {
 	return exc
 }
 
+// ClearException discards the active exception, if any, so that evaluation
+// can continue with a new program after an unhandled exception.
+func (i *Interpreter) ClearException() {
+	i.clearException()
+}
+
 // SetSemanticInfo sets the semantic metadata table for this interpreter.
 // The semantic info contains type annotations and symbol resolutions from analysis.
 func (i *Interpreter) SetSemanticInfo(info *ast.SemanticInfo) {
 	i.engineState.SemanticInfo = info
 }
 
+// SetInterrupt makes a running Eval stop once interrupted returns true. The
+// function is polled before every statement and loop iteration; Eval then
+// returns an error value, which neither try/except nor try/finally blocks in
+// the script handle. A nil function never interrupts.
+func (i *Interpreter) SetInterrupt(interrupted func() bool) {
+	i.evaluatorInstance.SetInterrupt(interrupted)
+}
+
 // GetCallStack returns a copy of the current call stack.
 // Returns stack frames in the order they were called (oldest to newest).
 func (i *Interpreter) GetCallStack() errors.StackTrace {
@@ -190,7 +209,12 @@ func (i *Interpreter) evalViaEvaluator(node ast.Node) Value {
 	result := i.evaluatorInstance.Eval(node, i.ctx)
 	// Convert runtime.ErrorValue to interp.ErrorValue for type compatibility
 	if runtimeErr, ok := result.(*runtime.ErrorValue); ok {
-		return &ErrorValue{Message: formatDWScriptRuntimeMessage(runtimeErr.Message)}
+		return &ErrorValue{
+			Message:        formatDWScriptRuntimeMessage(runtimeErr.Message),
+			ExceptionClass: runtimeErr.ExceptionClass,
+			Pos:            runtimeErr.Pos,
+			Detail:         runtimeErr.Detail,
+		}
 	}
 	return result
 }
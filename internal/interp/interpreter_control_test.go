@@ -828,6 +828,166 @@ func TestCaseStatementWithRanges(t *testing.T) {
 			`,
 			expected: "double digit\n",
 		},
+		{
+			name: "Enum range",
+			input: `
+				type TColor = (Red, Green, Blue, Yellow);
+				var c := Green;
+				case c of
+					Red..Blue: PrintLn('primary');
+					Yellow: PrintLn('yellow');
+				end
+			`,
+			expected: "primary\n",
+		},
+		{
+			name: "Enum value outside range",
+			input: `
+				type TColor = (Red, Green, Blue, Yellow);
+				case Yellow of
+					Red..Blue: PrintLn('primary');
+				else
+					PrintLn('other');
+				end
+			`,
+			expected: "other\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, output := testEvalWithOutput(tt.input)
+			if output != tt.expected {
+				t.Errorf("wrong output.\nexpected=%q\ngot=%q", tt.expected, output)
+			}
+		})
+	}
+}
+
+// TestCaseStatementWithStrings tests string selectors, including large cases
+// dispatched through a jump table.
+func TestCaseStatementWithStrings(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{
+			name: "Many values per branch",
+			input: `
+				var s := 'def';
+				case s of
+					'abc', 'def': PrintLn('first');
+					'ghi': PrintLn('second');
+				else
+					PrintLn('none');
+				end
+			`,
+			expected: "first\n",
+		},
+		{
+			name: "Case-sensitive comparison",
+			input: `
+				case 'ABC' of
+					'abc': PrintLn('lower');
+				else
+					PrintLn('none');
+				end
+			`,
+			expected: "none\n",
+		},
+		{
+			name: "Jump table hit",
+			input: `
+				var days := ['Mon', 'Tue', 'Wed', 'Thu', 'Fri', 'Sat', 'Sun'];
+				for var d in days do
+					case d of
+						'Mon', 'Tue', 'Wed', 'Thu', 'Fri': PrintLn(d + ' work');
+						'Sat', 'Sun': PrintLn(d + ' rest');
+						'Mon': PrintLn('unreachable');
+					end;
+			`,
+			expected: "Mon work\nTue work\nWed work\nThu work\nFri work\nSat rest\nSun rest\n",
+		},
+		{
+			name: "Jump table miss",
+			input: `
+				case 'sun' of
+					'Mon', 'Tue', 'Wed', 'Thu', 'Fri': PrintLn('work');
+					'Sat', 'Sun': PrintLn('rest');
+				else
+					PrintLn('unknown');
+				end
+			`,
+			expected: "unknown\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, output := testEvalWithOutput(tt.input)
+			if output != tt.expected {
+				t.Errorf("wrong output.\nexpected=%q\ngot=%q", tt.expected, output)
+			}
+		})
+	}
+}
+
+// TestInlineVarConditionExecution tests if and while statements whose
+// condition declares a variable.
+func TestInlineVarConditionExecution(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{
+			name:     "If with boolean inline var",
+			input:    `if var ok := 2 > 1 then PrintLn(ok) else PrintLn('no')`,
+			expected: "True\n",
+		},
+		{
+			name: "If with object inline var",
+			input: `
+				type TItem = class
+					Name: String;
+				end;
+				function Find(name: String): TItem;
+				begin
+					if name = 'a' then begin
+						Result := TItem.Create;
+						Result.Name := name;
+					end else
+						Result := nil;
+				end;
+				if var item := Find('a') then PrintLn('found ' + item.Name) else PrintLn('missing');
+				if var item := Find('b') then PrintLn('found ' + item.Name) else PrintLn('missing');
+			`,
+			expected: "found a\nmissing\n",
+		},
+		{
+			name: "While inline var is re-evaluated before each iteration",
+			input: `
+				var n := 0;
+				function Next: Boolean;
+				begin
+					n := n + 1;
+					Result := n <= 3;
+				end;
+				while var more := Next() do PrintLn(n);
+				PrintLn('done ' + IntToStr(n));
+			`,
+			expected: "1\n2\n3\ndone 4\n",
+		},
+		{
+			name: "Inline var shadows an outer variable only inside the statement",
+			input: `
+				var x := 'outer';
+				if var x := True then PrintLn(x);
+				PrintLn(x);
+			`,
+			expected: "True\nouter\n",
+		},
 	}
 
 	for _, tt := range tests {
@@ -543,28 +543,117 @@ func TestLambdaNoCapturedVariables(t *testing.T) {
 // ==============================================================================
 
 func TestLambdaCapturesLoopVariable(t *testing.T) {
-	t.Skip("Requires procedure type variables - not yet implemented")
-	// Test that each lambda captures the current value of the loop variable
-	// Note: In DWScript, loop variables are shared, so all lambdas see final value
-	input := `
-		var i: Integer;
-		var lastFunc: procedure;
-
-		for i := 1 to 3 do begin
-			lastFunc := lambda() begin end;
-		end;
+	// Each closure captures the loop variable's value for its iteration,
+	// whether the variable is inline or declared outside the loop.
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{
+			name: "inline for variable",
+			input: `
+				type TGetter = function: Integer;
+				var getters: array of TGetter;
+				for var i := 1 to 3 do
+					getters.Add(lambda => i);
+				for var g in getters do
+					PrintLn(g());
+			`,
+			expected: "1\n2\n3\n",
+		},
+		{
+			name: "outer for variable counting down",
+			input: `
+				type TGetter = function: Integer;
+				var getters: array of TGetter;
+				var i: Integer;
+				for i := 3 downto 1 do
+					getters.Add(lambda => i);
+				for var g in getters do
+					PrintLn(g());
+			`,
+			expected: "3\n2\n1\n",
+		},
+		{
+			name: "for-in variable",
+			input: `
+				type TGetter = function: String;
+				var getters: array of TGetter;
+				for var s in ['a', 'b', 'c'] do
+					getters.Add(lambda => s);
+				for var g in getters do
+					PrintLn(g());
+			`,
+			expected: "a\nb\nc\n",
+		},
+		{
+			name: "other captures stay by reference",
+			input: `
+				type TGetter = function: Integer;
+				var getters: array of TGetter;
+				var offset := 0;
+				for var i := 1 to 2 do
+					getters.Add(lambda => i + offset);
+				offset := 10;
+				for var g in getters do
+					PrintLn(g());
+			`,
+			expected: "11\n12\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, output := testEvalWithOutputAndSemantic(t, tt.input)
+			if isError(result) {
+				t.Fatalf("evaluation failed: %v", result)
+			}
+			if output != tt.expected {
+				t.Errorf("output = %q, want %q", output, tt.expected)
+			}
+		})
+	}
+}
 
-		// After loop, i should be 4 (one past the end)
+func TestLambdaCapturesNestedLoopVariables(t *testing.T) {
+	input := `
+		type TGetter = function: Integer;
+		var getters: array of TGetter;
+		for var i := 1 to 2 do
+			for var j in [10, 20] do begin
+				getters.Add(lambda => i * 100 + j);
+				for var k := 1 to 2 do
+					if k = j div 10 then
+						getters.Add(lambda => -(i * 100 + j + k));
+			end;
+		for var g in getters do
+			PrintLn(g());
 	`
 
-	_, interp := runLambdaTest(t, input)
+	result, output := testEvalWithOutputAndSemantic(t, input)
+	if isError(result) {
+		t.Fatalf("evaluation failed: %v", result)
+	}
+	expected := "110\n-111\n120\n-122\n210\n-211\n220\n-222\n"
+	if output != expected {
+		t.Errorf("output = %q, want %q", output, expected)
+	}
+}
 
-	iVal, _ := interp.Env().Get("i")
-	intVal := iVal.(*IntegerValue)
+func TestLambdaUntypedParametersDefaultToVariant(t *testing.T) {
+	input := `
+		var double := lambda(x) => x * 2;
+		PrintLn(double(21));
+		PrintLn(double(1.5));
+	`
 
-	// Loop variable ends at 4 (one past 'to 3')
-	if intVal.Value != 4 {
-		t.Errorf("Expected i = 4 after loop, got %d", intVal.Value)
+	result, output := testEvalWithOutputAndSemantic(t, input)
+	if isError(result) {
+		t.Fatalf("evaluation failed: %v", result)
+	}
+	if expected := "42\n3\n"; output != expected {
+		t.Errorf("output = %q, want %q", output, expected)
 	}
 }
 
@@ -4,7 +4,8 @@ import (
 	"fmt"
 	"reflect"
 
-	"github.com/cwbudde/go-dws/internal/types"
+	"github.com/cwbudde/go-dws/internal/interp/runtime"
+	"github.com/cwbudde/go-dws/internal/jsonvalue"
 )
 
 // MarshalToGo converts a DWScript Value to a Go value of the target type.
@@ -18,6 +19,7 @@ import (
 //   - BOOLEAN → bool
 //   - ARRAY → []T (Go slices)
 //   - RECORD → map[string]T (Go maps with string keys)
+//   - RECORD, OBJECT, JSON object → struct (see marshalStruct)
 //   - FUNCTION POINTER → func(...)
 //
 // The interp parameter is optional and only required for function pointer marshaling.
@@ -89,6 +91,9 @@ func MarshalToGo(dwsValue Value, targetType reflect.Type, interp *Interpreter) (
 
 	case reflect.Slice:
 		// Convert DWScript array to Go slice
+		if jsonVal, ok := unwrapVariant(dwsValue).(*JSONValue); ok && jsonVal.Value != nil && jsonVal.Value.Kind() == jsonvalue.KindArray {
+			return marshalJSONArray(jsonVal.Value, targetType, interp)
+		}
 		if dwsValue.Type() != "ARRAY" {
 			return nil, fmt.Errorf("expected ARRAY, got %s", dwsValue.Type())
 		}
@@ -118,12 +123,9 @@ func MarshalToGo(dwsValue Value, targetType reflect.Type, interp *Interpreter) (
 			return nil, fmt.Errorf("only map[string]T is supported")
 		}
 
-		if dwsValue.Type() != "RECORD" {
-			return nil, fmt.Errorf("expected RECORD, got %s", dwsValue.Type())
-		}
 		recordVal, ok := dwsValue.(*RecordValue)
 		if !ok {
-			return nil, fmt.Errorf("expected RecordValue, got %T", dwsValue)
+			return nil, fmt.Errorf("expected RECORD, got %s", dwsValue.Type())
 		}
 
 		// Create a map of the target type
@@ -165,6 +167,9 @@ func MarshalToGo(dwsValue Value, targetType reflect.Type, interp *Interpreter) (
 
 		return ptrValue.Interface(), nil
 
+	case reflect.Struct:
+		return marshalStruct(dwsValue, targetType, interp)
+
 	case reflect.Func:
 		// Marshal DWScript function pointers to Go callbacks
 		// Check if DWScript value is a function pointer
@@ -192,100 +197,26 @@ func MarshalToGo(dwsValue Value, targetType reflect.Type, interp *Interpreter) (
 // This function handles the conversion from Go native types to DWScript runtime values
 // for use in FFI (Foreign Function Interface) return values.
 //
-// Supported conversions:
-//   - int64, int, int32, int16, int8 → INTEGER
+// Supported conversions (see runtime.FromGo):
+//   - signed and unsigned integers → INTEGER
 //   - float64, float32 → FLOAT
 //   - string → STRING
 //   - bool → BOOLEAN
 //   - []T → ARRAY
 //   - map[string]T → RECORD
 //   - nil → NIL
+//
+// Structs, and values holding structs such as []T of a struct type T, become
+// a JSON Variant instead (see structToJSON).
 func MarshalToDWS(goValue any) (Value, error) {
-	// Handle nil
-	if goValue == nil {
-		return NewNilValue(), nil
-	}
-
-	// Use reflection to handle more complex types
-	v := reflect.ValueOf(goValue)
-
-	switch v.Kind() {
-	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
-		return NewIntegerValue(v.Int()), nil
-
-	case reflect.Float32, reflect.Float64:
-		return NewFloatValue(v.Float()), nil
-
-	case reflect.String:
-		return NewStringValue(v.String()), nil
-
-	case reflect.Bool:
-		return NewBooleanValue(v.Bool()), nil
-
-	case reflect.Slice:
-		// Convert Go slice to DWScript array
-		elements := make([]Value, v.Len())
-		for i := 0; i < v.Len(); i++ {
-			elem, err := MarshalToDWS(v.Index(i).Interface())
-			if err != nil {
-				return nil, fmt.Errorf("slice element %d: %w", i, err)
-			}
-			elements[i] = elem
-		}
-
-		// Determine element type from first element (if any)
-		var elemType types.Type = types.NIL
-		if len(elements) > 0 {
-			switch elements[0].Type() {
-			case "INTEGER":
-				elemType = types.INTEGER
-			case "FLOAT":
-				elemType = types.FLOAT
-			case "STRING":
-				elemType = types.STRING
-			case "BOOLEAN":
-				elemType = types.BOOLEAN
-			}
-		} else {
-			// Empty array - try to infer from Go type
-			if v.Type().Elem().Kind() == reflect.Int64 {
-				elemType = types.INTEGER
-			}
-		}
-
-		return &ArrayValue{
-			ArrayType: &types.ArrayType{
-				ElementType: elemType,
-				LowBound:    nil, // Dynamic array
-				HighBound:   nil,
-			},
-			Elements: elements,
-		}, nil
-
-	case reflect.Map:
-		// Convert Go map[string]T to DWScript record
-		if v.Type().Key().Kind() != reflect.String {
-			return nil, fmt.Errorf("only map[string]T is supported for marshaling")
-		}
-
-		fields := make(map[string]Value)
-		for _, key := range v.MapKeys() {
-			keyStr := key.String()
-			val, err := MarshalToDWS(v.MapIndex(key).Interface())
-			if err != nil {
-				return nil, fmt.Errorf("map field %s: %w", keyStr, err)
-			}
-			fields[keyStr] = val
+	if goValue != nil && ContainsStruct(reflect.TypeOf(goValue)) {
+		jv, err := structToJSON(reflect.ValueOf(goValue))
+		if err != nil {
+			return nil, err
 		}
-
-		return &RecordValue{
-			RecordType: nil, // Anonymous record
-			Fields:     fields,
-		}, nil
-
-	default:
-		return nil, fmt.Errorf("unsupported Go return type: %T", goValue)
+		return runtime.BoxVariantWithJSON(jv), nil
 	}
+	return runtime.FromGo(goValue)
 }
 
 // UnmarshalFromGoPtr reads a value from a Go pointer and converts it back to DWScript.
@@ -0,0 +1,205 @@
+package interp
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"strings"
+
+	"github.com/cwbudde/go-dws/internal/interp/runtime"
+	"github.com/cwbudde/go-dws/internal/jsonvalue"
+	"github.com/cwbudde/go-dws/pkg/ident"
+)
+
+// ============================================================================
+// Struct Marshaling
+// ============================================================================
+//
+// Records, objects and JSON objects are converted to Go structs field by
+// field. A Go field matches the script field of the same name, compared
+// case-insensitively; a `dws:"name"` tag matches another name instead, and
+// `dws:"-"` skips the field. Every exported Go field must have a match unless
+// its tag has the omitempty option, as in `dws:"email,omitempty"`.
+//
+// In the other direction a Go struct becomes a JSON object using the same
+// names, which scripts receive as a Variant. Assigning it to a variable or
+// parameter of a record type converts it to that record type.
+// ============================================================================
+
+// structField describes an exported Go struct field taking part in marshaling.
+type structField struct {
+	index     int
+	name      string
+	omitEmpty bool
+}
+
+// structFields returns the fields of structType that are marshaled, with the
+// names they have on the DWScript side.
+func structFields(structType reflect.Type) []structField {
+	fields := make([]structField, 0, structType.NumField())
+	for i := 0; i < structType.NumField(); i++ {
+		field := structType.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		name, options, _ := strings.Cut(field.Tag.Get("dws"), ",")
+		if name == "-" && options == "" {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		fields = append(fields, structField{
+			index:     i,
+			name:      name,
+			omitEmpty: options == "omitempty",
+		})
+	}
+	return fields
+}
+
+// marshalStruct converts a record, object or JSON object to a Go struct of
+// targetType.
+func marshalStruct(dwsValue Value, targetType reflect.Type, interp *Interpreter) (any, error) {
+	dwsValue = unwrapVariant(dwsValue)
+
+	var source string
+	var lookup func(name string) (Value, bool)
+	switch val := dwsValue.(type) {
+	case *RecordValue:
+		source = "record " + val.Type()
+		lookup = val.GetRecordField
+	case *ObjectInstance:
+		source = "class " + val.ClassName()
+		lookup = func(name string) (Value, bool) {
+			field := val.GetField(name)
+			return field, field != nil
+		}
+	case *JSONValue:
+		if val.Value == nil || val.Value.Kind() != jsonvalue.KindObject {
+			return nil, fmt.Errorf("expected record, got %s", dwsValue.Type())
+		}
+		source = "JSON object"
+		lookup = func(name string) (Value, bool) {
+			for _, key := range val.Value.ObjectKeys() {
+				if ident.Equal(key, name) {
+					return runtime.JSONValueToValue(val.Value.ObjectGet(key)), true
+				}
+			}
+			return nil, false
+		}
+	default:
+		if dwsValue == nil {
+			return nil, fmt.Errorf("expected record, got nil")
+		}
+		return nil, fmt.Errorf("expected record, got %s", dwsValue.Type())
+	}
+
+	result := reflect.New(targetType).Elem()
+	for _, field := range structFields(targetType) {
+		fieldVal, ok := lookup(field.name)
+		if !ok {
+			if field.omitEmpty {
+				continue
+			}
+			return nil, fmt.Errorf("%s has no field %s required by Go type %s", source, field.name, targetType)
+		}
+		goField := result.Field(field.index)
+		goVal, err := MarshalToGo(fieldVal, goField.Type(), interp)
+		if err != nil {
+			return nil, fmt.Errorf("%s field %s to Go type %s: %w", source, field.name, targetType, err)
+		}
+		goField.Set(reflect.ValueOf(goVal))
+	}
+	return result.Interface(), nil
+}
+
+// marshalJSONArray converts the elements of a JSON array to a Go slice of
+// targetType.
+func marshalJSONArray(array *jsonvalue.Value, targetType reflect.Type, interp *Interpreter) (any, error) {
+	elements := array.ArrayElements()
+	goSlice := reflect.MakeSlice(targetType, len(elements), len(elements))
+	for i, elem := range elements {
+		goElem, err := MarshalToGo(runtime.JSONValueToValue(elem), targetType.Elem(), interp)
+		if err != nil {
+			return nil, fmt.Errorf("array element %d: %w", i, err)
+		}
+		goSlice.Index(i).Set(reflect.ValueOf(goElem))
+	}
+	return goSlice.Interface(), nil
+}
+
+// ContainsStruct reports whether values of goType hold a struct, directly or
+// in an element.
+func ContainsStruct(goType reflect.Type) bool {
+	switch goType.Kind() {
+	case reflect.Struct:
+		return true
+	case reflect.Pointer, reflect.Slice, reflect.Array, reflect.Map:
+		return ContainsStruct(goType.Elem())
+	}
+	return false
+}
+
+// structToJSON converts a Go value holding structs to a JSON value.
+func structToJSON(rv reflect.Value) (*jsonvalue.Value, error) {
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return jsonvalue.NewInt64(rv.Int()), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		if rv.Uint() > math.MaxInt64 {
+			return nil, fmt.Errorf("value %d overflows Integer", rv.Uint())
+		}
+		return jsonvalue.NewInt64(int64(rv.Uint())), nil
+	case reflect.Float32, reflect.Float64:
+		return jsonvalue.NewNumber(rv.Float()), nil
+	case reflect.String:
+		return jsonvalue.NewString(rv.String()), nil
+	case reflect.Bool:
+		return jsonvalue.NewBoolean(rv.Bool()), nil
+	case reflect.Pointer, reflect.Interface:
+		if rv.IsNil() {
+			return jsonvalue.NewNull(), nil
+		}
+		return structToJSON(rv.Elem())
+	case reflect.Slice, reflect.Array:
+		array := jsonvalue.NewArray()
+		for i := 0; i < rv.Len(); i++ {
+			elem, err := structToJSON(rv.Index(i))
+			if err != nil {
+				return nil, fmt.Errorf("slice element %d: %w", i, err)
+			}
+			array.ArrayAppend(elem)
+		}
+		return array, nil
+	case reflect.Map:
+		if rv.Type().Key().Kind() != reflect.String {
+			return nil, fmt.Errorf("only map[string]T can be converted to an object, got %s", rv.Type())
+		}
+		object := jsonvalue.NewObject()
+		iter := rv.MapRange()
+		for iter.Next() {
+			field, err := structToJSON(iter.Value())
+			if err != nil {
+				return nil, fmt.Errorf("map field %s: %w", iter.Key().String(), err)
+			}
+			object.ObjectSet(iter.Key().String(), field)
+		}
+		return object, nil
+	case reflect.Struct:
+		object := jsonvalue.NewObject()
+		for _, field := range structFields(rv.Type()) {
+			goField := rv.Field(field.index)
+			if field.omitEmpty && goField.IsZero() {
+				continue
+			}
+			value, err := structToJSON(goField)
+			if err != nil {
+				return nil, fmt.Errorf("field %s of Go type %s: %w", field.name, rv.Type(), err)
+			}
+			object.ObjectSet(field.name, value)
+		}
+		return object, nil
+	}
+	return nil, fmt.Errorf("cannot convert Go value of type %s to a DWScript value", rv.Type())
+}
@@ -667,3 +667,39 @@ end
 		})
 	}
 }
+
+// TestBuiltinRounding_InvalidOp tests that Round, Trunc, Ceil and Floor raise
+// EInvalidOp for values that have no Integer representation.
+func TestBuiltinRounding_InvalidOp(t *testing.T) {
+	input := `
+procedure Check(name: String; x: Float);
+begin
+	try
+		case name of
+			'Round': PrintLn(Round(x));
+			'Trunc': PrintLn(Trunc(x));
+			'Ceil': PrintLn(Ceil(x));
+		else
+			PrintLn(Floor(x));
+		end;
+	except
+		on E: EInvalidOp do PrintLn(E.Message);
+	end;
+end;
+
+Check('Round', NaN);
+Check('Trunc', Infinity);
+Check('Ceil', 1e19);
+Check('Floor', -Infinity);
+Check('Floor', -2.5);
+`
+	_, output := testEvalWithOutputAndSemantic(t, input)
+	want := "Round() cannot convert NAN to Integer\n" +
+		"Trunc() cannot convert INF to Integer\n" +
+		"Ceil() cannot convert 1e+19 to Integer\n" +
+		"Floor() cannot convert -INF to Integer\n" +
+		"-3\n"
+	if output != want {
+		t.Errorf("output = %q, want %q", output, want)
+	}
+}
@@ -875,3 +875,38 @@ end
 		})
 	}
 }
+
+// TestBuiltinMinMax_NaN tests that a NaN operand yields NaN in either position.
+func TestBuiltinMinMax_NaN(t *testing.T) {
+	input := `
+PrintLn(Min(NaN, 1));
+PrintLn(Min(1, NaN));
+PrintLn(Max(NaN, 1.5));
+PrintLn(Max(1.5, NaN));
+var v: Variant := NaN;
+PrintLn(Max(2, v));
+`
+	_, output := testEvalWithOutputAndSemantic(t, input)
+	if want := "NAN\nNAN\nNAN\nNAN\nNAN\n"; output != want {
+		t.Errorf("output = %q, want %q", output, want)
+	}
+}
+
+// TestBuiltinMinMax_SubrangeArguments tests that Integer subrange and alias
+// arguments resolve to the Integer and Float overloads.
+func TestBuiltinMinMax_SubrangeArguments(t *testing.T) {
+	input := `
+type TDigit = 0..9;
+type TReal = Float;
+var d: TDigit := 7;
+var r: TReal := 2.5;
+var i: Integer := Max(d, 3) + Min(d, 4);
+PrintLn(i);
+PrintLn(Min(d, r));
+PrintLn(Ceil(r) + ClampInt(d, 0, 5));
+`
+	_, output := testEvalWithOutputAndSemantic(t, input)
+	if want := "11\n2.5\n8\n"; output != want {
+		t.Errorf("output = %q, want %q", output, want)
+	}
+}
@@ -96,10 +96,19 @@ func TestBuiltinRandom_Errors(t *testing.T) {
 			name: "Too many arguments",
 			input: `
 begin
-	Random(5);
+	Random(5, 10);
 end
 			`,
-			expectedError: "Random() expects no arguments",
+			expectedError: "Random() expects 0 or 1 arguments",
+		},
+		{
+			name: "String argument",
+			input: `
+begin
+	Random('5');
+end
+			`,
+			expectedError: "Random() expects Integer",
 		},
 	}
 
@@ -257,16 +266,34 @@ begin
 	RandomInt();
 end
 			`,
-			expectedError: "RandomInt() expects exactly 1 argument",
+			expectedError: "RandomInt() expects 1 or 2 arguments",
 		},
 		{
 			name: "Too many arguments",
 			input: `
 begin
-	RandomInt(10, 20);
+	RandomInt(10, 20, 30);
+end
+			`,
+			expectedError: "RandomInt() expects 1 or 2 arguments",
+		},
+		{
+			name: "Empty range",
+			input: `
+begin
+	RandomInt(5, 5);
 end
 			`,
-			expectedError: "RandomInt() expects exactly 1 argument",
+			expectedError: "RandomInt() expects max > min",
+		},
+		{
+			name: "Reversed range",
+			input: `
+begin
+	RandomInt(10, -10);
+end
+			`,
+			expectedError: "RandomInt() expects max > min",
 		},
 		{
 			name: "Max is zero",
@@ -330,3 +357,83 @@ end
 		})
 	}
 }
+
+// TestBuiltinRandom_IntegerRange tests that Random(n) returns Integers in
+// [0, n) and 0 when n <= 0.
+func TestBuiltinRandom_IntegerRange(t *testing.T) {
+	input := `
+var i: Integer;
+var allInRange := true;
+for i := 1 to 200 do begin
+	var r := Random(7);
+	if (r < 0) or (r >= 7) then
+		allInRange := false;
+end;
+begin
+	allInRange and (Random(1) = 0) and (Random(0) = 0) and (Random(-5) = 0);
+end
+	`
+	result := testEval(input)
+
+	boolVal, ok := result.(*BooleanValue)
+	if !ok {
+		t.Fatalf("result is not *BooleanValue. got=%T (%+v)", result, result)
+	}
+	if !boolVal.Value {
+		t.Errorf("Random(n) produced value outside [0, n)")
+	}
+
+	if _, ok := testEval("Random(10);").(*IntegerValue); !ok {
+		t.Errorf("Random(10) did not return *IntegerValue")
+	}
+}
+
+// TestBuiltinRandomInt_MinMax tests that RandomInt(min, max) covers exactly
+// [min, max), including ranges wider than the Integer range.
+func TestBuiltinRandomInt_MinMax(t *testing.T) {
+	_, output := testEvalWithOutputAndSemantic(t, `
+var seen: array [-3..1] of Boolean;
+var i: Integer;
+var allInRange := true;
+for i := 1 to 500 do begin
+	var r := RandomInt(-3, 2);
+	if (r < -3) or (r >= 2) then
+		allInRange := false
+	else
+		seen[r] := true;
+end;
+PrintLn(allInRange);
+PrintLn(seen[-3] and seen[1]);
+PrintLn(RandomInt(41, 42));
+var wide := RandomInt(Low(Integer), High(Integer));
+PrintLn(wide < High(Integer));
+`)
+
+	if want := "True\nTrue\n41\nTrue\n"; output != want {
+		t.Errorf("output = %q, want %q", output, want)
+	}
+}
+
+// TestBuiltinSetRandSeed_Reproducible tests that reseeding replays the same
+// sequence for every random builtin.
+func TestBuiltinSetRandSeed_Reproducible(t *testing.T) {
+	_, output := testEvalWithOutputAndSemantic(t, `
+function Draw: String;
+begin
+	Result := FloatToStr(Random()) + ' ' + IntToStr(Random(1000)) + ' ' +
+		IntToStr(RandomInt(-50, 50)) + ' ' + FloatToStr(RandG(10.0, 2.0));
+end;
+
+SetRandSeed(2024);
+var first := Draw();
+SetRandSeed(2024);
+PrintLn(first = Draw());
+PrintLn(RandSeed);
+SetRandSeed(2025);
+PrintLn(first = Draw());
+`)
+
+	if want := "True\n2024\nFalse\n"; output != want {
+		t.Errorf("output = %q, want %q", output, want)
+	}
+}
@@ -0,0 +1,127 @@
+package interp
+
+import (
+	"strings"
+	"testing"
+)
+
+// TestNamedArgumentCalls tests that named arguments bind to their parameters
+// regardless of order, mixed with leading positional arguments and defaults.
+func TestNamedArgumentCalls(t *testing.T) {
+	input := `
+procedure Show(count: Integer; name: String = 'def'; sep: String = ':');
+begin
+  PrintLn(name + sep + IntToStr(count));
+end;
+
+function Combine(a, b: Integer): Integer;
+begin
+  Result := a * 10 + b;
+end;
+
+procedure Bump(var x: Integer; by: Integer = 2);
+begin
+  x := x + by;
+end;
+
+Show(count := 3, name := 'x');
+Show(name := 'y', count := 4);
+Show(5, sep := '-');
+PrintLn(Combine(b := 1, a := 2));
+var v := 1;
+Bump(by := 5, x := v);
+PrintLn(v);
+`
+
+	_, output := testEvalWithOutputAndSemantic(t, input)
+	expected := "x:3\ny:4\ndef-5\n21\n6\n"
+	if output != expected {
+		t.Errorf("expected output %q, got %q", expected, output)
+	}
+}
+
+// TestNamedArgumentErrors tests that the evaluator rejects duplicate and
+// unknown names when semantic analysis is skipped.
+func TestNamedArgumentErrors(t *testing.T) {
+	decl := `
+procedure Show(count: Integer; name: String = 'def');
+begin
+end;
+`
+	tests := []struct {
+		call string
+		want string
+	}{
+		{call: "Show(count := 1, count := 2);", want: `parameter "count" is given more than once`},
+		{call: "Show(cnt := 1);", want: `there is no parameter named "cnt"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.call, func(t *testing.T) {
+			result := testEval(decl + tt.call)
+			if !isError(result) {
+				t.Fatalf("expected error, got %v", result)
+			}
+			if !strings.Contains(result.String(), tt.want) {
+				t.Errorf("expected error containing %q, got %q", tt.want, result.String())
+			}
+		})
+	}
+}
+
+// TestNamedArgumentMethodCalls tests named arguments in method, class method,
+// constructor and record method calls.
+func TestNamedArgumentMethodCalls(t *testing.T) {
+	input := `
+type
+  TPoint = class
+    X, Y: Integer;
+    constructor Create(ax, ay: Integer);
+    function M(a, b: Integer): Integer;
+    class function Join(s: String; sep: String = ','): String;
+  end;
+
+constructor TPoint.Create(ax, ay: Integer);
+begin
+  X := ax;
+  Y := ay;
+end;
+
+function TPoint.M(a, b: Integer): Integer;
+begin
+  Result := a * 10 + b;
+end;
+
+class function TPoint.Join(s: String; sep: String = ','): String;
+begin
+  Result := s + sep + s;
+end;
+
+type
+  TRec = record
+    N: Integer;
+    function Scaled(by: Integer; offset: Integer = 0): Integer;
+  end;
+
+function TRec.Scaled(by: Integer; offset: Integer = 0): Integer;
+begin
+  Result := N * by + offset;
+end;
+
+var c := TPoint.Create(ay := 2, ax := 1);
+PrintLn(IntToStr(c.X) + ' ' + IntToStr(c.Y));
+PrintLn(c.M(b := 1, a := 10));
+var d := new TPoint(ay := 4, ax := 3);
+PrintLn(d.M(d.X, b := d.Y));
+PrintLn(TPoint.Join(sep := '-', s := 'ab'));
+var r: TRec;
+r.N := 5;
+PrintLn(r.Scaled(offset := 1, by := 2));
+`
+
+	_, output := testEvalWithOutputAndSemantic(t, input)
+	expected := "1 2\n101\n34\nab-ab\n11\n"
+	if output != expected {
+		t.Errorf("expected output %q, got %q", expected, output)
+	}
+}
@@ -34,6 +34,9 @@ func NewWithOptions(output io.Writer, opts Options) *Interpreter {
 
 	maxRecursionDepth := DefaultMaxRecursionDepth
 	if opts != nil {
+		if registry := opts.GetBuiltins(); registry != nil {
+			ts.Functions().SetBuiltinRegistry(registry)
+		}
 		if depth := opts.GetMaxRecursionDepth(); depth > 0 {
 			maxRecursionDepth = depth
 		}
@@ -41,6 +44,20 @@ func NewWithOptions(output io.Writer, opts Options) *Interpreter {
 
 	evalConfig := &evaluator.Config{
 		MaxRecursionDepth: maxRecursionDepth,
+		VariantOverflow:   runtime.DefaultVariantNumericRule().Overflow,
+	}
+	if opts != nil {
+		evalConfig.VariantOverflow = opts.GetVariantOverflow()
+		evalConfig.IntegerOverflowCheck = opts.GetIntegerOverflowCheck()
+		evalConfig.RangeChecks = opts.GetRangeChecks()
+		evalConfig.DisableAssertions = !opts.GetAssertions()
+		evalConfig.ContractMode = opts.GetContracts()
+		evalConfig.RandomSeed, evalConfig.FixedRandomSeed = opts.GetRandomSeed()
+		evalConfig.DisableDestructors = !opts.GetDestructors()
+		evalConfig.FormatSettings = opts.GetFormatSettings()
+		evalConfig.Clock = opts.GetClock()
+		evalConfig.RecursionStrategy = opts.GetRecursionStrategy()
+		evalConfig.ValuePool = opts.GetValuePool()
 	}
 
 	refCountMgr := runtime.NewRefCountManager()
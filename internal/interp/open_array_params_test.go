@@ -0,0 +1,83 @@
+package interp
+
+import (
+	"testing"
+)
+
+// TestOpenArrayParameters tests binding array arguments to open array
+// (`array of T` and `array of const`) parameters of user routines.
+func TestOpenArrayParameters(t *testing.T) {
+	tests := []struct {
+		name     string
+		script   string
+		expected string
+	}{
+		{
+			name: "Static array is seen zero-based",
+			script: `
+function Describe(const a: array of Integer): String;
+begin
+  Result := IntToStr(Low(a)) + '..' + IntToStr(High(a)) + ' first=' + IntToStr(a[0]);
+end;
+var s: array[5..7] of Integer;
+s[5] := 10; s[6] := 20; s[7] := 30;
+PrintLn(Describe(s));
+`,
+			expected: "0..2 first=10\n",
+		},
+		{
+			name: "Value parameter copies a static array",
+			script: `
+procedure Clear(a: array of Integer);
+var i: Integer;
+begin
+  for i := 0 to High(a) do a[i] := 0;
+end;
+var s: array[1..2] of Integer;
+s[1] := 1; s[2] := 2;
+Clear(s);
+PrintLn(s[1] + s[2]);
+`,
+			expected: "3\n",
+		},
+		{
+			name: "Value parameter shares a dynamic array",
+			script: `
+procedure Clear(a: array of Integer);
+var i: Integer;
+begin
+  for i := 0 to High(a) do a[i] := 0;
+end;
+var d: array of Integer := [1, 2];
+Clear(d);
+PrintLn(d[0] + d[1]);
+`,
+			expected: "0\n",
+		},
+		{
+			name: "Array of const accepts literals and variables",
+			script: `
+function Join(const parts: array of const): String;
+var i: Integer;
+begin
+  for i := Low(parts) to High(parts) do
+    Result := Result + VarToStr(parts[i]);
+end;
+var names: array[1..2] of String;
+names[1] := 'a'; names[2] := 'b';
+PrintLn(Join([1, 'x', True]));
+PrintLn(Join(names));
+`,
+			expected: "1xTrue\nab\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, output := testEvalWithOutputAndSemantic(t, tt.script)
+			if output != tt.expected {
+				t.Errorf("expected output %q, got %q", tt.expected, output)
+			}
+		})
+	}
+}
@@ -1085,3 +1085,90 @@ func TestCompoundAssignmentClassOperator(t *testing.T) {
 		t.Fatalf("expected %q, got %q", expected, output)
 	}
 }
+
+func TestRecordClassOperators(t *testing.T) {
+	input := `
+type TMoney = record
+  Cents: Integer;
+  class function FromInt(i: Integer): TMoney; begin Result.Cents := i; end;
+  function ToFloat: Float; begin Result := Cents / 100; end;
+  function Less(other: TMoney): Boolean; begin Result := Cents < other.Cents; end;
+  function Same(other: TMoney): Boolean; begin Result := Cents = other.Cents; end;
+  class operator < TMoney uses Less;
+  class operator = TMoney uses Same;
+  class operator Implicit Integer uses FromInt;
+  class operator Implicit TMoney: Float uses ToFloat;
+end;
+
+procedure Show(m: TMoney); begin PrintLn(m.Cents); end;
+
+var a: TMoney := 250;
+var b: TMoney := 100;
+PrintLn(a < b);
+PrintLn(b < a);
+PrintLn(a = b);
+Show(77);
+var f: Float := a;
+PrintLn(f);
+
+var arr: array of TMoney;
+arr.Add(TMoney.FromInt(3));
+arr.Add(TMoney.FromInt(1));
+arr.Add(TMoney.FromInt(2));
+arr.Sort;
+for var m in arr do PrintLn(m.Cents);
+`
+	_, output := testEvalWithOutput(input)
+	expected := "False\nTrue\nFalse\n77\n2.5\n1\n2\n3\n"
+	if output != expected {
+		t.Fatalf("expected %q, got %q", expected, output)
+	}
+}
+
+func TestRecordClassOperatorOutOfLineBody(t *testing.T) {
+	input := `
+type TV = record
+  X: Integer;
+  function Less(other: TV): Boolean;
+  class operator < TV uses Less;
+end;
+
+function TV.Less(other: TV): Boolean;
+begin
+  PrintLn('Less called');
+  Result := X < other.X;
+end;
+
+var c, d: TV;
+c.X := 1;
+d.X := 2;
+PrintLn(c < d);
+PrintLn(d < c);
+`
+	_, output := testEvalWithOutput(input)
+	expected := "Less called\nTrue\nLess called\nFalse\n"
+	if output != expected {
+		t.Fatalf("expected %q, got %q", expected, output)
+	}
+}
+
+func TestClassImplicitConversion(t *testing.T) {
+	input := `
+type TTag = class
+  Name: String;
+  class function FromStr(s: String): TTag; begin Result := TTag.Create; Result.Name := s; end;
+  class operator Implicit String: TTag uses FromStr;
+end;
+
+procedure ShowTag(t: TTag); begin PrintLn(t.Name); end;
+
+var t: TTag := 'hello';
+PrintLn(t.Name);
+ShowTag('world');
+`
+	_, output := testEvalWithOutput(input)
+	expected := "hello\nworld\n"
+	if output != expected {
+		t.Fatalf("expected %q, got %q", expected, output)
+	}
+}
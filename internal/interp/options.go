@@ -1,5 +1,12 @@
 package interp
 
+import (
+	"time"
+
+	"github.com/cwbudde/go-dws/internal/builtins"
+	"github.com/cwbudde/go-dws/internal/interp/runtime"
+)
+
 // Options defines the interface for configuring the interpreter.
 // This interface breaks the circular dependency between internal/interp and pkg/dwscript.
 // The pkg/dwscript.Options concrete type implements this interface.
@@ -12,7 +19,103 @@ type Options interface {
 	// GetExternalFunctions returns the external function registry, or nil if not set.
 	GetExternalFunctions() *ExternalFunctionRegistry
 
+	// GetBuiltins returns the registry of built-in functions available to
+	// scripts, or nil for builtins.DefaultRegistry.
+	GetBuiltins() *builtins.Registry
+
 	// GetMaxRecursionDepth returns the maximum recursion depth for function calls.
 	// Returns 0 if not set (caller should use default).
 	GetMaxRecursionDepth() int
+
+	// GetVariantOverflow returns how Integer overflow in Variant arithmetic is handled.
+	GetVariantOverflow() VariantOverflowMode
+
+	// GetIntegerOverflowCheck reports whether Integer +, -, *, Inc, Dec and Abs
+	// raise EIntOverflow on overflow instead of wrapping around.
+	GetIntegerOverflowCheck() bool
+
+	// GetRangeChecks reports whether writes to subrange-typed array elements
+	// and fields are checked against the subrange bounds.
+	GetRangeChecks() bool
+
+	// GetAssertions reports whether Assert calls are executed. When false,
+	// Assert is a no-op and none of its arguments are evaluated.
+	GetAssertions() bool
+
+	// GetContracts returns which function contracts (require/ensure) are checked.
+	GetContracts() ContractMode
+
+	// GetRandomSeed returns the seed of the random number generator and
+	// whether it is fixed. A fixed seed makes Randomize a no-op.
+	GetRandomSeed() (seed int64, fixed bool)
+
+	// GetDestructors reports whether Free, FreeAndNil and Destroy run
+	// destructors. When false, objects are left to the garbage collector and
+	// those calls are no-ops.
+	GetDestructors() bool
+
+	// GetFormatSettings returns the separators used by FloatToStr, StrToFloat,
+	// Format and DateToStr.
+	GetFormatSettings() FormatSettings
+
+	// GetClock returns the function Now, Date, Time and the other
+	// clock-reading builtins take the current time from. Nil selects time.Now.
+	GetClock() func() time.Time
+
+	// GetRecursionStrategy returns where the Go stack frames of nested script
+	// calls live.
+	GetRecursionStrategy() RecursionStrategy
+
+	// GetValuePool returns the pool the Integer and Float results of
+	// arithmetic are drawn from, or nil for a new enabled pool.
+	GetValuePool() *ValuePool
+}
+
+// VariantOverflowMode selects the Integer overflow behavior of Variant arithmetic.
+type VariantOverflowMode = runtime.VariantOverflowMode
+
+// Variant overflow modes (see runtime.VariantOverflowMode).
+const (
+	VariantOverflowWrap            = runtime.VariantOverflowWrap
+	VariantOverflowError           = runtime.VariantOverflowError
+	VariantOverflowSaturateToFloat = runtime.VariantOverflowSaturateToFloat
+)
+
+// FormatSettings holds the locale-dependent separators of the conversion builtins.
+type FormatSettings = runtime.FormatSettings
+
+// InvariantFormatSettings returns the default, locale-independent settings.
+func InvariantFormatSettings() FormatSettings {
+	return runtime.InvariantFormatSettings()
+}
+
+// ContractMode selects which function contracts are checked at run time.
+type ContractMode = runtime.ContractMode
+
+// Contract modes (see runtime.ContractMode).
+const (
+	ContractsFull = runtime.ContractsFull
+	ContractsPre  = runtime.ContractsPre
+	ContractsOff  = runtime.ContractsOff
+)
+
+// RecursionStrategy selects where the Go stack frames of nested script calls live.
+type RecursionStrategy = runtime.RecursionStrategy
+
+// Recursion strategies (see runtime.RecursionStrategy).
+const (
+	RecursionNative = runtime.RecursionNative
+	RecursionHeap   = runtime.RecursionHeap
+)
+
+// ValuePool hands out Integer and Float values (see runtime.ValuePool).
+type ValuePool = runtime.ValuePool
+
+// PoolStats holds value pool statistics (see runtime.PoolStatistics).
+type PoolStats = runtime.PoolStatistics
+
+// NewValuePool creates a value pool that reuses released values when
+// enabled.
+func NewValuePool(enabled bool) *ValuePool {
+	return runtime.NewValuePool(enabled)
 }
@@ -1277,6 +1277,31 @@ PrintLn(z);
 	}
 }
 
+// TestSuccPredOrdinalTypes tests Succ/Pred on Booleans, characters and
+// subranges, with results usable as values of the argument's type.
+func TestSuccPredOrdinalTypes(t *testing.T) {
+	input := `
+type TDigit = 0..9;
+var d: TDigit := 5;
+var ch: String := 'b';
+var b: Boolean := Succ(False);
+var s: String := Succ(ch);
+var i: Integer := Pred(d);
+PrintLn(b);
+PrintLn(Pred(True));
+PrintLn(s);
+PrintLn(Pred('b'));
+PrintLn(i);
+PrintLn(Succ(d));
+`
+
+	_, output := testEvalWithOutputAndSemantic(t, input)
+	expected := "True\nFalse\nc\na\n4\n6\n"
+	if output != expected {
+		t.Errorf("expected output %q, got %q", expected, output)
+	}
+}
+
 // ============================================================================
 // Low/High Tests for Enums
 // ============================================================================
@@ -1518,3 +1543,24 @@ PrintLn(count);
 		t.Errorf("expected output %q, got %q", expected, out.String())
 	}
 }
+
+// TestLowHighSubrange tests Low/High on subrange type names and variables,
+// and Ord on subrange values.
+func TestLowHighSubrange(t *testing.T) {
+	input := `
+type TDigit = 1..9;
+var d: TDigit := 5;
+var lo: Integer := Low(TDigit);
+PrintLn(lo);
+PrintLn(High(TDigit));
+PrintLn(Low(d));
+PrintLn(High(d));
+PrintLn(Ord(d));
+`
+
+	_, output := testEvalWithOutputAndSemantic(t, input)
+	expected := "1\n9\n1\n9\n5\n"
+	if output != expected {
+		t.Errorf("expected output %q, got %q", expected, output)
+	}
+}
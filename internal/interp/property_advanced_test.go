@@ -928,3 +928,94 @@ for i := 0 to 4 do
 		t.Errorf("expected output '%s', got '%s'", expectedOutput, buf.String())
 	}
 }
+
+// TestRecordDefaultPropertyWrite tests writing through a record's default indexed property
+func TestRecordDefaultPropertyWrite(t *testing.T) {
+	input := `
+type TVec = record
+	FData: array [0..2] of Integer;
+	function GetItem(i: Integer): Integer; begin Result := FData[i]; end;
+	procedure SetItem(i: Integer; v: Integer); begin FData[i] := v; PrintLn('set ' + IntToStr(i)); end;
+	property Items[i: Integer]: Integer read GetItem write SetItem; default;
+end;
+
+var v: TVec;
+v[1] := 42;
+v[2] := v[1] + 1;
+PrintLn(IntToStr(v[1]));
+PrintLn(IntToStr(v[2]));
+`
+
+	l := lexer.New(input)
+	p := parser.New(l)
+	program := p.ParseProgram()
+
+	if len(p.Errors()) != 0 {
+		t.Fatalf("parser errors: %v", p.Errors())
+	}
+
+	var buf bytes.Buffer
+	interp := New(&buf)
+	result := interp.Eval(program)
+
+	if isError(result) {
+		t.Fatalf("eval error: %v", result)
+	}
+
+	expectedOutput := "set 1\nset 2\n42\n43\n"
+	if buf.String() != expectedOutput {
+		t.Errorf("expected output '%s', got '%s'", expectedOutput, buf.String())
+	}
+}
+
+// TestInterfaceDefaultAndIndexDirectiveProperties tests that interface default
+// properties and index directives forward to the implementing class
+func TestInterfaceDefaultAndIndexDirectiveProperties(t *testing.T) {
+	input := `
+type IBag = interface
+	function GetItem(i: Integer): String;
+	procedure SetItem(i: Integer; v: String);
+	function GetNamed(idx: Integer): String;
+	procedure SetNamed(idx: Integer; v: String);
+	property Items[i: Integer]: String read GetItem write SetItem; default;
+	property First: String index 0 read GetNamed write SetNamed;
+	property Second: String index 1 read GetNamed write SetNamed;
+end;
+
+type TBag = class(TObject, IBag)
+	FData: array [0..2] of String;
+	function GetItem(i: Integer): String; begin Result := FData[i]; end;
+	procedure SetItem(i: Integer; v: String); begin FData[i] := v; end;
+	function GetNamed(idx: Integer): String; begin Result := 'named' + IntToStr(idx); end;
+	procedure SetNamed(idx: Integer; v: String); begin PrintLn('set ' + IntToStr(idx) + ' ' + v); end;
+end;
+
+var b: IBag := TBag.Create;
+b[1] := 'x';
+b.Items[2] := 'y';
+PrintLn(b[1] + b.Items[2]);
+PrintLn(b.First + ' ' + b.Second);
+b.Second := 'z';
+`
+
+	l := lexer.New(input)
+	p := parser.New(l)
+	program := p.ParseProgram()
+
+	if len(p.Errors()) != 0 {
+		t.Fatalf("parser errors: %v", p.Errors())
+	}
+
+	var buf bytes.Buffer
+	interp := New(&buf)
+	result := interp.Eval(program)
+
+	if isError(result) {
+		t.Fatalf("eval error: %v", result)
+	}
+
+	expectedOutput := "xy\nnamed0 named1\nset 1 z\n"
+	if buf.String() != expectedOutput {
+		t.Errorf("expected output '%s', got '%s'", expectedOutput, buf.String())
+	}
+}
@@ -824,6 +824,63 @@ PrintLn(arr.Values[4]);
 	}
 }
 
+// TestDefaultPropertyStringKey tests reading and writing a default indexed
+// property with a String index through obj[key]
+func TestDefaultPropertyStringKey(t *testing.T) {
+	input := `
+type TMap = class
+	FKeys: array of String;
+	FValues: array of Integer;
+	function GetItem(key: String): Integer;
+	begin
+		var i := FKeys.IndexOf(key);
+		if i < 0 then Result := -1 else Result := FValues[i];
+	end;
+	procedure SetItem(key: String; value: Integer);
+	begin
+		var i := FKeys.IndexOf(key);
+		if i < 0 then begin
+			FKeys.Add(key);
+			FValues.Add(value);
+		end else
+			FValues[i] := value;
+	end;
+	property Items[key: String]: Integer read GetItem write SetItem; default;
+end;
+
+var m := TMap.Create;
+var key := 'b';
+m['a'] := 1;
+m[key] := 2;
+m['a'] := m['a'] + 10;
+PrintLn(m['a']);
+PrintLn(m[key]);
+PrintLn(m.Items['b']);
+PrintLn(m['missing']);
+`
+
+	l := lexer.New(input)
+	p := parser.New(l)
+	program := p.ParseProgram()
+
+	if len(p.Errors()) != 0 {
+		t.Fatalf("parser errors: %v", p.Errors())
+	}
+
+	var buf bytes.Buffer
+	interp := New(&buf)
+	result := interp.Eval(program)
+
+	if isError(result) {
+		t.Fatalf("eval error: %v", result)
+	}
+
+	expectedOutput := "11\n2\n2\n-1\n"
+	if buf.String() != expectedOutput {
+		t.Errorf("expected output '%s', got '%s'", expectedOutput, buf.String())
+	}
+}
+
 // TestIndexedPropertyWriteInheritance tests that indexed property writes work through inheritance
 func TestIndexedPropertyWriteInheritance(t *testing.T) {
 	input := `
@@ -116,6 +116,23 @@ func TestEvalRecordLiteral_AnonymousWithTypeAnnotation(t *testing.T) {
 	}
 }
 
+func TestEvalRecordLiteral_AnonymousArrayElements(t *testing.T) {
+	input := `
+		type TPoint = record
+			X, Y: Integer;
+		end;
+		var pts: array of TPoint := [(X: 1; Y: 2), (X: 3; Y: 4)];
+		var fixed: array [0..1] of TPoint := [(X: 5; Y: 6), (X: 7; Y: 8)];
+		PrintLn(pts[1].X + pts[0].Y);
+		PrintLn(fixed[1].Y);
+	`
+
+	_, output := testEvalWithOutputAndSemantic(t, input)
+	if output != "5\n8\n" {
+		t.Errorf("expected %q, got %q", "5\n8\n", output)
+	}
+}
+
 func TestEvalRecordLiteral_DeathStarExample(t *testing.T) {
 	// Test actual Death_Star.dws examples
 	input := `
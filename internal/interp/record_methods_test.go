@@ -405,6 +405,71 @@ PrintLn(t.AccessSelf());
 	}
 }
 
+// TestRecordMethodMutatesSelfInPlace tests that a record method modifying Self
+// updates the receiver's storage when the record lives in an array, another
+// record or an object field.
+func TestRecordMethodMutatesSelfInPlace(t *testing.T) {
+	decls := `
+type TPoint = record
+	X, Y: Integer;
+	procedure Offset(dx, dy: Integer);
+	begin
+		X := X + dx;
+		Self.Y := Self.Y + dy;
+	end;
+end;
+type TBox = record P: TPoint; end;
+type TObj = class P: TPoint; B: TBox; end;
+`
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{"dynamic array element", `
+var pts: array of TPoint;
+SetLength(pts, 2);
+pts[1].Offset(1, 2);
+PrintLn(IntToStr(pts[1].X) + ',' + IntToStr(pts[1].Y));`, "1,2\n"},
+		{"static array element", `
+var pts: array[1..2] of TPoint;
+pts[2].Offset(1, 2);
+PrintLn(IntToStr(pts[2].X) + ',' + IntToStr(pts[2].Y));`, "1,2\n"},
+		{"nested array element", `
+var grid: array of array of TPoint;
+SetLength(grid, 2);
+SetLength(grid[1], 2);
+grid[1][1].Offset(1, 2);
+PrintLn(IntToStr(grid[1][1].X) + ',' + IntToStr(grid[1][1].Y));`, "1,2\n"},
+		{"record field", `
+var b: TBox;
+b.P.Offset(1, 2);
+PrintLn(IntToStr(b.P.X) + ',' + IntToStr(b.P.Y));`, "1,2\n"},
+		{"record field of array element", `
+var boxes: array of TBox;
+SetLength(boxes, 1);
+boxes[0].P.Offset(1, 2);
+PrintLn(IntToStr(boxes[0].P.X) + ',' + IntToStr(boxes[0].P.Y));`, "1,2\n"},
+		{"object field", `
+var o := TObj.Create;
+o.P.Offset(1, 2);
+o.B.P.Offset(1, 2);
+PrintLn(IntToStr(o.P.X + o.B.P.X) + ',' + IntToStr(o.P.Y + o.B.P.Y));`, "2,4\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, output := testEvalWithOutput(decls + tt.input)
+			if isError(result) {
+				t.Fatalf("unexpected error: %s", result)
+			}
+			if output != tt.expected {
+				t.Errorf("expected output %q, got %q", tt.expected, output)
+			}
+		})
+	}
+}
+
 // TestStaticRecordMethods tests static record methods (class function/procedure)
 func TestStaticRecordMethods(t *testing.T) {
 	tests := []struct {
@@ -468,3 +468,61 @@ end.`,
 		})
 	}
 }
+
+// TestRTTITypeInfoMembers tests querying TypeOf results: kind flags, the
+// ordered field and method lists, comparisons, TypeName and InheritsFrom.
+func TestRTTITypeInfoMembers(t *testing.T) {
+	input := `
+type TBase = class
+  published
+    Zeta: Integer = 1;
+    Alpha: String = 'a';
+  private
+    FHidden: Integer;
+  public
+    function Twice: Integer; begin Result := Zeta * 2; end;
+    property Double: Integer read Twice;
+end;
+type TSub = class(TBase)
+  published
+    Sub: Float = 1.5;
+    procedure Hello; begin end;
+    procedure Hello(s: String); overload; begin end;
+end;
+type TRec = record X: Integer; end;
+
+var s := TSub.Create;
+var ti := TypeOf(s);
+PrintLn(ti.Name, ' ', ti.IsClass, ' ', ti.IsArray, ' ', ti.IsRecord);
+for var f in ti.Fields do PrintLn(f.Name, '=', f.Value);
+for var m in ti.Methods do PrintLn(m);
+PrintLn(Length(TypeOf(TSub).Fields), ' ', TypeOf(s.ClassType).Name);
+PrintLn(ti = TypeOf(TSub), ' ', ti = TypeOf(TBase), ' ', ti = 'tsub', ' ', 'is ' + ti);
+PrintLn(s.InheritsFrom('TBase'), ' ', s.InheritsFrom('tsub'), ' ', s.InheritsFrom('TRec'));
+PrintLn(TSub.InheritsFrom(TBase), ' ', TBase.InheritsFrom(TSub), ' ', s.ClassParent.ClassName);
+var a: array of Integer;
+var r: TRec;
+PrintLn(TypeOf(a).IsArray, ' ', TypeOf(r).IsRecord, ' ', TypeOf(r).Name, ' ', TypeOf(42).Name);
+PrintLn(TypeName(s), ' ', TypeName('x'));
+`
+	result, output := testEvalWithOutputAndSemantic(t, input)
+	if isError(result) {
+		t.Fatalf("unexpected error: %s", result.String())
+	}
+	want := "TSub True False False\n" +
+		"Sub=1.5\n" +
+		"Alpha=a\n" +
+		"Double=2\n" +
+		"Zeta=1\n" +
+		"Hello\n" +
+		"Twice\n" +
+		"4 TSub\n" +
+		"True False True is TSub\n" +
+		"True True False\n" +
+		"True False TBase\n" +
+		"True True TRec Integer\n" +
+		"TSub String\n"
+	if output != want {
+		t.Errorf("output = %q, want %q", output, want)
+	}
+}
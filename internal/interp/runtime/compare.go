@@ -0,0 +1,47 @@
+package runtime
+
+import "fmt"
+
+// CompareValues evaluates a comparison operator (=, <>, <, >, <=, >=) through
+// the ComparableValue and OrderableValue interfaces of the left operand.
+//
+// CompareTo reports unordered operands (a NaN float) as equal, so <= and >=
+// confirm a zero result with Equals: every ordering of NaN is False and only
+// <> is True, as with IEEE-754 comparisons.
+//
+// Returns an error if the left operand does not support the operator or
+// cannot be compared with the right operand.
+func CompareValues(op string, left, right Value) (bool, error) {
+	switch op {
+	case "=", "<>":
+		cmp, ok := left.(ComparableValue)
+		if !ok {
+			return false, fmt.Errorf("cannot compare %s with %s", left.Type(), right.Type())
+		}
+		equal, err := cmp.Equals(right)
+		if err != nil {
+			return false, err
+		}
+		return equal == (op == "="), nil
+	case "<", ">", "<=", ">=":
+		ord, ok := left.(OrderableValue)
+		if !ok {
+			return false, fmt.Errorf("cannot order %s values", left.Type())
+		}
+		c, err := ord.CompareTo(right)
+		if err != nil {
+			return false, err
+		}
+		switch op {
+		case "<":
+			return c < 0, nil
+		case ">":
+			return c > 0, nil
+		}
+		if c != 0 {
+			return (c < 0) == (op == "<="), nil
+		}
+		return ord.Equals(right)
+	}
+	return false, fmt.Errorf("unknown comparison operator %s", op)
+}
@@ -0,0 +1,85 @@
+package runtime
+
+import (
+	"math"
+	"testing"
+)
+
+// ============================================================================
+// CompareValues Tests
+// ============================================================================
+
+func TestCompareValues(t *testing.T) {
+	nan := NewFloat(math.NaN())
+	tests := []struct {
+		left  Value
+		right Value
+		want  map[string]bool
+		name  string
+	}{
+		{
+			name: "integers", left: NewInteger(1), right: NewInteger(2),
+			want: map[string]bool{"=": false, "<>": true, "<": true, ">": false, "<=": true, ">=": false},
+		},
+		{
+			name: "equal integers", left: NewInteger(-3), right: NewInteger(-3),
+			want: map[string]bool{"=": true, "<>": false, "<": false, ">": false, "<=": true, ">=": true},
+		},
+		{
+			name: "integer and float", left: NewInteger(2), right: NewFloat(1.5),
+			want: map[string]bool{"=": false, "<>": true, "<": false, ">": true, "<=": false, ">=": true},
+		},
+		{
+			name: "float and integer", left: NewFloat(2), right: NewInteger(2),
+			want: map[string]bool{"=": true, "<>": false, "<": false, ">": false, "<=": true, ">=": true},
+		},
+		{
+			name: "NaN", left: nan, right: nan,
+			want: map[string]bool{"=": false, "<>": true, "<": false, ">": false, "<=": false, ">=": false},
+		},
+		{
+			name: "strings", left: NewString("B"), right: NewString("a"),
+			want: map[string]bool{"=": false, "<>": true, "<": true, ">": false, "<=": true, ">=": false},
+		},
+		{
+			name: "booleans", left: NewBoolean(true), right: NewBoolean(true),
+			want: map[string]bool{"=": true, "<>": false},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for op, want := range tt.want {
+				got, err := CompareValues(op, tt.left, tt.right)
+				if err != nil {
+					t.Fatalf("CompareValues(%q) error = %v", op, err)
+				}
+				if got != want {
+					t.Errorf("%s %s %s = %v, want %v", tt.left, op, tt.right, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestCompareValuesErrors(t *testing.T) {
+	tests := []struct {
+		left  Value
+		right Value
+		name  string
+		op    string
+	}{
+		{name: "string with integer", op: "=", left: NewString("1"), right: NewInteger(1)},
+		{name: "ordering booleans", op: "<", left: NewBoolean(false), right: NewBoolean(true)},
+		{name: "not comparable", op: "=", left: &ArrayValue{}, right: &ArrayValue{}},
+		{name: "unknown operator", op: "+", left: NewInteger(1), right: NewInteger(2)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := CompareValues(tt.op, tt.left, tt.right); err == nil {
+				t.Errorf("CompareValues(%q, %s, %s) expected an error", tt.op, tt.left.Type(), tt.right.Type())
+			}
+		})
+	}
+}
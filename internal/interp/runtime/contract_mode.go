@@ -0,0 +1,30 @@
+package runtime
+
+// ContractMode selects which function contracts (require/ensure) are checked
+// at run time.
+type ContractMode int
+
+const (
+	// ContractsFull checks preconditions and postconditions, capturing the
+	// entry values referenced through "old". This is the default.
+	ContractsFull ContractMode = iota
+	// ContractsPre checks preconditions only. Postconditions are skipped and
+	// no "old" values are captured.
+	ContractsPre
+	// ContractsOff skips all contract evaluation.
+	ContractsOff
+)
+
+// String returns the name of the contract mode.
+func (m ContractMode) String() string {
+	switch m {
+	case ContractsFull:
+		return "Full"
+	case ContractsPre:
+		return "Pre"
+	case ContractsOff:
+		return "Off"
+	default:
+		return "Unknown"
+	}
+}
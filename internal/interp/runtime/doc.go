@@ -7,6 +7,7 @@
 //
 // The package is organized into:
 //   - value_interfaces.go: Interface definitions for value operations
+//   - compare.go: Comparison operators dispatched through those interfaces
 //   - primitives.go: Basic value types (Integer, Float, String, Boolean, Nil)
 //   - composite.go: Composite types (Array, Record, Set) - TODO
 //   - object.go: Object types (Class instances, Interfaces) - TODO
@@ -108,6 +108,12 @@ func (e *Environment) Define(name string, val Value) {
 	e.store.Set(name, val)
 }
 
+// Delete removes a variable from the current environment's scope, without
+// searching outer scopes. Returns true if the variable was defined there.
+func (e *Environment) Delete(name string) bool {
+	return e.store.Delete(name)
+}
+
 // Has checks if a variable is defined in the current environment or any outer scope.
 func (e *Environment) Has(name string) bool {
 	_, ok := e.Get(name)
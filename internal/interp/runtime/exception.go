@@ -24,6 +24,10 @@ type ExceptionValue struct {
 	// script code. DWScript reports these unhandled as
 	// "User defined exception: <message>"; runtime errors keep their message.
 	UserRaised bool
+
+	// Detail is extra information kept out of Message so that Message reads
+	// as in DWScript, such as the valid range of an out-of-bounds index.
+	Detail string
 }
 
 // Type returns the type of this exception value.
@@ -143,6 +143,10 @@ type ExecutionContext struct {
 	envStack                  []*Environment
 	oldValuesStack            []map[string]any
 	refCountManager           RefCountManager
+	// function and functionEnv identify the user function whose body runs in
+	// this context and the environment of that activation.
+	function    *ast.FunctionDecl
+	functionEnv *Environment
 }
 
 // NewExecutionContext creates a new execution context with the given environment.
@@ -321,6 +325,20 @@ func (ctx *ExecutionContext) ClearCurrentFunctionReturnType() {
 	ctx.currentFunctionReturnType = ""
 }
 
+// CurrentFunction returns the user function whose body runs in this context
+// and the environment of that activation, or nil if the context does not run
+// a function body.
+func (ctx *ExecutionContext) CurrentFunction() (*ast.FunctionDecl, *Environment) {
+	return ctx.function, ctx.functionEnv
+}
+
+// SetCurrentFunction records the user function whose body runs in this context
+// and the environment of that activation.
+func (ctx *ExecutionContext) SetCurrentFunction(fn *ast.FunctionDecl, env *Environment) {
+	ctx.function = fn
+	ctx.functionEnv = env
+}
+
 // ArrayTypeContext returns the current array type context for array literal evaluation.
 func (ctx *ExecutionContext) ArrayTypeContext() *types.ArrayType {
 	return ctx.arrayTypeContext
@@ -372,9 +390,13 @@ func (ctx *ExecutionContext) GetOldValue(name string) (any, bool) {
 //   - arrayTypeContext, recordTypeContext: Shared for type context
 //   - exception, handlerException: Shared exception state
 //
+// FRESH STATE:
+//   - envStack, oldValuesStack: Start empty. A nested scope pushes and pops its
+//     own entries in balance and never pops the caller's, so copying them would
+//     only make each call cost as much as the depth of the calls around it
+//   - function: Cleared; the caller records the function it runs, if any
+//
 // COPIED REFERENCES:
-//   - envStack: Slice is copied to prevent corruption during push/pop
-//   - oldValuesStack: Slice is copied to prevent postcondition stack corruption
 //   - currentNode: Copied so the clone starts at the caller's node; updates in the
 //     clone do not leak back to the parent context
 //
@@ -387,21 +409,13 @@ func (ctx *ExecutionContext) GetOldValue(name string) (any, bool) {
 // retained after the function returns. The caller is responsible for swapping the
 // environment via SetEnv() to establish the function's lexical scope.
 func (ctx *ExecutionContext) Clone() *ExecutionContext {
-	envStackCopy := make([]*Environment, len(ctx.envStack))
-	copy(envStackCopy, ctx.envStack)
-
-	oldValuesStackCopy := make([]map[string]any, len(ctx.oldValuesStack))
-	copy(oldValuesStackCopy, ctx.oldValuesStack)
-
 	return &ExecutionContext{
 		env:                       ctx.env,
-		envStack:                  envStackCopy,
 		callStack:                 ctx.callStack,
 		controlFlow:               ctx.controlFlow,
 		exception:                 ctx.exception,
 		handlerException:          ctx.handlerException,
 		currentNode:               ctx.currentNode,
-		oldValuesStack:            oldValuesStackCopy,
 		propContext:               ctx.propContext,
 		recordTypeContextType:     ctx.recordTypeContextType,
 		recordTypeContext:         ctx.recordTypeContext,
@@ -425,6 +439,8 @@ func (ctx *ExecutionContext) Reset() {
 	ctx.currentFunctionReturnType = ""
 	ctx.arrayTypeContext = nil
 	ctx.currentNode = nil
+	ctx.function = nil
+	ctx.functionEnv = nil
 }
 
 // SetRefCountManager attaches a RefCountManager used by assignment helpers.
@@ -0,0 +1,53 @@
+package runtime
+
+import (
+	"strconv"
+	"strings"
+)
+
+// FormatSettings holds the locale-dependent separators used by the
+// number and date conversion builtins (FloatToStr, StrToFloat, Format,
+// DateToStr, ...).
+type FormatSettings struct {
+	// DecimalSeparator separates the integer and fractional parts of a number.
+	DecimalSeparator string
+	// ThousandSeparator groups digits in Format's %n and %m output. An empty
+	// separator disables grouping.
+	ThousandSeparator string
+	// DateSeparator separates year, month and day in DateToStr output.
+	DateSeparator string
+}
+
+// InvariantFormatSettings returns the locale-independent settings used by
+// default: "." as decimal separator, "," for thousands and "-" for dates.
+func InvariantFormatSettings() FormatSettings {
+	return FormatSettings{
+		DecimalSeparator:  ".",
+		ThousandSeparator: ",",
+		DateSeparator:     "-",
+	}
+}
+
+// LocalizeNumber replaces the "." decimal point of a number formatted by Go
+// with the configured decimal separator.
+func (fs FormatSettings) LocalizeNumber(s string) string {
+	if fs.DecimalSeparator == "." || fs.DecimalSeparator == "" {
+		return s
+	}
+	return strings.Replace(s, ".", fs.DecimalSeparator, 1)
+}
+
+// ParseFloat parses s as a floating-point number written with the configured
+// decimal separator. Surrounding whitespace is ignored; thousand separators
+// are not accepted.
+func (fs FormatSettings) ParseFloat(s string) (float64, bool) {
+	s = strings.TrimSpace(s)
+	if sep := fs.DecimalSeparator; sep != "." && sep != "" {
+		if strings.Contains(s, ".") {
+			return 0, false
+		}
+		s = strings.Replace(s, sep, ".", 1)
+	}
+	f, err := strconv.ParseFloat(s, 64)
+	return f, err == nil
+}
@@ -0,0 +1,401 @@
+package runtime
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+
+	"github.com/cwbudde/go-dws/internal/types"
+	"github.com/cwbudde/go-dws/pkg/ident"
+)
+
+// ============================================================================
+// Go Value Conversion
+// ============================================================================
+//
+// ToGo and FromGo convert between runtime values and idiomatic Go values.
+// They are shared by the FFI layer (callback arguments and results) and by
+// APIs that expose script state to Go code.
+//
+//   DWScript            Go
+//   Integer             int64
+//   Float               float64
+//   String              string
+//   Boolean             bool
+//   nil                 nil
+//   array               []any
+//   record              map[string]any
+//   enum                int64 (ordinal), see EnumFromGo for the reverse
+//   set                 []any of int64 ordinals, in ascending order
+//   Variant             the Go value of the wrapped value
+//
+// FromGo infers the DWScript type from the Go value. GoType and FromGoAs
+// split this in two steps for callers that must know the type before the
+// value, such as host globals declared to the semantic analyzer.
+// ============================================================================
+
+// ToGo converts a runtime value to its idiomatic Go representation.
+// Record fields use their declared names when the record type is known.
+// Objects, interfaces, function pointers and other reference values cannot
+// be converted and return an error.
+func ToGo(v Value) (any, error) {
+	switch val := v.(type) {
+	case nil, *NilValue:
+		return nil, nil
+	case *IntegerValue:
+		return val.Value, nil
+	case *FloatValue:
+		return val.Value, nil
+	case *StringValue:
+		return val.Value, nil
+	case *BooleanValue:
+		return val.Value, nil
+	case *EnumValue:
+		return int64(val.OrdinalValue), nil
+	case *VariantValue:
+		return ToGo(val.Value)
+	case *ArrayValue:
+		result := make([]any, len(val.Elements))
+		for i, elem := range val.Elements {
+			goElem, err := ToGo(elem)
+			if err != nil {
+				return nil, fmt.Errorf("array element %d: %w", i, err)
+			}
+			result[i] = goElem
+		}
+		return result, nil
+	case *RecordValue:
+		result := make(map[string]any, len(val.Fields))
+		for key, field := range val.Fields {
+			goField, err := ToGo(field)
+			if err != nil {
+				return nil, fmt.Errorf("record field %s: %w", key, err)
+			}
+			name := key
+			if val.RecordType != nil {
+				if declared, ok := val.RecordType.FieldNames[key]; ok {
+					name = declared
+				}
+			}
+			result[name] = goField
+		}
+		return result, nil
+	case *SetValue:
+		ordinals := val.Ordinals()
+		result := make([]any, len(ordinals))
+		for i, ordinal := range ordinals {
+			result[i] = int64(ordinal)
+		}
+		return result, nil
+	}
+	return nil, fmt.Errorf("cannot convert DWScript %s to a Go value", v.Type())
+}
+
+// FromGo converts a Go value to a runtime value. It accepts every signed and
+// unsigned integer and float kind, strings, booleans, nil, slices and arrays
+// (as dynamic arrays) and maps with string keys (as anonymous records).
+// Values that already are runtime values are returned unchanged.
+func FromGo(v any) (Value, error) {
+	if v == nil {
+		return &NilValue{}, nil
+	}
+	if val, ok := v.(Value); ok {
+		return val, nil
+	}
+
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return &IntegerValue{Value: rv.Int()}, nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		u := rv.Uint()
+		if u > math.MaxInt64 {
+			return nil, fmt.Errorf("value %d overflows Integer", u)
+		}
+		return &IntegerValue{Value: int64(u)}, nil
+	case reflect.Float32, reflect.Float64:
+		return &FloatValue{Value: rv.Float()}, nil
+	case reflect.String:
+		return &StringValue{Value: rv.String()}, nil
+	case reflect.Bool:
+		return &BooleanValue{Value: rv.Bool()}, nil
+	case reflect.Pointer, reflect.Interface:
+		if rv.IsNil() {
+			return &NilValue{}, nil
+		}
+	case reflect.Slice, reflect.Array:
+		if rv.Kind() == reflect.Slice && rv.IsNil() {
+			return &ArrayValue{ArrayType: types.NewDynamicArrayType(goElementType(rv.Type().Elem(), nil))}, nil
+		}
+		elements := make([]Value, rv.Len())
+		for i := range elements {
+			elem, err := FromGo(rv.Index(i).Interface())
+			if err != nil {
+				return nil, fmt.Errorf("slice element %d: %w", i, err)
+			}
+			elements[i] = elem
+		}
+		return &ArrayValue{
+			ArrayType: types.NewDynamicArrayType(goElementType(rv.Type().Elem(), elements)),
+			Elements:  elements,
+		}, nil
+	case reflect.Map:
+		if rv.Type().Key().Kind() != reflect.String {
+			return nil, fmt.Errorf("only map[string]T can be converted to a record, got %T", v)
+		}
+		fields := make(map[string]Value, rv.Len())
+		iter := rv.MapRange()
+		for iter.Next() {
+			key := iter.Key().String()
+			field, err := FromGo(iter.Value().Interface())
+			if err != nil {
+				return nil, fmt.Errorf("map field %s: %w", key, err)
+			}
+			fields[key] = field
+		}
+		return &RecordValue{Fields: fields}, nil
+	}
+	return nil, fmt.Errorf("cannot convert Go value of type %T to a DWScript value", v)
+}
+
+// EnumFromGo converts a Go ordinal (any integer kind) or value name (matched
+// case-insensitively) to a value of the given enum type. It is the reverse of
+// ToGo for enum values, which needs the enum type to restore the value.
+func EnumFromGo(typeName string, enumType *types.EnumType, v any) (*EnumValue, error) {
+	if enumType == nil {
+		return nil, fmt.Errorf("enum type metadata is nil for %s", typeName)
+	}
+	if name, ok := v.(string); ok {
+		for _, valueName := range enumType.OrderedNames {
+			if ident.Equal(valueName, name) {
+				return &EnumValue{TypeName: typeName, ValueName: valueName, OrdinalValue: enumType.Values[valueName]}, nil
+			}
+		}
+		return nil, fmt.Errorf("'%s' is not a value of enum type '%s'", name, typeName)
+	}
+
+	val, err := FromGo(v)
+	if err != nil {
+		return nil, err
+	}
+	ordinal, ok := val.(*IntegerValue)
+	if !ok {
+		return nil, fmt.Errorf("cannot convert Go value of type %T to enum type '%s'", v, typeName)
+	}
+	if enumType.GetEnumName(int(ordinal.Value)) == "" {
+		return nil, fmt.Errorf("ordinal %d is not a value of enum type '%s'", ordinal.Value, typeName)
+	}
+	return NewEnumValue(typeName, enumType, int(ordinal.Value)), nil
+}
+
+// GoType infers the DWScript type of a Go value: integer kinds → Integer,
+// float kinds → Float, string → String, bool → Boolean, slices and arrays → a
+// dynamic array of the inferred element type, and map[string]T → an
+// anonymous record with one field per key. The element type of a slice of
+// interfaces or maps is inferred from its first element, so such a slice
+// must not be empty. nil and any other Go type return an error.
+func GoType(v any) (types.Type, error) {
+	return goType(reflect.ValueOf(v))
+}
+
+func goType(v reflect.Value) (types.Type, error) {
+	if v.Kind() == reflect.Interface {
+		v = v.Elem()
+	}
+	if !v.IsValid() {
+		return nil, fmt.Errorf("cannot infer a type for nil")
+	}
+
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return types.INTEGER, nil
+	case reflect.Float32, reflect.Float64:
+		return types.FLOAT, nil
+	case reflect.String:
+		return types.STRING, nil
+	case reflect.Bool:
+		return types.BOOLEAN, nil
+	case reflect.Slice, reflect.Array:
+		// Interfaces and maps only reveal their type through a value.
+		elem := reflect.Zero(v.Type().Elem())
+		if kind := elem.Kind(); kind == reflect.Interface || kind == reflect.Map {
+			if v.Len() == 0 {
+				return nil, fmt.Errorf("cannot infer the element type of an empty %s", v.Type())
+			}
+			elem = v.Index(0)
+		}
+		elemType, err := goType(elem)
+		if err != nil {
+			return nil, fmt.Errorf("element: %w", err)
+		}
+		return types.NewDynamicArrayType(elemType), nil
+	case reflect.Map:
+		if v.Type().Key().Kind() != reflect.String {
+			return nil, fmt.Errorf("unsupported map key type %s, want string", v.Type().Key())
+		}
+		fields := make(map[string]types.Type, v.Len())
+		seen := make(map[string]bool, v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			key := iter.Key().String()
+			if seen[ident.Normalize(key)] {
+				return nil, fmt.Errorf("duplicate field %s", key)
+			}
+			seen[ident.Normalize(key)] = true
+			fieldType, err := goType(iter.Value())
+			if err != nil {
+				return nil, fmt.Errorf("field %s: %w", key, err)
+			}
+			fields[key] = fieldType
+		}
+		return types.NewRecordType("", fields), nil
+	default:
+		return nil, fmt.Errorf("unsupported Go type %s", v.Type())
+	}
+}
+
+// FromGoAs converts a Go value to a runtime value of type typ. Besides the
+// types GoType infers, it accepts integers for Float, enum ordinals or value
+// names for enums (see EnumFromGo), and slices of those for sets of an enum
+// or ordinal type. Record fields are matched case-insensitively and must all
+// be present.
+func FromGoAs(v any, typ types.Type) (Value, error) {
+	return fromGoAs(reflect.ValueOf(v), typ)
+}
+
+func fromGoAs(v reflect.Value, typ types.Type) (Value, error) {
+	if v.Kind() == reflect.Interface {
+		v = v.Elem()
+	}
+	mismatch := func() error {
+		if !v.IsValid() {
+			return fmt.Errorf("cannot convert nil to %s", typ)
+		}
+		return fmt.Errorf("cannot convert %s to %s", v.Type(), typ)
+	}
+	if !v.IsValid() {
+		return nil, mismatch()
+	}
+
+	switch t := types.GetUnderlyingType(typ).(type) {
+	case *types.IntegerType:
+		switch v.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+			reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			return FromGo(v.Interface())
+		}
+	case *types.FloatType:
+		switch v.Kind() {
+		case reflect.Float32, reflect.Float64:
+			return NewFloat(v.Float()), nil
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			return NewFloat(float64(v.Int())), nil
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			return NewFloat(float64(v.Uint())), nil
+		}
+	case *types.StringType:
+		if v.Kind() == reflect.String {
+			return NewString(v.String()), nil
+		}
+	case *types.BooleanType:
+		if v.Kind() == reflect.Bool {
+			return NewBoolean(v.Bool()), nil
+		}
+	case *types.EnumType:
+		return EnumFromGo(t.Name, t, v.Interface())
+	case *types.SetType:
+		if v.Kind() == reflect.Slice || v.Kind() == reflect.Array {
+			set := NewSetValue(t)
+			for i := 0; i < v.Len(); i++ {
+				elem, err := fromGoAs(v.Index(i), t.ElementType)
+				if err != nil {
+					return nil, fmt.Errorf("element %d: %w", i, err)
+				}
+				ordinal, err := GetOrdinalValue(elem)
+				if err != nil {
+					return nil, fmt.Errorf("element %d: %w", i, err)
+				}
+				set.AddElement(ordinal)
+			}
+			return set, nil
+		}
+	case *types.ArrayType:
+		if v.Kind() == reflect.Slice || v.Kind() == reflect.Array {
+			array := NewArrayValue(t, nil)
+			array.Elements = make([]Value, v.Len())
+			for i := range array.Elements {
+				elem, err := fromGoAs(v.Index(i), t.ElementType)
+				if err != nil {
+					return nil, fmt.Errorf("element %d: %w", i, err)
+				}
+				array.Elements[i] = elem
+			}
+			return array, nil
+		}
+	case *types.RecordType:
+		if v.Kind() == reflect.Map && v.Type().Key().Kind() == reflect.String {
+			if v.Len() != len(t.Fields) {
+				return nil, mismatch()
+			}
+			fields := make(map[string]Value, len(t.Fields))
+			iter := v.MapRange()
+			for iter.Next() {
+				key := ident.Normalize(iter.Key().String())
+				fieldType, ok := t.Fields[key]
+				if !ok {
+					return nil, fmt.Errorf("%s has no field %s", typ, iter.Key().String())
+				}
+				field, err := fromGoAs(iter.Value(), fieldType)
+				if err != nil {
+					return nil, fmt.Errorf("field %s: %w", iter.Key().String(), err)
+				}
+				fields[key] = field
+			}
+			return &RecordValue{RecordType: t, Fields: fields}, nil
+		}
+	}
+	return nil, mismatch()
+}
+
+// goElementType returns the DWScript element type for a Go slice element
+// type. Interface element types take the common type of the converted
+// elements, or Variant when they differ.
+func goElementType(elemType reflect.Type, elements []Value) types.Type {
+	switch elemType.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return types.INTEGER
+	case reflect.Float32, reflect.Float64:
+		return types.FLOAT
+	case reflect.String:
+		return types.STRING
+	case reflect.Bool:
+		return types.BOOLEAN
+	}
+
+	var common types.Type
+	for _, elem := range elements {
+		var t types.Type
+		switch elem.(type) {
+		case *IntegerValue:
+			t = types.INTEGER
+		case *FloatValue:
+			t = types.FLOAT
+		case *StringValue:
+			t = types.STRING
+		case *BooleanValue:
+			t = types.BOOLEAN
+		default:
+			return types.VARIANT
+		}
+		if common != nil && common != t {
+			return types.VARIANT
+		}
+		common = t
+	}
+	if common == nil {
+		return types.VARIANT
+	}
+	return common
+}
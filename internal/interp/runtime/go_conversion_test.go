@@ -0,0 +1,265 @@
+package runtime
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/cwbudde/go-dws/internal/types"
+)
+
+// ============================================================================
+// ToGo / FromGo Tests
+// ============================================================================
+
+func TestGoConversionRoundTrip(t *testing.T) {
+	tests := []struct {
+		value Value
+		want  any
+		name  string
+	}{
+		{name: "integer", value: NewInteger(42), want: int64(42)},
+		{name: "negative integer", value: NewInteger(-7), want: int64(-7)},
+		{name: "float", value: NewFloat(3.5), want: 3.5},
+		{name: "string", value: NewString("hello"), want: "hello"},
+		{name: "empty string", value: NewString(""), want: ""},
+		{name: "boolean true", value: NewBoolean(true), want: true},
+		{name: "boolean false", value: NewBoolean(false), want: false},
+		{name: "nil", value: &NilValue{}, want: nil},
+		{
+			name: "array",
+			value: &ArrayValue{
+				ArrayType: types.NewDynamicArrayType(types.INTEGER),
+				Elements:  []Value{NewInteger(1), NewInteger(2)},
+			},
+			want: []any{int64(1), int64(2)},
+		},
+		{
+			name:  "empty array",
+			value: &ArrayValue{ArrayType: types.NewDynamicArrayType(types.VARIANT), Elements: []Value{}},
+			want:  []any{},
+		},
+		{
+			name: "nested array",
+			value: &ArrayValue{
+				ArrayType: types.NewDynamicArrayType(types.VARIANT),
+				Elements: []Value{
+					NewString("a"),
+					&ArrayValue{ArrayType: types.NewDynamicArrayType(types.BOOLEAN), Elements: []Value{NewBoolean(true)}},
+				},
+			},
+			want: []any{"a", []any{true}},
+		},
+		{
+			name: "record",
+			value: &RecordValue{Fields: map[string]Value{
+				"name": NewString("John"),
+				"tags": &ArrayValue{ArrayType: types.NewDynamicArrayType(types.STRING), Elements: []Value{NewString("x")}},
+			}},
+			want: map[string]any{"name": "John", "tags": []any{"x"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ToGo(tt.value)
+			if err != nil {
+				t.Fatalf("ToGo() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("ToGo() = %#v, want %#v", got, tt.want)
+			}
+
+			back, err := FromGo(got)
+			if err != nil {
+				t.Fatalf("FromGo() error = %v", err)
+			}
+			if back.Type() != tt.value.Type() || back.String() != tt.value.String() {
+				t.Errorf("FromGo(ToGo(v)) = %s %s, want %s %s", back.Type(), back, tt.value.Type(), tt.value)
+			}
+			again, err := ToGo(back)
+			if err != nil {
+				t.Fatalf("ToGo() of round-tripped value error = %v", err)
+			}
+			if !reflect.DeepEqual(again, tt.want) {
+				t.Errorf("ToGo(FromGo(ToGo(v))) = %#v, want %#v", again, tt.want)
+			}
+		})
+	}
+}
+
+func TestToGoDeclaredRecordFieldNames(t *testing.T) {
+	recordType := types.NewRecordType("TPoint", map[string]types.Type{"X": types.INTEGER, "Y": types.INTEGER})
+	record := &RecordValue{
+		RecordType: recordType,
+		Fields:     map[string]Value{"x": NewInteger(1), "y": NewInteger(2)},
+	}
+
+	got, err := ToGo(record)
+	if err != nil {
+		t.Fatalf("ToGo() error = %v", err)
+	}
+	if want := map[string]any{"X": int64(1), "Y": int64(2)}; !reflect.DeepEqual(got, want) {
+		t.Errorf("ToGo() = %#v, want %#v", got, want)
+	}
+}
+
+func TestToGoVariantAndEnum(t *testing.T) {
+	got, err := ToGo(BoxVariant(NewString("boxed")))
+	if err != nil || got != "boxed" {
+		t.Errorf("ToGo(variant) = %#v, %v, want \"boxed\"", got, err)
+	}
+
+	enumType := types.NewEnumType("TColor", map[string]int{"Red": 0, "Green": 1, "Blue": 5}, []string{"Red", "Green", "Blue"})
+	blue := NewEnumValue("TColor", enumType, 5)
+	got, err = ToGo(blue)
+	if err != nil || got != int64(5) {
+		t.Fatalf("ToGo(enum) = %#v, %v, want int64(5)", got, err)
+	}
+
+	for _, input := range []any{got, 5, uint8(5), "blue", "Blue"} {
+		back, err := EnumFromGo("TColor", enumType, input)
+		if err != nil {
+			t.Fatalf("EnumFromGo(%#v) error = %v", input, err)
+		}
+		if back.ValueName != "Blue" || back.OrdinalValue != 5 || back.TypeName != "TColor" {
+			t.Errorf("EnumFromGo(%#v) = %+v, want TColor.Blue", input, back)
+		}
+	}
+	for _, input := range []any{int64(3), "Purple", 1.5, nil} {
+		if _, err := EnumFromGo("TColor", enumType, input); err == nil {
+			t.Errorf("EnumFromGo(%#v) expected an error", input)
+		}
+	}
+}
+
+func TestSetGoConversion(t *testing.T) {
+	enumType := types.NewEnumType("TColor", map[string]int{"Red": 0, "Green": 1, "Blue": 5}, []string{"Red", "Green", "Blue"})
+	setType := types.NewSetType(enumType)
+	set := NewSetValue(setType)
+	set.AddElement(5)
+	set.AddElement(0)
+
+	got, err := ToGo(set)
+	if want := []any{int64(0), int64(5)}; err != nil || !reflect.DeepEqual(got, want) {
+		t.Fatalf("ToGo(set) = %#v, %v, want %#v", got, err, want)
+	}
+
+	for _, input := range []any{got, []string{"blue", "Red"}} {
+		back, err := FromGoAs(input, setType)
+		if err != nil {
+			t.Fatalf("FromGoAs(%#v) error = %v", input, err)
+		}
+		if back.String() != "[Red, Blue]" {
+			t.Errorf("FromGoAs(%#v) = %s, want [Red, Blue]", input, back)
+		}
+	}
+	if _, err := FromGoAs([]any{int64(3)}, setType); err == nil {
+		t.Error("FromGoAs with an invalid ordinal expected an error")
+	}
+}
+
+func TestGoTypeAndFromGoAs(t *testing.T) {
+	input := map[string]any{"Name": "Ann", "Scores": []float64{1.5}}
+	typ, err := GoType(input)
+	if err != nil {
+		t.Fatalf("GoType() error = %v", err)
+	}
+	value, err := FromGoAs(input, typ)
+	if err != nil {
+		t.Fatalf("FromGoAs() error = %v", err)
+	}
+	got, err := ToGo(value)
+	if want := map[string]any{"Name": "Ann", "Scores": []any{1.5}}; err != nil || !reflect.DeepEqual(got, want) {
+		t.Errorf("ToGo(FromGoAs(GoType())) = %#v, %v, want %#v", got, err, want)
+	}
+
+	if v, err := FromGoAs(3, types.FLOAT); err != nil || v.(*FloatValue).Value != 3 {
+		t.Errorf("FromGoAs(3, Float) = %v, %v, want 3.0", v, err)
+	}
+	for _, input := range []any{nil, struct{}{}, map[int]int{}, []any{}} {
+		if _, err := GoType(input); err == nil {
+			t.Errorf("GoType(%#v) expected an error", input)
+		}
+	}
+	if _, err := FromGoAs("x", types.INTEGER); err == nil {
+		t.Error("FromGoAs(string, Integer) expected an error")
+	}
+}
+
+func TestFromGoKinds(t *testing.T) {
+	var nilPtr *int
+	var nilSlice []string
+	tests := []struct {
+		input    any
+		wantType string
+		want     string
+		name     string
+	}{
+		{name: "int", input: 7, wantType: "INTEGER", want: "7"},
+		{name: "int8", input: int8(-8), wantType: "INTEGER", want: "-8"},
+		{name: "int32", input: int32(32), wantType: "INTEGER", want: "32"},
+		{name: "uint16", input: uint16(16), wantType: "INTEGER", want: "16"},
+		{name: "uint64", input: uint64(64), wantType: "INTEGER", want: "64"},
+		{name: "float32", input: float32(0.5), wantType: "FLOAT", want: "0.5"},
+		{name: "nil pointer", input: nilPtr, wantType: "NIL", want: "nil"},
+		{name: "runtime value", input: NewString("as is"), wantType: "STRING", want: "as is"},
+		{name: "Go array", input: [2]bool{true, false}, wantType: "ARRAY", want: "[True, False]"},
+		{name: "nil slice", input: nilSlice, wantType: "ARRAY", want: "[]"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := FromGo(tt.input)
+			if err != nil {
+				t.Fatalf("FromGo() error = %v", err)
+			}
+			if got.Type() != tt.wantType || got.String() != tt.want {
+				t.Errorf("FromGo() = %s %s, want %s %s", got.Type(), got, tt.wantType, tt.want)
+			}
+		})
+	}
+}
+
+func TestFromGoArrayElementType(t *testing.T) {
+	tests := []struct {
+		input any
+		want  types.Type
+		name  string
+	}{
+		{name: "typed slice", input: []float64{1}, want: types.FLOAT},
+		{name: "empty typed slice", input: []string{}, want: types.STRING},
+		{name: "uniform any slice", input: []any{int64(1), 2}, want: types.INTEGER},
+		{name: "mixed any slice", input: []any{1, "a"}, want: types.VARIANT},
+		{name: "empty any slice", input: []any{}, want: types.VARIANT},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := FromGo(tt.input)
+			if err != nil {
+				t.Fatalf("FromGo() error = %v", err)
+			}
+			arr, ok := got.(*ArrayValue)
+			if !ok {
+				t.Fatalf("FromGo() = %T, want *ArrayValue", got)
+			}
+			if !arr.ArrayType.IsDynamic() || !arr.ArrayType.ElementType.Equals(tt.want) {
+				t.Errorf("array type = %s, want array of %s", arr.ArrayType, tt.want)
+			}
+		})
+	}
+}
+
+func TestGoConversionErrors(t *testing.T) {
+	if _, err := ToGo(&ObjectInstance{}); err == nil {
+		t.Error("ToGo(object) expected an error")
+	}
+	if _, err := ToGo(&ArrayValue{Elements: []Value{&ObjectInstance{}}}); err == nil {
+		t.Error("ToGo(array of objects) expected an error")
+	}
+	for _, input := range []any{complex64(1 + 2i), map[int]string{1: "x"}, uint64(1 << 63), struct{}{}, []any{func() {}}} {
+		if _, err := FromGo(input); err == nil {
+			t.Errorf("FromGo(%T) expected an error", input)
+		}
+	}
+}
@@ -0,0 +1,30 @@
+package runtime
+
+import "math"
+
+// CheckedIntegerArithmetic applies op (+, - or *) to two Integers using
+// two's complement arithmetic and reports whether the exact result is outside
+// the 64-bit Integer range. The wrapped result is always returned, so callers
+// that do not check for overflow can use it as-is. Operators other than
+// +, - and * return (0, false).
+func CheckedIntegerArithmetic(op string, left, right int64) (int64, bool) {
+	switch op {
+	case "+":
+		result := left + right
+		return result, (left > 0 && right > 0 && result < 0) || (left < 0 && right < 0 && result >= 0)
+	case "-":
+		result := left - right
+		return result, (left >= 0 && right < 0 && result < 0) || (left < 0 && right > 0 && result >= 0)
+	case "*":
+		result := left * right
+		return result, left != 0 && (result/left != right || (left == -1 && right == math.MinInt64))
+	default:
+		return 0, false
+	}
+}
+
+// CheckedIntegerNegate negates an Integer and reports whether the result
+// overflows, which only happens for the minimum 64-bit Integer.
+func CheckedIntegerNegate(value int64) (int64, bool) {
+	return -value, value == math.MinInt64
+}
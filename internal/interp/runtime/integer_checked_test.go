@@ -0,0 +1,48 @@
+package runtime
+
+import (
+	"math"
+	"testing"
+)
+
+func TestCheckedIntegerArithmetic(t *testing.T) {
+	tests := []struct {
+		name     string
+		op       string
+		left     int64
+		right    int64
+		want     int64
+		overflow bool
+	}{
+		{name: "add", op: "+", left: 2, right: 3, want: 5},
+		{name: "add overflow", op: "+", left: math.MaxInt64, right: 1, want: math.MinInt64, overflow: true},
+		{name: "add negative overflow", op: "+", left: math.MinInt64, right: -1, want: math.MaxInt64, overflow: true},
+		{name: "sub", op: "-", left: 2, right: 3, want: -1},
+		{name: "sub overflow", op: "-", left: math.MinInt64, right: 1, want: math.MaxInt64, overflow: true},
+		{name: "sub zero minus MinInt64", op: "-", left: 0, right: math.MinInt64, want: math.MinInt64, overflow: true},
+		{name: "mul", op: "*", left: -4, right: 5, want: -20},
+		{name: "mul overflow", op: "*", left: math.MaxInt64, right: 2, want: -2, overflow: true},
+		{name: "mul -1 by MinInt64", op: "*", left: -1, right: math.MinInt64, want: math.MinInt64, overflow: true},
+		{name: "mul MinInt64 by -1", op: "*", left: math.MinInt64, right: -1, want: math.MinInt64, overflow: true},
+		{name: "unsupported operator", op: "div", left: 4, right: 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, overflow := CheckedIntegerArithmetic(tt.op, tt.left, tt.right)
+			if got != tt.want || overflow != tt.overflow {
+				t.Errorf("CheckedIntegerArithmetic(%q, %d, %d) = (%d, %v), want (%d, %v)",
+					tt.op, tt.left, tt.right, got, overflow, tt.want, tt.overflow)
+			}
+		})
+	}
+}
+
+func TestCheckedIntegerNegate(t *testing.T) {
+	if got, overflow := CheckedIntegerNegate(5); got != -5 || overflow {
+		t.Errorf("CheckedIntegerNegate(5) = (%d, %v), want (-5, false)", got, overflow)
+	}
+	if got, overflow := CheckedIntegerNegate(math.MinInt64); got != math.MinInt64 || !overflow {
+		t.Errorf("CheckedIntegerNegate(MinInt64) = (%d, %v), want (MinInt64, true)", got, overflow)
+	}
+}
@@ -3,7 +3,6 @@ package runtime
 import (
 	"encoding/json"
 	"fmt"
-	"strings"
 
 	"github.com/cwbudde/go-dws/internal/jsonvalue"
 )
@@ -80,8 +79,8 @@ func valueToJSONValueUnwrapped(val Value) *jsonvalue.Value {
 		return arr
 	case *RecordValue:
 		obj := jsonvalue.NewObject()
-		for fieldName, fieldValue := range v.Fields {
-			obj.ObjectSet(fieldName, ValueToJSONValue(fieldValue))
+		for _, fieldName := range v.OrderedFieldNames() {
+			obj.ObjectSet(fieldName, ValueToJSONValue(v.Fields[fieldName]))
 		}
 		return obj
 	case *JSONValue:
@@ -120,13 +119,11 @@ func JSONKindToVarType(kind jsonvalue.Kind) int64 {
 
 // ParseJSONString parses a JSON string and returns a jsonvalue.Value.
 func ParseJSONString(jsonStr string) (*jsonvalue.Value, error) {
-	var data any
-	decoder := json.NewDecoder(strings.NewReader(jsonStr))
-	decoder.UseNumber()
-	if err := decoder.Decode(&data); err != nil {
+	jsonVal, err := jsonvalue.Parse(jsonStr)
+	if err != nil {
 		return nil, FormatJSONError(err, jsonStr)
 	}
-	return GoValueToJSONValue(data), nil
+	return jsonVal, nil
 }
 
 // GoValueToJSONValue converts encoding/json decoded values to jsonvalue.Value.
@@ -83,9 +83,9 @@ func FieldMetadataFromAST(field *ast.FieldDecl) *FieldMetadata {
 	// collapses default/published/public), so this preserves the private and
 	// protected distinctions consumers such as JSON serialization rely on.
 	switch field.Visibility {
-	case ast.VisibilityPrivate:
+	case ast.VisibilityPrivate, ast.VisibilityStrictPrivate:
 		metadata.Visibility = FieldVisibilityPrivate
-	case ast.VisibilityProtected:
+	case ast.VisibilityProtected, ast.VisibilityStrictProtected:
 		metadata.Visibility = FieldVisibilityProtected
 	default:
 		metadata.Visibility = FieldVisibilityPublic
@@ -16,52 +16,74 @@ import (
 // BooleanValue uses singletons instead (only two possible values)
 // Not pooled: StringValue (variable size), complex types (less frequent)
 //
+// Each engine owns a ValuePool, so pooling can be disabled for one engine
+// without affecting the others. The package-level functions use a shared
+// default pool that is always enabled.
+//
 // Usage:
-//   val := NewInteger(42)  // Gets from pool if available
+//   val := pool.NewInteger(42)  // Gets from pool if available
 //   ... use val ...
-//   ReleaseInteger(val)    // Returns to pool for reuse
+//   pool.ReleaseInteger(val)    // Returns to pool for reuse
 //
 // The Release functions are optional - values will be garbage collected normally
 // if not explicitly released. Pools are primarily beneficial in tight loops.
 // ============================================================================
 
-var (
-	// Object pools for primitive value types
-	integerPool = sync.Pool{
-		New: func() interface{} {
-			poolStats.integerAllocs.Add(1)
-			return &IntegerValue{}
-		},
-	}
+// ValuePool hands out Integer and Float values, reusing released ones. A
+// disabled pool allocates every value afresh and drops released values,
+// which helps to tell whether a bug is caused by a value being reused while
+// still referenced. A ValuePool is safe for concurrent use.
+type ValuePool struct {
+	integers sync.Pool
+	floats   sync.Pool
+	disabled bool
+
+	integerAllocs atomic.Uint64
+	integerGets   atomic.Uint64
+	integerPuts   atomic.Uint64
+
+	floatAllocs atomic.Uint64
+	floatGets   atomic.Uint64
+	floatPuts   atomic.Uint64
+}
 
-	floatPool = sync.Pool{
-		New: func() interface{} {
-			poolStats.floatAllocs.Add(1)
-			return &FloatValue{}
-		},
+// NewValuePool creates a value pool. With enabled false, the pool never
+// reuses a value.
+func NewValuePool(enabled bool) *ValuePool {
+	p := &ValuePool{disabled: !enabled}
+	p.integers.New = func() interface{} {
+		p.integerAllocs.Add(1)
+		return &IntegerValue{}
 	}
+	p.floats.New = func() interface{} {
+		p.floatAllocs.Add(1)
+		return &FloatValue{}
+	}
+	return p
+}
 
-	// Pool statistics for monitoring
-	poolStats = struct {
-		integerAllocs atomic.Uint64
-		integerGets   atomic.Uint64
-		integerPuts   atomic.Uint64
+// Enabled reports whether the pool reuses released values.
+func (p *ValuePool) Enabled() bool {
+	return !p.disabled
+}
 
-		floatAllocs atomic.Uint64
-		floatGets   atomic.Uint64
-		floatPuts   atomic.Uint64
-	}{}
-)
+// defaultPool backs the package-level pooling functions.
+var defaultPool = NewValuePool(true)
 
 // ============================================================================
 // Integer Value Pooling
 // ============================================================================
 
-// NewInteger creates a new IntegerValue, potentially reusing a pooled instance.
-// This is more efficient than &IntegerValue{Value: v} for frequently allocated values.
-func NewInteger(value int64) *IntegerValue {
-	poolStats.integerGets.Add(1)
-	v := integerPool.Get().(*IntegerValue)
+// NewInteger creates a new IntegerValue, reusing a released instance when
+// the pool is enabled. A disabled pool counts every value as an alloc and
+// none as a get.
+func (p *ValuePool) NewInteger(value int64) *IntegerValue {
+	if p.disabled {
+		p.integerAllocs.Add(1)
+		return &IntegerValue{Value: value}
+	}
+	p.integerGets.Add(1)
+	v := p.integers.Get().(*IntegerValue)
 	v.Value = value
 	return v
 }
@@ -69,35 +91,61 @@ func NewInteger(value int64) *IntegerValue {
 // ReleaseInteger returns an IntegerValue to the pool for reuse.
 // This is optional - if not called, the value will be garbage collected normally.
 // Only call this when you're certain the value is no longer needed.
-func ReleaseInteger(v *IntegerValue) {
-	if v != nil {
+func (p *ValuePool) ReleaseInteger(v *IntegerValue) {
+	if v != nil && !p.disabled {
 		v.Value = 0 // Clear for safety
-		poolStats.integerPuts.Add(1)
-		integerPool.Put(v)
+		p.integerPuts.Add(1)
+		p.integers.Put(v)
 	}
 }
 
+// NewInteger creates a new IntegerValue from the default pool.
+// This is more efficient than &IntegerValue{Value: v} for frequently allocated values.
+func NewInteger(value int64) *IntegerValue {
+	return defaultPool.NewInteger(value)
+}
+
+// ReleaseInteger returns an IntegerValue to the default pool for reuse.
+func ReleaseInteger(v *IntegerValue) {
+	defaultPool.ReleaseInteger(v)
+}
+
 // ============================================================================
 // Float Value Pooling
 // ============================================================================
 
-// NewFloat creates a new FloatValue, potentially reusing a pooled instance.
-func NewFloat(value float64) *FloatValue {
-	poolStats.floatGets.Add(1)
-	v := floatPool.Get().(*FloatValue)
+// NewFloat creates a new FloatValue, reusing a released instance when the
+// pool is enabled.
+func (p *ValuePool) NewFloat(value float64) *FloatValue {
+	if p.disabled {
+		p.floatAllocs.Add(1)
+		return &FloatValue{Value: value}
+	}
+	p.floatGets.Add(1)
+	v := p.floats.Get().(*FloatValue)
 	v.Value = value
 	return v
 }
 
 // ReleaseFloat returns a FloatValue to the pool for reuse.
-func ReleaseFloat(v *FloatValue) {
-	if v != nil {
+func (p *ValuePool) ReleaseFloat(v *FloatValue) {
+	if v != nil && !p.disabled {
 		v.Value = 0.0 // Clear for safety
-		poolStats.floatPuts.Add(1)
-		floatPool.Put(v)
+		p.floatPuts.Add(1)
+		p.floats.Put(v)
 	}
 }
 
+// NewFloat creates a new FloatValue from the default pool.
+func NewFloat(value float64) *FloatValue {
+	return defaultPool.NewFloat(value)
+}
+
+// ReleaseFloat returns a FloatValue to the default pool for reuse.
+func ReleaseFloat(v *FloatValue) {
+	defaultPool.ReleaseFloat(v)
+}
+
 // ============================================================================
 // Boolean Value Pooling
 // ============================================================================
@@ -139,9 +187,9 @@ func NewString(value string) *StringValue {
 // Pool Statistics
 // ============================================================================
 
-// PoolStats holds statistics about value pool usage.
-type PoolStats struct {
-	IntegerAllocs uint64 // Total allocations (pool misses)
+// PoolStatistics holds statistics about value pool usage.
+type PoolStatistics struct {
+	IntegerAllocs uint64 // Total allocations (pool misses and unpooled values)
 	IntegerGets   uint64 // Total gets from pool
 	IntegerPuts   uint64 // Total returns to pool
 
@@ -150,36 +198,53 @@ type PoolStats struct {
 	FloatPuts   uint64
 }
 
-// GetPoolStats returns current pool statistics.
-// Useful for monitoring and debugging pool effectiveness.
-func GetPoolStats() PoolStats {
-	return PoolStats{
-		IntegerAllocs: poolStats.integerAllocs.Load(),
-		IntegerGets:   poolStats.integerGets.Load(),
-		IntegerPuts:   poolStats.integerPuts.Load(),
-
-		FloatAllocs: poolStats.floatAllocs.Load(),
-		FloatGets:   poolStats.floatGets.Load(),
-		FloatPuts:   poolStats.floatPuts.Load(),
+// Stats returns the pool's current statistics.
+func (p *ValuePool) Stats() PoolStatistics {
+	return PoolStatistics{
+		IntegerAllocs: p.integerAllocs.Load(),
+		IntegerGets:   p.integerGets.Load(),
+		IntegerPuts:   p.integerPuts.Load(),
+
+		FloatAllocs: p.floatAllocs.Load(),
+		FloatGets:   p.floatGets.Load(),
+		FloatPuts:   p.floatPuts.Load(),
 	}
 }
 
-// ResetPoolStats resets pool statistics to zero.
+// ResetStats resets the pool's statistics to zero.
+func (p *ValuePool) ResetStats() {
+	p.integerAllocs.Store(0)
+	p.integerGets.Store(0)
+	p.integerPuts.Store(0)
+
+	p.floatAllocs.Store(0)
+	p.floatGets.Store(0)
+	p.floatPuts.Store(0)
+}
+
+// PoolStats returns the default pool's current statistics.
+// Useful for monitoring and debugging pool effectiveness.
+func PoolStats() PoolStatistics {
+	return defaultPool.Stats()
+}
+
+// GetPoolStats returns the default pool's current statistics.
+//
+// Deprecated: use PoolStats.
+func GetPoolStats() PoolStatistics {
+	return PoolStats()
+}
+
+// ResetPoolStats resets the default pool's statistics to zero.
 // Useful for benchmarking and testing.
 func ResetPoolStats() {
-	poolStats.integerAllocs.Store(0)
-	poolStats.integerGets.Store(0)
-	poolStats.integerPuts.Store(0)
-
-	poolStats.floatAllocs.Store(0)
-	poolStats.floatGets.Store(0)
-	poolStats.floatPuts.Store(0)
+	defaultPool.ResetStats()
 }
 
 // PoolEfficiency returns the pool hit rate as a percentage (0-100).
 // A higher percentage means the pool is more effective at reusing values.
 // Formula: (Gets - Allocs) / Gets * 100
-func (s PoolStats) PoolEfficiency() (integer, float float64) {
+func (s PoolStatistics) PoolEfficiency() (integer, float float64) {
 	intEff := 0.0
 	if s.IntegerGets > 0 {
 		intEff = float64(s.IntegerGets-s.IntegerAllocs) / float64(s.IntegerGets) * 100
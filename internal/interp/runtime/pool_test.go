@@ -24,7 +24,7 @@ func TestIntegerPool(t *testing.T) {
 		t.Log("Note: Pool may not reuse on first get (this is OK)")
 	}
 
-	stats := GetPoolStats()
+	stats := PoolStats()
 	if stats.IntegerGets != 2 {
 		t.Errorf("Expected 2 gets, got %d", stats.IntegerGets)
 	}
@@ -47,7 +47,7 @@ func TestFloatPool(t *testing.T) {
 		t.Errorf("Expected value 2.71, got %f", v2.Value)
 	}
 
-	stats := GetPoolStats()
+	stats := PoolStats()
 	if stats.FloatGets != 2 {
 		t.Errorf("Expected 2 gets, got %d", stats.FloatGets)
 	}
@@ -97,7 +97,7 @@ func TestPoolStats(t *testing.T) {
 		}
 	}
 
-	stats := GetPoolStats()
+	stats := PoolStats()
 	if stats.IntegerGets != 10 {
 		t.Errorf("Expected 10 gets, got %d", stats.IntegerGets)
 	}
@@ -112,6 +112,93 @@ func TestPoolStats(t *testing.T) {
 	}
 }
 
+func TestPoolStatsCounters(t *testing.T) {
+	ResetPoolStats()
+
+	ReleaseInteger(NewInteger(1))
+	ReleaseFloat(NewFloat(1))
+	NewFloat(2)
+	if _, err := AddNumeric(NewInteger(2), NewInteger(3)); err != nil {
+		t.Fatalf("AddNumeric failed: %v", err)
+	}
+
+	stats := PoolStats()
+	if stats.IntegerGets != 4 || stats.IntegerPuts != 1 {
+		t.Errorf("integer gets/puts = %d/%d, want 4/1", stats.IntegerGets, stats.IntegerPuts)
+	}
+	if stats.FloatGets != 2 || stats.FloatPuts != 1 {
+		t.Errorf("float gets/puts = %d/%d, want 2/1", stats.FloatGets, stats.FloatPuts)
+	}
+	if stats.IntegerAllocs > stats.IntegerGets || stats.FloatAllocs > stats.FloatGets {
+		t.Errorf("allocs exceed gets: %+v", stats)
+	}
+
+	ResetPoolStats()
+	if stats := PoolStats(); stats != (PoolStatistics{}) {
+		t.Errorf("stats after reset = %+v, want zero", stats)
+	}
+}
+
+func TestDisabledValuePool(t *testing.T) {
+	pool := NewValuePool(false)
+	if pool.Enabled() {
+		t.Fatal("pool should be disabled")
+	}
+
+	// Released values are dropped, so every value is a fresh allocation.
+	seen := make(map[*IntegerValue]bool)
+	for i := 0; i < 10; i++ {
+		v := pool.NewInteger(int64(i))
+		if seen[v] {
+			t.Fatalf("value %d reuses a released instance", i)
+		}
+		seen[v] = true
+		pool.ReleaseInteger(v)
+		if v.Value != int64(i) {
+			t.Errorf("released value was cleared to %d, want %d", v.Value, i)
+		}
+	}
+	f := pool.NewFloat(1.5)
+	pool.ReleaseFloat(f)
+	if g := pool.NewFloat(2.5); g == f {
+		t.Error("float reuses a released instance")
+	}
+
+	stats := pool.Stats()
+	if stats.IntegerGets != 0 || stats.IntegerAllocs != 10 || stats.IntegerPuts != 0 {
+		t.Errorf("integer gets/allocs/puts = %d/%d/%d, want 0/10/0",
+			stats.IntegerGets, stats.IntegerAllocs, stats.IntegerPuts)
+	}
+	if stats.FloatGets != 0 || stats.FloatAllocs != 2 || stats.FloatPuts != 0 {
+		t.Errorf("float gets/allocs/puts = %d/%d/%d, want 0/2/0",
+			stats.FloatGets, stats.FloatAllocs, stats.FloatPuts)
+	}
+
+	// The default pool is not affected.
+	v := NewInteger(7)
+	ReleaseInteger(v)
+	if v.Value != 0 {
+		t.Errorf("released pooled value = %d, want it cleared", v.Value)
+	}
+}
+
+func TestValuePoolStatsArePerPool(t *testing.T) {
+	a, b := NewValuePool(true), NewValuePool(true)
+	a.ReleaseInteger(a.NewInteger(1))
+	a.NewFloat(2)
+
+	if stats := a.Stats(); stats.IntegerGets != 1 || stats.IntegerPuts != 1 || stats.FloatGets != 1 {
+		t.Errorf("pool a stats = %+v", stats)
+	}
+	if stats := b.Stats(); stats != (PoolStatistics{}) {
+		t.Errorf("pool b stats = %+v, want zero", stats)
+	}
+	a.ResetStats()
+	if stats := a.Stats(); stats != (PoolStatistics{}) {
+		t.Errorf("stats after reset = %+v, want zero", stats)
+	}
+}
+
 func TestPoolNilSafety(t *testing.T) {
 	// Releasing nil should not panic
 	ReleaseInteger(nil)
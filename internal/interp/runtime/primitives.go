@@ -4,7 +4,9 @@ import (
 	"fmt"
 	"math"
 	"strconv"
+	"unicode/utf8"
 
+	"github.com/cwbudde/go-dws/internal/lexer"
 	"github.com/cwbudde/go-dws/internal/types"
 	"github.com/cwbudde/go-dws/pkg/ast"
 )
@@ -223,8 +225,19 @@ func (f *FloatValue) ConvertTo(targetType string) (Value, error) {
 // ============================================================================
 
 // StringValue represents a string value in DWScript.
+//
+// Value holds UTF-8 text; Length, indexing and the other character-based
+// operations count Unicode code points, so s[i] is the i-th code point.
 type StringValue struct {
 	Value string
+
+	// runes caches the code points of runesOf so that indexing the same
+	// string repeatedly (e.g. in a loop) does not decode it every time.
+	// The cache is rebuilt whenever Value no longer matches runesOf.
+	runes   []rune
+	runesOf string
+	ascii   bool
+	cached  bool
 }
 
 // Type returns "STRING".
@@ -263,13 +276,60 @@ func (s *StringValue) Copy() Value {
 	return &StringValue{Value: s.Value}
 }
 
+// refreshRunes rebuilds the code point cache when Value has changed.
+// ASCII-only strings are indexed by byte and need no rune slice.
+func (s *StringValue) refreshRunes() {
+	if s.cached && s.runesOf == s.Value {
+		return
+	}
+	s.runesOf = s.Value
+	s.cached = true
+	s.ascii = isASCII(s.Value)
+	s.runes = nil
+	if !s.ascii {
+		s.runes = []rune(s.Value)
+	}
+}
+
+// RuneLength returns the number of code points in the string.
+func (s *StringValue) RuneLength() int {
+	s.refreshRunes()
+	if s.ascii {
+		return len(s.Value)
+	}
+	return len(s.runes)
+}
+
+// RuneAt returns the code point at the given 1-based index, and false when
+// the index is out of range.
+func (s *StringValue) RuneAt(index int) (rune, bool) {
+	if index < 1 || index > s.RuneLength() {
+		return 0, false
+	}
+	if s.ascii {
+		return rune(s.Value[index-1]), true
+	}
+	return s.runes[index-1], true
+}
+
+// isASCII reports whether str contains only single-byte characters.
+func isASCII(str string) bool {
+	for i := 0; i < len(str); i++ {
+		if str[i] >= utf8.RuneSelf {
+			return false
+		}
+	}
+	return true
+}
+
 // GetIndex retrieves a character at the specified index (1-based, DWScript convention).
 func (s *StringValue) GetIndex(index int64) (Value, error) {
-	// DWScript uses 1-based indexing
-	if index < 1 || index > int64(len(s.Value)) {
-		return nil, fmt.Errorf("string index %d out of range [1..%d]", index, len(s.Value))
+	// DWScript uses 1-based indexing over code points
+	r, ok := s.RuneAt(int(index))
+	if !ok {
+		return nil, fmt.Errorf("string index %d out of range [1..%d]", index, s.RuneLength())
 	}
-	return &StringValue{Value: string(s.Value[index-1])}, nil
+	return &StringValue{Value: string(r)}, nil
 }
 
 // SetIndex is not supported for strings (they are immutable).
@@ -277,9 +337,9 @@ func (s *StringValue) SetIndex(index int64, value Value) error {
 	return fmt.Errorf("cannot modify string: strings are immutable")
 }
 
-// Length returns the length of the string.
+// Length returns the length of the string in code points.
 func (s *StringValue) Length() int64 {
-	return int64(len(s.Value))
+	return int64(s.RuneLength())
 }
 
 // ConvertTo converts the string to the target type.
@@ -597,6 +657,16 @@ func (f *FunctionPointerValue) String() string {
 // ErrorValue represents an error runtime value returned by builtin functions.
 type ErrorValue struct {
 	Message string
+	// ExceptionClass is the DWScript exception class (e.g. "EDivByZero")
+	// raised when this error is converted into a script exception or
+	// reported as uncaught. Empty means the base Exception class.
+	ExceptionClass string
+	// Pos is where the error was raised, when known. Its Source names the
+	// file for code spliced in by {$INCLUDE}.
+	Pos *lexer.Position
+	// Detail is the uncaught exception's extra information, when it has any
+	// (see ExceptionValue.Detail).
+	Detail string
 }
 
 // Type returns "ERROR".
@@ -0,0 +1,34 @@
+package runtime
+
+import "testing"
+
+func TestStringValueRuneIndexing(t *testing.T) {
+	s := &StringValue{Value: "héllo€"}
+	if got := s.RuneLength(); got != 6 {
+		t.Errorf("RuneLength() = %d, want 6", got)
+	}
+	if r, ok := s.RuneAt(2); !ok || r != 'é' {
+		t.Errorf("RuneAt(2) = %q, %v, want 'é'", r, ok)
+	}
+	if r, ok := s.RuneAt(6); !ok || r != '€' {
+		t.Errorf("RuneAt(6) = %q, %v, want '€'", r, ok)
+	}
+	if _, ok := s.RuneAt(7); ok {
+		t.Error("RuneAt(7) should be out of range")
+	}
+	if got, err := s.GetIndex(2); err != nil || got.String() != "é" {
+		t.Errorf("GetIndex(2) = %v, %v, want é", got, err)
+	}
+	if got := s.Length(); got != 6 {
+		t.Errorf("Length() = %d, want 6", got)
+	}
+
+	// The cached code points follow changes to Value.
+	s.Value = "abc"
+	if got := s.RuneLength(); got != 3 {
+		t.Errorf("RuneLength() after update = %d, want 3", got)
+	}
+	if r, ok := s.RuneAt(3); !ok || r != 'c' {
+		t.Errorf("RuneAt(3) after update = %q, %v, want 'c'", r, ok)
+	}
+}
@@ -56,15 +56,8 @@ func (r *RecordValue) String() string {
 		sb.WriteString("record(")
 	}
 
-	// Sort field names for consistent output
-	fieldNames := make([]string, 0, len(r.Fields))
-	for name := range r.Fields {
-		fieldNames = append(fieldNames, name)
-	}
-	sort.Strings(fieldNames)
-
-	// Add field values
-	for i, name := range fieldNames {
+	// Add field values in declaration order
+	for i, name := range r.OrderedFieldNames() {
 		if i > 0 {
 			sb.WriteString(", ")
 		}
@@ -81,6 +74,35 @@ func (r *RecordValue) String() string {
 	return sb.String()
 }
 
+// OrderedFieldNames returns the keys of r.Fields in the record type's field
+// declaration order. Keys unknown to the record type (or all keys, for a
+// record without type information) follow in sorted order, so printing and
+// serializing a record is deterministic.
+func (r *RecordValue) OrderedFieldNames() []string {
+	names := make([]string, 0, len(r.Fields))
+	seen := make(map[string]bool, len(r.Fields))
+	if r.RecordType != nil {
+		for _, name := range r.RecordType.OrderedFieldNames() {
+			if _, ok := r.Fields[name]; ok {
+				names = append(names, name)
+				seen[name] = true
+			}
+		}
+	}
+	if len(names) == len(r.Fields) {
+		return names
+	}
+
+	extra := make([]string, 0, len(r.Fields)-len(names))
+	for name := range r.Fields {
+		if !seen[name] {
+			extra = append(extra, name)
+		}
+	}
+	sort.Strings(extra)
+	return append(names, extra...)
+}
+
 // Copy creates a deep copy of the record value.
 // Records have value semantics in DWScript, so assignment should copy.
 func (r *RecordValue) Copy() Value {
@@ -1,6 +1,8 @@
 package runtime
 
 import (
+	"sync"
+
 	"github.com/cwbudde/go-dws/pkg/ast"
 	"github.com/cwbudde/go-dws/pkg/ident"
 )
@@ -11,7 +13,6 @@ func (r *RecordTypeValue) RegisterMethodImplementation(fn *ast.FunctionDecl) {
 	}
 
 	normalizedMethodName := ident.Normalize(fn.Name.Value)
-	methodMeta := MethodMetadataFromAST(fn)
 
 	if fn.IsClassMethod {
 		r.ClassMethods[normalizedMethodName] = fn
@@ -19,6 +20,7 @@ func (r *RecordTypeValue) RegisterMethodImplementation(fn *ast.FunctionDecl) {
 		r.ClassMethodOverloads[normalizedMethodName] = replaceMethodOverloadList(overloads, fn)
 
 		if r.Metadata != nil {
+			methodMeta := MethodMetadataFromAST(fn)
 			r.Metadata.StaticMethods[normalizedMethodName] = methodMeta
 			r.Metadata.StaticMethodOverloads[normalizedMethodName] = replaceMethodMetadataOverloadList(
 				r.Metadata.StaticMethodOverloads[normalizedMethodName],
@@ -33,6 +35,7 @@ func (r *RecordTypeValue) RegisterMethodImplementation(fn *ast.FunctionDecl) {
 	r.MethodOverloads[normalizedMethodName] = replaceMethodOverloadList(overloads, fn)
 
 	if r.Metadata != nil {
+		methodMeta := MethodMetadataFromAST(fn)
 		r.Metadata.Methods[normalizedMethodName] = methodMeta
 		r.Metadata.MethodOverloads[normalizedMethodName] = replaceMethodMetadataOverloadList(
 			r.Metadata.MethodOverloads[normalizedMethodName],
@@ -44,6 +47,7 @@ func (r *RecordTypeValue) RegisterMethodImplementation(fn *ast.FunctionDecl) {
 func replaceMethodOverloadList(list []*ast.FunctionDecl, impl *ast.FunctionDecl) []*ast.FunctionDecl {
 	for idx, decl := range list {
 		if parametersMatchAST(decl.Parameters, impl.Parameters) {
+			MergeParameterDefaults(impl, decl)
 			list[idx] = impl
 			return list
 		}
@@ -51,6 +55,27 @@ func replaceMethodOverloadList(list []*ast.FunctionDecl, impl *ast.FunctionDecl)
 	return append(list, impl)
 }
 
+// mergeDefaultsMu serializes MergeParameterDefaults. The AST is shared by
+// all runs of a compiled program, and the first run to register an
+// implementation writes the defaults that later runs only read.
+var mergeDefaultsMu sync.Mutex
+
+// MergeParameterDefaults copies parameter default values from a declaration
+// into an out-of-line implementation that did not respecify them, so callers
+// can still omit those arguments. It is safe for concurrent use.
+func MergeParameterDefaults(impl, decl *ast.FunctionDecl) {
+	if impl == nil || decl == nil || len(impl.Parameters) != len(decl.Parameters) {
+		return
+	}
+	mergeDefaultsMu.Lock()
+	defer mergeDefaultsMu.Unlock()
+	for i, declParam := range decl.Parameters {
+		if impl.Parameters[i].DefaultValue == nil && declParam.DefaultValue != nil {
+			impl.Parameters[i].DefaultValue = declParam.DefaultValue
+		}
+	}
+}
+
 func replaceMethodMetadataOverloadList(list []*MethodMetadata, impl *MethodMetadata) []*MethodMetadata {
 	if impl == nil {
 		return list
@@ -0,0 +1,65 @@
+package runtime
+
+import (
+	"testing"
+
+	"github.com/cwbudde/go-dws/internal/types"
+)
+
+func TestRecordValueStringFieldOrder(t *testing.T) {
+	rt := types.NewRecordType("TPerson", map[string]types.Type{})
+	rt.AddField("Name", types.STRING, false)
+	rt.AddField("Age", types.INTEGER, false)
+	rt.AddField("Email", types.STRING, false)
+	rt.AddField("Active", types.BOOLEAN, false)
+
+	rec := &RecordValue{
+		RecordType: rt,
+		Fields: map[string]Value{
+			"name":   &StringValue{Value: "Ann"},
+			"age":    &IntegerValue{Value: 42},
+			"email":  &StringValue{Value: "ann@example.com"},
+			"active": &BooleanValue{Value: true},
+		},
+	}
+
+	first := rec.String()
+	if want := "TPerson(name: Ann, age: 42, email: ann@example.com, active: True)"; first != want {
+		t.Errorf("String() = %q, want %q", first, want)
+	}
+	for i := 0; i < 10; i++ {
+		if got := rec.String(); got != first {
+			t.Fatalf("String() is not deterministic: %q vs %q", got, first)
+		}
+	}
+}
+
+func TestRecordValueStringWithoutType(t *testing.T) {
+	rec := &RecordValue{
+		Fields: map[string]Value{
+			"y": &IntegerValue{Value: 2},
+			"x": &IntegerValue{Value: 1},
+		},
+	}
+	if got, want := rec.String(), "record(x: 1, y: 2)"; got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+}
+
+func TestSetValueStringAscendingOrder(t *testing.T) {
+	setType := &types.SetType{ElementType: types.INTEGER, StorageKind: types.SetStorageMap}
+	set := NewSetValue(setType)
+	for _, ordinal := range []int{300, 7, 150, -3, 64} {
+		set.AddElement(ordinal)
+	}
+
+	first := set.String()
+	if want := "[-3, 7, 64, 150, 300]"; first != want {
+		t.Errorf("String() = %q, want %q", first, want)
+	}
+	for i := 0; i < 10; i++ {
+		if got := set.String(); got != first {
+			t.Fatalf("String() is not deterministic: %q vs %q", got, first)
+		}
+	}
+}
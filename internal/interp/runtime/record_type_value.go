@@ -21,6 +21,9 @@ type RecordTypeValue struct {
 	ClassMethodOverloads map[string][]*ast.FunctionDecl
 	Constants            map[string]Value
 	ClassVars            map[string]Value
+	// Operators holds the class operators declared in the record, keyed by
+	// normalized operator symbol.
+	Operators map[string][]*OperatorEntry
 }
 
 func (r *RecordTypeValue) Type() string { return "RECORD_TYPE" }
@@ -51,3 +54,42 @@ func (r *RecordTypeValue) HasStaticMethod(name string) bool {
 	overloads, exists := r.ClassMethodOverloads[methodNameLower]
 	return exists && len(overloads) > 0
 }
+
+// RegisterOperator adds a class operator to the record type.
+// Returns false if an operator with the same operand types already exists.
+func (r *RecordTypeValue) RegisterOperator(entry *OperatorEntry) bool {
+	key := ident.Normalize(entry.Operator)
+	for _, existing := range r.Operators[key] {
+		if operandTypesEqual(existing.OperandTypes, entry.OperandTypes) {
+			return false
+		}
+	}
+	if r.Operators == nil {
+		r.Operators = make(map[string][]*OperatorEntry)
+	}
+	r.Operators[key] = append(r.Operators[key], entry)
+	return true
+}
+
+// LookupOperator finds a class operator declared in the record for the given
+// normalized operand type keys.
+func (r *RecordTypeValue) LookupOperator(operator string, operandTypes []string) (*OperatorEntry, bool) {
+	for _, entry := range r.Operators[ident.Normalize(operator)] {
+		if operandTypesEqual(entry.OperandTypes, operandTypes) {
+			return entry, true
+		}
+	}
+	return nil, false
+}
+
+func operandTypesEqual(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,28 @@
+package runtime
+
+// RecursionStrategy selects where the Go stack frames of nested script calls
+// live.
+type RecursionStrategy int
+
+const (
+	// RecursionNative nests script calls on the stack of the goroutine running
+	// the script. This is the default.
+	RecursionNative RecursionStrategy = iota
+	// RecursionHeap moves every few hundred nested script calls onto a fresh
+	// goroutine, whose stack the Go runtime allocates on the heap, so the
+	// depth of a script is bounded by memory rather than by the maximum size
+	// of one goroutine stack.
+	RecursionHeap
+)
+
+// String returns the name of the recursion strategy.
+func (s RecursionStrategy) String() string {
+	switch s {
+	case RecursionNative:
+		return "Native"
+	case RecursionHeap:
+		return "Heap"
+	default:
+		return "Unknown"
+	}
+}
@@ -0,0 +1,42 @@
+package runtime
+
+import (
+	"github.com/cwbudde/go-dws/internal/types"
+	"github.com/cwbudde/go-dws/pkg/ident"
+)
+
+// RTTITypeInfoValue represents runtime type information returned by TypeOf().
+// Scripts query it through the members of types.RTTITypeInfoType (Name,
+// IsClass, IsArray, IsRecord, Fields, Methods).
+type RTTITypeInfoValue struct {
+	TypeInfo types.Type // Static type, when known
+	Class    IClassInfo // Described class, nil for non-class types
+	// Instance is the object TypeOf was applied to, if any. Fields then
+	// reports the object's current field and property values.
+	Instance *ObjectInstance
+	TypeName string
+	TypeID   int
+	IsArray  bool
+	IsRecord bool
+}
+
+// Type returns "RTTI_TYPEINFO".
+func (r *RTTITypeInfoValue) Type() string { return "RTTI_TYPEINFO" }
+
+// String returns the type name.
+func (r *RTTITypeInfoValue) String() string { return r.TypeName }
+
+// IsClass reports whether the type info describes a class.
+func (r *RTTITypeInfoValue) IsClass() bool { return r.Class != nil }
+
+// SameType reports whether two type infos describe the same type. Type names
+// are compared case-insensitively.
+func (r *RTTITypeInfoValue) SameType(other *RTTITypeInfoValue) bool {
+	if r == nil || other == nil {
+		return r == other
+	}
+	if r.Class != nil || other.Class != nil {
+		return r.Class == other.Class
+	}
+	return ident.Equal(r.TypeName, other.TypeName)
+}
@@ -118,10 +118,6 @@ func (s *SetValue) Ordinals() []int {
 // HasElement checks if an element with the given ordinal value is in the set.
 // Checks both lazy ranges and configured storage backend (bitmask or map).
 func (s *SetValue) HasElement(ordinal int) bool {
-	if ordinal < 0 {
-		return false // Negative ordinals are invalid
-	}
-
 	// First check lazy ranges (most common for large sets)
 	for _, r := range s.Ranges {
 		if r.Start <= r.End {
@@ -140,7 +136,7 @@ func (s *SetValue) HasElement(ordinal int) bool {
 	// Choose storage backend based on set type
 	switch s.SetType.StorageKind {
 	case types.SetStorageBitmask:
-		if ordinal >= 64 {
+		if ordinal < 0 || ordinal >= 64 {
 			return false // Out of range for bitset
 		}
 		mask := uint64(1) << uint(ordinal)
@@ -157,14 +153,10 @@ func (s *SetValue) HasElement(ordinal int) bool {
 // AddElement adds an element with the given ordinal value to the set.
 // This mutates the set in place.
 func (s *SetValue) AddElement(ordinal int) {
-	if ordinal < 0 {
-		return // Negative ordinals are invalid
-	}
-
 	// Choose storage backend based on set type
 	switch s.SetType.StorageKind {
 	case types.SetStorageBitmask:
-		if ordinal >= 64 {
+		if ordinal < 0 || ordinal >= 64 {
 			return // Out of range for bitset
 		}
 		mask := uint64(1) << uint(ordinal)
@@ -178,14 +170,10 @@ func (s *SetValue) AddElement(ordinal int) {
 // RemoveElement removes an element with the given ordinal value from the set.
 // This mutates the set in place.
 func (s *SetValue) RemoveElement(ordinal int) {
-	if ordinal < 0 {
-		return // Negative ordinals are invalid
-	}
-
 	// Choose storage backend based on set type
 	switch s.SetType.StorageKind {
 	case types.SetStorageBitmask:
-		if ordinal >= 64 {
+		if ordinal < 0 || ordinal >= 64 {
 			return // Out of range for bitset
 		}
 		mask := uint64(1) << uint(ordinal)
@@ -0,0 +1,77 @@
+package runtime
+
+// VariantOverflowMode selects what happens when Integer arithmetic on Variant
+// operands overflows the 64-bit Integer range.
+type VariantOverflowMode int
+
+const (
+	// VariantOverflowWrap wraps around using two's complement arithmetic.
+	// This is the default and matches original DWScript, whose Variant
+	// Integers are Int64 values computed without overflow checks.
+	VariantOverflowWrap VariantOverflowMode = iota
+	// VariantOverflowError raises an EIntOverflow exception.
+	VariantOverflowError
+	// VariantOverflowSaturateToFloat recomputes the operation in Float
+	// precision and returns a Float result.
+	VariantOverflowSaturateToFloat
+)
+
+// String returns the name of the overflow mode.
+func (m VariantOverflowMode) String() string {
+	switch m {
+	case VariantOverflowWrap:
+		return "Wrap"
+	case VariantOverflowError:
+		return "Error"
+	case VariantOverflowSaturateToFloat:
+		return "SaturateToFloat"
+	default:
+		return "Unknown"
+	}
+}
+
+// VariantNumericRule describes numeric promotion for binary operations whose
+// operands are Variants:
+//   - Integer op Integer → Integer, with overflow handled according to Overflow
+//   - Integer op Float and Float op Float → Float (IEEE 754, never overflows)
+//   - Integer / Integer → Float
+type VariantNumericRule struct {
+	Overflow VariantOverflowMode
+}
+
+// DefaultVariantNumericRule returns the rule matching original DWScript.
+func DefaultVariantNumericRule() VariantNumericRule {
+	return VariantNumericRule{Overflow: VariantOverflowWrap}
+}
+
+// IntegerArithmetic applies op (+, - or *) to two Integers under the rule.
+// Returns the result and true on success. On overflow in VariantOverflowError
+// mode it returns (nil, false) and the caller reports the error; operators
+// other than +, - and * also return (nil, false).
+func (r VariantNumericRule) IntegerArithmetic(op string, left, right int64) (Value, bool) {
+	if op != "+" && op != "-" && op != "*" {
+		return nil, false
+	}
+
+	result, overflow := CheckedIntegerArithmetic(op, left, right)
+	if !overflow {
+		return &IntegerValue{Value: result}, true
+	}
+
+	switch r.Overflow {
+	case VariantOverflowError:
+		return nil, false
+	case VariantOverflowSaturateToFloat:
+		l, rf := float64(left), float64(right)
+		switch op {
+		case "+":
+			return &FloatValue{Value: l + rf}, true
+		case "-":
+			return &FloatValue{Value: l - rf}, true
+		default:
+			return &FloatValue{Value: l * rf}, true
+		}
+	default:
+		return &IntegerValue{Value: result}, true
+	}
+}
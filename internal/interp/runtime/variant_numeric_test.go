@@ -0,0 +1,53 @@
+package runtime
+
+import (
+	"math"
+	"testing"
+)
+
+func TestVariantNumericRuleIntegerArithmetic(t *testing.T) {
+	tests := []struct {
+		want     Value
+		name     string
+		op       string
+		left     int64
+		right    int64
+		mode     VariantOverflowMode
+		wantFail bool
+	}{
+		{name: "no overflow", mode: VariantOverflowError, op: "+", left: 2, right: 3, want: &IntegerValue{Value: 5}},
+		{name: "wrap add", mode: VariantOverflowWrap, op: "+", left: math.MaxInt64, right: 1, want: &IntegerValue{Value: math.MinInt64}},
+		{name: "wrap sub", mode: VariantOverflowWrap, op: "-", left: math.MinInt64, right: 1, want: &IntegerValue{Value: math.MaxInt64}},
+		{name: "wrap mul", mode: VariantOverflowWrap, op: "*", left: math.MaxInt64, right: 2, want: &IntegerValue{Value: -2}},
+		{name: "error add", mode: VariantOverflowError, op: "+", left: math.MaxInt64, right: 1, wantFail: true},
+		{name: "error sub", mode: VariantOverflowError, op: "-", left: math.MinInt64, right: 1, wantFail: true},
+		{name: "error mul", mode: VariantOverflowError, op: "*", left: 1 << 62, right: 4, wantFail: true},
+		{name: "error negate MinInt64", mode: VariantOverflowError, op: "*", left: -1, right: math.MinInt64, wantFail: true},
+		{name: "saturate add", mode: VariantOverflowSaturateToFloat, op: "+", left: math.MaxInt64, right: 1, want: &FloatValue{Value: float64(math.MaxInt64) + 1}},
+		{name: "saturate sub", mode: VariantOverflowSaturateToFloat, op: "-", left: math.MinInt64, right: 1, want: &FloatValue{Value: float64(math.MinInt64) - 1}},
+		{name: "saturate mul", mode: VariantOverflowSaturateToFloat, op: "*", left: math.MaxInt64, right: 2, want: &FloatValue{Value: float64(math.MaxInt64) * 2}},
+		{name: "unsupported operator", mode: VariantOverflowWrap, op: "div", left: 4, right: 2, wantFail: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule := VariantNumericRule{Overflow: tt.mode}
+			got, ok := rule.IntegerArithmetic(tt.op, tt.left, tt.right)
+			if ok == tt.wantFail {
+				t.Fatalf("IntegerArithmetic() ok = %v, want %v", ok, !tt.wantFail)
+			}
+			if tt.wantFail {
+				return
+			}
+			if got.Type() != tt.want.Type() || got.String() != tt.want.String() {
+				t.Errorf("IntegerArithmetic() = %s(%s), want %s(%s)", got.Type(), got, tt.want.Type(), tt.want)
+			}
+		})
+	}
+}
+
+func TestDefaultVariantNumericRule(t *testing.T) {
+	if got := DefaultVariantNumericRule().Overflow; got != VariantOverflowWrap {
+		t.Errorf("default overflow mode = %s, want Wrap", got)
+	}
+}
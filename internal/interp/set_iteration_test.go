@@ -2,6 +2,7 @@ package interp
 
 import (
 	"fmt"
+	"strings"
 	"testing"
 
 	"github.com/cwbudde/go-dws/internal/types"
@@ -238,3 +239,94 @@ func TestLargeSet_NestedForInIteration(t *testing.T) {
 		t.Errorf("total inner iterations should be %d, got %d", expectedTotal, totalInnerCount)
 	}
 }
+
+func TestForInSet_NegativeSubrange(t *testing.T) {
+	input := `
+type TNeg = -5..5;
+type TNegSet = set of TNeg;
+var n: TNegSet := [3, -1, -5];
+PrintLn(-1 in n);
+PrintLn(0 in n);
+Include(n, -3);
+Exclude(n, 3);
+for var i in n do PrintLn(i);
+var m: TNegSet := [-5, -1];
+PrintLn(m <= n);
+PrintLn(n >= m);
+PrintLn(n = m + [-3]);
+PrintLn(n <> m);
+PrintLn((n * m) = m);
+`
+	_, output := testEvalWithOutputAndSemantic(t, input)
+	expected := "True\nFalse\n-5\n-3\n-1\nTrue\nTrue\nTrue\nTrue\nTrue\n"
+	if output != expected {
+		t.Errorf("expected %q, got %q", expected, output)
+	}
+}
+
+func TestForInSet_ByteAboveBitmask(t *testing.T) {
+	input := `
+var s: set of Byte := [200];
+Include(s, 250);
+Include(s, 3);
+PrintLn(200 in s);
+PrintLn(100 in s);
+Exclude(s, 200);
+PrintLn(200 in s);
+for var b in s do PrintLn(b);
+`
+	_, output := testEvalWithOutputAndSemantic(t, input)
+	expected := "True\nFalse\nFalse\n3\n250\n"
+	if output != expected {
+		t.Errorf("expected %q, got %q", expected, output)
+	}
+}
+
+func TestLargeSet_Comparisons(t *testing.T) {
+	names := make([]string, 70)
+	for i := range names {
+		names[i] = fmt.Sprintf("E%02d", i)
+	}
+	input := fmt.Sprintf(`
+type TLarge = (%s);
+var s: set of TLarge := [E01, E65, E69];
+var t: set of TLarge := [E65];
+PrintLn(t <= s);
+PrintLn(s >= t);
+PrintLn(s = t);
+Include(t, E01);
+Include(t, E69);
+PrintLn(s = t);
+for var e in s do PrintLn(Ord(e));
+`, strings.Join(names, ", "))
+
+	_, output := testEvalWithOutputAndSemantic(t, input)
+	expected := "True\nTrue\nFalse\nTrue\n1\n65\n69\n"
+	if output != expected {
+		t.Errorf("expected %q, got %q", expected, output)
+	}
+}
+
+func TestForInSet_OrdinalOrder(t *testing.T) {
+	input := `
+type TOrder = (Third = 3, First = 1, Second = 2);
+var s: set of TOrder := [Third, First, Second];
+for var o in s do PrintLn(o.Name);
+
+type TRange = enum (Low = 2, High = 10);
+var r: set of TRange;
+for var k in TRange do
+	if Ord(k) mod 5 = 2 then
+		r.Include(k);
+for var e in r do PrintLn(Ord(e));
+
+type TDigit = 0..9;
+var digits: set of TDigit := [7, 2, 5];
+for var d in digits step 2 do PrintLn(d);
+`
+	_, output := testEvalWithOutputAndSemantic(t, input)
+	expected := "First\nSecond\nThird\n2\n7\n2\n7\n"
+	if output != expected {
+		t.Errorf("expected %q, got %q", expected, output)
+	}
+}
@@ -89,6 +89,55 @@ func TestExitStatementWithValues(t *testing.T) {
 			`,
 			want: "123\n",
 		},
+		{
+			name: "ExitRunsFinally",
+			source: `
+				function Guarded(x: Integer): Integer;
+				begin
+					try
+						Exit(x * 2);
+					finally
+						PrintLn('finally');
+					end;
+					Result := -1;
+				end;
+
+				PrintLn(Guarded(21));
+			`,
+			want: "finally\n42\n",
+		},
+		{
+			name: "ExitInTypedLambda",
+			source: `
+				function Outer: Integer;
+				begin
+					var f := lambda(x: Integer): Integer begin
+						if x > 1 then Exit(x + 100);
+						Result := 0;
+					end;
+					Result := f(5) + f(0);
+				end;
+
+				PrintLn(Outer());
+			`,
+			want: "105\n",
+		},
+		{
+			name: "ExitInInferredLambdaKeepsOuterResult",
+			source: `
+				function Outer: Integer;
+				var
+					f: function(x: Integer): Integer;
+				begin
+					Result := 7;
+					f := lambda(x) begin Exit(x div 2); end;
+					PrintLn(f(9));
+				end;
+
+				PrintLn(Outer());
+			`,
+			want: "4\n7\n",
+		},
 	}
 
 	for _, tc := range testCases {
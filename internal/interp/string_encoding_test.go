@@ -0,0 +1,52 @@
+package interp
+
+import "testing"
+
+// TestStringIndexingCodePoints tests that Length, indexing and Ord count
+// Unicode code points, not UTF-8 bytes.
+func TestStringIndexingCodePoints(t *testing.T) {
+	input := `
+var s := 'héllo€';
+PrintLn(Length(s));
+PrintLn(s[2]);
+PrintLn(Ord(s[6]));
+s[2] := 'e';
+PrintLn(s);
+for var i := 1 to Length(s) do Print(s[i] + '|');
+PrintLn('');
+`
+
+	_, output := testEvalWithOutputAndSemantic(t, input)
+	expected := "6\né\n8364\nhello€\nh|e|l|l|o|€|\n"
+	if output != expected {
+		t.Errorf("expected output %q, got %q", expected, output)
+	}
+}
+
+// TestStringEncodingBuiltins tests UTF8Encode/UTF8Decode and
+// BytesOf/StringOf round trips.
+func TestStringEncodingBuiltins(t *testing.T) {
+	input := `
+var s := 'Aé€';
+var u := UTF8Encode(s);
+PrintLn(Length(u));
+PrintLn(Ord(u[2]));
+PrintLn(UTF8Decode(u) = s);
+var b: array of Byte := BytesOf(s);
+PrintLn(Length(b));
+PrintLn(b[1]);
+PrintLn(StringOf(b));
+PrintLn(StringOf([79, 75]));
+try
+  PrintLn(StringOf([300]));
+except
+  on E: EConvertError do PrintLn(E.ClassName);
+end;
+`
+
+	_, output := testEvalWithOutputAndSemantic(t, input)
+	expected := "6\n195\nTrue\n6\n195\nAé€\nOK\nEConvertError\n"
+	if output != expected {
+		t.Errorf("expected output %q, got %q", expected, output)
+	}
+}
@@ -1319,6 +1319,21 @@ end
 			`,
 			expected: "Pi: 3.14",
 		},
+		{
+			name: "Unused trailing arguments are ignored",
+			input: `
+type TStrArray = array of String;
+var arr: TStrArray;
+begin
+	SetLength(arr, 3);
+	arr[0] := "a";
+	arr[1] := "b";
+	arr[2] := "c";
+	Format("hello %s", arr);
+end
+			`,
+			expected: "hello a",
+		},
 		{
 			name: "Multiple arguments with strings and integers",
 			input: `
@@ -1636,20 +1651,6 @@ var arr: TStrArray;
 begin
 	SetLength(arr, 0);
 	Format("hello %s %s", arr);
-end
-			`,
-		},
-		{
-			name: "Too many arguments in array",
-			input: `
-type TStrArray = array of String;
-var arr: TStrArray;
-begin
-	SetLength(arr, 3);
-	arr[0] := "a";
-	arr[1] := "b";
-	arr[2] := "c";
-	Format("hello %s", arr);
 end
 			`,
 		},
@@ -2163,4 +2164,33 @@ end
 	}
 }
 
+// TestBuiltinFormat_ExceptionClasses tests that malformed format strings raise
+// a catchable EConvertError while incompatible arguments raise EDelphi.
+func TestBuiltinFormat_ExceptionClasses(t *testing.T) {
+	input := `
+procedure Show(const fmt: String; const args: array of const);
+begin
+	try
+		PrintLn(Format(fmt, args));
+	except
+		on E: Exception do PrintLn(E.ClassName + ': ' + E.Message.Before(' ['));
+	end;
+end;
+
+Show('%1:s %0:s', ['World', 'Hello']);
+Show('%z', [1]);
+Show('%d %d', [1]);
+Show('%d', ['x']);
+`
+	_, output := testEvalWithOutput(input)
+
+	expected := "Hello World\n" +
+		"EConvertError: Invalid format specifier '%z'\n" +
+		"EConvertError: No argument for format '%d'\n" +
+		"EDelphi: Format '%d' invalid or incompatible with argument\n"
+	if output != expected {
+		t.Errorf("output = %q, want %q", output, expected)
+	}
+}
+
 // TestBuiltin_ tests the _() built-in function (alias for GetText).
@@ -388,3 +388,53 @@ func TestMultipleSubrangeTypes(t *testing.T) {
 		t.Errorf("Expected output %q, got %q", expected, output)
 	}
 }
+
+// TestSubrangeArithmetic tests that subrange values take part in expressions
+// and widen to Integer and Float variables as values of their base type.
+func TestSubrangeArithmetic(t *testing.T) {
+	input := `
+		type TByte = 0..255;
+		type TDigit = 0..9;
+		var b: TByte := 200;
+		var d: TDigit := 7;
+		var i: Integer := b;
+		var f: Float := d;
+		PrintLn(b + 1);
+		PrintLn(-d);
+		PrintLn(i * 2);
+		PrintLn(f / 2);
+		b := d;
+		PrintLn(b);
+	`
+
+	_, output := testEvalWithOutputAndSemantic(t, input)
+	expected := "201\n-7\n400\n3.5\n7\n"
+	if output != expected {
+		t.Errorf("Expected output %q, got %q", expected, output)
+	}
+}
+
+// TestSubrangeSetElementType tests sets whose element type is a named or an
+// inline subrange.
+func TestSubrangeSetElementType(t *testing.T) {
+	input := `
+		type TDigit = 0..9;
+		type TDigits = set of TDigit;
+		type TSmall = set of 1..5;
+		var ds: TDigits := [1, 3];
+		var sm: TSmall := [2];
+		Include(ds, 9);
+		Include(sm, 5);
+		PrintLn(3 in ds);
+		PrintLn(4 in ds);
+		PrintLn(9 in ds);
+		PrintLn(5 in sm);
+		PrintLn(1 in sm);
+	`
+
+	_, output := testEvalWithOutputAndSemantic(t, input)
+	expected := "True\nFalse\nTrue\nTrue\nFalse\n"
+	if output != expected {
+		t.Errorf("Expected output %q, got %q", expected, output)
+	}
+}
@@ -458,7 +458,8 @@ func (i *Interpreter) RegisterClassOperator(classInfo interface{}, opDecl *ast.O
 		}
 		operandTypes = append(operandTypes, key)
 	}
-	if !includesClass {
+	// A single operand type is always combined with the class itself.
+	if len(operandTypes) == 1 || !includesClass {
 		if ident.Equal(opDecl.OperatorSymbol, "in") {
 			operandTypes = append(operandTypes, classKey)
 		} else {
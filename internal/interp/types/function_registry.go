@@ -43,6 +43,37 @@ type FunctionEntry struct {
 
 	// Name is the original case-sensitive function name
 	Name string
+
+	// IsVirtual, IsOverride, IsReintroduce and IsAbstract hold the directives
+	// of the function. An implementation that replaces a declaration keeps the
+	// declaration's directives here; Decl itself is never modified, because
+	// one compiled program may be registered by several runs at once.
+	IsVirtual     bool
+	IsOverride    bool
+	IsReintroduce bool
+	IsAbstract    bool
+}
+
+// newFunctionEntry creates an entry for fn with the directives of its declaration.
+func newFunctionEntry(fn *ast.FunctionDecl, unitName, name string) *FunctionEntry {
+	return &FunctionEntry{
+		Decl:          fn,
+		UnitName:      unitName,
+		Name:          name,
+		IsVirtual:     fn.IsVirtual,
+		IsOverride:    fn.IsOverride,
+		IsReintroduce: fn.IsReintroduce,
+		IsAbstract:    fn.IsAbstract,
+	}
+}
+
+// inheritDirectives copies the directives of the declaration entry decl,
+// which entry replaces.
+func (entry *FunctionEntry) inheritDirectives(decl *FunctionEntry) {
+	entry.IsVirtual = decl.IsVirtual
+	entry.IsOverride = decl.IsOverride
+	entry.IsReintroduce = decl.IsReintroduce
+	entry.IsAbstract = decl.IsAbstract
 }
 
 // NewFunctionRegistry creates a new empty function registry.
@@ -70,11 +101,7 @@ func (r *FunctionRegistry) Register(name string, fn *ast.FunctionDecl) {
 		return
 	}
 
-	entry := &FunctionEntry{
-		Decl:     fn,
-		UnitName: "",
-		Name:     name,
-	}
+	entry := newFunctionEntry(fn, "", name)
 
 	existing, _ := r.functions.Get(name)
 	r.functions.Set(name, append(existing, entry))
@@ -88,11 +115,7 @@ func (r *FunctionRegistry) RegisterWithUnit(unitName, functionName string, fn *a
 		return
 	}
 
-	entry := &FunctionEntry{
-		Decl:     fn,
-		UnitName: unitName,
-		Name:     functionName,
-	}
+	entry := newFunctionEntry(fn, unitName, functionName)
 
 	// Register in global namespace
 	existing, _ := r.functions.Get(functionName)
@@ -112,11 +135,7 @@ func (r *FunctionRegistry) RegisterOrReplaceWithUnit(unitName, functionName stri
 		return
 	}
 
-	entry := &FunctionEntry{
-		Decl:     fn,
-		UnitName: unitName,
-		Name:     functionName,
-	}
+	entry := newFunctionEntry(fn, unitName, functionName)
 
 	r.registerOrReplaceEntry(r.functions, functionName, entry)
 	r.registerOrReplaceEntry(r.qualifiedFunctions, unitName+"."+functionName, entry)
@@ -275,6 +294,33 @@ func (r *FunctionRegistry) RemoveFunction(name string) bool {
 	return true
 }
 
+// Clone returns a copy of the registry that later registrations and
+// removals in either registry do not affect. The builtin registry is shared.
+func (r *FunctionRegistry) Clone() *FunctionRegistry {
+	return &FunctionRegistry{
+		functions:          cloneEntries(r.functions),
+		qualifiedFunctions: cloneEntries(r.qualifiedFunctions),
+		builtins:           r.builtins,
+	}
+}
+
+// RestoreFrom replaces the functions of the registry with those of a clone
+// taken earlier (see Clone). The clone itself stays unchanged, so it can be
+// restored again.
+func (r *FunctionRegistry) RestoreFrom(clone *FunctionRegistry) {
+	r.functions = cloneEntries(clone.functions)
+	r.qualifiedFunctions = cloneEntries(clone.qualifiedFunctions)
+}
+
+func cloneEntries(entries *ident.Map[[]*FunctionEntry]) *ident.Map[[]*FunctionEntry] {
+	cloned := ident.NewMapWithCapacity[[]*FunctionEntry](entries.Len())
+	entries.Range(func(name string, overloads []*FunctionEntry) bool {
+		cloned.Set(name, append([]*FunctionEntry(nil), overloads...))
+		return true
+	})
+	return cloned
+}
+
 // FindFunctionsByParameterCount returns all functions that have at least one overload
 // with the specified number of parameters.
 func (r *FunctionRegistry) FindFunctionsByParameterCount(paramCount int) map[string][]*ast.FunctionDecl {
@@ -311,6 +357,10 @@ func (r *FunctionRegistry) GetFunctionMetadata(name string) []FunctionMetadata {
 			UnitName:       entry.UnitName,
 			ParameterCount: len(entry.Decl.Parameters),
 			IsForward:      entry.Decl.Body == nil,
+			IsVirtual:      entry.IsVirtual,
+			IsOverride:     entry.IsOverride,
+			IsReintroduce:  entry.IsReintroduce,
+			IsAbstract:     entry.IsAbstract,
 		}
 	}
 	return result
@@ -322,6 +372,10 @@ type FunctionMetadata struct {
 	UnitName       string
 	ParameterCount int
 	IsForward      bool // true if declaration without body
+	IsVirtual      bool
+	IsOverride     bool
+	IsReintroduce  bool
+	IsAbstract     bool
 }
 
 // ValidateNoConflicts checks if adding a new function would create ambiguous overloads.
@@ -426,11 +480,7 @@ func (r *FunctionRegistry) RegisterOrReplace(name string, fn *ast.FunctionDecl)
 		return
 	}
 
-	entry := &FunctionEntry{
-		Decl:     fn,
-		UnitName: "",
-		Name:     name,
-	}
+	entry := newFunctionEntry(fn, "", name)
 
 	existing, ok := r.functions.Get(name)
 	if !ok {
@@ -446,10 +496,7 @@ func (r *FunctionRegistry) RegisterOrReplace(name string, fn *ast.FunctionDecl)
 			}
 			if parametersMatchFn(e.Decl.Parameters, fn.Parameters) {
 				// Preserve virtual/override/reintroduce/abstract flags from declaration
-				fn.IsVirtual = e.Decl.IsVirtual
-				fn.IsOverride = e.Decl.IsOverride
-				fn.IsReintroduce = e.Decl.IsReintroduce
-				fn.IsAbstract = e.Decl.IsAbstract
+				entry.inheritDirectives(e)
 				existing[idx] = entry
 				r.functions.Set(name, existing)
 				return
@@ -480,10 +527,7 @@ func (r *FunctionRegistry) registerOrReplaceEntry(
 			continue
 		}
 		if entry.Decl.Body != nil || candidate.Decl.Body == nil || candidate.Decl == entry.Decl {
-			entry.Decl.IsVirtual = candidate.Decl.IsVirtual
-			entry.Decl.IsOverride = candidate.Decl.IsOverride
-			entry.Decl.IsReintroduce = candidate.Decl.IsReintroduce
-			entry.Decl.IsAbstract = candidate.Decl.IsAbstract
+			entry.inheritDirectives(candidate)
 			existing[idx] = entry
 			registry.Set(name, existing)
 			return
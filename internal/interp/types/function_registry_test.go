@@ -414,6 +414,31 @@ func TestFunctionRegistry_GetFunctionMetadata(t *testing.T) {
 	}
 }
 
+func TestFunctionRegistry_RegisterOrReplace_KeepsDeclarationDirectives(t *testing.T) {
+	registry := NewFunctionRegistry()
+
+	decl := makeFunctionDecl("Test", 1)
+	decl.IsVirtual = true
+	decl.IsAbstract = true
+	impl := makeFunctionDecl("Test", 1)
+	impl.Body = &ast.BlockStatement{}
+
+	registry.RegisterOrReplace("Test", decl)
+	registry.RegisterOrReplace("Test", impl)
+
+	decls := registry.Lookup("Test")
+	if len(decls) != 1 || decls[0] != impl {
+		t.Fatalf("Expected the implementation to replace the declaration, got %v", decls)
+	}
+	metadata := registry.GetFunctionMetadata("Test")
+	if !metadata[0].IsVirtual || !metadata[0].IsAbstract || metadata[0].IsOverride {
+		t.Errorf("Expected the declaration's directives on the entry, got %+v", metadata[0])
+	}
+	if impl.IsVirtual || impl.IsAbstract {
+		t.Error("RegisterOrReplace must not modify the implementation declaration")
+	}
+}
+
 func TestFunctionRegistry_ValidateNoConflicts(t *testing.T) {
 	registry := NewFunctionRegistry()
 
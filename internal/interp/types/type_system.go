@@ -655,11 +655,17 @@ type ConversionRegistry struct {
 }
 
 // ConversionEntry represents a registered type conversion.
+// Owner is the record type (*runtime.RecordTypeValue) or class
+// (runtime.IClassInfo) declaring a class operator conversion, or nil for a
+// global conversion bound to a function. Owned conversions bound to an
+// instance method (IsClassMethod false) are invoked on the converted value.
 type ConversionEntry struct {
-	From        string
-	To          string
-	BindingName string
-	Implicit    bool
+	Owner         interface{}
+	From          string
+	To            string
+	BindingName   string
+	Implicit      bool
+	IsClassMethod bool
 }
 
 // NewConversionRegistry creates a new conversion registry.
@@ -354,11 +354,16 @@ func (i *Interpreter) ResolveQualifiedVariable(unitName, variableName string) (V
 	}
 
 	// Get the unit from the registry
-	_, exists := i.unitRegistry().GetUnit(unitName)
+	unit, exists := i.unitRegistry().GetUnit(unitName)
 	if !exists {
 		return nil, fmt.Errorf("unit '%s' not loaded", unitName)
 	}
 
+	// Constants of a host unit live in the unit itself
+	if val, ok := unit.LookupConstant(variableName); ok {
+		return val, nil
+	}
+
 	// Try to find in the environment (for constants, variables)
 	if val, ok := i.Env().Get(variableName); ok {
 		return val, nil
@@ -366,3 +371,26 @@ func (i *Interpreter) ResolveQualifiedVariable(unitName, variableName string) (V
 
 	return nil, fmt.Errorf("variable '%s' not found in unit '%s'", variableName, unitName)
 }
+
+// ResolveQualifiedExternalFunction resolves a qualified identifier naming a
+// Go-backed function of a host unit (see units.Unit.DefineHostFunction) to its
+// entry in the external function registry. Script-defined unit functions are
+// resolved by ResolveQualifiedFunction instead.
+func (i *Interpreter) ResolveQualifiedExternalFunction(unitName, functionName string) (*ExternalFunctionValue, error) {
+	if i.unitRegistry() == nil {
+		return nil, fmt.Errorf("unit registry not initialized")
+	}
+
+	unit, exists := i.unitRegistry().GetUnit(unitName)
+	if !exists {
+		return nil, fmt.Errorf("unit '%s' not loaded", unitName)
+	}
+
+	if externalName, ok := unit.LookupHostFunction(functionName); ok && i.externalFunctions() != nil {
+		if fn, ok := i.externalFunctions().Get(externalName); ok {
+			return fn, nil
+		}
+	}
+
+	return nil, fmt.Errorf("function '%s' not found in unit '%s'", functionName, unitName)
+}
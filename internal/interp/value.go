@@ -59,16 +59,8 @@ type (
 // Complex Value Types
 // ============================================================================
 
-// RTTITypeInfoValue represents runtime type information.
-// Used by TypeOf() to return unique type identifiers for RTTI operations.
-type RTTITypeInfoValue struct {
-	TypeInfo types.Type
-	TypeName string
-	TypeID   int
-}
-
-func (r *RTTITypeInfoValue) Type() string   { return "RTTI_TYPEINFO" }
-func (r *RTTITypeInfoValue) String() string { return r.TypeName }
+// RTTITypeInfoValue represents runtime type information returned by TypeOf().
+type RTTITypeInfoValue = runtime.RTTITypeInfoValue
 
 // RecordValue represents a record (struct) value
 type RecordValue = runtime.RecordValue
@@ -344,3 +344,130 @@ func TestVarParam_RecordMethodRecordParameterCopyBack(t *testing.T) {
 		t.Errorf("wrong output. expected=%q, got=%q", expected, output)
 	}
 }
+
+// TestVarParam_LiveFieldReferences verifies that record and object fields,
+// including fields reached through array elements and nested records, are
+// passed by reference: writes through another path are visible through the
+// parameter and writes to the parameter land in the named storage.
+func TestVarParam_LiveFieldReferences(t *testing.T) {
+	input := `
+		type TRec = record A: Integer; end;
+		type TObj = class N: Integer; R: TRec; end;
+		var o := TObj.Create;
+		var r: TRec;
+		var recs: array of TRec;
+		SetLength(recs, 2);
+
+		procedure ObjField(var x: Integer); begin o.N := 5; PrintLn(x); x := 7; end;
+		procedure RecField(var x: Integer); begin r.A := 5; PrintLn(x); x := 8; end;
+		procedure NestedField(var x: Integer); begin o.R.A := 5; PrintLn(x); x := 9; end;
+		procedure ElementField(var x: Integer); begin recs[1].A := 5; PrintLn(x); x := 10; end;
+
+		ObjField(o.N);
+		RecField(r.A);
+		NestedField(o.R.A);
+		ElementField(recs[1].A);
+		PrintLn(o.N);
+		PrintLn(r.A);
+		PrintLn(o.R.A);
+		PrintLn(recs[1].A);
+	`
+
+	result, output := testEvalWithOutput(input)
+	if isError(result) {
+		t.Fatalf("unexpected error: %s", result)
+	}
+
+	expected := "5\n5\n5\n5\n7\n8\n9\n10\n"
+	if output != expected {
+		t.Errorf("wrong output. expected=%q, got=%q", expected, output)
+	}
+}
+
+// TestVarParam_ArrayElementsInPlace verifies that builtins and user routines
+// taking var parameters mutate array elements and fields in place.
+func TestVarParam_ArrayElementsInPlace(t *testing.T) {
+	input := `
+		type TObj = class Items: array of Integer; end;
+		procedure Swap(var a, b: Integer);
+		var t: Integer;
+		begin
+			t := a; a := b; b := t;
+		end;
+
+		var counts: array of Integer;
+		SetLength(counts, 3);
+		Inc(counts[1]);
+		Inc(counts[1], 5);
+		Swap(counts[0], counts[1]);
+		PrintLn(IntToStr(counts[0]) + ' ' + IntToStr(counts[1]));
+
+		var o := TObj.Create;
+		SetLength(o.Items, 5);
+		PrintLn(Length(o.Items));
+
+		var m: array of array of Integer;
+		SetLength(m, 2);
+		SetLength(m[1], 3);
+		PrintLn(Length(m[1]));
+	`
+
+	result, output := testEvalWithOutput(input)
+	if isError(result) {
+		t.Fatalf("unexpected error: %s", result)
+	}
+
+	expected := "6 0\n5\n3\n"
+	if output != expected {
+		t.Errorf("wrong output. expected=%q, got=%q", expected, output)
+	}
+}
+
+// TestVarParam_ArrayElementBounds verifies that an out-of-range element fails
+// at the call, and that a reference stays bound to its index when the callee
+// resizes the array.
+func TestVarParam_ArrayElementBounds(t *testing.T) {
+	input := `
+		type TRec = record A: Integer; end;
+		var arr: array of Integer;
+		var recs: array of TRec;
+		SetLength(arr, 2);
+
+		procedure SetTo(var x: Integer; v: Integer); begin PrintLn('called'); x := v; end;
+		procedure Grow(var x: Integer); begin SetLength(arr, 10); x := 13; end;
+		procedure Shrink(var x: Integer); begin SetLength(arr, 0); x := 14; end;
+
+		try
+			SetTo(arr[5], 1);
+		except
+			on E: ERangeError do PrintLn('call: ' + E.Message);
+		end;
+		try
+			SetTo(recs[0].A, 1);
+		except
+			on E: ERangeError do PrintLn('field: ' + E.Message);
+		end;
+
+		Grow(arr[1]);
+		PrintLn(arr[1]);
+		try
+			Shrink(arr[1]);
+		except
+			on E: ERangeError do PrintLn('stale: ' + E.Message);
+		end;
+	`
+
+	result, output := testEvalWithOutput(input)
+	if isError(result) {
+		t.Fatalf("unexpected error: %s", result)
+	}
+
+	for _, want := range []string{"call: Upper bound exceeded! Index 5", "field: Upper bound exceeded! Index 0", "13\n", "stale: Upper bound exceeded! Index 1"} {
+		if !strings.Contains(output, want) {
+			t.Errorf("output missing %q:\n%s", want, output)
+		}
+	}
+	if strings.Contains(output, "called") {
+		t.Errorf("callee ran despite an out-of-range argument:\n%s", output)
+	}
+}
@@ -166,7 +166,7 @@ func (l *Lexer) readDirectiveContent(startPos Position) string {
 	}
 
 	if l.ch == 0 {
-		l.addError("unterminated compiler directive", startPos)
+		l.addIncompleteError("unterminated compiler directive", startPos)
 		return ""
 	}
 
@@ -185,7 +185,7 @@ func (l *Lexer) readDirectiveContent(startPos Position) string {
 // handleDefine handles {$DEFINE} directives.
 func (l *Lexer) handleDefine(arg string, parentActive bool, startPos Position) {
 	if arg == "" {
-		l.addError("name expected after $define", startPos)
+		l.addDirectiveError("name expected after $define", startPos)
 		return
 	}
 	if parentActive {
@@ -196,7 +196,7 @@ func (l *Lexer) handleDefine(arg string, parentActive bool, startPos Position) {
 // handleUndef handles {$UNDEF} directives.
 func (l *Lexer) handleUndef(arg string, parentActive bool, startPos Position) {
 	if arg == "" {
-		l.addError("name expected after $undef", startPos)
+		l.addDirectiveError("name expected after $undef", startPos)
 		return
 	}
 	if parentActive {
@@ -207,7 +207,7 @@ func (l *Lexer) handleUndef(arg string, parentActive bool, startPos Position) {
 // handleIfDef handles {$IFDEF} and {$IFNDEF} directives.
 func (l *Lexer) handleIfDef(name, arg string, parentActive bool, startPos Position) {
 	if arg == "" {
-		l.addError("name expected after $"+name, startPos)
+		l.addDirectiveError("name expected after $"+name, startPos)
 		return
 	}
 	cond := l.isDefined(arg)
@@ -226,12 +226,12 @@ func (l *Lexer) handleIfDef(name, arg string, parentActive bool, startPos Positi
 // handleElse handles {$ELSE} directives.
 func (l *Lexer) handleElse(startPos Position) {
 	if len(l.condStack) == 0 {
-		l.addError("unbalanced conditional directive", startPos)
+		l.addDirectiveError("unbalanced conditional directive", startPos)
 		return
 	}
 	top := &l.condStack[len(l.condStack)-1]
 	if top.elseSeen {
-		l.addError("unfinished conditional directive", startPos)
+		l.addDirectiveError("unfinished conditional directive", startPos)
 		return
 	}
 	top.elseSeen = true
@@ -245,7 +245,7 @@ func (l *Lexer) handleElse(startPos Position) {
 // handleEndIf handles {$ENDIF} directives.
 func (l *Lexer) handleEndIf(startPos Position) {
 	if len(l.condStack) == 0 {
-		l.addError("unbalanced conditional directive", startPos)
+		l.addDirectiveError("unbalanced conditional directive", startPos)
 	} else {
 		l.condStack = l.condStack[:len(l.condStack)-1]
 	}
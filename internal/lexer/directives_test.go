@@ -557,3 +557,64 @@ func containsMiddle(s, substr string) bool {
 	}
 	return false
 }
+
+// TestWithDefines tests predefining symbols through the lexer option.
+func TestWithDefines(t *testing.T) {
+	input := "{$IFDEF DEBUG}a{$ENDIF} {$IFDEF feature_x}b{$ELSE}c{$ENDIF} {$IFDEF RELEASE}d{$ENDIF}"
+
+	l := New(input, WithDefines("DEBUG", "FEATURE_X"))
+	got := collectLiterals(l)
+	want := []string{"a", "b"}
+	if len(got) != len(want) || got[0] != want[0] || got[1] != want[1] {
+		t.Fatalf("got %#v, want %#v", got, want)
+	}
+
+	// Predefined symbols can still be undefined by the script.
+	l = New("{$UNDEF DEBUG}{$IFDEF DEBUG}a{$ENDIF}", WithDefines("DEBUG"))
+	if got := collectLiterals(l); len(got) != 0 {
+		t.Errorf("expected DEBUG to be undefined, got %#v", got)
+	}
+}
+
+// TestConditionalErrorsAreDirectiveErrors tests that unbalanced conditionals
+// are reported as fatal directive errors at the opening directive.
+func TestConditionalErrorsAreDirectiveErrors(t *testing.T) {
+	input := "x := 1;\n  {$IFDEF DEBUG}\ny := 2;\n  {$IFNDEF DEBUG}\nz := 3;\n{$ENDIF}"
+
+	l := New(input)
+	_ = collectLiterals(l)
+
+	errs := l.DirectiveErrors()
+	if len(errs) != 1 {
+		t.Fatalf("expected 1 directive error, got %v", errs)
+	}
+	if errs[0].Message != "unfinished conditional directive" {
+		t.Errorf("message = %q, want %q", errs[0].Message, "unfinished conditional directive")
+	}
+	if errs[0].Pos.Line != 2 || errs[0].Pos.Column != 3 {
+		t.Errorf("position = %d:%d, want 2:3 (the unclosed {$IFDEF})", errs[0].Pos.Line, errs[0].Pos.Column)
+	}
+
+	// Unknown directives stay advisory.
+	l = New("{$HINTS OFF}x")
+	_ = collectLiterals(l)
+	if len(l.Errors()) == 0 || len(l.DirectiveErrors()) != 0 {
+		t.Errorf("expected an advisory error only, got errors %v, directive errors %v",
+			l.Errors(), l.DirectiveErrors())
+	}
+}
+
+// TestConditionalKeepsLineNumbers tests that code after a skipped block keeps
+// its original line and column.
+func TestConditionalKeepsLineNumbers(t *testing.T) {
+	input := "{$IFDEF DEBUG}\nskipped1;\n{ comment\n}\nskipped2;\n{$ENDIF}\n  kept;"
+
+	l := New(input)
+	tok := l.NextToken()
+	if tok.Literal != "kept" {
+		t.Fatalf("first token = %s, want kept", tok)
+	}
+	if tok.Pos.Line != 7 || tok.Pos.Column != 3 {
+		t.Errorf("position = %d:%d, want 7:3", tok.Pos.Line, tok.Pos.Column)
+	}
+}
@@ -12,6 +12,7 @@
 package lexer
 
 import (
+	"os"
 	"path/filepath"
 	"strings"
 
@@ -51,10 +52,11 @@ type includeFrame struct {
 // NewFileIncludeResolver returns an IncludeResolver that reads include files from
 // the filesystem. Relative names are resolved against the directory of the file
 // containing the directive (fromPath); at the top level, where no including file
-// exists yet, they resolve against baseDir. Files are decoded with the same
-// BOM/UTF-16 handling as top-level sources so included content matches DWScript's
-// file reading.
-func NewFileIncludeResolver(baseDir string) IncludeResolver {
+// exists yet, they resolve against baseDir. If the file does not exist there, each
+// of searchPaths is tried in order, the same way unit files are searched. Files
+// are decoded with the same BOM/UTF-16 handling as top-level sources so included
+// content matches DWScript's file reading.
+func NewFileIncludeResolver(baseDir string, searchPaths ...string) IncludeResolver {
 	return func(name, fromPath string) (string, string, error) {
 		path := name
 		if !filepath.IsAbs(path) {
@@ -63,6 +65,15 @@ func NewFileIncludeResolver(baseDir string) IncludeResolver {
 				dir = filepath.Dir(fromPath)
 			}
 			path = filepath.Join(dir, name)
+			if _, err := os.Stat(path); os.IsNotExist(err) {
+				for _, searchPath := range searchPaths {
+					candidate := filepath.Join(searchPath, name)
+					if _, err := os.Stat(candidate); err == nil {
+						path = candidate
+						break
+					}
+				}
+			}
 		}
 		content, err := encoding.DecodeFile(path)
 		if err != nil {
@@ -86,7 +97,7 @@ func (l *Lexer) handleInclude(name, content string, parentActive bool, startPos
 
 	filename := extractIncludeArg(content)
 	if filename == "" {
-		l.addIncludeError("file name expected after $"+name, startPos)
+		l.addDirectiveError("file name expected after $"+name, startPos)
 		return
 	}
 
@@ -103,7 +114,7 @@ func (l *Lexer) handleInclude(name, content string, parentActive bool, startPos
 
 	includeContent, canonical, err := l.includeResolver(filename, l.currentIncludePath)
 	if err != nil {
-		l.addIncludeError("cannot open include file '"+filename+"': "+err.Error(), startPos)
+		l.addDirectiveError("cannot open include file '"+filename+"': "+err.Error(), startPos)
 		return
 	}
 
@@ -118,7 +129,7 @@ func (l *Lexer) handleInclude(name, content string, parentActive bool, startPos
 	}
 
 	if len(l.includeStack) >= maxIncludeDepth {
-		l.addIncludeError("include nesting too deep (possible cyclic {$INCLUDE})", startPos)
+		l.addDirectiveError("include nesting too deep (possible cyclic {$INCLUDE})", startPos)
 		return
 	}
 
@@ -4,6 +4,8 @@ package lexer
 
 import (
 	"fmt"
+	"os"
+	"path/filepath"
 	"testing"
 )
 
@@ -224,6 +226,43 @@ func TestIncludeSurvivesSaveRestoreState(t *testing.T) {
 	}
 }
 
+// TestIncludePositionSource verifies that tokens report the file they were
+// read from, with line numbers relative to that file.
+func TestIncludePositionSource(t *testing.T) {
+	files := map[string]string{"inc.inc": "x\n  y"}
+	tests := []struct {
+		name string
+		opts []LexerOption
+		main string
+	}{
+		{name: "unnamed", main: ""},
+		{name: "named", opts: []LexerOption{WithSourceName("main.dws")}, main: "main.dws"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := append([]LexerOption{WithIncludeResolver(mapIncludeResolver(files))}, tt.opts...)
+			l := New("a\n{$INCLUDE 'inc.inc'} b", opts...)
+
+			want := []Position{
+				{Source: tt.main, Line: 1, Column: 1, Offset: 0},
+				{Source: "inc.inc", Line: 1, Column: 1, Offset: 0},
+				{Source: "inc.inc", Line: 2, Column: 3, Offset: 4},
+				{Source: tt.main, Line: 2, Column: 22, Offset: 23},
+			}
+			for i, w := range want {
+				tok := l.NextToken()
+				if tok.Pos != w {
+					t.Errorf("token[%d] %q at %+v, want %+v", i, tok.Literal, tok.Pos, w)
+				}
+				if end := tok.End(); end.Source != w.Source {
+					t.Errorf("token[%d] end source = %q, want %q", i, end.Source, w.Source)
+				}
+			}
+		})
+	}
+}
+
 func TestIncludeValueSubstitutionIsSkipped(t *testing.T) {
 	// {$I %FILE%} style value substitutions are not file includes and must not be
 	// treated as a missing file.
@@ -233,3 +272,31 @@ func TestIncludeValueSubstitutionIsSkipped(t *testing.T) {
 		t.Fatalf("value substitution should not error: %v", l.Errors())
 	}
 }
+
+func TestFileIncludeResolverSearchPaths(t *testing.T) {
+	base := t.TempDir()
+	lib := t.TempDir()
+	if err := os.WriteFile(filepath.Join(lib, "lib.inc"), []byte("fromlib"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(base, "local.inc"), []byte("fromlocal"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	resolver := NewFileIncludeResolver(base, lib)
+	l := New(`{$I 'local.inc'} {$I 'lib.inc'}`, WithIncludeResolver(resolver))
+	got := collectLiterals(l)
+	want := []string{"fromlocal", "fromlib"}
+	if len(got) != len(want) || got[0] != want[0] || got[1] != want[1] {
+		t.Fatalf("got %#v, want %#v", got, want)
+	}
+	if errs := l.DirectiveErrors(); len(errs) != 0 {
+		t.Fatalf("unexpected directive errors: %v", errs)
+	}
+
+	l = New(`{$I 'missing.inc'}`, WithIncludeResolver(resolver))
+	_ = collectLiterals(l)
+	if len(l.DirectiveErrors()) != 1 {
+		t.Errorf("expected 1 directive error for a missing include, got %v", l.DirectiveErrors())
+	}
+}
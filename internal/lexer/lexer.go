@@ -45,7 +45,8 @@ type Lexer struct {
 	input              string
 	constPending       string
 	currentIncludePath string
-	includeErrors      []LexerError
+	sourceName         string
+	directiveErrors    []LexerError
 	includeStack       []includeFrame
 	condStack          []conditionalFrame
 	errors             []LexerError
@@ -57,6 +58,7 @@ type Lexer struct {
 	column             int
 	ch                 rune
 	preserveComments   bool
+	preserveWhitespace bool
 	tracing            bool
 	constBlock         bool
 	constWait          bool
@@ -94,6 +96,17 @@ func WithPreserveComments(preserve bool) LexerOption {
 	}
 }
 
+// WithPreserveWhitespace enables or disables whitespace preservation.
+// When enabled, each run of spaces, tabs and line breaks is returned as a
+// WHITESPACE token instead of being skipped. Together with
+// WithPreserveComments this yields a token stream that covers every byte of
+// the input, as needed by syntax highlighters.
+func WithPreserveWhitespace(preserve bool) LexerOption {
+	return func(l *Lexer) {
+		l.preserveWhitespace = preserve
+	}
+}
+
 // WithIncludeResolver configures how {$INCLUDE 'file'} / {$I 'file'} /
 // {$INCLUDE_ONCE 'file'} directives resolve and load their referenced files.
 // When no resolver is set, include directives are ignored (their content is not
@@ -104,6 +117,26 @@ func WithIncludeResolver(resolver IncludeResolver) LexerOption {
 	}
 }
 
+// WithSourceName sets the name reported in the Source field of positions in
+// the top-level input, typically its file name. Positions inside included
+// files report the included file's resolved path instead.
+func WithSourceName(name string) LexerOption {
+	return func(l *Lexer) {
+		l.sourceName = name
+	}
+}
+
+// WithDefines predefines conditional symbols, as if each name had been
+// declared with {$DEFINE name} before the first line of the source.
+// DWSCRIPT is always defined.
+func WithDefines(names ...string) LexerOption {
+	return func(l *Lexer) {
+		for _, name := range names {
+			l.define(name)
+		}
+	}
+}
+
 // WithTracing enables or disables debug tracing output.
 // When enabled, the lexer may output debug information about its operation.
 // This is useful for debugging lexer behavior during development.
@@ -119,6 +152,8 @@ func WithTracing(trace bool) LexerOption {
 //
 // Options can be provided to configure the lexer:
 //   - WithPreserveComments(true): Return COMMENT tokens instead of skipping them
+//   - WithPreserveWhitespace(true): Return WHITESPACE tokens instead of skipping them
+//   - WithDefines("DEBUG"): Predefine conditional compilation symbols
 //   - WithTracing(true): Enable debug tracing output
 //
 // Example:
@@ -233,12 +268,22 @@ func (l *Lexer) matchAndConsume(expected rune) bool {
 // currentPos returns the current Position for token creation.
 func (l *Lexer) currentPos() Position {
 	return Position{
+		Source: l.currentSource(),
 		Line:   l.line,
 		Column: l.column,
 		Offset: l.position,
 	}
 }
 
+// currentSource returns the name of the file being scanned: the resolved path
+// of the innermost included file, or the top-level source name.
+func (l *Lexer) currentSource() string {
+	if l.currentIncludePath != "" {
+		return l.currentIncludePath
+	}
+	return l.sourceName
+}
+
 // Input returns the source code being tokenized.
 //
 // Deprecated: Use Peek(n) for token lookahead instead of creating temporary lexers.
@@ -253,21 +298,32 @@ func (l *Lexer) Errors() []LexerError {
 	return l.errors
 }
 
-// IncludeErrors returns the subset of lexer errors produced while resolving
-// {$INCLUDE} directives (e.g. a missing or unreadable include file). These are
-// surfaced as fatal front-end diagnostics, unlike other lexer errors which remain
-// advisory, so a script with a broken include fails to compile rather than running
-// with the include content silently dropped.
-func (l *Lexer) IncludeErrors() []LexerError {
-	return l.includeErrors
+// DirectiveErrors returns the subset of lexer errors produced by {$INCLUDE} and
+// conditional-compilation directives (e.g. a missing include file or an
+// {$IFDEF} without {$ENDIF}). These are surfaced as fatal front-end diagnostics,
+// unlike other lexer errors which remain advisory, so a script with a broken
+// include or unbalanced conditional fails to compile rather than running with
+// code silently dropped or kept.
+func (l *Lexer) DirectiveErrors() []LexerError {
+	return l.directiveErrors
 }
 
-// addIncludeError records an include-resolution failure. It is tracked both in the
-// general error list and in the dedicated include-error list.
-func (l *Lexer) addIncludeError(msg string, pos Position) {
+// addDirectiveError records an include or conditional directive failure. It is
+// tracked both in the general error list and in the dedicated directive-error list.
+func (l *Lexer) addDirectiveError(msg string, pos Position) {
 	err := LexerError{Message: msg, Pos: pos}
 	l.errors = append(l.errors, err)
-	l.includeErrors = append(l.includeErrors, err)
+	l.directiveErrors = append(l.directiveErrors, err)
+}
+
+// addIncompleteError adds an error about a token that the end of the input
+// cut short, such as an unterminated string literal or comment.
+func (l *Lexer) addIncompleteError(msg string, pos Position) {
+	l.errors = append(l.errors, LexerError{
+		Message:    msg,
+		Pos:        pos,
+		Incomplete: true,
+	})
 }
 
 // addError adds a new error to the lexer's error list.
@@ -717,7 +773,7 @@ func (l *Lexer) handleTilde(pos Position) Token {
 
 // skipWhitespace skips over whitespace characters (space, tab, newline, carriage return).
 func (l *Lexer) skipWhitespace() {
-	for l.ch == ' ' || l.ch == '\t' || l.ch == '\n' || l.ch == '\r' {
+	for isWhitespace(l.ch) {
 		if l.ch == '\n' {
 			l.line++
 			l.column = 0
@@ -740,7 +796,7 @@ func (l *Lexer) skipBlockComment(style rune) {
 	startPos := l.currentPos()
 	_, terminated := l.readBlockComment(style)
 	if !terminated {
-		l.addError("unterminated block comment", startPos)
+		l.addIncompleteError("unterminated block comment", startPos)
 	}
 }
 
@@ -751,7 +807,7 @@ func (l *Lexer) skipCStyleComment() {
 	startPos := l.currentPos()
 	_, terminated := l.readCStyleComment()
 	if !terminated {
-		l.addError("unterminated C-style comment", startPos)
+		l.addIncompleteError("unterminated C-style comment", startPos)
 	}
 }
 
@@ -962,7 +1018,7 @@ func (l *Lexer) readTripleQuoteString(quote rune) string {
 	var raw strings.Builder
 	for {
 		if l.ch == 0 {
-			l.addError("unterminated triple-quoted string literal", startPos)
+			l.addIncompleteError("unterminated triple-quoted string literal", startPos)
 			return ""
 		}
 		if l.ch == quote && l.peekChar() == quote && l.peekCharN(2) == quote {
@@ -1040,7 +1096,8 @@ func (l *Lexer) readString(quote rune) string {
 	}
 
 	// Unterminated string - add error and return partial string
-	l.addError("unterminated string literal", Position{
+	l.addIncompleteError("unterminated string literal", Position{
+		Source: l.currentSource(),
 		Line:   startLine,
 		Column: startColumn,
 		Offset: startPos,
@@ -1196,7 +1253,17 @@ func (l *Lexer) handleSimpleToken(tokenType TokenType, literal string, pos Posit
 func (l *Lexer) handleString(pos Position) Token {
 	// DWScript concatenates adjacent string/char literals: 'hello'#13#10 → "hello\r\n"
 	literal := l.readStringOrCharSequence()
-	return NewToken(STRING, literal, pos)
+	return l.newStringToken(literal, pos)
+}
+
+// newStringToken creates a STRING token for the literal read since pos,
+// recording the source text so Length and End cover the whole literal.
+func (l *Lexer) newStringToken(literal string, pos Position) Token {
+	tok := NewToken(STRING, literal, pos)
+	if raw := l.input[pos.Offset:l.position]; raw != literal {
+		tok.Raw = raw
+	}
+	return tok
 }
 
 // handleDefault handles characters not matched by specific cases.
@@ -1225,10 +1292,23 @@ func (l *Lexer) handleDefault(pos Position) Token {
 		}
 		tok := NewToken(ILLEGAL, string(l.ch), pos)
 		l.readChar()
+		if raw := l.input[pos.Offset:l.position]; raw != tok.Literal {
+			tok.Raw = raw
+		}
 		return tok
 	}
 }
 
+// newUnterminatedCommentToken reports an unterminated comment read in
+// comment-preserving mode and returns an ILLEGAL token whose span covers the
+// rest of the input, so that scanning can continue at EOF.
+func (l *Lexer) newUnterminatedCommentToken(msg string, pos Position) Token {
+	l.addError(msg, pos)
+	tok := NewToken(ILLEGAL, msg, pos)
+	tok.Raw = l.input[pos.Offset:l.position]
+	return tok
+}
+
 // handleDot handles the '.' character which could be a single dot or range (..).
 func (l *Lexer) handleDot(pos Position) Token {
 	if l.peekChar() == '.' {
@@ -1277,7 +1357,7 @@ func (l *Lexer) handleHash(pos Position) Token {
 	}
 	// Part of string concatenation: 'hello'#13#10 → "hello\r\n"
 	literal := l.readStringOrCharSequence()
-	return NewToken(STRING, literal, pos)
+	return l.newStringToken(literal, pos)
 }
 
 // handleSlashToken handles the '/' character which could be division, comment, or compound assignment.
@@ -1295,7 +1375,7 @@ func (l *Lexer) handleSlashToken(pos Position) Token {
 		if l.preserveComments {
 			text, ok := l.readCStyleComment()
 			if !ok {
-				return NewToken(ILLEGAL, "unterminated C-style comment", pos)
+				return l.newUnterminatedCommentToken("unterminated C-style comment", pos)
 			}
 			return NewToken(COMMENT, text, pos)
 		}
@@ -1312,7 +1392,7 @@ func (l *Lexer) handleCurlyBrace(pos Position) Token {
 	if l.preserveComments {
 		text, ok := l.readBlockComment('{')
 		if !ok {
-			return NewToken(ILLEGAL, "unterminated block comment", pos)
+			return l.newUnterminatedCommentToken("unterminated block comment", pos)
 		}
 		return NewToken(COMMENT, text, pos)
 	}
@@ -1327,7 +1407,7 @@ func (l *Lexer) handleLeftParen(pos Position) Token {
 		if l.preserveComments {
 			text, ok := l.readBlockComment('(')
 			if !ok {
-				return NewToken(ILLEGAL, "unterminated block comment", pos)
+				return l.newUnterminatedCommentToken("unterminated block comment", pos)
 			}
 			return NewToken(COMMENT, text, pos)
 		}
@@ -1345,6 +1425,11 @@ func (l *Lexer) handleLeftParen(pos Position) Token {
 //nolint:gocyclo // Lexer complexity is acceptable for token dispatching
 func (l *Lexer) nextTokenInternal() Token {
 	for {
+		if l.preserveWhitespace && isWhitespace(l.ch) && !l.isSkippingTokens() {
+			pos := l.currentPos()
+			l.skipWhitespace()
+			return NewToken(WHITESPACE, l.input[pos.Offset:l.position], pos)
+		}
 		l.skipWhitespace()
 
 		// End of an included file: resume the file that included it.
@@ -1363,7 +1448,7 @@ func (l *Lexer) nextTokenInternal() Token {
 		if l.isSkippingTokens() {
 			if l.ch == 0 {
 				if len(l.condStack) > 0 {
-					l.addError("unfinished conditional directive", l.condStack[len(l.condStack)-1].startPos)
+					l.addDirectiveError("unfinished conditional directive", l.condStack[len(l.condStack)-1].startPos)
 					l.condStack = nil
 				}
 				return NewToken(EOF, "", pos)
@@ -1375,7 +1460,7 @@ func (l *Lexer) nextTokenInternal() Token {
 		switch l.ch {
 		case 0:
 			if len(l.condStack) > 0 {
-				l.addError("unfinished conditional directive", l.condStack[len(l.condStack)-1].startPos)
+				l.addDirectiveError("unfinished conditional directive", l.condStack[len(l.condStack)-1].startPos)
 				l.condStack = nil
 			}
 			return NewToken(EOF, "", pos)
@@ -1423,6 +1508,10 @@ func (l *Lexer) nextTokenInternal() Token {
 
 // Helper functions
 
+func isWhitespace(ch rune) bool {
+	return ch == ' ' || ch == '\t' || ch == '\n' || ch == '\r'
+}
+
 func isLetter(ch rune) bool {
 	return unicode.IsLetter(ch) || ch == '_'
 }
@@ -1441,6 +1530,9 @@ func isHexDigit(ch rune) bool {
 type LexerError struct {
 	Message string
 	Pos     Position
+	// Incomplete is set when the input ended inside the token, so more
+	// input could complete it.
+	Incomplete bool
 }
 
 func (e *LexerError) Error() string {
@@ -41,6 +41,58 @@ y`
 	}
 }
 
+// TestStringTokenSpans verifies that string tokens whose value differs from
+// their source text still report the source span through Length and End,
+// and that positions after them stay accurate.
+func TestStringTokenSpans(t *testing.T) {
+	tests := []struct {
+		name            string
+		input           string
+		expectedLiteral string
+		expectedLength  int
+		expectedEndLine int
+		expectedEndCol  int
+	}{
+		{"plain string", "'abc' x", "abc", 5, 1, 6},
+		{"doubled quote", "'it''s' x", "it's", 7, 1, 8},
+		{"char codes", "#13#10 x", "\r\n", 6, 1, 7},
+		{"string with char codes", "'a'#13#10'b' x", "a\r\nb", 12, 1, 13},
+		{"heredoc", "'''\n  one\n  two\n  ''' x", "one\ntwo", 21, 4, 6},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l := New(tt.input)
+			tok := l.NextToken()
+
+			if tok.Type != STRING {
+				t.Fatalf("tokentype wrong. expected=%q, got=%q", STRING, tok.Type)
+			}
+			if tok.Literal != tt.expectedLiteral {
+				t.Fatalf("literal wrong. expected=%q, got=%q", tt.expectedLiteral, tok.Literal)
+			}
+			if tok.Length() != tt.expectedLength {
+				t.Errorf("length wrong. expected=%d, got=%d", tt.expectedLength, tok.Length())
+			}
+			end := tok.End()
+			if end.Line != tt.expectedEndLine || end.Column != tt.expectedEndCol {
+				t.Errorf("end wrong. expected=%d:%d, got=%d:%d",
+					tt.expectedEndLine, tt.expectedEndCol, end.Line, end.Column)
+			}
+
+			// The following identifier starts one space after the literal.
+			next := l.NextToken()
+			if next.Type != IDENT || next.Pos.Line != end.Line || next.Pos.Column != end.Column+1 {
+				t.Errorf("next token wrong. expected IDENT at %d:%d, got %s",
+					end.Line, end.Column+1, next)
+			}
+			if next.Pos.Offset != end.Offset+1 {
+				t.Errorf("next offset wrong. expected=%d, got=%d", end.Offset+1, next.Pos.Offset)
+			}
+		})
+	}
+}
+
 func TestDebugSHR(t *testing.T) {
 	input := "shl shr"
 	l := New(input)
@@ -12,9 +12,10 @@ type TokenType = token.TokenType
 
 // Re-export all token type constants
 const (
-	ILLEGAL = token.ILLEGAL
-	EOF     = token.EOF
-	COMMENT = token.COMMENT
+	ILLEGAL    = token.ILLEGAL
+	EOF        = token.EOF
+	COMMENT    = token.COMMENT
+	WHITESPACE = token.WHITESPACE
 
 	IDENT  = token.IDENT
 	INT    = token.INT
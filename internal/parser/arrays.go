@@ -213,7 +213,7 @@ func (p *Parser) parseArrayLiteral() ast.Expression {
 		}
 
 		// Unexpected token between elements
-		p.addError(fmt.Sprintf("expected ',' or ']', got %s", nextToken.Type), ErrUnexpectedToken)
+		p.addPeekTokenError(fmt.Sprintf("expected ',' or ']', got %s", nextToken.Type), ErrUnexpectedToken)
 		return nil
 	}
 
@@ -1,6 +1,7 @@
 package parser
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/cwbudde/go-dws/pkg/ast"
@@ -135,3 +136,125 @@ func TestCallExpressionPrecedence(t *testing.T) {
 }
 
 // TestStatementDispatch ensures identifiers followed by parentheses parse as calls, not assignments.
+
+// TestCallExpressionNamedArguments ensures "name := value" arguments record
+// their names parallel to the argument list.
+func TestCallExpressionNamedArguments(t *testing.T) {
+	tests := []struct {
+		name   string
+		input  string
+		want   string
+		labels []string
+	}{
+		{name: "all named", input: "Foo(count := 3, name := 'x');", want: "Foo(count := 3, name := \"x\")", labels: []string{"count", "name"}},
+		{name: "positional then named", input: "Foo(1, name := x);", want: "Foo(1, name := x)", labels: []string{"", "name"}},
+		{name: "identifier first", input: "Foo(a, b := 2);", want: "Foo(a, b := 2)", labels: []string{"", "b"}},
+		{name: "non-identifier callee", input: "F(x)(a := 1);", want: "F(x)(a := 1)", labels: []string{"a"}},
+		{name: "positional only", input: "Foo(a, b);", want: "Foo(a, b)", labels: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := testParser(tt.input)
+			program := p.ParseProgram()
+			checkParserErrors(t, p)
+
+			stmt, ok := program.Statements[0].(*ast.ExpressionStatement)
+			if !ok {
+				t.Fatalf("statement is not ast.ExpressionStatement. got=%T", program.Statements[0])
+			}
+			call, ok := stmt.Expression.(*ast.CallExpression)
+			if !ok {
+				t.Fatalf("expression is not ast.CallExpression. got=%T", stmt.Expression)
+			}
+
+			if tt.labels == nil {
+				if call.ArgumentNames != nil {
+					t.Errorf("expected no argument names, got %v", call.ArgumentNames)
+				}
+			} else {
+				if len(call.ArgumentNames) != len(call.Arguments) {
+					t.Fatalf("ArgumentNames has %d entries for %d arguments", len(call.ArgumentNames), len(call.Arguments))
+				}
+				for i, label := range tt.labels {
+					name := call.ArgumentName(i)
+					if label == "" && name != nil {
+						t.Errorf("argument %d: expected positional, got name %q", i, name.Value)
+					}
+					if label != "" && (name == nil || name.Value != label) {
+						t.Errorf("argument %d: expected name %q, got %v", i, label, name)
+					}
+				}
+			}
+
+			if got := call.String(); got != tt.want {
+				t.Errorf("String() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestMethodCallNamedArguments ensures method and constructor argument lists
+// accept "name := value" arguments like plain calls do.
+func TestMethodCallNamedArguments(t *testing.T) {
+	tests := []struct {
+		name   string
+		input  string
+		want   string
+		labels []string
+	}{
+		{name: "method call", input: "c.M(b := 1, a := 10);", want: "c.M(b := 1, a := 10)", labels: []string{"b", "a"}},
+		{name: "positional then named", input: "c.M(1, a := 10);", want: "c.M(1, a := 10)", labels: []string{"", "a"}},
+		{name: "create sugar", input: "TPoint.Create(y := 2, x := 1);", want: "TPoint.Create(y := 2, x := 1)", labels: []string{"y", "x"}},
+		{name: "new", input: "new TPoint(y := 2);", want: "TPoint.Create(y := 2)", labels: []string{"y"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := testParser(tt.input)
+			program := p.ParseProgram()
+			checkParserErrors(t, p)
+
+			stmt, ok := program.Statements[0].(*ast.ExpressionStatement)
+			if !ok {
+				t.Fatalf("statement is not ast.ExpressionStatement. got=%T", program.Statements[0])
+			}
+			call, ok := stmt.Expression.(interface {
+				ast.Expression
+				ArgumentName(i int) *ast.Identifier
+			})
+			if !ok {
+				t.Fatalf("expression has no named arguments. got=%T", stmt.Expression)
+			}
+
+			for i, label := range tt.labels {
+				name := call.ArgumentName(i)
+				if label == "" && name != nil {
+					t.Errorf("argument %d: expected positional, got name %q", i, name.Value)
+				}
+				if label != "" && (name == nil || name.Value != label) {
+					t.Errorf("argument %d: expected name %q, got %v", i, label, name)
+				}
+			}
+
+			if got := call.String(); got != tt.want {
+				t.Errorf("String() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestCallExpressionPositionalAfterNamed ensures positional arguments may not
+// follow named ones.
+func TestCallExpressionPositionalAfterNamed(t *testing.T) {
+	p := testParser("Foo(a := 1, 2);")
+	p.ParseProgram()
+
+	errors := p.Errors()
+	if len(errors) == 0 {
+		t.Fatal("expected a parser error")
+	}
+	if !strings.Contains(errors[0].Message, "positional argument cannot follow a named argument") {
+		t.Errorf("unexpected error: %s", errors[0].Message)
+	}
+}
@@ -56,6 +56,27 @@ func TestParseClassConstants(t *testing.T) {
 			expectedIsClass:   false,
 			expectedVis:       ast.VisibilityProtected,
 		},
+		{
+			name: "strict private class constant",
+			input: `type TBase = class
+				strict private const cStrictPrivate = 4;
+			end;`,
+			expectedConstName: "cStrictPrivate",
+			expectedValue:     "4",
+			expectedIsClass:   false,
+			expectedVis:       ast.VisibilityStrictPrivate,
+		},
+		{
+			name: "strict protected class constant",
+			input: `type TBase = class
+				strict protected
+				class const cStrictProtected = 5;
+			end;`,
+			expectedConstName: "cStrictProtected",
+			expectedValue:     "5",
+			expectedIsClass:   true,
+			expectedVis:       ast.VisibilityStrictProtected,
+		},
 		{
 			name: "class constant with expression",
 			input: `type TBase = class
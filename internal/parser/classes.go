@@ -184,8 +184,10 @@ func isBuiltinClass(name string) bool {
 		"EConvertError", // Standard exception types
 		"ERangeError",
 		"EDivByZero",
+		"EIntOverflow",
 		"EAssertionFailed",
 		"EInvalidOp",
+		"EInvalidCast",
 	}
 
 	for _, builtin := range builtinClasses {
@@ -204,21 +206,29 @@ func isBuiltinClass(name string) bool {
 // PRE: cursor is CLASS
 // POST: cursor is END
 // handleVisibilityKeyword checks for and handles visibility section keywords, returning true if one was found.
-func (p *Parser) handleVisibilityKeyword(cursor *TokenCursor, currentVisibility *ast.Visibility) bool {
-	if cursor.Current().Type == lexer.PRIVATE {
+// For 'strict private' and 'strict protected' the returned cursor is on the second keyword.
+func (p *Parser) handleVisibilityKeyword(cursor *TokenCursor, currentVisibility *ast.Visibility) (*TokenCursor, bool) {
+	switch cursor.Current().Type {
+	case lexer.PRIVATE:
 		*currentVisibility = ast.VisibilityPrivate
-		return true
-	} else if cursor.Current().Type == lexer.PROTECTED {
+	case lexer.PROTECTED:
 		*currentVisibility = ast.VisibilityProtected
-		return true
-	} else if cursor.Current().Type == lexer.PUBLIC {
+	case lexer.PUBLIC, lexer.PUBLISHED:
 		*currentVisibility = ast.VisibilityPublic
-		return true
-	} else if cursor.Current().Type == lexer.PUBLISHED {
-		*currentVisibility = ast.VisibilityPublic
-		return true
+	case lexer.STRICT:
+		switch cursor.Peek(1).Type {
+		case lexer.PRIVATE:
+			*currentVisibility = ast.VisibilityStrictPrivate
+		case lexer.PROTECTED:
+			*currentVisibility = ast.VisibilityStrictProtected
+		default:
+			return cursor, false
+		}
+		return cursor.Advance(), true
+	default:
+		return cursor, false
 	}
-	return false
+	return cursor, true
 }
 
 // parseClassLevelMember parses class-level members (class var/const/property/method/operator).
@@ -467,8 +477,8 @@ func (p *Parser) parseClassDeclarationBody(nameIdent *ast.Identifier) *ast.Class
 		}
 
 		// Check for visibility section keywords
-		if p.handleVisibilityKeyword(cursor, &currentVisibility) {
-			cursor = cursor.Advance()
+		if next, ok := p.handleVisibilityKeyword(cursor, &currentVisibility); ok {
+			cursor = next.Advance()
 			p.cursor = cursor
 			continue
 		}
@@ -710,8 +720,8 @@ func (p *Parser) parseMemberAccess(left ast.Expression) ast.Expression {
 				Arguments: []ast.Expression{},
 			}
 
-			// Parse arguments - cursor will be at RPAREN after parseExpressionList
-			newExpr.Arguments = p.parseExpressionList()
+			// Parse arguments - cursor will be at RPAREN after parseCallArguments
+			newExpr.Arguments, newExpr.ArgumentNames = p.parseCallArguments()
 
 			expr := builder.Finish(newExpr).(*ast.NewExpression)
 
@@ -732,8 +742,8 @@ func (p *Parser) parseMemberAccess(left ast.Expression) ast.Expression {
 			Arguments: []ast.Expression{},
 		}
 
-		// Parse arguments - cursor will be at RPAREN after parseExpressionList
-		methodCall.Arguments = p.parseExpressionList()
+		// Parse arguments - cursor will be at RPAREN after parseCallArguments
+		methodCall.Arguments, methodCall.ArgumentNames = p.parseCallArguments()
 
 		expr := builder.Finish(methodCall).(*ast.MethodCallExpression)
 
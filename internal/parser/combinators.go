@@ -724,7 +724,7 @@ func (p *Parser) StatementBlock(config StatementBlockConfig) *ast.BlockStatement
 		}
 
 		// Parse statement
-		stmt := p.parseStatement()
+		stmt := p.parseStatementWithRecovery()
 		if stmt != nil {
 			block.Statements = append(block.Statements, stmt)
 		}
@@ -165,9 +165,17 @@ func (p *Parser) parseIfStatement() *ast.IfStatement {
 	p.pushBlockContext("if", ifToken.Pos)
 	defer p.popBlockContext()
 
-	// Move past 'if' and parse the condition
+	// Move past 'if' and parse the condition or inline variable
 	p.cursor = p.cursor.Advance()
-	stmt.Condition = p.parseExpression(LOWEST)
+	if p.cursor.Current().Type == lexer.VAR {
+		stmt.InlineVar, stmt.Condition = p.parseInlineVarCondition("if statement")
+		if stmt.InlineVar == nil {
+			p.synchronize([]lexer.TokenType{lexer.THEN, lexer.ELSE, lexer.END})
+			return nil
+		}
+	} else {
+		stmt.Condition = p.parseExpression(LOWEST)
+	}
 
 	if stmt.Condition == nil {
 		// Use structured error for better diagnostics
@@ -186,8 +194,8 @@ func (p *Parser) parseIfStatement() *ast.IfStatement {
 	}
 
 	// Expect 'then' keyword
-	nextToken := p.cursor.Peek(1)
-	if nextToken.Type != lexer.THEN {
+	if !p.advanceToClauseKeyword(lexer.THEN) {
+		nextToken := p.cursor.Peek(1)
 		// Use structured error for missing 'then'
 		err := NewStructuredError(ErrKindMissing).
 			WithCode(ErrMissingThen).
@@ -209,7 +217,6 @@ func (p *Parser) parseIfStatement() *ast.IfStatement {
 
 	// Advance past 'then'
 	p.cursor = p.cursor.Advance()
-	p.cursor = p.cursor.Advance()
 
 	// Allow empty then branches so the analyzer can emit the DWScript hint
 	// instead of falling back to a syntax error.
@@ -218,6 +225,7 @@ func (p *Parser) parseIfStatement() *ast.IfStatement {
 		stmt.Consequence = &ast.EmptyStatement{
 			BaseNode: ast.BaseNode{Token: p.cursor.Current()},
 		}
+		p.unfinished = p.unfinished || p.cursor.Current().Type == lexer.EOF
 	default:
 		// Parse the consequence (then branch)
 		stmt.Consequence = p.parseStatement()
@@ -263,7 +271,7 @@ func (p *Parser) parseIfStatement() *ast.IfStatement {
 		return stmt
 	}
 
-	nextToken = p.cursor.Peek(1)
+	nextToken := p.cursor.Peek(1)
 	if nextToken.Type == lexer.ELSE {
 		p.cursor = p.cursor.Advance() // move to 'else'
 		p.cursor = p.cursor.Advance() // move to statement after 'else'
@@ -308,9 +316,17 @@ func (p *Parser) parseWhileStatement() *ast.WhileStatement {
 	p.pushBlockContext("while", whileToken.Pos)
 	defer p.popBlockContext()
 
-	// Move past 'while' and parse the condition
+	// Move past 'while' and parse the condition or inline variable
 	p.cursor = p.cursor.Advance()
-	stmt.Condition = p.parseExpression(LOWEST)
+	if p.cursor.Current().Type == lexer.VAR {
+		stmt.InlineVar, stmt.Condition = p.parseInlineVarCondition("while loop")
+		if stmt.InlineVar == nil {
+			p.synchronize([]lexer.TokenType{lexer.DO, lexer.END})
+			return nil
+		}
+	} else {
+		stmt.Condition = p.parseExpression(LOWEST)
+	}
 
 	if stmt.Condition == nil {
 		// Use structured error for better diagnostics
@@ -329,8 +345,8 @@ func (p *Parser) parseWhileStatement() *ast.WhileStatement {
 	}
 
 	// Expect 'do' keyword
-	nextToken := p.cursor.Peek(1)
-	if nextToken.Type != lexer.DO {
+	if !p.advanceToClauseKeyword(lexer.DO) {
+		nextToken := p.cursor.Peek(1)
 		// Use structured error for missing 'do'
 		err := NewStructuredError(ErrKindMissing).
 			WithCode(ErrMissingDo).
@@ -350,9 +366,6 @@ func (p *Parser) parseWhileStatement() *ast.WhileStatement {
 		}
 	}
 
-	// Advance past 'do'
-	p.cursor = p.cursor.Advance()
-
 	// Parse the body statement
 	p.cursor = p.cursor.Advance()
 	stmt.Body = p.parseStatement()
@@ -378,6 +391,63 @@ func (p *Parser) parseWhileStatement() *ast.WhileStatement {
 	return stmt
 }
 
+// parseInlineVarCondition parses the inline variable declaration that takes
+// the place of the condition in "if var x := <expr> then" and
+// "while var x := <expr> do". It returns the declaration and an identifier
+// naming the variable, which serves as the statement's condition, or nil
+// values after reporting an error.
+// Syntax: var <name> [: <type>] := <expression>
+// PRE: cursor is on VAR token
+// POST: cursor is on last token of the initial value
+func (p *Parser) parseInlineVarCondition(phase string) (*ast.VarDeclStatement, ast.Expression) {
+	builder := p.StartNode()
+	decl := &ast.VarDeclStatement{}
+	if !p.validateAndAdvanceVarToken(decl) {
+		return nil, nil
+	}
+
+	nameToken := p.cursor.Current()
+	name := &ast.Identifier{
+		TypedExpressionBase: ast.TypedExpressionBase{
+			BaseNode: ast.BaseNode{Token: nameToken},
+		},
+		Value: nameToken.Literal,
+	}
+	decl.Names = []*ast.Identifier{name}
+	p.parseVarType(decl)
+
+	nextToken := p.cursor.Peek(1)
+	if nextToken.Type != lexer.ASSIGN {
+		err := NewStructuredError(ErrKindMissing).
+			WithCode(ErrMissingAssign).
+			WithMessage("expected ':=' after inline variable").
+			WithPosition(nextToken.Pos, nextToken.Length()).
+			WithExpectedString("':='").
+			WithActual(nextToken.Type, nextToken.Literal).
+			WithSuggestion("initialize the inline variable, like 'var x := Find()'").
+			WithParsePhase(phase).
+			Build()
+		p.addStructuredError(err)
+		return nil, nil
+	}
+	p.cursor = p.cursor.Advance() // move to ':='
+	p.cursor = p.cursor.Advance() // move to value expression
+	decl.Inferred = decl.Type == nil
+	decl.Value = p.parseExpression(LOWEST)
+	if decl.Value == nil {
+		return nil, nil
+	}
+	decl = builder.FinishWithNode(decl, decl.Value).(*ast.VarDeclStatement)
+
+	condition := &ast.Identifier{
+		TypedExpressionBase: ast.TypedExpressionBase{
+			BaseNode: ast.BaseNode{Token: nameToken},
+		},
+		Value: nameToken.Literal,
+	}
+	return decl, condition
+}
+
 // Syntax: repeat <statements> until <condition>
 // Note: The body can contain multiple statements
 // PRE: cursor is on REPEAT token
@@ -410,7 +480,7 @@ func (p *Parser) parseRepeatStatement() *ast.RepeatStatement {
 			continue
 		}
 
-		bodyStmt := p.parseStatement()
+		bodyStmt := p.parseStatementWithRecovery()
 		if bodyStmt != nil {
 			block.Statements = append(block.Statements, bodyStmt)
 		}
@@ -738,8 +808,8 @@ func (p *Parser) parseForStatement() ast.Statement {
 	}
 
 	// Parse direction keyword ('to' or 'downto')
-	nextToken = p.cursor.Peek(1)
-	if nextToken.Type != lexer.TO && nextToken.Type != lexer.DOWNTO {
+	if !p.advanceToClauseKeyword(lexer.TO, lexer.DOWNTO) {
+		nextToken = p.cursor.Peek(1)
 		// Use structured error
 		err := NewStructuredError(ErrKindMissing).
 			WithCode(ErrMissingTo).
@@ -754,8 +824,6 @@ func (p *Parser) parseForStatement() ast.Statement {
 		return nil
 	}
 
-	p.cursor = p.cursor.Advance() // Move to TO or DOWNTO
-
 	// Set direction based on token
 	currentToken := p.cursor.Current()
 	switch currentToken.Type {
@@ -806,8 +874,8 @@ func (p *Parser) parseForStatement() ast.Statement {
 	}
 
 	// Expect 'do' keyword
-	nextToken = p.cursor.Peek(1)
-	if nextToken.Type != lexer.DO {
+	if !p.advanceToClauseKeyword(lexer.DO) {
+		nextToken = p.cursor.Peek(1)
 		// Use structured error
 		err := NewStructuredError(ErrKindMissing).
 			WithCode(ErrMissingDo).
@@ -822,8 +890,6 @@ func (p *Parser) parseForStatement() ast.Statement {
 		return nil
 	}
 
-	p.cursor = p.cursor.Advance() // move to 'do'
-
 	// Parse the body statement
 	p.cursor = p.cursor.Advance()
 	stmt.Body = p.parseStatement()
@@ -847,6 +913,15 @@ func (p *Parser) parseForStatement() ast.Statement {
 	return stmt
 }
 
+// lastForExpression returns the expression that precedes 'do' in a for
+// loop header: the step if present, otherwise the bound or collection.
+func lastForExpression(expr, step ast.Expression) ast.Expression {
+	if step != nil {
+		return step
+	}
+	return expr
+}
+
 // Syntax: for [var] <variable> in <expression> [step <step>] do <statement>
 // PRE: cursor is on variable IDENT, forToken and variable already parsed
 // POST: cursor is on last token of body statement
@@ -918,8 +993,8 @@ func (p *Parser) parseForInLoop(forToken lexer.Token, variable *ast.Identifier,
 	}
 
 	// Expect 'do' keyword
-	nextToken = p.cursor.Peek(1)
-	if nextToken.Type != lexer.DO {
+	if !p.advanceToClauseKeyword(lexer.DO) {
+		nextToken = p.cursor.Peek(1)
 		// Use structured error
 		err := NewStructuredError(ErrKindMissing).
 			WithCode(ErrMissingDo).
@@ -934,8 +1009,6 @@ func (p *Parser) parseForInLoop(forToken lexer.Token, variable *ast.Identifier,
 		return nil
 	}
 
-	p.cursor = p.cursor.Advance() // move to 'do'
-
 	// Parse the body statement
 	p.cursor = p.cursor.Advance()
 	stmt.Body = p.parseStatement()
@@ -1135,7 +1208,7 @@ func (p *Parser) parseCaseElseClause() ast.Statement {
 			continue
 		}
 
-		elseStmt := p.parseStatement()
+		elseStmt := p.parseStatementWithRecovery()
 		if elseStmt != nil {
 			block.Statements = append(block.Statements, elseStmt)
 		}
@@ -1202,8 +1275,8 @@ func (p *Parser) parseCaseStatement() *ast.CaseStatement {
 	}
 
 	// Expect 'of' keyword
-	nextToken := p.cursor.Peek(1)
-	if nextToken.Type != lexer.OF {
+	if !p.advanceToClauseKeyword(lexer.OF) {
+		nextToken := p.cursor.Peek(1)
 		// Use structured error
 		err := NewStructuredError(ErrKindMissing).
 			WithCode(ErrMissingOf).
@@ -1218,8 +1291,6 @@ func (p *Parser) parseCaseStatement() *ast.CaseStatement {
 		return nil
 	}
 
-	p.cursor = p.cursor.Advance() // move to 'of'
-
 	// Parse case branches
 	stmt.Cases = []*ast.CaseBranch{}
 
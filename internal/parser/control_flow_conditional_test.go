@@ -316,6 +316,30 @@ end;`,
 				}
 			},
 		},
+		{
+			name:  "if with inline var",
+			input: "if var o: TObject := Find('a') then PrintLn(o.ClassName) else PrintLn('none');",
+			assertions: func(t *testing.T, stmt *ast.IfStatement) {
+				if stmt.InlineVar == nil {
+					t.Fatalf("InlineVar is nil")
+				}
+				if len(stmt.InlineVar.Names) != 1 || stmt.InlineVar.Names[0].Value != "o" {
+					t.Fatalf("InlineVar names = %v, want [o]", stmt.InlineVar.Names)
+				}
+				if stmt.InlineVar.Type == nil || stmt.InlineVar.Type.String() != "TObject" {
+					t.Errorf("InlineVar type = %v, want TObject", stmt.InlineVar.Type)
+				}
+				if _, ok := stmt.InlineVar.Value.(*ast.CallExpression); !ok {
+					t.Errorf("InlineVar value is not CallExpression. got=%T", stmt.InlineVar.Value)
+				}
+				if !testIdentifier(t, stmt.Condition, "o") {
+					return
+				}
+				if stmt.Alternative == nil {
+					t.Errorf("alternative is nil")
+				}
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -195,6 +195,27 @@ end;`,
 				}
 			},
 		},
+		{
+			name:  "while with inline var",
+			input: "while var line := ReadNext() do PrintLn(line);",
+			assertions: func(t *testing.T, stmt *ast.WhileStatement) {
+				if stmt.InlineVar == nil {
+					t.Fatalf("InlineVar is nil")
+				}
+				if len(stmt.InlineVar.Names) != 1 || stmt.InlineVar.Names[0].Value != "line" {
+					t.Fatalf("InlineVar names = %v, want [line]", stmt.InlineVar.Names)
+				}
+				if !stmt.InlineVar.Inferred {
+					t.Errorf("InlineVar.Inferred = false, want true")
+				}
+				if !testIdentifier(t, stmt.Condition, "line") {
+					return
+				}
+				if got := stmt.String(); got != "while var line = ReadNext() do PrintLn(line)" {
+					t.Errorf("String() = %q", got)
+				}
+			},
+		},
 	}
 
 	for _, tt := range tests {
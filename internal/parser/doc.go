@@ -21,4 +21,12 @@
 //	if len(p.Errors()) > 0 {
 //	    // handle errors
 //	}
+//
+// After a syntax error the parser resynchronizes at the next statement
+// boundary (';', 'end', 'begin' or a declaration keyword), so one mistake
+// is reported once instead of cascading. Statements that could not be parsed
+// at all appear in the AST as *ast.InvalidStatement nodes spanning the skipped
+// tokens. A missing operand before a clause keyword such as 'then' or 'do' is
+// reported once, not again as a missing keyword, and Errors reports at most
+// one error per source position and at most ParserConfig.MaxErrors errors.
 package parser
@@ -12,6 +12,14 @@ type ParserError struct {
 	Code    string
 	Pos     lexer.Position
 	Length  int
+	// Incomplete is set when the error was caused by the input ending early,
+	// such as an unclosed begin/end block or a trailing operator, so more
+	// input could make the program valid.
+	Incomplete bool
+	// companion is set on an error reported on purpose at the position of
+	// an earlier one, as a second diagnostic for the same mistake, so Errors
+	// does not drop it as a follow-on.
+	companion bool
 }
 
 // Error implements the error interface.
@@ -93,4 +101,7 @@ const (
 
 	// ErrInvalidType indicates an invalid type
 	ErrInvalidType = "E_INVALID_TYPE"
+
+	// ErrTooManyErrors summarizes the errors dropped once MaxErrors is reached
+	ErrTooManyErrors = "E_TOO_MANY_ERRORS"
 )
@@ -1,10 +1,12 @@
 package parser
 
 import (
+	"fmt"
 	"strings"
 	"testing"
 
 	"github.com/cwbudde/go-dws/internal/lexer"
+	"github.com/cwbudde/go-dws/pkg/ast"
 )
 
 // TestErrorRecoveryBlockStatement tests error recovery in begin...end blocks
@@ -113,7 +115,7 @@ func TestErrorRecoveryIfStatement(t *testing.T) {
 				Print('invalid');
 			`,
 			expectErrors:  1,
-			errorContains: []string{"if block"},
+			errorContains: []string{"Expression expected"},
 		},
 		{
 			name: "missing consequence",
@@ -130,8 +132,8 @@ func TestErrorRecoveryIfStatement(t *testing.T) {
 			input: `
 			if x > 10
 				y := 20; // missing then
-			if z < 5 then
-			// missing statement after then
+			if z < 5
+				w := 1; // missing then
 			`,
 			expectErrors:  2,
 			errorContains: []string{"if block"},
@@ -200,7 +202,7 @@ func TestErrorRecoveryWhileStatement(t *testing.T) {
 				x := x - 1;
 			`,
 			expectErrors:  1,
-			errorContains: []string{"while block"},
+			errorContains: []string{"Expression expected"},
 		},
 		{
 			name: "missing body",
@@ -413,6 +415,79 @@ func TestMultipleErrorsReported(t *testing.T) {
 	}
 }
 
+// TestIndependentErrorsReported verifies that the parser recovers from each
+// broken statement and reports every independent error once, at its own
+// position, while still returning the statements around them.
+func TestIndependentErrorsReported(t *testing.T) {
+	input := "var a := 1 + ;\n" +
+		"if a > then PrintLn('big');\n" +
+		"for var i := 1 to do PrintLn(i);\n" +
+		"PrintLn('done');\n"
+
+	p := New(lexer.New(input))
+	program := p.ParseProgram()
+
+	errs := p.Errors()
+	if len(errs) != 3 {
+		t.Fatalf("got %d errors, want 3", len(errs))
+	}
+	for i, err := range errs {
+		if err.Pos.Line != i+1 {
+			t.Errorf("error %d is on line %d, want line %d: %s", i, err.Pos.Line, i+1, err)
+		}
+	}
+
+	if program == nil || len(program.Statements) != 4 {
+		t.Fatalf("expected a partial AST with 4 statements, got %v", program)
+	}
+	for i, want := range []string{"*ast.VarDeclStatement", "*ast.IfStatement", "*ast.ForStatement", "*ast.ExpressionStatement"} {
+		if got := fmt.Sprintf("%T", program.Statements[i]); got != want {
+			t.Errorf("statement %d = %s, want %s", i, got, want)
+		}
+	}
+}
+
+// TestErrorsOnePerPosition verifies that follow-on errors reported at the
+// position of an earlier error are left out.
+func TestErrorsOnePerPosition(t *testing.T) {
+	p := New(lexer.New("Foo(;\nBar(;\n"))
+	p.ParseProgram()
+
+	errs := p.Errors()
+	if len(errs) != 2 {
+		t.Fatalf("got %d errors, want one per line", len(errs))
+	}
+	if errs[0].Pos == errs[1].Pos {
+		t.Errorf("errors share position %v", errs[0].Pos)
+	}
+}
+
+// TestRecordMethodMissingBeginErrors verifies that a record method written
+// without 'begin' reports the misplaced-fields error at the position of the
+// missing ';', and that parsing resumes with the record members so the
+// record's own 'end' is not reported again.
+func TestRecordMethodMissingBeginErrors(t *testing.T) {
+	input := "type\n" +
+		"   TRec = record\n" +
+		"      x : Integer;\n" +
+		"      procedure Test print(x); end;\n" +
+		"   end;\n"
+
+	p := New(lexer.New(input))
+	p.ParseProgram()
+
+	errs := p.Errors()
+	if len(errs) != 2 {
+		t.Fatalf("got %d errors, want 2: %v", len(errs), errs)
+	}
+	if errs[0].Pos != errs[1].Pos {
+		t.Errorf("errors at %v and %v, want the same position", errs[0].Pos, errs[1].Pos)
+	}
+	if !strings.Contains(errs[1].Message, "Record fields must be declared before record methods") {
+		t.Errorf("second error = %q, want the misplaced-fields error", errs[1].Message)
+	}
+}
+
 // TestContextInNestedBlocks tests that error messages include proper context for nested blocks
 func TestContextInNestedBlocks(t *testing.T) {
 	input := `
@@ -484,6 +559,166 @@ func TestSynchronizationPreventsInfiniteLoops(t *testing.T) {
 	}
 }
 
+// TestStatementLevelRecovery verifies that a broken statement is reported once
+// and that parsing resumes at the next statement boundary.
+func TestStatementLevelRecovery(t *testing.T) {
+	tests := []struct {
+		name       string
+		input      string
+		wantErrors int
+		wantTypes  []string // top-level statement types, in order
+	}{
+		{
+			name:       "stray closing parens",
+			input:      "x := ) ) );\ny := 1;\n",
+			wantErrors: 1,
+			wantTypes:  []string{"*ast.AssignmentStatement", "*ast.AssignmentStatement"},
+		},
+		{
+			name:       "unparseable statement becomes invalid statement",
+			input:      "if x > 10\n  y := 20;\nPrintLn('after');\n",
+			wantErrors: 1,
+			wantTypes:  []string{"*ast.InvalidStatement", "*ast.ExpressionStatement"},
+		},
+		{
+			name:       "resumes at declaration keyword without semicolon",
+			input:      "class x y z\nvar a := 1;\n",
+			wantErrors: 1,
+			wantTypes:  []string{"*ast.InvalidStatement", "*ast.VarDeclStatement"},
+		},
+		{
+			name:       "broken statement before block",
+			input:      "class 1 2 3\nbegin\n  PrintLn('x');\nend;\n",
+			wantErrors: 1,
+			wantTypes:  []string{"*ast.InvalidStatement", "*ast.BlockStatement"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := New(lexer.New(tt.input))
+			program := p.ParseProgram()
+
+			if errs := p.Errors(); len(errs) != tt.wantErrors {
+				t.Errorf("got %d errors, want %d", len(errs), tt.wantErrors)
+				for _, err := range errs {
+					t.Logf("  %s", err)
+				}
+			}
+			var got []string
+			for _, stmt := range program.Statements {
+				got = append(got, fmt.Sprintf("%T", stmt))
+			}
+			if strings.Join(got, " ") != strings.Join(tt.wantTypes, " ") {
+				t.Errorf("statements = %v, want %v", got, tt.wantTypes)
+			}
+		})
+	}
+}
+
+// TestStatementRecoveryKeepsEnclosingBlock verifies that recovery inside a
+// begin...end block does not consume the block's own 'end'.
+func TestStatementRecoveryKeepsEnclosingBlock(t *testing.T) {
+	input := "procedure Foo;\nbegin\n  x := ) ) );\n  if a > 1\n  y := 1;\nend;\n\nprocedure Bar;\nbegin\nend;\n"
+	p := New(lexer.New(input))
+	program := p.ParseProgram()
+
+	if errs := p.Errors(); len(errs) != 2 {
+		t.Errorf("got %d errors, want 2 (one per broken statement)", len(errs))
+		for _, err := range errs {
+			t.Logf("  %s", err)
+		}
+	}
+	if len(program.Statements) != 2 {
+		t.Fatalf("got %d top-level statements, want 2", len(program.Statements))
+	}
+	foo, ok := program.Statements[0].(*ast.FunctionDecl)
+	if !ok || foo.Body == nil {
+		t.Fatalf("first statement = %T, want a FunctionDecl with a body", program.Statements[0])
+	}
+	if n := len(foo.Body.Statements); n != 2 {
+		t.Errorf("Foo body has %d statements, want 2", n)
+	}
+	if end := foo.End(); end.Line != 6 {
+		t.Errorf("Foo ends on line %d, want 6", end.Line)
+	}
+}
+
+// TestInvalidStatementSpan verifies that an InvalidStatement covers the
+// skipped tokens.
+func TestInvalidStatementSpan(t *testing.T) {
+	p := New(lexer.New("class a b c;\nvar x := 1;"))
+	program := p.ParseProgram()
+
+	bad, ok := program.Statements[0].(*ast.InvalidStatement)
+	if !ok {
+		t.Fatalf("first statement = %T, want *ast.InvalidStatement", program.Statements[0])
+	}
+	if pos := bad.Pos(); pos.Line != 1 || pos.Column != 1 {
+		t.Errorf("Pos() = %d:%d, want 1:1", pos.Line, pos.Column)
+	}
+	if end := bad.End(); end.Line != 1 || end.Column != 13 {
+		t.Errorf("End() = %d:%d, want 1:13", end.Line, end.Column)
+	}
+}
+
+// TestRecoveredASTPositions parses broken inputs and walks the recovered AST,
+// checking that every node reports a usable span.
+func TestRecoveredASTPositions(t *testing.T) {
+	inputs := []string{
+		"while do ;\nfor := ;\nPrintLn('x');",
+		"x := 1 +* 2;\nif then else;\nrepeat until;",
+		"begin\n  x := (1 + ;\n  case of end;\nend.",
+		"type TFoo = class\n  FX Integer;\n  procedure ;\nend;\nvar a := ;",
+		"procedure P(; begin try except on do end; end;",
+		"end end end ) ] ;",
+	}
+
+	for _, input := range inputs {
+		p := New(lexer.New(input))
+		program := p.ParseProgram()
+		if len(p.Errors()) == 0 {
+			t.Errorf("%q: expected errors", input)
+		}
+		ast.Inspect(program, func(node ast.Node) bool {
+			if node == nil {
+				return false
+			}
+			start, end := node.Pos(), node.End()
+			if end.Line < start.Line || (end.Line == start.Line && end.Column < start.Column) {
+				t.Errorf("%q: %T ends at %d:%d before it starts at %d:%d",
+					input, node, end.Line, end.Column, start.Line, start.Column)
+			}
+			return true
+		})
+	}
+}
+
+// TestMaxErrors verifies that Errors caps its result and summarizes the rest.
+func TestMaxErrors(t *testing.T) {
+	input := strings.Repeat("x := );\n", 10)
+
+	p := NewParserBuilder(lexer.New(input)).WithMaxErrors(3).Build()
+	p.ParseProgram()
+	errs := p.Errors()
+	if len(errs) != 4 {
+		t.Fatalf("got %d errors, want 3 plus a summary", len(errs))
+	}
+	last := errs[3]
+	if last.Code != ErrTooManyErrors || !strings.Contains(last.Message, "7 more") {
+		t.Errorf("summary = %s (%s), want ErrTooManyErrors mentioning 7 more", last, last.Code)
+	}
+	if last.Pos.Line != 4 {
+		t.Errorf("summary line = %d, want 4", last.Pos.Line)
+	}
+
+	p = NewParserBuilder(lexer.New(input)).WithMaxErrors(0).Build()
+	p.ParseProgram()
+	if n := len(p.Errors()); n != 10 {
+		t.Errorf("unlimited: got %d errors, want 10", n)
+	}
+}
+
 // Unit tests for ErrorRecovery methods
 
 // TestNewErrorRecovery tests creation of ErrorRecovery instance
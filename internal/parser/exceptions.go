@@ -260,7 +260,7 @@ func (p *Parser) parseBlockStatementForTry() *ast.BlockStatement {
 			continue
 		}
 
-		stmt := p.parseStatement()
+		stmt := p.parseStatementWithRecovery()
 		if stmt != nil {
 			block.Statements = append(block.Statements, stmt)
 		}
@@ -350,7 +350,7 @@ func (p *Parser) parseExceptClause() *ast.ExceptClause {
 			}
 
 			// Parse statement
-			stmt := p.parseStatement()
+			stmt := p.parseStatementWithRecovery()
 			if stmt != nil {
 				bareBlock.Statements = append(bareBlock.Statements, stmt)
 			}
@@ -403,7 +403,7 @@ func (p *Parser) parseExceptClause() *ast.ExceptClause {
 			}
 
 			// Parse statement
-			stmt := p.parseStatement()
+			stmt := p.parseStatementWithRecovery()
 			if stmt != nil {
 				elseBlock.Statements = append(elseBlock.Statements, stmt)
 			}
@@ -597,7 +597,7 @@ func (p *Parser) parseFinallyClause() *ast.FinallyClause {
 		}
 
 		// Parse statement
-		stmt := p.parseStatement()
+		stmt := p.parseStatementWithRecovery()
 		if stmt != nil {
 			block.Statements = append(block.Statements, stmt)
 		}
@@ -261,10 +261,7 @@ func (p *Parser) parseInfixExpression(left ast.Expression) ast.Expression {
 	}
 
 	// Get precedence based on operator token type
-	precedence := LOWEST
-	if prec, ok := precedences[operatorToken.Type]; ok {
-		precedence = prec
-	}
+	precedence := getPrecedence(operatorToken.Type)
 
 	// Advance cursor to next token (the start of right expression)
 	p.cursor = p.cursor.Advance()
@@ -281,6 +278,17 @@ func (p *Parser) parseInfixExpression(left ast.Expression) ast.Expression {
 // PRE: cursor is before the list (at opening delimiter)
 // POST: cursor is at terminator (RPAREN)
 func (p *Parser) parseExpressionList() []ast.Expression {
+	return p.parseExpressionListWith(func() ast.Expression {
+		return p.parseExpression(LOWEST)
+	})
+}
+
+// parseExpressionListWith parses a comma-separated list using parseItem for
+// each element. parseItem starts at the element's first token and leaves
+// the cursor on its last token.
+// PRE: cursor is before the list (at opening delimiter)
+// POST: cursor is at terminator (RPAREN)
+func (p *Parser) parseExpressionListWith(parseItem func() ast.Expression) []ast.Expression {
 	list := []ast.Expression{}
 
 	// Check for empty list
@@ -294,7 +302,7 @@ func (p *Parser) parseExpressionList() []ast.Expression {
 	p.cursor = p.cursor.Advance()
 
 	// Parse first expression
-	expr := p.parseExpression(LOWEST)
+	expr := parseItem()
 	if expr != nil {
 		list = append(list, expr)
 	}
@@ -322,7 +330,7 @@ func (p *Parser) parseExpressionList() []ast.Expression {
 
 			// Move from current comma to the next expression.
 			p.cursor = p.cursor.Advance()
-			expr = p.parseExpression(LOWEST)
+			expr = parseItem()
 			if expr != nil {
 				list = append(list, expr)
 			}
@@ -350,7 +358,7 @@ func (p *Parser) parseExpressionList() []ast.Expression {
 			p.cursor = p.cursor.Advance()
 
 			// Parse next expression
-			expr = p.parseExpression(LOWEST)
+			expr = parseItem()
 			if expr != nil {
 				list = append(list, expr)
 			}
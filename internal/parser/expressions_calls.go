@@ -43,7 +43,7 @@ func (p *Parser) parseCallExpression(function ast.Expression) ast.Expression {
 		return builder.Finish(exp).(ast.Expression)
 	}
 
-	exp.Arguments = p.parseExpressionList()
+	exp.Arguments, exp.ArgumentNames = p.parseCallArguments()
 	return builder.Finish(exp).(ast.Expression) // cursor is now at RPAREN
 }
 
@@ -66,7 +66,7 @@ func (p *Parser) parseCallOrRecordLiteral(typeName *ast.Identifier) ast.Expressi
 
 	// We have: TypeName(IDENT ...
 	// Parse arguments/fields and determine type based on whether ALL have colons
-	items, allHaveColons := p.parseArgumentsOrFields(lexer.RPAREN)
+	items, argNames, allHaveColons := p.parseArgumentsOrFields(lexer.RPAREN)
 
 	if allHaveColons {
 		// All items were field initializers -> record literal
@@ -74,7 +74,9 @@ func (p *Parser) parseCallOrRecordLiteral(typeName *ast.Identifier) ast.Expressi
 	}
 
 	// Some or no items had colons -> function call
-	return p.buildCallExpressionFromFields(typeName, items)
+	call := p.buildCallExpressionFromFields(typeName, items)
+	call.ArgumentNames = argNames
+	return call
 }
 
 // parseEmptyCall creates a call expression with no arguments.
@@ -116,7 +118,7 @@ func (p *Parser) parseCallWithExpressionList(typeName *ast.Identifier) *ast.Call
 	}
 
 	// Parse argument list using cursor version
-	exp.Arguments = p.parseExpressionList()
+	exp.Arguments, exp.ArgumentNames = p.parseCallArguments()
 
 	// Set end position to RPAREN
 	expr, _ := builder.Finish(exp).(*ast.CallExpression)
@@ -155,28 +157,52 @@ func (p *Parser) buildCallExpressionFromFields(typeName *ast.Identifier, items [
 }
 
 // parseArgumentsOrFields parses a list that could be either function arguments or record fields.
-// Returns the parsed items and whether ALL of them were colon-based fields.
+// Returns the parsed items, the names of named call arguments ("name := value",
+// parallel to items, or nil if there are none) and whether ALL items were
+// colon-based fields.
 // PRE: cursor is on LPAREN
 // POST: cursor is on end token
-func (p *Parser) parseArgumentsOrFields(end lexer.TokenType) ([]*ast.FieldInitializer, bool) {
+func (p *Parser) parseArgumentsOrFields(end lexer.TokenType) ([]*ast.FieldInitializer, []*ast.Identifier, bool) {
 	var items []*ast.FieldInitializer
+	var argNames []*ast.Identifier
+	hasNamed := false
 	allHaveColons := true
 
 	// Check for empty list
 	nextToken := p.cursor.Peek(1)
 	if nextToken.Type == end {
 		p.cursor = p.cursor.Advance() // consume end token
-		return items, true            // empty list
+		return items, nil, true       // empty list
 	}
 
 	// Move to first element
 	p.cursor = p.cursor.Advance()
 
+	finish := func() []*ast.Identifier {
+		if hasNamed {
+			return argNames
+		}
+		return nil
+	}
+
 	for {
+		// A named call argument (name := value) rules out a record literal.
+		argName := p.parseArgumentName(hasNamed)
+		if argName != nil {
+			hasNamed = true
+			allHaveColons = false
+		}
+
 		// Parse either a field initializer (name: value) or plain expression
-		item, hasColon := p.parseSingleArgumentOrField()
+		var item *ast.FieldInitializer
+		hasColon := false
+		if argName != nil {
+			item = p.parseArgumentAsFieldInitializer()
+		} else {
+			item, hasColon = p.parseSingleArgumentOrField()
+		}
 		if item == nil {
-			return items, false
+			return items, finish(), false
 		}
 
 		if !hasColon {
@@ -184,6 +210,7 @@ func (p *Parser) parseArgumentsOrFields(end lexer.TokenType) ([]*ast.FieldInitia
 		}
 
 		items = append(items, item)
+		argNames = append(argNames, argName)
 
 		recoveredOnBoundary := isInvalidExpression(item.Value) &&
 			(p.cursor.Current().Type == end || p.cursor.Current().Type == lexer.COMMA || p.cursor.Current().Type == lexer.SEMICOLON)
@@ -202,14 +229,68 @@ func (p *Parser) parseArgumentsOrFields(end lexer.TokenType) ([]*ast.FieldInitia
 		// Check if we should continue to next item
 		shouldContinue, ok := p.advanceToNextItem(end)
 		if !ok {
-			return items, false
+			return items, finish(), false
 		}
 		if !shouldContinue {
 			break
 		}
 	}
 
-	return items, allHaveColons
+	return items, finish(), allHaveColons
+}
+
+// parseCallArguments parses a call's argument list, accepting named
+// arguments written as "name := value". The names are returned parallel to
+// the arguments (nil for positional ones), or as nil if none was named.
+// PRE: cursor is at LPAREN
+// POST: cursor is at RPAREN
+func (p *Parser) parseCallArguments() ([]ast.Expression, []*ast.Identifier) {
+	var names []*ast.Identifier
+	hasNamed := false
+
+	args := p.parseExpressionListWith(func() ast.Expression {
+		name := p.parseArgumentName(hasNamed)
+		if name != nil {
+			hasNamed = true
+		}
+		expr := p.parseExpression(LOWEST)
+		if expr != nil {
+			names = append(names, name)
+		}
+		return expr
+	})
+
+	if !hasNamed {
+		return args, nil
+	}
+	return args, names
+}
+
+// parseArgumentName consumes the "name :=" prefix of a named call argument
+// and returns the name, or returns nil (consuming nothing) for a positional
+// argument. Positional arguments may not follow named ones.
+// PRE: cursor is at the argument's first token
+// POST: cursor is at the argument value's first token
+func (p *Parser) parseArgumentName(afterNamed bool) *ast.Identifier {
+	current := p.cursor.Current()
+	if current.Type != lexer.IDENT || p.cursor.Peek(1).Type != lexer.ASSIGN {
+		if afterNamed {
+			p.addError("positional argument cannot follow a named argument", ErrInvalidExpression)
+		}
+		return nil
+	}
+
+	name := &ast.Identifier{
+		TypedExpressionBase: ast.TypedExpressionBase{
+			BaseNode: ast.BaseNode{
+				Token:  current,
+				EndPos: p.endPosFromToken(current),
+			},
+		},
+		Value: current.Literal,
+	}
+	p.cursor = p.cursor.AdvanceN(2) // skip name and ':='
+	return name
 }
 
 // parseNamedFieldInitializer parses a field initializer: name : value
@@ -119,7 +119,7 @@ func (p *Parser) parseLambdaExpression() ast.Expression {
 
 		statements := []ast.Statement{}
 		for p.cursor.Current().Type != lexer.END && p.cursor.Current().Type != lexer.EOF {
-			stmt := p.parseStatement()
+			stmt := p.parseStatementWithRecovery()
 			if stmt != nil {
 				statements = append(statements, stmt)
 			}
@@ -200,7 +200,7 @@ func (p *Parser) parseNewClassExpression(newToken lexer.Token, className *ast.Id
 	p.cursor = p.cursor.Advance()
 
 	// Parse constructor arguments
-	newExpr.Arguments = p.parseExpressionList()
+	newExpr.Arguments, newExpr.ArgumentNames = p.parseCallArguments()
 
 	// Record the end position (one past the closing parenthesis) so that
 	// End() reflects the whole expression, e.g. for raise-position reporting.
@@ -250,7 +250,7 @@ func (p *Parser) parseNewOperandExpression(newToken lexer.Token) ast.Expression
 	// Optional trailing constructor arguments: new (operand)(args)
 	if p.cursor.Peek(1).Type == lexer.LPAREN {
 		p.cursor = p.cursor.Advance() // move to '('
-		newExpr.Arguments = p.parseExpressionList()
+		newExpr.Arguments, newExpr.ArgumentNames = p.parseCallArguments()
 	}
 
 	newExpr.EndPos = p.endPosFromToken(p.cursor.Current())
@@ -264,9 +264,11 @@ func (p *Parser) parseFunctionReturnType() *ast.TypeAnnotation {
 }
 
 // parseFunctionQualifiedName parses a function name, which may be qualified (ClassName.MethodName).
+// A generic class or record qualifier carries its type parameters
+// (TBox<T>.MethodName), which are returned as classTypeParams.
 // PRE: cursor is at function/procedure name
 // POST: cursor is at function name (last identifier)
-func (p *Parser) parseFunctionQualifiedName() (name, className *ast.Identifier) {
+func (p *Parser) parseFunctionQualifiedName() (name, className *ast.Identifier, classTypeParams []string) {
 	cursor := p.cursor
 
 	firstIdent := &ast.Identifier{
@@ -278,6 +280,11 @@ func (p *Parser) parseFunctionQualifiedName() (name, className *ast.Identifier)
 		Value: cursor.Current().Literal,
 	}
 
+	if p.isGenericMethodQualifier() {
+		classTypeParams = p.parseTypeParameters()
+		cursor = p.cursor
+	}
+
 	// Collect qualified identifiers for nested classes (e.g., TOuter.TInner.Method)
 	parts := []string{firstIdent.Value}
 	// Advance through any ".Ident" segments to build the qualified class name
@@ -307,7 +314,26 @@ func (p *Parser) parseFunctionQualifiedName() (name, className *ast.Identifier)
 		className = nil
 	}
 
-	return name, className
+	return name, className, classTypeParams
+}
+
+// isGenericMethodQualifier reports whether the current identifier is followed
+// by a type-parameter list and a dot, as in `TBox<T>.Get`.
+func (p *Parser) isGenericMethodQualifier() bool {
+	if p.cursor.Peek(1).Type != lexer.LESS {
+		return false
+	}
+	for i := 2; ; i++ {
+		switch t := p.cursor.Peek(i).Type; t {
+		case lexer.GREATER:
+			return p.cursor.Peek(i+1).Type == lexer.DOT
+		case lexer.COMMA, lexer.COLON:
+		default:
+			if !p.isIdentifierToken(t) {
+				return false
+			}
+		}
+	}
 }
 
 // Syntax: function Name(params): Type; begin ... end;
@@ -332,7 +358,7 @@ func (p *Parser) parseFunctionDeclaration() *ast.FunctionDecl {
 	cursor = cursor.Advance() // move to name
 	p.cursor = cursor
 
-	fn.Name, fn.ClassName = p.parseFunctionQualifiedName()
+	fn.Name, fn.ClassName, fn.ClassTypeParams = p.parseFunctionQualifiedName()
 	cursor = p.cursor // reload cursor after parsing qualified name
 
 	// Parse parameter list (if present)
@@ -395,6 +421,9 @@ func (p *Parser) parseFunctionDeclaration() *ast.FunctionDecl {
 	// Check if this is a forward declaration (no body)
 	nextTok := cursor.Peek(1)
 	if fn.IsForward || (nextTok.Type != lexer.BEGIN && nextTok.Type != lexer.VAR && nextTok.Type != lexer.CONST && nextTok.Type != lexer.REQUIRE) {
+		if nextTok.Type == lexer.EOF && !fn.IsForward && !fn.IsExternal {
+			p.unfinished = true
+		}
 		decl, _ := builder.Finish(fn).(*ast.FunctionDecl)
 		return decl
 	}
@@ -42,6 +42,36 @@ func TestParseGenericRecordDeclaration(t *testing.T) {
 	}
 }
 
+func TestParseGenericMethodImplementation(t *testing.T) {
+	prog := parseGenericProgram(t, `function TBox<T>.Get: T; begin Result := Value; end;
+procedure TPair<K, V>.Put(key: K; value: V); begin end;`)
+	tests := []struct {
+		className string
+		name      string
+		params    []string
+	}{
+		{className: "TBox", name: "Get", params: []string{"T"}},
+		{className: "TPair", name: "Put", params: []string{"K", "V"}},
+	}
+	for i, tt := range tests {
+		fn, ok := prog.Statements[i].(*ast.FunctionDecl)
+		if !ok {
+			t.Fatalf("statement %d: expected *ast.FunctionDecl, got %T", i, prog.Statements[i])
+		}
+		if fn.ClassName == nil || fn.ClassName.Value != tt.className || fn.Name.Value != tt.name {
+			t.Errorf("statement %d: name = %v.%s, want %s.%s", i, fn.ClassName, fn.Name.Value, tt.className, tt.name)
+		}
+		if len(fn.ClassTypeParams) != len(tt.params) {
+			t.Fatalf("statement %d: ClassTypeParams = %v, want %v", i, fn.ClassTypeParams, tt.params)
+		}
+		for j, param := range tt.params {
+			if fn.ClassTypeParams[j] != param {
+				t.Errorf("statement %d: ClassTypeParams = %v, want %v", i, fn.ClassTypeParams, tt.params)
+			}
+		}
+	}
+}
+
 func TestParseGenericTypeAnnotationArgs(t *testing.T) {
 	prog := parseGenericProgram(t, `var x : TList<Integer>;`)
 	var ta *ast.TypeAnnotation
@@ -218,7 +218,7 @@ func TestParseHelperErrors(t *testing.T) {
 		{
 			name:     "missing target type",
 			input:    `type THelper = helper for end;`,
-			expected: "expected type name",
+			expected: "expected type expression",
 		},
 		{
 			name:     "missing end keyword",
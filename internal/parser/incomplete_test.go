@@ -0,0 +1,48 @@
+package parser
+
+import (
+	"testing"
+
+	"github.com/cwbudde/go-dws/internal/lexer"
+)
+
+// TestIncomplete tests telling input that the end of the source cut short
+// from input with a real syntax error.
+func TestIncomplete(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  bool
+	}{
+		{"complete statement", "PrintLn(1 + 2);", false},
+		{"complete expression", "1 + 2", false},
+		{"open begin block", "begin\n  x := 1;", true},
+		{"open routine body", "procedure P;\nbegin", true},
+		{"routine header only", "function F(n: Integer): Integer;", true},
+		{"forward declaration", "function F: Integer; forward;", false},
+		{"open class declaration", "type T = class\n  A: Integer;", true},
+		{"open case statement", "case x of\n  1: y;", true},
+		{"open repeat loop", "repeat\n  x := x + 1;", true},
+		{"trailing operator", "x := 1 +", true},
+		{"open argument list", "PrintLn(1,", true},
+		{"open array literal", "x := [1, 2", true},
+		{"then without statement", "if x > 0 then", true},
+		{"unterminated string", "PrintLn('abc", true},
+		{"unterminated string in declaration", "var s := 'abc", true},
+		{"unterminated comment", "x := 1; { comment", true},
+		{"operator before semicolon", "x := 1 +;", false},
+		{"stray end", "end;", false},
+		{"extra parenthesis", "PrintLn(1));", false},
+		{"error before open block", "x := 1 +;\nbegin", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := New(lexer.New(tt.input))
+			p.ParseProgram()
+			if got := p.Incomplete(); got != tt.want {
+				t.Errorf("Incomplete() = %v, want %v (errors: %v)", got, tt.want, p.Errors())
+			}
+		})
+	}
+}
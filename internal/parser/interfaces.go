@@ -122,7 +122,7 @@ func (p *Parser) parseSingleTypeDeclaration(typeToken lexer.Token) ast.Statement
 	// Expect '=' after type name
 	if p.cursor.Peek(1).Type != lexer.EQ {
 		nextToken := p.cursor.Peek(1)
-		p.errors = append(p.errors, NewParserError(
+		p.appendError(NewParserError(
 			nextToken.Pos,
 			nextToken.Length(),
 			"expected '=' after type name",
@@ -142,25 +142,8 @@ func (p *Parser) parseSingleTypeDeclaration(typeToken lexer.Token) ast.Statement
 		cursor = cursor.Advance() // move past '='
 		p.cursor = cursor
 
-		// Parse the low bound expression
-		lowBound := p.parseExpression(LOWEST)
-		if lowBound == nil {
-			p.addError("expected expression for subrange low bound", ErrUnexpectedToken)
-			return nil
-		}
-
-		// Check for '..' operator
-		if p.cursor.Peek(1).Type != lexer.DOTDOT {
-			p.addError("expected '..' in subrange type", ErrUnexpectedToken)
-			return nil
-		}
-		p.cursor = p.cursor.Advance() // move to DOTDOT
-		p.cursor = p.cursor.Advance() // move past DOTDOT
-
-		// Parse the high bound expression
-		highBound := p.parseExpression(LOWEST)
-		if highBound == nil {
-			p.addError("expected expression for subrange high bound", ErrUnexpectedToken)
+		lowBound, highBound := p.parseSubrangeBounds()
+		if lowBound == nil || highBound == nil {
 			return nil
 		}
 
@@ -297,7 +280,7 @@ func (p *Parser) parseTypeNameIdentifier() *ast.Identifier {
 		// After 'type' keyword, expect identifier next
 		if !p.isIdentifierToken(cursor.Peek(1).Type) {
 			nextToken := cursor.Peek(1)
-			p.errors = append(p.errors, NewParserError(
+			p.appendError(NewParserError(
 				nextToken.Pos,
 				nextToken.Length(),
 				"expected identifier after 'type'",
@@ -862,3 +845,33 @@ func (p *Parser) parseInterfaceMethodDecl() *ast.InterfaceMethodDecl {
 
 	return methodDecl
 }
+
+// parseSubrangeBounds parses the 'low..high' bounds of a subrange type.
+// Returns nil bounds (with an error recorded) when either bound is missing.
+//
+// PRE: cursor is first token of the low bound
+// POST: cursor is last token of the high bound
+func (p *Parser) parseSubrangeBounds() (ast.Expression, ast.Expression) {
+	// Parse the low bound expression
+	lowBound := p.parseExpression(LOWEST)
+	if lowBound == nil {
+		p.addError("expected expression for subrange low bound", ErrUnexpectedToken)
+		return nil, nil
+	}
+
+	// Check for '..' operator
+	if p.cursor.Peek(1).Type != lexer.DOTDOT {
+		p.addError("expected '..' in subrange type", ErrUnexpectedToken)
+		return nil, nil
+	}
+	p.cursor = p.cursor.Advance() // move to DOTDOT
+	p.cursor = p.cursor.Advance() // move past DOTDOT
+
+	// Parse the high bound expression
+	highBound := p.parseExpression(LOWEST)
+	if highBound == nil {
+		p.addError("expected expression for subrange high bound", ErrUnexpectedToken)
+		return nil, nil
+	}
+	return lowBound, highBound
+}
@@ -14,63 +14,29 @@ import (
 
 	"github.com/cwbudde/go-dws/internal/lexer"
 	"github.com/cwbudde/go-dws/pkg/ast"
+	"github.com/cwbudde/go-dws/pkg/token"
 )
 
-// Precedence levels for operators (lowest to highest).
+// Precedence levels for operators (lowest to highest). They mirror the
+// exported table in pkg/token so tooling and the parser cannot drift apart.
 const (
-	_ int = iota
-	LOWEST
-	ASSIGN      // :=
-	COALESCE    // ?? (higher than ASSIGN so it works in assignment RHS)
-	IMPLIES     // implies (lowest-precedence boolean connective)
-	OR          // or
-	AND         // and
-	EQUALS      // = <>
-	LESSGREATER // < > <= >=
-	SUM         // + -
-	SHIFT       // shl shr
-	PRODUCT     // * / div mod
-	PREFIX      // -x, not x, +x
-	CALL        // function(args)
-	INDEX       // array[index]
-	MEMBER      // obj.field
+	LOWEST      = token.PrecLowest
+	ASSIGN      = token.PrecAssign      // :=
+	COALESCE    = token.PrecCoalesce    // ??
+	IMPLIES     = token.PrecImplies     // implies
+	OR          = token.PrecOr          // or
+	AND         = token.PrecAnd         // and
+	EQUALS      = token.PrecEquals      // = <>
+	LESSGREATER = token.PrecLessGreater // < > <= >=
+	SUM         = token.PrecSum         // + -
+	SHIFT       = token.PrecShift       // shl shr
+	PRODUCT     = token.PrecProduct     // * / div mod
+	PREFIX      = token.PrecPrefix      // -x, not x, +x
+	CALL        = token.PrecCall        // function(args)
+	INDEX       = token.PrecIndex       // array[index]
+	MEMBER      = token.PrecMember      // obj.field
 )
 
-// precedences maps token types to their precedence levels.
-var precedences = map[lexer.TokenType]int{
-	lexer.QUESTION_QUESTION: COALESCE,
-	lexer.ASSIGN:            ASSIGN,
-	lexer.IMPLIES:           IMPLIES,
-	lexer.OR:                OR,
-	lexer.XOR:               OR,
-	lexer.AND:               AND,
-	lexer.EQ:                EQUALS,
-	lexer.NOT_EQ:            EQUALS,
-	lexer.IN:                EQUALS, // Set membership test
-	lexer.IS:                EQUALS, // Type checking: obj is TClass
-	lexer.AS:                EQUALS, // Type casting: obj as IInterface
-	lexer.IMPLEMENTS:        EQUALS, // Interface check: obj implements IInterface
-	lexer.LESS:              LESSGREATER,
-	lexer.GREATER:           LESSGREATER,
-	lexer.LESS_EQ:           LESSGREATER,
-	lexer.GREATER_EQ:        LESSGREATER,
-	lexer.PLUS:              SUM,
-	lexer.MINUS:             SUM,
-	lexer.SHL:               SHIFT,
-	lexer.SHR:               SHIFT,
-	lexer.SAR:               SHIFT,
-	lexer.ASTERISK:          PRODUCT,
-	lexer.SLASH:             PRODUCT,
-	lexer.DIV:               PRODUCT,
-	lexer.MOD:               PRODUCT,
-	lexer.LPAREN:            CALL,
-	lexer.LBRACK:            INDEX,
-	lexer.DOT:               MEMBER,
-	// Note: Compound assignment operators (+=, -=, *=, /=) are NOT in this table
-	// because they are statement-level operators, not expression operators.
-	// They are handled in parseAssignmentOrExpression() in statements.go
-}
-
 // prefixParseFn parses prefix expressions (literals, unary ops, grouping).
 type prefixParseFn func(lexer.Token) ast.Expression
 
@@ -94,7 +60,11 @@ type Parser struct {
 	cursor               *TokenCursor
 	errors               []*ParserError
 	blockStack           []BlockContext
+	maxErrors            int
 	parsingPostCondition bool
+	// unfinished is set when a construct that is valid on its own, such as a
+	// routine header without a body, is cut off by the end of the input.
+	unfinished bool
 }
 
 // ParserState is a heavyweight snapshot for speculative parsing with full backtracking.
@@ -120,9 +90,74 @@ func NewCursorParser(l *lexer.Lexer) *Parser {
 	return NewParserBuilder(l).Build()
 }
 
-// Errors returns the list of parsing errors.
+// Errors returns the list of parsing errors, one per source position: an
+// error reported where an earlier one already points is a follow-on of the
+// same mistake and is left out, unless it was added as a companion of that
+// error. When there are more than the configured
+// MaxErrors, only that many are returned, followed by one ErrTooManyErrors
+// entry positioned at the first error left out.
 func (p *Parser) Errors() []*ParserError {
-	return p.errors
+	errs := distinctErrorPositions(p.errors)
+	if p.maxErrors <= 0 || len(errs) <= p.maxErrors {
+		return errs
+	}
+	capped := make([]*ParserError, p.maxErrors, p.maxErrors+1)
+	copy(capped, errs)
+	first := errs[p.maxErrors]
+	msg := fmt.Sprintf("too many errors, %d more not reported", len(errs)-p.maxErrors)
+	return append(capped, NewParserError(first.Pos, first.Length, msg, ErrTooManyErrors))
+}
+
+// distinctErrorPositions returns errs without the errors positioned where an
+// earlier error is, other than companions. errs is returned unchanged if no
+// error is left out.
+func distinctErrorPositions(errs []*ParserError) []*ParserError {
+	type position struct{ line, column int }
+	seen := make(map[position]bool, len(errs))
+	var distinct []*ParserError
+	for i, err := range errs {
+		pos := position{err.Pos.Line, err.Pos.Column}
+		if !seen[pos] || err.companion {
+			seen[pos] = true
+			if distinct != nil {
+				distinct = append(distinct, err)
+			}
+			continue
+		}
+		if distinct == nil {
+			distinct = append(make([]*ParserError, 0, len(errs)-1), errs[:i]...)
+		}
+	}
+	if distinct == nil {
+		return errs
+	}
+	return distinct
+}
+
+// Incomplete reports whether the input ended inside an unfinished construct,
+// so that appending more input could make it parse: the lexer stopped inside
+// a string literal or comment, or every parser error was reported at the end
+// of the input, like a missing 'end' or an operand missing after an operator.
+// Input ending right after 'then' or after a routine header also counts,
+// although it parses, as the body is expected on the following lines.
+func (p *Parser) Incomplete() bool {
+	if p.unfinished && len(p.errors) == 0 {
+		return true
+	}
+	for _, err := range p.l.Errors() {
+		if err.Incomplete {
+			return true
+		}
+	}
+	if len(p.errors) == 0 {
+		return false
+	}
+	for _, err := range p.errors {
+		if !err.Incomplete {
+			return false
+		}
+	}
+	return true
 }
 
 // LexerErrors returns all lexer errors accumulated during tokenization.
@@ -131,11 +166,12 @@ func (p *Parser) LexerErrors() []lexer.LexerError {
 	return p.l.Errors()
 }
 
-// LexerIncludeErrors returns the lexer errors produced while resolving {$INCLUDE}
-// directives. These are fatal (a broken include means missing code) and are
-// surfaced through the normal error path, unlike other advisory lexer errors.
-func (p *Parser) LexerIncludeErrors() []lexer.LexerError {
-	return p.l.IncludeErrors()
+// LexerDirectiveErrors returns the lexer errors produced by {$INCLUDE} and
+// conditional-compilation directives. These are fatal (a broken include or an
+// unbalanced {$IFDEF} means missing or extra code) and are surfaced through the
+// normal error path, unlike other advisory lexer errors.
+func (p *Parser) LexerDirectiveErrors() []lexer.LexerError {
+	return p.l.DirectiveErrors()
 }
 
 // nextToken advances the cursor.
@@ -230,7 +266,7 @@ func (p *Parser) peekError(t lexer.TokenType) {
 		msg,
 		ErrUnexpectedToken,
 	)
-	p.errors = append(p.errors, err)
+	p.appendError(err)
 }
 
 // addError adds a generic error message with the specified error code.
@@ -243,15 +279,37 @@ func (p *Parser) addError(msg string, code string) {
 		msg,
 		code,
 	)
+	p.appendError(err)
+}
+
+// appendError records err, marking it incomplete when the parser has
+// reached the end of the input.
+func (p *Parser) appendError(err *ParserError) {
+	err.Incomplete = p.cursor.Current().Type == lexer.EOF
 	p.errors = append(p.errors, err)
 }
 
+// markIncompleteErrors marks the errors positioned at the end of the input
+// as incomplete. Errors about an unexpected next token are reported before
+// the parser reaches the end, so appendError cannot tell.
+func (p *Parser) markIncompleteErrors() {
+	eof := p.cursor.Current()
+	if eof.Type != lexer.EOF {
+		return
+	}
+	for _, err := range p.errors {
+		if err.Pos.Offset >= eof.Pos.Offset {
+			err.Incomplete = true
+		}
+	}
+}
+
 // addStructuredError adds a structured error with auto-injected block context.
 func (p *Parser) addStructuredError(structErr *StructuredParserError) {
 	if structErr.BlockContext == nil {
 		structErr.BlockContext = p.currentBlockContext()
 	}
-	p.errors = append(p.errors, structErr.ToParserError())
+	p.appendError(structErr.ToParserError())
 }
 
 // noPrefixParseFnError adds a localized syntax error for tokens that cannot start an expression.
@@ -270,7 +328,7 @@ func (p *Parser) noPrefixParseFnError(tok lexer.Token) {
 	}
 
 	err := NewParserError(tok.Pos, tok.Length(), msg, code)
-	p.errors = append(p.errors, err)
+	p.appendError(err)
 }
 
 // registerPrefix registers a prefix parse function for a token type.
@@ -285,10 +343,7 @@ func (p *Parser) registerInfix(tokenType lexer.TokenType, fn infixParseFn) {
 
 // getPrecedence returns the precedence of a token type (LOWEST if not found).
 func getPrecedence(tokenType lexer.TokenType) int {
-	if prec, ok := precedences[tokenType]; ok {
-		return prec
-	}
-	return LOWEST
+	return token.Precedence(tokenType)
 }
 
 // saveState captures full parser state for speculative parsing with backtracking.
@@ -361,8 +416,30 @@ var (
 		lexer.FUNCTION, lexer.PROCEDURE,
 		lexer.CLASS, lexer.RECORD, lexer.INTERFACE,
 	}
+
+	// statementBoundaries are where statement-level recovery resumes after a
+	// broken statement (besides ';'): block closers, 'begin' and keywords that
+	// start a declaration or unit section. ELSE is left out on purpose: a
+	// broken then-branch should not leave a dangling 'else' behind.
+	statementBoundaries = []lexer.TokenType{
+		lexer.END, lexer.UNTIL, lexer.EXCEPT, lexer.FINALLY, lexer.ENSURE,
+		lexer.BEGIN,
+		lexer.VAR, lexer.CONST, lexer.RESOURCESTRING, lexer.TYPE,
+		lexer.FUNCTION, lexer.PROCEDURE, lexer.METHOD, lexer.CONSTRUCTOR, lexer.DESTRUCTOR,
+		lexer.IMPLEMENTATION, lexer.INITIALIZATION, lexer.FINALIZATION,
+	}
 )
 
+// isStatementBoundary reports whether t is one of statementBoundaries.
+func isStatementBoundary(t lexer.TokenType) bool {
+	for _, boundary := range statementBoundaries {
+		if t == boundary {
+			return true
+		}
+	}
+	return false
+}
+
 // synchronize advances to a safe point after an error.
 // Stops at syncTokens, statement starters, block closers, or EOF.
 func (p *Parser) synchronize(syncTokens []lexer.TokenType) bool {
@@ -386,6 +463,32 @@ func (p *Parser) synchronize(syncTokens []lexer.TokenType) bool {
 	return false
 }
 
+// advanceToClauseKeyword moves the cursor onto the keyword that follows the
+// expression just parsed, such as 'then' or 'do', if it is one of types, and
+// reports whether it did. A missing operand that was already reported leaves
+// the cursor on the keyword itself; the keyword still closes the clause, so
+// the error is not repeated as a missing keyword.
+func (p *Parser) advanceToClauseKeyword(types ...lexer.TokenType) bool {
+	cur := p.cursor.Current()
+	if tokenTypeIsOneOf(cur.Type, types...) && p.hasErrorAt(cur.Pos) {
+		return true
+	}
+	if tokenTypeIsOneOf(p.cursor.Peek(1).Type, types...) {
+		p.cursor = p.cursor.Advance()
+		return true
+	}
+	return false
+}
+
+// hasErrorAt reports whether the most recent error is at pos.
+func (p *Parser) hasErrorAt(pos lexer.Position) bool {
+	if len(p.errors) == 0 {
+		return false
+	}
+	last := p.errors[len(p.errors)-1].Pos
+	return last.Line == pos.Line && last.Column == pos.Column
+}
+
 // addErrorWithContext adds an error with block context info appended.
 func (p *Parser) addErrorWithContext(msg string, code string) {
 	if ctx := p.currentBlockContext(); ctx != nil {
@@ -396,10 +499,7 @@ func (p *Parser) addErrorWithContext(msg string, code string) {
 
 // endPosFromToken calculates the end position of a token for AST EndPos fields.
 func (p *Parser) endPosFromToken(tok lexer.Token) lexer.Position {
-	pos := tok.Pos
-	pos.Column += tok.Length()
-	pos.Offset += tok.Length()
-	return pos
+	return tok.End()
 }
 
 // ListParseOptions configures parseSeparatedList behavior.
@@ -482,6 +582,7 @@ func (p *Parser) parseSeparatedListBeforeStart(opts ListParseOptions, parseItem
 
 // ParseProgram parses the entire program and returns the AST root node.
 func (p *Parser) ParseProgram() *ast.Program {
+	defer p.markIncompleteErrors()
 	builder := p.StartNode()
 	program := &ast.Program{}
 	program.Statements = []ast.Statement{}
@@ -514,7 +615,7 @@ func (p *Parser) ParseProgram() *ast.Program {
 			break
 		}
 
-		stmt := p.parseStatement()
+		stmt := p.parseStatementWithRecovery()
 		if stmt != nil {
 			// Unwrap var declaration blocks to avoid extra scope nesting
 			if blockStmt, ok := stmt.(*ast.BlockStatement); ok && p.isVarDeclBlock(blockStmt) {
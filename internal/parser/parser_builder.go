@@ -19,14 +19,23 @@ type ParserConfig struct {
 	// MaxRecursionDepth sets the maximum recursion depth for expression parsing
 	// to prevent stack overflow on deeply nested expressions (future use)
 	MaxRecursionDepth int
+
+	// MaxErrors caps the number of errors reported by Errors. Errors past the
+	// cap are summarized by a single ErrTooManyErrors entry. Zero or a
+	// negative value reports every error.
+	MaxErrors int
 }
 
+// DefaultMaxErrors is the default value of ParserConfig.MaxErrors.
+const DefaultMaxErrors = 100
+
 // DefaultConfig returns a ParserConfig with default settings.
 func DefaultConfig() ParserConfig {
 	return ParserConfig{
 		AllowReservedKeywordsAsIdentifiers: true,
 		StrictMode:                         false,
 		MaxRecursionDepth:                  1000,
+		MaxErrors:                          DefaultMaxErrors,
 	}
 }
 
@@ -80,6 +89,13 @@ func (b *ParserBuilder) WithMaxRecursionDepth(depth int) *ParserBuilder {
 	return b
 }
 
+// WithMaxErrors caps the number of errors reported by the parser (see
+// ParserConfig.MaxErrors).
+func (b *ParserBuilder) WithMaxErrors(limit int) *ParserBuilder {
+	b.config.MaxErrors = limit
+	return b
+}
+
 // Build constructs and returns a configured Parser instance.
 // This is the main entry point for creating parsers via the builder pattern.
 func (b *ParserBuilder) Build() *Parser {
@@ -91,6 +107,7 @@ func (b *ParserBuilder) Build() *Parser {
 		infixParseFns:  make(map[lexer.TokenType]infixParseFn),
 		blockStack:     []BlockContext{},
 		ctx:            NewParseContext(),
+		maxErrors:      b.config.MaxErrors,
 	}
 
 	p.cursor = NewTokenCursor(b.lexer)
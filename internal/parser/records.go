@@ -156,8 +156,9 @@ func (p *Parser) parseRecordBody(recordDecl *ast.RecordDecl, currentVisibility a
 			continue
 		}
 
-		// Check for 'class function' / 'class procedure' / 'class var' / 'class const'
+		// Check for 'class function' / 'class procedure' / 'class var' / 'class const' / 'class operator'
 		if cursor.Current().Type == lexer.CLASS {
+			classToken := cursor.Current()
 			cursor = cursor.Advance() // move past 'class'
 			p.cursor = cursor
 
@@ -196,8 +197,17 @@ func (p *Parser) parseRecordBody(recordDecl *ast.RecordDecl, currentVisibility a
 				cursor = p.cursor.Advance()
 				p.cursor = cursor
 				continue
+			} else if cursor.Current().Type == lexer.OPERATOR {
+				// Class operator: class operator < TRec uses LessThan;
+				operator := p.parseClassOperatorDeclaration(classToken, currentVisibility)
+				if operator != nil {
+					recordDecl.Operators = append(recordDecl.Operators, operator)
+				}
+				cursor = p.cursor.Advance()
+				p.cursor = cursor
+				continue
 			} else {
-				p.addError("expected 'var', 'const', 'function' or 'procedure' after 'class' keyword in record", ErrUnexpectedToken)
+				p.addError("expected 'var', 'const', 'function', 'procedure' or 'operator' after 'class' keyword in record", ErrUnexpectedToken)
 				cursor = cursor.Advance()
 				p.cursor = cursor
 				continue
@@ -213,10 +223,21 @@ func (p *Parser) parseRecordBody(recordDecl *ast.RecordDecl, currentVisibility a
 				seenMethod = true
 			}
 			if len(p.errors) > errorCount && method != nil && method.Body == nil {
+				// DWScript reports the body of a method declared without 'begin'
+				// as misplaced fields, at the same position as the first error.
 				firstErr := p.errors[errorCount]
-				p.addParserErrorAt(firstErr.Pos, firstErr.Length, "Record fields must be declared before record methods", ErrUnexpectedToken)
+				fieldsErr := NewParserError(firstErr.Pos, firstErr.Length, "Record fields must be declared before record methods", ErrUnexpectedToken)
+				fieldsErr.companion = true
+				p.appendError(fieldsErr)
 				p.synchronize([]lexer.TokenType{lexer.END, lexer.EOF})
-				return currentVisibility
+				if !p.closesRecordMethodBody() {
+					return currentVisibility
+				}
+				// The 'end' closed the method body: resume with the members
+				// after it instead of leaving the record's own 'end' behind.
+				cursor = p.cursor.Advance().Advance()
+				p.cursor = cursor
+				continue
 			}
 			cursor = p.cursor.Advance()
 			p.cursor = cursor
@@ -254,6 +275,21 @@ func (p *Parser) parseRecordBody(recordDecl *ast.RecordDecl, currentVisibility a
 	return currentVisibility
 }
 
+// closesRecordMethodBody reports whether the 'end' at the cursor closes the
+// body of a record method rather than the record: it is followed by ';' and
+// then by a token that can only continue a record declaration.
+// PRE: cursor is on END
+func (p *Parser) closesRecordMethodBody() bool {
+	if p.cursor.Current().Type != lexer.END || p.cursor.Peek(1).Type != lexer.SEMICOLON {
+		return false
+	}
+	switch p.cursor.Peek(2).Type {
+	case lexer.END, lexer.PRIVATE, lexer.PUBLIC, lexer.PUBLISHED, lexer.PROPERTY:
+		return true
+	}
+	return false
+}
+
 // parseRecordFieldDeclarations parses one or more field declarations (dispatcher).
 
 // Pattern: Name1, Name2, Name3: Type;
@@ -11,6 +11,7 @@ import (
 //
 // Syntax:
 //   - type TDays = set of TWeekday;
+//   - type TDigits = set of 0..9;
 //
 // PRE: cursor is SET
 // POST: cursor is SEMICOLON
@@ -63,6 +64,42 @@ func (p *Parser) parseSetDeclaration(nameIdent *ast.Identifier, typeToken lexer.
 		}
 	}
 
+	// Inline subrange: type TDigits = set of 0..9;
+	// Desugared into an implicit subrange declaration plus the set declaration.
+	if next := p.cursor.Peek(1).Type; next == lexer.INT || next == lexer.MINUS {
+		subrangeName := &ast.Identifier{
+			Value: "$" + nameIdent.Value + "$InlineSubrange",
+			TypedExpressionBase: ast.TypedExpressionBase{
+				BaseNode: ast.BaseNode{Token: nameIdent.Token},
+			},
+		}
+		p.cursor = p.cursor.Advance() // move to low bound
+		lowBound, highBound := p.parseSubrangeBounds()
+		if lowBound == nil || highBound == nil {
+			return nil
+		}
+		if p.cursor.Peek(1).Type != lexer.SEMICOLON {
+			p.addError("expected ';' after set declaration", ErrMissingSemicolon)
+			return nil
+		}
+		p.cursor = p.cursor.Advance() // move to semicolon
+		subrangeDecl := &ast.TypeDeclaration{
+			BaseNode:   ast.BaseNode{Token: typeToken},
+			Name:       subrangeName,
+			IsSubrange: true,
+			LowBound:   lowBound,
+			HighBound:  highBound,
+		}
+		setDecl.ElementType = &ast.TypeAnnotation{
+			Token: subrangeName.Token,
+			Name:  subrangeName.Value,
+		}
+		return &ast.BlockStatement{
+			BaseNode:   ast.BaseNode{Token: typeToken},
+			Statements: []ast.Statement{subrangeDecl, setDecl},
+		}
+	}
+
 	// Expect type identifier
 	nextToken = p.cursor.Peek(1)
 	if nextToken.Type != lexer.IDENT {
@@ -586,3 +586,36 @@ func TestParseInlineEnumSetInTypeSection(t *testing.T) {
 		t.Errorf("third declaration should not be a nested block, got %T", nested)
 	}
 }
+
+// An inline subrange element type desugars into an implicit subrange
+// declaration followed by the set declaration.
+func TestParseInlineSubrangeSetDeclaration(t *testing.T) {
+	input := `type TDigits = set of 0..9;`
+
+	l := lexer.New(input)
+	p := New(l)
+	program := p.ParseProgram()
+	checkParserErrors(t, p)
+
+	if len(program.Statements) != 1 {
+		t.Fatalf("program.Statements should contain 1 statement, got %d", len(program.Statements))
+	}
+	block, ok := program.Statements[0].(*ast.BlockStatement)
+	if !ok || len(block.Statements) != 2 {
+		t.Fatalf("statement should be a block with 2 declarations, got %T", program.Statements[0])
+	}
+	subrange, ok := block.Statements[0].(*ast.TypeDeclaration)
+	if !ok || !subrange.IsSubrange {
+		t.Fatalf("first declaration should be a subrange *ast.TypeDeclaration, got %T", block.Statements[0])
+	}
+	if subrange.LowBound.String() != "0" || subrange.HighBound.String() != "9" {
+		t.Errorf("subrange bounds = %s..%s, want 0..9", subrange.LowBound.String(), subrange.HighBound.String())
+	}
+	setDecl, ok := block.Statements[1].(*ast.SetDecl)
+	if !ok {
+		t.Fatalf("second declaration should be *ast.SetDecl, got %T", block.Statements[1])
+	}
+	if setDecl.ElementType.String() != subrange.Name.Value {
+		t.Errorf("set element type = %s, want %s", setDecl.ElementType.String(), subrange.Name.Value)
+	}
+}
@@ -65,8 +65,97 @@ func (p *Parser) parseDestructorStatement() ast.Statement {
 	return method
 }
 
-//nolint:gocyclo // Statement dispatcher with many statement types
+// parseStatement parses a single statement. A statement that could not be
+// parsed is returned as an untyped nil, never as a nil pointer of a concrete
+// node type, so callers can test it with == nil and AST walkers never see it.
 func (p *Parser) parseStatement() ast.Statement {
+	stmt := p.dispatchStatement()
+	if isNilStatement(stmt) {
+		return nil
+	}
+	return stmt
+}
+
+// parseStatementWithRecovery parses one statement of a statement list and
+// resynchronizes if that reported errors, so a single mistake does not cascade
+// into an error for every token that follows. A statement that was recovered
+// is kept, and tokens after it that cannot start a statement are skipped. A
+// statement that could not be parsed at all is replaced by an InvalidStatement
+// covering everything up to the next statement boundary.
+// PRE: cursor is the first token of the statement
+// POST: cursor is the last token of the statement, ready for the caller to advance
+func (p *Parser) parseStatementWithRecovery() ast.Statement {
+	start := p.cursor.Mark()
+	startToken := p.cursor.Current()
+	errorCount := len(p.errors)
+
+	stmt := p.parseStatement()
+	if len(p.errors) == errorCount {
+		return stmt
+	}
+	if stmt != nil {
+		for p.isStrayToken(p.cursor.Peek(1).Type) {
+			p.cursor = p.cursor.Advance()
+		}
+		return stmt
+	}
+
+	p.skipToStatementBoundary(start)
+	return &ast.InvalidStatement{
+		BaseNode: ast.BaseNode{Token: startToken, EndPos: p.cursor.Current().End()},
+	}
+}
+
+// skipToStatementBoundary advances past the remains of a broken statement that
+// started at start. It stops on a ';' or on the token before a statement
+// boundary, which is left for the enclosing construct. The start token itself
+// is always consumed so the caller makes progress.
+func (p *Parser) skipToStatementBoundary(start Mark) {
+	for {
+		cur := p.cursor.Current()
+		if cur.Type == lexer.SEMICOLON || cur.Type == lexer.EOF {
+			return
+		}
+		if p.cursor.index > start.index && (isStatementBoundary(cur.Type) || isStatementKeyword(cur.Type)) {
+			// The failed parse already resynchronized onto a boundary or the
+			// next statement; step back so the caller's advance lands on it.
+			p.cursor = p.cursor.ResetTo(Mark{index: p.cursor.index - 1})
+			return
+		}
+		next := p.cursor.Peek(1).Type
+		if next == lexer.EOF || isStatementBoundary(next) {
+			return
+		}
+		p.cursor = p.cursor.Advance()
+	}
+}
+
+// isStatementKeyword reports whether t is a keyword that starts a statement.
+func isStatementKeyword(t lexer.TokenType) bool {
+	if t == lexer.IDENT {
+		return false
+	}
+	for _, starter := range statementStarters {
+		if t == starter {
+			return true
+		}
+	}
+	return false
+}
+
+// isStrayToken reports whether t can neither start a statement nor follow
+// one, i.e. it is left over from a statement whose parse failed part-way.
+func (p *Parser) isStrayToken(t lexer.TokenType) bool {
+	switch t {
+	case lexer.SEMICOLON, lexer.DOT, lexer.EOF, lexer.ELSE,
+		lexer.WITH, lexer.USES, lexer.OPERATOR, lexer.CLASS:
+		return false
+	}
+	return t != lexer.IDENT && !isStatementBoundary(t) && !isStatementKeyword(t) && p.prefixParseFns[t] == nil
+}
+
+//nolint:gocyclo // Statement dispatcher with many statement types
+func (p *Parser) dispatchStatement() ast.Statement {
 	// As we implement each statement cursor handler, they'll be added here
 
 	currentToken := p.cursor.Current()
@@ -355,7 +444,7 @@ func (p *Parser) parseBlockStatement() *ast.BlockStatement {
 			continue
 		}
 
-		stmt := p.parseStatement()
+		stmt := p.parseStatementWithRecovery()
 		if stmt != nil {
 			block.Statements = append(block.Statements, stmt)
 		}
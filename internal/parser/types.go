@@ -209,7 +209,7 @@ func (p *Parser) addPeekTokenError(message string, code string) {
 		message,
 		code,
 	)
-	p.errors = append(p.errors, err)
+	p.appendError(err)
 }
 
 // detectFunctionPointerFullSyntax determines if we have full syntax (with parameter names)
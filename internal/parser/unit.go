@@ -230,7 +230,7 @@ func (p *Parser) parseInterfaceSection() *ast.BlockStatement {
 			continue
 		}
 
-		stmt := p.parseStatement()
+		stmt := p.parseStatementWithRecovery()
 		if stmt != nil {
 			// Interface-section function declarations have no body: they are
 			// forward declarations implemented in the implementation section.
@@ -277,7 +277,7 @@ func (p *Parser) parseImplementationSection() *ast.BlockStatement {
 			continue
 		}
 
-		stmt := p.parseStatement()
+		stmt := p.parseStatementWithRecovery()
 		if stmt != nil {
 			block.Statements = append(block.Statements, stmt)
 		}
@@ -310,7 +310,7 @@ func (p *Parser) parseInitializationSection() *ast.BlockStatement {
 			continue
 		}
 
-		stmt := p.parseStatement()
+		stmt := p.parseStatementWithRecovery()
 		if stmt != nil {
 			block.Statements = append(block.Statements, stmt)
 		}
@@ -340,7 +340,7 @@ func (p *Parser) parseFinalizationSection() *ast.BlockStatement {
 			continue
 		}
 
-		stmt := p.parseStatement()
+		stmt := p.parseStatementWithRecovery()
 		if stmt != nil {
 			block.Statements = append(block.Statements, stmt)
 		}
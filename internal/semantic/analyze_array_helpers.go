@@ -310,7 +310,13 @@ func (a *Analyzer) validateArrayIntegerArgAt(arg ast.Expression, pos token.Posit
 	return argType
 }
 
-func isArrayNaturallySortable(elementType types.Type) bool {
+// isArrayNaturallySortable reports whether Sort without a comparator can order
+// the elements: ordered types, Boolean, and types overloading '<'.
+func (a *Analyzer) isArrayNaturallySortable(elementType types.Type) bool {
+	if sig, ok := a.resolveBinaryOperator("<", elementType, elementType); ok && sig.ResultType != nil &&
+		sig.ResultType.TypeKind() == "BOOLEAN" {
+		return true
+	}
 	elementType = types.GetUnderlyingType(elementType)
 	if elementType != nil && elementType.TypeKind() == "BOOLEAN" {
 		// Boolean is an ordinal type (False < True) and has a natural sort order,
@@ -359,7 +365,7 @@ func (a *Analyzer) analyzeArrayMemberAccess(expr *ast.MemberAccessExpression, ar
 		}
 		return types.VOID
 	case "sort":
-		if !isArrayNaturallySortable(arrayType.ElementType) {
+		if !a.isArrayNaturallySortable(arrayType.ElementType) {
 			a.addArrayHelperError(expr.Member.Token.Pos, "Array does not have a natural sort order")
 		}
 		return arrayType
@@ -645,7 +651,7 @@ func (a *Analyzer) analyzeArrayMethodCall(expr *ast.MethodCallExpression, arrayT
 			return arrayType
 		}
 		if len(expr.Arguments) == 0 {
-			if !isArrayNaturallySortable(arrayType.ElementType) {
+			if !a.isArrayNaturallySortable(arrayType.ElementType) {
 				a.addArrayHelperError(expr.Method.Token.Pos, "Array does not have a natural sort order")
 			}
 			return arrayType
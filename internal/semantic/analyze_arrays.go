@@ -2,6 +2,7 @@ package semantic
 
 import (
 	"github.com/cwbudde/go-dws/internal/errors"
+	"github.com/cwbudde/go-dws/internal/lexer"
 	"github.com/cwbudde/go-dws/internal/types"
 	"github.com/cwbudde/go-dws/pkg/ast"
 	"github.com/cwbudde/go-dws/pkg/ident"
@@ -37,7 +38,9 @@ func (a *Analyzer) analyzeArrayDecl(decl *ast.ArrayDecl) {
 	elementTypeName := getTypeExpressionName(arrayType.ElementType)
 	elementType, err := a.resolveType(elementTypeName)
 	if err != nil {
-		a.addError("unknown type '%s' at %s", elementTypeName, decl.Token.Pos.String())
+		if !a.isCyclicType(arrayName) {
+			a.addError("unknown type '%s' at %s", elementTypeName, decl.Token.Pos.String())
+		}
 		return
 	}
 
@@ -85,6 +88,7 @@ func (a *Analyzer) analyzeIndexExpression(expr *ast.IndexExpression) types.Type
 	// Allow default indexed properties on classes (obj[index] -> obj.DefaultProperty[index])
 	if classType, ok := types.GetUnderlyingType(leftType).(*types.ClassType); ok {
 		if defaultProp := a.getDefaultClassProperty(classType); defaultProp != nil {
+			a.checkIndexedPropertyRead(expr, defaultProp.Name, expr.Token.Pos, defaultProp.ReadKind)
 			expectedIndexTypes := a.getIndexedPropertyParamTypes(defaultProp, classType)
 			if len(expectedIndexTypes) > 0 {
 				indexType := a.analyzeExpressionWithExpectedType(expr.Index, expectedIndexTypes[0])
@@ -101,6 +105,26 @@ func (a *Analyzer) analyzeIndexExpression(expr *ast.IndexExpression) types.Type
 		return nil
 	}
 
+	// Interfaces can expose a default indexed property as well (intf[index]).
+	if ifaceType, ok := types.GetUnderlyingType(leftType).(*types.InterfaceType); ok {
+		defaultProp := ifaceType.GetDefaultProperty()
+		if defaultProp == nil {
+			a.addStructuredError(NewCannotIndexTypeError(expr.Token.Pos, leftType.String()))
+			return nil
+		}
+		a.checkIndexedPropertyRead(expr, defaultProp.Name, expr.Token.Pos, defaultProp.ReadKind)
+		if len(defaultProp.IndexParamTypes) > 0 {
+			expected := defaultProp.IndexParamTypes[0]
+			indexType := a.analyzeExpressionWithExpectedType(expr.Index, expected)
+			if indexType != nil && !a.canAssign(indexType, expected) {
+				a.addStructuredError(NewArrayIndexError(expr.Index.Pos(), expected.String(), indexType.String()))
+			}
+		} else {
+			a.analyzeExpression(expr.Index)
+		}
+		return defaultProp.Type
+	}
+
 	// Associative array indexing: a[key] where key is assignable to KeyType,
 	// yielding the element type. New keys are legal (validated on assignment),
 	// so a read of a missing key returns the element's zero value at runtime.
@@ -161,6 +185,7 @@ func (a *Analyzer) analyzeIndexExpression(expr *ast.IndexExpression) types.Type
 				// Analyze the index expression
 				// TODO: Validate index type matches property index parameter types
 				a.analyzeExpression(expr.Index)
+				a.checkIndexedPropertyRead(expr, defaultProp.Name, expr.Token.Pos, defaultProp.ReadKind)
 				return defaultProp.Type
 			}
 		}
@@ -252,42 +277,71 @@ func (a *Analyzer) constantArrayIndex(expr ast.Expression) (int, bool) {
 // by validating the index type against the property's signature and returning the property type.
 func (a *Analyzer) analyzeIndexedPropertyAccess(memberAccess *ast.MemberAccessExpression, expr *ast.IndexExpression) types.Type {
 	// Determine the object type for the member access
-	objectType := a.analyzeExpression(memberAccess.Object)
+	objectType := a.analyzeMemberReceiver(memberAccess.Object, memberAccess.Member)
 	if objectType == nil {
 		return nil
 	}
 
+	propInfo, classType := indexedPropertyOf(objectType, memberAccess.Member.Value)
+	if propInfo == nil {
+		// Not an indexed property – let general indexing rules apply to the property type
+		return nil
+	}
+	a.checkIndexedPropertyRead(expr, memberAccess.Member.Value, memberAccess.Member.Token.Pos, propInfo.ReadKind)
+
+	// Interface properties carry their index types; class properties take
+	// them from the accessor methods.
+	expectedIndexTypes := propInfo.IndexParamTypes
+	if classType != nil {
+		expectedIndexTypes = a.getIndexedPropertyParamTypes(propInfo, classType)
+	}
+	if len(expectedIndexTypes) > 0 {
+		indexType := a.analyzeExpressionWithExpectedType(expr.Index, expectedIndexTypes[0])
+		if indexType != nil && !a.canAssign(indexType, expectedIndexTypes[0]) {
+			a.addStructuredError(NewArrayIndexError(expr.Index.Pos(), expectedIndexTypes[0].String(), indexType.String()))
+			return propInfo.Type
+		}
+	} else {
+		a.analyzeExpression(expr.Index)
+	}
+	return propInfo.Type
+}
+
+// indexedPropertyOf returns the indexed property named name on a receiver of
+// objectType, which may be a class, a metaclass or an interface. The owning
+// class is returned for class receivers and is nil for interfaces.
+func indexedPropertyOf(objectType types.Type, name string) (*types.PropertyInfo, *types.ClassType) {
 	objectResolved := types.GetUnderlyingType(objectType)
 	if metaclassType, ok := objectResolved.(*types.ClassOfType); ok {
 		objectResolved = metaclassType.ClassType
 	}
 
-	memberName := ident.Normalize(memberAccess.Member.Value)
-
-	// Handle class instance properties
-	if classType, ok := objectResolved.(*types.ClassType); ok {
-		if propInfo, found := classType.GetProperty(memberName); found {
-			if !propInfo.IsIndexed {
-				// Not an indexed property – let general indexing rules apply to the property type
-				return nil
-			}
-
-			expectedIndexTypes := a.getIndexedPropertyParamTypes(propInfo, classType)
-			if len(expectedIndexTypes) > 0 {
-				indexType := a.analyzeExpressionWithExpectedType(expr.Index, expectedIndexTypes[0])
-				if indexType != nil && !a.canAssign(indexType, expectedIndexTypes[0]) {
-					a.addStructuredError(NewArrayIndexError(expr.Index.Pos(), expectedIndexTypes[0].String(), indexType.String()))
-					return propInfo.Type
-				}
-			} else {
-				a.analyzeExpression(expr.Index)
-			}
-			return propInfo.Type
+	switch t := objectResolved.(type) {
+	case *types.ClassType:
+		if propInfo, found := t.GetProperty(ident.Normalize(name)); found && propInfo.IsIndexed {
+			return propInfo, t
+		}
+	case *types.InterfaceType:
+		if propInfo := t.GetProperty(name); propInfo != nil && propInfo.IsIndexed {
+			return propInfo, nil
 		}
 	}
+	return nil, nil
+}
 
-	// Not an indexed property access
-	return nil
+// checkIndexedPropertyRead reports reading a write-only indexed property.
+// The index expression of an assignment target is exempt: the assignment
+// checks write access (and read access for compound operators) itself.
+func (a *Analyzer) checkIndexedPropertyRead(expr *ast.IndexExpression, name string, pos lexer.Position, readKind types.PropAccessKind) {
+	if readKind == types.PropAccessNone && expr != a.indexAssignTarget {
+		a.addStructuredError(NewWriteOnlyPropertyError(pos, name))
+	}
+}
+
+// isIndexAssignTargetMember reports whether expr is the obj.Prop part of the
+// obj.Prop[i] assignment target being analyzed.
+func (a *Analyzer) isIndexAssignTargetMember(expr *ast.MemberAccessExpression) bool {
+	return a.indexAssignTarget != nil && a.indexAssignTarget.Left == expr
 }
 
 // getDefaultClassProperty walks the class hierarchy to find a default property, if any.
@@ -302,13 +356,72 @@ func (a *Analyzer) getDefaultClassProperty(classType *types.ClassType) *types.Pr
 	return nil
 }
 
+// indexedPropertyWrite describes the indexed property written by an assignment
+// such as obj.Prop[i] := v or obj[i] := v (through a default property).
+type indexedPropertyWrite struct {
+	name      string
+	pos       lexer.Position
+	readKind  types.PropAccessKind
+	writeKind types.PropAccessKind
+}
+
+// indexedPropertyWriteTarget returns the indexed property that an assignment to
+// expr writes to, or nil if expr indexes an ordinary array, string or map.
+// baseType is the already analyzed type of expr.Left.
+func (a *Analyzer) indexedPropertyWriteTarget(expr *ast.IndexExpression, baseType types.Type) *indexedPropertyWrite {
+	if memberAccess, ok := expr.Left.(*ast.MemberAccessExpression); ok {
+		objectType := a.analyzeMemberReceiver(memberAccess.Object, memberAccess.Member)
+		if propInfo, _ := indexedPropertyOf(objectType, memberAccess.Member.Value); propInfo != nil {
+			return &indexedPropertyWrite{
+				name:      memberAccess.Member.Value,
+				pos:       memberAccess.Member.Token.Pos,
+				readKind:  propInfo.ReadKind,
+				writeKind: propInfo.WriteKind,
+			}
+		}
+	}
+
+	var defaultProp *types.PropertyInfo
+	switch t := types.GetUnderlyingType(baseType).(type) {
+	case *types.ClassType:
+		defaultProp = a.getDefaultClassProperty(t)
+	case *types.InterfaceType:
+		defaultProp = t.GetDefaultProperty()
+	case *types.RecordType:
+		for _, propInfo := range t.Properties {
+			if propInfo.IsDefault {
+				return &indexedPropertyWrite{
+					name:      propInfo.Name,
+					pos:       expr.Token.Pos,
+					readKind:  propInfo.ReadKind,
+					writeKind: propInfo.WriteKind,
+				}
+			}
+		}
+	}
+	if defaultProp == nil {
+		return nil
+	}
+	return &indexedPropertyWrite{
+		name:      defaultProp.Name,
+		pos:       expr.Token.Pos,
+		readKind:  defaultProp.ReadKind,
+		writeKind: defaultProp.WriteKind,
+	}
+}
+
 // getIndexedPropertyParamTypes tries to determine the index parameter types for an indexed property.
 // Preference order:
-//  1. Getter method parameters (all parameters are index parameters)
-//  2. Setter method parameters (all but the last parameter are index parameters)
+//  1. Declared index parameters (property Items[key: String])
+//  2. Getter method parameters (all parameters are index parameters)
+//  3. Setter method parameters (all but the last parameter are index parameters)
 //
 // If no method information is available, returns nil.
 func (a *Analyzer) getIndexedPropertyParamTypes(propInfo *types.PropertyInfo, classType *types.ClassType) []types.Type {
+	if len(propInfo.IndexParamTypes) > 0 {
+		return propInfo.IndexParamTypes
+	}
+
 	// Use getter signature if it is a method
 	if propInfo.ReadKind == types.PropAccessMethod && propInfo.ReadSpec != "" {
 		if methodType, found := classType.GetMethod(ident.Normalize(propInfo.ReadSpec)); found {
@@ -13,76 +13,48 @@ import (
 // analyzeLow analyzes the Low built-in function.
 // Low takes one argument (array, enum, or type meta-value) and returns a value of the appropriate type.
 func (a *Analyzer) analyzeLow(args []ast.Expression, callExpr *ast.CallExpression) types.Type {
-	if len(args) != 1 {
-		a.addError("function 'Low' expects 1 argument, got %d at %s",
-			len(args), callExpr.Token.Pos.String())
-		return types.INTEGER
-	}
-	// Analyze the argument
-	argType := a.analyzeExpression(args[0])
-	// Verify it's an array, enum, or basic type (type meta-value)
-	if argType != nil {
-		if _, isArray := types.GetUnderlyingType(argType).(*types.ArrayType); isArray {
-			// For arrays, return Integer
-			return types.INTEGER
-		}
-		if enumType, isEnum := types.GetUnderlyingType(argType).(*types.EnumType); isEnum {
-			// For enums, return the same enum type
-			return enumType
-		}
-		if argType == types.STRING {
-			return types.INTEGER
-		}
-		if argType == types.INTEGER || argType == types.BOOLEAN {
-			return argType
-		}
-
-		// Handle type meta-values (Integer, Float, Boolean, String)
-		if a.isTypeMetaValueExpression(args[0]) {
-			switch argType {
-			case types.INTEGER:
-				return types.INTEGER
-			case types.FLOAT:
-				return types.FLOAT
-			case types.BOOLEAN:
-				return types.BOOLEAN
-			case types.STRING:
-				return types.INTEGER
-			}
-		}
-		// Neither array, enum, nor type meta-value
-		a.addError("function 'Low' expects array, enum, or type name, got %s at %s",
-			argType.String(), callExpr.Token.Pos.String())
-	}
-	return types.INTEGER
+	return a.analyzeBound("Low", args, callExpr)
 }
 
 // analyzeHigh analyzes the High built-in function.
 // High takes one argument (array, enum, or type meta-value) and returns a value of the appropriate type.
 func (a *Analyzer) analyzeHigh(args []ast.Expression, callExpr *ast.CallExpression) types.Type {
+	return a.analyzeBound("High", args, callExpr)
+}
+
+// analyzeBound checks the argument of Low/High. Arrays and strings yield
+// Integer bounds; enums, Booleans, Integers and subranges (as values or type
+// names) yield a value of the ordinal type itself.
+func (a *Analyzer) analyzeBound(name string, args []ast.Expression, callExpr *ast.CallExpression) types.Type {
 	if len(args) != 1 {
-		a.addError("function 'High' expects 1 argument, got %d at %s",
-			len(args), callExpr.Token.Pos.String())
+		a.addError("function '%s' expects 1 argument, got %d at %s",
+			name, len(args), callExpr.Token.Pos.String())
 		return types.INTEGER
 	}
 	// Analyze the argument
 	argType := a.analyzeExpression(args[0])
-	// Verify it's an array, enum, or basic type (type meta-value)
+	// Verify it's an array, ordinal, or basic type (type meta-value)
 	if argType != nil {
-		if _, isArray := types.GetUnderlyingType(argType).(*types.ArrayType); isArray {
+		underlying := types.GetUnderlyingType(argType)
+		if _, isArray := underlying.(*types.ArrayType); isArray {
 			// For arrays, return Integer
 			return types.INTEGER
 		}
-		if enumType, isEnum := types.GetUnderlyingType(argType).(*types.EnumType); isEnum {
+		if enumType, isEnum := underlying.(*types.EnumType); isEnum {
 			// For enums, return the same enum type
 			return enumType
 		}
+		if subrange, isSubrange := underlying.(*types.SubrangeType); isSubrange {
+			// For subranges, return the base ordinal type
+			return types.GetUnderlyingType(subrange.BaseType)
+		}
 		if argType == types.STRING {
 			return types.INTEGER
 		}
 		if argType == types.INTEGER || argType == types.BOOLEAN {
 			return argType
 		}
+
 		// Handle type meta-values (Integer, Float, Boolean, String)
 		if a.isTypeMetaValueExpression(args[0]) {
 			switch argType {
@@ -96,9 +68,9 @@ func (a *Analyzer) analyzeHigh(args []ast.Expression, callExpr *ast.CallExpressi
 				return types.INTEGER
 			}
 		}
-		// Neither array, enum, nor type meta-value
-		a.addError("function 'High' expects array, enum, or type name, got %s at %s",
-			argType.String(), callExpr.Token.Pos.String())
+		// Neither array, ordinal, nor type meta-value
+		a.addError("function '%s' expects array, enum, or type name, got %s at %s",
+			name, argType.String(), callExpr.Token.Pos.String())
 	}
 	return types.INTEGER
 }
@@ -336,6 +336,7 @@ func (a *Analyzer) analyzeChr(args []ast.Expression, callExpr *ast.CallExpressio
 // analyzeDefault analyzes the Default built-in function.
 // Default takes one argument (a type identifier) and returns the default value for that type.
 // Default(Integer) returns 0, Default(String) returns "", Default(Boolean) returns False, etc.
+// The result type is T, so Default(TRec) is a TRec and Default(TObj) a nil TObj.
 func (a *Analyzer) analyzeDefault(args []ast.Expression, callExpr *ast.CallExpression) types.Type {
 	if len(args) != 1 {
 		a.addError("function 'Default' expects 1 argument, got %d at %s",
@@ -344,7 +345,6 @@ func (a *Analyzer) analyzeDefault(args []ast.Expression, callExpr *ast.CallExpre
 	}
 
 	// The argument should be a type identifier
-	// For now, we'll handle it as an identifier and look up the type
 	ident, ok := args[0].(*ast.Identifier)
 	if !ok {
 		a.addError("function 'Default' expects a type name as argument at %s",
@@ -352,34 +352,30 @@ func (a *Analyzer) analyzeDefault(args []ast.Expression, callExpr *ast.CallExpre
 		return types.NIL
 	}
 
-	// Look up the type by name
 	typeName := ident.Value
 
 	// Return the appropriate type based on the type name
-	switch typeName {
-	case "Integer", "Int64", "Byte", "Word", "Cardinal", "SmallInt", "ShortInt", "LongWord":
+	switch pkgident.Normalize(typeName) {
+	case "integer", "int64", "byte", "word", "cardinal", "smallint", "shortint", "longword":
 		return types.INTEGER
-	case "Float", "Double", "Single", "Extended", "Currency":
+	case "float", "double", "single", "extended", "currency":
 		return types.FLOAT
-	case "String", "UnicodeString", "AnsiString":
+	case "string", "unicodestring", "ansistring":
 		return types.STRING
-	case "Boolean":
+	case "boolean":
 		return types.BOOLEAN
-	case "Variant":
+	case "variant":
 		return types.VARIANT
 	default:
-		// Check if it's a valid type in the symbol table
-		// This could be a class, record, enum, or other custom type
-		lowerName := pkgident.Normalize(typeName)
-		if _, exists := a.symbols.Resolve(lowerName); exists {
-			// Valid custom type - all default to nil/zero values
-			// Return VARIANT as a safe fallback type for analysis
-			return types.VARIANT
+		// Classes, records, arrays, sets, enums and aliases: the result is
+		// typed as T itself so it can be assigned and member-accessed.
+		resolved, err := a.resolveType(typeName)
+		if err != nil || resolved == nil {
+			a.addError("function 'Default' received unknown type '%s' at %s",
+				typeName, callExpr.Token.Pos.String())
+			return types.NIL
 		}
-		// Unknown type - report error instead of silently succeeding
-		a.addError("function 'Default' received unknown type '%s' at %s",
-			typeName, callExpr.Token.Pos.String())
-		return types.NIL
+		return resolved
 	}
 }
 
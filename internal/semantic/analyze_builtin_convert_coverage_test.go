@@ -98,6 +98,22 @@ func TestAnalyzeDefault(t *testing.T) {
 			code:        `type TMyType = Integer; var x: Variant; x := Default(Integer);`,
 			expectError: false,
 		},
+		{
+			name:        "Default of a record is typed as the record",
+			code:        `type TPoint = record X: Integer; end; var p: TPoint; var i: Integer := Default(TPoint).X; p := Default(TPoint);`,
+			expectError: false,
+		},
+		{
+			name:        "Default of a class type is assignable to the class",
+			code:        `type TObj = class end; var o: TObj := Default(TObj);`,
+			expectError: false,
+		},
+		{
+			name:        "Default result type is checked",
+			code:        `type TPoint = record X: Integer; end; var i: Integer := Default(TPoint);`,
+			expectError: true,
+			errorMsg:    "TPoint",
+		},
 		{
 			name:        "Default with unknown type",
 			code:        `var x: Variant; x := Default(UnknownType);`,
@@ -130,7 +130,7 @@ func (a *Analyzer) analyzeDecodeDate(args []ast.Expression, callExpr *ast.CallEx
 	// First argument: TDateTime (Float)
 	if len(args) > 0 {
 		argType := a.analyzeExpression(args[0])
-		if argType != nil && argType != types.FLOAT {
+		if argType != nil && !isDateTimeCompatible(argType) {
 			a.addError("function 'DecodeDate' expects Float/TDateTime as first argument, got %s at %s",
 				argType.String(), callExpr.Token.Pos.String())
 		}
@@ -152,7 +152,7 @@ func (a *Analyzer) analyzeDecodeTime(args []ast.Expression, callExpr *ast.CallEx
 	// First argument: TDateTime (Float)
 	if len(args) > 0 {
 		argType := a.analyzeExpression(args[0])
-		if argType != nil && argType != types.FLOAT {
+		if argType != nil && !isDateTimeCompatible(argType) {
 			a.addError("function 'DecodeTime' expects Float/TDateTime as first argument, got %s at %s",
 				argType.String(), callExpr.Token.Pos.String())
 		}
@@ -173,7 +173,7 @@ func (a *Analyzer) analyzeYearOf(args []ast.Expression, callExpr *ast.CallExpres
 	}
 	if len(args) > 0 {
 		argType := a.analyzeExpression(args[0])
-		if argType != nil && argType != types.FLOAT {
+		if argType != nil && !isDateTimeCompatible(argType) {
 			a.addError("function 'YearOf' expects Float/TDateTime, got %s at %s",
 				argType.String(), callExpr.Token.Pos.String())
 		}
@@ -190,7 +190,7 @@ func (a *Analyzer) analyzeMonthOf(args []ast.Expression, callExpr *ast.CallExpre
 	}
 	if len(args) > 0 {
 		argType := a.analyzeExpression(args[0])
-		if argType != nil && argType != types.FLOAT {
+		if argType != nil && !isDateTimeCompatible(argType) {
 			a.addError("function 'MonthOf' expects Float/TDateTime, got %s at %s",
 				argType.String(), callExpr.Token.Pos.String())
 		}
@@ -207,7 +207,7 @@ func (a *Analyzer) analyzeDayOf(args []ast.Expression, callExpr *ast.CallExpress
 	}
 	if len(args) > 0 {
 		argType := a.analyzeExpression(args[0])
-		if argType != nil && argType != types.FLOAT {
+		if argType != nil && !isDateTimeCompatible(argType) {
 			a.addError("function 'DayOf' expects Float/TDateTime, got %s at %s",
 				argType.String(), callExpr.Token.Pos.String())
 		}
@@ -224,7 +224,7 @@ func (a *Analyzer) analyzeHourOf(args []ast.Expression, callExpr *ast.CallExpres
 	}
 	if len(args) > 0 {
 		argType := a.analyzeExpression(args[0])
-		if argType != nil && argType != types.FLOAT {
+		if argType != nil && !isDateTimeCompatible(argType) {
 			a.addError("function 'HourOf' expects Float/TDateTime, got %s at %s",
 				argType.String(), callExpr.Token.Pos.String())
 		}
@@ -241,7 +241,7 @@ func (a *Analyzer) analyzeMinuteOf(args []ast.Expression, callExpr *ast.CallExpr
 	}
 	if len(args) > 0 {
 		argType := a.analyzeExpression(args[0])
-		if argType != nil && argType != types.FLOAT {
+		if argType != nil && !isDateTimeCompatible(argType) {
 			a.addError("function 'MinuteOf' expects Float/TDateTime, got %s at %s",
 				argType.String(), callExpr.Token.Pos.String())
 		}
@@ -258,7 +258,7 @@ func (a *Analyzer) analyzeSecondOf(args []ast.Expression, callExpr *ast.CallExpr
 	}
 	if len(args) > 0 {
 		argType := a.analyzeExpression(args[0])
-		if argType != nil && argType != types.FLOAT {
+		if argType != nil && !isDateTimeCompatible(argType) {
 			a.addError("function 'SecondOf' expects Float/TDateTime, got %s at %s",
 				argType.String(), callExpr.Token.Pos.String())
 		}
@@ -275,7 +275,7 @@ func (a *Analyzer) analyzeDayOfWeek(args []ast.Expression, callExpr *ast.CallExp
 	}
 	if len(args) > 0 {
 		argType := a.analyzeExpression(args[0])
-		if argType != nil && argType != types.FLOAT {
+		if argType != nil && !isDateTimeCompatible(argType) {
 			a.addError("function 'DayOfWeek' expects Float/TDateTime, got %s at %s",
 				argType.String(), callExpr.Token.Pos.String())
 		}
@@ -292,7 +292,7 @@ func (a *Analyzer) analyzeDayOfTheWeek(args []ast.Expression, callExpr *ast.Call
 	}
 	if len(args) > 0 {
 		argType := a.analyzeExpression(args[0])
-		if argType != nil && argType != types.FLOAT {
+		if argType != nil && !isDateTimeCompatible(argType) {
 			a.addError("function 'DayOfTheWeek' expects Float/TDateTime, got %s at %s",
 				argType.String(), callExpr.Token.Pos.String())
 		}
@@ -309,7 +309,7 @@ func (a *Analyzer) analyzeDayOfYear(args []ast.Expression, callExpr *ast.CallExp
 	}
 	if len(args) > 0 {
 		argType := a.analyzeExpression(args[0])
-		if argType != nil && argType != types.FLOAT {
+		if argType != nil && !isDateTimeCompatible(argType) {
 			a.addError("function 'DayOfYear' expects Float/TDateTime, got %s at %s",
 				argType.String(), callExpr.Token.Pos.String())
 		}
@@ -326,7 +326,7 @@ func (a *Analyzer) analyzeWeekNumber(args []ast.Expression, callExpr *ast.CallEx
 	}
 	if len(args) > 0 {
 		argType := a.analyzeExpression(args[0])
-		if argType != nil && argType != types.FLOAT {
+		if argType != nil && !isDateTimeCompatible(argType) {
 			a.addError("function 'WeekNumber' expects Float/TDateTime, got %s at %s",
 				argType.String(), callExpr.Token.Pos.String())
 		}
@@ -343,7 +343,7 @@ func (a *Analyzer) analyzeYearOfWeek(args []ast.Expression, callExpr *ast.CallEx
 	}
 	if len(args) > 0 {
 		argType := a.analyzeExpression(args[0])
-		if argType != nil && argType != types.FLOAT {
+		if argType != nil && !isDateTimeCompatible(argType) {
 			a.addError("function 'YearOfWeek' expects Float/TDateTime, got %s at %s",
 				argType.String(), callExpr.Token.Pos.String())
 		}
@@ -367,7 +367,7 @@ func (a *Analyzer) analyzeFormatDateTime(args []ast.Expression, callExpr *ast.Ca
 	}
 	if len(args) > 1 {
 		argType := a.analyzeExpression(args[1])
-		if argType != nil && argType != types.FLOAT {
+		if argType != nil && !isDateTimeCompatible(argType) {
 			a.addError("function 'FormatDateTime' expects Float/TDateTime as second argument, got %s at %s",
 				argType.String(), callExpr.Token.Pos.String())
 		}
@@ -384,7 +384,7 @@ func (a *Analyzer) analyzeDateTimeToStr(args []ast.Expression, callExpr *ast.Cal
 	}
 	if len(args) > 0 {
 		argType := a.analyzeExpression(args[0])
-		if argType != nil && argType != types.FLOAT {
+		if argType != nil && !isDateTimeCompatible(argType) {
 			a.addError("function 'DateTimeToStr' expects Float/TDateTime, got %s at %s",
 				argType.String(), callExpr.Token.Pos.String())
 		}
@@ -401,7 +401,7 @@ func (a *Analyzer) analyzeDateToStr(args []ast.Expression, callExpr *ast.CallExp
 	}
 	if len(args) > 0 {
 		argType := a.analyzeExpression(args[0])
-		if argType != nil && argType != types.FLOAT {
+		if argType != nil && !isDateTimeCompatible(argType) {
 			a.addError("function 'DateToStr' expects Float/TDateTime, got %s at %s",
 				argType.String(), callExpr.Token.Pos.String())
 		}
@@ -418,7 +418,7 @@ func (a *Analyzer) analyzeTimeToStr(args []ast.Expression, callExpr *ast.CallExp
 	}
 	if len(args) > 0 {
 		argType := a.analyzeExpression(args[0])
-		if argType != nil && argType != types.FLOAT {
+		if argType != nil && !isDateTimeCompatible(argType) {
 			a.addError("function 'TimeToStr' expects Float/TDateTime, got %s at %s",
 				argType.String(), callExpr.Token.Pos.String())
 		}
@@ -435,7 +435,7 @@ func (a *Analyzer) analyzeDateToISO8601(args []ast.Expression, callExpr *ast.Cal
 	}
 	if len(args) > 0 {
 		argType := a.analyzeExpression(args[0])
-		if argType != nil && argType != types.FLOAT {
+		if argType != nil && !isDateTimeCompatible(argType) {
 			a.addError("function 'DateToISO8601' expects Float/TDateTime, got %s at %s",
 				argType.String(), callExpr.Token.Pos.String())
 		}
@@ -452,7 +452,7 @@ func (a *Analyzer) analyzeDateTimeToISO8601(args []ast.Expression, callExpr *ast
 	}
 	if len(args) > 0 {
 		argType := a.analyzeExpression(args[0])
-		if argType != nil && argType != types.FLOAT {
+		if argType != nil && !isDateTimeCompatible(argType) {
 			a.addError("function 'DateTimeToISO8601' expects Float/TDateTime, got %s at %s",
 				argType.String(), callExpr.Token.Pos.String())
 		}
@@ -469,7 +469,7 @@ func (a *Analyzer) analyzeDateTimeToRFC822(args []ast.Expression, callExpr *ast.
 	}
 	if len(args) > 0 {
 		argType := a.analyzeExpression(args[0])
-		if argType != nil && argType != types.FLOAT {
+		if argType != nil && !isDateTimeCompatible(argType) {
 			a.addError("function 'DateTimeToRFC822' expects Float/TDateTime, got %s at %s",
 				argType.String(), callExpr.Token.Pos.String())
 		}
@@ -571,7 +571,7 @@ func (a *Analyzer) analyzeIncYear(args []ast.Expression, callExpr *ast.CallExpre
 	}
 	if len(args) > 0 {
 		argType := a.analyzeExpression(args[0])
-		if argType != nil && argType != types.FLOAT {
+		if argType != nil && !isDateTimeCompatible(argType) {
 			a.addError("function 'IncYear' expects Float/TDateTime as first argument, got %s at %s",
 				argType.String(), callExpr.Token.Pos.String())
 		}
@@ -595,7 +595,7 @@ func (a *Analyzer) analyzeIncMonth(args []ast.Expression, callExpr *ast.CallExpr
 	}
 	if len(args) > 0 {
 		argType := a.analyzeExpression(args[0])
-		if argType != nil && argType != types.FLOAT {
+		if argType != nil && !isDateTimeCompatible(argType) {
 			a.addError("function 'IncMonth' expects Float/TDateTime as first argument, got %s at %s",
 				argType.String(), callExpr.Token.Pos.String())
 		}
@@ -619,7 +619,7 @@ func (a *Analyzer) analyzeIncDay(args []ast.Expression, callExpr *ast.CallExpres
 	}
 	if len(args) > 0 {
 		argType := a.analyzeExpression(args[0])
-		if argType != nil && argType != types.FLOAT {
+		if argType != nil && !isDateTimeCompatible(argType) {
 			a.addError("function 'IncDay' expects Float/TDateTime as first argument, got %s at %s",
 				argType.String(), callExpr.Token.Pos.String())
 		}
@@ -643,7 +643,7 @@ func (a *Analyzer) analyzeIncHour(args []ast.Expression, callExpr *ast.CallExpre
 	}
 	if len(args) > 0 {
 		argType := a.analyzeExpression(args[0])
-		if argType != nil && argType != types.FLOAT {
+		if argType != nil && !isDateTimeCompatible(argType) {
 			a.addError("function 'IncHour' expects Float/TDateTime as first argument, got %s at %s",
 				argType.String(), callExpr.Token.Pos.String())
 		}
@@ -667,7 +667,7 @@ func (a *Analyzer) analyzeIncMinute(args []ast.Expression, callExpr *ast.CallExp
 	}
 	if len(args) > 0 {
 		argType := a.analyzeExpression(args[0])
-		if argType != nil && argType != types.FLOAT {
+		if argType != nil && !isDateTimeCompatible(argType) {
 			a.addError("function 'IncMinute' expects Float/TDateTime as first argument, got %s at %s",
 				argType.String(), callExpr.Token.Pos.String())
 		}
@@ -691,7 +691,7 @@ func (a *Analyzer) analyzeIncSecond(args []ast.Expression, callExpr *ast.CallExp
 	}
 	if len(args) > 0 {
 		argType := a.analyzeExpression(args[0])
-		if argType != nil && argType != types.FLOAT {
+		if argType != nil && !isDateTimeCompatible(argType) {
 			a.addError("function 'IncSecond' expects Float/TDateTime as first argument, got %s at %s",
 				argType.String(), callExpr.Token.Pos.String())
 		}
@@ -715,7 +715,7 @@ func (a *Analyzer) analyzeDaysBetween(args []ast.Expression, callExpr *ast.CallE
 	}
 	for i, arg := range args {
 		argType := a.analyzeExpression(arg)
-		if argType != nil && argType != types.FLOAT {
+		if argType != nil && !isDateTimeCompatible(argType) {
 			a.addError("function 'DaysBetween' expects Float/TDateTime as argument %d, got %s at %s",
 				i+1, argType.String(), callExpr.Token.Pos.String())
 		}
@@ -732,7 +732,7 @@ func (a *Analyzer) analyzeHoursBetween(args []ast.Expression, callExpr *ast.Call
 	}
 	for i, arg := range args {
 		argType := a.analyzeExpression(arg)
-		if argType != nil && argType != types.FLOAT {
+		if argType != nil && !isDateTimeCompatible(argType) {
 			a.addError("function 'HoursBetween' expects Float/TDateTime as argument %d, got %s at %s",
 				i+1, argType.String(), callExpr.Token.Pos.String())
 		}
@@ -749,7 +749,7 @@ func (a *Analyzer) analyzeMinutesBetween(args []ast.Expression, callExpr *ast.Ca
 	}
 	for i, arg := range args {
 		argType := a.analyzeExpression(arg)
-		if argType != nil && argType != types.FLOAT {
+		if argType != nil && !isDateTimeCompatible(argType) {
 			a.addError("function 'MinutesBetween' expects Float/TDateTime as argument %d, got %s at %s",
 				i+1, argType.String(), callExpr.Token.Pos.String())
 		}
@@ -766,7 +766,7 @@ func (a *Analyzer) analyzeSecondsBetween(args []ast.Expression, callExpr *ast.Ca
 	}
 	for i, arg := range args {
 		argType := a.analyzeExpression(arg)
-		if argType != nil && argType != types.FLOAT {
+		if argType != nil && !isDateTimeCompatible(argType) {
 			a.addError("function 'SecondsBetween' expects Float/TDateTime as argument %d, got %s at %s",
 				i+1, argType.String(), callExpr.Token.Pos.String())
 		}
@@ -800,7 +800,7 @@ func (a *Analyzer) analyzeFirstDayOfYear(args []ast.Expression, callExpr *ast.Ca
 	}
 	if len(args) > 0 {
 		argType := a.analyzeExpression(args[0])
-		if argType != nil && argType != types.FLOAT {
+		if argType != nil && !isDateTimeCompatible(argType) {
 			a.addError("function 'FirstDayOfYear' expects Float/TDateTime, got %s at %s",
 				argType.String(), callExpr.Token.Pos.String())
 		}
@@ -817,7 +817,7 @@ func (a *Analyzer) analyzeFirstDayOfNextYear(args []ast.Expression, callExpr *as
 	}
 	if len(args) > 0 {
 		argType := a.analyzeExpression(args[0])
-		if argType != nil && argType != types.FLOAT {
+		if argType != nil && !isDateTimeCompatible(argType) {
 			a.addError("function 'FirstDayOfNextYear' expects Float/TDateTime, got %s at %s",
 				argType.String(), callExpr.Token.Pos.String())
 		}
@@ -834,7 +834,7 @@ func (a *Analyzer) analyzeFirstDayOfMonth(args []ast.Expression, callExpr *ast.C
 	}
 	if len(args) > 0 {
 		argType := a.analyzeExpression(args[0])
-		if argType != nil && argType != types.FLOAT {
+		if argType != nil && !isDateTimeCompatible(argType) {
 			a.addError("function 'FirstDayOfMonth' expects Float/TDateTime, got %s at %s",
 				argType.String(), callExpr.Token.Pos.String())
 		}
@@ -851,7 +851,7 @@ func (a *Analyzer) analyzeFirstDayOfNextMonth(args []ast.Expression, callExpr *a
 	}
 	if len(args) > 0 {
 		argType := a.analyzeExpression(args[0])
-		if argType != nil && argType != types.FLOAT {
+		if argType != nil && !isDateTimeCompatible(argType) {
 			a.addError("function 'FirstDayOfNextMonth' expects Float/TDateTime, got %s at %s",
 				argType.String(), callExpr.Token.Pos.String())
 		}
@@ -868,7 +868,7 @@ func (a *Analyzer) analyzeFirstDayOfWeek(args []ast.Expression, callExpr *ast.Ca
 	}
 	if len(args) > 0 {
 		argType := a.analyzeExpression(args[0])
-		if argType != nil && argType != types.FLOAT {
+		if argType != nil && !isDateTimeCompatible(argType) {
 			a.addError("function 'FirstDayOfWeek' expects Float/TDateTime, got %s at %s",
 				argType.String(), callExpr.Token.Pos.String())
 		}
@@ -919,7 +919,7 @@ func (a *Analyzer) analyzeDateTimeToUnixTime(args []ast.Expression, callExpr *as
 	}
 	if len(args) > 0 {
 		argType := a.analyzeExpression(args[0])
-		if argType != nil && argType != types.FLOAT {
+		if argType != nil && !isDateTimeCompatible(argType) {
 			a.addError("function 'DateTimeToUnixTime' expects Float/TDateTime, got %s at %s",
 				argType.String(), callExpr.Token.Pos.String())
 		}
@@ -936,10 +936,21 @@ func (a *Analyzer) analyzeDateTimeToUnixTimeMSec(args []ast.Expression, callExpr
 	}
 	if len(args) > 0 {
 		argType := a.analyzeExpression(args[0])
-		if argType != nil && argType != types.FLOAT {
+		if argType != nil && !isDateTimeCompatible(argType) {
 			a.addError("function 'DateTimeToUnixTimeMSec' expects Float/TDateTime, got %s at %s",
 				argType.String(), callExpr.Token.Pos.String())
 		}
 	}
 	return types.INTEGER
 }
+
+// isDateTimeCompatible reports whether a value of type t can be passed as a
+// TDateTime: a Float (or the TDateTime alias of it) or an Integer day count.
+func isDateTimeCompatible(t types.Type) bool {
+	switch types.GetUnderlyingType(t).TypeKind() {
+	case "FLOAT", "INTEGER":
+		return true
+	default:
+		return false
+	}
+}
@@ -3,6 +3,7 @@ package semantic
 import (
 	"github.com/cwbudde/go-dws/internal/types"
 	"github.com/cwbudde/go-dws/pkg/ast"
+	pkgident "github.com/cwbudde/go-dws/pkg/ident"
 )
 
 // ============================================================================
@@ -110,3 +111,59 @@ func (a *Analyzer) analyzeStrToXML(args []ast.Expression, callExpr *ast.CallExpr
 	}
 	return types.STRING
 }
+
+// analyzeUTF8Conversion analyzes the UTF8Encode and UTF8Decode built-in functions.
+// Both take one string argument and return a string.
+func (a *Analyzer) analyzeUTF8Conversion(args []ast.Expression, callExpr *ast.CallExpression) types.Type {
+	name := "UTF8Encode"
+	if fn, ok := callExpr.Function.(*ast.Identifier); ok && pkgident.Equal(fn.Value, "UTF8Decode") {
+		name = "UTF8Decode"
+	}
+	if len(args) != 1 {
+		a.addError("function '%s' expects 1 argument, got %d at %s",
+			name, len(args), callExpr.Token.Pos.String())
+		return types.STRING
+	}
+	argType := a.analyzeExpression(args[0])
+	if argType != nil && argType != types.STRING && !builtinArgIsVariant(argType) {
+		a.addError("function '%s' expects String as argument, got %s at %s",
+			name, argType.String(), callExpr.Token.Pos.String())
+	}
+	return types.STRING
+}
+
+// analyzeBytesOf analyzes the BytesOf built-in function.
+// BytesOf takes one string argument and returns its UTF-8 bytes as an array of Byte.
+func (a *Analyzer) analyzeBytesOf(args []ast.Expression, callExpr *ast.CallExpression) types.Type {
+	result := types.NewDynamicArrayType(types.BYTE)
+	if len(args) != 1 {
+		a.addError("function 'BytesOf' expects 1 argument, got %d at %s",
+			len(args), callExpr.Token.Pos.String())
+		return result
+	}
+	argType := a.analyzeExpression(args[0])
+	if argType != nil && argType != types.STRING && !builtinArgIsVariant(argType) {
+		a.addError("function 'BytesOf' expects String as argument, got %s at %s",
+			argType.String(), callExpr.Token.Pos.String())
+	}
+	return result
+}
+
+// analyzeStringOf analyzes the StringOf built-in function.
+// StringOf takes an array of Byte (or Integer) and returns the decoded string.
+func (a *Analyzer) analyzeStringOf(args []ast.Expression, callExpr *ast.CallExpression) types.Type {
+	if len(args) != 1 {
+		a.addError("function 'StringOf' expects 1 argument, got %d at %s",
+			len(args), callExpr.Token.Pos.String())
+		return types.STRING
+	}
+	argType := a.analyzeExpressionWithExpectedType(args[0], types.NewDynamicArrayType(types.BYTE))
+	if argType != nil {
+		arrayType, ok := types.GetUnderlyingType(argType).(*types.ArrayType)
+		if !ok || !types.GetUnderlyingType(arrayType.ElementType).Equals(types.INTEGER) {
+			a.addError("function 'StringOf' expects array of Byte as argument, got %s at %s",
+				argType.String(), callExpr.Token.Pos.String())
+		}
+	}
+	return types.STRING
+}
@@ -14,6 +14,10 @@ import (
 // Returns (resultType, true) if the function is a recognized built-in,
 // or (nil, false) if it's not a built-in function.
 func (a *Analyzer) analyzeBuiltinFunction(name string, args []ast.Expression, callExpr *ast.CallExpression) (types.Type, bool) {
+	if a.isBuiltinRemoved(name) {
+		return nil, false
+	}
+
 	// Normalize function name to lowercase for case-insensitive matching
 	lowerName := ident.Normalize(name)
 
@@ -226,6 +230,12 @@ func (a *Analyzer) analyzeBuiltinFunction(name string, args []ast.Expression, ca
 		return a.analyzeStrToCSSText(args, callExpr), true
 	case "strtoxml":
 		return a.analyzeStrToXML(args, callExpr), true
+	case "utf8encode", "utf8decode":
+		return a.analyzeUTF8Conversion(args, callExpr), true
+	case "bytesof":
+		return a.analyzeBytesOf(args, callExpr), true
+	case "stringof":
+		return a.analyzeStringOf(args, callExpr), true
 
 	// Math Functions - Basic
 	case "abs":
@@ -380,8 +390,20 @@ func (a *Analyzer) analyzeBuiltinFunction(name string, args []ast.Expression, ca
 		return a.analyzePred(args, callExpr), true
 	case "assigned":
 		return a.analyzeAssigned(args, callExpr), true
+	case "getclass":
+		return a.analyzeGetClass(args, callExpr), true
+	case "typeof":
+		return a.analyzeTypeOf(args, callExpr), true
+	case "typename":
+		return a.analyzeTypeName("TypeName", args, callExpr), true
+	case "typeofclass":
+		return a.analyzeTypeName("TypeOfClass", args, callExpr), true
 	case "swap":
 		return a.analyzeSwap(args, callExpr), true
+	case "freeandnil":
+		return a.analyzeFreeAndNil(args, callExpr), true
+	case "supports":
+		return a.analyzeSupports(args, callExpr), true
 
 	// Date/Time Functions - Current time
 	case "now":
@@ -522,6 +544,8 @@ func (a *Analyzer) analyzeBuiltinFunction(name string, args []ast.Expression, ca
 		return a.analyzeJSONHasField(args, callExpr), true
 	case "jsonkeys":
 		return a.analyzeJSONKeys(args, callExpr), true
+	case "keys":
+		return a.analyzeKeys(args, callExpr), true
 	case "jsonvalues":
 		return a.analyzeJSONValues(args, callExpr), true
 	case "jsonlength":
@@ -652,16 +676,23 @@ func (a *Analyzer) getBuiltinReturnType(name string) (types.Type, bool) {
 	// ========================================================================
 	// Encoding/Escaping Functions
 	// ========================================================================
-	case "strtohtml", "strtohtmlattribute", "strtojson", "strtocsstext", "strtoxml":
+	case "strtohtml", "strtohtmlattribute", "strtojson", "strtocsstext", "strtoxml",
+		"utf8encode", "utf8decode", "stringof":
 		return types.STRING, true
+	case "bytesof":
+		return types.NewDynamicArrayType(types.BYTE), true
 
 	// ========================================================================
 	// Math Functions - Basic
 	// ========================================================================
 	case "abs", "sqr", "sqrt", "power":
 		return types.FLOAT, true
-	case "min", "max", "clamp", "clampint", "minint", "maxint":
-		return types.VARIANT, true // Return type depends on arguments
+	case "min", "max":
+		return types.VARIANT, true // Integer for two Integers, otherwise Float
+	case "clamp":
+		return types.FLOAT, true
+	case "clampint", "minint", "maxint":
+		return types.INTEGER, true
 
 	// ========================================================================
 	// Math Functions - Trigonometric
@@ -746,9 +777,15 @@ func (a *Analyzer) getBuiltinReturnType(name string) (types.Type, bool) {
 		return types.VOID, true
 	case "succ", "pred":
 		return types.VARIANT, true // Return type matches argument type
-	case "assigned":
+	case "assigned", "supports":
 		return types.BOOLEAN, true
-	case "swap":
+	case "getclass":
+		return types.NewClassOfType(a.getClassType("TObject")), true
+	case "typeof":
+		return types.RTTI_TYPEINFO, true
+	case "typename", "typeofclass":
+		return types.STRING, true
+	case "swap", "freeandnil":
 		return types.VOID, true
 
 	// ========================================================================
@@ -833,7 +870,7 @@ func (a *Analyzer) getBuiltinReturnType(name string) (types.Type, bool) {
 		return types.STRING, true
 	case "jsonhasfield":
 		return types.BOOLEAN, true
-	case "jsonkeys", "jsonvalues":
+	case "jsonkeys", "keys", "jsonvalues":
 		return types.VARIANT, true // Returns array
 	case "jsonlength":
 		return types.INTEGER, true
@@ -867,7 +904,9 @@ func (a *Analyzer) analyzePrintLn(args []ast.Expression) types.Type {
 }
 
 // analyzeOrd analyzes the Ord/Integer built-in function.
-// These functions take one argument and return an integer.
+// These functions take one argument and return an integer. Ord only accepts
+// ordinal values (Integer, subrange, enum, Boolean or a character String);
+// the Integer cast also converts Floats and other values.
 func (a *Analyzer) analyzeOrd(args []ast.Expression, callExpr *ast.CallExpression) types.Type {
 	if len(args) != 1 {
 		a.addError("function 'Ord' expects 1 argument, got %d at %s",
@@ -875,6 +914,25 @@ func (a *Analyzer) analyzeOrd(args []ast.Expression, callExpr *ast.CallExpressio
 		return types.INTEGER
 	}
 	// Analyze the argument
-	a.analyzeExpression(args[0])
+	argType := a.analyzeExpression(args[0])
+	if fn, ok := callExpr.Function.(*ast.Identifier); ok && ident.Equal(fn.Value, "Ord") &&
+		argType != nil && !isOrdinalArgument(argType) {
+		a.addError("function 'Ord' expects an ordinal value, got %s at %s",
+			argType.String(), callExpr.Token.Pos.String())
+	}
 	return types.INTEGER
 }
+
+// isOrdinalArgument reports whether t can be passed to Ord: an ordinal type,
+// a character String, or a Variant holding one.
+func isOrdinalArgument(t types.Type) bool {
+	if builtinArgIsVariant(t) {
+		return true
+	}
+	switch underlying := types.GetUnderlyingType(t); underlying.(type) {
+	case *types.EnumType, *types.SubrangeType:
+		return true
+	default:
+		return underlying == types.INTEGER || underlying == types.BOOLEAN || underlying == types.STRING
+	}
+}
@@ -98,6 +98,31 @@ func (a *Analyzer) analyzeJSONKeys(args []ast.Expression, callExpr *ast.CallExpr
 	return types.NewDynamicArrayType(types.STRING)
 }
 
+// analyzeKeys analyzes the Keys built-in function.
+// Keys takes a JSON (Variant) value and returns an array of strings, or an
+// associative array and returns an array of its key type.
+func (a *Analyzer) analyzeKeys(args []ast.Expression, callExpr *ast.CallExpression) types.Type {
+	if len(args) != 1 {
+		a.addError("function 'Keys' expects 1 argument, got %d at %s",
+			len(args), callExpr.Token.Pos.String())
+		return types.NewDynamicArrayType(types.STRING)
+	}
+	argType := a.analyzeExpression(args[0])
+	if argType == nil {
+		return types.NewDynamicArrayType(types.STRING)
+	}
+	switch at := types.GetUnderlyingType(argType).(type) {
+	case *types.AssociativeArrayType:
+		return types.NewDynamicArrayType(at.KeyType)
+	case *types.VariantType, *types.JSONVariantType:
+		return types.NewDynamicArrayType(types.STRING)
+	default:
+		a.addError("function 'Keys' expects a JSON value or an associative array, got %s at %s",
+			argType.String(), callExpr.Token.Pos.String())
+		return types.NewDynamicArrayType(types.STRING)
+	}
+}
+
 // analyzeJSONValues analyzes the JSONValues built-in function.
 // JSONValues takes one argument (json object) and returns an array of variants.
 func (a *Analyzer) analyzeJSONValues(args []ast.Expression, callExpr *ast.CallExpression) types.Type {
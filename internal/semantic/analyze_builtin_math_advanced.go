@@ -22,8 +22,8 @@ func (a *Analyzer) analyzePower(args []ast.Expression, callExpr *ast.CallExpress
 			len(args), callExpr.Token.Pos.String())
 		return types.FLOAT
 	}
-	arg1Type := a.analyzeExpression(args[0])
-	arg2Type := a.analyzeExpression(args[1])
+	arg1Type := a.analyzeNumericArgument(args[0])
+	arg2Type := a.analyzeNumericArgument(args[1])
 
 	if arg1Type != nil && arg2Type != nil {
 		if (arg1Type != types.INTEGER && arg1Type != types.FLOAT) ||
@@ -44,13 +44,13 @@ func (a *Analyzer) analyzeIntPower(args []ast.Expression, callExpr *ast.CallExpr
 		return types.FLOAT
 	}
 
-	argType1 := a.analyzeExpression(args[0])
+	argType1 := a.analyzeNumericArgument(args[0])
 	if argType1 != nil && argType1 != types.FLOAT && argType1 != types.INTEGER {
 		a.addError("function 'IntPower' expects Float or Integer as first argument, got %s at %s",
 			argType1.String(), callExpr.Token.Pos.String())
 	}
 
-	argType2 := a.analyzeExpression(args[1])
+	argType2 := a.analyzeNumericArgument(args[1])
 	if argType2 != nil && argType2 != types.INTEGER {
 		a.addError("function 'IntPower' expects Integer as second argument, got %s at %s",
 			argType2.String(), callExpr.Token.Pos.String())
@@ -67,7 +67,7 @@ func (a *Analyzer) analyzeSqrt(args []ast.Expression, callExpr *ast.CallExpressi
 			len(args), callExpr.Token.Pos.String())
 		return types.FLOAT
 	}
-	argType := a.analyzeExpression(args[0])
+	argType := a.analyzeNumericArgument(args[0])
 	if argType != nil {
 		if argType != types.INTEGER && argType != types.FLOAT {
 			a.addError("function 'Sqrt' expects Integer or Float as argument, got %s at %s",
@@ -85,7 +85,7 @@ func (a *Analyzer) analyzeExp(args []ast.Expression, callExpr *ast.CallExpressio
 			len(args), callExpr.Token.Pos.String())
 		return types.FLOAT
 	}
-	argType := a.analyzeExpression(args[0])
+	argType := a.analyzeNumericArgument(args[0])
 	if argType != nil {
 		if argType != types.INTEGER && argType != types.FLOAT {
 			a.addError("function 'Exp' expects Integer or Float as argument, got %s at %s",
@@ -103,7 +103,7 @@ func (a *Analyzer) analyzeLn(args []ast.Expression, callExpr *ast.CallExpression
 			len(args), callExpr.Token.Pos.String())
 		return types.FLOAT
 	}
-	argType := a.analyzeExpression(args[0])
+	argType := a.analyzeNumericArgument(args[0])
 	if argType != nil {
 		if argType != types.INTEGER && argType != types.FLOAT {
 			a.addError("function 'Ln' expects Integer or Float as argument, got %s at %s",
@@ -121,7 +121,7 @@ func (a *Analyzer) analyzeLog2(args []ast.Expression, callExpr *ast.CallExpressi
 			len(args), callExpr.Token.Pos.String())
 		return types.FLOAT
 	}
-	argType := a.analyzeExpression(args[0])
+	argType := a.analyzeNumericArgument(args[0])
 	if argType != nil {
 		if argType != types.INTEGER && argType != types.FLOAT {
 			a.addError("function 'Log2' expects Integer or Float as argument, got %s at %s",
@@ -139,7 +139,7 @@ func (a *Analyzer) analyzeLog10(args []ast.Expression, callExpr *ast.CallExpress
 			len(args), callExpr.Token.Pos.String())
 		return types.FLOAT
 	}
-	argType := a.analyzeExpression(args[0])
+	argType := a.analyzeNumericArgument(args[0])
 	if argType != nil && argType != types.FLOAT && argType != types.INTEGER {
 		a.addError("function 'Log10' expects Float or Integer, got %s at %s",
 			argType.String(), callExpr.Token.Pos.String())
@@ -156,13 +156,13 @@ func (a *Analyzer) analyzeLogN(args []ast.Expression, callExpr *ast.CallExpressi
 		return types.FLOAT
 	}
 
-	argType1 := a.analyzeExpression(args[0])
+	argType1 := a.analyzeNumericArgument(args[0])
 	if argType1 != nil && argType1 != types.FLOAT && argType1 != types.INTEGER {
 		a.addError("function 'LogN' expects Float or Integer as first argument, got %s at %s",
 			argType1.String(), callExpr.Token.Pos.String())
 	}
 
-	argType2 := a.analyzeExpression(args[1])
+	argType2 := a.analyzeNumericArgument(args[1])
 	if argType2 != nil && argType2 != types.FLOAT && argType2 != types.INTEGER {
 		a.addError("function 'LogN' expects Float or Integer as second argument, got %s at %s",
 			argType2.String(), callExpr.Token.Pos.String())
@@ -212,7 +212,7 @@ func (a *Analyzer) analyzeIsNaN(args []ast.Expression, callExpr *ast.CallExpress
 			len(args), callExpr.Token.Pos.String())
 		return types.BOOLEAN
 	}
-	argType := a.analyzeExpression(args[0])
+	argType := a.analyzeNumericArgument(args[0])
 	if argType != nil && argType != types.FLOAT {
 		// Don't error - just check at runtime
 		// This allows IsNaN to be called on any type
@@ -228,7 +228,7 @@ func (a *Analyzer) analyzeIsFinite(args []ast.Expression, callExpr *ast.CallExpr
 			len(args), callExpr.Token.Pos.String())
 		return types.BOOLEAN
 	}
-	argType := a.analyzeExpression(args[0])
+	argType := a.analyzeNumericArgument(args[0])
 	if argType != nil && argType != types.FLOAT && argType != types.INTEGER {
 		a.addError("function 'IsFinite' expects Float or Integer, got %s at %s",
 			argType.String(), callExpr.Token.Pos.String())
@@ -244,7 +244,7 @@ func (a *Analyzer) analyzeIsInfinite(args []ast.Expression, callExpr *ast.CallEx
 			len(args), callExpr.Token.Pos.String())
 		return types.BOOLEAN
 	}
-	argType := a.analyzeExpression(args[0])
+	argType := a.analyzeNumericArgument(args[0])
 	if argType != nil && argType != types.FLOAT && argType != types.INTEGER {
 		a.addError("function 'IsInfinite' expects Float or Integer, got %s at %s",
 			argType.String(), callExpr.Token.Pos.String())
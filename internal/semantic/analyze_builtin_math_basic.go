@@ -22,7 +22,7 @@ func (a *Analyzer) analyzeAbs(args []ast.Expression, callExpr *ast.CallExpressio
 			len(args), callExpr.Token.Pos.String())
 		return types.INTEGER
 	}
-	argType := a.analyzeExpression(args[0])
+	argType := a.analyzeNumericArgument(args[0])
 	if argType != nil {
 		if argType != types.INTEGER && argType != types.FLOAT {
 			a.addError("function 'Abs' expects numeric (Integer or Float) argument, got %s at %s",
@@ -42,8 +42,8 @@ func (a *Analyzer) analyzeMin(args []ast.Expression, callExpr *ast.CallExpressio
 			len(args), callExpr.Token.Pos.String())
 		return types.INTEGER
 	}
-	arg1Type := a.analyzeExpression(args[0])
-	arg2Type := a.analyzeExpression(args[1])
+	arg1Type := a.analyzeNumericArgument(args[0])
+	arg2Type := a.analyzeNumericArgument(args[1])
 
 	if arg1Type != nil && arg2Type != nil {
 		// Variant arguments are coerced at runtime.
@@ -72,8 +72,8 @@ func (a *Analyzer) analyzeMax(args []ast.Expression, callExpr *ast.CallExpressio
 			len(args), callExpr.Token.Pos.String())
 		return types.INTEGER
 	}
-	arg1Type := a.analyzeExpression(args[0])
-	arg2Type := a.analyzeExpression(args[1])
+	arg1Type := a.analyzeNumericArgument(args[0])
+	arg2Type := a.analyzeNumericArgument(args[1])
 
 	if arg1Type != nil && arg2Type != nil {
 		// Variant arguments are coerced at runtime.
@@ -102,9 +102,9 @@ func (a *Analyzer) analyzeClampInt(args []ast.Expression, callExpr *ast.CallExpr
 			len(args), callExpr.Token.Pos.String())
 		return types.INTEGER
 	}
-	arg1Type := a.analyzeExpression(args[0])
-	arg2Type := a.analyzeExpression(args[1])
-	arg3Type := a.analyzeExpression(args[2])
+	arg1Type := a.analyzeNumericArgument(args[0])
+	arg2Type := a.analyzeNumericArgument(args[1])
+	arg3Type := a.analyzeNumericArgument(args[2])
 
 	if arg1Type != nil && arg2Type != nil && arg3Type != nil {
 		if arg1Type != types.INTEGER || arg2Type != types.INTEGER || arg3Type != types.INTEGER {
@@ -123,9 +123,9 @@ func (a *Analyzer) analyzeClamp(args []ast.Expression, callExpr *ast.CallExpress
 			len(args), callExpr.Token.Pos.String())
 		return types.FLOAT
 	}
-	arg1Type := a.analyzeExpression(args[0])
-	arg2Type := a.analyzeExpression(args[1])
-	arg3Type := a.analyzeExpression(args[2])
+	arg1Type := a.analyzeNumericArgument(args[0])
+	arg2Type := a.analyzeNumericArgument(args[1])
+	arg3Type := a.analyzeNumericArgument(args[2])
 
 	if arg1Type != nil && arg2Type != nil && arg3Type != nil {
 		if (arg1Type != types.INTEGER && arg1Type != types.FLOAT) ||
@@ -180,7 +180,7 @@ func (a *Analyzer) analyzeSqr(args []ast.Expression, callExpr *ast.CallExpressio
 			len(args), callExpr.Token.Pos.String())
 		return types.INTEGER
 	}
-	argType := a.analyzeExpression(args[0])
+	argType := a.analyzeNumericArgument(args[0])
 	if argType != nil {
 		if argType != types.INTEGER && argType != types.FLOAT {
 			a.addError("function 'Sqr' expects Integer or Float as argument, got %s at %s",
@@ -200,7 +200,7 @@ func (a *Analyzer) analyzeSign(args []ast.Expression, callExpr *ast.CallExpressi
 			len(args), callExpr.Token.Pos.String())
 		return types.INTEGER
 	}
-	argType := a.analyzeExpression(args[0])
+	argType := a.analyzeNumericArgument(args[0])
 	if argType != nil && argType != types.FLOAT && argType != types.INTEGER {
 		a.addError("function 'Sign' expects Float or Integer, got %s at %s",
 			argType.String(), callExpr.Token.Pos.String())
@@ -216,7 +216,7 @@ func (a *Analyzer) analyzeOdd(args []ast.Expression, callExpr *ast.CallExpressio
 			len(args), callExpr.Token.Pos.String())
 		return types.BOOLEAN
 	}
-	argType := a.analyzeExpression(args[0])
+	argType := a.analyzeNumericArgument(args[0])
 	if argType != nil && argType != types.INTEGER {
 		a.addError("function 'Odd' expects Integer, got %s at %s",
 			argType.String(), callExpr.Token.Pos.String())
@@ -235,25 +235,25 @@ func (a *Analyzer) analyzeDivMod(args []ast.Expression, callExpr *ast.CallExpres
 		return nil
 	}
 
-	dividendType := a.analyzeExpression(args[0])
+	dividendType := a.analyzeNumericArgument(args[0])
 	if dividendType != nil && dividendType != types.INTEGER {
 		a.addError("function 'DivMod' expects Integer as first argument, got %s at %s",
 			dividendType.String(), callExpr.Token.Pos.String())
 	}
 
-	divisorType := a.analyzeExpression(args[1])
+	divisorType := a.analyzeNumericArgument(args[1])
 	if divisorType != nil && divisorType != types.INTEGER {
 		a.addError("function 'DivMod' expects Integer as second argument, got %s at %s",
 			divisorType.String(), callExpr.Token.Pos.String())
 	}
 
-	quotientType := a.analyzeExpression(args[2])
+	quotientType := a.analyzeNumericArgument(args[2])
 	if quotientType != nil && quotientType != types.INTEGER {
 		a.addError("function 'DivMod' expects Integer as third argument, got %s at %s",
 			quotientType.String(), callExpr.Token.Pos.String())
 	}
 
-	remainderType := a.analyzeExpression(args[3])
+	remainderType := a.analyzeNumericArgument(args[3])
 	if remainderType != nil && remainderType != types.INTEGER {
 		a.addError("function 'DivMod' expects Integer as fourth argument, got %s at %s",
 			remainderType.String(), callExpr.Token.Pos.String())
@@ -261,3 +261,23 @@ func (a *Analyzer) analyzeDivMod(args []ast.Expression, callExpr *ast.CallExpres
 
 	return nil
 }
+
+// analyzeNumericArgument analyzes an argument of a math built-in function.
+// Aliases of Integer and Float and Integer subranges resolve to Integer or
+// Float, so the checks below and the chosen return type follow the underlying
+// type. Other types are returned unchanged.
+func (a *Analyzer) analyzeNumericArgument(arg ast.Expression) types.Type {
+	argType := a.analyzeExpression(arg)
+	if argType == nil {
+		return nil
+	}
+	switch t := types.GetUnderlyingType(argType).(type) {
+	case *types.SubrangeType:
+		if types.GetUnderlyingType(t.BaseType) == types.INTEGER {
+			return types.INTEGER
+		}
+	case *types.IntegerType, *types.FloatType:
+		return t
+	}
+	return argType
+}
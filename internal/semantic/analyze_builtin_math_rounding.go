@@ -21,7 +21,7 @@ func (a *Analyzer) analyzeRound(args []ast.Expression, callExpr *ast.CallExpress
 			len(args), callExpr.Token.Pos.String())
 		return types.INTEGER
 	}
-	argType := a.analyzeExpression(args[0])
+	argType := a.analyzeNumericArgument(args[0])
 	if argType != nil {
 		if argType != types.INTEGER && argType != types.FLOAT && argType != types.VARIANT {
 			a.addError("function 'Round' expects Integer, Float, or Variant as argument, got %s at %s",
@@ -39,7 +39,7 @@ func (a *Analyzer) analyzeTrunc(args []ast.Expression, callExpr *ast.CallExpress
 			len(args), callExpr.Token.Pos.String())
 		return types.INTEGER
 	}
-	argType := a.analyzeExpression(args[0])
+	argType := a.analyzeNumericArgument(args[0])
 	if argType != nil {
 		if argType != types.INTEGER && argType != types.FLOAT {
 			a.addError("function 'Trunc' expects Integer or Float as argument, got %s at %s",
@@ -57,7 +57,7 @@ func (a *Analyzer) analyzeCeil(args []ast.Expression, callExpr *ast.CallExpressi
 			len(args), callExpr.Token.Pos.String())
 		return types.INTEGER
 	}
-	argType := a.analyzeExpression(args[0])
+	argType := a.analyzeNumericArgument(args[0])
 	if argType != nil {
 		if argType != types.INTEGER && argType != types.FLOAT {
 			a.addError("function 'Ceil' expects Integer or Float as argument, got %s at %s",
@@ -75,7 +75,7 @@ func (a *Analyzer) analyzeFloor(args []ast.Expression, callExpr *ast.CallExpress
 			len(args), callExpr.Token.Pos.String())
 		return types.INTEGER
 	}
-	argType := a.analyzeExpression(args[0])
+	argType := a.analyzeNumericArgument(args[0])
 	if argType != nil {
 		if argType != types.INTEGER && argType != types.FLOAT {
 			a.addError("function 'Floor' expects Integer or Float as argument, got %s at %s",
@@ -93,7 +93,7 @@ func (a *Analyzer) analyzeFrac(args []ast.Expression, callExpr *ast.CallExpressi
 			len(args), callExpr.Token.Pos.String())
 		return types.FLOAT
 	}
-	argType := a.analyzeExpression(args[0])
+	argType := a.analyzeNumericArgument(args[0])
 	if argType != nil && argType != types.FLOAT && argType != types.INTEGER {
 		a.addError("function 'Frac' expects Float or Integer, got %s at %s",
 			argType.String(), callExpr.Token.Pos.String())
@@ -109,7 +109,7 @@ func (a *Analyzer) analyzeInt(args []ast.Expression, callExpr *ast.CallExpressio
 			len(args), callExpr.Token.Pos.String())
 		return types.FLOAT
 	}
-	argType := a.analyzeExpression(args[0])
+	argType := a.analyzeNumericArgument(args[0])
 	if argType != nil && argType != types.FLOAT && argType != types.INTEGER {
 		a.addError("function 'Int' expects Float or Integer, got %s at %s",
 			argType.String(), callExpr.Token.Pos.String())
@@ -125,7 +125,7 @@ func (a *Analyzer) analyzeUnsigned32(args []ast.Expression, callExpr *ast.CallEx
 			len(args), callExpr.Token.Pos.String())
 		return types.INTEGER
 	}
-	argType := a.analyzeExpression(args[0])
+	argType := a.analyzeNumericArgument(args[0])
 	if argType != nil && argType != types.INTEGER {
 		a.addError("function 'Unsigned32' expects Integer argument, got %s at %s",
 			argType.String(), callExpr.Token.Pos.String())
@@ -24,7 +24,7 @@ func (a *Analyzer) analyzeSin(args []ast.Expression, callExpr *ast.CallExpressio
 			len(args), callExpr.Token.Pos.String())
 		return types.FLOAT
 	}
-	argType := a.analyzeExpression(args[0])
+	argType := a.analyzeNumericArgument(args[0])
 	if argType != nil {
 		if argType != types.INTEGER && argType != types.FLOAT {
 			a.addError("function 'Sin' expects Integer or Float as argument, got %s at %s",
@@ -42,7 +42,7 @@ func (a *Analyzer) analyzeCos(args []ast.Expression, callExpr *ast.CallExpressio
 			len(args), callExpr.Token.Pos.String())
 		return types.FLOAT
 	}
-	argType := a.analyzeExpression(args[0])
+	argType := a.analyzeNumericArgument(args[0])
 	if argType != nil {
 		if argType != types.INTEGER && argType != types.FLOAT {
 			a.addError("function 'Cos' expects Integer or Float as argument, got %s at %s",
@@ -60,7 +60,7 @@ func (a *Analyzer) analyzeTan(args []ast.Expression, callExpr *ast.CallExpressio
 			len(args), callExpr.Token.Pos.String())
 		return types.FLOAT
 	}
-	argType := a.analyzeExpression(args[0])
+	argType := a.analyzeNumericArgument(args[0])
 	if argType != nil {
 		if argType != types.INTEGER && argType != types.FLOAT {
 			a.addError("function 'Tan' expects Integer or Float as argument, got %s at %s",
@@ -78,7 +78,7 @@ func (a *Analyzer) analyzeCoTan(args []ast.Expression, callExpr *ast.CallExpress
 			len(args), callExpr.Token.Pos.String())
 		return types.FLOAT
 	}
-	argType := a.analyzeExpression(args[0])
+	argType := a.analyzeNumericArgument(args[0])
 	if argType != nil && argType != types.INTEGER && argType != types.FLOAT {
 		a.addError("function 'CoTan' expects Integer or Float as argument, got %s at %s",
 			argType.String(), callExpr.Token.Pos.String())
@@ -94,7 +94,7 @@ func (a *Analyzer) analyzeDegToRad(args []ast.Expression, callExpr *ast.CallExpr
 			len(args), callExpr.Token.Pos.String())
 		return types.FLOAT
 	}
-	argType := a.analyzeExpression(args[0])
+	argType := a.analyzeNumericArgument(args[0])
 	if argType != nil && argType != types.INTEGER && argType != types.FLOAT {
 		a.addError("function 'DegToRad' expects Integer or Float as argument, got %s at %s",
 			argType.String(), callExpr.Token.Pos.String())
@@ -110,7 +110,7 @@ func (a *Analyzer) analyzeRadToDeg(args []ast.Expression, callExpr *ast.CallExpr
 			len(args), callExpr.Token.Pos.String())
 		return types.FLOAT
 	}
-	argType := a.analyzeExpression(args[0])
+	argType := a.analyzeNumericArgument(args[0])
 	if argType != nil && argType != types.INTEGER && argType != types.FLOAT {
 		a.addError("function 'RadToDeg' expects Integer or Float as argument, got %s at %s",
 			argType.String(), callExpr.Token.Pos.String())
@@ -126,7 +126,7 @@ func (a *Analyzer) analyzeArcSin(args []ast.Expression, callExpr *ast.CallExpres
 			len(args), callExpr.Token.Pos.String())
 		return types.FLOAT
 	}
-	argType := a.analyzeExpression(args[0])
+	argType := a.analyzeNumericArgument(args[0])
 	if argType != nil && argType != types.INTEGER && argType != types.FLOAT {
 		a.addError("function 'ArcSin' expects Integer or Float as argument, got %s at %s",
 			argType.String(), callExpr.Token.Pos.String())
@@ -142,7 +142,7 @@ func (a *Analyzer) analyzeArcCos(args []ast.Expression, callExpr *ast.CallExpres
 			len(args), callExpr.Token.Pos.String())
 		return types.FLOAT
 	}
-	argType := a.analyzeExpression(args[0])
+	argType := a.analyzeNumericArgument(args[0])
 	if argType != nil && argType != types.INTEGER && argType != types.FLOAT {
 		a.addError("function 'ArcCos' expects Integer or Float as argument, got %s at %s",
 			argType.String(), callExpr.Token.Pos.String())
@@ -158,7 +158,7 @@ func (a *Analyzer) analyzeArcTan(args []ast.Expression, callExpr *ast.CallExpres
 			len(args), callExpr.Token.Pos.String())
 		return types.FLOAT
 	}
-	argType := a.analyzeExpression(args[0])
+	argType := a.analyzeNumericArgument(args[0])
 	if argType != nil && argType != types.INTEGER && argType != types.FLOAT {
 		a.addError("function 'ArcTan' expects Integer or Float as argument, got %s at %s",
 			argType.String(), callExpr.Token.Pos.String())
@@ -174,8 +174,8 @@ func (a *Analyzer) analyzeArcTan2(args []ast.Expression, callExpr *ast.CallExpre
 			len(args), callExpr.Token.Pos.String())
 		return types.FLOAT
 	}
-	yType := a.analyzeExpression(args[0])
-	xType := a.analyzeExpression(args[1])
+	yType := a.analyzeNumericArgument(args[0])
+	xType := a.analyzeNumericArgument(args[1])
 	if yType != nil && yType != types.INTEGER && yType != types.FLOAT {
 		a.addError("function 'ArcTan2' expects Integer or Float as first argument, got %s at %s",
 			yType.String(), callExpr.Token.Pos.String())
@@ -195,8 +195,8 @@ func (a *Analyzer) analyzeHypot(args []ast.Expression, callExpr *ast.CallExpress
 			len(args), callExpr.Token.Pos.String())
 		return types.FLOAT
 	}
-	xType := a.analyzeExpression(args[0])
-	yType := a.analyzeExpression(args[1])
+	xType := a.analyzeNumericArgument(args[0])
+	yType := a.analyzeNumericArgument(args[1])
 	if xType != nil && xType != types.INTEGER && xType != types.FLOAT {
 		a.addError("function 'Hypot' expects Integer or Float as first argument, got %s at %s",
 			xType.String(), callExpr.Token.Pos.String())
@@ -216,7 +216,7 @@ func (a *Analyzer) analyzeSinh(args []ast.Expression, callExpr *ast.CallExpressi
 			len(args), callExpr.Token.Pos.String())
 		return types.FLOAT
 	}
-	argType := a.analyzeExpression(args[0])
+	argType := a.analyzeNumericArgument(args[0])
 	if argType != nil && argType != types.INTEGER && argType != types.FLOAT {
 		a.addError("function 'Sinh' expects Integer or Float as argument, got %s at %s",
 			argType.String(), callExpr.Token.Pos.String())
@@ -232,7 +232,7 @@ func (a *Analyzer) analyzeCosh(args []ast.Expression, callExpr *ast.CallExpressi
 			len(args), callExpr.Token.Pos.String())
 		return types.FLOAT
 	}
-	argType := a.analyzeExpression(args[0])
+	argType := a.analyzeNumericArgument(args[0])
 	if argType != nil && argType != types.INTEGER && argType != types.FLOAT {
 		a.addError("function 'Cosh' expects Integer or Float as argument, got %s at %s",
 			argType.String(), callExpr.Token.Pos.String())
@@ -248,7 +248,7 @@ func (a *Analyzer) analyzeTanh(args []ast.Expression, callExpr *ast.CallExpressi
 			len(args), callExpr.Token.Pos.String())
 		return types.FLOAT
 	}
-	argType := a.analyzeExpression(args[0])
+	argType := a.analyzeNumericArgument(args[0])
 	if argType != nil && argType != types.INTEGER && argType != types.FLOAT {
 		a.addError("function 'Tanh' expects Integer or Float as argument, got %s at %s",
 			argType.String(), callExpr.Token.Pos.String())
@@ -264,7 +264,7 @@ func (a *Analyzer) analyzeArcSinh(args []ast.Expression, callExpr *ast.CallExpre
 			len(args), callExpr.Token.Pos.String())
 		return types.FLOAT
 	}
-	argType := a.analyzeExpression(args[0])
+	argType := a.analyzeNumericArgument(args[0])
 	if argType != nil && argType != types.INTEGER && argType != types.FLOAT {
 		a.addError("function 'ArcSinh' expects Integer or Float as argument, got %s at %s",
 			argType.String(), callExpr.Token.Pos.String())
@@ -280,7 +280,7 @@ func (a *Analyzer) analyzeArcCosh(args []ast.Expression, callExpr *ast.CallExpre
 			len(args), callExpr.Token.Pos.String())
 		return types.FLOAT
 	}
-	argType := a.analyzeExpression(args[0])
+	argType := a.analyzeNumericArgument(args[0])
 	if argType != nil && argType != types.INTEGER && argType != types.FLOAT {
 		a.addError("function 'ArcCosh' expects Integer or Float as argument, got %s at %s",
 			argType.String(), callExpr.Token.Pos.String())
@@ -296,7 +296,7 @@ func (a *Analyzer) analyzeArcTanh(args []ast.Expression, callExpr *ast.CallExpre
 			len(args), callExpr.Token.Pos.String())
 		return types.FLOAT
 	}
-	argType := a.analyzeExpression(args[0])
+	argType := a.analyzeNumericArgument(args[0])
 	if argType != nil && argType != types.INTEGER && argType != types.FLOAT {
 		a.addError("function 'ArcTanh' expects Integer or Float as argument, got %s at %s",
 			argType.String(), callExpr.Token.Pos.String())
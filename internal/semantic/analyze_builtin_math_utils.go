@@ -1,6 +1,8 @@
 package semantic
 
 import (
+	"unicode/utf8"
+
 	"github.com/cwbudde/go-dws/internal/types"
 	"github.com/cwbudde/go-dws/pkg/ast"
 )
@@ -82,44 +84,47 @@ func (a *Analyzer) analyzeDec(args []ast.Expression, callExpr *ast.CallExpressio
 // analyzeSucc analyzes the Succ built-in function.
 // Succ takes 1 argument: ordinal value and returns the successor.
 func (a *Analyzer) analyzeSucc(args []ast.Expression, callExpr *ast.CallExpression) types.Type {
-	if len(args) != 1 {
-		a.addError("function 'Succ' expects 1 argument, got %d at %s",
-			len(args), callExpr.Token.Pos.String())
-		return types.INTEGER
-	}
-	argType := a.analyzeExpression(args[0])
-	if argType != nil {
-		if argType == types.INTEGER {
-			return types.INTEGER
-		}
-		if enumType, isEnum := argType.(*types.EnumType); isEnum {
-			return enumType
-		}
-		a.addError("function 'Succ' expects Integer or Enum, got %s at %s",
-			argType.String(), callExpr.Token.Pos.String())
-	}
-	return types.INTEGER
+	return a.analyzeOrdinalStep("Succ", args, callExpr)
 }
 
 // analyzePred analyzes the Pred built-in function.
 // Pred takes 1 argument: ordinal value and returns the predecessor.
 func (a *Analyzer) analyzePred(args []ast.Expression, callExpr *ast.CallExpression) types.Type {
+	return a.analyzeOrdinalStep("Pred", args, callExpr)
+}
+
+// analyzeOrdinalStep checks the argument of Succ/Pred. The result has the
+// type of the argument: Integer (subranges widen to their base type), an
+// enum, Boolean, or a single-character String (Char).
+func (a *Analyzer) analyzeOrdinalStep(name string, args []ast.Expression, callExpr *ast.CallExpression) types.Type {
 	if len(args) != 1 {
-		a.addError("function 'Pred' expects 1 argument, got %d at %s",
-			len(args), callExpr.Token.Pos.String())
+		a.addError("function '%s' expects 1 argument, got %d at %s",
+			name, len(args), callExpr.Token.Pos.String())
 		return types.INTEGER
 	}
 	argType := a.analyzeExpression(args[0])
-	if argType != nil {
-		if argType == types.INTEGER {
-			return types.INTEGER
-		}
-		if enumType, isEnum := argType.(*types.EnumType); isEnum {
-			return enumType
+	if argType == nil {
+		return types.INTEGER
+	}
+	underlying := types.GetUnderlyingType(argType)
+	if subrange, ok := underlying.(*types.SubrangeType); ok {
+		return types.GetUnderlyingType(subrange.BaseType)
+	}
+	switch underlying {
+	case types.INTEGER, types.BOOLEAN, types.VARIANT:
+		return underlying
+	case types.STRING:
+		if lit, ok := args[0].(*ast.StringLiteral); ok && utf8.RuneCountInString(lit.Value) != 1 {
+			a.addError("function '%s' expects a single character, got string of length %d at %s",
+				name, utf8.RuneCountInString(lit.Value), callExpr.Token.Pos.String())
 		}
-		a.addError("function 'Pred' expects Integer or Enum, got %s at %s",
-			argType.String(), callExpr.Token.Pos.String())
+		return types.STRING
+	}
+	if enumType, isEnum := underlying.(*types.EnumType); isEnum {
+		return enumType
 	}
+	a.addError("function '%s' expects an ordinal value, got %s at %s",
+		name, argType.String(), callExpr.Token.Pos.String())
 	return types.INTEGER
 }
 
@@ -136,6 +141,65 @@ func (a *Analyzer) analyzeAssigned(args []ast.Expression, callExpr *ast.CallExpr
 	return types.BOOLEAN
 }
 
+// analyzeGetClass analyzes the GetClass built-in function.
+// GetClass takes an object (or class reference) and returns its runtime
+// class. The static result is a metaclass of the argument's declared class,
+// so GetClass(obj).Create is typed like obj; other arguments give class of TObject.
+func (a *Analyzer) analyzeGetClass(args []ast.Expression, callExpr *ast.CallExpression) types.Type {
+	result := types.Type(types.NewClassOfType(a.getClassType("TObject")))
+	if len(args) != 1 {
+		a.addError("function 'GetClass' expects 1 argument, got %d at %s",
+			len(args), callExpr.Token.Pos.String())
+		return result
+	}
+
+	argType := a.analyzeExpression(args[0])
+	if argType == nil {
+		return result
+	}
+	switch t := types.GetUnderlyingType(argType).(type) {
+	case *types.ClassType:
+		return types.NewClassOfType(t)
+	case *types.ClassOfType:
+		return t
+	case *types.NilType, *types.VariantType:
+		return result
+	}
+	a.addError("function 'GetClass' expects an object, got %s at %s",
+		argType.String(), callExpr.Token.Pos.String())
+	return result
+}
+
+// analyzeTypeOf analyzes the TypeOf built-in function.
+// TypeOf takes a value, object or class reference and returns its TRTTITypeInfo.
+func (a *Analyzer) analyzeTypeOf(args []ast.Expression, callExpr *ast.CallExpression) types.Type {
+	if len(args) != 1 {
+		a.addError("function 'TypeOf' expects 1 argument, got %d at %s",
+			len(args), callExpr.Token.Pos.String())
+		return types.RTTI_TYPEINFO
+	}
+	if argType := a.analyzeExpression(args[0]); argType != nil && argType.Equals(types.VOID) {
+		a.addError("function 'TypeOf' expects a value, got %s at %s",
+			argType.String(), callExpr.Token.Pos.String())
+	}
+	return types.RTTI_TYPEINFO
+}
+
+// analyzeTypeName analyzes the TypeName and TypeOfClass built-in functions.
+// Both take a single value and return the name of its type as a String.
+func (a *Analyzer) analyzeTypeName(funcName string, args []ast.Expression, callExpr *ast.CallExpression) types.Type {
+	if len(args) != 1 {
+		a.addError("function '%s' expects 1 argument, got %d at %s",
+			funcName, len(args), callExpr.Token.Pos.String())
+		return types.STRING
+	}
+	if argType := a.analyzeExpression(args[0]); argType != nil && argType.Equals(types.VOID) {
+		a.addError("function '%s' expects a value, got %s at %s",
+			funcName, argType.String(), callExpr.Token.Pos.String())
+	}
+	return types.STRING
+}
+
 // analyzeSwap analyzes the Swap built-in function.
 // Swap takes 2 var arguments and swaps their values.
 //
@@ -167,28 +231,115 @@ func (a *Analyzer) analyzeSwap(args []ast.Expression, callExpr *ast.CallExpressi
 	return nil
 }
 
+// analyzeFreeAndNil analyzes the FreeAndNil built-in procedure.
+// FreeAndNil takes a var argument of class type, sets it to nil and frees the
+// object it referred to.
+func (a *Analyzer) analyzeFreeAndNil(args []ast.Expression, callExpr *ast.CallExpression) types.Type {
+	if len(args) != 1 {
+		a.addError("function 'FreeAndNil' expects 1 argument, got %d at %s",
+			len(args), callExpr.Token.Pos.String())
+		return types.VOID
+	}
+	if !a.isLValue(args[0]) {
+		a.addError("function 'FreeAndNil' argument must be a variable at %s",
+			callExpr.Token.Pos.String())
+	}
+
+	argType := a.analyzeExpression(args[0])
+	if argType == nil {
+		return types.VOID
+	}
+	switch types.GetUnderlyingType(argType).(type) {
+	case *types.ClassType, *types.NilType, *types.VariantType:
+		return types.VOID
+	}
+	a.addError("function 'FreeAndNil' expects an object, got %s at %s",
+		argType.String(), callExpr.Token.Pos.String())
+	return types.VOID
+}
+
+// analyzeSupports analyzes the Supports built-in function.
+// Supports(obj, IIntf) reports whether the object (or the object behind an
+// interface) implements IIntf; Supports(obj, IIntf, intf) also stores the
+// interface in the out variable intf when it does.
+func (a *Analyzer) analyzeSupports(args []ast.Expression, callExpr *ast.CallExpression) types.Type {
+	if len(args) != 2 && len(args) != 3 {
+		a.addError("function 'Supports' expects 2 or 3 arguments, got %d at %s",
+			len(args), callExpr.Token.Pos.String())
+		return types.BOOLEAN
+	}
+
+	if argType := a.analyzeExpression(args[0]); argType != nil {
+		switch types.GetUnderlyingType(argType).(type) {
+		case *types.ClassType, *types.InterfaceType, *types.NilType, *types.VariantType:
+		default:
+			a.addError("function 'Supports' expects an object or interface, got %s at %s",
+				argType.String(), callExpr.Token.Pos.String())
+		}
+	}
+
+	typeName, ok := args[1].(*ast.Identifier)
+	if !ok {
+		a.addError("function 'Supports' expects an interface type as second argument at %s",
+			callExpr.Token.Pos.String())
+		return types.BOOLEAN
+	}
+	intfType := a.getInterfaceType(typeName.Value)
+	if intfType == nil {
+		a.addError("function 'Supports' expects an interface type as second argument, got '%s' at %s",
+			typeName.Value, callExpr.Token.Pos.String())
+		return types.BOOLEAN
+	}
+
+	if len(args) == 3 {
+		if !a.isLValue(args[2]) {
+			a.addError("function 'Supports' out argument must be a variable at %s",
+				callExpr.Token.Pos.String())
+		}
+		if outType := a.analyzeExpression(args[2]); outType != nil && !a.canAssign(intfType, outType) {
+			a.addError("function 'Supports' cannot store %s in a variable of type %s at %s",
+				intfType.Name, outType.String(), callExpr.Token.Pos.String())
+		}
+	}
+	return types.BOOLEAN
+}
+
 // analyzeRandom analyzes the Random built-in function.
-// Random takes no arguments and always returns Float.
+// Random() returns a Float in [0, 1); Random(n: Integer) returns an Integer
+// in [0, n).
 func (a *Analyzer) analyzeRandom(args []ast.Expression, callExpr *ast.CallExpression) types.Type {
-	if len(args) != 0 {
-		a.addError("function 'Random' expects no arguments, got %d at %s",
+	switch len(args) {
+	case 0:
+		return types.FLOAT
+	case 1:
+		argType := a.analyzeExpression(args[0])
+		if argType != nil && argType != types.INTEGER {
+			a.addError("function 'Random' expects Integer argument, got %s at %s",
+				argType.String(), callExpr.Token.Pos.String())
+		}
+		return types.INTEGER
+	default:
+		a.addError("function 'Random' expects 0 or 1 arguments, got %d at %s",
 			len(args), callExpr.Token.Pos.String())
+		return types.FLOAT
 	}
-	return types.FLOAT
 }
 
 // analyzeRandomInt analyzes the RandomInt built-in function.
-// RandomInt takes one Integer argument and returns random Integer in [0, max).
+// RandomInt(max) returns a random Integer in [0, max) and RandomInt(min, max)
+// one in [min, max).
 func (a *Analyzer) analyzeRandomInt(args []ast.Expression, callExpr *ast.CallExpression) types.Type {
-	if len(args) != 1 {
-		a.addError("function 'RandomInt' expects 1 argument, got %d at %s",
+	if len(args) != 1 && len(args) != 2 {
+		a.addError("function 'RandomInt' expects 1 or 2 arguments, got %d at %s",
 			len(args), callExpr.Token.Pos.String())
 		return types.INTEGER
 	}
-	argType := a.analyzeExpression(args[0])
-	if argType != nil && argType != types.INTEGER {
-		a.addError("function 'RandomInt' expects Integer argument, got %s at %s",
-			argType.String(), callExpr.Token.Pos.String())
+	for _, arg := range args {
+		argType := a.analyzeExpression(arg)
+		if argType != nil && argType != types.INTEGER {
+			a.addError("function 'RandomInt' expects Integer argument, got %s at %s",
+				argType.String(), callExpr.Token.Pos.String())
+		}
 	}
 	return types.INTEGER
 }
@@ -236,11 +387,19 @@ func (a *Analyzer) analyzeRandSeed(args []ast.Expression, callExpr *ast.CallExpr
 
 // analyzeRandG analyzes the RandG built-in function.
 // RandG: Float
+// RandG(mean, stdDev: Float): Float
 func (a *Analyzer) analyzeRandG(args []ast.Expression, callExpr *ast.CallExpression) types.Type {
-	if len(args) != 0 {
-		a.addError("function 'RandG' expects no arguments, got %d at %s",
+	if len(args) != 0 && len(args) != 2 {
+		a.addError("function 'RandG' expects 0 or 2 arguments, got %d at %s",
 			len(args), callExpr.Token.Pos.String())
 		return types.FLOAT
 	}
+	for _, arg := range args {
+		argType := a.analyzeExpression(arg)
+		if argType != nil && argType != types.INTEGER && argType != types.FLOAT && argType != types.VARIANT {
+			a.addError("function 'RandG' expects Float argument, got %s at %s",
+				argType.String(), callExpr.Token.Pos.String())
+		}
+	}
 	return types.FLOAT
 }
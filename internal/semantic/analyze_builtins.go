@@ -1,6 +1,7 @@
 package semantic
 
 import (
+	"github.com/cwbudde/go-dws/internal/builtins"
 	"github.com/cwbudde/go-dws/internal/types"
 	"github.com/cwbudde/go-dws/pkg/ast"
 	"github.com/cwbudde/go-dws/pkg/ident"
@@ -29,8 +30,19 @@ func (a *Analyzer) isLValue(expr ast.Expression) bool {
 	}
 }
 
+// isBuiltinRemoved reports whether name is a standard built-in function that
+// the host removed from the analyzer's builtin registry.
+func (a *Analyzer) isBuiltinRemoved(name string) bool {
+	return a.builtinRegistry != builtins.DefaultRegistry &&
+		builtins.DefaultRegistry.Has(name) && !a.builtinRegistry.Has(name)
+}
+
 // isBuiltinFunction checks if a name refers to a built-in function.
 func (a *Analyzer) isBuiltinFunction(name string) bool {
+	if a.isBuiltinRemoved(name) {
+		return false
+	}
+
 	// Normalize to lowercase for case-insensitive matching
 	lowerName := ident.Normalize(name)
 
@@ -60,7 +72,7 @@ func (a *Analyzer) isBuiltinFunction(name string) bool {
 		"vartostr", "varisnull", "varisempty", "varisclear", "varisarray", "varisstr", "varisnumeric", "vartype", "varclear",
 		"include", "exclude", "map", "filter", "reduce", "foreach",
 		"maxint", "minint",
-		"now", "date", "time", "utcdatetime", "encodedate", "encodetime",
+		"now", "date", "time", "utcdatetime", "unixtime", "unixtimemsec", "encodedate", "encodetime",
 		"encodedatetime", "yearof", "monthof", "dayof", "hourof", "minuteof",
 		"secondof", "millisecondof", "dayofweek", "dayofyear", "weekofyear",
 		"datetimetostr", "datetostr", "timetostr", "formatdatetime",
@@ -71,7 +83,7 @@ func (a *Analyzer) isBuiltinFunction(name string) bool {
 		"startofmonth", "endofmonth", "startofyear", "endofyear", "istoday",
 		"isyesterday", "istomorrow", "issameday", "comparedate", "comparetime",
 		"comparedatetime", "parsejson", "tojson", "tojsonformatted",
-		"jsonhasfield", "jsonkeys", "jsonvalues", "jsonlength",
+		"jsonhasfield", "jsonkeys", "keys", "jsonvalues", "jsonlength",
 		"getstacktrace", "getcallstack":
 		return true
 	default:
@@ -168,6 +168,13 @@ func (a *Analyzer) analyzeNewExpression(expr *ast.NewExpression) types.Type {
 		constructorOverloads = allOverloads
 	}
 
+	if expr.HasNamedArguments() {
+		expr = a.bindNamedConstructorArguments(expr, constructorOverloads, constructorName)
+		if expr == nil {
+			return classType
+		}
+	}
+
 	if len(constructorOverloads) == 0 {
 		// No explicit constructor - use implicit default constructor (no arguments allowed)
 		if len(expr.Arguments) > 0 {
@@ -315,6 +322,20 @@ func constructorCallPosition(expr *ast.CallExpression) token.Position {
 
 // analyzeMemberAccessExpression analyzes member access on classes, records, interfaces, and helpers.
 func (a *Analyzer) analyzeMemberAccessExpression(expr *ast.MemberAccessExpression) types.Type {
+	if unitName, isUnit := a.unitNamespace(expr.Object); isUnit {
+		sym := a.resolveUnitMember(unitName, expr.Member)
+		if sym == nil {
+			return nil
+		}
+		// A unit function named without arguments is called implicitly.
+		if funcType, ok := sym.Type.(*types.FunctionType); ok {
+			if !acceptsArgumentCount(funcType, 0) {
+				return types.NewFunctionPointerType(funcType.Parameters, funcType.ReturnType)
+			}
+			return funcType.ReturnType
+		}
+		return sym.Type
+	}
 	if identExpr, ok := expr.Object.(*ast.Identifier); ok {
 		switch ident.Normalize(identExpr.Value) {
 		case "system", "internal":
@@ -346,7 +367,7 @@ func (a *Analyzer) analyzeMemberAccessExpression(expr *ast.MemberAccessExpressio
 		}
 	}
 
-	objectType := a.analyzeExpression(expr.Object)
+	objectType := a.analyzeMemberReceiver(expr.Object, expr.Member)
 	if objectType == nil {
 		return nil
 	}
@@ -367,6 +388,15 @@ func (a *Analyzer) analyzeMemberAccessExpression(expr *ast.MemberAccessExpressio
 		return types.JSON_VARIANT
 	}
 
+	// TRTTITypeInfo (the result of TypeOf) exposes read-only properties.
+	if types.IsRTTITypeInfo(objectTypeResolved) {
+		if memberType := types.RTTITypeInfoMemberType(memberName); memberType != nil {
+			return memberType
+		}
+		a.addStructuredError(NewAccessibleMemberError(expr.Member.Token.Pos, expr.Member.Value, objectType.String()))
+		return nil
+	}
+
 	// Handle record type (static methods or instance fields/methods)
 	if recordType, ok := objectTypeResolved.(*types.RecordType); ok {
 		if recordType.HasClassMethod(memberName) {
@@ -387,6 +417,10 @@ func (a *Analyzer) analyzeMemberAccessExpression(expr *ast.MemberAccessExpressio
 
 		// Interface properties resolve to their declared type.
 		if propInfo := ifaceType.GetProperty(memberName); propInfo != nil {
+			if propInfo.ReadKind == types.PropAccessNone && !(propInfo.IsIndexed && a.isIndexAssignTargetMember(expr)) {
+				a.addStructuredError(NewWriteOnlyPropertyError(expr.Member.Token.Pos, expr.Member.Value))
+				return nil
+			}
 			return propInfo.Type
 		}
 
@@ -569,7 +603,7 @@ func (a *Analyzer) analyzeMemberAccessExpression(expr *ast.MemberAccessExpressio
 		if fieldOwner != nil {
 			visibility, hasVisibility := fieldOwner.FieldVisibility[memberName]
 			if hasVisibility && !a.checkVisibility(fieldOwner, visibility, memberName, "field") {
-				a.addStructuredError(NewVisibilityScopeError(expr.Member.Token.Pos, expr.Member.Value))
+				a.addStructuredError(NewVisibilityScopeError(expr.Member.Token.Pos, expr.Member.Value, visibility))
 				return nil
 			}
 			a.recordClassFieldUsage(fieldOwner, memberName)
@@ -578,13 +612,17 @@ func (a *Analyzer) analyzeMemberAccessExpression(expr *ast.MemberAccessExpressio
 	}
 
 	// Look up class variable (including inherited class vars)
+	if classType == a.currentClass && a.pendingClassVars[memberName] {
+		a.addStructuredError(NewClassVarInitCycleError(expr.Member.Token.Pos, expr.Member.Value))
+		return nil
+	}
 	classVarType, foundClassVar := classType.GetClassVar(memberName)
 	if foundClassVar {
 		classVarOwner := a.getClassVarOwner(classType, memberName)
 		if classVarOwner != nil {
 			visibility, hasVisibility := classVarOwner.ClassVarVisibility[memberName]
 			if hasVisibility && !a.checkVisibility(classVarOwner, visibility, memberName, "class variable") {
-				a.addStructuredError(NewVisibilityScopeError(expr.Member.Token.Pos, expr.Member.Value))
+				a.addStructuredError(NewVisibilityScopeError(expr.Member.Token.Pos, expr.Member.Value, visibility))
 				return nil
 			}
 		}
@@ -594,7 +632,7 @@ func (a *Analyzer) analyzeMemberAccessExpression(expr *ast.MemberAccessExpressio
 	// Look up property (including inherited properties)
 	propInfo, propFound := classType.GetProperty(memberName)
 	if propFound {
-		if propInfo.ReadKind == types.PropAccessNone {
+		if propInfo.ReadKind == types.PropAccessNone && !(propInfo.IsIndexed && a.isIndexAssignTargetMember(expr)) {
 			a.addStructuredError(NewWriteOnlyPropertyError(expr.Member.Token.Pos, expr.Member.Value))
 			return nil
 		}
@@ -638,7 +676,7 @@ func (a *Analyzer) analyzeMemberAccessExpression(expr *ast.MemberAccessExpressio
 		// Check if parameterless (auto-invoked when accessed without parentheses)
 		hasParameterless := false
 		for _, ctor := range constructorOverloads {
-			if len(ctor.Signature.Parameters) == 0 {
+			if requiredParamCount(ctor.Signature) == 0 {
 				hasParameterless = true
 				break
 			}
@@ -689,13 +727,13 @@ func (a *Analyzer) analyzeMemberAccessExpression(expr *ast.MemberAccessExpressio
 		if methodOwner != nil {
 			visibility, hasVisibility := methodOwner.MethodVisibility[ident.Normalize(memberName)]
 			if hasVisibility && !a.checkVisibility(methodOwner, visibility, memberName, "method") {
-				a.addStructuredError(NewVisibilityScopeError(expr.Member.Token.Pos, expr.Member.Value))
+				a.addStructuredError(NewVisibilityScopeError(expr.Member.Token.Pos, expr.Member.Value, visibility))
 				return nil
 			}
 			a.recordClassMethodUsage(methodOwner, memberName)
 		}
 		// Parameterless methods are auto-invoked when accessed without parentheses
-		if len(methodType.Parameters) == 0 {
+		if requiredParamCount(methodType) == 0 {
 			if methodType.ReturnType == nil {
 				return types.VOID
 			}
@@ -745,7 +783,7 @@ func (a *Analyzer) analyzeMemberAccessExpression(expr *ast.MemberAccessExpressio
 // procedure/function distinction is normalised so a procedure method is a
 // procedure pointer, not a function-returning-void.
 func (a *Analyzer) analyzeMethodReferenceInPointerContext(expr *ast.MemberAccessExpression) (types.Type, bool) {
-	objectType := a.analyzeExpression(expr.Object)
+	objectType := a.analyzeMemberReceiver(expr.Object, expr.Member)
 	if objectType == nil {
 		return nil, false
 	}
@@ -805,6 +843,11 @@ func (a *Analyzer) analyzeRecordStaticMethodCallFromNew(expr *ast.NewExpression,
 	methodName := "Create"
 	lowerMethodName := ident.Normalize(methodName)
 
+	if expr.HasNamedArguments() {
+		a.addError("named arguments are only supported in calls to declared functions and methods at %s", expr.Token.Pos.String())
+		return nil
+	}
+
 	overloads := recordType.GetClassMethodOverloads(lowerMethodName)
 	if len(overloads) == 0 {
 		a.addError("record type '%s' has no class method '%s' at %s",
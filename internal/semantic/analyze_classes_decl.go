@@ -480,6 +480,17 @@ func (a *Analyzer) analyzeClassDecl(decl *ast.ClassDecl) {
 		}
 	}
 
+	// Class var initializers run once, in declaration order: while one is
+	// analyzed, it and every class var declared after it are still pending.
+	previousPending := a.pendingClassVars
+	a.pendingClassVars = make(map[string]bool)
+	defer func() { a.pendingClassVars = previousPending }()
+	for _, field := range decl.Fields {
+		if field.IsClassVar {
+			a.pendingClassVars[ident.Normalize(field.Name.Value)] = true
+		}
+	}
+
 	// Analyze fields (instance and class variables).
 	fieldNames := make(map[string]bool)
 	classVarNames := make(map[string]bool)
@@ -525,13 +536,14 @@ func (a *Analyzer) analyzeClassDecl(decl *ast.ClassDecl) {
 
 			if field.InitValue != nil && field.Type != nil {
 				initType := a.analyzeExpression(field.InitValue)
-				if initType != nil && fieldType != nil && !types.IsAssignableFrom(fieldType, initType) {
+				if initType != nil && fieldType != nil && !a.canAssign(initType, fieldType) {
 					a.addError("type mismatch for class var '%s' at %s", originalFieldName, field.Token.Pos.String())
 				}
 			}
 
 			classType.ClassVars[normalizedFieldName] = fieldType
 			classType.ClassVarVisibility[normalizedFieldName] = int(field.Visibility)
+			delete(a.pendingClassVars, normalizedFieldName)
 		} else {
 			// Handle instance fields.
 			fieldExists := false
@@ -902,6 +914,7 @@ func (a *Analyzer) analyzeMethodDecl(method *ast.FunctionDecl, classType *types.
 		varParams = append(varParams, param.ByRef)
 		constParams = append(constParams, param.IsConst)
 	}
+	a.checkParameterDefaults(method.Parameters, paramTypes)
 
 	// Auto-detect constructors and validate signatures.
 	wasExplicitConstructor := method.IsConstructor
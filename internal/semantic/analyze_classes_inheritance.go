@@ -19,7 +19,7 @@ func (a *Analyzer) inheritParentConstructors(childClass *types.ClassType, parent
 		for _, parentCtor := range overloads {
 			// Private constructors are not inherited
 			visibility := ast.Visibility(parentCtor.Visibility)
-			if visibility == ast.VisibilityPrivate {
+			if visibility.IsPrivate() {
 				continue
 			}
 
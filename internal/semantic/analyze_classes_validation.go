@@ -169,8 +169,9 @@ func (a *Analyzer) validateVirtualOverride(method *ast.FunctionDecl, classType *
 // Returns true if accessible, false otherwise.
 //
 // Visibility rules:
-//   - Private: only accessible from the same class
-//   - Protected: accessible from the same class and all descendants
+//   - Private: only accessible from the same class (including its helpers)
+//   - Protected: accessible from the same class and all descendants (including their helpers)
+//   - Strict private / strict protected: as above, but never from a helper
 //   - Public: accessible from anywhere
 //
 // Parameters:
@@ -179,8 +180,10 @@ func (a *Analyzer) validateVirtualOverride(method *ast.FunctionDecl, classType *
 //   - memberName: the name of the member (for error messages)
 //   - memberType: "field" or "method" (for error messages)
 func (a *Analyzer) checkVisibility(memberClass *types.ClassType, visibility int, _, _ string) bool {
+	vis := ast.Visibility(visibility)
+
 	// Public is always accessible
-	if visibility == int(ast.VisibilityPublic) {
+	if vis == ast.VisibilityPublic {
 		return true
 	}
 
@@ -189,13 +192,19 @@ func (a *Analyzer) checkVisibility(memberClass *types.ClassType, visibility int,
 		return false
 	}
 
+	// Strict members are only visible to the class's own methods: helper
+	// methods analyze with the helped class as currentClass, so exclude them.
+	if (vis == ast.VisibilityStrictPrivate || vis == ast.VisibilityStrictProtected) && a.currentHelperType != nil {
+		return false
+	}
+
 	// Private members are only accessible from the same class
-	if visibility == int(ast.VisibilityPrivate) {
+	if vis.IsPrivate() {
 		return a.currentClass.Name == memberClass.Name
 	}
 
 	// Protected members are accessible from the same class and descendants
-	if visibility == int(ast.VisibilityProtected) {
+	if vis.IsProtected() {
 		// Same class?
 		if a.currentClass.Name == memberClass.Name {
 			return true
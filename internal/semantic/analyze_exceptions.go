@@ -3,6 +3,7 @@ package semantic
 import (
 	"github.com/cwbudde/go-dws/internal/types"
 	"github.com/cwbudde/go-dws/pkg/ast"
+	"github.com/cwbudde/go-dws/pkg/ident"
 )
 
 // ============================================================================
@@ -31,7 +32,9 @@ func (a *Analyzer) analyzeRaiseStatement(stmt *ast.RaiseStatement) {
 
 	// Validate that the expression evaluates to an Exception type
 	if !a.isExceptionType(excType) {
-		a.addError("raise statement requires Exception type, got %s", excType.String())
+		a.addError("raise statement requires Exception type, got %s at %s",
+			excType.String(), stmt.Exception.Pos().String())
+		return
 	}
 }
 
@@ -49,6 +52,7 @@ func (a *Analyzer) analyzeTryStatement(stmt *ast.TryStatement) {
 	// Analyze except clause if present
 	if stmt.ExceptClause != nil {
 		a.analyzeExceptClause(stmt.ExceptClause)
+		a.checkShadowedHandlers(stmt.ExceptClause)
 	}
 
 	// Analyze finally clause if present
@@ -96,13 +100,15 @@ func (a *Analyzer) analyzeExceptionHandler(handler *ast.ExceptionHandler) {
 		var err error
 		excType, err = a.resolveType(getTypeExpressionName(handler.ExceptionType))
 		if err != nil {
-			a.addError("unknown exception type '%s'", getTypeExpressionName(handler.ExceptionType))
+			a.addError("unknown exception type '%s' at %s",
+				getTypeExpressionName(handler.ExceptionType), handler.ExceptionType.Pos().String())
 			return
 		}
 
 		// Validate that the type is Exception-compatible
 		if !a.isExceptionType(excType) {
-			a.addError("exception handler type must be Exception or derived class, got %s", excType.String())
+			a.addError("exception handler type must be Exception or derived class, got %s at %s",
+				excType.String(), handler.ExceptionType.Pos().String())
 			return
 		}
 	} else {
@@ -150,6 +156,44 @@ func (a *Analyzer) analyzeFinallyClause(clause *ast.FinallyClause) {
 	}
 }
 
+// checkShadowedHandlers warns about handlers placed after a handler for one of
+// their ancestor classes. Handlers are matched in declared order, so the
+// ancestor's handler always wins and the later one can never run. Repeated
+// handlers for the same class are reported as duplicates elsewhere, and which
+// exceptions the try block raises is not considered: any call may raise
+// anything.
+func (a *Analyzer) checkShadowedHandlers(clause *ast.ExceptClause) {
+	var earlier []*types.ClassType
+	for _, handler := range clause.Handlers {
+		handlerClass := a.handlerClass(handler)
+		if handlerClass == nil {
+			continue
+		}
+		for _, ancestor := range earlier {
+			if a.isDescendantOf(handlerClass, ancestor) {
+				pos := handler.ExceptionType.Pos()
+				a.addWarning("Exception handler for \"%s\" can never be reached, \"%s\" is handled first [line: %d, column: %d]",
+					handlerClass.Name, ancestor.Name, pos.Line, pos.Column)
+				break
+			}
+		}
+		earlier = append(earlier, handlerClass)
+	}
+}
+
+// handlerClass returns the exception class a typed handler catches, or nil
+// for bare handlers and handlers whose type is invalid (already reported).
+func (a *Analyzer) handlerClass(handler *ast.ExceptionHandler) *types.ClassType {
+	if handler.ExceptionType == nil {
+		return nil
+	}
+	handlerType, err := a.resolveType(getTypeExpressionName(handler.ExceptionType))
+	if err != nil || !a.isExceptionType(handlerType) {
+		return nil
+	}
+	return handlerType.(*types.ClassType)
+}
+
 // isExceptionType checks if a type is Exception or derived from Exception
 func (a *Analyzer) isExceptionType(t types.Type) bool {
 	classType, ok := t.(*types.ClassType)
@@ -159,7 +203,7 @@ func (a *Analyzer) isExceptionType(t types.Type) bool {
 
 	// Check if this is Exception or inherits from Exception
 	for classType != nil {
-		if classType.Name == "Exception" {
+		if ident.Equal(classType.Name, "Exception") {
 			return true
 		}
 		classType = classType.Parent
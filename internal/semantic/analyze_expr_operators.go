@@ -155,7 +155,7 @@ func (a *Analyzer) analyzeIdentifier(identifier *ast.Identifier) types.Type {
 						lowerFieldName := ident.Normalize(identifier.Value)
 						visibility, hasVisibility := fieldOwner.FieldVisibility[lowerFieldName]
 						if hasVisibility && !a.checkVisibility(fieldOwner, visibility, identifier.Value, "field") {
-							a.addStructuredError(NewVisibilityScopeError(identifier.Token.Pos, identifier.Value))
+							a.addStructuredError(NewVisibilityScopeError(identifier.Token.Pos, identifier.Value, visibility))
 							return nil
 						}
 						a.recordClassFieldUsage(fieldOwner, identifier.Value)
@@ -187,7 +187,7 @@ func (a *Analyzer) analyzeIdentifier(identifier *ast.Identifier) types.Type {
 					if methodOwner != nil {
 						visibility, hasVisibility := methodOwner.MethodVisibility[identifier.Value]
 						if hasVisibility && !a.checkVisibility(methodOwner, visibility, identifier.Value, "method") {
-							a.addStructuredError(NewVisibilityScopeError(identifier.Token.Pos, identifier.Value))
+							a.addStructuredError(NewVisibilityScopeError(identifier.Token.Pos, identifier.Value, visibility))
 							return nil
 						}
 						a.recordClassMethodUsage(methodOwner, identifier.Value)
@@ -216,6 +216,15 @@ func (a *Analyzer) analyzeIdentifier(identifier *ast.Identifier) types.Type {
 			if constType := a.findClassConstantWithVisibility(a.currentClass, identifier.Value, identifier.Token.Pos.String()); constType != nil {
 				return constType
 			}
+
+			// Class var initializers may read the class vars declared before them.
+			if a.pendingClassVars[ident.Normalize(identifier.Value)] {
+				a.addStructuredError(NewClassVarInitCycleError(identifier.Token.Pos, identifier.Value))
+				return nil
+			}
+			if classVarType, found := a.currentClass.GetClassVar(identifier.Value); found {
+				return classVarType
+			}
 		}
 
 		// Check if this is a built-in function used without parentheses
@@ -236,6 +245,12 @@ func (a *Analyzer) analyzeIdentifier(identifier *ast.Identifier) types.Type {
 				}
 				return funcPtrType
 			}
+			// Functions callable without arguments (Now, Date, Random, ...) are
+			// invoked implicitly and yield their return type
+			if sig, found := a.builtinRegistry.GetSignature(identifier.Value); found &&
+				sig.MinArgs == 0 && sig.ReturnType != nil && sig.ReturnType != types.VOID {
+				return sig.ReturnType
+			}
 			// Return Void type for built-in procedures (or appropriate type for functions)
 			// For simplicity, we'll return VOID type which means "any" - the interpreter will handle it
 			return types.VOID
@@ -254,6 +269,9 @@ func (a *Analyzer) analyzeIdentifier(identifier *ast.Identifier) types.Type {
 		case "system", "internal":
 			a.addError(`Dot "." expected at %s`, identifier.End().String())
 			return nil
+		case "result":
+			a.addStructuredError(NewResultOutsideFunctionError(identifier.Token.Pos))
+			return nil
 		}
 
 		a.addStructuredError(NewUnknownNameError(identifier.Token.Pos, identifier.Value))
@@ -377,6 +395,10 @@ func (a *Analyzer) analyzeBinaryExpression(expr *ast.BinaryExpression) types.Typ
 			// Errors already reported
 			return nil
 		}
+		// Subrange operands take part in arithmetic and comparisons as
+		// values of their base ordinal type.
+		leftType = ordinalBaseType(leftType)
+		rightType = ordinalBaseType(rightType)
 	}
 
 	if sig, ok := a.resolveBinaryOperator(operator, leftType, rightType); ok {
@@ -471,10 +493,12 @@ func (a *Analyzer) analyzeBinaryExpression(expr *ast.BinaryExpression) types.Typ
 
 		// Special case: + can also concatenate strings
 		if operator == "+" && (leftType.Equals(types.STRING) || rightType.Equals(types.STRING)) {
-			// Allow Variant in string concatenation
+			// Allow Variant in string concatenation, and TypeOf results,
+			// which concatenate as their type name
 			if !leftIsVariant && !rightIsVariant {
 				// String concatenation
-				if !leftType.Equals(types.STRING) || !rightType.Equals(types.STRING) {
+				if (!leftType.Equals(types.STRING) && !types.IsRTTITypeInfo(leftType)) ||
+					(!rightType.Equals(types.STRING) && !types.IsRTTITypeInfo(rightType)) {
 					a.addOperandMismatchError(expr.Token.Pos, leftType, rightType)
 					return nil
 				}
@@ -560,6 +584,18 @@ func (a *Analyzer) analyzeBinaryExpression(expr *ast.BinaryExpression) types.Typ
 		// DWScript does not define proper subset/superset (< and >) for sets.
 		leftSetType, leftIsSetCmp := types.GetUnderlyingType(leftType).(*types.SetType)
 		rightSetType, rightIsSetCmp := types.GetUnderlyingType(rightType).(*types.SetType)
+
+		// As for set arithmetic, a bracket literal compared with a set takes
+		// the set's type (e.g. `s = [-3]` for a set over a subrange).
+		if leftIsSetCmp && !rightIsSetCmp && isBracketLiteral(expr.Right) {
+			if st, ok := a.analyzeExpressionWithExpectedType(expr.Right, leftSetType).(*types.SetType); ok {
+				rightSetType, rightIsSetCmp, rightType = st, true, st
+			}
+		} else if rightIsSetCmp && !leftIsSetCmp && isBracketLiteral(expr.Left) {
+			if st, ok := a.analyzeExpressionWithExpectedType(expr.Left, rightSetType).(*types.SetType); ok {
+				leftSetType, leftIsSetCmp, leftType = st, true, st
+			}
+		}
 		if leftIsSetCmp || rightIsSetCmp {
 			if !leftIsSetCmp || !rightIsSetCmp {
 				a.addOperandMismatchError(expr.Token.Pos, leftType, rightType)
@@ -593,8 +629,11 @@ func (a *Analyzer) analyzeBinaryExpression(expr *ast.BinaryExpression) types.Typ
 						operator, expr.Token.Pos.String())
 					return nil
 				}
-				// Types must be compatible
-				if !leftType.Equals(rightType) && !a.canAssign(leftType, rightType) && !a.canAssign(rightType, leftType) {
+				// Types must be compatible; TypeOf results also compare with
+				// type names
+				rttiWithName := (types.IsRTTITypeInfo(leftType) && rightType.Equals(types.STRING)) ||
+					(leftType.Equals(types.STRING) && types.IsRTTITypeInfo(rightType))
+				if !rttiWithName && !leftType.Equals(rightType) && !a.canAssign(leftType, rightType) && !a.canAssign(rightType, leftType) {
 					a.addError("cannot compare %s with %s at %s",
 						leftType.String(), rightType.String(), expr.Token.Pos.String())
 					return nil
@@ -695,10 +734,7 @@ func (a *Analyzer) analyzeBinaryExpression(expr *ast.BinaryExpression) types.Typ
 			}
 
 			// Element types must match (resolve underlying types for comparison)
-			leftResolved := types.GetUnderlyingType(leftType)
-			rightResolved := types.GetUnderlyingType(rightSetType.ElementType)
-
-			if !leftResolved.Equals(rightResolved) {
+			if !setElementsCompatible(leftType, rightSetType.ElementType) {
 				a.addError("type mismatch in 'in' operator: %s is not compatible with set of %s at %s",
 					leftType.String(), rightSetType.ElementType.String(), expr.Token.Pos.String())
 				return nil
@@ -760,6 +796,7 @@ func (a *Analyzer) analyzeUnaryExpression(expr *ast.UnaryExpression) types.Type
 		// Error already reported
 		return nil
 	}
+	operandType = ordinalBaseType(operandType)
 
 	operator := expr.Operator
 
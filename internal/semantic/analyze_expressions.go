@@ -33,13 +33,13 @@ func (a *Analyzer) analyzeExpression(expr ast.Expression) types.Type {
 	case *ast.NilLiteral:
 		return types.NIL
 	case *ast.Identifier:
-		return a.analyzeIdentifier(e)
+		return a.foldExpression(e, a.analyzeIdentifier(e))
 	case *ast.BinaryExpression:
-		return a.analyzeBinaryExpression(e)
+		return a.foldExpression(e, a.analyzeBinaryExpression(e))
 	case *ast.UnaryExpression:
-		return a.analyzeUnaryExpression(e)
+		return a.foldExpression(e, a.analyzeUnaryExpression(e))
 	case *ast.GroupedExpression:
-		return a.analyzeExpression(e.Expression)
+		return a.foldExpression(e, a.analyzeExpression(e.Expression))
 	case *ast.CallExpression:
 		return a.analyzeCallExpression(e)
 	case *ast.NewExpression:
@@ -145,7 +145,7 @@ func (a *Analyzer) analyzeExpressionWithExpectedType(expr ast.Expression, expect
 					convertible = true
 					for _, elem := range e.Elements {
 						switch elem.(type) {
-						case *ast.Identifier, *ast.RangeExpression, *ast.IntegerLiteral:
+						case *ast.Identifier, *ast.RangeExpression, *ast.IntegerLiteral, *ast.UnaryExpression:
 						case *ast.CharLiteral, *ast.StringLiteral, *ast.BooleanLiteral:
 						// A qualified enum member (e.g. TEnum2.one) is a valid set
 						// element for a `set of <scoped enum>`.
@@ -224,6 +224,9 @@ func (a *Analyzer) analyzeExpressionWithExpectedType(expr ast.Expression, expect
 	case *ast.CallExpression:
 		// Pass expected type for overload resolution
 		return a.analyzeCallExpressionWithContext(e, expectedType)
+	case *ast.AddressOfExpression:
+		// The expected function pointer type selects the overload
+		return a.analyzeAddressOfExpressionWithContext(e, expectedType)
 	case *ast.Identifier:
 		// In contexts like `x := GetValue;`, DWScript auto-invokes a
 		// parameterless function when the expected type matches its return type.
@@ -255,7 +258,7 @@ func (a *Analyzer) analyzeExpressionWithExpectedType(expr ast.Expression, expect
 				return resultType
 			}
 		}
-		return a.analyzeIdentifier(e)
+		return a.foldExpression(e, a.analyzeIdentifier(e))
 	default:
 		return a.analyzeExpression(expr)
 	}
@@ -405,6 +408,17 @@ func (a *Analyzer) analyzeAsExpression(expr *ast.AsExpression) types.Type {
 		return targetType
 	}
 
+	// Interface casts query the underlying object at runtime, so the source
+	// and target types need not be related: `intf as IOther` succeeds when
+	// the object implements IOther, and `intf as TClass` when it is a TClass.
+	if _, isInterfaceSource := leftUnderlying.(*types.InterfaceType); isInterfaceSource {
+		a.semanticInfo.SetType(expr, &ast.TypeAnnotation{
+			Token: expr.Token,
+			Name:  targetType.String(),
+		})
+		return targetType
+	}
+
 	if !isClass {
 		a.addError("'as' operator requires class instance, got %s at %s",
 			leftType.String(), expr.Token.Pos.String())
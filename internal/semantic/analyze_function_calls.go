@@ -12,6 +12,12 @@ func (a *Analyzer) argumentMatchesParameter(argType, paramType types.Type, stric
 	if strict {
 		return types.IsIdentical(argType, paramType)
 	}
+	if isArrayOfConstType(paramType) {
+		// An open "array of const" parameter accepts any array value.
+		if _, isArr := types.GetUnderlyingType(argType).(*types.ArrayType); isArr {
+			return true
+		}
+	}
 	return a.canAssign(argType, paramType)
 }
 
@@ -30,6 +36,10 @@ func (a *Analyzer) analyzeCallExpressionWithContext(expr *ast.CallExpression, ex
 }
 
 func (a *Analyzer) analyzeCallExpression(expr *ast.CallExpression) types.Type {
+	if expr.HasNamedArguments() {
+		return a.analyzeNamedArgumentCall(expr)
+	}
+
 	// Handle member access expressions (method calls like obj.Method())
 	if memberAccess, ok := expr.Function.(*ast.MemberAccessExpression); ok {
 		// JSON namespace calls (JSON.Parse/Stringify/...) must be recognized before
@@ -47,7 +57,16 @@ func (a *Analyzer) analyzeCallExpression(expr *ast.CallExpression) types.Type {
 			return nil
 		}
 
-		objectType := a.analyzeExpression(memberAccess.Object)
+		// Unit function call: UnitName.Function(args)
+		if unitName, isUnit := a.unitNamespace(memberAccess.Object); isUnit {
+			sym := a.resolveUnitMember(unitName, memberAccess.Member)
+			if sym == nil {
+				return nil
+			}
+			return a.analyzeMethodCallArguments(expr, sym.Type)
+		}
+
+		objectType := a.analyzeMemberReceiver(memberAccess.Object, memberAccess.Member)
 		if objectType == nil {
 			return nil
 		}
@@ -77,41 +96,7 @@ func (a *Analyzer) analyzeCallExpression(expr *ast.CallExpression) types.Type {
 		if methodType == nil {
 			return nil
 		}
-
-		funcType, ok := methodType.(*types.FunctionType)
-		if !ok {
-			a.addError("cannot call non-function type %s at %s",
-				methodType.String(), expr.Token.Pos.String())
-			return nil
-		}
-
-		if len(expr.Arguments) != len(funcType.Parameters) {
-			a.addError("method call expects %d argument(s), got %d at %s",
-				len(funcType.Parameters), len(expr.Arguments), expr.Token.Pos.String())
-		}
-
-		// Validate argument types
-		for i, arg := range expr.Arguments {
-			if i >= len(funcType.Parameters) {
-				break
-			}
-
-			isVar := len(funcType.VarParams) > i && funcType.VarParams[i]
-			if isVar && !a.isLValue(arg) {
-				a.addError("var parameter %d requires a variable (identifier, array element, or field), got %s at %s",
-					i+1, arg.String(), arg.Pos().String())
-			}
-
-			paramType := funcType.Parameters[i]
-			argType := a.analyzeArgumentForParameter(arg, paramType, i < len(funcType.StrictParams) && funcType.StrictParams[i])
-			if argType != nil && !a.argumentMatchesParameter(argType, paramType, i < len(funcType.StrictParams) && funcType.StrictParams[i]) {
-				a.addError("argument %d has type %s, expected %s at %s",
-					i+1, argType.String(), paramType.String(),
-					expr.Token.Pos.String())
-			}
-		}
-
-		return funcType.ReturnType
+		return a.analyzeMethodCallArguments(expr, methodType)
 	}
 
 	// Handle regular function calls (identifier-based)
@@ -201,13 +186,13 @@ func (a *Analyzer) analyzeCallExpression(expr *ast.CallExpression) types.Type {
 					// visibility governs the check.
 					visibility, hasVisibility := selectedMethod.Visibility, true
 					if hasVisibility && !a.checkVisibility(methodOwner, visibility, funcIdent.Value, "method") {
-						a.addStructuredError(NewVisibilityScopeError(funcIdent.Token.Pos, funcIdent.Value))
+						a.addStructuredError(NewVisibilityScopeError(funcIdent.Token.Pos, funcIdent.Value, visibility))
 						return nil
 					}
 					a.recordClassMethodUsage(methodOwner, funcIdent.Value)
 				}
 
-				if len(expr.Arguments) != len(methodType.Parameters) {
+				if !acceptsArgumentCount(methodType, len(expr.Arguments)) {
 					a.addError("method '%s' expects %d argument(s), got %d at %s",
 						funcIdent.Value, len(methodType.Parameters), len(expr.Arguments), expr.Token.Pos.String())
 				}
@@ -325,14 +310,14 @@ func (a *Analyzer) analyzeCallExpression(expr *ast.CallExpression) types.Type {
 					len(expr.Arguments), expr.Token.Pos.String())
 				return types.VOID
 			}
-			condType := a.analyzeExpression(expr.Arguments[0])
-			if condType != nil && condType != types.BOOLEAN {
+			condType := a.analyzeExpressionWithExpectedType(expr.Arguments[0], types.BOOLEAN)
+			if condType != nil && types.GetUnderlyingType(condType) != types.BOOLEAN && !builtinArgIsVariant(condType) {
 				a.addError("function 'Assert' first argument must be Boolean, got %s at %s",
 					condType.String(), expr.Token.Pos.String())
 			}
 			if len(expr.Arguments) == 2 {
-				msgType := a.analyzeExpression(expr.Arguments[1])
-				if msgType != nil && msgType != types.STRING {
+				msgType := a.analyzeExpressionWithExpectedType(expr.Arguments[1], types.STRING)
+				if msgType != nil && types.GetUnderlyingType(msgType) != types.STRING && !builtinArgIsVariant(msgType) {
 					a.addError("function 'Assert' second argument must be String, got %s at %s",
 						msgType.String(), expr.Token.Pos.String())
 				}
@@ -489,12 +474,12 @@ func (a *Analyzer) analyzeCallExpression(expr *ast.CallExpression) types.Type {
 				if methodOwner := a.getMethodOwner(a.currentClass, funcIdent.Value); methodOwner != nil {
 					visibility, hasVisibility := methodOwner.MethodVisibility[ident.Normalize(funcIdent.Value)]
 					if hasVisibility && !a.checkVisibility(methodOwner, visibility, funcIdent.Value, "method") {
-						a.addStructuredError(NewVisibilityScopeError(funcIdent.Token.Pos, funcIdent.Value))
+						a.addStructuredError(NewVisibilityScopeError(funcIdent.Token.Pos, funcIdent.Value, visibility))
 						return nil
 					}
 					a.recordClassMethodUsage(methodOwner, funcIdent.Value)
 				}
-				if len(expr.Arguments) != len(methodType.Parameters) {
+				if !acceptsArgumentCount(methodType, len(expr.Arguments)) {
 					a.addError("method '%s' expects %d arguments, got %d at %s",
 						funcIdent.Value, len(methodType.Parameters), len(expr.Arguments), expr.Token.Pos.String())
 					return methodType.ReturnType
@@ -502,7 +487,7 @@ func (a *Analyzer) analyzeCallExpression(expr *ast.CallExpression) types.Type {
 				for i, arg := range expr.Arguments {
 					argType := a.analyzeExpression(arg)
 					expectedType := methodType.Parameters[i]
-					if argType != nil && !a.canAssign(argType, expectedType) {
+					if argType != nil && !a.argumentMatchesParameter(argType, expectedType, false) {
 						a.addError("argument %d to method '%s' has type %s, expected %s at %s",
 							i+1, funcIdent.Value, argType.String(), expectedType.String(), expr.Token.Pos.String())
 					}
@@ -729,6 +714,8 @@ func (a *Analyzer) analyzeCallExpression(expr *ast.CallExpression) types.Type {
 				if !a.canAssign(argType, expectedType) {
 					pos := arg.Pos()
 					a.addError("%s", errors.FormatArgumentError(i, semanticFunctionParamTypeName(funcType, i, expectedType), argType.String(), pos.Line, pos.Column))
+				} else {
+					a.checkImplicitConversionAmbiguity(argType, expectedType, arg.Pos())
 				}
 			}
 		}
@@ -761,7 +748,7 @@ func (a *Analyzer) analyzeImplicitHelperCall(methodName string, args []ast.Expre
 		return nil, false
 	}
 
-	if len(args) != len(methodType.Parameters) {
+	if !acceptsArgumentCount(methodType, len(args)) {
 		a.addError("method '%s' expects %d argument(s), got %d at %s",
 			methodName, len(methodType.Parameters), len(args), pos.String())
 		return methodType.ReturnType, true
@@ -903,7 +890,7 @@ func (a *Analyzer) analyzeConstructorCall(expr *ast.CallExpression, classType *t
 	}
 
 	// Validate argument count
-	if len(expr.Arguments) != len(selectedSignature.Parameters) {
+	if !acceptsArgumentCount(selectedSignature, len(expr.Arguments)) {
 		a.addError("constructor '%s' expects %d arguments, got %d at %s",
 			constructorName, len(selectedSignature.Parameters), len(expr.Arguments),
 			expr.Token.Pos.String())
@@ -1159,3 +1146,42 @@ func (a *Analyzer) analyzeRecordStaticMethodCall(expr *ast.CallExpression, recor
 
 	return funcType.ReturnType
 }
+
+// analyzeMethodCallArguments checks the arguments of a call to a method or
+// unit function of type methodType and returns the call's result type.
+func (a *Analyzer) analyzeMethodCallArguments(expr *ast.CallExpression, methodType types.Type) types.Type {
+	funcType, ok := methodType.(*types.FunctionType)
+	if !ok {
+		a.addError("cannot call non-function type %s at %s",
+			methodType.String(), expr.Token.Pos.String())
+		return nil
+	}
+
+	if !acceptsArgumentCount(funcType, len(expr.Arguments)) {
+		a.addError("method call expects %d argument(s), got %d at %s",
+			len(funcType.Parameters), len(expr.Arguments), expr.Token.Pos.String())
+	}
+
+	// Validate argument types
+	for i, arg := range expr.Arguments {
+		if i >= len(funcType.Parameters) {
+			break
+		}
+
+		isVar := len(funcType.VarParams) > i && funcType.VarParams[i]
+		if isVar && !a.isLValue(arg) {
+			a.addError("var parameter %d requires a variable (identifier, array element, or field), got %s at %s",
+				i+1, arg.String(), arg.Pos().String())
+		}
+
+		paramType := funcType.Parameters[i]
+		argType := a.analyzeArgumentForParameter(arg, paramType, i < len(funcType.StrictParams) && funcType.StrictParams[i])
+		if argType != nil && !a.argumentMatchesParameter(argType, paramType, i < len(funcType.StrictParams) && funcType.StrictParams[i]) {
+			a.addError("argument %d has type %s, expected %s at %s",
+				i+1, argType.String(), paramType.String(),
+				expr.Token.Pos.String())
+		}
+	}
+
+	return funcType.ReturnType
+}
@@ -2,6 +2,7 @@ package semantic
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/cwbudde/go-dws/internal/builtins"
 	"github.com/cwbudde/go-dws/internal/types"
@@ -94,6 +95,13 @@ func (a *Analyzer) analyzeFunctionPointerTypeDeclaration(decl *ast.TypeDeclarati
 // analyzeAddressOfExpression analyzes an address-of expression (@FunctionName).
 // Examples: @Ascending, @MyCallback, @TMyClass.MyMethod
 func (a *Analyzer) analyzeAddressOfExpression(expr *ast.AddressOfExpression) types.Type {
+	return a.analyzeAddressOfExpressionWithContext(expr, nil)
+}
+
+// analyzeAddressOfExpressionWithContext analyzes an address-of expression whose
+// value is assigned or passed to expectedType, which selects the overload an
+// overloaded function name refers to. expectedType may be nil.
+func (a *Analyzer) analyzeAddressOfExpressionWithContext(expr *ast.AddressOfExpression, expectedType types.Type) types.Type {
 	if expr == nil || expr.Operator == nil {
 		return nil
 	}
@@ -102,7 +110,7 @@ func (a *Analyzer) analyzeAddressOfExpression(expr *ast.AddressOfExpression) typ
 	switch target := expr.Operator.(type) {
 	case *ast.Identifier:
 		// Simple function/procedure reference: @FunctionName
-		return a.analyzeAddressOfFunction(target.Value, expr)
+		return a.analyzeAddressOfFunction(target, expr, expectedType)
 
 	case *ast.MemberAccessExpression:
 		// Method reference: @TMyClass.MyMethod
@@ -120,11 +128,12 @@ func (a *Analyzer) analyzeAddressOfExpression(expr *ast.AddressOfExpression) typ
 
 // analyzeAddressOfFunction resolves a function name and creates a function pointer type.
 // Queries both symbol table and builtin registry.
-func (a *Analyzer) analyzeAddressOfFunction(funcName string, expr *ast.AddressOfExpression) types.Type {
+func (a *Analyzer) analyzeAddressOfFunction(target *ast.Identifier, expr *ast.AddressOfExpression, expectedType types.Type) types.Type {
+	funcName := target.Value
 	sym, ok := a.symbols.Resolve(funcName)
 	if !ok {
 		// Query builtin registry for function signatures
-		if sig, found := builtins.DefaultRegistry.GetSignature(funcName); found {
+		if sig, found := a.builtinRegistry.GetSignature(funcName); found {
 			if sig.IsVariadic {
 				a.addError("cannot take address of variadic built-in function '%s' at %s",
 					funcName, expr.Token.Pos.String())
@@ -135,7 +144,7 @@ func (a *Analyzer) analyzeAddressOfFunction(funcName string, expr *ast.AddressOf
 		}
 
 		// Builtin without signature metadata - return generic function pointer type
-		if builtins.DefaultRegistry.Has(funcName) {
+		if a.builtinRegistry.Has(funcName) {
 			funcPtrType := types.NewFunctionPointerType(nil, types.VARIANT)
 			typeAnnotation := &ast.TypeAnnotation{
 				Name: fmt.Sprintf("function pointer to %s", funcName),
@@ -148,6 +157,13 @@ func (a *Analyzer) analyzeAddressOfFunction(funcName string, expr *ast.AddressOf
 		return nil
 	}
 
+	if sym.IsOverloadSet {
+		sym = a.selectAddressOfOverload(funcName, sym.Overloads, expr, expectedType)
+		if sym == nil {
+			return nil
+		}
+	}
+
 	// The symbol must be a function type
 	funcType, ok := sym.Type.(*types.FunctionType)
 	if !ok {
@@ -156,9 +172,77 @@ func (a *Analyzer) analyzeAddressOfFunction(funcName string, expr *ast.AddressOf
 		return nil
 	}
 
+	// Record the declaration so the interpreter takes the address of the
+	// same overload.
+	if decl, ok := a.functionDecls[funcType]; ok {
+		a.semanticInfo.SetSymbol(target, decl)
+	}
+
 	return a.buildFunctionPointerType(funcName, funcType, expr)
 }
 
+// selectAddressOfOverload picks the overload of funcName whose signature
+// matches the function pointer type expected at the address-of site. An exact
+// signature match wins over an assignment-compatible one. Without a single
+// match it reports an error listing the candidates and returns nil.
+func (a *Analyzer) selectAddressOfOverload(funcName string, overloads []*Symbol, expr *ast.AddressOfExpression, expectedType types.Type) *Symbol {
+	var expected *types.FunctionPointerType
+	if expectedType != nil {
+		expected, _ = types.GetUnderlyingType(expectedType).(*types.FunctionPointerType)
+	}
+
+	var exact, compatible []*Symbol
+	for _, overload := range overloads {
+		funcType, ok := overload.Type.(*types.FunctionType)
+		if !ok {
+			continue
+		}
+		if expected == nil {
+			compatible = append(compatible, overload)
+			continue
+		}
+		ptrType := functionPointerTypeOf(funcType)
+		if ptrType.Equals(expected) {
+			exact = append(exact, overload)
+		} else if a.canAssign(ptrType, expected) {
+			compatible = append(compatible, overload)
+		}
+	}
+	if len(exact) == 1 {
+		return exact[0]
+	}
+	if len(exact) == 0 && len(compatible) == 1 {
+		return compatible[0]
+	}
+
+	candidates := make([]string, 0, len(overloads))
+	for _, overload := range overloads {
+		candidates = append(candidates, funcName+overload.Type.String())
+	}
+	switch {
+	case expected == nil:
+		a.addError("ambiguous address of overloaded '%s' (candidates: %s) at %s",
+			funcName, strings.Join(candidates, ", "), expr.Token.Pos.String())
+	case len(exact) == 0 && len(compatible) == 0:
+		a.addError("no overload of '%s' matches %s (candidates: %s) at %s",
+			funcName, expectedType.String(), strings.Join(candidates, ", "), expr.Token.Pos.String())
+	default:
+		a.addError("ambiguous address of overloaded '%s' for %s (candidates: %s) at %s",
+			funcName, expectedType.String(), strings.Join(candidates, ", "), expr.Token.Pos.String())
+	}
+	return nil
+}
+
+// functionPointerTypeOf returns the function pointer type of a routine
+// signature. Procedures have a nil return type.
+func functionPointerTypeOf(funcType *types.FunctionType) *types.FunctionPointerType {
+	var returnType types.Type
+	if funcType.ReturnType != nil && funcType.ReturnType != types.VOID {
+		returnType = funcType.ReturnType
+	}
+	return types.NewFunctionPointerType(funcType.Parameters, returnType)
+}
+
 // buildFunctionPointerTypeFromBuiltin creates a FunctionPointerType from a builtin signature.
 func (a *Analyzer) buildFunctionPointerTypeFromBuiltin(funcName string, sig *builtins.FunctionSignature, expr *ast.AddressOfExpression) types.Type {
 	var returnType types.Type
@@ -177,12 +261,7 @@ func (a *Analyzer) buildFunctionPointerTypeFromBuiltin(funcName string, sig *bui
 
 // buildFunctionPointerType creates a FunctionPointerType from a function signature.
 func (a *Analyzer) buildFunctionPointerType(funcName string, funcType *types.FunctionType, expr *ast.AddressOfExpression) types.Type {
-	var returnType types.Type
-	if funcType.ReturnType != nil && funcType.ReturnType != types.VOID {
-		returnType = funcType.ReturnType
-	}
-
-	funcPtrType := types.NewFunctionPointerType(funcType.Parameters, returnType)
+	funcPtrType := functionPointerTypeOf(funcType)
 	typeAnnotation := &ast.TypeAnnotation{
 		Name: fmt.Sprintf("function pointer to %s", funcName),
 	}
@@ -289,7 +368,7 @@ func (a *Analyzer) classCallableMemberVisible(classType *types.ClassType, member
 		if owner := a.getFieldOwner(classType, memberName); owner != nil {
 			if visibility, ok := owner.FieldVisibility[normalized]; ok &&
 				!a.checkVisibility(owner, visibility, memberName, "field") {
-				a.addStructuredError(NewVisibilityScopeError(member.Token.Pos, member.Value))
+				a.addStructuredError(NewVisibilityScopeError(member.Token.Pos, member.Value, visibility))
 				return false
 			}
 		}
@@ -299,7 +378,7 @@ func (a *Analyzer) classCallableMemberVisible(classType *types.ClassType, member
 		if owner := a.getClassVarOwner(classType, memberName); owner != nil {
 			if visibility, ok := owner.ClassVarVisibility[normalized]; ok &&
 				!a.checkVisibility(owner, visibility, memberName, "class variable") {
-				a.addStructuredError(NewVisibilityScopeError(member.Token.Pos, member.Value))
+				a.addStructuredError(NewVisibilityScopeError(member.Token.Pos, member.Value, visibility))
 				return false
 			}
 		}
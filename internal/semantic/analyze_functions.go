@@ -60,7 +60,6 @@ func (a *Analyzer) registerFunctionSignature(decl *ast.FunctionDecl) (paramTypes
 	varParams := make([]bool, 0, len(decl.Parameters))
 	constParams := make([]bool, 0, len(decl.Parameters))
 	strictParams := make([]bool, 0, len(decl.Parameters))
-	foundOptional := false // Track if we've seen an optional parameter
 
 	for _, param := range decl.Parameters {
 		// Validate that lazy, var, and const are mutually exclusive
@@ -80,16 +79,9 @@ func (a *Analyzer) registerFunctionSignature(decl *ast.FunctionDecl) (paramTypes
 			return nil, nil, false
 		}
 
-		// Optional parameters must come last, without modifiers
-		if param.DefaultValue != nil {
-			foundOptional = true
-			if param.IsLazy || param.ByRef || param.IsConst {
-				a.addError("optional parameter '%s' cannot have lazy, var, or const modifiers in function '%s' at %s",
-					param.Name.Value, decl.Name.Value, param.Token.Pos.String())
-				return nil, nil, false
-			}
-		} else if foundOptional {
-			a.addError("required parameter '%s' cannot come after optional parameters in function '%s' at %s",
+		// Optional parameters cannot have modifiers
+		if param.DefaultValue != nil && (param.IsLazy || param.ByRef || param.IsConst) {
+			a.addError("optional parameter '%s' cannot have lazy, var, or const modifiers in function '%s' at %s",
 				param.Name.Value, decl.Name.Value, param.Token.Pos.String())
 			return nil, nil, false
 		}
@@ -114,21 +106,6 @@ func (a *Analyzer) registerFunctionSignature(decl *ast.FunctionDecl) (paramTypes
 			}
 		}
 
-		// Validate default value type matches parameter type
-		if param.DefaultValue != nil {
-			defaultType := a.analyzeExpressionWithExpectedType(param.DefaultValue, paramType)
-			if defaultType == nil {
-				a.addError("invalid default value for parameter '%s' in function '%s'",
-					param.Name.Value, decl.Name.Value)
-				return nil, nil, false
-			}
-			if !paramType.Equals(defaultType) && !defaultType.Equals(paramType) {
-				a.addError("default value type '%s' does not match parameter type '%s' for parameter '%s' in function '%s'",
-					defaultType.String(), paramType.String(), param.Name.Value, decl.Name.Value)
-				return nil, nil, false
-			}
-		}
-
 		paramTypes = append(paramTypes, paramType)
 		paramNames = append(paramNames, param.Name.Value)
 		paramTypeNames = append(paramTypeNames, semanticDeclaredTypeName(param.Type, paramType))
@@ -139,6 +116,8 @@ func (a *Analyzer) registerFunctionSignature(decl *ast.FunctionDecl) (paramTypes
 		strictParams = append(strictParams, isStrictTypeAnnotation(param.Type))
 	}
 
+	a.checkParameterDefaults(decl.Parameters, paramTypes)
+
 	// Determine return type
 	if decl.ReturnType != nil {
 		var err error
@@ -188,10 +167,53 @@ func (a *Analyzer) registerFunctionSignature(decl *ast.FunctionDecl) (paramTypes
 		a.addError("Syntax Error: %s [line: %d, column: %d]", err.Error(), decl.Token.Pos.Line, decl.Token.Pos.Column)
 		return nil, nil, false
 	}
+	a.functionDecls[funcType] = decl
 
 	return paramTypes, returnType, true
 }
 
+// checkParameterDefaults validates parameter default values against their
+// parameter types, and requires a default for every parameter that follows a
+// defaulted one. Errors do not abort the declaration, so the routine is still
+// registered and calls to it are checked against its signature.
+func (a *Analyzer) checkParameterDefaults(params []*ast.Parameter, paramTypes []types.Type) {
+	foundOptional := false
+	for i, param := range params {
+		if param.DefaultValue == nil {
+			if foundOptional {
+				pos := param.Token.Pos
+				if param.Type != nil {
+					pos = param.Type.End()
+				}
+				a.addError("Syntax Error: Default value required [line: %d, column: %d]", pos.Line, pos.Column)
+			}
+			continue
+		}
+		foundOptional = true
+		if i >= len(paramTypes) || paramTypes[i] == nil {
+			continue
+		}
+		paramType := paramTypes[i]
+		defaultType := a.analyzeExpressionWithExpectedType(param.DefaultValue, paramType)
+		if defaultType != nil && !a.canAssign(defaultType, paramType) {
+			a.addStructuredError(NewIncompatibleTypesPairError(param.DefaultValue.Pos(), paramType.String(), defaultType.String()))
+		}
+	}
+}
+
+// parameterDefaultValues returns the default value expressions of params,
+// with nil for parameters that have none, in the form stored in
+// types.FunctionType.DefaultValues.
+func parameterDefaultValues(params []*ast.Parameter) []interface{} {
+	defaults := make([]interface{}, len(params))
+	for i, param := range params {
+		if param.DefaultValue != nil {
+			defaults[i] = param.DefaultValue
+		}
+	}
+	return defaults
+}
+
 // analyzeFunctionBody analyzes a regular function's body in a fresh scope, using the
 // parameter and return types already resolved by registerFunctionSignature. Callers
 // must skip forward declarations before invoking this.
@@ -231,6 +253,9 @@ func (a *Analyzer) analyzeFunctionBody(decl *ast.FunctionDecl, paramTypes []type
 	previousFunc := a.currentFunction
 	a.currentFunction = decl
 	defer func() { a.currentFunction = previousFunc }()
+	previousLambdaReturn, previousLambdaResults := a.currentLambdaReturn, a.lambdaResultTypes
+	a.currentLambdaReturn, a.lambdaResultTypes = nil, nil
+	defer func() { a.currentLambdaReturn, a.lambdaResultTypes = previousLambdaReturn, previousLambdaResults }()
 	defer a.emitUnusedWarningsForCurrentScope()
 
 	if decl.Body != nil {
@@ -259,6 +284,16 @@ func (a *Analyzer) analyzeReturn(stmt *ast.ReturnStatement) {
 		return
 	}
 
+	// The values a lambda returns determine its inferred return type
+	if a.lambdaResultTypes != nil {
+		var returnType types.Type = types.VOID
+		if stmt.ReturnValue != nil {
+			returnType = a.analyzeExpression(stmt.ReturnValue)
+		}
+		a.recordLambdaResult(returnType)
+		return
+	}
+
 	// Get expected return type
 	var expectedType types.Type
 	if a.currentFunction != nil {
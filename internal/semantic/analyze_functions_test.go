@@ -188,7 +188,7 @@ func TestReturnInProcedure(t *testing.T) {
 			Result := 42;
 		end;
 	`
-	expectError(t, input, "undefined variable 'Result'")
+	expectError(t, input, "Result is only valid inside a function")
 }
 
 func TestReturnOutsideFunction(t *testing.T) {
@@ -197,7 +197,21 @@ func TestReturnOutsideFunction(t *testing.T) {
 			Result := 42;
 		end;
 	`
-	expectError(t, input, "undefined variable 'Result'")
+	expectError(t, input, "Result is only valid inside a function")
+	expectError(t, `Result := 1;`, "Result is only valid inside a function")
+	expectError(t, `PrintLn(Result);`, "Result is only valid inside a function")
+}
+
+func TestAssignToFunctionNameOutsideBody(t *testing.T) {
+	input := `
+		function Answer: Integer;
+		begin
+			Answer := 42;
+		end;
+
+		Answer := 1;
+	`
+	expectError(t, input, "cannot assign to function 'Answer' outside its own body")
 }
 
 // ============================================================================
@@ -337,3 +351,214 @@ func TestForwardFunctionReferenceWithoutForwardKeyword(t *testing.T) {
 		expectError(t, input, "Unknown name")
 	})
 }
+
+// ============================================================================
+// Default Parameter Tests
+// ============================================================================
+
+func TestDefaultParameterValues(t *testing.T) {
+	t.Run("omitted and supplied defaults", func(t *testing.T) {
+		input := `
+			function Scale(x: Integer; factor: Integer = 2; suffix: String = ''): String;
+			begin
+				Result := IntToStr(x * factor) + suffix;
+			end;
+
+			PrintLn(Scale(1));
+			PrintLn(Scale(1, 3));
+			PrintLn(Scale(1, 3, '!'));
+		`
+		expectNoErrors(t, input)
+	})
+
+	t.Run("default value type mismatch", func(t *testing.T) {
+		input := `
+			procedure P(a: Integer = 'x');
+			begin
+			end;
+		`
+		expectError(t, input, `Incompatible types: "Integer" and "String"`)
+	})
+
+	t.Run("default value assignable to parameter type", func(t *testing.T) {
+		input := `
+			procedure P(a: Float = 1);
+			begin
+			end;
+
+			P;
+		`
+		expectNoErrors(t, input)
+	})
+
+	t.Run("required parameter after default", func(t *testing.T) {
+		input := `
+			procedure P(a: Integer = 1; b: Integer);
+			begin
+			end;
+		`
+		expectError(t, input, "Default value required")
+	})
+
+	t.Run("too few arguments", func(t *testing.T) {
+		input := `
+			procedure P(a: Integer; b: Integer = 1);
+			begin
+			end;
+
+			P();
+		`
+		expectError(t, input, "expects at least 1 arguments, got 0")
+	})
+
+	t.Run("method defaults", func(t *testing.T) {
+		input := `
+			type TC = class
+				procedure M(a: Integer = 1; b: String = 'x');
+				constructor Make(a: Integer = 2);
+			end;
+
+			procedure TC.M(a: Integer; b: String);
+			begin
+			end;
+
+			constructor TC.Make(a: Integer);
+			begin
+			end;
+
+			var c := TC.Make();
+			c := TC.Make;
+			c := TC.Make(5);
+			c.M;
+			c.M(2);
+			c.M(2, 'y');
+		`
+		expectNoErrors(t, input)
+	})
+
+	t.Run("record method defaults", func(t *testing.T) {
+		input := `
+			type TR = record
+				X: Integer;
+				procedure M(a: Integer = 1);
+				class function F(a: Integer = 3): Integer;
+			end;
+
+			procedure TR.M(a: Integer);
+			begin
+			end;
+
+			class function TR.F(a: Integer): Integer;
+			begin
+				Result := a;
+			end;
+
+			var r: TR;
+			r.M;
+			r.M(5);
+			PrintLn(TR.F());
+			PrintLn(TR.F(4));
+		`
+		expectNoErrors(t, input)
+	})
+
+	t.Run("method call with too many arguments", func(t *testing.T) {
+		input := `
+			type TC = class
+				procedure M(a: Integer = 1);
+			end;
+
+			procedure TC.M(a: Integer);
+			begin
+			end;
+
+			var c := TC.Create;
+			c.M(1, 2);
+		`
+		expectError(t, input, "expects 1 arguments, got 2")
+	})
+}
+
+// ============================================================================
+// Named Argument Tests
+// ============================================================================
+
+func TestNamedArguments(t *testing.T) {
+	decl := `
+		procedure Foo(count: Integer; name: String = 'x'; sep: String = ':');
+		begin
+		end;
+	`
+
+	t.Run("all named", func(t *testing.T) {
+		expectNoErrors(t, decl+`Foo(name := 'y', count := 3);`)
+	})
+
+	t.Run("positional then named", func(t *testing.T) {
+		expectNoErrors(t, decl+`Foo(3, sep := '-');`)
+	})
+
+	t.Run("named argument type mismatch", func(t *testing.T) {
+		expectError(t, decl+`Foo(count := 'a');`, `Argument 0 expects type "Integer"`)
+	})
+
+	t.Run("duplicate name", func(t *testing.T) {
+		expectError(t, decl+`Foo(count := 1, count := 2);`, `parameter "count" is given more than once`)
+	})
+
+	t.Run("name repeats positional argument", func(t *testing.T) {
+		expectError(t, decl+`Foo(1, count := 2);`, `parameter "count" is given more than once`)
+	})
+
+	t.Run("unknown name", func(t *testing.T) {
+		expectError(t, decl+`Foo(cnt := 1);`, `there is no parameter named "cnt"`)
+	})
+
+	t.Run("missing required parameter", func(t *testing.T) {
+		expectError(t, decl+`Foo(name := 'y');`, `no value given for parameter "count"`)
+	})
+
+	t.Run("builtin callee", func(t *testing.T) {
+		expectError(t, `PrintLn(s := 'a');`, "named arguments are only supported in calls to declared functions")
+	})
+}
+
+func TestNamedArgumentsMethodCalls(t *testing.T) {
+	decl := `
+		type TPoint = class
+			constructor Create(x, y: Integer);
+			function M(a, b: Integer): Integer;
+			procedure P(i: Integer); overload;
+			procedure P(s: String); overload;
+		end;
+		constructor TPoint.Create(x, y: Integer); begin end;
+		function TPoint.M(a, b: Integer): Integer; begin Result := a + b; end;
+		procedure TPoint.P(i: Integer); begin end;
+		procedure TPoint.P(s: String); begin end;
+		var c := TPoint.Create(1, 2);
+	`
+
+	t.Run("method", func(t *testing.T) {
+		expectNoErrors(t, decl+`var n: Integer := c.M(b := 1, a := 10);`)
+	})
+
+	t.Run("constructor", func(t *testing.T) {
+		expectNoErrors(t, decl+`c := TPoint.Create(y := 2, x := 1); c := new TPoint(y := 2, x := 1);`)
+	})
+
+	t.Run("method type mismatch", func(t *testing.T) {
+		expectError(t, decl+`c.M(b := 'x', a := 1);`, "has type String, expected Integer")
+	})
+
+	t.Run("unknown name", func(t *testing.T) {
+		expectError(t, decl+`c.M(z := 1);`, `there is no parameter named "z"`)
+	})
+
+	t.Run("constructor missing parameter", func(t *testing.T) {
+		expectError(t, decl+`c := TPoint.Create(y := 2);`, `no value given for parameter "x"`)
+	})
+
+	t.Run("overloaded method", func(t *testing.T) {
+		expectError(t, decl+`c.P(i := 1);`, "named arguments are not supported for overloaded method 'P'")
+	})
+}
@@ -270,6 +270,12 @@ func (a *Analyzer) analyzeHelperMethodBody(decl *ast.FunctionDecl, helperType *t
 
 	if classType, ok := types.GetUnderlyingType(helperType.TargetType).(*types.ClassType); ok && !decl.IsClassMethod {
 		for fieldName, fieldType := range classType.Fields {
+			// Strict fields are not in scope; a bare reference to one falls
+			// through to the visibility check instead.
+			if vis, ok := classType.FieldVisibility[ident.Normalize(fieldName)]; ok &&
+				(vis == int(ast.VisibilityStrictPrivate) || vis == int(ast.VisibilityStrictProtected)) {
+				continue
+			}
 			a.symbols.Define(fieldName, fieldType, token.Position{})
 		}
 	}
@@ -386,6 +392,10 @@ func (a *Analyzer) analyzeHelperMethod(method *ast.FunctionDecl, helperType *typ
 	} else {
 		funcType = types.NewProcedureType(paramTypes)
 	}
+	if len(paramTypes) == len(method.Parameters) {
+		funcType.DefaultValues = parameterDefaultValues(method.Parameters)
+		a.checkParameterDefaults(method.Parameters, paramTypes)
+	}
 
 	// Add method to helper
 	helperType.Methods[methodNameLower] = funcType
@@ -468,7 +478,7 @@ func (a *Analyzer) analyzeHelperClassVar(classVar *ast.FieldDecl, helperType *ty
 	// Validate initializer compatibility when both are present
 	if classVar.InitValue != nil && classVar.Type != nil {
 		initType := a.analyzeExpression(classVar.InitValue)
-		if initType != nil && varType != nil && !types.IsAssignableFrom(varType, initType) {
+		if initType != nil && varType != nil && !a.canAssign(initType, varType) {
 			a.addError("cannot initialize class variable '%s' of type '%s' with value of type '%s' in helper '%s' at %s",
 				varName, varType.String(), initType.String(), helperName, classVar.Token.Pos.String())
 			return
@@ -111,11 +111,10 @@ func (a *Analyzer) analyzeInterfaceMethodDecl(method *ast.InterfaceMethodDecl, i
 }
 
 // analyzeInterfacePropertyDecl analyzes a property declared on an interface and
-// registers its PropertyInfo. Interface property accessors (method names or
-// inline expressions) reference members that are validated against the
-// implementing class, so only the declared property type and access kinds are
-// recorded here; the expression accessors are executed at runtime against the
-// underlying object.
+// registers its PropertyInfo. Accessors that name a method of the interface are
+// checked against the property signature here; all method accessors are checked
+// again against each implementing class in validateInterfacePropertyImplementation.
+// Expression accessors are executed at runtime against the underlying object.
 func (a *Analyzer) analyzeInterfacePropertyDecl(prop *ast.PropertyDecl, iface *types.InterfaceType) {
 	if prop == nil || prop.Name == nil {
 		return
@@ -140,14 +139,45 @@ func (a *Analyzer) analyzeInterfacePropertyDecl(prop *ast.PropertyDecl, iface *t
 		return
 	}
 
+	var indexParamTypes []types.Type
+	for _, param := range prop.IndexParams {
+		paramType, err := a.resolveType(getTypeExpressionName(param.Type))
+		if err != nil {
+			a.addStructuredError(NewPropertyDeclarationError(prop.Token.Pos,
+				"unknown type '"+getTypeExpressionName(param.Type)+"' for index parameter '"+param.Name.Value+"' in property '"+propName+"'"))
+			return
+		}
+		indexParamTypes = append(indexParamTypes, paramType)
+	}
+
 	propInfo := &types.PropertyInfo{
 		Name:            propName,
 		Type:            propType,
+		IndexParamTypes: indexParamTypes,
 		IsIndexed:       len(prop.IndexParams) > 0,
 		IsDefault:       prop.IsDefault,
 		IsClassProperty: prop.IsClassProperty,
 	}
 
+	// Index directive: the accessors receive the literal as a trailing
+	// Integer argument, as for class properties.
+	if prop.IndexValue != nil {
+		if propInfo.IsIndexed {
+			a.addStructuredError(NewPropertyDeclarationError(prop.Token.Pos,
+				"property '"+propName+"' cannot combine index parameters with an index directive"))
+			return
+		}
+		val, ok := ast.ExtractIntegerLiteral(prop.IndexValue)
+		if !ok {
+			a.addStructuredError(NewPropertyDeclarationError(prop.Token.Pos,
+				"property '"+propName+"' index directive must be an integer literal"))
+			return
+		}
+		propInfo.HasIndexValue = true
+		propInfo.IndexValue = val
+		propInfo.IndexValueType = types.INTEGER
+	}
+
 	// Record read access kind.
 	if prop.ReadSpec != nil {
 		if id, ok := prop.ReadSpec.(*ast.Identifier); ok {
@@ -182,9 +212,91 @@ func (a *Analyzer) analyzeInterfacePropertyDecl(prop *ast.PropertyDecl, iface *t
 		propInfo.WriteKind = types.PropAccessNone
 	}
 
+	// Accessors naming a method of the interface itself (or an ancestor) can be
+	// checked right away; other accessors are checked per implementing class.
+	allMethods := types.GetAllInterfaceMethods(iface)
+	if propInfo.ReadKind == types.PropAccessMethod {
+		if getter, ok := allMethods[ident.Normalize(propInfo.ReadSpec)]; ok {
+			if msg := interfacePropertyGetterMismatch(propInfo, getter); msg != "" {
+				a.addStructuredError(NewPropertyDeclarationTypeMismatchError(prop.Token.Pos, msg))
+				return
+			}
+		}
+	}
+	if propInfo.WriteKind == types.PropAccessMethod {
+		if setter, ok := allMethods[ident.Normalize(propInfo.WriteSpec)]; ok {
+			if msg := interfacePropertySetterMismatch(propInfo, setter); msg != "" {
+				a.addStructuredError(NewPropertyDeclarationTypeMismatchError(prop.Token.Pos, msg))
+				return
+			}
+		}
+	}
+
 	iface.Properties[propKey] = propInfo
 }
 
+// interfacePropertyAccessorIndexTypes returns the parameter types an accessor
+// of propInfo receives before the value: the index parameters, or the Integer
+// of an index directive.
+func interfacePropertyAccessorIndexTypes(propInfo *types.PropertyInfo) []types.Type {
+	if propInfo.HasIndexValue {
+		return []types.Type{types.INTEGER}
+	}
+	return propInfo.IndexParamTypes
+}
+
+// interfacePropertyGetterMismatch checks that getter can serve as the read
+// accessor of an interface property: it must take exactly the index
+// parameters and return the property type. Returns a description of the
+// mismatch, or "" if the signature is compatible.
+func interfacePropertyGetterMismatch(propInfo *types.PropertyInfo, getter *types.FunctionType) string {
+	prefix := "property '" + propInfo.Name + "' getter method '" + propInfo.ReadSpec + "'"
+	indexTypes := interfacePropertyAccessorIndexTypes(propInfo)
+	expected := len(indexTypes)
+	if len(getter.Parameters) != expected {
+		return prefix + " has " + formatInt(len(getter.Parameters)) + " " + pluralizeParam(len(getter.Parameters)) +
+			", expected " + formatInt(expected) + " " + pluralizeParam(expected)
+	}
+	for i, paramType := range indexTypes {
+		if !getter.Parameters[i].Equals(paramType) {
+			return prefix + " parameter " + formatInt(i+1) + " has type " + getter.Parameters[i].String() +
+				", expected " + paramType.String()
+		}
+	}
+	if getter.ReturnType == nil || !getter.ReturnType.Equals(propInfo.Type) {
+		returnType := types.VOID.String()
+		if getter.ReturnType != nil {
+			returnType = getter.ReturnType.String()
+		}
+		return prefix + " returns " + returnType + ", expected " + propInfo.Type.String()
+	}
+	return ""
+}
+
+// interfacePropertySetterMismatch checks that setter can serve as the write
+// accessor of an interface property: it must take the index parameters
+// followed by a value of the property type. Returns a description of the
+// mismatch, or "" if the signature is compatible.
+func interfacePropertySetterMismatch(propInfo *types.PropertyInfo, setter *types.FunctionType) string {
+	prefix := "property '" + propInfo.Name + "' setter method '" + propInfo.WriteSpec + "'"
+	indexTypes := interfacePropertyAccessorIndexTypes(propInfo)
+	expected := len(indexTypes) + 1
+	if len(setter.Parameters) != expected {
+		return prefix + " has " + formatInt(len(setter.Parameters)) + " " + pluralizeParam(len(setter.Parameters)) +
+			", expected " + formatInt(expected) + " " + pluralizeParam(expected)
+	}
+	for i, paramType := range indexTypes {
+		if !setter.Parameters[i].Equals(paramType) {
+			return prefix + " parameter " + formatInt(i+1) + " has type " + setter.Parameters[i].String() +
+				", expected " + paramType.String()
+		}
+	}
+	if valueType := setter.Parameters[expected-1]; !valueType.Equals(propInfo.Type) {
+		return prefix + " value parameter has type " + valueType.String() + ", expected " + propInfo.Type.String()
+	}
+	return ""
+}
+
 // validateInterfaceImplementation validates that a class implements all required interface methods
 func (a *Analyzer) validateInterfaceImplementation(classType *types.ClassType, decl *ast.ClassDecl) {
 	// For each interface declared on the class
@@ -226,5 +338,37 @@ func (a *Analyzer) validateInterfaceImplementation(classType *types.ClassType, d
 				delete(a.forwardMethodNames, forwardKey)
 			}
 		}
+
+		a.validateInterfacePropertyImplementation(classType, ifaceType, decl)
+	}
+}
+
+// validateInterfacePropertyImplementation checks that a class provides the
+// accessor methods named by every property of an interface it implements
+// (including inherited interface properties), with signatures compatible with
+// the property type and index parameters.
+func (a *Analyzer) validateInterfacePropertyImplementation(classType *types.ClassType, ifaceType *types.InterfaceType, decl *ast.ClassDecl) {
+	for _, propInfo := range types.GetAllInterfaceProperties(ifaceType) {
+		if propInfo.ReadKind == types.PropAccessMethod {
+			getter, found := classType.GetMethod(propInfo.ReadSpec)
+			if !found {
+				a.addError("class '%s' does not implement getter '%s' of property '%s' from interface '%s' at %s",
+					classType.Name, propInfo.ReadSpec, propInfo.Name, ifaceType.Name, decl.Token.Pos.String())
+			} else if msg := interfacePropertyGetterMismatch(propInfo, getter); msg != "" {
+				a.addError("%s in class '%s' (interface '%s') at %s",
+					msg, classType.Name, ifaceType.Name, decl.Token.Pos.String())
+			}
+		}
+
+		if propInfo.WriteKind == types.PropAccessMethod {
+			setter, found := classType.GetMethod(propInfo.WriteSpec)
+			if !found {
+				a.addError("class '%s' does not implement setter '%s' of property '%s' from interface '%s' at %s",
+					classType.Name, propInfo.WriteSpec, propInfo.Name, ifaceType.Name, decl.Token.Pos.String())
+			} else if msg := interfacePropertySetterMismatch(propInfo, setter); msg != "" {
+				a.addError("%s in class '%s' (interface '%s') at %s",
+					msg, classType.Name, ifaceType.Name, decl.Token.Pos.String())
+			}
+		}
 	}
 }
@@ -5,6 +5,7 @@ import (
 
 	"github.com/cwbudde/go-dws/internal/types"
 	"github.com/cwbudde/go-dws/pkg/ast"
+	"github.com/cwbudde/go-dws/pkg/ident"
 )
 
 // ============================================================================
@@ -21,7 +22,7 @@ import (
 //   - lambda(a, b: Integer): Integer begin Result := a + b; end
 //
 // Analysis steps:
-//   - Validate all parameter types exist (require explicit types for now)
+//   - Validate all parameter types exist (untyped parameters default to Variant)
 //   - Check for duplicate parameter names
 //   - Create new scope for lambda parameters
 //   - Add parameters to the scope
@@ -46,35 +47,27 @@ func (a *Analyzer) analyzeLambdaExpression(expr *ast.LambdaExpression) types.Typ
 		paramNames[param.Name.Value] = true
 	}
 
-	// Validate and infer parameter types
+	// Validate parameter types. Without an expected function pointer type
+	// there is nothing to infer untyped parameters from, so they default to
+	// Variant and are bound late, as in DWScript. Strict mode rejects them.
 	paramTypes := make([]types.Type, 0, len(expr.Parameters))
-	hasUntypedParams := false
-
 	for _, param := range expr.Parameters {
 		if param.Type == nil {
-			hasUntypedParams = true
-			// Try to infer parameter type from context
-			// For now, mark as needing inference
-			paramTypes = append(paramTypes, nil)
-		} else {
-			paramType, err := a.resolveType(getTypeExpressionName(param.Type))
-			if err != nil {
-				a.addError("unknown parameter type '%s' in lambda at %s",
-					getTypeExpressionName(param.Type), param.Type.Pos().String())
+			if a.strictTypes {
+				a.addError("could not determine type of parameter '%s' at %s",
+					param.Name.Value, param.Name.Token.Pos.String())
 				return nil
 			}
-			paramTypes = append(paramTypes, paramType)
+			paramTypes = append(paramTypes, types.VARIANT)
+			continue
 		}
-	}
-
-	// If we have untyped parameters, attempt type inference
-	if hasUntypedParams {
-		// Try to infer from context
-		// This would require passing expected type from parent expression
-		// For now, report an error
-		a.addError("lambda parameter type inference not fully implemented at %s - please provide explicit types",
-			expr.Token.Pos.String())
-		return nil
+		paramType, err := a.resolveType(getTypeExpressionName(param.Type))
+		if err != nil {
+			a.addError("unknown parameter type '%s' in lambda at %s",
+				getTypeExpressionName(param.Type), param.Type.Pos().String())
+			return nil
+		}
+		paramTypes = append(paramTypes, paramType)
 	}
 
 	// Create new scope for lambda body
@@ -94,6 +87,9 @@ func (a *Analyzer) analyzeLambdaExpression(expr *ast.LambdaExpression) types.Typ
 	previousInLambda := a.inLambda
 	a.inLambda = true
 	defer func() { a.inLambda = previousInLambda }()
+	previousLambdaReturn, previousLambdaResults := a.currentLambdaReturn, a.lambdaResultTypes
+	a.currentLambdaReturn, a.lambdaResultTypes = nil, nil
+	defer func() { a.currentLambdaReturn, a.lambdaResultTypes = previousLambdaReturn, previousLambdaResults }()
 
 	// Determine or infer return type
 	var returnType types.Type
@@ -123,6 +119,11 @@ func (a *Analyzer) analyzeLambdaExpression(expr *ast.LambdaExpression) types.Typ
 				expr.Token.Pos.String())
 			return nil
 		}
+		if a.strictTypes && returnType == types.VARIANT {
+			a.addError("could not determine type of Result in lambda at %s",
+				expr.Token.Pos.String())
+			return nil
+		}
 		// Add Result variable now that we know the type
 		// Note: The body was already analyzed during inference, so we don't need to analyze it again
 		if returnType != types.VOID {
@@ -137,6 +138,7 @@ func (a *Analyzer) analyzeLambdaExpression(expr *ast.LambdaExpression) types.Typ
 	// Analyze lambda body (only if we had an explicit return type)
 	// If return type was inferred, the body was already analyzed during inference
 	if expr.ReturnType != nil && expr.Body != nil {
+		a.currentLambdaReturn = returnType
 		a.analyzeBlock(expr.Body)
 	}
 
@@ -251,6 +253,9 @@ func (a *Analyzer) analyzeLambdaExpressionWithContext(expr *ast.LambdaExpression
 	previousInLambda := a.inLambda
 	a.inLambda = true
 	defer func() { a.inLambda = previousInLambda }()
+	previousLambdaReturn, previousLambdaResults := a.currentLambdaReturn, a.lambdaResultTypes
+	a.currentLambdaReturn, a.lambdaResultTypes = nil, nil
+	defer func() { a.currentLambdaReturn, a.lambdaResultTypes = previousLambdaReturn, previousLambdaResults }()
 
 	// Determine or infer return type
 	var returnType types.Type
@@ -325,6 +330,7 @@ func (a *Analyzer) analyzeLambdaExpressionWithContext(expr *ast.LambdaExpression
 	// Analyze lambda body (only if we had an explicit return type)
 	// If return type was inferred, the body was already analyzed during inference
 	if expr.ReturnType != nil && expr.Body != nil {
+		a.currentLambdaReturn = returnType
 		a.analyzeBlock(expr.Body)
 	}
 
@@ -358,73 +364,29 @@ func (a *Analyzer) analyzeLambdaExpressionWithContext(expr *ast.LambdaExpression
 	return resultType
 }
 
-// inferReturnTypeFromBody attempts to infer the return type from a lambda body
-// by walking through statements looking for return statements and Result assignments.
+// inferReturnTypeFromBody infers the return type of a lambda without a
+// declared one. It analyzes the whole body in the current (lambda) scope,
+// collecting the types of the values given by return statements, Exit(value)
+// and Result assignments at any depth. A body that yields no value is a
+// procedure (VOID).
+//
+// Result is visible in the body with type Variant while the type is being
+// inferred; the caller redefines it with the inferred type afterwards.
 func (a *Analyzer) inferReturnTypeFromBody(body *ast.BlockStatement) types.Type {
 	if body == nil || len(body.Statements) == 0 {
 		// Empty body - treat as procedure
 		return types.VOID
 	}
 
-	// Collect return types from all return statements
 	var returnTypes []types.Type
+	previousResults := a.lambdaResultTypes
+	a.lambdaResultTypes = &returnTypes
+	defer func() { a.lambdaResultTypes = previousResults }()
 
-	// Walk through statements looking for returns and Result assignments
-	for _, stmt := range body.Statements {
-		if retStmt, ok := stmt.(*ast.ReturnStatement); ok {
-			if retStmt.ReturnValue != nil {
-				// Analyze the return value expression to get its type
-				retType := a.analyzeExpression(retStmt.ReturnValue)
-				if retType != nil {
-					returnTypes = append(returnTypes, retType)
-				}
-			} else {
-				// Return with no value - procedure
-				returnTypes = append(returnTypes, types.VOID)
-			}
-		} else if assignStmt, ok := stmt.(*ast.AssignmentStatement); ok {
-			// Check if this is a Result assignment
-			if ident, ok := assignStmt.Target.(*ast.Identifier); ok {
-				if ident.Value == "Result" {
-					// This is a Result assignment - infer type from RHS
-					rhsType := a.analyzeExpression(assignStmt.Value)
-					if rhsType != nil {
-						returnTypes = append(returnTypes, rhsType)
-					}
-				}
-			}
-		} else if ifStmt, ok := stmt.(*ast.IfStatement); ok {
-			// Recursively check if/else blocks for Result assignments
-			if ifStmt.Consequence != nil {
-				var consequenceType types.Type
-				if consequenceBlock, ok := ifStmt.Consequence.(*ast.BlockStatement); ok {
-					consequenceType = a.inferReturnTypeFromBody(consequenceBlock)
-				} else if assignStmt, ok := ifStmt.Consequence.(*ast.AssignmentStatement); ok {
-					// Single assignment statement - check if it's Result
-					if ident, ok := assignStmt.Target.(*ast.Identifier); ok && ident.Value == "Result" {
-						consequenceType = a.analyzeExpression(assignStmt.Value)
-					}
-				}
-				if consequenceType != nil && consequenceType != types.VOID {
-					returnTypes = append(returnTypes, consequenceType)
-				}
-			}
-			if ifStmt.Alternative != nil {
-				var alternativeType types.Type
-				if alternativeBlock, ok := ifStmt.Alternative.(*ast.BlockStatement); ok {
-					alternativeType = a.inferReturnTypeFromBody(alternativeBlock)
-				} else if assignStmt, ok := ifStmt.Alternative.(*ast.AssignmentStatement); ok {
-					// Single assignment statement - check if it's Result
-					if ident, ok := assignStmt.Target.(*ast.Identifier); ok && ident.Value == "Result" {
-						alternativeType = a.analyzeExpression(assignStmt.Value)
-					}
-				}
-				if alternativeType != nil && alternativeType != types.VOID {
-					returnTypes = append(returnTypes, alternativeType)
-				}
-			}
-		}
+	if mentionsResult(body) {
+		a.symbols.Define("Result", types.VARIANT, blockEndStart(body.End()))
 	}
+	a.analyzeBlock(body)
 
 	if len(returnTypes) == 0 {
 		// No return statements found - treat as procedure
@@ -448,6 +410,32 @@ func (a *Analyzer) inferReturnTypeFromBody(body *ast.BlockStatement) types.Type
 	return firstType
 }
 
+// recordLambdaResult records the type of a value returned from a lambda
+// whose return type is being inferred.
+func (a *Analyzer) recordLambdaResult(typ types.Type) {
+	if a.lambdaResultTypes != nil && typ != nil {
+		*a.lambdaResultTypes = append(*a.lambdaResultTypes, typ)
+	}
+}
+
+// mentionsResult reports whether body refers to Result outside of nested
+// lambdas and routines, which have their own.
+func mentionsResult(body *ast.BlockStatement) bool {
+	found := false
+	ast.Inspect(body, func(node ast.Node) bool {
+		switch n := node.(type) {
+		case *ast.LambdaExpression, *ast.FunctionDecl:
+			return false
+		case *ast.Identifier:
+			if ident.Equal(n.Value, "Result") {
+				found = true
+			}
+		}
+		return !found
+	})
+	return found
+}
+
 // ============================================================================
 // Closure Capture Analysis
 // ============================================================================
@@ -518,6 +506,9 @@ func (a *Analyzer) collectIdentifiers(node ast.Node) []string {
 		}
 
 	case *ast.IfStatement:
+		if n.InlineVar != nil {
+			identifiers = append(identifiers, a.collectIdentifiers(n.InlineVar)...)
+		}
 		identifiers = append(identifiers, a.collectIdentifiers(n.Condition)...)
 		if n.Consequence != nil {
 			identifiers = append(identifiers, a.collectIdentifiers(n.Consequence)...)
@@ -527,6 +518,9 @@ func (a *Analyzer) collectIdentifiers(node ast.Node) []string {
 		}
 
 	case *ast.WhileStatement:
+		if n.InlineVar != nil {
+			identifiers = append(identifiers, a.collectIdentifiers(n.InlineVar)...)
+		}
 		identifiers = append(identifiers, a.collectIdentifiers(n.Condition)...)
 		if n.Body != nil {
 			identifiers = append(identifiers, a.collectIdentifiers(n.Body)...)
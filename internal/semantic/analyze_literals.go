@@ -265,14 +265,14 @@ func (a *Analyzer) analyzeRecordLiteral(lit *ast.RecordLiteralExpression, expect
 		typeName := lit.TypeName.Value
 		resolvedType, err := a.resolveType(typeName)
 		if err != nil {
-			a.addError("unknown record type '%s' in record literal", typeName)
+			a.addError("unknown record type '%s' in record literal at %s", typeName, lit.TypeName.Token.Pos.String())
 			return nil
 		}
 
 		var ok bool
 		recordType, ok = resolvedType.(*types.RecordType)
 		if !ok {
-			a.addError("'%s' is not a record type, got %s", typeName, resolvedType.String())
+			a.addError("'%s' is not a record type, got %s at %s", typeName, resolvedType.String(), lit.TypeName.Token.Pos.String())
 			return nil
 		}
 
@@ -280,8 +280,8 @@ func (a *Analyzer) analyzeRecordLiteral(lit *ast.RecordLiteralExpression, expect
 		if expectedType != nil {
 			if expectedRecordType, ok := types.GetUnderlyingType(expectedType).(*types.RecordType); ok {
 				if expectedRecordType.Name != recordType.Name {
-					a.addError("record literal type '%s' does not match expected type '%s'",
-						recordType.Name, expectedRecordType.Name)
+					a.addError("record literal type '%s' does not match expected type '%s' at %s",
+						recordType.Name, expectedRecordType.Name, lit.Pos().String())
 					return nil
 				}
 			}
@@ -290,14 +290,14 @@ func (a *Analyzer) analyzeRecordLiteral(lit *ast.RecordLiteralExpression, expect
 		// Anonymous record literal: (x: 10; y: 20)
 		// Requires expectedType from context
 		if expectedType == nil {
-			a.addError("anonymous record literal requires type context (use explicit type annotation or typed literal)")
+			a.addError("anonymous record literal requires type context (use explicit type annotation or typed literal) at %s", lit.Pos().String())
 			return nil
 		}
 
 		var ok bool
 		recordType, ok = types.GetUnderlyingType(expectedType).(*types.RecordType)
 		if !ok {
-			a.addError("record literal requires a record type, got %s", expectedType.String())
+			a.addError("record literal requires a record type, got %s at %s", expectedType.String(), lit.Pos().String())
 			return nil
 		}
 	}
@@ -309,7 +309,7 @@ func (a *Analyzer) analyzeRecordLiteral(lit *ast.RecordLiteralExpression, expect
 	for _, field := range lit.Fields {
 		// Skip positional fields (not yet implemented)
 		if field.Name == nil {
-			a.addError("positional record field initialization not yet supported")
+			a.addError("positional record field initialization not yet supported at %s", field.Pos().String())
 			continue
 		}
 
@@ -319,7 +319,7 @@ func (a *Analyzer) analyzeRecordLiteral(lit *ast.RecordLiteralExpression, expect
 
 		// Check for duplicate field initialization
 		if initializedFields[lowerFieldName] {
-			a.addError("duplicate field '%s' in record literal", fieldName)
+			a.addError("duplicate field '%s' in record literal at %s", fieldName, field.Name.Token.Pos.String())
 			continue
 		}
 		initializedFields[lowerFieldName] = true
@@ -327,7 +327,7 @@ func (a *Analyzer) analyzeRecordLiteral(lit *ast.RecordLiteralExpression, expect
 		// Check if field exists in record type
 		expectedFieldType, exists := recordType.Fields[lowerFieldName]
 		if !exists {
-			a.addError("field '%s' does not exist in record type '%s'", fieldName, recordType.Name)
+			a.addError("field '%s' does not exist in record type '%s' at %s", fieldName, recordType.Name, field.Name.Token.Pos.String())
 			continue
 		}
 
@@ -339,8 +339,8 @@ func (a *Analyzer) analyzeRecordLiteral(lit *ast.RecordLiteralExpression, expect
 
 		// Check type compatibility
 		if !a.canAssign(actualType, expectedFieldType) {
-			a.addError("cannot assign %s to %s in field '%s'",
-				actualType.String(), expectedFieldType.String(), fieldName)
+			a.addError("cannot assign %s to %s in field '%s' at %s",
+				actualType.String(), expectedFieldType.String(), fieldName, field.Value.Pos().String())
 		}
 	}
 
@@ -352,7 +352,7 @@ func (a *Analyzer) analyzeRecordLiteral(lit *ast.RecordLiteralExpression, expect
 				// Field has a default initializer, so it's not required in the literal
 				continue
 			}
-			a.addError("missing required field '%s' in record literal", fieldName)
+			a.addError("missing required field '%s' in record literal at %s", fieldName, lit.Pos().String())
 		}
 	}
 
@@ -535,7 +535,7 @@ func (a *Analyzer) analyzeSetLiteralWithContext(lit *ast.SetLiteral, expectedTyp
 
 	// If we have an expected set type, verify the element type matches
 	if expectedSetType != nil {
-		if !elementType.Equals(expectedSetType.ElementType) {
+		if !setElementsCompatible(elementType, expectedSetType.ElementType) {
 			a.addError("type mismatch in set literal: expected set of %s, got set of %s at %s",
 				expectedSetType.ElementType.String(), elementType.String(), lit.Token.Pos.String())
 			return expectedSetType // Return expected type to continue analysis
@@ -28,6 +28,23 @@ func (a *Analyzer) analyzeMethodCallExpression(expr *ast.MethodCallExpression) t
 		return nil
 	}
 
+	// Unit function call: UnitName.Function(args)
+	if unitName, isUnit := a.unitNamespace(expr.Object); isUnit {
+		if expr.HasNamedArguments() {
+			a.addError("named arguments are only supported in calls to declared functions and methods at %s", expr.Token.Pos.String())
+			return nil
+		}
+		sym := a.resolveUnitMember(unitName, expr.Method)
+		if sym == nil {
+			return nil
+		}
+		return a.analyzeMethodCallArguments(&ast.CallExpression{
+			TypedExpressionBase: expr.TypedExpressionBase,
+			Function:            expr.Method,
+			Arguments:           expr.Arguments,
+		}, sym.Type)
+	}
+
 	// Default namespace: `Default.PrintLn(x)` resolves the member against the
 	// global scope (builtins / top-level routines), bypassing local/class
 	// members. Rewrite to a plain global call and reuse call analysis.
@@ -41,7 +58,7 @@ func (a *Analyzer) analyzeMethodCallExpression(expr *ast.MethodCallExpression) t
 	}
 
 	// Analyze the object expression
-	objectType := a.analyzeExpression(expr.Object)
+	objectType := a.analyzeMemberReceiver(expr.Object, expr.Method)
 	if objectType == nil {
 		// Error already reported
 		return nil
@@ -65,6 +82,15 @@ func (a *Analyzer) analyzeMethodCallExpression(expr *ast.MethodCallExpression) t
 		}
 	}
 
+	// Named arguments are bound to parameter order; the call is then checked
+	// as an ordinary positional one.
+	if expr.HasNamedArguments() {
+		expr = a.bindNamedMethodArguments(expr, objectType)
+		if expr == nil {
+			return nil
+		}
+	}
+
 	// Check if object is an interface type
 	if interfaceType, ok := objectType.(*types.InterfaceType); ok {
 		// Look up method in interface (including inherited methods from parent interfaces)
@@ -86,7 +112,7 @@ func (a *Analyzer) analyzeMethodCallExpression(expr *ast.MethodCallExpression) t
 		}
 
 		// Validate arguments
-		if len(expr.Arguments) != len(methodType.Parameters) {
+		if !acceptsArgumentCount(methodType, len(expr.Arguments)) {
 			a.addError("method '%s' expects %d arguments, got %d at %s",
 				methodName, len(methodType.Parameters), len(expr.Arguments),
 				expr.Token.Pos.String())
@@ -97,7 +123,7 @@ func (a *Analyzer) analyzeMethodCallExpression(expr *ast.MethodCallExpression) t
 		for i, arg := range expr.Arguments {
 			argType := a.analyzeExpression(arg)
 			expectedType := methodType.Parameters[i]
-			if argType != nil && !a.canAssign(argType, expectedType) {
+			if argType != nil && !a.argumentMatchesParameter(argType, expectedType, false) {
 				a.addError("argument %d to method '%s' has type %s, expected %s at %s",
 					i+1, methodName, argType.String(), expectedType.String(),
 					expr.Token.Pos.String())
@@ -223,8 +249,7 @@ func (a *Analyzer) analyzeMethodCallExpression(expr *ast.MethodCallExpression) t
 			}
 
 			// Validate method arguments (defaulted parameters are optional)
-			if len(expr.Arguments) > len(method.Parameters) ||
-				len(expr.Arguments) < requiredParamCount(method) {
+			if !acceptsArgumentCount(method, len(expr.Arguments)) {
 				a.addError("record method '%s' expects %d arguments, got %d at %s",
 					methodName, len(method.Parameters), len(expr.Arguments),
 					expr.Token.Pos.String())
@@ -236,7 +261,7 @@ func (a *Analyzer) analyzeMethodCallExpression(expr *ast.MethodCallExpression) t
 			for i, arg := range expr.Arguments {
 				expectedType := method.Parameters[i]
 				argType := a.analyzeExpressionWithExpectedType(arg, expectedType)
-				if argType != nil && !a.canAssign(argType, expectedType) {
+				if argType != nil && !a.argumentMatchesParameter(argType, expectedType, false) {
 					a.addError("argument %d to record method '%s' has type %s, expected %s at %s",
 						i+1, methodName, argType.String(), expectedType.String(),
 						expr.Token.Pos.String())
@@ -379,8 +404,7 @@ func (a *Analyzer) analyzeMethodCallExpression(expr *ast.MethodCallExpression) t
 
 		methodType := selectedInfo.Signature
 
-		if len(expr.Arguments) > len(methodType.Parameters) ||
-			len(expr.Arguments) < requiredParamCount(methodType) {
+		if !acceptsArgumentCount(methodType, len(expr.Arguments)) {
 			a.addError("constructor '%s' of class '%s' expects %d arguments, got %d at %s",
 				methodName, classType.Name, len(methodType.Parameters), len(expr.Arguments),
 				expr.Token.Pos.String())
@@ -390,7 +414,7 @@ func (a *Analyzer) analyzeMethodCallExpression(expr *ast.MethodCallExpression) t
 		for i, arg := range expr.Arguments {
 			expectedType := methodType.Parameters[i]
 			argType := a.analyzeExpressionWithExpectedType(arg, expectedType)
-			if argType != nil && !a.canAssign(argType, expectedType) {
+			if argType != nil && !a.argumentMatchesParameter(argType, expectedType, false) {
 				a.addError("argument %d to constructor '%s' of class '%s' has type %s, expected %s at %s",
 					i+1, methodName, classType.Name, argType.String(), expectedType.String(),
 					expr.Token.Pos.String())
@@ -520,7 +544,7 @@ func (a *Analyzer) analyzeMethodCallExpression(expr *ast.MethodCallExpression) t
 			visibility, hasVisibility = selectedOverload.Visibility, true
 		}
 		if hasVisibility && !a.checkVisibility(methodOwner, visibility, methodName, "method") {
-			a.addStructuredError(NewVisibilityScopeError(expr.Method.Token.Pos, expr.Method.Value))
+			a.addStructuredError(NewVisibilityScopeError(expr.Method.Token.Pos, expr.Method.Value, visibility))
 			if methodOwner.HasConstructor(methodName) {
 				return classType
 			}
@@ -531,7 +555,7 @@ func (a *Analyzer) analyzeMethodCallExpression(expr *ast.MethodCallExpression) t
 	// For non-overloaded methods, check argument types (overloaded methods already validated by ResolveOverload)
 	if len(overloads) <= 1 {
 		// Check argument count
-		if len(expr.Arguments) != len(methodType.Parameters) {
+		if !acceptsArgumentCount(methodType, len(expr.Arguments)) {
 			a.addError("method '%s' of class '%s' expects %d arguments, got %d at %s",
 				methodName, classType.Name, len(methodType.Parameters), len(expr.Arguments),
 				expr.Token.Pos.String())
@@ -542,7 +566,7 @@ func (a *Analyzer) analyzeMethodCallExpression(expr *ast.MethodCallExpression) t
 		for i, arg := range expr.Arguments {
 			argType := a.analyzeExpression(arg)
 			expectedType := methodType.Parameters[i]
-			if argType != nil && !a.canAssign(argType, expectedType) {
+			if argType != nil && !a.argumentMatchesParameter(argType, expectedType, false) {
 				a.addError("argument %d to method '%s' of class '%s' has type %s, expected %s at %s",
 					i+1, methodName, classType.Name, argType.String(), expectedType.String(),
 					expr.Token.Pos.String())
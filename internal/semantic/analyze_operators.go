@@ -1,17 +1,71 @@
 package semantic
 
 import (
+	"fmt"
+	"slices"
+	"strconv"
 	"strings"
 
 	"github.com/cwbudde/go-dws/internal/types"
 	"github.com/cwbudde/go-dws/pkg/ast"
 	"github.com/cwbudde/go-dws/pkg/ident"
+	"github.com/cwbudde/go-dws/pkg/token"
 )
 
 // ============================================================================
 // Operator Analysis (Stage 8)
 // ============================================================================
 
+// overloadableOperators maps each operator that a global or class operator
+// declaration can overload to the numbers of operands it takes.
+var overloadableOperators = map[string][]int{
+	"+": {1, 2}, "-": {1, 2}, "not": {1},
+	"*": {2}, "/": {2}, "%": {2}, "^": {2}, "**": {2},
+	"=": {2}, "<>": {2}, "<": {2}, ">": {2}, "<=": {2}, ">=": {2},
+	"==": {2}, "===": {2}, "!=": {2},
+	"<<": {2}, ">>": {2}, "|": {2}, "||": {2}, "&": {2}, "&&": {2},
+	"in": {2}, "??": {2},
+}
+
+// classOnlyOperators are the compound assignments, which only class
+// operators can overload.
+var classOnlyOperators = map[string]bool{
+	"+=": true, "-=": true, "*=": true, "/=": true, "%=": true, "^=": true,
+}
+
+// checkOverloadableOperator reports an error unless decl overloads an
+// operator that can be overloaded, and for a global operator, unless it
+// declares as many operands as the operator takes and a return type.
+// Conversion operators are checked elsewhere. Returns true if the
+// declaration passed.
+func (a *Analyzer) checkOverloadableOperator(decl *ast.OperatorDecl) bool {
+	symbol := ident.Normalize(decl.OperatorSymbol)
+	arities, ok := overloadableOperators[symbol]
+	if !ok && !(decl.Kind == ast.OperatorKindClass && classOnlyOperators[symbol]) {
+		a.addError("operator '%s' cannot be overloaded at %s", decl.OperatorSymbol, decl.OperatorToken.Pos.String())
+		return false
+	}
+	if decl.Kind == ast.OperatorKindClass {
+		// Class operator operands are combined with the declaring type later
+		return true
+	}
+
+	if !slices.Contains(arities, decl.Arity) {
+		expected := make([]string, len(arities))
+		for i, arity := range arities {
+			expected[i] = strconv.Itoa(arity)
+		}
+		a.addError("operator '%s' expects %s operands, got %d at %s",
+			decl.OperatorSymbol, strings.Join(expected, " or "), decl.Arity, decl.Token.Pos.String())
+		return false
+	}
+	if decl.ReturnType == nil {
+		a.addError("operator '%s' must specify a return type at %s", decl.OperatorSymbol, decl.Token.Pos.String())
+		return false
+	}
+	return true
+}
+
 func (a *Analyzer) analyzeOperatorDecl(decl *ast.OperatorDecl) {
 	if decl == nil {
 		return
@@ -22,6 +76,10 @@ func (a *Analyzer) analyzeOperatorDecl(decl *ast.OperatorDecl) {
 		return
 	}
 
+	if decl.Kind != ast.OperatorKindConversion && !a.checkOverloadableOperator(decl) {
+		return
+	}
+
 	operandTypes := make([]types.Type, decl.Arity)
 	for i, operand := range decl.OperandTypes {
 		typ, err := a.resolveOperatorType(operand.String())
@@ -92,6 +150,7 @@ func (a *Analyzer) analyzeOperatorDecl(decl *ast.OperatorDecl) {
 			From:    operandTypes[0],
 			To:      resultType,
 			Binding: decl.Binding.Value,
+			Pos:     decl.Token.Pos,
 			Kind:    kind,
 		}
 
@@ -129,6 +188,16 @@ func (a *Analyzer) resolveBinaryOperator(operator string, leftType, rightType ty
 			return sig, true
 		}
 	}
+	if recordType, ok := leftType.(*types.RecordType); ok {
+		if sig, found := recordType.LookupOperator(operator, []types.Type{leftType, rightType}); found {
+			return sig, true
+		}
+	}
+	if recordType, ok := rightType.(*types.RecordType); ok {
+		if sig, found := recordType.LookupOperator(operator, []types.Type{leftType, rightType}); found {
+			return sig, true
+		}
+	}
 	if sig, found := a.globalOperators.Lookup(operator, []types.Type{leftType, rightType}); found {
 		return sig, true
 	}
@@ -141,125 +210,277 @@ func (a *Analyzer) resolveUnaryOperator(operator string, operand types.Type) (*t
 			return sig, true
 		}
 	}
+	if recordType, ok := operand.(*types.RecordType); ok {
+		if sig, found := recordType.LookupOperator(operator, []types.Type{operand}); found {
+			return sig, true
+		}
+	}
 	if sig, found := a.globalOperators.Lookup(operator, []types.Type{operand}); found {
 		return sig, true
 	}
 	return nil, false
 }
 
+// registerClassOperators registers the class operators declared in a class.
 func (a *Analyzer) registerClassOperators(classType *types.ClassType, decl *ast.ClassDecl) {
 	for _, opDecl := range decl.Operators {
-		if opDecl == nil {
-			continue
+		a.registerOwnerOperator(&operatorOwner{
+			typ:       classType,
+			kind:      "class",
+			overloads: classType.GetMethodOverloads,
+			register:  classType.RegisterOperator,
+		}, opDecl)
+	}
+}
+
+// registerRecordOperators registers the class operators declared in a record.
+func (a *Analyzer) registerRecordOperators(recordType *types.RecordType, decl *ast.RecordDecl) {
+	overloads := func(name string) []*types.MethodInfo {
+		if infos := recordType.GetClassMethodOverloads(name); len(infos) > 0 {
+			return infos
 		}
+		return recordType.GetMethodOverloads(name)
+	}
+	for _, opDecl := range decl.Operators {
+		a.registerOwnerOperator(&operatorOwner{
+			typ:       recordType,
+			kind:      "record",
+			overloads: overloads,
+			register:  recordType.RegisterOperator,
+		}, opDecl)
+	}
+}
 
-		if opDecl.Binding == nil {
-			a.addError("class operator '%s' missing binding in class '%s' at %s",
-				opDecl.OperatorSymbol, classType.Name, opDecl.Token.Pos.String())
-			continue
+// operatorOwner describes the class or record declaring a class operator.
+type operatorOwner struct {
+	typ       types.Type
+	overloads func(name string) []*types.MethodInfo
+	register  func(*types.OperatorSignature) error
+	kind      string // "class" or "record", used in diagnostics
+}
+
+// registerOwnerOperator validates a class operator against its binding method and
+// registers it. Implicit and Explicit operators become conversions owned by the
+// declaring type; every other operator is registered on the type itself.
+func (a *Analyzer) registerOwnerOperator(owner *operatorOwner, opDecl *ast.OperatorDecl) {
+	if opDecl == nil {
+		return
+	}
+	ownerName := owner.typ.String()
+
+	isConversion := ident.Equal(opDecl.OperatorSymbol, "implicit") || ident.Equal(opDecl.OperatorSymbol, "explicit")
+	if !isConversion && !a.checkOverloadableOperator(opDecl) {
+		return
+	}
+
+	if opDecl.Binding == nil {
+		a.addError("class operator '%s' missing binding in %s '%s' at %s",
+			opDecl.OperatorSymbol, owner.kind, ownerName, opDecl.Token.Pos.String())
+		return
+	}
+
+	// Look up method using overload system
+	methodOverloads := owner.overloads(opDecl.Binding.Value)
+	if len(methodOverloads) == 0 {
+		a.addError("binding '%s' for class operator '%s' not found in %s '%s' at %s",
+			opDecl.Binding.Value, opDecl.OperatorSymbol, owner.kind, ownerName, opDecl.Token.Pos.String())
+		return
+	}
+
+	// For class operators, use the first matching overload
+	// (In the future, we could support overloaded operators with different parameter types)
+	methodInfo := methodOverloads[0]
+	methodType := methodInfo.Signature
+	isClassMethod := methodInfo.IsClassMethod
+
+	operandTypes := make([]types.Type, 0, len(opDecl.OperandTypes)+1)
+	includesOwner := false
+	for _, operand := range opDecl.OperandTypes {
+		resolved, err := a.resolveOperatorType(operand.String())
+		if err != nil {
+			a.addError("unknown type '%s' in class operator declaration at %s", operand.String(), opDecl.Token.Pos.String())
+			return
 		}
+		if resolved.Equals(owner.typ) {
+			includesOwner = true
+		}
+		operandTypes = append(operandTypes, resolved)
+	}
 
-		// Look up method using overload system
-		methodOverloads := classType.GetMethodOverloads(opDecl.Binding.Value)
-		if len(methodOverloads) == 0 {
-			a.addError("binding '%s' for class operator '%s' not found in class '%s' at %s",
-				opDecl.Binding.Value, opDecl.OperatorSymbol, classType.Name, opDecl.Token.Pos.String())
-			continue
+	if isConversion {
+		a.registerOwnerConversion(owner, opDecl, methodType, isClassMethod, operandTypes)
+		return
+	}
+
+	// A single operand type is always the operand combined with the declaring
+	// type, e.g. `class operator < TVersion uses Less` compares two TVersion.
+	if len(operandTypes) == 1 || !includesOwner {
+		if ident.Equal(opDecl.OperatorSymbol, "in") {
+			operandTypes = append(operandTypes, owner.typ)
+		} else {
+			operandTypes = append([]types.Type{owner.typ}, operandTypes...)
 		}
+	}
 
-		// For class operators, use the first matching overload
-		// (In the future, we could support overloaded operators with different parameter types)
-		methodInfo := methodOverloads[0]
-		methodType := methodInfo.Signature
-
-		isClassMethod := methodInfo.IsClassMethod
-
-		operandTypes := make([]types.Type, 0, len(opDecl.OperandTypes)+1)
-		includesClass := false
-		for _, operand := range opDecl.OperandTypes {
-			resolved, err := a.resolveOperatorType(operand.String())
-			if err != nil {
-				a.addError("unknown type '%s' in class operator declaration at %s", operand.String(), opDecl.Token.Pos.String())
-				includesClass = false
-				operandTypes = nil
+	selfIndex := -1
+	if !isClassMethod {
+		for idx, operandType := range operandTypes {
+			if operandType.Equals(owner.typ) {
+				selfIndex = idx
 				break
 			}
-			if resolved.Equals(classType) {
-				includesClass = true
-			}
-			operandTypes = append(operandTypes, resolved)
-		}
-		if operandTypes == nil {
-			continue
 		}
+	}
 
-		if !includesClass {
-			if ident.Equal(opDecl.OperatorSymbol, "in") {
-				operandTypes = append(operandTypes, classType)
-			} else {
-				operandTypes = append([]types.Type{classType}, operandTypes...)
-			}
-		}
+	expectedParams := len(operandTypes)
+	if !isClassMethod {
+		expectedParams--
+	}
+	if len(methodType.Parameters) != expectedParams {
+		a.addError("binding '%s' for class operator '%s' expects %d parameters, got %d at %s",
+			opDecl.Binding.Value, opDecl.OperatorSymbol, expectedParams, len(methodType.Parameters), opDecl.Token.Pos.String())
+		return
+	}
 
-		expectedParams := len(operandTypes)
-		if !isClassMethod {
-			expectedParams--
-		}
-		if len(methodType.Parameters) != expectedParams {
-			a.addError("binding '%s' for class operator '%s' expects %d parameters, got %d at %s",
-				opDecl.Binding.Value, opDecl.OperatorSymbol, expectedParams, len(methodType.Parameters), opDecl.Token.Pos.String())
+	paramIdx := 0
+	for idx, operandType := range operandTypes {
+		if idx == selfIndex {
 			continue
 		}
+		if !methodType.Parameters[paramIdx].Equals(operandType) {
+			a.addError("binding '%s' parameter %d type %s does not match operator operand type %s at %s",
+				opDecl.Binding.Value, paramIdx+1, methodType.Parameters[paramIdx].String(), operandType.String(), opDecl.Token.Pos.String())
+			return
+		}
+		paramIdx++
+	}
 
-		paramIdx := 0
-		mismatch := false
-		for _, operandType := range operandTypes {
-			if !isClassMethod && operandType.Equals(classType) {
-				continue
-			}
-			if paramIdx >= len(methodType.Parameters) {
-				a.addError("binding '%s' parameter count mismatch for operator '%s' at %s",
-					opDecl.Binding.Value, opDecl.OperatorSymbol, opDecl.Token.Pos.String())
-				mismatch = true
-				break
-			}
-			if !methodType.Parameters[paramIdx].Equals(operandType) {
-				a.addError("binding '%s' parameter %d type %s does not match operator operand type %s at %s",
-					opDecl.Binding.Value, paramIdx+1, methodType.Parameters[paramIdx].String(), operandType.String(), opDecl.Token.Pos.String())
-				mismatch = true
-				break
-			}
-			paramIdx++
+	resultType := methodType.ReturnType
+	if opDecl.ReturnType != nil {
+		var err error
+		resultType, err = a.resolveOperatorType(opDecl.ReturnType.String())
+		if err != nil {
+			a.addError("unknown return type '%s' in class operator declaration at %s", opDecl.ReturnType.String(), opDecl.Token.Pos.String())
+			return
 		}
-		if mismatch {
-			continue
+		if !methodType.ReturnType.Equals(resultType) {
+			a.addError("binding '%s' return type %s does not match operator return type %s at %s",
+				opDecl.Binding.Value, methodType.ReturnType.String(), resultType.String(), opDecl.Token.Pos.String())
+			return
 		}
+	}
 
-		resultType := methodType.ReturnType
-		if opDecl.ReturnType != nil {
-			var err error
-			resultType, err = a.resolveOperatorType(opDecl.ReturnType.String())
-			if err != nil {
-				a.addError("unknown return type '%s' in class operator declaration at %s", opDecl.ReturnType.String(), opDecl.Token.Pos.String())
-				continue
-			}
-			if !methodType.ReturnType.Equals(resultType) {
-				a.addError("binding '%s' return type %s does not match operator return type %s at %s",
-					opDecl.Binding.Value, methodType.ReturnType.String(), resultType.String(), opDecl.Token.Pos.String())
-				continue
-			}
+	sig := &types.OperatorSignature{
+		Operator:     opDecl.OperatorSymbol,
+		OperandTypes: operandTypes,
+		ResultType:   resultType,
+		Binding:      opDecl.Binding.Value,
+	}
+
+	if err := owner.register(sig); err != nil {
+		a.addError("class operator '%s' already defined for %s '%s' at %s",
+			opDecl.OperatorSymbol, owner.kind, ownerName, opDecl.Token.Pos.String())
+	}
+}
+
+// registerOwnerConversion registers a class operator Implicit or Explicit.
+// The operand is the source type and the return type the target, which
+// defaults to the declaring type. Either side must be the declaring type. The
+// binding is a class method taking the source value, or an instance method
+// without parameters when converting from the declaring type.
+func (a *Analyzer) registerOwnerConversion(owner *operatorOwner, opDecl *ast.OperatorDecl, methodType *types.FunctionType, isClassMethod bool, operandTypes []types.Type) {
+	ownerName := owner.typ.String()
+
+	if len(operandTypes) != 1 {
+		a.addError("conversion operator '%s' must have exactly one operand at %s", opDecl.OperatorSymbol, opDecl.Token.Pos.String())
+		return
+	}
+	from := operandTypes[0]
+	to := owner.typ
+	if opDecl.ReturnType != nil {
+		var err error
+		to, err = a.resolveOperatorType(opDecl.ReturnType.String())
+		if err != nil {
+			a.addError("unknown return type '%s' in class operator declaration at %s", opDecl.ReturnType.String(), opDecl.Token.Pos.String())
+			return
 		}
+	}
+	if !from.Equals(owner.typ) && !to.Equals(owner.typ) {
+		a.addError("conversion operator '%s' in %s '%s' must convert from or to '%s' at %s",
+			opDecl.OperatorSymbol, owner.kind, ownerName, ownerName, opDecl.Token.Pos.String())
+		return
+	}
 
-		sig := &types.OperatorSignature{
-			Operator:     opDecl.OperatorSymbol,
-			OperandTypes: operandTypes,
-			ResultType:   resultType,
-			Binding:      opDecl.Binding.Value,
+	var params []types.Type
+	if isClassMethod {
+		params = []types.Type{from}
+	} else if !from.Equals(owner.typ) {
+		a.addError("binding '%s' for conversion operator '%s' must be a class method at %s",
+			opDecl.Binding.Value, opDecl.OperatorSymbol, opDecl.Token.Pos.String())
+		return
+	}
+	if len(methodType.Parameters) != len(params) {
+		a.addError("binding '%s' for class operator '%s' expects %d parameters, got %d at %s",
+			opDecl.Binding.Value, opDecl.OperatorSymbol, len(params), len(methodType.Parameters), opDecl.Token.Pos.String())
+		return
+	}
+	if len(params) == 1 && !methodType.Parameters[0].Equals(from) {
+		a.addError("binding '%s' parameter 1 type %s does not match operator operand type %s at %s",
+			opDecl.Binding.Value, methodType.Parameters[0].String(), from.String(), opDecl.Token.Pos.String())
+		return
+	}
+	if methodType.ReturnType == nil || !methodType.ReturnType.Equals(to) {
+		returnName := "none"
+		if methodType.ReturnType != nil {
+			returnName = methodType.ReturnType.String()
 		}
+		a.addError("binding '%s' return type %s does not match operator return type %s at %s",
+			opDecl.Binding.Value, returnName, to.String(), opDecl.Token.Pos.String())
+		return
+	}
+
+	kind := types.ConversionExplicit
+	if ident.Equal(opDecl.OperatorSymbol, "implicit") {
+		kind = types.ConversionImplicit
+	}
+
+	sig := &types.ConversionSignature{
+		From:    from,
+		To:      to,
+		Owner:   owner.typ,
+		Binding: opDecl.Binding.Value,
+		Pos:     opDecl.Token.Pos,
+		Kind:    kind,
+	}
+
+	if err := a.conversionRegistry.Register(sig); err != nil {
+		a.addError("conversion from %s to %s already defined in %s '%s' at %s",
+			from.String(), to.String(), owner.kind, ownerName, opDecl.Token.Pos.String())
+	}
+}
+
+// checkImplicitConversionAmbiguity reports an error when more than one implicit
+// conversion operator converts from into to, e.g. a global operator and a class
+// operator declared in the target record. The error lists every candidate with
+// the position of its declaration in parentheses. Returns true if an error was reported.
+func (a *Analyzer) checkImplicitConversionAmbiguity(from, to types.Type, pos token.Position) bool {
+	if from == nil || to == nil || types.IsCompatible(from, to) {
+		return false
+	}
+	candidates := a.conversionRegistry.ImplicitCandidates(from, to)
+	if len(candidates) < 2 {
+		return false
+	}
 
-		if err := classType.RegisterOperator(sig); err != nil {
-			a.addError("class operator '%s' already defined for class '%s' at %s",
-				opDecl.OperatorSymbol, classType.Name, opDecl.Token.Pos.String())
+	descriptions := make([]string, len(candidates))
+	for i, candidate := range candidates {
+		if candidate.Owner != nil {
+			descriptions[i] = fmt.Sprintf("%s.%s (%s)", candidate.Owner.String(), candidate.Binding, candidate.Pos.String())
+		} else {
+			descriptions[i] = fmt.Sprintf("operator implicit uses %s (%s)", candidate.Binding, candidate.Pos.String())
 		}
 	}
+	a.addError("ambiguous implicit conversion from %s to %s, candidates: %s at %s",
+		from.String(), to.String(), strings.Join(descriptions, ", "), pos.String())
+	return true
 }
@@ -154,6 +154,7 @@ func (a *Analyzer) analyzePropertyDecl(prop *ast.PropertyDecl, classType *types.
 		IsIndexed:       isIndexed,
 		IsDefault:       prop.IsDefault,
 		IsClassProperty: prop.IsClassProperty,
+		IndexParamTypes: indexParamTypes,
 	}
 	if prop.IndexValue != nil {
 		propInfo.HasIndexValue = true
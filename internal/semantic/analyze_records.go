@@ -61,6 +61,10 @@ func (a *Analyzer) analyzeRecordDecl(decl *ast.RecordDecl) {
 			typeName := getTypeExpressionName(field.Type)
 			fieldType, err = a.resolveTypeExpression(field.Type)
 			if err != nil {
+				if a.isCyclicType(recordName) {
+					// Already reported as an illegal cyclic type definition.
+					continue
+				}
 				a.addError("unknown type '%s' for field '%s' in record '%s' at %s",
 					typeName, fieldName, recordName, field.Token.Pos.String())
 				continue
@@ -121,7 +125,7 @@ func (a *Analyzer) analyzeRecordDecl(decl *ast.RecordDecl) {
 			constType = ct
 
 			// Check if value type is compatible with declared type
-			if !types.IsAssignableFrom(constType, constValueType) {
+			if !a.canAssign(constValueType, constType) {
 				a.addError("constant '%s' type mismatch: expected %s, got %s at %s",
 					constName, constType.String(), constValueType.String(), constant.Token.Pos.String())
 				continue
@@ -176,7 +180,7 @@ func (a *Analyzer) analyzeRecordDecl(decl *ast.RecordDecl) {
 		// Validate initializer if present
 		if classVar.InitValue != nil && classVar.Type != nil {
 			initType := a.analyzeExpression(classVar.InitValue)
-			if initType != nil && !types.IsAssignableFrom(varType, initType) {
+			if initType != nil && !a.canAssign(initType, varType) {
 				a.addError("class variable '%s' initializer type mismatch: expected %s, got %s at %s",
 					varName, varType.String(), initType.String(), classVar.Token.Pos.String())
 			}
@@ -186,7 +190,9 @@ func (a *Analyzer) analyzeRecordDecl(decl *ast.RecordDecl) {
 		recordType.ClassVarNames[lowerVarName] = varName
 	}
 
-	// Process methods if any
+	// Process methods if any. Inline bodies are analyzed once every signature
+	// and class operator is known, so bodies can use the record's operators.
+	var inlineMethods []*ast.FunctionDecl
 	for _, method := range decl.Methods {
 		methodName := method.Name.Value
 		lowerMethodName := ident.Normalize(methodName)
@@ -236,6 +242,13 @@ func (a *Analyzer) analyzeRecordDecl(decl *ast.RecordDecl) {
 		} else {
 			funcType = types.NewProcedureType(paramTypes)
 		}
+		if len(paramTypes) == len(method.Parameters) {
+			for i, param := range method.Parameters {
+				funcType.ParamNames[i] = param.Name.Value
+			}
+			funcType.DefaultValues = parameterDefaultValues(method.Parameters)
+			a.checkParameterDefaults(method.Parameters, paramTypes)
+		}
 
 		// Create MethodInfo for overload tracking
 		methodInfo := &types.MethodInfo{
@@ -262,12 +275,18 @@ func (a *Analyzer) analyzeRecordDecl(decl *ast.RecordDecl) {
 				recordType.MethodOverloads[lowerMethodName], methodInfo)
 		}
 
-		// Analyze method body if present (inline method)
 		if method.Body != nil {
-			a.analyzeRecordMethodBody(method, recordType)
+			inlineMethods = append(inlineMethods, method)
 		}
 	}
 
+	a.registerRecordOperators(recordType, decl)
+
+	// Analyze inline method bodies
+	for _, method := range inlineMethods {
+		a.analyzeRecordMethodBody(method, recordType)
+	}
+
 	// Process properties if any
 	for _, prop := range decl.Properties {
 		propName := prop.Name.Value
@@ -316,6 +335,8 @@ func (a *Analyzer) analyzeRecordDecl(decl *ast.RecordDecl) {
 	}
 
 	// Record type already registered above (after fields, before methods)
+
+	a.bindHostRecordGlobals(recordType)
 }
 
 func (a *Analyzer) recordFieldContainsRecordByValue(fieldType types.Type, target *types.RecordType, seen map[*types.RecordType]bool) bool {
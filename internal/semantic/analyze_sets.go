@@ -25,21 +25,40 @@ func (a *Analyzer) analyzeSetDecl(decl *ast.SetDecl) {
 		return
 	}
 
-	// Resolve the element type (must be an enum type)
-	// Validate set element types (must be enum or small integer range)
+	// Resolve the element type (any ordinal type: enum, subrange, Integer, ...)
 	elementTypeName := getTypeExpressionName(decl.ElementType)
-
-	// First check if it's an enum type
-	enumType := a.getEnumType(elementTypeName)
-	if enumType == nil {
+	elementType, err := a.resolveType(elementTypeName)
+	if err != nil {
 		a.addError("unknown type '%s' at %s", elementTypeName, decl.Token.Pos.String())
 		return
 	}
+	if !types.IsOrdinalType(elementType) {
+		a.addError("set element type must be ordinal, got %s at %s", elementType.String(), decl.Token.Pos.String())
+		return
+	}
 
 	// Create the set type
-	setType := types.NewSetType(enumType)
+	setType := types.NewSetType(elementType)
 
 	// Register the set type
 	// Use lowercase key for case-insensitive lookup
 	a.registerTypeWithPos(setName, setType, decl.Token.Pos)
 }
+
+// ordinalBaseType returns the base ordinal type of a subrange, looking
+// through aliases, or the underlying type of t otherwise. Set elements are
+// matched by it, so `[-1, 3]` and `x in s` work for a set over `-5..5`, and
+// subrange operands take part in expressions as values of their base type.
+func ordinalBaseType(t types.Type) types.Type {
+	t = types.GetUnderlyingType(t)
+	if subrange, ok := t.(*types.SubrangeType); ok && subrange.BaseType != nil {
+		return types.GetUnderlyingType(subrange.BaseType)
+	}
+	return t
+}
+
+// setElementsCompatible reports whether a value of type elem can be an
+// element of a set of target.
+func setElementsCompatible(elem, target types.Type) bool {
+	return elem.Equals(target) || ordinalBaseType(elem).Equals(ordinalBaseType(target))
+}
@@ -58,6 +58,9 @@ func (a *Analyzer) analyzeStatement(stmt ast.Statement) {
 		a.analyzeExpression(s.Expression)
 	case *ast.EmptyStatement:
 		return
+	case *ast.InvalidStatement:
+		// The parser already reported why this statement is invalid.
+		return
 	case *ast.BlockStatement:
 		a.analyzeBlock(s)
 	case *ast.IfStatement:
@@ -222,6 +225,7 @@ func (a *Analyzer) analyzeVarDecl(stmt *ast.VarDeclStatement) {
 				))
 				return
 			}
+			a.checkImplicitConversionAmbiguity(initType, varType, stmt.Token.Pos)
 		}
 	}
 
@@ -318,6 +322,7 @@ func (a *Analyzer) analyzeConstDecl(stmt *ast.ConstDecl) {
 
 	// Add constant to symbol table with its compile-time value
 	a.symbols.DefineConst(stmt.Name.Value, constType, constValue, stmt.Name.Token.Pos)
+	a.recordFoldedConst(stmt)
 }
 
 // analyzeAssignment analyzes an assignment statement
@@ -363,6 +368,14 @@ func (a *Analyzer) analyzeAssignment(stmt *ast.AssignmentStatement) {
 			return
 		}
 
+		// While a lambda's return type is inferred, the values assigned to
+		// Result are candidates for it rather than checked against it
+		if a.lambdaResultTypes != nil && !isCompound && ident.Equal(target.Value, "Result") {
+			a.recordSymbolUsage("Result", target.Token.Pos)
+			a.recordLambdaResult(a.analyzeExpression(stmt.Value))
+			return
+		}
+
 		sym, ok := a.symbols.Resolve(target.Value)
 
 		if !ok && a.currentClass != nil {
@@ -377,7 +390,7 @@ func (a *Analyzer) analyzeAssignment(stmt *ast.AssignmentStatement) {
 					lowerFieldName := ident.Normalize(target.Value)
 					visibility, hasVisibility := fieldOwner.FieldVisibility[lowerFieldName]
 					if hasVisibility && !a.checkVisibility(fieldOwner, visibility, target.Value, "field") {
-						a.addStructuredError(NewVisibilityScopeError(target.Token.Pos, target.Value))
+						a.addStructuredError(NewVisibilityScopeError(target.Token.Pos, target.Value, visibility))
 						return
 					}
 					a.recordClassFieldUsage(fieldOwner, target.Value)
@@ -439,7 +452,18 @@ func (a *Analyzer) analyzeAssignment(stmt *ast.AssignmentStatement) {
 		}
 
 		if !ok {
-			a.addStructuredError(NewUndefinedVariable(stmt.Token.Pos, target.Value))
+			if ident.Equal(target.Value, "Result") {
+				a.addStructuredError(NewResultOutsideFunctionError(target.Token.Pos))
+			} else {
+				a.addStructuredError(NewUndefinedVariable(stmt.Token.Pos, target.Value))
+			}
+			return
+		}
+
+		// A routine's name only aliases Result inside its own body (handled above)
+		if _, isRoutine := sym.Type.(*types.FunctionType); isRoutine {
+			a.addError("cannot assign to function '%s' outside its own body at %s",
+				target.Value, stmt.Token.Pos.String())
 			return
 		}
 
@@ -450,7 +474,7 @@ func (a *Analyzer) analyzeAssignment(stmt *ast.AssignmentStatement) {
 		// Record the target's declared type on the identifier node so runtime
 		// behaviour that depends on the declared (not current) type — e.g.
 		// auto-boxing a scalar into a JSONVariant — can consult it.
-		if sym.Type != nil {
+		if sym.Type != nil && (a.lambdaResultTypes == nil || !ident.Equal(target.Value, "Result")) {
 			a.semanticInfo.SetType(target, &ast.TypeAnnotation{Token: target.Token, Name: sym.Type.String()})
 		}
 
@@ -506,13 +530,15 @@ func (a *Analyzer) analyzeAssignment(stmt *ast.AssignmentStatement) {
 		if !usesClassOperator && !a.canAssign(valueType, sym.Type) {
 			pos := assignmentMismatchPos(stmt.Value, stmt.Token.Pos, sym.Type, valueType)
 			a.addError("%s", errors.FormatCannotAssign(valueType.String(), sym.Type.String(), pos.Line, pos.Column))
+		} else if !usesClassOperator {
+			a.checkImplicitConversionAmbiguity(valueType, sym.Type, stmt.Token.Pos)
 		}
 
 	case *ast.MemberAccessExpression:
 		// Member assignment: obj.field := value or obj.field += value
 
 		// Check if this is an assignment to a class constant (which is not allowed)
-		objectType := a.analyzeExpression(target.Object)
+		objectType := a.analyzeMemberReceiver(target.Object, target.Member)
 		if objectType != nil {
 			memberName := ident.Normalize(target.Member.Value)
 			objectTypeResolved := types.GetUnderlyingType(objectType)
@@ -524,6 +550,22 @@ func (a *Analyzer) analyzeAssignment(stmt *ast.AssignmentStatement) {
 				objectTypeResolved = metaclassType.ClassType
 			}
 
+			// Interface properties are only writable through a setter.
+			if ifaceType, ok := objectTypeResolved.(*types.InterfaceType); ok {
+				if propInfo := ifaceType.GetProperty(memberName); propInfo != nil {
+					if isCompound && propInfo.ReadKind == types.PropAccessNone {
+						a.addStructuredError(NewWriteOnlyPropertyError(target.Member.Token.Pos, target.Member.Value))
+						return
+					}
+					if propInfo.WriteKind == types.PropAccessNone {
+						a.addStructuredError(NewReadOnlyPropertyError(target.Member.Token.Pos, target.Member.Value))
+						return
+					}
+					a.analyzePropertyAssignmentValue(stmt, target, propInfo, isCompound)
+					return
+				}
+			}
+
 			// Check if it's a class constant
 			if classType, ok := objectTypeResolved.(*types.ClassType); ok {
 				if constType := a.findClassConstantWithVisibility(classType, memberName, stmt.Token.Pos.String()); constType != nil {
@@ -571,23 +613,7 @@ func (a *Analyzer) analyzeAssignment(stmt *ast.AssignmentStatement) {
 						}
 					}
 
-					valueType := a.analyzeExpressionWithExpectedType(stmt.Value, propInfo.Type)
-					if valueType == nil {
-						return
-					}
-
-					usesClassOperator := false
-					if isCompound {
-						valid, classOp := a.isCompoundOperatorValid(stmt.Operator, propInfo.Type, valueType, stmt.Token.Pos)
-						if !valid {
-							return
-						}
-						usesClassOperator = classOp
-					}
-
-					if !usesClassOperator && !a.canAssign(valueType, propInfo.Type) {
-						a.addStructuredError(NewPropertyValueTypeMismatchError(target.Member.Token.Pos, propInfo.Type.String(), valueType.String()))
-					}
+					a.analyzePropertyAssignmentValue(stmt, target, propInfo, isCompound)
 					return
 				}
 			}
@@ -623,15 +649,32 @@ func (a *Analyzer) analyzeAssignment(stmt *ast.AssignmentStatement) {
 	case *ast.IndexExpression:
 		// Array index assignment: arr[i] := value or arr[i] += value
 		// Analyze the target to ensure it's valid
+		prevIndexTarget := a.indexAssignTarget
+		a.indexAssignTarget = target
 		baseType := a.analyzeExpression(target.Left)
 		if isArrayOfConstType(baseType) {
+			a.indexAssignTarget = prevIndexTarget
 			a.addError("Cannot assign a value to the left-side argument at %s", stmt.Token.Pos.String())
 			return
 		}
 		targetType := a.analyzeExpression(target)
+		a.indexAssignTarget = prevIndexTarget
 		if targetType == nil {
 			return
 		}
+		// Writing through an indexed property (obj.Prop[i] or a default
+		// property obj[i]) requires a write accessor, and a compound
+		// assignment also reads it first.
+		if prop := a.indexedPropertyWriteTarget(target, baseType); prop != nil {
+			if isCompound && prop.readKind == types.PropAccessNone {
+				a.addStructuredError(NewWriteOnlyPropertyError(prop.pos, prop.name))
+				return
+			}
+			if prop.writeKind == types.PropAccessNone {
+				a.addStructuredError(NewReadOnlyPropertyError(prop.pos, prop.name))
+				return
+			}
+		}
 		if arrayType, ok := types.GetUnderlyingType(baseType).(*types.ArrayType); ok && arrayType.IsStatic() {
 			if idx, ok := a.constantArrayIndex(target.Index); ok {
 				low := *arrayType.LowBound
@@ -759,7 +802,11 @@ func (a *Analyzer) analyzeBlock(stmt *ast.BlockStatement) {
 	// Check if this block is a declaration section (types/const/var).
 	// Declaration sections should not create a new scope - their symbols
 	// must stay visible to subsequent statements in the enclosing scope.
-	shareEnclosingScope := a.isTypeDeclarationBlock(stmt) || a.isConstOrVarDeclBlock(stmt)
+	isTypeSection := a.isTypeDeclarationBlock(stmt)
+	shareEnclosingScope := isTypeSection || a.isConstOrVarDeclBlock(stmt)
+	if isTypeSection {
+		a.detectTypeDeclarationCycles(stmt.Statements)
+	}
 
 	// Create a new scope for the block (unless it's a type declaration block)
 	var oldSymbols *SymbolTable
@@ -849,7 +896,17 @@ func (a *Analyzer) analyzeIf(stmt *ast.IfStatement) {
 		return
 	}
 	// Check condition type
-	condType := a.analyzeExpression(stmt.Condition)
+	var condType types.Type
+	if stmt.InlineVar != nil {
+		// The inline variable is visible in both branches only
+		oldSymbols := a.symbols
+		a.symbols = NewEnclosedSymbolTable(oldSymbols)
+		defer func() { a.symbols = oldSymbols }()
+		defer a.emitUnusedWarningsForCurrentScope()
+		condType = a.analyzeInlineVarCondition(stmt.InlineVar, stmt.Condition)
+	} else {
+		condType = a.analyzeExpression(stmt.Condition)
+	}
 	if condType != nil && !isBooleanCompatible(condType) {
 		a.addError("if condition must be boolean, got %s at %s",
 			condType.String(), stmt.Token.Pos.String())
@@ -873,6 +930,8 @@ func (a *Analyzer) analyzeIf(stmt *ast.IfStatement) {
 		}
 		a.analyzeStatement(stmt.Alternative)
 	}
+
+	a.markDeadIfBranch(stmt)
 }
 
 // analyzeWhile analyzes a while statement
@@ -881,7 +940,17 @@ func (a *Analyzer) analyzeWhile(stmt *ast.WhileStatement) {
 		return
 	}
 	// Check condition type
-	condType := a.analyzeExpression(stmt.Condition)
+	var condType types.Type
+	if stmt.InlineVar != nil {
+		// The inline variable is visible in the body only
+		oldSymbols := a.symbols
+		a.symbols = NewEnclosedSymbolTable(oldSymbols)
+		defer func() { a.symbols = oldSymbols }()
+		defer a.emitUnusedWarningsForCurrentScope()
+		condType = a.analyzeInlineVarCondition(stmt.InlineVar, stmt.Condition)
+	} else {
+		condType = a.analyzeExpression(stmt.Condition)
+	}
 	if condType != nil && !isBooleanCompatible(condType) {
 		a.addError("while condition must be boolean, got %s at %s",
 			condType.String(), stmt.Token.Pos.String())
@@ -931,6 +1000,23 @@ func (a *Analyzer) analyzeWhile(stmt *ast.WhileStatement) {
 	a.analyzeStatement(stmt.Body)
 }
 
+// analyzeInlineVarCondition declares the inline variable of an if or while
+// statement in the current scope and returns the type of the condition that
+// names it. A variable of class, class-of or interface type is a condition
+// that holds when the variable is assigned, so Boolean is returned for it.
+func (a *Analyzer) analyzeInlineVarCondition(decl *ast.VarDeclStatement, condition ast.Expression) types.Type {
+	a.analyzeVarDecl(decl)
+	condType := a.analyzeExpression(condition)
+	if condType == nil {
+		return nil
+	}
+	switch types.GetUnderlyingType(condType).(type) {
+	case *types.ClassType, *types.ClassOfType, *types.InterfaceType:
+		return types.BOOLEAN
+	}
+	return condType
+}
+
 // analyzeRepeat analyzes a repeat-until statement
 func (a *Analyzer) analyzeRepeat(stmt *ast.RepeatStatement) {
 	if stmt == nil {
@@ -1110,7 +1196,7 @@ func (a *Analyzer) analyzeForIn(stmt *ast.ForInStatement) {
 	var elementType types.Type
 
 	if collectionType != nil {
-		switch ct := collectionType.(type) {
+		switch ct := types.GetUnderlyingType(collectionType).(type) {
 		case *types.ArrayType:
 			// Arrays are enumerable, element type is the array's element type
 			elementType = ct.ElementType
@@ -1134,33 +1220,19 @@ func (a *Analyzer) analyzeForIn(stmt *ast.ForInStatement) {
 			// The element type is the enum type itself
 			elementType = ct
 
-		case *types.TypeAlias:
-			// Unwrap type alias and check the underlying type
-			underlyingType := ct.AliasedType
-			// Re-check with underlying type
-			switch ut := underlyingType.(type) {
-			case *types.ArrayType:
-				elementType = ut.ElementType
-			case *types.SetType:
-				elementType = ut.ElementType
-			case *types.StringType:
-				if types.GetUnderlyingType(existingLoopVarType) == types.INTEGER {
-					elementType = types.INTEGER
-				} else {
-					elementType = types.STRING
-				}
-			case *types.EnumType:
-				elementType = ut
-			default:
-				a.addError("for-in collection type %s (alias of %s) is not enumerable at %s",
-					ct.String(), underlyingType.String(), stmt.Token.Pos.String())
-				elementType = types.VOID
-			}
+		case *types.VariantType:
+			// Variants are enumerated at runtime: JSON objects yield their keys,
+			// JSON arrays and variant arrays their elements.
+			elementType = types.VARIANT
+
+		case *types.JSONVariantType:
+			// Like Variant, but elements keep supporting JSON member access.
+			elementType = types.JSON_VARIANT
 
 		default:
 			// Not an enumerable type
-			a.addError("for-in collection type %s is not enumerable at %s",
-				collectionType.String(), stmt.Token.Pos.String())
+			a.addError("cannot iterate over type %s at %s",
+				collectionType.String(), stmt.Collection.Pos().String())
 			elementType = types.VOID
 		}
 	} else {
@@ -1171,7 +1243,8 @@ func (a *Analyzer) analyzeForIn(stmt *ast.ForInStatement) {
 	// Define loop variable with the element type
 	if !stmt.InlineVar {
 		a.symbols.RecordUsage(stmt.Variable.Value, stmt.Variable.Token.Pos)
-		if existingLoopVarType != nil && elementType != nil && !a.canAssign(elementType, existingLoopVarType) {
+		if existingLoopVarType != nil && elementType != nil && elementType != types.VOID &&
+			!a.canAssign(elementType, existingLoopVarType) {
 			a.addError("for-in loop variable %s has type %s, cannot assign %s at %s",
 				stmt.Variable.Value, existingLoopVarType.String(), elementType.String(), stmt.Token.Pos.String())
 		}
@@ -1224,24 +1297,21 @@ func (a *Analyzer) analyzeCase(stmt *ast.CaseStatement) {
 				startType := a.analyzeExpression(rangeExpr.Start)
 				endType := a.analyzeExpression(rangeExpr.RangeEnd)
 
-				// Check start is compatible with case expression
-				if caseType != nil && startType != nil {
-					if !a.canAssign(startType, caseType) {
-						a.addError("case range start type %s incompatible with case expression type %s at %s",
-							startType.String(), caseType.String(), rangeExpr.Start.Pos().String())
-					}
+				// Check each bound is compatible with case expression
+				boundsMatch := true
+				if caseType != nil && startType != nil && !a.canAssign(startType, caseType) {
+					a.addError("case range start type %s incompatible with case expression type %s at %s",
+						startType.String(), caseType.String(), rangeExpr.Start.Pos().String())
+					boundsMatch = false
 				}
-
-				// Check end is compatible with case expression
-				if caseType != nil && endType != nil {
-					if !a.canAssign(endType, caseType) {
-						a.addError("case range end type %s incompatible with case expression type %s at %s",
-							endType.String(), caseType.String(), rangeExpr.RangeEnd.Pos().String())
-					}
+				if caseType != nil && endType != nil && !a.canAssign(endType, caseType) {
+					a.addError("case range end type %s incompatible with case expression type %s at %s",
+						endType.String(), caseType.String(), rangeExpr.RangeEnd.Pos().String())
+					boundsMatch = false
 				}
 
 				// Check start and end are compatible with each other
-				if startType != nil && endType != nil {
+				if boundsMatch && startType != nil && endType != nil {
 					if !a.canAssign(startType, endType) && !a.canAssign(endType, startType) {
 						a.addError("case range start type %s and end type %s are incompatible at %s",
 							startType.String(), endType.String(), rangeExpr.Pos().String())
@@ -1252,8 +1322,8 @@ func (a *Analyzer) analyzeCase(stmt *ast.CaseStatement) {
 				valueType := a.analyzeExpression(value)
 				if caseType != nil && valueType != nil {
 					if !a.canAssign(valueType, caseType) {
-						a.addError("case value type %s incompatible with case expression type %s",
-							valueType.String(), caseType.String())
+						a.addError("case value type %s incompatible with case expression type %s at %s",
+							valueType.String(), caseType.String(), value.Pos().String())
 					}
 				}
 			}
@@ -1266,6 +1336,8 @@ func (a *Analyzer) analyzeCase(stmt *ast.CaseStatement) {
 	if stmt.Else != nil {
 		a.analyzeStatement(stmt.Else)
 	}
+
+	a.markDeadCaseBranches(stmt)
 }
 
 // analyzeBreakStatement analyzes a break statement
@@ -1315,6 +1387,19 @@ func (a *Analyzer) analyzeExitStatement(stmt *ast.ExitStatement) {
 	// Mark ALL loops in the stack as exitable (Exit exits the entire function)
 	a.markLoopExitable(LoopExitExit)
 
+	// Inside a lambda, Exit leaves the lambda and its value is checked
+	// against the lambda's declared return type
+	if a.inLambda && a.currentLambdaReturn != nil {
+		a.validateExitValue(stmt, a.currentLambdaReturn)
+		return
+	}
+	if a.inLambda && a.lambdaResultTypes != nil {
+		if stmt.ReturnValue != nil {
+			a.recordLambdaResult(a.analyzeExpression(stmt.ReturnValue))
+		}
+		return
+	}
+
 	// If we're at the top level (not in a function), only allow exit without a value
 	if a.currentFunction == nil {
 		if stmt.ReturnValue != nil {
@@ -1339,22 +1424,29 @@ func (a *Analyzer) analyzeExitStatement(stmt *ast.ExitStatement) {
 		}
 	}
 
-	// If exit has a return value, analyze it
-	if stmt.ReturnValue != nil {
-		if expectedType == types.VOID {
-			// Procedure (no return type) - exit should not have a value
-			a.addError("exit with value not allowed in procedure at %s", stmt.Token.Pos.String())
-			return
-		}
+	a.validateExitValue(stmt, expectedType)
+}
 
-		valueType := a.analyzeExpression(stmt.ReturnValue)
-		if valueType != nil && !a.canAssign(valueType, expectedType) {
-			a.addError("exit value type %s incompatible with function return type %s at %s",
-				valueType.String(), expectedType.String(), stmt.Token.Pos.String())
-		}
-	}
+// validateExitValue checks the value of Exit(value) against the return type of
+// the enclosing function or lambda (VOID for procedures).
+func (a *Analyzer) validateExitValue(stmt *ast.ExitStatement, expectedType types.Type) {
 	// Exit without an explicit return value is allowed. Functions rely on the current
 	// Result variable (or their default) in that case, matching DWScript semantics.
+	if stmt.ReturnValue == nil {
+		return
+	}
+
+	if expectedType == types.VOID {
+		// Procedure (no return type) - exit should not have a value
+		a.addError("exit with value not allowed in procedure at %s", stmt.Token.Pos.String())
+		return
+	}
+
+	valueType := a.analyzeExpressionWithExpectedType(stmt.ReturnValue, expectedType)
+	if valueType != nil && !a.canAssign(valueType, expectedType) {
+		a.addError("exit value type %s incompatible with function return type %s at %s",
+			valueType.String(), expectedType.String(), stmt.Token.Pos.String())
+	}
 }
 
 // analyzeUnitDeclaration analyzes a unit declaration
@@ -1420,3 +1512,26 @@ func compoundOperatorToSymbol(op lexer.TokenType) string {
 		return ""
 	}
 }
+
+// analyzePropertyAssignmentValue checks the value assigned to the property
+// propInfo by obj.Prop := value (or a compound assignment) against the
+// property type.
+func (a *Analyzer) analyzePropertyAssignmentValue(stmt *ast.AssignmentStatement, target *ast.MemberAccessExpression, propInfo *types.PropertyInfo, isCompound bool) {
+	valueType := a.analyzeExpressionWithExpectedType(stmt.Value, propInfo.Type)
+	if valueType == nil {
+		return
+	}
+
+	usesClassOperator := false
+	if isCompound {
+		valid, classOp := a.isCompoundOperatorValid(stmt.Operator, propInfo.Type, valueType, stmt.Token.Pos)
+		if !valid {
+			return
+		}
+		usesClassOperator = classOp
+	}
+
+	if !usesClassOperator && !a.canAssign(valueType, propInfo.Type) {
+		a.addStructuredError(NewPropertyValueTypeMismatchError(target.Member.Token.Pos, propInfo.Type.String(), valueType.String()))
+	}
+}
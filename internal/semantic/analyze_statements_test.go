@@ -312,6 +312,35 @@ func TestCaseTypeMismatch(t *testing.T) {
 	expectError(t, input, "incompatible")
 }
 
+func TestCaseStringAndEnumRanges(t *testing.T) {
+	input := `
+		type TColor = (Red, Green, Blue, Yellow);
+		var s: String := 'abc';
+		var c: TColor := Green;
+		case s of
+			'abc', 'def': PrintLn('first');
+			'a'..'z': PrintLn('letter');
+		end;
+		case c of
+			Red..Blue: PrintLn('primary');
+			Yellow: PrintLn('yellow');
+		end;
+	`
+	expectNoErrors(t, input)
+}
+
+func TestCaseRangeTypeMismatch(t *testing.T) {
+	input := `
+		type TColor = (Red, Green, Blue);
+		type TFruit = (Apple, Pear);
+		var c: TColor := Green;
+		case c of
+			Red..Pear: PrintLn('mixed');
+		end;
+	`
+	expectError(t, input, "case range end type TFruit incompatible with case expression type TColor")
+}
+
 // ============================================================================
 // Compound Assignment Tests
 // ============================================================================
@@ -444,3 +473,43 @@ func TestExitInProcedureNoValue(t *testing.T) {
 	`
 	expectNoErrors(t, input)
 }
+
+func TestExitWithValueInProcedureError(t *testing.T) {
+	input := `
+		procedure DoSomething;
+		begin
+			Exit(1);
+		end;
+	`
+	expectError(t, input, "exit with value not allowed in procedure")
+}
+
+func TestExitWithValueInLambda(t *testing.T) {
+	input := `
+		function Outer: String;
+		begin
+			var f := lambda(x: Integer): Integer begin
+				if x > 0 then Exit(x * 2);
+				Result := 0;
+			end;
+			Result := IntToStr(f(1));
+		end;
+
+		var g: function(x: Integer): Integer;
+		g := lambda(x) begin Exit(x + 1); end;
+	`
+	expectNoErrors(t, input)
+}
+
+func TestExitWithWrongTypeInLambda(t *testing.T) {
+	input := `
+		function Outer: String;
+		begin
+			var f := lambda(x: Integer): Integer begin
+				Exit('text');
+			end;
+			Result := '';
+		end;
+	`
+	expectError(t, input, "exit value type String incompatible with function return type Integer")
+}
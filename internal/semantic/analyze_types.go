@@ -721,6 +721,10 @@ func (a *Analyzer) analyzeTypeDeclaration(decl *ast.TypeDeclaration) {
 		// Resolve the aliased type expression
 		aliasedType, err = a.resolveTypeExpression(decl.AliasedType)
 		if err != nil {
+			if a.isCyclicType(decl.Name.Value) {
+				// Already reported as an illegal cyclic type definition.
+				return
+			}
 			typeName := getTypeExpressionName(decl.AliasedType)
 			if strings.Contains(typeName, ".") {
 				pos := decl.AliasedType.Pos()
@@ -72,12 +72,16 @@ const (
 
 // Analyzer performs semantic analysis on a DWScript program.
 type Analyzer struct {
-	currentSelfType       types.Type
-	forwardMethodNames    map[string]string
-	globalOperators       *types.OperatorRegistry
-	subranges             map[string]*types.SubrangeType
-	functionPointers      map[string]*types.FunctionPointerType
-	currentFunction       *ast.FunctionDecl
+	currentSelfType     types.Type
+	forwardMethodNames  map[string]string
+	globalOperators     *types.OperatorRegistry
+	subranges           map[string]*types.SubrangeType
+	functionPointers    map[string]*types.FunctionPointerType
+	currentFunction     *ast.FunctionDecl
+	currentLambdaReturn types.Type
+	// lambdaResultTypes collects the types of the values a lambda body
+	// returns while its return type is inferred; nil otherwise.
+	lambdaResultTypes     *[]types.Type
 	currentRecord         *types.RecordType
 	helpers               map[string][]*types.HelperType
 	currentHelperType     *types.HelperType
@@ -96,7 +100,14 @@ type Analyzer struct {
 	sourceCode            string
 	sourceFile            string
 	pendingClassWarnings  []*types.ClassType
+	hostRecordGlobals     []*Symbol
 	predeclaredClassTypes map[string]bool
+	cyclicTypes           map[string]bool
+	pendingClassVars      map[string]bool // class vars of currentClass whose initializers have not run yet
+	memberReceivers       map[*ast.Identifier]types.Type
+	functionDecls         map[*types.FunctionType]*ast.FunctionDecl // declaration of each global routine signature
+	indexAssignTarget     *ast.IndexExpression                      // index expression being analyzed as an assignment target
+	foldedConsts          map[*Symbol]any
 	errors                []string
 	loopPosStack          []token.Position
 	structuredErrors      []*SemanticError
@@ -111,6 +122,9 @@ type Analyzer struct {
 	inPropertyExpr        bool
 	inFinallyBlock        bool
 	inExceptionHandler    bool
+	foldConstants         bool
+	foldOverflowCheck     bool
+	strictTypes           bool
 }
 
 // NewAnalyzer creates a new semantic analyzer
@@ -133,6 +147,10 @@ func NewAnalyzer() *Analyzer {
 		forwardMethodNames:    make(map[string]string),
 		forwardMethodReported: make(map[string]bool),
 		predeclaredClassTypes: make(map[string]bool),
+		cyclicTypes:           make(map[string]bool),
+		memberReceivers:       make(map[*ast.Identifier]types.Type),
+		functionDecls:         make(map[*types.FunctionType]*ast.FunctionDecl),
+		foldedConsts:          make(map[*Symbol]any),
 		hintsLevel:            HintsLevelNormal,
 	}
 
@@ -156,6 +174,11 @@ func NewAnalyzer() *Analyzer {
 	a.symbols.DefineConst("NaN", types.FLOAT, math.NaN(), token.Position{})
 	a.symbols.DefineConst("Infinity", types.FLOAT, math.Inf(1), token.Position{})
 
+	// Register FloatToStrF formats (builtin - no source position)
+	for ordinal, name := range []string{"ffGeneral", "ffExponent", "ffFixed", "ffNumber", "ffCurrency"} {
+		a.symbols.DefineConst(name, types.INTEGER, int64(ordinal), token.Position{})
+	}
+
 	// Register Variant special values (builtin - no source position)
 	a.symbols.DefineConst("Null", types.VARIANT, nil, token.Position{})
 	a.symbols.DefineConst("Unassigned", types.VARIANT, nil, token.Position{})
@@ -193,9 +216,24 @@ func (a *Analyzer) registerBuiltinExceptionTypes() {
 		Visibility: int(ast.VisibilityPublic),
 	})
 
+	// InheritsFrom accepts a class name or a class reference and can be
+	// called on objects and class references alike.
+	for _, paramType := range []types.Type{types.STRING, types.NewClassOfType(objectClass)} {
+		objectClass.AddMethodOverload("InheritsFrom", &types.MethodInfo{
+			Signature: &types.FunctionType{
+				Parameters: []types.Type{paramType},
+				ReturnType: types.BOOLEAN,
+			},
+			IsClassMethod: true,
+			Visibility:    int(ast.VisibilityPublic),
+		})
+	}
+	objectClass.ClassMethodFlags[ident.Normalize("InheritsFrom")] = true
+
 	objectClass.VirtualMethods[ident.Normalize("Destroy")] = true
 	objectClass.MethodVisibility[ident.Normalize("Destroy")] = int(ast.VisibilityPublic)
 	objectClass.MethodVisibility[ident.Normalize("Free")] = int(ast.VisibilityPublic)
+	objectClass.MethodVisibility[ident.Normalize("InheritsFrom")] = int(ast.VisibilityPublic)
 
 	objectClass.Methods["ClassName"] = &types.FunctionType{
 		Parameters: []types.Type{},
@@ -249,41 +287,56 @@ func (a *Analyzer) registerBuiltinExceptionTypes() {
 		"EConvertError",
 		"ERangeError",
 		"EDivByZero",
+		"EIntOverflow",
 		"EAssertionFailed",
 		"EInvalidOp",
+		"EInvalidCast",
 	}
 
 	for _, excName := range standardExceptions {
-		excClass := &types.ClassType{
-			Name:                 excName,
-			Parent:               exceptionClass,
-			Fields:               make(map[string]types.Type),
-			Methods:              make(map[string]*types.FunctionType),
-			FieldVisibility:      make(map[string]int),
-			MethodVisibility:     make(map[string]int),
-			VirtualMethods:       make(map[string]bool),
-			OverrideMethods:      make(map[string]bool),
-			AbstractMethods:      make(map[string]bool),
-			ReintroduceMethods:   make(map[string]bool),
-			Constructors:         make(map[string]*types.FunctionType),
-			ConstructorOverloads: make(map[string][]*types.MethodInfo),
-			Interfaces:           make([]*types.InterfaceType, 0),
-			Properties:           make(map[string]*types.PropertyInfo),
-			ClassMethodFlags:     make(map[string]bool),
-		}
-
-		excClass.Fields["Message"] = types.STRING
+		a.registerBuiltinType(excName, newBuiltinExceptionClass(excName, exceptionClass))
+	}
 
-		excClass.AddConstructorOverload("Create", &types.MethodInfo{
-			Signature: &types.FunctionType{
-				Parameters: []types.Type{types.STRING},
-				ReturnType: excClass,
-			},
-			Visibility: int(ast.VisibilityPublic),
-		})
+	// EHost wraps host runtime errors; EExternal, raised for errors returned
+	// by external Go functions, derives from it.
+	hostClass := newBuiltinExceptionClass("EHost", exceptionClass)
+	hostClass.Fields["ExceptionClass"] = types.STRING
+	a.registerBuiltinType("EHost", hostClass)
+	a.registerBuiltinType("EExternal", newBuiltinExceptionClass("EExternal", hostClass))
+}
 
-		a.registerBuiltinType(excName, excClass)
+// newBuiltinExceptionClass creates a built-in exception class with a Message
+// field and a Create(Message) constructor.
+func newBuiltinExceptionClass(name string, parent *types.ClassType) *types.ClassType {
+	excClass := &types.ClassType{
+		Name:                 name,
+		Parent:               parent,
+		Fields:               make(map[string]types.Type),
+		Methods:              make(map[string]*types.FunctionType),
+		FieldVisibility:      make(map[string]int),
+		MethodVisibility:     make(map[string]int),
+		VirtualMethods:       make(map[string]bool),
+		OverrideMethods:      make(map[string]bool),
+		AbstractMethods:      make(map[string]bool),
+		ReintroduceMethods:   make(map[string]bool),
+		Constructors:         make(map[string]*types.FunctionType),
+		ConstructorOverloads: make(map[string][]*types.MethodInfo),
+		Interfaces:           make([]*types.InterfaceType, 0),
+		Properties:           make(map[string]*types.PropertyInfo),
+		ClassMethodFlags:     make(map[string]bool),
 	}
+
+	excClass.Fields["Message"] = types.STRING
+
+	excClass.AddConstructorOverload("Create", &types.MethodInfo{
+		Signature: &types.FunctionType{
+			Parameters: []types.Type{types.STRING},
+			ReturnType: excClass,
+		},
+		Visibility: int(ast.VisibilityPublic),
+	})
+
+	return excClass
 }
 
 // registerBuiltinInterfaces registers IInterface, the root interface type.
@@ -476,6 +529,23 @@ func (a *Analyzer) GetSemanticInfo() *ast.SemanticInfo {
 	return a.semanticInfo
 }
 
+// MemberReceiverType returns the static type of the object expression that
+// member is accessed on (the type of obj in obj.Member or obj.Member(...)),
+// or nil if the member access was not analyzed.
+func (a *Analyzer) MemberReceiverType(member *ast.Identifier) types.Type {
+	return a.memberReceivers[member]
+}
+
+// analyzeMemberReceiver analyzes the object expression of a member access
+// and records its type for MemberReceiverType.
+func (a *Analyzer) analyzeMemberReceiver(object ast.Expression, member *ast.Identifier) types.Type {
+	objectType := a.analyzeExpression(object)
+	if objectType != nil && member != nil {
+		a.memberReceivers[member] = objectType
+	}
+	return objectType
+}
+
 // GetHelpers returns all registered helper types.
 func (a *Analyzer) GetHelpers() map[string][]*types.HelperType {
 	return a.helpers
@@ -504,6 +574,21 @@ func (a *Analyzer) SetHintsLevel(level HintsLevel) {
 	a.hintsLevel = level
 }
 
+// SetStrictTypes configures whether types the analyzer cannot determine are
+// reported as errors instead of silently defaulting to Variant. This covers
+// untyped lambda parameters with no expected function pointer type to infer
+// them from, and lambda Result types inferred as Variant.
+func (a *Analyzer) SetStrictTypes(strict bool) {
+	a.strictTypes = strict
+}
+
+// SetBuiltinRegistry sets the registry of built-in functions available to
+// the analyzed program. Builtins of builtins.DefaultRegistry missing from reg
+// are reported as unknown names. It must be called before Analyze.
+func (a *Analyzer) SetBuiltinRegistry(reg *builtins.Registry) {
+	a.builtinRegistry = reg
+}
+
 func (a *Analyzer) addError(format string, args ...any) {
 	a.errors = append(a.errors, fmt.Sprintf(format, args...))
 }
@@ -542,282 +627,21 @@ func (a *Analyzer) addStructuredError(err *SemanticError) {
 }
 
 // canAssign checks assignment compatibility, accounting for implicit conversions.
+// Built-in rules come from types.IsAssignable; user-defined implicit operators
+// are looked up in the analyzer's conversion registry.
 func (a *Analyzer) canAssign(from, to types.Type) bool {
-	if from == nil || to == nil {
-		return false
-	}
-	if types.IsCompatible(from, to) {
-		return true
-	}
-	if a.canAssignNil(from, to) {
-		return true
-	}
-	if a.canAssignMetaclass(from, to) {
-		return true
-	}
-	if a.canAssignClass(from, to) {
-		return true
-	}
-	if a.canAssignInterface(from, to) {
-		return true
-	}
-	if a.canAssignSubrange(from, to) {
-		return true
-	}
-	if a.canAssignVariant(from, to) {
-		return true
-	}
-	if a.canAssignJSONVariant(from, to) {
-		return true
-	}
-	if a.canAssignEnumToInteger(from, to) {
-		return true
-	}
-	if a.canAssignPointer(from, to) {
-		return true
-	}
-	if sig, ok := a.conversionRegistry.FindImplicit(from, to); ok && sig != nil {
+	if ok, _ := types.IsAssignable(to, from); ok {
 		return true
 	}
-	return false
-}
-
-// canAssignNil checks if nil can be assigned to/from the target type.
-func (a *Analyzer) canAssignNil(from, to types.Type) bool {
-	fromKind := from.TypeKind()
-	toKind := to.TypeKind()
-
-	if fromKind == "NIL" {
-		if toKind == "CLASS" || toKind == "INTERFACE" || toKind == "CLASSOF" {
-			return true
-		}
-		// nil can be assigned to a dynamic array (clears it)
-		if arrType, ok := types.GetUnderlyingType(to).(*types.ArrayType); ok && arrType.IsDynamic() {
-			return true
-		}
-		// nil can be assigned to an associative array (clears it)
-		if _, ok := types.GetUnderlyingType(to).(*types.AssociativeArrayType); ok {
-			return true
-		}
-		return false
-	}
-	if toKind == "NIL" {
-		return fromKind == "CLASS" || fromKind == "INTERFACE" || fromKind == "CLASSOF"
-	}
-	return false
-}
-
-// canAssignMetaclass checks metaclass-to-metaclass assignment compatibility.
-func (a *Analyzer) canAssignMetaclass(from, to types.Type) bool {
-	fromResolved := types.GetUnderlyingType(from)
-	toResolved := types.GetUnderlyingType(to)
-
-	fromMetaclass, fromOk := fromResolved.(*types.ClassOfType)
-	toMetaclass, toOk := toResolved.(*types.ClassOfType)
-
-	if !fromOk || !toOk {
-		return false
-	}
-
-	fromClass := fromMetaclass.ClassType
-	toClass := toMetaclass.ClassType
-	return fromClass.Equals(toClass) || a.isDescendantOf(fromClass, toClass)
-}
-
-// canAssignClass checks class type assignment compatibility.
-func (a *Analyzer) canAssignClass(from, to types.Type) bool {
-	fromClass, ok := from.(*types.ClassType)
-	if !ok {
-		return false
-	}
-
-	if toMetaclass, ok := to.(*types.ClassOfType); ok {
-		return fromClass.Equals(toMetaclass.ClassType) || a.isDescendantOf(fromClass, toMetaclass.ClassType)
-	}
-	if toClass, ok := to.(*types.ClassType); ok {
-		return fromClass.Equals(toClass) || a.isDescendantOf(fromClass, toClass)
-	}
-	if toInterface, ok := to.(*types.InterfaceType); ok {
-		return fromClass.ImplementsInterface(toInterface)
-	}
-	return false
-}
-
-// canAssignInterface checks interface assignment compatibility.
-func (a *Analyzer) canAssignInterface(from, to types.Type) bool {
-	fromInterface, fromOk := from.(*types.InterfaceType)
-	toInterface, toOk := to.(*types.InterfaceType)
-
-	if !fromOk || !toOk {
+	if from == nil || to == nil {
 		return false
 	}
-	return fromInterface.Equals(toInterface) || fromInterface.InheritsFrom(toInterface)
-}
-
-// canAssignSubrange checks subrange type assignment compatibility.
-func (a *Analyzer) canAssignSubrange(from, to types.Type) bool {
-	if fromSubrange, ok := from.(*types.SubrangeType); ok {
-		if fromSubrange.BaseType.Equals(to) {
-			return true
-		}
-	}
-	if toSubrange, ok := to.(*types.SubrangeType); ok {
-		if toSubrange.BaseType.Equals(from) {
-			return true
-		}
-	}
-	return false
-}
-
-// canAssignVariant checks Variant type assignment rules.
-func (a *Analyzer) canAssignVariant(from, to types.Type) bool {
-	toUnderlying := types.GetUnderlyingType(to)
-	fromUnderlying := types.GetUnderlyingType(from)
-
-	// Anything can be assigned to Variant
-	if toUnderlying.TypeKind() == "VARIANT" {
-		return true
-	}
-	// Variant can be assigned to anything
-	if fromUnderlying.TypeKind() == "VARIANT" {
+	if sig, ok := a.conversionRegistry.FindImplicit(from, to); ok && sig != nil {
 		return true
 	}
 	return false
 }
 
-// canAssignJSONVariant checks JSONVariant assignment rules. A base scalar (or a
-// Variant) auto-boxes into a JSONVariant, and a JSONVariant implicitly converts to
-// the base scalar types. Function pointers and metaclasses do not auto-box, which
-// yields DWScript's "Cannot assign … to JSONVariant" diagnostic.
-func (a *Analyzer) canAssignJSONVariant(from, to types.Type) bool {
-	toUnderlying := types.GetUnderlyingType(to)
-	fromUnderlying := types.GetUnderlyingType(from)
-
-	isBaseScalar := func(k string) bool {
-		switch k {
-		case "INTEGER", "FLOAT", "STRING", "BOOLEAN":
-			return true
-		default:
-			return false
-		}
-	}
-
-	if toUnderlying.TypeKind() == "JSON_VARIANT" {
-		fromKind := fromUnderlying.TypeKind()
-		return fromKind == "JSON_VARIANT" || fromKind == "VARIANT" || fromKind == "NIL" || isBaseScalar(fromKind)
-	}
-	if fromUnderlying.TypeKind() == "JSON_VARIANT" {
-		toKind := toUnderlying.TypeKind()
-		return toKind == "VARIANT" || isBaseScalar(toKind)
-	}
-	return false
-}
-
-// canAssignEnumToInteger checks implicit enum-to-integer conversion.
-func (a *Analyzer) canAssignEnumToInteger(from, to types.Type) bool {
-	fromUnderlying := types.GetUnderlyingType(from)
-	toUnderlying := types.GetUnderlyingType(to)
-
-	return fromUnderlying.TypeKind() == "ENUM" && toUnderlying.Equals(types.INTEGER)
-}
-
-// canAssignPointer checks function and method pointer assignment compatibility.
-func (a *Analyzer) canAssignPointer(from, to types.Type) bool {
-	toUnderlying := types.GetUnderlyingType(to)
-	fromUnderlying := types.GetUnderlyingType(from)
-
-	toKind := toUnderlying.TypeKind()
-	if toKind != "FUNCTION_POINTER" && toKind != "METHOD_POINTER" {
-		return false
-	}
-
-	if fromMethodPtr, ok := fromUnderlying.(*types.MethodPointerType); ok {
-		return fromMethodPtr.IsCompatibleWith(toUnderlying)
-	}
-	if toFuncPtr, ok := toUnderlying.(*types.FunctionPointerType); ok {
-		if toFuncPtr.IsCompatibleWith(fromUnderlying) {
-			return true
-		}
-		// For helper methods like Map that use Variant parameters,
-		// allow function pointers with compatible concrete types.
-		if fromFuncPtr, ok := fromUnderlying.(*types.FunctionPointerType); ok {
-			return a.isFunctionPointerVariantCompatible(fromFuncPtr, toFuncPtr)
-		}
-		return false
-	}
-	if toMethodPtr, ok := toUnderlying.(*types.MethodPointerType); ok {
-		return toMethodPtr.IsCompatibleWith(fromUnderlying)
-	}
-	return false
-}
-
-// isFunctionPointerVariantCompatible checks if a function pointer with concrete types
-// can be assigned to a function pointer that uses Variant parameters/return type.
-// This enables higher-order functions like Map, Filter, etc. to accept functions
-// with specific types when the helper declares Variant-based signatures.
-//
-// Rules:
-// - Parameter count must match
-// - Target must have at least one Variant parameter OR Variant return type
-// - Each target Variant parameter accepts any concrete type from source
-// - Non-Variant parameters must match exactly (use Equals, not assignability)
-// - Return types: Variant target accepts any source, otherwise must match exactly
-func (a *Analyzer) isFunctionPointerVariantCompatible(from, to *types.FunctionPointerType) bool {
-	// Parameter count must match
-	if len(from.Parameters) != len(to.Parameters) {
-		return false
-	}
-
-	// Track whether target has ANY Variant usage (parameter or return)
-	// This function is specifically for Variant-based compatibility, not general assignability
-	var hasVariantUsage bool
-
-	// Check each parameter
-	for i := range from.Parameters {
-		toParam := to.Parameters[i]
-		fromParam := from.Parameters[i]
-
-		// If target expects Variant, any concrete type is acceptable
-		if toParam.Equals(types.VARIANT) {
-			if !hasVariantUsage {
-				hasVariantUsage = true
-			}
-			continue
-		}
-
-		// Otherwise, types must match exactly (not just be assignable)
-		// This prevents Integer→Float implicit conversion from making function pointers compatible
-		if !fromParam.Equals(toParam) {
-			return false
-		}
-	}
-
-	// Check return type compatibility
-	// If target returns Variant, any concrete return type is acceptable
-	if to.ReturnType != nil && to.ReturnType.Equals(types.VARIANT) {
-		return true
-	}
-
-	// Both have nil return type (procedures)
-	if from.ReturnType == nil && to.ReturnType == nil {
-		// Only allow if there was Variant usage in parameters
-		return hasVariantUsage
-	}
-
-	// One has return type, the other doesn't
-	if from.ReturnType == nil || to.ReturnType == nil {
-		return false
-	}
-
-	// Both have return types - must match exactly (not just be assignable)
-	if !from.ReturnType.Equals(to.ReturnType) {
-		return false
-	}
-
-	// Only return true if there was Variant usage somewhere
-	return hasVariantUsage
-}
-
 // ============================================================================
 // Symbol Table Accessors
 // ============================================================================
@@ -924,7 +748,7 @@ func (a *Analyzer) validateFieldInitializer(field *ast.FieldDecl, fieldName stri
 	if field.InitValue != nil {
 		initType := a.analyzeExpressionWithExpectedType(field.InitValue, fieldType)
 		if initType != nil && fieldType != nil {
-			if !types.IsAssignableFrom(fieldType, initType) {
+			if !a.canAssign(initType, fieldType) {
 				a.addError("cannot initialize field '%s' of type '%s' with value of type '%s' at %s",
 					fieldName, fieldType.String(), initType.String(), field.Token.Pos.String())
 			}
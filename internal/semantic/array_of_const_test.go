@@ -176,3 +176,28 @@ func TestClassOperatorCompoundAssignmentWithEmptyArray(t *testing.T) {
 	`
 	expectNoErrors(t, input)
 }
+
+// TestArrayOfConstMethodArgumentArrays tests that class methods accept array
+// variables of any element type for array of const parameters
+func TestArrayOfConstMethodArgumentArrays(t *testing.T) {
+	input := `
+		type TTest = class
+			procedure Log(const items: array of const);
+			class procedure Dump(const items: array of const);
+		end;
+
+		procedure TTest.Log(const items: array of const);
+		begin
+		end;
+
+		class procedure TTest.Dump(const items: array of const);
+		begin
+		end;
+
+		var names: array[1..2] of String;
+		var counts: array of Integer;
+		TTest.Create.Log(names);
+		TTest.Dump(counts);
+	`
+	expectNoErrors(t, input)
+}
@@ -473,6 +473,73 @@ func TestInlineArrayTypeErrors(t *testing.T) {
 	}
 }
 
+// TestInlineArrayElementAssignmentErrors tests that variables declared with
+// inline dynamic, static and multi-dimensional array types resolve to array
+// types, so element assignments and initializers are type-checked.
+func TestInlineArrayElementAssignmentErrors(t *testing.T) {
+	tests := []struct {
+		name          string
+		input         string
+		expectedError string
+	}{
+		{
+			name: "dynamic array element assignment",
+			input: `
+				var arr: array of Integer;
+				arr[0] := 'x';
+			`,
+			expectedError: "cannot assign String to Integer",
+		},
+		{
+			name: "static array element assignment",
+			input: `
+				var arr: array[1..3] of Integer;
+				arr[1] := 'x';
+			`,
+			expectedError: "cannot assign String to Integer",
+		},
+		{
+			name: "2D array element assignment",
+			input: `
+				var grid: array[1..2, 1..2] of String;
+				grid[1, 2] := 3;
+			`,
+			expectedError: "cannot assign Integer to String",
+		},
+		{
+			name: "nested 2D array element assignment",
+			input: `
+				var grid: array[1..2] of array of Float;
+				grid[1][0] := 'x';
+			`,
+			expectedError: "cannot assign String to Float",
+		},
+		{
+			name: "dynamic array initializer",
+			input: `
+				var arr: array of Integer := ['a', 'b'];
+			`,
+			expectedError: "array element 1 has type String, expected Integer",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			expectError(t, tt.input, tt.expectedError)
+		})
+	}
+
+	expectNoErrors(t, `
+		var arr: array of Integer;
+		var fixed: array[1..3] of Integer;
+		var grid: array[1..2, 1..2] of String;
+		SetLength(arr, 1);
+		arr[0] := 1;
+		fixed[2] := arr[0];
+		grid[1, 2] := 'x';
+	`)
+}
+
 // ============================================================================
 // Array Instantiation with 'new' Keyword Tests
 // ============================================================================
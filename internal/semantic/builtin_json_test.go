@@ -155,6 +155,52 @@ func TestBuiltinJSONKeys_Array(t *testing.T) {
 	expectNoErrors(t, input)
 }
 
+// Keys function tests
+func TestBuiltinKeys_JSON(t *testing.T) {
+	input := `
+		var json := ParseJSON('{"name": "John", "age": 30}');
+		var keys: array of String := Keys(json);
+		var first: String := keys[0];
+		var value: Variant := json[keys[0]];
+	`
+	expectNoErrors(t, input)
+}
+
+func TestBuiltinKeys_AssociativeArray(t *testing.T) {
+	input := `
+		var a: array [Integer] of String;
+		var keys: array of Integer := Keys(a);
+	`
+	expectNoErrors(t, input)
+}
+
+func TestBuiltinKeys_InvalidType(t *testing.T) {
+	input := `
+		var i := 42;
+		var keys := Keys(i);
+	`
+	expectError(t, input, "expects a JSON value or an associative array")
+}
+
+func TestBuiltinKeys_InvalidArgCount(t *testing.T) {
+	input := `
+		var keys := Keys();
+	`
+	expectError(t, input, "expects 1 argument")
+}
+
+func TestForInJSON(t *testing.T) {
+	input := `
+		var json := ParseJSON('{"name": "John", "age": 30}');
+		var s: String;
+		for var key in json do
+			PrintLn(json[key]);
+		for s in json do
+			PrintLn(s);
+	`
+	expectNoErrors(t, input)
+}
+
 // JSONValues function tests
 func TestBuiltinJSONValues_Basic(t *testing.T) {
 	input := `
@@ -99,6 +99,35 @@ func TestBuiltinClamp_Float(t *testing.T) {
 	expectNoErrors(t, input)
 }
 
+func TestBuiltinMath_AliasAndSubrangeArguments(t *testing.T) {
+	input := `
+		type TScore = Integer;
+		type TReal = Float;
+		type TDigit = 0..9;
+		var s: TScore := 3;
+		var r: TReal := 2.5;
+		var d: TDigit := 4;
+		var i: Integer := Min(s, d);
+		i := Max(d, 5);
+		i := Ceil(r);
+		i := Floor(s);
+		i := ClampInt(d, 0, 2);
+		var f: Float := Power(s, r);
+		f := Hypot(d, 4);
+		f := ArcTan2(r, s);
+		f := Log2(s);
+		f := LogN(2, d);
+		f := Clamp(r, 0, 1);
+		var b: Boolean := IsNaN(r) or IsInfinite(s);
+	`
+	expectNoErrors(t, input)
+}
+
+func TestBuiltinMinMax_ReturnType(t *testing.T) {
+	expectError(t, `type TReal = Float; var r: TReal; var i: Integer := Min(r, 1);`, "Float")
+	expectError(t, `type TDigit = 0..9; var d: TDigit; var s: String := Max(d, 1);`, "Integer")
+}
+
 // Sqr and Sqrt function tests
 func TestBuiltinSqr_Integer(t *testing.T) {
 	input := `
@@ -373,6 +402,30 @@ func TestBuiltinSucc_Enum(t *testing.T) {
 	expectNoErrors(t, input)
 }
 
+func TestBuiltinSuccPred_ResultType(t *testing.T) {
+	input := `
+		type TColor = (Red, Green, Blue);
+		type TDigit = 0..9;
+		var d: TDigit := 3;
+		var c: TColor := Succ(Red);
+		var b: Boolean := Pred(True);
+		var s: String := Succ('a');
+		var i: Integer := Succ(d);
+	`
+	expectNoErrors(t, input)
+}
+
+func TestBuiltinSuccPred_Errors(t *testing.T) {
+	expectError(t, `var i: Integer := Succ(False);`, "Cannot assign Boolean to Integer")
+	expectError(t, `var x := Pred(1.5);`, "expects an ordinal value")
+	expectError(t, `var x := Succ('ab');`, "expects a single character")
+}
+
+func TestBuiltinOrd_RejectsNonOrdinal(t *testing.T) {
+	expectNoErrors(t, `var i := Ord(True) + Ord('A') + Integer(3.5);`)
+	expectError(t, `var i := Ord(3.5);`, "function 'Ord' expects an ordinal value")
+}
+
 // Random function tests
 func TestBuiltinRandom_NoArgs(t *testing.T) {
 	input := `
@@ -402,6 +455,40 @@ func TestBuiltinSetRandSeed_Basic(t *testing.T) {
 	expectNoErrors(t, input)
 }
 
+func TestBuiltinRandom_Overloads(t *testing.T) {
+	input := `
+		var f: Float := Random;
+		var g: Float := Random();
+		var i: Integer := Random(10);
+		var j: Integer := RandomInt(-5, 5);
+		var n: Float := RandG;
+		var m: Float := RandG(10, 2.5);
+	`
+	expectNoErrors(t, input)
+}
+
+func TestBuiltinRandom_OverloadErrors(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		err   string
+	}{
+		{"Random float as Integer", "var i: Integer := Random();", "Float"},
+		{"Random string argument", "var i := Random('10');", "function 'Random' expects Integer argument"},
+		{"Random two arguments", "var i := Random(1, 2);", "function 'Random' expects 0 or 1 arguments"},
+		{"RandomInt three arguments", "var i := RandomInt(1, 2, 3);", "function 'RandomInt' expects 1 or 2 arguments"},
+		{"RandomInt float bound", "var i := RandomInt(1, 2.5);", "function 'RandomInt' expects Integer argument"},
+		{"RandG one argument", "var f := RandG(1.0);", "function 'RandG' expects 0 or 2 arguments"},
+		{"RandG string argument", "var f := RandG('a', 1.0);", "function 'RandG' expects Float argument"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			expectError(t, tt.input, tt.err)
+		})
+	}
+}
+
 // Other math functions
 func TestBuiltinCoTan_Basic(t *testing.T) {
 	input := `
@@ -471,6 +558,58 @@ func TestBuiltinAssigned_Basic(t *testing.T) {
 	expectNoErrors(t, input)
 }
 
+func TestBuiltinGetClass_Basic(t *testing.T) {
+	input := `
+		type TBase = class
+		end;
+		type TChild = class(TBase)
+		end;
+		var obj: TBase := TChild.Create;
+		var meta: class of TBase := GetClass(obj);
+		var copy: TBase := GetClass(obj).Create;
+		var name := GetClass(TChild).ClassName;
+	`
+	expectNoErrors(t, input)
+}
+
+func TestBuiltinGetClass_NonObject(t *testing.T) {
+	input := `
+		var result := GetClass(42);
+	`
+	expectError(t, input, "function 'GetClass' expects an object")
+}
+
+func TestBuiltinTypeOf_Members(t *testing.T) {
+	input := `
+		type TBase = class
+		public
+			Field: Integer;
+		end;
+		type TChild = class(TBase)
+		end;
+		var obj: TBase := TChild.Create;
+		var ti := TypeOf(obj);
+		var name: String := ti.Name;
+		var isClass: Boolean := ti.IsClass and not ti.IsArray and not ti.IsRecord;
+		for var f in ti.Fields do
+			PrintLn(f.Name + ' = ' + VarToStr(f.Value));
+		for var m in TypeOf(obj.ClassType).Methods do
+			PrintLn(m);
+		var same := (ti = TypeOf(TChild)) and (ti = 'TChild');
+		var text := 'type: ' + ti;
+		var typeName: String := TypeName(obj);
+		var inherits := obj.InheritsFrom('TBase') and TChild.InheritsFrom(TBase);
+	`
+	expectNoErrors(t, input)
+}
+
+func TestBuiltinTypeOf_UnknownMember(t *testing.T) {
+	input := `
+		var x := TypeOf(42).Foo;
+	`
+	expectError(t, input, "There is no accessible member with name \"Foo\" for type TRTTITypeInfo")
+}
+
 // Combined math operations tests
 func TestBuiltinMath_ChainedOperations(t *testing.T) {
 	input := `
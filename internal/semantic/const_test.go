@@ -209,3 +209,45 @@ func TestFunctionLocalConstWithOrd(t *testing.T) {
 	`
 	expectNoErrors(t, input)
 }
+
+func TestFunctionLocalConstNotVisibleAtTopLevel(t *testing.T) {
+	input := `
+		function Test(): Integer;
+		begin
+			const LOCAL_CONST = 42;
+			Result := LOCAL_CONST;
+		end;
+		var x := LOCAL_CONST;
+	`
+	expectError(t, input, "Unknown name")
+}
+
+func TestBlockLocalConstScope(t *testing.T) {
+	expectNoErrors(t, `
+		const LIMIT = 1;
+		procedure Test();
+		begin
+			const LIMIT = 5;
+			PrintLn(LIMIT);
+		end;
+		PrintLn(LIMIT);
+	`)
+
+	expectError(t, `
+		begin
+			begin
+				const INNER = 1;
+				PrintLn(INNER);
+			end;
+			PrintLn(INNER);
+		end;
+	`, "Unknown name")
+
+	expectError(t, `
+		procedure Test();
+		begin
+			PrintLn(LATER);
+			const LATER = 1;
+		end;
+	`, "Unknown name")
+}
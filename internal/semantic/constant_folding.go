@@ -0,0 +1,412 @@
+package semantic
+
+import (
+	"math"
+
+	"github.com/cwbudde/go-dws/internal/interp/runtime"
+	"github.com/cwbudde/go-dws/internal/types"
+	"github.com/cwbudde/go-dws/pkg/ast"
+)
+
+// ============================================================================
+// Constant Folding
+// ============================================================================
+//
+// When enabled, the analyzer evaluates arithmetic, boolean and string
+// expressions whose operands are literals or declared constants, and records
+// the result in SemanticInfo (see ast.SemanticInfo.SetConstant). Folded values
+// are int64, float64, string or bool and follow the interpreter's semantics:
+// Integer arithmetic wraps around on 64 bits, unless overflow checking is on,
+// in which case an overflowing expression is left for the runtime to raise
+// EIntOverflow. Division or mod by a constant zero, Integer or Float, is
+// reported as an error.
+//
+// Branches of if and case statements selected by a folded condition are
+// recorded with SemanticInfo.MarkDead when they can never run.
+
+// EnableConstantFolding turns on the constant folding pass. checkOverflow
+// must match the runtime's Integer overflow checking so that folded values
+// are the ones the program would compute.
+func (a *Analyzer) EnableConstantFolding(checkOverflow bool) {
+	a.foldConstants = true
+	a.foldOverflowCheck = checkOverflow
+}
+
+// foldExpression records the compile-time value of expr, if it has one, and
+// returns typ unchanged so it can wrap the analysis of expr.
+func (a *Analyzer) foldExpression(expr ast.Expression, typ types.Type) types.Type {
+	if !a.foldConstants || typ == nil {
+		return typ
+	}
+
+	var value any
+	var ok bool
+	switch e := expr.(type) {
+	case *ast.Identifier:
+		value, ok = a.foldIdentifier(e, typ)
+	case *ast.GroupedExpression:
+		value, ok = a.constantValue(e.Expression)
+	case *ast.UnaryExpression:
+		value, ok = a.foldUnary(e)
+	case *ast.BinaryExpression:
+		value, ok = a.foldBinary(e, typ)
+	}
+	if ok {
+		a.semanticInfo.SetConstant(expr, value)
+	}
+	return typ
+}
+
+// constantValue returns the compile-time value of a literal, or the value
+// recorded for an already folded expression.
+func (a *Analyzer) constantValue(expr ast.Expression) (any, bool) {
+	switch e := expr.(type) {
+	case *ast.IntegerLiteral:
+		return e.Value, true
+	case *ast.FloatLiteral:
+		return e.Value, true
+	case *ast.StringLiteral:
+		return e.Value, true
+	case *ast.CharLiteral:
+		return string(e.Value), true
+	case *ast.BooleanLiteral:
+		return e.Value, true
+	case nil:
+		return nil, false
+	}
+	return a.semanticInfo.GetConstant(expr)
+}
+
+// foldIdentifier returns the value of a reference to a declared constant.
+func (a *Analyzer) foldIdentifier(identifier *ast.Identifier, typ types.Type) (any, bool) {
+	sym, ok := a.symbols.Resolve(identifier.Value)
+	if !ok || !sym.IsConst || sym.Type == nil || !sym.Type.Equals(typ) {
+		return nil, false
+	}
+	if value, ok := a.foldedConsts[sym]; ok {
+		return value, true
+	}
+	return coerceConstant(sym.Value, sym.Type)
+}
+
+// recordFoldedConst remembers the folded value of a const declaration, which
+// is exact where the symbol's own value may have gone through float math.
+func (a *Analyzer) recordFoldedConst(stmt *ast.ConstDecl) {
+	if !a.foldConstants {
+		return
+	}
+	value, ok := a.constantValue(stmt.Value)
+	if !ok {
+		return
+	}
+	sym, ok := a.symbols.Resolve(stmt.Name.Value)
+	if !ok || !sym.IsConst {
+		return
+	}
+	if value, ok := coerceConstant(value, sym.Type); ok {
+		a.foldedConsts[sym] = value
+	}
+}
+
+// coerceConstant converts a compile-time value to the representation the
+// interpreter uses for typ. It fails for types that are not folded.
+func coerceConstant(value any, typ types.Type) (any, bool) {
+	switch {
+	case typ.Equals(types.INTEGER):
+		switch v := value.(type) {
+		case int64:
+			return v, true
+		case int:
+			return int64(v), true
+		}
+	case typ.Equals(types.FLOAT):
+		switch v := value.(type) {
+		case float64:
+			return v, true
+		case int64:
+			return float64(v), true
+		case int:
+			return float64(v), true
+		}
+	case typ.Equals(types.STRING):
+		if v, ok := value.(string); ok {
+			return v, true
+		}
+	case typ.Equals(types.BOOLEAN):
+		if v, ok := value.(bool); ok {
+			return v, true
+		}
+	}
+	return nil, false
+}
+
+// foldUnary folds -, + and not applied to a constant operand.
+func (a *Analyzer) foldUnary(expr *ast.UnaryExpression) (any, bool) {
+	operand, ok := a.constantValue(expr.Right)
+	if !ok {
+		return nil, false
+	}
+
+	switch v := operand.(type) {
+	case int64:
+		switch expr.Operator {
+		case "-":
+			result, overflow := runtime.CheckedIntegerNegate(v)
+			if overflow && a.foldOverflowCheck {
+				return nil, false
+			}
+			return result, true
+		case "+":
+			return v, true
+		case "not":
+			return ^v, true
+		}
+	case float64:
+		switch expr.Operator {
+		case "-":
+			return -v, true
+		case "+":
+			return v, true
+		}
+	case bool:
+		if expr.Operator == "not" {
+			return !v, true
+		}
+	}
+	return nil, false
+}
+
+// foldBinary folds a binary expression whose operands are both constant. It
+// reports division by a constant zero, Integer or Float, even when the left
+// operand is not constant, since the expression can never yield a value.
+func (a *Analyzer) foldBinary(expr *ast.BinaryExpression, typ types.Type) (any, bool) {
+	right, rightOk := a.constantValue(expr.Right)
+	if rightOk && isZeroDivisor(expr.Operator, right, typ) {
+		a.addError("division by zero at %s", expr.Pos().String())
+		return nil, false
+	}
+
+	left, leftOk := a.constantValue(expr.Left)
+	if !leftOk || !rightOk {
+		return nil, false
+	}
+
+	switch l := left.(type) {
+	case int64:
+		switch r := right.(type) {
+		case int64:
+			return a.foldIntegerBinary(expr, l, r)
+		case float64:
+			return foldFloatBinary(expr.Operator, float64(l), r)
+		}
+	case float64:
+		switch r := right.(type) {
+		case int64:
+			return foldFloatBinary(expr.Operator, l, float64(r))
+		case float64:
+			return foldFloatBinary(expr.Operator, l, r)
+		}
+	case string:
+		if r, ok := right.(string); ok {
+			return foldStringBinary(expr.Operator, l, r)
+		}
+	case bool:
+		if r, ok := right.(bool); ok {
+			return foldBooleanBinary(expr.Operator, l, r)
+		}
+	}
+	return nil, false
+}
+
+// isZeroDivisor reports whether right is a constant zero divisor of an
+// arithmetic division with result type typ.
+func isZeroDivisor(op string, right any, typ types.Type) bool {
+	if op != "/" && op != "div" && op != "mod" {
+		return false
+	}
+	if !typ.Equals(types.INTEGER) && !typ.Equals(types.FLOAT) {
+		return false
+	}
+	switch r := right.(type) {
+	case int64:
+		return r == 0
+	case float64:
+		return r == 0
+	}
+	return false
+}
+
+// foldIntegerBinary mirrors the interpreter's Integer operators.
+func (a *Analyzer) foldIntegerBinary(expr *ast.BinaryExpression, l, r int64) (any, bool) {
+	switch expr.Operator {
+	case "+", "-", "*":
+		result, overflow := runtime.CheckedIntegerArithmetic(expr.Operator, l, r)
+		if overflow && a.foldOverflowCheck {
+			return nil, false
+		}
+		return result, true
+	case "/":
+		return float64(l) / float64(r), true
+	case "div":
+		return l / r, true
+	case "mod":
+		return l % r, true
+	case "shl":
+		if r < 0 {
+			return nil, false
+		}
+		return l << uint(r), true
+	case "shr", "sar":
+		if r < 0 {
+			return nil, false
+		}
+		return l >> uint(r), true
+	case "and":
+		return l & r, true
+	case "or":
+		return l | r, true
+	case "xor":
+		return l ^ r, true
+	}
+	return compareConstants(expr.Operator, l, r)
+}
+
+// foldFloatBinary mirrors the interpreter's Float operators, which also
+// apply when one operand is an Integer.
+func foldFloatBinary(op string, l, r float64) (any, bool) {
+	switch op {
+	case "+":
+		return l + r, true
+	case "-":
+		return l - r, true
+	case "*":
+		return l * r, true
+	case "/":
+		return l / r, true
+	case "mod":
+		m := math.Mod(l, r)
+		if m == 0 {
+			m = 0
+		}
+		return m, true
+	}
+	return compareConstants(op, l, r)
+}
+
+// foldStringBinary folds concatenation and comparison of strings.
+func foldStringBinary(op string, l, r string) (any, bool) {
+	if op == "+" {
+		return l + r, true
+	}
+	return compareConstants(op, l, r)
+}
+
+// foldBooleanBinary folds the logical operators on Booleans.
+func foldBooleanBinary(op string, l, r bool) (any, bool) {
+	switch op {
+	case "and":
+		return l && r, true
+	case "or":
+		return l || r, true
+	case "xor", "<>":
+		return l != r, true
+	case "=":
+		return l == r, true
+	case "implies":
+		return !l || r, true
+	}
+	return nil, false
+}
+
+// compareConstants folds the comparison operators.
+func compareConstants[T int64 | float64 | string](op string, l, r T) (any, bool) {
+	switch op {
+	case "=":
+		return l == r, true
+	case "<>":
+		return l != r, true
+	case "<":
+		return l < r, true
+	case ">":
+		return l > r, true
+	case "<=":
+		return l <= r, true
+	case ">=":
+		return l >= r, true
+	}
+	return nil, false
+}
+
+// markDeadIfBranch marks the branch of an if statement that a constant
+// condition never selects.
+func (a *Analyzer) markDeadIfBranch(stmt *ast.IfStatement) {
+	if !a.foldConstants {
+		return
+	}
+	value, ok := a.constantValue(stmt.Condition)
+	condition, isBool := value.(bool)
+	if !ok || !isBool {
+		return
+	}
+	if condition {
+		if stmt.Alternative != nil {
+			a.semanticInfo.MarkDead(stmt.Alternative)
+		}
+	} else if stmt.Consequence != nil {
+		a.semanticInfo.MarkDead(stmt.Consequence)
+	}
+}
+
+// markDeadCaseBranches marks the branches of a case statement that a
+// constant selector never reaches. Nothing is marked unless every branch
+// value is a constant of the selector's kind.
+func (a *Analyzer) markDeadCaseBranches(stmt *ast.CaseStatement) {
+	if !a.foldConstants {
+		return
+	}
+	selector, ok := a.constantValue(stmt.Expression)
+	if !ok {
+		return
+	}
+
+	selected := -1
+	for i, branch := range stmt.Cases {
+		for _, expr := range branch.Values {
+			value, ok := a.constantValue(expr)
+			if !ok || !sameConstantKind(selector, value) {
+				return
+			}
+			if selected < 0 && value == selector {
+				selected = i
+			}
+		}
+	}
+
+	for i, branch := range stmt.Cases {
+		if i != selected && branch.Statement != nil {
+			a.semanticInfo.MarkDead(branch.Statement)
+		}
+	}
+	if selected >= 0 && stmt.Else != nil {
+		a.semanticInfo.MarkDead(stmt.Else)
+	}
+}
+
+// sameConstantKind reports whether two folded values have the same Go type.
+func sameConstantKind(a, b any) bool {
+	switch a.(type) {
+	case int64:
+		_, ok := b.(int64)
+		return ok
+	case float64:
+		_, ok := b.(float64)
+		return ok
+	case string:
+		_, ok := b.(string)
+		return ok
+	case bool:
+		_, ok := b.(bool)
+		return ok
+	}
+	return false
+}
@@ -0,0 +1,149 @@
+package semantic
+
+import (
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/cwbudde/go-dws/pkg/ast"
+)
+
+// analyzeFolded analyzes input with constant folding enabled.
+func analyzeFolded(t *testing.T, input string, checkOverflow bool) (*ast.Program, *Analyzer) {
+	t.Helper()
+	program := parseProgram(t, input)
+	analyzer := NewAnalyzer()
+	analyzer.EnableConstantFolding(checkOverflow)
+	if err := analyzer.Analyze(program); err != nil {
+		t.Fatalf("unexpected analysis error: %v", err)
+	}
+	return program, analyzer
+}
+
+// initializerOf returns the initializer of the var declaration at index i.
+func initializerOf(t *testing.T, program *ast.Program, i int) ast.Expression {
+	t.Helper()
+	decl, ok := program.Statements[i].(*ast.VarDeclStatement)
+	if !ok {
+		t.Fatalf("statement %d is %T, want *ast.VarDeclStatement", i, program.Statements[i])
+	}
+	return decl.Value
+}
+
+func TestConstantFolding_Values(t *testing.T) {
+	tests := []struct {
+		want any
+		name string
+		expr string
+	}{
+		{name: "integer arithmetic", expr: "24 * 60 * 60", want: int64(86400)},
+		{name: "declared constant", expr: "Day * 7", want: int64(604800)},
+		{name: "grouping and unary", expr: "-(Day div 2) mod 7", want: int64(-43200 % 7)},
+		{name: "integer division", expr: "7 / 2", want: 3.5},
+		{name: "mixed float", expr: "Rate * 2", want: 1.0},
+		{name: "typed float constant", expr: "Scale + 1", want: 4.0},
+		{name: "string concatenation", expr: "Greeting + ', World'", want: "Hello, World"},
+		{name: "comparison", expr: "Day > 1000", want: true},
+		{name: "boolean logic", expr: "not Debug and (Day <> 0)", want: true},
+		{name: "wraparound", expr: "High64 + 1", want: int64(math.MinInt64)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			program, analyzer := analyzeFolded(t, `
+				const Day = 24 * 60 * 60;
+				const Rate = 0.5;
+				const Scale: Float = 3;
+				const Greeting = 'Hello';
+				const Debug = False;
+				const High64 = 9223372036854775807;
+				var v := `+tt.expr+`;
+			`, false)
+
+			got, ok := analyzer.GetSemanticInfo().GetConstant(initializerOf(t, program, 6))
+			if !ok {
+				t.Fatalf("%s was not folded", tt.expr)
+			}
+			if got != tt.want {
+				t.Errorf("%s folded to %v (%T), want %v (%T)", tt.expr, got, got, tt.want, tt.want)
+			}
+		})
+	}
+}
+
+func TestConstantFolding_NotFolded(t *testing.T) {
+	tests := []struct {
+		name          string
+		expr          string
+		checkOverflow bool
+	}{
+		{name: "variable operand", expr: "n + 1"},
+		{name: "function call", expr: "Length('abc') + 1"},
+		{name: "checked overflow", expr: "High64 + 1", checkOverflow: true},
+		{name: "negative shift", expr: "1 shl -1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			program, analyzer := analyzeFolded(t, `
+				const High64 = 9223372036854775807;
+				var n: Integer;
+				var v := `+tt.expr+`;
+			`, tt.checkOverflow)
+
+			if got, ok := analyzer.GetSemanticInfo().GetConstant(initializerOf(t, program, 2)); ok {
+				t.Errorf("%s folded to %v, want no value", tt.expr, got)
+			}
+		})
+	}
+}
+
+func TestConstantFolding_DivisionByZero(t *testing.T) {
+	for _, expr := range []string{"n div 0", "n mod (2 - 2)", "1 / 0", "1.0 / 0.0", "1 / 0.0", "5.5 / Z", "5.5 mod Z"} {
+		t.Run(expr, func(t *testing.T) {
+			program := parseProgram(t, "const Z = 0.0; var n: Integer; var v := "+expr+";")
+			analyzer := NewAnalyzer()
+			analyzer.EnableConstantFolding(false)
+			err := analyzer.Analyze(program)
+			if err == nil || !strings.Contains(err.Error(), "division by zero") {
+				t.Errorf("expected a division by zero error, got %v", err)
+			}
+		})
+	}
+
+	// Without folding, the division is left to raise at runtime.
+	expectNoErrors(t, "var n: Integer; var v := n div 0;")
+}
+
+func TestConstantFolding_DeadBranches(t *testing.T) {
+	program, analyzer := analyzeFolded(t, `
+		const Debug = False;
+		var n: Integer;
+		if Debug then n := 1 else n := 2;
+		case 2 + 1 of
+			1: n := 3;
+			3: n := 4;
+		else
+			n := 5;
+		end;
+		case n of
+			1: n := 6;
+		end;
+	`, false)
+	info := analyzer.GetSemanticInfo()
+
+	ifStmt := program.Statements[2].(*ast.IfStatement)
+	if !info.IsDead(ifStmt.Consequence) || info.IsDead(ifStmt.Alternative) {
+		t.Errorf("if Debug: want only the then branch dead")
+	}
+
+	caseStmt := program.Statements[3].(*ast.CaseStatement)
+	if !info.IsDead(caseStmt.Cases[0].Statement) || info.IsDead(caseStmt.Cases[1].Statement) || !info.IsDead(caseStmt.Else) {
+		t.Errorf("case 2 + 1: want only the branch for 3 live")
+	}
+
+	dynamic := program.Statements[4].(*ast.CaseStatement)
+	if info.IsDead(dynamic.Cases[0].Statement) {
+		t.Errorf("case n: branch marked dead for a non-constant selector")
+	}
+}
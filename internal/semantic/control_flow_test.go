@@ -545,8 +545,11 @@ func TestForInWithNonEnumerableType(t *testing.T) {
 	if err == nil {
 		t.Fatal("Expected semantic error for for-in with non-enumerable type, got nil")
 	}
+	if len(analyzer.Errors()) != 1 {
+		t.Errorf("Expected a single error, got: %v", analyzer.Errors())
+	}
 
-	if !strings.Contains(err.Error(), "not enumerable") {
+	if !strings.Contains(err.Error(), "cannot iterate over type Integer at 5:12") {
 		t.Errorf("Expected error about non-enumerable type, got: %v", err)
 	}
 }
@@ -569,11 +572,56 @@ func TestForInWithBoolean(t *testing.T) {
 		t.Fatal("Expected semantic error for for-in with boolean type, got nil")
 	}
 
-	if !strings.Contains(err.Error(), "not enumerable") {
+	if !strings.Contains(err.Error(), "cannot iterate over type") {
 		t.Errorf("Expected error about non-enumerable type, got: %v", err)
 	}
 }
 
+// TestForInInferredLoopVariableType tests that inline loop variables take the
+// element type of the collection
+func TestForInInferredLoopVariableType(t *testing.T) {
+	tests := []struct {
+		name          string
+		input         string
+		expectedError string
+	}{
+		{
+			name: "array element type",
+			input: `
+				type TInts = array of Integer;
+				var arr: TInts;
+				for var x in arr do
+					var s: String := x;
+			`,
+			expectedError: "cannot assign Integer to String",
+		},
+		{
+			name: "set element type",
+			input: `
+				type TColor = (Red, Green, Blue);
+				var colors: set of TColor;
+				for var c in colors do
+					var s: String := c;
+			`,
+			expectedError: "cannot assign TColor to String",
+		},
+		{
+			name: "string element type",
+			input: `
+				for var ch in 'abc' do
+					var i: Integer := ch;
+			`,
+			expectedError: "cannot assign String to Integer",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			expectError(t, tt.input, tt.expectedError)
+		})
+	}
+}
+
 // TestForInLoopVariableScope tests that loop variable is scoped to the loop
 func TestForInLoopVariableScope(t *testing.T) {
 	input := `
@@ -676,3 +724,60 @@ func TestForInWithSetLiteral(t *testing.T) {
 }
 
 // Note: parseProgram helper function is defined in exceptions_test.go
+
+// TestInlineVarCondition tests if and while conditions that declare a variable
+func TestInlineVarCondition(t *testing.T) {
+	expectNoErrors(t, `
+		type TNode = class
+			Next: TNode;
+		end;
+		var head := TNode.Create;
+		if var n := head.Next then
+			PrintLn('has next')
+		else if var b: Boolean := n = nil then
+			PrintLn('none');
+		var count := 0;
+		while var more := count < 3 do
+			count := count + 1;
+	`)
+
+	expectError(t, `
+		if var n := 42 then
+			PrintLn(n);
+	`, "if condition must be boolean")
+}
+
+// TestInlineVarConditionScope tests that an inline variable of an if, while
+// or for statement is not visible after the statement
+func TestInlineVarConditionScope(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+	}{
+		{"if", `
+			if var ok := 1 < 2 then PrintLn(ok) else PrintLn(not ok);
+			PrintLn(ok);
+		`},
+		{"while", `
+			while var ok := False do PrintLn(ok);
+			PrintLn(ok);
+		`},
+		{"for", `
+			for var i := 1 to 3 do PrintLn(i);
+			PrintLn(i);
+		`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := analyzeSource(t, tt.input)
+			if err == nil {
+				t.Fatal("expected an error for the inline variable used after the statement, got nil")
+			}
+			if !strings.Contains(strings.ToLower(err.Error()), "unknown name \"ok\"") &&
+				!strings.Contains(strings.ToLower(err.Error()), "unknown name \"i\"") {
+				t.Errorf("expected an unknown name error, got: %v", err)
+			}
+		})
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/cwbudde/go-dws/internal/errors"
 	"github.com/cwbudde/go-dws/internal/lexer"
 	"github.com/cwbudde/go-dws/internal/types"
+	"github.com/cwbudde/go-dws/pkg/ast"
 )
 
 // ErrorSeverity represents the severity level of an error or warning.
@@ -225,6 +226,18 @@ func NewUndefinedVariable(pos lexer.Position, varName string) *SemanticError {
 	}
 }
 
+// NewResultOutsideFunctionError reports a use of Result where no function
+// result is in scope (top level or a procedure).
+func NewResultOutsideFunctionError(pos lexer.Position) *SemanticError {
+	return &SemanticError{
+		Type:         ErrorGeneric,
+		Message:      "Result is only valid inside a function",
+		Pos:          pos,
+		Severity:     SeverityError,
+		VariableName: "Result",
+	}
+}
+
 // NewUndefinedFunction creates an undefined function error
 func NewUndefinedFunction(pos lexer.Position, funcName string) *SemanticError {
 	return &SemanticError{
@@ -676,11 +689,30 @@ func NewArrayDimensionTypeError(pos lexer.Position, dimension int, got string) *
 	}
 }
 
+// NewClassVarInitCycleError reports a class var initializer that reads a class
+// var which is not initialized yet: itself or one declared after it.
+func NewClassVarInitCycleError(pos lexer.Position, name string) *SemanticError {
+	return &SemanticError{
+		Type:         ErrorInvalidOperation,
+		Message:      fmt.Sprintf(`Circular initialization: class variable "%s" is not initialized yet`, name),
+		Pos:          pos,
+		Severity:     SeverityError,
+		VariableName: name,
+	}
+}
+
 // NewVisibilityScopeError creates a DWScript-style visibility diagnostic.
-func NewVisibilityScopeError(pos lexer.Position, member string) *SemanticError {
+// Strict private and strict protected members get their own wording so they
+// can be told apart from plain private and protected ones.
+func NewVisibilityScopeError(pos lexer.Position, member string, visibility int) *SemanticError {
+	message := fmt.Sprintf(`Member symbol "%s" is not visible from this scope`, member)
+	switch vis := ast.Visibility(visibility); vis {
+	case ast.VisibilityStrictPrivate, ast.VisibilityStrictProtected:
+		message = fmt.Sprintf(`Member symbol "%s" is %s and not visible from this scope`, member, vis)
+	}
 	return &SemanticError{
 		Type:         ErrorVisibility,
-		Message:      fmt.Sprintf(`Member symbol "%s" is not visible from this scope`, member),
+		Message:      message,
 		Pos:          pos,
 		Severity:     SeverityError,
 		VariableName: member,
@@ -1,6 +1,8 @@
 package semantic
 
 import (
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
@@ -70,6 +72,34 @@ func TestStandardExceptionTypesRegistered(t *testing.T) {
 	}
 }
 
+// Test that EExternal is registered under EHost and can be caught by name
+func TestHostExceptionTypesRegistered(t *testing.T) {
+	analyzer := NewAnalyzer()
+
+	external, exists := analyzer.GetClasses()["eexternal"]
+	if !exists {
+		t.Fatal("EExternal should be registered as a built-in exception type")
+	}
+	if external.Parent == nil || external.Parent.Name != "EHost" {
+		t.Fatalf("EExternal parent = %v, want EHost", external.Parent)
+	}
+	if external.Parent.Parent == nil || external.Parent.Parent.Name != "Exception" {
+		t.Errorf("EHost parent = %v, want Exception", external.Parent.Parent)
+	}
+
+	program := parseProgram(t, `
+		try
+			raise Exception.Create('x');
+		except
+			on E: EExternal do PrintLn(E.Message);
+			on E: EHost do PrintLn(E.ExceptionClass);
+		end;
+	`)
+	if err := analyzer.Analyze(program); err != nil {
+		t.Errorf("Expected no semantic errors, got: %v", err)
+	}
+}
+
 // ============================================================================
 // Raise Statement Semantic Analysis Tests
 // ============================================================================
@@ -335,6 +365,155 @@ func TestRaiseInFinallyBlockAllowed(t *testing.T) {
 	}
 }
 
+// Test raising a record reports the position of the raised expression
+func TestRaiseRecordReportsPosition(t *testing.T) {
+	input := `type TRec = record X: Integer; end;
+var r: TRec;
+raise r;`
+
+	program := parseProgram(t, input)
+	analyzer := NewAnalyzer()
+	err := analyzer.Analyze(program)
+
+	if err == nil {
+		t.Fatal("Expected semantic error for raising a record")
+	}
+	if !strings.Contains(err.Error(), "raise statement requires Exception type, got TRec at 3:7") {
+		t.Errorf("Expected positioned error for raised record, got: %v", err)
+	}
+}
+
+// Test handling a class that does not derive from Exception
+func TestHandlerNonExceptionClass(t *testing.T) {
+	input := `type TPlain = class end;
+try
+  PrintLn('try');
+except
+  on E: TPlain do PrintLn('plain');
+end;`
+
+	program := parseProgram(t, input)
+	analyzer := NewAnalyzer()
+	err := analyzer.Analyze(program)
+
+	if err == nil {
+		t.Fatal("Expected semantic error for non-exception handler type")
+	}
+	if !strings.Contains(err.Error(), "exception handler type must be Exception or derived class, got TPlain") ||
+		!strings.HasSuffix(err.Error(), "at 5:9") {
+		t.Errorf("Expected positioned error for handler type, got: %v", err)
+	}
+}
+
+// Test handlers are not reported as unreachable just because the try block
+// only raises other classes: reachability depends on handler order alone
+func TestUnraisedExceptionHandlerIsReachable(t *testing.T) {
+	input := `type EMine = class(Exception) end;
+type EOther = class(Exception) end;
+try
+  raise EMine.Create('mine');
+except
+  on E: EOther do PrintLn('other');
+  on E: Exception do PrintLn('base');
+end;`
+
+	program := parseProgram(t, input)
+	analyzer := NewAnalyzer()
+	if err := analyzer.Analyze(program); err != nil {
+		t.Fatalf("Expected no semantic errors, got: %v", err)
+	}
+
+	for _, msg := range analyzer.Errors() {
+		if strings.Contains(msg, "can never be reached") {
+			t.Errorf("Unexpected unreachable warning: %s", msg)
+		}
+	}
+}
+
+// Test the DWScript exception fixtures analyze without unreachable handler
+// warnings
+func TestExceptionFixturesHaveNoUnreachableHandlers(t *testing.T) {
+	fixtures := []string{
+		"../../testdata/fixtures/SimpleScripts/exceptions.pas",
+		"../../testdata/fixtures/SimpleScripts/exceptions3.pas",
+	}
+
+	for _, fixture := range fixtures {
+		t.Run(filepath.Base(fixture), func(t *testing.T) {
+			source, err := os.ReadFile(fixture)
+			if err != nil {
+				t.Fatalf("Failed to read fixture: %v", err)
+			}
+
+			program := parseProgram(t, string(source))
+			analyzer := NewAnalyzer()
+			if err := analyzer.Analyze(program); err != nil {
+				t.Fatalf("Expected no semantic errors, got: %v", err)
+			}
+
+			for _, msg := range analyzer.Errors() {
+				if strings.Contains(msg, "can never be reached") {
+					t.Errorf("Unexpected unreachable warning: %s", msg)
+				}
+			}
+		})
+	}
+}
+
+// Test a derived-class handler after its base-class handler is reported as
+// unreachable, while sibling and correctly ordered handlers are not
+func TestShadowedExceptionHandler(t *testing.T) {
+	input := `type EBase = class(Exception) end;
+type EDerived = class(EBase) end;
+type ESibling = class(EBase) end;
+try
+  PrintLn('may raise anything');
+except
+  on E: EDerived do PrintLn('derived');
+  on E: EBase do PrintLn('base');
+  on E: ESibling do PrintLn('sibling');
+  on E: ERangeError do PrintLn('range');
+end;`
+
+	program := parseProgram(t, input)
+	analyzer := NewAnalyzer()
+	if err := analyzer.Analyze(program); err != nil {
+		t.Fatalf("Expected no semantic errors, got: %v", err)
+	}
+
+	var warnings []string
+	for _, msg := range analyzer.Errors() {
+		if strings.Contains(msg, "can never be reached") {
+			warnings = append(warnings, msg)
+		}
+	}
+	if len(warnings) != 1 || !strings.Contains(warnings[0], `"ESibling"`) ||
+		!strings.Contains(warnings[0], `"EBase" is handled first`) ||
+		!strings.Contains(warnings[0], "line: 9") {
+		t.Errorf("Expected a single shadowed warning for ESibling on line 9, got: %v", warnings)
+	}
+}
+
+// Test Assert accepts a paren-less String function as its message
+func TestAssertArguments(t *testing.T) {
+	expectNoErrors(t, `
+		function Msg: String;
+		begin
+			Result := 'failed';
+		end;
+		var v: Variant := True;
+		Assert(1 < 2);
+		Assert(v, Msg);
+	`)
+}
+
+// Test Assert rejects a non-Boolean condition
+func TestAssertNonBooleanCondition(t *testing.T) {
+	expectError(t, `Assert(1, 'msg');`, "first argument must be Boolean")
+	expectError(t, `Assert(True, 42);`, "second argument must be String")
+	expectError(t, `Assert();`, "expects 1-2 arguments")
+}
+
 // ============================================================================
 // Helper Functions
 // ============================================================================
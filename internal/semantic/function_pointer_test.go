@@ -215,6 +215,25 @@ func TestAddressOfExpressionErrors(t *testing.T) {
 			`,
 			expectedErr: "not a function",
 		},
+		{
+			name: "address of overloaded function without expected type",
+			input: `
+				function Twice(x: Integer): Integer; overload; begin Result := x * 2; end;
+				function Twice(s: String): String; overload; begin Result := s + s; end;
+				var f := @Twice;
+			`,
+			expectedErr: "ambiguous address of overloaded 'Twice' (candidates: Twice(Integer) -> Integer, Twice(String) -> String)",
+		},
+		{
+			name: "address of overloaded function matching no overload",
+			input: `
+				function Twice(x: Integer): Integer; overload; begin Result := x * 2; end;
+				function Twice(s: String): String; overload; begin Result := s + s; end;
+				type TFloatFunc = function(x: Float): Float;
+				var f: TFloatFunc := @Twice;
+			`,
+			expectedErr: "no overload of 'Twice' matches TFloatFunc",
+		},
 	}
 
 	for _, tt := range tests {
@@ -240,6 +259,36 @@ func TestAddressOfExpressionErrors(t *testing.T) {
 	}
 }
 
+// TestAddressOfOverloadedFunction tests that the expected function pointer
+// type selects the overload an address-of expression refers to.
+func TestAddressOfOverloadedFunction(t *testing.T) {
+	input := `
+		function Twice(x: Integer): Integer; overload; begin Result := x * 2; end;
+		function Twice(s: String): String; overload; begin Result := s + s; end;
+		type TIntFunc = function(x: Integer): Integer;
+		type TStrFunc = function(s: String): String;
+		procedure Run(f: TStrFunc); begin end;
+		var fi: TIntFunc := @Twice;
+		var fs: TStrFunc;
+		fs := @Twice;
+		Run(@Twice);
+		fi := nil;
+		if (fi = nil) or (nil <> fs) then Run(fs);
+	`
+
+	l := lexer.New(input)
+	p := parser.New(l)
+	program := p.ParseProgram()
+	if len(p.Errors()) != 0 {
+		t.Fatalf("parser errors: %v", p.Errors())
+	}
+
+	a := NewAnalyzer()
+	if err := a.Analyze(program); err != nil {
+		t.Fatalf("expected no errors, got: %v", err)
+	}
+}
+
 // TestFunctionPointerAssignment tests function pointer assignment compatibility.
 func TestFunctionPointerAssignment(t *testing.T) {
 	tests := []struct {
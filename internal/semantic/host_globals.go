@@ -0,0 +1,112 @@
+package semantic
+
+import (
+	"github.com/cwbudde/go-dws/internal/types"
+	"github.com/cwbudde/go-dws/pkg/ast"
+	"github.com/cwbudde/go-dws/pkg/ident"
+	"github.com/cwbudde/go-dws/pkg/token"
+)
+
+// DeclareGlobal predeclares a global variable supplied by the host
+// application, so the analyzed program can read and assign it like any other
+// variable. It must be called before Analyze.
+//
+// An unnamed record type stands for a Go map whose record type is not known
+// yet: the variable is retyped to the first record declared in the program
+// whose fields have the same names and accept the map's field types.
+func (a *Analyzer) DeclareGlobal(name string, typ types.Type) {
+	a.symbols.Define(name, typ, token.Position{})
+	sym, _ := a.symbols.Resolve(name)
+	sym.SuppressUnusedWarning = true
+	if rt, ok := typ.(*types.RecordType); ok && rt.Name == "" {
+		a.hostRecordGlobals = append(a.hostRecordGlobals, sym)
+	}
+}
+
+// DeclareFunction predeclares a global function implemented by the host
+// application, so the analyzed program can call it like a function it
+// declared. It must be called before Analyze.
+func (a *Analyzer) DeclareFunction(name string, typ *types.FunctionType) {
+	a.symbols.DefineFunction(name, typ, token.Position{})
+}
+
+// DeclareUnitFunction predeclares a function of a unit implemented by the
+// host application, which the analyzed program calls with a qualified name
+// (Unit.Function). It must be called before Analyze.
+func (a *Analyzer) DeclareUnitFunction(unit, name string, typ *types.FunctionType) {
+	a.hostUnitSymbols(unit).DefineFunction(name, typ, token.Position{})
+}
+
+// DeclareUnitConstant predeclares a constant of a unit implemented by the
+// host application, which the analyzed program reads with a qualified name
+// (Unit.Constant). Its value is only known at run time. It must be called
+// before Analyze.
+func (a *Analyzer) DeclareUnitConstant(unit, name string, typ types.Type) {
+	a.hostUnitSymbols(unit).DefineReadOnly(name, typ, token.Position{})
+}
+
+// hostUnitSymbols returns the symbol table of a host unit, creating it on
+// first use.
+func (a *Analyzer) hostUnitSymbols(unit string) *SymbolTable {
+	normalized := ident.Normalize(unit)
+	symbols, ok := a.unitSymbols[normalized]
+	if !ok {
+		symbols = NewSymbolTable()
+		a.unitSymbols[normalized] = symbols
+	}
+	return symbols
+}
+
+// unitNamespace reports whether obj names a unit whose symbols are known to
+// the analyzer, unless a program symbol shadows it.
+func (a *Analyzer) unitNamespace(obj ast.Expression) (string, bool) {
+	identExpr, ok := obj.(*ast.Identifier)
+	if !ok {
+		return "", false
+	}
+	if _, known := a.unitSymbols[ident.Normalize(identExpr.Value)]; !known {
+		return "", false
+	}
+	if _, resolved := a.symbols.Resolve(identExpr.Value); resolved {
+		return "", false
+	}
+	return identExpr.Value, true
+}
+
+// resolveUnitMember resolves the member of a unit named by a qualified name,
+// reporting an unknown name when the unit does not declare it.
+func (a *Analyzer) resolveUnitMember(unitName string, member *ast.Identifier) *Symbol {
+	sym, err := a.ResolveQualifiedSymbol(unitName, member.Value)
+	if err != nil {
+		a.addStructuredError(NewUnknownNameError(member.Token.Pos, unitName+"."+member.Value))
+		return nil
+	}
+	return sym
+}
+
+// bindHostRecordGlobals retypes the host globals that match the record type
+// just declared; see DeclareGlobal.
+func (a *Analyzer) bindHostRecordGlobals(recordType *types.RecordType) {
+	pending := a.hostRecordGlobals[:0]
+	for _, sym := range a.hostRecordGlobals {
+		if a.recordFieldsMatch(sym.Type.(*types.RecordType), recordType) {
+			sym.Type = recordType
+			continue
+		}
+		pending = append(pending, sym)
+	}
+	a.hostRecordGlobals = pending
+}
+
+func (a *Analyzer) recordFieldsMatch(from, to *types.RecordType) bool {
+	if len(from.Fields) != len(to.Fields) {
+		return false
+	}
+	for name, fieldType := range from.Fields {
+		target, ok := to.Fields[ident.Normalize(name)]
+		if !ok || !a.canAssign(fieldType, target) {
+			return false
+		}
+	}
+	return true
+}
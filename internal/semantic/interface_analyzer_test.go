@@ -439,3 +439,141 @@ func TestIncompatibleInterfaceAssignment(t *testing.T) {
 // ============================================================================
 // Note: expectNoErrors() and expectError() are already defined in analyzer_test.go
 // and will be available since this is in the same package
+
+// ============================================================================
+// Interface Property Tests
+// ============================================================================
+
+// TestInterfacePropertyImplemented tests that a class providing the accessors
+// of an interface property (including inherited ones) is accepted
+func TestInterfacePropertyImplemented(t *testing.T) {
+	input := `
+		type IBase = interface
+			function GetName: String;
+			property Name: String read GetName;
+		end;
+
+		type IList = interface(IBase)
+			function GetItem(i: Integer): String;
+			procedure SetItem(i: Integer; v: String);
+			property Items[i: Integer]: String read GetItem write SetItem; default;
+		end;
+
+		type TList = class(TObject, IList)
+			function GetName: String; begin Result := 'list'; end;
+			function GetItem(i: Integer): String; begin Result := ''; end;
+			procedure SetItem(i: Integer; v: String); begin end;
+		end;
+
+		var l: IList := TList.Create;
+		l[0] := l.Items[1] + l.Name;
+	`
+	expectNoErrors(t, input)
+}
+
+// TestInterfacePropertyMissingSetter tests that an implementing class must provide the setter
+func TestInterfacePropertyMissingSetter(t *testing.T) {
+	input := `
+		type ICounter = interface
+			function GetCount: Integer;
+			property Count: Integer read GetCount write SetCount;
+		end;
+
+		type TCounter = class(TObject, ICounter)
+			function GetCount: Integer; begin Result := 0; end;
+		end;
+	`
+	expectError(t, input, "class 'TCounter' does not implement setter 'SetCount' of property 'Count' from interface 'ICounter'")
+}
+
+// TestInterfacePropertyGetterSignatureMismatch tests that getter return types must match
+func TestInterfacePropertyGetterSignatureMismatch(t *testing.T) {
+	input := `
+		type ICounter = interface
+			property Count: Integer read GetCount;
+		end;
+
+		type TCounter = class(TObject, ICounter)
+			function GetCount: String; begin Result := ''; end;
+		end;
+	`
+	expectError(t, input, "property 'Count' getter method 'GetCount' returns String, expected Integer in class 'TCounter'")
+}
+
+// TestInterfacePropertyInterfaceMethodMismatch tests that accessors declared on the
+// interface itself are checked against the property signature
+func TestInterfacePropertyInterfaceMethodMismatch(t *testing.T) {
+	input := `
+		type ICounter = interface
+			procedure SetCount(v: String);
+			property Count: Integer write SetCount;
+		end;
+	`
+	expectError(t, input, "property 'Count' setter method 'SetCount' value parameter has type String, expected Integer")
+}
+
+// TestInterfaceReadOnlyPropertyAssignment tests that read-only interface properties reject writes
+func TestInterfaceReadOnlyPropertyAssignment(t *testing.T) {
+	input := `
+		type ICounter = interface
+			function GetCount: Integer;
+			property Count: Integer read GetCount;
+		end;
+
+		var c: ICounter;
+		c.Count := 3;
+	`
+	expectError(t, input, "Cannot set a value for a read-only property")
+}
+
+// TestInterfaceToUnrelatedInterfaceCast tests that 'as' between unrelated
+// interfaces is checked at runtime rather than rejected statically
+func TestInterfaceToUnrelatedInterfaceCast(t *testing.T) {
+	input := `
+		type IA = interface procedure A; end;
+		type IB = interface function B: Integer; end;
+		type TBoth = class(TObject, IA, IB)
+			procedure A; begin end;
+			function B: Integer; begin Result := 1; end;
+		end;
+
+		var a: IA := TBoth.Create;
+		var b: IB := a as IB;
+		var o: TBoth := a as TBoth;
+	`
+	expectNoErrors(t, input)
+}
+
+// TestSupportsBuiltin tests the 2- and 3-argument forms of Supports
+func TestSupportsBuiltin(t *testing.T) {
+	decls := `
+		type IA = interface procedure A; end;
+		type IB = interface function B: Integer; end;
+		type TOnlyA = class(TObject, IA) procedure A; begin end; end;
+		var o := TOnlyA.Create;
+		var a: IA := o;
+		var b: IB;
+		var n: Integer;
+	`
+	expectNoErrors(t, decls+`
+		var ok: Boolean := Supports(o, IB);
+		ok := Supports(a, IB, b) or Supports(nil, IA);
+	`)
+
+	tests := []struct {
+		name  string
+		call  string
+		error string
+	}{
+		{"missing interface", "Supports(o);", "function 'Supports' expects 2 or 3 arguments, got 1"},
+		{"class instead of interface", "Supports(o, TOnlyA);", "expects an interface type as second argument, got 'TOnlyA'"},
+		{"non-object argument", "Supports(n, IA);", "function 'Supports' expects an object or interface, got Integer"},
+		{"incompatible out variable", "Supports(o, IA, n);", "function 'Supports' cannot store IA in a variable of type Integer"},
+		{"out argument not a variable", "Supports(o, IB, a as IB);", "function 'Supports' out argument must be a variable"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			expectError(t, decls+tt.call, tt.error)
+		})
+	}
+}
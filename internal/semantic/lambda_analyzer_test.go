@@ -233,13 +233,6 @@ func TestLambdaErrors(t *testing.T) {
 			`,
 			expectedErr: "unknown return type",
 		},
-		{
-			name: "missing parameter type annotation",
-			input: `
-				var f := lambda(x): Integer begin Result := x * 2; end;
-			`,
-			expectedErr: "not fully implemented",
-		},
 		{
 			name: "conflicting return types",
 			input: `
@@ -501,6 +494,90 @@ func TestLambdaWithResultVariable(t *testing.T) {
 	}
 }
 
+// TestLambdaInferredVariableType tests that a variable initialized with a
+// lambda without a declared return type gets a function pointer type whose
+// return type is inferred from the whole lambda body.
+func TestLambdaInferredVariableType(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{
+			name:  "expression-bodied lambda",
+			input: `var f := lambda(x: Integer) => x * 2;`,
+			want:  "function(Integer): Integer",
+		},
+		{
+			name: "block-bodied lambda assigning Result",
+			input: `
+				var f := lambda(x: Integer) begin
+					var y := x * 3;
+					Result := y;
+				end;
+			`,
+			want: "function(Integer): Integer",
+		},
+		{
+			name: "Result assigned in nested statements",
+			input: `
+				var f := lambda(s: String) begin
+					for var i := 1 to 2 do
+						if i > 1 then
+							Result := s + IntToStr(i);
+				end;
+			`,
+			want: "function(String): String",
+		},
+		{
+			name: "Exit with a value",
+			input: `
+				var f := lambda(x: Integer) begin
+					if x < 0 then
+						Exit(False);
+					Result := True;
+				end;
+			`,
+			want: "function(Integer): Boolean",
+		},
+		{
+			name: "procedure lambda",
+			input: `
+				var f := lambda(x: Integer) begin
+					var y := x;
+					PrintLn(y);
+				end;
+			`,
+			want: "procedure(Integer)",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := NewAnalyzer()
+			if err := a.Analyze(parseProgram(t, tt.input)); err != nil {
+				t.Fatalf("expected no semantic errors, got: %v", err)
+			}
+			sym, ok := a.symbols.Resolve("f")
+			if !ok {
+				t.Fatal("expected variable 'f' to be in symbol table")
+			}
+			if got := sym.Type.String(); got != tt.want {
+				t.Errorf("type of f = %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	a := NewAnalyzer()
+	err := a.Analyze(parseProgram(t, `
+		var f := lambda(x: Integer) begin var y := x + 1; Result := y; end;
+		var s: String := f(1);
+	`))
+	if err == nil {
+		t.Fatal("expected assigning the inferred Integer result to a String to fail")
+	}
+}
+
 // TestLambdaParameterTypeInference tests parameter type inference from context.
 func TestLambdaParameterTypeInference(t *testing.T) {
 	tests := []struct {
@@ -571,6 +648,16 @@ func TestLambdaParameterTypeInference(t *testing.T) {
 				var p: TProc := lambda(x) begin PrintLn(x); end;
 			`,
 		},
+		{
+			name: "no context for inference - parameters default to Variant",
+			input: `
+				var f := lambda(x) => x * 2;
+				var g := lambda(a, b: Integer; c) => a + b + c;
+				PrintLn(f(21));
+				PrintLn(f(1.5));
+				PrintLn(g(1, 2, 3));
+			`,
+		},
 	}
 
 	for _, tt := range tests {
@@ -633,13 +720,6 @@ func TestLambdaParameterTypeInferenceErrors(t *testing.T) {
 			`,
 			expectedErr: "inferred lambda return type String incompatible with expected return type Integer",
 		},
-		{
-			name: "no context for inference - still requires explicit types",
-			input: `
-				var f := lambda(x) => x * 2;
-			`,
-			expectedErr: "not fully implemented",
-		},
 	}
 
 	for _, tt := range tests {
@@ -936,7 +1016,7 @@ func TestVariadicLambdaInference(t *testing.T) {
 					ApplyAll([lambda(n) => IntToStr(n), lambda(m) => IntToStr(m*2)]);
 				end;
 			`,
-			expectOk: false, // array of const accepts any type, so lambdas will be Variant
+			expectOk: true, // array of const gives no function type, so the parameters are Variant
 			checkFn:  nil,
 		},
 		{
@@ -1232,3 +1312,56 @@ end;
 		})
 	}
 }
+
+// TestLambdaStrictTypes tests that strict mode rejects Variant fallbacks
+// that lenient mode accepts.
+func TestLambdaStrictTypes(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		wantErr string
+	}{
+		{
+			name:    "untyped parameter",
+			input:   `var f := lambda(x) => x * 2;`,
+			wantErr: "could not determine type of parameter 'x' at 1:17",
+		},
+		{
+			name: "Result inferred as Variant",
+			input: `
+				var v: Variant := 1;
+				var f := lambda(): Variant begin Result := v; end;
+				var g := lambda begin Result := v; end;
+			`,
+			wantErr: "could not determine type of Result in lambda at 4:14",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lenient := NewAnalyzer()
+			if err := lenient.Analyze(parseProgram(t, tt.input)); err != nil {
+				t.Fatalf("lenient analysis failed: %v", err)
+			}
+
+			strict := NewAnalyzer()
+			strict.SetStrictTypes(true)
+			if err := strict.Analyze(parseProgram(t, tt.input)); err == nil {
+				t.Fatal("expected strict analysis to fail")
+			}
+			if errs := strict.Errors(); len(errs) == 0 || !strings.Contains(errs[0], tt.wantErr) {
+				t.Errorf("errors = %v, want %q", errs, tt.wantErr)
+			}
+		})
+	}
+
+	strict := NewAnalyzer()
+	strict.SetStrictTypes(true)
+	if err := strict.Analyze(parseProgram(t, `
+		type TFunc = function(x: Integer): Integer;
+		var f: TFunc := lambda(x) => x * 2;
+		var g := lambda(x: Integer) => x + 1;
+	`)); err != nil {
+		t.Errorf("strict analysis of inferable lambdas failed: %v", err)
+	}
+}
@@ -0,0 +1,204 @@
+package semantic
+
+import (
+	"fmt"
+
+	"github.com/cwbudde/go-dws/internal/types"
+	"github.com/cwbudde/go-dws/pkg/ast"
+	"github.com/cwbudde/go-dws/pkg/ident"
+	"github.com/cwbudde/go-dws/pkg/token"
+)
+
+// BindNamedArguments returns args in parameter order. names is parallel to
+// args and holds nil for positional arguments: those bind to the leading
+// parameters, while named arguments ("name := value") bind to the parameter
+// of that name. Skipped parameters before the last bound one receive their
+// default value expression, so the result can be passed on as an ordinary
+// positional argument list.
+//
+// paramNames and defaults describe the callee's parameters; defaults holds
+// nil for required parameters. An error is returned for unknown or
+// duplicated names and for required parameters that receive no value.
+func BindNamedArguments(args []ast.Expression, names []*ast.Identifier, paramNames []string, defaults []ast.Expression) ([]ast.Expression, error) {
+	bound := make([]ast.Expression, len(paramNames))
+	last := -1
+
+	for i, arg := range args {
+		index := i
+		var name *ast.Identifier
+		if i < len(names) {
+			name = names[i]
+		}
+		if name != nil {
+			index = -1
+			for j, paramName := range paramNames {
+				if ident.Equal(paramName, name.Value) {
+					index = j
+					break
+				}
+			}
+			if index < 0 {
+				return nil, fmt.Errorf("there is no parameter named \"%s\"", name.Value)
+			}
+			if bound[index] != nil {
+				return nil, fmt.Errorf("parameter \"%s\" is given more than once", paramNames[index])
+			}
+		} else if index >= len(paramNames) {
+			return nil, fmt.Errorf("too many arguments")
+		}
+
+		bound[index] = arg
+		if index > last {
+			last = index
+		}
+	}
+
+	for i := 0; i < len(paramNames); i++ {
+		if bound[i] != nil {
+			continue
+		}
+		if i < len(defaults) && defaults[i] != nil {
+			if i < last {
+				bound[i] = defaults[i]
+			}
+			continue
+		}
+		return nil, fmt.Errorf("no value given for parameter \"%s\"", paramNames[i])
+	}
+
+	return bound[:last+1], nil
+}
+
+// analyzeNamedArgumentCall analyzes a call that passes arguments by name.
+// Named arguments are supported for declared, non-overloaded functions and
+// procedures; the call is checked as if its arguments were written
+// positionally in parameter order.
+func (a *Analyzer) analyzeNamedArgumentCall(expr *ast.CallExpression) types.Type {
+	funcIdent, ok := expr.Function.(*ast.Identifier)
+	if !ok {
+		a.addError("named arguments are only supported in calls to declared functions and methods at %s", expr.Token.Pos.String())
+		return nil
+	}
+
+	sym, ok := a.symbols.Resolve(funcIdent.Value)
+	if !ok {
+		a.addError("named arguments are only supported in calls to declared functions and methods at %s", expr.Token.Pos.String())
+		return nil
+	}
+	if sym.IsOverloadSet || len(a.symbols.GetOverloadSet(funcIdent.Value)) > 1 {
+		a.addError("named arguments are not supported for overloaded function '%s' at %s",
+			funcIdent.Value, expr.Token.Pos.String())
+		return nil
+	}
+	funcType, ok := sym.Type.(*types.FunctionType)
+	if !ok {
+		a.addError("named arguments are only supported in calls to declared functions and methods at %s", expr.Token.Pos.String())
+		return nil
+	}
+
+	args, ok := a.bindNamedArguments(expr.Arguments, expr.ArgumentNames, funcType, funcIdent.Value, expr.Token.Pos)
+	if !ok {
+		return nil
+	}
+
+	positional := *expr
+	positional.Arguments = args
+	positional.ArgumentNames = nil
+	return a.analyzeCallExpression(&positional)
+}
+
+// bindNamedMethodArguments returns a copy of expr whose arguments are listed
+// positionally in the parameter order of the called method. objectType is the
+// analyzed receiver: named arguments are supported for non-overloaded methods
+// of classes, metaclasses and records. It reports an error and returns nil
+// when the arguments cannot be bound.
+func (a *Analyzer) bindNamedMethodArguments(expr *ast.MethodCallExpression, objectType types.Type) *ast.MethodCallExpression {
+	var candidates []*types.MethodInfo
+	switch t := objectType.(type) {
+	case *types.ClassOfType:
+		candidates = a.getMethodOverloadsInHierarchy(expr.Method.Value, t.ClassType)
+	case *types.ClassType:
+		candidates = a.getMethodOverloadsInHierarchy(expr.Method.Value, t)
+	case *types.RecordType:
+		candidates = append(append(candidates, t.GetClassMethodOverloads(expr.Method.Value)...),
+			t.GetMethodOverloads(expr.Method.Value)...)
+	}
+
+	method := a.namedArgumentTarget(candidates, expr.Method.Value, expr.Token.Pos)
+	if method == nil {
+		return nil
+	}
+	args, ok := a.bindNamedArguments(expr.Arguments, expr.ArgumentNames, method.Signature, expr.Method.Value, expr.Token.Pos)
+	if !ok {
+		return nil
+	}
+
+	positional := *expr
+	positional.Arguments = args
+	positional.ArgumentNames = nil
+	return &positional
+}
+
+// bindNamedConstructorArguments is the NewExpression counterpart of
+// bindNamedMethodArguments; constructors holds the candidate overloads.
+func (a *Analyzer) bindNamedConstructorArguments(expr *ast.NewExpression, constructors []*types.MethodInfo, constructorName string) *ast.NewExpression {
+	ctor := a.namedArgumentTarget(constructors, constructorName, expr.Token.Pos)
+	if ctor == nil {
+		return nil
+	}
+	args, ok := a.bindNamedArguments(expr.Arguments, expr.ArgumentNames, ctor.Signature, constructorName, expr.Token.Pos)
+	if !ok {
+		return nil
+	}
+
+	positional := *expr
+	positional.Arguments = args
+	positional.ArgumentNames = nil
+	return &positional
+}
+
+// namedArgumentTarget returns the single declared method among candidates,
+// ignoring the implicit parameterless constructor. It reports an error and
+// returns nil when there is no declaration or the method is overloaded.
+func (a *Analyzer) namedArgumentTarget(candidates []*types.MethodInfo, name string, pos token.Position) *types.MethodInfo {
+	var declared []*types.MethodInfo
+	for _, candidate := range candidates {
+		if candidate.IsSynthesized || (candidate.IsConstructor && candidate.Visibility == 0 && len(candidate.Signature.Parameters) == 0) {
+			continue
+		}
+		declared = append(declared, candidate)
+	}
+
+	switch {
+	case len(declared) == 0:
+		a.addError("named arguments are only supported in calls to declared functions and methods at %s", pos.String())
+		return nil
+	case len(declared) > 1 || declared[0].HasOverloadDirective:
+		a.addError("named arguments are not supported for overloaded method '%s' at %s", name, pos.String())
+		return nil
+	}
+	return declared[0]
+}
+
+// bindNamedArguments binds args against the parameters of funcType, reporting
+// binding errors against the callee name.
+func (a *Analyzer) bindNamedArguments(args []ast.Expression, names []*ast.Identifier, funcType *types.FunctionType, name string, pos token.Position) ([]ast.Expression, bool) {
+	if len(funcType.ParamNames) != len(funcType.Parameters) {
+		a.addError("named arguments are only supported in calls to declared functions and methods at %s", pos.String())
+		return nil, false
+	}
+
+	defaults := make([]ast.Expression, len(funcType.Parameters))
+	for i, def := range funcType.DefaultValues {
+		if defExpr, ok := def.(ast.Expression); ok && i < len(defaults) {
+			defaults[i] = defExpr
+		}
+	}
+
+	bound, err := BindNamedArguments(args, names, funcType.ParamNames, defaults)
+	if err != nil {
+		a.addError("%s in call to '%s' at %s", err.Error(), name, pos.String())
+		return nil, false
+	}
+	return bound, true
+}
@@ -120,6 +120,113 @@ func TestOperatorSignatureMismatch(t *testing.T) {
 	})
 }
 
+// Test that operator declarations overload an overloadable operator with the
+// number of operands it takes and a return type
+func TestOperatorDeclarationValidation(t *testing.T) {
+	t.Run("valid binary and unary overloads", func(t *testing.T) {
+		input := `
+			type TVector = record X, Y: Integer; end;
+
+			function VectorAdd(a, b: TVector): TVector;
+			begin
+				Result.X := a.X + b.X;
+				Result.Y := a.Y + b.Y;
+			end;
+
+			function VectorNegate(a: TVector): TVector;
+			begin
+				Result.X := -a.X;
+				Result.Y := -a.Y;
+			end;
+
+			operator + (TVector, TVector) : TVector uses VectorAdd;
+			operator - (TVector) : TVector uses VectorNegate;
+
+			var v: TVector;
+			var w := v + v;
+			var n := -v;
+		`
+		expectNoErrors(t, input)
+	})
+
+	t.Run("wrong arity", func(t *testing.T) {
+		input := `
+			function Twice(i: Integer): Integer;
+			begin
+				Result := i * 2;
+			end;
+
+			operator * (Integer) : Integer uses Twice;
+		`
+		expectError(t, input, "operator '*' expects 2 operands, got 1")
+	})
+
+	t.Run("wrong arity for an operator with a unary form", func(t *testing.T) {
+		input := `
+			function Sum3(a, b, c: Integer): Integer;
+			begin
+				Result := a + b + c;
+			end;
+
+			operator + (Integer, Integer, Integer) : Integer uses Sum3;
+		`
+		expectError(t, input, "operator '+' expects 1 or 2 operands, got 3")
+	})
+
+	t.Run("missing return type", func(t *testing.T) {
+		input := `
+			procedure Combine(a, b: String);
+			begin
+			end;
+
+			operator + (String, Boolean) uses Combine;
+		`
+		expectError(t, input, "operator '+' must specify a return type")
+	})
+
+	t.Run("operator that cannot be overloaded", func(t *testing.T) {
+		input := `
+			function Assign(a, b: String): String;
+			begin
+				Result := b;
+			end;
+
+			operator := (String, String) : String uses Assign;
+		`
+		expectError(t, input, "operator ':=' cannot be overloaded")
+	})
+
+	t.Run("compound assignment outside a class", func(t *testing.T) {
+		input := `
+			function Append(a, b: String): String;
+			begin
+				Result := a + b;
+			end;
+
+			operator += (String, Integer) : String uses Append;
+		`
+		expectError(t, input, "operator '+=' cannot be overloaded")
+	})
+
+	t.Run("duplicate overload with another binding", func(t *testing.T) {
+		input := `
+			function StrPlusInt(s: String; i: Integer): String;
+			begin
+				Result := s;
+			end;
+
+			function StrPlusInt2(s: String; i: Integer): String;
+			begin
+				Result := s + s;
+			end;
+
+			operator + (String, Integer) : String uses StrPlusInt;
+			operator + (String, Integer) : String uses StrPlusInt2;
+		`
+		expectError(t, input, "operator '+' already defined for operand types (String, Integer)")
+	})
+}
+
 // Test invalid binding function (not found, wrong signature)
 func TestInvalidBindingFunction(t *testing.T) {
 	// Test 1: Binding function not found
@@ -151,3 +258,87 @@ func TestInvalidBindingFunction(t *testing.T) {
 		expectError(t, input, "not found")
 	})
 }
+
+// Test class operators declared on records and single-operand comparisons
+func TestRecordClassOperators(t *testing.T) {
+	t.Run("comparison operators", func(t *testing.T) {
+		input := `
+			type TMoney = record
+				Cents: Integer;
+				function Less(other: TMoney): Boolean; begin Result := Cents < other.Cents; end;
+				function Max(other: TMoney): TMoney; begin if Self < other then Result := other else Result := Self; end;
+				class operator < TMoney uses Less;
+			end;
+
+			var a, b: TMoney;
+			var ok: Boolean := a < b;
+		`
+		expectNoErrors(t, input)
+	})
+
+	t.Run("implicit conversions", func(t *testing.T) {
+		input := `
+			type TMoney = record
+				Cents: Integer;
+				class function FromInt(i: Integer): TMoney; begin Result.Cents := i; end;
+				function ToFloat: Float; begin Result := Cents / 100; end;
+				class operator Implicit Integer uses FromInt;
+				class operator Implicit TMoney: Float uses ToFloat;
+			end;
+
+			procedure Show(m: TMoney); begin end;
+
+			var m: TMoney := 250;
+			var f: Float := m;
+			m := 5;
+			Show(7);
+		`
+		expectNoErrors(t, input)
+	})
+
+	t.Run("sort uses less-than operator", func(t *testing.T) {
+		input := `
+			type TMoney = record
+				Cents: Integer;
+				function Less(other: TMoney): Boolean; begin Result := Cents < other.Cents; end;
+				class operator < TMoney uses Less;
+			end;
+
+			var arr: array of TMoney;
+			arr.Sort;
+		`
+		expectNoErrors(t, input)
+	})
+}
+
+// Test implicit conversions declared on classes
+func TestClassImplicitConversion(t *testing.T) {
+	input := `
+		type TTag = class
+			Name: String;
+			class function FromStr(s: String): TTag; begin Result := TTag.Create; Result.Name := s; end;
+			class operator Implicit String: TTag uses FromStr;
+		end;
+
+		var t: TTag := 'hello';
+	`
+	expectNoErrors(t, input)
+}
+
+// Test ambiguous implicit conversions are reported with their candidates
+func TestAmbiguousImplicitConversion(t *testing.T) {
+	input := `
+		type TMoney = record
+			Cents: Integer;
+			class function FromInt(i: Integer): TMoney; begin Result.Cents := i; end;
+			class operator Implicit Integer uses FromInt;
+		end;
+
+		function IntToMoney(i: Integer): TMoney; begin Result.Cents := i * 100; end;
+		operator implicit (Integer): TMoney uses IntToMoney;
+
+		var c: TMoney := 42;
+	`
+	expectError(t, input, "ambiguous implicit conversion from Integer to TMoney")
+	expectError(t, input, "TMoney.FromInt")
+}
@@ -419,6 +419,12 @@ func requiredParamCount(sig *types.FunctionType) int {
 	return required
 }
 
+// acceptsArgumentCount reports whether a call may pass n arguments to sig,
+// treating parameters with default values as optional.
+func acceptsArgumentCount(sig *types.FunctionType, n int) bool {
+	return n >= requiredParamCount(sig) && n <= len(sig.Parameters)
+}
+
 // mergeDefaultValues copies parameter default values from a declaration signature
 // into an implementation signature for parameters where the implementation did not
 // respecify them. DWScript allows (and expects) implementations to omit defaults
@@ -539,6 +539,44 @@ end;
 	}
 }
 
+func TestDefaultPropertyIndexer(t *testing.T) {
+	const decl = `
+type TMap = class
+	function GetItem(key: String): Integer; begin Result := 0; end;
+	procedure SetItem(key: String; value: Integer); begin end;
+	property Items[key: String]: Integer read GetItem write SetItem; default;
+end;
+var m := TMap.Create;
+`
+
+	program := parseProgram(t, decl)
+	analyzer := NewAnalyzer()
+	if err := analyzer.Analyze(program); err != nil {
+		t.Fatalf("unexpected semantic error: %v", err)
+	}
+	prop, found := analyzer.GetClasses()["tmap"].Properties["Items"]
+	if !found {
+		t.Fatal("Items property not found")
+	}
+	if !prop.IsIndexed || !prop.IsDefault {
+		t.Errorf("Items: IsIndexed = %v, IsDefault = %v, want both true", prop.IsIndexed, prop.IsDefault)
+	}
+	if len(prop.IndexParamTypes) != 1 || prop.IndexParamTypes[0] != types.STRING || prop.Type != types.INTEGER {
+		t.Errorf("Items signature = %v: %v, want [String]: Integer", prop.IndexParamTypes, prop.Type)
+	}
+
+	expectNoErrors(t, decl+`
+m['a'] := 1;
+m['a'] := m['a'] + 1;
+var n: Integer := m['b'];
+var f: Float := m['c'];
+`)
+	expectError(t, decl+`PrintLn(m[1]);`, `Array index expected "String" but got "Integer"`)
+	expectError(t, decl+`m[1] := 2;`, `Array index expected "String" but got "Integer"`)
+	expectError(t, decl+`m['a'] := 'x';`, `Cannot assign "String" to "Integer"`)
+	expectError(t, decl+`var s: String := m['a'];`, "Cannot assign Integer to String")
+}
+
 // ============================================================================
 // Class Property Tests
 // ============================================================================
@@ -1047,6 +1085,81 @@ end;
 `,
 			expectedError: `Argument 0 expects type "Integer" instead of "String"`,
 		},
+		{
+			name: "read-only default indexed property write",
+			input: `
+type
+	TList = class
+		function GetItem(i: Integer): Integer; begin Result := i; end;
+		property Items[i: Integer]: Integer read GetItem; default;
+	end;
+var l := TList.Create;
+begin
+	l[0] := 1;
+end;
+`,
+			expectedError: "Cannot set a value for a read-only property",
+		},
+		{
+			name: "read-only named indexed property write",
+			input: `
+type
+	TList = class
+		function GetItem(i: Integer): Integer; begin Result := i; end;
+		property Items[i: Integer]: Integer read GetItem;
+	end;
+var l := TList.Create;
+begin
+	l.Items[0] := 1;
+end;
+`,
+			expectedError: "Cannot set a value for a read-only property",
+		},
+		{
+			name: "write-only default indexed property read",
+			input: `
+type
+	TList = class
+		procedure SetItem(i: Integer; v: Integer); begin end;
+		property Items[i: Integer]: Integer write SetItem; default;
+	end;
+var l := TList.Create;
+begin
+	PrintLn(l[0]);
+end;
+`,
+			expectedError: "Cannot read a write only property",
+		},
+		{
+			name: "write-only indexed property compound assignment",
+			input: `
+type
+	TList = class
+		procedure SetItem(i: Integer; v: Integer); begin end;
+		property Items[i: Integer]: Integer write SetItem; default;
+	end;
+var l := TList.Create;
+begin
+	l[0] += 1;
+end;
+`,
+			expectedError: "Cannot read a write only property",
+		},
+		{
+			name: "read-only interface default property write",
+			input: `
+type
+	IList = interface
+		function GetItem(i: Integer): Integer;
+		property Items[i: Integer]: Integer read GetItem; default;
+	end;
+var l: IList;
+begin
+	l[0] := 1;
+end;
+`,
+			expectedError: "Cannot set a value for a read-only property",
+		},
 	}
 
 	for _, tt := range tests {
@@ -1055,3 +1168,29 @@ end;
 		})
 	}
 }
+
+func TestWriteOnlyIndexedPropertyAssignment(t *testing.T) {
+	input := `
+type
+	IList = interface
+		procedure SetItem(i: Integer; v: Integer);
+		procedure SetNamed(idx: Integer; v: Integer);
+		property Items[i: Integer]: Integer write SetItem; default;
+		property First: Integer index 0 write SetNamed;
+	end;
+	TList = class
+		procedure SetItem(i: Integer; v: Integer); begin end;
+		property Items[i: Integer]: Integer write SetItem; default;
+	end;
+var l := TList.Create;
+var il: IList;
+begin
+	l[0] := 1;
+	l.Items[1] := 2;
+	il[0] := 1;
+	il.Items[1] := 2;
+	il.First := 3;
+end;
+`
+	expectNoErrors(t, input)
+}
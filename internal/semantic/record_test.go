@@ -144,6 +144,89 @@ func TestRecordErrors(t *testing.T) {
 	}
 }
 
+func TestRecordCyclicTypeDefinitions(t *testing.T) {
+	tests := []struct {
+		name          string
+		input         string
+		expectedError string
+	}{
+		{
+			name: "mutual record by-value cycle",
+			input: `
+				type
+					TA = record
+						B: TB;
+					end;
+					TB = record
+						A: TA;
+					end;
+			`,
+			expectedError: "illegal cyclic type definition: TA -> TB -> TA at 3:6",
+		},
+		{
+			name: "cycle through static array type",
+			input: `
+				type
+					TArr = array[0..1] of TB;
+					TB = record
+						Items: TArr;
+					end;
+			`,
+			expectedError: "illegal cyclic type definition: TArr -> TB -> TArr",
+		},
+		{
+			name: "cycle through alias and inline static array",
+			input: `
+				type
+					TA = record
+						C: TC;
+					end;
+					TC = TB;
+					TB = record
+						X: Integer;
+						Items: array[1..2] of TA;
+					end;
+			`,
+			expectedError: "illegal cyclic type definition: TA -> TC -> TB -> TA",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := analyzeSource(t, tt.input)
+			if err == nil {
+				t.Fatalf("expected error containing '%s', got no error", tt.expectedError)
+			}
+			analysisErr, ok := err.(*AnalysisError)
+			if !ok || len(analysisErr.Errors) != 1 {
+				t.Fatalf("expected exactly one error, got: %v", err)
+			}
+			if !ErrorMatches(analysisErr.Errors[0], tt.expectedError) {
+				t.Errorf("expected error containing '%s', got: %v", tt.expectedError, err)
+			}
+		})
+	}
+}
+
+func TestClassReferenceCycleIsLegal(t *testing.T) {
+	input := `
+		type
+			TB = class;
+			TA = class
+				B: TB;
+			end;
+			TB = class
+				A: TA;
+			end;
+			TRec = record
+				Owner: TA;
+				Children: array of TRec;
+			end;
+		var a: TA := TA.Create;
+	`
+	expectNoErrors(t, input)
+}
+
 func TestRecordAllowsRecursiveDynamicArrayField(t *testing.T) {
 	input := `
 		type TRec = record
@@ -179,6 +262,16 @@ func TestRecordLiterals(t *testing.T) {
 				var person: TPerson := (Name: 'Alice', Age: 30);
 			`,
 		},
+		{
+			name: "array of anonymous record literals",
+			input: `
+				type TPoint = record
+					X, Y: Integer;
+				end;
+				var pts: array of TPoint := [(X: 1; Y: 2), (X: 3; Y: 4)];
+				const fixed: array [0..1] of TPoint = [(X: 5; Y: 6), (X: 7; Y: 8)];
+			`,
+		},
 	}
 
 	for _, tt := range tests {
@@ -234,6 +327,14 @@ func TestRecordLiteralErrors(t *testing.T) {
 			`,
 			expectedError: "duplicate field",
 		},
+		{
+			name: "type mismatch in array element literal",
+			input: `type TPoint = record
+	X, Y: Integer;
+end;
+var pts: array of TPoint := [(X: 1; Y: 2), (X: 3; Y: 'four')];`,
+			expectedError: "cannot assign String to Integer in field 'Y' at 4:54",
+		},
 	}
 
 	for _, tt := range tests {
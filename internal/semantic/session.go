@@ -0,0 +1,27 @@
+package semantic
+
+import "github.com/cwbudde/go-dws/internal/types"
+
+// ResetErrors discards the errors, warnings and hints reported so far while
+// keeping every declaration, so the analyzer can analyze a further program
+// that builds on the programs it analyzed before (as in an interactive
+// session) and report only that program's diagnostics.
+func (a *Analyzer) ResetErrors() {
+	a.errors = make([]string, 0)
+	a.structuredErrors = make([]*SemanticError, 0)
+}
+
+// ForgetFunction removes the global function name declared by a program
+// analyzed earlier, so that a further program may declare it anew. All
+// overloads are removed. Functions predeclared with DeclareFunction are kept.
+// Returns true if a function was removed.
+func (a *Analyzer) ForgetFunction(name string) bool {
+	sym, ok := a.symbols.Resolve(name)
+	if !ok || sym.DeclPosition.Line == 0 {
+		return false
+	}
+	if _, isFunc := sym.Type.(*types.FunctionType); !isFunc && !sym.IsOverloadSet {
+		return false
+	}
+	return a.symbols.Remove(name)
+}
@@ -28,6 +28,15 @@ func TestSetTypeRegistration(t *testing.T) {
 				var colors: TColors;
 			`,
 		},
+		{
+			name: "set of subrange",
+			input: `
+				type TNeg = -5..5;
+				type TNegSet = set of TNeg;
+				var s: TNegSet := [-5, -1, 3];
+				var b: Boolean := -1 in s;
+			`,
+		},
 		{
 			name: "multiple set types",
 			input: `
@@ -75,6 +84,13 @@ func TestSetTypeErrors(t *testing.T) {
 			`,
 			expectedError: "unknown type 'TDays'",
 		},
+		{
+			name: "non-ordinal element type",
+			input: `
+				type TFloats = set of Float;
+			`,
+			expectedError: "set element type must be ordinal",
+		},
 	}
 
 	for _, tt := range tests {
@@ -217,6 +233,17 @@ func TestSetOperations(t *testing.T) {
 				var s3: TColors := s1 * s2;
 			`,
 		},
+		{
+			name: "set comparisons",
+			input: `
+				type TColor = (Red, Green, Blue);
+				type TColors = set of TColor;
+				var s1: TColors := [Red];
+				var s2: TColors := [Red, Green];
+				var b: Boolean := (s1 <= s2) and (s2 >= s1) and (s1 <> s2) and not (s1 = s2);
+				b := s1 <= [Red, Blue];
+			`,
+		},
 		{
 			name: "chained set operations",
 			input: `
@@ -0,0 +1,161 @@
+package semantic
+
+import "testing"
+
+const strictVisibilityClasses = `
+type TBase = class
+strict private
+  FSecret: Integer;
+private
+  FPriv: Integer;
+strict protected
+  FGuarded: Integer;
+  procedure Guard;
+public
+  procedure Touch;
+end;
+
+type TChild = class(TBase)
+  procedure Test;
+end;
+
+type TBaseHelper = class helper for TBase
+  procedure Peek;
+end;
+
+procedure TBase.Guard;
+begin
+end;
+
+procedure TBase.Touch;
+begin
+  FSecret := 1;
+  FPriv := 2;
+  FGuarded := 3;
+  Guard;
+end;
+`
+
+func TestStrictVisibilityAllowedFromOwnClass(t *testing.T) {
+	expectNoErrors(t, strictVisibilityClasses+`
+procedure TChild.Test;
+begin
+  FGuarded := 4;
+  Guard;
+end;
+
+procedure TBaseHelper.Peek;
+begin
+  PrintLn(FPriv);
+end;
+`)
+}
+
+func TestStrictPrivateHiddenFromDescendants(t *testing.T) {
+	expectError(t, strictVisibilityClasses+`
+procedure TChild.Test;
+begin
+  FSecret := 4;
+end;
+
+procedure TBaseHelper.Peek;
+begin
+end;
+`, `Member symbol "FSecret" is strict private and not visible from this scope`)
+}
+
+func TestStrictMembersHiddenFromHelpers(t *testing.T) {
+	tests := []struct {
+		name     string
+		body     string
+		expected string
+	}{
+		{"strict private field", "PrintLn(FSecret);", `Member symbol "FSecret" is strict private and not visible from this scope`},
+		{"strict private via Self", "PrintLn(Self.FSecret);", `Member symbol "FSecret" is strict private and not visible from this scope`},
+		{"strict protected field", "PrintLn(FGuarded);", `Member symbol "FGuarded" is strict protected and not visible from this scope`},
+		{"strict protected method", "Self.Guard;", `Member symbol "Guard" is strict protected and not visible from this scope`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			expectError(t, strictVisibilityClasses+`
+procedure TChild.Test;
+begin
+end;
+
+procedure TBaseHelper.Peek;
+begin
+  `+tt.body+`
+end;
+`, tt.expected)
+		})
+	}
+}
+
+func TestStrictPrivateHiddenFromOutside(t *testing.T) {
+	expectError(t, strictVisibilityClasses+`
+procedure TChild.Test;
+begin
+end;
+
+procedure TBaseHelper.Peek;
+begin
+end;
+
+var b := TBase.Create;
+PrintLn(b.FSecret);
+`, `Member symbol "FSecret" is strict private and not visible from this scope`)
+}
+
+func TestClassVarInitializers(t *testing.T) {
+	expectNoErrors(t, `
+type TCounter = class
+  const Base = 10;
+  const Double = Base * 2;
+  class var Start: Integer := Double + 1;
+  class var Next: Integer := Start * 2;
+  class var Label: String := 'n' + IntToStr(TCounter.Next);
+end;
+`)
+}
+
+func TestClassVarInitializerCycle(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{
+			name: "self reference",
+			input: `
+type TA = class
+  class var X: Integer := X + 1;
+end;`,
+			expected: `Circular initialization: class variable "X" is not initialized yet`,
+		},
+		{
+			name: "forward reference",
+			input: `
+type TA = class
+  class var X: Integer := Y;
+  class var Y: Integer := 1;
+end;`,
+			expected: `Circular initialization: class variable "Y" is not initialized yet`,
+		},
+		{
+			name: "qualified forward reference",
+			input: `
+type TA = class
+  class var X: Integer := TA.Y;
+  class var Y: Integer := TA.X;
+end;`,
+			expected: `Circular initialization: class variable "Y" is not initialized yet`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			expectError(t, tt.input, tt.expected)
+		})
+	}
+}
@@ -662,3 +662,18 @@ func TestEvaluateConstantInt(t *testing.T) {
 		})
 	}
 }
+
+// TestSubrangeOperandsAndWidening tests that subrange values are usable as
+// operands and assignable to Float and to other subranges of the same base.
+func TestSubrangeOperandsAndWidening(t *testing.T) {
+	expectNoErrors(t, `
+		type TByte = 0..255;
+		type TDigit = 0..9;
+		var b: TByte := 200;
+		var d: TDigit := 3;
+		var i: Integer := b + d * 2 - (-d);
+		var f: Float := d;
+		var ok: Boolean := b > d;
+		b := d;
+	`)
+}
@@ -610,6 +610,12 @@ func (st *SymbolTable) Resolve(name string) (*Symbol, bool) {
 	return nil, false
 }
 
+// Remove deletes the symbol name from the current scope (case-insensitive).
+// Returns true if the symbol was found and removed.
+func (st *SymbolTable) Remove(name string) bool {
+	return st.symbols.Delete(name)
+}
+
 // IsDeclaredInCurrentScope checks if a symbol is declared in the current scope (case-insensitive).
 func (st *SymbolTable) IsDeclaredInCurrentScope(name string) bool {
 	return st.symbols.Has(name)
@@ -0,0 +1,187 @@
+package semantic
+
+import (
+	"strings"
+
+	"github.com/cwbudde/go-dws/pkg/ast"
+	"github.com/cwbudde/go-dws/pkg/ident"
+)
+
+// ============================================================================
+// Type Declaration Cycle Detection
+// ============================================================================
+
+// detectTypeDeclarationCycles reports by-value cycles among the declarations of
+// a single type section, e.g.
+//
+//	type
+//	  TA = record B: TB; end;
+//	  TB = record A: TA; end;
+//
+// Such a cycle has no finite layout, so it is reported as
+// "illegal cyclic type definition: TA -> TB -> TA" at the first declaration of
+// the cycle. Only records, aliases and static arrays contain their element types
+// by value; class, interface, dynamic array and function pointer references are
+// legal cycles and are not followed. Direct self-containment (a record holding a
+// field of its own type) is left to analyzeRecordDecl, which reports it with the
+// DWScript "not fully defined" diagnostic.
+func (a *Analyzer) detectTypeDeclarationCycles(decls []ast.Statement) {
+	nodes := make(map[string]ast.Statement)
+	var order []string
+	for _, decl := range decls {
+		name := valueTypeDeclName(decl)
+		if name == "" {
+			continue
+		}
+		key := ident.Normalize(name)
+		if _, exists := nodes[key]; exists {
+			continue
+		}
+		nodes[key] = decl
+		order = append(order, key)
+	}
+	if len(nodes) < 2 {
+		return
+	}
+
+	edges := make(map[string][]string, len(nodes))
+	for key, decl := range nodes {
+		for _, dep := range valueTypeDeclDependencies(decl) {
+			depKey := ident.Normalize(dep)
+			if _, ok := nodes[depKey]; ok && depKey != key {
+				edges[key] = append(edges[key], depKey)
+			}
+		}
+	}
+
+	const (
+		unvisited = iota
+		visiting
+		done
+	)
+	state := make(map[string]int, len(nodes))
+	var stack []string
+
+	var visit func(key string)
+	visit = func(key string) {
+		state[key] = visiting
+		stack = append(stack, key)
+		for _, dep := range edges[key] {
+			switch state[dep] {
+			case visiting:
+				a.reportTypeCycle(nodes, stack, dep)
+			case unvisited:
+				visit(dep)
+			}
+		}
+		stack = stack[:len(stack)-1]
+		state[key] = done
+	}
+
+	for _, key := range order {
+		if state[key] == unvisited {
+			visit(key)
+		}
+	}
+}
+
+// reportTypeCycle reports the cycle that closes at start, using the declared
+// names of the types on the DFS stack. Every type on the cycle is remembered in
+// cyclicTypes so field resolution does not pile "unknown type" errors on top.
+func (a *Analyzer) reportTypeCycle(nodes map[string]ast.Statement, stack []string, start string) {
+	first := 0
+	for i, key := range stack {
+		if key == start {
+			first = i
+			break
+		}
+	}
+	cycle := stack[first:]
+
+	names := make([]string, 0, len(cycle)+1)
+	for _, key := range cycle {
+		names = append(names, valueTypeDeclName(nodes[key]))
+		a.cyclicTypes[key] = true
+	}
+	names = append(names, names[0])
+
+	a.addStructuredError(NewGenericError(valueTypeDeclIdent(nodes[start]).Token.Pos,
+		"illegal cyclic type definition: "+strings.Join(names, " -> ")))
+}
+
+// isCyclicType reports whether a type name was found on a by-value declaration cycle.
+func (a *Analyzer) isCyclicType(name string) bool {
+	return a.cyclicTypes[ident.Normalize(name)]
+}
+
+// valueTypeDeclName returns the declared name of a type declaration that can
+// contain other types by value, or "" for any other statement.
+func valueTypeDeclName(stmt ast.Statement) string {
+	if name := valueTypeDeclIdent(stmt); name != nil {
+		return name.Value
+	}
+	return ""
+}
+
+// valueTypeDeclIdent returns the name identifier of a type declaration that can
+// contain other types by value, or nil for any other statement.
+func valueTypeDeclIdent(stmt ast.Statement) *ast.Identifier {
+	switch d := stmt.(type) {
+	case *ast.RecordDecl:
+		if len(d.TypeParams) == 0 {
+			return d.Name
+		}
+	case *ast.ArrayDecl:
+		return d.Name
+	case *ast.TypeDeclaration:
+		if d.IsAlias && len(d.TypeParams) == 0 {
+			return d.Name
+		}
+	}
+	return nil
+}
+
+// valueTypeDeclDependencies lists the type names a declaration holds by value.
+func valueTypeDeclDependencies(stmt ast.Statement) []string {
+	var deps []string
+	switch d := stmt.(type) {
+	case *ast.RecordDecl:
+		for _, field := range d.Fields {
+			deps = appendValueTypeNames(deps, field.Type)
+		}
+	case *ast.ArrayDecl:
+		if d.ArrayType != nil && d.ArrayType.LowBound != nil {
+			deps = appendValueTypeNames(deps, d.ArrayType.ElementType)
+		}
+	case *ast.TypeDeclaration:
+		deps = appendValueTypeNames(deps, d.AliasedType)
+	}
+	return deps
+}
+
+// appendValueTypeNames appends the named types contained by value in typeExpr.
+func appendValueTypeNames(deps []string, typeExpr ast.TypeExpression) []string {
+	switch t := typeExpr.(type) {
+	case *ast.TypeAnnotation:
+		if t == nil {
+			return deps
+		}
+		if t.InlineType != nil {
+			return appendValueTypeNames(deps, t.InlineType)
+		}
+		if t.Name != "" && len(t.TypeArgs) == 0 {
+			deps = append(deps, t.Name)
+		}
+	case *ast.ArrayTypeNode:
+		if t != nil && (t.LowBound != nil || t.IndexType != nil) {
+			deps = appendValueTypeNames(deps, t.ElementType)
+		}
+	case *ast.RecordTypeNode:
+		if t != nil {
+			for _, field := range t.Fields {
+				deps = appendValueTypeNames(deps, field.Type)
+			}
+		}
+	}
+	return deps
+}
@@ -228,6 +228,9 @@ func (a *Analyzer) resolveType(typeName string) (types.Type, error) {
 	// Try basic types first (TypeFromString handles case-insensitivity)
 	basicType, err := types.TypeFromString(typeName)
 	if err == nil {
+		if basicType == types.DATETIME {
+			return types.TDATETIME, nil
+		}
 		return basicType, nil
 	}
 
@@ -241,6 +244,10 @@ func (a *Analyzer) resolveType(typeName string) (types.Type, error) {
 		return subrangeType, nil
 	}
 
+	if normalizedName == "byte" {
+		return types.BYTE, nil
+	}
+
 	if sym, found := a.symbols.Resolve(typeName); found && sym != nil {
 		return nil, fmt.Errorf("%s is not a Type", sym.Name)
 	}
@@ -918,10 +925,12 @@ func (a *Analyzer) getMethodOverloadsInHierarchy(methodName string, classType *t
 		result = append(result, constructorOverloads...)
 
 		// Add implicit parameterless constructor if not already present
-		// (DWScript allows calling constructors with no arguments)
+		// (DWScript allows calling constructors with no arguments). A
+		// constructor whose parameters all have defaults already accepts
+		// an empty argument list.
 		hasParameterlessConstructor := false
 		for _, ctor := range constructorOverloads {
-			if len(ctor.Signature.Parameters) == 0 {
+			if requiredParamCount(ctor.Signature) == 0 {
 				hasParameterlessConstructor = true
 				break
 			}
@@ -1115,7 +1124,7 @@ func (a *Analyzer) addParentFieldsToScope(parent *types.ClassType) {
 			// FieldVisibility uses normalized keys, but Fields uses original case
 			normalizedFieldName := ident.Normalize(fieldName)
 			visibility, ok := parent.FieldVisibility[normalizedFieldName]
-			if ok && visibility == int(ast.VisibilityPrivate) {
+			if ok && ast.Visibility(visibility).IsPrivate() {
 				continue
 			}
 			// Use zero position for synthesized parent field bindings
@@ -149,7 +149,7 @@ func (a *Analyzer) collectUnusedPrivateClassMemberWarnings(classType *types.Clas
 	warnings := make([]memberWarning, 0)
 
 	for name, vis := range classType.FieldVisibility {
-		if vis != int(ast.VisibilityPrivate) {
+		if !ast.Visibility(vis).IsPrivate() {
 			continue
 		}
 		if classType.FieldUsed(name) {
@@ -170,7 +170,7 @@ func (a *Analyzer) collectUnusedPrivateClassMemberWarnings(classType *types.Clas
 	}
 
 	for name, vis := range classType.MethodVisibility {
-		if vis != int(ast.VisibilityPrivate) {
+		if !ast.Visibility(vis).IsPrivate() {
 			continue
 		}
 		if classType.MethodUsed(name) {
@@ -0,0 +1,233 @@
+package types
+
+// ============================================================================
+// Assignability
+// ============================================================================
+
+// ConversionKind describes the implicit conversion applied when a value of one
+// type is assigned to a location of another type.
+type ConversionKind int
+
+const (
+	// ConversionNone means the value is stored as-is (identical or structurally
+	// compatible types, subranges, function pointers).
+	ConversionNone ConversionKind = iota
+	// ConversionIntToFloat widens an Integer to a Float.
+	ConversionIntToFloat
+	// ConversionUpcast treats a class, metaclass or interface as one of its ancestors.
+	ConversionUpcast
+	// ConversionInterfaceWrap wraps an object in an interface it implements.
+	ConversionInterfaceWrap
+	// ConversionNilToRef assigns nil to a reference type or clears a dynamic
+	// or associative array.
+	ConversionNilToRef
+	// ConversionEnumToInt replaces an enum value with its ordinal.
+	ConversionEnumToInt
+	// ConversionVariant boxes a value into, or unboxes it from, a Variant or JSONVariant.
+	ConversionVariant
+)
+
+// String returns the name of the conversion kind.
+func (k ConversionKind) String() string {
+	switch k {
+	case ConversionNone:
+		return "None"
+	case ConversionIntToFloat:
+		return "IntToFloat"
+	case ConversionUpcast:
+		return "Upcast"
+	case ConversionInterfaceWrap:
+		return "InterfaceWrap"
+	case ConversionNilToRef:
+		return "NilToRef"
+	case ConversionEnumToInt:
+		return "EnumToInt"
+	case ConversionVariant:
+		return "Variant"
+	default:
+		return "Unknown"
+	}
+}
+
+// IsAssignable reports whether a value of type source can be assigned to a
+// location of type target and, if so, which implicit conversion applies.
+//
+// This is the single source of truth for built-in assignment rules; both the
+// semantic analyzer and the interpreter consult it. User-defined implicit
+// operators are not considered here since they live in per-program registries.
+func IsAssignable(target, source Type) (bool, ConversionKind) {
+	if target == nil || source == nil {
+		return false, ConversionNone
+	}
+
+	to := GetUnderlyingType(target)
+	from := GetUnderlyingType(source)
+	toKind := to.TypeKind()
+	fromKind := from.TypeKind()
+
+	if from.Equals(to) {
+		return true, ConversionNone
+	}
+
+	switch {
+	case fromKind == "NIL":
+		return isNilAssignable(to), ConversionNilToRef
+	case toKind == "NIL":
+		// Reference comparisons against nil (obj = nil)
+		switch fromKind {
+		case "CLASS", "INTERFACE", "CLASSOF", "FUNCTION_POINTER", "METHOD_POINTER":
+			return true, ConversionNone
+		}
+		return false, ConversionNone
+	case toKind == "VARIANT" || fromKind == "VARIANT":
+		return true, ConversionVariant
+	case toKind == "JSON_VARIANT":
+		return fromKind == "JSON_VARIANT" || isJSONScalarKind(fromKind), ConversionVariant
+	case fromKind == "JSON_VARIANT":
+		return isJSONScalarKind(toKind), ConversionVariant
+	case fromKind == "INTEGER" && toKind == "FLOAT":
+		return true, ConversionIntToFloat
+	case fromKind == "ENUM" && toKind == "INTEGER":
+		return true, ConversionEnumToInt
+	}
+
+	switch t := to.(type) {
+	case *ClassType:
+		if c, ok := from.(*ClassType); ok && isClassDescendantOf(c, t) {
+			return true, ConversionUpcast
+		}
+	case *ClassOfType:
+		switch f := from.(type) {
+		case *ClassOfType:
+			if f.ClassType.Equals(t.ClassType) {
+				return true, ConversionNone
+			}
+			return isClassDescendantOf(f.ClassType, t.ClassType), ConversionUpcast
+		case *ClassType:
+			// A class name used as a value is a metaclass reference
+			return f.Equals(t.ClassType) || isClassDescendantOf(f, t.ClassType), ConversionUpcast
+		}
+	case *InterfaceType:
+		switch f := from.(type) {
+		case *ClassType:
+			return f.ImplementsInterface(t), ConversionInterfaceWrap
+		case *InterfaceType:
+			return f.InheritsFrom(t), ConversionUpcast
+		}
+	case *ArrayType:
+		if f, ok := from.(*ArrayType); ok {
+			return isArrayAssignable(t, f), ConversionNone
+		}
+	case *FunctionPointerType, *MethodPointerType:
+		return isPointerAssignable(to, from), ConversionNone
+	}
+
+	if isSubrangeOf(from, to) || isSubrangeOf(to, from) {
+		return true, ConversionNone
+	}
+	if f, ok := from.(*SubrangeType); ok && f.BaseType != nil {
+		// Subranges of the same base type are assignable (bounds are checked
+		// at runtime), and Integer subranges widen to Float.
+		if t, ok := to.(*SubrangeType); ok && t.BaseType != nil && f.BaseType.Equals(t.BaseType) {
+			return true, ConversionNone
+		}
+		if GetUnderlyingType(f.BaseType).TypeKind() == "INTEGER" && toKind == "FLOAT" {
+			return true, ConversionIntToFloat
+		}
+	}
+
+	return false, ConversionNone
+}
+
+// isNilAssignable reports whether nil can be assigned to target.
+func isNilAssignable(target Type) bool {
+	switch t := target.(type) {
+	case *ClassType, *InterfaceType, *ClassOfType, *AssociativeArrayType,
+		*FunctionPointerType, *MethodPointerType:
+		return true
+	case *ArrayType:
+		return t.IsDynamic()
+	}
+	kind := target.TypeKind()
+	return kind == "VARIANT" || kind == "JSON_VARIANT"
+}
+
+// isJSONScalarKind reports whether values of the kind auto-box into a JSONVariant.
+func isJSONScalarKind(kind string) bool {
+	switch kind {
+	case "INTEGER", "FLOAT", "STRING", "BOOLEAN":
+		return true
+	default:
+		return false
+	}
+}
+
+// isArrayAssignable checks array assignment. Arrays are invariant in their
+// element type; dynamic and static arrays mix freely, static arrays must
+// otherwise have matching bounds.
+func isArrayAssignable(target, source *ArrayType) bool {
+	if !source.ElementType.Equals(target.ElementType) {
+		return false
+	}
+	if source.IsDynamic() || target.IsDynamic() {
+		return true
+	}
+	return source.Equals(target)
+}
+
+// isSubrangeOf reports whether sub is a subrange whose base type is base.
+func isSubrangeOf(sub, base Type) bool {
+	subrange, ok := sub.(*SubrangeType)
+	return ok && subrange.BaseType != nil && subrange.BaseType.Equals(base)
+}
+
+// isPointerAssignable checks function and method pointer assignment.
+func isPointerAssignable(target, source Type) bool {
+	if fromMethodPtr, ok := source.(*MethodPointerType); ok {
+		return fromMethodPtr.IsCompatibleWith(target)
+	}
+	switch t := target.(type) {
+	case *FunctionPointerType:
+		if t.IsCompatibleWith(source) {
+			return true
+		}
+		// For helper methods like Map that use Variant parameters,
+		// allow function pointers with compatible concrete types.
+		if fromFuncPtr, ok := source.(*FunctionPointerType); ok {
+			return isFunctionPointerVariantCompatible(fromFuncPtr, t)
+		}
+	case *MethodPointerType:
+		return t.IsCompatibleWith(source)
+	}
+	return false
+}
+
+// isFunctionPointerVariantCompatible checks if a function pointer with concrete types
+// can be assigned to a function pointer that uses Variant parameters/return type.
+// Non-Variant positions must match exactly (not just be assignable), so that
+// Integer→Float widening does not make function pointers compatible.
+func isFunctionPointerVariantCompatible(from, to *FunctionPointerType) bool {
+	if len(from.Parameters) != len(to.Parameters) {
+		return false
+	}
+
+	hasVariantUsage := false
+	for i, toParam := range to.Parameters {
+		if toParam.Equals(VARIANT) {
+			hasVariantUsage = true
+			continue
+		}
+		if !from.Parameters[i].Equals(toParam) {
+			return false
+		}
+	}
+
+	// If target returns Variant, any concrete return type is acceptable
+	if to.ReturnType != nil && to.ReturnType.Equals(VARIANT) {
+		return true
+	}
+	if from.ReturnType == nil || to.ReturnType == nil {
+		return from.ReturnType == nil && to.ReturnType == nil && hasVariantUsage
+	}
+	return from.ReturnType.Equals(to.ReturnType) && hasVariantUsage
+}
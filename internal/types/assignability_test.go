@@ -0,0 +1,107 @@
+package types
+
+import "testing"
+
+func TestIsAssignable(t *testing.T) {
+	tObject := NewClassType("TObject", nil)
+	tAnimal := NewClassType("TAnimal", tObject)
+	tDog := NewClassType("TDog", tAnimal)
+
+	iBase := NewInterfaceType("IBase")
+	iDerived := NewInterfaceType("IDerived")
+	iDerived.Parent = iBase
+	iOther := NewInterfaceType("IOther")
+	tAnimal.Interfaces = append(tAnimal.Interfaces, iDerived)
+
+	tColor := NewEnumType("TColor", map[string]int{"Red": 0, "Green": 1}, []string{"Red", "Green"})
+	tDigit := &SubrangeType{Name: "TDigit", BaseType: INTEGER, LowBound: 0, HighBound: 9}
+	tMyInt := &TypeAlias{Name: "TMyInt", AliasedType: INTEGER}
+
+	dynInts := NewDynamicArrayType(INTEGER)
+	staticInts := NewStaticArrayType(INTEGER, 0, 4)
+	otherStaticInts := NewStaticArrayType(INTEGER, 1, 5)
+	dynFloats := NewDynamicArrayType(FLOAT)
+	assoc := NewAssociativeArrayType(STRING, INTEGER)
+
+	intFunc := NewFunctionPointerType([]Type{INTEGER}, INTEGER)
+	variantFunc := NewFunctionPointerType([]Type{VARIANT}, VARIANT)
+	floatFunc := NewFunctionPointerType([]Type{FLOAT}, FLOAT)
+
+	tests := []struct {
+		target         Type
+		source         Type
+		name           string
+		wantAssignable bool
+		wantKind       ConversionKind
+	}{
+		// ConversionNone
+		{name: "identical", target: INTEGER, source: INTEGER, wantAssignable: true, wantKind: ConversionNone},
+		{name: "alias to base", target: INTEGER, source: tMyInt, wantAssignable: true, wantKind: ConversionNone},
+		{name: "subrange to base", target: INTEGER, source: tDigit, wantAssignable: true, wantKind: ConversionNone},
+		{name: "base to subrange", target: tDigit, source: INTEGER, wantAssignable: true, wantKind: ConversionNone},
+		{name: "subrange to subrange", target: &SubrangeType{Name: "TByte", BaseType: INTEGER, LowBound: 0, HighBound: 255}, source: tDigit, wantAssignable: true, wantKind: ConversionNone},
+		{name: "static to dynamic array", target: dynInts, source: staticInts, wantAssignable: true, wantKind: ConversionNone},
+		{name: "same metaclass", target: NewClassOfType(tAnimal), source: NewClassOfType(tAnimal), wantAssignable: true, wantKind: ConversionNone},
+		{name: "object compared to nil", target: NIL, source: tDog, wantAssignable: true, wantKind: ConversionNone},
+		{name: "function pointer compared to nil", target: NIL, source: intFunc, wantAssignable: true, wantKind: ConversionNone},
+		{name: "function pointer to Variant signature", target: variantFunc, source: intFunc, wantAssignable: true, wantKind: ConversionNone},
+
+		// ConversionIntToFloat
+		{name: "integer to float", target: FLOAT, source: INTEGER, wantAssignable: true, wantKind: ConversionIntToFloat},
+		{name: "integer alias to float", target: FLOAT, source: tMyInt, wantAssignable: true, wantKind: ConversionIntToFloat},
+		{name: "integer subrange to float", target: FLOAT, source: tDigit, wantAssignable: true, wantKind: ConversionIntToFloat},
+
+		// ConversionUpcast
+		{name: "class to ancestor", target: tObject, source: tDog, wantAssignable: true, wantKind: ConversionUpcast},
+		{name: "metaclass to ancestor metaclass", target: NewClassOfType(tObject), source: NewClassOfType(tDog), wantAssignable: true, wantKind: ConversionUpcast},
+		{name: "class name to metaclass", target: NewClassOfType(tAnimal), source: tDog, wantAssignable: true, wantKind: ConversionUpcast},
+		{name: "interface to parent interface", target: iBase, source: iDerived, wantAssignable: true, wantKind: ConversionUpcast},
+
+		// ConversionInterfaceWrap
+		{name: "class to declared interface", target: iDerived, source: tAnimal, wantAssignable: true, wantKind: ConversionInterfaceWrap},
+		{name: "descendant to inherited interface", target: iBase, source: tDog, wantAssignable: true, wantKind: ConversionInterfaceWrap},
+
+		// ConversionNilToRef
+		{name: "nil to class", target: tAnimal, source: NIL, wantAssignable: true, wantKind: ConversionNilToRef},
+		{name: "nil to interface", target: iBase, source: NIL, wantAssignable: true, wantKind: ConversionNilToRef},
+		{name: "nil to metaclass", target: NewClassOfType(tAnimal), source: NIL, wantAssignable: true, wantKind: ConversionNilToRef},
+		{name: "nil to dynamic array", target: dynInts, source: NIL, wantAssignable: true, wantKind: ConversionNilToRef},
+		{name: "nil to associative array", target: assoc, source: NIL, wantAssignable: true, wantKind: ConversionNilToRef},
+		{name: "nil to function pointer", target: intFunc, source: NIL, wantAssignable: true, wantKind: ConversionNilToRef},
+
+		// ConversionEnumToInt
+		{name: "enum to integer", target: INTEGER, source: tColor, wantAssignable: true, wantKind: ConversionEnumToInt},
+
+		// ConversionVariant
+		{name: "integer to variant", target: VARIANT, source: INTEGER, wantAssignable: true, wantKind: ConversionVariant},
+		{name: "variant to string", target: STRING, source: VARIANT, wantAssignable: true, wantKind: ConversionVariant},
+		{name: "string to JSONVariant", target: JSON_VARIANT, source: STRING, wantAssignable: true, wantKind: ConversionVariant},
+		{name: "JSONVariant to float", target: FLOAT, source: JSON_VARIANT, wantAssignable: true, wantKind: ConversionVariant},
+
+		// Not assignable
+		{name: "float to integer", target: INTEGER, source: FLOAT},
+		{name: "integer to enum", target: tColor, source: INTEGER},
+		{name: "ancestor to descendant", target: tDog, source: tObject},
+		{name: "class to unrelated interface", target: iOther, source: tDog},
+		{name: "parent interface to derived", target: iDerived, source: iBase},
+		{name: "nil to integer", target: INTEGER, source: NIL},
+		{name: "nil to static array", target: staticInts, source: NIL},
+		{name: "array element types differ", target: dynFloats, source: dynInts},
+		{name: "static array bounds differ", target: staticInts, source: otherStaticInts},
+		{name: "function pointer widening", target: floatFunc, source: intFunc},
+		{name: "metaclass to JSONVariant", target: JSON_VARIANT, source: NewClassOfType(tDog)},
+		{name: "nil target", target: nil, source: INTEGER},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assignable, kind := IsAssignable(tt.target, tt.source)
+			if assignable != tt.wantAssignable {
+				t.Fatalf("IsAssignable() assignable = %v, want %v", assignable, tt.wantAssignable)
+			}
+			if assignable && kind != tt.wantKind {
+				t.Errorf("IsAssignable() kind = %s, want %s", kind, tt.wantKind)
+			}
+		})
+	}
+}
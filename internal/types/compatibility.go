@@ -169,7 +169,8 @@ func PromoteTypes(left, right Type) Type {
 //   - Functions are not comparable
 func IsComparableType(t Type) bool {
 	switch t.TypeKind() {
-	case "INTEGER", "FLOAT", "STRING", "BOOLEAN", "NIL", "ENUM", "CLASS", "INTERFACE", "CLASSOF":
+	case "INTEGER", "FLOAT", "STRING", "BOOLEAN", "NIL", "ENUM", "CLASS", "INTERFACE", "CLASSOF",
+		"FUNCTION_POINTER", "METHOD_POINTER", "RTTI_TYPEINFO":
 		return true
 	case "FUNCTION", "VOID":
 		return false
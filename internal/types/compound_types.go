@@ -166,6 +166,7 @@ type RecordPropertyInfo struct {
 type RecordType struct {
 	Fields               map[string]Type
 	FieldNames           map[string]string        // Normalized field name -> original casing
+	FieldOrder           []string                 // Normalized field names in declaration order
 	Methods              map[string]*FunctionType // Instance methods (primary signature)
 	MethodOverloads      map[string][]*MethodInfo // Instance method overloads
 	ClassMethods         map[string]*FunctionType // Static (class) methods (primary signature)
@@ -178,9 +179,26 @@ type RecordType struct {
 	ClassVarNames        map[string]string        // Normalized class var name -> original casing
 	FieldsWithInit       map[string]bool          // Fields that have default initializers
 	FieldVisibility      map[string]int           // Normalized field name -> ast.Visibility (absent = public)
+	Operators            *OperatorRegistry        // Class operators declared in the record
 	Name                 string
 }
 
+// RegisterOperator adds a class operator overload to the record type.
+func (rt *RecordType) RegisterOperator(signature *OperatorSignature) error {
+	if rt.Operators == nil {
+		rt.Operators = NewOperatorRegistry()
+	}
+	return rt.Operators.Register(signature)
+}
+
+// LookupOperator searches for a matching class operator overload declared in the record.
+func (rt *RecordType) LookupOperator(operator string, operandTypes []Type) (*OperatorSignature, bool) {
+	if rt == nil || rt.Operators == nil {
+		return nil, false
+	}
+	return rt.Operators.Lookup(operator, operandTypes)
+}
+
 // String returns a string representation of the record type
 func (rt *RecordType) String() string {
 	if rt.Name != "" {
@@ -316,6 +334,9 @@ func (rt *RecordType) AddField(name string, fieldType Type, hasInitializer bool)
 	}
 
 	fieldKey := ident.Normalize(name)
+	if _, exists := rt.Fields[fieldKey]; !exists {
+		rt.FieldOrder = append(rt.FieldOrder, fieldKey)
+	}
 	rt.Fields[fieldKey] = fieldType
 	if _, exists := rt.FieldNames[fieldKey]; !exists {
 		rt.FieldNames[fieldKey] = name
@@ -325,6 +346,32 @@ func (rt *RecordType) AddField(name string, fieldType Type, hasInitializer bool)
 	}
 }
 
+// OrderedFieldNames returns the normalized field names in declaration order.
+// Fields registered without going through AddField are appended in sorted
+// order, so the result is always deterministic.
+func (rt *RecordType) OrderedFieldNames() []string {
+	names := make([]string, 0, len(rt.Fields))
+	seen := make(map[string]bool, len(rt.Fields))
+	for _, name := range rt.FieldOrder {
+		if _, ok := rt.Fields[name]; ok && !seen[name] {
+			names = append(names, name)
+			seen[name] = true
+		}
+	}
+	if len(names) == len(rt.Fields) {
+		return names
+	}
+
+	extra := make([]string, 0, len(rt.Fields)-len(names))
+	for name := range rt.Fields {
+		if !seen[name] {
+			extra = append(extra, name)
+		}
+	}
+	sort.Strings(extra)
+	return append(names, extra...)
+}
+
 // NewRecordType creates a new record type with the given name and fields
 func NewRecordType(name string, fields map[string]Type) *RecordType {
 	// Normalize field keys for case-insensitive lookup
@@ -337,10 +384,18 @@ func NewRecordType(name string, fields map[string]Type) *RecordType {
 			fieldNames[norm] = k
 		}
 	}
+	// A map carries no declaration order; fall back to a stable sorted order.
+	// Fields added later through AddField keep their declaration order.
+	fieldOrder := make([]string, 0, len(normalizedFields))
+	for norm := range normalizedFields {
+		fieldOrder = append(fieldOrder, norm)
+	}
+	sort.Strings(fieldOrder)
 	return &RecordType{
 		Name:                 name,
 		Fields:               normalizedFields,
 		FieldNames:           fieldNames,
+		FieldOrder:           fieldOrder,
 		Methods:              make(map[string]*FunctionType),
 		MethodOverloads:      make(map[string][]*MethodInfo),
 		ClassMethods:         make(map[string]*FunctionType),
@@ -443,12 +498,14 @@ func (st *SetType) Equals(other Type) bool {
 //   - SubrangeType: (HighBound - LowBound + 1)
 //   - IntegerType: always uses map (unbounded)
 //   - StringType: always uses map (unbounded, used for character sets)
+//
+// Aliases (e.g. Byte) are sized by the type they alias.
 func NewSetType(elementType Type) *SetType {
 	// Determine storage strategy based on element type size
 	storageKind := SetStorageBitmask
 
 	if elementType != nil {
-		switch et := elementType.(type) {
+		switch et := GetUnderlyingType(elementType).(type) {
 		case *EnumType:
 			// Use map storage for enums whose ordinal span doesn't fit in a 64-bit mask
 			minOrd := et.MinOrdinal()
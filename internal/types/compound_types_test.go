@@ -246,6 +246,20 @@ func TestSetStorageKind_Boundary(t *testing.T) {
 	}
 }
 
+// TestSetStorageKind_Alias tests that a set of an alias is stored like a set
+// of the aliased type.
+func TestSetStorageKind_Alias(t *testing.T) {
+	if got := NewSetType(BYTE).StorageKind; got != SetStorageMap {
+		t.Errorf("set of Byte should use map, got %v", got)
+	}
+
+	enum65 := createEnumWithSize(t, "TEnum65", 65)
+	alias := &TypeAlias{Name: "TAlias65", AliasedType: enum65}
+	if got := NewSetType(alias).StorageKind; got != SetStorageMap {
+		t.Errorf("set of an alias of a 65-element enum should use map, got %v", got)
+	}
+}
+
 // TestSetType_EqualityWithDifferentStorageKinds tests that sets with different
 // storage kinds can still be equal if their element types are compatible.
 func TestSetType_EqualityWithDifferentStorageKinds(t *testing.T) {
@@ -769,6 +769,32 @@ func TestConversionRegistry(t *testing.T) {
 		}
 	})
 
+	t.Run("Register implicit conversions from different owners", func(t *testing.T) {
+		registry := NewConversionRegistry()
+		money := NewRecordType("TMoney", map[string]Type{"Cents": INTEGER})
+		global := &ConversionSignature{From: INTEGER, To: money, Kind: ConversionImplicit, Binding: "IntToMoney"}
+		owned := &ConversionSignature{From: INTEGER, To: money, Owner: money, Kind: ConversionImplicit, Binding: "FromInt"}
+
+		if err := registry.Register(global); err != nil {
+			t.Fatalf("Register global failed: %v", err)
+		}
+		if err := registry.Register(owned); err != nil {
+			t.Fatalf("Register owned failed: %v", err)
+		}
+		if err := registry.Register(&ConversionSignature{From: INTEGER, To: money, Owner: money, Kind: ConversionImplicit}); err != ErrConversionDuplicate {
+			t.Errorf("Expected ErrConversionDuplicate for same owner, got %v", err)
+		}
+
+		candidates := registry.ImplicitCandidates(INTEGER, money)
+		if len(candidates) != 2 {
+			t.Fatalf("ImplicitCandidates returned %d candidates, want 2", len(candidates))
+		}
+		found, ok := registry.FindImplicit(INTEGER, money)
+		if !ok || found != global {
+			t.Errorf("FindImplicit should return the first registered candidate")
+		}
+	})
+
 	t.Run("Register nil signature", func(t *testing.T) {
 		registry := NewConversionRegistry()
 		err := registry.Register(nil)
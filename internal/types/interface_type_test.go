@@ -524,3 +524,37 @@ func TestClassTypeInterfacesField(t *testing.T) {
 		}
 	})
 }
+
+// Test interface property inheritance and default property lookup
+func TestInterfacePropertyInheritance(t *testing.T) {
+	iBase := NewInterfaceType("IBase")
+	iBase.Properties["name"] = &PropertyInfo{Name: "Name", Type: STRING, ReadKind: PropAccessMethod, ReadSpec: "GetName"}
+
+	iDerived := NewInterfaceType("IDerived")
+	iDerived.Parent = iBase
+	iDerived.Properties["items"] = &PropertyInfo{
+		Name:            "Items",
+		Type:            STRING,
+		IndexParamTypes: []Type{INTEGER},
+		IsIndexed:       true,
+		IsDefault:       true,
+	}
+
+	allProps := GetAllInterfaceProperties(iDerived)
+	if len(allProps) != 2 {
+		t.Errorf("Expected 2 properties (1 own + 1 inherited), got %d", len(allProps))
+	}
+	if _, ok := allProps["name"]; !ok {
+		t.Error("Should have inherited Name from parent")
+	}
+
+	if prop := iDerived.GetDefaultProperty(); prop == nil || prop.Name != "Items" {
+		t.Errorf("Expected default property Items, got %v", prop)
+	}
+	if prop := iBase.GetDefaultProperty(); prop != nil {
+		t.Errorf("IBase should have no default property, got %s", prop.Name)
+	}
+	if len(GetAllInterfaceProperties(nil)) != 0 {
+		t.Error("nil interface should have no properties")
+	}
+}
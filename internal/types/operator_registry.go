@@ -6,6 +6,7 @@ import (
 	"strings"
 
 	"github.com/cwbudde/go-dws/pkg/ident"
+	"github.com/cwbudde/go-dws/pkg/token"
 )
 
 // ErrOperatorDuplicate is returned when attempting to register a duplicate operator signature.
@@ -151,80 +152,111 @@ func operatorEntryKey(operator string, operandTypes []Type) string {
 	return fmt.Sprintf("%s(%s)", ident.Normalize(operator), strings.Join(parts, ","))
 }
 
-// ConversionKind indicates whether a conversion is implicit or explicit.
-type ConversionKind int
+// ConversionMode indicates whether a conversion is implicit or explicit.
+type ConversionMode int
 
 const (
 	// ConversionImplicit registers an implicit conversion (automatically applied).
-	ConversionImplicit ConversionKind = iota
+	ConversionImplicit ConversionMode = iota
 	// ConversionExplicit registers an explicit conversion (requires explicit syntax).
 	ConversionExplicit
 )
 
 // ConversionSignature describes a type conversion operator.
+// Owner is the class or record declaring the conversion as a class operator,
+// or nil for a global operator; Pos is the position of the declaration.
 type ConversionSignature struct {
 	From    Type
 	To      Type
+	Owner   Type
 	Binding string
-	Kind    ConversionKind
+	Pos     token.Position
+	Kind    ConversionMode
 }
 
 // ConversionRegistry stores implicit and explicit conversions.
+// Several candidates may exist for the same pair of types when they are
+// declared by different owners (globally, in the source type or in the target
+// type); callers decide how to treat such ambiguities.
 type ConversionRegistry struct {
-	implicit map[string]*ConversionSignature
-	explicit map[string]*ConversionSignature
+	implicit map[string][]*ConversionSignature
+	explicit map[string][]*ConversionSignature
 }
 
 // NewConversionRegistry creates an empty conversion registry.
 func NewConversionRegistry() *ConversionRegistry {
 	return &ConversionRegistry{
-		implicit: make(map[string]*ConversionSignature),
-		explicit: make(map[string]*ConversionSignature),
+		implicit: make(map[string][]*ConversionSignature),
+		explicit: make(map[string][]*ConversionSignature),
 	}
 }
 
 // Register adds a conversion signature to the registry.
-// Returns ErrConversionDuplicate if an identical conversion already exists.
+// Returns ErrConversionDuplicate if the same owner already declares a
+// conversion between the same types.
 func (r *ConversionRegistry) Register(signature *ConversionSignature) error {
 	if signature == nil {
 		return errors.New("nil conversion signature")
 	}
 
-	key := conversionKey(signature.From, signature.To)
+	var entries map[string][]*ConversionSignature
 	switch signature.Kind {
 	case ConversionImplicit:
-		if _, exists := r.implicit[key]; exists {
-			return ErrConversionDuplicate
-		}
-		r.implicit[key] = signature
+		entries = r.implicit
 	case ConversionExplicit:
-		if _, exists := r.explicit[key]; exists {
-			return ErrConversionDuplicate
-		}
-		r.explicit[key] = signature
+		entries = r.explicit
 	default:
 		return fmt.Errorf("unknown conversion kind: %d", signature.Kind)
 	}
 
+	key := conversionKey(signature.From, signature.To)
+	for _, existing := range entries[key] {
+		if sameConversionOwner(existing.Owner, signature.Owner) {
+			return ErrConversionDuplicate
+		}
+	}
+	entries[key] = append(entries[key], signature)
+
 	return nil
 }
 
 // FindImplicit returns an implicit conversion between types, if any.
+// When several candidates exist, the first registered one is returned.
 func (r *ConversionRegistry) FindImplicit(from, to Type) (*ConversionSignature, bool) {
-	if r == nil {
+	candidates := r.ImplicitCandidates(from, to)
+	if len(candidates) == 0 {
 		return nil, false
 	}
-	sig, ok := r.implicit[conversionKey(from, to)]
-	return sig, ok
+	return candidates[0], true
+}
+
+// ImplicitCandidates returns every implicit conversion registered between types.
+func (r *ConversionRegistry) ImplicitCandidates(from, to Type) []*ConversionSignature {
+	if r == nil || from == nil || to == nil {
+		return nil
+	}
+	return r.implicit[conversionKey(from, to)]
 }
 
 // FindExplicit returns an explicit conversion between types, if any.
 func (r *ConversionRegistry) FindExplicit(from, to Type) (*ConversionSignature, bool) {
-	if r == nil {
+	if r == nil || from == nil || to == nil {
 		return nil, false
 	}
-	sig, ok := r.explicit[conversionKey(from, to)]
-	return sig, ok
+	candidates := r.explicit[conversionKey(from, to)]
+	if len(candidates) == 0 {
+		return nil, false
+	}
+	return candidates[0], true
+}
+
+// sameConversionOwner reports whether two conversion owners are the same
+// declaring type (both nil for global conversions).
+func sameConversionOwner(a, b Type) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return typeKey(a) == typeKey(b)
 }
 
 // conversionKey builds a stable key identifying a conversion pair.
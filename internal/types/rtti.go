@@ -0,0 +1,56 @@
+package types
+
+import "github.com/cwbudde/go-dws/pkg/ident"
+
+// RTTITypeInfoType represents the runtime type information returned by the
+// TypeOf built-in (TRTTITypeInfo). Its members are read-only properties:
+//
+//   - Name: the type name, e.g. "Integer" or "TMyClass"
+//   - IsClass, IsArray, IsRecord: the kind of the type
+//   - Fields: the public fields and readable properties of a class, each with
+//     its name and, when TypeOf was applied to an object, its current value
+//   - Methods: the names of the public methods of a class
+type RTTITypeInfoType struct{}
+
+func (t *RTTITypeInfoType) String() string   { return "TRTTITypeInfo" }
+func (t *RTTITypeInfoType) TypeKind() string { return "RTTI_TYPEINFO" }
+
+func (t *RTTITypeInfoType) Equals(other Type) bool {
+	other = GetUnderlyingType(other)
+	_, ok := other.(*RTTITypeInfoType)
+	return ok
+}
+
+// RTTI_TYPEINFO is the singleton TRTTITypeInfo type instance.
+var RTTI_TYPEINFO = &RTTITypeInfoType{}
+
+// RTTI_MEMBER is the record type of the entries of TRTTITypeInfo.Fields.
+var RTTI_MEMBER = NewRecordType("TRTTIMember", map[string]Type{
+	"Name":  STRING,
+	"Value": VARIANT,
+})
+
+// IsRTTITypeInfo reports whether t resolves to the TRTTITypeInfo type.
+func IsRTTITypeInfo(t Type) bool {
+	if t == nil {
+		return false
+	}
+	return GetUnderlyingType(t).TypeKind() == "RTTI_TYPEINFO"
+}
+
+// RTTITypeInfoMemberType returns the type of a TRTTITypeInfo member, or nil if
+// there is no such member. Member names are case-insensitive.
+func RTTITypeInfoMemberType(name string) Type {
+	switch ident.Normalize(name) {
+	case "name":
+		return STRING
+	case "isclass", "isarray", "isrecord":
+		return BOOLEAN
+	case "fields":
+		return NewDynamicArrayType(RTTI_MEMBER)
+	case "methods":
+		return NewDynamicArrayType(STRING)
+	default:
+		return nil
+	}
+}
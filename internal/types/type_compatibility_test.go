@@ -95,6 +95,7 @@ func TestIsAssignableFrom(t *testing.T) {
 	tPerson.AddMethodOverload("CompareTo", &MethodInfo{
 		Signature: NewFunctionType([]Type{}, INTEGER),
 	})
+	tPerson.Interfaces = append(tPerson.Interfaces, iComparable)
 
 	tests := []struct {
 		target   Type
@@ -188,6 +188,14 @@ var (
 // that accept heterogeneous arrays (array of const in Pascal)
 var ARRAY_OF_CONST = NewDynamicArrayType(VARIANT)
 
+// TDATETIME is TDateTime as scripts see it. DWScript's system unit declares
+// it as an alias of Float, so it mixes freely with Float and Integer values.
+var TDATETIME = &TypeAlias{Name: "TDateTime", AliasedType: FLOAT}
+
+// BYTE is the element type of byte arrays (array of Byte), as used by
+// BytesOf and StringOf. It is an alias of Integer holding values 0..255.
+var BYTE = &TypeAlias{Name: "Byte", AliasedType: INTEGER}
+
 // IINTERFACE is the base interface type (like IUnknown in COM)
 // All interfaces can inherit from this root interface.
 var IINTERFACE = &InterfaceType{
@@ -413,12 +421,15 @@ const (
 // PropertyInfo represents property metadata for a class.
 // Fields: Name, Type, ReadSpec, WriteSpec, IsIndexed, IsDefault
 // Properties provide syntactic sugar for getter/setter access.
+// IndexParamTypes holds the declared index parameter types of an indexed
+// property, such as [String] for `property Items[key: String]`.
 type PropertyInfo struct {
 	IndexValue      any
 	ReadExpr        any
 	WriteExpr       any
 	IndexValueType  Type
 	Type            Type
+	IndexParamTypes []Type
 	ReadSpec        string
 	WriteSpec       string
 	Name            string
@@ -1084,6 +1095,42 @@ func (it *InterfaceType) GetProperty(name string) *PropertyInfo {
 	return it.Parent.GetProperty(name)
 }
 
+// GetDefaultProperty returns the default (array) property of the interface,
+// walking the parent interface chain. Returns nil if there is none.
+func (it *InterfaceType) GetDefaultProperty() *PropertyInfo {
+	for current := it; current != nil; current = current.Parent {
+		for _, prop := range current.Properties {
+			if prop.IsDefault {
+				return prop
+			}
+		}
+	}
+	return nil
+}
+
+// GetAllInterfaceProperties returns all properties of an interface, including
+// inherited properties. Keys are normalized property names.
+func GetAllInterfaceProperties(iface *InterfaceType) map[string]*PropertyInfo {
+	if iface == nil {
+		return make(map[string]*PropertyInfo)
+	}
+
+	// Start with parent properties (if any)
+	allProps := make(map[string]*PropertyInfo)
+	if iface.Parent != nil {
+		for name, prop := range GetAllInterfaceProperties(iface.Parent) {
+			allProps[name] = prop
+		}
+	}
+
+	// Add/override with own properties
+	for name, prop := range iface.Properties {
+		allProps[name] = prop
+	}
+
+	return allProps
+}
+
 // GetAllInterfaceMethods returns all methods of an interface, including inherited methods.
 func GetAllInterfaceMethods(iface *InterfaceType) map[string]*FunctionType {
 	if iface == nil {
@@ -1112,48 +1159,11 @@ func GetAllInterfaceMethods(iface *InterfaceType) map[string]*FunctionType {
 // ============================================================================
 
 // IsAssignableFrom checks if a value of type 'source' can be assigned to a variable of type 'target'.
-// This includes:
-// - Exact type match
-// - Numeric coercion (Integer -> Float)
-// - Subclass to superclass (covariance)
-// - Class to interface (if class implements the interface)
-// - Interface to interface (if source is subinterface of target)
+// It is a convenience wrapper around IsAssignable for callers that do not
+// need the conversion kind.
 func IsAssignableFrom(target, source Type) bool {
-	target = GetUnderlyingType(target)
-	source = GetUnderlyingType(source)
-
-	// Exact match
-	if target.Equals(source) {
-		return true
-	}
-
-	// Numeric coercion: Integer -> Float
-	if target.TypeKind() == "FLOAT" && source.TypeKind() == "INTEGER" {
-		return true
-	}
-
-	// Subclass to superclass assignment
-	if targetClass, ok := target.(*ClassType); ok {
-		if sourceClass, ok := source.(*ClassType); ok {
-			return IsSubclassOf(sourceClass, targetClass)
-		}
-	}
-
-	// Class to interface assignment (check if class implements interface)
-	if targetInterface, ok := target.(*InterfaceType); ok {
-		if sourceClass, ok := source.(*ClassType); ok {
-			return ImplementsInterface(sourceClass, targetInterface)
-		}
-	}
-
-	// A derived interface can be assigned to a base interface
-	if targetInterface, ok := target.(*InterfaceType); ok {
-		if sourceInterface, ok := source.(*InterfaceType); ok {
-			return IsSubinterfaceOf(sourceInterface, targetInterface)
-		}
-	}
-
-	return false
+	ok, _ := IsAssignable(target, source)
+	return ok
 }
 
 // IsSubclassOf checks if 'child' is a subclass of 'parent'.
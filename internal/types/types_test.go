@@ -1,6 +1,7 @@
 package types
 
 import (
+	"strings"
 	"testing"
 )
 
@@ -129,6 +130,27 @@ func TestRecordType(t *testing.T) {
 	})
 }
 
+func TestRecordTypeFieldOrder(t *testing.T) {
+	rt := NewRecordType("TPerson", map[string]Type{})
+	rt.AddField("Name", STRING, false)
+	rt.AddField("Age", INTEGER, false)
+	rt.AddField("Email", STRING, false)
+	rt.AddField("age", INTEGER, true) // redeclaring keeps the original position
+
+	got := strings.Join(rt.OrderedFieldNames(), ",")
+	if want := "name,age,email"; got != want {
+		t.Errorf("OrderedFieldNames() = %q, want %q", got, want)
+	}
+
+	// Fields without recorded declaration order follow in sorted order.
+	rt.Fields["zeta"] = INTEGER
+	rt.Fields["beta"] = INTEGER
+	got = strings.Join(rt.OrderedFieldNames(), ",")
+	if want := "name,age,email,beta,zeta"; got != want {
+		t.Errorf("OrderedFieldNames() = %q, want %q", got, want)
+	}
+}
+
 func TestRecordTypeEquality(t *testing.T) {
 	fields1 := map[string]Type{
 		"X": INTEGER,
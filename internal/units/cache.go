@@ -1,17 +1,30 @@
 package units
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
 	"os"
+	"sort"
 	"sync"
 	"time"
+
+	"github.com/cwbudde/go-dws/internal/lexer"
+	"github.com/cwbudde/go-dws/pkg/ident"
 )
 
 // UnitCache caches parsed and analyzed units to speed up repeated runs.
-// The cache is invalidated when the source file is modified.
+// Entries are keyed by unit name and validated against the StructuralHash of
+// the unit source and the defines and include paths it was parsed with, so a cache can be shared by several registries (e.g. one
+// per compilation) and a unit is only re-parsed when its source changes.
 type UnitCache struct {
 	// entries maps unit names (normalized) to cache entries
 	entries map[string]*CacheEntry
 
+	// hits and misses count Lookup results
+	hits   int
+	misses int
+
 	// mutex protects concurrent access to the cache
 	mutex sync.RWMutex
 }
@@ -29,6 +42,47 @@ type CacheEntry struct {
 
 	// FilePath is the source file path
 	FilePath string
+
+	// SourceHash is the SourceHash of the unit file when it was cached
+	SourceHash string
+
+	// StructuralHash is the StructuralHash of the unit source when it was
+	// cached from a registry load
+	StructuralHash string
+}
+
+// SourceHash returns the content hash used to validate cached unit files.
+func SourceHash(source []byte) string {
+	sum := sha256.Sum256(source)
+	return hex.EncodeToString(sum[:])
+}
+
+// StructuralHash returns a hash of the tokens of a unit source and their
+// positions. Edits that leave every token in place, such as changing the text
+// of a comment or trailing whitespace, keep the hash; any other edit changes
+// it, so a unit parsed from the old source is not reused.
+//
+// The source is lexed with the defines and include paths it is parsed with,
+// and both are part of the hash: a unit parsed with other defines or include
+// paths is not reused either.
+func StructuralHash(source []byte, defines, includePaths []string) string {
+	h := sha256.New()
+	normalized := make([]string, len(defines))
+	for i, name := range defines {
+		normalized[i] = ident.Normalize(name)
+	}
+	sort.Strings(normalized)
+	fmt.Fprintf(h, "defines:%q\nincludes:%q\n", normalized, includePaths)
+
+	l := lexer.New(string(source), preprocessorOptions(defines, includePaths)...)
+	for {
+		tok := l.NextToken()
+		fmt.Fprintf(h, "%d:%d:%d:%q:%q\n", tok.Type, tok.Pos.Line, tok.Pos.Column, tok.Literal, tok.Raw)
+		if tok.Type == lexer.EOF {
+			break
+		}
+	}
+	return hex.EncodeToString(h.Sum(nil))
 }
 
 // NewUnitCache creates a new empty unit cache
@@ -60,17 +114,38 @@ func (c *UnitCache) Get(name string) (*Unit, bool) {
 		return nil, false
 	}
 
-	// Check if the file has been modified since we cached it
+	// Check if the file has been modified since we cached it. A touched file
+	// whose content is unchanged keeps its cache entry.
 	if !fileInfo.ModTime().Equal(entry.ModTime) {
-		// File has been modified - cache is stale
-		return nil, false
+		source, err := os.ReadFile(entry.FilePath)
+		if err != nil || entry.SourceHash == "" || SourceHash(source) != entry.SourceHash {
+			// File content has changed - cache is stale
+			return nil, false
+		}
 	}
 
 	// Cache entry is valid
 	return entry.Unit, true
 }
 
-// Put adds a unit to the cache with its file modification time.
+// Lookup retrieves a unit from the cache if it was cached from source with
+// the given StructuralHash. Lookups are counted in the cache statistics.
+func (c *UnitCache) Lookup(name, structuralHash string) (*Unit, bool) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	entry, exists := c.entries[name]
+	if !exists || entry.StructuralHash == "" || entry.StructuralHash != structuralHash {
+		c.misses++
+		return nil, false
+	}
+
+	c.hits++
+	return entry.Unit, true
+}
+
+// Put adds a unit to the cache with its file modification time and the
+// SourceHash of the file's current content.
 //
 // If the file cannot be stat'd, the unit is still cached but will be
 // invalidated on the next Get() call.
@@ -78,17 +153,38 @@ func (c *UnitCache) Put(name string, unit *Unit, filePath string) {
 	c.mutex.Lock()
 	defer c.mutex.Unlock()
 
-	// Get the file modification time
+	var sourceHash string
+	if source, err := os.ReadFile(filePath); err == nil {
+		sourceHash = SourceHash(source)
+	}
+
+	c.store(name, unit, filePath, sourceHash, "")
+}
+
+// PutSource adds a unit parsed from source to the cache, keyed by name and
+// validated by the source's StructuralHash. It replaces the entry cached
+// from an earlier version of the source.
+func (c *UnitCache) PutSource(name string, unit *Unit, filePath string, source []byte, structuralHash string) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.store(name, unit, filePath, SourceHash(source), structuralHash)
+}
+
+// store adds an entry; the caller must hold the write lock.
+func (c *UnitCache) store(name string, unit *Unit, filePath, sourceHash, structuralHash string) {
 	modTime := time.Now() // Default to current time if stat fails
 	if fileInfo, err := os.Stat(filePath); err == nil {
 		modTime = fileInfo.ModTime()
 	}
 
 	c.entries[name] = &CacheEntry{
-		Unit:     unit,
-		FilePath: filePath,
-		ModTime:  modTime,
-		LoadTime: time.Now(),
+		Unit:           unit,
+		FilePath:       filePath,
+		ModTime:        modTime,
+		LoadTime:       time.Now(),
+		SourceHash:     sourceHash,
+		StructuralHash: structuralHash,
 	}
 }
 
@@ -126,6 +222,12 @@ type CacheStats struct {
 
 	// NewestEntry is the age of the newest cached entry
 	NewestEntry time.Duration
+
+	// Hits is the number of Lookup calls served from the cache
+	Hits int
+
+	// Misses is the number of Lookup calls that required a (re-)parse
+	Misses int
 }
 
 // GetStats returns statistics about the cache
@@ -135,6 +237,8 @@ func (c *UnitCache) GetStats() CacheStats {
 
 	stats := CacheStats{
 		TotalEntries: len(c.entries),
+		Hits:         c.hits,
+		Misses:       c.misses,
 	}
 
 	if len(c.entries) == 0 {
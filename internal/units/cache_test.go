@@ -3,6 +3,7 @@ package units
 import (
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 )
@@ -374,3 +375,99 @@ func TestClearCacheViaRegistry(t *testing.T) {
 		t.Errorf("Expected empty cache after clear, got %d", registry.GetCache().Size())
 	}
 }
+
+// TestStructuralHash tests that the hash ignores comment text and changes
+// with the tokens of the source
+func TestStructuralHash(t *testing.T) {
+	source := "unit Shared;\ninterface\nconst Answer = 42; // the answer\nimplementation\nend."
+	hash := StructuralHash([]byte(source), nil, nil)
+
+	if got := StructuralHash([]byte(strings.Replace(source, "the answer", "THE ANSWER", 1)), nil, nil); got != hash {
+		t.Error("Expected a comment edit to keep the structural hash")
+	}
+	if got := StructuralHash([]byte(strings.Replace(source, "42", "43", 1)), nil, nil); got == hash {
+		t.Error("Expected a changed constant to change the structural hash")
+	}
+	if got := StructuralHash([]byte(strings.Replace(source, "const", "\nconst", 1)), nil, nil); got == hash {
+		t.Error("Expected moved tokens to change the structural hash")
+	}
+}
+
+// TestStructuralHashDefinesAndIncludePaths tests that the defines and include
+// paths a unit is parsed with are part of the hash
+func TestStructuralHashDefinesAndIncludePaths(t *testing.T) {
+	source := []byte("unit Shared;\ninterface\n{$IFDEF DEBUG}\nconst Mode = 1;\n{$ENDIF}\nimplementation\nend.")
+	hash := StructuralHash(source, []string{"DEBUG", "TRACE"}, nil)
+
+	if got := StructuralHash(source, []string{"trace", "debug"}, nil); got != hash {
+		t.Error("Expected the order and case of defines to keep the structural hash")
+	}
+	if got := StructuralHash(source, []string{"DEBUG"}, nil); got == hash {
+		t.Error("Expected other defines to change the structural hash")
+	}
+	if got := StructuralHash(source, []string{"DEBUG", "TRACE"}, []string{"inc"}); got == hash {
+		t.Error("Expected include paths to change the structural hash")
+	}
+}
+
+// TestSharedCacheKeyedByDefines tests that registries with other defines do
+// not reuse a unit from a shared cache
+func TestSharedCacheKeyedByDefines(t *testing.T) {
+	resolver := func(name string) (string, error) {
+		if strings.EqualFold(name, "DebugLog") {
+			return "unit DebugLog;\ninterface\nimplementation\nend.", nil
+		}
+		return "unit Config;\ninterface\n{$IFDEF DEBUG}\nuses DebugLog;\n{$ENDIF}\nimplementation\nend.", nil
+	}
+
+	cache := NewUnitCache()
+	for _, defines := range [][]string{{"DEBUG"}, nil} {
+		registry := NewUnitRegistry(nil)
+		registry.SetCache(cache)
+		registry.SetDefines(defines)
+		registry.SetSourceResolver(resolver)
+
+		unit, err := registry.LoadUnit("Config", nil)
+		if err != nil {
+			t.Fatalf("LoadUnit with defines %v failed: %v", defines, err)
+		}
+		if got, want := len(unit.Uses), len(defines); got != want {
+			t.Errorf("with defines %v, unit uses %v, want %d unit(s)", defines, unit.Uses, want)
+		}
+	}
+	if stats := cache.GetStats(); stats.Hits != 0 {
+		t.Errorf("Expected no cache hits across different defines, got %d", stats.Hits)
+	}
+}
+
+// TestCacheHitLoadsDependencies tests that a unit served from a shared cache
+// still registers its dependencies in the new registry
+func TestCacheHitLoadsDependencies(t *testing.T) {
+	tempDir := t.TempDir()
+
+	files := map[string]string{
+		"Base.dws": "unit Base;\ninterface\nimplementation\nend.",
+		"Top.dws":  "unit Top;\ninterface\nuses Base;\nimplementation\nend.",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(tempDir, name), []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to create %s: %v", name, err)
+		}
+	}
+
+	cache := NewUnitCache()
+	for i := 0; i < 2; i++ {
+		registry := NewUnitRegistry([]string{tempDir})
+		registry.SetCache(cache)
+		if _, err := registry.LoadUnit("Top", nil); err != nil {
+			t.Fatalf("Failed to load unit: %v", err)
+		}
+		if _, ok := registry.GetUnit("Base"); !ok {
+			t.Errorf("load %d: expected dependency 'Base' to be registered", i+1)
+		}
+	}
+
+	if stats := cache.GetStats(); stats.Misses != 2 || stats.Hits != 2 {
+		t.Errorf("Expected 2 parses and 2 cache hits, got %d misses and %d hits", stats.Misses, stats.Hits)
+	}
+}
@@ -26,10 +26,23 @@ type UnitRegistry struct {
 	// loadingChain tracks the current dependency chain for error reporting
 	loadingChain []string
 
+	// resolver, when set, supplies unit sources instead of the file system
+	resolver SourceResolver
+
 	// searchPaths are directories to search for unit files
 	searchPaths []string
+
+	// defines are the conditional symbols predefined when parsing units
+	defines []string
+
+	// includePaths are the directories searched for files included by units
+	includePaths []string
 }
 
+// SourceResolver returns the source text of the unit named name. It lets a host
+// serve units from memory, an archive or a database instead of search paths.
+type SourceResolver func(name string) (string, error)
+
 // NewUnitRegistry creates a new unit registry with the given search paths.
 // If no search paths are provided, only the current directory is searched.
 func NewUnitRegistry(searchPaths []string) *UnitRegistry {
@@ -80,13 +93,6 @@ func (r *UnitRegistry) LoadUnit(name string, searchPaths []string) (*Unit, error
 		return unit, nil
 	}
 
-	// Check compilation cache before parsing
-	if cachedUnit, found := r.cache.Get(normalized); found {
-		// Unit found in cache and is still valid - use it
-		r.units.Set(name, cachedUnit)
-		return cachedUnit, nil
-	}
-
 	// Check for circular dependency
 	if r.loading[normalized] {
 		// Build the cycle path for better error reporting
@@ -111,20 +117,23 @@ func (r *UnitRegistry) LoadUnit(name string, searchPaths []string) (*Unit, error
 		paths = r.searchPaths
 	}
 
-	// Find the unit file
-	filePath, err := FindUnit(name, paths)
+	filePath, source, err := r.readUnitSource(name, paths)
 	if err != nil {
-		return nil, fmt.Errorf("cannot load unit '%s': %w", name, err)
+		return nil, err
 	}
 
-	// Read the source file
-	source, err := os.ReadFile(filePath)
-	if err != nil {
-		return nil, fmt.Errorf("cannot read unit file '%s': %w", filePath, err)
+	// Reuse a previously parsed unit if its source is unchanged
+	structuralHash := StructuralHash(source, r.defines, r.includePaths)
+	if cachedUnit, found := r.cache.Lookup(normalized, structuralHash); found {
+		if err := r.loadDependencies(cachedUnit, name, paths); err != nil {
+			return nil, err
+		}
+		r.units.Set(name, cachedUnit)
+		return cachedUnit, nil
 	}
 
 	// Parse the unit file
-	l := lexer.New(string(source))
+	l := lexer.New(string(source), r.lexerOptions(filePath)...)
 	p := parser.New(l)
 	program := p.ParseProgram()
 
@@ -191,11 +200,8 @@ func (r *UnitRegistry) LoadUnit(name string, searchPaths []string) (*Unit, error
 	}
 
 	// Load dependencies recursively (if any)
-	for _, depName := range unit.Uses {
-		_, err := r.LoadUnit(depName, paths)
-		if err != nil {
-			return nil, fmt.Errorf("failed to load dependency '%s' for unit '%s': %w", depName, name, err)
-		}
+	if err := r.loadDependencies(unit, name, paths); err != nil {
+		return nil, err
 	}
 
 	// Register the unit
@@ -204,11 +210,46 @@ func (r *UnitRegistry) LoadUnit(name string, searchPaths []string) (*Unit, error
 	}
 
 	// Add to compilation cache
-	r.cache.Put(normalized, unit, filePath)
+	r.cache.PutSource(normalized, unit, filePath, source, structuralHash)
 
 	return unit, nil
 }
 
+// readUnitSource returns the location and source text of the named unit, asking
+// the source resolver when one is set and searching paths otherwise.
+func (r *UnitRegistry) readUnitSource(name string, paths []string) (string, []byte, error) {
+	if r.resolver != nil {
+		source, err := r.resolver(name)
+		if err != nil {
+			return "", nil, fmt.Errorf("cannot load unit '%s': %w", name, err)
+		}
+		return name, []byte(source), nil
+	}
+
+	// Find the unit file
+	filePath, err := FindUnit(name, paths)
+	if err != nil {
+		return "", nil, fmt.Errorf("cannot load unit '%s': %w", name, err)
+	}
+
+	// Read the source file
+	source, err := os.ReadFile(filePath)
+	if err != nil {
+		return "", nil, fmt.Errorf("cannot read unit file '%s': %w", filePath, err)
+	}
+	return filePath, source, nil
+}
+
+// loadDependencies loads every unit listed in unit.Uses.
+func (r *UnitRegistry) loadDependencies(unit *Unit, name string, paths []string) error {
+	for _, depName := range unit.Uses {
+		if _, err := r.LoadUnit(depName, paths); err != nil {
+			return fmt.Errorf("failed to load dependency '%s' for unit '%s': %w", depName, name, err)
+		}
+	}
+	return nil
+}
+
 // UnregisterUnit removes a unit from the registry.
 // This is primarily useful for testing or when reloading a unit.
 func (r *UnitRegistry) UnregisterUnit(name string) {
@@ -232,11 +273,52 @@ func (r *UnitRegistry) ListUnits() []string {
 	return names
 }
 
+// SetSourceResolver makes LoadUnit obtain unit sources from resolver instead of
+// searching the file system. A nil resolver restores file-based loading.
+func (r *UnitRegistry) SetSourceResolver(resolver SourceResolver) {
+	r.resolver = resolver
+}
+
+// SetDefines predefines conditional symbols for the units LoadUnit parses,
+// as if each unit started with {$DEFINE name} for every name.
+func (r *UnitRegistry) SetDefines(defines []string) {
+	r.defines = defines
+}
+
+// SetIncludePaths sets the directories searched for the files that units
+// include with {$INCLUDE}. Without include paths, include directives in units
+// are ignored.
+func (r *UnitRegistry) SetIncludePaths(paths []string) {
+	r.includePaths = paths
+}
+
+// lexerOptions returns the lexer configuration for the unit source read from
+// filePath.
+func (r *UnitRegistry) lexerOptions(filePath string) []lexer.LexerOption {
+	return append(preprocessorOptions(r.defines, r.includePaths), lexer.WithSourceName(filePath))
+}
+
+// preprocessorOptions returns the lexer configuration for the given defines
+// and include paths.
+func preprocessorOptions(defines, includePaths []string) []lexer.LexerOption {
+	opts := []lexer.LexerOption{lexer.WithDefines(defines...)}
+	if len(includePaths) > 0 {
+		opts = append(opts, lexer.WithIncludeResolver(lexer.NewFileIncludeResolver(includePaths[0], includePaths[1:]...)))
+	}
+	return opts
+}
+
 // GetCache returns the unit registry's compilation cache
 func (r *UnitRegistry) GetCache() *UnitCache {
 	return r.cache
 }
 
+// SetCache replaces the registry's compilation cache. Registries sharing a
+// cache parse each unit source only once.
+func (r *UnitRegistry) SetCache(cache *UnitCache) {
+	r.cache = cache
+}
+
 // InvalidateCache invalidates a specific unit in the cache
 func (r *UnitRegistry) InvalidateCache(name string) {
 	normalized := ident.Normalize(name)
@@ -1,6 +1,7 @@
 package units
 
 import (
+	"fmt"
 	"os"
 	"path/filepath"
 	"strings"
@@ -311,6 +312,78 @@ func TestLoadUnit_CircularDependency(t *testing.T) {
 	}
 }
 
+func TestLoadUnit_SourceResolver(t *testing.T) {
+	sources := map[string]string{
+		"unita": "unit UnitA;\ninterface\nuses UnitB;\nimplementation\nend.",
+		"unitb": "unit UnitB;\ninterface\nfunction Two: Integer;\nimplementation\nfunction Two: Integer;\nbegin\n  Result := 2;\nend;\nend.",
+	}
+	registry := NewUnitRegistry(nil)
+	registry.SetSourceResolver(func(name string) (string, error) {
+		source, ok := sources[strings.ToLower(name)]
+		if !ok {
+			return "", fmt.Errorf("no such unit")
+		}
+		return source, nil
+	})
+
+	unit, err := registry.LoadUnit("UnitA", nil)
+	if err != nil {
+		t.Fatalf("LoadUnit failed: %v", err)
+	}
+	if unit.Name != "UnitA" {
+		t.Errorf("expected unit name UnitA, got %q", unit.Name)
+	}
+	if _, ok := registry.GetUnit("UnitB"); !ok {
+		t.Error("expected dependency UnitB to be loaded through the resolver")
+	}
+
+	_, err = registry.LoadUnit("Missing", nil)
+	if err == nil || !strings.Contains(err.Error(), "no such unit") {
+		t.Errorf("expected resolver error, got: %v", err)
+	}
+}
+
+func TestLoadUnit_Defines(t *testing.T) {
+	source := "unit Config;\ninterface\n{$IFDEF DEBUG}\nuses DebugLog;\n{$ENDIF}\nimplementation\nend."
+	for _, defines := range [][]string{nil, {"DEBUG"}} {
+		registry := NewUnitRegistry(nil)
+		registry.SetDefines(defines)
+		registry.SetSourceResolver(func(name string) (string, error) {
+			if strings.EqualFold(name, "DebugLog") {
+				return "unit DebugLog;\ninterface\nimplementation\nend.", nil
+			}
+			return source, nil
+		})
+
+		unit, err := registry.LoadUnit("Config", nil)
+		if err != nil {
+			t.Fatalf("LoadUnit with defines %v failed: %v", defines, err)
+		}
+		if got, want := len(unit.Uses), len(defines); got != want {
+			t.Errorf("with defines %v, unit uses %v, want %d unit(s)", defines, unit.Uses, want)
+		}
+	}
+}
+
+func TestLoadUnit_SourceResolverCircular(t *testing.T) {
+	sources := map[string]string{
+		"unita": "unit UnitA;\ninterface\nuses UnitB;\nimplementation\nend.",
+		"unitb": "unit UnitB;\ninterface\nuses UnitA;\nimplementation\nend.",
+	}
+	registry := NewUnitRegistry(nil)
+	registry.SetSourceResolver(func(name string) (string, error) {
+		return sources[strings.ToLower(name)], nil
+	})
+
+	_, err := registry.LoadUnit("UnitA", nil)
+	if err == nil {
+		t.Fatal("expected circular dependency error")
+	}
+	if !strings.Contains(err.Error(), "circular dependency detected: UnitA -> UnitB -> UnitA") {
+		t.Errorf("expected cycle path in error, got: %v", err)
+	}
+}
+
 func TestLoadUnit_ParseError(t *testing.T) {
 	tempDir := t.TempDir()
 
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/cwbudde/go-dws/internal/interp/runtime"
 	"github.com/cwbudde/go-dws/internal/semantic"
 	"github.com/cwbudde/go-dws/pkg/ast"
 	"github.com/cwbudde/go-dws/pkg/ident"
@@ -55,6 +56,15 @@ type Unit struct {
 	// declarations when the implementation section is imported.
 	FunctionSymbols *ident.Map[[]*ast.FunctionDecl]
 
+	// HostFunctions maps the functions of a unit implemented by the host
+	// application, keyed case-insensitively, to the names they are registered
+	// under in the external function registry.
+	HostFunctions *ident.Map[string]
+
+	// Constants holds the values of a host unit's constants, keyed
+	// case-insensitively by name.
+	Constants *ident.Map[runtime.Value]
+
 	// Name is the unit's name (case-insensitive in DWScript)
 	Name string
 
@@ -146,6 +156,40 @@ func (u *Unit) HasFunction(name string) bool {
 	return len(u.LookupFunction(name)) > 0
 }
 
+// DefineHostFunction records a Go-backed function of this unit, registered in
+// the external function registry as externalName.
+func (u *Unit) DefineHostFunction(name, externalName string) {
+	if u.HostFunctions == nil {
+		u.HostFunctions = ident.NewMap[string]()
+	}
+	u.HostFunctions.Set(name, externalName)
+}
+
+// LookupHostFunction returns the external function registry name of a
+// Go-backed function of this unit.
+func (u *Unit) LookupHostFunction(name string) (string, bool) {
+	if u.HostFunctions == nil {
+		return "", false
+	}
+	return u.HostFunctions.Get(name)
+}
+
+// DefineConstant records a constant of this unit.
+func (u *Unit) DefineConstant(name string, value runtime.Value) {
+	if u.Constants == nil {
+		u.Constants = ident.NewMap[runtime.Value]()
+	}
+	u.Constants.Set(name, value)
+}
+
+// LookupConstant returns the value of a constant defined with DefineConstant.
+func (u *Unit) LookupConstant(name string) (runtime.Value, bool) {
+	if u.Constants == nil {
+		return nil, false
+	}
+	return u.Constants.Get(name)
+}
+
 func unitFunctionParametersMatch(left, right []*ast.Parameter) bool {
 	if len(left) != len(right) {
 		return false
@@ -14,7 +14,7 @@ async function main() {
             PrintLn('Hello from Node #' + IntToStr(i));
     `);
 
-    const result = dws.run(program);
+    const result = await dws.run(program);
     if (!result.success) {
         console.error(result.error?.message ?? 'Unknown error');
         process.exitCode = 1;
@@ -8,18 +8,34 @@ export interface RuntimeError extends Error {
     executionTime?: number;
 }
 
+export interface Diagnostic {
+    line: number;
+    column: number;
+    message: string;
+    severity: 'error' | 'warning' | 'info' | 'hint';
+    code: string;
+}
+
 export interface Program {
-    id: number;
+    id?: number;
     success: boolean;
+    diagnostics: Diagnostic[];
+    message?: string;
 }
 
 export interface Result {
     success: boolean;
     output: string;
     executionTime: number;
+    interrupted?: boolean;
+    value?: unknown;
     error?: RuntimeError;
 }
 
+export interface RunOptions {
+    onOutput?: (text: string) => void;
+}
+
 export interface DWScriptInitOptions {
     onOutput?: (text: string) => void;
     onError?: (error: RuntimeError) => void;
@@ -30,7 +46,8 @@ export interface DWScriptInitOptions {
 export interface DWScriptInstance {
     init(options?: DWScriptInitOptions): Promise<void>;
     compile(source: string): Program;
-    run(program: Program): Result;
+    run(program: Program | number, options?: RunOptions): Promise<Result>;
+    interrupt(program: Program | number): boolean;
     eval(source: string): Result;
     on(event: 'output', callback: (text: string) => void): void;
     on(event: 'error', callback: (error: RuntimeError) => void): void;
@@ -341,6 +341,20 @@ func (es *EmptyStatement) String() string {
 	return ""
 }
 
+// InvalidStatement is a recoverable placeholder for a statement the parser
+// could not make sense of. It covers the tokens skipped while resynchronizing
+// at the next statement boundary: Token is the first of them and EndPos the
+// end of the last, so tools can still locate the broken region.
+type InvalidStatement struct {
+	BaseNode
+}
+
+func (is *InvalidStatement) statementNode() {}
+
+func (is *InvalidStatement) String() string {
+	return "<invalid>"
+}
+
 // NilLiteral represents a nil literal value.
 type NilLiteral struct {
 	TypedExpressionBase
@@ -356,8 +370,8 @@ func (nl *NilLiteral) String() string  { return "nil" }
 // Returns true if the object is of the specified type, false otherwise.
 type IsExpression struct {
 	Left       Expression
-	TargetType TypeExpression
-	Right      Expression
+	TargetType TypeExpression `ast:"optional"`
+	Right      Expression     `ast:"optional"`
 	TypedExpressionBase
 }
 
@@ -14,7 +14,8 @@ import (
 // ============================================================================
 
 // Visibility represents the access level of class members (fields and methods).
-// DWScript supports three visibility levels: private, protected, and public.
+// DWScript supports private, protected and public, plus the strict variants
+// of private and protected which also deny access to class helpers.
 type Visibility int
 
 const (
@@ -27,6 +28,14 @@ const (
 
 	// VisibilityPublic means the member is accessible from anywhere.
 	VisibilityPublic
+
+	// VisibilityStrictPrivate means the member is only accessible from the
+	// methods of the declaring class itself, not even from its helpers.
+	VisibilityStrictPrivate
+
+	// VisibilityStrictProtected means the member is accessible from the
+	// methods of the declaring class and its descendants, but not helpers.
+	VisibilityStrictProtected
 )
 
 // String returns the string representation of the visibility level.
@@ -38,11 +47,25 @@ func (v Visibility) String() string {
 		return "protected"
 	case VisibilityPublic:
 		return "public"
+	case VisibilityStrictPrivate:
+		return "strict private"
+	case VisibilityStrictProtected:
+		return "strict protected"
 	default:
 		return "unknown"
 	}
 }
 
+// IsPrivate reports whether v is private or strict private.
+func (v Visibility) IsPrivate() bool {
+	return v == VisibilityPrivate || v == VisibilityStrictPrivate
+}
+
+// IsProtected reports whether v is protected or strict protected.
+func (v Visibility) IsProtected() bool {
+	return v == VisibilityProtected || v == VisibilityStrictProtected
+}
+
 // ============================================================================
 // Class Declaration
 // ============================================================================
@@ -60,11 +83,11 @@ func (v Visibility) String() string {
 //	  // partial class (can be declared multiple times)
 //	end;
 type ClassDecl struct {
-	Constructor       *FunctionDecl
+	Constructor       *FunctionDecl `ast:"optional"`
 	Name              *Identifier
-	EnclosingClass    *Identifier
-	Parent            *Identifier
-	Destructor        *FunctionDecl
+	EnclosingClass    *Identifier   `ast:"optional"`
+	Parent            *Identifier   `ast:"optional"`
+	Destructor        *FunctionDecl `ast:"optional"`
 	Invariants        *InvariantClause
 	ExternalName      string
 	DeprecatedMessage string
@@ -218,8 +241,8 @@ func (cd *ClassDecl) String() string {
 //	class var Count: Integer := 42;   // class variable with initialization
 //	property PropertyName: Type read FFieldName write FFieldName;
 type FieldDecl struct {
-	Type      TypeExpression
-	InitValue Expression
+	Type      TypeExpression `ast:"optional"`
+	InitValue Expression     `ast:"optional"`
 	Name      *Identifier
 	BaseNode
 	Visibility Visibility
@@ -267,15 +290,18 @@ func (fd *FieldDecl) String() string {
 //   - ClassDeclaration: for class type definitions
 //   - ConstructorDeclaration: for constructor method definitions
 type NewExpression struct {
-	ClassName *Identifier
+	ClassName *Identifier `ast:"optional"`
 	// Operand holds a parenthesized operand expression for the forms
 	// `new (Type)(args)` / `new (classRefExpr)(args)` where the constructed
 	// class is given by an arbitrary expression (a parenthesized type/alias,
 	// a class-reference variable, or a call returning a metaclass) rather than
 	// a bare class-name identifier. Nil for the common `new ClassName(...)`
 	// form, in which case ClassName is used. When Operand is set, ClassName is nil.
-	Operand   Expression
+	Operand   Expression `ast:"optional"`
 	Arguments []Expression
+	// ArgumentNames holds the parameter names of named constructor
+	// arguments, parallel to Arguments (see CallExpression.ArgumentNames).
+	ArgumentNames []*Identifier `ast:"skip"`
 	// TypeArgs holds the generic type arguments for `new TTest<Integer>(...)`.
 	// Nil for non-generic instantiations. When ClassName refers to a collected
 	// generic template, monomorphization rewrites ClassName to the mangled
@@ -319,18 +345,23 @@ func (ne *NewExpression) String() string {
 		out.WriteString(")")
 	}
 	out.WriteString(".Create(")
-
-	args := []string{}
-	for _, arg := range ne.Arguments {
-		args = append(args, arg.String())
-	}
-	out.WriteString(strings.Join(args, ", "))
-
+	out.WriteString(joinArguments(ne.Arguments, ne.ArgumentNames))
 	out.WriteString(")")
 
 	return out.String()
 }
 
+// ArgumentName returns the name given to constructor argument i, or nil if
+// it was passed positionally.
+func (ne *NewExpression) ArgumentName(i int) *Identifier {
+	return argumentName(ne.ArgumentNames, i)
+}
+
+// HasNamedArguments reports whether any constructor argument was passed by name.
+func (ne *NewExpression) HasNamedArguments() bool {
+	return hasNamedArguments(ne.ArgumentNames)
+}
+
 // ============================================================================
 // Member Access Expression
 // ============================================================================
@@ -376,6 +407,9 @@ type MethodCallExpression struct {
 	Object    Expression
 	Method    *Identifier
 	Arguments []Expression
+	// ArgumentNames holds the parameter names of named arguments, parallel
+	// to Arguments (see CallExpression.ArgumentNames).
+	ArgumentNames []*Identifier `ast:"skip"`
 	TypedExpressionBase
 }
 
@@ -392,18 +426,23 @@ func (mc *MethodCallExpression) String() string {
 	out.WriteString(".")
 	out.WriteString(mc.Method.String())
 	out.WriteString("(")
-
-	args := []string{}
-	for _, arg := range mc.Arguments {
-		args = append(args, arg.String())
-	}
-	out.WriteString(strings.Join(args, ", "))
-
+	out.WriteString(joinArguments(mc.Arguments, mc.ArgumentNames))
 	out.WriteString(")")
 
 	return out.String()
 }
 
+// ArgumentName returns the name given to argument i, or nil if it was
+// passed positionally.
+func (mc *MethodCallExpression) ArgumentName(i int) *Identifier {
+	return argumentName(mc.ArgumentNames, i)
+}
+
+// HasNamedArguments reports whether any argument was passed by name.
+func (mc *MethodCallExpression) HasNamedArguments() bool {
+	return hasNamedArguments(mc.ArgumentNames)
+}
+
 // InheritedExpression represents a call to the parent class's implementation.
 // Used in overridden methods to call the base class method.
 //
@@ -413,7 +452,7 @@ func (mc *MethodCallExpression) String() string {
 //	inherited MethodName
 //	inherited
 type InheritedExpression struct {
-	Method    *Identifier
+	Method    *Identifier `ast:"optional"`
 	Arguments []Expression
 	TypedExpressionBase
 	IsCall   bool
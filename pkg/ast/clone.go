@@ -0,0 +1,85 @@
+package ast
+
+import "reflect"
+
+// Clone returns a deep copy of the tree rooted at node. Every node, slice and
+// map reachable through exported fields is copied, and a node referenced from
+// several places is copied once, so the copy shares no mutable state with the
+// original and keeps its internal aliasing. Tokens and positions are copied by
+// value.
+//
+// Clone is used to hand out private copies of a tree that is shared between
+// compilations, e.g. cached units, because semantic analysis and program
+// setup annotate the nodes they visit.
+func Clone(node Node) Node {
+	if node == nil {
+		return nil
+	}
+	c := cloner{seen: make(map[any]reflect.Value)}
+	return c.clone(reflect.ValueOf(node)).Interface().(Node)
+}
+
+// cloner deep-copies reflected values, remembering copied pointers.
+type cloner struct {
+	seen map[any]reflect.Value
+}
+
+func (c *cloner) clone(v reflect.Value) reflect.Value {
+	switch v.Kind() {
+	case reflect.Pointer:
+		if v.IsNil() {
+			return v
+		}
+		key := v.Interface()
+		if copied, ok := c.seen[key]; ok {
+			return copied
+		}
+		copied := reflect.New(v.Type().Elem())
+		c.seen[key] = copied
+		copied.Elem().Set(c.clone(v.Elem()))
+		return copied
+	case reflect.Interface:
+		if v.IsNil() {
+			return v
+		}
+		copied := reflect.New(v.Type()).Elem()
+		copied.Set(c.clone(v.Elem()))
+		return copied
+	case reflect.Struct:
+		copied := reflect.New(v.Type()).Elem()
+		copied.Set(v)
+		for i := 0; i < v.NumField(); i++ {
+			if v.Type().Field(i).IsExported() {
+				copied.Field(i).Set(c.clone(v.Field(i)))
+			}
+		}
+		return copied
+	case reflect.Slice:
+		if v.IsNil() {
+			return v
+		}
+		copied := reflect.MakeSlice(v.Type(), v.Len(), v.Len())
+		for i := 0; i < v.Len(); i++ {
+			copied.Index(i).Set(c.clone(v.Index(i)))
+		}
+		return copied
+	case reflect.Array:
+		copied := reflect.New(v.Type()).Elem()
+		for i := 0; i < v.Len(); i++ {
+			copied.Index(i).Set(c.clone(v.Index(i)))
+		}
+		return copied
+	case reflect.Map:
+		if v.IsNil() {
+			return v
+		}
+		copied := reflect.MakeMapWithSize(v.Type(), v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			copied.SetMapIndex(c.clone(iter.Key()), c.clone(iter.Value()))
+		}
+		return copied
+	default:
+		return v
+	}
+}
@@ -0,0 +1,49 @@
+package ast_test
+
+import (
+	"testing"
+
+	"github.com/cwbudde/go-dws/pkg/ast"
+)
+
+// TestClone tests that a cloned tree prints the same source but shares no
+// nodes with the original, so annotating the copy leaves the original intact.
+func TestClone(t *testing.T) {
+	program := parseForRewrite(t, `
+function Apply(x: Integer): Integer;
+begin
+  Result := x * 2;
+end;
+var f := lambda(x: Integer): Integer => x + 1;
+PrintLn(Apply(3));
+`)
+
+	clone, ok := ast.Clone(program).(*ast.Program)
+	if !ok {
+		t.Fatalf("Clone returned %T, want *ast.Program", ast.Clone(program))
+	}
+	if clone.String() != program.String() {
+		t.Fatalf("clone prints\n%s\nwant\n%s", clone.String(), program.String())
+	}
+
+	original := make(map[ast.Node]bool)
+	ast.Inspect(program, func(node ast.Node) bool {
+		if node != nil {
+			original[node] = true
+		}
+		return true
+	})
+	ast.Inspect(clone, func(node ast.Node) bool {
+		if node != nil && original[node] {
+			t.Errorf("clone shares %T node with the original", node)
+		}
+		return true
+	})
+
+	fn := clone.Statements[0].(*ast.FunctionDecl)
+	fn.IsVirtual = true
+	fn.Parameters[0].Name.Value = "y"
+	if orig := program.Statements[0].(*ast.FunctionDecl); orig.IsVirtual || orig.Parameters[0].Name.Value != "x" {
+		t.Errorf("mutating the clone changed the original declaration")
+	}
+}
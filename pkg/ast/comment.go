@@ -63,6 +63,7 @@ func (c *Comment) End() token.Position {
 	if len(lines) == 1 {
 		// Single-line comment
 		return token.Position{
+			Source: c.Pos.Source,
 			Line:   c.Pos.Line,
 			Column: c.Pos.Column + len(c.Text),
 			Offset: c.Pos.Offset + len(c.Text),
@@ -71,6 +72,7 @@ func (c *Comment) End() token.Position {
 
 	// Multi-line comment
 	return token.Position{
+		Source: c.Pos.Source,
 		Line:   c.Pos.Line + len(lines) - 1,
 		Column: len(lastLine) + 1,
 		Offset: c.Pos.Offset + len(c.Text),
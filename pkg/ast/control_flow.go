@@ -14,10 +14,16 @@ import (
 //	if x > 0 then PrintLn('positive');
 //	if x > 0 then PrintLn('positive') else PrintLn('non-positive');
 //	if condition then begin ... end;
+//	if var o := Find(name) then PrintLn(o.Name);
+//
+// With an inline variable (if var ...), InlineVar declares the variable,
+// which is visible in both branches only, and Condition is an Identifier
+// naming it.
 type IfStatement struct {
 	Condition   Expression
 	Consequence Statement
-	Alternative Statement
+	Alternative Statement         `ast:"optional"`
+	InlineVar   *VarDeclStatement `ast:"optional"`
 	BaseNode
 }
 
@@ -27,7 +33,7 @@ func (is *IfStatement) String() string {
 	var out bytes.Buffer
 
 	out.WriteString("if ")
-	out.WriteString(is.Condition.String())
+	writeCondition(&out, is.Condition, is.InlineVar)
 	out.WriteString(" then ")
 	out.WriteString(is.Consequence.String())
 
@@ -50,7 +56,7 @@ func (is *IfStatement) String() string {
 type IfExpression struct {
 	Condition   Expression
 	Consequence Expression
-	Alternative Expression
+	Alternative Expression `ast:"optional"`
 	TypedExpressionBase
 }
 
@@ -94,9 +100,15 @@ func (ie *IfExpression) String() string {
 //
 //	while x < 10 do x := x + 1;
 //	while condition do begin ... end;
+//	while var line := ReadNext() do PrintLn(line);
+//
+// With an inline variable (while var ...), InlineVar declares the variable,
+// which is visible in the body only and initialized anew before every
+// iteration, and Condition is an Identifier naming it.
 type WhileStatement struct {
 	Condition Expression
 	Body      Statement
+	InlineVar *VarDeclStatement `ast:"optional"`
 	BaseNode
 }
 
@@ -106,13 +118,23 @@ func (ws *WhileStatement) String() string {
 	var out bytes.Buffer
 
 	out.WriteString("while ")
-	out.WriteString(ws.Condition.String())
+	writeCondition(&out, ws.Condition, ws.InlineVar)
 	out.WriteString(" do ")
 	out.WriteString(ws.Body.String())
 
 	return out.String()
 }
 
+// writeCondition writes the condition of an if or while statement, which is
+// the declaration itself when the statement declares an inline variable.
+func writeCondition(out *bytes.Buffer, condition Expression, inlineVar *VarDeclStatement) {
+	if inlineVar != nil {
+		out.WriteString(inlineVar.String())
+		return
+	}
+	out.WriteString(condition.String())
+}
+
 // RepeatStatement represents a repeat-until loop.
 // The body executes at least once, then repeats until the condition becomes true.
 // Examples:
@@ -168,7 +190,7 @@ type ForStatement struct {
 	Start    Expression
 	EndValue Expression
 	Body     Statement
-	Step     Expression
+	Step     Expression `ast:"optional"`
 	Variable *Identifier
 	BaseNode
 	Direction ForDirection
@@ -221,7 +243,7 @@ func (fs *ForStatement) String() string {
 type ForInStatement struct {
 	Collection Expression
 	Body       Statement
-	Step       Expression
+	Step       Expression `ast:"optional"`
 	Variable   *Identifier
 	BaseNode
 	InlineVar bool
@@ -310,7 +332,7 @@ func (cb *CaseBranch) String() string {
 //	end;
 type CaseStatement struct {
 	Expression Expression
-	Else       Statement
+	Else       Statement `ast:"optional"`
 	Cases      []*CaseBranch
 	BaseNode
 }
@@ -400,7 +422,7 @@ func (cs *ContinueStatement) String() string {
 //	   Result := i * 2;
 //	end;
 type ExitStatement struct {
-	ReturnValue Expression
+	ReturnValue Expression `ast:"optional"`
 	BaseNode
 }
 
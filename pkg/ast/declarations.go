@@ -20,7 +20,7 @@ import (
 //	class const cPublic = 3;
 type ConstDecl struct {
 	Value             Expression
-	Type              TypeExpression
+	Type              TypeExpression `ast:"optional"`
 	Name              *Identifier
 	DeprecatedMessage string
 	BaseNode
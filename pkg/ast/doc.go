@@ -156,6 +156,22 @@
 //	    return true  // Continue traversal
 //	})
 //
+// Rewrite replaces nodes instead of just visiting them. Children are
+// rewritten before their parent; returning nil deletes a slice element:
+//
+//	ast.Rewrite(tree, func(node ast.Node) ast.Node {
+//	    if id, ok := node.(*ast.Identifier); ok && id.Value == "Count" {
+//	        return &ast.MemberAccessExpression{Object: &ast.Identifier{Value: "Stats"}, Member: id}
+//	    }
+//	    return node
+//	})
+//
+// PathTo finds the chain of nodes from the root down to the node at a
+// source position, the starting point for hover, rename and completion:
+//
+//	path := ast.PathTo(tree, token.Position{Line: 3, Column: 5})
+//	node, parent := path[len(path)-1], path[len(path)-2]
+//
 // # Code Generation
 //
 // The visitor implementation is automatically generated from AST node
@@ -166,6 +182,7 @@
 // This runs cmd/gen-visitor/main.go which:
 //   - Parses all AST node type definitions
 //   - Generates type-safe walk functions for each node
+//   - Generates the matching rewrite functions used by Rewrite
 //   - Handles slices, interfaces, and helper types automatically
 //   - Supports struct tags for controlling traversal
 //
@@ -176,6 +193,7 @@
 //	    Field2  *Node `ast:"skip"`         // Skipped during traversal
 //	    Field3  *Node `ast:"order:1"`      // Visited first
 //	    Field4  *Node `ast:"order:2"`      // Visited second
+//	    Field5  *Node `ast:"optional"`     // May be nil; Rewrite can clear it
 //	}
 //
 // After adding struct tags, regenerate:
@@ -30,8 +30,8 @@ import (
 //	end;
 type TryStatement struct {
 	TryBlock      *BlockStatement
-	ExceptClause  *ExceptClause
-	FinallyClause *FinallyClause
+	ExceptClause  *ExceptClause  `ast:"optional"`
+	FinallyClause *FinallyClause `ast:"optional"`
 	BaseNode
 }
 
@@ -83,7 +83,7 @@ func (ts *TryStatement) String() string {
 //	    HandleGeneric(E);
 //	end
 type ExceptClause struct {
-	ElseBlock *BlockStatement
+	ElseBlock *BlockStatement `ast:"optional"`
 	Handlers  []*ExceptionHandler
 	Token     token.Token    // 'except' keyword token
 	EndPos    token.Position // End of except clause
@@ -151,7 +151,7 @@ func (ec *ExceptClause) String() string {
 //	end;
 type ExceptionHandler struct {
 	Statement     Statement
-	Variable      *Identifier
+	Variable      *Identifier    `ast:"optional"`
 	ExceptionType TypeExpression // Can be TypeAnnotation, ArrayTypeNode, FunctionPointerTypeNode, etc.
 	Token         token.Token    // 'on' keyword token
 	EndPos        token.Position // End of handler statement
@@ -243,7 +243,7 @@ func (fc *FinallyClause) String() string {
 //	raise new EMyException('custom error');
 //	raise; // re-raise
 type RaiseStatement struct {
-	Exception Expression
+	Exception Expression `ast:"optional"`
 	BaseNode
 }
 
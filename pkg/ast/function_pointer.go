@@ -21,7 +21,7 @@ import (
 // and parameter lists.
 type FunctionPointerTypeNode struct {
 	Parameters []*Parameter   // Parameter list
-	ReturnType TypeExpression // Return type (nil for procedures)
+	ReturnType TypeExpression `ast:"optional"` // Return type (nil for procedures)
 	Token      token.Token    // The 'function' or 'procedure' token
 	OfObject   bool           // True for method pointers (procedure/function of object)
 	EndPos     token.Position
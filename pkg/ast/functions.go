@@ -28,9 +28,9 @@ import (
 // providing a value for an optional parameter, the default expression is evaluated
 // in the caller's context. Optional parameters must come after all required parameters.
 type Parameter struct {
-	DefaultValue Expression
+	DefaultValue Expression `ast:"optional"`
 	Name         *Identifier
-	Type         TypeExpression // Can be TypeAnnotation, ArrayTypeNode, FunctionPointerTypeNode, etc.
+	Type         TypeExpression `ast:"optional"` // Can be TypeAnnotation, ArrayTypeNode, FunctionPointerTypeNode, etc.
 	Token        token.Token
 	EndPos       token.Position
 	IsLazy       bool
@@ -110,17 +110,20 @@ func (p *Parameter) String() string {
 //	ensure
 //	   Result >= 0;
 type FunctionDecl struct {
-	ReturnType        TypeExpression
+	ReturnType        TypeExpression `ast:"optional"`
 	Name              *Identifier
-	ClassName         *Identifier
-	HelperName        *Identifier
-	Body              *BlockStatement
-	PreConditions     *PreConditions
-	PostConditions    *PostConditions
+	ClassName         *Identifier     `ast:"optional"`
+	HelperName        *Identifier     `ast:"optional"`
+	Body              *BlockStatement `ast:"optional"`
+	PreConditions     *PreConditions  `ast:"optional"`
+	PostConditions    *PostConditions `ast:"optional"`
 	ExternalName      string
 	CallingConvention string
 	DeprecatedMessage string
 	Parameters        []*Parameter
+	// ClassTypeParams holds the type-parameter names of the generic class or
+	// record in a method implementation such as `function TBox<T>.Get: T`.
+	ClassTypeParams []string
 	BaseNode
 	CallingConventionPos token.Position
 	StaticPos            token.Position
@@ -257,7 +260,7 @@ func (fd *FunctionDecl) String() string {
 //	Add := a + b
 //	exit
 type ReturnStatement struct {
-	ReturnValue Expression
+	ReturnValue Expression `ast:"optional"`
 	BaseNode
 }
 
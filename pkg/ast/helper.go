@@ -52,7 +52,7 @@ import (
 type HelperDecl struct {
 	ForType        TypeExpression
 	Name           *Identifier
-	ParentHelper   *Identifier
+	ParentHelper   *Identifier `ast:"optional"`
 	Methods        []*FunctionDecl
 	Properties     []*PropertyDecl
 	ClassVars      []*FieldDecl
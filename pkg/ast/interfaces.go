@@ -22,7 +22,7 @@ import (
 //
 // Note: Interface methods only declare signatures, they have no body.
 type InterfaceMethodDecl struct {
-	ReturnType TypeExpression
+	ReturnType TypeExpression `ast:"optional"`
 	Name       *Identifier
 	Parameters []*Parameter
 	BaseNode
@@ -87,7 +87,7 @@ func (imd *InterfaceMethodDecl) String() string {
 //	end;
 type InterfaceDecl struct {
 	Name         *Identifier
-	Parent       *Identifier
+	Parent       *Identifier `ast:"optional"`
 	ExternalName string
 	Methods      []*InterfaceMethodDecl
 	Properties   []*PropertyDecl
@@ -126,6 +126,13 @@ func (id *InterfaceDecl) String() string {
 		out.WriteString(";\n")
 	}
 
+	// Add property declarations
+	for _, prop := range id.Properties {
+		out.WriteString("  ")
+		out.WriteString(prop.String())
+		out.WriteString("\n")
+	}
+
 	out.WriteString("end")
 
 	return out.String()
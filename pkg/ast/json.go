@@ -0,0 +1,449 @@
+package ast
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/cwbudde/go-dws/pkg/token"
+)
+
+// JSONVersion is the version of the JSON AST schema, written to the
+// "astVersion" field of the root object. Bump it whenever the serialized
+// shape of any node changes so that consumers can detect stale input.
+const JSONVersion = 1
+
+// The JSON schema is derived from the node structs:
+//
+//   - Every node is an object whose "type" field holds the Go type name
+//     (e.g. "BinaryExpression").
+//   - Exported fields are emitted under their lowerCamelCase name; fields
+//     of embedded structs (BaseNode) are flattened into the node object.
+//     Zero-valued fields are omitted. Fields named Type are written as
+//     "typeExpr" because "type" is reserved for the node tag.
+//   - Tokens are objects {"type": "INT", "literal": "42", "pos": {...}}.
+//   - Positions ("pos", "endPos", and the informational per-node
+//     "position") are only emitted when positions are requested. They
+//     carry an optional "source" naming the file (token.Position.Source).
+//   - Program.Comments is not serialized.
+//
+// A handful of keys predate the generic scheme and are kept for
+// compatibility; see jsonFieldRenames.
+
+// jsonFieldRenames maps "NodeType.Field" to a JSON key that differs from the
+// default lowerCamelCase field name.
+var jsonFieldRenames = map[string]string{
+	"UnaryExpression.Right": "operand",
+}
+
+// jsonNodeTypes maps a "type" tag to the concrete node struct it names.
+var jsonNodeTypes = map[string]reflect.Type{}
+
+func init() {
+	for _, n := range []interface{}{
+		&AddressOfExpression{}, &ArrayDecl{}, &ArrayLiteralExpression{}, &ArrayTypeAnnotation{},
+		&ArrayTypeNode{}, &AsExpression{}, &AssignmentStatement{}, &BinaryExpression{},
+		&BlockStatement{}, &BooleanLiteral{}, &BreakStatement{}, &CallExpression{},
+		&CaseBranch{}, &CaseStatement{}, &CharLiteral{}, &ClassDecl{},
+		&ClassOfTypeNode{}, &Comment{}, &CommentGroup{}, &Condition{},
+		&ConstDecl{}, &ContinueStatement{}, &EmptyStatement{}, &EnumDecl{},
+		&EnumLiteral{}, &ExceptClause{}, &ExceptionHandler{}, &ExitStatement{},
+		&ExpressionStatement{}, &FieldDecl{}, &FieldInitializer{}, &FinallyClause{},
+		&FloatLiteral{}, &ForInStatement{}, &ForStatement{}, &FunctionDecl{},
+		&FunctionPointerTypeNode{}, &GenericTypeRef{}, &GroupedExpression{}, &HelperDecl{},
+		&Identifier{}, &IfExpression{}, &IfStatement{}, &ImplementsExpression{},
+		&IndexExpression{}, &InheritedExpression{}, &IntegerLiteral{}, &InterfaceDecl{},
+		&InterfaceMethodDecl{}, &InvalidExpression{}, &InvalidStatement{}, &InvalidTypeExpression{},
+		&InvariantClause{}, &IsExpression{}, &LambdaExpression{}, &MemberAccessExpression{},
+		&MethodCallExpression{}, &NewArrayExpression{}, &NewExpression{}, &NilLiteral{},
+		&OldExpression{}, &OperatorDecl{}, &Parameter{}, &PostConditions{},
+		&PreConditions{}, &Program{}, &PropertyDecl{}, &RaiseStatement{},
+		&RangeExpression{}, &RecordDecl{}, &RecordLiteralExpression{}, &RecordTypeNode{},
+		&RepeatStatement{}, &ReturnStatement{}, &SelfExpression{}, &SetDecl{},
+		&SetLiteral{}, &SetTypeNode{}, &StringLiteral{}, &TryStatement{},
+		&TypeAnnotation{}, &TypeDeclaration{}, &UnaryExpression{}, &UnitDeclaration{},
+		&UsesClause{}, &VarDeclStatement{}, &WhileStatement{}, &WithStatement{},
+	} {
+		t := reflect.TypeOf(n).Elem()
+		jsonNodeTypes[t.Name()] = t
+	}
+}
+
+var (
+	jsonTokenType      = reflect.TypeOf(token.Token{})
+	jsonPositionType   = reflect.TypeOf(token.Position{})
+	jsonCommentMapType = reflect.TypeOf(CommentMap{})
+)
+
+// NodeToJSON converts node into the generic map form of the JSON AST schema.
+// The root object carries the "astVersion" field. When includePositions is
+// false, all source positions are left out. The result can be passed to
+// json.Marshal; UnmarshalJSON reads it back.
+func NodeToJSON(node Node, includePositions bool) map[string]interface{} {
+	if node == nil || reflect.ValueOf(node).IsNil() {
+		return nil
+	}
+
+	enc := jsonEncoder{positions: includePositions}
+	result := enc.node(reflect.ValueOf(node))
+	result["astVersion"] = JSONVersion
+	return result
+}
+
+type jsonEncoder struct {
+	positions bool
+}
+
+// node encodes a non-nil pointer to a node struct as a tagged object.
+func (enc jsonEncoder) node(v reflect.Value) map[string]interface{} {
+	result := map[string]interface{}{"type": v.Elem().Type().Name()}
+
+	if n, ok := v.Interface().(Node); ok && enc.positions {
+		if pos := n.Pos(); pos.Line > 0 {
+			position := map[string]interface{}{
+				"line":   pos.Line,
+				"column": pos.Column,
+			}
+			if pos.Source != "" {
+				position["source"] = pos.Source
+			}
+			result["position"] = position
+		}
+	}
+
+	enc.fields(result, v.Elem().Type().Name(), v.Elem())
+	return result
+}
+
+// fields adds the exported fields of struct value v to out, flattening
+// embedded structs.
+func (enc jsonEncoder) fields(out map[string]interface{}, typeName string, v reflect.Value) {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if !f.IsExported() || f.Type == jsonCommentMapType {
+			continue
+		}
+		if f.Anonymous && f.Type.Kind() == reflect.Struct && f.Type != jsonTokenType {
+			enc.fields(out, typeName, v.Field(i))
+			continue
+		}
+
+		fv := v.Field(i)
+		if fv.IsZero() {
+			continue
+		}
+		if value := enc.value(fv); value != nil {
+			out[jsonFieldKey(typeName, f.Name)] = value
+		}
+	}
+}
+
+// value encodes an arbitrary field value. A nil result means "omit".
+func (enc jsonEncoder) value(v reflect.Value) interface{} {
+	switch v.Type() {
+	case jsonTokenType:
+		tok := v.Interface().(token.Token)
+		result := map[string]interface{}{"type": tok.Type.String()}
+		if tok.Literal != "" {
+			result["literal"] = tok.Literal
+		}
+		if pos := enc.position(tok.Pos); pos != nil {
+			result["pos"] = pos
+		}
+		return result
+	case jsonPositionType:
+		if pos := enc.position(v.Interface().(token.Position)); pos != nil {
+			return pos
+		}
+		return nil
+	}
+
+	switch v.Kind() {
+	case reflect.Interface:
+		if v.IsNil() {
+			return nil
+		}
+		return enc.value(v.Elem())
+	case reflect.Ptr:
+		if v.IsNil() {
+			return nil
+		}
+		if v.Elem().Kind() == reflect.Struct {
+			return enc.node(v)
+		}
+		return enc.value(v.Elem())
+	case reflect.Slice:
+		items := make([]interface{}, v.Len())
+		for i := range items {
+			items[i] = enc.value(v.Index(i))
+		}
+		return items
+	case reflect.Struct:
+		result := map[string]interface{}{}
+		enc.fields(result, v.Type().Name(), v)
+		return result
+	case reflect.Map:
+		return nil
+	default:
+		return v.Interface()
+	}
+}
+
+func (enc jsonEncoder) position(pos token.Position) map[string]interface{} {
+	if !enc.positions || pos == (token.Position{}) {
+		return nil
+	}
+	result := map[string]interface{}{
+		"line":   pos.Line,
+		"column": pos.Column,
+		"offset": pos.Offset,
+	}
+	if pos.Source != "" {
+		result["source"] = pos.Source
+	}
+	return result
+}
+
+// jsonFieldKey returns the JSON key for field fieldName of node typeName.
+func jsonFieldKey(typeName, fieldName string) string {
+	if key, ok := jsonFieldRenames[typeName+"."+fieldName]; ok {
+		return key
+	}
+	r, size := utf8.DecodeRuneInString(fieldName)
+	key := string(unicode.ToLower(r)) + fieldName[size:]
+	if key == "type" {
+		// "type" is the node tag, so Type fields are written as "typeExpr".
+		return "typeExpr"
+	}
+	return key
+}
+
+// UnmarshalJSON reconstructs a program from the JSON AST schema produced by
+// NodeToJSON (and by the printer's FormatJSON output). The input must carry
+// an "astVersion" equal to JSONVersion. Unknown node types and fields are
+// reported as errors rather than silently dropped.
+func UnmarshalJSON(data []byte) (*Program, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+
+	var raw map[string]interface{}
+	if err := dec.Decode(&raw); err != nil {
+		return nil, fmt.Errorf("ast: invalid JSON: %w", err)
+	}
+	if raw == nil {
+		return nil, fmt.Errorf("ast: expected a Program object, got null")
+	}
+
+	version, ok := raw["astVersion"]
+	if !ok {
+		return nil, fmt.Errorf("ast: missing astVersion field")
+	}
+	if n, ok := version.(json.Number); !ok || n.String() != fmt.Sprint(JSONVersion) {
+		return nil, fmt.Errorf("ast: unsupported astVersion %v (expected %d)", version, JSONVersion)
+	}
+	if raw["type"] != "Program" {
+		return nil, fmt.Errorf("ast: root node must be a Program, got %v", raw["type"])
+	}
+
+	program := &Program{}
+	if err := decodeJSONStruct(reflect.ValueOf(program).Elem(), raw, "Program", "$"); err != nil {
+		return nil, err
+	}
+	return program, nil
+}
+
+// decodeJSONStruct fills struct value v from object m. typeName selects
+// field renames; path is used in error messages.
+func decodeJSONStruct(v reflect.Value, m map[string]interface{}, typeName, path string) error {
+	fields := jsonStructFields(v.Type(), typeName)
+	for key, raw := range m {
+		switch key {
+		case "type", "position", "astVersion":
+			continue
+		}
+		index, ok := fields[key]
+		if !ok {
+			return fmt.Errorf("ast: %s: unknown field %q for %s", path, key, typeName)
+		}
+		if err := decodeJSONValue(v.FieldByIndex(index), raw, path+"."+key); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// jsonStructFields maps JSON keys to field index paths for struct type t.
+func jsonStructFields(t reflect.Type, typeName string) map[string][]int {
+	fields := make(map[string][]int)
+	var walk func(t reflect.Type, prefix []int)
+	walk = func(t reflect.Type, prefix []int) {
+		for i := 0; i < t.NumField(); i++ {
+			f := t.Field(i)
+			if !f.IsExported() || f.Type == jsonCommentMapType {
+				continue
+			}
+			index := append(append([]int{}, prefix...), i)
+			if f.Anonymous && f.Type.Kind() == reflect.Struct && f.Type != jsonTokenType {
+				walk(f.Type, index)
+				continue
+			}
+			fields[jsonFieldKey(typeName, f.Name)] = index
+		}
+	}
+	walk(t, nil)
+	return fields
+}
+
+// decodeJSONValue stores raw into the settable value v.
+func decodeJSONValue(v reflect.Value, raw interface{}, path string) error {
+	if raw == nil {
+		return nil
+	}
+
+	switch v.Type() {
+	case jsonTokenType:
+		m, ok := raw.(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("ast: %s: expected token object", path)
+		}
+		var tok token.Token
+		if name, ok := m["type"].(string); ok {
+			tt, found := token.LookupTokenType(name)
+			if !found {
+				return fmt.Errorf("ast: %s: unknown token type %q", path, name)
+			}
+			tok.Type = tt
+		}
+		if literal, ok := m["literal"].(string); ok {
+			tok.Literal = literal
+		}
+		if err := decodeJSONValue(reflect.ValueOf(&tok.Pos).Elem(), m["pos"], path+".pos"); err != nil {
+			return err
+		}
+		v.Set(reflect.ValueOf(tok))
+		return nil
+	case jsonPositionType:
+		m, ok := raw.(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("ast: %s: expected position object", path)
+		}
+		var pos token.Position
+		if source, ok := m["source"].(string); ok {
+			pos.Source = source
+		}
+		for key, dst := range map[string]*int{"line": &pos.Line, "column": &pos.Column, "offset": &pos.Offset} {
+			if n, ok := m[key].(json.Number); ok {
+				i, err := n.Int64()
+				if err != nil {
+					return fmt.Errorf("ast: %s.%s: %w", path, key, err)
+				}
+				*dst = int(i)
+			}
+		}
+		v.Set(reflect.ValueOf(pos))
+		return nil
+	}
+
+	switch v.Kind() {
+	case reflect.Interface:
+		m, ok := raw.(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("ast: %s: expected node object", path)
+		}
+		typeName, _ := m["type"].(string)
+		t, ok := jsonNodeTypes[typeName]
+		if !ok {
+			return fmt.Errorf("ast: %s: unknown node type %q", path, typeName)
+		}
+		node := reflect.New(t)
+		if !node.Type().Implements(v.Type()) {
+			return fmt.Errorf("ast: %s: %s is not a valid %s", path, typeName, v.Type().Name())
+		}
+		if err := decodeJSONStruct(node.Elem(), m, typeName, path); err != nil {
+			return err
+		}
+		v.Set(node)
+		return nil
+	case reflect.Ptr:
+		elem := reflect.New(v.Type().Elem())
+		if elem.Elem().Kind() == reflect.Struct {
+			m, ok := raw.(map[string]interface{})
+			if !ok {
+				return fmt.Errorf("ast: %s: expected node object", path)
+			}
+			typeName := v.Type().Elem().Name()
+			if tag, ok := m["type"].(string); ok && tag != typeName {
+				return fmt.Errorf("ast: %s: expected %s, got %s", path, typeName, tag)
+			}
+			if err := decodeJSONStruct(elem.Elem(), m, typeName, path); err != nil {
+				return err
+			}
+		} else if err := decodeJSONValue(elem.Elem(), raw, path); err != nil {
+			return err
+		}
+		v.Set(elem)
+		return nil
+	case reflect.Slice:
+		items, ok := raw.([]interface{})
+		if !ok {
+			return fmt.Errorf("ast: %s: expected array", path)
+		}
+		slice := reflect.MakeSlice(v.Type(), len(items), len(items))
+		for i, item := range items {
+			if err := decodeJSONValue(slice.Index(i), item, fmt.Sprintf("%s[%d]", path, i)); err != nil {
+				return err
+			}
+		}
+		v.Set(slice)
+		return nil
+	case reflect.Struct:
+		m, ok := raw.(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("ast: %s: expected object", path)
+		}
+		return decodeJSONStruct(v, m, v.Type().Name(), path)
+	case reflect.String:
+		s, ok := raw.(string)
+		if !ok {
+			return fmt.Errorf("ast: %s: expected string", path)
+		}
+		v.SetString(s)
+		return nil
+	case reflect.Bool:
+		b, ok := raw.(bool)
+		if !ok {
+			return fmt.Errorf("ast: %s: expected boolean", path)
+		}
+		v.SetBool(b)
+		return nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, ok := raw.(json.Number)
+		if !ok {
+			return fmt.Errorf("ast: %s: expected integer", path)
+		}
+		i, err := n.Int64()
+		if err != nil || v.OverflowInt(i) {
+			return fmt.Errorf("ast: %s: invalid integer %s", path, n)
+		}
+		v.SetInt(i)
+		return nil
+	case reflect.Float32, reflect.Float64:
+		n, ok := raw.(json.Number)
+		if !ok {
+			return fmt.Errorf("ast: %s: expected number", path)
+		}
+		f, err := n.Float64()
+		if err != nil {
+			return fmt.Errorf("ast: %s: invalid number %s", path, n)
+		}
+		v.SetFloat(f)
+		return nil
+	}
+
+	return fmt.Errorf("ast: %s: cannot decode into %s", path, strings.TrimPrefix(v.Type().String(), "ast."))
+}
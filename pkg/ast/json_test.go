@@ -0,0 +1,227 @@
+package ast_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/cwbudde/go-dws/internal/lexer"
+	"github.com/cwbudde/go-dws/internal/parser"
+	"github.com/cwbudde/go-dws/pkg/ast"
+	"github.com/cwbudde/go-dws/pkg/printer"
+)
+
+// roundTripJSON parses source, prints it as JSON, loads the JSON back and
+// prints the loaded tree again. It returns both JSON blobs.
+func roundTripJSON(t *testing.T, source string) (string, string) {
+	t.Helper()
+
+	p := parser.New(lexer.New(source))
+	program := p.ParseProgram()
+	if len(p.Errors()) > 0 {
+		t.Skipf("source does not parse: %v", p.Errors()[0])
+	}
+
+	jp := printer.New(printer.JSONOptionsWithPositions())
+	first := jp.Print(program)
+
+	loaded, err := ast.UnmarshalJSON([]byte(first))
+	if err != nil {
+		t.Fatalf("UnmarshalJSON failed: %v", err)
+	}
+	return first, jp.Print(loaded)
+}
+
+func TestJSONRoundTrip(t *testing.T) {
+	tests := []struct {
+		name   string
+		source string
+	}{
+		{name: "literals", source: "PrintLn(42); PrintLn(3.5); PrintLn('hi'); PrintLn(True); PrintLn(#65);"},
+		{name: "unary and binary", source: "var x := -(1 + 2) * 3; if not (x > 0) then PrintLn(x);"},
+		{name: "typed vars and consts", source: "const C: Integer = 5; var s: String := 'a'; var a: array of Integer;"},
+		{name: "loops", source: "var i: Integer; for i := 1 to 3 do PrintLn(i); while i > 0 do Dec(i); repeat Inc(i) until i = 2;"},
+		{name: "case and try", source: `
+var x := 2;
+case x of
+  1: PrintLn('one');
+  2, 3: PrintLn('two or three');
+else
+  PrintLn('other');
+end;
+try
+  raise Exception.Create('boom');
+except
+  on E: Exception do PrintLn(E.Message);
+end;`},
+		{name: "functions", source: `
+function Add(a, b: Integer; const c: Integer = 1): Integer;
+begin
+  Result := a + b + c;
+end;
+procedure Swap(var a, b: Integer);
+var t: Integer;
+begin
+  t := a; a := b; b := t;
+end;
+PrintLn(Add(1, 2));`},
+		{name: "classes", source: `
+type TShape = class
+private
+  FName: String;
+public
+  class var Count: Integer;
+  constructor Create(const name: String); virtual;
+  function Area: Float; virtual; abstract;
+  property Name: String read FName;
+end;
+type TSquare = class(TShape)
+  FSide: Float;
+  function Area: Float; override;
+end;
+constructor TShape.Create(const name: String);
+begin
+  FName := name;
+  Inc(Count);
+end;
+function TSquare.Area: Float;
+begin
+  Result := FSide * FSide;
+end;`},
+		{name: "records enums sets", source: `
+type TColor = (Red, Green = 5, Blue);
+type TColors = set of TColor;
+type TPoint = record
+  X, Y: Integer;
+end;
+var p: TPoint;
+p.X := 1;
+var cs: TColors := [Red, Blue];
+if Green in cs then PrintLn('green');`},
+		{name: "lambdas", source: `
+type TFunc = function(x: Integer): Integer;
+var f: TFunc := lambda(x: Integer): Integer => x * 2;
+PrintLn(f(21));`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			first, second := roundTripJSON(t, tt.source)
+			if first != second {
+				t.Errorf("JSON round-trip mismatch\nfirst:\n%s\nsecond:\n%s", first, second)
+			}
+		})
+	}
+}
+
+// TestJSONRoundTripFixtures round-trips every script in testdata so that new
+// node types or fields missing from the loader are caught.
+func TestJSONRoundTripFixtures(t *testing.T) {
+	files, err := filepath.Glob(filepath.Join("..", "..", "testdata", "*.dws"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) == 0 {
+		t.Skip("no fixtures found")
+	}
+
+	for _, file := range files {
+		t.Run(filepath.Base(file), func(t *testing.T) {
+			source, err := os.ReadFile(file)
+			if err != nil {
+				t.Fatal(err)
+			}
+			first, second := roundTripJSON(t, string(source))
+			if first != second {
+				t.Errorf("JSON round-trip mismatch for %s", file)
+			}
+		})
+	}
+}
+
+func TestUnmarshalJSONPreservesPositions(t *testing.T) {
+	p := parser.New(lexer.New("var x := 1;\nPrintLn(x);"))
+	program := p.ParseProgram()
+
+	jp := printer.New(printer.JSONOptionsWithPositions())
+	loaded, err := ast.UnmarshalJSON([]byte(jp.Print(program)))
+	if err != nil {
+		t.Fatalf("UnmarshalJSON failed: %v", err)
+	}
+
+	if len(loaded.Statements) != 2 {
+		t.Fatalf("expected 2 statements, got %d", len(loaded.Statements))
+	}
+	got := loaded.Statements[1].Pos()
+	want := program.Statements[1].Pos()
+	if got != want {
+		t.Errorf("statement position = %v, want %v", got, want)
+	}
+	if loaded.String() != program.String() {
+		t.Errorf("String() mismatch:\n got: %s\nwant: %s", loaded.String(), program.String())
+	}
+}
+
+func TestUnmarshalJSONPreservesSource(t *testing.T) {
+	p := parser.New(lexer.New("var x := 1;", lexer.WithSourceName("main.dws")))
+	program := p.ParseProgram()
+
+	jp := printer.New(printer.JSONOptionsWithPositions())
+	output := jp.Print(program)
+	if !strings.Contains(output, `"source": "main.dws"`) {
+		t.Fatalf("JSON output does not include the source name:\n%s", output)
+	}
+	loaded, err := ast.UnmarshalJSON([]byte(output))
+	if err != nil {
+		t.Fatalf("UnmarshalJSON failed: %v", err)
+	}
+	if got := loaded.Statements[0].Pos(); got != program.Statements[0].Pos() {
+		t.Errorf("statement position = %+v, want %+v", got, program.Statements[0].Pos())
+	}
+}
+
+func TestUnmarshalJSONErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		wantErr string
+	}{
+		{name: "invalid JSON", input: `{`, wantErr: "invalid JSON"},
+		{name: "missing version", input: `{"type": "Program"}`, wantErr: "missing astVersion"},
+		{name: "future version", input: `{"astVersion": 99, "type": "Program"}`, wantErr: "unsupported astVersion 99"},
+		{name: "non-program root", input: `{"astVersion": 1, "type": "Identifier"}`, wantErr: "root node must be a Program"},
+		{
+			name:    "unknown node type",
+			input:   `{"astVersion": 1, "type": "Program", "statements": [{"type": "GotoStatement"}]}`,
+			wantErr: `unknown node type "GotoStatement"`,
+		},
+		{
+			name:    "unknown field",
+			input:   `{"astVersion": 1, "type": "Program", "statements": [{"type": "ExpressionStatement", "bogus": 1}]}`,
+			wantErr: `unknown field "bogus"`,
+		},
+		{
+			name:    "expression in statement slot",
+			input:   `{"astVersion": 1, "type": "Program", "statements": [{"type": "IntegerLiteral", "value": 1}]}`,
+			wantErr: "IntegerLiteral is not a valid Statement",
+		},
+		{
+			name:    "unknown token type",
+			input:   `{"astVersion": 1, "type": "Program", "statements": [{"type": "EmptyStatement", "token": {"type": "NOPE"}}]}`,
+			wantErr: `unknown token type "NOPE"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ast.UnmarshalJSON([]byte(tt.input))
+			if err == nil {
+				t.Fatalf("expected error containing %q, got nil", tt.wantErr)
+			}
+			if !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("error = %q, want it to contain %q", err.Error(), tt.wantErr)
+			}
+		})
+	}
+}
@@ -27,7 +27,7 @@ import (
 //	var squared := Map(numbers, lambda(x: Integer) => x * x);
 //	var printer := lambda() begin PrintLn('Hello'); end;
 type LambdaExpression struct {
-	ReturnType   TypeExpression
+	ReturnType   TypeExpression `ast:"optional"`
 	Body         *BlockStatement
 	Parameters   []*Parameter
 	CapturedVars []string
@@ -69,17 +69,21 @@ import (
 // writes. Typical usage is single-threaded analysis (writes) followed by
 // concurrent interpretation/compilation (reads).
 type SemanticInfo struct {
-	types   map[Expression]*TypeAnnotation
-	symbols map[*Identifier]interface{}
-	mu      sync.RWMutex
+	types     map[Expression]*TypeAnnotation
+	symbols   map[*Identifier]interface{}
+	constants map[Expression]any
+	dead      map[Statement]bool
+	mu        sync.RWMutex
 }
 
 // NewSemanticInfo creates a new empty semantic metadata table.
 // Each semantic analysis should create its own SemanticInfo instance.
 func NewSemanticInfo() *SemanticInfo {
 	return &SemanticInfo{
-		types:   make(map[Expression]*TypeAnnotation),
-		symbols: make(map[*Identifier]interface{}),
+		types:     make(map[Expression]*TypeAnnotation),
+		symbols:   make(map[*Identifier]interface{}),
+		constants: make(map[Expression]any),
+		dead:      make(map[Statement]bool),
 	}
 }
 
@@ -167,6 +171,51 @@ func (si *SemanticInfo) HasSymbol(ident *Identifier) bool {
 	return ok
 }
 
+// ============================================================================
+// Constant Folding API
+// ============================================================================
+
+// GetConstant returns the compile-time value folded for an expression node.
+// The value is an int64, float64, string or bool. The second result is false
+// if the expression was not folded.
+//
+// Thread-safe for concurrent reads.
+func (si *SemanticInfo) GetConstant(expr Expression) (any, bool) {
+	si.mu.RLock()
+	defer si.mu.RUnlock()
+	value, ok := si.constants[expr]
+	return value, ok
+}
+
+// SetConstant records the compile-time value of an expression node.
+// This is called by the semantic analyzer when constant folding is enabled.
+//
+// Not safe for concurrent writes. Should only be called during analysis.
+func (si *SemanticInfo) SetConstant(expr Expression, value any) {
+	si.mu.Lock()
+	defer si.mu.Unlock()
+	si.constants[expr] = value
+}
+
+// IsDead returns true if the statement was found unreachable because the
+// condition selecting it folded to a constant.
+//
+// Thread-safe for concurrent reads.
+func (si *SemanticInfo) IsDead(stmt Statement) bool {
+	si.mu.RLock()
+	defer si.mu.RUnlock()
+	return si.dead[stmt]
+}
+
+// MarkDead records that a statement is never executed.
+//
+// Not safe for concurrent writes. Should only be called during analysis.
+func (si *SemanticInfo) MarkDead(stmt Statement) {
+	si.mu.Lock()
+	defer si.mu.Unlock()
+	si.dead[stmt] = true
+}
+
 // ============================================================================
 // Statistics and Debugging
 // ============================================================================
@@ -191,6 +240,16 @@ func (si *SemanticInfo) SymbolCount() int {
 	return len(si.symbols)
 }
 
+// ConstantCount returns the number of expressions with a folded value.
+// Useful for statistics and testing.
+//
+// Thread-safe for concurrent reads.
+func (si *SemanticInfo) ConstantCount() int {
+	si.mu.RLock()
+	defer si.mu.RUnlock()
+	return len(si.constants)
+}
+
 // Clear removes all semantic information.
 // Useful for resetting the metadata table or memory management.
 //
@@ -200,4 +259,6 @@ func (si *SemanticInfo) Clear() {
 	defer si.mu.Unlock()
 	si.types = make(map[Expression]*TypeAnnotation)
 	si.symbols = make(map[*Identifier]interface{})
+	si.constants = make(map[Expression]any)
+	si.dead = make(map[Statement]bool)
 }
@@ -136,6 +136,39 @@ func TestSemanticInfo_SymbolOperations(t *testing.T) {
 	}
 }
 
+func TestSemanticInfo_ConstantOperations(t *testing.T) {
+	si := NewSemanticInfo()
+
+	expr := NewTestIntegerLiteral(42)
+	stmt := &ExpressionStatement{Expression: expr}
+
+	if _, ok := si.GetConstant(expr); ok {
+		t.Error("GetConstant() reported a value before SetConstant")
+	}
+	if si.IsDead(stmt) {
+		t.Error("IsDead() = true, want false")
+	}
+
+	si.SetConstant(expr, int64(42))
+	si.MarkDead(stmt)
+
+	if value, ok := si.GetConstant(expr); !ok || value != int64(42) {
+		t.Errorf("GetConstant() = %v, %v, want 42, true", value, ok)
+	}
+	if !si.IsDead(stmt) {
+		t.Error("IsDead() = false, want true")
+	}
+	if count := si.ConstantCount(); count != 1 {
+		t.Errorf("ConstantCount() = %d, want 1", count)
+	}
+
+	si.Clear()
+
+	if si.ConstantCount() != 0 || si.IsDead(stmt) {
+		t.Error("Clear() kept constant folding results")
+	}
+}
+
 func TestSemanticInfo_MultipleExpressions(t *testing.T) {
 	si := NewSemanticInfo()
 
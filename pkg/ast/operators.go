@@ -45,7 +45,7 @@ func (k OperatorKind) String() string {
 //	class operator += String uses AppendString;
 type OperatorDecl struct {
 	ReturnType     TypeExpression
-	Binding        *Identifier
+	Binding        *Identifier `ast:"optional"`
 	OperatorSymbol string
 	OperandTypes   []TypeExpression
 	OperatorToken  token.Token
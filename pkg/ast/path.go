@@ -0,0 +1,109 @@
+package ast
+
+import "github.com/cwbudde/go-dws/pkg/token"
+
+// PathTo returns the chain of nodes from root down to the innermost node
+// covering pos, so that path[len(path)-1] is the node at pos and path[i-1]
+// is the parent of path[i]. It returns nil if root does not cover pos.
+//
+// A node covers pos when pos lies in the half-open range [start, end) formed
+// by the earliest Pos() and latest End() of the node and its descendants,
+// compared by line and column. Descendants are included because some nodes
+// report an inner token as their position; an AssignmentStatement, for
+// example, starts at its ":=" operator. Only positions in pos.Source count,
+// so nodes pulled in from an {$INCLUDE}d file never cover a position in the
+// including file. A *Program root covers every valid position, including
+// leading and trailing whitespace. Where sibling ranges overlap, the first
+// sibling in traversal order wins.
+//
+// Example:
+//
+//	path := ast.PathTo(program, token.Position{Line: 3, Column: 5})
+//	if len(path) >= 2 {
+//	    if assign, ok := path[len(path)-2].(*ast.AssignmentStatement); ok && assign.Target == path[len(path)-1] {
+//	        // the node at the cursor is the target of an assignment
+//	    }
+//	}
+func PathTo(root Node, pos token.Position) []Node {
+	if root == nil || !pos.IsValid() {
+		return nil
+	}
+	_, path := pathTo(root, pos)
+	if _, ok := root.(*Program); ok && path == nil {
+		path = []Node{root}
+	}
+	return path
+}
+
+// span is the range of positions covered by a node and its descendants.
+type span struct {
+	start, end token.Position
+	valid      bool
+}
+
+func (s *span) add(start, end token.Position) {
+	if !s.valid || before(start, s.start) {
+		s.start = start
+	}
+	if !s.valid || before(s.end, end) {
+		s.end = end
+	}
+	s.valid = true
+}
+
+// pathTo returns the span of node in pos.Source, and the path from node to
+// the innermost node covering pos if node covers it.
+func pathTo(node Node, pos token.Position) (span, []Node) {
+	var s span
+	if start, end := node.Pos(), node.End(); start.IsValid() && start.Source == pos.Source {
+		if !end.IsValid() {
+			end = start
+		}
+		s.add(start, end)
+	}
+
+	var inner []Node
+	for _, child := range children(node) {
+		childSpan, childPath := pathTo(child, pos)
+		if childSpan.valid {
+			s.add(childSpan.start, childSpan.end)
+		}
+		if inner == nil {
+			inner = childPath
+		}
+	}
+
+	if !s.valid || before(pos, s.start) || !before(pos, s.end) {
+		return s, nil
+	}
+	return s, append([]Node{node}, inner...)
+}
+
+// children returns the direct children of node, in traversal order.
+func children(node Node) []Node {
+	collector := &childCollector{}
+	Walk(collector, node)
+	return collector.children
+}
+
+// childCollector records the nodes Walk visits one level below its start.
+type childCollector struct {
+	children []Node
+	started  bool
+}
+
+func (c *childCollector) Visit(node Node) Visitor {
+	if !c.started {
+		c.started = true
+		return c
+	}
+	if node != nil {
+		c.children = append(c.children, node)
+	}
+	return nil
+}
+
+// before reports whether a comes before b, comparing lines, then columns.
+func before(a, b token.Position) bool {
+	return a.Line < b.Line || (a.Line == b.Line && a.Column < b.Column)
+}
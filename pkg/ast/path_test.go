@@ -0,0 +1,97 @@
+package ast_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/cwbudde/go-dws/pkg/ast"
+	"github.com/cwbudde/go-dws/pkg/dwscript"
+	"github.com/cwbudde/go-dws/pkg/token"
+)
+
+// pathTypes formats the node types along a path, without the ast prefix.
+func pathTypes(path []ast.Node) string {
+	names := make([]string, len(path))
+	for i, node := range path {
+		names[i] = strings.TrimPrefix(fmt.Sprintf("%T", node), "*ast.")
+	}
+	return strings.Join(names, " > ")
+}
+
+// TestPathTo tests the node chain returned for positions in a small program.
+func TestPathTo(t *testing.T) {
+	engine, _ := dwscript.New()
+	program, err := engine.Parse("var total: Integer;\n\nfunction Twice(n: Integer): Integer;\nbegin\n  total := total + n * 2;\nend;\n")
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	tests := []struct {
+		name string
+		want string
+		pos  token.Position
+	}{
+		{
+			name: "assignment target",
+			pos:  token.Position{Line: 5, Column: 3},
+			want: "Program > FunctionDecl > BlockStatement > AssignmentStatement > Identifier",
+		},
+		{
+			name: "last character of an identifier",
+			pos:  token.Position{Line: 5, Column: 7},
+			want: "Program > FunctionDecl > BlockStatement > AssignmentStatement > Identifier",
+		},
+		{
+			name: "nested operand",
+			pos:  token.Position{Line: 5, Column: 20},
+			want: "Program > FunctionDecl > BlockStatement > AssignmentStatement > BinaryExpression > BinaryExpression > Identifier",
+		},
+		{
+			name: "parameter",
+			pos:  token.Position{Line: 3, Column: 16},
+			want: "Program > FunctionDecl > Parameter > Identifier",
+		},
+		{
+			name: "blank line",
+			pos:  token.Position{Line: 2, Column: 1},
+			want: "Program",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := ast.PathTo(program, tt.pos)
+			if got := pathTypes(path); got != tt.want {
+				t.Errorf("PathTo(%d:%d) = %s, want %s", tt.pos.Line, tt.pos.Column, got, tt.want)
+			}
+		})
+	}
+
+	// The chain identifies the assignment target from its parent.
+	path := ast.PathTo(program, token.Position{Line: 5, Column: 3})
+	assign, ok := path[len(path)-2].(*ast.AssignmentStatement)
+	if !ok || assign.Target != path[len(path)-1] {
+		t.Errorf("expected the innermost node to be the assignment target, got %s", pathTypes(path))
+	}
+}
+
+// TestPathTo_NotCovered tests that positions outside a non-program root, or
+// in another source file, yield no path.
+func TestPathTo_NotCovered(t *testing.T) {
+	engine, _ := dwscript.New()
+	program, err := engine.Parse("PrintLn(1);\nPrintLn(2);\n")
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	if path := ast.PathTo(program.Statements[0], token.Position{Line: 2, Column: 1}); path != nil {
+		t.Errorf("PathTo outside the statement = %s, want nil", pathTypes(path))
+	}
+	if path := ast.PathTo(program, token.Position{Source: "other.pas", Line: 1, Column: 1}); pathTypes(path) != "Program" {
+		t.Errorf("PathTo in another source = %s, want Program", pathTypes(path))
+	}
+	if path := ast.PathTo(program, token.Position{}); path != nil {
+		t.Errorf("PathTo at an invalid position = %s, want nil", pathTypes(path))
+	}
+}
@@ -22,18 +22,18 @@ import (
 //	property Items[i: Integer]: String read GetItem; default;          // Default property
 //	class property Version: String read GetVersion;                    // Class property (static)
 type PropertyDecl struct {
-	ReadSpec  Expression
-	WriteSpec Expression
+	ReadSpec  Expression `ast:"optional"`
+	WriteSpec Expression `ast:"optional"`
 	// WriteStmt holds an expression-based write specifier, e.g.
 	//   property P: Integer read (2*F) write (F := Value div 2);
 	// or a parenthesized lvalue write, e.g. write (FSub.Field), which the
 	// parser normalizes to the assignment statement `FSub.Field := Value`.
 	// When WriteStmt is non-nil, WriteSpec is nil.
-	WriteStmt   Statement
+	WriteStmt   Statement `ast:"optional"`
 	Type        TypeExpression
 	Name        *Identifier
 	IndexParams []*Parameter
-	IndexValue  Expression
+	IndexValue  Expression `ast:"optional"`
 	BaseNode
 	IsDefault       bool
 	IsClassProperty bool
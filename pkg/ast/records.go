@@ -29,6 +29,7 @@ type RecordDecl struct {
 	Properties []RecordPropertyDecl
 	Constants  []*ConstDecl
 	ClassVars  []*FieldDecl
+	Operators  []*OperatorDecl
 	// TypeParams holds the generic type-parameter names for a generic record
 	// (e.g. ["A", "B"] for `type TRec<A,B> = record ... end;`). Empty for
 	// non-generic records. Generic records are monomorphized before analysis.
@@ -91,6 +92,13 @@ func (rd *RecordDecl) String() string {
 		out.WriteString(";\n")
 	}
 
+	// Add operators
+	for _, operator := range rd.Operators {
+		out.WriteString("  ")
+		out.WriteString(operator.String())
+		out.WriteString(";\n")
+	}
+
 	out.WriteString("end")
 
 	return out.String()
@@ -107,10 +115,10 @@ type RecordPropertyDecl struct {
 	WriteField string
 	// ReadExpr holds an expression-based read specifier: read (2*Field).
 	// When set, ReadField is empty.
-	ReadExpr Expression
+	ReadExpr Expression `ast:"optional"`
 	// WriteStmt holds an expression-based write specifier: write (Field := Value)
 	// or a normalized parenthesized lvalue write. When set, WriteField is empty.
-	WriteStmt   Statement
+	WriteStmt   Statement `ast:"optional"`
 	IndexParams []*Parameter
 	BaseNode
 	IsDefault bool
@@ -203,7 +211,7 @@ func (fi *FieldInitializer) String() string {
 //   - const big : TSphere = (cx: 20; cy: 20; cz: 0; r: 20);
 //   - const small : TSphere = (cx: 7; cy: 7; cz: -10; r: 15);
 type RecordLiteralExpression struct {
-	TypeName *Identifier
+	TypeName *Identifier `ast:"optional"`
 	Fields   []*FieldInitializer
 	BaseNode
 }
@@ -0,0 +1,41 @@
+package ast
+
+import "fmt"
+
+// RewriteError describes an invalid replacement returned by a Rewrite
+// callback. Rewrite panics with a *RewriteError value.
+type RewriteError struct {
+	// Replacement is the node the callback returned, or nil.
+	Replacement Node
+	// Field names the field the replacement was meant for, as
+	// "NodeType.FieldName".
+	Field string
+}
+
+// Error implements the error interface.
+func (e *RewriteError) Error() string {
+	if e.Replacement == nil {
+		return fmt.Sprintf("ast.Rewrite: %s cannot be deleted", e.Field)
+	}
+	return fmt.Sprintf("ast.Rewrite: cannot use %T as %s", e.Replacement, e.Field)
+}
+
+// rewriteField converts the replacement for a required single-node field to
+// the field's type T. Optional fields are cleared by a nil replacement
+// instead, so their generated code calls rewriteElement directly.
+func rewriteField[T Node](r Node, field string) T {
+	if r == nil {
+		panic(&RewriteError{Field: field})
+	}
+	return rewriteElement[T](r, field)
+}
+
+// rewriteElement converts the non-nil replacement for a slice element or an
+// optional field to the element or field type T.
+func rewriteElement[T Node](r Node, field string) T {
+	t, ok := r.(T)
+	if !ok {
+		panic(&RewriteError{Field: field, Replacement: r})
+	}
+	return t
+}
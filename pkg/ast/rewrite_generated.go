@@ -0,0 +1,1808 @@
+// Code generated by cmd/gen-visitor/main.go. DO NOT EDIT.
+
+package ast
+
+// Rewrite traverses an AST in depth-first order, starting at the given node,
+// and replaces each node with the result of calling fn on it. Children are
+// rewritten before their parent, so fn sees a node whose children have
+// already been replaced, and the result of fn is not traversed again.
+// Rewrite returns fn's result for node itself.
+//
+// Nodes are updated in place. When fn returns nil for an element of a slice
+// field, the element is deleted, and when it returns nil for an optional
+// single-node field (one tagged ast:"optional", such as IfStatement.Alternative),
+// the field is cleared. Any other replacement must be assignable to the static
+// type of the field it is stored in; otherwise, or when fn returns nil for a
+// required single-node field, Rewrite panics with a *RewriteError.
+//
+// This function is automatically generated from AST node definitions.
+// To regenerate, run: go generate ./pkg/ast
+func Rewrite(node Node, fn func(Node) Node) Node {
+	if node == nil {
+		return nil
+	}
+
+	// Rewrite children based on node type
+	switch n := node.(type) {
+	case *AddressOfExpression:
+		rewriteAddressOfExpression(n, fn)
+	case *ArrayDecl:
+		rewriteArrayDecl(n, fn)
+	case *ArrayLiteralExpression:
+		rewriteArrayLiteralExpression(n, fn)
+	case *ArrayTypeNode:
+		rewriteArrayTypeNode(n, fn)
+	case *AsExpression:
+		rewriteAsExpression(n, fn)
+	case *AssignmentStatement:
+		rewriteAssignmentStatement(n, fn)
+	case *BinaryExpression:
+		rewriteBinaryExpression(n, fn)
+	case *BlockStatement:
+		rewriteBlockStatement(n, fn)
+	case *BooleanLiteral:
+		rewriteBooleanLiteral(n, fn)
+	case *BreakStatement:
+		rewriteBreakStatement(n, fn)
+	case *CallExpression:
+		rewriteCallExpression(n, fn)
+	case *CaseBranch:
+		rewriteCaseBranch(n, fn)
+	case *CaseStatement:
+		rewriteCaseStatement(n, fn)
+	case *CharLiteral:
+		rewriteCharLiteral(n, fn)
+	case *ClassDecl:
+		rewriteClassDecl(n, fn)
+	case *ClassOfTypeNode:
+		rewriteClassOfTypeNode(n, fn)
+	case *Condition:
+		rewriteCondition(n, fn)
+	case *ConstDecl:
+		rewriteConstDecl(n, fn)
+	case *ContinueStatement:
+		rewriteContinueStatement(n, fn)
+	case *EmptyStatement:
+		rewriteEmptyStatement(n, fn)
+	case *EnumDecl:
+		rewriteEnumDecl(n, fn)
+	case *ExceptClause:
+		rewriteExceptClause(n, fn)
+	case *ExceptionHandler:
+		rewriteExceptionHandler(n, fn)
+	case *ExitStatement:
+		rewriteExitStatement(n, fn)
+	case *ExpressionStatement:
+		rewriteExpressionStatement(n, fn)
+	case *FieldDecl:
+		rewriteFieldDecl(n, fn)
+	case *FieldInitializer:
+		rewriteFieldInitializer(n, fn)
+	case *FinallyClause:
+		rewriteFinallyClause(n, fn)
+	case *FloatLiteral:
+		rewriteFloatLiteral(n, fn)
+	case *ForInStatement:
+		rewriteForInStatement(n, fn)
+	case *ForStatement:
+		rewriteForStatement(n, fn)
+	case *FunctionDecl:
+		rewriteFunctionDecl(n, fn)
+	case *FunctionPointerTypeNode:
+		rewriteFunctionPointerTypeNode(n, fn)
+	case *GenericTypeRef:
+		rewriteGenericTypeRef(n, fn)
+	case *GroupedExpression:
+		rewriteGroupedExpression(n, fn)
+	case *HelperDecl:
+		rewriteHelperDecl(n, fn)
+	case *Identifier:
+		rewriteIdentifier(n, fn)
+	case *IfExpression:
+		rewriteIfExpression(n, fn)
+	case *IfStatement:
+		rewriteIfStatement(n, fn)
+	case *ImplementsExpression:
+		rewriteImplementsExpression(n, fn)
+	case *IndexExpression:
+		rewriteIndexExpression(n, fn)
+	case *InheritedExpression:
+		rewriteInheritedExpression(n, fn)
+	case *IntegerLiteral:
+		rewriteIntegerLiteral(n, fn)
+	case *InterfaceDecl:
+		rewriteInterfaceDecl(n, fn)
+	case *InterfaceMethodDecl:
+		rewriteInterfaceMethodDecl(n, fn)
+	case *InvalidExpression:
+		rewriteInvalidExpression(n, fn)
+	case *InvalidStatement:
+		rewriteInvalidStatement(n, fn)
+	case *InvalidTypeExpression:
+		rewriteInvalidTypeExpression(n, fn)
+	case *InvariantClause:
+		rewriteInvariantClause(n, fn)
+	case *IsExpression:
+		rewriteIsExpression(n, fn)
+	case *LambdaExpression:
+		rewriteLambdaExpression(n, fn)
+	case *MemberAccessExpression:
+		rewriteMemberAccessExpression(n, fn)
+	case *MethodCallExpression:
+		rewriteMethodCallExpression(n, fn)
+	case *NewArrayExpression:
+		rewriteNewArrayExpression(n, fn)
+	case *NewExpression:
+		rewriteNewExpression(n, fn)
+	case *NilLiteral:
+		rewriteNilLiteral(n, fn)
+	case *OldExpression:
+		rewriteOldExpression(n, fn)
+	case *OperatorDecl:
+		rewriteOperatorDecl(n, fn)
+	case *Parameter:
+		rewriteParameter(n, fn)
+	case *PostConditions:
+		rewritePostConditions(n, fn)
+	case *PreConditions:
+		rewritePreConditions(n, fn)
+	case *Program:
+		rewriteProgram(n, fn)
+	case *PropertyDecl:
+		rewritePropertyDecl(n, fn)
+	case *RaiseStatement:
+		rewriteRaiseStatement(n, fn)
+	case *RangeExpression:
+		rewriteRangeExpression(n, fn)
+	case *RecordDecl:
+		rewriteRecordDecl(n, fn)
+	case *RecordLiteralExpression:
+		rewriteRecordLiteralExpression(n, fn)
+	case *RecordPropertyDecl:
+		rewriteRecordPropertyDecl(n, fn)
+	case *RecordTypeNode:
+		rewriteRecordTypeNode(n, fn)
+	case *RepeatStatement:
+		rewriteRepeatStatement(n, fn)
+	case *ReturnStatement:
+		rewriteReturnStatement(n, fn)
+	case *SelfExpression:
+		rewriteSelfExpression(n, fn)
+	case *SetDecl:
+		rewriteSetDecl(n, fn)
+	case *SetLiteral:
+		rewriteSetLiteral(n, fn)
+	case *SetTypeNode:
+		rewriteSetTypeNode(n, fn)
+	case *StringLiteral:
+		rewriteStringLiteral(n, fn)
+	case *TryStatement:
+		rewriteTryStatement(n, fn)
+	case *TypeAnnotation:
+		rewriteTypeAnnotation(n, fn)
+	case *TypeDeclaration:
+		rewriteTypeDeclaration(n, fn)
+	case *UnaryExpression:
+		rewriteUnaryExpression(n, fn)
+	case *UnitDeclaration:
+		rewriteUnitDeclaration(n, fn)
+	case *UsesClause:
+		rewriteUsesClause(n, fn)
+	case *VarDeclStatement:
+		rewriteVarDeclStatement(n, fn)
+	case *WhileStatement:
+		rewriteWhileStatement(n, fn)
+	case *WithStatement:
+		rewriteWithStatement(n, fn)
+	}
+	return fn(node)
+}
+
+// rewriteAddressOfExpression rewrites the children of a AddressOfExpression node
+func rewriteAddressOfExpression(n *AddressOfExpression, fn func(Node) Node) {
+	if n.Operator != nil {
+		n.Operator = rewriteField[Expression](Rewrite(n.Operator, fn), "AddressOfExpression.Operator")
+	}
+}
+
+// rewriteArrayDecl rewrites the children of a ArrayDecl node
+func rewriteArrayDecl(n *ArrayDecl, fn func(Node) Node) {
+	if n.Name != nil {
+		n.Name = rewriteField[*Identifier](Rewrite(n.Name, fn), "ArrayDecl.Name")
+	}
+}
+
+// rewriteArrayLiteralExpression rewrites the children of a ArrayLiteralExpression node
+func rewriteArrayLiteralExpression(n *ArrayLiteralExpression, fn func(Node) Node) {
+	if n.Elements != nil {
+		kept := n.Elements[:0]
+		for _, item := range n.Elements {
+			if item == nil {
+				kept = append(kept, item)
+			} else if r := Rewrite(item, fn); r != nil {
+				kept = append(kept, rewriteElement[Expression](r, "ArrayLiteralExpression.Elements"))
+			}
+		}
+		n.Elements = kept
+	}
+}
+
+// rewriteArrayTypeNode rewrites the children of a ArrayTypeNode node
+func rewriteArrayTypeNode(n *ArrayTypeNode, fn func(Node) Node) {
+	if n.ElementType != nil {
+		n.ElementType = rewriteField[TypeExpression](Rewrite(n.ElementType, fn), "ArrayTypeNode.ElementType")
+	}
+	if n.LowBound != nil {
+		if r := Rewrite(n.LowBound, fn); r != nil {
+			n.LowBound = rewriteElement[Expression](r, "ArrayTypeNode.LowBound")
+		} else {
+			n.LowBound = nil
+		}
+	}
+	if n.HighBound != nil {
+		if r := Rewrite(n.HighBound, fn); r != nil {
+			n.HighBound = rewriteElement[Expression](r, "ArrayTypeNode.HighBound")
+		} else {
+			n.HighBound = nil
+		}
+	}
+	if n.IndexType != nil {
+		if r := Rewrite(n.IndexType, fn); r != nil {
+			n.IndexType = rewriteElement[TypeExpression](r, "ArrayTypeNode.IndexType")
+		} else {
+			n.IndexType = nil
+		}
+	}
+}
+
+// rewriteAsExpression rewrites the children of a AsExpression node
+func rewriteAsExpression(n *AsExpression, fn func(Node) Node) {
+	if n.Left != nil {
+		n.Left = rewriteField[Expression](Rewrite(n.Left, fn), "AsExpression.Left")
+	}
+	if n.TargetType != nil {
+		n.TargetType = rewriteField[TypeExpression](Rewrite(n.TargetType, fn), "AsExpression.TargetType")
+	}
+}
+
+// rewriteAssignmentStatement rewrites the children of a AssignmentStatement node
+func rewriteAssignmentStatement(n *AssignmentStatement, fn func(Node) Node) {
+	if n.Target != nil {
+		n.Target = rewriteField[Expression](Rewrite(n.Target, fn), "AssignmentStatement.Target")
+	}
+	if n.Value != nil {
+		n.Value = rewriteField[Expression](Rewrite(n.Value, fn), "AssignmentStatement.Value")
+	}
+}
+
+// rewriteBinaryExpression rewrites the children of a BinaryExpression node
+func rewriteBinaryExpression(n *BinaryExpression, fn func(Node) Node) {
+	if n.Left != nil {
+		n.Left = rewriteField[Expression](Rewrite(n.Left, fn), "BinaryExpression.Left")
+	}
+	if n.Right != nil {
+		n.Right = rewriteField[Expression](Rewrite(n.Right, fn), "BinaryExpression.Right")
+	}
+}
+
+// rewriteBlockStatement rewrites the children of a BlockStatement node
+func rewriteBlockStatement(n *BlockStatement, fn func(Node) Node) {
+	if n.Statements != nil {
+		kept := n.Statements[:0]
+		for _, item := range n.Statements {
+			if item == nil {
+				kept = append(kept, item)
+			} else if r := Rewrite(item, fn); r != nil {
+				kept = append(kept, rewriteElement[Statement](r, "BlockStatement.Statements"))
+			}
+		}
+		n.Statements = kept
+	}
+}
+
+// rewriteBooleanLiteral rewrites the children of a BooleanLiteral node
+func rewriteBooleanLiteral(_ *BooleanLiteral, _ func(Node) Node) {
+	// No children to rewrite
+}
+
+// rewriteBreakStatement rewrites the children of a BreakStatement node
+func rewriteBreakStatement(_ *BreakStatement, _ func(Node) Node) {
+	// No children to rewrite
+}
+
+// rewriteCallExpression rewrites the children of a CallExpression node
+func rewriteCallExpression(n *CallExpression, fn func(Node) Node) {
+	if n.Function != nil {
+		n.Function = rewriteField[Expression](Rewrite(n.Function, fn), "CallExpression.Function")
+	}
+	if n.Arguments != nil {
+		kept := n.Arguments[:0]
+		for _, item := range n.Arguments {
+			if item == nil {
+				kept = append(kept, item)
+			} else if r := Rewrite(item, fn); r != nil {
+				kept = append(kept, rewriteElement[Expression](r, "CallExpression.Arguments"))
+			}
+		}
+		n.Arguments = kept
+	}
+}
+
+// rewriteCaseBranch rewrites the children of a CaseBranch node
+func rewriteCaseBranch(n *CaseBranch, fn func(Node) Node) {
+	if n.Statement != nil {
+		n.Statement = rewriteField[Statement](Rewrite(n.Statement, fn), "CaseBranch.Statement")
+	}
+	if n.Values != nil {
+		kept := n.Values[:0]
+		for _, item := range n.Values {
+			if item == nil {
+				kept = append(kept, item)
+			} else if r := Rewrite(item, fn); r != nil {
+				kept = append(kept, rewriteElement[Expression](r, "CaseBranch.Values"))
+			}
+		}
+		n.Values = kept
+	}
+}
+
+// rewriteCaseStatement rewrites the children of a CaseStatement node
+func rewriteCaseStatement(n *CaseStatement, fn func(Node) Node) {
+	if n.Expression != nil {
+		n.Expression = rewriteField[Expression](Rewrite(n.Expression, fn), "CaseStatement.Expression")
+	}
+	if n.Else != nil {
+		if r := Rewrite(n.Else, fn); r != nil {
+			n.Else = rewriteElement[Statement](r, "CaseStatement.Else")
+		} else {
+			n.Else = nil
+		}
+	}
+	if n.Cases != nil {
+		kept := n.Cases[:0]
+		for _, item := range n.Cases {
+			if item == nil {
+				kept = append(kept, item)
+			} else if r := Rewrite(item, fn); r != nil {
+				kept = append(kept, rewriteElement[*CaseBranch](r, "CaseStatement.Cases"))
+			}
+		}
+		n.Cases = kept
+	}
+}
+
+// rewriteCharLiteral rewrites the children of a CharLiteral node
+func rewriteCharLiteral(_ *CharLiteral, _ func(Node) Node) {
+	// No children to rewrite
+}
+
+// rewriteClassDecl rewrites the children of a ClassDecl node
+func rewriteClassDecl(n *ClassDecl, fn func(Node) Node) {
+	if n.Constructor != nil {
+		if r := Rewrite(n.Constructor, fn); r != nil {
+			n.Constructor = rewriteElement[*FunctionDecl](r, "ClassDecl.Constructor")
+		} else {
+			n.Constructor = nil
+		}
+	}
+	if n.Name != nil {
+		n.Name = rewriteField[*Identifier](Rewrite(n.Name, fn), "ClassDecl.Name")
+	}
+	if n.EnclosingClass != nil {
+		if r := Rewrite(n.EnclosingClass, fn); r != nil {
+			n.EnclosingClass = rewriteElement[*Identifier](r, "ClassDecl.EnclosingClass")
+		} else {
+			n.EnclosingClass = nil
+		}
+	}
+	if n.Parent != nil {
+		if r := Rewrite(n.Parent, fn); r != nil {
+			n.Parent = rewriteElement[*Identifier](r, "ClassDecl.Parent")
+		} else {
+			n.Parent = nil
+		}
+	}
+	if n.Destructor != nil {
+		if r := Rewrite(n.Destructor, fn); r != nil {
+			n.Destructor = rewriteElement[*FunctionDecl](r, "ClassDecl.Destructor")
+		} else {
+			n.Destructor = nil
+		}
+	}
+	if n.Methods != nil {
+		kept := n.Methods[:0]
+		for _, item := range n.Methods {
+			if item == nil {
+				kept = append(kept, item)
+			} else if r := Rewrite(item, fn); r != nil {
+				kept = append(kept, rewriteElement[*FunctionDecl](r, "ClassDecl.Methods"))
+			}
+		}
+		n.Methods = kept
+	}
+	if n.Interfaces != nil {
+		kept := n.Interfaces[:0]
+		for _, item := range n.Interfaces {
+			if item == nil {
+				kept = append(kept, item)
+			} else if r := Rewrite(item, fn); r != nil {
+				kept = append(kept, rewriteElement[*Identifier](r, "ClassDecl.Interfaces"))
+			}
+		}
+		n.Interfaces = kept
+	}
+	if n.Operators != nil {
+		kept := n.Operators[:0]
+		for _, item := range n.Operators {
+			if item == nil {
+				kept = append(kept, item)
+			} else if r := Rewrite(item, fn); r != nil {
+				kept = append(kept, rewriteElement[*OperatorDecl](r, "ClassDecl.Operators"))
+			}
+		}
+		n.Operators = kept
+	}
+	if n.Fields != nil {
+		kept := n.Fields[:0]
+		for _, item := range n.Fields {
+			if item == nil {
+				kept = append(kept, item)
+			} else if r := Rewrite(item, fn); r != nil {
+				kept = append(kept, rewriteElement[*FieldDecl](r, "ClassDecl.Fields"))
+			}
+		}
+		n.Fields = kept
+	}
+	if n.Constants != nil {
+		kept := n.Constants[:0]
+		for _, item := range n.Constants {
+			if item == nil {
+				kept = append(kept, item)
+			} else if r := Rewrite(item, fn); r != nil {
+				kept = append(kept, rewriteElement[*ConstDecl](r, "ClassDecl.Constants"))
+			}
+		}
+		n.Constants = kept
+	}
+	if n.NestedTypes != nil {
+		kept := n.NestedTypes[:0]
+		for _, item := range n.NestedTypes {
+			if item == nil {
+				kept = append(kept, item)
+			} else if r := Rewrite(item, fn); r != nil {
+				kept = append(kept, rewriteElement[Statement](r, "ClassDecl.NestedTypes"))
+			}
+		}
+		n.NestedTypes = kept
+	}
+	if n.Properties != nil {
+		kept := n.Properties[:0]
+		for _, item := range n.Properties {
+			if item == nil {
+				kept = append(kept, item)
+			} else if r := Rewrite(item, fn); r != nil {
+				kept = append(kept, rewriteElement[*PropertyDecl](r, "ClassDecl.Properties"))
+			}
+		}
+		n.Properties = kept
+	}
+}
+
+// rewriteClassOfTypeNode rewrites the children of a ClassOfTypeNode node
+func rewriteClassOfTypeNode(n *ClassOfTypeNode, fn func(Node) Node) {
+	if n.ClassType != nil {
+		n.ClassType = rewriteField[TypeExpression](Rewrite(n.ClassType, fn), "ClassOfTypeNode.ClassType")
+	}
+}
+
+// rewriteCondition rewrites the children of a Condition node
+func rewriteCondition(n *Condition, fn func(Node) Node) {
+	if n.Test != nil {
+		n.Test = rewriteField[Expression](Rewrite(n.Test, fn), "Condition.Test")
+	}
+	if n.Message != nil {
+		if r := Rewrite(n.Message, fn); r != nil {
+			n.Message = rewriteElement[Expression](r, "Condition.Message")
+		} else {
+			n.Message = nil
+		}
+	}
+}
+
+// rewriteConstDecl rewrites the children of a ConstDecl node
+func rewriteConstDecl(n *ConstDecl, fn func(Node) Node) {
+	if n.Value != nil {
+		n.Value = rewriteField[Expression](Rewrite(n.Value, fn), "ConstDecl.Value")
+	}
+	if n.Type != nil {
+		if r := Rewrite(n.Type, fn); r != nil {
+			n.Type = rewriteElement[TypeExpression](r, "ConstDecl.Type")
+		} else {
+			n.Type = nil
+		}
+	}
+	if n.Name != nil {
+		n.Name = rewriteField[*Identifier](Rewrite(n.Name, fn), "ConstDecl.Name")
+	}
+}
+
+// rewriteContinueStatement rewrites the children of a ContinueStatement node
+func rewriteContinueStatement(_ *ContinueStatement, _ func(Node) Node) {
+	// No children to rewrite
+}
+
+// rewriteEmptyStatement rewrites the children of a EmptyStatement node
+func rewriteEmptyStatement(_ *EmptyStatement, _ func(Node) Node) {
+	// No children to rewrite
+}
+
+// rewriteEnumDecl rewrites the children of a EnumDecl node
+func rewriteEnumDecl(n *EnumDecl, fn func(Node) Node) {
+	if n.Name != nil {
+		n.Name = rewriteField[*Identifier](Rewrite(n.Name, fn), "EnumDecl.Name")
+	}
+}
+
+// rewriteExceptClause rewrites the children of a ExceptClause node
+func rewriteExceptClause(n *ExceptClause, fn func(Node) Node) {
+	if n.ElseBlock != nil {
+		if r := Rewrite(n.ElseBlock, fn); r != nil {
+			n.ElseBlock = rewriteElement[*BlockStatement](r, "ExceptClause.ElseBlock")
+		} else {
+			n.ElseBlock = nil
+		}
+	}
+	if n.Handlers != nil {
+		kept := n.Handlers[:0]
+		for _, item := range n.Handlers {
+			if item == nil {
+				kept = append(kept, item)
+			} else if r := Rewrite(item, fn); r != nil {
+				kept = append(kept, rewriteElement[*ExceptionHandler](r, "ExceptClause.Handlers"))
+			}
+		}
+		n.Handlers = kept
+	}
+}
+
+// rewriteExceptionHandler rewrites the children of a ExceptionHandler node
+func rewriteExceptionHandler(n *ExceptionHandler, fn func(Node) Node) {
+	if n.Statement != nil {
+		n.Statement = rewriteField[Statement](Rewrite(n.Statement, fn), "ExceptionHandler.Statement")
+	}
+	if n.Variable != nil {
+		if r := Rewrite(n.Variable, fn); r != nil {
+			n.Variable = rewriteElement[*Identifier](r, "ExceptionHandler.Variable")
+		} else {
+			n.Variable = nil
+		}
+	}
+	if n.ExceptionType != nil {
+		n.ExceptionType = rewriteField[TypeExpression](Rewrite(n.ExceptionType, fn), "ExceptionHandler.ExceptionType")
+	}
+}
+
+// rewriteExitStatement rewrites the children of a ExitStatement node
+func rewriteExitStatement(n *ExitStatement, fn func(Node) Node) {
+	if n.ReturnValue != nil {
+		if r := Rewrite(n.ReturnValue, fn); r != nil {
+			n.ReturnValue = rewriteElement[Expression](r, "ExitStatement.ReturnValue")
+		} else {
+			n.ReturnValue = nil
+		}
+	}
+}
+
+// rewriteExpressionStatement rewrites the children of a ExpressionStatement node
+func rewriteExpressionStatement(n *ExpressionStatement, fn func(Node) Node) {
+	if n.Expression != nil {
+		n.Expression = rewriteField[Expression](Rewrite(n.Expression, fn), "ExpressionStatement.Expression")
+	}
+}
+
+// rewriteFieldDecl rewrites the children of a FieldDecl node
+func rewriteFieldDecl(n *FieldDecl, fn func(Node) Node) {
+	if n.Type != nil {
+		if r := Rewrite(n.Type, fn); r != nil {
+			n.Type = rewriteElement[TypeExpression](r, "FieldDecl.Type")
+		} else {
+			n.Type = nil
+		}
+	}
+	if n.InitValue != nil {
+		if r := Rewrite(n.InitValue, fn); r != nil {
+			n.InitValue = rewriteElement[Expression](r, "FieldDecl.InitValue")
+		} else {
+			n.InitValue = nil
+		}
+	}
+	if n.Name != nil {
+		n.Name = rewriteField[*Identifier](Rewrite(n.Name, fn), "FieldDecl.Name")
+	}
+}
+
+// rewriteFieldInitializer rewrites the children of a FieldInitializer node
+func rewriteFieldInitializer(n *FieldInitializer, fn func(Node) Node) {
+	if n.Value != nil {
+		n.Value = rewriteField[Expression](Rewrite(n.Value, fn), "FieldInitializer.Value")
+	}
+	if n.Name != nil {
+		n.Name = rewriteField[*Identifier](Rewrite(n.Name, fn), "FieldInitializer.Name")
+	}
+}
+
+// rewriteFinallyClause rewrites the children of a FinallyClause node
+func rewriteFinallyClause(n *FinallyClause, fn func(Node) Node) {
+	if n.Block != nil {
+		n.Block = rewriteField[*BlockStatement](Rewrite(n.Block, fn), "FinallyClause.Block")
+	}
+}
+
+// rewriteFloatLiteral rewrites the children of a FloatLiteral node
+func rewriteFloatLiteral(_ *FloatLiteral, _ func(Node) Node) {
+	// No children to rewrite
+}
+
+// rewriteForInStatement rewrites the children of a ForInStatement node
+func rewriteForInStatement(n *ForInStatement, fn func(Node) Node) {
+	if n.Collection != nil {
+		n.Collection = rewriteField[Expression](Rewrite(n.Collection, fn), "ForInStatement.Collection")
+	}
+	if n.Body != nil {
+		n.Body = rewriteField[Statement](Rewrite(n.Body, fn), "ForInStatement.Body")
+	}
+	if n.Step != nil {
+		if r := Rewrite(n.Step, fn); r != nil {
+			n.Step = rewriteElement[Expression](r, "ForInStatement.Step")
+		} else {
+			n.Step = nil
+		}
+	}
+	if n.Variable != nil {
+		n.Variable = rewriteField[*Identifier](Rewrite(n.Variable, fn), "ForInStatement.Variable")
+	}
+}
+
+// rewriteForStatement rewrites the children of a ForStatement node
+func rewriteForStatement(n *ForStatement, fn func(Node) Node) {
+	if n.Start != nil {
+		n.Start = rewriteField[Expression](Rewrite(n.Start, fn), "ForStatement.Start")
+	}
+	if n.EndValue != nil {
+		n.EndValue = rewriteField[Expression](Rewrite(n.EndValue, fn), "ForStatement.EndValue")
+	}
+	if n.Body != nil {
+		n.Body = rewriteField[Statement](Rewrite(n.Body, fn), "ForStatement.Body")
+	}
+	if n.Step != nil {
+		if r := Rewrite(n.Step, fn); r != nil {
+			n.Step = rewriteElement[Expression](r, "ForStatement.Step")
+		} else {
+			n.Step = nil
+		}
+	}
+	if n.Variable != nil {
+		n.Variable = rewriteField[*Identifier](Rewrite(n.Variable, fn), "ForStatement.Variable")
+	}
+}
+
+// rewriteFunctionDecl rewrites the children of a FunctionDecl node
+func rewriteFunctionDecl(n *FunctionDecl, fn func(Node) Node) {
+	if n.ReturnType != nil {
+		if r := Rewrite(n.ReturnType, fn); r != nil {
+			n.ReturnType = rewriteElement[TypeExpression](r, "FunctionDecl.ReturnType")
+		} else {
+			n.ReturnType = nil
+		}
+	}
+	if n.Name != nil {
+		n.Name = rewriteField[*Identifier](Rewrite(n.Name, fn), "FunctionDecl.Name")
+	}
+	if n.ClassName != nil {
+		if r := Rewrite(n.ClassName, fn); r != nil {
+			n.ClassName = rewriteElement[*Identifier](r, "FunctionDecl.ClassName")
+		} else {
+			n.ClassName = nil
+		}
+	}
+	if n.HelperName != nil {
+		if r := Rewrite(n.HelperName, fn); r != nil {
+			n.HelperName = rewriteElement[*Identifier](r, "FunctionDecl.HelperName")
+		} else {
+			n.HelperName = nil
+		}
+	}
+	if n.Body != nil {
+		if r := Rewrite(n.Body, fn); r != nil {
+			n.Body = rewriteElement[*BlockStatement](r, "FunctionDecl.Body")
+		} else {
+			n.Body = nil
+		}
+	}
+	if n.PreConditions != nil {
+		if r := Rewrite(n.PreConditions, fn); r != nil {
+			n.PreConditions = rewriteElement[*PreConditions](r, "FunctionDecl.PreConditions")
+		} else {
+			n.PreConditions = nil
+		}
+	}
+	if n.PostConditions != nil {
+		if r := Rewrite(n.PostConditions, fn); r != nil {
+			n.PostConditions = rewriteElement[*PostConditions](r, "FunctionDecl.PostConditions")
+		} else {
+			n.PostConditions = nil
+		}
+	}
+	if n.Parameters != nil {
+		kept := n.Parameters[:0]
+		for _, item := range n.Parameters {
+			if item == nil {
+				kept = append(kept, item)
+			} else if r := Rewrite(item, fn); r != nil {
+				kept = append(kept, rewriteElement[*Parameter](r, "FunctionDecl.Parameters"))
+			}
+		}
+		n.Parameters = kept
+	}
+}
+
+// rewriteFunctionPointerTypeNode rewrites the children of a FunctionPointerTypeNode node
+func rewriteFunctionPointerTypeNode(n *FunctionPointerTypeNode, fn func(Node) Node) {
+	if n.Parameters != nil {
+		kept := n.Parameters[:0]
+		for _, item := range n.Parameters {
+			if item == nil {
+				kept = append(kept, item)
+			} else if r := Rewrite(item, fn); r != nil {
+				kept = append(kept, rewriteElement[*Parameter](r, "FunctionPointerTypeNode.Parameters"))
+			}
+		}
+		n.Parameters = kept
+	}
+	if n.ReturnType != nil {
+		if r := Rewrite(n.ReturnType, fn); r != nil {
+			n.ReturnType = rewriteElement[TypeExpression](r, "FunctionPointerTypeNode.ReturnType")
+		} else {
+			n.ReturnType = nil
+		}
+	}
+}
+
+// rewriteGenericTypeRef rewrites the children of a GenericTypeRef node
+func rewriteGenericTypeRef(n *GenericTypeRef, fn func(Node) Node) {
+	if n.Base != nil {
+		n.Base = rewriteField[*Identifier](Rewrite(n.Base, fn), "GenericTypeRef.Base")
+	}
+	if n.TypeArgs != nil {
+		kept := n.TypeArgs[:0]
+		for _, item := range n.TypeArgs {
+			if item == nil {
+				kept = append(kept, item)
+			} else if r := Rewrite(item, fn); r != nil {
+				kept = append(kept, rewriteElement[TypeExpression](r, "GenericTypeRef.TypeArgs"))
+			}
+		}
+		n.TypeArgs = kept
+	}
+}
+
+// rewriteGroupedExpression rewrites the children of a GroupedExpression node
+func rewriteGroupedExpression(n *GroupedExpression, fn func(Node) Node) {
+	if n.Expression != nil {
+		n.Expression = rewriteField[Expression](Rewrite(n.Expression, fn), "GroupedExpression.Expression")
+	}
+}
+
+// rewriteHelperDecl rewrites the children of a HelperDecl node
+func rewriteHelperDecl(n *HelperDecl, fn func(Node) Node) {
+	if n.ForType != nil {
+		n.ForType = rewriteField[TypeExpression](Rewrite(n.ForType, fn), "HelperDecl.ForType")
+	}
+	if n.Name != nil {
+		n.Name = rewriteField[*Identifier](Rewrite(n.Name, fn), "HelperDecl.Name")
+	}
+	if n.ParentHelper != nil {
+		if r := Rewrite(n.ParentHelper, fn); r != nil {
+			n.ParentHelper = rewriteElement[*Identifier](r, "HelperDecl.ParentHelper")
+		} else {
+			n.ParentHelper = nil
+		}
+	}
+	if n.Methods != nil {
+		kept := n.Methods[:0]
+		for _, item := range n.Methods {
+			if item == nil {
+				kept = append(kept, item)
+			} else if r := Rewrite(item, fn); r != nil {
+				kept = append(kept, rewriteElement[*FunctionDecl](r, "HelperDecl.Methods"))
+			}
+		}
+		n.Methods = kept
+	}
+	if n.Properties != nil {
+		kept := n.Properties[:0]
+		for _, item := range n.Properties {
+			if item == nil {
+				kept = append(kept, item)
+			} else if r := Rewrite(item, fn); r != nil {
+				kept = append(kept, rewriteElement[*PropertyDecl](r, "HelperDecl.Properties"))
+			}
+		}
+		n.Properties = kept
+	}
+	if n.ClassVars != nil {
+		kept := n.ClassVars[:0]
+		for _, item := range n.ClassVars {
+			if item == nil {
+				kept = append(kept, item)
+			} else if r := Rewrite(item, fn); r != nil {
+				kept = append(kept, rewriteElement[*FieldDecl](r, "HelperDecl.ClassVars"))
+			}
+		}
+		n.ClassVars = kept
+	}
+	if n.ClassConsts != nil {
+		kept := n.ClassConsts[:0]
+		for _, item := range n.ClassConsts {
+			if item == nil {
+				kept = append(kept, item)
+			} else if r := Rewrite(item, fn); r != nil {
+				kept = append(kept, rewriteElement[*ConstDecl](r, "HelperDecl.ClassConsts"))
+			}
+		}
+		n.ClassConsts = kept
+	}
+	if n.PrivateMembers != nil {
+		kept := n.PrivateMembers[:0]
+		for _, item := range n.PrivateMembers {
+			if item == nil {
+				kept = append(kept, item)
+			} else if r := Rewrite(item, fn); r != nil {
+				kept = append(kept, rewriteElement[Statement](r, "HelperDecl.PrivateMembers"))
+			}
+		}
+		n.PrivateMembers = kept
+	}
+	if n.PublicMembers != nil {
+		kept := n.PublicMembers[:0]
+		for _, item := range n.PublicMembers {
+			if item == nil {
+				kept = append(kept, item)
+			} else if r := Rewrite(item, fn); r != nil {
+				kept = append(kept, rewriteElement[Statement](r, "HelperDecl.PublicMembers"))
+			}
+		}
+		n.PublicMembers = kept
+	}
+}
+
+// rewriteIdentifier rewrites the children of a Identifier node
+func rewriteIdentifier(_ *Identifier, _ func(Node) Node) {
+	// No children to rewrite
+}
+
+// rewriteIfExpression rewrites the children of a IfExpression node
+func rewriteIfExpression(n *IfExpression, fn func(Node) Node) {
+	if n.Condition != nil {
+		n.Condition = rewriteField[Expression](Rewrite(n.Condition, fn), "IfExpression.Condition")
+	}
+	if n.Consequence != nil {
+		n.Consequence = rewriteField[Expression](Rewrite(n.Consequence, fn), "IfExpression.Consequence")
+	}
+	if n.Alternative != nil {
+		if r := Rewrite(n.Alternative, fn); r != nil {
+			n.Alternative = rewriteElement[Expression](r, "IfExpression.Alternative")
+		} else {
+			n.Alternative = nil
+		}
+	}
+}
+
+// rewriteIfStatement rewrites the children of a IfStatement node
+func rewriteIfStatement(n *IfStatement, fn func(Node) Node) {
+	if n.Condition != nil {
+		n.Condition = rewriteField[Expression](Rewrite(n.Condition, fn), "IfStatement.Condition")
+	}
+	if n.Consequence != nil {
+		n.Consequence = rewriteField[Statement](Rewrite(n.Consequence, fn), "IfStatement.Consequence")
+	}
+	if n.Alternative != nil {
+		if r := Rewrite(n.Alternative, fn); r != nil {
+			n.Alternative = rewriteElement[Statement](r, "IfStatement.Alternative")
+		} else {
+			n.Alternative = nil
+		}
+	}
+	if n.InlineVar != nil {
+		if r := Rewrite(n.InlineVar, fn); r != nil {
+			n.InlineVar = rewriteElement[*VarDeclStatement](r, "IfStatement.InlineVar")
+		} else {
+			n.InlineVar = nil
+		}
+	}
+}
+
+// rewriteImplementsExpression rewrites the children of a ImplementsExpression node
+func rewriteImplementsExpression(n *ImplementsExpression, fn func(Node) Node) {
+	if n.Left != nil {
+		n.Left = rewriteField[Expression](Rewrite(n.Left, fn), "ImplementsExpression.Left")
+	}
+	if n.TargetType != nil {
+		n.TargetType = rewriteField[TypeExpression](Rewrite(n.TargetType, fn), "ImplementsExpression.TargetType")
+	}
+}
+
+// rewriteIndexExpression rewrites the children of a IndexExpression node
+func rewriteIndexExpression(n *IndexExpression, fn func(Node) Node) {
+	if n.Left != nil {
+		n.Left = rewriteField[Expression](Rewrite(n.Left, fn), "IndexExpression.Left")
+	}
+	if n.Index != nil {
+		n.Index = rewriteField[Expression](Rewrite(n.Index, fn), "IndexExpression.Index")
+	}
+}
+
+// rewriteInheritedExpression rewrites the children of a InheritedExpression node
+func rewriteInheritedExpression(n *InheritedExpression, fn func(Node) Node) {
+	if n.Method != nil {
+		if r := Rewrite(n.Method, fn); r != nil {
+			n.Method = rewriteElement[*Identifier](r, "InheritedExpression.Method")
+		} else {
+			n.Method = nil
+		}
+	}
+	if n.Arguments != nil {
+		kept := n.Arguments[:0]
+		for _, item := range n.Arguments {
+			if item == nil {
+				kept = append(kept, item)
+			} else if r := Rewrite(item, fn); r != nil {
+				kept = append(kept, rewriteElement[Expression](r, "InheritedExpression.Arguments"))
+			}
+		}
+		n.Arguments = kept
+	}
+}
+
+// rewriteIntegerLiteral rewrites the children of a IntegerLiteral node
+func rewriteIntegerLiteral(_ *IntegerLiteral, _ func(Node) Node) {
+	// No children to rewrite
+}
+
+// rewriteInterfaceDecl rewrites the children of a InterfaceDecl node
+func rewriteInterfaceDecl(n *InterfaceDecl, fn func(Node) Node) {
+	if n.Name != nil {
+		n.Name = rewriteField[*Identifier](Rewrite(n.Name, fn), "InterfaceDecl.Name")
+	}
+	if n.Parent != nil {
+		if r := Rewrite(n.Parent, fn); r != nil {
+			n.Parent = rewriteElement[*Identifier](r, "InterfaceDecl.Parent")
+		} else {
+			n.Parent = nil
+		}
+	}
+	if n.Methods != nil {
+		kept := n.Methods[:0]
+		for _, item := range n.Methods {
+			if item == nil {
+				kept = append(kept, item)
+			} else if r := Rewrite(item, fn); r != nil {
+				kept = append(kept, rewriteElement[*InterfaceMethodDecl](r, "InterfaceDecl.Methods"))
+			}
+		}
+		n.Methods = kept
+	}
+	if n.Properties != nil {
+		kept := n.Properties[:0]
+		for _, item := range n.Properties {
+			if item == nil {
+				kept = append(kept, item)
+			} else if r := Rewrite(item, fn); r != nil {
+				kept = append(kept, rewriteElement[*PropertyDecl](r, "InterfaceDecl.Properties"))
+			}
+		}
+		n.Properties = kept
+	}
+}
+
+// rewriteInterfaceMethodDecl rewrites the children of a InterfaceMethodDecl node
+func rewriteInterfaceMethodDecl(n *InterfaceMethodDecl, fn func(Node) Node) {
+	if n.ReturnType != nil {
+		if r := Rewrite(n.ReturnType, fn); r != nil {
+			n.ReturnType = rewriteElement[TypeExpression](r, "InterfaceMethodDecl.ReturnType")
+		} else {
+			n.ReturnType = nil
+		}
+	}
+	if n.Name != nil {
+		n.Name = rewriteField[*Identifier](Rewrite(n.Name, fn), "InterfaceMethodDecl.Name")
+	}
+	if n.Parameters != nil {
+		kept := n.Parameters[:0]
+		for _, item := range n.Parameters {
+			if item == nil {
+				kept = append(kept, item)
+			} else if r := Rewrite(item, fn); r != nil {
+				kept = append(kept, rewriteElement[*Parameter](r, "InterfaceMethodDecl.Parameters"))
+			}
+		}
+		n.Parameters = kept
+	}
+}
+
+// rewriteInvalidExpression rewrites the children of a InvalidExpression node
+func rewriteInvalidExpression(_ *InvalidExpression, _ func(Node) Node) {
+	// No children to rewrite
+}
+
+// rewriteInvalidStatement rewrites the children of a InvalidStatement node
+func rewriteInvalidStatement(_ *InvalidStatement, _ func(Node) Node) {
+	// No children to rewrite
+}
+
+// rewriteInvalidTypeExpression rewrites the children of a InvalidTypeExpression node
+func rewriteInvalidTypeExpression(_ *InvalidTypeExpression, _ func(Node) Node) {
+	// No children to rewrite
+}
+
+// rewriteInvariantClause rewrites the children of a InvariantClause node
+func rewriteInvariantClause(n *InvariantClause, fn func(Node) Node) {
+	if n.Conditions != nil {
+		kept := n.Conditions[:0]
+		for _, item := range n.Conditions {
+			if item == nil {
+				kept = append(kept, item)
+			} else if r := Rewrite(item, fn); r != nil {
+				kept = append(kept, rewriteElement[*Condition](r, "InvariantClause.Conditions"))
+			}
+		}
+		n.Conditions = kept
+	}
+}
+
+// rewriteIsExpression rewrites the children of a IsExpression node
+func rewriteIsExpression(n *IsExpression, fn func(Node) Node) {
+	if n.Left != nil {
+		n.Left = rewriteField[Expression](Rewrite(n.Left, fn), "IsExpression.Left")
+	}
+	if n.TargetType != nil {
+		if r := Rewrite(n.TargetType, fn); r != nil {
+			n.TargetType = rewriteElement[TypeExpression](r, "IsExpression.TargetType")
+		} else {
+			n.TargetType = nil
+		}
+	}
+	if n.Right != nil {
+		if r := Rewrite(n.Right, fn); r != nil {
+			n.Right = rewriteElement[Expression](r, "IsExpression.Right")
+		} else {
+			n.Right = nil
+		}
+	}
+}
+
+// rewriteLambdaExpression rewrites the children of a LambdaExpression node
+func rewriteLambdaExpression(n *LambdaExpression, fn func(Node) Node) {
+	if n.ReturnType != nil {
+		if r := Rewrite(n.ReturnType, fn); r != nil {
+			n.ReturnType = rewriteElement[TypeExpression](r, "LambdaExpression.ReturnType")
+		} else {
+			n.ReturnType = nil
+		}
+	}
+	if n.Body != nil {
+		n.Body = rewriteField[*BlockStatement](Rewrite(n.Body, fn), "LambdaExpression.Body")
+	}
+	if n.Parameters != nil {
+		kept := n.Parameters[:0]
+		for _, item := range n.Parameters {
+			if item == nil {
+				kept = append(kept, item)
+			} else if r := Rewrite(item, fn); r != nil {
+				kept = append(kept, rewriteElement[*Parameter](r, "LambdaExpression.Parameters"))
+			}
+		}
+		n.Parameters = kept
+	}
+}
+
+// rewriteMemberAccessExpression rewrites the children of a MemberAccessExpression node
+func rewriteMemberAccessExpression(n *MemberAccessExpression, fn func(Node) Node) {
+	if n.Object != nil {
+		n.Object = rewriteField[Expression](Rewrite(n.Object, fn), "MemberAccessExpression.Object")
+	}
+	if n.Member != nil {
+		n.Member = rewriteField[*Identifier](Rewrite(n.Member, fn), "MemberAccessExpression.Member")
+	}
+}
+
+// rewriteMethodCallExpression rewrites the children of a MethodCallExpression node
+func rewriteMethodCallExpression(n *MethodCallExpression, fn func(Node) Node) {
+	if n.Object != nil {
+		n.Object = rewriteField[Expression](Rewrite(n.Object, fn), "MethodCallExpression.Object")
+	}
+	if n.Method != nil {
+		n.Method = rewriteField[*Identifier](Rewrite(n.Method, fn), "MethodCallExpression.Method")
+	}
+	if n.Arguments != nil {
+		kept := n.Arguments[:0]
+		for _, item := range n.Arguments {
+			if item == nil {
+				kept = append(kept, item)
+			} else if r := Rewrite(item, fn); r != nil {
+				kept = append(kept, rewriteElement[Expression](r, "MethodCallExpression.Arguments"))
+			}
+		}
+		n.Arguments = kept
+	}
+}
+
+// rewriteNewArrayExpression rewrites the children of a NewArrayExpression node
+func rewriteNewArrayExpression(n *NewArrayExpression, fn func(Node) Node) {
+	if n.ElementTypeName != nil {
+		n.ElementTypeName = rewriteField[*Identifier](Rewrite(n.ElementTypeName, fn), "NewArrayExpression.ElementTypeName")
+	}
+	if n.Dimensions != nil {
+		kept := n.Dimensions[:0]
+		for _, item := range n.Dimensions {
+			if item == nil {
+				kept = append(kept, item)
+			} else if r := Rewrite(item, fn); r != nil {
+				kept = append(kept, rewriteElement[Expression](r, "NewArrayExpression.Dimensions"))
+			}
+		}
+		n.Dimensions = kept
+	}
+}
+
+// rewriteNewExpression rewrites the children of a NewExpression node
+func rewriteNewExpression(n *NewExpression, fn func(Node) Node) {
+	if n.ClassName != nil {
+		if r := Rewrite(n.ClassName, fn); r != nil {
+			n.ClassName = rewriteElement[*Identifier](r, "NewExpression.ClassName")
+		} else {
+			n.ClassName = nil
+		}
+	}
+	if n.Operand != nil {
+		if r := Rewrite(n.Operand, fn); r != nil {
+			n.Operand = rewriteElement[Expression](r, "NewExpression.Operand")
+		} else {
+			n.Operand = nil
+		}
+	}
+	if n.Arguments != nil {
+		kept := n.Arguments[:0]
+		for _, item := range n.Arguments {
+			if item == nil {
+				kept = append(kept, item)
+			} else if r := Rewrite(item, fn); r != nil {
+				kept = append(kept, rewriteElement[Expression](r, "NewExpression.Arguments"))
+			}
+		}
+		n.Arguments = kept
+	}
+	if n.TypeArgs != nil {
+		kept := n.TypeArgs[:0]
+		for _, item := range n.TypeArgs {
+			if item == nil {
+				kept = append(kept, item)
+			} else if r := Rewrite(item, fn); r != nil {
+				kept = append(kept, rewriteElement[TypeExpression](r, "NewExpression.TypeArgs"))
+			}
+		}
+		n.TypeArgs = kept
+	}
+}
+
+// rewriteNilLiteral rewrites the children of a NilLiteral node
+func rewriteNilLiteral(_ *NilLiteral, _ func(Node) Node) {
+	// No children to rewrite
+}
+
+// rewriteOldExpression rewrites the children of a OldExpression node
+func rewriteOldExpression(n *OldExpression, fn func(Node) Node) {
+	if n.Identifier != nil {
+		n.Identifier = rewriteField[*Identifier](Rewrite(n.Identifier, fn), "OldExpression.Identifier")
+	}
+}
+
+// rewriteOperatorDecl rewrites the children of a OperatorDecl node
+func rewriteOperatorDecl(n *OperatorDecl, fn func(Node) Node) {
+	if n.ReturnType != nil {
+		n.ReturnType = rewriteField[TypeExpression](Rewrite(n.ReturnType, fn), "OperatorDecl.ReturnType")
+	}
+	if n.Binding != nil {
+		if r := Rewrite(n.Binding, fn); r != nil {
+			n.Binding = rewriteElement[*Identifier](r, "OperatorDecl.Binding")
+		} else {
+			n.Binding = nil
+		}
+	}
+	if n.OperandTypes != nil {
+		kept := n.OperandTypes[:0]
+		for _, item := range n.OperandTypes {
+			if item == nil {
+				kept = append(kept, item)
+			} else if r := Rewrite(item, fn); r != nil {
+				kept = append(kept, rewriteElement[TypeExpression](r, "OperatorDecl.OperandTypes"))
+			}
+		}
+		n.OperandTypes = kept
+	}
+}
+
+// rewriteParameter rewrites the children of a Parameter node
+func rewriteParameter(n *Parameter, fn func(Node) Node) {
+	if n.DefaultValue != nil {
+		if r := Rewrite(n.DefaultValue, fn); r != nil {
+			n.DefaultValue = rewriteElement[Expression](r, "Parameter.DefaultValue")
+		} else {
+			n.DefaultValue = nil
+		}
+	}
+	if n.Name != nil {
+		n.Name = rewriteField[*Identifier](Rewrite(n.Name, fn), "Parameter.Name")
+	}
+	if n.Type != nil {
+		if r := Rewrite(n.Type, fn); r != nil {
+			n.Type = rewriteElement[TypeExpression](r, "Parameter.Type")
+		} else {
+			n.Type = nil
+		}
+	}
+}
+
+// rewritePostConditions rewrites the children of a PostConditions node
+func rewritePostConditions(n *PostConditions, fn func(Node) Node) {
+	if n.Conditions != nil {
+		kept := n.Conditions[:0]
+		for _, item := range n.Conditions {
+			if item == nil {
+				kept = append(kept, item)
+			} else if r := Rewrite(item, fn); r != nil {
+				kept = append(kept, rewriteElement[*Condition](r, "PostConditions.Conditions"))
+			}
+		}
+		n.Conditions = kept
+	}
+}
+
+// rewritePreConditions rewrites the children of a PreConditions node
+func rewritePreConditions(n *PreConditions, fn func(Node) Node) {
+	if n.Conditions != nil {
+		kept := n.Conditions[:0]
+		for _, item := range n.Conditions {
+			if item == nil {
+				kept = append(kept, item)
+			} else if r := Rewrite(item, fn); r != nil {
+				kept = append(kept, rewriteElement[*Condition](r, "PreConditions.Conditions"))
+			}
+		}
+		n.Conditions = kept
+	}
+}
+
+// rewriteProgram rewrites the children of a Program node
+func rewriteProgram(n *Program, fn func(Node) Node) {
+	if n.Statements != nil {
+		kept := n.Statements[:0]
+		for _, item := range n.Statements {
+			if item == nil {
+				kept = append(kept, item)
+			} else if r := Rewrite(item, fn); r != nil {
+				kept = append(kept, rewriteElement[Statement](r, "Program.Statements"))
+			}
+		}
+		n.Statements = kept
+	}
+}
+
+// rewritePropertyDecl rewrites the children of a PropertyDecl node
+func rewritePropertyDecl(n *PropertyDecl, fn func(Node) Node) {
+	if n.ReadSpec != nil {
+		if r := Rewrite(n.ReadSpec, fn); r != nil {
+			n.ReadSpec = rewriteElement[Expression](r, "PropertyDecl.ReadSpec")
+		} else {
+			n.ReadSpec = nil
+		}
+	}
+	if n.WriteSpec != nil {
+		if r := Rewrite(n.WriteSpec, fn); r != nil {
+			n.WriteSpec = rewriteElement[Expression](r, "PropertyDecl.WriteSpec")
+		} else {
+			n.WriteSpec = nil
+		}
+	}
+	if n.WriteStmt != nil {
+		if r := Rewrite(n.WriteStmt, fn); r != nil {
+			n.WriteStmt = rewriteElement[Statement](r, "PropertyDecl.WriteStmt")
+		} else {
+			n.WriteStmt = nil
+		}
+	}
+	if n.Type != nil {
+		n.Type = rewriteField[TypeExpression](Rewrite(n.Type, fn), "PropertyDecl.Type")
+	}
+	if n.Name != nil {
+		n.Name = rewriteField[*Identifier](Rewrite(n.Name, fn), "PropertyDecl.Name")
+	}
+	if n.IndexParams != nil {
+		kept := n.IndexParams[:0]
+		for _, item := range n.IndexParams {
+			if item == nil {
+				kept = append(kept, item)
+			} else if r := Rewrite(item, fn); r != nil {
+				kept = append(kept, rewriteElement[*Parameter](r, "PropertyDecl.IndexParams"))
+			}
+		}
+		n.IndexParams = kept
+	}
+	if n.IndexValue != nil {
+		if r := Rewrite(n.IndexValue, fn); r != nil {
+			n.IndexValue = rewriteElement[Expression](r, "PropertyDecl.IndexValue")
+		} else {
+			n.IndexValue = nil
+		}
+	}
+}
+
+// rewriteRaiseStatement rewrites the children of a RaiseStatement node
+func rewriteRaiseStatement(n *RaiseStatement, fn func(Node) Node) {
+	if n.Exception != nil {
+		if r := Rewrite(n.Exception, fn); r != nil {
+			n.Exception = rewriteElement[Expression](r, "RaiseStatement.Exception")
+		} else {
+			n.Exception = nil
+		}
+	}
+}
+
+// rewriteRangeExpression rewrites the children of a RangeExpression node
+func rewriteRangeExpression(n *RangeExpression, fn func(Node) Node) {
+	if n.Start != nil {
+		n.Start = rewriteField[Expression](Rewrite(n.Start, fn), "RangeExpression.Start")
+	}
+	if n.RangeEnd != nil {
+		n.RangeEnd = rewriteField[Expression](Rewrite(n.RangeEnd, fn), "RangeExpression.RangeEnd")
+	}
+}
+
+// rewriteRecordDecl rewrites the children of a RecordDecl node
+func rewriteRecordDecl(n *RecordDecl, fn func(Node) Node) {
+	if n.Name != nil {
+		n.Name = rewriteField[*Identifier](Rewrite(n.Name, fn), "RecordDecl.Name")
+	}
+	if n.Fields != nil {
+		kept := n.Fields[:0]
+		for _, item := range n.Fields {
+			if item == nil {
+				kept = append(kept, item)
+			} else if r := Rewrite(item, fn); r != nil {
+				kept = append(kept, rewriteElement[*FieldDecl](r, "RecordDecl.Fields"))
+			}
+		}
+		n.Fields = kept
+	}
+	if n.Methods != nil {
+		kept := n.Methods[:0]
+		for _, item := range n.Methods {
+			if item == nil {
+				kept = append(kept, item)
+			} else if r := Rewrite(item, fn); r != nil {
+				kept = append(kept, rewriteElement[*FunctionDecl](r, "RecordDecl.Methods"))
+			}
+		}
+		n.Methods = kept
+	}
+	if n.Properties != nil {
+		kept := n.Properties[:0]
+		for i := range n.Properties {
+			if r := Rewrite(&n.Properties[i], fn); r != nil {
+				kept = append(kept, *rewriteElement[*RecordPropertyDecl](r, "RecordDecl.Properties"))
+			}
+		}
+		n.Properties = kept
+	}
+	if n.Constants != nil {
+		kept := n.Constants[:0]
+		for _, item := range n.Constants {
+			if item == nil {
+				kept = append(kept, item)
+			} else if r := Rewrite(item, fn); r != nil {
+				kept = append(kept, rewriteElement[*ConstDecl](r, "RecordDecl.Constants"))
+			}
+		}
+		n.Constants = kept
+	}
+	if n.ClassVars != nil {
+		kept := n.ClassVars[:0]
+		for _, item := range n.ClassVars {
+			if item == nil {
+				kept = append(kept, item)
+			} else if r := Rewrite(item, fn); r != nil {
+				kept = append(kept, rewriteElement[*FieldDecl](r, "RecordDecl.ClassVars"))
+			}
+		}
+		n.ClassVars = kept
+	}
+	if n.Operators != nil {
+		kept := n.Operators[:0]
+		for _, item := range n.Operators {
+			if item == nil {
+				kept = append(kept, item)
+			} else if r := Rewrite(item, fn); r != nil {
+				kept = append(kept, rewriteElement[*OperatorDecl](r, "RecordDecl.Operators"))
+			}
+		}
+		n.Operators = kept
+	}
+}
+
+// rewriteRecordLiteralExpression rewrites the children of a RecordLiteralExpression node
+func rewriteRecordLiteralExpression(n *RecordLiteralExpression, fn func(Node) Node) {
+	if n.TypeName != nil {
+		if r := Rewrite(n.TypeName, fn); r != nil {
+			n.TypeName = rewriteElement[*Identifier](r, "RecordLiteralExpression.TypeName")
+		} else {
+			n.TypeName = nil
+		}
+	}
+	if n.Fields != nil {
+		kept := n.Fields[:0]
+		for _, item := range n.Fields {
+			if item == nil {
+				kept = append(kept, item)
+			} else if r := Rewrite(item, fn); r != nil {
+				kept = append(kept, rewriteElement[*FieldInitializer](r, "RecordLiteralExpression.Fields"))
+			}
+		}
+		n.Fields = kept
+	}
+}
+
+// rewriteRecordPropertyDecl rewrites the children of a RecordPropertyDecl node
+func rewriteRecordPropertyDecl(n *RecordPropertyDecl, fn func(Node) Node) {
+	if n.Type != nil {
+		n.Type = rewriteField[TypeExpression](Rewrite(n.Type, fn), "RecordPropertyDecl.Type")
+	}
+	if n.Name != nil {
+		n.Name = rewriteField[*Identifier](Rewrite(n.Name, fn), "RecordPropertyDecl.Name")
+	}
+	if n.ReadExpr != nil {
+		if r := Rewrite(n.ReadExpr, fn); r != nil {
+			n.ReadExpr = rewriteElement[Expression](r, "RecordPropertyDecl.ReadExpr")
+		} else {
+			n.ReadExpr = nil
+		}
+	}
+	if n.WriteStmt != nil {
+		if r := Rewrite(n.WriteStmt, fn); r != nil {
+			n.WriteStmt = rewriteElement[Statement](r, "RecordPropertyDecl.WriteStmt")
+		} else {
+			n.WriteStmt = nil
+		}
+	}
+	if n.IndexParams != nil {
+		kept := n.IndexParams[:0]
+		for _, item := range n.IndexParams {
+			if item == nil {
+				kept = append(kept, item)
+			} else if r := Rewrite(item, fn); r != nil {
+				kept = append(kept, rewriteElement[*Parameter](r, "RecordPropertyDecl.IndexParams"))
+			}
+		}
+		n.IndexParams = kept
+	}
+}
+
+// rewriteRecordTypeNode rewrites the children of a RecordTypeNode node
+func rewriteRecordTypeNode(n *RecordTypeNode, fn func(Node) Node) {
+	if n.Fields != nil {
+		kept := n.Fields[:0]
+		for _, item := range n.Fields {
+			if item == nil {
+				kept = append(kept, item)
+			} else if r := Rewrite(item, fn); r != nil {
+				kept = append(kept, rewriteElement[*FieldDecl](r, "RecordTypeNode.Fields"))
+			}
+		}
+		n.Fields = kept
+	}
+	if n.Methods != nil {
+		kept := n.Methods[:0]
+		for _, item := range n.Methods {
+			if item == nil {
+				kept = append(kept, item)
+			} else if r := Rewrite(item, fn); r != nil {
+				kept = append(kept, rewriteElement[*FunctionDecl](r, "RecordTypeNode.Methods"))
+			}
+		}
+		n.Methods = kept
+	}
+	if n.Properties != nil {
+		kept := n.Properties[:0]
+		for i := range n.Properties {
+			if r := Rewrite(&n.Properties[i], fn); r != nil {
+				kept = append(kept, *rewriteElement[*RecordPropertyDecl](r, "RecordTypeNode.Properties"))
+			}
+		}
+		n.Properties = kept
+	}
+	if n.Constants != nil {
+		kept := n.Constants[:0]
+		for _, item := range n.Constants {
+			if item == nil {
+				kept = append(kept, item)
+			} else if r := Rewrite(item, fn); r != nil {
+				kept = append(kept, rewriteElement[*ConstDecl](r, "RecordTypeNode.Constants"))
+			}
+		}
+		n.Constants = kept
+	}
+	if n.ClassVars != nil {
+		kept := n.ClassVars[:0]
+		for _, item := range n.ClassVars {
+			if item == nil {
+				kept = append(kept, item)
+			} else if r := Rewrite(item, fn); r != nil {
+				kept = append(kept, rewriteElement[*FieldDecl](r, "RecordTypeNode.ClassVars"))
+			}
+		}
+		n.ClassVars = kept
+	}
+}
+
+// rewriteRepeatStatement rewrites the children of a RepeatStatement node
+func rewriteRepeatStatement(n *RepeatStatement, fn func(Node) Node) {
+	if n.Body != nil {
+		n.Body = rewriteField[Statement](Rewrite(n.Body, fn), "RepeatStatement.Body")
+	}
+	if n.Condition != nil {
+		n.Condition = rewriteField[Expression](Rewrite(n.Condition, fn), "RepeatStatement.Condition")
+	}
+}
+
+// rewriteReturnStatement rewrites the children of a ReturnStatement node
+func rewriteReturnStatement(n *ReturnStatement, fn func(Node) Node) {
+	if n.ReturnValue != nil {
+		if r := Rewrite(n.ReturnValue, fn); r != nil {
+			n.ReturnValue = rewriteElement[Expression](r, "ReturnStatement.ReturnValue")
+		} else {
+			n.ReturnValue = nil
+		}
+	}
+}
+
+// rewriteSelfExpression rewrites the children of a SelfExpression node
+func rewriteSelfExpression(_ *SelfExpression, _ func(Node) Node) {
+	// No children to rewrite
+}
+
+// rewriteSetDecl rewrites the children of a SetDecl node
+func rewriteSetDecl(n *SetDecl, fn func(Node) Node) {
+	if n.ElementType != nil {
+		n.ElementType = rewriteField[TypeExpression](Rewrite(n.ElementType, fn), "SetDecl.ElementType")
+	}
+	if n.Name != nil {
+		n.Name = rewriteField[*Identifier](Rewrite(n.Name, fn), "SetDecl.Name")
+	}
+}
+
+// rewriteSetLiteral rewrites the children of a SetLiteral node
+func rewriteSetLiteral(n *SetLiteral, fn func(Node) Node) {
+	if n.Elements != nil {
+		kept := n.Elements[:0]
+		for _, item := range n.Elements {
+			if item == nil {
+				kept = append(kept, item)
+			} else if r := Rewrite(item, fn); r != nil {
+				kept = append(kept, rewriteElement[Expression](r, "SetLiteral.Elements"))
+			}
+		}
+		n.Elements = kept
+	}
+}
+
+// rewriteSetTypeNode rewrites the children of a SetTypeNode node
+func rewriteSetTypeNode(n *SetTypeNode, fn func(Node) Node) {
+	if n.ElementType != nil {
+		n.ElementType = rewriteField[TypeExpression](Rewrite(n.ElementType, fn), "SetTypeNode.ElementType")
+	}
+}
+
+// rewriteStringLiteral rewrites the children of a StringLiteral node
+func rewriteStringLiteral(_ *StringLiteral, _ func(Node) Node) {
+	// No children to rewrite
+}
+
+// rewriteTryStatement rewrites the children of a TryStatement node
+func rewriteTryStatement(n *TryStatement, fn func(Node) Node) {
+	if n.TryBlock != nil {
+		n.TryBlock = rewriteField[*BlockStatement](Rewrite(n.TryBlock, fn), "TryStatement.TryBlock")
+	}
+	if n.ExceptClause != nil {
+		if r := Rewrite(n.ExceptClause, fn); r != nil {
+			n.ExceptClause = rewriteElement[*ExceptClause](r, "TryStatement.ExceptClause")
+		} else {
+			n.ExceptClause = nil
+		}
+	}
+	if n.FinallyClause != nil {
+		if r := Rewrite(n.FinallyClause, fn); r != nil {
+			n.FinallyClause = rewriteElement[*FinallyClause](r, "TryStatement.FinallyClause")
+		} else {
+			n.FinallyClause = nil
+		}
+	}
+}
+
+// rewriteTypeAnnotation rewrites the children of a TypeAnnotation node
+func rewriteTypeAnnotation(n *TypeAnnotation, fn func(Node) Node) {
+	if n.InlineType != nil {
+		if r := Rewrite(n.InlineType, fn); r != nil {
+			n.InlineType = rewriteElement[TypeExpression](r, "TypeAnnotation.InlineType")
+		} else {
+			n.InlineType = nil
+		}
+	}
+	if n.TypeArgs != nil {
+		kept := n.TypeArgs[:0]
+		for _, item := range n.TypeArgs {
+			if item == nil {
+				kept = append(kept, item)
+			} else if r := Rewrite(item, fn); r != nil {
+				kept = append(kept, rewriteElement[TypeExpression](r, "TypeAnnotation.TypeArgs"))
+			}
+		}
+		n.TypeArgs = kept
+	}
+}
+
+// rewriteTypeDeclaration rewrites the children of a TypeDeclaration node
+func rewriteTypeDeclaration(n *TypeDeclaration, fn func(Node) Node) {
+	if n.AliasedType != nil {
+		if r := Rewrite(n.AliasedType, fn); r != nil {
+			n.AliasedType = rewriteElement[TypeExpression](r, "TypeDeclaration.AliasedType")
+		} else {
+			n.AliasedType = nil
+		}
+	}
+	if n.LowBound != nil {
+		if r := Rewrite(n.LowBound, fn); r != nil {
+			n.LowBound = rewriteElement[Expression](r, "TypeDeclaration.LowBound")
+		} else {
+			n.LowBound = nil
+		}
+	}
+	if n.HighBound != nil {
+		if r := Rewrite(n.HighBound, fn); r != nil {
+			n.HighBound = rewriteElement[Expression](r, "TypeDeclaration.HighBound")
+		} else {
+			n.HighBound = nil
+		}
+	}
+	if n.Name != nil {
+		n.Name = rewriteField[*Identifier](Rewrite(n.Name, fn), "TypeDeclaration.Name")
+	}
+	if n.FunctionPointerType != nil {
+		if r := Rewrite(n.FunctionPointerType, fn); r != nil {
+			n.FunctionPointerType = rewriteElement[*FunctionPointerTypeNode](r, "TypeDeclaration.FunctionPointerType")
+		} else {
+			n.FunctionPointerType = nil
+		}
+	}
+}
+
+// rewriteUnaryExpression rewrites the children of a UnaryExpression node
+func rewriteUnaryExpression(n *UnaryExpression, fn func(Node) Node) {
+	if n.Right != nil {
+		n.Right = rewriteField[Expression](Rewrite(n.Right, fn), "UnaryExpression.Right")
+	}
+}
+
+// rewriteUnitDeclaration rewrites the children of a UnitDeclaration node
+func rewriteUnitDeclaration(n *UnitDeclaration, fn func(Node) Node) {
+	if n.Name != nil {
+		n.Name = rewriteField[*Identifier](Rewrite(n.Name, fn), "UnitDeclaration.Name")
+	}
+	if n.InterfaceSection != nil {
+		n.InterfaceSection = rewriteField[*BlockStatement](Rewrite(n.InterfaceSection, fn), "UnitDeclaration.InterfaceSection")
+	}
+	if n.ImplementationSection != nil {
+		n.ImplementationSection = rewriteField[*BlockStatement](Rewrite(n.ImplementationSection, fn), "UnitDeclaration.ImplementationSection")
+	}
+	if n.InitSection != nil {
+		if r := Rewrite(n.InitSection, fn); r != nil {
+			n.InitSection = rewriteElement[*BlockStatement](r, "UnitDeclaration.InitSection")
+		} else {
+			n.InitSection = nil
+		}
+	}
+	if n.FinalSection != nil {
+		if r := Rewrite(n.FinalSection, fn); r != nil {
+			n.FinalSection = rewriteElement[*BlockStatement](r, "UnitDeclaration.FinalSection")
+		} else {
+			n.FinalSection = nil
+		}
+	}
+}
+
+// rewriteUsesClause rewrites the children of a UsesClause node
+func rewriteUsesClause(n *UsesClause, fn func(Node) Node) {
+	if n.Units != nil {
+		kept := n.Units[:0]
+		for _, item := range n.Units {
+			if item == nil {
+				kept = append(kept, item)
+			} else if r := Rewrite(item, fn); r != nil {
+				kept = append(kept, rewriteElement[*Identifier](r, "UsesClause.Units"))
+			}
+		}
+		n.Units = kept
+	}
+}
+
+// rewriteVarDeclStatement rewrites the children of a VarDeclStatement node
+func rewriteVarDeclStatement(n *VarDeclStatement, fn func(Node) Node) {
+	if n.Value != nil {
+		if r := Rewrite(n.Value, fn); r != nil {
+			n.Value = rewriteElement[Expression](r, "VarDeclStatement.Value")
+		} else {
+			n.Value = nil
+		}
+	}
+	if n.Type != nil {
+		if r := Rewrite(n.Type, fn); r != nil {
+			n.Type = rewriteElement[TypeExpression](r, "VarDeclStatement.Type")
+		} else {
+			n.Type = nil
+		}
+	}
+	if n.Names != nil {
+		kept := n.Names[:0]
+		for _, item := range n.Names {
+			if item == nil {
+				kept = append(kept, item)
+			} else if r := Rewrite(item, fn); r != nil {
+				kept = append(kept, rewriteElement[*Identifier](r, "VarDeclStatement.Names"))
+			}
+		}
+		n.Names = kept
+	}
+}
+
+// rewriteWhileStatement rewrites the children of a WhileStatement node
+func rewriteWhileStatement(n *WhileStatement, fn func(Node) Node) {
+	if n.Condition != nil {
+		n.Condition = rewriteField[Expression](Rewrite(n.Condition, fn), "WhileStatement.Condition")
+	}
+	if n.Body != nil {
+		n.Body = rewriteField[Statement](Rewrite(n.Body, fn), "WhileStatement.Body")
+	}
+	if n.InlineVar != nil {
+		if r := Rewrite(n.InlineVar, fn); r != nil {
+			n.InlineVar = rewriteElement[*VarDeclStatement](r, "WhileStatement.InlineVar")
+		} else {
+			n.InlineVar = nil
+		}
+	}
+}
+
+// rewriteWithStatement rewrites the children of a WithStatement node
+func rewriteWithStatement(n *WithStatement, fn func(Node) Node) {
+	if n.Body != nil {
+		n.Body = rewriteField[Statement](Rewrite(n.Body, fn), "WithStatement.Body")
+	}
+	if n.Declarations != nil {
+		kept := n.Declarations[:0]
+		for _, item := range n.Declarations {
+			if item == nil {
+				kept = append(kept, item)
+			} else if r := Rewrite(item, fn); r != nil {
+				kept = append(kept, rewriteElement[*VarDeclStatement](r, "WithStatement.Declarations"))
+			}
+		}
+		n.Declarations = kept
+	}
+}
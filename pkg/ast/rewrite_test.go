@@ -0,0 +1,195 @@
+package ast_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/cwbudde/go-dws/pkg/ast"
+	"github.com/cwbudde/go-dws/pkg/dwscript"
+)
+
+func parseForRewrite(t *testing.T, source string) *ast.Program {
+	t.Helper()
+	engine, err := dwscript.New()
+	if err != nil {
+		t.Fatalf("failed to create engine: %v", err)
+	}
+	program, err := engine.Parse(source)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	return program
+}
+
+// TestRewrite_ReplacesExpressions tests qualifying identifiers in
+// expression positions with a member access.
+func TestRewrite_ReplacesExpressions(t *testing.T) {
+	program := parseForRewrite(t, "PrintLn(Count + Limit * Count);")
+
+	result := ast.Rewrite(program, func(node ast.Node) ast.Node {
+		if id, ok := node.(*ast.Identifier); ok && strings.EqualFold(id.Value, "Count") {
+			return &ast.MemberAccessExpression{
+				Object: &ast.Identifier{Value: "Stats"},
+				Member: id,
+			}
+		}
+		return node
+	})
+
+	if result != program {
+		t.Fatalf("Rewrite returned %T, want the program itself", result)
+	}
+	got := program.Statements[0].String()
+	if want := "PrintLn((Stats.Count + (Limit * Stats.Count)))"; got != want {
+		t.Errorf("rewritten statement = %q, want %q", got, want)
+	}
+}
+
+// TestRewrite_PostOrder tests that fn sees children before their parent and
+// that the parent already holds the rewritten children.
+func TestRewrite_PostOrder(t *testing.T) {
+	program := parseForRewrite(t, "PrintLn(1 + 2);")
+
+	var order []string
+	ast.Rewrite(program, func(node ast.Node) ast.Node {
+		switch n := node.(type) {
+		case *ast.IntegerLiteral:
+			order = append(order, n.String())
+			return ast.NewTestIntegerLiteral(n.Value * 10)
+		case *ast.BinaryExpression:
+			order = append(order, n.String())
+		}
+		return node
+	})
+
+	if got, want := strings.Join(order, " "), "1 2 (10 + 20)"; got != want {
+		t.Errorf("visit order = %q, want %q", got, want)
+	}
+}
+
+// TestRewrite_DeletesSliceElements tests that returning nil removes an
+// element from a slice field.
+func TestRewrite_DeletesSliceElements(t *testing.T) {
+	program := parseForRewrite(t, `
+		PrintLn('a');
+		Assert(True);
+		PrintLn('b');
+		Assert(False);
+	`)
+
+	ast.Rewrite(program, func(node ast.Node) ast.Node {
+		if stmt, ok := node.(*ast.ExpressionStatement); ok {
+			if call, ok := stmt.Expression.(*ast.CallExpression); ok && call.Function.String() == "Assert" {
+				return nil
+			}
+		}
+		return node
+	})
+
+	if len(program.Statements) != 2 {
+		t.Fatalf("got %d statements, want 2: %v", len(program.Statements), program.Statements)
+	}
+	for _, stmt := range program.Statements {
+		if !strings.HasPrefix(stmt.String(), "PrintLn") {
+			t.Errorf("unexpected statement %q", stmt.String())
+		}
+	}
+}
+
+// TestRewrite_DeletesOptionalFields tests that returning nil clears an
+// optional single-node field.
+func TestRewrite_DeletesOptionalFields(t *testing.T) {
+	program := parseForRewrite(t, `
+		var x := 1;
+		if x > 0 then PrintLn('then') else PrintLn('else');
+		var y := if x > 0 then 1 else 2;
+	`)
+
+	ast.Rewrite(program, func(node ast.Node) ast.Node {
+		switch n := node.(type) {
+		case *ast.ExpressionStatement:
+			if strings.Contains(n.String(), "else") {
+				return nil
+			}
+		case *ast.IntegerLiteral:
+			if n.Value == 2 {
+				return nil
+			}
+		}
+		return node
+	})
+
+	ifStmt, ok := program.Statements[1].(*ast.IfStatement)
+	if !ok {
+		t.Fatalf("statement 1 = %T, want *ast.IfStatement", program.Statements[1])
+	}
+	if ifStmt.Alternative != nil {
+		t.Errorf("IfStatement.Alternative = %v, want nil", ifStmt.Alternative)
+	}
+	decl, ok := program.Statements[2].(*ast.VarDeclStatement)
+	if !ok {
+		t.Fatalf("statement 2 = %T, want *ast.VarDeclStatement", program.Statements[2])
+	}
+	ifExpr, ok := decl.Value.(*ast.IfExpression)
+	if !ok {
+		t.Fatalf("var value = %T, want *ast.IfExpression", decl.Value)
+	}
+	if ifExpr.Alternative != nil {
+		t.Errorf("IfExpression.Alternative = %v, want nil", ifExpr.Alternative)
+	}
+}
+
+// TestRewrite_InvalidReplacement tests that replacements not assignable to
+// a field, and nil for a single-node field, panic with a *RewriteError.
+func TestRewrite_InvalidReplacement(t *testing.T) {
+	tests := []struct {
+		replacement ast.Node
+		name        string
+		source      string
+		want        string
+	}{
+		{
+			name:        "statement for expression",
+			replacement: &ast.BlockStatement{},
+			want:        "cannot use *ast.BlockStatement as BinaryExpression.Left",
+		},
+		{
+			name: "nil for required field",
+			want: "BinaryExpression.Left cannot be deleted",
+		},
+		{
+			name:   "nil for required branch",
+			source: "if x then PrintLn(1);",
+			want:   "IfStatement.Condition cannot be deleted",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			source := tt.source
+			if source == "" {
+				source = "PrintLn(x + 1);"
+			}
+			program := parseForRewrite(t, source)
+
+			defer func() {
+				err, ok := recover().(error)
+				var rewriteErr *ast.RewriteError
+				if !ok || !errors.As(err, &rewriteErr) {
+					t.Fatalf("expected a *RewriteError panic, got %v", err)
+				}
+				if !strings.Contains(rewriteErr.Error(), tt.want) {
+					t.Errorf("error = %q, want it to contain %q", rewriteErr.Error(), tt.want)
+				}
+			}()
+
+			ast.Rewrite(program, func(node ast.Node) ast.Node {
+				if id, ok := node.(*ast.Identifier); ok && id.Value == "x" {
+					return tt.replacement
+				}
+				return node
+			})
+		})
+	}
+}
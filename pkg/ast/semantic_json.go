@@ -0,0 +1,201 @@
+package ast
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+)
+
+// Semantic information is serialized next to the JSON AST of the program it
+// describes. Nodes are referred to by their index in the Inspect traversal
+// of that program, which is identical for a program and its UnmarshalJSON
+// round trip:
+//
+//	{
+//	  "nodes": 412,
+//	  "types": [{"node": 7, "annotation": {TypeAnnotation}}, ...],
+//	  "constants": [{"node": 9, "kind": "int", "value": 42}, ...],
+//	  "dead": [31, ...]
+//	}
+//
+// Symbols are not serialized. Non-finite folded floats are dropped, since
+// JSON cannot represent them; the expression is evaluated at run time then.
+
+// SemanticInfoToJSON converts the type, constant and dead-code information
+// that info records for the nodes of program into its generic map form.
+// The result can be passed to json.Marshal; UnmarshalSemanticInfoJSON reads
+// it back for the round-tripped program.
+func SemanticInfoToJSON(program *Program, info *SemanticInfo) map[string]interface{} {
+	nodes := indexNodes(program)
+	enc := jsonEncoder{positions: true}
+
+	typeEntries := []interface{}{}
+	constantEntries := []interface{}{}
+	deadEntries := []interface{}{}
+	for i, node := range nodes {
+		if expr, ok := node.(Expression); ok {
+			if annotation := info.GetType(expr); annotation != nil {
+				typeEntries = append(typeEntries, map[string]interface{}{
+					"node":       i,
+					"annotation": enc.node(reflect.ValueOf(annotation)),
+				})
+			}
+			if value, ok := info.GetConstant(expr); ok {
+				if entry := constantToJSON(value); entry != nil {
+					entry["node"] = i
+					constantEntries = append(constantEntries, entry)
+				}
+			}
+		}
+		if stmt, ok := node.(Statement); ok && info.IsDead(stmt) {
+			deadEntries = append(deadEntries, i)
+		}
+	}
+
+	return map[string]interface{}{
+		"nodes":     len(nodes),
+		"types":     typeEntries,
+		"constants": constantEntries,
+		"dead":      deadEntries,
+	}
+}
+
+// UnmarshalSemanticInfoJSON rebuilds semantic information produced by
+// SemanticInfoToJSON, attaching it to the nodes of program. It fails when
+// program does not have the shape of the program the data was written for.
+func UnmarshalSemanticInfoJSON(program *Program, data []byte) (*SemanticInfo, error) {
+	var raw struct {
+		Nodes int `json:"nodes"`
+		Types []struct {
+			Node       int             `json:"node"`
+			Annotation json.RawMessage `json:"annotation"`
+		} `json:"types"`
+		Constants []struct {
+			Node  int             `json:"node"`
+			Kind  string          `json:"kind"`
+			Value json.RawMessage `json:"value"`
+		} `json:"constants"`
+		Dead []int `json:"dead"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("ast: invalid semantic info JSON: %w", err)
+	}
+
+	nodes := indexNodes(program)
+	if raw.Nodes != len(nodes) {
+		return nil, fmt.Errorf("ast: semantic info describes %d nodes, program has %d", raw.Nodes, len(nodes))
+	}
+	expression := func(i int, what string) (Expression, error) {
+		if i < 0 || i >= len(nodes) {
+			return nil, fmt.Errorf("ast: %s refers to node %d of %d", what, i, len(nodes))
+		}
+		expr, ok := nodes[i].(Expression)
+		if !ok {
+			return nil, fmt.Errorf("ast: %s refers to node %d, which is not an expression", what, i)
+		}
+		return expr, nil
+	}
+
+	info := NewSemanticInfo()
+	for _, entry := range raw.Types {
+		expr, err := expression(entry.Node, "type")
+		if err != nil {
+			return nil, err
+		}
+		var m map[string]interface{}
+		if err := decodeJSONNumbers(entry.Annotation, &m); err != nil {
+			return nil, fmt.Errorf("ast: type of node %d: %w", entry.Node, err)
+		}
+		annotation := &TypeAnnotation{}
+		if err := decodeJSONStruct(reflect.ValueOf(annotation).Elem(), m, "TypeAnnotation", fmt.Sprintf("$.types[%d]", entry.Node)); err != nil {
+			return nil, err
+		}
+		info.SetType(expr, annotation)
+	}
+	for _, entry := range raw.Constants {
+		expr, err := expression(entry.Node, "constant")
+		if err != nil {
+			return nil, err
+		}
+		value, err := constantFromJSON(entry.Kind, entry.Value)
+		if err != nil {
+			return nil, fmt.Errorf("ast: constant of node %d: %w", entry.Node, err)
+		}
+		info.SetConstant(expr, value)
+	}
+	for _, i := range raw.Dead {
+		if i < 0 || i >= len(nodes) {
+			return nil, fmt.Errorf("ast: dead statement refers to node %d of %d", i, len(nodes))
+		}
+		stmt, ok := nodes[i].(Statement)
+		if !ok {
+			return nil, fmt.Errorf("ast: dead statement refers to node %d, which is not a statement", i)
+		}
+		info.MarkDead(stmt)
+	}
+	return info, nil
+}
+
+// indexNodes lists the nodes of program in Inspect order.
+func indexNodes(program *Program) []Node {
+	var nodes []Node
+	Inspect(program, func(node Node) bool {
+		if node != nil {
+			nodes = append(nodes, node)
+		}
+		return true
+	})
+	return nodes
+}
+
+// constantToJSON encodes a folded value, or returns nil when it cannot be
+// represented.
+func constantToJSON(value any) map[string]interface{} {
+	switch v := value.(type) {
+	case int64:
+		return map[string]interface{}{"kind": "int", "value": v}
+	case float64:
+		if math.IsInf(v, 0) || math.IsNaN(v) {
+			return nil
+		}
+		return map[string]interface{}{"kind": "float", "value": v}
+	case string:
+		return map[string]interface{}{"kind": "string", "value": v}
+	case bool:
+		return map[string]interface{}{"kind": "bool", "value": v}
+	}
+	return nil
+}
+
+func constantFromJSON(kind string, data json.RawMessage) (any, error) {
+	var err error
+	switch kind {
+	case "int":
+		var v int64
+		err = json.Unmarshal(data, &v)
+		return v, err
+	case "float":
+		var v float64
+		err = json.Unmarshal(data, &v)
+		return v, err
+	case "string":
+		var v string
+		err = json.Unmarshal(data, &v)
+		return v, err
+	case "bool":
+		var v bool
+		err = json.Unmarshal(data, &v)
+		return v, err
+	}
+	return nil, fmt.Errorf("unknown kind %q", kind)
+}
+
+// decodeJSONNumbers unmarshals data into v, keeping numbers as json.Number
+// as decodeJSONValue expects.
+func decodeJSONNumbers(data []byte, v interface{}) error {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	return dec.Decode(v)
+}
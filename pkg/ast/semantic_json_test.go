@@ -0,0 +1,70 @@
+package ast_test
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/cwbudde/go-dws/internal/lexer"
+	"github.com/cwbudde/go-dws/internal/parser"
+	"github.com/cwbudde/go-dws/pkg/ast"
+)
+
+func TestSemanticInfoJSONRoundTrip(t *testing.T) {
+	program := parser.New(lexer.New("var x := 1 + 2;\nif False then PrintLn(x);")).ParseProgram()
+
+	decl := program.Statements[0].(*ast.VarDeclStatement)
+	sum := decl.Value
+	ifStmt := program.Statements[1].(*ast.IfStatement)
+
+	info := ast.NewSemanticInfo()
+	info.SetType(sum, &ast.TypeAnnotation{Name: "Integer"})
+	info.SetConstant(sum, int64(3))
+	info.MarkDead(ifStmt.Consequence)
+
+	data, err := json.Marshal(ast.SemanticInfoToJSON(program, info))
+	if err != nil {
+		t.Fatalf("json.Marshal failed: %v", err)
+	}
+	treeData, err := json.Marshal(ast.NodeToJSON(program, true))
+	if err != nil {
+		t.Fatalf("json.Marshal failed: %v", err)
+	}
+	loaded, err := ast.UnmarshalJSON(treeData)
+	if err != nil {
+		t.Fatalf("UnmarshalJSON failed: %v", err)
+	}
+
+	restored, err := ast.UnmarshalSemanticInfoJSON(loaded, data)
+	if err != nil {
+		t.Fatalf("UnmarshalSemanticInfoJSON failed: %v", err)
+	}
+
+	loadedSum := loaded.Statements[0].(*ast.VarDeclStatement).Value
+	if typ := restored.GetType(loadedSum); typ == nil || typ.Name != "Integer" {
+		t.Errorf("type = %v, want Integer", typ)
+	}
+	if value, ok := restored.GetConstant(loadedSum); !ok || value != int64(3) {
+		t.Errorf("constant = %v (%T), want int64 3", value, value)
+	}
+	if !restored.IsDead(loaded.Statements[1].(*ast.IfStatement).Consequence) {
+		t.Error("dead statement was not restored")
+	}
+	if restored.TypeCount() != 1 || restored.ConstantCount() != 1 {
+		t.Errorf("restored %d types and %d constants, want 1 each", restored.TypeCount(), restored.ConstantCount())
+	}
+}
+
+func TestUnmarshalSemanticInfoJSONShapeMismatch(t *testing.T) {
+	program := parser.New(lexer.New("var x := 1 + 2;")).ParseProgram()
+	other := parser.New(lexer.New("PrintLn('a'); PrintLn('b');")).ParseProgram()
+
+	data, err := json.Marshal(ast.SemanticInfoToJSON(program, ast.NewSemanticInfo()))
+	if err != nil {
+		t.Fatalf("json.Marshal failed: %v", err)
+	}
+	_, err = ast.UnmarshalSemanticInfoJSON(other, data)
+	if err == nil || !strings.Contains(err.Error(), "nodes") {
+		t.Errorf("expected a node count mismatch error, got %v", err)
+	}
+}
@@ -18,8 +18,8 @@ import (
 //	var y: String; external;
 //	var z: Integer; external 'externalZ';
 type VarDeclStatement struct {
-	Value        Expression
-	Type         TypeExpression
+	Value        Expression     `ast:"optional"`
+	Type         TypeExpression `ast:"optional"`
 	ExternalName string
 	Names        []*Identifier
 	BaseNode
@@ -116,9 +116,14 @@ func (as *AssignmentStatement) String() string {
 //	PrintLn('hello')
 //	Add(3, 5)
 //	Foo()
+//	Foo(count := 3, name := 'x')
 type CallExpression struct {
 	Function  Expression
 	Arguments []Expression
+	// ArgumentNames holds the parameter names of named arguments
+	// ("name := value"), parallel to Arguments with nil entries for
+	// positional ones. It is nil when the call has no named arguments.
+	ArgumentNames []*Identifier `ast:"skip"`
 	TypedExpressionBase
 }
 
@@ -133,17 +138,56 @@ func (ce *CallExpression) String() string {
 	out.WriteString(ce.Function.String())
 	out.WriteString("(")
 
-	args := []string{}
-	for _, arg := range ce.Arguments {
-		args = append(args, arg.String())
-	}
-	out.WriteString(strings.Join(args, ", "))
+	out.WriteString(joinArguments(ce.Arguments, ce.ArgumentNames))
 
 	out.WriteString(")")
 
 	return out.String()
 }
 
+// ArgumentName returns the name given to argument i, or nil if it was
+// passed positionally.
+func (ce *CallExpression) ArgumentName(i int) *Identifier {
+	return argumentName(ce.ArgumentNames, i)
+}
+
+// HasNamedArguments reports whether any argument was passed by name.
+func (ce *CallExpression) HasNamedArguments() bool {
+	return hasNamedArguments(ce.ArgumentNames)
+}
+
+// argumentName returns names[i], or nil if i is out of range.
+func argumentName(names []*Identifier, i int) *Identifier {
+	if i < len(names) {
+		return names[i]
+	}
+	return nil
+}
+
+// hasNamedArguments reports whether names holds any non-nil entry.
+func hasNamedArguments(names []*Identifier) bool {
+	for _, name := range names {
+		if name != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// joinArguments renders an argument list, writing named arguments as
+// "name := value".
+func joinArguments(args []Expression, names []*Identifier) string {
+	parts := make([]string, 0, len(args))
+	for i, arg := range args {
+		if name := argumentName(names, i); name != nil {
+			parts = append(parts, name.Value+" := "+arg.String())
+			continue
+		}
+		parts = append(parts, arg.String())
+	}
+	return strings.Join(parts, ", ")
+}
+
 // Condition represents a single contract condition (precondition or postcondition).
 // A condition consists of a test expression (must be boolean) and an optional message.
 // Examples:
@@ -153,7 +197,7 @@ func (ce *CallExpression) String() string {
 //	a.Length = b.Length : 'arrays must have same length'
 type Condition struct {
 	Test    Expression
-	Message Expression
+	Message Expression `ast:"optional"`
 	BaseNode
 }
 
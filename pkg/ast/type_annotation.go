@@ -6,7 +6,7 @@ import "github.com/cwbudde/go-dws/pkg/token"
 // This is used for variable declarations, parameters, and return types.
 // Example: `: Integer` in `var x: Integer := 5;`
 type TypeAnnotation struct {
-	InlineType TypeExpression
+	InlineType TypeExpression `ast:"optional"`
 	Name       string
 	// TypeArgs holds generic type arguments for a specialized type reference
 	// such as `TTest<Integer, String>`. Nil for ordinary type names. When Name
@@ -85,11 +85,11 @@ type TypedExpression interface {
 // declaration nodes (EnumDecl, RecordDecl, ClassDecl) but may eventually
 // be unified under this node.
 type TypeDeclaration struct {
-	AliasedType         TypeExpression
-	LowBound            Expression
-	HighBound           Expression
+	AliasedType         TypeExpression `ast:"optional"`
+	LowBound            Expression     `ast:"optional"`
+	HighBound           Expression     `ast:"optional"`
 	Name                *Identifier
-	FunctionPointerType *FunctionPointerTypeNode
+	FunctionPointerType *FunctionPointerTypeNode `ast:"optional"`
 	// TypeParams holds the generic type-parameter names for a generic alias/array
 	// type (e.g. ["T"] for `type TArr<T> = array of T;`). Empty otherwise.
 	TypeParams []string
@@ -95,9 +95,9 @@ func (rt *RecordTypeNode) typeExpressionNode() {}
 //   - array[TEnum] of String (enum-indexed array)
 type ArrayTypeNode struct {
 	ElementType TypeExpression
-	LowBound    Expression
-	HighBound   Expression
-	IndexType   TypeExpression // For enum-indexed arrays: array[TEnum] of Type
+	LowBound    Expression     `ast:"optional"`
+	HighBound   Expression     `ast:"optional"`
+	IndexType   TypeExpression `ast:"optional"` // For enum-indexed arrays: array[TEnum] of Type
 	Token       token.Token
 	EndPos      token.Position
 }
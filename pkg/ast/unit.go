@@ -29,8 +29,8 @@ type UnitDeclaration struct {
 	Name                  *Identifier
 	InterfaceSection      *BlockStatement
 	ImplementationSection *BlockStatement
-	InitSection           *BlockStatement
-	FinalSection          *BlockStatement
+	InitSection           *BlockStatement `ast:"optional"`
+	FinalSection          *BlockStatement `ast:"optional"`
 	BaseNode
 }
 
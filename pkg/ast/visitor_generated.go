@@ -106,6 +106,8 @@ func Walk(v Visitor, node Node) {
 		walkInterfaceMethodDecl(n, v)
 	case *InvalidExpression:
 		walkInvalidExpression(n, v)
+	case *InvalidStatement:
+		walkInvalidStatement(n, v)
 	case *InvalidTypeExpression:
 		walkInvalidTypeExpression(n, v)
 	case *InvariantClause:
@@ -283,6 +285,7 @@ func walkCallExpression(n *CallExpression, v Visitor) {
 			Walk(v, item)
 		}
 	}
+	// ArgumentNames skipped (ast:"skip" tag)
 }
 
 // walkCaseBranch walks a CaseBranch node
@@ -645,6 +648,9 @@ func walkIfStatement(n *IfStatement, v Visitor) {
 	if n.Alternative != nil {
 		Walk(v, n.Alternative)
 	}
+	if n.InlineVar != nil {
+		Walk(v, n.InlineVar)
+	}
 }
 
 // walkImplementsExpression walks a ImplementsExpression node
@@ -724,6 +730,11 @@ func walkInvalidExpression(n *InvalidExpression, v Visitor) {
 	// No children to walk
 }
 
+// walkInvalidStatement walks a InvalidStatement node
+func walkInvalidStatement(n *InvalidStatement, v Visitor) {
+	// No children to walk
+}
+
 // walkInvalidTypeExpression walks a InvalidTypeExpression node
 func walkInvalidTypeExpression(n *InvalidTypeExpression, v Visitor) {
 	// No children to walk
@@ -789,6 +800,7 @@ func walkMethodCallExpression(n *MethodCallExpression, v Visitor) {
 			Walk(v, item)
 		}
 	}
+	// ArgumentNames skipped (ast:"skip" tag)
 }
 
 // walkNewArrayExpression walks a NewArrayExpression node
@@ -816,6 +828,7 @@ func walkNewExpression(n *NewExpression, v Visitor) {
 			Walk(v, item)
 		}
 	}
+	// ArgumentNames skipped (ast:"skip" tag)
 }
 
 // walkNilLiteral walks a NilLiteral node
@@ -957,6 +970,11 @@ func walkRecordDecl(n *RecordDecl, v Visitor) {
 			Walk(v, item)
 		}
 	}
+	for _, item := range n.Operators {
+		if item != nil {
+			Walk(v, item)
+		}
+	}
 }
 
 // walkRecordLiteralExpression walks a RecordLiteralExpression node
@@ -1169,6 +1187,9 @@ func walkWhileStatement(n *WhileStatement, v Visitor) {
 	if n.Body != nil {
 		Walk(v, n.Body)
 	}
+	if n.InlineVar != nil {
+		Walk(v, n.InlineVar)
+	}
 }
 
 // walkWithStatement walks a WithStatement node
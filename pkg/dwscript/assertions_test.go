@@ -0,0 +1,61 @@
+package dwscript
+
+import (
+	"bytes"
+	"testing"
+)
+
+const assertSource = `
+function Cond(b: Boolean): Boolean;
+begin
+  PrintLn('cond');
+  Result := b;
+end;
+function Msg: String;
+begin
+  PrintLn('msg');
+  Result := 'bad';
+end;
+Assert(Cond(True), Msg);
+try
+  Assert(Cond(False), Msg);
+except
+  on E: EAssertionFailed do PrintLn(E.ClassName + ': ' + E.Message);
+end;
+PrintLn('done');
+`
+
+// TestAssertLazyMessage verifies that the Assert message is only evaluated
+// when the condition fails and that the failure carries the call position.
+func TestAssertLazyMessage(t *testing.T) {
+	var buf bytes.Buffer
+	engine, err := New(WithOutput(&buf))
+	if err != nil {
+		t.Fatalf("failed to create engine: %v", err)
+	}
+	if _, err := engine.Eval(assertSource); err != nil {
+		t.Fatalf("Eval failed: %v", err)
+	}
+
+	want := "cond\ncond\nmsg\nEAssertionFailed: Assertion failed [line: 14, column: 3] : bad\ndone\n"
+	if buf.String() != want {
+		t.Errorf("output = %q, want %q", buf.String(), want)
+	}
+}
+
+// TestWithAssertionsDisabled verifies that WithAssertions(false) skips Assert
+// entirely, without evaluating the condition or the message.
+func TestWithAssertionsDisabled(t *testing.T) {
+	var buf bytes.Buffer
+	engine, err := New(WithOutput(&buf), WithAssertions(false))
+	if err != nil {
+		t.Fatalf("failed to create engine: %v", err)
+	}
+	if _, err := engine.Eval(assertSource); err != nil {
+		t.Fatalf("Eval failed: %v", err)
+	}
+
+	if buf.String() != "done\n" {
+		t.Errorf("output = %q, want %q", buf.String(), "done\n")
+	}
+}
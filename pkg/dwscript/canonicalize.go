@@ -0,0 +1,32 @@
+package dwscript
+
+import (
+	"fmt"
+
+	"github.com/cwbudde/go-dws/pkg/printer"
+)
+
+// Canonicalize returns a minimized, normalized rendering of the program's
+// source. It prints the AST in the compact style: comments and redundant
+// whitespace are dropped, keywords are lowercased and a single space is kept
+// only where two tokens would otherwise merge. Programs that differ only in
+// formatting, comments or keyword casing produce identical output, so the
+// result can serve as a deduplication or cache key, or for size comparisons.
+//
+// Identifier casing is preserved. The output is deterministic for a given
+// AST but is not guaranteed to be stable across releases of this package.
+//
+// Example usage:
+//
+//	program, _ := engine.Compile(source)
+//	key, err := program.Canonicalize()
+//	if err != nil {
+//	    return err
+//	}
+//	cache[key] = program
+func (p *Program) Canonicalize() (string, error) {
+	if p == nil || p.ast == nil {
+		return "", fmt.Errorf("program is nil")
+	}
+	return printer.New(printer.CompactOptions()).Print(p.ast), nil
+}
@@ -0,0 +1,85 @@
+package dwscript
+
+import (
+	"testing"
+)
+
+func canonicalize(t *testing.T, engine *Engine, source string) string {
+	t.Helper()
+	program, err := engine.Compile(source)
+	if err != nil {
+		t.Fatalf("Compile failed: %v", err)
+	}
+	out, err := program.Canonicalize()
+	if err != nil {
+		t.Fatalf("Canonicalize failed: %v", err)
+	}
+	return out
+}
+
+func TestCanonicalize(t *testing.T) {
+	engine, err := New()
+	if err != nil {
+		t.Fatalf("failed to create engine: %v", err)
+	}
+
+	original := `
+function Add(a, b: Integer): Integer;
+begin
+  Result := a + b;
+end;
+
+var x: Integer := 3;
+if x > 2 then
+  PrintLn(Add(x, 1))
+else
+  PrintLn('small');
+for var i := 1 to 3 do
+begin
+  PrintLn(i);
+end;
+`
+	reformatted := `// same program, different layout
+FUNCTION Add(a, b: Integer): Integer; BEGIN Result:=a+b; END;
+VAR x: Integer:=3;
+IF x>2 THEN PrintLn(Add(x,1)) ELSE PrintLn('small');
+{ loop }
+FOR VAR i:=1 TO 3 DO BEGIN PrintLn(i) END;
+`
+	changed := `
+function Add(a, b: Integer): Integer;
+begin
+  Result := a - b;
+end;
+
+var x: Integer := 3;
+if x > 2 then
+  PrintLn(Add(x, 1))
+else
+  PrintLn('small');
+for var i := 1 to 3 do
+begin
+  PrintLn(i);
+end;
+`
+
+	want := canonicalize(t, engine, original)
+	if got := canonicalize(t, engine, reformatted); got != want {
+		t.Errorf("formatting-only change altered canonical form:\n got: %s\nwant: %s", got, want)
+	}
+	if got := canonicalize(t, engine, changed); got == want {
+		t.Errorf("semantic change produced the same canonical form: %s", got)
+	}
+
+	// The canonical form is itself a valid program with the same canonical form.
+	if got := canonicalize(t, engine, want); got != want {
+		t.Errorf("canonical form is not a fixed point:\n got: %s\nwant: %s", got, want)
+	}
+}
+
+func TestCanonicalizeNilProgram(t *testing.T) {
+	var p *Program
+	if _, err := p.Canonicalize(); err == nil {
+		t.Error("expected error for nil program")
+	}
+}
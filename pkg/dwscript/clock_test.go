@@ -0,0 +1,101 @@
+package dwscript
+
+import (
+	"testing"
+	"time"
+)
+
+// fixedClock returns a clock that always reports t.
+func fixedClock(t time.Time) func() time.Time {
+	return func() time.Time { return t }
+}
+
+// TestWithClock verifies that Now, Date, Time and UnixTime read the injected
+// clock.
+func TestWithClock(t *testing.T) {
+	clock := fixedClock(time.Date(2024, 3, 15, 10, 30, 0, 0, time.UTC))
+	script := `
+var t: TDateTime := Now;
+PrintLn(FormatDateTime('yyyy-mm-dd hh:nn:ss', t));
+PrintLn(FormatDateTime('yyyy-mm-dd hh:nn:ss', Date));
+PrintLn(FormatDateTime('hh:nn', Time()));
+PrintLn(Date + Time = Now);
+PrintLn(UnixTime);`
+
+	expected := "2024-03-15 10:30:00\n2024-03-15 00:00:00\n10:30\nTrue\n1710498600\n"
+	if output := runWithFormatSettings(t, script, WithClock(clock)); output != expected {
+		t.Errorf("output = %q, want %q", output, expected)
+	}
+}
+
+// TestWithClockNil verifies that a nil clock is rejected.
+func TestWithClockNil(t *testing.T) {
+	if _, err := New(WithClock(nil)); err == nil {
+		t.Error("expected error for nil clock")
+	}
+}
+
+// TestDateTimeBuiltins verifies EncodeDate/DecodeDate and EncodeTime/DecodeTime
+// round-trips, DayOfWeek and the Delphi epoch.
+func TestDateTimeBuiltins(t *testing.T) {
+	tests := []struct {
+		name     string
+		script   string
+		expected string
+	}{
+		{
+			name: "epoch",
+			script: `
+PrintLn(FloatToStr(EncodeDate(1899, 12, 30)));
+PrintLn(FloatToStr(EncodeDate(1900, 1, 1)));
+PrintLn(FormatDateTime('yyyy-mm-dd', 0));`,
+			expected: "0\n2\n1899-12-30\n",
+		},
+		{
+			name: "date round-trip",
+			script: `
+var y, m, d: Integer;
+DecodeDate(EncodeDate(2024, 2, 29), y, m, d);
+PrintLn(Format('%d-%d-%d', [y, m, d]));
+DecodeDate(EncodeDate(1, 1, 1), y, m, d);
+PrintLn(Format('%d-%d-%d', [y, m, d]));
+DecodeDate(EncodeDate(9999, 12, 31), y, m, d);
+PrintLn(Format('%d-%d-%d', [y, m, d]));`,
+			expected: "2024-2-29\n1-1-1\n9999-12-31\n",
+		},
+		{
+			name: "time round-trip",
+			script: `
+var h, n, s, z: Integer;
+DecodeTime(EncodeTime(13, 45, 30, 250), h, n, s, z);
+PrintLn(Format('%d:%d:%d.%d', [h, n, s, z]));`,
+			expected: "13:45:30.250\n",
+		},
+		{
+			name: "day of week",
+			script: `
+PrintLn(DayOfWeek(EncodeDate(2024, 3, 10)));
+PrintLn(DayOfWeek(EncodeDate(2024, 3, 15)));`,
+			expected: "1\n6\n",
+		},
+		{
+			name: "format patterns",
+			script: `
+var dt: TDateTime := EncodeDate(2024, 2, 29) + EncodeTime(13, 45, 0, 0);
+PrintLn(FormatDateTime('dddd, mmmm d, yyyy', dt));
+PrintLn(FormatDateTime('ddd dd/mm/yy', dt));
+PrintLn(FormatDateTime('h:nn am/pm', dt));
+PrintLn(FormatDateTime('"at" hh:mm', dt));
+PrintLn(FormatDateTime('c', dt));`,
+			expected: "Thursday, February 29, 2024\nThu 29/02/24\n1:45 pm\nat 13:45\n2024-02-29 13:45:00\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if output := runWithFormatSettings(t, tt.script); output != tt.expected {
+				t.Errorf("output = %q, want %q", output, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,126 @@
+package dwscript
+
+import (
+	"container/list"
+	"crypto/sha256"
+	"sync"
+)
+
+// compileKey identifies a compiled program in the compile cache.
+type compileKey [sha256.Size]byte
+
+// newCompileKey hashes the source together with the names and types of the
+// host globals, which are declared to the semantic analyzer and so affect the
+// compiled program. The remaining compile options cannot change after New, so
+// they are the same for every entry of an engine's cache.
+func newCompileKey(source string, globals []hostGlobal) compileKey {
+	h := sha256.New()
+	h.Write([]byte(source))
+	for _, global := range globals {
+		h.Write([]byte{0})
+		h.Write([]byte(global.name))
+		h.Write([]byte{0})
+		h.Write([]byte(global.typ.String()))
+	}
+	var key compileKey
+	h.Sum(key[:0])
+	return key
+}
+
+// compileCache is a least-recently-used cache of successfully compiled
+// programs. It is safe for concurrent use.
+type compileCache struct {
+	entries map[compileKey]*list.Element
+	order   *list.List // *compileCacheEntry, most recently used first
+	size    int
+	mu      sync.Mutex
+}
+
+type compileCacheEntry struct {
+	program *Program
+	key     compileKey
+}
+
+// newCompileCache creates a cache holding at most size programs.
+func newCompileCache(size int) *compileCache {
+	return &compileCache{
+		entries: make(map[compileKey]*list.Element, size),
+		order:   list.New(),
+		size:    size,
+	}
+}
+
+// get returns the program cached for key and marks it as recently used.
+func (c *compileCache) get(key compileKey) (*Program, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	elem, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	c.order.MoveToFront(elem)
+	return elem.Value.(*compileCacheEntry).program, true
+}
+
+// put caches program under key, evicting the least recently used program
+// when the cache is full.
+func (c *compileCache) put(key compileKey, program *Program) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if elem, ok := c.entries[key]; ok {
+		elem.Value.(*compileCacheEntry).program = program
+		c.order.MoveToFront(elem)
+		return
+	}
+	c.entries[key] = c.order.PushFront(&compileCacheEntry{key: key, program: program})
+	if c.order.Len() > c.size {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*compileCacheEntry).key)
+	}
+}
+
+// len returns the number of cached programs.
+func (c *compileCache) len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.order.Len()
+}
+
+// clear removes all cached programs.
+func (c *compileCache) clear() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries = make(map[compileKey]*list.Element, c.size)
+	c.order.Init()
+}
+
+// ClearCache removes all programs from the compile cache enabled with
+// WithCompileCache, and the units parsed for uses clauses, which later
+// compilations then parse again.
+func (e *Engine) ClearCache() {
+	if e.cache != nil {
+		e.cache.clear()
+	}
+	e.unitCache.Clear()
+}
+
+// cachedCopy returns a Program for a cache hit. It shares the compiled AST,
+// semantic information and bytecode with p, and binds the current values of
+// the host globals.
+func (p *Program) cachedCopy(globals []hostGlobal) *Program {
+	p.bytecodeMu.Lock()
+	defer p.bytecodeMu.Unlock()
+	return &Program{
+		ast:              p.ast,
+		analyzer:         p.analyzer,
+		semanticInfo:     p.semanticInfo,
+		bytecodeChunk:    p.bytecodeChunk,
+		bytecodeFallback: p.bytecodeFallback,
+		compileErr:       p.compileErr,
+		diagnostics:      p.diagnostics,
+		globals:          boundHostGlobals(globals, p.analyzer),
+		units:            p.units,
+		options:          p.options,
+	}
+}
@@ -0,0 +1,52 @@
+package dwscript
+
+import (
+	"io"
+	"testing"
+)
+
+// BenchmarkCompileCache compares compiling a short script from scratch with
+// serving it from the compile cache.
+func BenchmarkCompileCache(b *testing.B) {
+	source := `
+type TPoint = record X, Y: Integer; end;
+function Dist2(p: TPoint): Integer;
+begin
+  Result := p.X * p.X + p.Y * p.Y;
+end;
+var p: TPoint;
+p.X := 3; p.Y := 4;
+PrintLn(Dist2(p));
+`
+
+	b.Run("miss", func(b *testing.B) {
+		engine, err := New(WithOutput(io.Discard))
+		if err != nil {
+			b.Fatalf("failed to create engine: %v", err)
+		}
+
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			if _, err := engine.Compile(source); err != nil {
+				b.Fatalf("Compile failed: %v", err)
+			}
+		}
+	})
+
+	b.Run("hit", func(b *testing.B) {
+		engine, err := New(WithOutput(io.Discard), WithCompileCache(16))
+		if err != nil {
+			b.Fatalf("failed to create engine: %v", err)
+		}
+		if _, err := engine.Compile(source); err != nil {
+			b.Fatalf("Compile failed: %v", err)
+		}
+
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			if _, err := engine.Compile(source); err != nil {
+				b.Fatalf("Compile failed: %v", err)
+			}
+		}
+	})
+}
@@ -0,0 +1,132 @@
+package dwscript
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestCompileCache_Hit(t *testing.T) {
+	var buf bytes.Buffer
+	engine, err := New(WithOutput(&buf), WithCompileCache(4))
+	if err != nil {
+		t.Fatalf("failed to create engine: %v", err)
+	}
+
+	source := `var x := 6 * 7; PrintLn(x);`
+	first, err := engine.Compile(source)
+	if err != nil {
+		t.Fatalf("Compile failed: %v", err)
+	}
+	second, err := engine.Compile(source)
+	if err != nil {
+		t.Fatalf("Compile failed: %v", err)
+	}
+
+	if first == second {
+		t.Error("cache hit returned the same *Program, want a copy per caller")
+	}
+	if first.AST() != second.AST() {
+		t.Error("cache hit recompiled the source, want a shared AST")
+	}
+
+	for _, program := range []*Program{first, second} {
+		if _, err := engine.Run(program); err != nil {
+			t.Fatalf("Run failed: %v", err)
+		}
+	}
+	if want := "42\n42\n"; buf.String() != want {
+		t.Errorf("output = %q, want %q", buf.String(), want)
+	}
+}
+
+func TestCompileCache_EvictionAndClear(t *testing.T) {
+	engine, err := New(WithCompileCache(1))
+	if err != nil {
+		t.Fatalf("failed to create engine: %v", err)
+	}
+
+	a1, _ := engine.Compile(`PrintLn('a');`)
+	if _, err := engine.Compile(`PrintLn('b');`); err != nil {
+		t.Fatalf("Compile failed: %v", err)
+	}
+	if engine.cache.len() != 1 {
+		t.Errorf("cache holds %d programs, want 1", engine.cache.len())
+	}
+
+	// 'a' was evicted by 'b', so it is compiled again.
+	a2, _ := engine.Compile(`PrintLn('a');`)
+	if a1.AST() == a2.AST() {
+		t.Error("evicted program was served from the cache")
+	}
+
+	engine.ClearCache()
+	if engine.cache.len() != 0 {
+		t.Errorf("cache holds %d programs after ClearCache, want 0", engine.cache.len())
+	}
+	a3, _ := engine.Compile(`PrintLn('a');`)
+	if a2.AST() == a3.AST() {
+		t.Error("program was served from the cache after ClearCache")
+	}
+}
+
+func TestCompileCache_ErrorsNotCached(t *testing.T) {
+	engine, err := New(WithCompileCache(4))
+	if err != nil {
+		t.Fatalf("failed to create engine: %v", err)
+	}
+
+	for i := 0; i < 2; i++ {
+		if _, err := engine.Compile(`var x: Integer := 'text';`); err == nil {
+			t.Fatalf("compile %d: expected a type error", i)
+		}
+	}
+	if engine.cache.len() != 0 {
+		t.Errorf("cache holds %d programs, want failed compiles to be skipped", engine.cache.len())
+	}
+}
+
+func TestCompileCache_HostGlobals(t *testing.T) {
+	var buf bytes.Buffer
+	engine, err := New(WithOutput(&buf), WithCompileCache(4))
+	if err != nil {
+		t.Fatalf("failed to create engine: %v", err)
+	}
+
+	source := `PrintLn(Limit);`
+	run := func() *Program {
+		t.Helper()
+		program, err := engine.Compile(source)
+		if err != nil {
+			t.Fatalf("Compile failed: %v", err)
+		}
+		if _, err := engine.Run(program); err != nil {
+			t.Fatalf("Run failed: %v", err)
+		}
+		return program
+	}
+
+	engine.SetGlobal("Limit", 1)
+	first := run()
+	// A new value of the same type reuses the compiled program.
+	engine.SetGlobal("Limit", 2)
+	second := run()
+	// A new type changes the analysis, so the source is compiled again.
+	engine.SetGlobal("Limit", "three")
+	third := run()
+
+	if first.AST() != second.AST() {
+		t.Error("changing a global's value recompiled the source")
+	}
+	if second.AST() == third.AST() {
+		t.Error("changing a global's type reused the cached program")
+	}
+	if want := "1\n2\nthree\n"; buf.String() != want {
+		t.Errorf("output = %q, want %q", buf.String(), want)
+	}
+}
+
+func TestWithCompileCache_InvalidSize(t *testing.T) {
+	if _, err := New(WithCompileCache(-1)); err == nil {
+		t.Error("expected an error for a negative cache size")
+	}
+}
@@ -1,6 +1,11 @@
 package dwscript
 
-import "testing"
+import (
+	"bytes"
+	"sort"
+	"strings"
+	"testing"
+)
 
 func TestEngineEvalBytecodeMode(t *testing.T) {
 	script := `
@@ -38,3 +43,115 @@ func TestCompileStoresBytecodeChunk(t *testing.T) {
 		t.Fatalf("expected bytecode chunk to be populated when compiling in bytecode mode")
 	}
 }
+
+func TestProgramCompileMode(t *testing.T) {
+	for _, mode := range []CompileMode{CompileModeAST, CompileModeBytecode} {
+		engine, err := New(WithCompileMode(mode))
+		if err != nil {
+			t.Fatalf("failed to create engine: %v", err)
+		}
+		program, err := engine.Compile(`var n: Integer := 1;`)
+		if err != nil {
+			t.Fatalf("Compile failed: %v", err)
+		}
+		if got := program.CompileMode(); got != mode {
+			t.Errorf("CompileMode() = %v, want %v", got, mode)
+		}
+		if len(program.Diagnostics()) != 0 {
+			t.Errorf("unexpected diagnostics: %v", program.Diagnostics())
+		}
+	}
+}
+
+func TestBytecodeUnsupportedConstructFallsBack(t *testing.T) {
+	var buf bytes.Buffer
+	engine, err := New(WithCompileMode(CompileModeBytecode), WithOutput(&buf))
+	if err != nil {
+		t.Fatalf("failed to create engine: %v", err)
+	}
+
+	program, err := engine.Compile(`var s := 0;
+for var i := 1 to 3 do
+  s := s + i;
+PrintLn(s);`)
+	if err != nil {
+		t.Fatalf("Compile failed: %v", err)
+	}
+	if got := program.CompileMode(); got != CompileModeAST {
+		t.Errorf("CompileMode() = %v, want %v", got, CompileModeAST)
+	}
+
+	diagnostics := program.Diagnostics()
+	if len(diagnostics) != 1 {
+		t.Fatalf("got %d diagnostics, want 1: %v", len(diagnostics), diagnostics)
+	}
+	d := diagnostics[0]
+	if d.Severity != SeverityWarning || d.Phase != PhaseBytecode || d.Code != "W_BYTECODE_FALLBACK" {
+		t.Errorf("diagnostic = %+v, want a bytecode fallback warning", d)
+	}
+	if !strings.Contains(d.Message, "ForStatement") {
+		t.Errorf("diagnostic message %q does not name the construct", d.Message)
+	}
+	if d.Start.Line != 2 || d.Start.Column != 1 {
+		t.Errorf("diagnostic position = %d:%d, want 2:1", d.Start.Line, d.Start.Column)
+	}
+
+	if _, err := engine.Run(program); err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	if want := "6\n"; buf.String() != want {
+		t.Errorf("output = %q, want %q", buf.String(), want)
+	}
+}
+
+func TestBytecodeUnsupportedBuiltinFallsBack(t *testing.T) {
+	var buf bytes.Buffer
+	engine, err := New(WithCompileMode(CompileModeBytecode), WithOutput(&buf))
+	if err != nil {
+		t.Fatalf("failed to create engine: %v", err)
+	}
+
+	program, err := engine.Compile(`var ti := TypeOf(42);
+PrintLn(ti.Name, ' ', ti.IsClass);`)
+	if err != nil {
+		t.Fatalf("Compile failed: %v", err)
+	}
+	if got := program.CompileMode(); got != CompileModeAST {
+		t.Errorf("CompileMode() = %v, want %v", got, CompileModeAST)
+	}
+	diagnostics := program.Diagnostics()
+	if len(diagnostics) != 1 || diagnostics[0].Code != "W_BYTECODE_FALLBACK" ||
+		!strings.Contains(diagnostics[0].Message, "TypeOf") {
+		t.Fatalf("diagnostics = %v, want a bytecode fallback warning naming TypeOf", diagnostics)
+	}
+
+	if _, err := engine.Run(program); err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	if want := "Integer False\n"; buf.String() != want {
+		t.Errorf("output = %q, want %q", buf.String(), want)
+	}
+}
+
+func TestBytecodeSupportedNodeTypes(t *testing.T) {
+	supported := BytecodeSupportedNodeTypes()
+	if !sort.StringsAreSorted(supported) {
+		t.Errorf("BytecodeSupportedNodeTypes() is not sorted: %v", supported)
+	}
+	has := func(name string) bool {
+		for _, s := range supported {
+			if s == name {
+				return true
+			}
+		}
+		return false
+	}
+	for _, name := range []string{"WhileStatement", "BinaryExpression", "CallExpression"} {
+		if !has(name) {
+			t.Errorf("BytecodeSupportedNodeTypes() lacks %s", name)
+		}
+	}
+	if has("ForStatement") {
+		t.Error("BytecodeSupportedNodeTypes() lists ForStatement")
+	}
+}
@@ -0,0 +1,170 @@
+package dwscript
+
+import (
+	"bytes"
+	"sync"
+	"testing"
+)
+
+// concurrentRunSource mutates global, class and record state, so runs that
+// shared interpreter state would see each other's updates. Its forward
+// declaration and the unit it uses are registered anew by every run.
+const concurrentRunSource = `
+type TCounter = class
+  class var Total: Integer;
+  FValue: Integer;
+  constructor Create(v: Integer);
+end;
+
+constructor TCounter.Create(v: Integer);
+begin
+  FValue := v;
+  Total := Total + v;
+end;
+
+type TPoint = record
+  X: Integer;
+  procedure Move(dx: Integer = 7);
+end;
+
+procedure TPoint.Move(dx: Integer);
+begin
+  X := X + dx;
+end;
+
+type TIntHelper = helper for Integer
+  function Twice(extra: Integer = 0): Integer;
+end;
+
+function TIntHelper.Twice(extra: Integer): Integer;
+begin
+  Result := Self * 2 + extra;
+end;
+
+function Twice(x: Integer): Integer; forward;
+
+function Twice(x: Integer): Integer;
+begin
+  Result := x * 2;
+end;
+
+var Sum: Integer;
+var Names: array of String;
+
+for var i := 1 to 100 do
+  Sum := Sum + i;
+Names.Add('a');
+Names.Add('b');
+
+var c := TCounter.Create(Sum);
+var p: TPoint;
+p.Move;
+p.Move(3);
+
+PrintLn(IntToStr(Sum) + ' ' + IntToStr(TCounter.Total) + ' ' + IntToStr(p.X) + ' ' +
+  IntToStr(Names.Length) + ' ' + IntToStr(Sum.Twice()) + ' ' + IntToStr(Twice(Half)));
+`
+
+// concurrentRunUnits declares functions in a unit interface and implements
+// them in its implementation section.
+var concurrentRunUnits = map[string]string{
+	"shared": `unit Shared;
+interface
+function Half: Integer;
+implementation
+function Half: Integer;
+begin
+  Result := 21;
+end;
+end.`,
+}
+
+// TestConcurrentRuns runs one compiled Program from many goroutines and
+// checks that every run starts from fresh state with its own output.
+func TestConcurrentRuns(t *testing.T) {
+	const goroutines = 16
+
+	tests := []struct {
+		mode   CompileMode
+		source string
+		want   string
+	}{
+		{CompileModeAST, "uses Shared;\n" + concurrentRunSource, "5050 5050 10 2 10100 42\n"},
+		{CompileModeBytecode, `
+var Sum: Integer;
+for var i := 1 to 100 do
+  Sum := Sum + i;
+PrintLn(IntToStr(Sum));
+`, "5050\n"},
+	}
+
+	for _, tt := range tests {
+		mode, want := tt.mode, tt.want
+		t.Run(mode.String(), func(t *testing.T) {
+			engine, err := New(WithCompileMode(mode), WithUnitResolver(mapUnitResolver(concurrentRunUnits)))
+			if err != nil {
+				t.Fatalf("failed to create engine: %v", err)
+			}
+			program, err := engine.Compile(tt.source)
+			if err != nil {
+				t.Fatalf("Compile failed: %v", err)
+			}
+
+			var wg sync.WaitGroup
+			outputs := make([]bytes.Buffer, goroutines)
+			results := make([]*Result, goroutines)
+			errs := make([]error, goroutines)
+			for g := 0; g < goroutines; g++ {
+				wg.Add(1)
+				go func(g int) {
+					defer wg.Done()
+					results[g], errs[g] = engine.RunWithOutput(program, &outputs[g])
+				}(g)
+			}
+			wg.Wait()
+
+			for g := 0; g < goroutines; g++ {
+				if errs[g] != nil {
+					t.Fatalf("run %d failed: %v", g, errs[g])
+				}
+				if got := outputs[g].String(); got != want {
+					t.Errorf("run %d output = %q, want %q", g, got, want)
+				}
+				if results[g].Output != want {
+					t.Errorf("run %d Result.Output = %q, want %q", g, results[g].Output, want)
+				}
+				if mode == CompileModeAST {
+					sum, ok, err := results[g].GlobalValue("Sum")
+					if err != nil || !ok || sum != int64(5050) {
+						t.Errorf("run %d Sum = %v (ok=%v, err=%v), want 5050", g, sum, ok, err)
+					}
+				}
+			}
+		})
+	}
+}
+
+// TestRunWithOutputNil verifies that a nil writer captures the output of
+// the run in Result.Output instead of the engine's writer.
+func TestRunWithOutputNil(t *testing.T) {
+	var engineOut bytes.Buffer
+	engine, err := New(WithOutput(&engineOut))
+	if err != nil {
+		t.Fatalf("failed to create engine: %v", err)
+	}
+	program, err := engine.Compile(`PrintLn('hello');`)
+	if err != nil {
+		t.Fatalf("Compile failed: %v", err)
+	}
+
+	result, err := engine.RunWithOutput(program, nil)
+	if err != nil {
+		t.Fatalf("RunWithOutput failed: %v", err)
+	}
+	if result.Output != "hello\n" {
+		t.Errorf("Result.Output = %q, want %q", result.Output, "hello\n")
+	}
+	if engineOut.Len() != 0 {
+		t.Errorf("engine output = %q, want it untouched", engineOut.String())
+	}
+}
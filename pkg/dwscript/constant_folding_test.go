@@ -0,0 +1,147 @@
+package dwscript
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/cwbudde/go-dws/pkg/token"
+)
+
+const foldingScript = `
+const SecondsPerDay = 24 * 60 * 60;
+const Name = 'dws';
+const Verbose = False;
+var days := 3;
+PrintLn(SecondsPerDay * days);
+PrintLn(Name + '-' + IntToStr(SecondsPerDay div 3600));
+PrintLn(7 / 2);
+PrintLn(-(5 - 10) mod 3);
+PrintLn(not Verbose and (SecondsPerDay > 1000));
+if Verbose then PrintLn('verbose') else PrintLn('quiet');
+case SecondsPerDay mod 7 of
+  0: PrintLn('zero');
+  6: PrintLn('six');
+else
+  PrintLn('other');
+end;
+`
+
+// TestConstantFolding tests that folding does not change program output.
+func TestConstantFolding(t *testing.T) {
+	var outputs [2]string
+	for i, fold := range []bool{false, true} {
+		var buf bytes.Buffer
+		engine, err := New(WithOutput(&buf), WithConstantFolding(fold))
+		if err != nil {
+			t.Fatalf("New failed: %v", err)
+		}
+		if _, err := engine.Eval(foldingScript); err != nil {
+			t.Fatalf("Eval with folding=%v failed: %v", fold, err)
+		}
+		outputs[i] = buf.String()
+	}
+
+	if outputs[0] != outputs[1] {
+		t.Errorf("folded output differs:\n%s\nwant:\n%s", outputs[1], outputs[0])
+	}
+	if want := "259200\ndws-24\n3.5\n2\nTrue\nquiet\nsix\n"; outputs[1] != want {
+		t.Errorf("output = %q, want %q", outputs[1], want)
+	}
+}
+
+// TestConstantFolding_IntegerOverflow tests that folding follows the
+// runtime's Integer overflow setting.
+func TestConstantFolding_IntegerOverflow(t *testing.T) {
+	script := `
+const Max = 9223372036854775807;
+try
+  PrintLn(Max + 1);
+except
+  on E: EIntOverflow do PrintLn('overflow');
+end;
+`
+	tests := []struct {
+		name  string
+		want  string
+		check bool
+	}{
+		{name: "wraparound", want: "-9223372036854775808"},
+		{name: "checked", check: true, want: "overflow"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			engine, _ := New(WithOutput(&buf), WithConstantFolding(true), WithIntegerOverflowCheck(tt.check))
+			if _, err := engine.Eval(script); err != nil {
+				t.Fatalf("Eval failed: %v", err)
+			}
+			if got := strings.TrimSpace(buf.String()); got != tt.want {
+				t.Errorf("output = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestConstantFolding_DivisionByZero tests that division by a
+// constant zero is a compile error with folding and a runtime error without.
+func TestConstantFolding_DivisionByZero(t *testing.T) {
+	script := "var n := 10;\nPrintLn(n div (3 - 3));\n"
+
+	engine, _ := New(WithOutput(&bytes.Buffer{}), WithConstantFolding(true))
+	_, err := engine.Compile(script)
+	if err == nil || !strings.Contains(err.Error(), "division by zero") {
+		t.Fatalf("expected a division by zero compile error, got %v", err)
+	}
+
+	engine, _ = New(WithOutput(&bytes.Buffer{}))
+	program, err := engine.Compile(script)
+	if err != nil {
+		t.Fatalf("Compile without folding failed: %v", err)
+	}
+	if _, err := engine.Run(program); err == nil {
+		t.Error("expected a runtime division by zero error")
+	}
+}
+
+// TestProgram_ConstantAt tests the hover text for folded expressions.
+func TestProgram_ConstantAt(t *testing.T) {
+	source := "const SecondsPerDay = 24 * 60 * 60;\nvar n := 2;\nPrintLn(SecondsPerDay * 7 + n);\nPrintLn('a' + 'b');\n"
+
+	engine, _ := New(WithConstantFolding(true))
+	program, err := engine.Compile(source)
+	if err != nil {
+		t.Fatalf("Compile failed: %v", err)
+	}
+
+	tests := []struct {
+		name string
+		want string
+		pos  token.Position
+	}{
+		{name: "const declaration name", pos: token.Position{Line: 1, Column: 7}, want: "const 86400"},
+		{name: "innermost operator", pos: token.Position{Line: 1, Column: 26}, want: "const 1440"},
+		{name: "const reference", pos: token.Position{Line: 3, Column: 9}, want: "const 86400"},
+		{name: "folded subexpression", pos: token.Position{Line: 3, Column: 23}, want: "const 604800"},
+		{name: "string concatenation", pos: token.Position{Line: 4, Column: 13}, want: "const 'ab'"},
+		{name: "variable", pos: token.Position{Line: 3, Column: 29}},
+		{name: "declaration keyword", pos: token.Position{Line: 2, Column: 1}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := program.ConstantAt(tt.pos)
+			if got != tt.want || ok != (tt.want != "") {
+				t.Errorf("ConstantAt(%d:%d) = %q, %v, want %q", tt.pos.Line, tt.pos.Column, got, ok, tt.want)
+			}
+		})
+	}
+
+	// Without folding there are no constant values to report.
+	engine, _ = New()
+	program, _ = engine.Compile(source)
+	if got, ok := program.ConstantAt(token.Position{Line: 1, Column: 7}); ok {
+		t.Errorf("ConstantAt without folding = %q, want none", got)
+	}
+}
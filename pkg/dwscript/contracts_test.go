@@ -0,0 +1,102 @@
+package dwscript
+
+import (
+	"bytes"
+	"testing"
+)
+
+const contractsSource = `
+function Half(x: Integer): Integer;
+require
+   x mod 2 = 0;
+begin
+   PrintLn('body');
+   Result := x div 2;
+ensure
+   Result * 2 = old x + 1;
+end;
+
+try
+   Half(3);
+except
+   on E: EAssertionFailed do PrintLn(E.Message);
+end;
+try
+   Half(4);
+except
+   on E: EAssertionFailed do PrintLn(E.Message);
+end;
+`
+
+// TestWithContracts verifies that each contract mode evaluates the expected
+// require/ensure clauses and that violations name the function, position and
+// condition text.
+func TestWithContracts(t *testing.T) {
+	const (
+		pre  = "Pre-condition failed in Half [line: 4, column: 4], x mod 2 = 0\n"
+		post = "Post-condition failed in Half [line: 9, column: 4], Result * 2 = old x + 1\n"
+	)
+
+	tests := []struct {
+		name string
+		mode ContractMode
+		want string
+	}{
+		{name: "Full", mode: ContractsFull, want: pre + "body\n" + post},
+		{name: "Pre", mode: ContractsPre, want: pre + "body\n"},
+		{name: "Off", mode: ContractsOff, want: "body\nbody\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			engine, err := New(WithOutput(&buf), WithContracts(tt.mode))
+			if err != nil {
+				t.Fatalf("failed to create engine: %v", err)
+			}
+			if _, err := engine.Eval(contractsSource); err != nil {
+				t.Fatalf("Eval failed: %v", err)
+			}
+			if buf.String() != tt.want {
+				t.Errorf("output = %q, want %q", buf.String(), tt.want)
+			}
+		})
+	}
+}
+
+// TestContractsOldInNestedExpression verifies that old values are captured at
+// entry even when referenced inside calls and if-expressions.
+func TestContractsOldInNestedExpression(t *testing.T) {
+	source := `
+procedure Grow(var s: String; var n: Integer);
+begin
+   s := s + 'x';
+   n := n + 1;
+ensure
+   Length(s) = Length(old s) + 1;
+   n = (if old n > 0 then old n + 1 else 1);
+end;
+
+var s := 'ab';
+var n := 2;
+Grow(s, n);
+PrintLn(s + ' ' + IntToStr(n));
+`
+	var buf bytes.Buffer
+	engine, err := New(WithOutput(&buf))
+	if err != nil {
+		t.Fatalf("failed to create engine: %v", err)
+	}
+	if _, err := engine.Eval(source); err != nil {
+		t.Fatalf("Eval failed: %v", err)
+	}
+	if buf.String() != "abx 3\n" {
+		t.Errorf("output = %q, want %q", buf.String(), "abx 3\n")
+	}
+}
+
+func TestWithContractsInvalidMode(t *testing.T) {
+	if _, err := New(WithContracts(ContractMode(99))); err == nil {
+		t.Error("expected error for invalid contract mode")
+	}
+}
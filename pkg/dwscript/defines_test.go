@@ -0,0 +1,187 @@
+package dwscript
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const definesSource = `
+{$IFDEF DEBUG}
+PrintLn('debug');
+{$ELSE}
+PrintLn('release');
+{$ENDIF}
+{$IFNDEF FEATURE_X}
+PrintLn('no feature');
+{$ENDIF}
+{$IFDEF DWSCRIPT}
+PrintLn('dwscript');
+{$ENDIF}
+`
+
+// TestWithDefines verifies that engine-level defines drive conditional
+// compilation.
+func TestWithDefines(t *testing.T) {
+	tests := []struct {
+		name    string
+		defines []string
+		want    string
+	}{
+		{name: "none", want: "release\nno feature\ndwscript\n"},
+		{name: "debug", defines: []string{"DEBUG"}, want: "debug\nno feature\ndwscript\n"},
+		{name: "case-insensitive", defines: []string{"debug", "Feature_X"}, want: "debug\ndwscript\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			engine, err := New(WithOutput(&buf), WithDefines(tt.defines...))
+			if err != nil {
+				t.Fatalf("failed to create engine: %v", err)
+			}
+			if _, err := engine.Eval(definesSource); err != nil {
+				t.Fatalf("Eval failed: %v", err)
+			}
+			if buf.String() != tt.want {
+				t.Errorf("output = %q, want %q", buf.String(), tt.want)
+			}
+		})
+	}
+}
+
+func TestWithDefinesEmptyName(t *testing.T) {
+	if _, err := New(WithDefines("")); err == nil {
+		t.Fatal("expected an error for an empty define name")
+	}
+}
+
+// TestUnterminatedConditional verifies that an {$IFDEF} without {$ENDIF}
+// fails to compile, reporting the opening directive's position.
+func TestUnterminatedConditional(t *testing.T) {
+	engine, err := New()
+	if err != nil {
+		t.Fatalf("failed to create engine: %v", err)
+	}
+
+	_, err = engine.Compile("PrintLn('a');\n{$IFDEF DEBUG}\nPrintLn('b');\n")
+	compileErr, ok := err.(*CompileError)
+	if !ok {
+		t.Fatalf("expected *CompileError, got %v", err)
+	}
+	if len(compileErr.Errors) == 0 {
+		t.Fatal("expected at least one error")
+	}
+	first := compileErr.Errors[0]
+	if !strings.Contains(first.Message, "unfinished conditional directive") {
+		t.Errorf("message = %q, want it to mention the unfinished conditional", first.Message)
+	}
+	if first.Line != 2 || first.Column != 1 {
+		t.Errorf("position = %d:%d, want 2:1", first.Line, first.Column)
+	}
+}
+
+// TestConditionalPreservesLineNumbers verifies that diagnostics in code after
+// a skipped block point at the original source lines.
+func TestConditionalPreservesLineNumbers(t *testing.T) {
+	engine, err := New()
+	if err != nil {
+		t.Fatalf("failed to create engine: %v", err)
+	}
+
+	source := "{$IFDEF DEBUG}\nvar a := 1;\nvar b := 2;\n{$ENDIF}\nvar c: Integer := 'x';\n"
+	_, err = engine.Compile(source)
+	compileErr, ok := err.(*CompileError)
+	if !ok || len(compileErr.Errors) == 0 {
+		t.Fatalf("expected a compile error, got %v", err)
+	}
+	if line := compileErr.Errors[0].Line; line != 5 {
+		t.Errorf("error line = %d, want 5 (%s)", line, compileErr.Errors[0].Message)
+	}
+}
+
+// TestWithIncludePaths verifies that {$INCLUDE} files are found in the
+// configured paths and that nested conditionals apply inside them.
+func TestWithIncludePaths(t *testing.T) {
+	dir := t.TempDir()
+	lib := filepath.Join(dir, "lib")
+	if err := os.Mkdir(lib, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	inc := "{$IFDEF DEBUG}\nPrintLn('included debug');\n{$ELSE}\nPrintLn('included');\n{$ENDIF}\n"
+	if err := os.WriteFile(filepath.Join(lib, "common.inc"), []byte(inc), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	engine, err := New(WithOutput(&buf), WithIncludePaths(dir, lib), WithDefines("DEBUG"))
+	if err != nil {
+		t.Fatalf("failed to create engine: %v", err)
+	}
+	if _, err := engine.Eval("{$INCLUDE 'common.inc'}\nPrintLn('main');"); err != nil {
+		t.Fatalf("Eval failed: %v", err)
+	}
+	if want := "included debug\nmain\n"; buf.String() != want {
+		t.Errorf("output = %q, want %q", buf.String(), want)
+	}
+
+	if _, err := engine.Compile("{$INCLUDE 'missing.inc'}"); err == nil {
+		t.Error("expected a compile error for a missing include file")
+	}
+}
+
+// TestIncludeErrorSource verifies that compile and runtime errors raised in
+// an included file name that file, while errors in the main source keep an
+// empty Source.
+func TestIncludeErrorSource(t *testing.T) {
+	dir := t.TempDir()
+	badPath := filepath.Join(dir, "bad.inc")
+	if err := os.WriteFile(badPath, []byte("PrintLn('ok');\nvar x := ;\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	boomPath := filepath.Join(dir, "boom.inc")
+	boom := "procedure Boom;\nbegin\n  raise Exception.Create('boom');\nend;\n"
+	if err := os.WriteFile(boomPath, []byte(boom), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	engine, err := New(WithIncludePaths(dir))
+	if err != nil {
+		t.Fatalf("failed to create engine: %v", err)
+	}
+
+	_, err = engine.Compile("PrintLn('main');\n{$INCLUDE 'bad.inc'}\n")
+	compileErr, ok := err.(*CompileError)
+	if !ok || len(compileErr.Errors) == 0 {
+		t.Fatalf("expected a compile error, got %v", err)
+	}
+	if first := compileErr.Errors[0]; first.Source != badPath || first.Line != 2 {
+		t.Errorf("error at %s:%d, want %s:2 (%s)", first.Source, first.Line, badPath, first.Message)
+	}
+
+	_, err = engine.Compile("var y := ;\n")
+	compileErr, ok = err.(*CompileError)
+	if !ok || len(compileErr.Errors) == 0 {
+		t.Fatalf("expected a compile error, got %v", err)
+	}
+	if source := compileErr.Errors[0].Source; source != "" {
+		t.Errorf("main source error Source = %q, want empty", source)
+	}
+
+	var buf bytes.Buffer
+	engine, err = New(WithOutput(&buf), WithIncludePaths(dir))
+	if err != nil {
+		t.Fatalf("failed to create engine: %v", err)
+	}
+	_, err = engine.Eval("{$INCLUDE 'boom.inc'}\nBoom;\n")
+	runtimeErr, ok := err.(*RuntimeError)
+	if !ok {
+		t.Fatalf("expected *RuntimeError, got %v", err)
+	}
+	if runtimeErr.Source != boomPath || runtimeErr.Line != 3 || runtimeErr.Column != 33 {
+		t.Errorf("runtime error at %s:%d:%d, want %s:3:33",
+			runtimeErr.Source, runtimeErr.Line, runtimeErr.Column, boomPath)
+	}
+}
@@ -0,0 +1,115 @@
+package dwscript
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+const destructorScript = `
+type TBase = class
+  Name: String;
+  destructor Destroy; override;
+end;
+type TChild = class(TBase)
+  destructor Destroy; override;
+end;
+
+destructor TBase.Destroy;
+begin
+  PrintLn('TBase.Destroy ' + Name);
+  inherited Destroy;
+end;
+
+destructor TChild.Destroy;
+begin
+  PrintLn('TChild.Destroy ' + Name);
+  inherited;
+end;
+
+var b: TBase := TChild.Create;
+b.Name := 'b';
+b.Free;
+try
+  PrintLn(b.Name);
+except
+  on E: Exception do PrintLn('read: ' + E.Message);
+end;
+try
+  b.Name := 'x';
+except
+  on E: Exception do PrintLn('write: ' + E.Message);
+end;
+
+var c := TChild.Create;
+c.Name := 'c';
+FreeAndNil(c);
+PrintLn(c = nil);
+FreeAndNil(c);`
+
+// runDestructorScript runs destructorScript with opts and returns its output.
+func runDestructorScript(t *testing.T, opts ...Option) string {
+	t.Helper()
+	var buf bytes.Buffer
+	engine, err := New(append([]Option{WithOutput(&buf)}, opts...)...)
+	if err != nil {
+		t.Fatalf("failed to create engine: %v", err)
+	}
+	program, err := engine.Compile(destructorScript)
+	if err != nil {
+		t.Fatalf("Compile failed: %v", err)
+	}
+	if _, err := engine.Run(program); err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	return buf.String()
+}
+
+// TestFreeRunsDestructorChain verifies that Free and FreeAndNil run the
+// most-derived destructor first, then the inherited ones, and that members
+// of a freed object can no longer be accessed.
+func TestFreeRunsDestructorChain(t *testing.T) {
+	output := runDestructorScript(t)
+
+	want := []string{
+		"TChild.Destroy b",
+		"TBase.Destroy b",
+		"read: Object already destroyed",
+		"write: Object already destroyed",
+		"TChild.Destroy c",
+		"TBase.Destroy c",
+		"True",
+	}
+	lines := strings.Split(strings.TrimSpace(output), "\n")
+	if len(lines) != len(want) {
+		t.Fatalf("output = %q, want %d lines", output, len(want))
+	}
+	for i, line := range lines {
+		if !strings.HasPrefix(line, want[i]) {
+			t.Errorf("line %d = %q, want prefix %q", i, line, want[i])
+		}
+	}
+}
+
+// TestWithDestructorsDisabled verifies that pure GC mode skips destructors
+// and keeps freed objects usable, while FreeAndNil still clears its variable.
+func TestWithDestructorsDisabled(t *testing.T) {
+	output := runDestructorScript(t, WithDestructors(false))
+
+	if want := "b\nTrue\n"; output != want {
+		t.Errorf("output = %q, want %q", output, want)
+	}
+}
+
+// TestFreeAndNilRequiresObjectVariable verifies that FreeAndNil rejects
+// non-object arguments at compile time.
+func TestFreeAndNilRequiresObjectVariable(t *testing.T) {
+	engine, err := New(WithOutput(&bytes.Buffer{}))
+	if err != nil {
+		t.Fatalf("failed to create engine: %v", err)
+	}
+	_, err = engine.Compile("var i: Integer; FreeAndNil(i);")
+	if err == nil || !strings.Contains(err.Error(), "FreeAndNil") {
+		t.Errorf("Compile error = %v, want FreeAndNil argument error", err)
+	}
+}
@@ -0,0 +1,100 @@
+package dwscript
+
+import (
+	"github.com/cwbudde/go-dws/internal/frontend"
+	"github.com/cwbudde/go-dws/pkg/token"
+)
+
+// Phase names the compilation stage that reported a diagnostic. The values
+// match CompileError.Stage.
+type Phase string
+
+const (
+	// PhaseParsing marks lexer and parser diagnostics.
+	PhaseParsing Phase = "parsing"
+	// PhaseTypeChecking marks diagnostics from semantic analysis.
+	PhaseTypeChecking Phase = "type checking"
+	// PhaseBytecode marks errors from the bytecode compiler.
+	PhaseBytecode Phase = "bytecode"
+)
+
+// Diagnostic is a single message reported while compiling a program: an
+// error, warning, hint or informational note.
+//
+// Start and End delimit the source range the diagnostic refers to, using the
+// same 1-based coordinates as Error. End equals Start when the compiler did
+// not report a length. Both positions carry the Source of an {$INCLUDE}d
+// file when the diagnostic points into one.
+type Diagnostic struct {
+	Message  string
+	Code     string
+	Phase    Phase
+	Start    token.Position
+	End      token.Position
+	Severity ErrorSeverity
+}
+
+// IsError returns true if the diagnostic is an error.
+func (d Diagnostic) IsError() bool {
+	return d.Severity == SeverityError
+}
+
+// Diagnostics returns every diagnostic reported while compiling the program,
+// regardless of phase or severity, in the order CompileError lists them.
+//
+// A successfully compiled program may still report warnings and hints. When
+// Compile fails, the partially compiled program is available through
+// CompileError.Program, and its Diagnostics include the errors as well:
+//
+//	program, err := engine.Compile(source)
+//	if compileErr, ok := err.(*dwscript.CompileError); ok {
+//	    program = compileErr.Program
+//	}
+//	for _, d := range program.Diagnostics() {
+//	    publish(d.Start, d.End, d.Severity, d.Code, d.Message)
+//	}
+func (p *Program) Diagnostics() []Diagnostic {
+	if p == nil {
+		return nil
+	}
+	p.bytecodeMu.Lock()
+	defer p.bytecodeMu.Unlock()
+	return append([]Diagnostic(nil), p.diagnostics...)
+}
+
+func diagnosticsFromFrontend(result *frontend.Result) []Diagnostic {
+	diagnostics := make([]Diagnostic, 0, len(result.Diagnostics))
+	for _, diag := range result.Diagnostics {
+		phase := PhaseParsing
+		if diag.Phase == frontend.PhaseSemantic {
+			phase = PhaseTypeChecking
+		}
+		start := token.Position{Source: diag.Source, Line: diag.Line, Column: diag.Column}
+		end := start
+		end.Column += diag.Length
+		diagnostics = append(diagnostics, Diagnostic{
+			Message:  diag.Message,
+			Code:     diag.Code,
+			Phase:    phase,
+			Start:    start,
+			End:      end,
+			Severity: severityFromFrontend(diag.Severity),
+		})
+	}
+	return diagnostics
+}
+
+// diagnosticFromError converts a structured compile error to a Diagnostic.
+func diagnosticFromError(err *Error, phase Phase) Diagnostic {
+	start := token.Position{Source: err.Source, Line: err.Line, Column: err.Column}
+	end := start
+	end.Column += err.Length
+	return Diagnostic{
+		Message:  err.Message,
+		Code:     err.Code,
+		Phase:    phase,
+		Start:    start,
+		End:      end,
+		Severity: err.Severity,
+	}
+}
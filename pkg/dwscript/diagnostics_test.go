@@ -0,0 +1,93 @@
+package dwscript
+
+import (
+	"strings"
+	"testing"
+)
+
+// TestDiagnostics_WarningAndError verifies that a failed compile exposes
+// both its warnings and its errors, with phase and range metadata, through
+// the partially compiled program.
+func TestDiagnostics_WarningAndError(t *testing.T) {
+	engine, err := New()
+	if err != nil {
+		t.Fatalf("failed to create engine: %v", err)
+	}
+
+	source := "var i: Integer;\nwhile True do\n  i := i + 1;\nvar s: String := 1;\nvar x := ;\n"
+	_, err = engine.Compile(source)
+	compileErr, ok := err.(*CompileError)
+	if !ok {
+		t.Fatalf("expected *CompileError, got %v", err)
+	}
+	if compileErr.Program == nil {
+		t.Fatal("CompileError.Program is nil")
+	}
+
+	diags := compileErr.Program.Diagnostics()
+	var warning, parseErr *Diagnostic
+	for i := range diags {
+		switch d := &diags[i]; {
+		case d.Severity == SeverityWarning && strings.Contains(d.Message, "Infinite loop"):
+			warning = d
+		case d.IsError() && d.Phase == PhaseParsing:
+			parseErr = d
+		}
+	}
+
+	if warning == nil {
+		t.Fatalf("no infinite loop warning in %+v", diags)
+	}
+	if warning.Phase != PhaseTypeChecking || warning.Code == "" {
+		t.Errorf("warning phase/code = %q/%q, want %q and a code", warning.Phase, warning.Code, PhaseTypeChecking)
+	}
+	if warning.Start.Line != 2 || warning.Start.Column != 1 {
+		t.Errorf("warning at %d:%d, want 2:1", warning.Start.Line, warning.Start.Column)
+	}
+
+	if parseErr == nil {
+		t.Fatalf("no parse error in %+v", diags)
+	}
+	if parseErr.Code != "E_INVALID_EXPRESSION" {
+		t.Errorf("parse error code = %q, want E_INVALID_EXPRESSION", parseErr.Code)
+	}
+	if parseErr.Start.Line != 5 || parseErr.Start.Column != 10 || parseErr.End.Column <= parseErr.Start.Column {
+		t.Errorf("parse error range = %+v..%+v, want a span starting at 5:10", parseErr.Start, parseErr.End)
+	}
+
+	if len(diags) != len(compileErr.Errors) {
+		t.Errorf("got %d diagnostics, CompileError has %d errors", len(diags), len(compileErr.Errors))
+	}
+	if _, err := engine.Run(compileErr.Program); err != compileErr {
+		t.Errorf("Run of a partially compiled program = %v, want the compile error", err)
+	}
+}
+
+// TestDiagnostics_SuccessfulCompile verifies that warnings survive a
+// successful compile and that a clean program reports nothing.
+func TestDiagnostics_SuccessfulCompile(t *testing.T) {
+	engine, err := New()
+	if err != nil {
+		t.Fatalf("failed to create engine: %v", err)
+	}
+
+	program, err := engine.Compile("var i: Integer;\nwhile True do\n  i := i + 1;\n")
+	if err != nil {
+		t.Fatalf("Compile failed: %v", err)
+	}
+	diags := program.Diagnostics()
+	if len(diags) != 1 {
+		t.Fatalf("got %d diagnostics, want 1: %+v", len(diags), diags)
+	}
+	if d := diags[0]; d.Severity != SeverityWarning || d.Phase != PhaseTypeChecking || d.Start.Line != 2 {
+		t.Errorf("diagnostic = %+v, want a type checking warning on line 2", d)
+	}
+
+	program, err = engine.Compile("PrintLn('ok');")
+	if err != nil {
+		t.Fatalf("Compile failed: %v", err)
+	}
+	if diags := program.Diagnostics(); len(diags) != 0 {
+		t.Errorf("clean program reported %+v", diags)
+	}
+}
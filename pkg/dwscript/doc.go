@@ -51,6 +51,40 @@
 //	    fmt.Println(result.Output)
 //	}
 //
+// RunContext stops a run when its context is cancelled or times out, even in
+// the middle of an endless loop:
+//
+//	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+//	defer cancel()
+//	result, err := engine.RunContext(ctx, program, nil) // err is context.DeadlineExceeded
+//
+// A compiled program can also be saved and loaded in a later process, which
+// skips parsing and semantic analysis. Blobs from another EngineVersion are
+// rejected by LoadProgram:
+//
+//	data, _ := program.MarshalBinary()
+//	os.WriteFile("script.dwp", data, 0o644)
+//	...
+//	loaded, err := dwscript.LoadProgram(data)
+//	result, err := engine.Run(loaded)
+//
+// # Sessions
+//
+// A Session evaluates scripts that build on each other, like the inputs of
+// a REPL. Declarations of one Eval are visible to the next, and Snapshot and
+// Restore roll the global state back:
+//
+//	session, _ := engine.NewSession()
+//	session.Eval(`var Items: array of Integer;`)
+//	saved := session.Snapshot()
+//	session.Eval(`Items.Add(42);`)
+//	session.Restore(saved) // Items is empty again
+//
+// Result.ExpressionValue reports the value of a trailing bare expression such
+// as "1 + 2", and Engine.IsIncomplete tells source that ends inside an open
+// block or string from a syntax error. Package repl builds an interactive
+// loop on both.
+//
 // # Structured Errors
 //
 // The package provides structured error information with precise position data,
@@ -72,6 +106,26 @@
 // All positions use 1-based line and column numbering, matching most editors
 // and IDEs. The Length field indicates the span of the error in characters.
 //
+// Errors inside a file pulled in with {$INCLUDE} set Source to that file's
+// path, and their Line and Column are relative to it; Source is empty for
+// errors in the compiled source itself. RuntimeError carries the same
+// Source, Line and Column when the failing position is known.
+//
+// # Diagnostics
+//
+// Program.Diagnostics returns every error, warning and hint from one compile
+// as a uniform list, each with its phase, code, severity and start/end range.
+// A successful compile keeps its warnings and hints; a failed one exposes the
+// partially compiled program through CompileError.Program:
+//
+//	program, err := engine.Compile(source)
+//	if compileErr, ok := err.(*dwscript.CompileError); ok {
+//	    program = compileErr.Program
+//	}
+//	for _, d := range program.Diagnostics() {
+//	    fmt.Printf("%s %s at %d:%d: %s\n", d.Phase, d.Severity, d.Start.Line, d.Start.Column, d.Message)
+//	}
+//
 // # AST Access
 //
 // Access the Abstract Syntax Tree for advanced use cases like code analysis,
@@ -135,6 +189,24 @@
 //	    fmt.Printf("Type at position: %s\n", typeStr) // "Integer"
 //	}
 //
+// With WithConstantFolding enabled, ConstantAt reports the compile-time value
+// of the expression at a position, such as "const 86400" for 24 * 60 * 60.
+//
+// # References and Rename
+//
+// Find every reference to the symbol at a position, or compute the text
+// edits that rename it:
+//
+//	refs := program.ReferencesTo(pos)
+//	edits, err := program.RenamePreview(pos, "total")
+//	if err != nil {
+//	    // newName is a keyword or clashes with another name in scope
+//	}
+//
+// RenamePreview handles variables, constants, parameters and routines.
+// Rename also renames class members, together with their overloads,
+// overrides and call sites through base class variables.
+//
 // # Parse-Only Mode
 //
 // For LSP servers and IDEs that need fast syntax checking without full
@@ -163,6 +235,17 @@
 //	    // Use for syntax highlighting, outline view, etc.
 //	}
 //
+// The parser resumes at the next statement after a syntax error, so the
+// partial AST keeps the statements around a mistake. Statements it could not
+// make sense of are represented by *ast.InvalidStatement nodes.
+//
+// Editors that reparse on every keystroke can use ParseIncremental instead.
+// It reuses the previous tree and reparses only the top-level declaration
+// an edit falls inside, falling back to a full parse whenever that is not
+// safe:
+//
+//	tree, err = engine.ParseIncremental(tree, oldText, newText, edits)
+//
 // # LSP Integration
 //
 // This package is designed to support Language Server Protocol (LSP) implementations.
@@ -171,6 +254,7 @@
 // Key features for LSP support:
 //   - Structured errors with precise position information
 //   - Fast parse-only mode (Parse method)
+//   - Incremental reparsing after edits (ParseIncremental method)
 //   - Complete AST access with position metadata
 //   - Symbol table extraction
 //   - Type information at position
@@ -182,9 +266,26 @@
 //
 //	engine, _ := dwscript.New(
 //	    dwscript.WithMaxRecursionDepth(2048),
+//	    dwscript.WithRecursionStrategy(dwscript.RecursionHeap), // Deep recursion off the goroutine stack
+//	    dwscript.WithObjectPooling(false), // Allocate every value afresh (debugging)
 //	    dwscript.WithOutput(os.Stdout),
 //	    dwscript.WithTypeCheck(true), // Enable type checking
 //	    dwscript.WithCompileMode(dwscript.CompileModeBytecode), // Use bytecode VM (experimental)
+//	    dwscript.WithVariantOverflow(dwscript.VariantOverflowError), // Raise on Variant Integer overflow
+//	    dwscript.WithIntegerOverflowCheck(true), // Raise EIntOverflow on Integer overflow
+//	    dwscript.WithRangeChecks(true), // Raise ERangeError on out-of-range subrange writes
+//	    dwscript.WithConstantFolding(true), // Evaluate constant expressions at compile time
+//	    dwscript.WithCompileCache(128), // Reuse compiled programs for repeated source
+//	    dwscript.WithAssertions(false), // Compile out Assert calls
+//	    dwscript.WithContracts(dwscript.ContractsPre), // Check require clauses only
+//	    dwscript.WithRandomSeed(42), // Reproducible Random sequences
+//	    dwscript.WithDestructors(false), // Pure GC mode: Free is a no-op
+//	    dwscript.WithFormatSettings(german), // Locale separators for FloatToStr etc.
+//	    dwscript.WithClock(clock), // Time source for Now, Date and Time
+//	    dwscript.WithDefines("DEBUG"), // Predefine symbols for {$IFDEF}
+//	    dwscript.WithIncludePaths("scripts/inc"), // Resolve {$INCLUDE 'file'}
+//	    dwscript.WithUnitResolver(loadUnit), // Resolve units named in uses clauses
+//	    dwscript.WithMaxParseErrors(20), // Report at most 20 syntax errors
 //	)
 //
 // # Foreign Function Interface (FFI)
@@ -202,6 +303,89 @@
 //	    PrintLn(IntToStr(x));
 //	`)
 //
+// A non-nil error returned by a registered function is raised as an EExternal
+// exception carrying the error's message. Use RegisterFunctionWithExceptionClass
+// to raise a different exception class. A panic in a registered function is
+// recovered and raised as EExternal at the call site.
+//
+// Parameters of a func type accept DWScript lambdas, function pointers and
+// method pointers. Calling the Go func runs the script function in its
+// captured scope and converts the result to the declared Go return type:
+//
+//	engine.RegisterFunction("SumThree", func(f func(int64) int64) int64 {
+//	    return f(1) + f(2) + f(3)
+//	})
+//
+// An exception raised by the script function is returned as the error when the
+// func type has an error result; otherwise it propagates out of the host
+// function unchanged.
+//
+// # Host Globals
+//
+// Inject Go values as predeclared global variables before compiling. Their
+// DWScript types are inferred from the Go values, so type checking still
+// applies:
+//
+//	engine.SetGlobal("Limit", 3)
+//	engine.SetGlobal("Names", []string{"Ann", "Bob"})
+//
+//	result, _ := engine.Eval(`
+//	    for var i := 0 to Limit - 2 do
+//	        PrintLn(Names[i]);
+//	`)
+//
+// # Lambdas and Closures
+//
+// Lambdas capture the variables of their enclosing scope by reference: a
+// closure sees later assignments to a captured variable, and its own
+// assignments are visible outside. The control variable of a for or for-in
+// loop is the exception. It is captured by value, as a snapshot of the
+// iteration that created the closure, so each closure keeps its own value:
+//
+//	type TGetter = function: Integer;
+//	var getters: array of TGetter;
+//	for var i := 1 to 3 do
+//	    getters.Add(lambda => i);
+//	for var g in getters do
+//	    PrintLn(g()); // 1, 2, 3
+//
+// Lambda parameters without a type take theirs from the function pointer type
+// expected where the lambda is used. Without one, they are Variant and bound
+// late: var double := lambda (x) => x * 2; accepts any operand of *.
+//
+// # Units
+//
+// Scripts can split code into units and import them with uses. The host
+// supplies each unit's source by name through WithUnitResolver; units used by
+// those units are loaded the same way, and circular uses are reported as a
+// compile error:
+//
+//	sources := map[string]string{"mathutils": `unit MathUtils;
+//	interface
+//	function Add(a, b: Integer): Integer;
+//	implementation
+//	function Add(a, b: Integer): Integer;
+//	begin
+//	    Result := a + b;
+//	end;
+//	end.`}
+//
+//	engine, _ := dwscript.New(dwscript.WithUnitResolver(func(name string) (string, error) {
+//	    if source, ok := sources[strings.ToLower(name)]; ok {
+//	        return source, nil
+//	    }
+//	    return "", fmt.Errorf("unit %q not found", name)
+//	}))
+//	engine.Eval("uses MathUtils;\nPrintLn(Add(1, 2));")
+//
+// RegisterUnit provides a unit implemented in Go instead. Its functions and
+// constants are accessed with qualified names:
+//
+//	engine.RegisterUnit("MyLib", map[string]any{
+//	    "Twice": func(x int64) int64 { return 2 * x },
+//	}, map[string]any{"Version": "1.2"})
+//	engine.Eval("uses MyLib;\nPrintLn(MyLib.Twice(21));")
+//
 // # Position Coordinate System
 //
 // All position information uses 1-based indexing for both lines and columns:
@@ -231,9 +415,31 @@
 //
 // # Thread Safety
 //
-// Engine instances are safe for concurrent use. However, Program and Result
-// instances are not thread-safe and should not be shared across goroutines
-// without external synchronization.
+// Engine instances are safe for concurrent use, and a compiled Program may be
+// run from several goroutines at once: compile once, then call Run or
+// RunWithOutput per request. Every run builds its own execution state, so
+// global variables and output never leak between concurrent runs. Runs never
+// modify the Program itself: a function implementation that replaces its
+// forward or interface declaration is recorded in the run's own registry, and
+// each Program gets a private copy of the units it uses. Runs that share the
+// engine's output writer interleave their output; give each run its own
+// writer with RunWithOutput:
+//
+//	program, _ := engine.Compile(source)
+//	go func() {
+//	    var out bytes.Buffer
+//	    result, err := engine.RunWithOutput(program, &out)
+//	    // ...
+//	}()
+//
+// Result instances are not thread-safe and should not be shared across
+// goroutines without external synchronization.
+//
+// Script-level shared state such as class variables (class var) lives in the
+// run that created it: initializers execute once per run, when the class
+// declaration executes, and every instance of the class observes the same
+// value. Each run starts from a fresh interpreter, so class variables are
+// re-initialized on every run and never leak from one run into another.
 //
 // # Compatibility
 //
@@ -20,23 +20,52 @@ package dwscript
 
 import (
 	"bytes"
+	"context"
+	"errors"
 	"fmt"
 	"io"
 	"strings"
+	"sync"
 
+	"github.com/cwbudde/go-dws/internal/builtins"
 	"github.com/cwbudde/go-dws/internal/bytecode"
 	"github.com/cwbudde/go-dws/internal/frontend"
 	"github.com/cwbudde/go-dws/internal/interp"
 	"github.com/cwbudde/go-dws/internal/interp/runner"
+	"github.com/cwbudde/go-dws/internal/interp/runtime"
+	"github.com/cwbudde/go-dws/internal/lexer"
+	"github.com/cwbudde/go-dws/internal/parser"
 	"github.com/cwbudde/go-dws/internal/semantic"
+	"github.com/cwbudde/go-dws/internal/types"
+	"github.com/cwbudde/go-dws/internal/units"
 	"github.com/cwbudde/go-dws/pkg/ast"
+	"github.com/cwbudde/go-dws/pkg/ident"
 )
 
 // Engine is the main entry point for the DWScript interpreter.
 // It provides a high-level API for compiling and executing DWScript programs.
 type Engine struct {
 	externalFunctions *interp.ExternalFunctionRegistry
-	options           Options
+	// globals holds the values set with SetGlobal, guarded by globalsMu.
+	globals *ident.Map[hostGlobal]
+	// units holds the units registered with RegisterUnit, guarded by globalsMu.
+	units *ident.Map[*hostUnit]
+	// builtins holds the builtins left by UnregisterBuiltin and
+	// OverrideBuiltin, guarded by globalsMu; nil means the default builtins.
+	builtins *builtins.Registry
+	// overrides holds the types of the functions registered with
+	// OverrideBuiltin, guarded by globalsMu.
+	overrides *ident.Map[*types.FunctionType]
+	// cache holds compiled programs when WithCompileCache is used.
+	cache *compileCache
+	// unitCache holds the units parsed for uses clauses, so programs
+	// compiled by this engine share them.
+	unitCache *units.UnitCache
+	// cleanTrees holds weak references to the trees returned by Parse and
+	// ParseIncremental without syntax errors; see ParseIncremental.
+	cleanTrees sync.Map
+	options    Options
+	globalsMu  sync.Mutex
 }
 
 // New creates a new DWScript engine with the given options.
@@ -45,6 +74,7 @@ func New(opts ...Option) (*Engine, error) {
 	engine := &Engine{
 		options:           defaultOptions(),
 		externalFunctions: interp.NewExternalFunctionRegistry(),
+		unitCache:         units.NewUnitCache(),
 	}
 
 	for _, opt := range opts {
@@ -52,54 +82,101 @@ func New(opts ...Option) (*Engine, error) {
 			return nil, fmt.Errorf("failed to apply option: %w", err)
 		}
 	}
+	if engine.options.CompileCacheSize > 0 {
+		engine.cache = newCompileCache(engine.options.CompileCacheSize)
+	}
+	engine.options.valuePool = interp.NewValuePool(engine.options.ObjectPooling)
 
 	return engine, nil
 }
 
+// PoolStats returns the statistics of the engine's value pool, shared by
+// all programs and sessions of the engine (see WithObjectPooling).
+func (e *Engine) PoolStats() PoolStats {
+	return e.options.valuePool.Stats()
+}
+
 // Compile parses and type-checks the given DWScript source code,
 // returning a compiled Program that can be executed multiple times.
 //
 // This is useful when you want to compile once and run many times,
 // as it avoids re-parsing and re-checking the source code.
+//
+// With WithCompileCache, compiling source that was compiled before (with the
+// same host global names and types) returns a copy of the cached Program
+// without recompiling. Compile errors are not cached.
 func (e *Engine) Compile(source string) (*Program, error) {
+	globals := e.hostGlobals()
+	if e.cache == nil {
+		return e.compile(source, globals)
+	}
+
+	key := newCompileKey(source, globals)
+	if cached, ok := e.cache.get(key); ok {
+		return cached.cachedCopy(globals), nil
+	}
+	program, err := e.compile(source, globals)
+	if err != nil {
+		return nil, err
+	}
+	e.cache.put(key, program)
+	return program.cachedCopy(globals), nil
+}
+
+// compile parses, type-checks and, in bytecode mode, compiles source with
+// the given host globals predeclared.
+func (e *Engine) compile(source string, globals []hostGlobal) (*Program, error) {
+	builtinRegistry, overrides := e.hostBuiltins()
+	registeredUnits := e.hostUnits()
 	var result *frontend.Result
 	if e.options.TypeCheck {
-		result = frontend.Compile(source, "", semantic.HintsLevelPedantic)
+		result = frontend.CompileWithAnalysis(source, "", semantic.HintsLevelPedantic, e.parserConfig(), frontend.AnalysisOptions{
+			Globals:              frontendGlobals(globals),
+			Functions:            overrides,
+			Builtins:             builtinRegistry,
+			ConstantFolding:      e.options.ConstantFolding,
+			IntegerOverflowCheck: e.options.IntegerOverflowCheck,
+			StrictTypes:          e.options.StrictTypes,
+			UnitResolver:         e.options.UnitResolver,
+			UnitCache:            e.unitCache,
+			HostUnits:            frontendUnits(registeredUnits),
+			Defines:              e.options.Defines,
+			IncludePaths:         e.options.IncludePaths,
+		}, e.lexerOptions()...)
 	} else {
-		result = frontend.Parse(source)
+		result = frontend.ParseWithConfig(source, "", e.parserConfig(), e.lexerOptions()...)
+		e.linkUnits(result, registeredUnits)
 	}
 
-	if result.HasFatalDiagnostics() {
-		return nil, compileErrorFromFrontend(result)
+	options := e.options
+	options.ExternalFunctions = e.externalFunctions
+	options.Builtins = builtinRegistry
+
+	program := &Program{
+		ast:          result.Program,
+		analyzer:     result.Analyzer,
+		semanticInfo: result.SemanticInfo,
+		options:      options,
+		diagnostics:  diagnosticsFromFrontend(result),
+		globals:      boundHostGlobals(globals, result.Analyzer),
+		units:        registeredUnits,
 	}
 
-	program := result.Program
-	analyzer := result.Analyzer
-	semanticInfo := result.SemanticInfo
+	if result.HasFatalDiagnostics() {
+		return nil, program.fail(compileErrorFromFrontend(result))
+	}
 
-	var chunk *bytecode.Chunk
 	if e.options.CompileMode == CompileModeBytecode {
-		bc := bytecode.NewCompiler("dwscript")
-
-		// Pass semantic info to bytecode compiler
-		if semanticInfo != nil {
-			bc.SetSemanticInfo(semanticInfo)
-		}
-
-		var err error
-		chunk, err = bc.Compile(program)
-		if err != nil {
-			return nil, newBytecodeCompileError(err)
+		// Programs using constructs the bytecode compiler does not support
+		// yet fall back to the AST interpreter with a warning diagnostic.
+		if _, err := program.ensureBytecodeChunk(); err != nil {
+			compileErr := newBytecodeCompileError(err)
+			program.diagnostics = append(program.diagnostics, diagnosticFromError(compileErr.Errors[0], PhaseBytecode))
+			return nil, program.fail(compileErr)
 		}
 	}
 
-	return &Program{
-		ast:           program,
-		analyzer:      analyzer,
-		semanticInfo:  semanticInfo,
-		options:       e.options,
-		bytecodeChunk: chunk,
-	}, nil
+	return program, nil
 }
 
 // Parse parses the given DWScript source code and returns the AST without
@@ -139,7 +216,7 @@ func (e *Engine) Compile(source string) (*Program, error) {
 //	    }
 //	}
 func (e *Engine) Parse(source string) (*ast.Program, error) {
-	result := frontend.Parse(source)
+	result := frontend.ParseWithConfig(source, "", e.parserConfig(), e.lexerOptions()...)
 	program := result.Program
 
 	// Always return the AST (even if there are errors)
@@ -153,14 +230,54 @@ func (e *Engine) Parse(source string) (*ast.Program, error) {
 	}
 
 	// No errors - return the complete AST
+	e.rememberTree(program)
 	return program, nil
 }
 
+// IsIncomplete reports whether source ends inside an unfinished construct,
+// so that appending more input could make it compile: an open begin/end or
+// other block, an unterminated string literal or comment, or an expression
+// cut off after an operator. A REPL uses it to tell a line that continues on
+// the next one from a syntax error.
+func (e *Engine) IsIncomplete(source string) bool {
+	return frontend.ParseWithConfig(source, "", e.parserConfig(), e.lexerOptions()...).Incomplete
+}
+
+// parserConfig returns the parser configuration for the engine's options.
+func (e *Engine) parserConfig() parser.ParserConfig {
+	config := parser.DefaultConfig()
+	config.MaxErrors = e.options.MaxParseErrors
+	return config
+}
+
+// linkUnits splices the units named in the uses clauses of result's program
+// into it, like CompileWithAnalysis does when type checking.
+func (e *Engine) linkUnits(result *frontend.Result, registered []*hostUnit) {
+	frontend.LinkUnits(result, frontend.AnalysisOptions{
+		UnitResolver: e.options.UnitResolver,
+		UnitCache:    e.unitCache,
+		HostUnits:    frontendUnits(registered),
+		Defines:      e.options.Defines,
+		IncludePaths: e.options.IncludePaths,
+	})
+}
+
+// lexerOptions returns the lexer configuration for the engine's defines and
+// include paths.
+func (e *Engine) lexerOptions() []lexer.LexerOption {
+	opts := []lexer.LexerOption{lexer.WithDefines(e.options.Defines...)}
+	if paths := e.options.IncludePaths; len(paths) > 0 {
+		opts = append(opts, lexer.WithIncludeResolver(lexer.NewFileIncludeResolver(paths[0], paths[1:]...)))
+	}
+	return opts
+}
+
 func compileErrorFromFrontend(result *frontend.Result) *CompileError {
 	errors := make([]*Error, 0, len(result.Diagnostics))
 	for _, diag := range result.Diagnostics {
 		errors = append(errors, &Error{
 			Message:  diag.Message,
+			Source:   diag.Source,
 			Line:     diag.Line,
 			Column:   diag.Column,
 			Length:   diag.Length,
@@ -258,62 +375,119 @@ func extractPositionFromError(errStr string) (int, int, string) {
 }
 
 // Run executes a previously compiled Program and returns the result.
+//
+// Every call builds a fresh interpreter state and leaves the Program itself
+// unmodified: globals, class variables and records start from their compiled
+// initial values, so one Program may be run from several goroutines at once. Output goes to the engine's writer; use
+// RunWithOutput to give each concurrent run its own writer.
 func (e *Engine) Run(program *Program) (*Result, error) {
+	return e.run(context.Background(), program, e.options.Output)
+}
+
+// RunWithOutput executes a previously compiled Program like Run, but writes
+// its output to w instead of the engine's writer. If w is nil, the output is
+// captured in a fresh buffer and returned in Result.Output.
+//
+// RunWithOutput is safe for concurrent use; each call has its own output and
+// execution state.
+func (e *Engine) RunWithOutput(program *Program, w io.Writer) (*Result, error) {
+	return e.run(context.Background(), program, w)
+}
+
+// RunContext executes a previously compiled Program like RunWithOutput, but
+// stops it when ctx is cancelled or its deadline passes. The script stops at
+// its next statement or loop iteration, without running its except or
+// finally blocks. A stopped run returns ctx.Err() together with a Result
+// holding the output written so far.
+func (e *Engine) RunContext(ctx context.Context, program *Program, w io.Writer) (*Result, error) {
+	return e.run(ctx, program, w)
+}
+
+func (e *Engine) run(ctx context.Context, program *Program, output io.Writer) (*Result, error) {
 	if program == nil {
 		return nil, fmt.Errorf("program is nil")
 	}
+	if program.compileErr != nil {
+		return nil, program.compileErr
+	}
 
 	// Determine output writer
-	output := e.options.Output
 	if output == nil {
 		output = &bytes.Buffer{}
 	}
 
 	if e.options.CompileMode == CompileModeBytecode {
-		return e.runBytecode(program, output)
+		chunk, err := program.ensureBytecodeChunk()
+		if err != nil {
+			return nil, err
+		}
+		if chunk != nil {
+			return e.runBytecode(ctx, chunk, output)
+		}
 	}
 
-	return e.runInterpreter(program, output)
+	return e.runInterpreter(ctx, program, output)
 }
 
-func (e *Engine) runInterpreter(program *Program, output io.Writer) (*Result, error) {
-	e.options.ExternalFunctions = e.externalFunctions
-	interpreter := runner.NewWithOptions(output, &e.options)
-	if program.semanticInfo != nil {
-		interpreter.SetSemanticInfo(program.semanticInfo)
+func (e *Engine) runInterpreter(ctx context.Context, program *Program, output io.Writer) (*Result, error) {
+	// Each run gets its own copy of the options so that concurrent runs
+	// never write to the engine's shared state.
+	opts := e.options
+	opts.Output = output
+	opts.ExternalFunctions = e.externalFunctions
+	opts.Builtins, _ = e.hostBuiltins()
+	interpreter, err := program.newInterpreter(&opts)
+	if err != nil {
+		return nil, err
 	}
+	interpreter.SetInterrupt(contextInterrupt(ctx))
 	value := interpreter.Eval(program.ast)
 
+	if err := ctx.Err(); err != nil {
+		return &Result{
+			Output:  extractOutput(output),
+			Success: false,
+		}, err
+	}
 	if value != nil && value.Type() == "ERROR" {
 		return &Result{
-				Output:  extractOutput(output),
-				Success: false,
-			}, &RuntimeError{
-				Message: value.String(),
-			}
+			Output:  extractOutput(output),
+			Success: false,
+		}, newRuntimeError(value)
 	}
 
-	return &Result{
+	result := &Result{
 		Output:  extractOutput(output),
 		Success: true,
-	}, nil
-}
-
-func (e *Engine) runBytecode(program *Program, output io.Writer) (*Result, error) {
-	chunk, err := program.ensureBytecodeChunk()
-	if err != nil {
-		return nil, err
+		globals: captureGlobals(interpreter, program.globals, program.ast),
 	}
+	if endsWithExpression(program.ast) {
+		result.value = value
+	}
+	return result, nil
+}
 
+func (e *Engine) runBytecode(ctx context.Context, chunk *bytecode.Chunk, output io.Writer) (*Result, error) {
 	vm := bytecode.NewVMWithOutput(output)
+	if e.options.FixedRandomSeed {
+		vm.FixRandomSeed(e.options.RandomSeed)
+	}
+	vm.SetDecimalSeparator(e.options.FormatSettings.DecimalSeparator)
+	vm.SetInterrupt(contextInterrupt(ctx))
 	if _, err := vm.Run(chunk); err != nil {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return &Result{
+				Output:  extractOutput(output),
+				Success: false,
+			}, ctxErr
+		}
 		if runtimeErr, ok := err.(*bytecode.RuntimeError); ok {
 			return &Result{
-					Output:  extractOutput(output),
-					Success: false,
-				}, &RuntimeError{
-					Message: runtimeErr.Error(),
-				}
+				Output:  extractOutput(output),
+				Success: false,
+			}, &RuntimeError{
+				Message: runtimeErr.Error(),
+			}
 		}
 
 		return &Result{
@@ -328,6 +502,23 @@ func (e *Engine) runBytecode(program *Program, output io.Writer) (*Result, error
 	}, nil
 }
 
+// contextInterrupt returns the function a run polls to learn that ctx is
+// done, or nil for a context that is never cancelled. It calls ctx.Done on
+// every poll, so a context may use Done to run code while a script runs.
+func contextInterrupt(ctx context.Context) func() bool {
+	if ctx.Done() == nil {
+		return nil
+	}
+	return func() bool {
+		select {
+		case <-ctx.Done():
+			return true
+		default:
+			return false
+		}
+	}
+}
+
 func extractOutput(output io.Writer) string {
 	if buf, ok := output.(*bytes.Buffer); ok {
 		return buf.String()
@@ -349,27 +540,55 @@ func (e *Engine) Eval(source string) (*Result, error) {
 		return nil, err
 	}
 
-	// If no output was specified, capture to a buffer
-	if e.options.Output == nil {
-		var buf bytes.Buffer
-		oldOutput := e.options.Output
-		e.options.Output = &buf
-		result, err := e.Run(program)
-		e.options.Output = oldOutput
-		return result, err
-	}
-
-	return e.Run(program)
+	// If no output was specified, run captures to a buffer
+	return e.run(context.Background(), program, e.options.Output)
 }
 
 // Program represents a compiled DWScript program.
-// It can be executed multiple times without re-compilation.
+// It can be executed multiple times without re-compilation, also from
+// several goroutines at once.
 type Program struct {
-	ast           *ast.Program
-	analyzer      *semantic.Analyzer
-	semanticInfo  *ast.SemanticInfo
+	ast          *ast.Program
+	analyzer     *semantic.Analyzer
+	semanticInfo *ast.SemanticInfo
+	// bytecodeMu guards the lazily compiled bytecodeChunk, bytecodeFallback
+	// and the diagnostics appended by the bytecode compiler.
+	bytecodeMu    sync.Mutex
 	bytecodeChunk *bytecode.Chunk
-	options       Options
+	// bytecodeFallback is set when the program uses a construct the
+	// bytecode compiler does not support and runs on the AST interpreter.
+	bytecodeFallback bool
+	compileErr       *CompileError
+	diagnostics      []Diagnostic
+	globals          []hostGlobal
+	// units are the units registered with RegisterUnit when the program
+	// was compiled.
+	units   []*hostUnit
+	options Options
+}
+
+// newInterpreter returns an AST interpreter for the program, configured with
+// opts, with the program's host globals and units defined.
+func (p *Program) newInterpreter(opts *Options) (*interp.Interpreter, error) {
+	interpreter := runner.NewWithOptions(opts.Output, opts)
+	if p.semanticInfo != nil {
+		interpreter.SetSemanticInfo(p.semanticInfo)
+	}
+	if err := defineHostGlobals(interpreter, p.globals); err != nil {
+		return nil, err
+	}
+	if err := defineHostUnits(interpreter, p.units); err != nil {
+		return nil, err
+	}
+	return interpreter, nil
+}
+
+// fail marks the program as partially compiled and links it from err, so
+// tooling can still reach its AST and diagnostics.
+func (p *Program) fail(err *CompileError) *CompileError {
+	p.compileErr = err
+	err.Program = p
+	return err
 }
 
 // AST returns the Abstract Syntax Tree of the compiled program.
@@ -391,11 +610,29 @@ func (p *Program) AST() *ast.Program {
 	return p.ast
 }
 
+// CompileMode returns the execution engine the program runs on. It is
+// CompileModeBytecode once the program has been compiled to bytecode, and
+// CompileModeAST otherwise, including for programs compiled with
+// CompileModeBytecode that fell back to the AST interpreter because they use
+// a construct the bytecode compiler does not support; the fallback is
+// reported as a warning in Diagnostics. See BytecodeSupportedNodeTypes.
+func (p *Program) CompileMode() CompileMode {
+	if p != nil && p.bytecodeChunk != nil {
+		return CompileModeBytecode
+	}
+	return CompileModeAST
+}
+
+// ensureBytecodeChunk compiles the program to bytecode on first use. For a
+// program using a construct the bytecode compiler does not support, it
+// records a fallback warning and returns a nil chunk without an error.
 func (p *Program) ensureBytecodeChunk() (*bytecode.Chunk, error) {
 	if p == nil {
 		return nil, fmt.Errorf("program is nil")
 	}
-	if p.bytecodeChunk != nil {
+	p.bytecodeMu.Lock()
+	defer p.bytecodeMu.Unlock()
+	if p.bytecodeChunk != nil || p.bytecodeFallback {
 		return p.bytecodeChunk, nil
 	}
 	if p.ast == nil {
@@ -407,6 +644,12 @@ func (p *Program) ensureBytecodeChunk() (*bytecode.Chunk, error) {
 		compiler.SetSemanticInfo(p.semanticInfo)
 	}
 	chunk, err := compiler.Compile(p.ast)
+	var unsupported *bytecode.UnsupportedError
+	if errors.As(err, &unsupported) {
+		p.bytecodeFallback = true
+		p.diagnostics = append(p.diagnostics, bytecodeFallbackDiagnostic(unsupported))
+		return nil, nil
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -414,6 +657,29 @@ func (p *Program) ensureBytecodeChunk() (*bytecode.Chunk, error) {
 	return chunk, nil
 }
 
+// bytecodeFallbackDiagnostic returns the warning recorded when a program falls
+// back to the AST interpreter.
+func bytecodeFallbackDiagnostic(err *bytecode.UnsupportedError) Diagnostic {
+	pos := err.Pos()
+	return Diagnostic{
+		Message: fmt.Sprintf("bytecode VM does not support %s (%s); running on the AST interpreter",
+			err.NodeType, err.Message),
+		Code:     "W_BYTECODE_FALLBACK",
+		Phase:    PhaseBytecode,
+		Start:    pos,
+		End:      pos,
+		Severity: SeverityWarning,
+	}
+}
+
+// BytecodeSupportedNodeTypes returns the sorted names of the AST node types
+// (see package ast) that CompileModeBytecode can compile, such as
+// "WhileStatement" or "BinaryExpression". Some are only supported in part;
+// programs using any other construct run on the AST interpreter instead.
+func BytecodeSupportedNodeTypes() []string {
+	return bytecode.SupportedNodeTypes()
+}
+
 // Result represents the result of executing a DWScript program.
 type Result struct {
 	// Output contains all text written to stdout during program execution.
@@ -421,6 +687,11 @@ type Result struct {
 
 	// Success indicates whether the program completed without runtime errors.
 	Success bool
+
+	globals *ident.Map[interp.Value]
+	// value is the value of a trailing expression statement; see
+	// ExpressionValue.
+	value interp.Value
 }
 
 // CompileError is returned when source code fails to compile or type-check.
@@ -431,6 +702,11 @@ type CompileError struct {
 	// Errors contains one or more structured errors describing what went wrong.
 	// Each error includes position information, severity, and error codes for LSP integration.
 	Errors []*Error
+
+	// Program is the partially compiled program when Compile fails. Its AST
+	// and Diagnostics remain available for tooling, but it cannot be run.
+	// Program is nil for errors returned by Parse.
+	Program *Program
 }
 
 func (e *CompileError) Error() string {
@@ -475,12 +751,49 @@ func (e *CompileError) HasWarnings() bool {
 type RuntimeError struct {
 	// Message describes the runtime error.
 	Message string
+	// ExceptionClass is the class name of the uncaught script exception
+	// (e.g. "EDivByZero", "EConvertError"), or empty when the failure was
+	// not raised as a script exception.
+	ExceptionClass string
+	// Source, Line and Column locate the failure when it is known; Line is 0
+	// otherwise. Source names the file for code spliced in by {$INCLUDE} and
+	// is empty for an unnamed top-level source.
+	Source string
+	Line   int
+	Column int
+	// Detail adds information that is not part of the DWScript message, such
+	// as the valid range of an out-of-bounds static array index, or is empty.
+	Detail string
 }
 
 func (e *RuntimeError) Error() string {
 	return fmt.Sprintf("runtime error: %s", e.Message)
 }
 
+// newRuntimeError builds a RuntimeError from an interpreter error value,
+// preserving the exception class it would be raised as.
+func newRuntimeError(value runtime.Value) *RuntimeError {
+	err := &RuntimeError{Message: value.String()}
+	var pos *lexer.Position
+	switch errVal := value.(type) {
+	case *interp.ErrorValue:
+		err.ExceptionClass = errVal.ExceptionClass
+		err.Detail = errVal.Detail
+		pos = errVal.Pos
+		if pos == nil && errVal.Err != nil {
+			pos = errVal.Err.Pos
+		}
+	case *runtime.ErrorValue:
+		err.ExceptionClass = errVal.ExceptionClass
+		err.Detail = errVal.Detail
+		pos = errVal.Pos
+	}
+	if pos != nil {
+		err.Source, err.Line, err.Column = pos.Source, pos.Line, pos.Column
+	}
+	return err
+}
+
 // SetOutput sets the writer where program output (PrintLn, etc.) will be written.
 // This is used internally by the engine but exposed for advanced use cases.
 func (e *Engine) SetOutput(w io.Writer) {
@@ -39,6 +39,7 @@
 // # LSP Integration
 //
 // The Error struct is designed to map directly to LSP Diagnostic:
+//   - Error.Source → the document the diagnostic belongs to (empty: the compiled source)
 //   - Error.Line, Error.Column → diagnostic.range.start
 //   - Error.Length → diagnostic.range.end (start + length)
 //   - Error.Severity → diagnostic.severity
@@ -112,9 +113,13 @@ func (s ErrorSeverity) String() string {
 //
 // The Length field indicates the span of the error in characters, allowing
 // tools to highlight the exact portion of code that caused the error.
+//
+// Source names the file the position refers to, such as an {$INCLUDE}d file.
+// It is empty for errors in an unnamed top-level source.
 type Error struct {
 	Message  string
 	Code     string
+	Source   string
 	Line     int
 	Column   int
 	Length   int
@@ -124,12 +129,16 @@ type Error struct {
 // Error implements the error interface.
 // It formats the error in a human-readable format suitable for console output.
 func (e *Error) Error() string {
+	pos := fmt.Sprintf("%d:%d", e.Line, e.Column)
+	if e.Source != "" {
+		pos = e.Source + ":" + pos
+	}
 	if e.Code != "" {
-		return fmt.Sprintf("%s at %d:%d: %s [%s]",
-			e.Severity, e.Line, e.Column, e.Message, e.Code)
+		return fmt.Sprintf("%s at %s: %s [%s]",
+			e.Severity, pos, e.Message, e.Code)
 	}
-	return fmt.Sprintf("%s at %d:%d: %s",
-		e.Severity, e.Line, e.Column, e.Message)
+	return fmt.Sprintf("%s at %s: %s",
+		e.Severity, pos, e.Message)
 }
 
 // NewError creates a new Error with the given parameters.
@@ -0,0 +1,162 @@
+package dwscript
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+)
+
+// TestRuntimeFailureExceptionClasses verifies that runtime failures raise
+// instances of the matching standard exception class.
+func TestRuntimeFailureExceptionClasses(t *testing.T) {
+	tests := []struct {
+		name      string
+		statement string
+		class     string
+	}{
+		{name: "IntegerDivByZero", statement: `var a := 1; var b := 0; PrintLn(a div b);`, class: "EDivByZero"},
+		{name: "IntegerModByZero", statement: `var a := 1; var b := 0; PrintLn(a mod b);`, class: "EDivByZero"},
+		{name: "StrToInt", statement: `PrintLn(StrToInt('abc'));`, class: "EConvertError"},
+		{name: "StrToFloat", statement: `PrintLn(StrToFloat('abc'));`, class: "EConvertError"},
+		{name: "StaticArrayIndex", statement: `var arr: array[0..2] of Integer; var i := 5; PrintLn(arr[i]);`, class: "ERangeError"},
+		{name: "DynamicArrayIndex", statement: `var arr: array of Integer; var i := -1; PrintLn(arr[i]);`, class: "ERangeError"},
+		{name: "StringIndex", statement: `var s := 'abc'; var i := 5; PrintLn(s[i]);`, class: "ERangeError"},
+		{name: "InvalidCast", statement: `var o: TObject := TA.Create; PrintLn((o as TB).ClassName);`, class: "EInvalidCast"},
+		{name: "AssertionFailed", statement: `Assert(False, 'msg');`, class: "EAssertionFailed"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			source := `
+				type TA = class end;
+				type TB = class(TA) end;
+				procedure Run;
+				begin
+					` + tt.statement + `
+				end;
+				try
+					Run;
+				except
+					on E: ` + tt.class + ` do PrintLn('specific ' + E.ClassName);
+				end;
+				try
+					Run;
+				except
+					on E: Exception do PrintLn('base ' + E.ClassName + ' ' + BoolToStr(E.Message <> ''));
+				end;
+			`
+
+			var buf bytes.Buffer
+			engine, err := New(WithOutput(&buf))
+			if err != nil {
+				t.Fatalf("failed to create engine: %v", err)
+			}
+			if _, err := engine.Eval(source); err != nil {
+				t.Fatalf("Eval failed: %v", err)
+			}
+			want := "specific " + tt.class + "\nbase " + tt.class + " True\n"
+			if buf.String() != want {
+				t.Errorf("output = %q, want %q", buf.String(), want)
+			}
+		})
+	}
+}
+
+// TestUncaughtExceptionClass verifies that uncaught exceptions surface through
+// RuntimeError with their class name preserved.
+func TestUncaughtExceptionClass(t *testing.T) {
+	tests := []struct {
+		name   string
+		source string
+		class  string
+	}{
+		{name: "DivByZero", source: `var a := 1; var b := 0; PrintLn(a div b);`, class: "EDivByZero"},
+		{name: "DivByZeroInFunction", source: `function F(x: Integer): Integer; begin Result := 10 div x; end; PrintLn(F(0));`, class: "EDivByZero"},
+		{name: "ConvertError", source: `var i := StrToInt('x');`, class: "EConvertError"},
+		{name: "UserRaised", source: `raise ERangeError.Create('custom');`, class: "ERangeError"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			engine, err := New(WithOutput(&bytes.Buffer{}))
+			if err != nil {
+				t.Fatalf("failed to create engine: %v", err)
+			}
+			_, err = engine.Eval(tt.source)
+			var runtimeErr *RuntimeError
+			if !errors.As(err, &runtimeErr) {
+				t.Fatalf("expected *RuntimeError, got %v", err)
+			}
+			if runtimeErr.ExceptionClass != tt.class {
+				t.Errorf("ExceptionClass = %q, want %q (message %q)", runtimeErr.ExceptionClass, tt.class, runtimeErr.Message)
+			}
+			if strings.TrimSpace(runtimeErr.Message) == "" {
+				t.Error("expected a non-empty message")
+			}
+		})
+	}
+}
+
+// TestStaticArrayBoundDetail verifies that an out-of-range static array index
+// keeps DWScript's message and reports the declared bounds in Detail.
+func TestStaticArrayBoundDetail(t *testing.T) {
+	engine, err := New(WithOutput(&bytes.Buffer{}))
+	if err != nil {
+		t.Fatalf("failed to create engine: %v", err)
+	}
+	_, err = engine.Eval("var a: array[3..7] of Integer;\nvar i := 8;\nPrintLn(a[i]);")
+	var runtimeErr *RuntimeError
+	if !errors.As(err, &runtimeErr) {
+		t.Fatalf("expected *RuntimeError, got %v", err)
+	}
+	if !strings.Contains(runtimeErr.Message, "Upper bound exceeded! Index 8 [line: 3") {
+		t.Errorf("Message = %q, want DWScript's bound message", runtimeErr.Message)
+	}
+	if runtimeErr.Detail != "valid range 3..7" {
+		t.Errorf("Detail = %q, want %q", runtimeErr.Detail, "valid range 3..7")
+	}
+}
+
+// TestExceptionHandlerOrder verifies that handlers are tried in declared
+// order, so a derived-class handler listed first catches its subclass while
+// siblings fall through to later handlers.
+func TestExceptionHandlerOrder(t *testing.T) {
+	source := `
+		type EBase = class(Exception) end;
+		type EDerived = class(EBase) end;
+		type ESibling = class(EBase) end;
+		procedure Check(kind: Integer);
+		begin
+			try
+				case kind of
+					1: raise EDerived.Create('derived');
+					2: raise ESibling.Create('sibling');
+					3: raise EBase.Create('base');
+				else
+					raise Exception.Create('other');
+				end;
+			except
+				on E: EDerived do PrintLn('EDerived handler: ' + E.Message);
+				on E: EBase do PrintLn('EBase handler: ' + E.ClassName);
+				on E: Exception do PrintLn('Exception handler: ' + E.ClassName);
+			end;
+		end;
+		var i: Integer;
+		for i := 1 to 4 do Check(i);
+	`
+
+	var buf bytes.Buffer
+	engine, err := New(WithOutput(&buf))
+	if err != nil {
+		t.Fatalf("failed to create engine: %v", err)
+	}
+	if _, err := engine.Eval(source); err != nil {
+		t.Fatalf("Eval failed: %v", err)
+	}
+
+	want := "EDerived handler: derived\nEBase handler: ESibling\nEBase handler: EBase\nException handler: Exception\n"
+	if buf.String() != want {
+		t.Errorf("output = %q, want %q", buf.String(), want)
+	}
+}
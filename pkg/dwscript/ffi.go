@@ -66,7 +66,7 @@ type referenceArg interface {
 //  2. Marshals each DWScript Value to the expected Go type
 //  3. Calls the Go function with native types
 //  4. Marshals the return value(s) back to DWScript Values
-//  5. Converts errors to EHost exceptions automatically
+//  5. Converts errors to EExternal exceptions automatically
 //
 // Type Mapping (Go ↔ DWScript):
 //
@@ -80,8 +80,12 @@ type referenceArg interface {
 //	- []T ↔ array of T (dynamic arrays, also supports variadic-like behavior)
 //	- map[string]T ↔ record-like structure (associative array)
 //
+//	Structs:
+//	- struct ← record, object or JSON object, fields matched by name
+//	- struct → JSON Variant, converted to a record on assignment
+//
 //	Error Handling:
-//	- error ↔ EHost exception (Go errors are raised as DWScript exceptions)
+//	- error ↔ EExternal exception (Go errors are raised as DWScript exceptions)
 //	- Go panics are also caught and converted to EHost exceptions
 //
 // Function Signatures:
@@ -91,6 +95,25 @@ type referenceArg interface {
 //   - func(params...)                - void procedure
 //   - func(params, ...T)             - variadic function (Go's ...T syntax supported)
 //
+// Variadic Functions:
+// The arguments after the fixed parameters are packed into the Go slice, so a
+// function registered as func(prefix string, nums ...int64) accepts any number
+// of trailing Integer arguments, including none. A single array passed in the
+// variadic position supplies the whole slice instead, so Join('x', [1, 2])
+// and Join('x', 1, 2) are equivalent. This does not apply when the variadic
+// element type is itself a slice (...[]T), where each argument is one element.
+//
+// Structs:
+// A struct parameter accepts a record, an object or a JSON object. Each
+// exported Go field takes the script field of the same name, compared
+// case-insensitively, and a `dws:"name"` tag matches another name. A missing
+// field is a runtime error, unless the tag has the omitempty option, as in
+// `dws:"note,omitempty"`; `dws:"-"` skips a field. Nested structs and slices of
+// structs are converted the same way. A struct result, or a slice of them,
+// reaches the script as a JSON Variant with the same field names, and becomes
+// a record when it is assigned to a variable or parameter of a record type.
+// Pointers to structs are not supported as parameters.
+//
 // Example:
 //
 //	engine.RegisterFunction("Add", func(a, b int64) int64 {
@@ -106,8 +129,51 @@ type referenceArg interface {
 //	var sum := Add(40, 2);
 //	var scores := GetScores();
 func (e *Engine) RegisterFunction(name string, fn any) error {
+	return e.registerFunction(name, fn, "")
+}
+
+// RegisterFunctionWithExceptionClass registers a Go function like RegisterFunction,
+// but raises a non-nil error it returns as an exception of the named class instead
+// of EExternal. The exception's Message is the error's message, so scripts can
+// handle it with an ordinary try...except block:
+//
+//	engine.RegisterFunctionWithExceptionClass("SafeDivide", func(a, b int64) (int64, error) {
+//	    if b == 0 {
+//	        return 0, errors.New("division by zero")
+//	    }
+//	    return a / b, nil
+//	}, "EDivByZero")
+//
+// The class may be a built-in exception class or one declared by the script. It
+// is resolved when the error is raised; if no exception class of that name
+// exists at that point, EExternal is raised instead. Panics are still raised
+// as EHost.
+func (e *Engine) RegisterFunctionWithExceptionClass(name string, fn any, exceptionClass string) error {
+	if err := validateIdentifierName(exceptionClass); err != nil {
+		return fmt.Errorf("invalid exception class: %w", err)
+	}
+	return e.registerFunction(name, fn, exceptionClass)
+}
+
+// registerFunction wraps fn and adds it to the engine's external functions.
+func (e *Engine) registerFunction(name string, fn any, exceptionClass string) error {
+	wrapper, err := newExternalFunctionWrapper(name, fn)
+	if err != nil {
+		return err
+	}
+
+	// Register with the engine's registry
+	if e.externalFunctions == nil {
+		e.externalFunctions = interp.NewExternalFunctionRegistry()
+	}
+
+	return e.externalFunctions.RegisterWithExceptionClass(name, wrapper, exceptionClass)
+}
+
+// newExternalFunctionWrapper validates fn and wraps it for calls from DWScript.
+func newExternalFunctionWrapper(name string, fn any) (*externalFunctionWrapper, error) {
 	if fn == nil {
-		return fmt.Errorf("cannot register nil function")
+		return nil, fmt.Errorf("cannot register nil function")
 	}
 
 	// Use reflection to analyze the function
@@ -116,28 +182,21 @@ func (e *Engine) RegisterFunction(name string, fn any) error {
 
 	// Validate it's actually a function
 	if fnType.Kind() != reflect.Func {
-		return fmt.Errorf("expected function, got %s", fnType.Kind())
+		return nil, fmt.Errorf("expected function, got %s", fnType.Kind())
 	}
 
 	// Detect the signature
 	sig, err := detectSignature(name, fnType)
 	if err != nil {
-		return fmt.Errorf("invalid function signature for %s: %w", name, err)
+		return nil, fmt.Errorf("invalid function signature for %s: %w", name, err)
 	}
 
 	// Create a wrapper that handles marshaling
-	wrapper := &externalFunctionWrapper{
+	return &externalFunctionWrapper{
 		name:      name,
 		goFunc:    fnValue,
 		signature: sig,
-	}
-
-	// Register with the engine's registry
-	if e.externalFunctions == nil {
-		e.externalFunctions = interp.NewExternalFunctionRegistry()
-	}
-
-	return e.externalFunctions.Register(name, wrapper)
+	}, nil
 }
 
 // RegisterMethod registers a Go method from a struct to be callable from DWScript.
@@ -293,21 +352,36 @@ func (w *externalFunctionWrapper) Call(args []interp.Value) (interp.Value, error
 			}
 		}
 
-		// Pack variadic arguments into a slice
-		variadicType := fnType.In(numRequiredParams).Elem() // Get element type of slice
+		sliceType := fnType.In(numRequiredParams)
+		variadicType := sliceType.Elem() // Get element type of slice
 		numVariadicArgs := len(args) - numRequiredParams
-		variadicSlice := reflect.MakeSlice(fnType.In(numRequiredParams), numVariadicArgs, numVariadicArgs)
 
-		for i := 0; i < numVariadicArgs; i++ {
-			argIdx := numRequiredParams + i
-			goArg, err := interp.MarshalToGo(args[argIdx], variadicType, w.interp)
+		var spread *interp.ArrayValue
+		if numVariadicArgs == 1 && variadicType.Kind() != reflect.Slice {
+			spread, _ = args[numRequiredParams].(*interp.ArrayValue)
+		}
+		if spread != nil {
+			// A single array argument supplies the whole variadic slice
+			goArg, err := interp.MarshalToGo(spread, sliceType, w.interp)
 			if err != nil {
-				return nil, fmt.Errorf("variadic argument %d: %w", i, err)
+				return nil, fmt.Errorf("variadic arguments: %w", err)
+			}
+			goArgs[numRequiredParams] = reflect.ValueOf(goArg)
+		} else {
+			// Pack variadic arguments into a slice
+			variadicSlice := reflect.MakeSlice(sliceType, numVariadicArgs, numVariadicArgs)
+
+			for i := 0; i < numVariadicArgs; i++ {
+				argIdx := numRequiredParams + i
+				goArg, err := interp.MarshalToGo(args[argIdx], variadicType, w.interp)
+				if err != nil {
+					return nil, fmt.Errorf("variadic argument %d: %w", i, err)
+				}
+				variadicSlice.Index(i).Set(reflect.ValueOf(goArg))
 			}
-			variadicSlice.Index(i).Set(reflect.ValueOf(goArg))
-		}
 
-		goArgs[numRequiredParams] = variadicSlice
+			goArgs[numRequiredParams] = variadicSlice
+		}
 	} else {
 		// Non-variadic function: marshal arguments normally
 		goArgs = make([]reflect.Value, numParams)
@@ -440,7 +514,7 @@ func detectSignature(name string, fnType reflect.Type) (*FunctionSignature, erro
 				sig.ReturnType = "Void"
 			} else {
 				// func() T -> T
-				dwsType, err := goTypeToDWS(lastType)
+				dwsType, err := goReturnTypeToDWS(lastType)
 				if err != nil {
 					return nil, fmt.Errorf("return type: %w", err)
 				}
@@ -451,7 +525,7 @@ func detectSignature(name string, fnType reflect.Type) (*FunctionSignature, erro
 				return nil, fmt.Errorf("second return value must be error type")
 			}
 			// func() (T, error) -> T
-			dwsType, err := goTypeToDWS(fnType.Out(0))
+			dwsType, err := goReturnTypeToDWS(fnType.Out(0))
 			if err != nil {
 				return nil, fmt.Errorf("return type: %w", err)
 			}
@@ -487,9 +561,15 @@ func goTypeToDWS(goType reflect.Type) (string, error) {
 		}
 		// Could include value type info, but "record" is generic enough
 		return "record", nil
+	case reflect.Struct:
+		// struct -> "record" (fields matched by name)
+		return "record", nil
 	case reflect.Pointer:
 		// *T -> var T (by-reference parameter)
 		// Pointers indicate var parameters that can be modified by the Go function
+		if interp.ContainsStruct(goType.Elem()) {
+			return "", fmt.Errorf("unsupported Go type: %s (pass structs by value)", goType)
+		}
 		elemType, err := goTypeToDWS(goType.Elem())
 		if err != nil {
 			return "", fmt.Errorf("pointer element: %w", err)
@@ -504,6 +584,15 @@ func goTypeToDWS(goType reflect.Type) (string, error) {
 	}
 }
 
+// goReturnTypeToDWS maps the Go result type of a function to a DWScript type
+// name. Results holding structs are returned as JSON Variants.
+func goReturnTypeToDWS(goType reflect.Type) (string, error) {
+	if interp.ContainsStruct(goType) {
+		return "Variant", nil
+	}
+	return goTypeToDWS(goType)
+}
+
 // handleReturnValues processes the results from a Go function call and converts to DWScript values.
 func handleReturnValues(results []reflect.Value) (interp.Value, error) {
 	if len(results) == 0 {
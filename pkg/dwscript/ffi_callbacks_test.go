@@ -347,6 +347,104 @@ func TestCallbackWithSideEffects(t *testing.T) {
 	}
 }
 
+// TestCallbackSumsClosureResults tests a Go function that calls a script
+// lambda three times and sums the results, with the lambda reading and
+// updating variables captured from the enclosing function.
+func TestCallbackSumsClosureResults(t *testing.T) {
+	engine, err := New(WithTypeCheck(false))
+	if err != nil {
+		t.Fatalf("failed to create engine: %v", err)
+	}
+
+	err = engine.RegisterFunction("SumThree", func(callback func(int64) int) int {
+		return callback(1) + callback(2) + callback(3)
+	})
+	if err != nil {
+		t.Fatalf("failed to register SumThree: %v", err)
+	}
+
+	var buf bytes.Buffer
+	engine.SetOutput(&buf)
+
+	_, err = engine.Eval(`
+		function Accumulate(offset: Integer): Integer;
+		var calls: Integer;
+		begin
+			calls := 0;
+			Result := SumThree(lambda(x: Integer): Integer begin
+				calls := calls + 1;
+				Result := x * 10 + offset;
+			end);
+			PrintLn('calls: ' + IntToStr(calls));
+		end;
+
+		PrintLn(IntToStr(Accumulate(5)));
+	`)
+	if err != nil {
+		t.Fatalf("execution failed: %v", err)
+	}
+
+	// (10 + 5) + (20 + 5) + (30 + 5) = 75
+	if got := buf.String(); got != "calls: 3\n75\n" {
+		t.Errorf("expected output %q, got %q", "calls: 3\n75\n", got)
+	}
+}
+
+// TestCallbackExceptions tests that exceptions raised by a script callback
+// are returned as errors to callbacks with an error result, and re-raised
+// unchanged when the callback cannot return an error.
+func TestCallbackExceptions(t *testing.T) {
+	engine, err := New(WithTypeCheck(false))
+	if err != nil {
+		t.Fatalf("failed to create engine: %v", err)
+	}
+
+	err = engine.RegisterFunction("Validate", func(check func(string) error) string {
+		if err := check("input"); err != nil {
+			return "invalid: " + err.Error()
+		}
+		return "valid"
+	})
+	if err != nil {
+		t.Fatalf("failed to register Validate: %v", err)
+	}
+	err = engine.RegisterFunction("Apply", func(x int64, fn func(int64) int64) int64 {
+		return fn(x)
+	})
+	if err != nil {
+		t.Fatalf("failed to register Apply: %v", err)
+	}
+
+	var buf bytes.Buffer
+	engine.SetOutput(&buf)
+
+	_, err = engine.Eval(`
+		type EValidation = class(Exception) end;
+
+		PrintLn(Validate(lambda(s: String) begin end));
+		PrintLn(Validate(lambda(s: String) begin
+			raise EValidation.Create('bad ' + s);
+		end));
+
+		try
+			Apply(1, lambda(x: Integer): Integer begin
+				raise EValidation.Create('rejected');
+			end);
+		except
+			on E: EValidation do
+				PrintLn(E.ClassName + ': ' + E.Message);
+		end;
+	`)
+	if err != nil {
+		t.Fatalf("execution failed: %v", err)
+	}
+
+	want := "valid\ninvalid: DWScript callback exception [EValidation]: bad input\nEValidation: rejected\n"
+	if got := buf.String(); got != want {
+		t.Errorf("expected output %q, got %q", want, got)
+	}
+}
+
 // TestFFICallbackReentrancyLimit tests that recursion limits apply to callbacks.
 func TestFFICallbackReentrancyLimit(t *testing.T) {
 	engine, err := New(WithTypeCheck(false), WithMaxRecursionDepth(10))
@@ -366,8 +464,8 @@ func TestFFICallbackReentrancyLimit(t *testing.T) {
 	}
 
 	// Create deeply recursive callback that exceeds limit
-	// Note: When callbacks panic due to stack overflow, the exception bubbles up as EHost
-	// because Go catches the recursion limit exception and re-raises it
+	// Note: The recursion limit exception raised inside the callback is re-raised
+	// unchanged once it propagates back out of the Go function.
 	result, err := engine.Eval(`
 		function Recursive(n: Integer): Integer;
 		begin
@@ -382,7 +480,6 @@ func TestFFICallbackReentrancyLimit(t *testing.T) {
 			PrintLn('Should not reach here');
 		except
 			on E: Exception do begin
-				// May be EScriptStackOverflow or EHost (wrapped by FFI)
 				PrintLn('Caught recursion exception');
 			end;
 		end;
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"errors"
 	"fmt"
+	"strconv"
 	"strings"
 	"testing"
 )
@@ -156,6 +157,62 @@ func TestCallingConventions(t *testing.T) {
 		}
 	})
 
+	t.Run("NativeVariadic", func(t *testing.T) {
+		tests := []struct {
+			name   string
+			call   string
+			expect string
+		}{
+			{"NoVariadicArgs", "Join('n')", "n:"},
+			{"OneArg", "Join('n', 7)", "n:7"},
+			{"SeveralArgs", "Join('n', 1, 2, 3)", "n:1,2,3"},
+			{"ArrayLiteral", "Join('n', [4, 5])", "n:4,5"},
+			{"ArrayVariable", "Join('n', xs)", "n:8,9,10"},
+			{"EmptyArray", "Join('n', empty)", "n:"},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				engine, _ := New(WithTypeCheck(false))
+				engine.RegisterFunction("Join", func(prefix string, nums ...int64) string {
+					parts := make([]string, len(nums))
+					for i, n := range nums {
+						parts[i] = strconv.FormatInt(n, 10)
+					}
+					return prefix + ":" + strings.Join(parts, ",")
+				})
+
+				var buf bytes.Buffer
+				engine.SetOutput(&buf)
+
+				_, err := engine.Eval(`
+					var xs: array of Integer := [8, 9, 10];
+					var empty: array of Integer;
+					PrintLn(` + tt.call + `);
+				`)
+				if err != nil {
+					t.Fatalf("execution failed: %v", err)
+				}
+				if got := strings.TrimSpace(buf.String()); got != tt.expect {
+					t.Errorf("%s = %q, want %q", tt.call, got, tt.expect)
+				}
+			})
+		}
+
+		t.Run("TooFewArgs", func(t *testing.T) {
+			engine, _ := New(WithTypeCheck(false))
+			engine.RegisterFunction("Join", func(prefix string, nums ...int64) string {
+				return prefix
+			})
+			engine.SetOutput(&bytes.Buffer{})
+
+			_, err := engine.Eval(`PrintLn(Join());`)
+			if err == nil || !strings.Contains(err.Error(), "at least 1 arguments") {
+				t.Errorf("expected argument count error, got %v", err)
+			}
+		})
+	})
+
 	t.Run("MultipleReturnSignatures", func(t *testing.T) {
 		engine, _ := New(WithTypeCheck(false))
 
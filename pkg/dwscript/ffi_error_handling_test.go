@@ -7,7 +7,7 @@ import (
 	"testing"
 )
 
-// TestPanicConversionToException tests that all types of Go panics are converted to EHost exceptions.
+// TestPanicConversionToException tests that all types of Go panics are converted to EExternal exceptions.
 func TestPanicConversionToException(t *testing.T) {
 	t.Run("StringPanic", func(t *testing.T) {
 		engine, _ := New(WithTypeCheck(false))
@@ -177,6 +177,60 @@ func TestPanicConversionToException(t *testing.T) {
 	})
 }
 
+// TestMightPanicRecovered tests that a panicking host function raises a catchable
+// EExternal carrying the panic value, positioned at the call site, and that the
+// engine keeps working afterwards.
+func TestMightPanicRecovered(t *testing.T) {
+	engine, _ := New(WithTypeCheck(false))
+	engine.RegisterFunction("MightPanic", func(trigger bool) string {
+		if trigger {
+			panic("intentional panic for demonstration")
+		}
+		return "no panic"
+	})
+
+	var buf bytes.Buffer
+	engine.SetOutput(&buf)
+
+	_, err := engine.Eval(`
+		try
+			PrintLn(MightPanic(False));
+			PrintLn(MightPanic(True));
+		except
+			on E: EExternal do
+				PrintLn(E.ClassName + ': ' + E.Message);
+		end;
+		PrintLn('survived');
+	`)
+	if err != nil {
+		t.Fatalf("execution failed: %v", err)
+	}
+
+	want := "no panic\nEExternal: panic: intentional panic for demonstration\nsurvived\n"
+	if buf.String() != want {
+		t.Errorf("output = %q, want %q", buf.String(), want)
+	}
+
+	// An uncaught panic is reported at the call site.
+	_, err = engine.Eval("var s := 'x';\ns := MightPanic(True);")
+	var runtimeErr *RuntimeError
+	if !errors.As(err, &runtimeErr) {
+		t.Fatalf("expected RuntimeError, got %T: %v", err, err)
+	}
+	if runtimeErr.ExceptionClass != "EExternal" || runtimeErr.Line != 2 || runtimeErr.Column != 6 {
+		t.Errorf("got %s at %d:%d, want EExternal at 2:6", runtimeErr.ExceptionClass, runtimeErr.Line, runtimeErr.Column)
+	}
+
+	// The engine remains usable after the panic.
+	buf.Reset()
+	if _, err := engine.Eval("PrintLn(MightPanic(False));"); err != nil {
+		t.Fatalf("execution after panic failed: %v", err)
+	}
+	if buf.String() != "no panic\n" {
+		t.Errorf("output after panic = %q, want %q", buf.String(), "no panic\n")
+	}
+}
+
 // TestPanicPropagationNestedFFI tests that panics propagate correctly through nested FFI calls.
 func TestPanicPropagationNestedFFI(t *testing.T) {
 	t.Run("MultipleFFICallsWithPanic", func(t *testing.T) {
@@ -674,3 +728,114 @@ func TestRegisterFunctionWrongArgCount(t *testing.T) {
 		t.Errorf("expected error to be caught, got output: %s", output)
 	}
 }
+
+// TestErrorMappingToExceptionClass tests that errors returned by FFI functions
+// are raised as EExternal, or as the class given at registration.
+func TestErrorMappingToExceptionClass(t *testing.T) {
+	safeDivide := func(a, b int64) (int64, error) {
+		if b == 0 {
+			return 0, errors.New("division by zero")
+		}
+		return a / b, nil
+	}
+
+	tests := []struct {
+		name           string
+		exceptionClass string
+		script         string
+		expect         string
+	}{
+		{
+			name: "DefaultClass",
+			script: `
+				try
+					try
+						PrintLn(SafeDivide(1, 0));
+					finally
+						PrintLn('finally');
+					end;
+				except
+					on E: Exception do
+						PrintLn(E.ClassName + ': ' + E.Message);
+				end;
+			`,
+			expect: "finally\nEExternal: division by zero",
+		},
+		{
+			name:           "BuiltinClass",
+			exceptionClass: "EDivByZero",
+			script: `
+				try
+					PrintLn(SafeDivide(6, 3));
+					PrintLn(SafeDivide(1, 0));
+				except
+					on E: EExternal do
+						PrintLn('wrong handler');
+					on E: EDivByZero do
+						PrintLn(E.ClassName + ': ' + E.Message);
+				end;
+			`,
+			expect: "2\nEDivByZero: division by zero",
+		},
+		{
+			name:           "ScriptClass",
+			exceptionClass: "EMath",
+			script: `
+				type EMath = class(Exception) end;
+				try
+					PrintLn(SafeDivide(1, 0));
+				except
+					on E: EMath do
+						PrintLn(E.ClassName + ': ' + E.Message);
+				end;
+			`,
+			expect: "EMath: division by zero",
+		},
+		{
+			name:           "UnknownClass",
+			exceptionClass: "EMissing",
+			script: `
+				try
+					PrintLn(SafeDivide(1, 0));
+				except
+					on E: EHost do
+						PrintLn(E.ClassName + ': ' + E.Message);
+				end;
+			`,
+			expect: "EExternal: division by zero",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			engine, _ := New(WithTypeCheck(false))
+			var err error
+			if tt.exceptionClass == "" {
+				err = engine.RegisterFunction("SafeDivide", safeDivide)
+			} else {
+				err = engine.RegisterFunctionWithExceptionClass("SafeDivide", safeDivide, tt.exceptionClass)
+			}
+			if err != nil {
+				t.Fatalf("registration failed: %v", err)
+			}
+
+			var buf bytes.Buffer
+			engine.SetOutput(&buf)
+
+			if _, err := engine.Eval(tt.script); err != nil {
+				t.Fatalf("execution failed: %v", err)
+			}
+			if got := strings.TrimSpace(buf.String()); got != tt.expect {
+				t.Errorf("output = %q, want %q", got, tt.expect)
+			}
+		})
+	}
+
+	t.Run("InvalidClassName", func(t *testing.T) {
+		engine, _ := New(WithTypeCheck(false))
+		err := engine.RegisterFunctionWithExceptionClass("SafeDivide", safeDivide, "not a class")
+		if err == nil || !strings.Contains(err.Error(), "invalid exception class") {
+			t.Errorf("expected invalid exception class error, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,181 @@
+package dwscript
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+type testAddress struct {
+	City string
+}
+
+type testUser struct {
+	Name    string
+	Age     int64
+	Tags    []string `dws:"Labels"`
+	Address testAddress
+	Secret  string `dws:"-"`
+	Note    string `dws:",omitempty"`
+}
+
+const structTypes = `
+type TAddress = record City: String; end;
+type TUser = record
+  name: String;
+  Age: Integer;
+  Labels: array of String;
+  Address: TAddress;
+end;
+type TUserObj = class
+  Name: String;
+  Age: Integer;
+  Labels: array of String;
+  Address: TAddress;
+end;
+`
+
+func newStructEngine(t *testing.T) (*Engine, *bytes.Buffer) {
+	t.Helper()
+	engine, err := New(WithTypeCheck(false))
+	if err != nil {
+		t.Fatalf("failed to create engine: %v", err)
+	}
+	var buf bytes.Buffer
+	engine.SetOutput(&buf)
+	return engine, &buf
+}
+
+// TestRegisterFunctionWithStructParams tests marshaling records, objects and
+// arrays of records to Go structs.
+func TestRegisterFunctionWithStructParams(t *testing.T) {
+	engine, buf := newStructEngine(t)
+
+	describe := func(u testUser) string {
+		return u.Name + "/" + strings.Join(u.Tags, ",") + "/" + u.Address.City
+	}
+	if err := engine.RegisterFunction("Describe", describe); err != nil {
+		t.Fatalf("failed to register Describe: %v", err)
+	}
+	err := engine.RegisterFunction("DescribeAll", func(users []testUser) string {
+		parts := make([]string, len(users))
+		for i, u := range users {
+			parts[i] = describe(u)
+		}
+		return strings.Join(parts, ";")
+	})
+	if err != nil {
+		t.Fatalf("failed to register DescribeAll: %v", err)
+	}
+
+	_, err = engine.Eval(structTypes + `
+var u: TUser;
+u.Name := 'Ann';
+u.Labels := ['a', 'b'];
+u.Address.City := 'Paris';
+PrintLn(Describe(u));
+
+var o := TUserObj.Create;
+o.Name := 'Bob';
+o.Labels := ['c'];
+o.Address.City := 'Oslo';
+PrintLn(Describe(o));
+
+var users: array of TUser;
+users.Add(u);
+u.Name := 'Cid';
+users.Add(u);
+PrintLn(DescribeAll(users));
+`)
+	if err != nil {
+		t.Fatalf("execution failed: %v", err)
+	}
+
+	want := "Ann/a,b/Paris\nBob/c/Oslo\nAnn/a,b/Paris;Cid/a,b/Paris"
+	if got := strings.TrimSpace(buf.String()); got != want {
+		t.Errorf("output = %q, want %q", got, want)
+	}
+}
+
+// TestRegisterFunctionWithStructResult tests converting Go structs returned
+// by a host function to JSON Variants and to records of a declared type.
+func TestRegisterFunctionWithStructResult(t *testing.T) {
+	engine, buf := newStructEngine(t)
+
+	err := engine.RegisterFunction("GetUser", func() testUser {
+		return testUser{Name: "Ann", Age: 42, Tags: []string{"x", "y"}, Address: testAddress{City: "Rome"}, Secret: "hidden"}
+	})
+	if err != nil {
+		t.Fatalf("failed to register GetUser: %v", err)
+	}
+	err = engine.RegisterFunction("GetUsers", func() []testUser {
+		return []testUser{{Name: "Ann"}, {Name: "Bob"}}
+	})
+	if err != nil {
+		t.Fatalf("failed to register GetUsers: %v", err)
+	}
+	err = engine.RegisterFunction("Describe", func(u testUser) string {
+		return u.Name + "/" + u.Address.City
+	})
+	if err != nil {
+		t.Fatalf("failed to register Describe: %v", err)
+	}
+
+	_, err = engine.Eval(structTypes + `
+var v := GetUser();
+PrintLn(v);
+PrintLn(v.Name);
+
+var u: TUser := GetUser();
+PrintLn(u.Name + ' ' + IntToStr(u.Age) + ' ' + u.Labels[1] + ' ' + u.Address.City);
+
+var users: array of TUser;
+users := GetUsers();
+PrintLn(IntToStr(Length(users)) + ' ' + users[1].Name);
+
+PrintLn(Describe(v));
+`)
+	if err != nil {
+		t.Fatalf("execution failed: %v", err)
+	}
+
+	want := `{"Name":"Ann","Age":42,"Labels":["x","y"],"Address":{"City":"Rome"}}
+Ann
+Ann 42 y Rome
+2 Bob
+Ann/Rome`
+	if got := strings.TrimSpace(buf.String()); got != want {
+		t.Errorf("output = %q, want %q", got, want)
+	}
+}
+
+// TestRegisterFunctionStructErrors tests the errors reported for records
+// that do not match a Go struct and for unsupported struct types.
+func TestRegisterFunctionStructErrors(t *testing.T) {
+	engine, _ := newStructEngine(t)
+
+	if err := engine.RegisterFunction("Save", func(u testUser) {}); err != nil {
+		t.Fatalf("failed to register Save: %v", err)
+	}
+	_, err := engine.Eval(`
+type TPartial = record Name: String; Age: Integer; Labels: array of String; end;
+var p: TPartial;
+Save(p);
+`)
+	if err == nil || !strings.Contains(err.Error(), "record TPartial has no field Address required by Go type dwscript.testUser") {
+		t.Errorf("error = %v, want a missing field error naming both types", err)
+	}
+
+	_, err = engine.Eval(structTypes + `
+type TWrong = record Name: String; Age: String; Labels: array of String; Address: TAddress; end;
+var w: TWrong;
+Save(w);
+`)
+	if err == nil || !strings.Contains(err.Error(), "record TWrong field Age to Go type dwscript.testUser: expected Integer, got STRING") {
+		t.Errorf("error = %v, want a field type error naming both types", err)
+	}
+
+	if err := engine.RegisterFunction("Update", func(u *testUser) {}); err == nil {
+		t.Errorf("registering a function with a *struct parameter succeeded")
+	}
+}
@@ -0,0 +1,103 @@
+package dwscript
+
+import (
+	"bytes"
+	"testing"
+)
+
+var germanFormatSettings = FormatSettings{
+	DecimalSeparator:  ",",
+	ThousandSeparator: ".",
+	DateSeparator:     ".",
+}
+
+// runWithFormatSettings runs script with opts and returns its output.
+func runWithFormatSettings(t *testing.T, script string, opts ...Option) string {
+	t.Helper()
+	var buf bytes.Buffer
+	engine, err := New(append([]Option{WithOutput(&buf)}, opts...)...)
+	if err != nil {
+		t.Fatalf("failed to create engine: %v", err)
+	}
+	program, err := engine.Compile(script)
+	if err != nil {
+		t.Fatalf("Compile failed: %v", err)
+	}
+	if _, err := engine.Run(program); err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	return buf.String()
+}
+
+// TestWithFormatSettings verifies that the conversion builtins use the
+// configured separators and default to the invariant ones.
+func TestWithFormatSettings(t *testing.T) {
+	script := `
+PrintLn(FloatToStr(1.5));
+PrintLn(FloatToStr(StrToFloat(FloatToStr(2.25)) * 2));
+PrintLn(Format('%.2f|%n', [3.14159, 1234567.891]));
+PrintLn(DateToStr(EncodeDate(2024, 3, 15)));`
+
+	tests := []struct {
+		name     string
+		opts     []Option
+		expected string
+	}{
+		{
+			name:     "invariant",
+			expected: "1.5\n4.5\n3.14|1,234,567.89\n2024-03-15\n",
+		},
+		{
+			name:     "german",
+			opts:     []Option{WithFormatSettings(germanFormatSettings)},
+			expected: "1,5\n4,5\n3,14|1.234.567,89\n2024.03.15\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if output := runWithFormatSettings(t, script, tt.opts...); output != tt.expected {
+				t.Errorf("output = %q, want %q", output, tt.expected)
+			}
+		})
+	}
+}
+
+// TestWithFormatSettingsStrToFloat verifies that StrToFloat only accepts the
+// configured decimal separator.
+func TestWithFormatSettingsStrToFloat(t *testing.T) {
+	script := `
+try
+  PrintLn(StrToFloat('1.5'));
+except
+  on E: EConvertError do PrintLn('EConvertError');
+end;
+PrintLn(FloatToStr(StrToFloatDef('1,5', -1.0)));`
+
+	output := runWithFormatSettings(t, script, WithFormatSettings(germanFormatSettings))
+	if want := "EConvertError\n1,5\n"; output != want {
+		t.Errorf("output = %q, want %q", output, want)
+	}
+}
+
+// TestWithFormatSettingsBytecode verifies FloatToStr in the bytecode VM.
+func TestWithFormatSettingsBytecode(t *testing.T) {
+	output := runWithFormatSettings(t, "PrintLn(FloatToStr(1.5));",
+		WithFormatSettings(germanFormatSettings), WithCompileMode(CompileModeBytecode))
+	if want := "1,5\n"; output != want {
+		t.Errorf("output = %q, want %q", output, want)
+	}
+}
+
+// TestWithFormatSettingsValidation verifies that invalid settings are rejected.
+func TestWithFormatSettingsValidation(t *testing.T) {
+	invalid := []FormatSettings{
+		{ThousandSeparator: ","},
+		{DecimalSeparator: ",", ThousandSeparator: ","},
+	}
+	for _, settings := range invalid {
+		if _, err := New(WithFormatSettings(settings)); err == nil {
+			t.Errorf("New(WithFormatSettings(%+v)) succeeded, want error", settings)
+		}
+	}
+}
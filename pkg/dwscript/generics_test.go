@@ -0,0 +1,98 @@
+package dwscript
+
+import (
+	"bytes"
+	"testing"
+)
+
+// evalGenericScript runs script and returns its output.
+func evalGenericScript(t *testing.T, script string) string {
+	t.Helper()
+	var buf bytes.Buffer
+	engine, err := New(WithOutput(&buf))
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	if _, err := engine.Eval(script); err != nil {
+		t.Fatalf("Eval failed: %v", err)
+	}
+	return buf.String()
+}
+
+// TestGenericRecordMethodImplementations tests a generic record whose
+// out-of-line method bodies use the type parameter, instantiated with
+// Integer and String.
+func TestGenericRecordMethodImplementations(t *testing.T) {
+	input := `
+type TBox<T> = record
+	Value: T;
+	function Get: T;
+	procedure Put(v: T);
+	function Twice: T;
+end;
+
+function TBox<T>.Get: T;
+begin
+	Result := Value;
+end;
+
+procedure TBox<T>.Put(v: T);
+begin
+	Value := v;
+end;
+
+function TBox<T>.Twice: T;
+begin
+	Result := Value + Value;
+end;
+
+var i: TBox<Integer>;
+var s: TBox<String>;
+i.Put(21);
+s.Put('ab');
+PrintLn(i.Get + 1);
+PrintLn(i.Twice);
+PrintLn(s.Get + '!');
+PrintLn(s.Twice);
+`
+	output := evalGenericScript(t, input)
+	if want := "22\n42\nab!\nabab\n"; output != want {
+		t.Errorf("output = %q, want %q", output, want)
+	}
+}
+
+// TestGenericClassMethodUsedBeforeImplementation tests that an instantiation
+// declared before the method implementations still gets their bodies.
+func TestGenericClassMethodUsedBeforeImplementation(t *testing.T) {
+	input := `
+type TStack<T> = class
+	Items: array of T;
+	procedure Push(v: T);
+	function Pop: T;
+end;
+
+var ints := new TStack<Integer>;
+
+procedure TStack<T>.Push(v: T);
+begin
+	Items.Add(v);
+end;
+
+function TStack<T>.Pop: T;
+begin
+	Result := Items.Pop;
+end;
+
+var strs := new TStack<String>;
+ints.Push(1);
+ints.Push(2);
+strs.Push('x');
+PrintLn(ints.Pop);
+PrintLn(strs.Pop);
+PrintLn(ints.Items.Length);
+`
+	output := evalGenericScript(t, input)
+	if want := "2\nx\n1\n"; output != want {
+		t.Errorf("output = %q, want %q", output, want)
+	}
+}
@@ -0,0 +1,72 @@
+package dwscript
+
+import (
+	"fmt"
+
+	"github.com/cwbudde/go-dws/internal/interp"
+	"github.com/cwbudde/go-dws/internal/interp/runtime"
+	"github.com/cwbudde/go-dws/pkg/ast"
+	"github.com/cwbudde/go-dws/pkg/ident"
+)
+
+// GlobalValue returns the value a top-level var or const declaration, or a
+// global set with Engine.SetGlobal, held when the run finished, converted to
+// Go. The lookup is case-insensitive,
+// like DWScript itself.
+//
+// ok is false if the program declares no global with that name, or if the
+// run did not record globals: only successful runs on the AST interpreter do.
+//
+// Values are converted with runtime.ToGo: Integer → int64, Float → float64,
+// String → string, Boolean → bool, nil → nil, arrays → []interface{},
+// records → map[string]interface{} keyed by declared field name, enums → the
+// int64 ordinal, sets → []interface{} of int64 ordinals, and Variants holding
+// one of these → the wrapped value. Any other value, such as an object or a
+// JSON value, returns an error.
+//
+// Example:
+//
+//	result, _ := engine.Eval("var Port: Integer; Port := 8000 + 80;")
+//	port, ok, err := result.GlobalValue("port")
+//	// port == int64(8080), ok == true, err == nil
+func (r *Result) GlobalValue(name string) (value interface{}, ok bool, err error) {
+	if r == nil || r.globals == nil {
+		return nil, false, nil
+	}
+	v, ok := r.globals.Get(name)
+	if !ok {
+		return nil, false, nil
+	}
+	value, err = runtime.ToGo(v)
+	if err != nil {
+		return nil, true, fmt.Errorf("global %s: %w", r.globals.GetOriginalKey(name), err)
+	}
+	return value, true, nil
+}
+
+// captureGlobals snapshots the values of the program's host globals and
+// top-level var and const declarations from the interpreter's global scope.
+func captureGlobals(interpreter *interp.Interpreter, hostGlobals []hostGlobal, programs ...*ast.Program) *ident.Map[interp.Value] {
+	globals := ident.NewMap[interp.Value]()
+	capture := func(name string) {
+		if v, ok := interpreter.GetVariable(name); ok {
+			globals.Set(name, v)
+		}
+	}
+	for _, global := range hostGlobals {
+		capture(global.name)
+	}
+	for _, program := range programs {
+		for _, stmt := range program.Statements {
+			switch s := stmt.(type) {
+			case *ast.ConstDecl:
+				capture(s.Name.Value)
+			case *ast.VarDeclStatement:
+				for _, name := range s.Names {
+					capture(name.Value)
+				}
+			}
+		}
+	}
+	return globals
+}
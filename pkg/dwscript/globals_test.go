@@ -0,0 +1,153 @@
+package dwscript
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// TestResultGlobalValue verifies that the final values of top-level
+// declarations can be read back as Go values after a run.
+func TestResultGlobalValue(t *testing.T) {
+	engine, err := New()
+	if err != nil {
+		t.Fatalf("failed to create engine: %v", err)
+	}
+
+	result, err := engine.Eval(`
+type TColor = (Red, Green);
+type TPoint = record X, Y: Integer; end;
+const Greeting = 'hi';
+var Count: Integer;
+var Ratio: Float := 0.5;
+var Name: String;
+var Enabled: Boolean;
+var Color := Green;
+var Items: array of Integer;
+var Origin: TPoint;
+var Anything: Variant := 'wrapped';
+var Obj := TObject.Create;
+
+Count := 40 + 2;
+Ratio := Ratio * 3;
+Name := Greeting + ' there';
+Enabled := Count > 10;
+Items := [1, 2, 3];
+Origin.X := 7;
+begin
+  var Local := 1;
+end;
+`)
+	if err != nil {
+		t.Fatalf("Eval failed: %v", err)
+	}
+
+	tests := []struct {
+		name string
+		want interface{}
+	}{
+		{"Count", int64(42)},
+		{"count", int64(42)},
+		{"Ratio", 1.5},
+		{"NAME", "hi there"},
+		{"Enabled", true},
+		{"Greeting", "hi"},
+		{"Color", int64(1)},
+		{"Items", []interface{}{int64(1), int64(2), int64(3)}},
+		{"Origin", map[string]interface{}{"X": int64(7), "Y": int64(0)}},
+		{"Anything", "wrapped"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok, err := result.GlobalValue(tt.name)
+			if err != nil || !ok {
+				t.Fatalf("GlobalValue(%q) = %v, %v, %v", tt.name, got, ok, err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("GlobalValue(%q) = %#v, want %#v", tt.name, got, tt.want)
+			}
+		})
+	}
+
+	for _, name := range []string{"Missing", "Local", "PI"} {
+		if _, ok, _ := result.GlobalValue(name); ok {
+			t.Errorf("GlobalValue(%q) found a value, want none", name)
+		}
+	}
+
+	_, ok, err := result.GlobalValue("obj")
+	if !ok || err == nil || !strings.Contains(err.Error(), "Obj") {
+		t.Errorf("GlobalValue(obj) = ok %v, err %v; want an error naming Obj", ok, err)
+	}
+}
+
+// TestGoValueEntryPointsAgree verifies that every API returning script values
+// to Go converts enums, records and sets the same way.
+func TestGoValueEntryPointsAgree(t *testing.T) {
+	const declarations = `
+type TColor = (Red, Green, Blue);
+type TColors = set of TColor;
+type TPoint = record X, Y: Integer; end;
+var Color := Blue;
+var Colors: TColors := [Red, Blue];
+var Origin: TPoint := (X: 3; Y: 4);
+`
+	want := map[string]interface{}{
+		"Color":  int64(2),
+		"Colors": []interface{}{int64(0), int64(2)},
+		"Origin": map[string]interface{}{"X": int64(3), "Y": int64(4)},
+	}
+
+	engine, err := New()
+	if err != nil {
+		t.Fatalf("failed to create engine: %v", err)
+	}
+	result, err := engine.Eval(declarations)
+	if err != nil {
+		t.Fatalf("Eval failed: %v", err)
+	}
+	program, err := engine.Compile(declarations)
+	if err != nil {
+		t.Fatalf("Compile failed: %v", err)
+	}
+	initialized, err := program.RunInitializers()
+	if err != nil {
+		t.Fatalf("RunInitializers failed: %v", err)
+	}
+	session, err := engine.NewSession()
+	if err != nil {
+		t.Fatalf("NewSession failed: %v", err)
+	}
+	if _, err := session.Eval(declarations); err != nil {
+		t.Fatalf("Session.Eval failed: %v", err)
+	}
+
+	for name, expected := range want {
+		if got, _, err := result.GlobalValue(name); err != nil || !reflect.DeepEqual(got, expected) {
+			t.Errorf("GlobalValue(%q) = %#v, %v, want %#v", name, got, err, expected)
+		}
+		if got := initialized[name]; !reflect.DeepEqual(got, expected) {
+			t.Errorf("RunInitializers()[%q] = %#v, want %#v", name, got, expected)
+		}
+		exprResult, err := session.Eval(name)
+		if err != nil {
+			t.Fatalf("Session.Eval(%q) failed: %v", name, err)
+		}
+		if got, _, err := exprResult.ExpressionGoValue(); err != nil || !reflect.DeepEqual(got, expected) {
+			t.Errorf("ExpressionGoValue(%q) = %#v, %v, want %#v", name, got, err, expected)
+		}
+	}
+
+	// A record injected with SetGlobal reads back unchanged.
+	origin := want["Origin"]
+	if err := engine.SetGlobal("HostOrigin", origin); err != nil {
+		t.Fatalf("SetGlobal failed: %v", err)
+	}
+	result, err = engine.Eval(`PrintLn(HostOrigin.X);`)
+	if err != nil {
+		t.Fatalf("Eval failed: %v", err)
+	}
+	if got, _, err := result.GlobalValue("HostOrigin"); err != nil || !reflect.DeepEqual(got, origin) {
+		t.Errorf("GlobalValue(HostOrigin) = %#v, %v, want %#v", got, err, origin)
+	}
+}
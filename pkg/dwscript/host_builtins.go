@@ -0,0 +1,185 @@
+package dwscript
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/cwbudde/go-dws/internal/builtins"
+	"github.com/cwbudde/go-dws/internal/frontend"
+	"github.com/cwbudde/go-dws/internal/interp"
+	"github.com/cwbudde/go-dws/internal/types"
+	"github.com/cwbudde/go-dws/pkg/ident"
+)
+
+// UnregisterBuiltin removes a built-in function from the engine, for example
+// to sandbox scripts. Scripts compiled afterwards that call it fail to compile
+// with an unknown name error, exactly as if the function never existed; with
+// WithTypeCheck(false) the call fails at run time instead. Names are
+// case-insensitive. Removing a function replaced with OverrideBuiltin also
+// removes the override.
+//
+// Only the functions of the builtin registry can be removed. Intrinsics that
+// take their arguments by reference (Inc, Dec, SetLength, Swap, ...) and
+// compiler magic such as Default are part of the language and return an error.
+//
+// Example:
+//
+//	engine.UnregisterBuiltin("PrintLn")
+//	_, err := engine.Compile(`PrintLn('hi');`) // Unknown name "PrintLn"
+func (e *Engine) UnregisterBuiltin(name string) error {
+	e.globalsMu.Lock()
+	defer e.globalsMu.Unlock()
+
+	if e.overrides != nil && e.overrides.Has(name) {
+		e.overrides.Delete(name)
+		e.externalFunctions.Unregister(name)
+	} else if !e.customBuiltins().Unregister(name) {
+		return fmt.Errorf("%s is not a built-in function", name)
+	}
+	e.ClearCache()
+	return nil
+}
+
+// OverrideBuiltin replaces the implementation of a built-in function with a
+// Go function, for example to redirect PrintLn in a DSL. The function follows
+// the calling convention of RegisterFunction, and its Go signature replaces
+// the builtin's during type checking: scripts compiled afterwards must call
+// the override with arguments its parameters accept. Overriding a function
+// again replaces the previous override. Names are case-insensitive.
+//
+// The parameter and result types are limited to Integer, Float, String,
+// Boolean and arrays of them; as for UnregisterBuiltin, only the functions of
+// the builtin registry can be overridden. Overrides are only available to the
+// AST interpreter.
+//
+// Example:
+//
+//	var lines []string
+//	engine.OverrideBuiltin("PrintLn", func(s string) {
+//	    lines = append(lines, s)
+//	})
+func (e *Engine) OverrideBuiltin(name string, fn any) error {
+	wrapper, err := newExternalFunctionWrapper(name, fn)
+	if err != nil {
+		return err
+	}
+	funcType, err := hostFunctionType(wrapper.signature)
+	if err != nil {
+		return fmt.Errorf("invalid function signature for %s: %w", name, err)
+	}
+
+	e.globalsMu.Lock()
+	defer e.globalsMu.Unlock()
+
+	if e.overrides == nil {
+		e.overrides = ident.NewMap[*types.FunctionType]()
+	}
+	if e.externalFunctions == nil {
+		e.externalFunctions = interp.NewExternalFunctionRegistry()
+	}
+	overridden := e.overrides.Has(name)
+	if !overridden && !e.builtinRegistry().Has(name) {
+		return fmt.Errorf("%s is not a built-in function", name)
+	}
+	if overridden {
+		e.externalFunctions.Unregister(name)
+	}
+	if err := e.externalFunctions.Register(name, wrapper); err != nil {
+		return err
+	}
+	e.customBuiltins().Unregister(name)
+	e.overrides.Set(name, funcType)
+	e.ClearCache()
+	return nil
+}
+
+// builtinRegistry returns the builtins available to the engine's scripts.
+// The caller must hold globalsMu.
+func (e *Engine) builtinRegistry() *builtins.Registry {
+	if e.builtins == nil {
+		return builtins.DefaultRegistry
+	}
+	return e.builtins
+}
+
+// customBuiltins returns the engine's own builtin registry, copying
+// builtins.DefaultRegistry on first use. The caller must hold globalsMu.
+func (e *Engine) customBuiltins() *builtins.Registry {
+	if e.builtins == nil {
+		e.builtins = builtins.DefaultRegistry.Clone()
+	}
+	return e.builtins
+}
+
+// hostBuiltins returns the engine's builtin registry, or nil if its builtins
+// were not customized, and the overrides as host functions sorted by name.
+func (e *Engine) hostBuiltins() (*builtins.Registry, []frontend.Function) {
+	e.globalsMu.Lock()
+	defer e.globalsMu.Unlock()
+	if e.overrides == nil {
+		return e.builtins, nil
+	}
+	functions := make([]frontend.Function, 0, e.overrides.Len())
+	e.overrides.Range(func(name string, funcType *types.FunctionType) bool {
+		functions = append(functions, frontend.Function{Name: name, Type: funcType})
+		return true
+	})
+	sort.Slice(functions, func(i, j int) bool { return ident.Compare(functions[i].Name, functions[j].Name) < 0 })
+	return e.builtins, functions
+}
+
+// hostFunctionType returns the DWScript type of a function with the given
+// external signature.
+func hostFunctionType(sig *FunctionSignature) (*types.FunctionType, error) {
+	params := make([]types.Type, len(sig.ParamTypes))
+	for i, name := range sig.ParamTypes {
+		typ, err := hostTypeByName(name)
+		if err != nil {
+			return nil, fmt.Errorf("parameter %d: %w", i, err)
+		}
+		params[i] = typ
+	}
+
+	var returnType types.Type = types.VOID
+	if sig.ReturnType != "Void" {
+		typ, err := hostTypeByName(sig.ReturnType)
+		if err != nil {
+			return nil, fmt.Errorf("return type: %w", err)
+		}
+		returnType = typ
+	}
+
+	var funcType *types.FunctionType
+	if sig.IsVariadic {
+		variadic := params[len(params)-1].(*types.ArrayType)
+		funcType = types.NewVariadicFunctionType(params, variadic.ElementType, returnType)
+	} else {
+		funcType = types.NewFunctionType(params, returnType)
+	}
+	copy(funcType.VarParams, sig.VarParams)
+	return funcType, nil
+}
+
+// hostTypeByName resolves a type name produced by goTypeToDWS.
+func hostTypeByName(name string) (types.Type, error) {
+	if elem, ok := strings.CutPrefix(name, "array of "); ok {
+		elemType, err := hostTypeByName(elem)
+		if err != nil {
+			return nil, err
+		}
+		return types.NewDynamicArrayType(elemType), nil
+	}
+	switch name {
+	case "Integer":
+		return types.INTEGER, nil
+	case "Float":
+		return types.FLOAT, nil
+	case "String":
+		return types.STRING, nil
+	case "Boolean":
+		return types.BOOLEAN, nil
+	default:
+		return nil, fmt.Errorf("unsupported type %s", name)
+	}
+}
@@ -0,0 +1,174 @@
+package dwscript
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+// TestUnregisterBuiltin verifies that a removed builtin is an unknown name at
+// compile time and undefined at run time.
+func TestUnregisterBuiltin(t *testing.T) {
+	var buf bytes.Buffer
+	engine, err := New(WithOutput(&buf))
+	if err != nil {
+		t.Fatalf("failed to create engine: %v", err)
+	}
+	if err := engine.UnregisterBuiltin("println"); err != nil {
+		t.Fatalf("UnregisterBuiltin failed: %v", err)
+	}
+
+	for _, script := range []string{`PrintLn('hi');`, `PrintLn;`, `var p := @PrintLn;`} {
+		_, err := engine.Compile(script)
+		if err == nil {
+			t.Errorf("Compile(%q) succeeded, want an unknown name error", script)
+			continue
+		}
+		if !strings.Contains(err.Error(), `Unknown name "PrintLn"`) {
+			t.Errorf("Compile(%q) error = %v, want an unknown name error", script, err)
+		}
+	}
+
+	// Other builtins and other engines are unaffected.
+	if _, err := engine.Eval(`Print(IntToStr(42));`); err != nil {
+		t.Errorf("Print failed after removing PrintLn: %v", err)
+	}
+	other, err := New(WithOutput(&buf))
+	if err != nil {
+		t.Fatalf("failed to create engine: %v", err)
+	}
+	if _, err := other.Eval(`PrintLn('hi');`); err != nil {
+		t.Errorf("PrintLn failed in another engine: %v", err)
+	}
+
+	untyped, err := New(WithOutput(&buf), WithTypeCheck(false))
+	if err != nil {
+		t.Fatalf("failed to create engine: %v", err)
+	}
+	if err := untyped.UnregisterBuiltin("PrintLn"); err != nil {
+		t.Fatalf("UnregisterBuiltin failed: %v", err)
+	}
+	if _, err := untyped.Eval(`PrintLn('hi');`); err == nil {
+		t.Error("expected a run-time error calling a removed builtin")
+	}
+}
+
+// TestUnregisterBuiltinErrors verifies that only registered builtins can be
+// removed.
+func TestUnregisterBuiltinErrors(t *testing.T) {
+	engine, err := New()
+	if err != nil {
+		t.Fatalf("failed to create engine: %v", err)
+	}
+	for _, name := range []string{"NoSuchFunction", "Inc"} {
+		if err := engine.UnregisterBuiltin(name); err == nil {
+			t.Errorf("UnregisterBuiltin(%q) succeeded, want an error", name)
+		}
+	}
+	if err := engine.UnregisterBuiltin("Trim"); err != nil {
+		t.Fatalf("UnregisterBuiltin failed: %v", err)
+	}
+	if err := engine.UnregisterBuiltin("Trim"); err == nil {
+		t.Error("removing a builtin twice should fail")
+	}
+}
+
+// TestOverrideBuiltin verifies that an override replaces a builtin at run
+// time and its Go signature is used for type checking.
+func TestOverrideBuiltin(t *testing.T) {
+	var buf bytes.Buffer
+	engine, err := New(WithOutput(&buf))
+	if err != nil {
+		t.Fatalf("failed to create engine: %v", err)
+	}
+	var lines []string
+	if err := engine.OverrideBuiltin("PrintLn", func(s string) { lines = append(lines, s) }); err != nil {
+		t.Fatalf("OverrideBuiltin(PrintLn) failed: %v", err)
+	}
+	if err := engine.OverrideBuiltin("Length", func(s string) int64 { return int64(len(s)) * 10 }); err != nil {
+		t.Fatalf("OverrideBuiltin(Length) failed: %v", err)
+	}
+
+	if _, err := engine.Eval(`PrintLn(IntToStr(Length('abc')));
+println(IntToStr(length('')));`); err != nil {
+		t.Fatalf("Eval failed: %v", err)
+	}
+	if want := []string{"30", "0"}; strings.Join(lines, ",") != strings.Join(want, ",") {
+		t.Errorf("lines = %q, want %q", lines, want)
+	}
+	if buf.Len() != 0 {
+		t.Errorf("output = %q, want nothing", buf.String())
+	}
+
+	// The override's signature replaces the builtin's.
+	for _, script := range []string{`PrintLn(1);`, `var n := Length([1, 2]);`, `PrintLn('a', 'b');`} {
+		if _, err := engine.Compile(script); err == nil {
+			t.Errorf("Compile(%q) succeeded, want a type error", script)
+		}
+	}
+
+	// Overriding again replaces the previous override; removing it removes
+	// the builtin altogether.
+	if err := engine.OverrideBuiltin("Length", func(s string) int64 { return -1 }); err != nil {
+		t.Fatalf("second OverrideBuiltin failed: %v", err)
+	}
+	lines = nil
+	if _, err := engine.Eval(`PrintLn(IntToStr(Length('abc')));`); err != nil {
+		t.Fatalf("Eval failed: %v", err)
+	}
+	if len(lines) != 1 || lines[0] != "-1" {
+		t.Errorf("lines = %q, want [-1]", lines)
+	}
+	if err := engine.UnregisterBuiltin("Length"); err != nil {
+		t.Fatalf("UnregisterBuiltin failed: %v", err)
+	}
+	if _, err := engine.Compile(`var n := Length('abc');`); err == nil {
+		t.Error("Length should be unknown after removing its override")
+	}
+}
+
+// TestOverrideBuiltinParameterless verifies that a parameterless override is
+// invoked without parentheses like the builtin it replaces.
+func TestOverrideBuiltinParameterless(t *testing.T) {
+	var buf bytes.Buffer
+	engine, err := New(WithOutput(&buf))
+	if err != nil {
+		t.Fatalf("failed to create engine: %v", err)
+	}
+	if err := engine.OverrideBuiltin("Random", func() float64 { return 0.25 }); err != nil {
+		t.Fatalf("OverrideBuiltin failed: %v", err)
+	}
+	if _, err := engine.Eval(`var r: Float := Random;
+PrintLn(FloatToStr(r));
+PrintLn(FloatToStr(Random() * 2));`); err != nil {
+		t.Fatalf("Eval failed: %v", err)
+	}
+	if want := "0.25\n0.5\n"; buf.String() != want {
+		t.Errorf("output = %q, want %q", buf.String(), want)
+	}
+}
+
+// TestOverrideBuiltinErrors verifies that overrides are limited to builtins
+// and supported signatures.
+func TestOverrideBuiltinErrors(t *testing.T) {
+	var buf bytes.Buffer
+	engine, err := New(WithOutput(&buf))
+	if err != nil {
+		t.Fatalf("failed to create engine: %v", err)
+	}
+	if err := engine.OverrideBuiltin("NoSuchFunction", func() {}); err == nil {
+		t.Error("overriding an unknown function should fail")
+	}
+	if err := engine.OverrideBuiltin("Length", func(m map[string]int64) int64 { return 0 }); err == nil {
+		t.Error("overriding with an unsupported parameter type should fail")
+	}
+	if err := engine.OverrideBuiltin("Length", 42); err == nil {
+		t.Error("overriding with a non-function should fail")
+	}
+	if _, err := engine.Eval(`PrintLn(IntToStr(Length('abc')));`); err != nil {
+		t.Errorf("failed overrides should leave the builtin in place: %v", err)
+	}
+	if want := "3\n"; buf.String() != want {
+		t.Errorf("output = %q, want %q", buf.String(), want)
+	}
+}
@@ -0,0 +1,126 @@
+package dwscript
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/cwbudde/go-dws/internal/frontend"
+	"github.com/cwbudde/go-dws/internal/interp"
+	"github.com/cwbudde/go-dws/internal/interp/runtime"
+	"github.com/cwbudde/go-dws/internal/semantic"
+	"github.com/cwbudde/go-dws/internal/types"
+	"github.com/cwbudde/go-dws/pkg/ident"
+)
+
+// hostGlobal is a global variable injected with SetGlobal.
+type hostGlobal struct {
+	value any
+	typ   types.Type
+	name  string
+}
+
+// SetGlobal makes a Go value available to scripts compiled afterwards as a
+// predeclared global variable. Scripts can read and assign it like a
+// variable declared at the top of the program; each run starts from the value
+// given here. Setting the same name again (case-insensitively) replaces it.
+//
+// The DWScript type is inferred from the Go value (see runtime.GoType):
+//   - signed and unsigned integers → Integer
+//   - float32 and float64 → Float
+//   - string → String
+//   - bool → Boolean
+//   - slices and arrays → a dynamic array of the inferred element type
+//   - map[string]T → a record with one field per key. If the script declares
+//     a record type with the same field names whose field types accept the
+//     values, the variable has that type from its declaration on.
+//
+// Values of any other type, and nil, are rejected. The element type of a
+// []interface{} is inferred from its first element, so such a slice must not
+// be empty. Host globals are only available to the AST interpreter; a
+// program compiled in bytecode mode cannot reference them.
+//
+// Example:
+//
+//	engine.SetGlobal("MaxUsers", 100)
+//	engine.SetGlobal("Names", []string{"Ann", "Bob"})
+//	result, _ := engine.Eval(`PrintLn(Names[MaxUsers mod 2]);`)
+func (e *Engine) SetGlobal(name string, value any) error {
+	if err := validateIdentifierName(name); err != nil {
+		return fmt.Errorf("invalid global name: %w", err)
+	}
+	typ, err := runtime.GoType(value)
+	if err != nil {
+		return fmt.Errorf("global %s: %w", name, err)
+	}
+	// Convert once up front, so a value that cannot be represented is
+	// reported here rather than on every run.
+	if _, err := runtime.FromGoAs(value, typ); err != nil {
+		return fmt.Errorf("global %s: %w", name, err)
+	}
+
+	e.globalsMu.Lock()
+	defer e.globalsMu.Unlock()
+	if e.globals == nil {
+		e.globals = ident.NewMap[hostGlobal]()
+	}
+	e.globals.Set(name, hostGlobal{name: name, value: value, typ: typ})
+	return nil
+}
+
+// hostGlobals returns a snapshot of the globals set with SetGlobal, sorted by
+// name so compiles are deterministic.
+func (e *Engine) hostGlobals() []hostGlobal {
+	e.globalsMu.Lock()
+	defer e.globalsMu.Unlock()
+	if e.globals == nil {
+		return nil
+	}
+	globals := make([]hostGlobal, 0, e.globals.Len())
+	e.globals.Range(func(_ string, global hostGlobal) bool {
+		globals = append(globals, global)
+		return true
+	})
+	sort.Slice(globals, func(i, j int) bool { return ident.Compare(globals[i].name, globals[j].name) < 0 })
+	return globals
+}
+
+// frontendGlobals converts globals for semantic analysis.
+func frontendGlobals(globals []hostGlobal) []frontend.Global {
+	if len(globals) == 0 {
+		return nil
+	}
+	declared := make([]frontend.Global, len(globals))
+	for i, global := range globals {
+		declared[i] = frontend.Global{Name: global.name, Type: global.typ}
+	}
+	return declared
+}
+
+// defineHostGlobals converts globals to runtime values and defines them in
+// the interpreter's global environment.
+func defineHostGlobals(interpreter *interp.Interpreter, globals []hostGlobal) error {
+	for _, global := range globals {
+		value, err := runtime.FromGoAs(global.value, global.typ)
+		if err != nil {
+			return fmt.Errorf("global %s: %w", global.name, err)
+		}
+		interpreter.Env().Define(global.name, value)
+	}
+	return nil
+}
+
+// boundHostGlobals returns globals with the types semantic analysis settled
+// on, such as the script record type matched to a Go map.
+func boundHostGlobals(globals []hostGlobal, analyzer *semantic.Analyzer) []hostGlobal {
+	if analyzer == nil {
+		return globals
+	}
+	bound := make([]hostGlobal, len(globals))
+	for i, global := range globals {
+		if sym, ok := analyzer.GetSymbolTable().Resolve(global.name); ok && sym.Type != nil {
+			global.typ = sym.Type
+		}
+		bound[i] = global
+	}
+	return bound
+}
@@ -0,0 +1,135 @@
+package dwscript
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+// TestSetGlobal verifies that Go values injected with SetGlobal type-check
+// and can be read and assigned from a script.
+func TestSetGlobal(t *testing.T) {
+	var buf bytes.Buffer
+	engine, err := New(WithOutput(&buf))
+	if err != nil {
+		t.Fatalf("failed to create engine: %v", err)
+	}
+	if err := engine.SetGlobal("Limit", 3); err != nil {
+		t.Fatalf("SetGlobal(Limit) failed: %v", err)
+	}
+	if err := engine.SetGlobal("Greeting", "hello"); err != nil {
+		t.Fatalf("SetGlobal(Greeting) failed: %v", err)
+	}
+	if err := engine.SetGlobal("Scores", []int64{10, 20, 30}); err != nil {
+		t.Fatalf("SetGlobal(Scores) failed: %v", err)
+	}
+
+	program, err := engine.Compile(`
+var total: Integer;
+for var i := 0 to limit - 1 do
+  total := total + Scores[i];
+PrintLn(Greeting + ' ' + IntToStr(total) + ' ' + IntToStr(Length(Scores)));
+Limit := Limit * 2;
+`)
+	if err != nil {
+		t.Fatalf("Compile failed: %v", err)
+	}
+
+	for run := 0; run < 2; run++ {
+		buf.Reset()
+		result, err := engine.Run(program)
+		if err != nil {
+			t.Fatalf("Run failed: %v", err)
+		}
+		if got := strings.TrimSpace(buf.String()); got != "hello 60 3" {
+			t.Errorf("output = %q, want %q", got, "hello 60 3")
+		}
+		if limit, ok, err := result.GlobalValue("limit"); err != nil || !ok || limit != int64(6) {
+			t.Errorf("GlobalValue(limit) = %v, %v, %v; want 6", limit, ok, err)
+		}
+	}
+}
+
+// TestSetGlobal_TypeErrors verifies that the inferred types take part in
+// semantic analysis.
+func TestSetGlobal_TypeErrors(t *testing.T) {
+	engine, err := New()
+	if err != nil {
+		t.Fatalf("failed to create engine: %v", err)
+	}
+	if err := engine.SetGlobal("Name", "x"); err != nil {
+		t.Fatalf("SetGlobal failed: %v", err)
+	}
+	if err := engine.SetGlobal("Switches", []bool{true}); err != nil {
+		t.Fatalf("SetGlobal failed: %v", err)
+	}
+
+	for _, source := range []string{
+		"var i: Integer := Name;",
+		"Switches[0] := 1;",
+		"PrintLn(Missing);",
+	} {
+		if _, err := engine.Compile(source); err == nil {
+			t.Errorf("Compile(%q) succeeded, want a type error", source)
+		}
+	}
+}
+
+// TestSetGlobal_Record verifies that a Go map becomes a record, typed as the
+// script's record declaration with the same fields.
+func TestSetGlobal_Record(t *testing.T) {
+	var buf bytes.Buffer
+	engine, err := New(WithOutput(&buf))
+	if err != nil {
+		t.Fatalf("failed to create engine: %v", err)
+	}
+	if err := engine.SetGlobal("Origin", map[string]any{"X": 1, "y": 2.5}); err != nil {
+		t.Fatalf("SetGlobal failed: %v", err)
+	}
+
+	_, err = engine.Eval(`
+type TPoint = record
+  X: Integer;
+  Y: Float;
+end;
+procedure Show(p: TPoint);
+begin
+  PrintLn(IntToStr(p.X) + ' ' + FloatToStr(p.Y));
+end;
+Show(Origin);
+`)
+	if err != nil {
+		t.Fatalf("Eval failed: %v", err)
+	}
+	if got := strings.TrimSpace(buf.String()); got != "1 2.5" {
+		t.Errorf("output = %q, want %q", got, "1 2.5")
+	}
+}
+
+// TestSetGlobal_Invalid verifies that unsupported values and names are
+// rejected when they are set.
+func TestSetGlobal_Invalid(t *testing.T) {
+	engine, err := New()
+	if err != nil {
+		t.Fatalf("failed to create engine: %v", err)
+	}
+
+	tests := []struct {
+		value any
+		name  string
+	}{
+		{name: "Nothing", value: nil},
+		{name: "Channel", value: make(chan int)},
+		{name: "Empty", value: []any{}},
+		{name: "Mixed", value: []any{1, "two"}},
+		{name: "IntKeys", value: map[int]string{1: "one"}},
+		{name: "Huge", value: uint64(1) << 63},
+		{name: "begin", value: 1},
+		{name: "1st", value: 1},
+	}
+	for _, tt := range tests {
+		if err := engine.SetGlobal(tt.name, tt.value); err == nil {
+			t.Errorf("SetGlobal(%q, %#v) succeeded, want an error", tt.name, tt.value)
+		}
+	}
+}
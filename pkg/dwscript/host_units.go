@@ -0,0 +1,176 @@
+package dwscript
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/cwbudde/go-dws/internal/frontend"
+	"github.com/cwbudde/go-dws/internal/interp"
+	"github.com/cwbudde/go-dws/internal/interp/runtime"
+	"github.com/cwbudde/go-dws/internal/units"
+	"github.com/cwbudde/go-dws/pkg/ident"
+)
+
+// hostUnit is a unit registered with RegisterUnit.
+type hostUnit struct {
+	name string
+	// functions lists the unit's functions; each is registered as an
+	// external function named "<unit>.<function>".
+	functions []frontend.Function
+	consts    []hostGlobal
+}
+
+// RegisterUnit makes a unit implemented in Go available to scripts, which
+// call its functions and read its constants with qualified names:
+//
+//	engine.RegisterUnit("MyLib", map[string]any{
+//	    "Twice": func(x int64) int64 { return 2 * x },
+//	}, map[string]any{
+//	    "Version": "1.2",
+//	})
+//
+//	result, _ := engine.Eval(`
+//	    uses MyLib;
+//	    PrintLn(MyLib.Version + ' ' + IntToStr(MyLib.Twice(21)));
+//	`)
+//
+// Functions follow the calling convention of RegisterFunction, and constant
+// types are inferred from the Go values like SetGlobal; each run starts from
+// the values given here. Names are case-insensitive and a unit name can only
+// be registered once. WithUnitResolver is not consulted for registered units.
+//
+// Scripts are type-checked against the Go signatures, so as for
+// OverrideBuiltin the parameter and result types of the functions are limited
+// to Integer, Float, String, Boolean and arrays of them. Registered units are
+// only available to the AST interpreter.
+func (e *Engine) RegisterUnit(name string, funcs map[string]any, consts map[string]any) error {
+	if err := validateIdentifierName(name); err != nil {
+		return fmt.Errorf("invalid unit name: %w", err)
+	}
+
+	unit := &hostUnit{name: name}
+	wrappers := make(map[string]*externalFunctionWrapper, len(funcs))
+	for _, fnName := range sortedKeys(funcs) {
+		if err := validateIdentifierName(fnName); err != nil {
+			return fmt.Errorf("unit %s: invalid function name: %w", name, err)
+		}
+		if _, ok := wrappers[ident.Normalize(fnName)]; ok {
+			return fmt.Errorf("unit %s: function %s is declared twice", name, fnName)
+		}
+		wrapper, err := newExternalFunctionWrapper(name+"."+fnName, funcs[fnName])
+		if err != nil {
+			return fmt.Errorf("unit %s: %w", name, err)
+		}
+		funcType, err := hostFunctionType(wrapper.signature)
+		if err != nil {
+			return fmt.Errorf("unit %s: invalid function signature for %s: %w", name, fnName, err)
+		}
+		wrappers[ident.Normalize(fnName)] = wrapper
+		unit.functions = append(unit.functions, frontend.Function{Name: fnName, Type: funcType})
+	}
+	for _, constName := range sortedKeys(consts) {
+		if err := validateIdentifierName(constName); err != nil {
+			return fmt.Errorf("unit %s: invalid constant name: %w", name, err)
+		}
+		v := consts[constName]
+		typ, err := runtime.GoType(v)
+		if err != nil {
+			return fmt.Errorf("unit %s: constant %s: %w", name, constName, err)
+		}
+		if _, err := runtime.FromGoAs(v, typ); err != nil {
+			return fmt.Errorf("unit %s: constant %s: %w", name, constName, err)
+		}
+		unit.consts = append(unit.consts, hostGlobal{name: constName, value: v, typ: typ})
+	}
+
+	e.globalsMu.Lock()
+	defer e.globalsMu.Unlock()
+	if e.units == nil {
+		e.units = ident.NewMap[*hostUnit]()
+	}
+	if e.units.Has(name) {
+		return fmt.Errorf("unit %s is already registered", name)
+	}
+	if e.externalFunctions == nil {
+		e.externalFunctions = interp.NewExternalFunctionRegistry()
+	}
+	for _, fn := range unit.functions {
+		if err := e.externalFunctions.Register(name+"."+fn.Name, wrappers[ident.Normalize(fn.Name)]); err != nil {
+			return err
+		}
+	}
+	e.units.Set(name, unit)
+	e.ClearCache()
+	return nil
+}
+
+// hostUnits returns a snapshot of the units registered with RegisterUnit,
+// sorted by name.
+func (e *Engine) hostUnits() []*hostUnit {
+	e.globalsMu.Lock()
+	defer e.globalsMu.Unlock()
+	if e.units == nil {
+		return nil
+	}
+	registered := make([]*hostUnit, 0, e.units.Len())
+	e.units.Range(func(_ string, unit *hostUnit) bool {
+		registered = append(registered, unit)
+		return true
+	})
+	sort.Slice(registered, func(i, j int) bool { return ident.Compare(registered[i].name, registered[j].name) < 0 })
+	return registered
+}
+
+// frontendUnits returns the registered units as the frontend declares them to
+// the semantic analyzer.
+func frontendUnits(registered []*hostUnit) []frontend.Unit {
+	if len(registered) == 0 {
+		return nil
+	}
+	declared := make([]frontend.Unit, len(registered))
+	for i, hu := range registered {
+		declared[i] = frontend.Unit{Name: hu.name, Functions: hu.functions, Constants: frontendGlobals(hu.consts)}
+	}
+	return declared
+}
+
+// defineHostUnits adds the registered units to the interpreter's unit
+// registry, so qualified names resolve to their functions and constants.
+func defineHostUnits(interpreter *interp.Interpreter, registered []*hostUnit) error {
+	if len(registered) == 0 {
+		return nil
+	}
+	registry := interpreter.GetUnitRegistry()
+	if registry == nil {
+		registry = units.NewUnitRegistry(nil)
+		interpreter.SetUnitRegistry(registry)
+	}
+	for _, hu := range registered {
+		unit := units.NewUnit(hu.name, "")
+		for _, fn := range hu.functions {
+			unit.DefineHostFunction(fn.Name, hu.name+"."+fn.Name)
+		}
+		for _, c := range hu.consts {
+			value, err := runtime.FromGoAs(c.value, c.typ)
+			if err != nil {
+				return fmt.Errorf("unit %s: constant %s: %w", hu.name, c.name, err)
+			}
+			unit.DefineConstant(c.name, value)
+		}
+		if err := registry.RegisterUnit(hu.name, unit); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// sortedKeys returns the keys of m in sorted order, so registration errors
+// are reported deterministically.
+func sortedKeys(m map[string]any) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
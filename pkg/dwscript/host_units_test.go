@@ -0,0 +1,147 @@
+package dwscript
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+// TestRegisterUnit verifies that scripts call the functions and read the
+// constants of a Go-backed unit through qualified names.
+func TestRegisterUnit(t *testing.T) {
+	var buf bytes.Buffer
+	engine, err := New(WithOutput(&buf))
+	if err != nil {
+		t.Fatalf("failed to create engine: %v", err)
+	}
+	err = engine.RegisterUnit("MyLib", map[string]any{
+		"Twice": func(x int64) int64 { return 2 * x },
+		"Greet": func(name string) string { return "hello " + name },
+	}, map[string]any{
+		"Version": "1.2",
+		"Limit":   10,
+	})
+	if err != nil {
+		t.Fatalf("RegisterUnit failed: %v", err)
+	}
+
+	_, err = engine.Eval(`uses MyLib;
+PrintLn(MyLib.Greet('Ann'));
+PrintLn(MyLib.Twice(21));
+PrintLn(mylib.twice(MyLib.Limit));
+PrintLn(MyLib.Version);`)
+	if err != nil {
+		t.Fatalf("Eval failed: %v", err)
+	}
+	want := "hello Ann\n42\n20\n1.2\n"
+	if got := buf.String(); got != want {
+		t.Errorf("output = %q, want %q", got, want)
+	}
+}
+
+// TestRegisterUnit_WithUnitResolver verifies that registered units are not
+// passed to the unit resolver and can be used next to file-based units.
+func TestRegisterUnit_WithUnitResolver(t *testing.T) {
+	var buf bytes.Buffer
+	engine, err := New(WithOutput(&buf), WithUnitResolver(mapUnitResolver(map[string]string{
+		"helpers": `unit Helpers;
+interface
+function Inc2(x: Integer): Integer;
+implementation
+function Inc2(x: Integer): Integer;
+begin
+  Result := x + 2;
+end;
+end.`,
+	})))
+	if err != nil {
+		t.Fatalf("failed to create engine: %v", err)
+	}
+	if err := engine.RegisterUnit("MyLib", map[string]any{
+		"Twice": func(x int64) int64 { return 2 * x },
+	}, nil); err != nil {
+		t.Fatalf("RegisterUnit failed: %v", err)
+	}
+
+	if _, err := engine.Eval("uses MyLib, Helpers;\nPrintLn(MyLib.Twice(Inc2(1)));"); err != nil {
+		t.Fatalf("Eval failed: %v", err)
+	}
+	if got := strings.TrimSpace(buf.String()); got != "6" {
+		t.Errorf("output = %q, want %q", got, "6")
+	}
+}
+
+// TestRegisterUnit_Errors verifies that invalid registrations are rejected.
+func TestRegisterUnit_Errors(t *testing.T) {
+	engine, err := New()
+	if err != nil {
+		t.Fatalf("failed to create engine: %v", err)
+	}
+	if err := engine.RegisterUnit("MyLib", nil, map[string]any{"Pi": 3.14}); err != nil {
+		t.Fatalf("RegisterUnit failed: %v", err)
+	}
+
+	tests := []struct {
+		name   string
+		unit   string
+		funcs  map[string]any
+		consts map[string]any
+		want   string
+	}{
+		{"duplicate unit", "mylib", nil, nil, "already registered"},
+		{"invalid unit name", "My Lib", nil, nil, "invalid unit name"},
+		{"not a function", "Other", map[string]any{"F": 42}, nil, "expected function"},
+		{"unsupported constant", "Other", nil, map[string]any{"C": struct{}{}}, "constant C"},
+		{"duplicate function", "Other", map[string]any{"F": func() {}, "f": func() {}}, nil, "declared twice"},
+		{"untyped function", "Other", map[string]any{"F": func(m map[string]any) {}}, nil, "invalid function signature for F"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := engine.RegisterUnit(tt.unit, tt.funcs, tt.consts)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("RegisterUnit() error = %v, want containing %q", err, tt.want)
+			}
+		})
+	}
+
+	if _, err := engine.Eval("uses MyLib;\nPrintLn(MyLib.Missing(1));"); err == nil {
+		t.Error("expected an error calling an unknown unit function")
+	}
+}
+
+// TestRegisterUnit_TypeChecked verifies that scripts are type-checked against
+// the functions and constants of registered units.
+func TestRegisterUnit_TypeChecked(t *testing.T) {
+	engine, err := New()
+	if err != nil {
+		t.Fatalf("failed to create engine: %v", err)
+	}
+	if err := engine.RegisterUnit("Host", map[string]any{
+		"Twice": func(x int64) int64 { return 2 * x },
+	}, map[string]any{"Lim": 10}); err != nil {
+		t.Fatalf("RegisterUnit failed: %v", err)
+	}
+
+	if _, err := engine.Compile("uses Host;\nvar s: String := Host.Lim;"); err == nil {
+		t.Error("expected a type error assigning an Integer constant to a String")
+	}
+	if _, err := engine.Compile("uses Host;\nPrintLn(Host.Twice('four'));"); err == nil {
+		t.Error("expected a type error passing a String to an Integer parameter")
+	}
+	_, err = engine.Compile("uses Host;\nPrintLn(Host.Missing);")
+	if err == nil || !strings.Contains(err.Error(), `Unknown name "Host.Missing"`) {
+		t.Errorf("Compile() error = %v, want unknown name Host.Missing", err)
+	}
+
+	session, err := engine.NewSession()
+	if err != nil {
+		t.Fatalf("NewSession failed: %v", err)
+	}
+	result, err := session.EvalWithOutput("uses Host;\nvar n: Integer := Host.Twice(Host.Lim);\nPrintLn(n);", nil)
+	if err != nil {
+		t.Fatalf("session Eval failed: %v", err)
+	}
+	if got := strings.TrimSpace(result.Output); got != "20" {
+		t.Errorf("output = %q, want %q", got, "20")
+	}
+}
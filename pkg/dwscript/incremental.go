@@ -0,0 +1,299 @@
+package dwscript
+
+import (
+	"reflect"
+	"runtime"
+	"sort"
+	"strings"
+	"sync"
+	"weak"
+
+	"github.com/cwbudde/go-dws/internal/frontend"
+	"github.com/cwbudde/go-dws/pkg/ast"
+	"github.com/cwbudde/go-dws/pkg/token"
+)
+
+// ParseIncremental parses newSrc, reusing as much of oldTree as it safely can.
+// oldTree must have been returned by Parse or ParseIncremental on this engine
+// for oldSrc, and edits must describe how oldSrc became newSrc, with offsets
+// into oldSrc.
+//
+// When every edit falls strictly inside one top-level statement terminated by
+// a semicolon, such as a function declaration, only that statement is
+// reparsed. The other statements are reused and the positions of those
+// following the edit are shifted by the line, column and byte delta. Source
+// comments do not affect this, as the parser skips them. Comments attached to
+// the tree through Program.Comments are carried over and shifted the same way.
+// In every other case ParseIncremental falls back to a full Parse, including
+// when oldTree had syntax errors, when an edited region contains a compiler
+// directive, when an attached comment lies inside the edited statement, or
+// when the edits do not match the two sources.
+//
+// The result and error are the same as Parse(newSrc) would return. oldTree
+// is consumed: its nodes may be moved into the new tree and updated in
+// place, so it must not be used after the call.
+//
+// Example usage in an LSP server:
+//
+//	tree, err = engine.ParseIncremental(tree, text, newText, []dwscript.TextEdit{
+//	    {Start: 120, End: 120, NewText: "x"},
+//	})
+//	text = newText
+func (e *Engine) ParseIncremental(oldTree *ast.Program, oldSrc, newSrc string, edits []TextEdit) (*ast.Program, error) {
+	if tree := e.reparseStatement(oldTree, oldSrc, newSrc, edits); tree != nil {
+		return tree, nil
+	}
+	return e.Parse(newSrc)
+}
+
+// reparseStatement performs the incremental update described on
+// ParseIncremental. It returns nil, leaving oldTree untouched, whenever the
+// reuse cannot be proven equivalent to a full parse.
+func (e *Engine) reparseStatement(oldTree *ast.Program, oldSrc, newSrc string, edits []TextEdit) *ast.Program {
+	if oldTree == nil || len(edits) == 0 || !e.isCleanTree(oldTree) {
+		return nil
+	}
+
+	// Collapse the edits into one changed range of oldSrc, and check that
+	// everything outside it is identical in both sources.
+	sorted := append([]TextEdit(nil), edits...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Start < sorted[j].Start })
+	prevEnd := 0
+	for _, edit := range sorted {
+		if edit.Start < prevEnd || edit.End < edit.Start || edit.End > len(oldSrc) {
+			return nil
+		}
+		prevEnd = edit.End
+	}
+	start, end := sorted[0].Start, prevEnd
+	delta := len(newSrc) - len(oldSrc)
+	if end+delta < start || oldSrc[:start] != newSrc[:start] || oldSrc[end:] != newSrc[end+delta:] {
+		return nil
+	}
+
+	index := -1
+	for i, stmt := range oldTree.Statements {
+		if stmt == nil {
+			return nil
+		}
+		if stmt.Pos().Offset < start && end < stmt.End().Offset {
+			index = i
+			break
+		}
+	}
+	if index < 0 {
+		return nil
+	}
+	old := oldTree.Statements[index]
+	oldStart, oldEnd := old.Pos(), old.End()
+	if oldStart.Source != "" || oldEnd.Source != "" || oldEnd.Offset > len(oldSrc) {
+		return nil
+	}
+
+	// A semicolon ends every top-level statement, so the text after it is
+	// parsed the same way whatever the statement contains.
+	oldText := oldSrc[oldStart.Offset:oldEnd.Offset]
+	newText := newSrc[oldStart.Offset : oldEnd.Offset+delta]
+	if !strings.HasSuffix(oldText, ";") || strings.Contains(oldText, "{$") || strings.Contains(newText, "{$") {
+		return nil
+	}
+	if !commentsMovable(oldTree.Comments, old) {
+		return nil
+	}
+
+	result := frontend.ParseWithConfig(newText, "", e.parserConfig(), e.lexerOptions()...)
+	if len(result.Diagnostics) > 0 || result.Program == nil || len(result.Program.Statements) != 1 {
+		return nil
+	}
+	stmt := result.Program.Statements[0]
+	if stmt == nil || reflect.TypeOf(stmt) != reflect.TypeOf(old) ||
+		stmt.Pos().Offset != 0 || stmt.End().Offset != len(newText) {
+		return nil
+	}
+
+	shiftPositions(stmt, func(pos *token.Position) {
+		if !pos.IsValid() {
+			return
+		}
+		if pos.Line == 1 {
+			pos.Column += oldStart.Column - 1
+		}
+		pos.Line += oldStart.Line - 1
+		pos.Offset += oldStart.Offset
+	})
+
+	newEnd := stmt.End()
+	lineDelta := newEnd.Line - oldEnd.Line
+	columnDelta := newEnd.Column - oldEnd.Column
+	following := func(pos *token.Position) {
+		if pos.Source != "" || !pos.IsValid() || pos.Offset < oldEnd.Offset {
+			return
+		}
+		if pos.Line == oldEnd.Line {
+			pos.Column += columnDelta
+		}
+		pos.Line += lineDelta
+		pos.Offset += delta
+	}
+
+	tree := &ast.Program{
+		Statements: make([]ast.Statement, len(oldTree.Statements)),
+		EndPos:     oldTree.EndPos,
+	}
+	copy(tree.Statements, oldTree.Statements)
+	tree.Statements[index] = stmt
+	shiftPositions(tree.Statements[index+1:], following)
+	following(&tree.EndPos)
+	tree.Comments = moveComments(oldTree.Comments, old, stmt, following)
+
+	e.forgetTree(oldTree)
+	e.rememberTree(tree)
+	return tree
+}
+
+// commentsMovable reports whether the comments attached to the tree can be
+// carried over when old is replaced by its reparsed version: no comment may
+// lie inside old or be attached to one of the nodes below it, since the
+// reparsed statement has new nodes and the parser does not attach comments.
+func commentsMovable(comments ast.CommentMap, old ast.Statement) bool {
+	if len(comments) == 0 {
+		return true
+	}
+	inside := make(map[ast.Node]bool)
+	ast.Inspect(old, func(node ast.Node) bool {
+		if node != nil && node != old {
+			inside[node] = true
+		}
+		return true
+	})
+	start, end := old.Pos().Offset, old.End().Offset
+	overlaps := func(group *ast.CommentGroup) bool {
+		return group != nil && len(group.Comments) > 0 &&
+			group.Pos().Offset < end && group.End().Offset > start
+	}
+	for node, nc := range comments {
+		if inside[node] || nc == nil || overlaps(nc.Leading) || overlaps(nc.Trailing) {
+			return false
+		}
+	}
+	return true
+}
+
+// moveComments returns the comment map of the updated tree: the comments of
+// old are attached to its replacement stmt, and the positions of comments
+// after the edited statement are shifted like the statements.
+func moveComments(comments ast.CommentMap, old, stmt ast.Statement, shift func(*token.Position)) ast.CommentMap {
+	if len(comments) == 0 {
+		return comments
+	}
+	moved := make(ast.CommentMap, len(comments))
+	groups := make([]*ast.NodeComments, 0, len(comments))
+	for node, nc := range comments {
+		if node == old {
+			node = stmt
+		}
+		moved[node] = nc
+		groups = append(groups, nc)
+	}
+	shiftPositions(groups, shift)
+	return moved
+}
+
+var (
+	positionType = reflect.TypeOf(token.Position{})
+	astPkgPath   = reflect.TypeOf(ast.Program{}).PkgPath()
+	tokenPkgPath = positionType.PkgPath()
+)
+
+// shiftPositions applies shift to every token.Position reachable from root
+// through AST and token values, visiting each node once.
+func shiftPositions(root any, shift func(*token.Position)) {
+	visited := make(map[uintptr]bool)
+	var walk func(v reflect.Value)
+	walk = func(v reflect.Value) {
+		switch v.Kind() {
+		case reflect.Pointer:
+			if v.IsNil() || visited[v.Pointer()] {
+				return
+			}
+			visited[v.Pointer()] = true
+			walk(v.Elem())
+		case reflect.Interface:
+			if !v.IsNil() {
+				if elem := v.Elem(); isSyntaxType(elem.Type()) {
+					walk(elem)
+				}
+			}
+		case reflect.Slice, reflect.Array:
+			for i := 0; i < v.Len(); i++ {
+				walk(v.Index(i))
+			}
+		case reflect.Struct:
+			if v.Type() == positionType {
+				if v.CanAddr() {
+					shift(v.Addr().Interface().(*token.Position))
+				}
+				return
+			}
+			for _, i := range positionFields(v.Type()) {
+				walk(v.Field(i))
+			}
+		}
+	}
+	walk(reflect.ValueOf(root))
+}
+
+// positionFieldCache maps a struct type to the result of positionFields.
+var positionFieldCache sync.Map
+
+// positionFields returns the indices of the exported fields of struct type t
+// that can lead to a token.Position.
+func positionFields(t reflect.Type) []int {
+	if cached, ok := positionFieldCache.Load(t); ok {
+		return cached.([]int)
+	}
+	var fields []int
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.IsExported() && (field.Type == positionType || isSyntaxType(field.Type)) {
+			fields = append(fields, i)
+		}
+	}
+	positionFieldCache.Store(t, fields)
+	return fields
+}
+
+// isSyntaxType reports whether t is, or holds, a type declared in the ast or
+// token packages, so shiftPositions never follows pointers into other shared
+// data.
+func isSyntaxType(t reflect.Type) bool {
+	for t.Kind() == reflect.Slice || t.Kind() == reflect.Array || t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	switch t.Kind() {
+	case reflect.Interface, reflect.Struct:
+		return t.PkgPath() == astPkgPath || t.PkgPath() == tokenPkgPath
+	}
+	return false
+}
+
+// rememberTree records tree as parsed without syntax errors. The record is
+// dropped once the tree is garbage collected.
+func (e *Engine) rememberTree(tree *ast.Program) {
+	key := weak.Make(tree)
+	if _, loaded := e.cleanTrees.LoadOrStore(key, struct{}{}); !loaded {
+		cleanTrees := &e.cleanTrees
+		runtime.AddCleanup(tree, func(key weak.Pointer[ast.Program]) {
+			cleanTrees.Delete(key)
+		}, key)
+	}
+}
+
+func (e *Engine) forgetTree(tree *ast.Program) {
+	e.cleanTrees.Delete(weak.Make(tree))
+}
+
+func (e *Engine) isCleanTree(tree *ast.Program) bool {
+	_, ok := e.cleanTrees.Load(weak.Make(tree))
+	return ok
+}
@@ -0,0 +1,72 @@
+package dwscript
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// incrementalBenchmarkSource returns a commented script of roughly 10,000
+// lines: one large function followed by many small ones.
+func incrementalBenchmarkSource() string {
+	var sb strings.Builder
+	sb.WriteString("function Large(n: Integer): Integer;\nbegin\n  Result := 0;\n")
+	for i := 0; i < 500; i++ {
+		fmt.Fprintf(&sb, "  Result := Result + n * %d; // term %d\n", i, i)
+	}
+	sb.WriteString("end;\n\n")
+	for i := 0; i < 1900; i++ {
+		fmt.Fprintf(&sb, "{ P%d prints its offset argument }\nprocedure P%d(x: Integer);\nbegin\n  PrintLn(x + %d);\nend;\n\n", i, i, i)
+	}
+	sb.WriteString("PrintLn(Large(2));\n")
+	return sb.String()
+}
+
+// BenchmarkParseIncremental compares a full reparse with an incremental one
+// after a single-character edit inside the large function. Each iteration
+// alternately inserts and removes the character, so the incremental case
+// always starts from the previous result.
+func BenchmarkParseIncremental(b *testing.B) {
+	source := incrementalBenchmarkSource()
+	offset := strings.Index(source, "n * 250;") + 1
+	insert := TextEdit{Start: offset, End: offset, NewText: "0"}
+	edited := applyEdits(source, []TextEdit{insert})
+	remove := TextEdit{Start: offset, End: offset + 1}
+
+	b.Run("full", func(b *testing.B) {
+		engine, err := New()
+		if err != nil {
+			b.Fatalf("failed to create engine: %v", err)
+		}
+
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			if _, err := engine.Parse(edited); err != nil {
+				b.Fatalf("Parse failed: %v", err)
+			}
+		}
+	})
+
+	b.Run("incremental", func(b *testing.B) {
+		engine, err := New()
+		if err != nil {
+			b.Fatalf("failed to create engine: %v", err)
+		}
+		tree, err := engine.Parse(source)
+		if err != nil {
+			b.Fatalf("Parse failed: %v", err)
+		}
+
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			if i%2 == 0 {
+				tree, err = engine.ParseIncremental(tree, source, edited, []TextEdit{insert})
+			} else {
+				tree, err = engine.ParseIncremental(tree, edited, source, []TextEdit{remove})
+			}
+			if err != nil {
+				b.Fatalf("ParseIncremental failed: %v", err)
+			}
+		}
+	})
+}
@@ -0,0 +1,282 @@
+package dwscript
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/cwbudde/go-dws/pkg/ast"
+	"github.com/cwbudde/go-dws/pkg/token"
+)
+
+const incrementalSource = `procedure First;
+begin
+  PrintLn('first');
+end;
+
+function Twice(x: Integer): Integer;
+begin
+  Result := x * 2;
+end;
+
+procedure A; begin PrintLn('a'); end; procedure B; begin PrintLn('b'); end;
+
+var Total := Twice(21);
+PrintLn(Total);
+`
+
+// insertAt returns an edit inserting text before the first occurrence of
+// marker in src.
+func insertAt(t *testing.T, src, marker, text string) TextEdit {
+	t.Helper()
+	offset := strings.Index(src, marker)
+	if offset < 0 {
+		t.Fatalf("marker %q not found", marker)
+	}
+	return TextEdit{Start: offset, End: offset, NewText: text}
+}
+
+func mustParse(t *testing.T, engine *Engine, src string) *ast.Program {
+	t.Helper()
+	tree, err := engine.Parse(src)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	return tree
+}
+
+// TestParseIncremental verifies that an incremental reparse yields the same
+// tree as a full parse, reusing the statements the edit did not touch.
+func TestParseIncremental(t *testing.T) {
+	src := incrementalSource
+	tests := []struct {
+		name   string
+		edits  []TextEdit
+		edited int // index of the reparsed statement
+	}{
+		{name: "insert character", edits: []TextEdit{insertAt(t, src, " * 2", "0")}, edited: 1},
+		{name: "insert lines", edits: []TextEdit{insertAt(t, src, "  Result", "  PrintLn(x);\n\n")}, edited: 1},
+		{name: "delete text", edits: []TextEdit{{Start: strings.Index(src, " * 2"), End: strings.Index(src, " * 2") + 4}}, edited: 1},
+		{name: "same line as next statement", edits: []TextEdit{insertAt(t, src, "'a'", "'ä' + ")}, edited: 2},
+		{name: "several edits in one statement", edits: []TextEdit{
+			insertAt(t, src, "first')", "the "),
+			insertAt(t, src, "');\nend;\n\nfunction", "!"),
+		}, edited: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			engine, err := New()
+			if err != nil {
+				t.Fatalf("failed to create engine: %v", err)
+			}
+			oldTree := mustParse(t, engine, src)
+			oldStatements := append([]ast.Statement(nil), oldTree.Statements...)
+			newSrc := applyEdits(src, tt.edits)
+
+			tree, err := engine.ParseIncremental(oldTree, src, newSrc, tt.edits)
+			if err != nil {
+				t.Fatalf("ParseIncremental failed: %v", err)
+			}
+			want := mustParse(t, engine, newSrc)
+			if !reflect.DeepEqual(tree, want) {
+				t.Fatalf("incremental tree differs from a full parse:\n%s", diffTrees(tree, want))
+			}
+			for i, stmt := range tree.Statements {
+				if reused := stmt == oldStatements[i]; reused == (i == tt.edited) {
+					t.Errorf("statement %d reused = %v", i, reused)
+				}
+			}
+
+			// The result can itself be edited incrementally.
+			edit := insertAt(t, newSrc, "Total)", "Total + ")
+			nextSrc := applyEdits(newSrc, []TextEdit{edit})
+			next, err := engine.ParseIncremental(tree, newSrc, nextSrc, []TextEdit{edit})
+			if err != nil {
+				t.Fatalf("second ParseIncremental failed: %v", err)
+			}
+			if want := mustParse(t, engine, nextSrc); !reflect.DeepEqual(next, want) {
+				t.Errorf("second incremental tree differs from a full parse:\n%s", diffTrees(next, want))
+			}
+		})
+	}
+}
+
+// TestParseIncrementalFallback verifies that edits the incremental path
+// cannot handle still produce the result of a full parse.
+func TestParseIncrementalFallback(t *testing.T) {
+	src := incrementalSource
+	between := strings.Index(src, "\nfunction Twice")
+	body := strings.Index(src, " * 2")
+	tests := []struct {
+		name    string
+		oldSrc  string
+		edits   []TextEdit
+		newSrc  string // defaults to oldSrc with edits applied
+		wantErr bool
+	}{
+		{name: "between statements", edits: []TextEdit{{Start: between, End: between, NewText: "\n"}}},
+		{name: "spanning statements", edits: []TextEdit{{Start: body, End: between + 20}}, wantErr: true},
+		{name: "syntax error", edits: []TextEdit{{Start: body, End: body, NewText: " +"}}, wantErr: true},
+		{name: "closes the statement early", edits: []TextEdit{{Start: body, End: body, NewText: "; end; begin x := 1"}}},
+		{name: "directive", edits: []TextEdit{{Start: body, End: body, NewText: "{$IFDEF X}+1{$ENDIF}"}}},
+		{name: "edits do not match", edits: []TextEdit{{Start: body, End: body, NewText: "0"}}, newSrc: src + "\nPrintLn(1);"},
+		{name: "old tree had errors", oldSrc: strings.Replace(src, "var Total", "var Total := ;\nvar Total", 1),
+			edits: []TextEdit{{Start: body, End: body, NewText: "0"}}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			engine, err := New()
+			if err != nil {
+				t.Fatalf("failed to create engine: %v", err)
+			}
+			oldSrc := src
+			if tt.oldSrc != "" {
+				oldSrc = tt.oldSrc
+			}
+			oldTree, _ := engine.Parse(oldSrc)
+			newSrc := tt.newSrc
+			if newSrc == "" {
+				newSrc = applyEdits(oldSrc, tt.edits)
+			}
+
+			tree, err := engine.ParseIncremental(oldTree, oldSrc, newSrc, tt.edits)
+			want, wantErr := engine.Parse(newSrc)
+			if (err != nil) != tt.wantErr || (wantErr != nil) != tt.wantErr {
+				t.Fatalf("errors = %v / full parse %v, want error %v", err, wantErr, tt.wantErr)
+			}
+			if err != nil && err.Error() != wantErr.Error() {
+				t.Errorf("error = %v, want %v", err, wantErr)
+			}
+			if !reflect.DeepEqual(tree, want) {
+				t.Errorf("tree differs from a full parse:\n%s", diffTrees(tree, want))
+			}
+		})
+	}
+}
+
+// diffTrees describes the first statement where two trees differ.
+func diffTrees(got, want *ast.Program) string {
+	if len(got.Statements) != len(want.Statements) {
+		return fmt.Sprintf("%d statements, want %d", len(got.Statements), len(want.Statements))
+	}
+	for i := range got.Statements {
+		g := ast.NodeToJSON(got.Statements[i], true)
+		w := ast.NodeToJSON(want.Statements[i], true)
+		if !reflect.DeepEqual(g, w) {
+			return fmt.Sprintf("statement %d:\n got %v\nwant %v", i, g, w)
+		}
+	}
+	return fmt.Sprintf("EndPos %v, want %v", got.EndPos, want.EndPos)
+}
+
+// TestParseIncrementalComments verifies that source comments do not prevent
+// the incremental path.
+func TestParseIncrementalComments(t *testing.T) {
+	src := `// Greeting helpers
+procedure First; // says hello
+begin
+  { the only line }
+  PrintLn('first'); (* done *)
+end;
+
+// Doubles x
+function Twice(x: Integer): Integer;
+begin
+  // multiply
+  Result := x * 2;
+end;
+
+PrintLn(Twice(21)); // 42
+`
+	engine, err := New()
+	if err != nil {
+		t.Fatalf("failed to create engine: %v", err)
+	}
+	oldTree := mustParse(t, engine, src)
+	oldStatements := append([]ast.Statement(nil), oldTree.Statements...)
+	edits := []TextEdit{insertAt(t, src, "  // multiply", "  PrintLn(x); // trace\n")}
+	newSrc := applyEdits(src, edits)
+
+	tree, err := engine.ParseIncremental(oldTree, src, newSrc, edits)
+	if err != nil {
+		t.Fatalf("ParseIncremental failed: %v", err)
+	}
+	if want := mustParse(t, engine, newSrc); !reflect.DeepEqual(tree, want) {
+		t.Fatalf("incremental tree differs from a full parse:\n%s", diffTrees(tree, want))
+	}
+	for i, stmt := range tree.Statements {
+		if reused := stmt == oldStatements[i]; reused == (i == 1) {
+			t.Errorf("statement %d reused = %v", i, reused)
+		}
+	}
+}
+
+// TestParseIncrementalAttachedComments verifies that comments attached to
+// the tree move to the reparsed statement and shift with the statements
+// after it, and that a comment inside the edited statement forces a full
+// parse.
+func TestParseIncrementalAttachedComments(t *testing.T) {
+	src := incrementalSource
+	edits := []TextEdit{insertAt(t, src, "  Result", "  PrintLn(x);\n")}
+	newSrc := applyEdits(src, edits)
+	comment := func(marker string) *ast.CommentGroup {
+		offset := strings.Index(src, marker)
+		line := strings.Count(src[:offset], "\n") + 1
+		column := offset - strings.LastIndex(src[:offset], "\n")
+		return ast.NewCommentGroup(&ast.Comment{
+			Text: "//",
+			Pos:  token.Position{Line: line, Column: column, Offset: offset},
+		})
+	}
+
+	t.Run("moved", func(t *testing.T) {
+		engine, err := New()
+		if err != nil {
+			t.Fatalf("failed to create engine: %v", err)
+		}
+		oldTree := mustParse(t, engine, src)
+		edited, last := oldTree.Statements[1], oldTree.Statements[len(oldTree.Statements)-1]
+		oldTree.Comments = ast.NewCommentMap()
+		oldTree.Comments.SetLeading(edited, comment("\n\nfunction Twice"))
+		oldTree.Comments.SetLeading(last, comment("\nPrintLn(Total)"))
+
+		tree, err := engine.ParseIncremental(oldTree, src, newSrc, edits)
+		if err != nil {
+			t.Fatalf("ParseIncremental failed: %v", err)
+		}
+		if tree.Statements[1] == edited || tree.Statements[len(tree.Statements)-1] != last {
+			t.Fatal("expected only the edited statement to be reparsed")
+		}
+		if !tree.Comments.HasComments(tree.Statements[1]) || tree.Comments.HasComments(edited) {
+			t.Error("expected the comment of the edited statement to move to its replacement")
+		}
+		want := comment("\nPrintLn(Total)").Pos()
+		want.Line++
+		want.Offset += len("  PrintLn(x);\n")
+		if got := tree.Comments.GetComments(last).Leading.Pos(); got != want {
+			t.Errorf("comment after the edit at %v, want %v", got, want)
+		}
+	})
+
+	t.Run("inside edited statement", func(t *testing.T) {
+		engine, err := New()
+		if err != nil {
+			t.Fatalf("failed to create engine: %v", err)
+		}
+		oldTree := mustParse(t, engine, src)
+		edited := oldTree.Statements[1]
+		oldTree.Comments = ast.NewCommentMap()
+		oldTree.Comments.SetTrailing(edited, comment("Result := x"))
+
+		tree, err := engine.ParseIncremental(oldTree, src, newSrc, edits)
+		if err != nil {
+			t.Fatalf("ParseIncremental failed: %v", err)
+		}
+		if tree.Statements[0] == oldTree.Statements[0] {
+			t.Error("expected a full parse")
+		}
+	})
+}
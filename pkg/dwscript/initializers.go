@@ -0,0 +1,79 @@
+package dwscript
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/cwbudde/go-dws/internal/interp/runtime"
+	"github.com/cwbudde/go-dws/pkg/ast"
+)
+
+// RunInitializers executes only the top-level const and var declarations of
+// the program, in source order, and returns the resulting global values keyed
+// by their declared names.
+//
+// Type, function, class and other declarations are registered so initializers
+// can use them, but no statements outside of declarations are executed: the
+// main block and any top-level statements are skipped. Functions only run
+// when an initializer calls them.
+//
+// Values are converted to Go like Result.GlobalValue converts them. A global
+// whose value has no Go representation, such as an object, is an error.
+//
+// Example:
+//
+//	program, _ := engine.Compile("const Port = 8000; var Url := 'http://localhost:' + IntToStr(Port);")
+//	globals, err := program.RunInitializers()
+//	// globals["Port"] == int64(8000), globals["Url"] == "http://localhost:8000"
+func (p *Program) RunInitializers() (map[string]interface{}, error) {
+	if p == nil || p.ast == nil {
+		return nil, fmt.Errorf("program is nil")
+	}
+
+	declarations := &ast.Program{Comments: p.ast.Comments, EndPos: p.ast.EndPos}
+	var names []string
+	for _, stmt := range p.ast.Statements {
+		switch s := stmt.(type) {
+		case *ast.ConstDecl:
+			names = append(names, s.Name.Value)
+		case *ast.VarDeclStatement:
+			for _, name := range s.Names {
+				names = append(names, name.Value)
+			}
+		case *ast.TypeDeclaration, *ast.FunctionDecl, *ast.ClassDecl, *ast.InterfaceDecl,
+			*ast.RecordDecl, *ast.EnumDecl, *ast.SetDecl, *ast.ArrayDecl, *ast.HelperDecl,
+			*ast.OperatorDecl, *ast.UsesClause:
+		default:
+			continue
+		}
+		declarations.Statements = append(declarations.Statements, stmt)
+	}
+
+	opts := p.options
+	if opts.Output == nil {
+		opts.Output = &bytes.Buffer{}
+	}
+	interpreter, err := p.newInterpreter(&opts)
+	if err != nil {
+		return nil, err
+	}
+
+	value := interpreter.Eval(declarations)
+	if value != nil && value.Type() == "ERROR" {
+		return nil, newRuntimeError(value)
+	}
+
+	globals := make(map[string]interface{}, len(names))
+	for _, name := range names {
+		v, ok := interpreter.GetVariable(name)
+		if !ok {
+			return nil, fmt.Errorf("global %s was not initialized", name)
+		}
+		value, err := runtime.ToGo(v)
+		if err != nil {
+			return nil, fmt.Errorf("global %s: %w", name, err)
+		}
+		globals[name] = value
+	}
+	return globals, nil
+}
@@ -0,0 +1,124 @@
+package dwscript
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+)
+
+func TestRunInitializers(t *testing.T) {
+	var buf bytes.Buffer
+	engine, err := New(WithOutput(&buf))
+	if err != nil {
+		t.Fatalf("New() error: %v", err)
+	}
+
+	program, err := engine.Compile(`
+type TColor = (Red, Green, Blue);
+type TPoint = record X, Y: Integer; end;
+
+function Double(v: Integer): Integer;
+begin
+	Result := v * 2;
+end;
+
+const Base = 10;
+const Scale = Base * 3;
+var Total: Integer := Double(Scale) + 1;
+var Name := 'port-' + IntToStr(Total);
+var Ratio: Float := Total / 4;
+var Enabled := Total > Base;
+var Color := Green;
+var Sizes: array of Integer := [Base, Scale, Total];
+var Count, Unset: Integer;
+
+PrintLn('top-level statement');
+Count := 99;
+
+begin
+	PrintLn('main block');
+end;
+`)
+	if err != nil {
+		t.Fatalf("Compile() error: %v", err)
+	}
+
+	globals, err := program.RunInitializers()
+	if err != nil {
+		t.Fatalf("RunInitializers() error: %v", err)
+	}
+
+	want := map[string]interface{}{
+		"Base":    int64(10),
+		"Scale":   int64(30),
+		"Total":   int64(61),
+		"Name":    "port-61",
+		"Ratio":   15.25,
+		"Enabled": true,
+		"Color":   int64(1),
+		"Sizes":   []interface{}{int64(10), int64(30), int64(61)},
+		"Count":   int64(0),
+		"Unset":   int64(0),
+	}
+	if !reflect.DeepEqual(globals, want) {
+		t.Errorf("RunInitializers() = %#v, want %#v", globals, want)
+	}
+
+	if buf.Len() != 0 {
+		t.Errorf("expected no output from skipped statements, got %q", buf.String())
+	}
+}
+
+func TestRunInitializersRuntimeError(t *testing.T) {
+	engine, err := New(WithOutput(&bytes.Buffer{}))
+	if err != nil {
+		t.Fatalf("New() error: %v", err)
+	}
+
+	program, err := engine.Compile(`
+var Divisor := 0;
+var Quotient := 10 div Divisor;
+`)
+	if err != nil {
+		t.Fatalf("Compile() error: %v", err)
+	}
+
+	if _, err := program.RunInitializers(); err == nil {
+		t.Fatal("expected runtime error from initializer")
+	} else if _, ok := err.(*RuntimeError); !ok {
+		t.Errorf("expected *RuntimeError, got %T: %v", err, err)
+	}
+}
+
+func TestRunInitializersHostGlobalsAndUnits(t *testing.T) {
+	engine, err := New(WithOutput(&bytes.Buffer{}))
+	if err != nil {
+		t.Fatalf("New() error: %v", err)
+	}
+	if err := engine.SetGlobal("Base", 5); err != nil {
+		t.Fatalf("SetGlobal() error: %v", err)
+	}
+	if err := engine.RegisterUnit("Host", map[string]any{
+		"Twice": func(x int64) int64 { return 2 * x },
+	}, map[string]any{"Lim": 10}); err != nil {
+		t.Fatalf("RegisterUnit() error: %v", err)
+	}
+
+	program, err := engine.Compile(`
+uses Host;
+var Y := Base + 1;
+var Z := Host.Twice(Host.Lim);
+`)
+	if err != nil {
+		t.Fatalf("Compile() error: %v", err)
+	}
+
+	globals, err := program.RunInitializers()
+	if err != nil {
+		t.Fatalf("RunInitializers() error: %v", err)
+	}
+	want := map[string]interface{}{"Y": int64(6), "Z": int64(20)}
+	if !reflect.DeepEqual(globals, want) {
+		t.Errorf("RunInitializers() = %#v, want %#v", globals, want)
+	}
+}
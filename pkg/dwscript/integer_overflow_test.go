@@ -0,0 +1,137 @@
+package dwscript
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+// TestWithIntegerOverflowCheck verifies that Integer overflow wraps by default
+// and raises EIntOverflow when checked arithmetic is enabled.
+func TestWithIntegerOverflowCheck(t *testing.T) {
+	tests := []struct {
+		name      string
+		source    string
+		unchecked string
+		checked   string
+	}{
+		{
+			name:      "MaxIntPlusOne",
+			source:    `var i: Integer := High(Integer); i := i + 1; PrintLn(i);`,
+			unchecked: "-9223372036854775808\n",
+		},
+		{
+			name:      "MinIntMinusOne",
+			source:    `var i: Integer := Low(Integer); i := i - 1; PrintLn(i);`,
+			unchecked: "9223372036854775807\n",
+		},
+		{
+			name:      "Multiply",
+			source:    `var i: Integer := High(Integer); PrintLn(i * 2);`,
+			unchecked: "-2\n",
+		},
+		{
+			name:      "CompoundAssign",
+			source:    `var i: Integer := High(Integer); i += 1; PrintLn(i);`,
+			unchecked: "-9223372036854775808\n",
+		},
+		{
+			name:      "UnaryMinus",
+			source:    `var i: Integer := Low(Integer); PrintLn(-i);`,
+			unchecked: "-9223372036854775808\n",
+		},
+		{
+			name:      "Inc",
+			source:    `var i: Integer := High(Integer); Inc(i); PrintLn(i);`,
+			unchecked: "-9223372036854775808\n",
+		},
+		{
+			name:      "Dec",
+			source:    `var i: Integer := Low(Integer) + 1; Dec(i, 2); PrintLn(i);`,
+			unchecked: "9223372036854775807\n",
+		},
+		{
+			name:      "Abs",
+			source:    `var i: Integer := Low(Integer); PrintLn(Abs(i));`,
+			unchecked: "-9223372036854775808\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			engine, err := New(WithOutput(&buf))
+			if err != nil {
+				t.Fatalf("failed to create engine: %v", err)
+			}
+			if _, err := engine.Eval(tt.source); err != nil {
+				t.Fatalf("Eval failed: %v", err)
+			}
+			if buf.String() != tt.unchecked {
+				t.Errorf("unchecked output = %q, want %q", buf.String(), tt.unchecked)
+			}
+
+			source := "try\n" + tt.source + "\nexcept\non E: EIntOverflow do PrintLn(E.ClassName + ': ' + E.Message);\nend;"
+			buf.Reset()
+			engine, err = New(WithOutput(&buf), WithIntegerOverflowCheck(true))
+			if err != nil {
+				t.Fatalf("failed to create engine: %v", err)
+			}
+			if _, err := engine.Eval(source); err != nil {
+				t.Fatalf("Eval failed: %v", err)
+			}
+			if want := "EIntOverflow: Integer overflow\n"; buf.String() != want {
+				t.Errorf("checked output = %q, want %q", buf.String(), want)
+			}
+		})
+	}
+}
+
+func TestWithIntegerOverflowCheckNoOverflow(t *testing.T) {
+	var buf bytes.Buffer
+	engine, err := New(WithOutput(&buf), WithIntegerOverflowCheck(true))
+	if err != nil {
+		t.Fatalf("failed to create engine: %v", err)
+	}
+	if _, err := engine.Eval(`var i: Integer := High(Integer) - 1; i += 1; PrintLn(i - High(Integer)); PrintLn(Abs(-5) * 3);`); err != nil {
+		t.Fatalf("Eval failed: %v", err)
+	}
+	if want := "0\n15\n"; buf.String() != want {
+		t.Errorf("output = %q, want %q", buf.String(), want)
+	}
+}
+
+func TestWithIntegerOverflowCheckUnhandledPosition(t *testing.T) {
+	engine, err := New(WithOutput(&bytes.Buffer{}), WithIntegerOverflowCheck(true))
+	if err != nil {
+		t.Fatalf("failed to create engine: %v", err)
+	}
+	_, err = engine.Eval("var i: Integer := High(Integer);\ni := i + 1;")
+	if err == nil {
+		t.Fatal("expected unhandled EIntOverflow")
+	}
+	if !strings.Contains(err.Error(), "Integer overflow") || !strings.Contains(err.Error(), "line: 2, column: 8") {
+		t.Errorf("error = %q, want Integer overflow at line 2, column 8", err.Error())
+	}
+}
+
+func TestWithOverflowChecks(t *testing.T) {
+	var buf bytes.Buffer
+	engine, err := New(WithOutput(&buf), WithOverflowChecks(true))
+	if err != nil {
+		t.Fatalf("failed to create engine: %v", err)
+	}
+	if !engine.options.IntegerOverflowCheck {
+		t.Fatal("WithOverflowChecks(true) should enable checked Integer arithmetic")
+	}
+	if _, err := engine.Eval(`try
+var i: Integer := High(Integer); Inc(i);
+except
+on E: EIntOverflow do PrintLn(E.ClassName);
+end;`); err != nil {
+		t.Fatalf("Eval failed: %v", err)
+	}
+	if want := "EIntOverflow\n"; buf.String() != want {
+		t.Errorf("output = %q, want %q", buf.String(), want)
+	}
+}
@@ -0,0 +1,106 @@
+package dwscript
+
+import (
+	"bytes"
+	"testing"
+)
+
+// TestJSONKeyIteration verifies that the keys of a parsed JSON object can be
+// enumerated with Keys and for-in, in document order, and that each value can
+// be read back by key.
+func TestJSONKeyIteration(t *testing.T) {
+	tests := []struct {
+		name   string
+		script string
+		want   string
+	}{
+		{
+			name: "Keys",
+			script: `
+var j := ParseJSON('{"zeta": 1, "alpha": "two", "mid": [3, 4], "flag": true}');
+var keys := Keys(j);
+PrintLn(Length(keys));
+for var i := 0 to High(keys) do
+  PrintLn(keys[i] + '=' + VarToStr(j[keys[i]]));
+`,
+			want: "4\nzeta=1\nalpha=two\nmid=[3,4]\nflag=True\n",
+		},
+		{
+			name: "ForIn",
+			script: `
+var j := ParseJSON('{"zeta": 1, "alpha": "two", "mid": [3, 4], "flag": true}');
+for var key in j do
+  PrintLn(key + '=' + VarToStr(j[key]));
+`,
+			want: "zeta=1\nalpha=two\nmid=[3,4]\nflag=True\n",
+		},
+		{
+			name: "ForInExistingVariable",
+			script: `
+var j := ParseJSON('{"b": 1, "a": 2}');
+var key: String;
+for key in j do
+  PrintLn(key);
+`,
+			want: "b\na\n",
+		},
+		{
+			name: "ForInArray",
+			script: `
+var j := ParseJSON('{"items": ["x", 2, null]}');
+for var item in j['items'] do
+  PrintLn(item);
+`,
+			want: "x\n2\nnull\n",
+		},
+		{
+			name: "ForInScalar",
+			script: `
+var j := ParseJSON('42');
+for var item in j do
+  PrintLn('unreachable');
+PrintLn('done');
+`,
+			want: "done\n",
+		},
+		{
+			name: "AssociativeArray",
+			script: `
+var a: array [String] of Integer;
+a['one'] := 1;
+a['two'] := 2;
+for var key in Keys(a) do
+  PrintLn(key + '=' + IntToStr(a[key]));
+`,
+			want: "one=1\ntwo=2\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			engine, err := New(WithOutput(&buf))
+			if err != nil {
+				t.Fatalf("failed to create engine: %v", err)
+			}
+			if _, err := engine.Eval(tt.script); err != nil {
+				t.Fatalf("Eval failed: %v", err)
+			}
+			if buf.String() != tt.want {
+				t.Errorf("output = %q, want %q", buf.String(), tt.want)
+			}
+		})
+	}
+}
+
+// TestForInRejectsNonEnumerable verifies that for-in still rejects types
+// that cannot be enumerated.
+func TestForInRejectsNonEnumerable(t *testing.T) {
+	engine, err := New()
+	if err != nil {
+		t.Fatalf("failed to create engine: %v", err)
+	}
+	if _, err := engine.Compile("var i := 3;\nfor var x in i do PrintLn(x);"); err == nil {
+		t.Fatal("expected a compile error for iterating over an Integer")
+	}
+}
@@ -1,10 +1,14 @@
 package dwscript
 
 import (
+	"fmt"
 	"io"
 	"os"
+	"time"
 
+	"github.com/cwbudde/go-dws/internal/builtins"
 	"github.com/cwbudde/go-dws/internal/interp"
+	"github.com/cwbudde/go-dws/internal/parser"
 )
 
 // CompileMode selects which execution engine the DWScript runtime uses.
@@ -28,14 +32,96 @@ func (m CompileMode) String() string {
 	}
 }
 
+// VariantOverflowMode selects what happens when Integer arithmetic (+, -, *)
+// on Variant operands overflows the 64-bit Integer range. Mixed Integer and
+// Float Variant operands are always promoted to Float.
+type VariantOverflowMode = interp.VariantOverflowMode
+
+const (
+	// VariantOverflowWrap wraps around using two's complement arithmetic.
+	// This is the default and matches original DWScript.
+	VariantOverflowWrap = interp.VariantOverflowWrap
+	// VariantOverflowError raises a catchable EIntOverflow exception.
+	VariantOverflowError = interp.VariantOverflowError
+	// VariantOverflowSaturateToFloat returns the result as a Float instead.
+	VariantOverflowSaturateToFloat = interp.VariantOverflowSaturateToFloat
+)
+
+// FormatSettings holds the locale-dependent separators used by FloatToStr,
+// StrToFloat, Format and DateToStr:
+//
+//   - DecimalSeparator separates the integer and fractional parts of a number.
+//   - ThousandSeparator groups digits in Format's %n and %m output; an empty
+//     separator disables grouping.
+//   - DateSeparator separates year, month and day in DateToStr output.
+type FormatSettings = interp.FormatSettings
+
+// InvariantFormatSettings returns the locale-independent settings used by
+// default: "." as decimal separator, "," for thousands and "-" for dates.
+func InvariantFormatSettings() FormatSettings {
+	return interp.InvariantFormatSettings()
+}
+
+// ContractMode selects which function contracts (require/ensure) are checked
+// at run time.
+type ContractMode = interp.ContractMode
+
+const (
+	// ContractsFull checks preconditions and postconditions, capturing the
+	// values referenced through "old" at function entry. This is the default.
+	ContractsFull = interp.ContractsFull
+	// ContractsPre checks preconditions only.
+	ContractsPre = interp.ContractsPre
+	// ContractsOff skips all contract checks.
+	ContractsOff = interp.ContractsOff
+)
+
+// RecursionStrategy selects where the Go stack frames of nested script calls
+// live.
+type RecursionStrategy = interp.RecursionStrategy
+
+const (
+	// RecursionNative nests script calls on the stack of the goroutine running
+	// the script. This is the default. Go aborts the process when a goroutine
+	// exceeds its maximum stack size (1 GB on 64-bit platforms by default), so
+	// a WithMaxRecursionDepth in the tens of thousands can crash the process
+	// before EScriptStackOverflow is raised; use RecursionHeap for such depths.
+	RecursionNative = interp.RecursionNative
+	// RecursionHeap continues every few hundred nested script calls on a fresh
+	// goroutine, whose stack the Go runtime allocates on the heap. Scripts can
+	// then recurse as deep as WithMaxRecursionDepth and memory allow.
+	RecursionHeap = interp.RecursionHeap
+)
+
 // Options configures the behavior of the DWScript engine.
 type Options struct {
-	Output            io.Writer
-	ExternalFunctions *interp.ExternalFunctionRegistry
-	MaxRecursionDepth int
-	CompileMode       CompileMode
-	TypeCheck         bool
-	Trace             bool
+	Output               io.Writer
+	Defines              []string
+	IncludePaths         []string
+	ExternalFunctions    *interp.ExternalFunctionRegistry
+	Builtins             *builtins.Registry
+	UnitResolver         func(unitName string) (source string, err error)
+	Clock                func() time.Time
+	valuePool            *interp.ValuePool // created by New
+	MaxRecursionDepth    int
+	MaxParseErrors       int
+	CompileCacheSize     int
+	RandomSeed           int64
+	FormatSettings       FormatSettings
+	CompileMode          CompileMode
+	VariantOverflow      VariantOverflowMode
+	TypeCheck            bool
+	Trace                bool
+	IntegerOverflowCheck bool
+	RangeChecks          bool
+	Assertions           bool
+	Destructors          bool
+	ObjectPooling        bool
+	FixedRandomSeed      bool
+	ConstantFolding      bool
+	StrictTypes          bool
+	Contracts            ContractMode
+	RecursionStrategy    RecursionStrategy
 }
 
 // Option is a function that configures an Engine's Options.
@@ -48,7 +134,14 @@ func defaultOptions() Options {
 		Output:            os.Stdout,
 		Trace:             false,
 		MaxRecursionDepth: 1024, // Default matches DWScript's cDefaultMaxRecursionDepth
+		MaxParseErrors:    parser.DefaultMaxErrors,
 		CompileMode:       CompileModeAST,
+		VariantOverflow:   VariantOverflowWrap,
+		Assertions:        true,
+		Destructors:       true,
+		ObjectPooling:     true,
+		FormatSettings:    InvariantFormatSettings(),
+		Contracts:         ContractsFull,
 	}
 }
 
@@ -93,7 +186,9 @@ func WithTrace(enabled bool) Option {
 // This prevents infinite recursion and stack overflow errors. When the call
 // stack reaches this depth, the interpreter raises an EScriptStackOverflow exception.
 //
-// The default value is 1024, which matches DWScript's default limit.
+// The default value is 1024, which matches DWScript's default limit. Under
+// the default RecursionNative strategy, very deep limits risk overflowing the
+// Go stack; see RecursionNative and WithRecursionStrategy.
 //
 // Example:
 //
@@ -105,7 +200,58 @@ func WithMaxRecursionDepth(depth int) Option {
 	}
 }
 
+// WithRecursionStrategy selects where the Go stack frames of nested script
+// calls live. RecursionNative (the default) keeps them on the stack of the
+// goroutine running the script, which is fastest but bounded by the Go stack
+// size (see RecursionNative). RecursionHeap lets deeply recursive scripts run to
+// the depth set with WithMaxRecursionDepth, at the cost of a goroutine switch
+// every few hundred nested calls. Only the AST interpreter honors it.
+//
+// Example:
+//
+//	engine, err := dwscript.New(
+//	    dwscript.WithRecursionStrategy(dwscript.RecursionHeap),
+//	    dwscript.WithMaxRecursionDepth(1_000_000),
+//	)
+func WithRecursionStrategy(strategy RecursionStrategy) Option {
+	return func(opts *Options) error {
+		switch strategy {
+		case RecursionNative, RecursionHeap:
+			opts.RecursionStrategy = strategy
+			return nil
+		default:
+			return fmt.Errorf("invalid recursion strategy: %d", strategy)
+		}
+	}
+}
+
+// WithObjectPooling enables or disables the pooling of Integer and Float
+// values for this engine. Pooling is enabled by default; disabling it makes
+// every value a fresh allocation, which helps to bisect whether a bug is
+// caused by a pooled value being reused while still referenced. Other
+// engines keep their own setting. Engine.PoolStats reports the pool usage.
+//
+// Example:
+//
+//	engine, err := dwscript.New(dwscript.WithObjectPooling(false))
+func WithObjectPooling(enabled bool) Option {
+	return func(opts *Options) error {
+		opts.ObjectPooling = enabled
+		return nil
+	}
+}
+
+// PoolStats holds the number of gets, puts and fresh allocations of each
+// pooled value type.
+type PoolStats = interp.PoolStats
+
 // WithCompileMode selects which execution engine should be used (AST or bytecode VM).
+//
+// The bytecode VM is experimental and does not support every construct yet.
+// A program using an unsupported construct runs on the AST interpreter
+// instead: its CompileMode reports CompileModeAST, and its Diagnostics
+// include a W_BYTECODE_FALLBACK warning naming the construct and its position.
+// BytecodeSupportedNodeTypes lists the AST node types the VM supports.
 func WithCompileMode(mode CompileMode) Option {
 	return func(opts *Options) error {
 		opts.CompileMode = mode
@@ -113,12 +259,412 @@ func WithCompileMode(mode CompileMode) Option {
 	}
 }
 
+// WithVariantOverflow selects how Integer overflow in Variant arithmetic is
+// handled. The default is VariantOverflowWrap.
+//
+// Example:
+//
+//	engine, err := dwscript.New(dwscript.WithVariantOverflow(dwscript.VariantOverflowError))
+func WithVariantOverflow(mode VariantOverflowMode) Option {
+	return func(opts *Options) error {
+		switch mode {
+		case VariantOverflowWrap, VariantOverflowError, VariantOverflowSaturateToFloat:
+			opts.VariantOverflow = mode
+			return nil
+		default:
+			return fmt.Errorf("invalid Variant overflow mode: %d", mode)
+		}
+	}
+}
+
+// WithIntegerOverflowCheck enables or disables checked Integer arithmetic.
+// When enabled, Integer +, -, * (including compound assignments), unary minus,
+// Inc, Dec and Abs raise a catchable EIntOverflow exception on overflow, like
+// {$Q+} in Delphi. The default is disabled: Integer arithmetic wraps around,
+// matching original DWScript.
+// Variant arithmetic is governed by WithVariantOverflow instead.
+//
+// Example:
+//
+//	engine, err := dwscript.New(dwscript.WithIntegerOverflowCheck(true))
+func WithIntegerOverflowCheck(enabled bool) Option {
+	return func(opts *Options) error {
+		opts.IntegerOverflowCheck = enabled
+		return nil
+	}
+}
+
+// WithOverflowChecks enables or disables overflow checking, like {$Q+} in
+// Delphi. It is the companion of WithRangeChecks and is equivalent to
+// WithIntegerOverflowCheck.
+//
+// Example:
+//
+//	engine, err := dwscript.New(dwscript.WithOverflowChecks(true), dwscript.WithRangeChecks(true))
+func WithOverflowChecks(enabled bool) Option {
+	return WithIntegerOverflowCheck(enabled)
+}
+
+// WithRangeChecks enables or disables range checking, like {$R+} in Delphi.
+// When enabled, writing a value outside the bounds of a subrange type to an
+// array element or a record or object field of that type raises a catchable
+// ERangeError naming the value and the allowed range. Variables of a subrange
+// type are always checked. The default is disabled. Integer overflow checking
+// ({$Q+}) is configured separately with WithOverflowChecks.
+//
+// Example:
+//
+//	engine, err := dwscript.New(dwscript.WithRangeChecks(true))
+func WithRangeChecks(enabled bool) Option {
+	return func(opts *Options) error {
+		opts.RangeChecks = enabled
+		return nil
+	}
+}
+
+// WithCompileCache enables an in-engine cache of compiled programs holding up
+// to size programs, evicting the least recently used one when full. Compile
+// hashes the source (together with the names and types of the host globals)
+// and, on a hit, returns a copy of the cached Program instead of recompiling;
+// Eval benefits the same way. A size of 0 disables the cache, which is the
+// default. Use Engine.ClearCache to empty it, e.g. after included files
+// changed on disk, since their contents are not part of the cache key.
+//
+// Programs returned for the same source share their compiled AST and
+// semantic information, which runs only read, so they may be run
+// concurrently with each other like any single Program.
+//
+// Example:
+//
+//	engine, err := dwscript.New(dwscript.WithCompileCache(128))
+func WithCompileCache(size int) Option {
+	return func(opts *Options) error {
+		if size < 0 {
+			return fmt.Errorf("compile cache size must be non-negative, got %d", size)
+		}
+		opts.CompileCacheSize = size
+		return nil
+	}
+}
+
+// WithConstantFolding enables or disables constant folding during type
+// checking. When enabled, arithmetic, boolean and string expressions built
+// from literals and declared constants are evaluated at compile time, the
+// interpreter uses the folded values instead of re-evaluating them, and
+// Program.ConstantAt reports them. Folding follows WithIntegerOverflowCheck:
+// wrapped results are folded, while an overflow under checking is left to
+// raise EIntOverflow at runtime. Division by a constant zero, Integer or
+// Float, with /, div or mod becomes a compile error.
+// The default is disabled. Folding needs type checking and applies to
+// CompileModeAST.
+//
+// Example:
+//
+//	engine, err := dwscript.New(dwscript.WithConstantFolding(true))
+func WithConstantFolding(enabled bool) Option {
+	return func(opts *Options) error {
+		opts.ConstantFolding = enabled
+		return nil
+	}
+}
+
+// WithStrictTypes enables or disables strict type checking. When enabled,
+// types the type checker cannot determine are compile errors instead of
+// silently defaulting to Variant: an untyped lambda parameter with no function
+// pointer type to infer it from reports "could not determine type of
+// parameter 'x'", and a lambda without a declared return type whose Result is
+// inferred as Variant is rejected too. The default is disabled, which keeps
+// the lenient DWScript behavior. Strict checking needs type checking.
+//
+// Example:
+//
+//	engine, err := dwscript.New(dwscript.WithStrictTypes(true))
+func WithStrictTypes(enabled bool) Option {
+	return func(opts *Options) error {
+		opts.StrictTypes = enabled
+		return nil
+	}
+}
+
+// WithAssertions enables or disables Assert. When disabled, every
+// Assert(cond[, msg]) call is compiled out: neither the condition nor the
+// message is evaluated. The default is enabled, in which case a False
+// condition raises EAssertionFailed with the source position of the call.
+//
+// Example:
+//
+//	engine, err := dwscript.New(dwscript.WithAssertions(false))
+func WithAssertions(enabled bool) Option {
+	return func(opts *Options) error {
+		opts.Assertions = enabled
+		return nil
+	}
+}
+
+// WithDestructors enables or disables destructor calls. When disabled
+// (pure GC mode), Free, Destroy and FreeAndNil do not run destructors and
+// do not mark objects as freed; FreeAndNil still sets its variable to nil.
+// Objects are reclaimed by the Go garbage collector once unreachable. The
+// default is enabled, in which case Free runs the destructor chain and any
+// later member access raises "Object already destroyed".
+//
+// Example:
+//
+//	engine, err := dwscript.New(dwscript.WithDestructors(false))
+func WithDestructors(enabled bool) Option {
+	return func(opts *Options) error {
+		opts.Destructors = enabled
+		return nil
+	}
+}
+
+// WithFormatSettings sets the separators used when scripts convert numbers
+// and dates to and from strings. The default is InvariantFormatSettings, so
+// output is machine-readable unless a locale is chosen explicitly. The
+// decimal separator must be set and must differ from the thousand separator.
+//
+// Example:
+//
+//	german := dwscript.FormatSettings{
+//	    DecimalSeparator:  ",",
+//	    ThousandSeparator: ".",
+//	    DateSeparator:     ".",
+//	}
+//	engine, err := dwscript.New(dwscript.WithFormatSettings(german))
+func WithFormatSettings(settings FormatSettings) Option {
+	return func(opts *Options) error {
+		if settings.DecimalSeparator == "" {
+			return fmt.Errorf("format settings: decimal separator must not be empty")
+		}
+		if settings.DecimalSeparator == settings.ThousandSeparator {
+			return fmt.Errorf("format settings: decimal and thousand separators must differ")
+		}
+		opts.FormatSettings = settings
+		return nil
+	}
+}
+
+// WithClock sets the clock Now, Date, Time, UTCDateTime, UnixTime and
+// UnixTimeMSec read the current time from. The default is time.Now. Now,
+// Date and Time use the wall clock of the returned time in its own location,
+// so a clock returning UTC times makes them report UTC. A fixed clock makes
+// scripts that read the time deterministic, for example in tests.
+//
+// Example:
+//
+//	fixed := time.Date(2024, 3, 15, 10, 30, 0, 0, time.UTC)
+//	engine, err := dwscript.New(dwscript.WithClock(func() time.Time { return fixed }))
+func WithClock(clock func() time.Time) Option {
+	return func(opts *Options) error {
+		if clock == nil {
+			return fmt.Errorf("clock must not be nil")
+		}
+		opts.Clock = clock
+		return nil
+	}
+}
+
+// WithRandomSeed seeds the random number generator behind Random, RandomInt
+// and RandG with seed, so every run of a program draws the same sequence.
+// Randomize() becomes a no-op; a script can still reseed explicitly with
+// SetRandSeed. Each run has its own generator, so programs running
+// concurrently do not affect each other's sequences. The sequence for a seed
+// is stable across go-dws releases but does not match Delphi's.
+//
+// Example:
+//
+//	engine, err := dwscript.New(dwscript.WithRandomSeed(42))
+func WithRandomSeed(seed int64) Option {
+	return func(opts *Options) error {
+		opts.RandomSeed = seed
+		opts.FixedRandomSeed = true
+		return nil
+	}
+}
+
+// WithContracts selects how much of the function contracts is evaluated.
+// ContractsFull (the default) checks require and ensure clauses; ContractsPre
+// checks require clauses only; ContractsOff skips contracts entirely, which
+// suits release runs. A failed condition raises EAssertionFailed naming the
+// function, the condition's position and its source text (or custom message).
+//
+// Example:
+//
+//	engine, err := dwscript.New(dwscript.WithContracts(dwscript.ContractsPre))
+func WithContracts(mode ContractMode) Option {
+	return func(opts *Options) error {
+		switch mode {
+		case ContractsFull, ContractsPre, ContractsOff:
+			opts.Contracts = mode
+			return nil
+		default:
+			return fmt.Errorf("invalid contract mode: %d", mode)
+		}
+	}
+}
+
+// WithDefines predefines conditional compilation symbols, as if each name
+// had been declared with {$DEFINE name} at the top of every compiled script
+// and of the units it uses (see WithUnitResolver). Scripts can test them with {$IFDEF}/{$IFNDEF} and may still {$UNDEF} them.
+// Repeated calls add to the set.
+//
+// Example:
+//
+//	engine, err := dwscript.New(dwscript.WithDefines("DEBUG", "FEATURE_X"))
+func WithDefines(names ...string) Option {
+	return func(opts *Options) error {
+		for _, name := range names {
+			if name == "" {
+				return fmt.Errorf("define name must not be empty")
+			}
+		}
+		opts.Defines = append(opts.Defines, names...)
+		return nil
+	}
+}
+
+// WithIncludePaths enables {$INCLUDE 'file'} resolution for compiled scripts
+// and the units they use. Relative names are resolved against the directory of the including file
+// (the first path for the top-level script) and then against each path in
+// order. Without this option include directives are ignored, since scripts
+// compiled from a string have no directory of their own.
+//
+// Example:
+//
+//	engine, err := dwscript.New(dwscript.WithIncludePaths("scripts", "scripts/lib"))
+func WithIncludePaths(paths ...string) Option {
+	return func(opts *Options) error {
+		opts.IncludePaths = append(opts.IncludePaths, paths...)
+		return nil
+	}
+}
+
+// WithUnitResolver lets scripts use units that live outside the compiled
+// source. For every unit named in a uses clause, Compile calls resolver with
+// the unit name and parses the returned source, recursively loading the units
+// it uses in turn. The interface and implementation declarations of each unit
+// become visible to the program, initialization sections run before the main
+// statements and finalization sections after them, in reverse order. An error
+// from resolver, a syntax error in a unit or circular uses fail compilation.
+// Without a resolver, uses clauses are ignored.
+//
+// The engine keeps the units it has parsed and reuses them in later
+// compilations while resolver returns the same source; a changed source is
+// parsed again. Unit sources are not part of the WithCompileCache key; call
+// Engine.ClearCache when they change.
+//
+// Example:
+//
+//	units := map[string]string{"mathutils": mathUtilsSource}
+//	engine, err := dwscript.New(dwscript.WithUnitResolver(func(name string) (string, error) {
+//	    source, ok := units[strings.ToLower(name)]
+//	    if !ok {
+//	        return "", fmt.Errorf("unit %q not found", name)
+//	    }
+//	    return source, nil
+//	}))
+func WithUnitResolver(resolver func(unitName string) (source string, err error)) Option {
+	return func(opts *Options) error {
+		opts.UnitResolver = resolver
+		return nil
+	}
+}
+
+// WithMaxParseErrors caps the number of syntax errors reported by Compile and
+// Parse. Errors past the cap are summarized by a single "too many errors"
+// error; zero or a negative value reports every error. The default is 100.
+//
+// Example:
+//
+//	engine, err := dwscript.New(dwscript.WithMaxParseErrors(20))
+func WithMaxParseErrors(limit int) Option {
+	return func(opts *Options) error {
+		opts.MaxParseErrors = limit
+		return nil
+	}
+}
+
 // GetExternalFunctions returns the external function registry.
 func (o *Options) GetExternalFunctions() *interp.ExternalFunctionRegistry {
 	return o.ExternalFunctions
 }
 
+// GetBuiltins returns the engine's builtin registry, or nil if its builtins
+// were not customized.
+func (o *Options) GetBuiltins() *builtins.Registry {
+	return o.Builtins
+}
+
 // GetMaxRecursionDepth returns the maximum recursion depth for function calls.
 func (o *Options) GetMaxRecursionDepth() int {
 	return o.MaxRecursionDepth
 }
+
+// GetVariantOverflow returns how Integer overflow in Variant arithmetic is handled.
+func (o *Options) GetVariantOverflow() VariantOverflowMode {
+	return o.VariantOverflow
+}
+
+// GetIntegerOverflowCheck reports whether checked Integer arithmetic is enabled.
+func (o *Options) GetIntegerOverflowCheck() bool {
+	return o.IntegerOverflowCheck
+}
+
+// GetRangeChecks reports whether subrange bounds are checked on array element
+// and field writes.
+func (o *Options) GetRangeChecks() bool {
+	return o.RangeChecks
+}
+
+// GetConstantFolding reports whether constant expressions are folded at
+// compile time.
+func (o *Options) GetConstantFolding() bool {
+	return o.ConstantFolding
+}
+
+// GetStrictTypes reports whether undeterminable types are compile errors.
+func (o *Options) GetStrictTypes() bool {
+	return o.StrictTypes
+}
+
+// GetAssertions reports whether Assert calls are executed.
+func (o *Options) GetAssertions() bool {
+	return o.Assertions
+}
+
+// GetDestructors reports whether Free, Destroy and FreeAndNil run destructors.
+func (o *Options) GetDestructors() bool {
+	return o.Destructors
+}
+
+// GetFormatSettings returns the separators used by the conversion builtins.
+func (o *Options) GetFormatSettings() FormatSettings {
+	return o.FormatSettings
+}
+
+// GetClock returns the clock set with WithClock, or nil for time.Now.
+func (o *Options) GetClock() func() time.Time {
+	return o.Clock
+}
+
+// GetRandomSeed returns the random seed set with WithRandomSeed and whether
+// one was set.
+func (o *Options) GetRandomSeed() (int64, bool) {
+	return o.RandomSeed, o.FixedRandomSeed
+}
+
+// GetContracts returns which function contracts are checked.
+func (o *Options) GetContracts() ContractMode {
+	return o.Contracts
+}
+
+// GetRecursionStrategy returns where the Go stack frames of nested script
+// calls live.
+func (o *Options) GetRecursionStrategy() RecursionStrategy {
+	return o.RecursionStrategy
+}
+
+// GetValuePool returns the engine's value pool, or nil before New.
+func (o *Options) GetValuePool() *interp.ValuePool {
+	return o.valuePool
+}
@@ -2,6 +2,7 @@ package dwscript
 
 import (
 	"fmt"
+	"strings"
 	"testing"
 
 	"github.com/cwbudde/go-dws/pkg/ast"
@@ -312,6 +313,58 @@ func TestParse_ErrorRecovery(t *testing.T) {
 	}
 }
 
+// TestParse_StatementRecovery verifies that a syntax error is reported once and
+// the statements after it are still in the partial AST.
+func TestParse_StatementRecovery(t *testing.T) {
+	engine, err := New()
+	if err != nil {
+		t.Fatalf("Failed to create engine: %v", err)
+	}
+
+	tree, err := engine.Parse("var a := 1;\nx := ) ) );\nvar b := 2;\nPrintLn(a + b);\n")
+	compileErr, ok := err.(*CompileError)
+	if !ok {
+		t.Fatalf("expected *CompileError, got %v", err)
+	}
+	if len(compileErr.Errors) != 1 || compileErr.Errors[0].Line != 2 {
+		t.Errorf("expected a single error on line 2, got %v", compileErr.Errors)
+	}
+	if tree == nil || len(tree.Statements) != 4 {
+		t.Fatalf("expected 4 statements in the partial AST, got %v", tree)
+	}
+}
+
+// TestWithMaxParseErrors verifies that the number of reported syntax errors
+// is capped.
+func TestWithMaxParseErrors(t *testing.T) {
+	source := strings.Repeat("x := );\n", 10)
+
+	tests := []struct {
+		name  string
+		limit int
+		want  int
+	}{
+		{name: "capped", limit: 3, want: 4},
+		{name: "unlimited", limit: 0, want: 10},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			engine, err := New(WithMaxParseErrors(tt.limit))
+			if err != nil {
+				t.Fatalf("Failed to create engine: %v", err)
+			}
+			_, err = engine.Parse(source)
+			compileErr, ok := err.(*CompileError)
+			if !ok {
+				t.Fatalf("expected *CompileError, got %v", err)
+			}
+			if len(compileErr.Errors) != tt.want {
+				t.Errorf("got %d errors, want %d", len(compileErr.Errors), tt.want)
+			}
+		})
+	}
+}
+
 // Example_parse demonstrates using Parse() for LSP/editor integration
 func Example_parse() {
 	engine, _ := New()
@@ -0,0 +1,54 @@
+package dwscript
+
+import (
+	"bytes"
+	"testing"
+)
+
+const poolingScript = `
+var total := 0;
+var f := 0.0;
+for var i := 1 to 100 do begin
+  total := total + i;
+  f := f * 0.5 + i;
+end;
+PrintLn(total);
+`
+
+// TestWithObjectPooling verifies that WithObjectPooling(false) makes an
+// engine allocate every arithmetic result afresh without affecting other
+// engines.
+func TestWithObjectPooling(t *testing.T) {
+	run := func(opts ...Option) PoolStats {
+		t.Helper()
+		var buf bytes.Buffer
+		engine, err := New(append([]Option{WithOutput(&buf)}, opts...)...)
+		if err != nil {
+			t.Fatalf("failed to create engine: %v", err)
+		}
+		if _, err := engine.Eval(poolingScript); err != nil {
+			t.Fatalf("Eval failed: %v", err)
+		}
+		if buf.String() != "5050\n" {
+			t.Errorf("output = %q, want %q", buf.String(), "5050\n")
+		}
+		return engine.PoolStats()
+	}
+
+	unpooled := run(WithObjectPooling(false))
+	if unpooled.IntegerGets != 0 || unpooled.FloatGets != 0 {
+		t.Errorf("disabled pooling: gets = %d/%d, want 0/0", unpooled.IntegerGets, unpooled.FloatGets)
+	}
+	if unpooled.IntegerAllocs < 100 || unpooled.FloatAllocs < 100 {
+		t.Errorf("disabled pooling: allocs = %d/%d, want at least 100 each",
+			unpooled.IntegerAllocs, unpooled.FloatAllocs)
+	}
+
+	pooled := run()
+	if pooled.IntegerGets < 100 || pooled.FloatGets < 100 {
+		t.Errorf("default pooling: gets = %d/%d, want at least 100 each", pooled.IntegerGets, pooled.FloatGets)
+	}
+	if pooled.IntegerAllocs > pooled.IntegerGets || pooled.FloatAllocs > pooled.FloatGets {
+		t.Errorf("default pooling: allocs exceed gets: %+v", pooled)
+	}
+}
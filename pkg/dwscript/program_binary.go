@@ -0,0 +1,141 @@
+package dwscript
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/cwbudde/go-dws/pkg/ast"
+)
+
+// Version is the release version of the go-dws engine. The dwscript command
+// reports it as its version.
+const Version = "0.1.0-dev"
+
+// EngineVersion identifies the engine that produced a serialized Program. It
+// combines Version with the JSON AST schema version (ast.JSONVersion), so a
+// change to either one invalidates existing blobs. LoadProgram rejects blobs
+// stamped with any other value, since the AST and its annotations are not
+// guaranteed to be stable between releases.
+var EngineVersion = engineStamp(Version, ast.JSONVersion)
+
+// engineStamp formats the EngineVersion of a release and AST schema version.
+func engineStamp(version string, astVersion int) string {
+	return fmt.Sprintf("%s+ast.%d", version, astVersion)
+}
+
+// Serialized program format
+// =========================
+//
+// Header:
+//   - Magic number: "DWSP" (4 bytes)
+//   - Format version: uint8 (1 byte)
+//   - Engine version: uint16 length + UTF-8 bytes
+//
+// Body (JSON):
+//   - "ast": the program in the JSON AST schema, with positions (see ast.NodeToJSON)
+//   - "semantic": its semantic annotations, when type checking ran
+//     (see ast.SemanticInfoToJSON)
+const (
+	programMagic         = "DWSP"
+	programFormatVersion = 1
+)
+
+// programBlob is the JSON body of a serialized program.
+type programBlob struct {
+	AST      json.RawMessage `json:"ast"`
+	Semantic json.RawMessage `json:"semantic,omitempty"`
+}
+
+// MarshalBinary serializes the compiled program, its AST plus the type and
+// constant annotations recorded by semantic analysis, into a versioned binary
+// blob. LoadProgram restores it without parsing or analyzing the source
+// again, so the blob can serve as an on-disk precompiled script.
+//
+// Host globals, external functions and the analyzer's symbol tables are not
+// part of the blob; see LoadProgram.
+func (p *Program) MarshalBinary() ([]byte, error) {
+	if p == nil {
+		return nil, fmt.Errorf("program is nil")
+	}
+	if p.compileErr != nil {
+		return nil, p.compileErr
+	}
+
+	var blob programBlob
+	var err error
+	if blob.AST, err = json.Marshal(ast.NodeToJSON(p.ast, true)); err != nil {
+		return nil, fmt.Errorf("failed to serialize AST: %w", err)
+	}
+	if p.semanticInfo != nil {
+		if blob.Semantic, err = json.Marshal(ast.SemanticInfoToJSON(p.ast, p.semanticInfo)); err != nil {
+			return nil, fmt.Errorf("failed to serialize semantic info: %w", err)
+		}
+	}
+	body, err := json.Marshal(blob)
+	if err != nil {
+		return nil, err
+	}
+
+	buf := new(bytes.Buffer)
+	buf.WriteString(programMagic)
+	buf.WriteByte(programFormatVersion)
+	if err := binary.Write(buf, binary.LittleEndian, uint16(len(EngineVersion))); err != nil {
+		return nil, err
+	}
+	buf.WriteString(EngineVersion)
+	buf.Write(body)
+	return buf.Bytes(), nil
+}
+
+// LoadProgram restores a Program serialized by MarshalBinary. Blobs written
+// by a different EngineVersion or format version are rejected with an error
+// naming both versions; recompile the source in that case.
+//
+// The loaded program runs on any engine. It has no diagnostics and no
+// analyzer, so host globals set with SetGlobal are not visible to it and
+// symbol queries such as Program.Symbols report nothing.
+func LoadProgram(data []byte) (*Program, error) {
+	r := bytes.NewReader(data)
+
+	magic := make([]byte, len(programMagic))
+	if _, err := io.ReadFull(r, magic); err != nil || string(magic) != programMagic {
+		return nil, fmt.Errorf("not a serialized DWScript program")
+	}
+	format, err := r.ReadByte()
+	if err != nil {
+		return nil, fmt.Errorf("truncated program header: %w", err)
+	}
+	if format != programFormatVersion {
+		return nil, fmt.Errorf("unsupported program format version %d (want %d)", format, programFormatVersion)
+	}
+	var versionLen uint16
+	if err := binary.Read(r, binary.LittleEndian, &versionLen); err != nil {
+		return nil, fmt.Errorf("truncated program header: %w", err)
+	}
+	version := make([]byte, versionLen)
+	if _, err := io.ReadFull(r, version); err != nil {
+		return nil, fmt.Errorf("truncated program header: %w", err)
+	}
+	if string(version) != EngineVersion {
+		return nil, fmt.Errorf("stale program: compiled by engine version %q, this engine is %q", version, EngineVersion)
+	}
+
+	var blob programBlob
+	if err := json.NewDecoder(r).Decode(&blob); err != nil {
+		return nil, fmt.Errorf("failed to load program: %w", err)
+	}
+	tree, err := ast.UnmarshalJSON(blob.AST)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load program: %w", err)
+	}
+	program := &Program{ast: tree}
+	if len(blob.Semantic) > 0 {
+		if program.semanticInfo, err = ast.UnmarshalSemanticInfoJSON(tree, blob.Semantic); err != nil {
+			return nil, fmt.Errorf("failed to load program: %w", err)
+		}
+	}
+	return program, nil
+}
@@ -0,0 +1,169 @@
+package dwscript
+
+import (
+	"bytes"
+	"encoding/binary"
+	"strings"
+	"testing"
+
+	"github.com/cwbudde/go-dws/pkg/ast"
+)
+
+func TestProgramMarshalBinaryRoundTrip(t *testing.T) {
+	source := `
+type TShape = class
+  function Area: Float; virtual; abstract;
+end;
+type TRect = class(TShape)
+  W, H: Float;
+  constructor Create(w, h: Float);
+  function Area: Float; override;
+end;
+constructor TRect.Create(w, h: Float);
+begin
+  W := w; H := h;
+end;
+function TRect.Area: Float;
+begin
+  Result := W * H;
+end;
+
+function Fib(n: Integer): Integer;
+begin
+  if n < 2 then
+    Exit(n);
+  Result := Fib(n - 1) + Fib(n - 2);
+end;
+
+var fibs: array[1..10] of Integer;
+var i: Integer;
+for i := Low(fibs) to High(fibs) do
+  fibs[i] := Fib(i);
+for var f in fibs do
+  Print(f, ' ');
+PrintLn('');
+PrintLn(FloatToStr(TRect.Create(2, 3.5).Area));
+try
+  raise Exception.Create('boom');
+except
+  on E: Exception do PrintLn('caught ' + E.Message);
+end;
+`
+	var fresh bytes.Buffer
+	engine, err := New(WithOutput(&fresh))
+	if err != nil {
+		t.Fatalf("failed to create engine: %v", err)
+	}
+	program, err := engine.Compile(source)
+	if err != nil {
+		t.Fatalf("Compile failed: %v", err)
+	}
+	if _, err := engine.Run(program); err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+
+	if fresh.Len() == 0 {
+		t.Fatal("fresh run produced no output")
+	}
+
+	data, err := program.MarshalBinary()
+	if err != nil {
+		t.Fatalf("MarshalBinary failed: %v", err)
+	}
+	loaded, err := LoadProgram(data)
+	if err != nil {
+		t.Fatalf("LoadProgram failed: %v", err)
+	}
+
+	var restored bytes.Buffer
+	other, err := New(WithOutput(&restored))
+	if err != nil {
+		t.Fatalf("failed to create engine: %v", err)
+	}
+	if _, err := other.Run(loaded); err != nil {
+		t.Fatalf("Run of loaded program failed: %v", err)
+	}
+
+	if restored.String() != fresh.String() {
+		t.Errorf("loaded program output = %q, want %q", restored.String(), fresh.String())
+	}
+}
+
+func TestLoadProgramRejectsInvalidBlobs(t *testing.T) {
+	engine, err := New()
+	if err != nil {
+		t.Fatalf("failed to create engine: %v", err)
+	}
+	program, err := engine.Compile(`PrintLn('hi');`)
+	if err != nil {
+		t.Fatalf("Compile failed: %v", err)
+	}
+	data, err := program.MarshalBinary()
+	if err != nil {
+		t.Fatalf("MarshalBinary failed: %v", err)
+	}
+
+	stale := bytes.Replace(data, []byte(EngineVersion), []byte(strings.Repeat("9", len(EngineVersion))), 1)
+
+	tests := []struct {
+		name string
+		data []byte
+		want string
+	}{
+		{"empty", nil, "not a serialized DWScript program"},
+		{"source text", []byte(`PrintLn('hi');`), "not a serialized DWScript program"},
+		{"stale engine", stale, "stale program"},
+		{"truncated", data[:len(data)-4], "failed to load program"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := LoadProgram(tt.data)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("LoadProgram error = %v, want it to mention %q", err, tt.want)
+			}
+		})
+	}
+}
+
+// TestLoadProgramRejectsOtherStamps verifies that blobs stamped by another
+// release or AST schema version are rejected.
+func TestLoadProgramRejectsOtherStamps(t *testing.T) {
+	engine, err := New()
+	if err != nil {
+		t.Fatalf("failed to create engine: %v", err)
+	}
+	program, err := engine.Compile(`PrintLn('hi');`)
+	if err != nil {
+		t.Fatalf("Compile failed: %v", err)
+	}
+	data, err := program.MarshalBinary()
+	if err != nil {
+		t.Fatalf("MarshalBinary failed: %v", err)
+	}
+	if want := engineStamp(Version, ast.JSONVersion); EngineVersion != want {
+		t.Fatalf("EngineVersion = %q, want %q", EngineVersion, want)
+	}
+
+	header := len(programMagic) + 1
+	body := data[header+2+len(EngineVersion):]
+	restamp := func(stamp string) []byte {
+		out := append([]byte{}, data[:header]...)
+		out = binary.LittleEndian.AppendUint16(out, uint16(len(stamp)))
+		out = append(out, stamp...)
+		return append(out, body...)
+	}
+
+	if _, err := LoadProgram(restamp(EngineVersion)); err != nil {
+		t.Fatalf("LoadProgram of a restamped blob failed: %v", err)
+	}
+	for _, stamp := range []string{
+		engineStamp(Version, ast.JSONVersion-1),
+		engineStamp("0.0.1", ast.JSONVersion),
+		Version,
+	} {
+		_, err := LoadProgram(restamp(stamp))
+		if err == nil || !strings.Contains(err.Error(), "stale program") || !strings.Contains(err.Error(), stamp) {
+			t.Errorf("LoadProgram with stamp %q: error = %v, want a stale program error", stamp, err)
+		}
+	}
+}
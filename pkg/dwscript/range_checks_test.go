@@ -0,0 +1,151 @@
+package dwscript
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+const rangeCheckDecls = `
+type TDigit = 0..9;
+type TRec = record Digit: TDigit; end;
+type TObj = class Digit: TDigit; end;
+var arr: array[1..3] of TDigit;
+var dyn: array of TDigit;
+var rec: TRec;
+var obj := TObj.Create;
+SetLength(dyn, 2);
+`
+
+// TestWithRangeChecks verifies that out-of-range writes to subrange-typed
+// array elements and fields are only rejected when range checks are enabled.
+func TestWithRangeChecks(t *testing.T) {
+	tests := []struct {
+		name      string
+		source    string
+		unchecked string
+		message   string
+	}{
+		{
+			name:      "StaticArrayElement",
+			source:    `arr[2] := 42; PrintLn(arr[2]);`,
+			unchecked: "42\n",
+			message:   "value 42 is out of range for type TDigit (0..9)",
+		},
+		{
+			name:      "DynamicArrayElement",
+			source:    `dyn[1] := -3; PrintLn(dyn[1]);`,
+			unchecked: "-3\n",
+			message:   "value -3 is out of range for type TDigit (0..9)",
+		},
+		{
+			name:      "RecordField",
+			source:    `rec.Digit := 10; PrintLn(rec.Digit);`,
+			unchecked: "10\n",
+			message:   "value 10 is out of range for type TDigit (0..9)",
+		},
+		{
+			name:      "ObjectField",
+			source:    `obj.Digit := 100; PrintLn(obj.Digit);`,
+			unchecked: "100\n",
+			message:   "value 100 is out of range for type TDigit (0..9)",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			engine, err := New(WithOutput(&buf))
+			if err != nil {
+				t.Fatalf("failed to create engine: %v", err)
+			}
+			if _, err := engine.Eval(rangeCheckDecls + tt.source); err != nil {
+				t.Fatalf("Eval failed: %v", err)
+			}
+			if buf.String() != tt.unchecked {
+				t.Errorf("unchecked output = %q, want %q", buf.String(), tt.unchecked)
+			}
+
+			source := rangeCheckDecls + "try\n" + tt.source + "\nexcept\non E: ERangeError do PrintLn(E.ClassName + ': ' + E.Message);\nend;"
+			buf.Reset()
+			engine, err = New(WithOutput(&buf), WithRangeChecks(true))
+			if err != nil {
+				t.Fatalf("failed to create engine: %v", err)
+			}
+			if _, err := engine.Eval(source); err != nil {
+				t.Fatalf("Eval failed: %v", err)
+			}
+			if got := buf.String(); !strings.HasPrefix(got, "ERangeError: "+tt.message) {
+				t.Errorf("checked output = %q, want ERangeError: %s", got, tt.message)
+			}
+		})
+	}
+}
+
+func TestWithRangeChecksInRange(t *testing.T) {
+	var buf bytes.Buffer
+	engine, err := New(WithOutput(&buf), WithRangeChecks(true))
+	if err != nil {
+		t.Fatalf("failed to create engine: %v", err)
+	}
+	source := rangeCheckDecls + `arr[3] := 9; dyn[0] := 0; rec.Digit := 5; obj.Digit := arr[3];
+PrintLn(arr[3]); PrintLn(dyn[0]); PrintLn(rec.Digit); PrintLn(obj.Digit);`
+	if _, err := engine.Eval(source); err != nil {
+		t.Fatalf("Eval failed: %v", err)
+	}
+	if want := "9\n0\n5\n9\n"; buf.String() != want {
+		t.Errorf("output = %q, want %q", buf.String(), want)
+	}
+}
+
+// TestSubrangeVariableRaisesERangeError verifies that subrange variables are
+// checked regardless of the range check setting.
+func TestSubrangeVariableRaisesERangeError(t *testing.T) {
+	var buf bytes.Buffer
+	engine, err := New(WithOutput(&buf))
+	if err != nil {
+		t.Fatalf("failed to create engine: %v", err)
+	}
+	source := `type TDigit = 0..9;
+var d: TDigit;
+try
+  d := 12;
+except
+  on E: ERangeError do PrintLn(E.Message);
+end;`
+	if _, err := engine.Eval(source); err != nil {
+		t.Fatalf("Eval failed: %v", err)
+	}
+	if want := "value 12 is out of range for type TDigit (0..9)"; !strings.HasPrefix(buf.String(), want) {
+		t.Errorf("output = %q, want prefix %q", buf.String(), want)
+	}
+}
+
+// TestSuccPredEnumEndsRaiseERangeError verifies that Succ/Pred past the ends
+// of an enum raise a catchable ERangeError when range checks are enabled.
+func TestSuccPredEnumEndsRaiseERangeError(t *testing.T) {
+	var buf bytes.Buffer
+	engine, err := New(WithOutput(&buf), WithRangeChecks(true))
+	if err != nil {
+		t.Fatalf("failed to create engine: %v", err)
+	}
+	source := `type TColor = (Red, Green, Blue);
+var c: TColor := Blue;
+try
+  c := Succ(c);
+except
+  on E: ERangeError do PrintLn(E.Message);
+end;
+try
+  c := Pred(Red);
+except
+  on E: ERangeError do PrintLn(E.Message);
+end;`
+	if _, err := engine.Eval(source); err != nil {
+		t.Fatalf("Eval failed: %v", err)
+	}
+	want := "Succ() cannot get successor of maximum enum value\nPred() cannot get predecessor of minimum enum value\n"
+	if buf.String() != want {
+		t.Errorf("output = %q, want %q", buf.String(), want)
+	}
+}
@@ -0,0 +1,144 @@
+package dwscript
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+const deepRecursionSource = `
+function Depth(n: Integer): Integer;
+begin
+   if n = 0 then
+      Result := 0
+   else
+      Result := Depth(n - 1) + 1;
+end;
+
+PrintLn(Depth(100000));
+`
+
+// TestRecursionStrategyDeepRecursion verifies that 100k nested calls run
+// under RecursionHeap and raise a stack overflow under RecursionNative.
+func TestRecursionStrategyDeepRecursion(t *testing.T) {
+	var buf bytes.Buffer
+	engine, err := New(WithOutput(&buf), WithMaxRecursionDepth(200000), WithRecursionStrategy(RecursionHeap))
+	if err != nil {
+		t.Fatalf("failed to create engine: %v", err)
+	}
+	if _, err := engine.Eval(deepRecursionSource); err != nil {
+		t.Fatalf("Eval failed under RecursionHeap: %v", err)
+	}
+	if buf.String() != "100000\n" {
+		t.Errorf("output = %q, want %q", buf.String(), "100000\n")
+	}
+
+	buf.Reset()
+	engine, err = New(WithOutput(&buf))
+	if err != nil {
+		t.Fatalf("failed to create engine: %v", err)
+	}
+	_, err = engine.Eval(deepRecursionSource)
+	if err == nil {
+		t.Fatal("expected a stack overflow under RecursionNative")
+	}
+	if want := "Maximal recursion exceeded (1024)"; !strings.Contains(err.Error(), want) {
+		t.Errorf("error = %.200q, want it to contain %q", err.Error(), want)
+	}
+	if buf.Len() != 0 {
+		t.Errorf("output = %q, want nothing", buf.String())
+	}
+}
+
+// TestNativeRecursionHonorsConfiguredDepth verifies that RecursionNative
+// accepts a depth above 10000 and raises the overflow at exactly that depth.
+func TestNativeRecursionHonorsConfiguredDepth(t *testing.T) {
+	const source = `
+function Depth(n: Integer): Integer;
+begin
+  if n = 0 then
+    Result := 0
+  else
+    Result := Depth(n - 1) + 1;
+end;
+
+PrintLn(Depth(15000));
+`
+	var buf bytes.Buffer
+	engine, err := New(WithOutput(&buf), WithMaxRecursionDepth(20000))
+	if err != nil {
+		t.Fatalf("failed to create engine: %v", err)
+	}
+	if _, err := engine.Eval(source); err != nil {
+		t.Fatalf("Eval failed: %.200v", err)
+	}
+	if buf.String() != "15000\n" {
+		t.Errorf("output = %q, want %q", buf.String(), "15000\n")
+	}
+
+	engine, err = New(WithOutput(&buf), WithMaxRecursionDepth(12000))
+	if err != nil {
+		t.Fatalf("failed to create engine: %v", err)
+	}
+	_, err = engine.Eval(source)
+	if want := "Maximal recursion exceeded (12000)"; err == nil || !strings.Contains(err.Error(), want) {
+		t.Errorf("error = %.200v, want it to contain %q", err, want)
+	}
+}
+
+// TestRecursionStrategyHeapSemantics verifies that exceptions, mutual
+// recursion and lambdas behave the same across goroutine switches.
+func TestRecursionStrategyHeapSemantics(t *testing.T) {
+	source := `
+function IsEven(n: Integer): Boolean; forward;
+
+function IsOdd(n: Integer): Boolean;
+begin
+   if n = 0 then Result := False else Result := IsEven(n - 1);
+end;
+
+function IsEven(n: Integer): Boolean;
+begin
+   if n = 0 then Result := True else Result := IsOdd(n - 1);
+end;
+
+procedure Dive(n: Integer);
+begin
+   if n = 0 then
+      raise Exception.Create('bottom');
+   Dive(n - 1);
+end;
+
+type TCounter = function(n: Integer): Integer;
+var count: TCounter;
+count := lambda(n: Integer): Integer
+   begin
+      if n = 0 then Result := 0 else Result := count(n - 1) + 1;
+   end;
+
+PrintLn(IsEven(3001));
+PrintLn(count(3000));
+try
+   Dive(3000);
+except
+   on E: Exception do PrintLn(E.Message);
+end;
+`
+	var buf bytes.Buffer
+	engine, err := New(WithOutput(&buf), WithMaxRecursionDepth(5000), WithRecursionStrategy(RecursionHeap))
+	if err != nil {
+		t.Fatalf("failed to create engine: %v", err)
+	}
+	if _, err := engine.Eval(source); err != nil {
+		t.Fatalf("Eval failed: %v", err)
+	}
+	if want := "False\n3000\nbottom\n"; buf.String() != want {
+		t.Errorf("output = %q, want %q", buf.String(), want)
+	}
+}
+
+func TestWithRecursionStrategyInvalid(t *testing.T) {
+	if _, err := New(WithRecursionStrategy(RecursionStrategy(99))); err == nil {
+		t.Error("expected error for invalid recursion strategy")
+	}
+}
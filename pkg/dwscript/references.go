@@ -0,0 +1,676 @@
+package dwscript
+
+import (
+	"sort"
+
+	"github.com/cwbudde/go-dws/internal/semantic"
+	"github.com/cwbudde/go-dws/internal/types"
+	"github.com/cwbudde/go-dws/pkg/ast"
+	"github.com/cwbudde/go-dws/pkg/ident"
+	"github.com/cwbudde/go-dws/pkg/token"
+)
+
+// ReferencesTo returns the positions of the declaration and every reference of
+// the symbol whose identifier covers pos, sorted by source offset.
+//
+// References are resolved with DWScript's lexical scoping rules (globals,
+// routine parameters and locals, block-local and loop variables, lambda
+// parameters and exception variables), so a same-spelled identifier that
+// belongs to a different scope is not included. Class and record members are
+// only reachable through their declaring type and are not tracked.
+//
+// Returns nil if pos does not fall on an identifier that resolves to a tracked
+// symbol.
+//
+// Example usage:
+//
+//	program, _ := engine.Compile(`
+//	    var count := 0;
+//	    count := count + 1;
+//	`)
+//
+//	for _, ref := range program.ReferencesTo(token.Position{Line: 2, Column: 10}) {
+//	    fmt.Printf("reference at %s\n", ref)
+//	}
+func (p *Program) ReferencesTo(pos token.Position) []token.Position {
+	if p == nil || p.ast == nil {
+		return nil
+	}
+
+	index := buildReferenceIndex(p.ast, p.analyzer)
+	sym := index.symbolAt(pos)
+	if sym == nil || !sym.tracked() {
+		return nil
+	}
+
+	idents := index.occurrencesOf(sym)
+	positions := make([]token.Position, len(idents))
+	for i, id := range idents {
+		positions[i] = id.Token.Pos
+	}
+	return positions
+}
+
+// refSymbol is a declaration discovered while resolving references.
+type refSymbol struct {
+	// decl is the declaring identifier, nil for names without an identifier
+	// node (enum elements, implicitly declared members).
+	decl  *ast.Identifier
+	scope *refScope
+	name  string
+	kind  string
+	// owner is the normalized name of the type declaring a member.
+	owner string
+}
+
+// tracked reports whether references to the symbol are resolved lexically.
+// Members and type names are declared so they shadow correctly, but their
+// uses are not followed.
+func (s *refSymbol) tracked() bool {
+	switch s.kind {
+	case "variable", "constant", "parameter", "function":
+		return true
+	}
+	return false
+}
+
+// refScope is a single lexical scope.
+type refScope struct {
+	parent  *refScope
+	symbols map[string]*refSymbol
+	// owner is the normalized name of the type whose members the scope
+	// holds, empty for other scopes.
+	owner string
+}
+
+func newRefScope(parent *refScope) *refScope {
+	return &refScope{parent: parent, symbols: make(map[string]*refSymbol)}
+}
+
+// resolve looks name up in the scope chain.
+func (s *refScope) resolve(name string) *refSymbol {
+	key := ident.Normalize(name)
+	for scope := s; scope != nil; scope = scope.parent {
+		if sym, ok := scope.symbols[key]; ok {
+			return sym
+		}
+	}
+	return nil
+}
+
+// memberScope returns the nearest enclosing scope holding type members, or
+// nil outside of type bodies and methods.
+func (s *refScope) memberScope() *refScope {
+	for scope := s; scope != nil; scope = scope.parent {
+		if scope.owner != "" {
+			return scope
+		}
+	}
+	return nil
+}
+
+// encloses reports whether s is inner or s itself.
+func (s *refScope) encloses(inner *refScope) bool {
+	for scope := inner; scope != nil; scope = scope.parent {
+		if scope == s {
+			return true
+		}
+	}
+	return false
+}
+
+// refOccurrence is an identifier in the source together with the scope it
+// appears in and the symbol it resolved to (nil when unresolved, e.g. for
+// built-ins and type names).
+type refOccurrence struct {
+	id    *ast.Identifier
+	scope *refScope
+	sym   *refSymbol
+	// receiver is the normalized name of the type a qualified member
+	// (obj.Member, inherited Member) was looked up on, empty for identifiers
+	// resolved lexically.
+	receiver string
+}
+
+// referenceIndex resolves every identifier of a program to its declaration.
+type referenceIndex struct {
+	analyzer    *semantic.Analyzer
+	members     map[string][]string
+	parents     map[string]string
+	typeNames   map[string]string
+	classes     map[string]bool
+	implements  map[string][]string
+	occurrences []*refOccurrence
+}
+
+// buildReferenceIndex walks the program once and records all identifier
+// occurrences with their resolved symbols. Qualified member accesses are only
+// resolved when an analyzer is given, as that needs the receiver's type.
+func buildReferenceIndex(program *ast.Program, analyzer *semantic.Analyzer) *referenceIndex {
+	index := &referenceIndex{
+		analyzer:   analyzer,
+		members:    make(map[string][]string),
+		parents:    make(map[string]string),
+		typeNames:  make(map[string]string),
+		classes:    make(map[string]bool),
+		implements: make(map[string][]string),
+	}
+	index.collectMembers(program)
+	ast.Walk(&refVisitor{index: index, scope: newRefScope(nil)}, program)
+	return index
+}
+
+// collectMembers records the member names of every class and record so method
+// implementations declared outside the type body see their fields.
+func (idx *referenceIndex) collectMembers(program *ast.Program) {
+	ast.Inspect(program, func(node ast.Node) bool {
+		switch n := node.(type) {
+		case *ast.ClassDecl:
+			key := ident.Normalize(n.Name.Value)
+			idx.members[key] = append(idx.members[key], classMemberNames(n)...)
+			idx.typeNames[key] = n.Name.Value
+			idx.classes[key] = true
+			if n.Parent != nil {
+				idx.parents[key] = ident.Normalize(n.Parent.Value)
+			}
+			for _, iface := range n.Interfaces {
+				idx.implements[key] = append(idx.implements[key], ident.Normalize(iface.Value))
+			}
+		case *ast.InterfaceDecl:
+			key := ident.Normalize(n.Name.Value)
+			for _, method := range n.Methods {
+				idx.members[key] = append(idx.members[key], method.Name.Value)
+			}
+			idx.typeNames[key] = n.Name.Value
+			if n.Parent != nil {
+				idx.parents[key] = ident.Normalize(n.Parent.Value)
+			}
+		case *ast.RecordDecl:
+			key := ident.Normalize(n.Name.Value)
+			idx.members[key] = append(idx.members[key], recordMemberNames(n)...)
+		case *ast.HelperDecl:
+			key := ident.Normalize(n.Name.Value)
+			idx.members[key] = append(idx.members[key], helperMemberNames(n)...)
+			if n.ForType != nil {
+				idx.parents[key] = ident.Normalize(n.ForType.String())
+			}
+		}
+		return true
+	})
+}
+
+// memberScope returns a scope holding the members of typeName and its
+// ancestors, innermost type last so its members win.
+func (idx *referenceIndex) memberScope(parent *refScope, typeName string) *refScope {
+	chain := idx.ancestry(ident.Normalize(typeName))
+
+	scope := newRefScope(parent)
+	scope.owner = ident.Normalize(typeName)
+	for i := len(chain) - 1; i >= 0; i-- {
+		for _, name := range idx.members[chain[i]] {
+			scope.symbols[ident.Normalize(name)] = &refSymbol{name: name, kind: "member", scope: scope, owner: chain[i]}
+		}
+	}
+	return scope
+}
+
+// ancestry returns key followed by the types it inherits from, nearest first.
+func (idx *referenceIndex) ancestry(key string) []string {
+	var chain []string
+	seen := make(map[string]bool)
+	for ; key != "" && !seen[key]; key = idx.parents[key] {
+		seen[key] = true
+		chain = append(chain, key)
+	}
+	return chain
+}
+
+// declaredMember returns the spelling of the member name declared by the
+// type key itself, or "" if it declares no such member.
+func (idx *referenceIndex) declaredMember(key, name string) string {
+	for _, member := range idx.members[key] {
+		if ident.Equal(member, name) {
+			return member
+		}
+	}
+	return ""
+}
+
+// memberSymbol resolves name as a member of the type key, searching its
+// ancestors when the type itself does not declare it. It returns nil for
+// members of built-in types.
+func (idx *referenceIndex) memberSymbol(key, name string) *refSymbol {
+	for _, owner := range idx.ancestry(key) {
+		if declared := idx.declaredMember(owner, name); declared != "" {
+			return &refSymbol{name: declared, kind: "member", owner: owner}
+		}
+	}
+	return nil
+}
+
+// receiverType returns the normalized name of the class or record a member
+// identifier is accessed on, or "" if it is unknown.
+func (idx *referenceIndex) receiverType(member *ast.Identifier) string {
+	if idx.analyzer == nil {
+		return ""
+	}
+	switch t := types.GetUnderlyingType(idx.analyzer.MemberReceiverType(member)).(type) {
+	case *types.ClassType:
+		return ident.Normalize(t.Name)
+	case *types.ClassOfType:
+		if t.ClassType != nil {
+			return ident.Normalize(t.ClassType.Name)
+		}
+	case *types.RecordType:
+		return ident.Normalize(t.Name)
+	}
+	return ""
+}
+
+func classMemberNames(decl *ast.ClassDecl) []string {
+	var names []string
+	for _, field := range decl.Fields {
+		names = append(names, field.Name.Value)
+	}
+	for _, method := range decl.Methods {
+		names = append(names, method.Name.Value)
+	}
+	for _, prop := range decl.Properties {
+		names = append(names, prop.Name.Value)
+	}
+	for _, constant := range decl.Constants {
+		names = append(names, constant.Name.Value)
+	}
+	return names
+}
+
+func recordMemberNames(decl *ast.RecordDecl) []string {
+	var names []string
+	for _, field := range decl.Fields {
+		names = append(names, field.Name.Value)
+	}
+	for _, field := range decl.ClassVars {
+		names = append(names, field.Name.Value)
+	}
+	for _, method := range decl.Methods {
+		names = append(names, method.Name.Value)
+	}
+	for _, prop := range decl.Properties {
+		names = append(names, prop.Name.Value)
+	}
+	for _, constant := range decl.Constants {
+		names = append(names, constant.Name.Value)
+	}
+	return names
+}
+
+func helperMemberNames(decl *ast.HelperDecl) []string {
+	var names []string
+	for _, method := range decl.Methods {
+		names = append(names, method.Name.Value)
+	}
+	for _, prop := range decl.Properties {
+		names = append(names, prop.Name.Value)
+	}
+	for _, field := range decl.ClassVars {
+		names = append(names, field.Name.Value)
+	}
+	for _, constant := range decl.ClassConsts {
+		names = append(names, constant.Name.Value)
+	}
+	return names
+}
+
+// symbolAt returns the symbol whose identifier covers pos.
+func (idx *referenceIndex) symbolAt(pos token.Position) *refSymbol {
+	for _, occ := range idx.occurrences {
+		start := occ.id.Token.Pos
+		if start.Line == pos.Line && pos.Column >= start.Column &&
+			pos.Column < start.Column+len(occ.id.Token.Literal) {
+			return occ.sym
+		}
+	}
+	return nil
+}
+
+// occurrencesOf returns the identifiers bound to sym, deduplicated and sorted
+// by source offset.
+func (idx *referenceIndex) occurrencesOf(sym *refSymbol) []*ast.Identifier {
+	return idx.occurrencesWhere(func(occ *refOccurrence) bool {
+		return occ.sym == sym
+	})
+}
+
+// occurrencesWhere returns the identifiers of the occurrences matching keep,
+// deduplicated and sorted by source offset.
+func (idx *referenceIndex) occurrencesWhere(keep func(*refOccurrence) bool) []*ast.Identifier {
+	seen := make(map[int]bool)
+	var result []*ast.Identifier
+	for _, occ := range idx.occurrences {
+		if !keep(occ) || seen[occ.id.Token.Pos.Offset] {
+			continue
+		}
+		seen[occ.id.Token.Pos.Offset] = true
+		result = append(result, occ.id)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Token.Pos.Offset < result[j].Token.Pos.Offset
+	})
+	return result
+}
+
+// refVisitor resolves identifiers against the scope it was created for.
+type refVisitor struct {
+	index *referenceIndex
+	scope *refScope
+}
+
+// declare adds a symbol for id to the current scope. Redeclaring a function
+// (forward declarations, overloads) joins the existing symbol.
+func (v *refVisitor) declare(id *ast.Identifier, kind string) {
+	if id == nil {
+		return
+	}
+	key := ident.Normalize(id.Value)
+	sym, ok := v.scope.symbols[key]
+	if !ok || sym.kind != kind || kind != "function" {
+		sym = &refSymbol{decl: id, name: id.Value, kind: kind, scope: v.scope}
+		v.scope.symbols[key] = sym
+	}
+	v.record(id, sym)
+}
+
+// declareName adds a symbol that has no identifier node.
+func (v *refVisitor) declareName(name, kind string) {
+	v.scope.symbols[ident.Normalize(name)] = &refSymbol{name: name, kind: kind, scope: v.scope}
+}
+
+func (v *refVisitor) record(id *ast.Identifier, sym *refSymbol) {
+	v.index.occurrences = append(v.index.occurrences, &refOccurrence{id: id, scope: v.scope, sym: sym})
+}
+
+// recordMember records a member looked up on the type receiver.
+func (v *refVisitor) recordMember(id *ast.Identifier, receiver string) {
+	if id == nil || receiver == "" {
+		return
+	}
+	v.index.occurrences = append(v.index.occurrences, &refOccurrence{
+		id:       id,
+		scope:    v.scope,
+		sym:      v.index.memberSymbol(receiver, id.Value),
+		receiver: receiver,
+	})
+}
+
+// recordMemberDecl records a member declared in the type body of the
+// visitor's member scope. Identifiers the parser synthesized, such as the
+// backing field of an auto-property, are skipped.
+func (v *refVisitor) recordMemberDecl(id *ast.Identifier) {
+	if id == nil || !ident.Equal(id.Value, id.Token.Literal) {
+		return
+	}
+	v.record(id, v.scope.symbols[ident.Normalize(id.Value)])
+}
+
+// nested returns a visitor for a new scope below the current one.
+func (v *refVisitor) nested() *refVisitor {
+	return &refVisitor{index: v.index, scope: newRefScope(v.scope)}
+}
+
+func (v *refVisitor) walk(node ast.Node) {
+	if node != nil {
+		ast.Walk(v, node)
+	}
+}
+
+func (v *refVisitor) walkParameters(params []*ast.Parameter) {
+	for _, param := range params {
+		if param.DefaultValue != nil {
+			v.walk(param.DefaultValue)
+		}
+		v.declare(param.Name, "parameter")
+	}
+}
+
+// Visit implements ast.Visitor. Nodes that introduce scopes or carry member
+// names are handled here and their children walked explicitly.
+func (v *refVisitor) Visit(node ast.Node) ast.Visitor {
+	switch n := node.(type) {
+	case *ast.Identifier:
+		v.record(n, v.scope.resolve(n.Value))
+		return nil
+
+	case *ast.VarDeclStatement:
+		if n.Value != nil {
+			v.walk(n.Value)
+		}
+		for _, name := range n.Names {
+			v.declare(name, "variable")
+		}
+		return nil
+
+	case *ast.ConstDecl:
+		if n.Value != nil {
+			v.walk(n.Value)
+		}
+		v.declare(n.Name, "constant")
+		return nil
+
+	case *ast.FunctionDecl:
+		inner := v
+		if n.ClassName != nil {
+			inner = &refVisitor{index: v.index, scope: v.index.memberScope(v.scope, n.ClassName.Value)}
+			inner.recordMemberDecl(n.Name)
+		} else if n.HelperName == nil {
+			v.declare(n.Name, "function")
+		}
+		inner.nested().walkRoutine(n.Parameters, n.PreConditions, n.Body, n.PostConditions)
+		return nil
+
+	case *ast.LambdaExpression:
+		v.nested().walkRoutine(n.Parameters, nil, n.Body, nil)
+		return nil
+
+	case *ast.BlockStatement:
+		inner := v.nested()
+		for _, stmt := range n.Statements {
+			inner.walk(stmt)
+		}
+		return nil
+
+	case *ast.IfStatement:
+		if n.InlineVar == nil {
+			break
+		}
+		// The condition only repeats the inline variable's name.
+		inner := v.nested()
+		inner.walk(n.InlineVar)
+		inner.walk(n.Consequence)
+		if n.Alternative != nil {
+			inner.walk(n.Alternative)
+		}
+		return nil
+
+	case *ast.WhileStatement:
+		if n.InlineVar == nil {
+			break
+		}
+		inner := v.nested()
+		inner.walk(n.InlineVar)
+		inner.walk(n.Body)
+		return nil
+
+	case *ast.ForStatement:
+		v.walk(n.Start)
+		v.walk(n.EndValue)
+		if n.Step != nil {
+			v.walk(n.Step)
+		}
+		inner := v.nested()
+		if n.InlineVar {
+			inner.declare(n.Variable, "variable")
+		} else {
+			v.walk(n.Variable)
+		}
+		inner.walk(n.Body)
+		return nil
+
+	case *ast.ForInStatement:
+		v.walk(n.Collection)
+		if n.Step != nil {
+			v.walk(n.Step)
+		}
+		inner := v.nested()
+		if n.InlineVar {
+			inner.declare(n.Variable, "variable")
+		} else {
+			v.walk(n.Variable)
+		}
+		inner.walk(n.Body)
+		return nil
+
+	case *ast.ExceptionHandler:
+		inner := v.nested()
+		inner.declare(n.Variable, "variable")
+		if n.Statement != nil {
+			inner.walk(n.Statement)
+		}
+		return nil
+
+	case *ast.MemberAccessExpression:
+		v.walk(n.Object)
+		v.recordMember(n.Member, v.index.receiverType(n.Member))
+		return nil
+
+	case *ast.MethodCallExpression:
+		v.walk(n.Object)
+		v.recordMember(n.Method, v.index.receiverType(n.Method))
+		for _, arg := range n.Arguments {
+			v.walk(arg)
+		}
+		return nil
+
+	case *ast.InheritedExpression:
+		if scope := v.scope.memberScope(); scope != nil {
+			v.recordMember(n.Method, v.index.parents[scope.owner])
+		}
+		for _, arg := range n.Arguments {
+			v.walk(arg)
+		}
+		return nil
+
+	case *ast.RecordLiteralExpression:
+		for _, field := range n.Fields {
+			v.walk(field.Value)
+		}
+		return nil
+
+	case *ast.ClassDecl:
+		v.declare(n.Name, "type")
+		inner := &refVisitor{index: v.index, scope: v.index.memberScope(v.scope, n.Name.Value)}
+		for _, field := range n.Fields {
+			if field.InitValue != nil {
+				inner.walk(field.InitValue)
+			}
+		}
+		for _, constant := range n.Constants {
+			inner.walk(constant.Value)
+		}
+		inner.walkClassMembers(n)
+		inner.walkMethods(n.Methods)
+		return nil
+
+	case *ast.RecordDecl:
+		v.declare(n.Name, "type")
+		inner := &refVisitor{index: v.index, scope: v.index.memberScope(v.scope, n.Name.Value)}
+		for _, field := range n.Fields {
+			if field.InitValue != nil {
+				inner.walk(field.InitValue)
+			}
+		}
+		for _, constant := range n.Constants {
+			inner.walk(constant.Value)
+		}
+		inner.walkMethods(n.Methods)
+		return nil
+
+	case *ast.HelperDecl:
+		v.declare(n.Name, "type")
+		inner := &refVisitor{index: v.index, scope: v.index.memberScope(v.scope, n.Name.Value)}
+		inner.walkMethods(n.Methods)
+		return nil
+
+	case *ast.EnumDecl:
+		v.declare(n.Name, "type")
+		if !n.Scoped {
+			for _, value := range n.Values {
+				v.declareName(value.Name, "enum value")
+			}
+		}
+		return nil
+
+	case *ast.InterfaceDecl:
+		v.declare(n.Name, "type")
+		return nil
+	case *ast.ArrayDecl:
+		v.declare(n.Name, "type")
+		return nil
+	case *ast.SetDecl:
+		v.declare(n.Name, "type")
+		return nil
+	case *ast.TypeDeclaration:
+		v.declare(n.Name, "type")
+		return nil
+	}
+	return v
+}
+
+// walkClassMembers records the member declarations of a class body and
+// resolves the fields and methods its property accessors refer to.
+func (v *refVisitor) walkClassMembers(decl *ast.ClassDecl) {
+	for _, field := range decl.Fields {
+		v.recordMemberDecl(field.Name)
+	}
+	for _, method := range decl.Methods {
+		v.recordMemberDecl(method.Name)
+	}
+	for _, constant := range decl.Constants {
+		v.recordMemberDecl(constant.Name)
+	}
+	for _, prop := range decl.Properties {
+		v.recordMemberDecl(prop.Name)
+		if prop.IsAutoProperty {
+			continue
+		}
+		for _, spec := range []ast.Node{prop.ReadSpec, prop.WriteSpec, prop.WriteStmt} {
+			v.walk(spec)
+		}
+	}
+}
+
+// walkMethods walks the methods declared with a body inside a type body.
+func (v *refVisitor) walkMethods(methods []*ast.FunctionDecl) {
+	for _, method := range methods {
+		if method.Body != nil {
+			v.nested().walkRoutine(method.Parameters, method.PreConditions, method.Body, method.PostConditions)
+		}
+	}
+}
+
+// walkRoutine walks a routine in the visitor's scope. Parameters and the
+// locals of the body share that scope, as DWScript rejects a local that
+// redeclares a parameter.
+func (v *refVisitor) walkRoutine(params []*ast.Parameter, pre *ast.PreConditions, body *ast.BlockStatement, post *ast.PostConditions) {
+	v.walkParameters(params)
+	if pre != nil {
+		v.walk(pre)
+	}
+	if body != nil {
+		for _, stmt := range body.Statements {
+			v.walk(stmt)
+		}
+	}
+	if post != nil {
+		v.walk(post)
+	}
+}
@@ -0,0 +1,275 @@
+package dwscript
+
+import (
+	"fmt"
+	"sort"
+	"unicode"
+
+	"github.com/cwbudde/go-dws/pkg/ast"
+	"github.com/cwbudde/go-dws/pkg/ident"
+	"github.com/cwbudde/go-dws/pkg/token"
+)
+
+// TextEdit replaces the source bytes in [Start, End) with NewText.
+// Offsets are 0-indexed byte offsets into the source the program was compiled from.
+type TextEdit struct {
+	NewText string
+	Start   int
+	End     int
+}
+
+// RenamePreview returns the edits that rename the symbol under pos to newName,
+// covering its declaration and every reference found by ReferencesTo. Edits are
+// sorted by offset and do not overlap, so they can be applied back to front.
+//
+// The rename is rejected if newName is not a valid identifier, is a reserved
+// word, or would clash with another name: an existing declaration in the same
+// scope, a declaration that would shadow one of the references, or an outer
+// symbol that is referenced inside the renamed symbol's scope.
+//
+// Example usage:
+//
+//	program, _ := engine.Compile(source)
+//	edits, err := program.RenamePreview(token.Position{Line: 2, Column: 10}, "total")
+//	if err != nil {
+//	    return err
+//	}
+//	for i := len(edits) - 1; i >= 0; i-- {
+//	    source = source[:edits[i].Start] + edits[i].NewText + source[edits[i].End:]
+//	}
+func (p *Program) RenamePreview(pos token.Position, newName string) ([]TextEdit, error) {
+	return p.rename(pos, newName, false)
+}
+
+// Rename returns the edits that rename the symbol under pos to newName, like
+// RenamePreview, but also renames class members. Edits are sorted by offset
+// and do not overlap, so they can be applied back to front.
+//
+// A class member is renamed together with the members of the same name in
+// the classes that inherit from its topmost declaring class: its overloads,
+// the overrides of a virtual method, and their method implementations,
+// property accessors and call sites, including calls through a variable of
+// the base class type and inherited calls. Members of other classes with the
+// same spelling are left untouched.
+//
+// Besides the checks of RenamePreview, a member rename is rejected if an
+// affected class or one of its ancestors already declares newName, if the
+// member implements an interface method, or if an identifier that newName
+// would start resolving to the member is used in an affected class. Renaming
+// members needs the receiver types of member accesses, so the program must
+// have been compiled with type checking enabled.
+//
+// Example usage:
+//
+//	program, _ := engine.Compile(source)
+//	// rename TShape.Area, its overrides and every call site
+//	edits, err := program.Rename(token.Position{Line: 3, Column: 14}, "Surface")
+func (p *Program) Rename(pos token.Position, newName string) ([]TextEdit, error) {
+	return p.rename(pos, newName, true)
+}
+
+// rename implements RenamePreview and, with members set, Rename.
+func (p *Program) rename(pos token.Position, newName string, members bool) ([]TextEdit, error) {
+	if p == nil || p.ast == nil {
+		return nil, fmt.Errorf("program is nil")
+	}
+	if err := validateIdentifierName(newName); err != nil {
+		return nil, err
+	}
+
+	index := buildReferenceIndex(p.ast, p.analyzer)
+	sym := index.symbolAt(pos)
+	if sym == nil {
+		return nil, fmt.Errorf("no symbol found at %s", pos)
+	}
+
+	var idents []*ast.Identifier
+	switch {
+	case sym.tracked():
+		if err := index.checkRenameConflicts(sym, newName); err != nil {
+			return nil, err
+		}
+		idents = index.occurrencesOf(sym)
+	case members && sym.kind == "member" && index.classes[sym.owner]:
+		if p.analyzer == nil {
+			return nil, fmt.Errorf("cannot rename member '%s': renaming class members requires type checking", sym.name)
+		}
+		group := index.memberGroup(sym)
+		if err := index.checkMemberRenameConflicts(group, newName); err != nil {
+			return nil, err
+		}
+		idents = index.occurrencesWhere(func(occ *refOccurrence) bool {
+			return index.inGroup(group, occ.sym)
+		})
+	default:
+		return nil, fmt.Errorf("cannot rename %s '%s'", sym.kind, sym.name)
+	}
+
+	edits := make([]TextEdit, len(idents))
+	for i, id := range idents {
+		edits[i] = TextEdit{
+			Start:   id.Token.Pos.Offset,
+			End:     id.Token.Pos.Offset + len(id.Token.Literal),
+			NewText: newName,
+		}
+	}
+	return edits, nil
+}
+
+// validateIdentifierName checks that name is a plain, non-reserved identifier.
+func validateIdentifierName(name string) error {
+	if name == "" {
+		return fmt.Errorf("name cannot be empty")
+	}
+	for i, r := range name {
+		if r == '_' || unicode.IsLetter(r) || (i > 0 && unicode.IsDigit(r)) {
+			continue
+		}
+		return fmt.Errorf("'%s' is not a valid identifier", name)
+	}
+	if token.IsKeyword(name) {
+		return fmt.Errorf("'%s' is a reserved word", name)
+	}
+	return nil
+}
+
+// checkRenameConflicts reports whether renaming sym to newName would change
+// what any identifier in the program resolves to.
+func (idx *referenceIndex) checkRenameConflicts(sym *refSymbol, newName string) error {
+	if ident.Equal(sym.name, newName) {
+		return nil
+	}
+
+	key := ident.Normalize(newName)
+	if existing, ok := sym.scope.symbols[key]; ok {
+		return fmt.Errorf("cannot rename '%s' to '%s': %s", sym.name, newName, describeConflict(existing))
+	}
+
+	for _, occ := range idx.occurrences {
+		switch {
+		case occ.receiver != "":
+			// Qualified members are not resolved lexically.
+		case occ.sym == sym:
+			// A declaration between the reference and sym's scope would
+			// capture the renamed reference.
+			if other := occ.scope.resolve(newName); other != nil && sym.scope.encloses(other.scope) {
+				return fmt.Errorf("cannot rename '%s' to '%s': %s", sym.name, newName, describeConflict(other))
+			}
+		case ident.Equal(occ.id.Value, newName) && sym.scope.encloses(occ.scope):
+			// An outer symbol (or built-in) used inside sym's scope would be
+			// shadowed by the renamed declaration.
+			if occ.sym == nil || !sym.scope.encloses(occ.sym.scope) {
+				return fmt.Errorf("cannot rename '%s' to '%s': '%s' is already used at %s",
+					sym.name, newName, occ.id.Value, occ.id.Token.Pos)
+			}
+		}
+	}
+	return nil
+}
+
+// describeConflict formats the declaration that a rename would clash with.
+func describeConflict(sym *refSymbol) string {
+	if sym.decl != nil {
+		return fmt.Sprintf("%s '%s' is already declared at %s", sym.kind, sym.name, sym.decl.Token.Pos)
+	}
+	return fmt.Sprintf("%s '%s' is already declared", sym.kind, sym.name)
+}
+
+// memberGroup identifies a class member together with the members of the
+// same name in the classes inheriting from root, its topmost declaring class.
+type memberGroup struct {
+	name string
+	root string
+}
+
+// memberGroup returns the group of the class member sym.
+func (idx *referenceIndex) memberGroup(sym *refSymbol) memberGroup {
+	group := memberGroup{name: sym.name, root: sym.owner}
+	for _, key := range idx.ancestry(sym.owner) {
+		if idx.declaredMember(key, sym.name) != "" {
+			group.root = key
+		}
+	}
+	return group
+}
+
+// inGroup reports whether sym is a member of group.
+func (idx *referenceIndex) inGroup(group memberGroup, sym *refSymbol) bool {
+	return sym != nil && sym.kind == "member" && ident.Equal(sym.name, group.name) && idx.inherits(sym.owner, group.root)
+}
+
+// inherits reports whether the type key is ancestor or inherits from it.
+func (idx *referenceIndex) inherits(key, ancestor string) bool {
+	for _, k := range idx.ancestry(key) {
+		if k == ancestor {
+			return true
+		}
+	}
+	return false
+}
+
+// checkMemberRenameConflicts reports whether renaming the members of group
+// to newName would clash with another member or change what any identifier
+// in the affected classes resolves to.
+func (idx *referenceIndex) checkMemberRenameConflicts(group memberGroup, newName string) error {
+	if ident.Equal(group.name, newName) {
+		return nil
+	}
+	fail := func(format string, args ...any) error {
+		return fmt.Errorf("cannot rename '%s' to '%s': "+format, append([]any{group.name, newName}, args...)...)
+	}
+
+	var affected []string
+	for key := range idx.classes {
+		if idx.inherits(key, group.root) {
+			affected = append(affected, key)
+		}
+	}
+	sort.Strings(affected)
+
+	for _, key := range affected {
+		for _, owner := range idx.ancestry(key) {
+			if declared := idx.declaredMember(owner, newName); declared != "" {
+				return fail("member '%s' is already declared in %s", declared, idx.typeNames[owner])
+			}
+		}
+		for _, iface := range idx.implements[key] {
+			for _, owner := range idx.ancestry(iface) {
+				if declared := idx.declaredMember(owner, group.name); declared != "" {
+					return fail("%s implements %s.%s", idx.typeNames[key], idx.typeNames[owner], declared)
+				}
+			}
+		}
+	}
+
+	newKey := ident.Normalize(newName)
+	for _, occ := range idx.occurrences {
+		switch {
+		case idx.inGroup(group, occ.sym):
+			if occ.receiver != "" {
+				continue
+			}
+			// A local declaration between the reference and the member
+			// scope would capture the renamed reference.
+			for scope := occ.scope; scope != nil && scope.owner == ""; scope = scope.parent {
+				if other, ok := scope.symbols[newKey]; ok {
+					return fail("%s", describeConflict(other))
+				}
+			}
+		case ident.Equal(occ.id.Value, newName):
+			// A built-in member, or an outer symbol used inside an affected
+			// class, would resolve to the renamed member instead.
+			if occ.receiver != "" {
+				if occ.sym == nil && idx.inherits(occ.receiver, group.root) {
+					return fail("'%s' is already used at %s", occ.id.Value, occ.id.Token.Pos)
+				}
+				continue
+			}
+			scope := occ.scope.memberScope()
+			if scope != nil && idx.inherits(scope.owner, group.root) && (occ.sym == nil || occ.sym.scope.encloses(scope)) {
+				return fail("'%s' is already used at %s", occ.id.Value, occ.id.Token.Pos)
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,414 @@
+package dwscript
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/cwbudde/go-dws/pkg/token"
+)
+
+// applyEdits applies non-overlapping, offset-sorted edits to source.
+func applyEdits(source string, edits []TextEdit) string {
+	for i := len(edits) - 1; i >= 0; i-- {
+		source = source[:edits[i].Start] + edits[i].NewText + source[edits[i].End:]
+	}
+	return source
+}
+
+func compileForRename(t *testing.T, source string) *Program {
+	t.Helper()
+
+	engine, err := New(WithTypeCheck(true))
+	if err != nil {
+		t.Fatalf("Failed to create engine: %v", err)
+	}
+	program, err := engine.Compile(source)
+	if err != nil {
+		t.Fatalf("Compilation failed: %v", err)
+	}
+	return program
+}
+
+func TestProgram_RenamePreview_LocalVariable(t *testing.T) {
+	source := `var count := 1;
+
+procedure Work;
+var count: Integer;
+begin
+  count := 10;
+  count := count * 2;
+  PrintLn(count);
+end;
+
+count := count + 1;
+Work;
+`
+	program := compileForRename(t, source)
+
+	// 'count' in the local declaration of Work
+	edits, err := program.RenamePreview(token.Position{Line: 4, Column: 6}, "total")
+	if err != nil {
+		t.Fatalf("RenamePreview failed: %v", err)
+	}
+	if len(edits) != 5 {
+		t.Fatalf("expected 5 edits, got %d: %v", len(edits), edits)
+	}
+
+	want := `var count := 1;
+
+procedure Work;
+var total: Integer;
+begin
+  total := 10;
+  total := total * 2;
+  PrintLn(total);
+end;
+
+count := count + 1;
+Work;
+`
+	if got := applyEdits(source, edits); got != want {
+		t.Errorf("unexpected result:\n%s", got)
+	}
+
+	refs := program.ReferencesTo(token.Position{Line: 11, Column: 1})
+	if len(refs) != 3 {
+		t.Errorf("expected 3 references to global 'count', got %v", refs)
+	}
+}
+
+func TestProgram_RenamePreview_CaseInsensitive(t *testing.T) {
+	source := `var Value := 1;
+PrintLn(VALUE + value);
+`
+	program := compileForRename(t, source)
+
+	edits, err := program.RenamePreview(token.Position{Line: 2, Column: 10}, "Amount")
+	if err != nil {
+		t.Fatalf("RenamePreview failed: %v", err)
+	}
+	want := `var Amount := 1;
+PrintLn(Amount + Amount);
+`
+	if got := applyEdits(source, edits); got != want {
+		t.Errorf("unexpected result:\n%s", got)
+	}
+}
+
+func TestProgram_RenamePreview_Errors(t *testing.T) {
+	source := `var total := 0;
+
+procedure Work;
+var count: Integer;
+begin
+  count := total;
+end;
+
+function Twice(x: Integer): Integer;
+var y: Integer;
+begin
+  y := x;
+  Result := y * 2;
+end;
+`
+	program := compileForRename(t, source)
+
+	tests := []struct {
+		name    string
+		pos     token.Position
+		newName string
+		wantErr string
+	}{
+		{
+			name:    "name already declared in scope",
+			pos:     token.Position{Line: 12, Column: 3},
+			newName: "X",
+			wantErr: "parameter 'x' is already declared",
+		},
+		{
+			name:    "outer symbol referenced in scope",
+			pos:     token.Position{Line: 4, Column: 5},
+			newName: "Total",
+			wantErr: "'total' is already used",
+		},
+		{
+			name:    "implicit Result",
+			pos:     token.Position{Line: 12, Column: 3},
+			newName: "result",
+			wantErr: "already used",
+		},
+		{
+			name:    "keyword",
+			pos:     token.Position{Line: 4, Column: 5},
+			newName: "begin",
+			wantErr: "reserved word",
+		},
+		{
+			name:    "invalid identifier",
+			pos:     token.Position{Line: 4, Column: 5},
+			newName: "1count",
+			wantErr: "not a valid identifier",
+		},
+		{
+			name:    "no symbol",
+			pos:     token.Position{Line: 5, Column: 2},
+			newName: "other",
+			wantErr: "no symbol found",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := program.RenamePreview(tt.pos, tt.newName)
+			if err == nil {
+				t.Fatalf("expected error containing %q", tt.wantErr)
+			}
+			if !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("expected error containing %q, got %q", tt.wantErr, err.Error())
+			}
+		})
+	}
+}
+
+func TestProgram_RenamePreview_SkipsMembers(t *testing.T) {
+	source := `var Name := 'global';
+
+type TItem = class
+  Name: String;
+  procedure Show;
+end;
+
+procedure TItem.Show;
+begin
+  PrintLn(Name);
+end;
+
+var item := TItem.Create;
+item.Name := Name;
+`
+	program := compileForRename(t, source)
+
+	edits, err := program.RenamePreview(token.Position{Line: 1, Column: 5}, "Title")
+	if err != nil {
+		t.Fatalf("RenamePreview failed: %v", err)
+	}
+	want := strings.Replace(source, "var Name :=", "var Title :=", 1)
+	want = strings.Replace(want, "item.Name := Name;", "item.Name := Title;", 1)
+	if got := applyEdits(source, edits); got != want {
+		t.Errorf("unexpected result:\n%s", got)
+	}
+
+	if _, err := program.RenamePreview(token.Position{Line: 4, Column: 3}, "Caption"); err == nil {
+		t.Error("expected renaming a field to be rejected")
+	}
+}
+
+func TestProgram_Rename_VirtualMethod(t *testing.T) {
+	source := `type TShape = class
+  function Area: Float; virtual;
+  procedure Show;
+end;
+
+type TSquare = class(TShape)
+  Side: Float;
+  function Area: Float; override;
+end;
+
+type TCircle = class
+  function Area: Float;
+end;
+
+function TShape.Area: Float;
+begin
+  Result := 0;
+end;
+
+procedure TShape.Show;
+begin
+  PrintLn(area);
+end;
+
+function TSquare.Area: Float;
+begin
+  Result := inherited Area + Side * Side;
+end;
+
+function TCircle.Area: Float;
+begin
+  Result := 3.14;
+end;
+
+var shape: TShape := TSquare.Create;
+var circle := TCircle.Create;
+PrintLn(shape.Area + circle.Area);
+PrintLn(TSquare(shape).AREA());
+`
+	program := compileForRename(t, source)
+
+	// 'Area' in the TSquare override renames the whole override group
+	edits, err := program.Rename(token.Position{Line: 8, Column: 12}, "Surface")
+	if err != nil {
+		t.Fatalf("Rename failed: %v", err)
+	}
+	want := strings.NewReplacer(
+		"function Area: Float; virtual;", "function Surface: Float; virtual;",
+		"function Area: Float; override;", "function Surface: Float; override;",
+		"TShape.Area", "TShape.Surface",
+		"PrintLn(area)", "PrintLn(Surface)",
+		"TSquare.Area", "TSquare.Surface",
+		"inherited Area", "inherited Surface",
+		"shape.Area", "shape.Surface",
+		"(shape).AREA", "(shape).Surface",
+	).Replace(source)
+	if got := applyEdits(source, edits); got != want {
+		t.Errorf("unexpected result:\n%s", got)
+	}
+
+	// RenamePreview does not rename members
+	if _, err := program.RenamePreview(token.Position{Line: 8, Column: 12}, "Surface"); err == nil {
+		t.Error("expected RenamePreview to reject a method")
+	}
+}
+
+func TestProgram_Rename_OverloadsAndFields(t *testing.T) {
+	source := `type TLog = class
+  FCount: Integer;
+  procedure Note(s: String); overload;
+  procedure Note(i: Integer); overload;
+  property Count: Integer read FCount;
+end;
+
+procedure TLog.Note(s: String);
+begin
+  FCount := FCount + 1;
+end;
+
+procedure TLog.Note(i: Integer);
+begin
+  Note(IntToStr(i));
+end;
+
+var log := TLog.Create;
+log.Note('a');
+log.Note(1);
+PrintLn(log.Count);
+`
+	program := compileForRename(t, source)
+
+	edits, err := program.Rename(token.Position{Line: 19, Column: 5}, "Append")
+	if err != nil {
+		t.Fatalf("Rename failed: %v", err)
+	}
+	want := strings.ReplaceAll(source, "Note(", "Append(")
+	if got := applyEdits(source, edits); got != want {
+		t.Errorf("unexpected result:\n%s", got)
+	}
+
+	edits, err = program.Rename(token.Position{Line: 2, Column: 3}, "FTotal")
+	if err != nil {
+		t.Fatalf("Rename failed: %v", err)
+	}
+	want = strings.ReplaceAll(source, "FCount", "FTotal")
+	if got := applyEdits(source, edits); got != want {
+		t.Errorf("unexpected result:\n%s", got)
+	}
+}
+
+func TestProgram_Rename_Errors(t *testing.T) {
+	source := `type IRunner = interface
+  procedure Run;
+end;
+
+type TBase = class
+  procedure Start;
+  procedure Stop;
+end;
+
+type TJob = class(TBase, IRunner)
+  procedure Run;
+  procedure Work;
+end;
+
+procedure TBase.Start;
+begin
+end;
+
+procedure TBase.Stop;
+begin
+end;
+
+procedure TJob.Run;
+begin
+end;
+
+procedure TJob.Work;
+var Pause: Integer;
+begin
+  Pause := 1;
+  Start;
+  PrintLn(ClassName);
+end;
+`
+	program := compileForRename(t, source)
+
+	tests := []struct {
+		name    string
+		pos     token.Position
+		newName string
+		wantErr string
+	}{
+		{
+			name:    "member declared in the same class",
+			pos:     token.Position{Line: 6, Column: 13},
+			newName: "STOP",
+			wantErr: "member 'Stop' is already declared in TBase",
+		},
+		{
+			name:    "member declared in a descendant",
+			pos:     token.Position{Line: 6, Column: 13},
+			newName: "work",
+			wantErr: "member 'Work' is already declared in TJob",
+		},
+		{
+			name:    "member declared in an ancestor",
+			pos:     token.Position{Line: 12, Column: 13},
+			newName: "Stop",
+			wantErr: "member 'Stop' is already declared in TBase",
+		},
+		{
+			name:    "local captures a reference",
+			pos:     token.Position{Line: 6, Column: 13},
+			newName: "Pause",
+			wantErr: "variable 'Pause' is already declared",
+		},
+		{
+			name:    "built-in member in use",
+			pos:     token.Position{Line: 6, Column: 13},
+			newName: "ClassName",
+			wantErr: "'ClassName' is already used",
+		},
+		{
+			name:    "interface method",
+			pos:     token.Position{Line: 11, Column: 13},
+			newName: "Execute",
+			wantErr: "TJob implements IRunner.Run",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := program.Rename(tt.pos, tt.newName)
+			if err == nil {
+				t.Fatalf("expected error containing %q", tt.wantErr)
+			}
+			if !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("expected error containing %q, got %q", tt.wantErr, err.Error())
+			}
+		})
+	}
+
+	// Changing only the case of a member is always allowed.
+	if _, err := program.Rename(token.Position{Line: 6, Column: 13}, "START"); err != nil {
+		t.Errorf("Rename to a different case failed: %v", err)
+	}
+}
@@ -0,0 +1,141 @@
+package dwscript
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// TestRunContextCancel verifies that cancelling the context stops a script
+// that would otherwise never finish, without running except or finally
+// blocks.
+func TestRunContextCancel(t *testing.T) {
+	tests := []struct {
+		name   string
+		mode   CompileMode
+		source string
+		want   string
+	}{
+		{"loop", CompileModeAST, `
+PrintLn('start');
+while True do ;
+`, "start\n"},
+		{"try/except", CompileModeAST, `
+procedure Spin;
+begin
+  while True do ;
+end;
+try
+  try
+    Spin;
+  except
+    PrintLn('caught');
+  end;
+finally
+  PrintLn('finally');
+end;
+PrintLn('after');
+`, ""},
+		{"recursion", CompileModeAST, `
+function Count(n: Integer): Integer;
+begin
+  for var i := 1 to 1000 do ;
+  Result := Count(n + 1);
+end;
+Count(0);
+`, ""},
+		{"bytecode", CompileModeBytecode, `
+PrintLn('start');
+while True do ;
+`, "start\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			engine, err := New(WithCompileMode(tt.mode), WithMaxRecursionDepth(1<<20), WithRecursionStrategy(RecursionHeap))
+			if err != nil {
+				t.Fatalf("failed to create engine: %v", err)
+			}
+			program, err := engine.Compile(tt.source)
+			if err != nil {
+				t.Fatalf("Compile failed: %v", err)
+			}
+
+			ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+			defer cancel()
+			result, err := engine.RunContext(ctx, program, nil)
+			if !errors.Is(err, context.DeadlineExceeded) {
+				t.Fatalf("RunContext error = %v, want %v", err, context.DeadlineExceeded)
+			}
+			if result == nil || result.Success {
+				t.Fatalf("RunContext result = %+v, want an unsuccessful result", result)
+			}
+			if result.Output != tt.want {
+				t.Errorf("output = %q, want %q", result.Output, tt.want)
+			}
+		})
+	}
+}
+
+// TestRunContextCompletes verifies that a context that is never cancelled
+// leaves the run untouched.
+func TestRunContextCompletes(t *testing.T) {
+	engine, err := New()
+	if err != nil {
+		t.Fatalf("failed to create engine: %v", err)
+	}
+	program, err := engine.Compile(`for var i := 1 to 3 do PrintLn(i);`)
+	if err != nil {
+		t.Fatalf("Compile failed: %v", err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	result, err := engine.RunContext(ctx, program, nil)
+	if err != nil {
+		t.Fatalf("RunContext failed: %v", err)
+	}
+	if result.Output != "1\n2\n3\n" {
+		t.Errorf("output = %q, want %q", result.Output, "1\n2\n3\n")
+	}
+}
+
+// TestExpressionGoValue verifies that the value of a trailing expression of
+// an Engine run is converted to Go.
+func TestExpressionGoValue(t *testing.T) {
+	engine, err := New()
+	if err != nil {
+		t.Fatalf("failed to create engine: %v", err)
+	}
+
+	tests := []struct {
+		source string
+		want   interface{}
+		ok     bool
+	}{
+		{`40 + 2`, int64(42), true},
+		{`1.5 * 2`, 3.0, true},
+		{`'a' + 'b'`, "ab", true},
+		{`1 < 2`, true, true},
+		{`var x := 1;`, nil, false},
+	}
+	for _, tt := range tests {
+		result, err := engine.Eval(tt.source)
+		if err != nil {
+			t.Fatalf("Eval(%q) failed: %v", tt.source, err)
+		}
+		got, ok, err := result.ExpressionGoValue()
+		if err != nil || got != tt.want || ok != tt.ok {
+			t.Errorf("Eval(%q).ExpressionGoValue() = %v, %v, %v, want %v, %v", tt.source, got, ok, err, tt.want, tt.ok)
+		}
+	}
+
+	result, err := engine.Eval(`[1, 2, 3]`)
+	if err != nil {
+		t.Fatalf("Eval failed: %v", err)
+	}
+	got, ok, err := result.ExpressionGoValue()
+	if elems, isSlice := got.([]interface{}); err != nil || !ok || !isSlice || len(elems) != 3 || elems[2] != int64(3) {
+		t.Errorf("ExpressionGoValue() = %v, %v, %v, want [1 2 3]", got, ok, err)
+	}
+}
@@ -0,0 +1,285 @@
+package dwscript
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+
+	"github.com/cwbudde/go-dws/internal/frontend"
+	"github.com/cwbudde/go-dws/internal/interp"
+	"github.com/cwbudde/go-dws/internal/interp/runner"
+	"github.com/cwbudde/go-dws/internal/interp/runtime"
+	"github.com/cwbudde/go-dws/internal/semantic"
+	"github.com/cwbudde/go-dws/pkg/ast"
+)
+
+// StateID identifies a state of a Session saved with Snapshot.
+type StateID int
+
+// Session evaluates a sequence of scripts that build on each other, like the
+// inputs of a REPL: variables, functions and types declared by one Eval are
+// visible to every later Eval on the same session.
+//
+//	session, _ := engine.NewSession()
+//	session.Eval(`var Total := 0;`)
+//	saved := session.Snapshot()
+//	session.Eval(`Total := Total + 42;`)
+//	session.Restore(saved) // Total is 0 again
+//
+// Declaring a function that an earlier Eval declared replaces it and reports
+// a warning diagnostic instead of an error. A script that fails to compile
+// leaves the session unchanged; a script that fails at run time keeps the
+// declarations and assignments it made before the error.
+//
+// Sessions always run on the AST interpreter, whatever the engine's compile
+// mode. A Session is not safe for concurrent use.
+type Session struct {
+	engine      *Engine
+	analyzer    *semantic.Analyzer
+	interpreter *interp.Interpreter
+	output      *sessionOutput
+	globals     []hostGlobal
+	// programs lists the programs evaluated so far; the analyzer is rebuilt
+	// from them when an Eval fails to compile or a state is restored.
+	programs    []*ast.Program
+	states      []sessionState
+	diagnostics []Diagnostic
+}
+
+// sessionState is a state saved by Session.Snapshot.
+type sessionState struct {
+	globals  *interp.GlobalState
+	programs []*ast.Program
+}
+
+// sessionOutput forwards the interpreter's output to the writer of the
+// current Eval.
+type sessionOutput struct {
+	w io.Writer
+}
+
+func (o *sessionOutput) Write(p []byte) (int, error) {
+	return o.w.Write(p)
+}
+
+// NewSession returns a new session with the engine's options, host globals,
+// functions and units. Host globals start from the values they have when
+// the session is created.
+func (e *Engine) NewSession() (*Session, error) {
+	s := &Session{
+		engine:  e,
+		output:  &sessionOutput{w: io.Discard},
+		globals: e.hostGlobals(),
+	}
+	s.analyzer = s.newAnalyzer()
+	s.globals = boundHostGlobals(s.globals, s.analyzer)
+
+	opts := e.options
+	opts.Output = s.output
+	opts.ExternalFunctions = e.externalFunctions
+	opts.Builtins, _ = e.hostBuiltins()
+	s.interpreter = runner.NewWithOptions(s.output, &opts)
+	if err := defineHostGlobals(s.interpreter, s.globals); err != nil {
+		return nil, err
+	}
+	if err := defineHostUnits(s.interpreter, e.hostUnits()); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// Eval compiles and runs source in the session, writing its output to the
+// engine's output writer.
+//
+// On a compile error the session is left as it was and a *CompileError is
+// returned. The diagnostics of the call, including warnings about replaced
+// functions, are available from Diagnostics.
+func (s *Session) Eval(source string) (*Result, error) {
+	return s.EvalWithOutput(source, s.engine.options.Output)
+}
+
+// EvalWithOutput is like Eval but writes the output to w. A nil w captures
+// the output in Result.Output.
+func (s *Session) EvalWithOutput(source string, w io.Writer) (*Result, error) {
+	e := s.engine
+	result := frontend.ParseWithConfig(source, "", e.parserConfig(), e.lexerOptions()...)
+	e.linkUnits(result, e.hostUnits())
+
+	var replaced []*ast.FunctionDecl
+	if s.analyzer != nil && result.Program != nil {
+		replaced = s.forgetRedeclaredFunctions(s.analyzer, result.Program)
+		frontend.AnalyzeWith(result, s.analyzer, source, "")
+	}
+
+	s.diagnostics = diagnosticsFromFrontend(result)
+	for _, fn := range replaced {
+		s.diagnostics = append(s.diagnostics, Diagnostic{
+			Message:  fmt.Sprintf("Function \"%s\" replaces the declaration of an earlier evaluation", fn.Name.Value),
+			Code:     "W_FUNCTION_REDECLARED",
+			Phase:    PhaseTypeChecking,
+			Start:    fn.Name.Pos(),
+			End:      fn.Name.End(),
+			Severity: SeverityWarning,
+		})
+	}
+
+	if result.HasFatalDiagnostics() {
+		if result.SemanticAttempted || len(replaced) > 0 {
+			s.rebuildAnalyzer()
+		}
+		return nil, compileErrorFromFrontend(result)
+	}
+
+	for _, fn := range replaced {
+		s.interpreter.RemoveFunction(fn.Name.Value)
+	}
+	s.programs = append(s.programs, result.Program)
+	if s.analyzer != nil {
+		s.interpreter.SetSemanticInfo(s.analyzer.GetSemanticInfo())
+	}
+
+	output := w
+	if output == nil {
+		output = &bytes.Buffer{}
+	}
+	s.output.w = output
+	defer func() { s.output.w = io.Discard }()
+
+	s.interpreter.ClearException()
+	value := s.interpreter.Eval(result.Program)
+	if value != nil && value.Type() == "ERROR" {
+		return &Result{
+			Output:  extractOutput(output),
+			Success: false,
+		}, newRuntimeError(value)
+	}
+
+	res := &Result{
+		Output:  extractOutput(output),
+		Success: true,
+		globals: captureGlobals(s.interpreter, s.globals, s.programs...),
+	}
+	if endsWithExpression(result.Program) {
+		res.value = value
+	}
+	return res, nil
+}
+
+// ExpressionValue returns the value of the last statement of a program or
+// Session evaluation when that statement is an expression whose value is
+// otherwise unused, such as "1 + 2", formatted as PrintLn would print it. ok
+// is false when the last statement is not an expression, when the expression
+// has no value, like a procedure call, and for results of bytecode runs.
+func (r *Result) ExpressionValue() (value string, ok bool) {
+	if r == nil || r.value == nil || r.value.Type() == "NIL" {
+		return "", false
+	}
+	return r.value.String(), true
+}
+
+// ExpressionGoValue is like ExpressionValue, but converts the value to Go
+// the way GlobalValue does instead of formatting it.
+func (r *Result) ExpressionGoValue() (value interface{}, ok bool, err error) {
+	if r == nil || r.value == nil || r.value.Type() == "NIL" {
+		return nil, false, nil
+	}
+	value, err = runtime.ToGo(r.value)
+	return value, true, err
+}
+
+// endsWithExpression reports whether the last statement of program is an
+// expression statement.
+func endsWithExpression(program *ast.Program) bool {
+	if program == nil || len(program.Statements) == 0 {
+		return false
+	}
+	_, ok := program.Statements[len(program.Statements)-1].(*ast.ExpressionStatement)
+	return ok
+}
+
+// Diagnostics returns the diagnostics reported while compiling the source of
+// the last Eval.
+func (s *Session) Diagnostics() []Diagnostic {
+	return append([]Diagnostic(nil), s.diagnostics...)
+}
+
+// Snapshot saves the current global variables and declarations of the
+// session and returns an ID that Restore accepts. Mutable values such as
+// arrays and records are copied; objects are shared, so changes to their
+// fields are not undone by Restore. Class variables are not saved either.
+func (s *Session) Snapshot() StateID {
+	s.states = append(s.states, sessionState{
+		globals:  s.interpreter.SaveGlobalState(),
+		programs: append([]*ast.Program(nil), s.programs...),
+	})
+	return StateID(len(s.states) - 1)
+}
+
+// Restore returns the session to a state saved with Snapshot. Variables,
+// functions and types declared after the snapshot are forgotten. A state can
+// be restored any number of times, and restoring one state does not
+// invalidate states saved after it.
+func (s *Session) Restore(id StateID) error {
+	if id < 0 || int(id) >= len(s.states) {
+		return fmt.Errorf("unknown session state %d", id)
+	}
+	state := s.states[id]
+	s.programs = append([]*ast.Program(nil), state.programs...)
+	s.rebuildAnalyzer()
+	s.interpreter.RestoreGlobalState(state.globals)
+	if s.analyzer != nil {
+		s.interpreter.SetSemanticInfo(s.analyzer.GetSemanticInfo())
+	}
+	return nil
+}
+
+// newAnalyzer returns an analyzer with the engine's host globals, functions
+// and units predeclared, or nil when the engine does not type-check.
+func (s *Session) newAnalyzer() *semantic.Analyzer {
+	e := s.engine
+	if !e.options.TypeCheck {
+		return nil
+	}
+	builtinRegistry, overrides := e.hostBuiltins()
+	return frontend.NewAnalyzer(semantic.HintsLevelPedantic, frontend.AnalysisOptions{
+		Globals:              frontendGlobals(s.globals),
+		Functions:            overrides,
+		Builtins:             builtinRegistry,
+		ConstantFolding:      e.options.ConstantFolding,
+		IntegerOverflowCheck: e.options.IntegerOverflowCheck,
+		StrictTypes:          e.options.StrictTypes,
+		HostUnits:            frontendUnits(e.hostUnits()),
+	})
+}
+
+// rebuildAnalyzer replaces the analyzer with one that has analyzed exactly
+// the session's programs, dropping declarations of programs that failed to
+// compile or were rolled back.
+func (s *Session) rebuildAnalyzer() {
+	s.analyzer = s.newAnalyzer()
+	if s.analyzer == nil {
+		return
+	}
+	for _, program := range s.programs {
+		s.forgetRedeclaredFunctions(s.analyzer, program)
+		s.analyzer.ResetErrors()
+		_ = s.analyzer.Analyze(program)
+	}
+}
+
+// forgetRedeclaredFunctions removes the functions that program declares
+// from analyzer, when an earlier program declared them, so program replaces
+// them. It returns the declarations that replace them.
+func (s *Session) forgetRedeclaredFunctions(analyzer *semantic.Analyzer, program *ast.Program) []*ast.FunctionDecl {
+	var replaced []*ast.FunctionDecl
+	for _, stmt := range program.Statements {
+		fn, ok := stmt.(*ast.FunctionDecl)
+		if !ok || fn.ClassName != nil || fn.IsHelper || fn.Name == nil {
+			continue
+		}
+		if analyzer.ForgetFunction(fn.Name.Value) {
+			replaced = append(replaced, fn)
+		}
+	}
+	return replaced
+}
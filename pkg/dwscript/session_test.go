@@ -0,0 +1,251 @@
+package dwscript
+
+import (
+	"strings"
+	"testing"
+)
+
+func newTestSession(t *testing.T) *Session {
+	t.Helper()
+	engine, err := New()
+	if err != nil {
+		t.Fatalf("failed to create engine: %v", err)
+	}
+	session, err := engine.NewSession()
+	if err != nil {
+		t.Fatalf("NewSession failed: %v", err)
+	}
+	return session
+}
+
+// evalOutput evaluates source in the session and returns its output.
+func evalOutput(t *testing.T, session *Session, source string) string {
+	t.Helper()
+	result, err := session.EvalWithOutput(source, nil)
+	if err != nil {
+		t.Fatalf("Eval(%q) failed: %v", source, err)
+	}
+	return result.Output
+}
+
+// TestSessionPersistsDeclarations verifies that variables, functions and
+// types declared by one Eval are visible to later ones.
+func TestSessionPersistsDeclarations(t *testing.T) {
+	session := newTestSession(t)
+
+	evalOutput(t, session, `
+var X := 5;
+var Items: array of Integer;
+function Twice(a: Integer): Integer;
+begin
+  Result := a * 2;
+end;
+type TBox = class
+  Value: Integer;
+  function Get: Integer;
+end;
+function TBox.Get: Integer;
+begin
+  Result := Value;
+end;
+type TPair = record A, B: Integer; end;
+`)
+	if got := evalOutput(t, session, `
+X := Twice(X);
+var Box := TBox.Create;
+Box.Value := X;
+Items.Add(X);
+var P: TPair;
+P.A := 3;
+PrintLn(Box.Get);
+`); got != "10\n" {
+		t.Errorf("second Eval output = %q, want %q", got, "10\n")
+	}
+
+	result, err := session.EvalWithOutput(`PrintLn(Twice(X) + Box.Get + Items[0] + P.A);`, nil)
+	if err != nil {
+		t.Fatalf("third Eval failed: %v", err)
+	}
+	if result.Output != "43\n" {
+		t.Errorf("third Eval output = %q, want %q", result.Output, "43\n")
+	}
+	if x, ok, err := result.GlobalValue("X"); err != nil || !ok || x != int64(10) {
+		t.Errorf("X = %v (ok=%v, err=%v), want 10", x, ok, err)
+	}
+}
+
+// TestSessionRedeclareFunction verifies that redeclaring a function of an
+// earlier Eval replaces it with a warning.
+func TestSessionRedeclareFunction(t *testing.T) {
+	session := newTestSession(t)
+
+	evalOutput(t, session, `function F(a: Integer): Integer; begin Result := a + 1; end;`)
+	if got := evalOutput(t, session, `PrintLn(F(1));`); got != "2\n" {
+		t.Fatalf("output = %q, want %q", got, "2\n")
+	}
+
+	got := evalOutput(t, session, `
+function F(a, b: Integer): Integer; begin Result := a * b; end;
+PrintLn(F(3, 4));
+`)
+	if got != "12\n" {
+		t.Errorf("output after redeclaration = %q, want %q", got, "12\n")
+	}
+	found := false
+	for _, d := range session.Diagnostics() {
+		if d.Code == "W_FUNCTION_REDECLARED" && d.Severity == SeverityWarning && strings.Contains(d.Message, "F") {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("Diagnostics() = %v, want a W_FUNCTION_REDECLARED warning", session.Diagnostics())
+	}
+
+	if _, err := session.Eval(`PrintLn(F(1));`); err == nil {
+		t.Errorf("calling the replaced signature succeeded, want a compile error")
+	}
+}
+
+// TestSessionSnapshotRestore verifies that Restore rolls back variables,
+// including arrays and records mutated in place, and forgets declarations
+// made after the snapshot.
+func TestSessionSnapshotRestore(t *testing.T) {
+	session := newTestSession(t)
+
+	evalOutput(t, session, `
+type TPoint = record X, Y: Integer; end;
+var Count := 1;
+var Name := 'abc';
+var Items: array of Integer;
+var Alias: array of Integer;
+var P: TPoint;
+Items.Add(1);
+Alias := Items;
+P.X := 1;
+`)
+	saved := session.Snapshot()
+
+	evalOutput(t, session, `
+Count := 2;
+Name[1] := 'x';
+Items.Add(2);
+Items[0] := 10;
+P.X := 5;
+var Later := 7;
+function Extra: Integer; begin Result := 1; end;
+type TLater = class A: Integer; end;
+`)
+	if got := evalOutput(t, session, `PrintLn(IntToStr(Count) + Name + IntToStr(Alias.Length) + IntToStr(P.X));`); got != "2xbc25\n" {
+		t.Fatalf("output before restore = %q, want %q", got, "2xbc25\n")
+	}
+
+	for round := 0; round < 2; round++ {
+		if err := session.Restore(saved); err != nil {
+			t.Fatalf("Restore failed: %v", err)
+		}
+		got := evalOutput(t, session, `
+PrintLn(IntToStr(Count) + Name + IntToStr(Items.Length) + IntToStr(Items[0]) + IntToStr(P.X));
+Alias.Add(3);
+PrintLn(Items.Length);
+Count := 100;
+`)
+		if want := "1abc111\n2\n"; got != want {
+			t.Errorf("round %d: output after restore = %q, want %q", round, got, want)
+		}
+		if _, err := session.Eval(`PrintLn(Later);`); err == nil {
+			t.Errorf("round %d: variable declared after the snapshot is still visible", round)
+		}
+		if _, err := session.Eval(`PrintLn(Extra);`); err == nil {
+			t.Errorf("round %d: function declared after the snapshot is still visible", round)
+		}
+		if got := evalOutput(t, session, `type TLater = class B: String; end; PrintLn(TLater.Create.B + 'ok');`); got != "ok\n" {
+			t.Errorf("round %d: redeclared class output = %q, want %q", round, got, "ok\n")
+		}
+	}
+
+	if err := session.Restore(StateID(42)); err == nil {
+		t.Errorf("Restore of an unknown state succeeded")
+	}
+}
+
+// TestSessionCompileErrorKeepsState verifies that a script that fails to
+// compile leaves the session's declarations untouched.
+func TestSessionCompileErrorKeepsState(t *testing.T) {
+	session := newTestSession(t)
+
+	evalOutput(t, session, `var X := 1; function F: Integer; begin Result := 2; end;`)
+	if _, err := session.Eval(`var Y := 3; function F: String; begin Result := 'a'; end; X := 'oops';`); err == nil {
+		t.Fatalf("Eval with a type error succeeded")
+	}
+	if got := evalOutput(t, session, `PrintLn(X + F());`); got != "3\n" {
+		t.Errorf("output = %q, want %q", got, "3\n")
+	}
+	if _, err := session.Eval(`PrintLn(Y);`); err == nil {
+		t.Errorf("variable of the failed Eval is visible")
+	}
+}
+
+// TestSessionRuntimeErrorKeepsState verifies that the session stays usable
+// after a script raises an exception.
+func TestSessionRuntimeErrorKeepsState(t *testing.T) {
+	session := newTestSession(t)
+
+	result, err := session.Eval(`var X := 1; X := 2; raise Exception.Create('boom');`)
+	if err == nil || result.Success {
+		t.Fatalf("Eval raising an exception succeeded")
+	}
+	if got := evalOutput(t, session, `PrintLn(X);`); got != "2\n" {
+		t.Errorf("output = %q, want %q", got, "2\n")
+	}
+}
+
+// TestSessionExpressionValue verifies that the value of a trailing bare
+// expression is reported, and only for expressions that have a value.
+func TestSessionExpressionValue(t *testing.T) {
+	session := newTestSession(t)
+
+	tests := []struct {
+		source string
+		want   string
+		ok     bool
+	}{
+		{`var X := 20;`, "", false},
+		{`X + 22`, "42", true},
+		{`function F(a: Integer): Integer; begin Result := a * 2; end; F(4);`, "8", true},
+		{`'ab' + 'c'`, "abc", true},
+		{`X > 10`, "True", true},
+		{`PrintLn(X);`, "", false},
+		{`X := 5;`, "", false},
+		{`X; X := 6;`, "", false},
+	}
+	for _, tt := range tests {
+		result, err := session.EvalWithOutput(tt.source, nil)
+		if err != nil {
+			t.Fatalf("Eval(%q) failed: %v", tt.source, err)
+		}
+		got, ok := result.ExpressionValue()
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("Eval(%q).ExpressionValue() = %q, %v, want %q, %v", tt.source, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+// TestEngineIsIncomplete verifies that source cut short by its end is told
+// apart from source with a syntax error.
+func TestEngineIsIncomplete(t *testing.T) {
+	engine, err := New()
+	if err != nil {
+		t.Fatalf("failed to create engine: %v", err)
+	}
+	for source, want := range map[string]bool{
+		"begin\n  PrintLn(1);": true,
+		"PrintLn('abc":         true,
+		"1 +":                  true,
+		"PrintLn(1);":          false,
+		"1 + ;":                false,
+	} {
+		if got := engine.IsIncomplete(source); got != want {
+			t.Errorf("IsIncomplete(%q) = %v, want %v", source, got, want)
+		}
+	}
+}
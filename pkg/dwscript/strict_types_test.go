@@ -0,0 +1,37 @@
+package dwscript
+
+import (
+	"strings"
+	"testing"
+)
+
+// TestStrictTypes tests that a program relying on an untyped lambda
+// parameter compiles by default and is rejected under WithStrictTypes.
+func TestStrictTypes(t *testing.T) {
+	script := `
+var double := lambda(x) => x * 2;
+PrintLn(double(21));
+`
+	engine, err := New()
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	if _, err := engine.Compile(script); err != nil {
+		t.Fatalf("lenient Compile failed: %v", err)
+	}
+
+	strict, err := New(WithStrictTypes(true))
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	if !strict.options.GetStrictTypes() {
+		t.Error("GetStrictTypes() = false, want true")
+	}
+	_, err = strict.Compile(script)
+	if err == nil {
+		t.Fatal("expected strict Compile to fail")
+	}
+	if want := "could not determine type of parameter 'x'"; !strings.Contains(err.Error(), want) {
+		t.Errorf("error = %v, want %q", err, want)
+	}
+}
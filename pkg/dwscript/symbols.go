@@ -1,6 +1,10 @@
 package dwscript
 
 import (
+	"fmt"
+	"strconv"
+	"strings"
+
 	"github.com/cwbudde/go-dws/internal/semantic"
 	"github.com/cwbudde/go-dws/internal/types"
 	"github.com/cwbudde/go-dws/pkg/ast"
@@ -216,55 +220,65 @@ func (p *Program) TypeAt(pos token.Position) (string, bool) {
 	return getTypeForNode(p.analyzer, node)
 }
 
-// findNodeAtPosition walks the AST to find the node at the given position.
-// Returns the deepest (most specific) node that contains the position.
-func findNodeAtPosition(program *ast.Program, pos token.Position) ast.Node {
-	var result ast.Node
+// ConstantAt returns the compile-time value of the expression at the given
+// position, formatted for hover text as "const <value>", e.g. "const 86400"
+// for 24 * 60 * 60. The innermost enclosing expression with a folded value is
+// used, and the name in a const declaration reports the declared value.
+//
+// Values are only available when the program was compiled with
+// WithConstantFolding(true); otherwise this method returns ("", false).
+func (p *Program) ConstantAt(pos token.Position) (string, bool) {
+	if p.semanticInfo == nil || p.semanticInfo.ConstantCount() == 0 {
+		return "", false
+	}
 
-	// Use the AST visitor pattern to walk the tree
-	ast.Inspect(program, func(node ast.Node) bool {
-		if node == nil {
-			return false
+	path := ast.PathTo(p.ast, pos)
+	for i := len(path) - 1; i >= 0; i-- {
+		var expr ast.Expression
+		switch n := path[i].(type) {
+		case *ast.ConstDecl:
+			if i+1 < len(path) && path[i+1] == n.Name {
+				expr = n.Value
+			}
+		case ast.Expression:
+			expr = n
 		}
-
-		// Check if this node contains the position
-		nodeStart := node.Pos()
-		nodeEnd := node.End()
-
-		// Check if position is within this node's range
-		if positionInRange(pos, nodeStart, nodeEnd) {
-			// This node contains the position
-			// Keep going deeper to find the most specific node
-			result = node
-			return true
+		if expr == nil {
+			return "", false
 		}
-
-		// Position is not in this node, skip its children
-		return false
-	})
-
-	return result
+		if value, ok := p.semanticInfo.GetConstant(expr); ok {
+			return "const " + formatConstant(value), true
+		}
+	}
+	return "", false
 }
 
-// positionInRange checks if pos is within the range [start, end].
-func positionInRange(pos, start, end token.Position) bool {
-	// Check if pos is after or equal to start
-	if pos.Line < start.Line {
-		return false
-	}
-	if pos.Line == start.Line && pos.Column < start.Column {
-		return false
+// formatConstant renders a folded value the way it would be written in
+// DWScript source.
+func formatConstant(value any) string {
+	switch v := value.(type) {
+	case int64:
+		return strconv.FormatInt(v, 10)
+	case float64:
+		return strconv.FormatFloat(v, 'g', -1, 64)
+	case string:
+		return "'" + strings.ReplaceAll(v, "'", "''") + "'"
+	case bool:
+		if v {
+			return "True"
+		}
+		return "False"
 	}
+	return fmt.Sprint(value)
+}
 
-	// Check if pos is before or equal to end
-	if pos.Line > end.Line {
-		return false
-	}
-	if pos.Line == end.Line && pos.Column > end.Column {
-		return false
+// findNodeAtPosition returns the innermost node covering the given position.
+func findNodeAtPosition(program *ast.Program, pos token.Position) ast.Node {
+	path := ast.PathTo(program, pos)
+	if len(path) == 0 {
+		return nil
 	}
-
-	return true
+	return path[len(path)-1]
 }
 
 // getTypeForNode retrieves type information for a given AST node.
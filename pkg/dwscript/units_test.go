@@ -0,0 +1,284 @@
+package dwscript
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// mapUnitResolver serves unit sources from an in-memory map keyed by
+// lower-cased unit name.
+func mapUnitResolver(sources map[string]string) func(string) (string, error) {
+	return func(name string) (string, error) {
+		source, ok := sources[strings.ToLower(name)]
+		if !ok {
+			return "", fmt.Errorf("unit %q not found", name)
+		}
+		return source, nil
+	}
+}
+
+func TestWithUnitResolver(t *testing.T) {
+	sources := map[string]string{
+		"mathutils": `unit MathUtils;
+interface
+uses Strings2;
+type TPoint = record X, Y: Integer; end;
+var Counter: Integer;
+function Add(a, b: Integer): Integer;
+implementation
+function Add(a, b: Integer): Integer;
+begin
+  Result := a + b;
+end;
+initialization
+  Counter := 10;
+  PrintLn('init MathUtils');
+finalization
+  PrintLn('final MathUtils');
+end.`,
+		"strings2": `unit Strings2;
+interface
+function Shout(s: String): String;
+implementation
+function Shout(s: String): String;
+begin
+  Result := UpperCase(s) + '!';
+end;
+initialization
+  PrintLn('init Strings2');
+finalization
+  PrintLn('final Strings2');
+end.`,
+	}
+
+	for _, typeCheck := range []bool{true, false} {
+		t.Run(fmt.Sprintf("TypeCheck=%v", typeCheck), func(t *testing.T) {
+			var buf bytes.Buffer
+			engine, err := New(WithOutput(&buf), WithTypeCheck(typeCheck), WithUnitResolver(mapUnitResolver(sources)))
+			if err != nil {
+				t.Fatalf("failed to create engine: %v", err)
+			}
+
+			_, err = engine.Eval(`uses MathUtils;
+var p: TPoint;
+p.X := 3;
+PrintLn(Add(p.X, Counter));
+PrintLn(Shout('hi'));`)
+			if err != nil {
+				t.Fatalf("Eval failed: %v", err)
+			}
+
+			want := "init Strings2\ninit MathUtils\n13\nHI!\nfinal MathUtils\nfinal Strings2\n"
+			if buf.String() != want {
+				t.Errorf("output = %q, want %q", buf.String(), want)
+			}
+		})
+	}
+}
+
+func TestWithUnitResolverErrors(t *testing.T) {
+	sources := map[string]string{
+		"unita":  "unit UnitA;\ninterface\nuses UnitB;\nimplementation\nend.",
+		"unitb":  "unit UnitB;\ninterface\nuses UnitA;\nimplementation\nend.",
+		"broken": "unit Broken;\ninterface\nfunction F: Integer;\nimplementation\nfunction F: Integer;\nbegin\n  Result := ;\nend;\nend.",
+	}
+	engine, err := New(WithUnitResolver(mapUnitResolver(sources)))
+	if err != nil {
+		t.Fatalf("failed to create engine: %v", err)
+	}
+
+	tests := []struct {
+		name   string
+		source string
+		want   string
+	}{
+		{"circular", "uses UnitA;", "circular dependency detected: UnitA -> UnitB -> UnitA"},
+		{"missing", "uses Nowhere;", `unit "Nowhere" not found`},
+		{"syntax error", "uses Broken;", "parse errors in unit 'Broken'"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := engine.Compile(tt.source)
+			var compileErr *CompileError
+			if !errors.As(err, &compileErr) {
+				t.Fatalf("expected a CompileError, got %v", err)
+			}
+			if !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("error = %q, want it to contain %q", err.Error(), tt.want)
+			}
+		})
+	}
+}
+
+// TestUnitResolverDefinesAndIncludes tests that units are parsed with the
+// engine's defines and include paths.
+func TestUnitResolverDefinesAndIncludes(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "suffix.inc"), []byte("const Suffix = '!';"), 0o644); err != nil {
+		t.Fatalf("failed to write include file: %v", err)
+	}
+	sources := map[string]string{
+		"config": `unit Config;
+interface
+{$INCLUDE 'suffix.inc'}
+{$IFDEF DEBUG}
+const Mode = 'debug';
+{$ELSE}
+const Mode = 'release';
+{$ENDIF}
+implementation
+end.`,
+	}
+
+	for _, typeCheck := range []bool{true, false} {
+		t.Run(fmt.Sprintf("TypeCheck=%v", typeCheck), func(t *testing.T) {
+			var buf bytes.Buffer
+			engine, err := New(WithOutput(&buf), WithTypeCheck(typeCheck), WithDefines("DEBUG"),
+				WithIncludePaths(dir), WithUnitResolver(mapUnitResolver(sources)))
+			if err != nil {
+				t.Fatalf("failed to create engine: %v", err)
+			}
+			if _, err := engine.Eval("uses Config;\nPrintLn(Mode + Suffix);"); err != nil {
+				t.Fatalf("Eval failed: %v", err)
+			}
+			if got := strings.TrimSpace(buf.String()); got != "debug!" {
+				t.Errorf("output = %q, want %q", got, "debug!")
+			}
+		})
+	}
+}
+
+func TestUsesWithoutUnitResolver(t *testing.T) {
+	engine, err := New(WithOutput(&bytes.Buffer{}))
+	if err != nil {
+		t.Fatalf("failed to create engine: %v", err)
+	}
+	if _, err := engine.Eval("uses MathUtils;\nPrintLn('ok');"); err != nil {
+		t.Errorf("uses clauses should be ignored without a resolver, got %v", err)
+	}
+}
+
+// TestUnitCacheAcrossCompiles tests that programs compiled by one engine
+// share the units they use, and that a changed unit source is parsed again.
+func TestUnitCacheAcrossCompiles(t *testing.T) {
+	sources := map[string]string{
+		"shared": `unit Shared;
+interface
+function Answer: Integer;
+implementation
+function Answer: Integer;
+begin
+  Result := 42;
+end;
+end.`,
+	}
+	var buf bytes.Buffer
+	engine, err := New(WithOutput(&buf), WithUnitResolver(mapUnitResolver(sources)))
+	if err != nil {
+		t.Fatalf("failed to create engine: %v", err)
+	}
+
+	compileAndRun := func(source string) {
+		t.Helper()
+		program, err := engine.Compile(source)
+		if err != nil {
+			t.Fatalf("Compile failed: %v", err)
+		}
+		if _, err := engine.Run(program); err != nil {
+			t.Fatalf("Run failed: %v", err)
+		}
+	}
+
+	compileAndRun("uses Shared;\nPrintLn(Answer);")
+	compileAndRun("uses Shared;\nPrintLn(Answer() + 1);")
+	if stats := engine.unitCache.GetStats(); stats.Misses != 1 || stats.Hits != 1 {
+		t.Errorf("expected the unit to be parsed once, got %d parses and %d cache hits", stats.Misses, stats.Hits)
+	}
+
+	sources["shared"] = strings.Replace(sources["shared"], "42", "7", 1)
+	compileAndRun("uses Shared;\nPrintLn(Answer);")
+	if stats := engine.unitCache.GetStats(); stats.Misses != 2 {
+		t.Errorf("expected the changed unit to be parsed again, got %d parses", stats.Misses)
+	}
+
+	if want := "42\n43\n7\n"; buf.String() != want {
+		t.Errorf("output = %q, want %q", buf.String(), want)
+	}
+}
+
+// TestUnitCacheConcurrentCompiles compiles and runs programs using one cached
+// unit from several goroutines. Run with -race: analysis and registration must
+// not write to the unit's shared AST.
+func TestUnitCacheConcurrentCompiles(t *testing.T) {
+	const goroutines = 8
+
+	sources := map[string]string{
+		"shared": `unit Shared;
+interface
+type TIntFunc = function(x: Integer): Integer;
+type TBase = class
+  function Value: Integer; virtual;
+end;
+type TChild = class(TBase)
+  function Value: Integer; override;
+end;
+function Answer: Integer;
+function Apply(f: TIntFunc; x: Integer): Integer;
+implementation
+function TBase.Value: Integer;
+begin
+  Result := 1;
+end;
+function TChild.Value: Integer;
+begin
+  Result := 41;
+end;
+function Answer: Integer;
+var b: TBase;
+begin
+  b := TChild.Create;
+  Result := Apply(lambda(x) => x + b.Value, 0) + 1;
+end;
+function Apply(f: TIntFunc; x: Integer): Integer;
+begin
+  Result := f(x);
+end;
+end.`,
+	}
+	engine, err := New(WithUnitResolver(mapUnitResolver(sources)))
+	if err != nil {
+		t.Fatalf("failed to create engine: %v", err)
+	}
+
+	var wg sync.WaitGroup
+	outputs := make([]bytes.Buffer, goroutines)
+	errs := make([]error, goroutines)
+	for g := 0; g < goroutines; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			program, err := engine.Compile(fmt.Sprintf("uses Shared;\nPrintLn(Answer() + %d);", g))
+			if err != nil {
+				errs[g] = err
+				return
+			}
+			_, errs[g] = engine.RunWithOutput(program, &outputs[g])
+		}(g)
+	}
+	wg.Wait()
+
+	for g := 0; g < goroutines; g++ {
+		if errs[g] != nil {
+			t.Fatalf("goroutine %d failed: %v", g, errs[g])
+		}
+		if want := fmt.Sprintf("%d\n", 42+g); outputs[g].String() != want {
+			t.Errorf("goroutine %d output = %q, want %q", g, outputs[g].String(), want)
+		}
+	}
+}
@@ -0,0 +1,89 @@
+package dwscript
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+// TestWithVariantOverflow verifies Integer overflow handling of Variant
+// arithmetic under each overflow mode.
+func TestWithVariantOverflow(t *testing.T) {
+	source := `
+		var v: Variant := High(Integer);
+		var x: Variant;
+		try
+			x := v * 2;
+			PrintLn(x);
+		except
+			on E: Exception do PrintLn(E.ClassName + ': ' + E.Message);
+		end;
+		var f: Variant := 0.5;
+		PrintLn(v * f);
+	`
+
+	tests := []struct {
+		name     string
+		expected string
+		mode     VariantOverflowMode
+	}{
+		{name: "Wrap", mode: VariantOverflowWrap, expected: "-2\n4.611686018427388e+18\n"},
+		{name: "Error", mode: VariantOverflowError, expected: "EIntOverflow: Integer overflow: 9223372036854775807 * 2\n"},
+		{name: "SaturateToFloat", mode: VariantOverflowSaturateToFloat, expected: "1.8446744073709552e+19\n4.611686018427388e+18\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			engine, err := New(WithOutput(&buf), WithVariantOverflow(tt.mode))
+			if err != nil {
+				t.Fatalf("failed to create engine: %v", err)
+			}
+			if _, err := engine.Eval(source); err != nil {
+				t.Fatalf("Eval failed: %v", err)
+			}
+			if !strings.HasPrefix(buf.String(), tt.expected) {
+				t.Errorf("output = %q, want prefix %q", buf.String(), tt.expected)
+			}
+		})
+	}
+}
+
+// TestWithVariantOverflowEIntOverflow verifies that Variant overflow in
+// VariantOverflowError mode is caught by an EIntOverflow handler, like
+// checked Integer overflow.
+func TestWithVariantOverflowEIntOverflow(t *testing.T) {
+	var buf bytes.Buffer
+	engine, err := New(WithOutput(&buf), WithVariantOverflow(VariantOverflowError))
+	if err != nil {
+		t.Fatalf("failed to create engine: %v", err)
+	}
+	_, err = engine.Eval(`
+		var v: Variant := Low(Integer);
+		try
+			v := v - 1;
+		except
+			on E: EIntOverflow do PrintLn('caught ' + E.ClassName);
+			on E: Exception do PrintLn('missed ' + E.ClassName);
+		end;
+		v += 1;
+		v := High(Integer);
+		try
+			v += 1;
+		except
+			on E: EIntOverflow do PrintLn('caught ' + E.ClassName);
+		end;
+	`)
+	if err != nil {
+		t.Fatalf("Eval failed: %v", err)
+	}
+	if want := "caught EIntOverflow\ncaught EIntOverflow\n"; buf.String() != want {
+		t.Errorf("output = %q, want %q", buf.String(), want)
+	}
+}
+
+func TestWithVariantOverflowInvalidMode(t *testing.T) {
+	if _, err := New(WithVariantOverflow(VariantOverflowMode(42))); err == nil {
+		t.Fatal("expected error for invalid Variant overflow mode")
+	}
+}
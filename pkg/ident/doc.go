@@ -105,18 +105,32 @@
 //	    // Wasteful allocation
 //	}
 //
+// ## Pattern 7: Interning
+//
+// When the same identifiers are stored or looked up many times, intern them
+// once and key hot maps by InternedID instead of by string:
+//
+//	in := ident.NewInterner()
+//	fields := make(map[ident.InternedID]*Field)
+//	fields[in.ID("FValue")] = field
+//	field := fields[in.ID("fvalue")] // Found!
+//
 // # Performance Considerations
 //
 //   - Normalize() allocates a new string if the input isn't all lowercase.
 //     Use it when storing keys, not in hot comparison paths.
 //
-//   - Equal() is optimized for comparisons and doesn't allocate.
-//     Use it for one-off checks.
+//   - Equal() is optimized for comparisons and doesn't allocate. ASCII
+//     names take a fast path that beats strings.EqualFold; see
+//     BenchmarkEqualVsEqualFold. Use it for one-off checks.
 //
 //   - Compare() normalizes both strings. Cache the result if sorting repeatedly.
 //
 //   - HasPrefix()/HasSuffix() use EqualFold on substrings, avoiding allocation.
 //
+//   - Interner.ID() normalizes its argument; resolve names to IDs once and
+//     reuse the IDs in hot paths.
+//
 // # Migration from Existing Code
 //
 // Replace direct string operations with these helpers:
@@ -150,7 +164,6 @@
 // Future enhancements could include:
 //
 //   - Unicode-aware folding using golang.org/x/text/cases
-//   - Identifier interning for reduced memory usage
 //   - Full Identifier type with normalization as a type invariant
 //   - Locale-aware comparison for international identifiers
 //
@@ -31,6 +31,7 @@ package ident
 
 import (
 	"strings"
+	"unicode/utf8"
 )
 
 // Normalize returns the canonical normalized form of an identifier.
@@ -62,7 +63,11 @@ func Normalize(s string) string {
 //   - Validating identifier equality in semantic analysis
 //
 // This is more efficient than normalizing both strings and comparing,
-// as it avoids allocating new strings.
+// as it avoids allocating new strings. ASCII identifiers, by far the most
+// common case, are compared eight bytes at a time with ASCII case folding,
+// and ASCII identifiers of different lengths are rejected without folding.
+// From the first non-ASCII byte on, the comparison falls back to
+// strings.EqualFold, so results always match strings.EqualFold.
 //
 // Example:
 //
@@ -70,7 +75,71 @@ func Normalize(s string) string {
 //	    // Handle PrintLn function
 //	}
 func Equal(a, b string) bool {
-	return strings.EqualFold(a, b)
+	if len(a) != len(b) {
+		// Unicode case folding can change the encoded length (the Kelvin
+		// sign "\u212A" folds to "k"). Folded strings have the same number
+		// of runes, so a match needs a multi-byte rune in the longer one.
+		if len(a) < len(b) {
+			a, b = b, a
+		}
+		return !isASCII(a) && strings.EqualFold(a, b)
+	}
+	i := 0
+	// Compare eight bytes at a time while both words are pure ASCII.
+	for ; i+8 <= len(a); i += 8 {
+		x, y := load64(a, i), load64(b, i)
+		if (x|y)&highBits != 0 {
+			// Every byte before i is ASCII, so i starts a rune in both strings.
+			return strings.EqualFold(a[i:], b[i:])
+		}
+		if x != y && lowerASCII64(x) != lowerASCII64(y) {
+			return false
+		}
+	}
+	for ; i < len(a); i++ {
+		ca, cb := a[i], b[i]
+		if ca|cb >= utf8.RuneSelf {
+			return strings.EqualFold(a[i:], b[i:])
+		}
+		if ca == cb {
+			continue
+		}
+		// ASCII letters differ only in the 0x20 bit; any other pair of
+		// distinct bytes cannot be equal under case folding.
+		if lower := ca | 0x20; lower != cb|0x20 || lower < 'a' || lower > 'z' {
+			return false
+		}
+	}
+	return true
+}
+
+// highBits has the high bit of every byte in a 64-bit word set.
+const highBits = 0x8080808080808080
+
+// load64 returns the eight bytes of s starting at i as a little-endian word.
+func load64(s string, i int) uint64 {
+	s = s[i : i+8]
+	return uint64(s[0]) | uint64(s[1])<<8 | uint64(s[2])<<16 | uint64(s[3])<<24 |
+		uint64(s[4])<<32 | uint64(s[5])<<40 | uint64(s[6])<<48 | uint64(s[7])<<56
+}
+
+// lowerASCII64 lowercases the ASCII letters packed in w. Every byte of w
+// must be below 0x80, so the additions below never carry across bytes:
+// adding 0x3f sets a byte's high bit when it is >= 'A', adding 0x25 when it
+// is > 'Z'.
+func lowerASCII64(w uint64) uint64 {
+	upper := (w + 0x3f3f3f3f3f3f3f3f) &^ (w + 0x2525252525252525) & highBits
+	return w | upper>>2
+}
+
+// isASCII reports whether s contains only ASCII bytes.
+func isASCII(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] >= utf8.RuneSelf {
+			return false
+		}
+	}
+	return true
 }
 
 // Compare performs a case-insensitive lexicographic comparison of two strings.
@@ -2,7 +2,9 @@ package ident
 
 import (
 	"sort"
+	"strings"
 	"testing"
+	"unicode/utf8"
 )
 
 func TestNormalize(t *testing.T) {
@@ -67,11 +69,24 @@ func TestEqual(t *testing.T) {
 		{"empty vs non-empty", "", "x", false},
 		{"single char equal", "x", "X", true},
 		{"single char different", "x", "y", false},
+		{"same length, differs at end", "MyVariableA", "myvariableB", false},
+		{"letter vs punctuation", "a@", "A`", false},
+		{"non-ASCII match", "ΔValue", "δvalue", true},
+		{"non-ASCII after ASCII prefix", "myΔ", "MYδ", true},
+		{"non-ASCII mismatch", "myΔ", "myΣ", false},
+		{"Kelvin sign folds to k", "\u212Aey", "KEY", true},
+		{"long s folds to s", "\u017Fum", "SUM", true},
+		{"long mixed case match", "ExecuteCommandHandler", "executecommandHANDLER", true},
+		{"long, differs in first word", "ExecuteCommandHandler", "ExecutaCommandHandler", false},
+		{"long, non-ASCII in second word", "ExecuteCommandΔ", "executecommandδ", true},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			result := Equal(tt.a, tt.b)
+			if want := strings.EqualFold(tt.a, tt.b); result != want {
+				t.Errorf("Equal(%q, %q) = %v, strings.EqualFold = %v", tt.a, tt.b, result, want)
+			}
 			if result != tt.expected {
 				t.Errorf("Equal(%q, %q) = %v, want %v", tt.a, tt.b, result, tt.expected)
 			}
@@ -86,6 +101,26 @@ func TestEqual(t *testing.T) {
 	}
 }
 
+// TestEqualASCIIPairs checks every pair of ASCII bytes against
+// strings.EqualFold, in both the word-at-a-time loop and the tail loop.
+func TestEqualASCIIPairs(t *testing.T) {
+	for x := 0; x < utf8.RuneSelf; x++ {
+		for y := 0; y < utf8.RuneSelf; y++ {
+			// A 15-byte string puts byte 7 in a full word; an 8-byte
+			// string puts the byte after the word in the tail loop.
+			for _, prefix := range []string{"abcdefg", "ABCDEFGH"} {
+				for _, suffix := range []string{"1234567", ""} {
+					a := prefix + string(rune(x)) + suffix
+					b := strings.ToLower(prefix) + string(rune(y)) + suffix
+					if got, want := Equal(a, b), strings.EqualFold(a, b); got != want {
+						t.Fatalf("Equal(%q, %q) = %v, strings.EqualFold = %v", a, b, got, want)
+					}
+				}
+			}
+		}
+	}
+}
+
 func TestEqualTransitivity(t *testing.T) {
 	// If Equal(a, b) and Equal(b, c), then Equal(a, c) should be true
 	a := "Variable"
@@ -285,6 +320,34 @@ func BenchmarkEqual(b *testing.B) {
 	}
 }
 
+// BenchmarkEqualVsEqualFold compares Equal with strings.EqualFold on the
+// identifier pairs seen during member and overload resolution.
+func BenchmarkEqualVsEqualFold(b *testing.B) {
+	cases := []struct {
+		name string
+		a, b string
+	}{
+		{"identical", "ExecuteCommand", "ExecuteCommand"},
+		{"different case", "ExecuteCommand", "executecommand"},
+		{"different length", "ExecuteCommand", "Execute"},
+		{"different same length", "ExecuteCommand", "ExecuteRequest"},
+		{"non-ASCII", "ΔExecuteCommand", "δexecutecommand"},
+	}
+
+	for _, c := range cases {
+		b.Run(c.name+"/Equal", func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				_ = Equal(c.a, c.b)
+			}
+		})
+		b.Run(c.name+"/EqualFold", func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				_ = strings.EqualFold(c.a, c.b)
+			}
+		})
+	}
+}
+
 func BenchmarkEqualVsToLower(b *testing.B) {
 	a := "MyVariableName"
 	bLower := "myvariablename"
@@ -0,0 +1,107 @@
+package ident
+
+import "sync"
+
+// InternedID is a compact handle for an interned identifier. Equal
+// identifiers (case-insensitively) interned in the same Interner get the
+// same ID, so IDs can be used as map keys without hashing strings.
+//
+// The zero value NoID never refers to an identifier.
+type InternedID uint32
+
+// NoID is the zero InternedID; it is never assigned to an identifier.
+const NoID InternedID = 0
+
+// Interner maps identifiers to a single shared, normalized string and a
+// numeric InternedID. In large programs the same names (fields, methods,
+// types) are normalized and stored thousands of times; interning them keeps
+// one backing string per distinct identifier.
+//
+// Thread Safety: Interner is safe for concurrent use.
+//
+// Example:
+//
+//	in := ident.NewInterner()
+//	a := in.Intern("MyVariable")
+//	b := in.Intern("MYVARIABLE") // same backing string as a: "myvariable"
+//	id := in.ID("myVariable")
+//	name := in.Name(id) // "myvariable"
+type Interner struct {
+	mu    sync.RWMutex
+	ids   map[string]InternedID // normalized -> ID
+	names []string              // ID -> normalized; names[0] is unused
+}
+
+// NewInterner creates an empty Interner.
+func NewInterner() *Interner {
+	return &Interner{
+		ids:   make(map[string]InternedID),
+		names: []string{""},
+	}
+}
+
+// Intern returns the canonical normalized form of name. All identifiers
+// that are equal ignoring case return the same shared string.
+//
+// Example:
+//
+//	key := in.Intern("MyVar") // "myvar"
+func (in *Interner) Intern(name string) string {
+	return in.Name(in.ID(name))
+}
+
+// ID returns the InternedID for name, interning it if necessary.
+//
+// Example:
+//
+//	symbols := make(map[ident.InternedID]*Symbol)
+//	symbols[in.ID("MyVar")] = sym
+//	sym := symbols[in.ID("MYVAR")] // Found!
+func (in *Interner) ID(name string) InternedID {
+	normalized := Normalize(name)
+
+	in.mu.RLock()
+	id, ok := in.ids[normalized]
+	in.mu.RUnlock()
+	if ok {
+		return id
+	}
+
+	in.mu.Lock()
+	defer in.mu.Unlock()
+	// Another goroutine may have interned it since the read lock was released.
+	if id, ok := in.ids[normalized]; ok {
+		return id
+	}
+	id = InternedID(len(in.names))
+	in.names = append(in.names, normalized)
+	in.ids[normalized] = id
+	return id
+}
+
+// Lookup returns the InternedID for name without interning it.
+// Returns NoID and false if name has not been interned.
+func (in *Interner) Lookup(name string) (InternedID, bool) {
+	in.mu.RLock()
+	defer in.mu.RUnlock()
+	id, ok := in.ids[Normalize(name)]
+	return id, ok
+}
+
+// Name returns the canonical normalized string for id.
+// Returns an empty string for NoID or an ID not issued by this Interner.
+func (in *Interner) Name(id InternedID) string {
+	in.mu.RLock()
+	defer in.mu.RUnlock()
+	if int(id) >= len(in.names) {
+		return ""
+	}
+	return in.names[id]
+}
+
+// Len returns the number of distinct identifiers interned.
+func (in *Interner) Len() int {
+	in.mu.RLock()
+	defer in.mu.RUnlock()
+	return len(in.names) - 1
+}
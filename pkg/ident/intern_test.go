@@ -0,0 +1,184 @@
+package ident
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"unsafe"
+)
+
+func TestInternerIntern(t *testing.T) {
+	in := NewInterner()
+
+	a := in.Intern("MyVariable")
+	b := in.Intern("MYVARIABLE")
+	c := in.Intern("myvariable")
+
+	if a != "myvariable" {
+		t.Errorf("Intern(%q) = %q, want %q", "MyVariable", a, "myvariable")
+	}
+	if a != b || a != c {
+		t.Errorf("Intern returned different strings: %q, %q, %q", a, b, c)
+	}
+	if unsafe.StringData(a) != unsafe.StringData(b) || unsafe.StringData(a) != unsafe.StringData(c) {
+		t.Error("Intern did not return a shared backing string")
+	}
+	if in.Len() != 1 {
+		t.Errorf("Len() = %d, want 1", in.Len())
+	}
+}
+
+func TestInternerID(t *testing.T) {
+	in := NewInterner()
+
+	id1 := in.ID("Counter")
+	id2 := in.ID("Result")
+	id3 := in.ID("COUNTER")
+
+	if id1 == NoID || id2 == NoID {
+		t.Fatal("ID returned NoID")
+	}
+	if id1 != id3 {
+		t.Errorf("ID(%q) = %d, ID(%q) = %d, want equal", "Counter", id1, "COUNTER", id3)
+	}
+	if id1 == id2 {
+		t.Errorf("distinct identifiers got the same ID %d", id1)
+	}
+	if name := in.Name(id2); name != "result" {
+		t.Errorf("Name(%d) = %q, want %q", id2, name, "result")
+	}
+	if name := in.Name(NoID); name != "" {
+		t.Errorf("Name(NoID) = %q, want empty string", name)
+	}
+	if name := in.Name(InternedID(99)); name != "" {
+		t.Errorf("Name(99) = %q, want empty string", name)
+	}
+}
+
+func TestInternerLookup(t *testing.T) {
+	in := NewInterner()
+	want := in.ID("MyVar")
+
+	if id, ok := in.Lookup("MYVAR"); !ok || id != want {
+		t.Errorf("Lookup(%q) = %d, %v, want %d, true", "MYVAR", id, ok, want)
+	}
+	if id, ok := in.Lookup("Other"); ok || id != NoID {
+		t.Errorf("Lookup(%q) = %d, %v, want NoID, false", "Other", id, ok)
+	}
+	if in.Len() != 1 {
+		t.Errorf("Lookup interned a new identifier: Len() = %d, want 1", in.Len())
+	}
+}
+
+func TestInternerConcurrent(t *testing.T) {
+	in := NewInterner()
+	names := []string{"Alpha", "BETA", "gamma", "Delta", "EPSILON"}
+
+	const workers = 8
+	ids := make([][]InternedID, workers)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			for i := 0; i < 100; i++ {
+				for _, name := range names {
+					id := in.ID(name)
+					if i == 0 {
+						ids[w] = append(ids[w], id)
+					}
+				}
+			}
+		}(w)
+	}
+	wg.Wait()
+
+	if in.Len() != len(names) {
+		t.Errorf("Len() = %d, want %d", in.Len(), len(names))
+	}
+	for w := 1; w < workers; w++ {
+		for i := range names {
+			if ids[w][i] != ids[0][i] {
+				t.Errorf("worker %d got ID %d for %q, worker 0 got %d", w, ids[w][i], names[i], ids[0][i])
+			}
+		}
+	}
+}
+
+// Benchmarks
+
+// symbolTableWorkload returns declared identifiers and the references to
+// them, roughly shaped like a class-heavy program: many fields and methods,
+// each referenced several times with varying case.
+func symbolTableWorkload() (decls, refs []string) {
+	for c := 0; c < 20; c++ {
+		for m := 0; m < 25; m++ {
+			decls = append(decls, fmt.Sprintf("TClass%d_Member%d", c, m))
+		}
+	}
+	for i, name := range decls {
+		switch i % 3 {
+		case 0:
+			refs = append(refs, name, Normalize(name))
+		case 1:
+			refs = append(refs, name, name, "F"+name)
+		default:
+			refs = append(refs, Normalize(name))
+		}
+	}
+	return decls, refs
+}
+
+// BenchmarkSymbolLookup compares symbol table lookups keyed by normalized
+// strings against lookups keyed by InternedID. In both cases references are
+// resolved to their key once (as a parser or analyzer would do) and then
+// looked up repeatedly.
+func BenchmarkSymbolLookup(b *testing.B) {
+	decls, refs := symbolTableWorkload()
+
+	b.Run("map[string]", func(b *testing.B) {
+		table := make(map[string]int, len(decls))
+		for i, name := range decls {
+			table[Normalize(name)] = i
+		}
+		keys := make([]string, len(refs))
+		for i, ref := range refs {
+			keys[i] = Normalize(ref)
+		}
+
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			_ = table[keys[i%len(keys)]]
+		}
+	})
+
+	b.Run("map[InternedID]", func(b *testing.B) {
+		in := NewInterner()
+		table := make(map[InternedID]int, len(decls))
+		for i, name := range decls {
+			table[in.ID(name)] = i
+		}
+		keys := make([]InternedID, len(refs))
+		for i, ref := range refs {
+			keys[i] = in.ID(ref)
+		}
+
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			_ = table[keys[i%len(keys)]]
+		}
+	})
+}
+
+func BenchmarkInternerID(b *testing.B) {
+	_, refs := symbolTableWorkload()
+	in := NewInterner()
+	for _, ref := range refs {
+		in.ID(ref)
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = in.ID(refs[i%len(refs)])
+	}
+}
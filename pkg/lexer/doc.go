@@ -0,0 +1,37 @@
+// Package lexer provides the public, streaming tokenizer for DWScript.
+//
+// It exposes the lexer used by the go-dws parser for tools that work on the
+// token level, such as syntax highlighters, formatters and editors.
+//
+// # Streaming
+//
+// A Lexer produces one token per call to NextToken and ends with an EOF token:
+//
+//	l := lexer.New("var x := 42; // answer")
+//	for tok := l.NextToken(); tok.Type != token.EOF; tok = l.NextToken() {
+//		fmt.Printf("%s %d:%d-%d:%d\n", tok.Type,
+//			tok.Pos.Line, tok.Pos.Column, tok.End().Line, tok.End().Column)
+//	}
+//
+// TokenizeAll collects the whole stream at once.
+//
+// # Spans
+//
+// Every token reports its start position in Pos and the position just after
+// it through End. Source returns the exact source text of the token, so
+// input[tok.Pos.Offset:tok.End().Offset] == tok.Source() for tokens of the
+// top-level input.
+//
+// # Trivia
+//
+// Comments and whitespace are skipped by default. WithPreserveComments and
+// WithPreserveWhitespace turn them into COMMENT and WHITESPACE tokens; with
+// both enabled the token spans cover every byte of the input except compiler
+// directives such as {$DEFINE X}, which the lexer always consumes.
+//
+// # Error Recovery
+//
+// Illegal characters, invalid UTF-8 and unterminated comments do not stop the
+// lexer. Each produces an ILLEGAL token covering the offending text, is
+// recorded in Errors, and scanning continues with the next character.
+package lexer
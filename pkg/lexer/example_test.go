@@ -0,0 +1,24 @@
+package lexer_test
+
+import (
+	"fmt"
+
+	"github.com/cwbudde/go-dws/pkg/lexer"
+)
+
+func ExampleTokenizeAll() {
+	src := "x := 42; // answer"
+	for _, tok := range lexer.TokenizeAll(src, lexer.WithPreserveComments(true), lexer.WithPreserveWhitespace(true)) {
+		end := tok.End()
+		fmt.Printf("%-10s %q %d-%d\n", tok.Type, tok.Source(), tok.Pos.Column, end.Column)
+	}
+	// Output:
+	// IDENT      "x" 1-2
+	// WHITESPACE " " 2-3
+	// ASSIGN     ":=" 3-5
+	// WHITESPACE " " 5-6
+	// INT        "42" 6-8
+	// SEMICOLON  ";" 8-9
+	// WHITESPACE " " 9-10
+	// COMMENT    "// answer" 10-19
+}
@@ -0,0 +1,73 @@
+package lexer
+
+import (
+	internal "github.com/cwbudde/go-dws/internal/lexer"
+	"github.com/cwbudde/go-dws/pkg/token"
+)
+
+// Lexer is a streaming tokenizer for DWScript source code.
+// Use New to create one and NextToken to read tokens until EOF.
+type Lexer = internal.Lexer
+
+// Option configures a Lexer. Options are passed to New and TokenizeAll.
+type Option = internal.LexerOption
+
+// Error describes a problem found while tokenizing, such as an illegal
+// character or an unterminated comment.
+type Error = internal.LexerError
+
+// IncludeResolver loads the file named by an {$INCLUDE} directive.
+type IncludeResolver = internal.IncludeResolver
+
+// New creates a Lexer for input. A leading UTF-8 byte order mark is skipped.
+func New(input string, opts ...Option) *Lexer {
+	return internal.New(input, opts...)
+}
+
+// WithPreserveComments makes the lexer return COMMENT tokens instead of
+// skipping comments.
+func WithPreserveComments(preserve bool) Option {
+	return internal.WithPreserveComments(preserve)
+}
+
+// WithPreserveWhitespace makes the lexer return a WHITESPACE token for each
+// run of spaces, tabs and line breaks instead of skipping them.
+func WithPreserveWhitespace(preserve bool) Option {
+	return internal.WithPreserveWhitespace(preserve)
+}
+
+// WithSourceName sets the Source reported in token positions of the
+// top-level input, typically its file name.
+func WithSourceName(name string) Option {
+	return internal.WithSourceName(name)
+}
+
+// WithDefines predefines conditional compilation symbols, as if each had
+// been declared with {$DEFINE name} before the first line.
+func WithDefines(names ...string) Option {
+	return internal.WithDefines(names...)
+}
+
+// WithIncludeResolver sets how {$INCLUDE} directives load their files.
+// Without a resolver, include directives are ignored.
+func WithIncludeResolver(resolver IncludeResolver) Option {
+	return internal.WithIncludeResolver(resolver)
+}
+
+// NewFileIncludeResolver returns an IncludeResolver that reads included files
+// relative to the including file, baseDir and then searchPaths.
+func NewFileIncludeResolver(baseDir string, searchPaths ...string) IncludeResolver {
+	return internal.NewFileIncludeResolver(baseDir, searchPaths...)
+}
+
+// TokenizeAll returns every token of src in order, without the final EOF
+// token. Errors are reported as ILLEGAL tokens in the result; use New and
+// Errors when the messages are needed.
+func TokenizeAll(src string, opts ...Option) []token.Token {
+	l := internal.New(src, opts...)
+	tokens := make([]token.Token, 0, len(src)/6+1) // rough token density estimate
+	for tok := l.NextToken(); tok.Type != token.EOF; tok = l.NextToken() {
+		tokens = append(tokens, tok)
+	}
+	return tokens
+}
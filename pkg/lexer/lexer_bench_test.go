@@ -0,0 +1,58 @@
+package lexer
+
+import (
+	"strings"
+	"testing"
+)
+
+// largeSource builds a source file of roughly 1 MB from a realistic unit.
+func largeSource() string {
+	unit := `// Shapes and their areas
+type
+  TShape = class
+  private
+    FName: String;
+  public
+    constructor Create(const name: String);
+    function Area: Float; virtual; abstract;
+    property Name: String read FName;
+  end;
+
+{ Circle with a radius }
+function CircleArea(r: Float): Float;
+begin
+  Result := Pi * r * r;
+end;
+
+var total := 0.0;
+for var i := 1 to 100 do begin
+  if (i mod 3 = 0) and not (i = 99) then
+    total += CircleArea(i * 0.5)
+  else
+    total -= $FF / 2;
+  PrintLn(Format('%d: %.2f', [i, total]) + #13#10);
+end;
+`
+	return strings.Repeat(unit, 1<<20/len(unit))
+}
+
+// BenchmarkTokenizeAllLargeFile guards tokenizer throughput on a large file.
+func BenchmarkTokenizeAllLargeFile(b *testing.B) {
+	src := largeSource()
+	b.SetBytes(int64(len(src)))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		TokenizeAll(src)
+	}
+}
+
+// BenchmarkTokenizeAllLargeFileTrivia measures the cost of emitting comment
+// and whitespace tokens on the same input.
+func BenchmarkTokenizeAllLargeFileTrivia(b *testing.B) {
+	src := largeSource()
+	b.SetBytes(int64(len(src)))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		TokenizeAll(src, WithPreserveComments(true), WithPreserveWhitespace(true))
+	}
+}
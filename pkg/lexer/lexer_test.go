@@ -0,0 +1,132 @@
+package lexer
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/cwbudde/go-dws/pkg/token"
+)
+
+const sampleProgram = `// Sample
+program Demo;
+{ block } (* paren *) /* c-style */
+var s: String := 'it''s'#13#10'ok';
+var d := 'Δ€' + "x";
+begin
+  if s <> '' then
+    PrintLn(Length(s) * $FF + 1.5e3);
+end.
+`
+
+// TestTokenizeAllSpansCoverInput tests that with comments and whitespace
+// preserved, token spans are contiguous and cover the whole input.
+func TestTokenizeAllSpansCoverInput(t *testing.T) {
+	tokens := TokenizeAll(sampleProgram, WithPreserveComments(true), WithPreserveWhitespace(true))
+
+	var sb strings.Builder
+	offset := 0
+	for _, tok := range tokens {
+		if tok.Pos.Offset != offset {
+			t.Fatalf("token %s starts at offset %d, want %d", tok, tok.Pos.Offset, offset)
+		}
+		end := tok.End()
+		if got := sampleProgram[tok.Pos.Offset:end.Offset]; got != tok.Source() {
+			t.Errorf("token %s spans %q, want %q", tok, got, tok.Source())
+		}
+		sb.WriteString(tok.Source())
+		offset = end.Offset
+	}
+	if sb.String() != sampleProgram {
+		t.Errorf("reassembled source differs from input:\n%q\n%q", sb.String(), sampleProgram)
+	}
+}
+
+// TestTokenEndPositions tests end line and column of single- and
+// multi-line tokens.
+func TestTokenEndPositions(t *testing.T) {
+	tokens := TokenizeAll("x := 'Δ€';\n{ a\n  b }", WithPreserveComments(true))
+	if len(tokens) != 5 {
+		t.Fatalf("expected 5 tokens, got %d: %v", len(tokens), tokens)
+	}
+
+	tests := []struct {
+		tok                token.TokenType
+		endLine, endColumn int
+	}{
+		{token.IDENT, 1, 2},
+		{token.ASSIGN, 1, 5},
+		{token.STRING, 1, 10},
+		{token.SEMICOLON, 1, 11},
+		{token.COMMENT, 3, 6},
+	}
+	for i, tt := range tests {
+		tok := tokens[i]
+		end := tok.End()
+		if tok.Type != tt.tok || end.Line != tt.endLine || end.Column != tt.endColumn {
+			t.Errorf("token %d: got %s ending at %d:%d, want %s ending at %d:%d",
+				i, tok, end.Line, end.Column, tt.tok, tt.endLine, tt.endColumn)
+		}
+	}
+}
+
+// TestWhitespaceTokens tests that whitespace runs become single tokens and
+// are only emitted on request.
+func TestWhitespaceTokens(t *testing.T) {
+	input := "a \t\r\n  b"
+
+	tokens := TokenizeAll(input, WithPreserveWhitespace(true))
+	var types []token.TokenType
+	for _, tok := range tokens {
+		types = append(types, tok.Type)
+	}
+	want := []token.TokenType{token.IDENT, token.WHITESPACE, token.IDENT}
+	if len(types) != len(want) {
+		t.Fatalf("got token types %v, want %v", types, want)
+	}
+	for i := range want {
+		if types[i] != want[i] {
+			t.Errorf("token %d: got %s, want %s", i, types[i], want[i])
+		}
+	}
+	if ws := tokens[1]; ws.Literal != " \t\r\n  " || !ws.Type.IsTrivia() {
+		t.Errorf("unexpected whitespace token %s", ws)
+	}
+	if b := tokens[2]; b.Pos.Line != 2 || b.Pos.Column != 3 {
+		t.Errorf("token after whitespace at %d:%d, want 2:3", b.Pos.Line, b.Pos.Column)
+	}
+
+	if got := len(TokenizeAll(input)); got != 2 {
+		t.Errorf("expected whitespace to be skipped by default, got %d tokens", got)
+	}
+}
+
+// TestIllegalCharacterRecovery tests that the lexer reports illegal input
+// as ILLEGAL tokens and keeps tokenizing afterwards.
+func TestIllegalCharacterRecovery(t *testing.T) {
+	input := "a \x80 ` b; { open"
+	l := New(input, WithPreserveComments(true))
+
+	var tokens []token.Token
+	for tok := l.NextToken(); tok.Type != token.EOF; tok = l.NextToken() {
+		tokens = append(tokens, tok)
+	}
+
+	want := []token.TokenType{token.IDENT, token.ILLEGAL, token.ILLEGAL, token.IDENT, token.SEMICOLON, token.ILLEGAL}
+	if len(tokens) != len(want) {
+		t.Fatalf("got %d tokens %v, want %d", len(tokens), tokens, len(want))
+	}
+	for i, tok := range tokens {
+		if tok.Type != want[i] {
+			t.Errorf("token %d: got %s, want %s", i, tok.Type, want[i])
+		}
+		if got := input[tok.Pos.Offset:tok.End().Offset]; got != tok.Source() {
+			t.Errorf("token %d spans %q, want %q", i, got, tok.Source())
+		}
+	}
+	if src := tokens[5].Source(); src != "{ open" {
+		t.Errorf("unterminated comment token covers %q, want %q", src, "{ open")
+	}
+	if got := len(l.Errors()); got != 3 {
+		t.Errorf("expected 3 errors, got %d: %v", got, l.Errors())
+	}
+}
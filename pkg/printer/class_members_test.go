@@ -47,7 +47,7 @@ func TestPrintParameter(t *testing.T) {
 				},
 				ReturnType: &ast.TypeAnnotation{Name: "Integer"},
 			},
-			contains: "constx:Integer",
+			contains: "const x:Integer",
 		},
 		{
 			name: "function with var parameter",
@@ -79,7 +79,7 @@ func TestPrintParameter(t *testing.T) {
 				},
 				ReturnType: &ast.TypeAnnotation{Name: "Boolean"},
 			},
-			contains: "vary:String",
+			contains: "var y:String",
 		},
 		{
 			name: "function with default value",
@@ -487,7 +487,7 @@ func TestPrintRecordPropertyDecl(t *testing.T) {
 				ReadField:  "fValue",
 				WriteField: "fValue",
 			},
-			expected: "propertyValue:IntegerreadfValuewritefValue",
+			expected: "property Value:Integer read fValue write fValue",
 		},
 	}
 
@@ -40,7 +40,7 @@ func TestPrintEnumDecl(t *testing.T) {
 					{Name: "Blue"},
 				},
 			},
-			expected: "typeTColor=(Red,Green,Blue)",
+			expected: "type TColor=(Red,Green,Blue)",
 		},
 		{
 			name: "enum with explicit values",
@@ -62,7 +62,7 @@ func TestPrintEnumDecl(t *testing.T) {
 					{Name: "Stopped", Value: intPtr(2)},
 				},
 			},
-			expected: "typeTStatus=(Idle=0,Running=1,Stopped=2)",
+			expected: "type TStatus=(Idle=0,Running=1,Stopped=2)",
 		},
 	}
 
@@ -105,7 +105,7 @@ func TestPrintArrayDecl(t *testing.T) {
 					ElementType: &ast.TypeAnnotation{Name: "Integer"},
 				},
 			},
-			contains: "typeTIntArray=array",
+			contains: "type TIntArray=array",
 		},
 	}
 
@@ -146,7 +146,7 @@ func TestPrintSetDecl(t *testing.T) {
 				},
 				ElementType: &ast.TypeAnnotation{Name: "Char"},
 			},
-			expected: "typeTCharSet=setofChar",
+			expected: "type TCharSet=set of Char",
 		},
 	}
 
@@ -313,7 +313,7 @@ func TestPrintTypeDeclaration(t *testing.T) {
 				},
 				AliasedType: &ast.TypeAnnotation{Name: "Integer"},
 			},
-			expected: "typeTMyInt=Integer",
+			expected: "type TMyInt=Integer",
 		},
 		{
 			name: "subrange type",
@@ -347,7 +347,7 @@ func TestPrintTypeDeclaration(t *testing.T) {
 					Value: 9,
 				},
 			},
-			expected: "typeTDigit=0..9",
+			expected: "type TDigit=0..9",
 		},
 	}
 
@@ -467,7 +467,7 @@ func TestPrintArrayTypeAnnotation(t *testing.T) {
 			node: &ast.ArrayTypeAnnotation{
 				ElementType: &ast.TypeAnnotation{Name: "Integer"},
 			},
-			expected: "arrayofInteger",
+			expected: "array of Integer",
 		},
 		{
 			name: "static array",
@@ -490,7 +490,7 @@ func TestPrintArrayTypeAnnotation(t *testing.T) {
 				},
 				ElementType: &ast.TypeAnnotation{Name: "String"},
 			},
-			expected: "array[0..9]ofString",
+			expected: "array[0..9]of String",
 		},
 	}
 
@@ -520,7 +520,7 @@ func TestPrintArrayTypeNode(t *testing.T) {
 			node: &ast.ArrayTypeNode{
 				ElementType: &ast.TypeAnnotation{Name: "Float"},
 			},
-			expected: "arrayofFloat",
+			expected: "array of Float",
 		},
 		{
 			name: "indexed array type",
@@ -528,7 +528,7 @@ func TestPrintArrayTypeNode(t *testing.T) {
 				IndexType:   &ast.TypeAnnotation{Name: "TColor"},
 				ElementType: &ast.TypeAnnotation{Name: "String"},
 			},
-			expected: "array[TColor]ofString",
+			expected: "array[TColor]of String",
 		},
 	}
 
@@ -558,7 +558,7 @@ func TestPrintSetTypeNode(t *testing.T) {
 			node: &ast.SetTypeNode{
 				ElementType: &ast.TypeAnnotation{Name: "Byte"},
 			},
-			expected: "setofByte",
+			expected: "set of Byte",
 		},
 	}
 
@@ -588,7 +588,7 @@ func TestPrintClassOfTypeNode(t *testing.T) {
 			node: &ast.ClassOfTypeNode{
 				ClassType: &ast.TypeAnnotation{Name: "TComponent"},
 			},
-			expected: "classofTComponent",
+			expected: "class of TComponent",
 		},
 	}
 
@@ -659,7 +659,7 @@ func TestPrintFunctionPointerTypeNode(t *testing.T) {
 				ReturnType: &ast.TypeAnnotation{Name: "Boolean"},
 				OfObject:   true,
 			},
-			expected: "function:Booleanof object",
+			expected: "function:Boolean of object",
 		},
 	}
 
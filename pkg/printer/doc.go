@@ -11,6 +11,7 @@
 //   - DWScript syntax: Valid DWScript source code (default)
 //   - Tree format: Hierarchical AST structure visualization
 //   - JSON format: Machine-readable JSON representation
+//   - DOT format: GraphViz digraph for visualizing the tree
 //   - Compact format: Minimal whitespace, single-line output
 //
 // # Basic Usage
@@ -51,16 +52,36 @@
 // FormatJSON produces machine-readable JSON:
 //
 //	{
+//	  "astVersion": 1,
 //	  "type": "Program",
 //	  "statements": [
 //	    {
 //	      "type": "ClassDecl",
-//	      "name": "TMyClass",
+//	      "name": {"type": "Identifier", "value": "TMyClass", ...},
 //	      ...
 //	    }
 //	  ]
 //	}
 //
+// The schema is defined by ast.NodeToJSON and versioned through the
+// "astVersion" field. ast.UnmarshalJSON loads it back into an *ast.Program,
+// so JSON printed with IncludePositions round-trips (comments are not
+// serialized).
+//
+// FormatDOT produces a GraphViz digraph with one box per node, labeled with
+// the node type and its key literal, and edges labeled with the field name:
+//
+//	digraph AST {
+//	  node [shape=box, fontname="Helvetica"];
+//	  n0 [label="Program"];
+//	  n1 [label="ExpressionStatement"];
+//	  ...
+//	  n0 -> n1 [label="Statements[0]"];
+//	}
+//
+// Children are enumerated with the generated ast.Walk. Set Options.MaxDepth
+// to cut off deep subtrees.
+//
 // # Styles
 //
 // StyleCompact minimizes whitespace:
@@ -76,6 +97,18 @@
 //
 // StyleMultiline ensures every statement is on a new line with proper indentation.
 //
+// # Source Maps
+//
+// PrintWithMap returns the formatted output together with a SourceMap that
+// translates positions in the output back to the original node positions:
+//
+//	output, sm := printer.PrintWithMap(program)
+//	if pos, ok := sm.Original(token.Position{Line: 3, Column: 5}); ok {
+//		fmt.Printf("originally at %d:%d\n", pos.Line, pos.Column)
+//	}
+//
+// Each position resolves to the innermost node printed there.
+//
 // # Design Rationale
 //
 // Prior to this package, AST nodes contained extensive String() methods (some 50+ lines)
@@ -88,7 +121,7 @@
 //   - Multiple output formats are possible without touching AST
 //   - Formatting changes don't require AST modifications
 //   - Better separation of concerns (structure vs. presentation)
-//   - Easier to add new formats (e.g., GraphViz, XML)
+//   - Easier to add new formats (e.g., GraphViz DOT, XML)
 //
 // AST nodes retain minimal String() methods for debugging (e.g., "ClassDecl(TMyClass)"),
 // while the printer handles all production-quality formatting.
@@ -0,0 +1,226 @@
+package printer
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/cwbudde/go-dws/pkg/ast"
+)
+
+// This file contains the GraphViz DOT format printer.
+// Children are enumerated with the generated ast.Walk so that new node types
+// and fields show up in the graph without changes here; reflection is only
+// used to recover the field name for each edge label.
+
+// DOT Format Printer
+// ============================================================================
+
+// printDOT prints the node as a GraphViz DOT digraph.
+func (p *Printer) printDOT(node ast.Node) {
+	p.write("digraph AST {")
+	p.newline()
+	p.incIndent()
+	p.writeIndent()
+	p.write("node [shape=box, fontname=\"Helvetica\"];")
+	p.newline()
+
+	if !isNilNode(node) {
+		d := &dotWriter{p: p}
+		d.node(node, 0)
+	}
+
+	p.decIndent()
+	p.write("}")
+	p.newline()
+}
+
+// dotWriter assigns node IDs while emitting a DOT graph.
+type dotWriter struct {
+	p      *Printer
+	nextID int
+}
+
+// node emits node and, unless MaxDepth is reached, its subtree.
+// It returns the ID assigned to node.
+func (d *dotWriter) node(node ast.Node, depth int) string {
+	id := fmt.Sprintf("n%d", d.nextID)
+	d.nextID++
+
+	d.p.writeIndent()
+	d.p.write(fmt.Sprintf("%s [label=\"%s\"];", id, dotEscape(d.label(node))))
+	d.p.newline()
+
+	children := dotChildren(node)
+	if len(children) == 0 {
+		return id
+	}
+
+	if d.p.opts.MaxDepth > 0 && depth >= d.p.opts.MaxDepth {
+		// Mark the cut so truncated subtrees are not mistaken for leaves.
+		moreID := fmt.Sprintf("n%d", d.nextID)
+		d.nextID++
+		d.p.writeIndent()
+		d.p.write(fmt.Sprintf("%s [label=\"...\", shape=plaintext];", moreID))
+		d.p.newline()
+		d.edge(id, moreID, "")
+		return id
+	}
+
+	fields := dotFieldNames(node)
+	for _, child := range children {
+		childID := d.node(child, depth+1)
+		d.edge(id, childID, fields[child])
+	}
+	return id
+}
+
+// edge emits a parent -> child edge with an optional field label.
+func (d *dotWriter) edge(from, to, label string) {
+	d.p.writeIndent()
+	if label != "" {
+		d.p.write(fmt.Sprintf("%s -> %s [label=\"%s\"];", from, to, dotEscape(label)))
+	} else {
+		d.p.write(fmt.Sprintf("%s -> %s;", from, to))
+	}
+	d.p.newline()
+}
+
+// label returns the box label for node: its type name and, where the node
+// has one, its key literal (identifier name, literal value or operator).
+func (d *dotWriter) label(node ast.Node) string {
+	typeName := reflect.TypeOf(node).Elem().Name()
+
+	var key string
+	switch n := node.(type) {
+	case *ast.Identifier:
+		key = n.Value
+	case *ast.IntegerLiteral, *ast.FloatLiteral, *ast.BooleanLiteral, *ast.CharLiteral:
+		key = n.TokenLiteral()
+	case *ast.StringLiteral:
+		key = fmt.Sprintf("%q", n.Value)
+	case *ast.BinaryExpression:
+		key = n.Operator
+	case *ast.UnaryExpression:
+		key = n.Operator
+	case *ast.FunctionDecl:
+		key = dotName(n.Name)
+	case *ast.ClassDecl:
+		key = dotName(n.Name)
+	case *ast.FieldDecl:
+		key = dotName(n.Name)
+	}
+
+	label := typeName
+	if key != "" {
+		label += "\n" + key
+	}
+	if d.p.opts.IncludePositions {
+		if pos := node.Pos(); pos.Line > 0 {
+			label += "\n[" + positionLabel(pos) + "]"
+		}
+	}
+	return label
+}
+
+// dotName returns the name of a declaration, or "" if it has none.
+func dotName(name *ast.Identifier) string {
+	if name == nil {
+		return ""
+	}
+	return name.Value
+}
+
+// dotChildren returns the direct children of node in ast.Walk order.
+func dotChildren(node ast.Node) []ast.Node {
+	c := &dotChildCollector{}
+	ast.Walk(c, node)
+	return c.children
+}
+
+// dotChildCollector is an ast.Visitor that records the direct children of
+// the node it is walked from without descending further.
+type dotChildCollector struct {
+	children []ast.Node
+	entered  bool
+}
+
+func (c *dotChildCollector) Visit(node ast.Node) ast.Visitor {
+	if !c.entered {
+		c.entered = true
+		return c
+	}
+	if !isNilNode(node) {
+		c.children = append(c.children, node)
+	}
+	return nil
+}
+
+// dotFieldNames maps each child node of node to the struct field holding it,
+// e.g. "Left" or "Statements[2]". Fields of embedded structs are included.
+func dotFieldNames(node ast.Node) map[ast.Node]string {
+	names := make(map[ast.Node]string)
+	v := reflect.ValueOf(node)
+	if v.Kind() != reflect.Ptr || v.Elem().Kind() != reflect.Struct {
+		return names
+	}
+
+	var walk func(v reflect.Value)
+	walk = func(v reflect.Value) {
+		t := v.Type()
+		for i := 0; i < t.NumField(); i++ {
+			f := t.Field(i)
+			if !f.IsExported() {
+				continue
+			}
+			fv := v.Field(i)
+			if f.Anonymous && fv.Kind() == reflect.Struct {
+				walk(fv)
+				continue
+			}
+			switch fv.Kind() {
+			case reflect.Ptr, reflect.Interface:
+				if child, ok := dotNodeOf(fv); ok {
+					names[child] = f.Name
+				}
+			case reflect.Slice:
+				for j := 0; j < fv.Len(); j++ {
+					if child, ok := dotNodeOf(fv.Index(j)); ok {
+						names[child] = fmt.Sprintf("%s[%d]", f.Name, j)
+					}
+				}
+			}
+		}
+	}
+	walk(v.Elem())
+	return names
+}
+
+// dotNodeOf returns the node held by v if v is a non-nil node pointer.
+func dotNodeOf(v reflect.Value) (ast.Node, bool) {
+	if v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return nil, false
+		}
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Ptr || v.IsNil() || !v.CanInterface() {
+		return nil, false
+	}
+	node, ok := v.Interface().(ast.Node)
+	return node, ok
+}
+
+// isNilNode reports whether node is nil or a typed nil pointer.
+func isNilNode(node ast.Node) bool {
+	if node == nil {
+		return true
+	}
+	v := reflect.ValueOf(node)
+	return v.Kind() == reflect.Ptr && v.IsNil()
+}
+
+// dotEscape escapes s for use inside a double-quoted DOT string.
+func dotEscape(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(s)
+}
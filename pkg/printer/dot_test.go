@@ -0,0 +1,146 @@
+package printer_test
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/cwbudde/go-dws/internal/lexer"
+	"github.com/cwbudde/go-dws/internal/parser"
+	"github.com/cwbudde/go-dws/pkg/printer"
+)
+
+var (
+	dotNodeLine = regexp.MustCompile(`^(n\d+) \[label="(?:[^"\\]|\\.)*"(?:, shape=plaintext)?\];$`)
+	dotEdgeLine = regexp.MustCompile(`^(n\d+) -> (n\d+)(?: \[label="(?:[^"\\]|\\.)*"\])?;$`)
+)
+
+// checkDOT verifies that out is a well-formed DOT digraph as emitted by the
+// printer: balanced braces, one statement per line, every edge endpoint
+// defined as a node, and every node but the root having exactly one parent.
+// It returns the number of nodes.
+func checkDOT(t *testing.T, out string) int {
+	t.Helper()
+
+	if strings.Count(out, "{") != strings.Count(out, "}") {
+		t.Fatalf("unbalanced braces in DOT output:\n%s", out)
+	}
+
+	lines := strings.Split(strings.TrimSpace(out), "\n")
+	if len(lines) < 2 || lines[0] != "digraph AST {" || lines[len(lines)-1] != "}" {
+		t.Fatalf("output is not a digraph block:\n%s", out)
+	}
+
+	defined := map[string]bool{}
+	parents := map[string]int{}
+	var edges [][2]string
+	for _, line := range lines[2 : len(lines)-1] {
+		line = strings.TrimSpace(line)
+		if m := dotNodeLine.FindStringSubmatch(line); m != nil {
+			if defined[m[1]] {
+				t.Errorf("node %s defined twice", m[1])
+			}
+			defined[m[1]] = true
+			continue
+		}
+		if m := dotEdgeLine.FindStringSubmatch(line); m != nil {
+			edges = append(edges, [2]string{m[1], m[2]})
+			parents[m[2]]++
+			continue
+		}
+		t.Errorf("unexpected DOT statement: %q", line)
+	}
+
+	for _, e := range edges {
+		if !defined[e[0]] || !defined[e[1]] {
+			t.Errorf("edge %s -> %s references an undefined node", e[0], e[1])
+		}
+	}
+	for id := range defined {
+		if id != "n0" && parents[id] != 1 {
+			t.Errorf("node %s has %d parents, want 1", id, parents[id])
+		}
+	}
+	return len(defined)
+}
+
+func printDOT(t *testing.T, source string, opts printer.Options) string {
+	t.Helper()
+	p := parser.New(lexer.New(source))
+	program := p.ParseProgram()
+	if len(p.Errors()) > 0 {
+		t.Skipf("source does not parse: %v", p.Errors()[0])
+	}
+	return printer.New(opts).Print(program)
+}
+
+func TestDOTOutput(t *testing.T) {
+	source := `
+type TPoint = class
+  FX: Integer;
+  function Len: Float;
+end;
+function TPoint.Len: Float;
+begin
+  Result := Sqrt(FX * FX);
+end;
+var s := 'say "hi"\now';
+if s <> '' then PrintLn(-1 + 2);`
+
+	out := printDOT(t, source, printer.DOTOptions())
+	checkDOT(t, out)
+
+	for _, want := range []string{
+		`[label="ClassDecl\nTPoint"]`,
+		`[label="BinaryExpression\n*"]`,
+		`[label="UnaryExpression\n-"]`,
+		`[label="StringLiteral\n\"say \\\"hi\\\"\\\\now\""]`,
+		`[label="Statements[0]"]`,
+		`[label="Condition"]`,
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("DOT output missing %s\n%s", want, out)
+		}
+	}
+}
+
+func TestDOTMaxDepth(t *testing.T) {
+	source := "PrintLn(1 + 2 * (3 - 4));"
+
+	full := checkDOT(t, printDOT(t, source, printer.DOTOptions()))
+
+	opts := printer.DOTOptions()
+	opts.MaxDepth = 2
+	out := printDOT(t, source, opts)
+	limited := checkDOT(t, out)
+
+	if limited >= full {
+		t.Errorf("MaxDepth=2 produced %d nodes, want fewer than %d", limited, full)
+	}
+	if !strings.Contains(out, `[label="...", shape=plaintext]`) {
+		t.Errorf("expected truncation marker in output:\n%s", out)
+	}
+}
+
+// TestDOTFixtures checks that every script in testdata produces valid DOT.
+func TestDOTFixtures(t *testing.T) {
+	files, err := filepath.Glob(filepath.Join("..", "..", "testdata", "*.dws"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) == 0 {
+		t.Skip("no fixtures found")
+	}
+
+	for _, file := range files {
+		t.Run(filepath.Base(file), func(t *testing.T) {
+			source, err := os.ReadFile(file)
+			if err != nil {
+				t.Fatal(err)
+			}
+			checkDOT(t, printDOT(t, string(source), printer.DOTOptions()))
+		})
+	}
+}
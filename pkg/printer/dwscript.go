@@ -2,6 +2,8 @@ package printer
 
 import (
 	"fmt"
+	"strconv"
+	"strings"
 
 	"github.com/cwbudde/go-dws/pkg/ast"
 	"github.com/cwbudde/go-dws/pkg/token"
@@ -329,7 +331,7 @@ func (p *Printer) printReturnStatement(rs *ast.ReturnStatement) {
 func (p *Printer) printIfStatement(is *ast.IfStatement) {
 	p.write("if")
 	p.space()
-	p.printDWScript(is.Condition)
+	p.printCondition(is.Condition, is.InlineVar)
 	p.space()
 	p.write("then")
 
@@ -370,6 +372,16 @@ func (p *Printer) printIfStatement(is *ast.IfStatement) {
 	}
 }
 
+// printCondition prints the condition of an if or while statement, which is
+// the inline variable declaration when the statement has one.
+func (p *Printer) printCondition(condition ast.Expression, inlineVar *ast.VarDeclStatement) {
+	if inlineVar != nil {
+		p.printVarDeclStatement(inlineVar)
+		return
+	}
+	p.printDWScript(condition)
+}
+
 func (p *Printer) printIfExpression(ie *ast.IfExpression) {
 	p.write("if")
 	p.space()
@@ -389,7 +401,7 @@ func (p *Printer) printIfExpression(ie *ast.IfExpression) {
 func (p *Printer) printWhileStatement(ws *ast.WhileStatement) {
 	p.write("while")
 	p.space()
-	p.printDWScript(ws.Condition)
+	p.printCondition(ws.Condition, ws.InlineVar)
 	p.space()
 	p.write("do")
 	p.newline()
@@ -416,6 +428,10 @@ func (p *Printer) printRepeatStatement(rs *ast.RepeatStatement) {
 func (p *Printer) printForStatement(fs *ast.ForStatement) {
 	p.write("for")
 	p.space()
+	if fs.InlineVar {
+		p.write("var")
+		p.space()
+	}
 	p.printDWScript(fs.Variable)
 	p.space()
 	p.write(":=")
@@ -670,16 +686,6 @@ func (p *Printer) printFunctionDirectives(fd *ast.FunctionDecl) bool {
 }
 
 func (p *Printer) printFunctionDecl(fd *ast.FunctionDecl) {
-	// Print visibility for class methods (only if not public and part of a class)
-	// Standalone functions don't have visibility modifiers
-	if fd.ClassName != nil && fd.Visibility != ast.VisibilityPublic {
-		switch fd.Visibility {
-		case ast.VisibilityPrivate, ast.VisibilityProtected:
-			p.write(fd.Visibility.String())
-			p.requiredSpace()
-		}
-	}
-
 	// Print class keyword for class methods
 	if fd.IsClassMethod {
 		p.write("class")
@@ -699,7 +705,11 @@ func (p *Printer) printFunctionDecl(fd *ast.FunctionDecl) {
 	}
 	p.requiredSpace()
 
-	// Print name
+	// Print name, qualified for method implementations (TFoo.Bar)
+	if fd.ClassName != nil {
+		p.printDWScript(fd.ClassName)
+		p.write(".")
+	}
 	p.printDWScript(fd.Name)
 
 	// Print parameters
@@ -1031,6 +1041,14 @@ func (p *Printer) printRecordDecl(rd *ast.RecordDecl) {
 		p.newline()
 	}
 
+	// Print operators
+	for _, operator := range rd.Operators {
+		p.writeIndent()
+		p.printOperatorDecl(operator)
+		p.write(";")
+		p.newline()
+	}
+
 	p.decIndent()
 
 	p.writeIndent()
@@ -1464,3 +1482,37 @@ func (p *Printer) printFunctionPointerTypeNode(fptn *ast.FunctionPointerTypeNode
 		p.write("of object")
 	}
 }
+
+// quoteString returns s as a DWScript string literal. Quotes are doubled and
+// control characters are emitted as #N char codes between quoted segments,
+// e.g. "a"#13#10"b", so that multiline and heredoc strings print on one line.
+// The literal always opens with a quoted segment so it lexes as a string
+// rather than a standalone character literal.
+func quoteString(s string) string {
+	var b strings.Builder
+	b.WriteByte('"')
+	open := true
+	for _, r := range s {
+		if r < ' ' {
+			if open {
+				b.WriteByte('"')
+				open = false
+			}
+			b.WriteByte('#')
+			b.WriteString(strconv.Itoa(int(r)))
+			continue
+		}
+		if !open {
+			b.WriteByte('"')
+			open = true
+		}
+		if r == '"' {
+			b.WriteByte('"')
+		}
+		b.WriteRune(r)
+	}
+	if open {
+		b.WriteByte('"')
+	}
+	return b.String()
+}
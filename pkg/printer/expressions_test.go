@@ -166,7 +166,7 @@ func TestPrintLambdaExpression(t *testing.T) {
 					},
 				},
 			},
-			expected: "lambdabegin42;end",
+			expected: "lambda begin 42;end",
 		},
 		{
 			name: "lambda with single parameter",
@@ -226,7 +226,7 @@ func TestPrintLambdaExpression(t *testing.T) {
 					},
 				},
 			},
-			expected: "lambda(x:Integer)beginx + 1;end",
+			expected: "lambda(x:Integer)begin x + 1;end",
 		},
 		{
 			name: "lambda with multiple parameters",
@@ -297,7 +297,7 @@ func TestPrintLambdaExpression(t *testing.T) {
 					},
 				},
 			},
-			expected: "lambda(x:Integer;y:Integer)beginx + y;end",
+			expected: "lambda(x:Integer;y:Integer)begin x + y;end",
 		},
 	}
 
@@ -355,7 +355,7 @@ func TestPrintIfExpression(t *testing.T) {
 					Value: 0,
 				},
 			},
-			expected: "iftruethen1else0",
+			expected: "if true then 1 else 0",
 		},
 		{
 			name: "if expression without alternative",
@@ -382,7 +382,7 @@ func TestPrintIfExpression(t *testing.T) {
 					Value: 42,
 				},
 			},
-			expected: "iftruethen42",
+			expected: "if true then 42",
 		},
 	}
 
@@ -464,7 +464,7 @@ func TestPrintOldExpression(t *testing.T) {
 					Value: "value",
 				},
 			},
-			expected: "oldvalue",
+			expected: "old value",
 		},
 	}
 
@@ -507,7 +507,7 @@ func TestPrintNewExpressionWithArguments(t *testing.T) {
 				},
 				Arguments: []ast.Expression{},
 			},
-			expected: "newTObject",
+			expected: "new TObject",
 		},
 		{
 			name: "new with arguments",
@@ -544,7 +544,7 @@ func TestPrintNewExpressionWithArguments(t *testing.T) {
 					},
 				},
 			},
-			expected: "newTPoint(10,20)",
+			expected: "new TPoint(10,20)",
 		},
 	}
 
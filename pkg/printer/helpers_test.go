@@ -16,6 +16,7 @@ func TestFormatString(t *testing.T) {
 		{name: "FormatDWScript", format: printer.FormatDWScript, expected: "dwscript"},
 		{name: "FormatTree", format: printer.FormatTree, expected: "tree"},
 		{name: "FormatJSON", format: printer.FormatJSON, expected: "json"},
+		{name: "FormatDOT", format: printer.FormatDOT, expected: "dot"},
 		{name: "unknown format", format: printer.Format(999), expected: "unknown"},
 	}
 
@@ -7,6 +7,7 @@ import (
 	"strings"
 
 	"github.com/cwbudde/go-dws/pkg/ast"
+	"github.com/cwbudde/go-dws/pkg/token"
 )
 
 // Format specifies the output format for the printer.
@@ -21,6 +22,9 @@ const (
 
 	// FormatJSON produces a JSON representation of the AST.
 	FormatJSON
+
+	// FormatDOT produces a GraphViz DOT digraph of the AST.
+	FormatDOT
 )
 
 // String returns the string representation of the format.
@@ -32,6 +36,8 @@ func (f Format) String() string {
 		return "tree"
 	case FormatJSON:
 		return "json"
+	case FormatDOT:
+		return "dot"
 	default:
 		return "unknown"
 	}
@@ -67,7 +73,7 @@ func (s Style) String() string {
 
 // Options configures the printer behavior.
 type Options struct {
-	// Format specifies the output format (DWScript, Tree, JSON).
+	// Format specifies the output format (DWScript, Tree, JSON, DOT).
 	Format Format
 
 	// Style specifies the formatting style (Detailed, Compact, Multiline).
@@ -81,11 +87,15 @@ type Options struct {
 	// Default is true (spaces).
 	UseSpaces bool
 
-	// IncludePositions includes source position information in output (Tree and JSON formats only).
+	// IncludePositions includes source position information in output (Tree, JSON and DOT formats only).
 	IncludePositions bool
 
 	// IncludeTypes includes type information in output (Tree and JSON formats only).
 	IncludeTypes bool
+
+	// MaxDepth limits how many levels below the root are emitted (DOT format only).
+	// Truncated subtrees are marked with a "..." node. Zero means unlimited.
+	MaxDepth int
 }
 
 // DefaultOptions returns the default printer options.
@@ -105,6 +115,13 @@ type Printer struct {
 	buf    bytes.Buffer
 	opts   Options
 	indent int
+
+	// pendingSpace records a space or newline elided in compact mode. The
+	// next write emits a single space if it would otherwise merge two tokens.
+	pendingSpace bool
+
+	// sourceMap is non-nil while PrintWithMap is running.
+	sourceMap *sourceMapBuilder
 }
 
 // New creates a new Printer with the given options.
@@ -130,6 +147,7 @@ func Print(node ast.Node) string {
 func (p *Printer) Print(node ast.Node) string {
 	p.buf.Reset()
 	p.indent = 0
+	p.pendingSpace = false
 
 	switch p.opts.Format {
 	case FormatDWScript:
@@ -138,6 +156,8 @@ func (p *Printer) Print(node ast.Node) string {
 		p.printTree(node)
 	case FormatJSON:
 		p.printJSON(node)
+	case FormatDOT:
+		p.printDOT(node)
 	default:
 		p.printDWScript(node)
 	}
@@ -150,9 +170,31 @@ func (p *Printer) Print(node ast.Node) string {
 
 // write writes a string to the buffer without indentation.
 func (p *Printer) write(s string) {
+	if p.pendingSpace && s != "" {
+		p.pendingSpace = false
+		if n := p.buf.Len(); n > 0 && tokensMerge(p.buf.Bytes()[n-1], s[0]) {
+			p.buf.WriteByte(' ')
+		}
+	}
+	if p.sourceMap != nil && s != "" {
+		p.sourceMap.mark(p.buf.Len())
+	}
 	p.buf.WriteString(s)
 }
 
+// tokensMerge reports whether a token ending in last followed directly by a
+// token starting with next would lex differently (e.g. "begin" + "x", or
+// ":" + "=").
+func tokensMerge(last, next byte) bool {
+	isWord := func(b byte) bool {
+		return b == '_' || b >= 0x80 || ('a' <= b && b <= 'z') || ('A' <= b && b <= 'Z') || ('0' <= b && b <= '9')
+	}
+	isOp := func(b byte) bool {
+		return strings.IndexByte("<>=:.+-*/", b) >= 0
+	}
+	return (isWord(last) && isWord(next)) || (isOp(last) && isOp(next))
+}
+
 // writeIndent writes the current indentation.
 func (p *Printer) writeIndent() {
 	if p.opts.Style == StyleCompact {
@@ -176,16 +218,21 @@ func (p *Printer) getIndentString() string {
 	return strings.Repeat("\t", p.indent)
 }
 
-// newline writes a newline (unless in compact mode).
+// newline writes a newline (unless in compact mode, where it only separates
+// tokens that would otherwise merge).
 func (p *Printer) newline() {
-	if p.opts.Style != StyleCompact {
-		p.buf.WriteByte('\n')
+	if p.opts.Style == StyleCompact {
+		p.pendingSpace = true
+		return
 	}
+	p.buf.WriteByte('\n')
 }
 
-// space writes a space (unless in compact mode).
+// space writes a space (unless in compact mode, where it only separates
+// tokens that would otherwise merge).
 func (p *Printer) space() {
 	if p.opts.Style == StyleCompact {
+		p.pendingSpace = true
 		return
 	}
 	p.buf.WriteByte(' ')
@@ -194,6 +241,7 @@ func (p *Printer) space() {
 // requiredSpace writes a space that is syntactically necessary, even in compact mode.
 // Use this for spaces between keywords and identifiers, or other mandatory spaces.
 func (p *Printer) requiredSpace() {
+	p.pendingSpace = false
 	p.buf.WriteByte(' ')
 }
 
@@ -212,14 +260,27 @@ func (p *Printer) decIndent() {
 // DWScript Format Printer
 // ============================================================================
 
-// printDWScript prints the node in DWScript source format.
-// Uses a single type switch for O(1) dispatch, with helper methods for code organization.
-//
-//nolint:gocyclo // Type switch with many cases is intentional for O(1) dispatch
+// printDWScript prints the node in DWScript source format, recording a
+// source-map entry for it when a map is being built.
 func (p *Printer) printDWScript(node ast.Node) {
 	if node == nil {
 		return
 	}
+	if p.sourceMap == nil {
+		p.printDWScriptNode(node)
+		return
+	}
+
+	entry := p.sourceMap.begin(node)
+	p.printDWScriptNode(node)
+	p.sourceMap.end(entry, p.buf.Len())
+}
+
+// printDWScriptNode dispatches on the node type.
+// Uses a single type switch for O(1) dispatch, with helper methods for code organization.
+//
+//nolint:gocyclo // Type switch with many cases is intentional for O(1) dispatch
+func (p *Printer) printDWScriptNode(node ast.Node) {
 
 	switch n := node.(type) {
 	// Program
@@ -232,9 +293,14 @@ func (p *Printer) printDWScript(node ast.Node) {
 	case *ast.FloatLiteral:
 		p.write(n.Token.Literal)
 	case *ast.StringLiteral:
-		p.write(fmt.Sprintf("\"%s\"", n.Value))
+		p.write(quoteString(n.Value))
 	case *ast.BooleanLiteral:
-		p.write(n.Token.Literal)
+		// Lowercase like every other keyword, whatever the source casing
+		if n.Value {
+			p.write("true")
+		} else {
+			p.write("false")
+		}
 	case *ast.CharLiteral:
 		p.write(n.Token.Literal)
 	case *ast.NilLiteral:
@@ -525,17 +591,27 @@ func (p *Printer) printTreeNodeInfo(node ast.Node) {
 	if p.opts.IncludePositions {
 		pos := node.Pos()
 		if pos.Line > 0 {
-			p.write(fmt.Sprintf(" [%d:%d]", pos.Line, pos.Column))
+			p.write(" [" + positionLabel(pos) + "]")
 		}
 	}
 }
 
+// positionLabel formats pos as "line:column", prefixed with "source:" for
+// positions in a named file such as an included one.
+func positionLabel(pos token.Position) string {
+	if pos.Source != "" {
+		return fmt.Sprintf("%s:%d:%d", pos.Source, pos.Line, pos.Column)
+	}
+	return fmt.Sprintf("%d:%d", pos.Line, pos.Column)
+}
+
 // JSON Format Printer
 // ============================================================================
 
-// printJSON prints the node in JSON format.
+// printJSON prints the node in JSON format. The schema is defined by
+// ast.NodeToJSON and can be read back with ast.UnmarshalJSON.
 func (p *Printer) printJSON(node ast.Node) {
-	data := p.nodeToMap(node)
+	data := ast.NodeToJSON(node, p.opts.IncludePositions)
 	var output []byte
 	var err error
 
@@ -552,60 +628,3 @@ func (p *Printer) printJSON(node ast.Node) {
 
 	p.write(string(output))
 }
-
-// nodeToMap converts an AST node to a map for JSON serialization.
-func (p *Printer) nodeToMap(node ast.Node) map[string]interface{} {
-	if node == nil {
-		return nil
-	}
-
-	result := make(map[string]interface{})
-
-	// Add type information
-	result["type"] = fmt.Sprintf("%T", node)[5:] // Remove "*ast." prefix
-
-	// Add position if requested
-	if p.opts.IncludePositions {
-		pos := node.Pos()
-		if pos.Line > 0 {
-			result["position"] = map[string]interface{}{
-				"line":   pos.Line,
-				"column": pos.Column,
-			}
-		}
-	}
-
-	// Add node-specific fields
-	switch n := node.(type) {
-	case *ast.Identifier:
-		result["value"] = n.Value
-	case *ast.IntegerLiteral:
-		result["value"] = n.Value
-	case *ast.FloatLiteral:
-		result["value"] = n.Value
-	case *ast.StringLiteral:
-		result["value"] = n.Value
-	case *ast.BooleanLiteral:
-		result["value"] = n.Value
-	case *ast.BinaryExpression:
-		result["operator"] = n.Operator
-		result["left"] = p.nodeToMap(n.Left)
-		result["right"] = p.nodeToMap(n.Right)
-	case *ast.UnaryExpression:
-		result["operator"] = n.Operator
-		result["operand"] = p.nodeToMap(n.Right)
-	case *ast.Program:
-		stmts := make([]interface{}, len(n.Statements))
-		for i, stmt := range n.Statements {
-			stmts[i] = p.nodeToMap(stmt)
-		}
-		result["statements"] = stmts
-	case *ast.ExpressionStatement:
-		if n.Expression != nil {
-			result["expression"] = p.nodeToMap(n.Expression)
-		}
-		// Add more cases as needed
-	}
-
-	return result
-}
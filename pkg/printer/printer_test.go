@@ -369,7 +369,7 @@ func TestFunctionModifiers(t *testing.T) {
 			expected: "destructor Destroy",
 		},
 		{
-			name: "private visibility",
+			name: "method implementation",
 			fn: &ast.FunctionDecl{
 				BaseNode: ast.BaseNode{
 					Token: token.Token{Type: token.FUNCTION, Literal: "function"},
@@ -395,7 +395,7 @@ func TestFunctionModifiers(t *testing.T) {
 				},
 				Visibility: ast.VisibilityPrivate,
 			},
-			expected: "private function Helper: String",
+			expected: "function TMyClass.Helper: String",
 		},
 	}
 
@@ -609,3 +609,36 @@ func hasSubstring(s, substr string) bool {
 	}
 	return false
 }
+
+// TestTreePositionSource tests that tree and DOT output name the source file
+// of positions that have one.
+func TestTreePositionSource(t *testing.T) {
+	ident := func(name string, pos token.Position) *ast.Identifier {
+		return &ast.Identifier{
+			TypedExpressionBase: ast.TypedExpressionBase{
+				BaseNode: ast.BaseNode{Token: token.Token{Type: token.IDENT, Literal: name, Pos: pos}},
+			},
+			Value: name,
+		}
+	}
+
+	tests := []struct {
+		name     string
+		node     ast.Node
+		opts     printer.Options
+		contains string
+	}{
+		{"tree unnamed", ident("x", token.Position{Line: 2, Column: 5}), printer.TreeOptionsWithPositions(), "[2:5]"},
+		{"tree named", ident("x", token.Position{Source: "lib.inc", Line: 2, Column: 5}), printer.TreeOptionsWithPositions(), "[lib.inc:2:5]"},
+		{"dot named", ident("x", token.Position{Source: "lib.inc", Line: 2, Column: 5}), printer.Options{Format: printer.FormatDOT, IncludePositions: true}, "[lib.inc:2:5]"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := printer.New(tt.opts).Print(tt.node)
+			if !contains(result, tt.contains) {
+				t.Errorf("Expected output to contain %q, got:\n%s", tt.contains, result)
+			}
+		})
+	}
+}
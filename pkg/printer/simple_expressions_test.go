@@ -4,6 +4,8 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/cwbudde/go-dws/internal/lexer"
+	"github.com/cwbudde/go-dws/internal/parser"
 	"github.com/cwbudde/go-dws/pkg/ast"
 	"github.com/cwbudde/go-dws/pkg/printer"
 	"github.com/cwbudde/go-dws/pkg/token"
@@ -470,3 +472,49 @@ func TestImplementsExpression(t *testing.T) {
 		t.Errorf("Print() = %q, expected to contain 'implements' and 'IInterface'", result)
 	}
 }
+
+// TestStringLiteralEscapes tests that string literals written with doubled
+// quotes, char codes or heredoc syntax print as a single-line literal that
+// lexes back to the same value.
+func TestStringLiteralEscapes(t *testing.T) {
+	tests := []struct {
+		name     string
+		source   string
+		expected string
+	}{
+		{name: "plain", source: "'hello'", expected: `"hello"`},
+		{name: "doubled quotes", source: `'say "hi"'`, expected: `"say ""hi"""`},
+		{name: "char codes", source: "'a'#13#10'b'", expected: `"a"#13#10"b"`},
+		{name: "leading char code", source: "#9'x'", expected: `""#9"x"`},
+		{name: "only char codes", source: "#13#10", expected: `""#13#10`},
+		{name: "heredoc", source: "'''\n  one\n  two\n  '''", expected: `"one"#10"two"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := parser.New(lexer.New("var s := " + tt.source + ";"))
+			program := p.ParseProgram()
+			if len(p.Errors()) > 0 {
+				t.Fatalf("parser errors: %v", p.Errors())
+			}
+			decl, ok := program.Statements[0].(*ast.VarDeclStatement)
+			if !ok {
+				t.Fatalf("expected VarDeclStatement, got %T", program.Statements[0])
+			}
+			lit, ok := decl.Value.(*ast.StringLiteral)
+			if !ok {
+				t.Fatalf("expected StringLiteral, got %T", decl.Value)
+			}
+
+			result := printer.Print(lit)
+			if result != tt.expected {
+				t.Errorf("expected %q, got %q", tt.expected, result)
+			}
+
+			tok := lexer.New(result).NextToken()
+			if tok.Type != lexer.STRING || tok.Literal != lit.Value {
+				t.Errorf("printed literal lexes to %s, want STRING(%q)", tok, lit.Value)
+			}
+		})
+	}
+}
@@ -0,0 +1,180 @@
+package printer
+
+import (
+	"sort"
+	"unicode/utf8"
+
+	"github.com/cwbudde/go-dws/pkg/ast"
+	"github.com/cwbudde/go-dws/pkg/token"
+)
+
+// This file implements source maps for DWScript format output. While
+// printing, every node records the span of output bytes it produced against
+// its original node.Pos(), so positions in the printed text (for example from
+// diagnostics of a later compile) can be translated back to the input.
+
+// Mapping records that output bytes [Start, End) were printed for a node
+// whose position in the original source is Pos.
+type Mapping struct {
+	Pos   token.Position
+	Start int
+	End   int
+}
+
+// SourceMap translates positions in printed output back to positions in
+// the source the AST was parsed from.
+type SourceMap struct {
+	mappings   []Mapping
+	lineStarts []int
+	output     string
+}
+
+// PrintWithMap formats the node with default options and returns the output
+// together with a source map for it.
+// This is a convenience function that creates a printer with default options.
+func PrintWithMap(node ast.Node) (string, SourceMap) {
+	return New(DefaultOptions()).PrintWithMap(node)
+}
+
+// PrintWithMap formats the node like Print and also returns a source map
+// linking the output back to the nodes' original positions. Mappings are
+// only recorded for FormatDWScript; other formats return an empty map.
+// Nodes without a valid position (e.g. synthesized nodes) are not mapped.
+func (p *Printer) PrintWithMap(node ast.Node) (string, SourceMap) {
+	p.sourceMap = &sourceMapBuilder{}
+	defer func() { p.sourceMap = nil }()
+
+	output := p.Print(node)
+	return output, SourceMap{
+		mappings:   p.sourceMap.finish(),
+		lineStarts: lineStarts(output),
+		output:     output,
+	}
+}
+
+// Mappings returns all recorded mappings in print order (parents before
+// their children).
+func (m SourceMap) Mappings() []Mapping {
+	return append([]Mapping(nil), m.mappings...)
+}
+
+// OriginalOffset returns the original position of the innermost node whose
+// output contains the byte at offset.
+func (m SourceMap) OriginalOffset(offset int) (token.Position, bool) {
+	best := -1
+	for i, mp := range m.mappings {
+		if offset < mp.Start || offset >= mp.End {
+			continue
+		}
+		// Later entries at equal width are children of earlier ones.
+		if best < 0 || mp.End-mp.Start <= m.mappings[best].End-m.mappings[best].Start {
+			best = i
+		}
+	}
+	if best < 0 {
+		return token.Position{}, false
+	}
+	return m.mappings[best].Pos, true
+}
+
+// Original translates a position in the printed output to the original
+// position of the innermost node printed there. The output position is
+// located by Line and Column when Line is set, otherwise by Offset.
+func (m SourceMap) Original(pos token.Position) (token.Position, bool) {
+	offset := pos.Offset
+	if pos.Line > 0 {
+		var ok bool
+		if offset, ok = m.offsetOf(pos.Line, pos.Column); !ok {
+			return token.Position{}, false
+		}
+	}
+	return m.OriginalOffset(offset)
+}
+
+// offsetOf converts a 1-indexed line and rune column in the output to a
+// byte offset.
+func (m SourceMap) offsetOf(line, column int) (int, bool) {
+	if line < 1 || line > len(m.lineStarts) || column < 1 {
+		return 0, false
+	}
+	offset := m.lineStarts[line-1]
+	for col := 1; col < column; col++ {
+		if offset >= len(m.output) || m.output[offset] == '\n' {
+			return 0, false
+		}
+		_, size := utf8.DecodeRuneInString(m.output[offset:])
+		offset += size
+	}
+	return offset, true
+}
+
+// lineStarts returns the byte offset at which each line of s begins.
+func lineStarts(s string) []int {
+	starts := []int{0}
+	for i := 0; i < len(s); i++ {
+		if s[i] == '\n' {
+			starts = append(starts, i+1)
+		}
+	}
+	return starts
+}
+
+// sourceMapBuilder collects mappings while the printer runs.
+type sourceMapBuilder struct {
+	mappings []Mapping
+	// pending holds entries that have begun but not written any output yet;
+	// their Start is set by the next write, so that separators emitted before
+	// the node's first token are not attributed to it.
+	pending []int
+}
+
+// begin opens an entry for node and returns its index, or -1 if the node
+// has no valid position.
+func (b *sourceMapBuilder) begin(node ast.Node) int {
+	pos := node.Pos()
+	if !pos.IsValid() {
+		return -1
+	}
+	b.mappings = append(b.mappings, Mapping{Pos: pos, Start: -1})
+	index := len(b.mappings) - 1
+	b.pending = append(b.pending, index)
+	return index
+}
+
+// mark sets the start of all pending entries to offset.
+func (b *sourceMapBuilder) mark(offset int) {
+	for _, index := range b.pending {
+		b.mappings[index].Start = offset
+	}
+	b.pending = b.pending[:0]
+}
+
+// end closes the entry at index.
+func (b *sourceMapBuilder) end(index, offset int) {
+	if index < 0 {
+		return
+	}
+	b.mappings[index].End = offset
+	if b.mappings[index].Start < 0 {
+		// Nothing was written for the node; stop waiting for its start.
+		for i, pending := range b.pending {
+			if pending == index {
+				b.pending = append(b.pending[:i], b.pending[i+1:]...)
+				break
+			}
+		}
+	}
+}
+
+// finish drops entries for nodes that printed nothing and returns the rest
+// ordered by start offset.
+func (b *sourceMapBuilder) finish() []Mapping {
+	result := make([]Mapping, 0, len(b.mappings))
+	for _, mp := range b.mappings {
+		if mp.Start >= 0 && mp.End > mp.Start {
+			result = append(result, mp)
+		}
+	}
+	sort.SliceStable(result, func(i, j int) bool { return result[i].Start < result[j].Start })
+	return result
+}
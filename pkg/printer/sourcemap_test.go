@@ -0,0 +1,88 @@
+package printer_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/cwbudde/go-dws/internal/lexer"
+	"github.com/cwbudde/go-dws/internal/parser"
+	"github.com/cwbudde/go-dws/pkg/printer"
+	"github.com/cwbudde/go-dws/pkg/token"
+)
+
+func TestPrintWithMap(t *testing.T) {
+	source := `// leading comment
+var   x : Integer:=1;
+
+if x>0 then
+        PrintLn(   'positive'   );
+`
+	p := parser.New(lexer.New(source))
+	program := p.ParseProgram()
+	if len(p.Errors()) > 0 {
+		t.Fatalf("parser errors: %v", p.Errors())
+	}
+
+	output, sm := printer.PrintWithMap(program)
+	if output != printer.Print(program) {
+		t.Fatalf("PrintWithMap output differs from Print:\n%s", output)
+	}
+	if len(sm.Mappings()) == 0 {
+		t.Fatal("expected mappings to be recorded")
+	}
+
+	tests := []struct {
+		name     string
+		output   string // substring of the printed output
+		original string // substring of the source it should map to
+	}{
+		{name: "string literal", output: `"positive"`, original: "'positive'"},
+		{name: "call", output: "PrintLn", original: "PrintLn"},
+		{name: "initializer", output: "1;", original: "1;"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			outOffset := strings.Index(output, tt.output)
+			if outOffset < 0 {
+				t.Fatalf("%q not found in output:\n%s", tt.output, output)
+			}
+			want := positionOf(source, tt.original)
+
+			got, ok := sm.OriginalOffset(outOffset)
+			if !ok {
+				t.Fatalf("no mapping for output offset %d", outOffset)
+			}
+			if got.Line != want.Line || got.Column != want.Column {
+				t.Errorf("OriginalOffset(%d) = %d:%d, want %d:%d",
+					outOffset, got.Line, got.Column, want.Line, want.Column)
+			}
+
+			outPos := positionOf(output, tt.output)
+			got, ok = sm.Original(outPos)
+			if !ok {
+				t.Fatalf("no mapping for output position %d:%d", outPos.Line, outPos.Column)
+			}
+			if got.Line != want.Line || got.Column != want.Column {
+				t.Errorf("Original(%d:%d) = %d:%d, want %d:%d",
+					outPos.Line, outPos.Column, got.Line, got.Column, want.Line, want.Column)
+			}
+		})
+	}
+
+	if _, ok := sm.OriginalOffset(len(output) + 10); ok {
+		t.Error("expected no mapping past the end of the output")
+	}
+	if _, ok := sm.Original(token.Position{Line: 100, Column: 1}); ok {
+		t.Error("expected no mapping for a line past the end of the output")
+	}
+}
+
+// positionOf returns the 1-indexed line and column of the first occurrence
+// of substr in s.
+func positionOf(s, substr string) token.Position {
+	offset := strings.Index(s, substr)
+	line := strings.Count(s[:offset], "\n") + 1
+	column := offset - strings.LastIndex(s[:offset], "\n")
+	return token.Position{Line: line, Column: column, Offset: offset}
+}
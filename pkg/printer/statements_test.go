@@ -734,7 +734,7 @@ func TestPrintRaiseStatement(t *testing.T) {
 					},
 				},
 			},
-			expected: "raisenewException",
+			expected: "raise new Exception",
 		},
 	}
 
@@ -124,6 +124,20 @@ func JSONOptionsVerbose() Options {
 	}
 }
 
+// DOTOptions returns options for a GraphViz DOT graph of the AST.
+// Render the output with e.g. "dot -Tsvg". Set MaxDepth to keep large
+// programs readable.
+func DOTOptions() Options {
+	return Options{
+		Format:           FormatDOT,
+		Style:            StyleDetailed,
+		IndentWidth:      2,
+		UseSpaces:        true,
+		IncludePositions: false,
+		IncludeTypes:     false,
+	}
+}
+
 // CompactPrinter returns a new printer configured for compact output.
 // Equivalent to New(CompactOptions()).
 func CompactPrinter() *Printer {
@@ -156,9 +156,14 @@ func Example_jsonPrinter() {
 
 	// Output:
 	// {
+	//   "astVersion": 1,
 	//   "statements": [
 	//     {
 	//       "expression": {
+	//         "token": {
+	//           "literal": "42",
+	//           "type": "INT"
+	//         },
 	//         "type": "IntegerLiteral",
 	//         "value": 42
 	//       },
@@ -0,0 +1,22 @@
+// Package repl implements an interactive read-eval-print loop for DWScript
+// on top of a dwscript.Session.
+//
+// Input is fed one line at a time. Lines are collected until they form a
+// complete piece of source: a line that leaves a begin/end block, string
+// literal or comment open, or ends with an operator, asks for more input
+// instead of failing with a syntax error. Complete input is evaluated in the
+// session, so its declarations are visible to later input, and the value of
+// a trailing bare expression is echoed:
+//
+//	engine, _ := dwscript.New()
+//	r, _ := repl.New(engine, os.Stdout)
+//	r.Feed("var x := 20;")
+//	r.Feed("x +")     // NeedMore
+//	r.Feed("  22")    // prints 42
+//	r.Feed("begin")   // NeedMore
+//	r.Feed("  PrintLn(x);")
+//	r.Feed("end;")    // prints 20
+//
+// Run drives the loop from a reader, such as standard input, writing the
+// prompts and errors to the REPL's output.
+package repl
@@ -0,0 +1,140 @@
+package repl
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/cwbudde/go-dws/pkg/dwscript"
+)
+
+// Status reports what Feed did with a line.
+type Status int
+
+const (
+	// Evaluated means the collected input was complete and has been
+	// evaluated, or was blank and has been dropped.
+	Evaluated Status = iota
+	// NeedMore means the collected input ends inside an unfinished construct
+	// and the next line continues it.
+	NeedMore
+)
+
+// String returns the name of the status.
+func (s Status) String() string {
+	switch s {
+	case Evaluated:
+		return "Evaluated"
+	case NeedMore:
+		return "NeedMore"
+	default:
+		return fmt.Sprintf("Status(%d)", int(s))
+	}
+}
+
+// REPL collects input lines and evaluates them in a session once they form
+// complete source. A REPL is not safe for concurrent use.
+type REPL struct {
+	// Prompt is written by Run before the first line of an input.
+	Prompt string
+	// ContinuationPrompt is written by Run before a line that continues an
+	// unfinished input.
+	ContinuationPrompt string
+
+	engine  *dwscript.Engine
+	session *dwscript.Session
+	out     io.Writer
+	pending []string
+}
+
+// New returns a REPL that evaluates input in a new session of engine and
+// writes script output and echoed values to out.
+func New(engine *dwscript.Engine, out io.Writer) (*REPL, error) {
+	session, err := engine.NewSession()
+	if err != nil {
+		return nil, err
+	}
+	return &REPL{
+		Prompt:             "> ",
+		ContinuationPrompt: ". ",
+		engine:             engine,
+		session:            session,
+		out:                out,
+	}, nil
+}
+
+// Session returns the session the REPL evaluates input in.
+func (r *REPL) Session() *dwscript.Session {
+	return r.session
+}
+
+// Pending reports whether lines of an unfinished input are waiting for the
+// rest of it.
+func (r *REPL) Pending() bool {
+	return len(r.pending) > 0
+}
+
+// Reset drops the lines of an unfinished input.
+func (r *REPL) Reset() {
+	r.pending = nil
+}
+
+// Feed adds line to the collected input. If the input is now complete, it is
+// evaluated: script output is written to the REPL's output, followed by the
+// value of a trailing bare expression, and the input is cleared. A compile
+// or runtime error of the evaluation is returned, and the input is cleared
+// as well. If the input is unfinished, Feed returns NeedMore and keeps it.
+func (r *REPL) Feed(line string) (Status, error) {
+	r.pending = append(r.pending, line)
+	source := strings.Join(r.pending, "\n")
+	if strings.TrimSpace(source) == "" {
+		r.pending = nil
+		return Evaluated, nil
+	}
+	if r.engine.IsIncomplete(source) {
+		return NeedMore, nil
+	}
+	return Evaluated, r.evaluate(source)
+}
+
+// evaluate runs source in the session and echoes its expression value.
+func (r *REPL) evaluate(source string) error {
+	r.pending = nil
+	result, err := r.session.EvalWithOutput(source, r.out)
+	if err != nil {
+		return err
+	}
+	if value, ok := result.ExpressionValue(); ok {
+		fmt.Fprintln(r.out, value)
+	}
+	return nil
+}
+
+// Run reads lines from in and feeds them to the REPL until in is exhausted,
+// writing a prompt before every line. Errors of evaluations are written to
+// the REPL's output and do not stop the loop. Input that is still
+// unfinished at the end is evaluated, so its syntax error is reported.
+func (r *REPL) Run(in io.Reader) error {
+	scanner := bufio.NewScanner(in)
+	for {
+		if r.Pending() {
+			fmt.Fprint(r.out, r.ContinuationPrompt)
+		} else {
+			fmt.Fprint(r.out, r.Prompt)
+		}
+		if !scanner.Scan() {
+			break
+		}
+		if _, err := r.Feed(scanner.Text()); err != nil {
+			fmt.Fprintln(r.out, err)
+		}
+	}
+	if r.Pending() {
+		fmt.Fprintln(r.out)
+		if err := r.evaluate(strings.Join(r.pending, "\n")); err != nil {
+			fmt.Fprintln(r.out, err)
+		}
+	}
+	return scanner.Err()
+}
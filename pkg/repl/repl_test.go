@@ -0,0 +1,117 @@
+package repl
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/cwbudde/go-dws/pkg/dwscript"
+)
+
+func newTestREPL(t *testing.T) (*REPL, *bytes.Buffer) {
+	t.Helper()
+	engine, err := dwscript.New()
+	if err != nil {
+		t.Fatalf("failed to create engine: %v", err)
+	}
+	var out bytes.Buffer
+	r, err := New(engine, &out)
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	return r, &out
+}
+
+// TestFeed verifies that lines are collected until the input is complete,
+// and that complete input is evaluated with its expression value echoed.
+func TestFeed(t *testing.T) {
+	r, out := newTestREPL(t)
+
+	steps := []struct {
+		line   string
+		status Status
+		output string
+	}{
+		{"var x := 20;", Evaluated, ""},
+		{"x +", NeedMore, ""},
+		{"  22", Evaluated, "42\n"},
+		{"begin", NeedMore, ""},
+		{"  PrintLn('in block');", NeedMore, ""},
+		{"end;", Evaluated, "in block\n"},
+		{"function Twice(n: Integer): Integer;", NeedMore, ""},
+		{"begin", NeedMore, ""},
+		{"  Result := n * 2;", NeedMore, ""},
+		{"end;", Evaluated, ""},
+		{"Twice(x)", Evaluated, "40\n"},
+		{"PrintLn('a", NeedMore, ""},
+		{"b');", Evaluated, "a\nb\n"},
+		{"", Evaluated, ""},
+	}
+	for _, step := range steps {
+		out.Reset()
+		status, err := r.Feed(step.line)
+		if err != nil {
+			t.Fatalf("Feed(%q) failed: %v", step.line, err)
+		}
+		if status != step.status {
+			t.Errorf("Feed(%q) = %v, want %v", step.line, status, step.status)
+		}
+		if out.String() != step.output {
+			t.Errorf("Feed(%q) output = %q, want %q", step.line, out.String(), step.output)
+		}
+		if r.Pending() != (status == NeedMore) {
+			t.Errorf("Feed(%q): Pending() = %v with status %v", step.line, r.Pending(), status)
+		}
+	}
+}
+
+// TestFeedErrors verifies that syntax and runtime errors are returned
+// instead of waiting for more input, and that the REPL stays usable.
+func TestFeedErrors(t *testing.T) {
+	r, out := newTestREPL(t)
+
+	status, err := r.Feed("x := 1 +;")
+	var compileErr *dwscript.CompileError
+	if status != Evaluated || !errors.As(err, &compileErr) {
+		t.Fatalf("Feed with a syntax error = %v, %v, want Evaluated and a *CompileError", status, err)
+	}
+	if r.Pending() {
+		t.Errorf("input of a failed evaluation is still pending")
+	}
+
+	if _, err := r.Feed("raise Exception.Create('boom');"); err == nil {
+		t.Errorf("Feed raising an exception succeeded")
+	}
+
+	out.Reset()
+	if _, err := r.Feed("1 + 1"); err != nil || out.String() != "2\n" {
+		t.Errorf("Feed after errors = %q, %v, want %q", out.String(), err, "2\n")
+	}
+
+	if status, _ := r.Feed("begin"); status != NeedMore {
+		t.Fatalf("Feed(begin) = %v, want NeedMore", status)
+	}
+	r.Reset()
+	if r.Pending() {
+		t.Errorf("Reset left input pending")
+	}
+}
+
+// TestRun verifies the prompts and error reporting of the input loop.
+func TestRun(t *testing.T) {
+	r, out := newTestREPL(t)
+
+	in := strings.NewReader("var s := 'a';\nif s = 'a' then\n  PrintLn('yes');\n1 +;\nbegin\n")
+	if err := r.Run(in); err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+
+	got := out.String()
+	if !strings.HasPrefix(got, "> > . yes\n> ") {
+		t.Errorf("Run output = %q, want it to start with %q", got, "> > . yes\n> ")
+	}
+	if strings.Count(got, "parsing error") != 2 {
+		t.Errorf("Run output = %q, want the syntax error and the unfinished block reported", got)
+	}
+}
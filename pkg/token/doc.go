@@ -41,6 +41,12 @@
 //   - LookupIdent: Convert identifier strings to token types
 //   - IsKeyword: Check if a string is a keyword
 //   - GetKeywordLiteral: Get the canonical (lowercase) form of keywords
+//   - Keywords: List all keywords, e.g. for completion providers
+//   - Precedence: The parser's infix binding strength for a token type
+//
+// Token types classify themselves with the IsLiteral, IsKeyword, IsOperator,
+// IsDelimiter and IsTrivia methods, and their String form is stable, so it
+// can be used in golden files.
 //
 // # Integration with AST
 //
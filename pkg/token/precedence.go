@@ -0,0 +1,65 @@
+package token
+
+// Operator precedence levels used by the DWScript expression parser, from
+// lowest to highest binding strength.
+const (
+	_ int = iota
+	PrecLowest
+	PrecAssign      // :=
+	PrecCoalesce    // ?? (higher than := so it works in an assignment RHS)
+	PrecImplies     // implies (lowest-precedence boolean connective)
+	PrecOr          // or xor
+	PrecAnd         // and
+	PrecEquals      // = <> in is as implements
+	PrecLessGreater // < > <= >=
+	PrecSum         // + -
+	PrecShift       // shl shr sar
+	PrecProduct     // * / div mod
+	PrecPrefix      // -x, not x, +x
+	PrecCall        // function(args)
+	PrecIndex       // array[index]
+	PrecMember      // obj.field
+)
+
+// precedences maps infix token types to their precedence levels.
+// Compound assignment operators (+=, -=, ...) are statement-level and are
+// deliberately absent.
+var precedences = map[TokenType]int{
+	QUESTION_QUESTION: PrecCoalesce,
+	ASSIGN:            PrecAssign,
+	IMPLIES:           PrecImplies,
+	OR:                PrecOr,
+	XOR:               PrecOr,
+	AND:               PrecAnd,
+	EQ:                PrecEquals,
+	NOT_EQ:            PrecEquals,
+	IN:                PrecEquals, // Set membership test
+	IS:                PrecEquals, // Type checking: obj is TClass
+	AS:                PrecEquals, // Type casting: obj as IInterface
+	IMPLEMENTS:        PrecEquals, // Interface check: obj implements IInterface
+	LESS:              PrecLessGreater,
+	GREATER:           PrecLessGreater,
+	LESS_EQ:           PrecLessGreater,
+	GREATER_EQ:        PrecLessGreater,
+	PLUS:              PrecSum,
+	MINUS:             PrecSum,
+	SHL:               PrecShift,
+	SHR:               PrecShift,
+	SAR:               PrecShift,
+	ASTERISK:          PrecProduct,
+	SLASH:             PrecProduct,
+	DIV:               PrecProduct,
+	MOD:               PrecProduct,
+	LPAREN:            PrecCall,
+	LBRACK:            PrecIndex,
+	DOT:               PrecMember,
+}
+
+// Precedence returns the infix binding strength of t as used by the parser.
+// Tokens that cannot continue an expression report PrecLowest.
+func Precedence(t TokenType) int {
+	if prec, ok := precedences[t]; ok {
+		return prec
+	}
+	return PrecLowest
+}
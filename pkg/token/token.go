@@ -2,6 +2,7 @@ package token
 
 import (
 	"fmt"
+	"sort"
 	"strings"
 	"unicode/utf8"
 )
@@ -25,10 +26,18 @@ import (
 //   - Display width (terminal cells) may differ from column number
 //   - Error markers may not align visually for wide characters
 //   - But positions are consistent and reproducible across all systems
+//
+// # Source
+//
+// Source names the file the position belongs to. It is empty for the
+// top-level source unless the lexer was given a name (lexer.WithSourceName),
+// and holds the resolved path of the included file for code spliced in by
+// {$INCLUDE}. Line, Column and Offset are always relative to Source.
 type Position struct {
-	Line   int // Line number (1-indexed)
-	Column int // Column number (1-indexed, rune count not display width or byte offset)
-	Offset int // Byte offset (0-indexed)
+	Source string // File or unit name (empty for an unnamed top-level source)
+	Line   int    // Line number (1-indexed)
+	Column int    // Column number (1-indexed, rune count not display width or byte offset)
+	Offset int    // Byte offset (0-indexed)
 }
 
 // String returns a string representation of the position in the format "line:column".
@@ -46,8 +55,12 @@ func (p Position) IsValid() bool {
 // Every piece of DWScript source code is represented as a sequence of tokens.
 type Token struct {
 	Literal string
-	Pos     Position
-	Type    TokenType
+	// Raw is the source text of the token when it differs from Literal, e.g.
+	// for string literals whose Literal holds the decoded value ('it''s',
+	// 'a'#13#10'b', heredocs). It is empty when Literal is the source text.
+	Raw  string
+	Pos  Position
+	Type TokenType
 }
 
 // String returns a string representation of the token for debugging.
@@ -65,20 +78,36 @@ func (t Token) String() string {
 // Length returns the length of the token in characters (runes).
 // This is useful for error reporting and LSP integration, allowing tools
 // to highlight the exact span of code represented by this token.
-// For byte length, use len(t.Literal).
+// For byte length, use len(t.Source()).
 func (t Token) Length() int {
-	return utf8.RuneCountInString(t.Literal)
+	return utf8.RuneCountInString(t.Source())
+}
+
+// Source returns the source text of the token: Raw if set, otherwise Literal.
+func (t Token) Source() string {
+	if t.Raw != "" {
+		return t.Raw
+	}
+	return t.Literal
 }
 
 // End returns the position immediately after this token.
 // Column is calculated using rune count to match the lexer's rune-based column tracking.
 // Offset uses byte length for correct byte position in the source.
+// Tokens spanning several lines (e.g. heredoc strings) end on their last line.
 func (t Token) End() Position {
-	return Position{
+	src := t.Source()
+	end := Position{
+		Source: t.Pos.Source,
 		Line:   t.Pos.Line,
-		Column: t.Pos.Column + utf8.RuneCountInString(t.Literal),
-		Offset: t.Pos.Offset + len(t.Literal),
+		Column: t.Pos.Column + utf8.RuneCountInString(src),
+		Offset: t.Pos.Offset + len(src),
+	}
+	if i := strings.LastIndexByte(src, '\n'); i >= 0 {
+		end.Line += strings.Count(src, "\n")
+		end.Column = 1 + utf8.RuneCountInString(src[i+1:])
 	}
+	return end
 }
 
 // NewToken creates a new token with the given type, literal, and position.
@@ -326,6 +355,10 @@ const (
 
 	// Compiler directives
 	SWITCH // {$directive} compiler switch
+
+	// Trivia tokens added after the original set, so existing token numbers
+	// stay stable
+	WHITESPACE // Run of spaces, tabs and line breaks
 )
 
 // String returns the string representation of a TokenType.
@@ -336,9 +369,28 @@ func (tt TokenType) String() string {
 	return "UNKNOWN"
 }
 
-// IsLiteral returns true if the token type is a literal value.
+// LookupTokenType returns the TokenType whose String form is name.
+// It is the inverse of String and is used when decoding serialized tokens.
+func LookupTokenType(name string) (TokenType, bool) {
+	for i, s := range tokenTypeStrings {
+		if s != "" && s == name {
+			return TokenType(i), true
+		}
+	}
+	return ILLEGAL, false
+}
+
+// IsLiteral returns true if the token type is a literal value. COMMENT is
+// trivia rather than a literal (see IsTrivia).
 func (tt TokenType) IsLiteral() bool {
-	return tt > EOF && tt < literalEnd
+	return tt > EOF && tt < literalEnd && !tt.IsTrivia()
+}
+
+// IsTrivia returns true for tokens that carry no meaning for the parser
+// (comments and whitespace), which are only produced on request. Trivia
+// tokens are neither literals, keywords, operators nor delimiters.
+func (tt TokenType) IsTrivia() bool {
+	return tt == COMMENT || tt == WHITESPACE
 }
 
 // IsKeyword returns true if the token type is a keyword.
@@ -581,6 +633,9 @@ var tokenTypeStrings = [...]string{
 
 	// Compiler directives
 	SWITCH: "SWITCH",
+
+	// Trivia
+	WHITESPACE: "WHITESPACE",
 }
 
 // keywords maps DWScript keyword strings to their TokenType.
@@ -757,3 +812,14 @@ func GetKeywordLiteral(ident string) string {
 	}
 	return ident
 }
+
+// Keywords returns every DWScript keyword in canonical lowercase form,
+// sorted alphabetically. The slice is a fresh copy that callers may modify.
+func Keywords() []string {
+	words := make([]string, 0, len(keywords))
+	for word := range keywords {
+		words = append(words, word)
+	}
+	sort.Strings(words)
+	return words
+}
@@ -156,6 +156,20 @@ func TestTokenTypeString(t *testing.T) {
 	}
 }
 
+// TestLookupTokenType tests that LookupTokenType inverts TokenType.String()
+func TestLookupTokenType(t *testing.T) {
+	for _, want := range []TokenType{ILLEGAL, EOF, IDENT, INT, STRING, BEGIN, CLASS, PLUS, ASSIGN, SWITCH} {
+		got, ok := LookupTokenType(want.String())
+		if !ok || got != want {
+			t.Errorf("LookupTokenType(%q) = %v, %v; want %v, true", want.String(), got, ok, want)
+		}
+	}
+
+	if _, ok := LookupTokenType("NOT_A_TOKEN"); ok {
+		t.Error("LookupTokenType(\"NOT_A_TOKEN\") reported ok")
+	}
+}
+
 // TestTokenTypeIsLiteral tests TokenType.IsLiteral()
 func TestTokenTypeIsLiteral(t *testing.T) {
 	tests := []struct {
@@ -168,7 +182,6 @@ func TestTokenTypeIsLiteral(t *testing.T) {
 		{"FLOAT is literal", FLOAT, true},
 		{"STRING is literal", STRING, true},
 		{"CHAR is literal", CHAR, true},
-		{"COMMENT is literal", COMMENT, true},
 		// Note: TRUE, FALSE, NIL, NULL, UNASSIGNED are keywords, not literals
 		{"TRUE is not literal", TRUE, false},
 		{"FALSE is not literal", FALSE, false},
@@ -181,6 +194,8 @@ func TestTokenTypeIsLiteral(t *testing.T) {
 		{"LPAREN is not literal", LPAREN, false},
 		{"EOF is not literal", EOF, false},
 		{"ILLEGAL is not literal", ILLEGAL, false},
+		{"COMMENT is not literal", COMMENT, false},
+		{"WHITESPACE is not literal", WHITESPACE, false},
 	}
 
 	for _, tt := range tests {
@@ -193,6 +208,42 @@ func TestTokenTypeIsLiteral(t *testing.T) {
 	}
 }
 
+// TestTokenTypeNumbering tests that token types added later are appended,
+// so the numbers of the original token types stay stable.
+func TestTokenTypeNumbering(t *testing.T) {
+	if IDENT != 3 {
+		t.Errorf("IDENT = %d, want 3", IDENT)
+	}
+	if WHITESPACE != SWITCH+1 {
+		t.Errorf("WHITESPACE = %d, want %d (right after SWITCH)", WHITESPACE, SWITCH+1)
+	}
+}
+
+// TestTokenTypeIsTrivia tests TokenType.IsTrivia()
+func TestTokenTypeIsTrivia(t *testing.T) {
+	tests := []struct {
+		name     string
+		tt       TokenType
+		expected bool
+	}{
+		{"COMMENT is trivia", COMMENT, true},
+		{"WHITESPACE is trivia", WHITESPACE, true},
+		{"SWITCH is not trivia", SWITCH, false},
+		{"IDENT is not trivia", IDENT, false},
+		{"EOF is not trivia", EOF, false},
+		{"ILLEGAL is not trivia", ILLEGAL, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.tt.IsTrivia()
+			if got != tt.expected {
+				t.Errorf("TokenType(%v).IsTrivia() = %v, want %v", tt.tt, got, tt.expected)
+			}
+		})
+	}
+}
+
 // TestTokenTypeIsKeyword tests TokenType.IsKeyword()
 func TestTokenTypeIsKeyword(t *testing.T) {
 	tests := []struct {
@@ -432,54 +483,41 @@ func TestGetKeywordLiteral(t *testing.T) {
 
 // TestTokenTypeCategories tests that token types are properly categorized
 func TestTokenTypeCategories(t *testing.T) {
-	// Test that categories are mutually exclusive
+	// Every token type belongs to at most one category
 	t.Run("categories are mutually exclusive", func(t *testing.T) {
-		// Sample a few tokens from each category
-		samples := []TokenType{
-			IDENT, INT, STRING, // literals
-			BEGIN, IF, CLASS, // keywords
-			PLUS, EQ, ASSIGN, // operators
-			LPAREN, COMMA, DOT, // delimiters
-		}
-
-		for _, tt := range samples {
-			categories := 0
+		for tt := ILLEGAL; int(tt) < len(tokenTypeStrings); tt++ {
+			var categories []string
 			if tt.IsLiteral() {
-				categories++
+				categories = append(categories, "literal")
+			}
+			if tt.IsTrivia() {
+				categories = append(categories, "trivia")
 			}
 			if tt.IsKeyword() {
-				categories++
+				categories = append(categories, "keyword")
 			}
 			if tt.IsOperator() {
-				categories++
+				categories = append(categories, "operator")
 			}
 			if tt.IsDelimiter() {
-				categories++
+				categories = append(categories, "delimiter")
 			}
 
-			// Each token should belong to at most one category
-			if categories > 1 {
-				t.Errorf("TokenType %v belongs to %d categories (should be 0 or 1)", tt, categories)
+			if len(categories) > 1 {
+				t.Errorf("TokenType %v belongs to categories %v (should be 0 or 1)", tt, categories)
 			}
 		}
 	})
 
-	// Test special tokens (ILLEGAL and EOF only, COMMENT is considered a literal)
+	// Test special tokens
 	t.Run("special tokens not in categories", func(t *testing.T) {
-		special := []TokenType{ILLEGAL, EOF}
+		special := []TokenType{ILLEGAL, EOF, COMMENT, WHITESPACE}
 		for _, tt := range special {
 			if tt.IsLiteral() || tt.IsKeyword() || tt.IsOperator() || tt.IsDelimiter() {
 				t.Errorf("Special token %v should not be in any category", tt)
 			}
 		}
 	})
-
-	// Test that COMMENT is considered a literal
-	t.Run("COMMENT is a literal", func(t *testing.T) {
-		if !COMMENT.IsLiteral() {
-			t.Error("COMMENT should be considered a literal")
-		}
-	})
 }
 
 // TestAllKeywordsInMap tests that all keyword token types are in the keywords map
@@ -514,3 +552,77 @@ func TestAllKeywordsInMap(t *testing.T) {
 		}
 	}
 }
+
+// TestTokenTypeStringsUnique ensures String is unambiguous, so it can be used
+// as a stable name in golden files.
+func TestTokenTypeStringsUnique(t *testing.T) {
+	seen := make(map[string]TokenType)
+	for i, s := range tokenTypeStrings {
+		if s == "" {
+			continue
+		}
+		if prev, dup := seen[s]; dup {
+			t.Errorf("TokenType %d and %d share String %q", prev, i, s)
+		}
+		seen[s] = TokenType(i)
+	}
+}
+
+// TestKeywords tests that Keywords lists every keyword once, sorted
+func TestKeywords(t *testing.T) {
+	words := Keywords()
+	if len(words) != len(keywords) {
+		t.Fatalf("Keywords() returned %d words, want %d", len(words), len(keywords))
+	}
+	for i, w := range words {
+		if !IsKeyword(w) {
+			t.Errorf("Keywords()[%d] = %q is not a keyword", i, w)
+		}
+		if i > 0 && words[i-1] >= w {
+			t.Errorf("Keywords() not sorted: %q before %q", words[i-1], w)
+		}
+	}
+
+	words[0] = "mutated"
+	if Keywords()[0] == "mutated" {
+		t.Error("Keywords() returned shared storage")
+	}
+}
+
+// TestPrecedence tests the exported operator precedence table
+func TestPrecedence(t *testing.T) {
+	tests := []struct {
+		tt   TokenType
+		want int
+	}{
+		{tt: ASSIGN, want: PrecAssign},
+		{tt: QUESTION_QUESTION, want: PrecCoalesce},
+		{tt: OR, want: PrecOr},
+		{tt: XOR, want: PrecOr},
+		{tt: AND, want: PrecAnd},
+		{tt: EQ, want: PrecEquals},
+		{tt: IS, want: PrecEquals},
+		{tt: LESS_EQ, want: PrecLessGreater},
+		{tt: PLUS, want: PrecSum},
+		{tt: SHL, want: PrecShift},
+		{tt: DIV, want: PrecProduct},
+		{tt: LPAREN, want: PrecCall},
+		{tt: LBRACK, want: PrecIndex},
+		{tt: DOT, want: PrecMember},
+		{tt: IDENT, want: PrecLowest},
+		{tt: SEMICOLON, want: PrecLowest},
+		{tt: PLUS_ASSIGN, want: PrecLowest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.tt.String(), func(t *testing.T) {
+			if got := Precedence(tt.tt); got != tt.want {
+				t.Errorf("Precedence(%v) = %d, want %d", tt.tt, got, tt.want)
+			}
+		})
+	}
+
+	if !(PrecOr < PrecAnd && PrecAnd < PrecEquals && PrecSum < PrecProduct && PrecProduct < PrecMember) {
+		t.Error("precedence levels are not ordered from lowest to highest")
+	}
+}
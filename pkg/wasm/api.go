@@ -6,6 +6,8 @@ package wasm
 
 import (
 	"bytes"
+	"context"
+	"errors"
 	"fmt"
 	"runtime/debug"
 	"syscall/js"
@@ -13,6 +15,7 @@ import (
 
 	"github.com/cwbudde/go-dws/pkg/dwscript"
 	"github.com/cwbudde/go-dws/pkg/platform/wasm"
+	"github.com/cwbudde/go-dws/pkg/token"
 )
 
 // RegisterAPI registers the DWScript API with JavaScript.
@@ -53,6 +56,7 @@ func newDWScriptInstance(this js.Value, args []js.Value) interface{} {
 		outputBuffer: &outputBuffer,
 		callbacks:    callbacks,
 		programs:     make(map[int]*dwscript.Program),
+		running:      make(map[int]context.CancelFunc),
 		funcRefs:     make([]js.Func, 0),
 	}
 
@@ -63,6 +67,7 @@ func newDWScriptInstance(this js.Value, args []js.Value) interface{} {
 	ctx.bindMethod(obj, "init", initFunc)
 	ctx.bindMethod(obj, "compile", compileFunc)
 	ctx.bindMethod(obj, "run", runFunc)
+	ctx.bindMethod(obj, "interrupt", interruptFunc)
 	ctx.bindMethod(obj, "eval", evalFunc)
 	ctx.bindMethod(obj, "on", onFunc)
 	ctx.bindMethod(obj, "setFileSystem", setFileSystemFunc)
@@ -79,6 +84,7 @@ type Context struct {
 	outputBuffer *bytes.Buffer
 	callbacks    *Callbacks
 	programs     map[int]*dwscript.Program
+	running      map[int]context.CancelFunc // Cancels the running program with that ID
 	nextID       int
 	funcRefs     []js.Func // Store func references for proper cleanup
 }
@@ -133,8 +139,13 @@ func initFunc(ctx *Context, args []js.Value) interface{} {
 	return promise.Call("resolve", js.Null())
 }
 
-// compileFunc compiles DWScript source code and returns a program ID.
+// compileFunc compiles DWScript source code and returns a program handle
+// together with the diagnostics of the compile.
 // JavaScript usage: program = dws.compile(sourceCode)
+//
+// The result has success, diagnostics (an array of {line, column, message,
+// severity, code}) and, when compilation succeeded, the program id. A failed
+// compile also sets message to the formatted errors.
 func compileFunc(ctx *Context, args []js.Value) interface{} {
 	if len(args) < 1 {
 		return CreateErrorObject("ArgumentError", "compile requires 1 argument: source code", nil)
@@ -144,76 +155,137 @@ func compileFunc(ctx *Context, args []js.Value) interface{} {
 
 	// Compile the program
 	program, err := ctx.engine.Compile(sourceCode)
-	if err != nil {
+	var compileErr *dwscript.CompileError
+	if err != nil && !errors.As(err, &compileErr) {
 		return CreateErrorObject("CompileError", err.Error(), map[string]interface{}{
 			"source": sourceCode,
 		})
 	}
 
+	result := CreateObject()
+	if compileErr != nil {
+		result.Set("success", false)
+		result.Set("message", compileErr.Error())
+		result.Set("diagnostics", compileErrorDiagnostics(compileErr))
+		return result
+	}
+
 	// Store program and assign ID
 	ctx.nextID++
 	programID := ctx.nextID
 	ctx.programs[programID] = program
 
-	// Return program object
-	result := js.Global().Get("Object").New()
 	result.Set("id", programID)
 	result.Set("success", true)
+	result.Set("diagnostics", DiagnosticsToJS(program.Diagnostics()))
 	return result
 }
 
-// runFunc executes a previously compiled program.
-// JavaScript usage: result = dws.run(program)
+// runFunc executes a previously compiled program without blocking the
+// JavaScript thread and returns a Promise for its result.
+// JavaScript usage: result = await dws.run(program, { onOutput: fn })
+//
+// onOutput, or the instance's output callback when it is not given, is called
+// with the text of every Print and PrintLn as it happens. The result has
+// success, output, executionTime, interrupted, error on failure, and value
+// when the program ends with an expression. A program runs at most once at a
+// time; interrupt stops it.
 func runFunc(ctx *Context, args []js.Value) interface{} {
 	if len(args) < 1 {
 		return CreateErrorObject("ArgumentError", "run requires 1 argument: program object", nil)
 	}
 
-	programObj := args[0]
-	if !programObj.Get("id").Truthy() {
+	programID, ok := programHandle(args[0])
+	if !ok {
 		return CreateErrorObject("ArgumentError", "invalid program object", nil)
 	}
 
-	programID := programObj.Get("id").Int()
-
 	program, exists := ctx.programs[programID]
 	if !exists {
 		return CreateErrorObject("ProgramError", fmt.Sprintf("program not found: %d", programID), nil)
 	}
+	if _, busy := ctx.running[programID]; busy {
+		return CreateErrorObject("ProgramError", fmt.Sprintf("program is already running: %d", programID), nil)
+	}
 
-	// Clear output buffer
-	ctx.outputBuffer.Reset()
-
-	// Execute program
-	startTime := time.Now()
-	_, err := ctx.engine.Run(program)
-	executionTime := time.Since(startTime).Milliseconds()
-
-	// Emit output event if there's output
-	output := ctx.outputBuffer.String()
-	if output != "" && ctx.callbacks.HasOutputCallback() {
-		ctx.callbacks.Output(output)
+	onOutput := js.Null()
+	if len(args) > 1 && args[1].Type() == js.TypeObject {
+		if fn := args[1].Get("onOutput"); fn.Type() == js.TypeFunction {
+			onOutput = fn
+		}
 	}
+	output := &streamWriter{callback: onOutput, callbacks: ctx.callbacks}
+
+	runCtx, cancel := context.WithCancel(context.Background())
+	ctx.running[programID] = cancel
+
+	var executor js.Func
+	executor = js.FuncOf(func(this js.Value, promiseArgs []js.Value) interface{} {
+		resolve := promiseArgs[0]
+		go func() {
+			defer executor.Release()
+			defer func() {
+				cancel()
+				delete(ctx.running, programID)
+			}()
+			defer func() {
+				if r := recover(); r != nil {
+					resolve.Invoke(CreateErrorObject("RuntimeError", fmt.Sprintf("panic: %v", r), nil))
+				}
+			}()
+
+			startTime := time.Now()
+			result, err := ctx.engine.RunContext(newYieldingContext(runCtx), program, output)
+			executionTime := time.Since(startTime).Milliseconds()
+
+			resultObj := CreateObject()
+			resultObj.Set("success", err == nil)
+			resultObj.Set("output", output.buf.String())
+			resultObj.Set("executionTime", executionTime)
+			resultObj.Set("interrupted", errors.Is(err, context.Canceled))
+			setExpressionValue(resultObj, result)
+
+			if err != nil {
+				errorType := "RuntimeError"
+				if errors.Is(err, context.Canceled) {
+					errorType = "InterruptError"
+				}
+				resultObj.Set("error", CreateErrorObject(errorType, err.Error(), map[string]interface{}{
+					"executionTime": executionTime,
+				}))
+
+				// Emit error event
+				if ctx.callbacks.HasErrorCallback() {
+					ctx.callbacks.Error(err)
+				}
+			}
 
-	// Build result object
-	resultObj := js.Global().Get("Object").New()
-	resultObj.Set("success", err == nil)
-	resultObj.Set("output", output)
-	resultObj.Set("executionTime", executionTime)
+			resolve.Invoke(resultObj)
+		}()
+		return nil
+	})
 
-	if err != nil {
-		errObj := CreateErrorObject("RuntimeError", err.Error(), map[string]interface{}{
-			"executionTime": executionTime,
-		})
-		resultObj.Set("error", errObj)
+	return js.Global().Get("Promise").New(executor)
+}
 
-		// Emit error event
-		if ctx.callbacks.HasErrorCallback() {
-			ctx.callbacks.Error(err)
-		}
+// interruptFunc stops a program started with run. The pending run resolves
+// with interrupted set and an InterruptError.
+// JavaScript usage: stopped = dws.interrupt(program)
+func interruptFunc(ctx *Context, args []js.Value) interface{} {
+	if len(args) < 1 {
+		return CreateErrorObject("ArgumentError", "interrupt requires 1 argument: program object", nil)
 	}
 
-	return resultObj
+	programID, ok := programHandle(args[0])
+	if !ok {
+		return CreateErrorObject("ArgumentError", "invalid program object", nil)
+	}
+
+	cancel, running := ctx.running[programID]
+	if running {
+		cancel()
+	}
+	return running
 }
 
 // evalFunc compiles and runs DWScript code in one step.
@@ -230,7 +302,7 @@ func evalFunc(ctx *Context, args []js.Value) interface{} {
 
 	// Compile and run
 	startTime := time.Now()
-	_, err := ctx.engine.Eval(sourceCode)
+	result, err := ctx.engine.Eval(sourceCode)
 	executionTime := time.Since(startTime).Milliseconds()
 
 	// Emit output event if there's output
@@ -244,6 +316,7 @@ func evalFunc(ctx *Context, args []js.Value) interface{} {
 	resultObj.Set("success", err == nil)
 	resultObj.Set("output", output)
 	resultObj.Set("executionTime", executionTime)
+	setExpressionValue(resultObj, result)
 
 	if err != nil {
 		errObj := CreateErrorObject("RuntimeError", err.Error(), map[string]interface{}{
@@ -322,7 +395,10 @@ func disposeFunc(ctx *Context, args []js.Value) interface{} {
 	}
 	ctx.funcRefs = nil
 
-	// Clear programs
+	// Stop running programs and clear programs
+	for _, cancel := range ctx.running {
+		cancel()
+	}
 	ctx.programs = nil
 
 	// Clear callbacks
@@ -331,3 +407,87 @@ func disposeFunc(ctx *Context, args []js.Value) interface{} {
 	ConsoleLog("DWScript instance disposed")
 	return js.Null()
 }
+
+// programHandle returns the ID of a program handle, which is either the
+// object compile returned or its id.
+func programHandle(handle js.Value) (int, bool) {
+	if handle.Type() == js.TypeNumber {
+		return handle.Int(), true
+	}
+	if handle.Type() != js.TypeObject || !handle.Get("id").Truthy() {
+		return 0, false
+	}
+	return handle.Get("id").Int(), true
+}
+
+// setExpressionValue sets the value property of a result object to the
+// value of the program's final expression, if it has one.
+func setExpressionValue(resultObj js.Value, result *dwscript.Result) {
+	if value, ok, err := result.ExpressionGoValue(); ok && err == nil {
+		resultObj.Set("value", WrapValue(value))
+	}
+}
+
+// compileErrorDiagnostics returns the diagnostics of a failed compile as a
+// JavaScript array.
+func compileErrorDiagnostics(compileErr *dwscript.CompileError) js.Value {
+	if compileErr.Program != nil {
+		return DiagnosticsToJS(compileErr.Program.Diagnostics())
+	}
+	diagnostics := make([]dwscript.Diagnostic, len(compileErr.Errors))
+	for i, e := range compileErr.Errors {
+		diagnostics[i] = dwscript.Diagnostic{
+			Message:  e.Message,
+			Code:     e.Code,
+			Start:    token.Position{Line: e.Line, Column: e.Column},
+			Severity: e.Severity,
+		}
+	}
+	return DiagnosticsToJS(diagnostics)
+}
+
+// streamWriter passes every write of a running program to an output
+// callback as it happens and keeps a copy for the run's result.
+type streamWriter struct {
+	callback  js.Value
+	callbacks *Callbacks
+	buf       bytes.Buffer
+}
+
+func (w *streamWriter) Write(p []byte) (int, error) {
+	w.buf.Write(p)
+	if w.callback.Type() == js.TypeFunction {
+		w.callback.Invoke(string(p))
+	} else if w.callbacks.HasOutputCallback() {
+		w.callbacks.Output(string(p))
+	}
+	return len(p), nil
+}
+
+// yieldInterval is how long a running program may keep the JavaScript
+// thread before it yields to the event loop.
+const yieldInterval = 20 * time.Millisecond
+
+// yieldingContext is the context of a run started from JavaScript. Go has no
+// preemption under WebAssembly, so a busy script would keep the event loop
+// from ever calling interrupt. The script polls Done before every statement;
+// every so often Done sleeps briefly, which hands control back to the event
+// loop.
+type yieldingContext struct {
+	context.Context
+	polls     int
+	lastYield time.Time
+}
+
+func newYieldingContext(parent context.Context) *yieldingContext {
+	return &yieldingContext{Context: parent, lastYield: time.Now()}
+}
+
+func (c *yieldingContext) Done() <-chan struct{} {
+	c.polls++
+	if c.polls%1024 == 0 && time.Since(c.lastYield) >= yieldInterval {
+		time.Sleep(time.Millisecond)
+		c.lastYield = time.Now()
+	}
+	return c.Context.Done()
+}
@@ -5,6 +5,8 @@ package wasm
 import (
 	"fmt"
 	"syscall/js"
+
+	"github.com/cwbudde/go-dws/pkg/dwscript"
 )
 
 // WrapError wraps a Go error as a JavaScript Error object.
@@ -17,7 +19,8 @@ func WrapError(err error) js.Value {
 	return errorObj
 }
 
-// WrapValue wraps a Go value as a JavaScript value.
+// WrapValue wraps a Go value as a JavaScript value. Slices become arrays and
+// maps with string keys become objects, converted element by element.
 func WrapValue(v interface{}) js.Value {
 	switch val := v.(type) {
 	case string:
@@ -32,6 +35,18 @@ func WrapValue(v interface{}) js.Value {
 		return js.ValueOf(val)
 	case nil:
 		return js.Null()
+	case []interface{}:
+		arr := CreateArray(len(val))
+		for i, elem := range val {
+			arr.SetIndex(i, WrapValue(elem))
+		}
+		return arr
+	case map[string]interface{}:
+		obj := CreateObject()
+		for key, elem := range val {
+			obj.Set(key, WrapValue(elem))
+		}
+		return obj
 	default:
 		return js.ValueOf(fmt.Sprint(val))
 	}
@@ -73,6 +88,22 @@ func GetProperty(obj js.Value, key string) interface{} {
 	return UnwrapValue(obj.Get(key))
 }
 
+// DiagnosticsToJS converts compile diagnostics to a JavaScript array of
+// {line, column, message, severity, code} objects.
+func DiagnosticsToJS(diagnostics []dwscript.Diagnostic) js.Value {
+	arr := CreateArray(len(diagnostics))
+	for i, d := range diagnostics {
+		obj := CreateObject()
+		obj.Set("line", d.Start.Line)
+		obj.Set("column", d.Start.Column)
+		obj.Set("message", d.Message)
+		obj.Set("severity", d.Severity.String())
+		obj.Set("code", d.Code)
+		arr.SetIndex(i, obj)
+	}
+	return arr
+}
+
 // ConsoleLog logs a message to the JavaScript console.
 func ConsoleLog(msg string) {
 	js.Global().Get("console").Call("log", msg)